@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/armadaproject/armada/internal/scheduler"
 )
@@ -20,5 +21,5 @@ func runScheduler(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
-	return scheduler.Run(config)
+	return scheduler.Run(config, readConfig, viper.GetStringSlice(CustomConfigLocation))
 }
@@ -46,3 +46,12 @@ func loadConfig() (schedulerconfig.Configuration, error) {
 	}
 	return config, err
 }
+
+// readConfig re-reads and validates the config, returning an error rather than exiting if it's invalid.
+func readConfig() (schedulerconfig.Configuration, error) {
+	var config schedulerconfig.Configuration
+	if _, err := common.ReadConfig(&config, "./config/scheduler", viper.GetStringSlice(CustomConfigLocation)); err != nil {
+		return config, err
+	}
+	return config, config.Validate()
+}
@@ -18,6 +18,24 @@ const (
 	// Pods for which this annotation has value "true" are not retried.
 	// Instead, the job the pod is part of fails immediately.
 	FailFastAnnotation = "armadaproject.io/failFast"
+	// The scheduler skips jobs with the same scheduling requirements as a job already found unschedulable.
+	// Jobs for which this annotation has value "true" are always evaluated individually instead,
+	// e.g., since they differ from otherwise identical jobs in ways the scheduler doesn't account for.
+	DisableSchedulingKeySkippingAnnotation = "armadaproject.io/disableSchedulingKeySkipping"
+	// The scheduler copies the value of this annotation onto every event it publishes for the job,
+	// such that tracing systems can correlate these events with the request the job was submitted by.
+	CorrelationIdAnnotation = "armadaproject.io/correlationId"
+	// The queue ttl of a job is normally compared to the total time it has spent queued, excluding time spent running
+	// before being requeued. For jobs for which this annotation has value "true", it's compared to the time since
+	// the job was submitted instead.
+	QueueTtlSinceSubmissionAnnotation = "armadaproject.io/queueTtlSinceSubmission"
+	// If job set executor preference is enabled, jobs are preferably scheduled onto the executor given by this annotation,
+	// which also becomes the preferred executor of their job set once they're scheduled, overriding the executor the
+	// first job of the job set was scheduled onto. Jobs may still be scheduled onto other executors.
+	PreferredExecutorAnnotation = "armadaproject.io/preferredExecutor"
+	// If gang reservations are enabled, jobs of a backfill priority class for which this annotation is at most the
+	// configured maximum backfill runtime may run in capacity reserved for gangs. Expressed as a duration, e.g., "10m".
+	MaxExpectedRuntimeAnnotation = "armadaproject.io/maxExpectedRuntime"
 )
 
 const (
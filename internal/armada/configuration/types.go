@@ -2,6 +2,7 @@ package configuration
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/apache/pulsar-client-go/pulsar"
@@ -165,6 +166,11 @@ type SchedulingConfig struct {
 	DefaultJobTolerationsByPriorityClass map[string][]v1.Toleration
 	// Set of tolerations added to all submitted pods with a given resource request.
 	DefaultJobTolerationsByResourceRequest map[string][]v1.Toleration
+	// Set of tolerations the scheduler adds to the pods of all jobs in a given queue, e.g., to allow them onto nodes
+	// tainted as dedicated to that queue. Unlike the defaults above, these aren't added on submission and so aren't
+	// stored with jobs; they're taken into account when checking where jobs may be scheduled and added to pods when
+	// they're leased, except for tolerations jobs already have.
+	DefaultJobTolerationsByQueue map[string][]v1.Toleration
 	// Maximum number of times a job is retried before considered failed.
 	MaxRetries uint
 	// Controls how fairness is calculated. Can be either AssetFairness or DominantResourceFairness.
@@ -252,12 +258,135 @@ type SchedulingConfig struct {
 	ExecutorUpdateFrequency time.Duration
 	// Enable new preemption strategy.
 	EnableNewPreemptionStrategy bool
+	// If true, all members of a gang are scheduled as if they had the priority of the highest-priority member,
+	// i.e., the gang is considered for scheduling as soon as that member would be and all members are bound to nodes
+	// at the highest priority class priority of any member. The priorities stored with each job are not changed.
+	EnableGangPriorityInheritance bool
+	// If true, preemption treats gangs as a unit: evicted gangs are re-scheduled before evicted non-gang jobs of equal
+	// priority, such that non-gang jobs are preferred as preemption victims, and, with the new preemption strategy,
+	// preventing one member of a gang from being re-scheduled releases the resources of all members of that gang.
+	// Gangs are always preempted either entirely or not at all. Applies only to the new scheduler.
+	EnableGangAwarePreemption bool
+	// If true, queued jobs are skipped without attempting to schedule them if no node tolerated by the job
+	// has enough resources allocatable at the priority of the job after evicting jobs to balance resource usage.
+	EnableCapacityPruning bool
+	// Resources reserved for specific queues, indexed by pool, queue, and resource name, e.g., "cpu".
+	// Non-preemptible jobs of other queues aren't scheduled if doing so would leave too few resources free
+	// to cover the unused part of the reservation, i.e., reserved resources are kept free or occupied only
+	// by jobs of the reserving queue or by preemptible jobs of other queues.
+	// Queues using less than their reservation are considered for scheduling ahead of all other queues,
+	// such that preemptible jobs of other queues occupying reserved resources are preempted once the reserving
+	// queue has jobs to schedule.
+	ReservedResourcesByPool map[string]map[string]map[string]resource.Quantity
+	// Factors by which the resources of nodes are overcommitted, indexed by pool and resource name, e.g., "cpu".
+	// For example, a factor of 1.5 for cpu causes the scheduler to consider a node with 8 cpu to have 12 cpu.
+	// Overcommitted resources are rounded down to the precision with which the kubelet accounts for them.
+	// Resources without a factor aren't overcommitted. Applies only to the new scheduler.
+	OvercommitFactorsByPool map[string]map[string]float64
+	// Order in which nodes that score equally for a job are considered, indexed by pool.
+	// Pools without an entry use PackedNodeOrdering. Applies only to the new scheduler.
+	NodeOrderingByPool map[string]NodeOrdering
+	// Ordered pipeline of scorers by the weighted sum of whose scores the nodes a job may be scheduled on are ranked,
+	// in all pools. Nodes that score equally are chosen among according to NodeOrderingByPool.
+	// If empty, nodes are chosen according to NodeOrderingByPool alone. Applies only to the new scheduler.
+	NodeScorers []NodeScorerConfig `validate:"dive"`
+	// Controls burst credits, which make queues allocated more than their fair share of a pool repay the excess
+	// once other queues compete for the pool. Applies only to the new scheduler.
+	BurstCredits BurstCreditsConfig
+	// Controls reserving capacity for gangs that don't fit yet and backfilling that capacity with short jobs.
+	// Applies only to the new scheduler.
+	GangReservations GangReservationsConfig
+	// Controls capping the work lost to preemption per pool and across all pools. Applies only to the new scheduler.
+	PreemptionBudget PreemptionBudgetConfig
+	// Controls adjusting the share of each pool a queue receives by its usage of other pools.
+	// Applies only to the new scheduler.
+	CrossPoolFairness CrossPoolFairnessConfig
+}
+
+// BurstCreditsConfig controls burst credits. Queues may use idle capacity beyond their fair share as usual,
+// but accrue a debt while doing so. Until the debt is repaid, the weight of the queue is divided by one plus its debt,
+// such that the queue yields resources to other queues before queues not in debt do.
+//
+// Fair shares are here computed across all queues, whether active or not,
+// such that a queue using capacity idle only since other queues have no jobs accrues debt.
+// Debts are stored in the scheduler database, such that they survive restarts and failovers.
+type BurstCreditsConfig struct {
+	Enabled bool
+	// Debt accrued per second per unit of fair share, as measured by the fairness model, a queue is allocated beyond its fair share.
+	// For example, with an AccrualRate of 0.01, a queue allocated 60% of a pool with a fair share of 40% accrues 0.002 debt per second.
+	AccrualRate float64 `validate:"gte=0"`
+	// Debt repaid per second while a queue is allocated at most its fair share.
+	DecayRate float64 `validate:"gte=0"`
+}
+
+// GangReservationsConfig controls gang reservations. If a gang fails to schedule for lack of capacity,
+// the scheduler may reserve nodes for it, on which no other jobs are scheduled, such that capacity accumulates
+// across scheduling rounds as the jobs running on these nodes finish. Once the gang fits on the reserved nodes,
+// it's placed there and the reservation is released.
+//
+// While the gang waits, the reserved capacity may be backfilled with short preemptible jobs, which are preempted
+// once the gang is placed if they'd otherwise leave too little capacity for it.
+type GangReservationsConfig struct {
+	Enabled bool
+	// Only gangs of at least this many jobs are reserved capacity for.
+	MinGangCardinality int
+	// Maximum number of gangs reserved capacity for at once in each pool,
+	// or in each executor if scheduling isn't unified by pool.
+	MaxReservationsPerPool int
+	// Reservations for gangs still not placed after this long are released, such that other jobs may use the nodes.
+	MaxReservationDuration time.Duration
+	// Jobs of these priority classes may backfill reserved capacity. All of these must be preemptible.
+	BackfillPriorityClasses []string
+	// Jobs may backfill reserved capacity only if their MaxExpectedRuntimeAnnotation is at most this.
+	MaxBackfillRuntime time.Duration
+}
+
+// PreemptionBudgetConfig controls the preemption budget, which caps the work lost to preemption.
+// The work of a preempted run is its request for ResourceName multiplied by the time since it was leased.
+// Once the work preempted within the trailing Window reaches the budget of a pool, or the global budget,
+// jobs are no longer evicted to balance resource usage across queues in that pool until enough preemptions
+// have left the window. Since the budget is checked at the start of each round, a single round may exceed it.
+//
+// Urgency-based preemptions, i.e., of jobs on nodes oversubscribed by jobs of higher priority, are never deferred.
+type PreemptionBudgetConfig struct {
+	Enabled bool
+	// Length of the trailing window within which preempted work counts towards the budget, e.g., 1h.
+	Window time.Duration
+	// Resource, e.g., "cpu", by which preempted work is measured.
+	ResourceName string
+	// Maximum work, in resource-seconds, preempted across all pools within the window. Zero means no global budget.
+	// For example, with ResourceName cpu and a Window of 1h, a MaxGlobalResourceSeconds of 36000 allows 10 cpu-hours per hour.
+	MaxGlobalResourceSeconds float64 `validate:"gte=0"`
+	// Maximum work, in resource-seconds, preempted within the window, indexed by pool.
+	// Pools without an entry are limited only by the global budget.
+	MaxResourceSecondsByPool map[string]float64 `validate:"dive,gte=0"`
+	// If true, urgency-based preemptions don't count towards the budget.
+	ExemptUrgencyPreemptions bool
+}
+
+// CrossPoolFairnessConfig controls cross-pool fairness. The usage share of a queue in a pool is the cost,
+// as measured by the fairness model, of its allocation in that pool divided by the cost of the pool's total capacity.
+// When scheduling a pool, the weight of each queue is divided by one plus Strength times its usage share of all other pools,
+// averaged over all pools weighted by their importance, such that a queue using much of other pools yields
+// resources to queues that don't.
+//
+// For example, with two pools of equal importance and a Strength of 1, a queue using all of pool A
+// has its weight in pool B halved.
+type CrossPoolFairnessConfig struct {
+	Enabled bool
+	// Importance of each pool's usage relative to other pools, indexed by pool. Pools without an entry have importance 1.
+	PoolImportance map[string]float64 `validate:"dive,gte=0"`
+	// How strongly usage of other pools reduces the weight of a queue. Zero means usage of other pools is only reported.
+	Strength float64 `validate:"gte=0"`
 }
 
 const (
 	DuplicateWellKnownNodeTypeErrorMessage     = "duplicate well-known node type name"
 	AwayNodeTypesWithoutPreemptionErrorMessage = "priority class has away node types but is not preemptible"
 	UnknownWellKnownNodeTypeErrorMessage       = "priority class refers to unknown well-known node type"
+	NonPositiveOvercommitFactorErrorMessage    = "overcommit factor must be positive"
+	UnknownNodeOrderingErrorMessage            = "unknown node ordering; must be one of packed, balanced, or random"
+	InvalidBackfillPriorityClassErrorMessage   = "backfill priority class must exist and be preemptible"
 )
 
 func SchedulingConfigValidation(sl validator.StructLevel) {
@@ -285,8 +414,57 @@ func SchedulingConfigValidation(sl validator.StructLevel) {
 			}
 		}
 	}
+
+	for pool, overcommitFactorByResource := range c.OvercommitFactorsByPool {
+		for resourceName, factor := range overcommitFactorByResource {
+			if !(factor > 0) || math.IsInf(factor, 1) {
+				fieldName := fmt.Sprintf("OvercommitFactorsByPool[%s][%s]", pool, resourceName)
+				sl.ReportError(factor, fieldName, "", NonPositiveOvercommitFactorErrorMessage, "")
+			}
+		}
+	}
+
+	for pool, nodeOrdering := range c.NodeOrderingByPool {
+		switch nodeOrdering {
+		case PackedNodeOrdering, BalancedNodeOrdering, RandomNodeOrdering:
+		default:
+			fieldName := fmt.Sprintf("NodeOrderingByPool[%s]", pool)
+			sl.ReportError(nodeOrdering, fieldName, "", UnknownNodeOrderingErrorMessage, "")
+		}
+	}
+
+	if c.GangReservations.Enabled {
+		for i, priorityClassName := range c.GangReservations.BackfillPriorityClasses {
+			if priorityClass, ok := c.Preemption.PriorityClasses[priorityClassName]; !ok || !priorityClass.Preemptible {
+				fieldName := fmt.Sprintf("GangReservations.BackfillPriorityClasses[%d]", i)
+				sl.ReportError(priorityClassName, fieldName, "", InvalidBackfillPriorityClassErrorMessage, "")
+			}
+		}
+	}
+}
+
+// NodeScorerConfig selects a node scorer of a node scoring pipeline.
+type NodeScorerConfig struct {
+	// Name the scorer is registered under, e.g., "leastAllocated" or "mostAllocated".
+	Name string `validate:"required"`
+	// Factor by which the scores of the scorer are multiplied before being summed with those of other scorers.
+	Weight float64 `validate:"gte=0"`
 }
 
+// NodeOrdering controls on which of several nodes that score equally for a job the job is placed.
+type NodeOrdering string
+
+const (
+	// PackedNodeOrdering places jobs on the first suitable node in the order nodes are indexed,
+	// i.e., on the node with the least resources allocatable, such that jobs are bin-packed onto as few nodes as possible.
+	PackedNodeOrdering NodeOrdering = "packed"
+	// BalancedNodeOrdering places jobs on the least-allocated suitable node,
+	// such that jobs are spread evenly across equivalent nodes.
+	BalancedNodeOrdering NodeOrdering = "balanced"
+	// RandomNodeOrdering places jobs on a suitable node chosen uniformly at random.
+	RandomNodeOrdering NodeOrdering = "random"
+)
+
 // FairnessModel controls how fairness is computed.
 // More specifically, each queue has a cost associated with it and the next job to schedule
 // is taken from the queue with smallest cost. FairnessModel determines how that cost is computed.
@@ -334,6 +512,10 @@ type PreemptionConfig struct {
 	NodeOversubscriptionEvictionProbability float64
 	// Only queues allocated more than this fraction of their fair share are considered for preemption.
 	ProtectedFractionOfFairShare float64
+	// The weight, and hence fair share, of queues with preemption policy "never" is multiplied by this factor,
+	// to compensate for their jobs never being evicted to balance resource usage.
+	// Must be in [0, 1]; if zero, the fair share of such queues isn't reduced.
+	NeverPreemptFairShareFactor float64 `validate:"gte=0,lte=1"`
 	// If true, the Armada scheduler will add to scheduled pods a node selector
 	// NodeIdLabel: <value of label on node selected by scheduler>.
 	// If true, NodeIdLabel must be non-empty.
@@ -382,6 +564,9 @@ type MetricsConfig struct {
 type SchedulerMetricsConfig struct {
 	ScheduleCycleTimeHistogramSettings  HistogramConfig
 	ReconcileCycleTimeHistogramSettings HistogramConfig
+	// The per-queue breakdown of scheduling cycles of queues not considered for this long is deleted,
+	// such that the number of series doesn't grow with every queue ever scheduled. If zero, it's never deleted.
+	QueueCycleMetricsExpiry time.Duration
 }
 
 type HistogramConfig struct {
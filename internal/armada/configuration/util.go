@@ -9,3 +9,16 @@ func (c *SchedulingConfig) GetResourceScarcity(pool string) map[string]float64 {
 	}
 	return c.ResourceScarcity
 }
+
+// GetOvercommitFactors returns the factor by which each resource of the nodes in pool is overcommitted.
+func (c *SchedulingConfig) GetOvercommitFactors(pool string) map[string]float64 {
+	return c.OvercommitFactorsByPool[pool]
+}
+
+// GetNodeOrdering returns the order in which nodes in pool that score equally for a job are considered.
+func (c *SchedulingConfig) GetNodeOrdering(pool string) NodeOrdering {
+	if nodeOrdering, ok := c.NodeOrderingByPool[pool]; ok {
+		return nodeOrdering
+	}
+	return PackedNodeOrdering
+}
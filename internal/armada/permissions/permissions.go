@@ -14,4 +14,7 @@ const (
 	DeleteQueue                               = "delete_queue"
 	ExecuteJobs                               = "execute_jobs"
 	CordonNodes                               = "cordon_nodes"
+	ForceFailJobs                             = "force_fail_jobs"
+	InspectScheduler                          = "inspect_scheduler"
+	RebuildJobDb                              = "rebuild_jobdb"
 )
@@ -55,6 +55,9 @@ func FromEventSequence(es *armadaevents.EventSequence) ([]*api.EventMessage, err
 			*armadaevents.EventSequence_Event_CancelJobSet,
 			*armadaevents.EventSequence_Event_JobRunSucceeded,
 			*armadaevents.EventSequence_Event_JobRequeued,
+			*armadaevents.EventSequence_Event_JobRunCancelled,
+			*armadaevents.EventSequence_Event_JobValidated,
+			*armadaevents.EventSequence_Event_JobRetriesExhausted,
 			*armadaevents.EventSequence_Event_PartitionMarker:
 			// These events have no api analog right now, so we ignore
 			log.Debugf("ignoring event type %T", esEvent)
@@ -317,15 +320,16 @@ func FromInternalJobRunErrors(queueName string, jobSetName string, time time.Tim
 			event := &api.EventMessage{
 				Events: &api.EventMessage_LeaseReturned{
 					LeaseReturned: &api.JobLeaseReturnedEvent{
-						JobId:        jobId,
-						JobSetId:     jobSetName,
-						Queue:        queueName,
-						Created:      time,
-						ClusterId:    objectMeta.GetExecutorId(),
-						Reason:       reason.PodLeaseReturned.GetMessage(),
-						KubernetesId: objectMeta.GetKubernetesId(),
-						PodNumber:    reason.PodLeaseReturned.GetPodNumber(),
-						RunAttempted: reason.PodLeaseReturned.GetRunAttempted(),
+						JobId:                  jobId,
+						JobSetId:               jobSetName,
+						Queue:                  queueName,
+						Created:                time,
+						ClusterId:              objectMeta.GetExecutorId(),
+						Reason:                 reason.PodLeaseReturned.GetMessage(),
+						KubernetesId:           objectMeta.GetKubernetesId(),
+						PodNumber:              reason.PodLeaseReturned.GetPodNumber(),
+						RunAttempted:           reason.PodLeaseReturned.GetRunAttempted(),
+						LeaseNeverAcknowledged: reason.PodLeaseReturned.GetLeaseNeverAcknowledged(),
 					},
 				},
 			}
@@ -413,6 +417,58 @@ func FromInternalJobErrors(queueName string, jobSetName string, time time.Time,
 				},
 			}
 			events = append(events, event)
+		case *armadaevents.Error_QueueDoesNotExist:
+			event := &api.EventMessage{
+				Events: &api.EventMessage_Failed{
+					Failed: &api.JobFailedEvent{
+						JobId:    jobId,
+						JobSetId: jobSetName,
+						Queue:    queueName,
+						Created:  time,
+						Reason:   reason.QueueDoesNotExist.Message,
+					},
+				},
+			}
+			events = append(events, event)
+		case *armadaevents.Error_QueueBacklogLimitReached:
+			event := &api.EventMessage{
+				Events: &api.EventMessage_Failed{
+					Failed: &api.JobFailedEvent{
+						JobId:    jobId,
+						JobSetId: jobSetName,
+						Queue:    queueName,
+						Created:  time,
+						Reason:   reason.QueueBacklogLimitReached.Message,
+					},
+				},
+			}
+			events = append(events, event)
+		case *armadaevents.Error_JobExceedsLargestNode:
+			event := &api.EventMessage{
+				Events: &api.EventMessage_Failed{
+					Failed: &api.JobFailedEvent{
+						JobId:    jobId,
+						JobSetId: jobSetName,
+						Queue:    queueName,
+						Created:  time,
+						Reason:   reason.JobExceedsLargestNode.Message,
+					},
+				},
+			}
+			events = append(events, event)
+		case *armadaevents.Error_JobForceFailed:
+			event := &api.EventMessage{
+				Events: &api.EventMessage_Failed{
+					Failed: &api.JobFailedEvent{
+						JobId:    jobId,
+						JobSetId: jobSetName,
+						Queue:    queueName,
+						Created:  time,
+						Reason:   reason.JobForceFailed.Message,
+					},
+				},
+			}
+			events = append(events, event)
 		default:
 			log.Warnf("unknown error %T for job %s", reason, jobId)
 			event := &api.EventMessage{
@@ -554,6 +554,9 @@ func (q *AggregatedQueueServer) getJobs(ctx *armadacontext.Context, req *api.Str
 	if q.schedulingConfig.EnableNewPreemptionStrategy {
 		sch.EnableNewPreemptionStrategy()
 	}
+	if q.schedulingConfig.EnableCapacityPruning {
+		sch.EnableCapacityPruning()
+	}
 	log.Infof(
 		"starting scheduling with total resources %s",
 		schedulerobjects.ResourceList{Resources: totalCapacity}.CompactString(),
@@ -562,7 +565,7 @@ func (q *AggregatedQueueServer) getJobs(ctx *armadacontext.Context, req *api.Str
 	if err != nil {
 		return nil, err
 	}
-	nodeIdByJobId = result.NodeIdByJobId
+	nodeIdByJobId = result.NodeIdByJobId()
 
 	// Store the scheduling context for querying.
 	if q.SchedulingContextRepository != nil {
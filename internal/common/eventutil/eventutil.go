@@ -603,9 +603,10 @@ func EventSequenceFromApiEvent(msg *api.EventMessage) (sequence *armadaevents.Ev
 										ExecutorId:   m.LeaseReturned.ClusterId,
 										KubernetesId: m.LeaseReturned.KubernetesId,
 									},
-									PodNumber:    m.LeaseReturned.PodNumber,
-									Message:      m.LeaseReturned.Reason,
-									RunAttempted: m.LeaseReturned.RunAttempted,
+									PodNumber:              m.LeaseReturned.PodNumber,
+									Message:                m.LeaseReturned.Reason,
+									RunAttempted:           m.LeaseReturned.RunAttempted,
+									LeaseNeverAcknowledged: m.LeaseReturned.LeaseNeverAcknowledged,
 								},
 							},
 						},
@@ -242,6 +242,16 @@ var JobRunSucceeded = &armadaevents.EventSequence_Event{
 	},
 }
 
+var JobRunCancelled = &armadaevents.EventSequence_Event{
+	Created: &testfixtures.BaseTime,
+	Event: &armadaevents.EventSequence_Event_JobRunCancelled{
+		JobRunCancelled: &armadaevents.JobRunCancelled{
+			RunId: RunIdProto,
+			JobId: JobIdProto,
+		},
+	},
+}
+
 var LeaseReturned = &armadaevents.EventSequence_Event{
 	Created: &testfixtures.BaseTime,
 	Event: &armadaevents.EventSequence_Event_JobRunErrors{
@@ -504,6 +514,17 @@ var JobSucceeded = &armadaevents.EventSequence_Event{
 	},
 }
 
+var JobValidated = &armadaevents.EventSequence_Event{
+	Created: &testfixtures.BaseTime,
+	Event: &armadaevents.EventSequence_Event_JobValidated{
+		JobValidated: &armadaevents.JobValidated{
+			JobId:             JobIdProto,
+			EffectivePriority: Priority,
+			PriorityClassName: PriorityClassName,
+		},
+	},
+}
+
 func JobSetCancelRequestedWithStateFilter(states ...armadaevents.JobState) *armadaevents.EventSequence_Event {
 	return &armadaevents.EventSequence_Event{
 		Created: &testfixtures.BaseTime,
@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+// WarningCoalescer rate-limits warnings logged repeatedly, e.g., for every job on a stale executor.
+// Warnings with the same format and key are logged at most once per window; further such warnings within the window
+// are counted instead and logged once the window has ended, with a "repeated N times" suffix.
+// Warnings are logged using the logger passed in by the caller, such that any fields attached to it are kept.
+//
+// A nil *WarningCoalescer logs all warnings without coalescing them.
+type WarningCoalescer struct {
+	window time.Duration
+	// Warnings with new keys are dropped once this many keys are being tracked.
+	maxKeys int
+	// Warnings logged in the current window of each key.
+	warningsByKey map[coalescerKey]*coalescedWarning
+	// Number of warnings dropped since the last flush since maxKeys was reached.
+	numDropped int
+	clock      clock.Clock
+	mu         sync.Mutex
+}
+
+type coalescerKey struct {
+	format string
+	key    string
+}
+
+type coalescedWarning struct {
+	windowStart time.Time
+	// Number of warnings not logged since the window started.
+	numSuppressed int
+	// Most recent of those warnings.
+	lastSuppressed string
+}
+
+func NewWarningCoalescer(window time.Duration, maxKeys int) *WarningCoalescer {
+	return &WarningCoalescer{
+		window:        window,
+		maxKeys:       maxKeys,
+		warningsByKey: make(map[coalescerKey]*coalescedWarning),
+		clock:         clock.RealClock{},
+	}
+}
+
+// Warnf logs a warning with logger, unless a warning with the same format and key has already been logged within
+// the current window, in which case it's counted instead. The key should identify what the warning is about,
+// e.g., an executor id, and typically omits the arguments that vary between otherwise identical warnings, e.g., job ids.
+func (c *WarningCoalescer) Warnf(logger logrus.FieldLogger, key string, format string, args ...interface{}) {
+	if c == nil {
+		logger.Warnf(format, args...)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	k := coalescerKey{format: format, key: key}
+	warning, ok := c.warningsByKey[k]
+	if ok && now.Sub(warning.windowStart) < c.window {
+		warning.numSuppressed++
+		warning.lastSuppressed = fmt.Sprintf(format, args...)
+		return
+	}
+	if ok {
+		warning.logSuppressed(logger)
+	} else if len(c.warningsByKey) >= c.maxKeys {
+		c.numDropped++
+		return
+	}
+	logger.Warnf(format, args...)
+	c.warningsByKey[k] = &coalescedWarning{windowStart: now}
+}
+
+// Flush logs, using logger, the warnings suppressed in windows that have ended and stops tracking their keys.
+// Should be called periodically, e.g., once per cycle.
+func (c *WarningCoalescer) Flush(logger logrus.FieldLogger) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	for k, warning := range c.warningsByKey {
+		if now.Sub(warning.windowStart) < c.window {
+			continue
+		}
+		warning.logSuppressed(logger)
+		delete(c.warningsByKey, k)
+	}
+	if c.numDropped > 0 {
+		logger.Warnf("dropped %d warnings since more than %d distinct warnings were logged within %s", c.numDropped, c.maxKeys, c.window)
+		c.numDropped = 0
+	}
+}
+
+func (warning *coalescedWarning) logSuppressed(logger logrus.FieldLogger) {
+	if warning.numSuppressed == 0 {
+		return
+	}
+	logger.Warnf("%s (repeated %d times)", warning.lastSuppressed, warning.numSuppressed)
+}
@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestWarningCoalescer(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	fakeClock := clock.NewFakeClock(time.Now())
+	coalescer := NewWarningCoalescer(time.Minute, 10)
+	coalescer.clock = fakeClock
+	messages := func() []string {
+		var rv []string
+		for _, entry := range hook.AllEntries() {
+			rv = append(rv, entry.Message)
+		}
+		hook.Reset()
+		return rv
+	}
+
+	// Identical warnings within the window are logged once.
+	for i := 0; i < 5; i++ {
+		coalescer.Warnf(logger, "executor1", "job %d is on stale executor %s", i, "executor1")
+	}
+	// Warnings with distinct keys or formats aren't merged.
+	coalescer.Warnf(logger, "executor2", "job %d is on stale executor %s", 0, "executor2")
+	coalescer.Warnf(logger, "executor1", "executor %s is stale", "executor1")
+	assert.Equal(
+		t,
+		[]string{"job 0 is on stale executor executor1", "job 0 is on stale executor executor2", "executor executor1 is stale"},
+		messages(),
+	)
+
+	// Suppressed warnings are counted and only logged once the window has ended.
+	coalescer.Flush(logger)
+	assert.Empty(t, messages())
+	fakeClock.Step(time.Minute)
+	coalescer.Flush(logger)
+	assert.Equal(t, []string{"job 4 is on stale executor executor1 (repeated 4 times)"}, messages())
+
+	// The window starts again once flushed.
+	coalescer.Warnf(logger, "executor1", "job %d is on stale executor %s", 5, "executor1")
+	coalescer.Warnf(logger, "executor1", "job %d is on stale executor %s", 6, "executor1")
+	fakeClock.Step(time.Minute)
+	coalescer.Warnf(logger, "executor1", "job %d is on stale executor %s", 7, "executor1")
+	assert.Equal(
+		t,
+		[]string{
+			"job 5 is on stale executor executor1",
+			"job 6 is on stale executor executor1 (repeated 1 times)",
+			"job 7 is on stale executor executor1",
+		},
+		messages(),
+	)
+}
+
+func TestWarningCoalescer_MaxKeys(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	coalescer := NewWarningCoalescer(time.Minute, 2)
+	coalescer.clock = clock.NewFakeClock(time.Now())
+	for _, key := range []string{"a", "b", "c", "d", "a"} {
+		coalescer.Warnf(logger, key, "warning %s", key)
+	}
+	coalescer.Flush(logger)
+	var messages []string
+	for _, entry := range hook.AllEntries() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"warning a", "warning b", "dropped 2 warnings since more than 2 distinct warnings were logged within 1m0s"}, messages)
+}
+
+func TestWarningCoalescer_Nil(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	var coalescer *WarningCoalescer
+	coalescer.Warnf(logger.WithField("cycle", 1), "a", "warning")
+	coalescer.Warnf(logger.WithField("cycle", 1), "a", "warning")
+	coalescer.Flush(logger)
+	assert.Len(t, hook.AllEntries(), 2)
+	assert.Equal(t, logrus.Fields{"cycle": 1}, hook.LastEntry().Data)
+}
@@ -0,0 +1,80 @@
+package schedulers
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// LeaderEpochPropertyName is the name of the pulsar message property holding the leadership epoch
+// of the scheduler that published the message. Brokers may filter on it, e.g., using an entry filter.
+const LeaderEpochPropertyName = "armada_leader_epoch"
+
+// LeaderEpochFromMsg returns the leadership epoch the message was stamped with, or zero if it wasn't.
+func LeaderEpochFromMsg(msg pulsar.Message) uint64 {
+	s, ok := msg.Properties()[LeaderEpochPropertyName]
+	if !ok {
+		return 0
+	}
+	epoch, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		log.Warnf("Invalid leader epoch [%s] associated with pulsar message [%s]. Ignoring epoch", s, msg.ID())
+		return 0
+	}
+	return epoch
+}
+
+// MsgPropertyFromLeaderEpoch returns the pulsar message property associated with the leadership epoch.
+func MsgPropertyFromLeaderEpoch(epoch uint64) string {
+	return strconv.FormatUint(epoch, 10)
+}
+
+// LeaderEpochFilter discards messages published by a scheduler leader older than the newest one seen,
+// such that messages an old leader had in flight when leadership changed aren't interleaved with those of the new leader.
+// Epochs are tracked per message key, i.e., per job set. Messages without an epoch are never discarded.
+//
+// The newest epoch seen is kept in memory for at most maxKeys keys, such that stale messages are only discarded if a
+// message of the newer epoch for the same job set was seen since the filter was created and the job set is among
+// the maxKeys most recently seen.
+type LeaderEpochFilter struct {
+	// Maps each key to the newest epoch seen for it.
+	newestEpochByKey *lru.Cache
+	mu               sync.Mutex
+}
+
+func NewLeaderEpochFilter(maxKeys int) (*LeaderEpochFilter, error) {
+	newestEpochByKey, err := lru.New(maxKeys)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &LeaderEpochFilter{
+		newestEpochByKey: newestEpochByKey,
+	}, nil
+}
+
+// Filter returns false if msg was published by a leader older than the newest leader seen for its key, and true otherwise.
+func (f *LeaderEpochFilter) Filter(msg pulsar.Message) bool {
+	epoch := LeaderEpochFromMsg(msg)
+	if epoch == 0 {
+		return true
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var newestEpoch uint64
+	if value, ok := f.newestEpochByKey.Get(msg.Key()); ok {
+		newestEpoch = value.(uint64)
+	}
+	if epoch < newestEpoch {
+		log.Warnf(
+			"Discarding pulsar message [%s] for job set [%s] published with leader epoch %d, since a message with leader epoch %d was seen",
+			msg.ID(), msg.Key(), epoch, newestEpoch,
+		)
+		return false
+	}
+	f.newestEpochByKey.Add(msg.Key(), epoch)
+	return true
+}
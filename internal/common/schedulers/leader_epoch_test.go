@@ -0,0 +1,120 @@
+package schedulers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/mocks"
+)
+
+func TestLeaderEpochFromMsg(t *testing.T) {
+	tests := map[string]struct {
+		properties    map[string]string
+		expectedEpoch uint64
+	}{
+		"stamped": {
+			properties:    map[string]string{LeaderEpochPropertyName: MsgPropertyFromLeaderEpoch(3)},
+			expectedEpoch: 3,
+		},
+		"not stamped": {
+			properties:    map[string]string{PropertyName: PulsarSchedulerAttribute},
+			expectedEpoch: 0,
+		},
+		"invalid": {
+			properties:    map[string]string{LeaderEpochPropertyName: "not-an-epoch"},
+			expectedEpoch: 0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			msg := mocks.NewMockMessage(ctrl)
+			msg.EXPECT().Properties().Return(tc.properties).AnyTimes()
+			msg.EXPECT().ID().Return(nil).AnyTimes()
+			assert.Equal(t, tc.expectedEpoch, LeaderEpochFromMsg(msg))
+		})
+	}
+}
+
+func TestLeaderEpochFilter(t *testing.T) {
+	type message struct {
+		jobSet string
+		// Zero if the message isn't stamped with an epoch.
+		epoch    uint64
+		expected bool
+	}
+	tests := map[string][]message{
+		"single leader": {
+			{jobSet: "a", epoch: 1, expected: true},
+			{jobSet: "a", epoch: 1, expected: true},
+		},
+		"new leader": {
+			{jobSet: "a", epoch: 1, expected: true},
+			{jobSet: "a", epoch: 2, expected: true},
+			{jobSet: "a", epoch: 2, expected: true},
+		},
+		"old leader's in-flight message lands after new leader started publishing": {
+			{jobSet: "a", epoch: 1, expected: true},
+			{jobSet: "a", epoch: 2, expected: true},
+			{jobSet: "a", epoch: 1, expected: false},
+			{jobSet: "a", epoch: 2, expected: true},
+		},
+		"epochs are tracked per job set": {
+			{jobSet: "a", epoch: 2, expected: true},
+			{jobSet: "b", epoch: 1, expected: true},
+			{jobSet: "a", epoch: 1, expected: false},
+			{jobSet: "b", epoch: 2, expected: true},
+			{jobSet: "b", epoch: 1, expected: false},
+		},
+		"messages without epoch are never discarded": {
+			{jobSet: "a", epoch: 2, expected: true},
+			{jobSet: "a", epoch: 0, expected: true},
+			{jobSet: "a", epoch: 1, expected: false},
+		},
+	}
+	for name, messages := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			filter, err := NewLeaderEpochFilter(10)
+			require.NoError(t, err)
+			for i, m := range messages {
+				assert.Equal(t, m.expected, filter.Filter(leaderEpochMsg(ctrl, m.jobSet, m.epoch)), "message %d", i)
+			}
+		})
+	}
+}
+
+func TestLeaderEpochFilter_Bounded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	filter, err := NewLeaderEpochFilter(2)
+	require.NoError(t, err)
+
+	assert.True(t, filter.Filter(leaderEpochMsg(ctrl, "a", 2)))
+	assert.True(t, filter.Filter(leaderEpochMsg(ctrl, "b", 2)))
+	assert.False(t, filter.Filter(leaderEpochMsg(ctrl, "a", 1)))
+	for i := 0; i < 10; i++ {
+		assert.True(t, filter.Filter(leaderEpochMsg(ctrl, fmt.Sprintf("jobset-%d", i), 2)))
+	}
+	assert.Equal(t, 2, filter.newestEpochByKey.Len())
+
+	// The epochs of the least recently seen job sets are forgotten.
+	assert.True(t, filter.Filter(leaderEpochMsg(ctrl, "a", 1)))
+	assert.False(t, filter.Filter(leaderEpochMsg(ctrl, "jobset-9", 1)))
+}
+
+// leaderEpochMsg returns a message for jobSet stamped with epoch, or not stamped with an epoch if epoch is zero.
+func leaderEpochMsg(ctrl *gomock.Controller, jobSet string, epoch uint64) *mocks.MockMessage {
+	properties := map[string]string{PropertyName: PulsarSchedulerAttribute}
+	if epoch != 0 {
+		properties[LeaderEpochPropertyName] = MsgPropertyFromLeaderEpoch(epoch)
+	}
+	msg := mocks.NewMockMessage(ctrl)
+	msg.EXPECT().Properties().Return(properties).AnyTimes()
+	msg.EXPECT().Key().Return(jobSet).AnyTimes()
+	msg.EXPECT().ID().Return(nil).AnyTimes()
+	return msg
+}
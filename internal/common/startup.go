@@ -13,6 +13,7 @@ import (
 
 	"github.com/armadaproject/armada/internal/common/certs"
 
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
@@ -40,21 +41,29 @@ func BindCommandlineArguments() {
 
 // TODO Move code relating to config out of common into a new package internal/serverconfig
 func LoadConfig(config interface{}, defaultPath string, overrideConfigs []string) *viper.Viper {
+	v, err := ReadConfig(config, defaultPath, overrideConfigs)
+	if err != nil {
+		log.Error(err)
+		os.Exit(-1)
+	}
+	return v
+}
+
+// ReadConfig is like LoadConfig, except it returns an error rather than exiting if the config can't be read,
+// e.g., to re-read the config of a running process.
+func ReadConfig(config interface{}, defaultPath string, overrideConfigs []string) (*viper.Viper, error) {
 	v := viper.NewWithOptions(viper.KeyDelimiter("::"))
 	v.SetConfigName(baseConfigFileName)
 	v.AddConfigPath(defaultPath)
 	if err := v.ReadInConfig(); err != nil {
-		log.Errorf("Error reading base config path=%s name=%s: %v", defaultPath, baseConfigFileName, err)
-		os.Exit(-1)
+		return nil, errors.Wrapf(err, "error reading base config path=%s name=%s", defaultPath, baseConfigFileName)
 	}
 	log.Infof("Read base config from %s", v.ConfigFileUsed())
 
 	for _, overrideConfig := range overrideConfigs {
 		v.SetConfigFile(overrideConfig)
-		err := v.MergeInConfig()
-		if err != nil {
-			log.Errorf("Error reading config from %s: %v", overrideConfig, err)
-			os.Exit(-1)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, errors.Wrapf(err, "error reading config from %s", overrideConfig)
 		}
 		log.Infof("Read config from %s", v.ConfigFileUsed())
 	}
@@ -63,13 +72,11 @@ func LoadConfig(config interface{}, defaultPath string, overrideConfigs []string
 	v.SetEnvPrefix("ARMADA")
 	v.AutomaticEnv()
 
-	err := v.Unmarshal(config, commonconfig.CustomHooks...)
-	if err != nil {
-		log.Error(err)
-		os.Exit(-1)
+	if err := v.Unmarshal(config, commonconfig.CustomHooks...); err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	return v
+	return v, nil
 }
 
 func UnmarshalKey(v *viper.Viper, key string, item interface{}) error {
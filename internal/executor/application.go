@@ -149,7 +149,7 @@ func StartUpWithContext(
 	resourceCleanupService := service.NewResourceCleanupService(clusterContext, config.Kubernetes)
 	taskManager.Register(resourceCleanupService.CleanupResources, config.Task.ResourceCleanupInterval, "resource_cleanup")
 
-	if config.Metric.ExposeQueueUsageMetrics {
+	if config.Metric.ExposeQueueUsageMetrics || (config.Application.UseExecutorApi && config.Application.ReportRunResourceUsage) {
 		taskManager.Register(podUtilisationService.RefreshUtilisationData, config.Task.QueueUsageDataRefreshInterval, "pod_usage_data_refresh")
 	}
 
@@ -212,6 +212,12 @@ func setupExecutorApiComponents(
 
 	leaseRequester := service.NewJobLeaseRequester(
 		executorApiClient, clusterContext, config.Kubernetes.MinimumJobSize)
+	if config.Application.ExecutorTimeout > 0 {
+		leaseRequester.EnableExecutorTimeoutOverride(config.Application.ExecutorTimeout)
+	}
+	if config.Application.ReportNodeDeltas {
+		leaseRequester.EnableNodeDeltas()
+	}
 	preemptRunProcessor := processors.NewRunPreemptedProcessor(clusterContext, jobRunState, eventReporter)
 	removeRunProcessor := processors.NewRemoveRunProcessor(clusterContext, jobRunState)
 
@@ -224,6 +230,9 @@ func setupExecutorApiComponents(
 		config.Kubernetes.PodDefaults,
 		config.Application.MaxLeasedJobs,
 	)
+	if config.Application.ReportRunResourceUsage {
+		jobRequester.EnableRunResourceUsageReporting(clusterContext, podUtilisationService)
+	}
 	clusterAllocationService := service.NewClusterAllocationService(
 		clusterContext,
 		eventReporter,
@@ -22,6 +22,15 @@ type ApplicationConfiguration struct {
 	// MaxLeasedJobs is the maximum jobs the executor should have in Leased state ay any one time (i.e jobs not submitted to kubernetes)
 	// It is largely used to calculate how many new jobs to request from the scheduler
 	MaxLeasedJobs int
+	// If non-zero, how long the scheduler should wait for a heartbeat from this executor before considering it stale
+	// and expiring its leases, overriding the scheduler's default. Only used with the executor API.
+	ExecutorTimeout time.Duration
+	// If true, the actual resource usage of each job run is reported to the scheduler with each lease request,
+	// such that it can be taken into account when choosing which jobs to preempt. Only used with the executor API.
+	ReportRunResourceUsage bool
+	// If true, once the scheduler has accepted all nodes of the cluster, lease requests only contain the nodes added,
+	// changed, or removed since, which is much cheaper for large clusters. Only used with the executor API.
+	ReportNodeDeltas bool
 }
 
 type PodDefaults struct {
@@ -18,6 +18,8 @@ type SubmitJob struct {
 	Pod       *v1.Pod
 	Ingresses []*networking.Ingress
 	Services  []*v1.Service
+	// Hash of the spec the scheduler leased the run with, or nil if it provided none.
+	SpecHash []byte
 }
 
 type RunMeta struct {
@@ -82,6 +84,9 @@ type RunState struct {
 	CancelRequested         bool
 	PreemptionRequested     bool
 	LastPhaseTransitionTime time.Time
+	// Hash of the most recent spec the scheduler leased the run with, or nil if unknown,
+	// e.g., since the run was recovered from kubernetes.
+	SpecHash []byte
 }
 
 func (r *RunState) DeepCopy() *RunState {
@@ -93,5 +98,6 @@ func (r *RunState) DeepCopy() *RunState {
 		CancelRequested:         r.CancelRequested,
 		PreemptionRequested:     r.PreemptionRequested,
 		LastPhaseTransitionTime: r.LastPhaseTransitionTime,
+		SpecHash:                r.SpecHash,
 	}
 }
@@ -122,18 +122,30 @@ func (stateStore *JobRunStateStore) reportRunActive(pod *v1.Pod) {
 	currentState.LastPhaseTransitionTime = time.Now()
 }
 
+// ReportRunLeased records a run leased by the scheduler. If the run is already known, the scheduler re-sent its lease
+// since its spec changed: the spec replaces that of the run if the run hasn't been submitted yet, and otherwise only
+// its hash is recorded, since the spec of a pod can't be changed once created.
 func (stateStore *JobRunStateStore) ReportRunLeased(runMeta *RunMeta, job *SubmitJob) {
 	stateStore.lock.Lock()
 	defer stateStore.lock.Unlock()
-	_, present := stateStore.jobRunState[runMeta.RunId]
+	currentState, present := stateStore.jobRunState[runMeta.RunId]
 	if !present {
 		state := &RunState{
 			Meta:                    runMeta,
 			Job:                     job,
 			Phase:                   Leased,
 			LastPhaseTransitionTime: time.Now(),
+			SpecHash:                job.SpecHash,
 		}
 		stateStore.jobRunState[runMeta.RunId] = state
+	} else if job.SpecHash != nil {
+		currentState.SpecHash = job.SpecHash
+		if currentState.Phase == Leased {
+			log.Infof("updating spec of run %s of job %s, which hasn't been submitted yet", runMeta.RunId, runMeta.JobId)
+			currentState.Job = job
+		} else {
+			log.Warnf("not updating spec of run %s of job %s, since it has already been submitted", runMeta.RunId, runMeta.JobId)
+		}
 	} else {
 		log.Warnf("run unexpectedly reported as leased (runId=%s, jobId=%s), state already exists", runMeta.RunId, runMeta.JobId)
 	}
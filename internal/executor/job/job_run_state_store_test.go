@@ -71,6 +71,37 @@ func TestReportRunLeased(t *testing.T) {
 	assert.Equal(t, allKnownJobRuns[0].Job, job)
 }
 
+func TestReportRunLeased_UpdatedSpec(t *testing.T) {
+	tests := map[string]struct {
+		phase            RunPhase
+		expectSpecUpdate bool
+	}{
+		"not yet submitted": {phase: Leased, expectSpecUpdate: true},
+		"already submitted": {phase: SuccessfulSubmission, expectSpecUpdate: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			originalJob := &SubmitJob{Meta: SubmitJobMeta{RunMeta: defaultRunInfoMeta}, Pod: createPod(), SpecHash: []byte{1}}
+			updatedJob := &SubmitJob{Meta: SubmitJobMeta{RunMeta: defaultRunInfoMeta}, Pod: createPod(), SpecHash: []byte{2}}
+			jobRunStateManager, _ := setup(t, []*v1.Pod{})
+			jobRunStateManager.ReportRunLeased(defaultRunInfoMeta, originalJob)
+			jobRunStateManager.jobRunState[defaultRunInfoMeta.RunId].Phase = tc.phase
+
+			jobRunStateManager.ReportRunLeased(defaultRunInfoMeta, updatedJob)
+
+			allKnownJobRuns := jobRunStateManager.GetAll()
+			assert.Len(t, allKnownJobRuns, 1)
+			assert.Equal(t, []byte{2}, allKnownJobRuns[0].SpecHash)
+			assert.Equal(t, tc.phase, allKnownJobRuns[0].Phase)
+			if tc.expectSpecUpdate {
+				assert.Same(t, updatedJob, allKnownJobRuns[0].Job)
+			} else {
+				assert.Same(t, originalJob, allKnownJobRuns[0].Job)
+			}
+		})
+	}
+}
+
 func TestReportRunInvalid(t *testing.T) {
 	jobRunStateManager, _ := setup(t, []*v1.Pod{})
 	jobRunStateManager.ReportRunInvalid(defaultRunInfoMeta)
@@ -76,6 +76,7 @@ func CreateSubmitJobFromExecutorApiJobRunLease(
 		Pod:       pod,
 		Ingresses: util2.ExtractIngresses(jobRunLease, pod, podDefaults.Ingress),
 		Services:  util2.ExtractServices(jobRunLease, pod),
+		SpecHash:  jobRunLease.SpecHash,
 	}, nil
 }
 
@@ -264,6 +264,14 @@ func CreateReturnLeaseEvent(pod *v1.Pod, reason string, clusterId string, runAtt
 	}
 }
 
+// CreateUnacknowledgedLeaseReturnedEvent returns a lease for which the executor never created a pod.
+// This allows the scheduler to safely retry jobs that must run at most once.
+func CreateUnacknowledgedLeaseReturnedEvent(pod *v1.Pod, reason string, clusterId string) api.Event {
+	event := CreateReturnLeaseEvent(pod, reason, clusterId, true).(*api.JobLeaseReturnedEvent)
+	event.LeaseNeverAcknowledged = true
+	return event
+}
+
 func CreateJobUtilisationEvent(pod *v1.Pod, utilisationData *domain.UtilisationData, clusterId string) api.Event {
 	return &api.JobUtilisationEvent{
 		JobId:                 pod.Labels[domain.JobId],
@@ -99,7 +99,8 @@ func (allocationService *ClusterAllocationService) processFailedJobSubmissions(f
 		}
 
 		if details.Recoverable {
-			returnLeaseEvent := reporter.CreateReturnLeaseEvent(details.Pod, message, allocationService.clusterId.GetClusterId(), true)
+			// The pod was never created, so the lease was never acted on.
+			returnLeaseEvent := reporter.CreateUnacknowledgedLeaseReturnedEvent(details.Pod, message, allocationService.clusterId.GetClusterId())
 			err := allocationService.eventReporter.Report([]reporter.EventMessage{{Event: returnLeaseEvent, JobRunId: details.JobRunMeta.RunId}})
 			if err == nil {
 				allocationService.jobRunStateStore.ReportFailedSubmission(details.JobRunMeta.RunId)
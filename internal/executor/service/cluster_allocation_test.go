@@ -118,8 +118,9 @@ func TestAllocateSpareClusterCapacity_HandlesFailedPodCreations(t *testing.T) {
 				run := runStore.Get(leaseRun.Meta.RunId)
 				assert.Equal(t, run.Phase, job.FailedSubmission)
 				assert.Len(t, eventReporter.ReceivedEvents, 1)
-				_, ok := eventReporter.ReceivedEvents[0].Event.(*api.JobLeaseReturnedEvent)
+				leaseReturnedEvent, ok := eventReporter.ReceivedEvents[0].Event.(*api.JobLeaseReturnedEvent)
 				assert.True(t, ok)
+				assert.True(t, leaseReturnedEvent.LeaseNeverAcknowledged)
 			}
 
 			if tc.expectFailEvent {
@@ -5,6 +5,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
+	v1 "k8s.io/api/core/v1"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	"github.com/armadaproject/armada/internal/common/slices"
@@ -28,6 +29,16 @@ type JobRequester struct {
 	podDefaults        *configuration.PodDefaults
 	jobRunStateStore   job.RunStateStore
 	maxLeasedJobs      int
+	// New leases are not requested before this time, as instructed by the scheduler.
+	// Lease requests are still sent meanwhile, such that cancellations and preemptions are received.
+	nextLeaseRequestTime time.Time
+	// If non-nil, the actual resource usage of the runs of active pods is reported with each lease request.
+	podLister             activePodLister
+	podUtilisationService utilisation.PodUtilisationService
+}
+
+type activePodLister interface {
+	GetActiveBatchPods() ([]*v1.Pod, error)
 }
 
 func NewJobRequester(
@@ -50,6 +61,13 @@ func NewJobRequester(
 	}
 }
 
+// EnableRunResourceUsageReporting causes the actual resource usage of the runs of pods listed by podLister,
+// as provided by podUtilisationService, to be reported to the scheduler with each lease request.
+func (r *JobRequester) EnableRunResourceUsageReporting(podLister activePodLister, podUtilisationService utilisation.PodUtilisationService) {
+	r.podLister = podLister
+	r.podUtilisationService = podUtilisationService
+}
+
 func (r *JobRequester) RequestJobsRuns() {
 	leaseRequest, err := r.createLeaseRequest()
 	if err != nil {
@@ -71,6 +89,15 @@ func (r *JobRequester) RequestJobsRuns() {
 	r.markJobRunsAsCancelled(leaseResponse.RunIdsToCancel)
 	r.markJobRunsToPreempt(leaseResponse.RunIdsToPreempt)
 	r.handleFailedJobCreation(failedJobCreations)
+	if leaseResponse.RetryAfter > 0 {
+		r.nextLeaseRequestTime = time.Now().Add(leaseResponse.RetryAfter)
+	}
+	if len(leaseResponse.QuarantinedNodeNames) > 0 {
+		log.Warnf(
+			"Scheduler has quarantined nodes %v since many runs failed on them; they should be tainted with %s",
+			leaseResponse.QuarantinedNodeNames, leaseResponse.QuarantineTaintKey,
+		)
+	}
 }
 
 func (r *JobRequester) createLeaseRequest() (*LeaseRequest, error) {
@@ -93,6 +120,9 @@ func (r *JobRequester) createLeaseRequest() (*LeaseRequest, error) {
 	maxJobsToLease := r.maxLeasedJobs
 	if len(leasedJobs) > 0 {
 		maxJobsToLease = 0
+	} else if time.Now().Before(r.nextLeaseRequestTime) {
+		log.Infof("Not requesting new job leases until %s as instructed by the scheduler", r.nextLeaseRequestTime)
+		maxJobsToLease = 0
 	}
 
 	return &LeaseRequest{
@@ -100,9 +130,59 @@ func (r *JobRequester) createLeaseRequest() (*LeaseRequest, error) {
 		Nodes:               nodes,
 		UnassignedJobRunIds: unassignedRunIds,
 		MaxJobsToLease:      uint32(maxJobsToLease),
+		JobRunResourceUsage: r.getRunResourceUsage(),
+		JobRunSpecHashes:    r.getRunSpecHashes(),
 	}, nil
 }
 
+// Returns the hash of the spec of each run held whose spec hash is known.
+func (r *JobRequester) getRunSpecHashes() []*executorapi.JobRunSpecHash {
+	var rv []*executorapi.JobRunSpecHash
+	for _, state := range r.jobRunStateStore.GetAll() {
+		if state.SpecHash == nil {
+			continue
+		}
+		runId, err := armadaevents.ProtoUuidFromUuidString(state.Meta.RunId)
+		if err != nil {
+			continue
+		}
+		rv = append(rv, &executorapi.JobRunSpecHash{JobRunId: runId, SpecHash: state.SpecHash})
+	}
+	return rv
+}
+
+// Returns the actual resource usage of the runs of all active pods for which usage data is available.
+// Since usage is advisory, runs for which it can't be determined are omitted rather than failing the lease request.
+func (r *JobRequester) getRunResourceUsage() []*executorapi.JobRunResourceUsage {
+	if r.podLister == nil || r.podUtilisationService == nil {
+		return nil
+	}
+	pods, err := r.podLister.GetActiveBatchPods()
+	if err != nil {
+		log.Warnf("Not reporting job run resource usage because listing pods failed: %s", err)
+		return nil
+	}
+	var rv []*executorapi.JobRunResourceUsage
+	for _, pod := range pods {
+		runId, err := armadaevents.ProtoUuidFromUuidString(util.ExtractJobRunId(pod))
+		if err != nil {
+			continue
+		}
+		usage := r.podUtilisationService.GetPodUtilisation(pod)
+		cpu, hasCpu := usage.CumulativeUsage["cpu"]
+		memory, hasMemory := usage.CurrentUsage["memory"]
+		if !hasCpu && !hasMemory {
+			continue
+		}
+		rv = append(rv, &executorapi.JobRunResourceUsage{
+			JobRunId:        runId,
+			CpuSeconds:      cpu.AsApproximateFloat64(),
+			WorkingSetBytes: memory.Value(),
+		})
+	}
+	return rv
+}
+
 // Returns the RunIds of all managed pods that haven't been assigned to a node
 func (r *JobRequester) getUnassignedRunIds(capacityReport *utilisation.ClusterAvailableCapacityReport) ([]armadaevents.Uuid, error) {
 	allAssignedRunIds := []string{}
@@ -3,18 +3,21 @@ package service
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	armadaresource "github.com/armadaproject/armada/internal/common/resource"
 	"github.com/armadaproject/armada/internal/common/util"
 	"github.com/armadaproject/armada/internal/executor/configuration"
 	fakecontext "github.com/armadaproject/armada/internal/executor/context/fake"
+	"github.com/armadaproject/armada/internal/executor/domain"
 	"github.com/armadaproject/armada/internal/executor/job"
 	mocks3 "github.com/armadaproject/armada/internal/executor/reporter/mocks"
 	"github.com/armadaproject/armada/internal/executor/utilisation"
@@ -109,6 +112,23 @@ func TestRequestJobsRuns_ConstructsCorrectLeaseRequest(t *testing.T) {
 	}
 }
 
+func TestRequestJobsRuns_IncludesKnownSpecHashes(t *testing.T) {
+	runWithHashId := uuid.New()
+	runWithHash := createRun(runWithHashId.String(), job.Active)
+	runWithHash.SpecHash = []byte{1, 2, 3}
+	runWithoutHash := createRun(uuid.New().String(), job.Active)
+
+	jobRequester, _, leaseRequester, _, _ := setupJobRequesterTest([]*job.RunState{runWithHash, runWithoutHash})
+	jobRequester.RequestJobsRuns()
+
+	require.Len(t, leaseRequester.ReceivedLeaseRequests, 1)
+	assert.Equal(
+		t,
+		[]*executorapi.JobRunSpecHash{{JobRunId: armadaevents.ProtoUuidFromUuid(runWithHashId), SpecHash: []byte{1, 2, 3}}},
+		leaseRequester.ReceivedLeaseRequests[0].JobRunSpecHashes,
+	)
+}
+
 func TestRequestJobsRuns_HandlesLeasedJobs(t *testing.T) {
 	jobRequester, eventReporter, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
 
@@ -176,6 +196,43 @@ func TestRequestJobsRuns_HandlesRunIdsToCancel(t *testing.T) {
 	assert.Equal(t, allJobRuns[0], expectedRunState)
 }
 
+func TestRequestJobsRuns_HonoursRetryAfter(t *testing.T) {
+	jobRequester, _, leaseRequester, _, _ := setupJobRequesterTest([]*job.RunState{})
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{RetryAfter: time.Hour}
+
+	jobRequester.RequestJobsRuns()
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 1)
+
+	assert.Equal(t, uint32(defaultMaxLeasedJobs), leaseRequester.ReceivedLeaseRequests[0].MaxJobsToLease)
+
+	// No new leases should be requested until the retry period has elapsed,
+	// but lease requests are still sent, such that instructions for runs already leased are received.
+	jobRequester.RequestJobsRuns()
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 2)
+	assert.Equal(t, uint32(0), leaseRequester.ReceivedLeaseRequests[1].MaxJobsToLease)
+}
+
+func TestRequestJobsRuns_HandlesRunIdsToCancelWithinRetryAfter(t *testing.T) {
+	activeRun := createRun(uuid.New().String(), job.Active)
+	jobRequester, _, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{activeRun})
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{RetryAfter: time.Hour}
+	jobRequester.RequestJobsRuns()
+
+	activeRunUuid, err := armadaevents.ProtoUuidFromUuidString(activeRun.Meta.RunId)
+	require.NoError(t, err)
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{
+		RunIdsToCancel: []*armadaevents.Uuid{activeRunUuid},
+		RetryAfter:     time.Hour,
+	}
+	jobRequester.RequestJobsRuns()
+
+	require.Len(t, leaseRequester.ReceivedLeaseRequests, 2)
+	assert.Equal(t, uint32(0), leaseRequester.ReceivedLeaseRequests[1].MaxJobsToLease)
+	expectedRunState := activeRun.DeepCopy()
+	expectedRunState.CancelRequested = true
+	assert.Equal(t, []*job.RunState{expectedRunState}, stateStore.GetAll())
+}
+
 func TestRequestJobsRuns_HandlesRunIsToPreempt(t *testing.T) {
 	runId := uuid.New()
 	activeRun := createRun(runId.String(), job.Active)
@@ -279,3 +336,59 @@ func (s *StubLeaseRequester) LeaseJobRuns(_ *armadacontext.Context, request *Lea
 	s.ReceivedLeaseRequests = append(s.ReceivedLeaseRequests, request)
 	return s.LeaseJobRunLeaseResponse, s.LeaseJobRunError
 }
+
+func TestRequestJobsRuns_ReportsRunResourceUsage(t *testing.T) {
+	runId := uuid.New()
+	podWithUsage := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Labels: map[string]string{domain.JobRunId: runId.String()}}}
+	podWithoutUsage := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Labels: map[string]string{domain.JobRunId: uuid.New().String()}}}
+	podWithoutRunId := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-3"}}
+
+	jobRequester, _, leaseRequester, _, _ := setupJobRequesterTest([]*job.RunState{})
+	jobRequester.EnableRunResourceUsageReporting(
+		&stubActivePodLister{pods: []*v1.Pod{podWithUsage, podWithoutUsage, podWithoutRunId}},
+		&stubPodUtilisationService{
+			utilisationByPodName: map[string]*domain.UtilisationData{
+				"pod-1": {
+					CurrentUsage:    armadaresource.ComputeResources{"memory": resource.MustParse("1Gi")},
+					CumulativeUsage: armadaresource.ComputeResources{"cpu": resource.MustParse("90")},
+				},
+				"pod-3": {
+					CurrentUsage: armadaresource.ComputeResources{"memory": resource.MustParse("1Gi")},
+				},
+			},
+		},
+	)
+	jobRequester.RequestJobsRuns()
+
+	require.Len(t, leaseRequester.ReceivedLeaseRequests, 1)
+	assert.Equal(
+		t,
+		[]*executorapi.JobRunResourceUsage{
+			{
+				JobRunId:        armadaevents.ProtoUuidFromUuid(runId),
+				CpuSeconds:      90,
+				WorkingSetBytes: 1024 * 1024 * 1024,
+			},
+		},
+		leaseRequester.ReceivedLeaseRequests[0].JobRunResourceUsage,
+	)
+}
+
+type stubActivePodLister struct {
+	pods []*v1.Pod
+}
+
+func (s *stubActivePodLister) GetActiveBatchPods() ([]*v1.Pod, error) {
+	return s.pods, nil
+}
+
+type stubPodUtilisationService struct {
+	utilisationByPodName map[string]*domain.UtilisationData
+}
+
+func (s *stubPodUtilisationService) GetPodUtilisation(pod *v1.Pod) *domain.UtilisationData {
+	if utilisationData, ok := s.utilisationByPodName[pod.Name]; ok {
+		return utilisationData
+	}
+	return domain.EmptyUtilisationData()
+}
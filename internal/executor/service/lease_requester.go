@@ -1,14 +1,17 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"time"
 
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/encoding/gzip"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	armadaresource "github.com/armadaproject/armada/internal/common/resource"
@@ -23,12 +26,24 @@ type LeaseRequest struct {
 	Nodes               []*api.NodeInfo
 	UnassignedJobRunIds []armadaevents.Uuid
 	MaxJobsToLease      uint32
+	// Actual resource usage of the runs held by the executor; omitted if not reported.
+	JobRunResourceUsage []*executorapi.JobRunResourceUsage
+	// Hash of the spec of each run held by the executor, where known, such that the scheduler re-sends the leases of
+	// runs whose spec has changed or is unknown.
+	JobRunSpecHashes []*executorapi.JobRunSpecHash
 }
 
 type LeaseResponse struct {
 	LeasedRuns      []*executorapi.JobRunLease
 	RunIdsToCancel  []*armadaevents.Uuid
 	RunIdsToPreempt []*armadaevents.Uuid
+	// If non-zero, the scheduler isn't leasing new runs and no further leases should be requested for this long.
+	RetryAfter time.Duration
+	// Names of nodes the scheduler has quarantined, since many runs failed on them.
+	// Nil if the scheduler doesn't quarantine nodes.
+	QuarantinedNodeNames []string
+	// Key of the taint the scheduler asks to be added to quarantined nodes.
+	QuarantineTaintKey string
 }
 
 type LeaseRequester interface {
@@ -39,6 +54,19 @@ type JobLeaseRequester struct {
 	executorApiClient executorapi.ExecutorApiClient
 	clusterIdentity   clusterContext.ClusterIdentity
 	minimumJobSize    armadaresource.ComputeResources
+	// Sent to the scheduler with each request; see EnableExecutorTimeoutOverride.
+	executorTimeout time.Duration
+	// Used to timestamp requests, such that the scheduler can detect if this executor's clock is skewed.
+	clock clock.Clock
+	// If true, only the nodes changed since the previous request are reported, if the scheduler accepted that request's.
+	nodeDeltas bool
+	// Hash of each node reported, indexed by node name, as of the previous request if the scheduler accepted its nodes
+	// such that the next request may contain a delta; otherwise nil.
+	acceptedNodeInfoHashes map[string][]byte
+	// Id of the previous request, and whether a response to it was received. Unless it was, the next request has the
+	// same id, such that the scheduler can answer it with the response it already sent.
+	requestId         uint64
+	requestIdAnswered bool
 }
 
 func NewJobLeaseRequester(
@@ -46,18 +74,52 @@ func NewJobLeaseRequester(
 	clusterIdentity clusterContext.ClusterIdentity,
 	minimumJobSize armadaresource.ComputeResources,
 ) *JobLeaseRequester {
-	return &JobLeaseRequester{
+	requester := &JobLeaseRequester{
 		executorApiClient: executorApiClient,
 		clusterIdentity:   clusterIdentity,
 		minimumJobSize:    minimumJobSize,
+		clock:             clock.RealClock{},
 	}
+	// Ids start from the current time, such that they keep increasing when the executor is restarted.
+	requester.requestId = uint64(requester.clock.Now().UnixNano())
+	requester.requestIdAnswered = true
+	return requester
+}
+
+// EnableExecutorTimeoutOverride causes the scheduler to be asked to wait for timeout without hearing from
+// this executor before considering it stale, instead of using the scheduler's default timeout.
+func (requester *JobLeaseRequester) EnableExecutorTimeoutOverride(timeout time.Duration) {
+	requester.executorTimeout = timeout
+}
+
+// EnableNodeDeltas causes lease requests to contain only the nodes added, changed, or removed since the previous request,
+// provided the scheduler indicated it stored the nodes of that request; otherwise, all nodes are reported.
+func (requester *JobLeaseRequester) EnableNodeDeltas() {
+	requester.nodeDeltas = true
 }
 
 func (requester *JobLeaseRequester) LeaseJobRuns(ctx *armadacontext.Context, request *LeaseRequest) (*LeaseResponse, error) {
+	var nodeInfoHashes map[string][]byte
+	if requester.nodeDeltas {
+		nodeInfoHashes = make(map[string][]byte, len(request.Nodes))
+		for _, node := range request.Nodes {
+			nodeInfoHashes[node.Name] = executorapi.NodeInfoHash(node)
+		}
+	}
+	// Unless the scheduler accepts the nodes of this request, the next one reports all nodes.
+	acceptedNodeInfoHashes := requester.acceptedNodeInfoHashes
+	requester.acceptedNodeInfoHashes = nil
+
+	if requester.requestIdAnswered {
+		requester.requestId++
+		requester.requestIdAnswered = false
+	}
+
 	stream, err := requester.executorApiClient.LeaseJobRuns(ctx, grpcretry.Disable(), grpc.UseCompressor(gzip.Name))
 	if err != nil {
 		return nil, err
 	}
+	sentAt := requester.clock.Now()
 	leaseRequest := &executorapi.LeaseRequest{
 		ExecutorId:          requester.clusterIdentity.GetClusterId(),
 		Pool:                requester.clusterIdentity.GetClusterPool(),
@@ -66,6 +128,14 @@ func (requester *JobLeaseRequester) LeaseJobRuns(ctx *armadacontext.Context, req
 		Nodes:               request.Nodes,
 		UnassignedJobRunIds: request.UnassignedJobRunIds,
 		MaxJobsToLease:      request.MaxJobsToLease,
+		ExecutorTimeout:     requester.executorTimeout,
+		JobRunResourceUsage: request.JobRunResourceUsage,
+		JobRunSpecHashes:    request.JobRunSpecHashes,
+		SentAt:              &sentAt,
+		RequestId:           requester.requestId,
+	}
+	if acceptedNodeInfoHashes != nil {
+		setNodesDelta(leaseRequest, nodeInfoHashes, acceptedNodeInfoHashes)
 	}
 	if err := stream.Send(leaseRequest); err != nil {
 		return nil, errors.WithStack(err)
@@ -74,6 +144,10 @@ func (requester *JobLeaseRequester) LeaseJobRuns(ctx *armadacontext.Context, req
 	leaseRuns := []*executorapi.JobRunLease{}
 	runIdsToCancel := []*armadaevents.Uuid{}
 	runIdsToPreempt := []*armadaevents.Uuid{}
+	retryAfter := time.Duration(0)
+	var quarantinedNodeNames []string
+	quarantineTaintKey := ""
+	acceptsNodeDeltas := false
 	for {
 		shouldEndStreamCall := false
 		select {
@@ -98,7 +172,13 @@ func (requester *JobLeaseRequester) LeaseJobRuns(ctx *armadacontext.Context, req
 				runIdsToPreempt = append(runIdsToPreempt, typed.PreemptRuns.JobRunIdsToPreempt...)
 			case *executorapi.LeaseStreamMessage_CancelRuns:
 				runIdsToCancel = append(runIdsToCancel, typed.CancelRuns.JobRunIdsToCancel...)
+			case *executorapi.LeaseStreamMessage_QuarantineNodes:
+				quarantinedNodeNames = append([]string{}, typed.QuarantineNodes.NodeNames...)
+				quarantineTaintKey = typed.QuarantineNodes.TaintKey
 			case *executorapi.LeaseStreamMessage_End:
+				retryAfter = typed.End.RetryAfter
+				acceptsNodeDeltas = typed.End.AcceptsNodeDeltas
+				requester.requestIdAnswered = true
 				shouldEndStreamCall = true
 			default:
 				log.Errorf("unexpected lease stream message type %T", typed)
@@ -110,9 +190,36 @@ func (requester *JobLeaseRequester) LeaseJobRuns(ctx *armadacontext.Context, req
 		}
 	}
 
+	if acceptsNodeDeltas && requester.nodeDeltas {
+		requester.acceptedNodeInfoHashes = nodeInfoHashes
+	}
 	return &LeaseResponse{
-		LeasedRuns:      leaseRuns,
-		RunIdsToCancel:  runIdsToCancel,
-		RunIdsToPreempt: runIdsToPreempt,
+		LeasedRuns:           leaseRuns,
+		RunIdsToCancel:       runIdsToCancel,
+		RunIdsToPreempt:      runIdsToPreempt,
+		RetryAfter:           retryAfter,
+		QuarantinedNodeNames: quarantinedNodeNames,
+		QuarantineTaintKey:   quarantineTaintKey,
 	}, nil
 }
+
+// setNodesDelta replaces the nodes of req, whose hashes are given by nodeInfoHashes, with those added or changed since
+// the nodes with acceptedNodeInfoHashes were accepted by the scheduler, and adds the names of those removed since.
+func setNodesDelta(req *executorapi.LeaseRequest, nodeInfoHashes map[string][]byte, acceptedNodeInfoHashes map[string][]byte) {
+	var changedNodes []*api.NodeInfo
+	for _, node := range req.Nodes {
+		if acceptedHash, ok := acceptedNodeInfoHashes[node.Name]; !ok || !bytes.Equal(acceptedHash, nodeInfoHashes[node.Name]) {
+			changedNodes = append(changedNodes, node)
+		}
+	}
+	var removedNodeNames []string
+	for name := range acceptedNodeInfoHashes {
+		if _, ok := nodeInfoHashes[name]; !ok {
+			removedNodeNames = append(removedNodeNames, name)
+		}
+	}
+	req.Nodes = changedNodes
+	req.NodesDelta = true
+	req.RemovedNodeNames = removedNodeNames
+	req.NodesHash = executorapi.NodesHash(nodeInfoHashes)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	"github.com/armadaproject/armada/internal/common/mocks"
@@ -105,6 +106,7 @@ func TestLeaseJobRuns_Send(t *testing.T) {
 		MaxJobsToLease:      uint32(5),
 	}
 
+	sentAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	expectedRequest := &executorapi.LeaseRequest{
 		ExecutorId:          defaultClusterIdentity.GetClusterId(),
 		Pool:                defaultClusterIdentity.GetClusterPool(),
@@ -113,9 +115,13 @@ func TestLeaseJobRuns_Send(t *testing.T) {
 		Nodes:               leaseRequest.Nodes,
 		UnassignedJobRunIds: leaseRequest.UnassignedJobRunIds,
 		MaxJobsToLease:      leaseRequest.MaxJobsToLease,
+		SentAt:              &sentAt,
+		RequestId:           2,
 	}
 
 	jobRequester, mockExecutorApiClient, mockStream := setup(t)
+	jobRequester.clock = clock.NewFakeClock(sentAt)
+	jobRequester.requestId = 1
 	mockExecutorApiClient.EXPECT().LeaseJobRuns(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockStream, nil)
 	mockStream.EXPECT().Send(expectedRequest).Return(nil)
 	mockStream.EXPECT().Recv().Return(endMarker, nil)
@@ -124,6 +130,138 @@ func TestLeaseJobRuns_Send(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLeaseJobRuns_RetryAfter(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 30*time.Second)
+	defer cancel()
+
+	jobRequester, mockExecutorApiClient, mockStream := setup(t)
+	mockExecutorApiClient.EXPECT().LeaseJobRuns(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockStream, nil)
+	mockStream.EXPECT().Send(gomock.Any()).Return(nil)
+	mockStream.EXPECT().Recv().Return(&executorapi.LeaseStreamMessage{
+		Event: &executorapi.LeaseStreamMessage_End{
+			End: &executorapi.EndMarker{RetryAfter: 5 * time.Second},
+		},
+	}, nil)
+
+	response, err := jobRequester.LeaseJobRuns(ctx, &LeaseRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, response.LeasedRuns)
+	assert.Equal(t, 5*time.Second, response.RetryAfter)
+}
+
+func TestLeaseJobRuns_QuarantineNodes(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 30*time.Second)
+	defer cancel()
+
+	jobRequester, mockExecutorApiClient, mockStream := setup(t)
+	mockExecutorApiClient.EXPECT().LeaseJobRuns(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockStream, nil)
+	mockStream.EXPECT().Send(gomock.Any()).Return(nil)
+	gomock.InOrder(
+		mockStream.EXPECT().Recv().Return(&executorapi.LeaseStreamMessage{
+			Event: &executorapi.LeaseStreamMessage_QuarantineNodes{
+				QuarantineNodes: &executorapi.QuarantineNodes{
+					NodeNames: []string{"node-1", "node-2"},
+					TaintKey:  "armadaproject.io/quarantined",
+				},
+			},
+		}, nil),
+		mockStream.EXPECT().Recv().Return(endMarker, nil),
+	)
+
+	response, err := jobRequester.LeaseJobRuns(ctx, &LeaseRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"node-1", "node-2"}, response.QuarantinedNodeNames)
+	assert.Equal(t, "armadaproject.io/quarantined", response.QuarantineTaintKey)
+}
+
+func TestLeaseJobRuns_NodeDeltas(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 30*time.Second)
+	defer cancel()
+	jobRequester, mockExecutorApiClient, mockStream := setup(t)
+	jobRequester.EnableNodeDeltas()
+	mockExecutorApiClient.EXPECT().LeaseJobRuns(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockStream, nil).AnyTimes()
+
+	// leaseJobRuns makes a lease request for nodes, to which the scheduler responds with acceptsNodeDeltas,
+	// and returns the request sent.
+	leaseJobRuns := func(nodes []*api.NodeInfo, acceptsNodeDeltas bool) *executorapi.LeaseRequest {
+		var sent *executorapi.LeaseRequest
+		mockStream.EXPECT().Send(gomock.Any()).Do(func(req *executorapi.LeaseRequest) { sent = req }).Return(nil)
+		mockStream.EXPECT().Recv().Return(&executorapi.LeaseStreamMessage{
+			Event: &executorapi.LeaseStreamMessage_End{
+				End: &executorapi.EndMarker{AcceptsNodeDeltas: acceptsNodeDeltas},
+			},
+		}, nil)
+		_, err := jobRequester.LeaseJobRuns(ctx, &LeaseRequest{Nodes: nodes})
+		assert.NoError(t, err)
+		return sent
+	}
+	nodeA := &api.NodeInfo{Name: "node-a"}
+	nodeB := &api.NodeInfo{Name: "node-b", RunIdsByState: map[string]api.JobState{"id1": api.JobState_RUNNING}}
+	changedNodeB := &api.NodeInfo{Name: "node-b", RunIdsByState: map[string]api.JobState{"id1": api.JobState_SUCCEEDED}}
+	nodeC := &api.NodeInfo{Name: "node-c"}
+	nodesHash := func(nodes ...*api.NodeInfo) []byte {
+		nodeInfoHashByName := make(map[string][]byte)
+		for _, node := range nodes {
+			nodeInfoHashByName[node.Name] = executorapi.NodeInfoHash(node)
+		}
+		return executorapi.NodesHash(nodeInfoHashByName)
+	}
+
+	// All nodes are reported until the scheduler accepts them.
+	req := leaseJobRuns([]*api.NodeInfo{nodeA, nodeB}, false)
+	assert.False(t, req.NodesDelta)
+	assert.Equal(t, []*api.NodeInfo{nodeA, nodeB}, req.Nodes)
+	req = leaseJobRuns([]*api.NodeInfo{nodeA, nodeB}, true)
+	assert.False(t, req.NodesDelta)
+
+	// Only changes are reported from then on.
+	req = leaseJobRuns([]*api.NodeInfo{nodeA, changedNodeB, nodeC}, true)
+	assert.True(t, req.NodesDelta)
+	assert.Equal(t, []*api.NodeInfo{changedNodeB, nodeC}, req.Nodes)
+	assert.Empty(t, req.RemovedNodeNames)
+	assert.Equal(t, nodesHash(nodeA, changedNodeB, nodeC), req.NodesHash)
+	req = leaseJobRuns([]*api.NodeInfo{changedNodeB, nodeC}, false)
+	assert.True(t, req.NodesDelta)
+	assert.Empty(t, req.Nodes)
+	assert.Equal(t, []string{"node-a"}, req.RemovedNodeNames)
+	assert.Equal(t, nodesHash(changedNodeB, nodeC), req.NodesHash)
+
+	// Since the scheduler didn't accept the last delta, e.g., since it lost track of the nodes, all nodes are reported.
+	req = leaseJobRuns([]*api.NodeInfo{changedNodeB, nodeC}, true)
+	assert.False(t, req.NodesDelta)
+	assert.Equal(t, []*api.NodeInfo{changedNodeB, nodeC}, req.Nodes)
+	assert.Nil(t, req.NodesHash)
+}
+
+func TestLeaseJobRuns_RequestIds(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 30*time.Second)
+	defer cancel()
+	jobRequester, mockExecutorApiClient, mockStream := setup(t)
+	mockExecutorApiClient.EXPECT().LeaseJobRuns(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockStream, nil).AnyTimes()
+
+	// leaseJobRuns makes a lease request, to which the scheduler responds if respond is true, and returns its id.
+	leaseJobRuns := func(respond bool) uint64 {
+		var sent *executorapi.LeaseRequest
+		mockStream.EXPECT().Send(gomock.Any()).Do(func(req *executorapi.LeaseRequest) { sent = req }).Return(nil)
+		if respond {
+			mockStream.EXPECT().Recv().Return(endMarker, nil)
+		} else {
+			mockStream.EXPECT().Recv().Return(nil, fmt.Errorf("recv error"))
+		}
+		_, err := jobRequester.LeaseJobRuns(ctx, &LeaseRequest{})
+		assert.Equal(t, !respond, err != nil)
+		return sent.RequestId
+	}
+
+	firstId := leaseJobRuns(true)
+	assert.NotZero(t, firstId)
+	assert.Equal(t, firstId+1, leaseJobRuns(false))
+	// Requests no response was received to are retried with the same id.
+	assert.Equal(t, firstId+1, leaseJobRuns(false))
+	assert.Equal(t, firstId+1, leaseJobRuns(true))
+	assert.Equal(t, firstId+2, leaseJobRuns(true))
+}
+
 func TestLeaseJobRuns_HandlesNoEndMarkerMessage(t *testing.T) {
 	leaseMessages := []*executorapi.JobRunLease{lease1, lease2}
 	shortCtx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 200*time.Millisecond)
@@ -133,6 +133,9 @@ func (c *InstructionConverter) convertSequence(
 		case *armadaevents.EventSequence_Event_ResourceUtilisation:
 		case *armadaevents.EventSequence_Event_StandaloneIngressInfo:
 		case *armadaevents.EventSequence_Event_PartitionMarker:
+		case *armadaevents.EventSequence_Event_JobValidated:
+		case *armadaevents.EventSequence_Event_JobRetriesExhausted:
+		case *armadaevents.EventSequence_Event_JobRunCancelled:
 			log.Debugf("Ignoring event type %T", event.GetEvent())
 		default:
 			log.Warnf("Ignoring unknown event type %T", event.GetEvent())
@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// Reasons for which queued jobs are cancelled or failed, as reported by the abandoned queued jobs metric.
+const (
+	abandonedReasonQueueTtl          = "queueTtl"
+	abandonedReasonQueueDoesNotExist = "queueDoesNotExist"
+)
+
+// Histories of abandoned queued jobs, as reported by the abandoned queued jobs metric.
+const (
+	// The job was never leased.
+	abandonedHistoryNeverScheduled = "neverScheduled"
+	// The job was leased, its runs were returned or preempted, and it was requeued but not scheduled again.
+	abandonedHistoryRetriedAndAbandoned = "retriedAndAbandoned"
+)
+
+// abandonedQueuedJobMessage returns message, which explains why queued job is being cancelled or failed for reason,
+// extended to state whether the job was ever scheduled. The job is reported to the abandoned queued jobs metric.
+func (s *Scheduler) abandonedQueuedJobMessage(job *jobdb.Job, reason string, message string) string {
+	if job.EverLeased() {
+		s.metrics.ReportAbandonedQueuedJob(reason, abandonedHistoryRetriedAndAbandoned)
+		return message + "; the job was scheduled before, but wasn't scheduled again after being requeued"
+	}
+	s.metrics.ReportAbandonedQueuedJob(reason, abandonedHistoryNeverScheduled)
+	return message + "; the job was never scheduled"
+}
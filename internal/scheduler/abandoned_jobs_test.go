@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_AbandonedQueuedJobMessages(t *testing.T) {
+	tests := map[string]struct {
+		// Queue of the jobs.
+		queue string
+		// Returns the message with which each job is cancelled or failed, by job id.
+		abandon func(t *testing.T, sched *Scheduler, txn *jobdb.Txn) map[string]string
+		// Expected messages for the job that was never leased and the job that was returned and requeued.
+		expectedNeverLeasedMessage string
+		expectedReturnedMessage    string
+		// Reason reported to the abandoned queued jobs metric.
+		reason string
+	}{
+		"expired queue ttl": {
+			queue: "testQueue",
+			abandon: func(t *testing.T, sched *Scheduler, txn *jobdb.Txn) map[string]string {
+				eventSequences, err := sched.cancelQueuedJobsIfExpired(txn)
+				require.NoError(t, err)
+				messages := make(map[string]string)
+				for _, eventSequence := range eventSequences {
+					for _, event := range eventSequence.Events {
+						if cancelledJob := event.GetCancelledJob(); cancelledJob != nil {
+							messages[ulidStringFromProtoUuid(t, cancelledJob.JobId)] = cancelledJob.Reason
+						}
+					}
+				}
+				return messages
+			},
+			expectedNeverLeasedMessage: "Expired queue ttl; the job was never scheduled",
+			expectedReturnedMessage:    "Expired queue ttl; the job was scheduled before, but wasn't scheduled again after being requeued",
+			reason:                     abandonedReasonQueueTtl,
+		},
+		"queue does not exist": {
+			queue: "deletedQueue",
+			abandon: func(t *testing.T, sched *Scheduler, txn *jobdb.Txn) map[string]string {
+				sched.EnableUnknownQueueHandling(
+					schedulerconfig.UnknownQueuesConfig{Policy: schedulerconfig.UnknownQueuePolicyFail},
+					&testQueueRepository{queues: []*database.Queue{{Name: "testQueue", Weight: 1}}},
+				)
+				eventSequences, err := sched.handleJobsInUnknownQueues(armadacontext.Background(), txn)
+				require.NoError(t, err)
+				messages := make(map[string]string)
+				for _, eventSequence := range eventSequences {
+					for _, event := range eventSequence.Events {
+						jobErrors := event.GetJobErrors()
+						require.NotNil(t, jobErrors)
+						messages[ulidStringFromProtoUuid(t, jobErrors.JobId)] = jobErrors.Errors[0].GetQueueDoesNotExist().GetMessage()
+					}
+				}
+				return messages
+			},
+			expectedNeverLeasedMessage: "Queue deletedQueue does not exist; the job was never scheduled",
+			expectedReturnedMessage:    "Queue deletedQueue does not exist; the job was scheduled before, but wasn't scheduled again after being requeued",
+			reason:                     abandonedReasonQueueDoesNotExist,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			newQueuedJob := func() *jobdb.Job {
+				return testfixtures.JobDb.NewJob(
+					util.NewULID(), "testJobset", tc.queue, 0, schedulingInfoWithQueueTtl, true, 0, false, false, false, 1,
+				)
+			}
+			neverLeasedJob := newQueuedJob()
+			returnedJob := newQueuedJob().WithNewRun("testExecutor", "test-node", "node", 0, now.Add(-time.Minute))
+			returnedJob = returnedJob.
+				WithUpdatedRun(returnedJob.LatestRun().WithFailed(true).WithReturned(true).WithAttempted(true)).
+				WithQueued(true).
+				WithQueuedVersion(2).
+				WithQueuedSince(now.Add(-30 * time.Second))
+			require.False(t, neverLeasedJob.EverLeased())
+			require.True(t, returnedJob.EverLeased())
+
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				nil,
+				nil,
+				nil,
+				NewStandaloneLeaderController(),
+				nil,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = clock.NewFakeClock(now)
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{neverLeasedJob, returnedJob}))
+
+			neverScheduledCount := testutil.ToFloat64(schedulerMetrics.abandonedQueuedJobs.WithLabelValues(tc.reason, abandonedHistoryNeverScheduled))
+			retriedCount := testutil.ToFloat64(schedulerMetrics.abandonedQueuedJobs.WithLabelValues(tc.reason, abandonedHistoryRetriedAndAbandoned))
+			messages := tc.abandon(t, sched, txn)
+			assert.Equal(
+				t,
+				map[string]string{
+					neverLeasedJob.Id(): tc.expectedNeverLeasedMessage,
+					returnedJob.Id():    tc.expectedReturnedMessage,
+				},
+				messages,
+			)
+			assert.Equal(t, neverScheduledCount+1, testutil.ToFloat64(schedulerMetrics.abandonedQueuedJobs.WithLabelValues(tc.reason, abandonedHistoryNeverScheduled)))
+			assert.Equal(t, retriedCount+1, testutil.ToFloat64(schedulerMetrics.abandonedQueuedJobs.WithLabelValues(tc.reason, abandonedHistoryRetriedAndAbandoned)))
+		})
+	}
+}
+
+func ulidStringFromProtoUuid(t *testing.T, id *armadaevents.Uuid) string {
+	jobId, err := armadaevents.UlidStringFromProtoUuid(id)
+	require.NoError(t, err)
+	return jobId
+}
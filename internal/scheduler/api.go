@@ -1,21 +1,30 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"hash/fnv"
 	"strings"
+	"time"
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
 	"github.com/armadaproject/armada/internal/common/compress"
 	"github.com/armadaproject/armada/internal/common/logging"
 	"github.com/armadaproject/armada/internal/common/pulsarutils"
 	"github.com/armadaproject/armada/internal/common/schedulers"
+	armadaslices "github.com/armadaproject/armada/internal/common/slices"
 	"github.com/armadaproject/armada/internal/common/util"
 	"github.com/armadaproject/armada/internal/scheduler/database"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
@@ -42,7 +51,38 @@ type ExecutorApi struct {
 	nodeIdLabel string
 	// See scheduling schedulingConfig.
 	priorityClassNameOverride *string
-	clock                     clock.Clock
+	// Tolerations added to the pods of jobs in each queue, except for those they already have.
+	defaultTolerationsByQueue map[string][]v1.Toleration
+	// If non-nil, new leases are held back while the scheduler is catching up after becoming leader.
+	catchUpState *CatchUpState
+	// Sent to executors while the scheduler is catching up to indicate when they should request leases again.
+	catchUpRetryAfter time.Duration
+	// If true, resource usage reported by executors for their runs is stored to make it available to the scheduler.
+	storeRunResourceUsage bool
+	// If positive, at most this many new leases are sent to an executor per lease request.
+	maxLeasesPerRequest uint
+	// If non-nil, executors may report only the nodes changed since their previous request, which are applied here.
+	nodeDeltaRepository database.ExecutorNodeDeltaRepository
+	// Used to report the number of leases yet to be sent to each executor and executors asked to report all of their
+	// nodes instead of a delta. May be nil.
+	metrics *SchedulerMetrics
+	// If non-nil, executors are told which of their nodes are quarantined here.
+	nodeQuarantine *NodeQuarantine
+	// If non-nil, the leader decides which runs to lease and cancel from the latest snapshot stored here.
+	jobDbLeaseSnapshots *JobDbLeaseSnapshots
+	// Used to determine whether this replica is the leader that took the latest snapshot.
+	leaderController LeaderController
+	// Snapshots older than this aren't served from.
+	jobDbLeasesMaxStaleness time.Duration
+	// If non-nil, responses to lease requests are remembered here, such that repeated requests aren't served again.
+	leaseResponses *responseCache[[]*executorapi.LeaseStreamMessage]
+	// If non-nil, event reports published are remembered here, such that repeated reports aren't published again.
+	reportedEvents *responseCache[struct{}]
+	// If true, node ids are created with api.LengthPrefixedNodeIdFromExecutorAndNodeName.
+	lengthPrefixedNodeIds bool
+	// If non-nil, the duration, payload sizes, and errors of requests are recorded here.
+	requestMetrics *ExecutorApiMetrics
+	clock          clock.Clock
 }
 
 func NewExecutorApi(producer pulsar.Producer,
@@ -70,42 +110,130 @@ func NewExecutorApi(producer pulsar.Producer,
 	}, nil
 }
 
+// EnableCatchUpBackPressure causes new leases to be held back while state indicates the scheduler is catching up.
+// In the meantime, executors are told to retry after retryAfter and only runs known to be terminal are cancelled.
+func (srv *ExecutorApi) EnableCatchUpBackPressure(state *CatchUpState, retryAfter time.Duration) {
+	srv.catchUpState = state
+	srv.catchUpRetryAfter = retryAfter
+}
+
+// EnableRunResourceUsage causes the resource usage executors report for their runs to be stored,
+// such that the scheduler can take it into account when selecting preemption victims.
+func (srv *ExecutorApi) EnableRunResourceUsage() {
+	srv.storeRunResourceUsage = true
+}
+
+// EnableLengthPrefixedNodeIds causes the ids of nodes reported by executors to be created with
+// api.LengthPrefixedNodeIdFromExecutorAndNodeName, such that distinct nodes never share an id.
+func (srv *ExecutorApi) EnableLengthPrefixedNodeIds() {
+	srv.lengthPrefixedNodeIds = true
+}
+
+// EnableNodeQuarantine causes executors to be sent the names of their nodes quarantined by q with each lease response,
+// such that they can taint them. Executors are sent the full set of quarantined nodes each time,
+// such that they can also remove the taint from nodes that have been released.
+func (srv *ExecutorApi) EnableNodeQuarantine(q *NodeQuarantine) {
+	srv.nodeQuarantine = q
+}
+
+// EnableQueueDefaultTolerations causes the tolerations of each queue in tolerationsByQueue to be added to the pods
+// of jobs in that queue when they're leased, except for tolerations the pods already have.
+// The submit messages stored for jobs are left unchanged.
+func (srv *ExecutorApi) EnableQueueDefaultTolerations(tolerationsByQueue map[string][]v1.Toleration) {
+	srv.defaultTolerationsByQueue = tolerationsByQueue
+}
+
 // LeaseJobRuns reconciles the state of the executor with that of the scheduler. Specifically it:
 // 1. Stores job and capacity information received from the executor to make it available to the scheduler.
 // 2. Notifies the executor if any of its jobs are no longer active, e.g., due to being preempted by the scheduler.
 // 3. Transfers any jobs scheduled on this executor cluster that the executor don't already have.
-func (srv *ExecutorApi) LeaseJobRuns(stream executorapi.ExecutorApi_LeaseJobRunsServer) error {
+func (srv *ExecutorApi) LeaseJobRuns(stream executorapi.ExecutorApi_LeaseJobRunsServer) (err error) {
+	if srv.requestMetrics != nil {
+		recordingStream := &sizeRecordingLeaseStream{ExecutorApi_LeaseJobRunsServer: stream}
+		stream = recordingStream
+		defer func(start time.Time) {
+			srv.requestMetrics.observe(
+				"LeaseJobRuns", recordingStream.executorId, srv.clock.Since(start),
+				recordingStream.requestBytes, recordingStream.responseBytes, err,
+			)
+		}(srv.clock.Now())
+	}
+
 	// Receive once to get info necessary to get jobs to lease.
 	req, err := stream.Recv()
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	ctx := armadacontext.WithLogField(armadacontext.FromGrpcCtx(stream.Context()), "executor", req.ExecutorId)
-
-	executor := srv.executorFromLeaseRequest(ctx, req)
-	if err := srv.executorRepository.StoreExecutor(ctx, executor); err != nil {
+	if err := api.ValidateExecutorId(req.ExecutorId); err != nil {
 		return err
 	}
-	if err = srv.legacyExecutorRepository.StoreExecutor(ctx, executor); err != nil {
-		return err
+
+	ctx := armadacontext.WithLogField(armadacontext.FromGrpcCtx(stream.Context()), "executor", req.ExecutorId)
+	if srv.leaseResponses != nil {
+		return srv.leaseJobRunsDeduplicated(ctx, stream, req)
 	}
+	return srv.leaseJobRuns(ctx, stream, req)
+}
 
-	requestRuns, err := runIdsFromLeaseRequest(req)
+func (srv *ExecutorApi) leaseJobRuns(ctx *armadacontext.Context, stream executorapi.ExecutorApi_LeaseJobRunsServer, req *executorapi.LeaseRequest) error {
+	requestRuns, ok, err := srv.storeExecutor(ctx, req)
 	if err != nil {
 		return err
 	}
-	runsToCancel, err := srv.jobRepository.FindInactiveRuns(ctx, requestRuns)
-	if err != nil {
-		return err
+	if !ok {
+		// Without knowing all nodes of the executor, we don't know which runs it has; hence, we send no leases.
+		return sendEndMarkerOnly(stream)
 	}
-	newRuns, err := srv.jobRepository.FetchJobRunLeases(ctx, req.ExecutorId, uint(req.MaxJobsToLease), requestRuns)
-	if err != nil {
-		return err
+
+	var runsToCancel []uuid.UUID
+	var newRuns []*database.JobRunLease
+	var updatedRuns []*database.JobRunLease
+	retryAfter := time.Duration(0)
+	if srv.catchUpState != nil && srv.catchUpState.CatchingUp() {
+		// Lease sets may be stale or incomplete while the scheduler is catching up.
+		// Hence, we only cancel runs that are known to be terminal and don't send any new runs.
+		ctx.Infof("scheduler is catching up; deferring new leases for %s", srv.catchUpRetryAfter)
+		runsToCancel, err = srv.jobRepository.FindTerminalRuns(ctx, requestRuns)
+		if err != nil {
+			return err
+		}
+		retryAfter = srv.catchUpRetryAfter
+	} else {
+		maxLeases := srv.maxLeasesToSend(uint(req.MaxJobsToLease))
+		if snapshot := srv.servableJobDbLeaseSnapshot(); snapshot != nil {
+			// On the leader, the jobDb is more up-to-date than postgres.
+			runsToCancel, err = srv.findInactiveRunsInSnapshot(ctx, snapshot, requestRuns)
+			if err != nil {
+				return err
+			}
+			newRuns, err = srv.fetchJobRunLeasesInSnapshot(ctx, snapshot, req.ExecutorId, maxLeases, requestRuns)
+			if err != nil {
+				return err
+			}
+		} else {
+			runsToCancel, err = srv.jobRepository.FindInactiveRuns(ctx, requestRuns)
+			if err != nil {
+				return err
+			}
+			newRuns, err = srv.jobRepository.FetchJobRunLeases(ctx, req.ExecutorId, maxLeases, requestRuns)
+			if err != nil {
+				return err
+			}
+		}
+		if srv.maxLeasesPerRequest > 0 {
+			srv.reportPendingLeases(ctx, req.ExecutorId, requestRuns, newRuns, maxLeases)
+		}
+		if len(req.JobRunSpecHashes) > 0 {
+			updatedRuns, err = srv.fetchLeasesWithOutdatedSpecs(ctx, req, requestRuns)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	ctx.Infof(
-		"executor currently has %d job runs; sending %d cancellations and %d new runs",
-		len(requestRuns), len(runsToCancel), len(newRuns),
+		"executor currently has %d job runs; sending %d cancellations, %d new runs, and %d runs with updated specs",
+		len(requestRuns), len(runsToCancel), len(newRuns), len(updatedRuns),
 	)
 
 	// Send any runs that should be cancelled.
@@ -123,38 +251,24 @@ func (srv *ExecutorApi) LeaseJobRuns(stream executorapi.ExecutorApi_LeaseJobRuns
 		}
 	}
 
-	// Send any scheduled jobs the executor doesn't already have.
+	// Send any scheduled jobs the executor doesn't already have, followed by any it has an outdated spec for.
 	decompressor := compress.NewZlibDecompressor()
-	for _, lease := range newRuns {
-		submitMsg := &armadaevents.SubmitJob{}
-		if err := unmarshalFromCompressedBytes(lease.SubmitMessage, decompressor, submitMsg); err != nil {
+	for _, lease := range append(newRuns, updatedRuns...) {
+		if err := srv.sendLease(stream, lease, decompressor); err != nil {
 			return err
 		}
-		if srv.priorityClassNameOverride != nil {
-			srv.setPriorityClassName(submitMsg, *srv.priorityClassNameOverride)
-		}
-		srv.addNodeIdSelector(submitMsg, lease.Node)
+	}
 
-		var groups []string
-		if len(lease.Groups) > 0 {
-			groups, err = compress.DecompressStringArray(lease.Groups, decompressor)
-			if err != nil {
-				return err
-			}
-		}
-		err := stream.Send(&executorapi.LeaseStreamMessage{
-			Event: &executorapi.LeaseStreamMessage_Lease{
-				Lease: &executorapi.JobRunLease{
-					JobRunId: armadaevents.ProtoUuidFromUuid(lease.RunID),
-					Queue:    lease.Queue,
-					Jobset:   lease.JobSet,
-					User:     lease.UserID,
-					Groups:   groups,
-					Job:      submitMsg,
+	// Send the executor's quarantined nodes.
+	if srv.nodeQuarantine != nil {
+		if err := stream.Send(&executorapi.LeaseStreamMessage{
+			Event: &executorapi.LeaseStreamMessage_QuarantineNodes{
+				QuarantineNodes: &executorapi.QuarantineNodes{
+					NodeNames: srv.nodeQuarantine.QuarantinedNodes(req.ExecutorId),
+					TaintKey:  srv.nodeQuarantine.TaintKey(),
 				},
 			},
-		})
-		if err != nil {
+		}); err != nil {
 			return errors.WithStack(err)
 		}
 	}
@@ -162,7 +276,111 @@ func (srv *ExecutorApi) LeaseJobRuns(stream executorapi.ExecutorApi_LeaseJobRuns
 	// Finally, send an end marker
 	err = stream.Send(&executorapi.LeaseStreamMessage{
 		Event: &executorapi.LeaseStreamMessage_End{
-			End: &executorapi.EndMarker{},
+			End: &executorapi.EndMarker{RetryAfter: retryAfter, AcceptsNodeDeltas: srv.nodeDeltaRepository != nil},
+		},
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// storeExecutor stores the state of the executor that made req, along with the resource usage of its runs,
+// and returns the ids of the runs it holds. Returns false if the delta of nodes in req can't be applied, in which case
+// nothing is stored.
+func (srv *ExecutorApi) storeExecutor(ctx *armadacontext.Context, req *executorapi.LeaseRequest) ([]uuid.UUID, bool, error) {
+	var requestRuns []uuid.UUID
+	if req.NodesDelta {
+		executor, err := srv.storeExecutorNodeDelta(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+		if executor == nil {
+			return nil, false, nil
+		}
+		if requestRuns, err = runIdsFromExecutor(executor); err != nil {
+			return nil, false, err
+		}
+	} else {
+		executor := srv.executorFromLeaseRequest(ctx, req)
+		if err := srv.executorRepository.StoreExecutor(ctx, executor); err != nil {
+			return nil, false, err
+		}
+		if err := srv.legacyExecutorRepository.StoreExecutor(ctx, executor); err != nil {
+			return nil, false, err
+		}
+		var err error
+		if requestRuns, err = runIdsFromLeaseRequest(req); err != nil {
+			return nil, false, err
+		}
+	}
+	if srv.storeRunResourceUsage {
+		// Usage is advisory; failing to store it shouldn't prevent the executor from receiving leases.
+		if err := srv.jobRepository.StoreJobRunResourceUsage(ctx, runResourceUsageFromLeaseRequest(req)); err != nil {
+			logging.WithStacktrace(ctx, err).Warnf("failed to store run resource usage")
+		}
+	}
+	return requestRuns, true, nil
+}
+
+// sendEndMarkerOnly ends the response to a lease request without sending any leases.
+func sendEndMarkerOnly(stream executorapi.ExecutorApi_LeaseJobRunsServer) error {
+	return errors.WithStack(stream.Send(&executorapi.LeaseStreamMessage{
+		Event: &executorapi.LeaseStreamMessage_End{End: &executorapi.EndMarker{}},
+	}))
+}
+
+// runResourceUsageFromLeaseRequest returns the run resource usage reported in req, skipping samples without a run id.
+func runResourceUsageFromLeaseRequest(req *executorapi.LeaseRequest) []database.JobRunResourceUsage {
+	usage := make([]database.JobRunResourceUsage, 0, len(req.JobRunResourceUsage))
+	for _, u := range req.JobRunResourceUsage {
+		if u.JobRunId == nil {
+			continue
+		}
+		usage = append(usage, database.JobRunResourceUsage{
+			RunID:           armadaevents.UuidFromProtoUuid(u.JobRunId),
+			CpuSeconds:      u.CpuSeconds,
+			WorkingSetBytes: u.WorkingSetBytes,
+		})
+	}
+	return usage
+}
+
+// sendLease sends lease to the executor, along with the hash of its spec.
+func (srv *ExecutorApi) sendLease(stream executorapi.ExecutorApi_LeaseJobRunsServer, lease *database.JobRunLease, decompressor compress.Decompressor) error {
+	submitMsg := &armadaevents.SubmitJob{}
+	if err := unmarshalFromCompressedBytes(lease.SubmitMessage, decompressor, submitMsg); err != nil {
+		return err
+	}
+	update, err := podSpecUpdateFromSchedulingInfo(lease.SchedulingInfo)
+	if err != nil {
+		return err
+	}
+	update.apply(submitMsg)
+	if srv.priorityClassNameOverride != nil {
+		srv.setPriorityClassName(submitMsg, *srv.priorityClassNameOverride)
+	}
+	srv.addNodeIdSelector(submitMsg, lease.Node)
+	srv.addQueueDefaultTolerations(submitMsg, lease.Queue)
+
+	var groups []string
+	if len(lease.Groups) > 0 {
+		groups, err = compress.DecompressStringArray(lease.Groups, decompressor)
+		if err != nil {
+			return err
+		}
+	}
+	err = stream.Send(&executorapi.LeaseStreamMessage{
+		Event: &executorapi.LeaseStreamMessage_Lease{
+			Lease: &executorapi.JobRunLease{
+				JobRunId: armadaevents.ProtoUuidFromUuid(lease.RunID),
+				Queue:    lease.Queue,
+				Jobset:   lease.JobSet,
+				User:     lease.UserID,
+				Groups:   groups,
+				Job:      submitMsg,
+				SpecHash: srv.jobRunSpecHash(lease.RunID, lease.Node, update),
+			},
 		},
 	})
 	if err != nil {
@@ -171,6 +389,113 @@ func (srv *ExecutorApi) LeaseJobRuns(stream executorapi.ExecutorApi_LeaseJobRuns
 	return nil
 }
 
+// fetchLeasesWithOutdatedSpecs returns the leases of the active runs among requestRuns for which the executor provided
+// a spec hash different from that of the current spec of the run. Runs for which the executor provided no hash,
+// e.g., since it recovered them from kubernetes, aren't re-sent, since the executor can't update their spec anyway.
+func (srv *ExecutorApi) fetchLeasesWithOutdatedSpecs(ctx *armadacontext.Context, req *executorapi.LeaseRequest, requestRuns []uuid.UUID) ([]*database.JobRunLease, error) {
+	specHashByRunId := make(map[uuid.UUID][]byte, len(req.JobRunSpecHashes))
+	for _, specHash := range req.JobRunSpecHashes {
+		if specHash.JobRunId == nil {
+			continue
+		}
+		specHashByRunId[armadaevents.UuidFromProtoUuid(specHash.JobRunId)] = specHash.SpecHash
+	}
+	runsWithSpecHash := armadaslices.Filter(requestRuns, func(runId uuid.UUID) bool {
+		_, ok := specHashByRunId[runId]
+		return ok
+	})
+	if len(runsWithSpecHash) == 0 {
+		return nil, nil
+	}
+	specVersions, err := srv.jobRepository.FetchJobRunSpecVersions(ctx, req.ExecutorId, runsWithSpecHash)
+	if err != nil {
+		return nil, err
+	}
+	var outdatedRunIds []uuid.UUID
+	for _, specVersion := range specVersions {
+		update, err := podSpecUpdateFromSchedulingInfo(specVersion.SchedulingInfo)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(specHashByRunId[specVersion.RunID], srv.jobRunSpecHash(specVersion.RunID, specVersion.Node, update)) {
+			outdatedRunIds = append(outdatedRunIds, specVersion.RunID)
+		}
+	}
+	if len(outdatedRunIds) == 0 {
+		return nil, nil
+	}
+	return srv.jobRepository.FetchJobRunLeasesByRunId(ctx, req.ExecutorId, outdatedRunIds)
+}
+
+// jobRunSpecHash returns a hash of the spec sent to executors for the run with the provided id.
+// The submit message of a job never changes, so the spec of a run only changes if the run is assigned to another node,
+// the fields of the scheduling info of its job applied to the pod spec change, or the priority class name override
+// changes; other scheduling info updates, e.g., reprioritisation, leave the hash unchanged.
+func (srv *ExecutorApi) jobRunSpecHash(runId uuid.UUID, node string, update *podSpecUpdate) []byte {
+	h := fnv.New64a()
+	h.Write(runId[:])
+	h.Write([]byte(node))
+	h.Write([]byte{0})
+	if update != nil {
+		// encoding/json sorts map keys, so equal updates are always encoded identically.
+		encodedUpdate, err := json.Marshal(update)
+		if err == nil {
+			h.Write(encodedUpdate)
+		}
+	}
+	h.Write([]byte{0})
+	if srv.priorityClassNameOverride != nil {
+		h.Write([]byte(*srv.priorityClassNameOverride))
+	}
+	return h.Sum(nil)
+}
+
+// podSpecUpdate contains the fields of the pod spec of a job that may be updated after the job was submitted,
+// as given by the most recent scheduling info of the job.
+type podSpecUpdate struct {
+	PriorityClassName string
+	NodeSelector      map[string]string
+	Affinity          *v1.Affinity
+	Tolerations       []v1.Toleration
+}
+
+// podSpecUpdateFromSchedulingInfo returns the pod spec update given by the provided serialised scheduling info,
+// or nil if no scheduling info is provided. The scheduling info of a job is derived from its pod spec on submission,
+// so applying the update to the submitted pod spec is a no-op unless the scheduling info has since been updated.
+func podSpecUpdateFromSchedulingInfo(schedulingInfoBytes []byte) (*podSpecUpdate, error) {
+	if len(schedulingInfoBytes) == 0 {
+		return nil, nil
+	}
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{}
+	if err := proto.Unmarshal(schedulingInfoBytes, schedulingInfo); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	update := &podSpecUpdate{PriorityClassName: schedulingInfo.PriorityClassName}
+	if req := schedulingInfo.GetPodRequirements(); req != nil {
+		update.NodeSelector = req.NodeSelector
+		update.Affinity = req.Affinity
+		update.Tolerations = req.Tolerations
+	}
+	return update, nil
+}
+
+// apply replaces the corresponding fields of the pod spec of job with those of the update.
+func (update *podSpecUpdate) apply(job *armadaevents.SubmitJob) {
+	if update == nil || job == nil || job.MainObject == nil {
+		return
+	}
+	podSpec, ok := job.MainObject.Object.(*armadaevents.KubernetesMainObject_PodSpec)
+	if !ok || podSpec.PodSpec == nil || podSpec.PodSpec.PodSpec == nil {
+		return
+	}
+	if update.PriorityClassName != "" {
+		podSpec.PodSpec.PodSpec.PriorityClassName = update.PriorityClassName
+	}
+	podSpec.PodSpec.PodSpec.NodeSelector = maps.Clone(update.NodeSelector)
+	podSpec.PodSpec.PodSpec.Affinity = update.Affinity
+	podSpec.PodSpec.PodSpec.Tolerations = slices.Clone(update.Tolerations)
+}
+
 func (srv *ExecutorApi) setPriorityClassName(job *armadaevents.SubmitJob, priorityClassName string) {
 	if job == nil {
 		return
@@ -195,6 +520,30 @@ func (srv *ExecutorApi) addNodeIdSelector(job *armadaevents.SubmitJob, nodeId st
 	}
 }
 
+func (srv *ExecutorApi) addQueueDefaultTolerations(job *armadaevents.SubmitJob, queue string) {
+	tolerations := srv.defaultTolerationsByQueue[queue]
+	if job == nil || len(tolerations) == 0 {
+		return
+	}
+	if job.MainObject != nil {
+		switch typed := job.MainObject.Object.(type) {
+		case *armadaevents.KubernetesMainObject_PodSpec:
+			addTolerations(typed.PodSpec, tolerations)
+		}
+	}
+}
+
+// addTolerations adds those of tolerations to podSpec that it doesn't have already.
+func addTolerations(podSpec *armadaevents.PodSpecWithAvoidList, tolerations []v1.Toleration) {
+	if podSpec == nil || podSpec.PodSpec == nil {
+		return
+	}
+	podSpec.PodSpec.Tolerations = append(
+		podSpec.PodSpec.Tolerations,
+		schedulerobjects.MissingTolerations(podSpec.PodSpec.Tolerations, tolerations)...,
+	)
+}
+
 func addNodeSelector(podSpec *armadaevents.PodSpecWithAvoidList, key string, value string) {
 	if podSpec == nil || podSpec.PodSpec == nil || key == "" || value == "" {
 		return
@@ -214,9 +563,30 @@ func setPriorityClassName(podSpec *armadaevents.PodSpecWithAvoidList, priorityCl
 }
 
 // ReportEvents publishes all events to Pulsar. The events are compacted for more efficient publishing.
-func (srv *ExecutorApi) ReportEvents(grpcCtx context.Context, list *executorapi.EventList) (*types.Empty, error) {
+func (srv *ExecutorApi) ReportEvents(grpcCtx context.Context, list *executorapi.EventList) (response *types.Empty, err error) {
+	if srv.requestMetrics != nil {
+		defer func(start time.Time) {
+			srv.requestMetrics.observe(
+				"ReportEvents", authorization.GetPrincipal(grpcCtx).GetName(), srv.clock.Since(start),
+				list.Size(), response.Size(), err,
+			)
+		}(srv.clock.Now())
+	}
 	ctx := armadacontext.FromGrpcCtx(grpcCtx)
-	err := pulsarutils.CompactAndPublishSequences(ctx, list.Events, srv.producer, srv.maxPulsarMessageSizeBytes, schedulers.Pulsar)
+	key := ""
+	if srv.reportedEvents != nil {
+		if key, err = eventListKey(list); err != nil {
+			return nil, err
+		}
+		if _, ok := srv.reportedEvents.Get(key); ok {
+			ctx.Infof("event report repeated within %s; not publishing its events again", srv.reportedEvents.window)
+			return &types.Empty{}, nil
+		}
+	}
+	err = pulsarutils.CompactAndPublishSequences(ctx, list.Events, srv.producer, srv.maxPulsarMessageSizeBytes, schedulers.Pulsar)
+	if err == nil && srv.reportedEvents != nil {
+		srv.reportedEvents.Put(key, struct{}{})
+	}
 	return &types.Empty{}, err
 }
 
@@ -225,20 +595,34 @@ func (srv *ExecutorApi) executorFromLeaseRequest(ctx *armadacontext.Context, req
 	nodes := make([]*schedulerobjects.Node, 0, len(req.Nodes))
 	now := srv.clock.Now().UTC()
 	for _, nodeInfo := range req.Nodes {
-		if node, err := api.NewNodeFromNodeInfo(nodeInfo, req.ExecutorId, srv.allowedPriorities, now); err != nil {
+		var node *schedulerobjects.Node
+		err := api.ValidateNodeName(nodeInfo.GetName())
+		if err == nil {
+			node, err = api.NewNodeFromNodeInfo(nodeInfo, req.ExecutorId, srv.allowedPriorities, now)
+		}
+		if err != nil {
 			logging.WithStacktrace(ctx, err).Warnf(
 				"skipping node %s from executor %s", nodeInfo.GetName(), req.GetExecutorId(),
 			)
-		} else {
-			nodes = append(nodes, node)
+			continue
+		}
+		if srv.lengthPrefixedNodeIds {
+			node.Id = api.LengthPrefixedNodeIdFromExecutorAndNodeName(req.ExecutorId, node.Name)
+		}
+		if srv.nodeDeltaRepository != nil {
+			node.NodeInfoHash = executorapi.NodeInfoHash(nodeInfo)
 		}
+		nodes = append(nodes, node)
 	}
 	return &schedulerobjects.Executor{
 		Id:             req.ExecutorId,
 		Pool:           req.Pool,
 		Nodes:          nodes,
 		MinimumJobSize: schedulerobjects.ResourceList{Resources: req.MinimumJobSize},
-		LastUpdateTime: now,
+		// Staleness is decided based on when the scheduler received the request, regardless of the executor's clock.
+		LastUpdateTime:   now,
+		LastReportedTime: req.SentAt,
+		Timeout:          req.ExecutorTimeout,
 		UnassignedJobRuns: util.Map(req.UnassignedJobRunIds, func(jobId armadaevents.Uuid) string {
 			return strings.ToLower(armadaevents.UuidFromProtoUuid(&jobId).String())
 		}),
@@ -9,15 +9,20 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
 	"github.com/armadaproject/armada/internal/common/compress"
 	"github.com/armadaproject/armada/internal/common/mocks"
 	"github.com/armadaproject/armada/internal/common/pulsarutils"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	"github.com/armadaproject/armada/internal/scheduler/database"
 	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
@@ -35,6 +40,8 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 	runId2 := uuid.New()
 	runId3 := uuid.New()
 	groups, compressedGroups := groups(t)
+	// The clock of the executor is an hour ahead of that of the scheduler.
+	sentAt := testClock.Now().Add(time.Hour).UTC()
 	defaultRequest := &executorapi.LeaseRequest{
 		ExecutorId: "test-executor",
 		Pool:       "test-pool",
@@ -50,6 +57,7 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 		},
 		UnassignedJobRunIds: []armadaevents.Uuid{*armadaevents.ProtoUuidFromUuid(runId3)},
 		MaxJobsToLease:      uint32(maxJobsPerCall),
+		SentAt:              &sentAt,
 	}
 	defaultExpectedExecutor := &schedulerobjects.Executor{
 		Id:   "test-executor",
@@ -77,9 +85,16 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 		},
 		MinimumJobSize:    schedulerobjects.ResourceList{},
 		LastUpdateTime:    testClock.Now().UTC(),
+		LastReportedTime:  &sentAt,
 		UnassignedJobRuns: []string{runId3.String()},
 	}
 
+	requestWithUsage := *defaultRequest
+	requestWithUsage.JobRunResourceUsage = []*executorapi.JobRunResourceUsage{
+		{JobRunId: armadaevents.ProtoUuidFromUuid(runId1), CpuSeconds: 10, WorkingSetBytes: 1024},
+		{JobRunId: nil, CpuSeconds: 20, WorkingSetBytes: 2048},
+	}
+
 	submit, compressedSubmit := submitMsg(t, "node-id")
 	defaultLease := &database.JobRunLease{
 		RunID:         uuid.New(),
@@ -102,11 +117,14 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 	}
 
 	tests := map[string]struct {
-		request          *executorapi.LeaseRequest
-		runsToCancel     []uuid.UUID
-		leases           []*database.JobRunLease
-		expectedExecutor *schedulerobjects.Executor
-		expectedMsgs     []*executorapi.LeaseStreamMessage
+		request      *executorapi.LeaseRequest
+		runsToCancel []uuid.UUID
+		leases       []*database.JobRunLease
+		catchingUp   bool
+		// If non-nil, storing run resource usage is enabled and this usage is expected to be stored.
+		expectedRunResourceUsage []database.JobRunResourceUsage
+		expectedExecutor         *schedulerobjects.Executor
+		expectedMsgs             []*executorapi.LeaseStreamMessage
 	}{
 		"lease and cancel": {
 			request:          defaultRequest,
@@ -127,6 +145,7 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 						User:     defaultLease.UserID,
 						Groups:   groups,
 						Job:      submit,
+						SpecHash: (&ExecutorApi{}).jobRunSpecHash(defaultLease.RunID, defaultLease.Node, nil),
 					}},
 				},
 				{
@@ -147,8 +166,36 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 						User:     leaseWithoutNode.UserID,
 						Groups:   groups,
 						Job:      submitWithoutNodeSelector,
+						SpecHash: (&ExecutorApi{}).jobRunSpecHash(leaseWithoutNode.RunID, "", nil),
+					}},
+				},
+				{
+					Event: &executorapi.LeaseStreamMessage_End{End: &executorapi.EndMarker{}},
+				},
+			},
+		},
+		"catching up": {
+			request:          defaultRequest,
+			runsToCancel:     []uuid.UUID{runId2},
+			leases:           []*database.JobRunLease{defaultLease},
+			catchingUp:       true,
+			expectedExecutor: defaultExpectedExecutor,
+			expectedMsgs: []*executorapi.LeaseStreamMessage{
+				{
+					Event: &executorapi.LeaseStreamMessage_CancelRuns{CancelRuns: &executorapi.CancelRuns{
+						JobRunIdsToCancel: []*armadaevents.Uuid{armadaevents.ProtoUuidFromUuid(runId2)},
 					}},
 				},
+				{
+					Event: &executorapi.LeaseStreamMessage_End{End: &executorapi.EndMarker{RetryAfter: 5 * time.Second}},
+				},
+			},
+		},
+		"store run resource usage": {
+			request:                  &requestWithUsage,
+			expectedRunResourceUsage: []database.JobRunResourceUsage{{RunID: runId1, CpuSeconds: 10, WorkingSetBytes: 1024}},
+			expectedExecutor:         defaultExpectedExecutor,
+			expectedMsgs: []*executorapi.LeaseStreamMessage{
 				{
 					Event: &executorapi.LeaseStreamMessage_End{End: &executorapi.EndMarker{}},
 				},
@@ -188,8 +235,16 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 				assert.Equal(t, tc.expectedExecutor, executor)
 				return nil
 			}).Times(1)
-			mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), schedulermocks.SliceMatcher[uuid.UUID]{Expected: runIds}).Return(tc.runsToCancel, nil).Times(1)
-			mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), tc.request.ExecutorId, maxJobsPerCall, runIds).Return(tc.leases, nil).Times(1)
+			if tc.expectedRunResourceUsage != nil {
+				mockJobRepository.EXPECT().StoreJobRunResourceUsage(gomock.Any(), tc.expectedRunResourceUsage).Return(nil).Times(1)
+			}
+			if tc.catchingUp {
+				// Only terminal runs should be cancelled and no new leases should be fetched.
+				mockJobRepository.EXPECT().FindTerminalRuns(gomock.Any(), schedulermocks.SliceMatcher[uuid.UUID]{Expected: runIds}).Return(tc.runsToCancel, nil).Times(1)
+			} else {
+				mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), schedulermocks.SliceMatcher[uuid.UUID]{Expected: runIds}).Return(tc.runsToCancel, nil).Times(1)
+				mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), tc.request.ExecutorId, maxJobsPerCall, runIds).Return(tc.leases, nil).Times(1)
+			}
 
 			// capture all sent messages
 			var capturedEvents []*executorapi.LeaseStreamMessage
@@ -210,6 +265,12 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 			)
 			require.NoError(t, err)
 			server.clock = testClock
+			catchUpState := NewCatchUpState()
+			catchUpState.catchingUp.Store(tc.catchingUp)
+			server.EnableCatchUpBackPressure(catchUpState, 5*time.Second)
+			if tc.expectedRunResourceUsage != nil {
+				server.EnableRunResourceUsage()
+			}
 
 			err = server.LeaseJobRuns(mockStream)
 			require.NoError(t, err)
@@ -219,6 +280,374 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 	}
 }
 
+func TestExecutorApi_LeaseJobRuns_SpecsOnlySentOncePerRun(t *testing.T) {
+	const maxJobsPerCall = uint(100)
+	submit, compressedSubmit := submitMsg(t, "node-id")
+	// schedulingInfo returns the serialised scheduling info of the job with the provided in-queue priority and tolerations.
+	schedulingInfo := func(version uint32, priority uint32, tolerations []v1.Toleration) []byte {
+		info := &schedulerobjects.JobSchedulingInfo{
+			Version:  version,
+			Priority: priority,
+			ObjectRequirements: []*schedulerobjects.ObjectRequirements{{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{
+						NodeSelector: submit.GetMainObject().GetPodSpec().PodSpec.NodeSelector,
+						Tolerations:  tolerations,
+					},
+				},
+			}},
+		}
+		bytes, err := proto.Marshal(info)
+		require.NoError(t, err)
+		return bytes
+	}
+	lease := &database.JobRunLease{
+		RunID:          uuid.New(),
+		Queue:          "test-queue",
+		JobSet:         "test-jobset",
+		UserID:         "test-user",
+		Node:           "node-id",
+		SubmitMessage:  compressedSubmit,
+		SchedulingInfo: schedulingInfo(0, 1, nil),
+	}
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	server, err := NewExecutorApi(
+		mocks.NewMockProducer(ctrl),
+		mockJobRepository,
+		mockExecutorRepository,
+		mockExecutorRepository,
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+
+	// leaseJobRuns makes a lease request as an executor holding the provided runs, with the provided spec hashes,
+	// and returns the leases received.
+	leaseJobRuns := func(runIds []uuid.UUID, specHashes map[uuid.UUID][]byte) []*executorapi.JobRunLease {
+		request := &executorapi.LeaseRequest{
+			ExecutorId:     "test-executor",
+			Pool:           "test-pool",
+			MaxJobsToLease: uint32(maxJobsPerCall),
+		}
+		for _, runId := range runIds {
+			request.UnassignedJobRunIds = append(request.UnassignedJobRunIds, *armadaevents.ProtoUuidFromUuid(runId))
+			if specHash, ok := specHashes[runId]; ok {
+				request.JobRunSpecHashes = append(
+					request.JobRunSpecHashes,
+					&executorapi.JobRunSpecHash{JobRunId: armadaevents.ProtoUuidFromUuid(runId), SpecHash: specHash},
+				)
+			}
+		}
+		mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+		mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+		mockStream.EXPECT().Recv().Return(request, nil).Times(1)
+		var leases []*executorapi.JobRunLease
+		mockStream.EXPECT().Send(gomock.Any()).
+			Do(func(msg *executorapi.LeaseStreamMessage) {
+				if lease := msg.GetLease(); lease != nil {
+					leases = append(leases, lease)
+				}
+			}).AnyTimes()
+		require.NoError(t, server.LeaseJobRuns(mockStream))
+		return leases
+	}
+	heldRunIds := []uuid.UUID{lease.RunID}
+
+	// The first fetch carries the spec of the new run.
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), "test-executor", maxJobsPerCall, []uuid.UUID{}).Return([]*database.JobRunLease{lease}, nil).Times(1)
+	leases := leaseJobRuns(nil, nil)
+	require.Len(t, leases, 1)
+	require.NotNil(t, leases[0].Job)
+	assert.Equal(t, submit.GetMainObject().GetPodSpec().PodSpec, leases[0].Job.GetMainObject().GetPodSpec().PodSpec)
+	specHash := leases[0].SpecHash
+	require.NotEmpty(t, specHash)
+
+	// expectHeldRuns sets up the repository for a request from an executor holding the provided runs.
+	expectHeldRuns := func(specVersions ...database.JobRunSpecVersion) {
+		heldRunIds := util.Map(specVersions, func(v database.JobRunSpecVersion) uuid.UUID { return v.RunID })
+		mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), heldRunIds).Return(nil, nil).Times(1)
+		mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), "test-executor", maxJobsPerCall, heldRunIds).Return(nil, nil).Times(1)
+		mockJobRepository.EXPECT().FetchJobRunSpecVersions(gomock.Any(), "test-executor", heldRunIds).Return(specVersions, nil).Times(1)
+	}
+
+	// The second fetch carries no specs, since the executor already holds the current spec of the run.
+	expectHeldRuns(database.JobRunSpecVersion{RunID: lease.RunID, Node: lease.Node, SchedulingInfo: lease.SchedulingInfo})
+	leases = leaseJobRuns(heldRunIds, map[uuid.UUID][]byte{lease.RunID: specHash})
+	assert.Empty(t, leases)
+
+	// Reprioritising the job doesn't change its spec, so it isn't sent again.
+	reprioritisedSchedulingInfo := schedulingInfo(1, 2, nil)
+	expectHeldRuns(database.JobRunSpecVersion{RunID: lease.RunID, Node: lease.Node, SchedulingInfo: reprioritisedSchedulingInfo})
+	leases = leaseJobRuns(heldRunIds, map[uuid.UUID][]byte{lease.RunID: specHash})
+	assert.Empty(t, leases)
+
+	// Once the tolerations of the job are updated, the spec is sent again, with the updated tolerations.
+	tolerations := []v1.Toleration{{Key: "example.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule}}
+	updatedLease := *lease
+	updatedLease.SchedulingInfo = schedulingInfo(2, 2, tolerations)
+	expectHeldRuns(database.JobRunSpecVersion{RunID: lease.RunID, Node: lease.Node, SchedulingInfo: updatedLease.SchedulingInfo})
+	mockJobRepository.EXPECT().FetchJobRunLeasesByRunId(gomock.Any(), "test-executor", heldRunIds).Return([]*database.JobRunLease{&updatedLease}, nil).Times(1)
+	leases = leaseJobRuns(heldRunIds, map[uuid.UUID][]byte{lease.RunID: specHash})
+	require.Len(t, leases, 1)
+	assert.Equal(t, armadaevents.ProtoUuidFromUuid(lease.RunID), leases[0].JobRunId)
+	expectedPodSpec := submit.GetMainObject().GetPodSpec().PodSpec.DeepCopy()
+	expectedPodSpec.Tolerations = tolerations
+	assert.Equal(t, expectedPodSpec, leases[0].Job.GetMainObject().GetPodSpec().PodSpec)
+	assert.NotEqual(t, specHash, leases[0].SpecHash)
+	updatedSpecHash := leases[0].SpecHash
+
+	// Runs for which the executor provides no hash, e.g., since it recovered them from kubernetes, aren't sent again.
+	otherRunId := uuid.New()
+	mockJobRepository.EXPECT().FetchJobRunSpecVersions(gomock.Any(), "test-executor", heldRunIds).Return(
+		[]database.JobRunSpecVersion{{RunID: lease.RunID, Node: lease.Node, SchedulingInfo: updatedLease.SchedulingInfo}}, nil,
+	).Times(1)
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), []uuid.UUID{lease.RunID, otherRunId}).Return(nil, nil).Times(1)
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), "test-executor", maxJobsPerCall, []uuid.UUID{lease.RunID, otherRunId}).Return(nil, nil).Times(1)
+	leases = leaseJobRuns([]uuid.UUID{lease.RunID, otherRunId}, map[uuid.UUID][]byte{lease.RunID: updatedSpecHash})
+	assert.Empty(t, leases)
+}
+
+func TestExecutorApi_LeaseJobRuns_LeaseFanOutLimit(t *testing.T) {
+	const maxJobsPerCall = uint(100)
+	const maxLeasesPerRequest = uint(2)
+	const executorId = "test-executor"
+	_, compressedSubmit := submitMsg(t, "node-id")
+	// Leases of the runs of jobs scheduled onto the executor, in the order they were scheduled.
+	scheduledLeases := make([]*database.JobRunLease, 5)
+	for i := range scheduledLeases {
+		scheduledLeases[i] = &database.JobRunLease{RunID: uuid.New(), Node: "node-id", SubmitMessage: compressedSubmit}
+	}
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// The repository returns the leases of active scheduled runs the executor doesn't have, in scheduling order.
+	inactiveRunIds := make(map[uuid.UUID]bool)
+	unsentLeases := func(excludedRunIds []uuid.UUID) []*database.JobRunLease {
+		var leases []*database.JobRunLease
+		for _, lease := range scheduledLeases {
+			if !inactiveRunIds[lease.RunID] && !slices.Contains(excludedRunIds, lease.RunID) {
+				leases = append(leases, lease)
+			}
+		}
+		return leases
+	}
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), executorId, maxLeasesPerRequest, gomock.Any()).DoAndReturn(
+		func(_ *armadacontext.Context, _ string, maxResults uint, excludedRunIds []uuid.UUID) ([]*database.JobRunLease, error) {
+			leases := unsentLeases(excludedRunIds)
+			if uint(len(leases)) > maxResults {
+				leases = leases[:maxResults]
+			}
+			return leases, nil
+		},
+	).AnyTimes()
+	mockJobRepository.EXPECT().CountJobRunLeases(gomock.Any(), executorId, gomock.Any()).DoAndReturn(
+		func(_ *armadacontext.Context, _ string, excludedRunIds []uuid.UUID) (uint, error) {
+			return uint(len(unsentLeases(excludedRunIds))), nil
+		},
+	).AnyTimes()
+
+	server, err := NewExecutorApi(
+		mocks.NewMockProducer(ctrl),
+		mockJobRepository,
+		mockExecutorRepository,
+		mockExecutorRepository,
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+	server.EnableLeaseFanOutLimit(maxLeasesPerRequest, schedulerMetrics)
+
+	// leaseJobRuns makes a lease request as an executor holding the provided runs, of which runsToCancel are inactive,
+	// and returns the ids of the runs cancelled and leased in the response.
+	leaseJobRuns := func(heldRunIds []uuid.UUID, runsToCancel []uuid.UUID) ([]uuid.UUID, []uuid.UUID) {
+		for _, runId := range runsToCancel {
+			inactiveRunIds[runId] = true
+		}
+		request := &executorapi.LeaseRequest{ExecutorId: executorId, Pool: "test-pool", MaxJobsToLease: uint32(maxJobsPerCall)}
+		for _, runId := range heldRunIds {
+			request.UnassignedJobRunIds = append(request.UnassignedJobRunIds, *armadaevents.ProtoUuidFromUuid(runId))
+		}
+		mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), heldRunIds).Return(runsToCancel, nil).Times(1)
+		mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+		mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+		mockStream.EXPECT().Recv().Return(request, nil).Times(1)
+		var cancelled, leased []uuid.UUID
+		mockStream.EXPECT().Send(gomock.Any()).
+			Do(func(msg *executorapi.LeaseStreamMessage) {
+				for _, runId := range msg.GetCancelRuns().GetJobRunIdsToCancel() {
+					cancelled = append(cancelled, armadaevents.UuidFromProtoUuid(runId))
+				}
+				if lease := msg.GetLease(); lease != nil {
+					leased = append(leased, armadaevents.UuidFromProtoUuid(lease.JobRunId))
+				}
+			}).AnyTimes()
+		require.NoError(t, server.LeaseJobRuns(mockStream))
+		return cancelled, leased
+	}
+	runIds := util.Map(scheduledLeases, func(lease *database.JobRunLease) uuid.UUID { return lease.RunID })
+	pendingLeases := func() float64 {
+		return testutil.ToFloat64(schedulerMetrics.pendingLeases.WithLabelValues(executorId))
+	}
+
+	// Leases are sent in scheduling order, at most maxLeasesPerRequest at a time.
+	cancelled, leased := leaseJobRuns([]uuid.UUID{}, nil)
+	assert.Empty(t, cancelled)
+	assert.Equal(t, runIds[0:2], leased)
+	assert.Equal(t, float64(3), pendingLeases())
+
+	// Cancellations, e.g., of preempted runs, are sent regardless of how many leases are held back.
+	cancelled, leased = leaseJobRuns(runIds[0:2], []uuid.UUID{runIds[0]})
+	assert.Equal(t, []uuid.UUID{runIds[0]}, cancelled)
+	assert.Equal(t, runIds[2:4], leased)
+	assert.Equal(t, float64(1), pendingLeases())
+
+	cancelled, leased = leaseJobRuns(runIds[1:4], nil)
+	assert.Empty(t, cancelled)
+	assert.Equal(t, runIds[4:5], leased)
+	assert.Equal(t, float64(0), pendingLeases())
+
+	// Once all leases have been sent, there are none left to send.
+	cancelled, leased = leaseJobRuns(runIds[1:5], nil)
+	assert.Empty(t, cancelled)
+	assert.Empty(t, leased)
+	assert.Equal(t, float64(0), pendingLeases())
+}
+
+func TestExecutorApi_LeaseJobRuns_NodeQuarantine(t *testing.T) {
+	const executorId = "test-executor"
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), executorId, gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	server, err := NewExecutorApi(
+		mocks.NewMockProducer(ctrl),
+		mockJobRepository,
+		mockExecutorRepository,
+		mockExecutorRepository,
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+	nodeQuarantine, err := NewNodeQuarantine(
+		schedulerconfig.NodeQuarantineConfig{FailureThreshold: 1, TaintKey: "armadaproject.io/quarantined"},
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+	server.EnableNodeQuarantine(nodeQuarantine)
+
+	// leaseJobRuns makes a lease request and returns the quarantine instruction in the response, if any.
+	leaseJobRuns := func() *executorapi.QuarantineNodes {
+		mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+		mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+		mockStream.EXPECT().Recv().Return(&executorapi.LeaseRequest{ExecutorId: executorId, Pool: "test-pool"}, nil).Times(1)
+		var quarantineNodes *executorapi.QuarantineNodes
+		var ended bool
+		mockStream.EXPECT().Send(gomock.Any()).
+			Do(func(msg *executorapi.LeaseStreamMessage) {
+				if msg.GetQuarantineNodes() != nil {
+					assert.False(t, ended, "quarantine instruction sent after end marker")
+					quarantineNodes = msg.GetQuarantineNodes()
+				}
+				if msg.GetEnd() != nil {
+					ended = true
+				}
+			}).AnyTimes()
+		require.NoError(t, server.LeaseJobRuns(mockStream))
+		return quarantineNodes
+	}
+
+	// Executors are told none of their nodes are quarantined, such that they can remove stale taints.
+	quarantineNodes := leaseJobRuns()
+	require.NotNil(t, quarantineNodes)
+	assert.Empty(t, quarantineNodes.NodeNames)
+
+	// Only nodes of the requesting executor are included.
+	nodeQuarantine.RecordRunFailure(ctx, time.Now(), executorId, "node-b", nil)
+	nodeQuarantine.RecordRunFailure(ctx, time.Now(), executorId, "node-a", nil)
+	nodeQuarantine.RecordRunFailure(ctx, time.Now(), "other-executor", "node-c", nil)
+	quarantineNodes = leaseJobRuns()
+	require.NotNil(t, quarantineNodes)
+	assert.Equal(t, []string{"node-a", "node-b"}, quarantineNodes.NodeNames)
+	assert.Equal(t, "armadaproject.io/quarantined", quarantineNodes.TaintKey)
+}
+
+func TestExecutorApi_LeaseJobRuns_NodeIds(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	var storedExecutor *schedulerobjects.Executor
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *armadacontext.Context, executor *schedulerobjects.Executor) error {
+			storedExecutor = executor
+			return nil
+		}).AnyTimes()
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	server, err := NewExecutorApi(
+		mocks.NewMockProducer(ctrl),
+		mockJobRepository,
+		mockExecutorRepository,
+		mockExecutorRepository,
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+	server.EnableLengthPrefixedNodeIds()
+
+	leaseJobRuns := func(req *executorapi.LeaseRequest) error {
+		mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+		mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+		mockStream.EXPECT().Recv().Return(req, nil).Times(1)
+		mockStream.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+		return server.LeaseJobRuns(mockStream)
+	}
+
+	// Requests from executors with invalid ids are rejected before anything is stored.
+	err = leaseJobRuns(&executorapi.LeaseRequest{ExecutorId: "test:executor", Pool: "test-pool"})
+	var invalidArgument *armadaerrors.ErrInvalidArgument
+	assert.ErrorAs(t, err, &invalidArgument)
+	assert.Nil(t, storedExecutor)
+
+	// Nodes with invalid names are skipped and the ids of the others are length-prefixed.
+	err = leaseJobRuns(&executorapi.LeaseRequest{
+		ExecutorId: "test-executor",
+		Pool:       "test-pool",
+		Nodes:      []*api.NodeInfo{{Name: "node-a"}, {Name: "Node_B"}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, storedExecutor)
+	require.Len(t, storedExecutor.Nodes, 1)
+	assert.Equal(t, "node-a", storedExecutor.Nodes[0].Name)
+	assert.Equal(t, "13:test-executor-node-a", storedExecutor.Nodes[0].Id)
+}
+
 func TestAddNodeSelector(t *testing.T) {
 	withNodeSelector := &armadaevents.PodSpecWithAvoidList{
 		PodSpec: &v1.PodSpec{
@@ -272,6 +701,45 @@ func TestAddNodeSelector(t *testing.T) {
 	}
 }
 
+func TestAddTolerations(t *testing.T) {
+	existing := v1.Toleration{Key: "gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule}
+	added := v1.Toleration{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "batch", Effect: v1.TaintEffectNoSchedule}
+
+	tests := map[string]struct {
+		input       *armadaevents.PodSpecWithAvoidList
+		expected    *armadaevents.PodSpecWithAvoidList
+		tolerations []v1.Toleration
+	}{
+		"Adds tolerations": {
+			input:       &armadaevents.PodSpecWithAvoidList{PodSpec: &v1.PodSpec{}},
+			expected:    &armadaevents.PodSpecWithAvoidList{PodSpec: &v1.PodSpec{Tolerations: []v1.Toleration{existing, added}}},
+			tolerations: []v1.Toleration{existing, added},
+		},
+		"Doesn't add existing tolerations": {
+			input:       &armadaevents.PodSpecWithAvoidList{PodSpec: &v1.PodSpec{Tolerations: []v1.Toleration{existing}}},
+			expected:    &armadaevents.PodSpecWithAvoidList{PodSpec: &v1.PodSpec{Tolerations: []v1.Toleration{existing, added}}},
+			tolerations: []v1.Toleration{added, existing},
+		},
+		"input is nil": {
+			input:       nil,
+			expected:    nil,
+			tolerations: []v1.Toleration{added},
+		},
+		"podspec is nil": {
+			input:       &armadaevents.PodSpecWithAvoidList{},
+			expected:    &armadaevents.PodSpecWithAvoidList{},
+			tolerations: []v1.Toleration{added},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			addTolerations(tc.input, tc.tolerations)
+			assert.Equal(t, tc.expected, tc.input)
+		})
+	}
+}
+
 func TestExecutorApi_Publish(t *testing.T) {
 	tests := map[string]struct {
 		sequences []*armadaevents.EventSequence
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	schedulerconstraints "github.com/armadaproject/armada/internal/scheduler/constraints"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+)
+
+// ClassifyBlockingCauses sets the BlockingCause of each job found unschedulable in sctx.
+// Evicted jobs that couldn't be re-scheduled are preempted rather than blocked and are hence not classified.
+// Must be called once scheduling is complete, since the classification depends on the final allocation of each queue.
+func ClassifyBlockingCauses(sctx *schedulercontext.SchedulingContext) {
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		for _, jctx := range qctx.UnsuccessfulJobSchedulingContexts {
+			if jctx.IsEvicted {
+				continue
+			}
+			jctx.BlockingCause = blockingCause(sctx, qctx, jctx.UnschedulableReason)
+		}
+	}
+}
+
+// blockingCause maps an unschedulable reason to the primary cause of a job of the queue of qctx not being scheduled.
+//
+// Jobs that didn't fit on any node are blocked by fair share if their queue is at or above its fair share
+// and there's at least one other queue competing for the pool, since other queues are then entitled to the remaining capacity;
+// otherwise they're infeasible, e.g., since the pool is full of non-preemptible jobs or the job is too large.
+func blockingCause(sctx *schedulercontext.SchedulingContext, qctx *schedulercontext.QueueSchedulingContext, reason string) schedulercontext.BlockingCause {
+	switch reason {
+	case schedulerconstraints.GlobalRateLimitExceededUnschedulableReason,
+		schedulerconstraints.QueueRateLimitExceededUnschedulableReason,
+		schedulerconstraints.GlobalRateLimitExceededByGangUnschedulableReason,
+		schedulerconstraints.QueueRateLimitExceededByGangUnschedulableReason,
+		schedulerconstraints.MaximumResourcesScheduledUnschedulableReason:
+		return schedulercontext.RateLimitBlockingCause
+	case schedulerconstraints.MaximumResourcesPerQueueExceededUnschedulableReason:
+		return schedulercontext.QueueCapBlockingCause
+	case GangMinimumCardinalityNotMetUnschedulableReason:
+		return schedulercontext.GangIncompleteBlockingCause
+	case schedulerconstraints.ReservedResourcesUnschedulableReason:
+		return schedulercontext.FairShareBlockingCause
+	case JobDoesNotFitUnschedulableReason, GangDoesNotFitUnschedulableReason, InsufficientCapacityUnschedulableReason:
+		if isAtOrAboveFairShare(sctx, qctx) {
+			return schedulercontext.FairShareBlockingCause
+		}
+		return schedulercontext.InfeasibleBlockingCause
+	}
+	return schedulercontext.InfeasibleBlockingCause
+}
+
+// isAtOrAboveFairShare returns true if the queue of qctx is allocated at least its fair share of the pool
+// and some other queue is competing for the pool.
+func isAtOrAboveFairShare(sctx *schedulercontext.SchedulingContext, qctx *schedulercontext.QueueSchedulingContext) bool {
+	if len(sctx.QueueSchedulingContexts) < 2 || sctx.WeightSum <= 0 {
+		return false
+	}
+	return qctx.CapacityShare() >= qctx.Weight/sctx.WeightSum
+}
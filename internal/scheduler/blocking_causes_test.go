@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	schedulerconstraints "github.com/armadaproject/armada/internal/scheduler/constraints"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/fairness"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestClassifyBlockingCauses(t *testing.T) {
+	tests := map[string]struct {
+		// Cpu allocated to each queue of a pool with 32 cpu, all with weight 1.
+		allocatedCpuByQueue map[string]string
+		queue               string
+		unschedulableReason string
+		isEvicted           bool
+		expected            schedulercontext.BlockingCause
+	}{
+		"doesn't fit and at fair share": {
+			allocatedCpuByQueue: map[string]string{"A": "16", "B": "4"},
+			queue:               "A",
+			unschedulableReason: JobDoesNotFitUnschedulableReason,
+			expected:            schedulercontext.FairShareBlockingCause,
+		},
+		"would use reserved resources": {
+			allocatedCpuByQueue: map[string]string{"A": "16", "B": "4"},
+			queue:               "B",
+			unschedulableReason: schedulerconstraints.ReservedResourcesUnschedulableReason,
+			expected:            schedulercontext.FairShareBlockingCause,
+		},
+		"queue cap": {
+			allocatedCpuByQueue: map[string]string{"A": "16", "B": "4"},
+			queue:               "B",
+			unschedulableReason: schedulerconstraints.MaximumResourcesPerQueueExceededUnschedulableReason,
+			expected:            schedulercontext.QueueCapBlockingCause,
+		},
+		"doesn't fit and below fair share": {
+			allocatedCpuByQueue: map[string]string{"A": "16", "B": "4"},
+			queue:               "B",
+			unschedulableReason: GangDoesNotFitUnschedulableReason,
+			expected:            schedulercontext.InfeasibleBlockingCause,
+		},
+		"doesn't fit and no other queue": {
+			allocatedCpuByQueue: map[string]string{"A": "32"},
+			queue:               "A",
+			unschedulableReason: InsufficientCapacityUnschedulableReason,
+			expected:            schedulercontext.InfeasibleBlockingCause,
+		},
+		"too large": {
+			allocatedCpuByQueue: map[string]string{"A": "16", "B": "4"},
+			queue:               "A",
+			unschedulableReason: schedulerconstraints.GangExceedsQueueBurstSizeUnschedulableReason,
+			expected:            schedulercontext.InfeasibleBlockingCause,
+		},
+		"rate limit": {
+			allocatedCpuByQueue: map[string]string{"A": "16", "B": "4"},
+			queue:               "A",
+			unschedulableReason: schedulerconstraints.QueueRateLimitExceededUnschedulableReason,
+			expected:            schedulercontext.RateLimitBlockingCause,
+		},
+		"gang incomplete": {
+			allocatedCpuByQueue: map[string]string{"A": "16", "B": "4"},
+			queue:               "B",
+			unschedulableReason: GangMinimumCardinalityNotMetUnschedulableReason,
+			expected:            schedulercontext.GangIncompleteBlockingCause,
+		},
+		"evicted jobs aren't classified": {
+			allocatedCpuByQueue: map[string]string{"A": "16", "B": "4"},
+			queue:               "A",
+			unschedulableReason: JobDoesNotFitUnschedulableReason,
+			isEvicted:           true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			totalResources := schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("32")}}
+			fairnessCostProvider, err := fairness.NewDominantResourceFairness(totalResources, []string{"cpu"})
+			require.NoError(t, err)
+			sctx := schedulercontext.NewSchedulingContext(
+				"executor",
+				"blocking-pool",
+				testfixtures.TestPriorityClasses,
+				testfixtures.TestDefaultPriorityClass,
+				fairnessCostProvider,
+				rate.NewLimiter(rate.Inf, 1),
+				totalResources,
+			)
+			for queue, cpu := range tc.allocatedCpuByQueue {
+				allocated := schedulerobjects.QuantityByTAndResourceType[string]{
+					testfixtures.PriorityClass0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse(cpu)}},
+				}
+				require.NoError(t, sctx.AddQueueSchedulingContext(queue, 1, allocated, rate.NewLimiter(rate.Inf, 1)))
+			}
+			job := testfixtures.Test1Cpu4GiJob(tc.queue, testfixtures.PriorityClass0)
+			jctx := schedulercontext.JobSchedulingContextFromJob(testfixtures.TestPriorityClasses, job, GangIdAndCardinalityFromAnnotations)
+			jctx.IsEvicted = tc.isEvicted
+			jctx.Fail(tc.unschedulableReason)
+			_, err = sctx.QueueSchedulingContexts[tc.queue].AddJobSchedulingContext(jctx)
+			require.NoError(t, err)
+
+			ClassifyBlockingCauses(sctx)
+			assert.Equal(t, tc.expected, jctx.BlockingCause)
+
+			schedulerMetrics.blockedJobs.Reset()
+			schedulerMetrics.reportBlockedJobs([]*schedulercontext.SchedulingContext{sctx})
+			for _, cause := range schedulercontext.BlockingCauses {
+				expected := 0.0
+				if cause == tc.expected {
+					expected = 1
+				}
+				assert.Equal(t, expected, testutil.ToFloat64(schedulerMetrics.blockedJobs.WithLabelValues(tc.queue, "blocking-pool", string(cause))), cause)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package build
+
+var ReleaseVersion string
+
+var GitCommit string
@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"time"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+)
+
+// BurstCreditLedger tracks the debt of each queue accrued by being allocated more than its fair share of a pool;
+// see configuration.BurstCreditsConfig. If persistence is enabled, debts are stored alongside the other state of the
+// scheduler, such that they survive restarts and failovers; see EnablePersistence.
+type BurstCreditLedger struct {
+	config configuration.BurstCreditsConfig
+	// Debt of each queue, indexed by pool and queue.
+	debtByPoolAndQueue map[string]map[string]float64
+	// Time at which the debts of each pool were last updated.
+	lastUpdatedByPool map[string]time.Time
+	// If non-nil, debts are stored here after each update and restored from here by Restore.
+	repository database.BurstCreditRepository
+	// If true, debts are restored from repository before they're next used.
+	stale bool
+}
+
+func NewBurstCreditLedger(config configuration.BurstCreditsConfig) *BurstCreditLedger {
+	return &BurstCreditLedger{
+		config:             config,
+		debtByPoolAndQueue: make(map[string]map[string]float64),
+		lastUpdatedByPool:  make(map[string]time.Time),
+	}
+}
+
+// EnablePersistence causes the debts of each pool to be stored in repository each time they're updated,
+// and restored from repository before they're first used and after each call to Invalidate.
+func (l *BurstCreditLedger) EnablePersistence(repository database.BurstCreditRepository) {
+	l.repository = repository
+	l.stale = true
+}
+
+// Invalidate causes debts to be restored before they're next used, if persistence is enabled.
+// Called on becoming leader, since other replicas may have updated the stored debts since they were last restored.
+func (l *BurstCreditLedger) Invalidate() {
+	l.stale = l.repository != nil
+}
+
+// Restore replaces all debts with those stored in the repository, if invalidated; see Invalidate.
+// Since the time of the last update of each pool isn't stored, the first update of each pool afterwards only records the time.
+func (l *BurstCreditLedger) Restore(ctx *armadacontext.Context) error {
+	if !l.stale {
+		return nil
+	}
+	debtByPoolAndQueue, err := l.repository.FetchBurstCreditDebts(ctx)
+	if err != nil {
+		return err
+	}
+	if debtByPoolAndQueue == nil {
+		debtByPoolAndQueue = make(map[string]map[string]float64)
+	}
+	l.debtByPoolAndQueue = debtByPoolAndQueue
+	l.lastUpdatedByPool = make(map[string]time.Time)
+	l.stale = false
+	return nil
+}
+
+// Store stores the debts of pool in the repository, if persistence is enabled.
+func (l *BurstCreditLedger) Store(ctx *armadacontext.Context, pool string) error {
+	if l.repository == nil {
+		return nil
+	}
+	return l.repository.StoreBurstCreditDebts(ctx, pool, maps.Clone(l.debtByPoolAndQueue[pool]))
+}
+
+// Debt returns the current debt of queue in pool.
+func (l *BurstCreditLedger) Debt(pool, queue string) float64 {
+	return l.debtByPoolAndQueue[pool][queue]
+}
+
+// EffectiveWeight returns the weight queue should be scheduled with in pool, given its nominal weight.
+func (l *BurstCreditLedger) EffectiveWeight(pool, queue string, weight float64) float64 {
+	return weight / (1 + l.Debt(pool, queue))
+}
+
+// Update accrues or repays the debt of each queue in the pool of sctx for the time elapsed since the previous update,
+// based on the allocation of each queue at the end of the round. weightByQueue must contain the nominal weight of all queues,
+// whether active or not. The first update of each pool only records the time.
+func (l *BurstCreditLedger) Update(sctx *schedulercontext.SchedulingContext, weightByQueue map[string]float64, now time.Time) {
+	lastUpdated, ok := l.lastUpdatedByPool[sctx.Pool]
+	l.lastUpdatedByPool[sctx.Pool] = now
+	if !ok || !now.After(lastUpdated) {
+		return
+	}
+	elapsed := now.Sub(lastUpdated).Seconds()
+	weightSum := 0.0
+	for _, weight := range weightByQueue {
+		weightSum += weight
+	}
+	if weightSum <= 0 {
+		return
+	}
+	debtByQueue := l.debtByPoolAndQueue[sctx.Pool]
+	if debtByQueue == nil {
+		debtByQueue = make(map[string]float64)
+		l.debtByPoolAndQueue[sctx.Pool] = debtByQueue
+	}
+	for queue, weight := range weightByQueue {
+		share := 0.0
+		if qctx, ok := sctx.QueueSchedulingContexts[queue]; ok {
+			share = qctx.CapacityShare()
+		}
+		debt := debtByQueue[queue]
+		if excess := share - weight/weightSum; excess > 0 {
+			debt += l.config.AccrualRate * excess * elapsed
+		} else {
+			debt -= l.config.DecayRate * elapsed
+		}
+		if debt > 0 {
+			debtByQueue[queue] = debt
+		} else {
+			delete(debtByQueue, queue)
+		}
+	}
+	// Queues that no longer exist are forgiven their debt.
+	for queue := range debtByQueue {
+		if _, ok := weightByQueue[queue]; !ok {
+			delete(debtByQueue, queue)
+		}
+	}
+}
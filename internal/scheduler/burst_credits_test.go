@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/fairness"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestBurstCreditLedger_Update(t *testing.T) {
+	ledger := NewBurstCreditLedger(configuration.BurstCreditsConfig{Enabled: true, AccrualRate: 0.1, DecayRate: 0.05})
+	sctx := testSchedulingContextWithCpuAllocation(t, map[string]string{"A": "24", "B": "8"})
+	weightByQueue := map[string]float64{"A": 1, "B": 1}
+
+	// The first update only records the time.
+	ledger.Update(sctx, weightByQueue, testfixtures.BaseTime)
+	assert.Equal(t, 0.0, ledger.Debt(testfixtures.TestPool, "A"))
+
+	// A is allocated 75% of the pool with a fair share of 50%.
+	ledger.Update(sctx, weightByQueue, testfixtures.BaseTime.Add(10*time.Second))
+	assert.InDelta(t, 0.25, ledger.Debt(testfixtures.TestPool, "A"), 1e-9)
+	assert.Equal(t, 0.0, ledger.Debt(testfixtures.TestPool, "B"))
+	assert.InDelta(t, 0.8, ledger.EffectiveWeight(testfixtures.TestPool, "A", 1), 1e-9)
+	assert.Equal(t, 1.0, ledger.EffectiveWeight(testfixtures.TestPool, "B", 1))
+
+	// Debt is repaid while at or below fair share.
+	sctx = testSchedulingContextWithCpuAllocation(t, map[string]string{"A": "16", "B": "16"})
+	ledger.Update(sctx, weightByQueue, testfixtures.BaseTime.Add(12*time.Second))
+	assert.InDelta(t, 0.15, ledger.Debt(testfixtures.TestPool, "A"), 1e-9)
+	ledger.Update(sctx, weightByQueue, testfixtures.BaseTime.Add(20*time.Second))
+	assert.Equal(t, 0.0, ledger.Debt(testfixtures.TestPool, "A"))
+
+	// Queues without jobs in the pool count towards fair share.
+	sctx = testSchedulingContextWithCpuAllocation(t, map[string]string{"A": "32"})
+	ledger.Update(sctx, weightByQueue, testfixtures.BaseTime.Add(30*time.Second))
+	assert.InDelta(t, 0.5, ledger.Debt(testfixtures.TestPool, "A"), 1e-9)
+
+	// Debt of deleted queues is forgiven.
+	ledger.Update(sctx, map[string]float64{"B": 1}, testfixtures.BaseTime.Add(40*time.Second))
+	assert.Equal(t, 0.0, ledger.Debt(testfixtures.TestPool, "A"))
+}
+
+// burstCreditTestRepository is a database.BurstCreditRepository storing debts in memory.
+type burstCreditTestRepository struct {
+	debtByPoolAndQueue map[string]map[string]float64
+}
+
+func (r *burstCreditTestRepository) FetchBurstCreditDebts(_ *armadacontext.Context) (map[string]map[string]float64, error) {
+	rv := make(map[string]map[string]float64, len(r.debtByPoolAndQueue))
+	for pool, debtByQueue := range r.debtByPoolAndQueue {
+		rv[pool] = maps.Clone(debtByQueue)
+	}
+	return rv, nil
+}
+
+func (r *burstCreditTestRepository) StoreBurstCreditDebts(_ *armadacontext.Context, pool string, debtByQueue map[string]float64) error {
+	r.debtByPoolAndQueue[pool] = maps.Clone(debtByQueue)
+	return nil
+}
+
+func TestBurstCreditLedger_Persistence(t *testing.T) {
+	ctx := armadacontext.Background()
+	config := configuration.BurstCreditsConfig{Enabled: true, AccrualRate: 0.1, DecayRate: 0.05}
+	repo := &burstCreditTestRepository{debtByPoolAndQueue: make(map[string]map[string]float64)}
+	sctx := testSchedulingContextWithCpuAllocation(t, map[string]string{"A": "24", "B": "8"})
+	weightByQueue := map[string]float64{"A": 1, "B": 1}
+
+	leader := NewBurstCreditLedger(config)
+	leader.EnablePersistence(repo)
+	require.NoError(t, leader.Restore(ctx))
+	leader.Update(sctx, weightByQueue, testfixtures.BaseTime)
+	leader.Update(sctx, weightByQueue, testfixtures.BaseTime.Add(10*time.Second))
+	require.NoError(t, leader.Store(ctx, testfixtures.TestPool))
+
+	// The ledger of the replica taking over after a failover restores the debts stored by the previous leader.
+	follower := NewBurstCreditLedger(config)
+	follower.EnablePersistence(repo)
+	require.NoError(t, follower.Restore(ctx))
+	assert.InDelta(t, 0.25, follower.Debt(testfixtures.TestPool, "A"), 1e-9)
+	assert.Equal(t, 0.0, follower.Debt(testfixtures.TestPool, "B"))
+
+	// Debts are only restored again once invalidated, e.g., on becoming leader again.
+	leader.Update(sctx, weightByQueue, testfixtures.BaseTime.Add(20*time.Second))
+	require.NoError(t, leader.Store(ctx, testfixtures.TestPool))
+	require.NoError(t, follower.Restore(ctx))
+	assert.InDelta(t, 0.25, follower.Debt(testfixtures.TestPool, "A"), 1e-9)
+	follower.Invalidate()
+	require.NoError(t, follower.Restore(ctx))
+	assert.InDelta(t, 0.5, follower.Debt(testfixtures.TestPool, "A"), 1e-9)
+
+	// Since the time of the last update isn't stored, the first update after restoring only records the time.
+	follower.Update(sctx, weightByQueue, testfixtures.BaseTime.Add(time.Hour))
+	assert.InDelta(t, 0.5, follower.Debt(testfixtures.TestPool, "A"), 1e-9)
+}
+
+func TestBurstCredits_IndebtedQueueYieldsFirst(t *testing.T) {
+	const cycleInterval = 10 * time.Second
+	ctx := armadacontext.Background()
+	config := testfixtures.TestSchedulingConfig()
+	config.BurstCredits = configuration.BurstCreditsConfig{Enabled: true, AccrualRate: 0.01, DecayRate: 0.01}
+
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{testfixtures.Test1Node32CoreExecutor("executor1")}, nil).AnyTimes()
+	mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+	mockQueueRepo.EXPECT().GetAllQueues().Return([]*database.Queue{{Name: "A", Weight: 1}, {Name: "B", Weight: 1}}, nil).AnyTimes()
+	burstCreditRepo := &burstCreditTestRepository{debtByPoolAndQueue: make(map[string]map[string]float64)}
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+	newAlgo := func() *FairSchedulingAlgo {
+		algo, err := NewFairSchedulingAlgo(config, 0, mockExecutorRepo, mockQueueRepo, nil)
+		require.NoError(t, err)
+		algo.clock = testClock
+		algo.BurstCredits().EnablePersistence(burstCreditRepo)
+		return algo
+	}
+	algo := newAlgo()
+
+	jobDb := testfixtures.NewJobDb()
+	runCycle := func() map[string]int {
+		txn := jobDb.WriteTxn()
+		_, err := algo.Schedule(ctx, txn)
+		require.NoError(t, err)
+		// As the scheduler does, remove jobs that reached a terminal state, e.g., since they were preempted.
+		var terminalJobIds []string
+		for _, job := range txn.GetAll() {
+			if job.InTerminalState() {
+				terminalJobIds = append(terminalJobIds, job.Id())
+			}
+		}
+		require.NoError(t, txn.BatchDelete(terminalJobIds))
+		txn.Commit()
+		testClock.Step(cycleInterval)
+		return runningJobsByQueue(jobDb.ReadTxn())
+	}
+	submit := func(jobs []*jobdb.Job) {
+		txn := jobDb.WriteTxn()
+		require.NoError(t, txn.Upsert(jobs))
+		txn.Commit()
+	}
+
+	// Burst: A uses the entire pool while B has no jobs, thus accruing debt.
+	submit(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 64)))
+	for i := 0; i < 21; i++ {
+		assert.Equal(t, map[string]int{"A": 32}, runCycle())
+	}
+	assert.InDelta(t, 1.0, algo.burstCredits.Debt(testfixtures.TestPool, "A"), 1e-9)
+
+	// Failover: the debt of A is restored by the scheduling algorithm of the new leader.
+	algo = newAlgo()
+
+	// Contention: A yields more than its fair share to B while in debt.
+	submit(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("B", testfixtures.PriorityClass0, 64)))
+	running := runCycle()
+	assert.Less(t, running["A"], running["B"])
+	assert.Equal(t, 32, running["A"]+running["B"])
+
+	// A repays its debt while below its fair share, after which the pool is again split evenly.
+	for i := 0; i < 20 && algo.burstCredits.Debt(testfixtures.TestPool, "A") > 0; i++ {
+		running = runCycle()
+		assert.LessOrEqual(t, running["A"], running["B"])
+	}
+	assert.Equal(t, 0.0, algo.burstCredits.Debt(testfixtures.TestPool, "A"))
+	assert.Equal(t, map[string]int{"A": 16, "B": 16}, runCycle())
+}
+
+func runningJobsByQueue(txn *jobdb.Txn) map[string]int {
+	rv := make(map[string]int)
+	for _, job := range txn.GetAll() {
+		if !job.Queued() {
+			rv[job.Queue()]++
+		}
+	}
+	return rv
+}
+
+func testSchedulingContextWithCpuAllocation(t *testing.T, allocatedCpuByQueue map[string]string) *schedulercontext.SchedulingContext {
+	totalResources := schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("32")}}
+	fairnessCostProvider, err := fairness.NewAssetFairness(map[string]float64{"cpu": 1})
+	require.NoError(t, err)
+	sctx := schedulercontext.NewSchedulingContext(
+		"executor",
+		testfixtures.TestPool,
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestDefaultPriorityClass,
+		fairnessCostProvider,
+		rate.NewLimiter(rate.Inf, 1),
+		totalResources,
+	)
+	for queue, cpu := range allocatedCpuByQueue {
+		allocated := schedulerobjects.QuantityByTAndResourceType[string]{
+			testfixtures.PriorityClass0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse(cpu)}},
+		}
+		require.NoError(t, sctx.AddQueueSchedulingContext(queue, 1, allocated, rate.NewLimiter(rate.Inf, 1)))
+	}
+	return sctx
+}
@@ -0,0 +1,199 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// EnableCancellationEnforcement causes the scheduler to track the active runs of cancelled jobs until their executor
+// stops reporting them. If the executor still reports a run escalateAfter after it was cancelled,
+// the run cancellation is re-published and the run counted as an ignored cancellation.
+// If the executor still reports the run forceFailAfter after that, the run is failed.
+func (s *Scheduler) EnableCancellationEnforcement(escalateAfter, forceFailAfter time.Duration) {
+	s.cancellationEnforcer = newCancellationEnforcer(escalateAfter, forceFailAfter)
+}
+
+// pendingCancellation is a cancelled run the executor hasn't yet been seen to stop.
+type pendingCancellation struct {
+	jobId       string
+	protoJobId  *armadaevents.Uuid
+	runId       uuid.UUID
+	queue       string
+	jobSet      string
+	executor    string
+	nodeName    string
+	cancelledAt time.Time
+	escalated   bool
+}
+
+// cancellationEnforcer tracks cancelled runs until their executor stops reporting them.
+type cancellationEnforcer struct {
+	escalateAfter  time.Duration
+	forceFailAfter time.Duration
+	pendingByRunId map[uuid.UUID]*pendingCancellation
+}
+
+func newCancellationEnforcer(escalateAfter, forceFailAfter time.Duration) *cancellationEnforcer {
+	return &cancellationEnforcer{
+		escalateAfter:  escalateAfter,
+		forceFailAfter: forceFailAfter,
+		pendingByRunId: make(map[uuid.UUID]*pendingCancellation),
+	}
+}
+
+// add tracks run, cancelled at time now. Runs already tracked keep the time they were first cancelled at.
+func (e *cancellationEnforcer) add(now time.Time, job *jobdb.Job, jobId *armadaevents.Uuid, run *jobdb.JobRun) {
+	if _, ok := e.pendingByRunId[run.Id()]; ok {
+		return
+	}
+	e.pendingByRunId[run.Id()] = &pendingCancellation{
+		jobId:       job.Id(),
+		protoJobId:  jobId,
+		runId:       run.Id(),
+		queue:       job.Queue(),
+		jobSet:      job.Jobset(),
+		executor:    run.Executor(),
+		nodeName:    run.NodeName(),
+		cancelledAt: now,
+	}
+}
+
+// acknowledged returns true if executor has stopped the run of p,
+// i.e., if it last reported in after the run was cancelled and didn't report the run as pending or running.
+// Runs on executors that no longer exist are considered acknowledged, since there's nothing left to cancel them on.
+func (p *pendingCancellation) acknowledged(executor *schedulerobjects.Executor) bool {
+	if executor == nil {
+		return true
+	}
+	if !executor.LastUpdateTime.After(p.cancelledAt) {
+		return false
+	}
+	runId := p.runId.String()
+	for _, node := range executor.Nodes {
+		if state, ok := node.StateByJobRunId[runId]; ok {
+			return state != schedulerobjects.JobRunState_PENDING && state != schedulerobjects.JobRunState_RUNNING
+		}
+	}
+	for _, unassignedRunId := range executor.UnassignedJobRuns {
+		if unassignedRunId == runId {
+			return false
+		}
+	}
+	return true
+}
+
+// enforceCancellations stops tracking cancelled runs their executor has stopped, re-publishes the cancellation of runs
+// their executor hasn't stopped within escalateAfter, and fails runs their executor hasn't stopped within
+// escalateAfter + forceFailAfter. Returns the resulting events along with the ids of the runs failed.
+// Failed runs are only untracked once resolveEnforcedCancellations is called, such that they're failed again
+// if publishing fails.
+func (s *Scheduler) enforceCancellations(ctx *armadacontext.Context, txn *jobdb.Txn) ([]*armadaevents.EventSequence, []uuid.UUID, error) {
+	if s.cancellationEnforcer == nil || len(s.cancellationEnforcer.pendingByRunId) == 0 {
+		return nil, nil, nil
+	}
+	executors, err := s.executorRepository.GetExecutors(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	executorsById := make(map[string]*schedulerobjects.Executor, len(executors))
+	for _, executor := range executors {
+		executorsById[executor.Id] = executor
+	}
+	now := s.clock.Now()
+	var events []*armadaevents.EventSequence
+	var failedRunIds []uuid.UUID
+	for runId, p := range s.cancellationEnforcer.pendingByRunId {
+		if p.acknowledged(executorsById[p.executor]) {
+			delete(s.cancellationEnforcer.pendingByRunId, runId)
+			continue
+		}
+		elapsed := now.Sub(p.cancelledAt)
+		if elapsed >= s.cancellationEnforcer.escalateAfter+s.cancellationEnforcer.forceFailAfter {
+			ctx.Warnf(
+				"failing run %s of job %s since executor %s hasn't stopped it %s after it was cancelled",
+				runId, p.jobId, p.executor, elapsed,
+			)
+			if job := txn.GetByRunId(runId); job != nil {
+				if run := job.RunById(runId); run != nil && !run.Failed() {
+					if err := txn.Upsert([]*jobdb.Job{job.WithUpdatedRun(run.WithFailed(true))}); err != nil {
+						return nil, nil, err
+					}
+				}
+			}
+			events = append(events, s.forceFailedRunErrorEvent(p, elapsed))
+			failedRunIds = append(failedRunIds, runId)
+		} else if elapsed >= s.cancellationEnforcer.escalateAfter && !p.escalated {
+			ctx.Warnf(
+				"re-publishing cancellation of run %s of job %s since executor %s hasn't stopped it %s after it was cancelled",
+				runId, p.jobId, p.executor, elapsed,
+			)
+			p.escalated = true
+			s.metrics.ReportIgnoredCancellation(p.executor)
+			events = append(events, &armadaevents.EventSequence{
+				Queue:      p.queue,
+				JobSetName: p.jobSet,
+				Events: []*armadaevents.EventSequence_Event{
+					{
+						Created: s.now(),
+						Event: &armadaevents.EventSequence_Event_JobRunCancelled{
+							JobRunCancelled: &armadaevents.JobRunCancelled{
+								RunId: armadaevents.ProtoUuidFromUuid(runId),
+								JobId: p.protoJobId,
+							},
+						},
+					},
+				},
+			})
+		}
+	}
+	return events, failedRunIds, nil
+}
+
+func (s *Scheduler) forceFailedRunErrorEvent(p *pendingCancellation, elapsed time.Duration) *armadaevents.EventSequence {
+	return &armadaevents.EventSequence{
+		Queue:      p.queue,
+		JobSetName: p.jobSet,
+		Events: []*armadaevents.EventSequence_Event{
+			{
+				Created: s.now(),
+				Event: &armadaevents.EventSequence_Event_JobRunErrors{
+					JobRunErrors: &armadaevents.JobRunErrors{
+						RunId: armadaevents.ProtoUuidFromUuid(p.runId),
+						JobId: p.protoJobId,
+						Errors: []*armadaevents.Error{
+							{
+								Terminal: true,
+								Reason: &armadaevents.Error_PodTerminated{
+									PodTerminated: &armadaevents.PodTerminated{
+										NodeName: p.nodeName,
+										Message: fmt.Sprintf(
+											"Run was failed since executor %s hadn't stopped it %s after it was cancelled",
+											p.executor, elapsed,
+										),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resolveEnforcedCancellations stops tracking runs failed by enforceCancellations.
+func (s *Scheduler) resolveEnforcedCancellations(runIds []uuid.UUID) {
+	if s.cancellationEnforcer == nil {
+		return
+	}
+	for _, runId := range runIds {
+		delete(s.cancellationEnforcer.pendingByRunId, runId)
+	}
+}
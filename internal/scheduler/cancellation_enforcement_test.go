@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_CancellationEnforcement(t *testing.T) {
+	const (
+		escalateAfter  = 10 * time.Minute
+		forceFailAfter = 20 * time.Minute
+	)
+	tests := map[string]struct {
+		// Whether the executor still reports the run in the second and third cycles respectively.
+		// The second cycle runs escalateAfter after the cancellation and the third forceFailAfter after that.
+		reportedInSecondCycle bool
+		reportedInThirdCycle  bool
+		expectEscalated       bool
+		expectForceFailed     bool
+	}{
+		"acknowledged": {},
+		"escalated and then acknowledged": {
+			reportedInSecondCycle: true,
+			expectEscalated:       true,
+		},
+		"escalated and then force-failed": {
+			reportedInSecondCycle: true,
+			reportedInThirdCycle:  true,
+			expectEscalated:       true,
+			expectForceFailed:     true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			testClock := clock.NewFakeClock(time.Now())
+			jobRepo := &testJobRepository{}
+			executorRepo := &testExecutorRepository{}
+			publisher := &testPublisher{}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				jobRepo,
+				executorRepo,
+				&testSchedulingAlgo{},
+				NewStandaloneLeaderController(),
+				publisher,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = testClock
+			sched.EnableCancellationEnforcement(escalateAfter, forceFailAfter)
+
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{leasedJob}))
+			txn.Commit()
+			runId := leasedJob.LatestRun().Id()
+			reportExecutor := func(reportRun bool) {
+				node := &schedulerobjects.Node{
+					Id:              "test-node",
+					StateByJobRunId: map[string]schedulerobjects.JobRunState{},
+				}
+				if reportRun {
+					node.StateByJobRunId[runId.String()] = schedulerobjects.JobRunState_RUNNING
+				}
+				executorRepo.executors = []*schedulerobjects.Executor{{
+					Id:             "testExecutor",
+					Nodes:          []*schedulerobjects.Node{node},
+					LastUpdateTime: testClock.Now(),
+				}}
+			}
+			numIgnoredCancellations := func() float64 {
+				return testutil.ToFloat64(schedulerMetrics.ignoredCancellations.WithLabelValues("testExecutor"))
+			}
+			initialNumIgnoredCancellations := numIgnoredCancellations()
+
+			// The job is cancelled while its run is running.
+			reportExecutor(true)
+			jobRepo.updatedJobs = []database.Job{
+				{
+					JobID:           leasedJob.Id(),
+					JobSet:          leasedJob.Jobset(),
+					Queue:           leasedJob.Queue(),
+					CancelRequested: true,
+					Serial:          1,
+				},
+			}
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+			jobRepo.updatedJobs = nil
+			assert.Empty(t, collectJobRunCancellations(publisher.events))
+
+			// The executor has had escalateAfter to stop the run.
+			testClock.Step(escalateAfter)
+			reportExecutor(tc.reportedInSecondCycle)
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+			if tc.expectEscalated {
+				runCancellations := collectJobRunCancellations(publisher.events)
+				require.Len(t, runCancellations, 1)
+				assert.Equal(t, armadaevents.ProtoUuidFromUuid(runId), runCancellations[0].RunId)
+				assert.Equal(t, initialNumIgnoredCancellations+1, numIgnoredCancellations())
+			} else {
+				assert.Empty(t, collectJobRunCancellations(publisher.events))
+				assert.Equal(t, initialNumIgnoredCancellations, numIgnoredCancellations())
+			}
+			assert.Empty(t, collectJobRunErrors(publisher.events))
+
+			// The executor has had another forceFailAfter to stop the run.
+			testClock.Step(forceFailAfter)
+			reportExecutor(tc.reportedInThirdCycle)
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+			assert.Empty(t, collectJobRunCancellations(publisher.events))
+			run := sched.jobDb.ReadTxn().GetById(leasedJob.Id()).RunById(runId)
+			require.NotNil(t, run)
+			if tc.expectForceFailed {
+				runErrors := collectJobRunErrors(publisher.events)
+				require.Len(t, runErrors, 1)
+				assert.Equal(t, armadaevents.ProtoUuidFromUuid(runId), runErrors[0].RunId)
+				assert.True(t, runErrors[0].Errors[0].Terminal)
+				assert.NotNil(t, runErrors[0].Errors[0].GetPodTerminated())
+				assert.True(t, run.Failed())
+			} else {
+				assert.Empty(t, collectJobRunErrors(publisher.events))
+				assert.False(t, run.Failed())
+			}
+			assert.Empty(t, sched.cancellationEnforcer.pendingByRunId)
+		})
+	}
+}
+
+func collectJobRunCancellations(eventSequences []*armadaevents.EventSequence) []*armadaevents.JobRunCancelled {
+	var rv []*armadaevents.JobRunCancelled
+	for _, eventSequence := range eventSequences {
+		for _, event := range eventSequence.Events {
+			if jobRunCancelled := event.GetJobRunCancelled(); jobRunCancelled != nil {
+				rv = append(rv, jobRunCancelled)
+			}
+		}
+	}
+	return rv
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// EnableCapacitySummary causes the allocatable resources, allocated resources, and queued demand of each pool
+// to be computed at the start of each scheduling round and recorded in the scheduling context of that pool.
+// Queued jobs that could run in several pools are attributed to the pool chosen by poolAssigner,
+// such that demand is consistent with other pool-level metrics.
+func (l *FairSchedulingAlgo) EnableCapacitySummary(poolAssigner PoolAssigner) {
+	l.poolAssigner = poolAssigner
+}
+
+// capacityByPool returns the capacity summary of each pool, given the total resources of the nodes in each pool,
+// the resources allocated to running jobs broken down by pool, queue, and priority class, and the jobs in the jobDb.
+// Queued jobs not assigned to any pool by poolAssigner are excluded from demand.
+func capacityByPool(
+	totalCapacityByPool schedulerobjects.QuantityByTAndResourceType[string],
+	allocationByPoolAndQueueAndPriorityClass map[string]map[string]schedulerobjects.QuantityByTAndResourceType[string],
+	jobs []*jobdb.Job,
+	poolAssigner PoolAssigner,
+) (map[string]*schedulercontext.PoolCapacity, error) {
+	rv := make(map[string]*schedulercontext.PoolCapacity)
+	getOrCreate := func(pool string) *schedulercontext.PoolCapacity {
+		capacity, ok := rv[pool]
+		if !ok {
+			capacity = &schedulercontext.PoolCapacity{
+				Allocatable: schedulerobjects.NewResourceListWithDefaultSize(),
+				Allocated:   schedulerobjects.NewResourceListWithDefaultSize(),
+				Demand:      schedulerobjects.NewResourceListWithDefaultSize(),
+			}
+			rv[pool] = capacity
+		}
+		return capacity
+	}
+	for pool, allocatable := range totalCapacityByPool {
+		getOrCreate(pool).Allocatable.Add(allocatable)
+	}
+	for pool, allocationByQueueAndPriorityClass := range allocationByPoolAndQueueAndPriorityClass {
+		capacity := getOrCreate(pool)
+		for _, allocationByPriorityClass := range allocationByQueueAndPriorityClass {
+			capacity.Allocated.Add(allocationByPriorityClass.AggregateByResource())
+		}
+	}
+	for _, job := range jobs {
+		if !job.Queued() {
+			continue
+		}
+		pool, err := poolAssigner.AssignPool(job)
+		if err != nil {
+			return nil, err
+		}
+		if pool == "" {
+			continue
+		}
+		getOrCreate(pool).Demand.AddV1ResourceList(job.GetResourceRequirements().Requests)
+	}
+	return rv, nil
+}
+
+// poolCapacities refreshes the pool assigner and returns the capacity summary of each pool,
+// or nil if capacity summaries aren't enabled.
+func (l *FairSchedulingAlgo) poolCapacities(
+	ctx *armadacontext.Context,
+	txn *jobdb.Txn,
+	totalCapacityByPool schedulerobjects.QuantityByTAndResourceType[string],
+	allocationByPoolAndQueueAndPriorityClass map[string]map[string]schedulerobjects.QuantityByTAndResourceType[string],
+) (map[string]*schedulercontext.PoolCapacity, error) {
+	if l.poolAssigner == nil {
+		return nil, nil
+	}
+	if err := l.poolAssigner.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return capacityByPool(totalCapacityByPool, allocationByPoolAndQueueAndPriorityClass, txn.GetAll(), l.poolAssigner)
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestCapacityByPool(t *testing.T) {
+	cpuMem := func(cpu, memory string) schedulerobjects.ResourceList {
+		return schedulerobjects.ResourceList{
+			Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse(cpu),
+				"memory": resource.MustParse(memory),
+			},
+		}
+	}
+	totalCapacityByPool := schedulerobjects.QuantityByTAndResourceType[string]{
+		"pool-a": cpuMem("64", "256Gi"),
+		"pool-b": cpuMem("32", "128Gi"),
+	}
+	allocationByPoolAndQueueAndPriorityClass := map[string]map[string]schedulerobjects.QuantityByTAndResourceType[string]{
+		"pool-a": {
+			"queue-a": {
+				testfixtures.PriorityClass0: cpuMem("8", "32Gi"),
+				testfixtures.PriorityClass1: cpuMem("8", "32Gi"),
+			},
+			"queue-b": {
+				testfixtures.PriorityClass0: cpuMem("16", "64Gi"),
+			},
+		},
+	}
+
+	// Two queued jobs attributed to pool-a, one to pool-b, and one that fits no pool.
+	queuedJobs := testfixtures.N1Cpu4GiJobs("queue-a", testfixtures.PriorityClass0, 4)
+	for i, job := range queuedJobs {
+		queuedJobs[i] = job.WithQueued(true)
+	}
+	runningJob := testfixtures.Test1Cpu4GiJob("queue-a", testfixtures.PriorityClass0).
+		WithQueued(false).
+		WithNewRun("executor", "node-id", "node", 0, time.Now())
+	poolAssigner := &MockPoolAssigner{
+		defaultPool: "pool-a",
+		poolsById: map[string]string{
+			queuedJobs[2].Id(): "pool-b",
+			queuedJobs[3].Id(): "",
+		},
+	}
+
+	actual, err := capacityByPool(
+		totalCapacityByPool,
+		allocationByPoolAndQueueAndPriorityClass,
+		append([]*jobdb.Job{runningJob}, queuedJobs...),
+		poolAssigner,
+	)
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+
+	poolA := actual["pool-a"]
+	assert.True(t, cpuMem("64", "256Gi").Equal(poolA.Allocatable), poolA.Allocatable.CompactString())
+	assert.True(t, cpuMem("32", "128Gi").Equal(poolA.Allocated), poolA.Allocated.CompactString())
+	assert.True(t, cpuMem("2", "8Gi").Equal(poolA.Demand), poolA.Demand.CompactString())
+	assert.Equal(t, 0.5, poolA.Utilisation("cpu"))
+	assert.Equal(t, 0.5, poolA.Utilisation("memory"))
+	assert.Equal(t, 0.0, poolA.Utilisation("nvidia.com/gpu"))
+
+	poolB := actual["pool-b"]
+	assert.True(t, cpuMem("32", "128Gi").Equal(poolB.Allocatable), poolB.Allocatable.CompactString())
+	assert.True(t, poolB.Allocated.IsZero(), poolB.Allocated.CompactString())
+	assert.True(t, cpuMem("1", "4Gi").Equal(poolB.Demand), poolB.Demand.CompactString())
+	assert.Equal(t, 0.0, poolB.Utilisation("cpu"))
+}
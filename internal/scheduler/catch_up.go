@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"sync/atomic"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+)
+
+// CatchUpState records whether the scheduler is catching up after becoming leader.
+// It's updated by the Scheduler and read by the ExecutorApi, which holds back new leases while catching up,
+// since lease sets read during this time may be stale or incomplete.
+type CatchUpState struct {
+	catchingUp atomic.Bool
+}
+
+func NewCatchUpState() *CatchUpState {
+	return &CatchUpState{}
+}
+
+// CatchingUp returns true if the scheduler is catching up.
+func (c *CatchUpState) CatchingUp() bool {
+	return c.catchingUp.Load()
+}
+
+// EnableCatchUpBackPressure causes the scheduler to mark state as catching up after becoming leader
+// until a cycle reads at most maxSerialLag new job and run serials from postgres.
+func (s *Scheduler) EnableCatchUpBackPressure(state *CatchUpState, maxSerialLag int64) {
+	s.catchUpState = state
+	s.maxCatchUpSerialLag = maxSerialLag
+}
+
+// updateCatchUpState is called at the end of each cycle.
+// becameLeader is true if leadership was acquired at the start of this cycle
+// and serialLag is the number of new serials read from postgres during the cycle.
+func (s *Scheduler) updateCatchUpState(ctx *armadacontext.Context, leaderToken LeaderToken, becameLeader bool, serialLag int64) {
+	if s.catchUpState == nil {
+		return
+	}
+	catchingUp := s.catchUpState.CatchingUp()
+	if !leaderToken.leader {
+		if catchingUp {
+			ctx.Infof("no longer leader; stopped catching up")
+			s.catchUpState.catchingUp.Store(false)
+		}
+		return
+	}
+	if becameLeader {
+		// The cycle in which we became leader always reads a backlog of updates;
+		// we only consider ourselves caught up once a subsequent cycle has little left to read.
+		ctx.Infof("catching up; no new leases will be distributed until serial lag is at most %d", s.maxCatchUpSerialLag)
+		s.catchUpStarted = s.clock.Now()
+		s.catchUpState.catchingUp.Store(true)
+		return
+	}
+	if catchingUp && serialLag <= s.maxCatchUpSerialLag {
+		catchingUpTime := s.clock.Since(s.catchUpStarted)
+		ctx.Infof("caught up in %s with serial lag %d", catchingUpTime, serialLag)
+		s.catchUpState.catchingUp.Store(false)
+		s.metrics.ReportCatchingUpTime(catchingUpTime)
+	}
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wallClockAllowlist contains the functions in the scheduler packages permitted to read the wall clock directly,
+// i.e., without going through an injected clock, indexed by "file:function" and mapped to the reason they're allowed.
+// Anything that affects scheduling decisions or the state written to the jobDb should use the injected clock instead,
+// such that it's deterministic under test and in the simulator.
+var wallClockAllowlist = map[string]string{
+	"dependency.go:Dependency.Start":                                  "measures how long dependencies take to construct",
+	"jobdb/jobdb.go:Txn.BatchDelete":                                  "measures how long updating the jobDb takes",
+	"jobdb/jobdb.go:Txn.Commit":                                       "measures how long committing takes",
+	"jobdb/jobdb.go:Txn.Upsert":                                       "measures how long updating the jobDb takes",
+	"metrics.go:MetricsCollector.refresh":                             "measures how long refreshing metrics takes",
+	"pool_assigner.go:DefaultPoolAssigner.AssignPool":                 "sets the creation time of a throwaway job scheduling context",
+	"preempting_queue_scheduler.go:PreemptingQueueScheduler.Schedule": "records when scheduling finished for reporting",
+	"preempting_queue_scheduler.go:NewNodeEvictor":                    "seeds the random number generator",
+	"preempting_queue_scheduler.go:NewOversubscribedEvictor":          "seeds the random number generator",
+	"publisher.go:now":                                                "timestamps published events",
+	"scheduling_algo.go:NewFairSchedulingAlgo":                        "seeds the random number generator",
+}
+
+// TestNoDirectWallClockAccess checks that no non-test file in the scheduler or jobdb package reads the wall clock
+// directly, except for in the functions listed in wallClockAllowlist.
+func TestNoDirectWallClockAccess(t *testing.T) {
+	found := make(map[string]bool)
+	for _, dir := range []string{".", "jobdb"} {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		require.NoError(t, err)
+		for _, path := range paths {
+			if strings.HasSuffix(path, "_test.go") {
+				continue
+			}
+			for _, location := range wallClockAccesses(t, path) {
+				found[location] = true
+				_, ok := wallClockAllowlist[location]
+				assert.True(t, ok, "%s reads the wall clock directly; use the injected clock instead", location)
+			}
+		}
+	}
+	for location := range wallClockAllowlist {
+		assert.True(t, found[location], "%s is allowlisted but no longer reads the wall clock; remove it from the allowlist", location)
+	}
+}
+
+// wallClockAccesses returns the "file:function" locations of all calls to time.Now, time.Since, and time.Until in the file at path.
+func wallClockAccesses(t *testing.T, path string) []string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	require.NoError(t, err)
+	timePackageName := ""
+	for _, imp := range file.Imports {
+		if imp.Path.Value != `"time"` {
+			continue
+		}
+		timePackageName = "time"
+		if imp.Name != nil {
+			timePackageName = imp.Name.Name
+		}
+	}
+	if timePackageName == "" {
+		return nil
+	}
+	var rv []string
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			selector, ok := node.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != timePackageName {
+				return true
+			}
+			switch selector.Sel.Name {
+			case "Now", "Since", "Until":
+				rv = append(rv, fmt.Sprintf("%s:%s", filepath.ToSlash(path), funcName(funcDecl)))
+			}
+			return true
+		})
+	}
+	return rv
+}
+
+// funcName returns the name of a function, prefixed with the name of its receiver type if it's a method.
+func funcName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	expr := funcDecl.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch typ := expr.(type) {
+	case *ast.IndexExpr:
+		expr = typ.X
+	case *ast.IndexListExpr:
+		expr = typ.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name + "." + funcDecl.Name.Name
+	}
+	return funcDecl.Name.Name
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// Phases of runs for which the latency from being leased is reported.
+const (
+	runPhasePending = "pending"
+	runPhaseRunning = "running"
+)
+
+// recordExecutorClockSkew records the clock skew of executor as of its most recent heartbeat,
+// such that timestamps it reports can be corrected, and reports it as exceeding the warning threshold if it does.
+func (s *Scheduler) recordExecutorClockSkew(ctx *armadacontext.Context, executor *schedulerobjects.Executor) {
+	skew := executor.ClockSkew()
+	if s.executorClockSkews == nil {
+		s.executorClockSkews = make(map[string]time.Duration)
+	}
+	s.executorClockSkews[executor.Id] = skew
+	exceeded := s.executorClockSkewWarningThreshold > 0 && absDuration(skew) > s.executorClockSkewWarningThreshold
+	s.metrics.ReportExecutorClockSkew(executor.Id, skew, exceeded)
+	if exceeded {
+		s.warnings.Warnf(
+			ctx, executor.Id, "Clock of executor %s is skewed by %s relative to that of the scheduler (threshold %s)",
+			executor.Id, skew, s.executorClockSkewWarningThreshold,
+		)
+	}
+}
+
+// reportRunPhaseLatencies reports the time from each run in jobRepoRuns being leased until it became pending
+// and running, for runs the jobDb knows about that are found running for the first time.
+// The times at which runs became pending and running are reported by executors according to their clocks
+// and are corrected for the skew of the executor's clock, whereas the time runs were leased is taken from the jobDb.
+// Must be called before jobRepoRuns are reconciled with the jobDb.
+func (s *Scheduler) reportRunPhaseLatencies(txn *jobdb.Txn, jobRepoRuns []database.Run) {
+	for _, jobRepoRun := range jobRepoRuns {
+		if jobRepoRun.RunningTimestamp == nil {
+			continue
+		}
+		job := txn.GetById(jobRepoRun.JobID)
+		if job == nil {
+			continue
+		}
+		run := job.RunById(jobRepoRun.RunID)
+		if run == nil || run.Running() {
+			continue
+		}
+		leased := time.Unix(0, run.Created())
+		skew := s.executorClockSkews[jobRepoRun.Executor]
+		if jobRepoRun.PendingTimestamp != nil {
+			s.metrics.ReportRunPhaseLatency(jobRepoRun.Executor, runPhasePending, phaseLatency(leased, *jobRepoRun.PendingTimestamp, skew))
+		}
+		s.metrics.ReportRunPhaseLatency(jobRepoRun.Executor, runPhaseRunning, phaseLatency(leased, *jobRepoRun.RunningTimestamp, skew))
+	}
+}
+
+// phaseLatency returns the time from leased, according to the scheduler's clock, until reported,
+// according to the clock of an executor skewed by skew relative to that of the scheduler.
+// Since the skew measured includes the time taken for heartbeats to reach the scheduler,
+// corrected latencies may be slightly negative; these are reported as zero.
+func phaseLatency(leased, reported time.Time, skew time.Duration) time.Duration {
+	latency := reported.Add(-skew).Sub(leased)
+	if latency < 0 {
+		return 0
+	}
+	return latency
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
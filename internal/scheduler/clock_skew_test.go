@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestExecutor_ClockSkew(t *testing.T) {
+	ahead := testfixtures.BaseTime.Add(time.Hour)
+	behind := testfixtures.BaseTime.Add(-time.Hour)
+	assert.Equal(t, time.Hour, (&schedulerobjects.Executor{LastUpdateTime: testfixtures.BaseTime, LastReportedTime: &ahead}).ClockSkew())
+	assert.Equal(t, -time.Hour, (&schedulerobjects.Executor{LastUpdateTime: testfixtures.BaseTime, LastReportedTime: &behind}).ClockSkew())
+	assert.Equal(t, time.Duration(0), (&schedulerobjects.Executor{LastUpdateTime: testfixtures.BaseTime}).ClockSkew())
+}
+
+func TestScheduler_ExecutorClockSkew(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+
+	// All executors heartbeated a minute ago according to the scheduler's clock,
+	// but the clock of one is an hour ahead and that of another an hour behind.
+	received := testClock.Now().Add(-time.Minute)
+	reportedAhead := received.Add(time.Hour)
+	reportedBehind := received.Add(-time.Hour)
+	executorRepo := &testExecutorRepository{
+		executors: []*schedulerobjects.Executor{
+			{Id: "ahead", LastUpdateTime: received, LastReportedTime: &reportedAhead},
+			{Id: "behind", LastUpdateTime: received, LastReportedTime: &reportedBehind},
+			{Id: "unreported", LastUpdateTime: received},
+		},
+	}
+	registry := prometheus.NewRegistry()
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+	}, registry)
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&testJobRepository{},
+		executorRepo,
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		// Shorter than the skew, such that executors would be considered stale if their clocks were relied upon.
+		30*time.Minute,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		metrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+	sched.EnableExecutorClockSkewWarnings(time.Minute)
+
+	jobByExecutor := map[string]*jobdb.Job{
+		"ahead":      jobLeasedOnExecutor("ahead"),
+		"behind":     jobLeasedOnExecutor("behind"),
+		"unreported": jobLeasedOnExecutor("unreported"),
+	}
+	txn := sched.jobDb.WriteTxn()
+	defer txn.Abort()
+	for _, job := range jobByExecutor {
+		require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	}
+
+	// Staleness is unaffected by skew, since it's based on when the scheduler received heartbeats.
+	events, err := sched.expireJobsIfNecessary(ctx, txn)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	for executorId, job := range jobByExecutor {
+		assert.False(t, txn.GetById(job.Id()).Failed())
+		assert.Equal(t, 0.0, testutil.ToFloat64(metrics.staleExecutors.WithLabelValues(executorId)))
+	}
+
+	// Skew is measured for each executor and reported as exceeding the threshold if it does.
+	assert.Equal(t, map[string]time.Duration{"ahead": time.Hour, "behind": -time.Hour, "unreported": 0}, sched.executorClockSkews)
+	assert.Equal(t, time.Hour.Seconds(), testutil.ToFloat64(metrics.executorClockSkew.WithLabelValues("ahead")))
+	assert.Equal(t, -time.Hour.Seconds(), testutil.ToFloat64(metrics.executorClockSkew.WithLabelValues("behind")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.executorClockSkewExceeded.WithLabelValues("ahead")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.executorClockSkewExceeded.WithLabelValues("behind")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.executorClockSkewExceeded.WithLabelValues("unreported")))
+
+	// Runs were leased at BaseTime. Each became pending 10 seconds and running 30 seconds later,
+	// as reported according to the skewed clock of its executor.
+	runningRuns := func() []database.Run {
+		var runs []database.Run
+		for executorId, job := range jobByExecutor {
+			pending := testfixtures.BaseTime.Add(10*time.Second + sched.executorClockSkews[executorId])
+			running := testfixtures.BaseTime.Add(30*time.Second + sched.executorClockSkews[executorId])
+			runs = append(runs, database.Run{
+				RunID:            job.LatestRun().Id(),
+				JobID:            job.Id(),
+				Executor:         executorId,
+				Running:          true,
+				PendingTimestamp: &pending,
+				RunningTimestamp: &running,
+			})
+		}
+		return runs
+	}
+	sched.reportRunPhaseLatencies(txn, runningRuns())
+	for executorId := range jobByExecutor {
+		assert.Equal(t, runPhaseLatencySample{count: 1, sum: 10}, gatherRunPhaseLatency(t, registry, executorId, runPhasePending), executorId)
+		assert.Equal(t, runPhaseLatencySample{count: 1, sum: 30}, gatherRunPhaseLatency(t, registry, executorId, runPhaseRunning), executorId)
+	}
+
+	// Latencies are reported once per run, when it's first found running.
+	for _, job := range jobByExecutor {
+		require.NoError(t, txn.Upsert([]*jobdb.Job{job.WithUpdatedRun(job.LatestRun().WithRunning(true))}))
+	}
+	sched.reportRunPhaseLatencies(txn, runningRuns())
+	for executorId := range jobByExecutor {
+		assert.Equal(t, uint64(1), gatherRunPhaseLatency(t, registry, executorId, runPhaseRunning).count, executorId)
+	}
+}
+
+type runPhaseLatencySample struct {
+	count uint64
+	sum   float64
+}
+
+// gatherRunPhaseLatency returns the number and sum of the run phase latencies reported to registry for the given executor and phase.
+func gatherRunPhaseLatency(t *testing.T, registry *prometheus.Registry, executorId string, phase string) runPhaseLatencySample {
+	metricFamilies, err := registry.Gather()
+	require.NoError(t, err)
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.GetName() != NAMESPACE+"_"+SUBSYSTEM+"_run_phase_latency_seconds" {
+			continue
+		}
+		for _, metric := range metricFamily.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["executor"] == executorId && labels["phase"] == phase {
+				return runPhaseLatencySample{count: metric.GetHistogram().GetSampleCount(), sum: metric.GetHistogram().GetSampleSum()}
+			}
+		}
+	}
+	return runPhaseLatencySample{}
+}
@@ -12,6 +12,7 @@ import (
 	armadaslices "github.com/armadaproject/armada/internal/common/slices"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
@@ -64,7 +65,14 @@ func JobsSummary(jctxs []*schedulercontext.JobSchedulingContext) string {
 }
 
 // GangIdAndCardinalityFromLegacySchedulerJob returns a tuple (gangId, gangCardinality, gangMinimumCardinality, isGangJob, error).
+// For jobs whose gang was checkpointed when they were admitted, the checkpoint is returned;
+// see jobdb.JobDb.EnableParameterCheckpoints.
 func GangIdAndCardinalityFromLegacySchedulerJob(job interfaces.LegacySchedulerJob) (string, int, int, bool, error) {
+	if job, ok := job.(*jobdb.Job); ok {
+		if gangId, gangCardinality, gangMinimumCardinality, ok := job.CheckpointedGangInfo(); ok {
+			return gangId, gangCardinality, gangMinimumCardinality, gangId != "", nil
+		}
+	}
 	return GangIdAndCardinalityFromAnnotations(job.GetAnnotations())
 }
 
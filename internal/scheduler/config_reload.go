@@ -0,0 +1,276 @@
+package scheduler
+
+import (
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/common/metrics"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+)
+
+// ConfigLoader re-reads the config the scheduler was started with.
+type ConfigLoader func() (schedulerconfig.Configuration, error)
+
+// ConfigReloadable is implemented by components that apply reloaded configs.
+// ReloadConfig is only called with configs that have been validated and that differ from the previous config
+// only in the settings copied by withReloadableSettings.
+type ConfigReloadable interface {
+	ReloadConfig(config schedulerconfig.Configuration) error
+}
+
+// ConfigReloader re-reads and validates the scheduler config on request, keeping track of the most recent valid config.
+// Reloaded configs are applied by the scheduler at the start of its next cycle; see Scheduler.EnableConfigReload.
+type ConfigReloader struct {
+	load ConfigLoader
+	// Most recent valid config, together with the number of times it's been changed by reloading.
+	config     schedulerconfig.Configuration
+	generation uint64
+	// Number of times the config couldn't be reloaded, e.g., since it was invalid.
+	failures         prometheus.Counter
+	generationMetric prometheus.Gauge
+	// If non-nil, reloaded configs are rejected if jobs in flight have priority classes missing from them;
+	// see EnablePriorityClassCheck.
+	priorityClassSampleRepository database.PriorityClassSampleRepository
+	mu                            sync.Mutex
+}
+
+func NewConfigReloader(config schedulerconfig.Configuration, load ConfigLoader) *ConfigReloader {
+	return &ConfigReloader{
+		load:   load,
+		config: config,
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: metrics.MetricPrefix + "scheduler_config_reload_failures",
+			Help: "Number of times the scheduler failed to reload its config, e.g., since the new config was invalid.",
+		}),
+		generationMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: metrics.MetricPrefix + "scheduler_config_generation",
+			Help: "Number of times the config of the scheduler has been changed by reloading it since the scheduler started.",
+		}),
+	}
+}
+
+// EnablePriorityClassCheck causes reloaded configs with the priority class check enabled to be checked against
+// the priority classes of a sample of the jobs in flight, read from repository; see CheckPriorityClasses.
+// Priority classes can't be changed without restarting, so this rejects configs the scheduler would refuse to start with.
+func (r *ConfigReloader) EnablePriorityClassCheck(repository database.PriorityClassSampleRepository) {
+	r.priorityClassSampleRepository = repository
+}
+
+// Config returns the current config and its generation,
+// which is zero for the config the scheduler was started with and is incremented each time a reload changes the config.
+func (r *ConfigReloader) Config() (schedulerconfig.Configuration, uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.config, r.generation
+}
+
+// Reload re-reads the config and, if valid, takes from it the settings that may be changed without restarting.
+// Otherwise, an error is returned and the current config is kept.
+func (r *ConfigReloader) Reload(ctx *armadacontext.Context) error {
+	config, err := r.load()
+	if err == nil {
+		err = validateReloadedConfig(config)
+	}
+	if err == nil && r.priorityClassSampleRepository != nil && config.PriorityClassCheck.Enabled {
+		err = CheckPriorityClasses(ctx, r.priorityClassSampleRepository, config)
+	}
+	if err != nil {
+		r.failures.Inc()
+		return errors.WithMessage(err, "failed to reload config; keeping the current config")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reloaded := withReloadableSettings(r.config, config)
+	if !reflect.DeepEqual(reloaded, config) {
+		ctx.Warn("reloaded config changes settings that can't be changed without restarting; these changes are ignored")
+	}
+	if reflect.DeepEqual(reloaded, r.config) {
+		ctx.Info("reloaded config is unchanged")
+		return nil
+	}
+	r.config = reloaded
+	r.generation++
+	r.generationMetric.Set(float64(r.generation))
+	ctx.Infof("reloaded config; config generation is now %d", r.generation)
+	return nil
+}
+
+// Run reloads the config whenever SIGHUP is received or, if pollPeriod is positive,
+// whenever the modification time of any of paths changes, until ctx is cancelled.
+func (r *ConfigReloader) Run(ctx *armadacontext.Context, paths []string, pollPeriod time.Duration) error {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	var poll <-chan time.Time
+	if pollPeriod > 0 {
+		ticker := time.NewTicker(pollPeriod)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+	modTimes := configFileModTimes(paths)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sighup:
+			ctx.Info("received SIGHUP; reloading config")
+		case <-poll:
+			newModTimes := configFileModTimes(paths)
+			if reflect.DeepEqual(modTimes, newModTimes) {
+				continue
+			}
+			modTimes = newModTimes
+			ctx.Info("config files modified; reloading config")
+		}
+		if err := r.Reload(ctx); err != nil {
+			logging.WithStacktrace(ctx, err).Error("failed to reload config")
+		}
+	}
+}
+
+func (r *ConfigReloader) Describe(desc chan<- *prometheus.Desc) {
+	r.failures.Describe(desc)
+	r.generationMetric.Describe(desc)
+}
+
+func (r *ConfigReloader) Collect(metrics chan<- prometheus.Metric) {
+	r.failures.Collect(metrics)
+	r.generationMetric.Collect(metrics)
+}
+
+// configFileModTimes returns the modification time of each of paths. Files that can't be read are omitted.
+func configFileModTimes(paths []string) map[string]time.Time {
+	rv := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			rv[path] = info.ModTime()
+		}
+	}
+	return rv
+}
+
+// validateReloadedConfig returns an error if config is invalid,
+// including if any of the settings it'd be reloaded with can't be applied.
+func validateReloadedConfig(config schedulerconfig.Configuration) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if len(config.RunErrorClassification) > 0 {
+		if _, err := NewRunErrorClassifier(config.RunErrorClassification); err != nil {
+			return err
+		}
+	}
+	if config.Scheduling.ExecutorUpdateFrequency <= 0 {
+		return errors.Errorf("executorUpdateFrequency must be positive, but is %s", config.Scheduling.ExecutorUpdateFrequency)
+	}
+	return nil
+}
+
+// withReloadableSettings returns a copy of current with the settings that may be changed without restarting taken from reloaded:
+// rate limits, per-round scheduling limits, the max number of retries, run error classification rules (which control backoff),
+// for how long the errors of runs are waited for, and how often the submit checker fetches executors.
+func withReloadableSettings(current, reloaded schedulerconfig.Configuration) schedulerconfig.Configuration {
+	rv := current
+	rv.Scheduling.MaximumSchedulingRate = reloaded.Scheduling.MaximumSchedulingRate
+	rv.Scheduling.MaximumSchedulingBurst = reloaded.Scheduling.MaximumSchedulingBurst
+	rv.Scheduling.MaximumPerQueueSchedulingRate = reloaded.Scheduling.MaximumPerQueueSchedulingRate
+	rv.Scheduling.MaximumPerQueueSchedulingBurst = reloaded.Scheduling.MaximumPerQueueSchedulingBurst
+	rv.Scheduling.MaximumResourceFractionToSchedule = reloaded.Scheduling.MaximumResourceFractionToSchedule
+	rv.Scheduling.MaximumResourceFractionToScheduleByPool = reloaded.Scheduling.MaximumResourceFractionToScheduleByPool
+	rv.Scheduling.MaxQueueLookback = reloaded.Scheduling.MaxQueueLookback
+	rv.Scheduling.MaxRetries = reloaded.Scheduling.MaxRetries
+	rv.RunErrorClassification = reloaded.RunErrorClassification
+	rv.RunErrorBackfill.Ttl = reloaded.RunErrorBackfill.Ttl
+	rv.Scheduling.ExecutorUpdateFrequency = reloaded.Scheduling.ExecutorUpdateFrequency
+	return rv
+}
+
+// ReloadConfig applies the rate limits and per-round scheduling limits of config from the next scheduling round on.
+func (l *FairSchedulingAlgo) ReloadConfig(config schedulerconfig.Configuration) error {
+	l.schedulingConfig.MaximumSchedulingRate = config.Scheduling.MaximumSchedulingRate
+	l.schedulingConfig.MaximumSchedulingBurst = config.Scheduling.MaximumSchedulingBurst
+	l.schedulingConfig.MaximumPerQueueSchedulingRate = config.Scheduling.MaximumPerQueueSchedulingRate
+	l.schedulingConfig.MaximumPerQueueSchedulingBurst = config.Scheduling.MaximumPerQueueSchedulingBurst
+	l.schedulingConfig.MaximumResourceFractionToSchedule = config.Scheduling.MaximumResourceFractionToSchedule
+	l.schedulingConfig.MaximumResourceFractionToScheduleByPool = config.Scheduling.MaximumResourceFractionToScheduleByPool
+	l.schedulingConfig.MaxQueueLookback = config.Scheduling.MaxQueueLookback
+	l.limiter.SetLimit(rate.Limit(config.Scheduling.MaximumSchedulingRate))
+	l.limiter.SetBurst(config.Scheduling.MaximumSchedulingBurst)
+	for _, limiter := range l.limiterByQueue {
+		limiter.SetLimit(rate.Limit(config.Scheduling.MaximumPerQueueSchedulingRate))
+		limiter.SetBurst(config.Scheduling.MaximumPerQueueSchedulingBurst)
+	}
+	if l.schedulingContextRepository != nil {
+		return l.schedulingContextRepository.ReloadConfig(config)
+	}
+	return nil
+}
+
+// ReloadConfig applies the retry, backoff, and run error backfill settings of config from the next cycle on.
+func (s *Scheduler) ReloadConfig(config schedulerconfig.Configuration) error {
+	var runErrorClassifier *RunErrorClassifier
+	if len(config.RunErrorClassification) > 0 {
+		var err error
+		if runErrorClassifier, err = NewRunErrorClassifier(config.RunErrorClassification); err != nil {
+			return err
+		}
+	}
+	s.runErrorClassifier = runErrorClassifier
+	s.maxAttemptedRuns = config.Scheduling.MaxRetries + 1
+	if s.jobDb != nil {
+		// Jobs already admitted keep the maximum they were checkpointed with, if any.
+		s.jobDb.SetMaxAttemptedRuns(s.maxAttemptedRuns)
+	}
+	if s.runErrorBackfill != nil {
+		s.runErrorBackfill.ttl = config.RunErrorBackfill.Ttl
+	}
+	return nil
+}
+
+// ReloadConfig applies the executor update frequency of config from the next time executors are fetched on.
+// Has no effect if executors are updated from snapshots; see EnableExecutorSnapshots.
+func (srv *SubmitChecker) ReloadConfig(config schedulerconfig.Configuration) error {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.ExecutorUpdateFrequency = config.Scheduling.ExecutorUpdateFrequency
+	return nil
+}
+
+// EnableConfigReload causes configs reloaded by reloader to be applied at the start of each cycle
+// to the scheduler and to any of its components that implement ConfigReloadable.
+func (s *Scheduler) EnableConfigReload(reloader *ConfigReloader) {
+	s.configReloader = reloader
+}
+
+// applyReloadedConfig applies the config of s.configReloader if it's changed since it was last applied.
+func (s *Scheduler) applyReloadedConfig(ctx *armadacontext.Context) {
+	if s.configReloader == nil {
+		return
+	}
+	config, generation := s.configReloader.Config()
+	if generation == s.configGeneration {
+		return
+	}
+	for _, component := range []any{s, s.schedulingAlgo, s.submitChecker} {
+		if reloadable, ok := component.(ConfigReloadable); ok {
+			if err := reloadable.ReloadConfig(config); err != nil {
+				logging.WithStacktrace(ctx, err).Errorf("failed to apply config generation %d", generation)
+			}
+		}
+	}
+	s.configGeneration = generation
+	ctx.Infof("applied config generation %d", generation)
+}
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestConfigReload(t *testing.T) {
+	ctx := armadacontext.Background()
+	path := filepath.Join(t.TempDir(), "override.yaml")
+	writeConfig := func(config string) {
+		require.NoError(t, os.WriteFile(path, []byte(config), 0o644))
+	}
+	load := func() (schedulerconfig.Configuration, error) {
+		var config schedulerconfig.Configuration
+		if _, err := common.ReadConfig(&config, "../../config/scheduler", []string{path}); err != nil {
+			return config, err
+		}
+		return config, config.Validate()
+	}
+
+	writeConfig("scheduling:\n  maximumSchedulingRate: 0.000001\n  maximumSchedulingBurst: 2\n")
+	initialConfig, err := load()
+	require.NoError(t, err)
+	reloader := NewConfigReloader(initialConfig, load)
+
+	schedulingConfig := testfixtures.TestSchedulingConfig()
+	schedulingConfig.MaximumSchedulingRate = initialConfig.Scheduling.MaximumSchedulingRate
+	schedulingConfig.MaximumSchedulingBurst = initialConfig.Scheduling.MaximumSchedulingBurst
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{testfixtures.Test1Node32CoreExecutor("executor1")}, nil).AnyTimes()
+	mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+	mockQueueRepo.EXPECT().GetAllQueues().Return([]*database.Queue{{Name: "A", Weight: 1}}, nil).AnyTimes()
+	algo, err := NewFairSchedulingAlgo(schedulingConfig, 0, mockExecutorRepo, mockQueueRepo, nil)
+	require.NoError(t, err)
+	algo.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	submitChecker := NewSubmitChecker(initialConfig.ExecutorTimeout, initialConfig.Scheduling, mockExecutorRepo)
+	scheduler := &Scheduler{schedulingAlgo: algo, submitChecker: submitChecker}
+	scheduler.EnableConfigReload(reloader)
+
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 16))))
+	txn.Commit()
+	runCycle := func() int {
+		scheduler.applyReloadedConfig(ctx)
+		txn := jobDb.WriteTxn()
+		result, err := algo.Schedule(ctx, txn)
+		require.NoError(t, err)
+		txn.Commit()
+		return len(result.ScheduledJobs)
+	}
+
+	// The burst is used up by the first cycle and the rate is too low for tokens to be replenished.
+	assert.Equal(t, 2, runCycle())
+	assert.Equal(t, 0, runCycle())
+
+	// An invalid config is rejected and the current config kept.
+	writeConfig("scheduling:\n  maximumSchedulingRate: 0\n  maximumSchedulingBurst: 5\n")
+	assert.Error(t, reloader.Reload(ctx))
+	writeConfig("scheduling:\n  executorUpdateFrequency: 0s\n")
+	assert.Error(t, reloader.Reload(ctx))
+	config, generation := reloader.Config()
+	assert.Equal(t, uint64(0), generation)
+	assert.Equal(t, initialConfig, config)
+	assert.Equal(t, 2.0, testutil.ToFloat64(reloader.failures))
+	assert.Equal(t, 0, runCycle())
+
+	// A valid config is used from the next cycle on.
+	writeConfig("scheduling:\n  maximumSchedulingRate: 1000000000\n  maximumSchedulingBurst: 5\n  executorUpdateFrequency: 10s\n")
+	require.NoError(t, reloader.Reload(ctx))
+	config, generation = reloader.Config()
+	assert.Equal(t, uint64(1), generation)
+	assert.Equal(t, 5, config.Scheduling.MaximumSchedulingBurst)
+	assert.Equal(t, 1.0, testutil.ToFloat64(reloader.generationMetric))
+	assert.Equal(t, time.Minute, submitChecker.executorUpdateFrequency())
+	assert.Equal(t, 5, runCycle())
+	assert.Equal(t, uint64(1), scheduler.configGeneration)
+	assert.Equal(t, 10*time.Second, submitChecker.executorUpdateFrequency())
+
+	// Reloading an unchanged config doesn't change the generation.
+	require.NoError(t, reloader.Reload(ctx))
+	_, generation = reloader.Config()
+	assert.Equal(t, uint64(1), generation)
+}
+
+func TestWithReloadableSettings(t *testing.T) {
+	current := schedulerconfig.Configuration{CyclePeriod: 1}
+	current.Scheduling.MaxRetries = 1
+	reloaded := schedulerconfig.Configuration{CyclePeriod: 2}
+	reloaded.Scheduling.MaxRetries = 3
+	reloaded.Scheduling.DisableScheduling = true
+
+	actual := withReloadableSettings(current, reloaded)
+	assert.Equal(t, uint(3), actual.Scheduling.MaxRetries)
+	// Settings that can't be changed without restarting are kept.
+	assert.Equal(t, current.CyclePeriod, actual.CyclePeriod)
+	assert.False(t, actual.Scheduling.DisableScheduling)
+}
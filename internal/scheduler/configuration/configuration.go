@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/armadaproject/armada/internal/armada/configuration"
 	authconfig "github.com/armadaproject/armada/internal/common/auth/configuration"
@@ -56,6 +57,134 @@ type Configuration struct {
 	DatabaseFetchSize int `validate:"required"`
 	// Timeout to use when sending messages to pulsar
 	PulsarSendTimeout time.Duration `validate:"required"`
+	// Controls how messages are sent to pulsar within each publish.
+	PulsarPublishing PulsarPublishingConfig
+	// Controls how jobs submitted to queues that don't exist in the queue repository are handled.
+	UnknownQueues UnknownQueuesConfig
+	// Controls back-pressure applied to executors while the scheduler catches up after becoming leader.
+	CatchUp CatchUpConfig
+	// Controls estimation of how long jobs wait in each queue before being scheduled.
+	WaitTimeEstimation WaitTimeEstimationConfig
+	// Controls the admin endpoint used to force the scheduler to reconsider a job.
+	JobNudges JobNudgesConfig
+	// Controls how dependencies are set up on startup.
+	Startup StartupConfig
+	// Jobs that must run at most once are never retried after their lease is returned.
+	// If true, such jobs are instead retried once if the executor reports it never acted on the lease.
+	RetryUnacknowledgedAtMostOnceJobs bool
+	// Controls tracking of how spread out the runs of each job set are.
+	JobSetPlacement JobSetPlacementConfig
+	// Controls the fast path used to lease jobs of urgent priority classes without waiting for a scheduling round.
+	UrgentScheduling UrgentSchedulingConfig
+	// If true, jobs for which the database provides scheduling info with a lower version than, or the same version but
+	// different contents to, that held by the scheduler are re-fetched from the database to determine which is right.
+	RefetchOnSchedulingInfoConflict bool
+	// If true, queued jobs requesting more of some resource than the largest node in any pool has allocatable are failed,
+	// rather than remaining queued until they expire. Such jobs are reported as unschedulable regardless.
+	FailJobsExceedingLargestNode bool
+	// Jobs are only failed for exceeding the largest node once the largest node resources have remained unchanged
+	// for this long, such that nodes of executors that are still being loaded or have briefly gone stale are accounted for.
+	LargestNodeStabilisationPeriod time.Duration
+	// Controls limits on the number of jobs queued in each queue.
+	QueueBacklogLimits QueueBacklogLimitsConfig
+	// Controls publishing the errors of runs that are marked failed before their error is written to the database.
+	RunErrorBackfill RunErrorBackfillConfig
+	// Rules used to classify the errors of failed runs to determine whether their job is retried.
+	// Rules are evaluated in order and the first matching rule applies.
+	// If no rule matches, whether the job is retried is determined by whether the run was returned and how many times
+	// the job has been attempted.
+	RunErrorClassification []RunErrorClassificationRule
+	// Controls escalation of run cancellations executors don't act on.
+	CancellationEnforcement CancellationEnforcementConfig
+	// If positive, the scheduler reports unhealthy once this many consecutive cycles have failed
+	// due to transient database errors, e.g., because postgres is unreachable.
+	MaxConsecutiveTransientCycleFailures int
+	// Controls splitting queues between several schedulers, each of which schedules the jobs of its own shard.
+	Sharding ShardingConfig
+	// Controls monitoring of how long ago the oldest job or run update not yet processed by the scheduler was made.
+	UpdateStaleness UpdateStalenessConfig
+	// Controls use of the resource usage executors report for their runs.
+	RunResourceUsage RunResourceUsageConfig
+	// If true, the jobDb stores the scheduling info of each job in serialised form, only unmarshalling it when needed,
+	// e.g., when the job is considered for scheduling. Reduces memory usage when many jobs are queued.
+	LazyJobSchedulingInfo bool
+	// Controls detection of the database's serials having regressed below those the scheduler has read up to.
+	SerialRegression SerialRegressionConfig
+	// If positive, at most this many new leases are sent to an executor per lease request, regardless of how many
+	// it requests. Remaining leases are sent in subsequent requests, in the order their jobs were scheduled in.
+	MaxLeasesPerExecutorRequest uint
+	// Controls capping the priority of jobs at the maximum job priority of their queue.
+	QueuePriorityCaps QueuePriorityCapsConfig
+	// Controls quarantining queues found to cause the scheduling algorithm to panic.
+	QueueQuarantine QueueQuarantineConfig
+	// One of "full" or "observer". Defaults to "full" if empty.
+	Role Role `validate:"omitempty,oneof=full observer"`
+	// Controls quarantining nodes on which many runs fail in a row.
+	NodeQuarantine NodeQuarantineConfig
+	// Controls serving executor leases from the jobDb of the leader instead of from postgres.
+	JobDbLeases JobDbLeasesConfig
+	// Controls retrying updates of runs of jobs unknown to the scheduler.
+	RunUpdateQuarantine RunUpdateQuarantineConfig
+	// Controls reloading a subset of the config without restarting the scheduler.
+	ConfigReload ConfigReloadConfig
+	// Controls tracking of idle executors and the scale-down hints derived from it.
+	ScaleHints ScaleHintsConfig
+	// Controls coalescing of identical warnings logged by the scheduling cycle.
+	WarningCoalescing WarningCoalescingConfig
+	// Controls deduplication of lease requests and event reports executors repeat, e.g., after timing out.
+	RequestDeduplication RequestDeduplicationConfig
+	// Controls the per-pool summary of allocatable resources, allocated resources, and queued demand.
+	CapacitySummary CapacitySummaryConfig
+	// If true, node ids are created by prefixing the executor id with its length, e.g., "10:executor-a-node-b",
+	// rather than by joining the executor id and node name with a '-', which may result in distinct nodes sharing an id.
+	// Executor state stored beforehand is migrated when read, and runs are assigned new node ids when loaded on startup.
+	// If disabled again, executor state stored with length-prefixed node ids is replaced once each executor next
+	// reports its nodes.
+	LengthPrefixedNodeIds bool
+	// If true, a JobValidated event is published for each job the first time the scheduler admits it,
+	// i.e., finds it queued without it ever having been leased. The event is published at most once per job.
+	PublishJobValidatedEvents bool
+	// Controls notifying about jobs failed since they were attempted the maximum number of times.
+	RetryExhaustionNotifications RetryExhaustionNotificationsConfig
+	// Controls sharing a single periodically refreshed view of executors and their nodes between the submit checker,
+	// the pool assigners, and the scheduling algorithm.
+	ExecutorSnapshots ExecutorSnapshotsConfig
+	// Executors whose clock is skewed relative to that of the scheduler by more than this are reported as such,
+	// via a metric and a warning. Skew is measured using the time at which executors report sending each heartbeat.
+	// If zero, skew is still measured and corrected for, but never reported as exceeding the threshold.
+	ExecutorClockSkewWarningThreshold time.Duration
+	// Controls checking the events generated by each cycle against the state transitions it applied to the jobDb.
+	EventConsistency EventConsistencyConfig
+	// Controls checking job and run state transitions against the legal transitions between their phases.
+	JobStateMachine JobStateMachineConfig
+	// Controls how runs the database reports as both succeeded and failed are reconciled.
+	ContradictoryRuns ContradictoryRunsConfig
+	// Controls checkpointing the effective parameters of jobs, e.g., their queueTtl and gang, when they're admitted.
+	ParameterCheckpoints ParameterCheckpointsConfig
+	// Controls exporting a record of each completed run to Parquet files for offline analysis.
+	RunAnalytics RunAnalyticsConfig
+	// Controls accepting lease requests from executors that report only the nodes changed since their previous request.
+	ExecutorNodeDeltas ExecutorNodeDeltasConfig
+	// Controls tracking the rates at which jobs of each queue and job set are admitted, cancelled, requeued, and failed.
+	EventRates EventRatesConfig
+	// Controls computing how fragmented the free capacity of each pool is and recommending jobs to move to reduce it.
+	Fragmentation FragmentationConfig
+	// Controls checking, on startup and on reloading the config, that jobs in flight don't use priority classes
+	// missing from the config.
+	PriorityClassCheck PriorityClassCheckConfig
+	// Controls jittering the periods between cycles, scheduling rounds, and metrics refreshes,
+	// such that scheduler replicas and the components of each replica don't load the database in lockstep.
+	CycleJitter CycleJitterConfig
+	// Controls checking that each scheduling round only writes jobs to the jobDb the scheduling algorithm may change.
+	JobDbWriteGuard JobDbWriteGuardConfig
+	// Controls recording the latency, payload sizes, and errors of executor api requests per executor.
+	ExecutorApiMetrics ExecutorApiMetricsConfig
+	// Names of the feature gates enabled for each queue, such that new scheduling behaviour can be rolled out to
+	// specific queues first; see scheduler.FeatureGate for the gates available. Unknown gates are ignored.
+	QueueFeatureGates map[string][]string
+	// If true, replicas that aren't leader run scheduling rounds whose decisions are discarded, at most once per
+	// schedule period, such that the scheduling algorithm is warm by the time they become leader and failing over is quick.
+	ShadowScheduling bool
 }
 
 func (c Configuration) Validate() error {
@@ -79,6 +208,10 @@ type MetricsConfig struct {
 	// Controls the cycle time metrics.
 	// TODO(albin): Not used yet.
 	CycleTimeConfig PrometheusSummaryConfig
+	// If true, the time each jobDb write transaction spends updating the tree storing jobs and updating indices
+	// is measured and exported, in addition to the commit duration and number of jobs written.
+	// Intended for debugging, since measuring adds overhead to every write.
+	JobDbCommitBreakdown bool
 }
 
 // PrometheusSummaryConfig contains the relevant config for a prometheus.Summary.
@@ -115,8 +248,569 @@ type LeaderConfig struct {
 	RetryPeriod time.Duration
 	// Connection details to the leader
 	LeaderConnection client.ApiConnectionDetails
+	// How often to update the status of the "leader" service reported via the gRPC health service.
+	HealthUpdatePeriod time.Duration `validate:"required"`
+}
+
+// Role determines which parts of the scheduler a replica runs.
+type Role string
+
+const (
+	// RoleFull replicas are candidates for leadership, serve the executor api, and publish scheduling decisions;
+	// this is the default.
+	RoleFull Role = "full"
+	// RoleObserver replicas never become leader, don't serve the executor api, and never publish.
+	// They keep their jobDb up to date and serve scheduling reports from it, without proxying requests to the leader.
+	RoleObserver Role = "observer"
+)
+
+// UnknownQueuePolicy determines what the scheduler does with jobs submitted to a queue that doesn't exist.
+type UnknownQueuePolicy string
+
+const (
+	// UnknownQueuePolicyIgnore leaves such jobs queued indefinitely; this is the default.
+	UnknownQueuePolicyIgnore UnknownQueuePolicy = "Ignore"
+	// UnknownQueuePolicyFail fails such jobs as soon as they're seen.
+	UnknownQueuePolicyFail UnknownQueuePolicy = "Fail"
+	// UnknownQueuePolicyAutoCreate creates the missing queue with default parameters.
+	UnknownQueuePolicyAutoCreate UnknownQueuePolicy = "AutoCreate"
+	// UnknownQueuePolicyHold leaves such jobs queued for a grace period, after which they're failed
+	// unless the queue has been created in the meantime.
+	UnknownQueuePolicyHold UnknownQueuePolicy = "Hold"
+)
+
+// SerialRegressionPolicy determines what the scheduler does upon detecting that the database's serials have regressed.
+type SerialRegressionPolicy string
+
+const (
+	// SerialRegressionPolicyHalt stops the scheduler from processing further updates, such that it reports unhealthy
+	// until restarted; this is the default.
+	SerialRegressionPolicyHalt SerialRegressionPolicy = "Halt"
+	// SerialRegressionPolicyRebuild resets the serials read up to and rebuilds the jobDb from scratch.
+	SerialRegressionPolicyRebuild SerialRegressionPolicy = "Rebuild"
+)
+
+type SerialRegressionConfig struct {
+	// If true, the highest serials assigned by the database are compared with those the scheduler has read up to
+	// before each fetch of job and run updates. If the former are lower, e.g., because the database was restored
+	// from a backup, the scheduler would otherwise never receive another update.
+	Enabled bool
+	// Regressions by at most this many serials are ignored.
+	Tolerance int64 `validate:"gte=0"`
+	// One of "Halt" or "Rebuild". Defaults to "Halt" if empty.
+	Policy SerialRegressionPolicy `validate:"omitempty,oneof=Halt Rebuild"`
+}
+
+// JobStateMachineMode determines what the scheduler does upon an illegal job or run state transition.
+type JobStateMachineMode string
+
+const (
+	// JobStateMachineModeCompatibility applies illegal transitions, as was done before transitions were checked,
+	// and reports them; this is the default.
+	JobStateMachineModeCompatibility JobStateMachineMode = "Compatibility"
+	// JobStateMachineModeStrict refuses illegal transitions, leaving the job or run unchanged, and reports them.
+	JobStateMachineModeStrict JobStateMachineMode = "Strict"
+)
+
+type JobStateMachineConfig struct {
+	// One of "Compatibility" or "Strict". Defaults to "Compatibility" if empty.
+	Mode JobStateMachineMode `validate:"omitempty,oneof=Compatibility Strict"`
+}
+
+// ContradictoryRunPolicy determines how runs the database reports as both succeeded and failed,
+// e.g., due to ingestion bugs, are reconciled.
+type ContradictoryRunPolicy string
+
+const (
+	// ContradictoryRunPolicyPreferFailed reconciles such runs as failed; this is the default.
+	ContradictoryRunPolicyPreferFailed ContradictoryRunPolicy = "PreferFailed"
+	// ContradictoryRunPolicyPreferSucceeded reconciles such runs as succeeded.
+	ContradictoryRunPolicyPreferSucceeded ContradictoryRunPolicy = "PreferSucceeded"
+	// ContradictoryRunPolicyQuarantine leaves such runs non-terminal, flagged as contradictory, and reports them
+	// as errors until the database no longer reports them as both.
+	ContradictoryRunPolicyQuarantine ContradictoryRunPolicy = "Quarantine"
+)
+
+type ContradictoryRunsConfig struct {
+	// One of "PreferFailed", "PreferSucceeded", or "Quarantine". Defaults to "PreferFailed" if empty.
+	Policy ContradictoryRunPolicy `validate:"omitempty,oneof=PreferFailed PreferSucceeded Quarantine"`
+}
+
+type RunAnalyticsConfig struct {
+	// If true, the leader writes a record of each run whose completion it observes, e.g., its queue, resources, node,
+	// queued and run durations, and outcome, to Parquet files. Records are written at least once; consumers should
+	// deduplicate them by run id. Exporting never delays or fails the cycle.
+	Enabled bool
+	// Where files are written, i.e., a local directory, given as a path or a file:// url, or an http:// or https://
+	// url of an object store prefix, to which each file is PUT. Files are written below a schema_version=<version>
+	// directory, such that readers can tell records of different schema versions apart.
+	Path string
+	// A file is written once this many records are waiting to be written.
+	MaxRecordsPerFile int `validate:"omitempty,gt=0"`
+	// A file is written once records have been waiting to be written for this long, however few there are.
+	FlushInterval time.Duration
+	// Maximum number of records handed to the exporter but not yet picked up by it. Further records are dropped and counted.
+	MaxPending int `validate:"omitempty,gt=0"`
+	// Timeout of each attempt to write a file to an object store.
+	Timeout time.Duration
+}
+
+type ParameterCheckpointsConfig struct {
+	// If true, the effective parameters of each job are derived from its scheduling info when it's admitted and remain
+	// fixed until the version of its scheduling info changes, such that changes to how scheduling info is interpreted
+	// don't change the treatment of queued jobs. Checkpoints are persisted in the scheduler database, such that they
+	// survive restarts. Jobs loaded or updated whose re-derived parameters differ from their checkpoint are counted.
+	Enabled bool
+	// If true, jobs loaded or updated whose re-derived parameters differ from their checkpoint are re-checkpointed,
+	// e.g., to migrate jobs already admitted to a deliberately changed interpretation of their scheduling info.
+	ForceRederivation bool
+}
+
+type EventConsistencyConfig struct {
+	// If true, each cycle compares, per transition, the jobs that were leased, succeeded, failed, or were cancelled
+	// in the jobDb with those for which an event is to be published, and is aborted before publishing if they diverge.
+	Enabled bool
+	// Divergences of at most this many jobs per transition are only reported, e.g., to allow for known benign cases.
+	Tolerance int `validate:"gte=0"`
+}
+
+type JobDbWriteGuardConfig struct {
+	// If true, each cycle records the jobs the scheduling algorithm may write, i.e., queued jobs and the jobs it declares
+	// as preemption candidates, and is aborted with an error listing the other jobs written if the algorithm wrote any.
+	Enabled bool
+}
+
+type ExecutorApiMetricsConfig struct {
+	// If true, the duration, request and response sizes, and errors of executor api requests are recorded,
+	// labelled by method and executor.
+	Enabled bool
+	// How often the executors known to the executor repository are refreshed. Requests of other executors are
+	// labelled as being of an unknown executor, such that the number of executor labels is bounded.
+	// If zero, known executors are only fetched on startup.
+	KnownExecutorsRefreshInterval time.Duration
+}
+
+type UnknownQueuesConfig struct {
+	// One of "Ignore", "Fail", "AutoCreate", or "Hold". Defaults to "Ignore" if empty.
+	Policy UnknownQueuePolicy `validate:"omitempty,oneof=Ignore Fail AutoCreate Hold"`
+	// How long jobs are held for before being failed when Policy is "Hold", measured from when the leader first found
+	// their queue not to exist. The grace period restarts on failover.
+	GracePeriod time.Duration
+	// Priority factor of queues created when Policy is "AutoCreate". Must be positive if Policy is "AutoCreate".
+	DefaultPriorityFactor float64 `validate:"required_if=Policy AutoCreate,gte=0"`
+}
+
+type QueueBacklogLimitsConfig struct {
+	// If true, new jobs are failed instead of being admitted if their queue already has as many queued jobs as its limit.
+	Enabled bool
+	// Limit of queues for which none is set in the queue repository. If zero, such queues aren't limited.
+	DefaultMaxQueuedJobs uint32
+}
+
+// PriorityClampWarning determines how the scheduler reports clamping the priority of a job to the maximum job
+// priority of its queue.
+type PriorityClampWarning string
+
+const (
+	// PriorityClampWarningLog logs each clamp; this is the default.
+	PriorityClampWarningLog PriorityClampWarning = "Log"
+	// PriorityClampWarningEvent additionally includes the requested priority and the reason for the clamp
+	// in the ReprioritisedJob event published for the job.
+	PriorityClampWarningEvent PriorityClampWarning = "Event"
+)
+
+type QueuePriorityCapsConfig struct {
+	// If true, jobs submitted or reprioritised above the maximum job priority of their queue in the queue repository
+	// are given that priority instead. The requested priority is retained, such that it's applied if the cap is removed.
+	Enabled bool
+	// One of "Log" or "Event". Defaults to "Log" if empty.
+	Warning PriorityClampWarning `validate:"omitempty,oneof=Log Event"`
+}
+
+type QueueQuarantineConfig struct {
+	// If true, once MaxConsecutivePanics consecutive scheduling rounds have panicked, queues are scheduled one at a time
+	// and any queue that causes a panic on its own is quarantined, i.e., its queued jobs are no longer scheduled.
+	// Panics are recovered from regardless, failing the cycle in which they occur.
+	Enabled bool
+	// Number of consecutive scheduling rounds that must panic before queues are scheduled one at a time.
+	MaxConsecutivePanics int `validate:"omitempty,gt=0"`
+	// How long queues remain quarantined for before they're scheduled again. If zero, they remain quarantined
+	// until the scheduler restarts.
+	Duration time.Duration
+}
+
+type NodeQuarantineConfig struct {
+	// If true, nodes on which FailureThreshold runs fail within Window, without any run succeeding in between, are
+	// quarantined, i.e., no new jobs are scheduled on them and their executor is asked to taint them.
+	Enabled bool
+	// Number of failures within Window at which a node is quarantined.
+	FailureThreshold int `validate:"omitempty,gt=0"`
+	// Failures older than this are no longer counted.
+	Window time.Duration
+	// How long nodes remain quarantined for. Quarantined nodes are also released if a run on them succeeds.
+	// If zero, nodes remain quarantined until a run on them succeeds or the scheduler restarts.
+	Duration time.Duration
+	// Names of run error classification rules matching errors caused by the job itself, e.g., running out of memory
+	// or an invalid image. Runs failing with such errors aren't counted against their node.
+	ExcludedErrorClassifications []string
+	// Key of the NoSchedule taint executors are asked to add to quarantined nodes.
+	TaintKey string
+}
+
+type RunUpdateQuarantineConfig struct {
+	// If true, updates of runs of jobs neither in the jobDb nor among the job updates received alongside them are
+	// quarantined and retried in subsequent cycles, since the row of the job may just not have been read yet,
+	// rather than being ignored. Updates still orphaned after MaxCycles cycles are dropped with a warning;
+	// if sharding is enabled, these include updates of runs of jobs of other shards read in an earlier cycle.
+	Enabled bool
+	// Maximum number of run updates quarantined at a time. Further such updates are dropped.
+	MaxRuns int `validate:"omitempty,gt=0"`
+	// Number of cycles for which quarantined run updates are retried before being dropped.
+	MaxCycles uint
+}
+
+type JobDbLeasesConfig struct {
+	// If true, the executor api of the leader decides which runs to lease to and cancel on each executor from a snapshot
+	// of the jobDb taken after each cycle that successfully published its events, instead of by querying postgres.
+	// Only the specs of newly leased runs are still read from postgres, by run id.
+	// Followers, and the leader while it has no sufficiently recent snapshot, query postgres as usual.
+	Enabled bool
+	// Snapshots older than this aren't served from, e.g., since publishing has been failing.
+	MaxStaleness time.Duration
+}
+
+type RunErrorBackfillConfig struct {
+	// If true, jobs whose failed run has no error in the database are failed with a placeholder error,
+	// and the run error is published in a separate event once it's written to the database.
+	// Otherwise, the scheduler crashes if it encounters such a job.
+	Enabled bool
+	// Maximum number of runs to wait for the error of at once. The runs failed first are dropped first.
+	MaxPendingRuns int
+	// How long to wait for the error of each run for.
+	Ttl time.Duration
+}
+
+type ConfigReloadConfig struct {
+	// If true, the config is re-read when the scheduler receives SIGHUP or any of the config files passed on the command line
+	// are modified. Only rate limits, scheduling limits, retry and backoff settings, the run error backfill ttl, and the
+	// executor update frequency of the submit checker are applied; changing other settings, e.g., database or Pulsar
+	// connection settings, still requires a restart.
+	Enabled bool
+	// How often config files are checked for modifications. If zero, the config is only re-read on SIGHUP.
+	PollPeriod time.Duration
+}
+
+type ScaleHintsConfig struct {
+	// If true, idle executors are tracked, exported as metrics, and listed by the ScaleHints admin endpoint.
+	Enabled bool
+	// Number of consecutive cycles an executor must have had no active runs and no queued jobs only it could run
+	// for before it's considered safe to shrink.
+	IdleCycles uint `validate:"gt=0"`
+}
+
+type WarningCoalescingConfig struct {
+	// Identical warnings, e.g., about the same stale executor, are logged at most once per window;
+	// further such warnings are counted and logged once the window has ended. If zero, warnings aren't coalesced.
+	Window time.Duration
+	// Maximum number of distinct warnings tracked at once. Warnings beyond this limit are dropped until the window ends.
+	MaxKeys int
+}
+
+type RequestDeduplicationConfig struct {
+	// Lease requests made by the same executor with the same request id, and event reports containing identical events,
+	// repeated within this window are answered with the response to the original request instead of being served again.
+	// If zero, requests aren't deduplicated.
+	Window time.Duration
+	// Maximum number of responses of each kind remembered at once. The oldest responses are forgotten first.
+	MaxEntries int `validate:"gt=0"`
 }
 
 type HttpConfig struct {
 	Port int `validate:"required"`
 }
+
+type CatchUpConfig struct {
+	// If true, the executor api won't distribute new leases while the scheduler is catching up after becoming leader.
+	Enabled bool
+	// The scheduler is considered caught up once a cycle receives at most this many new job and run serials from postgres.
+	MaxSerialLag int64
+	// Returned to executors while catching up to indicate how long they should wait before requesting leases again.
+	RetryAfter time.Duration
+}
+
+type ShardingConfig struct {
+	// If true, this scheduler only considers jobs of queues assigned to ShardId. Each shard elects its own leader,
+	// using a lease lock name suffixed by the shard id. Node capacity is shared; resources allocated to runs of jobs
+	// of other shards are unavailable to, and can't be preempted by, the jobs of this shard.
+	// The executor api of any shard leases the runs of all shards from postgres, such that an executor may connect
+	// to any of them. Requires RunUpdateQuarantine to be enabled and JobDbLeases to be disabled.
+	Enabled bool
+	// Shard of this scheduler, in [0, NumShards).
+	ShardId int
+	// Total number of shards.
+	NumShards int
+	// Maps queues to shards. Queues not listed here are assigned to shards by consistent hashing of their name.
+	QueueShards map[string]int
+}
+
+type UpdateStalenessConfig struct {
+	// If true, the age of the oldest job or run update in postgres not yet processed by the scheduler is sampled
+	// periodically, exported as a metric, and included in scheduling reports.
+	Enabled bool
+	// How often the age is sampled.
+	SamplePeriod time.Duration
+	// If positive, a warning is logged whenever the sampled age exceeds this bound.
+	MaxStaleness time.Duration
+	// If true, the scheduler reports unhealthy while the sampled age exceeds MaxStaleness.
+	FailReadiness bool
+}
+
+type WaitTimeEstimationConfig struct {
+	// If true, estimated wait times are exported as metrics and included in queue reports.
+	Enabled bool
+	// Jobs are bucketed by their request for this resource, e.g., "cpu".
+	ResourceName string
+	// Upper bounds of the buckets jobs are assigned to.
+	// Jobs requesting more than the largest bound are assigned to an additional, unbounded bucket.
+	BucketBoundaries []resource.Quantity
+	// Scheduling throughput is computed over this many of the most recent cycles.
+	WindowSize int
+}
+
+type RunErrorClassificationRule struct {
+	// Name of the classification, included in the errors of jobs failed due to this rule and in metrics.
+	Name string
+	// One of "Retryable", "NonRetryable", or "RetryWithBackoff".
+	// Jobs with a run error classified as non-retryable are failed immediately, while jobs with a run error classified
+	// as retryable are retried if they haven't yet been attempted the maximum number of times.
+	// Jobs retried with backoff are further not considered for scheduling until Backoff has passed.
+	Class string
+	// If set, the rule only matches errors with a pod, container, or lease message matching this regex.
+	MessageRegex string
+	// If non-empty, the rule only matches errors where a container exited with one of these exit codes.
+	ExitCodes []int32
+	// If non-empty, the rule only matches errors where Kubernetes reported one of these reasons
+	// for the pod or one of its containers failing, i.e., "AppError", "Evicted", "OOM", or "DeadlineExceeded".
+	KubernetesReasons []string
+	// How long jobs retried due to this rule are backed off for. Only used for the "RetryWithBackoff" class.
+	Backoff time.Duration
+}
+
+type CancellationEnforcementConfig struct {
+	// If true, the active runs of cancelled jobs are tracked until their executor stops reporting them.
+	Enabled bool
+	// If the executor still reports a cancelled run this long after it was cancelled,
+	// the run cancellation is re-published and counted as ignored by the executor.
+	EscalateAfter time.Duration
+	// If the executor still reports a cancelled run this long after the cancellation was re-published,
+	// the run is failed.
+	ForceFailAfter time.Duration
+}
+
+type JobNudgesConfig struct {
+	// Maximum number of jobs per second that may be nudged in any one queue.
+	MaximumPerQueueRate float64 `validate:"gt=0"`
+	// Maximum number of jobs that may be nudged in any one queue at once.
+	MaximumPerQueueBurst int `validate:"gt=0"`
+}
+
+type StartupConfig struct {
+	// Maximum time allowed for connecting to each of postgres, redis, and pulsar on startup.
+	// If zero, there's no limit.
+	DependencyTimeout time.Duration
+	// If true, failing to create optional dependencies, e.g., the scheduling context repository used for
+	// scheduling reports, is logged and the scheduler starts without them. Otherwise, startup is aborted.
+	DegradeOnOptionalDependencyFailure bool
+}
+
+type JobSetPlacementConfig struct {
+	// If true, placement stats are recorded for each job set and exposed via the job set report endpoint.
+	Enabled bool
+	// Node label used to determine which zone a node is in, e.g., "topology.kubernetes.io/zone".
+	ZoneLabel string
+	// Maximum number of job sets to store stats for. The least recently updated job sets are evicted first.
+	MaxJobSets int
+	// How long stats are kept for after all jobs with runs in a job set have become terminal.
+	Retention time.Duration
+	// If positive, nodes of the preferred executor of a job set, i.e., the executor its first run was placed on or the
+	// one given by the armadaproject.io/preferredExecutor annotation, have this added to their score when scheduling
+	// other jobs of that job set. Jobs still spill over onto other executors if the preferred one can't fit them.
+	// Only affects placement among executors scheduled together, i.e., if UnifiedSchedulingByPool is enabled.
+	PreferredExecutorWeight int
+}
+
+// RetryExhaustionNotificationMode determines how the scheduler notifies about jobs failed since they were attempted
+// the maximum number of times.
+type RetryExhaustionNotificationMode string
+
+const (
+	// RetryExhaustionNotificationEvent publishes a JobRetriesExhausted event for each such job; this is the default.
+	RetryExhaustionNotificationEvent RetryExhaustionNotificationMode = "Event"
+	// RetryExhaustionNotificationWebhook posts each such job to a webhook.
+	RetryExhaustionNotificationWebhook RetryExhaustionNotificationMode = "Webhook"
+	// RetryExhaustionNotificationBoth both publishes an event and posts to a webhook.
+	RetryExhaustionNotificationBoth RetryExhaustionNotificationMode = "Both"
+)
+
+type RetryExhaustionNotificationsConfig struct {
+	// If true, the scheduler notifies about jobs failed since they were attempted the maximum number of times,
+	// such that users can, e.g., halt pipelines submitting further jobs. Failing to notify never fails the cycle.
+	Enabled bool
+	// One of "Event", "Webhook", or "Both". Defaults to "Event" if empty.
+	Mode RetryExhaustionNotificationMode `validate:"omitempty,oneof=Event Webhook Both"`
+	// Maximum number of notifications per second for any one job set. Jobs failed beyond this rate aren't notified about.
+	MaxPerJobSetRate float64 `validate:"omitempty,gt=0"`
+	// Maximum number of notifications for any one job set at once.
+	MaxPerJobSetBurst int `validate:"omitempty,gt=0"`
+	// Maximum number of job sets rate limits are tracked for. The least recently notified job sets are forgotten first.
+	MaxJobSets int `validate:"omitempty,gt=0"`
+	// Webhook notifications are posted to. Only used if Mode is "Webhook" or "Both".
+	Webhook RetryExhaustionWebhookConfig
+}
+
+type RetryExhaustionWebhookConfig struct {
+	// Url each notification is posted to as a JSON object.
+	Url string
+	// Timeout of each attempt to post a notification.
+	Timeout time.Duration
+	// Maximum number of notifications posted at once.
+	MaxConcurrency int `validate:"omitempty,gt=0"`
+	// Number of times posting a notification is retried after the first attempt fails.
+	MaxRetries int `validate:"gte=0"`
+	// Time waited before the first retry, doubled for each further retry.
+	RetryBackoff time.Duration
+	// Maximum number of notifications waiting to be posted. Further notifications are dropped.
+	MaxPending int `validate:"omitempty,gt=0"`
+}
+
+type UrgentSchedulingConfig struct {
+	// If true, queued jobs of PriorityClasses are leased in the cycle in which they're received,
+	// onto nodes with enough free capacity for them, without waiting for the next scheduling round.
+	// Jobs for which there's no such node are left for the scheduling round.
+	Enabled bool
+	// Names of the urgent priority classes.
+	PriorityClasses []string
+}
+
+type ExecutorNodeDeltasConfig struct {
+	// If true, executors configured to do so may report only the nodes added, changed, or removed since their previous
+	// lease request, which are applied to the nodes stored for them. Executors are asked to report all of their nodes
+	// whenever a delta can't be applied, e.g., after the scheduler lost track of their nodes.
+	// Executors' nodes are then stored one row per node in the executor_nodes table, which schedulers predating it
+	// don't read. Hence, this must only be enabled once every scheduler sharing the database runs a version with the
+	// executor_nodes migration. When rolling back to such a version, first disable this on all schedulers and wait for
+	// each executor to report in, which stores its nodes in the executors table again, before rolling back.
+	Enabled bool
+}
+
+type EventRatesConfig struct {
+	// If true, the leader counts the jobs of each queue and job set admitted, cancelled, requeued, and failed
+	// over a sliding window. Per-queue rates are exported as metrics. Since there may be many job sets,
+	// the job sets with the most events are only exposed via the event rate report endpoint.
+	Enabled bool
+	// Length of the sliding window rates are computed over.
+	Window time.Duration `validate:"omitempty,gt=0"`
+	// Number of buckets the window is divided into. Events leave the window one bucket at a time,
+	// such that more buckets make rates more precise at the cost of memory per queue and job set.
+	NumBuckets int `validate:"omitempty,gt=0"`
+	// Number of job sets included in event rate reports unless the request specifies otherwise.
+	NumJobSets int `validate:"omitempty,gt=0"`
+	// Maximum rate, in events per second, of each of "admitted", "cancelled", "requeued", and "failed" per queue.
+	// Queues exceeding any of these are reported via a metric and a warning. Events without a maximum are never
+	// reported as exceeding it.
+	MaxQueueRateByEvent map[string]float64
+}
+
+type FragmentationConfig struct {
+	// If true, the leader computes at the end of each scheduling round how fragmented the free capacity of the nodes
+	// scheduled on is and how many jobs failed to schedule solely since no single node had enough free capacity.
+	// These are exported as metrics and summarised by the fragmentation report, which also recommends which preemptible
+	// jobs would need to move to unblock the largest such job. Recommendations are advisory only; no jobs are moved.
+	Enabled bool
+	// Resources for which the largest free allocation and the distribution of free capacity across nodes are exported.
+	Resources []string
+}
+
+// PriorityClassCheckMode determines what the scheduler does upon finding jobs in flight with priority classes
+// missing from its config.
+type PriorityClassCheckMode string
+
+const (
+	// PriorityClassCheckModeRefuse causes the scheduler to refuse to start, and reloaded configs to be rejected;
+	// this is the default.
+	PriorityClassCheckModeRefuse PriorityClassCheckMode = "Refuse"
+	// PriorityClassCheckModeFallback causes jobs with priority classes missing from the config to be assigned
+	// the fallback priority class instead, after logging a warning.
+	PriorityClassCheckModeFallback PriorityClassCheckMode = "Fallback"
+)
+
+type PriorityClassCheckConfig struct {
+	// If true, the priority classes of a sample of the jobs that haven't succeeded, failed, or been cancelled
+	// are checked against those configured when the scheduler starts and whenever the config is reloaded.
+	Enabled bool
+	// One of "Refuse" or "Fallback". Defaults to "Refuse" if empty.
+	Mode PriorityClassCheckMode `validate:"omitempty,oneof=Refuse Fallback"`
+	// Priority class assigned to jobs with a priority class missing from the config if Mode is "Fallback".
+	// Must be a configured priority class.
+	FallbackPriorityClass string
+	// Maximum number of jobs checked, most recently updated first, such that the check stays fast on large job tables.
+	MaxSampledJobs int `validate:"gte=0"`
+}
+
+// CycleJitterDistribution determines how the fraction by which each period is jittered is drawn.
+type CycleJitterDistribution string
+
+const (
+	// CycleJitterDistributionUniform lengthens or shortens each period by a fraction drawn uniformly from
+	// [-MaxFraction, MaxFraction]; this is the default.
+	CycleJitterDistributionUniform CycleJitterDistribution = "Uniform"
+	// CycleJitterDistributionExponential only lengthens periods, by an exponentially distributed fraction
+	// with mean a quarter of MaxFraction, capped at MaxFraction.
+	CycleJitterDistributionExponential CycleJitterDistribution = "Exponential"
+)
+
+type CycleJitterConfig struct {
+	// If true, CyclePeriod, SchedulePeriod, and the metrics refresh interval are jittered.
+	Enabled bool
+	// One of "Uniform" or "Exponential". Defaults to "Uniform" if empty.
+	Distribution CycleJitterDistribution `validate:"omitempty,oneof=Uniform Exponential"`
+	// Maximum fraction of each period by which it's jittered.
+	MaxFraction float64 `validate:"gte=0,lt=1"`
+	// If true, the first cycle and the first metrics refresh are each delayed by a random fraction of their period,
+	// such that they're out of phase with each other and with those of other replicas.
+	SmearPhase bool
+}
+
+type RunResourceUsageConfig struct {
+	// If true, the resource usage executors report for their runs is stored and, among running jobs that would otherwise
+	// be ordered by how long they've been running, those that have consumed the least resources are preempted first.
+	// Executors only report usage if configured to do so; runs without reported usage are ordered by run age.
+	Enabled bool
+}
+
+type CapacitySummaryConfig struct {
+	// If true, the capacity and demand of each pool are computed every scheduling round,
+	// included in scheduling reports, and exported as metrics.
+	Enabled bool
+}
+
+type ExecutorSnapshotsConfig struct {
+	// If true, executors are fetched from the database once per RefreshInterval into a versioned snapshot
+	// used by the submit checker, the pool assigners, and the scheduling algorithm, which otherwise each fetch executors
+	// on their own schedule and may disagree about which nodes exist.
+	Enabled bool
+	// How often the snapshot is refreshed. If zero, it's only refreshed on demand.
+	RefreshInterval time.Duration
+}
+
+// PulsarPublishingConfig controls how the messages of each publish are sent to pulsar.
+type PulsarPublishingConfig struct {
+	// Maximum number of messages sent but not yet acknowledged at any point during a publish.
+	// Further messages are only sent once earlier ones have been acknowledged or have failed.
+	// If zero, the number of messages in flight isn't bounded.
+	MaxInFlightMessages int
+	// Time after which a message that's been sent but not acknowledged is considered failed.
+	// This is distinct from PulsarSendTimeout, which bounds the publish as a whole.
+	// If zero, messages are only bounded by PulsarSendTimeout.
+	MessageSendTimeout time.Duration
+}
@@ -37,12 +37,30 @@ func TestSchedulingConfigValidate(t *testing.T) {
 					},
 				},
 			},
+			OvercommitFactorsByPool: map[string]map[string]float64{
+				"pool": {"cpu": 1.5, "memory": 0},
+			},
+			NodeOrderingByPool: map[string]configuration.NodeOrdering{
+				"pool":       configuration.BalancedNodeOrdering,
+				"other-pool": "spread",
+			},
+			BurstCredits: configuration.BurstCreditsConfig{Enabled: true, AccrualRate: -1},
+			GangReservations: configuration.GangReservationsConfig{
+				Enabled:                 true,
+				BackfillPriorityClasses: []string{"armada-preemptible-away", "missing"},
+			},
 		},
+		UnknownQueues: UnknownQueuesConfig{Policy: UnknownQueuePolicyAutoCreate},
 	}
 	expected := []string{
 		configuration.DuplicateWellKnownNodeTypeErrorMessage,
 		configuration.AwayNodeTypesWithoutPreemptionErrorMessage,
 		configuration.UnknownWellKnownNodeTypeErrorMessage,
+		configuration.NonPositiveOvercommitFactorErrorMessage,
+		configuration.UnknownNodeOrderingErrorMessage,
+		configuration.InvalidBackfillPriorityClassErrorMessage,
+		"'AccrualRate' failed on the 'gte' tag",
+		"'DefaultPriorityFactor' failed on the 'required_if' tag",
 	}
 
 	err := c.Validate()
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/armadaproject/armada/internal/armada/configuration"
@@ -31,6 +32,9 @@ const (
 	// This means the gang can not be scheduled without first increasing the burst size.
 	GangExceedsGlobalBurstSizeUnschedulableReason = "gang cardinality too large: exceeds global max burst size"
 	GangExceedsQueueBurstSizeUnschedulableReason  = "gang cardinality too large: exceeds queue max burst size"
+
+	// Indicates that scheduling a non-preemptible gang would use resources reserved for other queues.
+	ReservedResourcesUnschedulableReason = "would use resources reserved for other queues"
 )
 
 // IsTerminalUnschedulableReason returns true if reason indicates
@@ -62,6 +66,11 @@ type SchedulingConstraints struct {
 	PriorityClassSchedulingConstraintsByPriorityClassName map[string]PriorityClassSchedulingConstraints
 	// Limits total resources scheduled per invocation.
 	MaximumResourcesToSchedule schedulerobjects.ResourceList
+	// If true, gangs are scheduled with the priority of their highest-priority member.
+	EnableGangPriorityInheritance bool
+	// Resources reserved for specific queues in this pool.
+	// Non-preemptible jobs of other queues may not use resources that would leave the reservation uncovered.
+	ReservedResourcesByQueue map[string]schedulerobjects.ResourceList
 }
 
 // PriorityClassSchedulingConstraints contains scheduling constraints that apply to jobs of a specific priority class.
@@ -94,11 +103,20 @@ func SchedulingConstraintsFromSchedulingConfig(
 		// Use pool-specific config is available.
 		maximumResourceFractionToSchedule = m
 	}
+	var reservedResourcesByQueue map[string]schedulerobjects.ResourceList
+	if reservedResources := config.ReservedResourcesByPool[pool]; len(reservedResources) > 0 {
+		reservedResourcesByQueue = make(map[string]schedulerobjects.ResourceList, len(reservedResources))
+		for queue, resources := range reservedResources {
+			reservedResourcesByQueue[queue] = schedulerobjects.ResourceList{Resources: maps.Clone(resources)}
+		}
+	}
 	return SchedulingConstraints{
+		ReservedResourcesByQueue:   reservedResourcesByQueue,
 		MaxQueueLookback:           config.MaxQueueLookback,
 		MinimumJobSize:             minimumJobSize,
 		MaximumResourcesToSchedule: absoluteFromRelativeLimits(totalResources, maximumResourceFractionToSchedule),
 		PriorityClassSchedulingConstraintsByPriorityClassName: priorityClassSchedulingConstraintsByPriorityClassName,
+		EnableGangPriorityInheritance:                         config.EnableGangPriorityInheritance,
 	}
 }
 
@@ -170,9 +188,76 @@ func (constraints *SchedulingConstraints) CheckConstraints(
 			return false, MaximumResourcesPerQueueExceededUnschedulableReason, nil
 		}
 	}
+
+	// ReservedResourcesByQueue check.
+	if !constraints.reservationsAllow(sctx, gctx) {
+		return false, ReservedResourcesUnschedulableReason, nil
+	}
 	return true, "", nil
 }
 
+// reservationsAllow returns true if scheduling gctx leaves enough resources to cover the reservations of other queues.
+// Preemptible gangs are always allowed, since they can be preempted to make room for the reserving queue.
+// The reservation of a queue is covered by its non-preemptible jobs up to the reserved amount;
+// any remainder must be either free or occupied by preemptible jobs.
+func (constraints *SchedulingConstraints) reservationsAllow(sctx *schedulercontext.SchedulingContext, gctx *schedulercontext.GangSchedulingContext) bool {
+	if len(constraints.ReservedResourcesByQueue) == 0 {
+		return true
+	}
+	if sctx.PriorityClasses[gctx.PriorityClassName].Preemptible {
+		return true
+	}
+	// The gang has already been added to the allocation of its queue.
+	for t := range reservedResourceTypes(constraints.ReservedResourcesByQueue) {
+		var required resource.Quantity
+		for queue, qctx := range sctx.QueueSchedulingContexts {
+			if _, ok := constraints.ReservedResourcesByQueue[queue]; ok && queue != gctx.Queue {
+				// Accounted for below.
+				continue
+			}
+			required.Add(nonPreemptibleAllocation(sctx, qctx, t))
+		}
+		for queue, reserved := range constraints.ReservedResourcesByQueue {
+			if queue == gctx.Queue {
+				continue
+			}
+			footprint := reserved.Get(t)
+			if qctx := sctx.QueueSchedulingContexts[queue]; qctx != nil {
+				if allocated := nonPreemptibleAllocation(sctx, qctx, t); allocated.Cmp(footprint) == 1 {
+					footprint = allocated
+				}
+			}
+			required.Add(footprint)
+		}
+		if required.Cmp(sctx.TotalResources.Get(t)) == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func reservedResourceTypes(reservedResourcesByQueue map[string]schedulerobjects.ResourceList) map[string]bool {
+	rv := make(map[string]bool)
+	for _, reserved := range reservedResourcesByQueue {
+		for t := range reserved.Resources {
+			rv[t] = true
+		}
+	}
+	return rv
+}
+
+// nonPreemptibleAllocation returns the amount of resource t allocated to non-preemptible jobs of qctx.
+func nonPreemptibleAllocation(sctx *schedulercontext.SchedulingContext, qctx *schedulercontext.QueueSchedulingContext, t string) resource.Quantity {
+	var rv resource.Quantity
+	for priorityClassName, allocated := range qctx.AllocatedByPriorityClass {
+		if sctx.PriorityClasses[priorityClassName].Preemptible {
+			continue
+		}
+		rv.Add(allocated.Get(t))
+	}
+	return rv
+}
+
 func RequestsAreLargeEnough(totalResourceRequests, minRequest schedulerobjects.ResourceList) (bool, string) {
 	for t, minQuantity := range minRequest.Resources {
 		q := totalResourceRequests.Get(t)
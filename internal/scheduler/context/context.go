@@ -22,6 +22,7 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/fairness"
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	clientQueue "github.com/armadaproject/armada/pkg/client/queue"
 )
 
 // defaultSchedulingKeyGenerator is used for computing scheduling keys for legacy api.Job where one is not pre-computed.
@@ -77,6 +78,59 @@ type SchedulingContext struct {
 	// Used to immediately reject new jobs with identical reqirements.
 	// Maps to the JobSchedulingContext of a previous job attempted to schedule with the same key.
 	UnfeasibleSchedulingKeys map[schedulerobjects.SchedulingKey]*JobSchedulingContext
+	// Resources reserved for specific queues in this pool.
+	ReservedResourcesByQueue map[string]schedulerobjects.ResourceList
+	// Order in which nodes that score equally for a job were considered, e.g., "packed".
+	NodeOrdering string
+	// Capacity and demand of this pool at the start of the scheduling round.
+	// Nil unless capacity summaries are enabled.
+	Capacity *PoolCapacity
+	// Work preempted within the preemption budget window at the end of the scheduling round.
+	// Nil unless the preemption budget is enabled.
+	PreemptionBudget *PreemptionBudgetUsage
+}
+
+// PoolCapacity summarises the resources of a pool at the start of a scheduling round.
+type PoolCapacity struct {
+	// Resources across all nodes of the pool.
+	Allocatable schedulerobjects.ResourceList
+	// Resources requested by jobs running in the pool.
+	Allocated schedulerobjects.ResourceList
+	// Resources requested by queued jobs attributed to the pool.
+	Demand schedulerobjects.ResourceList
+}
+
+// Utilisation returns the fraction of allocatable resources of type t allocated to jobs,
+// or 0 if the pool has none of that resource.
+func (c *PoolCapacity) Utilisation(t string) float64 {
+	allocatable := c.Allocatable.Get(t)
+	if allocatable.IsZero() {
+		return 0
+	}
+	allocated := c.Allocated.Get(t)
+	return allocated.AsApproximateFloat64() / allocatable.AsApproximateFloat64()
+}
+
+// PreemptionBudgetUsage describes the work, in resource-seconds, preempted within the preemption budget window.
+type PreemptionBudgetUsage struct {
+	// Work preempted in this pool.
+	Consumed float64
+	// Work preempted across all pools.
+	ConsumedGlobally float64
+	// If true, the budget was exhausted at the start of the round,
+	// such that no jobs were evicted to balance resource usage across queues.
+	Exhausted bool
+}
+
+// CrossPoolUsage describes the usage share of a queue, i.e., the fraction of a pool allocated to it
+// as measured by the fairness model, in the pool being scheduled and across all pools.
+type CrossPoolUsage struct {
+	// Usage share of this pool.
+	PoolShare float64
+	// Usage share of all pools, averaged weighted by pool importance.
+	AggregateShare float64
+	// Contribution of pools other than this one to AggregateShare, by which the weight of the queue is reduced.
+	OtherPoolsShare float64
 }
 
 func NewSchedulingContext(
@@ -106,6 +160,15 @@ func NewSchedulingContext(
 	}
 }
 
+// GetReservedResources returns the resources reserved for queue, if any.
+func (sctx *SchedulingContext) GetReservedResources(queue string) (schedulerobjects.ResourceList, bool) {
+	if sctx == nil {
+		return schedulerobjects.ResourceList{}, false
+	}
+	reserved, ok := sctx.ReservedResourcesByQueue[queue]
+	return reserved, ok
+}
+
 func (sctx *SchedulingContext) ClearUnfeasibleSchedulingKeys() {
 	sctx.UnfeasibleSchedulingKeys = make(map[schedulerobjects.SchedulingKey]*JobSchedulingContext)
 }
@@ -140,6 +203,7 @@ func (sctx *SchedulingContext) AddQueueSchedulingContext(
 		Weight:                            weight,
 		Limiter:                           limiter,
 		Allocated:                         allocated,
+		InitialAllocated:                  allocated.DeepCopy(),
 		AllocatedByPriorityClass:          initialAllocatedByPriorityClass,
 		ScheduledResourcesByPriorityClass: make(schedulerobjects.QuantityByTAndResourceType[string]),
 		EvictedResourcesByPriorityClass:   make(schedulerobjects.QuantityByTAndResourceType[string]),
@@ -170,14 +234,55 @@ func (sctx *SchedulingContext) TotalCost() float64 {
 	return rv
 }
 
+// TotalInitialCost returns the sum of the costs across all queues at the start of the scheduling cycle.
+func (sctx *SchedulingContext) TotalInitialCost() float64 {
+	var rv float64
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		rv += sctx.FairnessCostProvider.CostFromAllocationAndWeight(qctx.InitialAllocated, qctx.Weight)
+	}
+	return rv
+}
+
+// queueShareTotals holds the total costs across all queues that the shares of queues are relative to,
+// such that reports covering many queues compute these once rather than once per queue.
+type queueShareTotals struct {
+	initialCost float64
+	cost        float64
+}
+
+// queueShareTotals returns the total costs across all queues, or zero totals if sctx is nil or has no fairness cost provider.
+func (sctx *SchedulingContext) queueShareTotals() queueShareTotals {
+	if sctx == nil || sctx.FairnessCostProvider == nil {
+		return queueShareTotals{}
+	}
+	return queueShareTotals{initialCost: sctx.TotalInitialCost(), cost: sctx.TotalCost()}
+}
+
 func (sctx *SchedulingContext) ReportString(verbosity int32) string {
+	var totals queueShareTotals
+	if verbosity > 0 {
+		// Queues are reported individually.
+		totals = sctx.queueShareTotals()
+	}
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
 	fmt.Fprintf(w, "Started:\t%s\n", sctx.Started)
 	fmt.Fprintf(w, "Finished:\t%s\n", sctx.Finished)
 	fmt.Fprintf(w, "Duration:\t%s\n", sctx.Finished.Sub(sctx.Started))
 	fmt.Fprintf(w, "Termination reason:\t%s\n", sctx.TerminationReason)
+	if sctx.NodeOrdering != "" {
+		fmt.Fprintf(w, "Node ordering:\t%s\n", sctx.NodeOrdering)
+	}
 	fmt.Fprintf(w, "Total capacity:\t%s\n", sctx.TotalResources.CompactString())
+	if sctx.Capacity != nil {
+		fmt.Fprintf(w, "Allocatable resources:\t%s\n", sctx.Capacity.Allocatable.CompactString())
+		fmt.Fprintf(w, "Allocated resources:\t%s\n", sctx.Capacity.Allocated.CompactString())
+		fmt.Fprintf(w, "Queued demand:\t%s\n", sctx.Capacity.Demand.CompactString())
+	}
+	if sctx.PreemptionBudget != nil {
+		fmt.Fprintf(w, "Preempted work in budget window:\t%f\n", sctx.PreemptionBudget.Consumed)
+		fmt.Fprintf(w, "Preemption budget exhausted:\t%t\n", sctx.PreemptionBudget.Exhausted)
+	}
 	fmt.Fprintf(w, "Scheduled resources:\t%s\n", sctx.ScheduledResources.CompactString())
 	fmt.Fprintf(w, "Preempted resources:\t%s\n", sctx.EvictedResources.CompactString())
 	fmt.Fprintf(w, "Number of gangs scheduled:\t%d\n", sctx.NumScheduledGangs)
@@ -195,7 +300,7 @@ func (sctx *SchedulingContext) ReportString(verbosity int32) string {
 		fmt.Fprint(w, "Scheduled queues:\n")
 		for queueName, qctx := range scheduled {
 			fmt.Fprintf(w, "\t%s:\n", queueName)
-			fmt.Fprint(w, indent.String("\t\t", qctx.ReportString(verbosity-2)))
+			fmt.Fprint(w, indent.String("\t\t", qctx.reportString(verbosity-2, totals)))
 		}
 	}
 	preempted := armadamaps.Filter(
@@ -210,7 +315,7 @@ func (sctx *SchedulingContext) ReportString(verbosity int32) string {
 		fmt.Fprint(w, "Preempted queues:\n")
 		for queueName, qctx := range preempted {
 			fmt.Fprintf(w, "\t%s:\n", queueName)
-			fmt.Fprint(w, indent.String("\t\t", qctx.ReportString(verbosity-2)))
+			fmt.Fprint(w, indent.String("\t\t", qctx.reportString(verbosity-2, totals)))
 		}
 	}
 	w.Flush()
@@ -342,12 +447,26 @@ type QueueSchedulingContext struct {
 	Queue string
 	// Determines the fair share of this queue relative to other queues.
 	Weight float64
+	// Debt accrued by this queue by exceeding its fair share, as of the start of the round.
+	// If non-zero, Weight has been reduced accordingly; see configuration.BurstCreditsConfig.
+	BurstCreditDebt float64
+	// Usage share of this queue in this and other pools, as of the start of the round; nil unless cross-pool fairness is enabled.
+	// Weight has been reduced according to OtherPoolsShare; see configuration.CrossPoolFairnessConfig.
+	CrossPoolUsage *CrossPoolUsage
+	// Determines whether jobs of this queue may be evicted; empty is equivalent to clientQueue.PreemptionPolicyStandard.
+	// If clientQueue.PreemptionPolicyNever, Weight may have been reduced; see configuration.PreemptionConfig.
+	PreemptionPolicy clientQueue.PreemptionPolicy
+	// Names of the feature gates enabled for this queue, in lexicographical order.
+	FeatureGates []string
 	// Limits job scheduling rate for this queue.
 	// Use the "Started" time to ensure limiter state remains constant within each scheduling round.
 	Limiter *rate.Limiter
 	// Total resources assigned to the queue across all clusters by priority class priority.
 	// Includes jobs scheduled during this invocation of the scheduler.
 	Allocated schedulerobjects.ResourceList
+	// Total resources assigned to the queue across all clusters at the start of the scheduling cycle.
+	// Excludes jobs scheduled during this invocation of the scheduler.
+	InitialAllocated schedulerobjects.ResourceList
 	// Total resources assigned to the queue across all clusters by priority class.
 	// Includes jobs scheduled during this invocation of the scheduler.
 	AllocatedByPriorityClass schedulerobjects.QuantityByTAndResourceType[string]
@@ -361,6 +480,18 @@ type QueueSchedulingContext struct {
 	UnsuccessfulJobSchedulingContexts map[string]*JobSchedulingContext
 	// Jobs evicted in this round.
 	EvictedJobsById map[string]bool
+	// Time spent evaluating candidate jobs of this queue in this round.
+	SchedulingDuration time.Duration
+	// Number of queued jobs skipped without attempting to schedule them,
+	// since no node could have had enough free capacity for them.
+	NumCapacityPrunedJobs int
+	// Number of queued jobs skipped without attempting to schedule them,
+	// since a job with the same scheduling key was found unschedulable earlier in the round.
+	NumSchedulingKeySkippedJobs int
+	// Number of jobs scheduled successfully despite a job with the same scheduling key
+	// having been found unschedulable earlier in the round. Such jobs would have been skipped
+	// had they not opted out of scheduling key-based skipping.
+	NumSchedulingKeyCollisions int
 }
 
 func GetSchedulingContextFromQueueSchedulingContext(qctx *QueueSchedulingContext) *SchedulingContext {
@@ -379,14 +510,76 @@ func (qctx *QueueSchedulingContext) GetAllocation() schedulerobjects.ResourceLis
 	return qctx.Allocated
 }
 
+// NumBlockedJobsByCause returns the number of unschedulable jobs of this queue with each BlockingCause.
+// Jobs not yet classified are omitted.
+func (qctx *QueueSchedulingContext) NumBlockedJobsByCause() map[BlockingCause]int {
+	rv := make(map[BlockingCause]int)
+	for _, jctx := range qctx.UnsuccessfulJobSchedulingContexts {
+		if jctx.BlockingCause != "" {
+			rv[jctx.BlockingCause]++
+		}
+	}
+	return rv
+}
+
 // GetWeight is necessary to implement the fairness.Queue interface.
 func (qctx *QueueSchedulingContext) GetWeight() float64 {
 	return qctx.Weight
 }
 
+// InitialShare returns the fraction of the total cost across all queues attributable to this queue
+// at the start of the scheduling cycle, i.e., before any jobs were scheduled or evicted.
+func (qctx *QueueSchedulingContext) InitialShare() float64 {
+	return qctx.initialShare(qctx.SchedulingContext.queueShareTotals())
+}
+
+func (qctx *QueueSchedulingContext) initialShare(totals queueShareTotals) float64 {
+	if totals.initialCost == 0 {
+		return 0
+	}
+	return qctx.SchedulingContext.FairnessCostProvider.CostFromAllocationAndWeight(qctx.InitialAllocated, qctx.Weight) / totals.initialCost
+}
+
+// Share returns the fraction of the total cost across all queues attributable to this queue,
+// including any jobs scheduled or evicted so far during the scheduling cycle.
+func (qctx *QueueSchedulingContext) Share() float64 {
+	return qctx.share(qctx.SchedulingContext.queueShareTotals())
+}
+
+func (qctx *QueueSchedulingContext) share(totals queueShareTotals) float64 {
+	if totals.cost == 0 {
+		return 0
+	}
+	return qctx.SchedulingContext.FairnessCostProvider.CostFromQueue(qctx) / totals.cost
+}
+
+// CapacityShare returns the fraction of the total resources of the pool allocated to this queue,
+// as measured by the fairness cost provider and disregarding the weight of the queue.
+// Unlike Share, this is independent of the allocation of other queues.
+func (qctx *QueueSchedulingContext) CapacityShare() float64 {
+	sctx := qctx.SchedulingContext
+	if sctx == nil || sctx.FairnessCostProvider == nil {
+		return 0
+	}
+	totalCost := sctx.FairnessCostProvider.CostFromAllocationAndWeight(sctx.TotalResources, 1)
+	if totalCost == 0 {
+		return 0
+	}
+	return sctx.FairnessCostProvider.CostFromAllocationAndWeight(qctx.Allocated, 1) / totalCost
+}
+
 const maxJobIdsToPrint = 1
 
 func (qctx *QueueSchedulingContext) ReportString(verbosity int32) string {
+	var totals queueShareTotals
+	if verbosity >= 0 {
+		totals = qctx.SchedulingContext.queueShareTotals()
+	}
+	return qctx.reportString(verbosity, totals)
+}
+
+// reportString returns the report of ReportString, with shares computed relative to totals.
+func (qctx *QueueSchedulingContext) reportString(verbosity int32, totals queueShareTotals) string {
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
 	if verbosity >= 0 {
@@ -400,9 +593,37 @@ func (qctx *QueueSchedulingContext) ReportString(verbosity int32) string {
 	if verbosity >= 0 {
 		fmt.Fprintf(w, "Total allocated resources after scheduling:\t%s\n", qctx.Allocated.CompactString())
 		fmt.Fprintf(w, "Total allocated resources after scheduling by priority class:\t%s\n", qctx.AllocatedByPriorityClass)
+		if reserved, ok := GetSchedulingContextFromQueueSchedulingContext(qctx).GetReservedResources(qctx.Queue); ok {
+			fmt.Fprintf(w, "Reserved resources:\t%s\n", reserved.CompactString())
+		}
+		if qctx.BurstCreditDebt > 0 {
+			fmt.Fprintf(w, "Burst credit debt:\t%.3f\n", qctx.BurstCreditDebt)
+		}
+		if qctx.PreemptionPolicy != "" {
+			fmt.Fprintf(w, "Preemption policy:\t%s\n", qctx.PreemptionPolicy)
+		}
+		if len(qctx.FeatureGates) > 0 {
+			fmt.Fprintf(w, "Feature gates:\t%s\n", strings.Join(qctx.FeatureGates, ", "))
+		}
+		fmt.Fprintf(w, "Share before scheduling:\t%.3f\n", qctx.initialShare(totals))
+		fmt.Fprintf(w, "Share after scheduling:\t%.3f\n", qctx.share(totals))
+		if usage := qctx.CrossPoolUsage; usage != nil {
+			fmt.Fprintf(w, "Usage share of pool:\t%.3f\n", usage.PoolShare)
+			fmt.Fprintf(w, "Usage share across pools:\t%.3f\n", usage.AggregateShare)
+		}
 		fmt.Fprintf(w, "Number of jobs scheduled:\t%d\n", len(qctx.SuccessfulJobSchedulingContexts))
 		fmt.Fprintf(w, "Number of jobs preempted:\t%d\n", len(qctx.EvictedJobsById))
 		fmt.Fprintf(w, "Number of jobs that could not be scheduled:\t%d\n", len(qctx.UnsuccessfulJobSchedulingContexts))
+		fmt.Fprintf(w, "Time spent evaluating candidate jobs:\t%s\n", qctx.SchedulingDuration)
+		if qctx.NumCapacityPrunedJobs > 0 {
+			fmt.Fprintf(w, "Number of jobs skipped due to insufficient capacity:\t%d\n", qctx.NumCapacityPrunedJobs)
+		}
+		if qctx.NumSchedulingKeySkippedJobs > 0 {
+			fmt.Fprintf(w, "Number of jobs skipped as identical to an unschedulable job:\t%d\n", qctx.NumSchedulingKeySkippedJobs)
+		}
+		if qctx.NumSchedulingKeyCollisions > 0 {
+			fmt.Fprintf(w, "Number of scheduling key collisions:\t%d\n", qctx.NumSchedulingKeyCollisions)
+		}
 		if len(qctx.SuccessfulJobSchedulingContexts) > 0 {
 			jobIdsToPrint := maps.Keys(qctx.SuccessfulJobSchedulingContexts)
 			if len(jobIdsToPrint) > maxJobIdsToPrint {
@@ -637,6 +858,45 @@ type JobSchedulingContext struct {
 	GangMinCardinality int
 	// If set, indicates this job should be failed back to the client when the gang is scheduled.
 	ShouldFail bool
+	// If set, PodRequirements.Priority is the highest priority class priority of any member of the job's gang,
+	// rather than that of the job itself. PodRequirements is then a copy, i.e., the job itself is unchanged.
+	HasInheritedGangPriority bool
+	// If set, the job wasn't evaluated individually, since the job with this id has the same scheduling key
+	// and was found unschedulable earlier in the round. UnschedulableReason is then that of the other job.
+	IdenticalToUnschedulableJobId string
+	// Primary cause of the job not being scheduled, derived from UnschedulableReason once the round is over.
+	// Empty if the job was scheduled successfully or hasn't been classified.
+	BlockingCause BlockingCause
+	// If set, the job was preempted since it was running on a node oversubscribed by jobs of higher priority,
+	// rather than to balance resource usage across queues.
+	IsUrgencyPreempted bool
+}
+
+// BlockingCause summarises why a job couldn't be scheduled.
+// Each unschedulable job is assigned exactly one cause.
+type BlockingCause string
+
+const (
+	// The job could have been scheduled were it not for the share of the pool assigned to other queues,
+	// i.e., its queue is at or above its fair share or the job would use resources reserved for other queues.
+	FairShareBlockingCause BlockingCause = "fair_share"
+	// The queue has reached a limit on the resources it may be allocated.
+	QueueCapBlockingCause BlockingCause = "queue_cap"
+	// The job can't be scheduled with the resources available to its queue, e.g., since it doesn't fit on any node.
+	InfeasibleBlockingCause BlockingCause = "infeasible"
+	// A scheduling rate limit has been reached.
+	RateLimitBlockingCause BlockingCause = "rate_limit"
+	// Fewer than the minimum number of jobs of the gang could be scheduled.
+	GangIncompleteBlockingCause BlockingCause = "gang_incomplete"
+)
+
+// BlockingCauses contains all blocking causes, in the order in which they're reported.
+var BlockingCauses = []BlockingCause{
+	FairShareBlockingCause,
+	QueueCapBlockingCause,
+	InfeasibleBlockingCause,
+	RateLimitBlockingCause,
+	GangIncompleteBlockingCause,
 }
 
 func (jctx *JobSchedulingContext) String() string {
@@ -644,19 +904,50 @@ func (jctx *JobSchedulingContext) String() string {
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
 	fmt.Fprintf(w, "Time:\t%s\n", jctx.Created)
 	fmt.Fprintf(w, "Job ID:\t%s\n", jctx.JobId)
+	if job, ok := jctx.Job.(interface {
+		PriorityClamped() bool
+		RequestedPriority() uint32
+	}); ok && job.PriorityClamped() {
+		fmt.Fprintf(
+			w, "Priority:\t%d (requested %d, clamped to the maximum job priority of the queue)\n",
+			jctx.Job.GetPerQueuePriority(), job.RequestedPriority(),
+		)
+	}
+	if jctx.Job != nil && jctx.Job.GetAnnotations()[configuration.CorrelationIdAnnotation] != "" {
+		fmt.Fprintf(w, "Correlation ID:\t%s\n", jctx.Job.GetAnnotations()[configuration.CorrelationIdAnnotation])
+	}
 	if jctx.UnschedulableReason != "" {
 		fmt.Fprintf(w, "UnschedulableReason:\t%s\n", jctx.UnschedulableReason)
 	} else {
 		fmt.Fprint(w, "UnschedulableReason:\tnone\n")
 	}
+	if jctx.BlockingCause != "" {
+		fmt.Fprintf(w, "Blocking cause:\t%s\n", jctx.BlockingCause)
+	}
+	if jctx.IdenticalToUnschedulableJobId != "" {
+		fmt.Fprintf(w, "Skipped:\tidentical to job %s found unschedulable\n", jctx.IdenticalToUnschedulableJobId)
+	}
 	if jctx.PodSchedulingContext != nil {
 		fmt.Fprint(w, jctx.PodSchedulingContext.String())
 	}
 	fmt.Fprintf(w, "GangMinCardinality:\t%d\n", jctx.GangMinCardinality)
+	if jctx.HasInheritedGangPriority {
+		fmt.Fprintf(w, "Inherited gang priority:\t%d\n", jctx.PodRequirements.Priority)
+	}
 	w.Flush()
 	return sb.String()
 }
 
+// InheritGangPriority sets PodRequirements.Priority to priority without modifying the requirements stored with the job.
+func (jctx *JobSchedulingContext) InheritGangPriority(priority int32) {
+	if jctx.PodRequirements.Priority != priority {
+		req := *jctx.PodRequirements
+		req.Priority = priority
+		jctx.PodRequirements = &req
+	}
+	jctx.HasInheritedGangPriority = true
+}
+
 // SchedulingKey returns the scheduling key of the embedded job.
 // If the jctx contains additional node selectors or tolerations,
 // the key is invalid and the second return value is false.
@@ -671,6 +962,12 @@ func (jctx *JobSchedulingContext) SchedulingKey() (schedulerobjects.SchedulingKe
 	return schedulingKey, true
 }
 
+// SchedulingKeySkippingDisabled returns true if the job has opted out of being skipped
+// when a job with the same scheduling key has been found unschedulable.
+func (jctx *JobSchedulingContext) SchedulingKeySkippingDisabled() bool {
+	return jctx.Job.GetAnnotations()[configuration.DisableSchedulingKeySkippingAnnotation] == "true"
+}
+
 func (jctx *JobSchedulingContext) IsSuccessful() bool {
 	return jctx.UnschedulableReason == ""
 }
@@ -710,14 +1007,19 @@ func JobSchedulingContextsFromJobs[J interfaces.LegacySchedulerJob](priorityClas
 func JobSchedulingContextFromJob(priorityClasses map[string]types.PriorityClass, job interfaces.LegacySchedulerJob, extractGangInfo func(map[string]string) (string, int, int, bool, error)) *JobSchedulingContext {
 	// TODO: Move cardinality to gang context only and remove from here.
 	// Requires re-phrasing nodedb in terms of gang context, as well as feeding the value extracted from the annotations downstream.
-	gangId, gangCardinality, gangMinCardinality, _, err := extractGangInfo(job.GetAnnotations())
+	// Jobs whose gang was checkpointed when they were admitted keep it, however their annotations are parsed now.
+	gangId, gangCardinality, gangMinCardinality, ok := checkpointedGangInfo(job)
+	var err error
+	if !ok {
+		gangId, gangCardinality, gangMinCardinality, _, err = extractGangInfo(job.GetAnnotations())
+	}
 	if err != nil {
 		logrus.Errorf("failed to get cardinality from job %s: %s", job.GetId(), err)
 		gangId = job.GetId()
 		gangCardinality = 1
 		gangMinCardinality = 1
 	}
-	return &JobSchedulingContext{
+	jctx := &JobSchedulingContext{
 		Created:            time.Now(),
 		JobId:              job.GetId(),
 		Job:                job,
@@ -727,6 +1029,22 @@ func JobSchedulingContextFromJob(priorityClasses map[string]types.PriorityClass,
 		GangMinCardinality: gangMinCardinality,
 		ShouldFail:         false,
 	}
+	// Tolerations the scheduler adds to those of the job, e.g., the default tolerations of its queue.
+	if job, ok := job.(interface{ InjectedTolerations() []v1.Toleration }); ok {
+		jctx.AdditionalTolerations = job.InjectedTolerations()
+	}
+	return jctx
+}
+
+// checkpointedGangInfo returns the gang id, cardinality, and minimum cardinality checkpointed for job and true,
+// or false if it has none; see jobdb.JobDb.EnableParameterCheckpoints.
+func checkpointedGangInfo(job interfaces.LegacySchedulerJob) (string, int, int, bool) {
+	if job, ok := job.(interface {
+		CheckpointedGangInfo() (string, int, int, bool)
+	}); ok {
+		return job.CheckpointedGangInfo()
+	}
+	return "", 0, 0, false
 }
 
 // PodSchedulingContext is returned by SelectAndBindNodeToPod and
@@ -746,6 +1064,11 @@ type PodSchedulingContext struct {
 	NumNodes int
 	// Number of nodes excluded by reason.
 	NumExcludedNodesByReason map[string]int
+	// If non-empty, nodes of this executor were preferred, since it's the preferred executor of the job set of the pod.
+	PreferredExecutor string
+	// Weighted score of the node the pod was assigned to by each scorer of the node scoring pipeline, if any,
+	// indexed by scorer name.
+	NodeScores map[string]float64
 }
 
 func (pctx *PodSchedulingContext) IsSuccessful() bool {
@@ -760,6 +1083,17 @@ func (pctx *PodSchedulingContext) String() string {
 	} else {
 		fmt.Fprint(w, "Node:\tnone\n")
 	}
+	if pctx.PreferredExecutor != "" {
+		fmt.Fprintf(w, "Preferred executor:\t%s\n", pctx.PreferredExecutor)
+	}
+	if len(pctx.NodeScores) > 0 {
+		fmt.Fprint(w, "Node scores:\n")
+		scorers := maps.Keys(pctx.NodeScores)
+		slices.Sort(scorers)
+		for _, scorer := range scorers {
+			fmt.Fprintf(w, "\t%s:\t%f\n", scorer, pctx.NodeScores[scorer])
+		}
+	}
 	fmt.Fprintf(w, "Number of nodes in cluster:\t%d\n", pctx.NumNodes)
 	if len(pctx.NumExcludedNodesByReason) == 0 {
 		fmt.Fprint(w, "Excluded nodes:\tnone\n")
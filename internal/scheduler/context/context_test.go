@@ -78,6 +78,46 @@ func TestSchedulingContextAccounting(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestQueueSchedulingContext_Share(t *testing.T) {
+	totalResources := schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("4")}}
+	fairnessCostProvider, err := fairness.NewAssetFairness(map[string]float64{"cpu": 1})
+	require.NoError(t, err)
+	sctx := NewSchedulingContext(
+		"executor",
+		"pool",
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestDefaultPriorityClass,
+		fairnessCostProvider,
+		nil,
+		totalResources,
+	)
+	allocatedByQueueAndPriorityClass := map[string]schedulerobjects.QuantityByTAndResourceType[string]{
+		"A": {
+			"foo": schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+		},
+	}
+	for _, queue := range []string{"A", "B"} {
+		err := sctx.AddQueueSchedulingContext(queue, 1, allocatedByQueueAndPriorityClass[queue], nil)
+		require.NoError(t, err)
+	}
+	qctxA := sctx.QueueSchedulingContexts["A"]
+	qctxB := sctx.QueueSchedulingContexts["B"]
+	assert.Equal(t, 1.0, qctxA.InitialShare())
+	assert.Equal(t, 0.0, qctxB.InitialShare())
+
+	// Jobs scheduled for B count towards its share immediately, but not towards its initial share.
+	_, err = sctx.AddGangSchedulingContext(NewGangSchedulingContext(testNSmallCpuJobSchedulingContext("B", testfixtures.TestDefaultPriorityClass, 3)))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, qctxA.InitialShare())
+	assert.Equal(t, 0.0, qctxB.InitialShare())
+	assert.Equal(t, 0.25, qctxA.Share())
+	assert.Equal(t, 0.75, qctxB.Share())
+
+	// Reports of all queues use the same shares as those of individual queues.
+	assert.Regexp(t, `Share before scheduling: +0\.000\n *Share after scheduling: +0\.750\n`, qctxB.ReportString(0))
+	assert.Regexp(t, `Share before scheduling: +0\.000\n *Share after scheduling: +0\.750\n`, sctx.ReportString(2))
+}
+
 func testNSmallCpuJobSchedulingContext(queue, priorityClassName string, n int) []*JobSchedulingContext {
 	rv := make([]*JobSchedulingContext, n)
 	for i := 0; i < n; i++ {
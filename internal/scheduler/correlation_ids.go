@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// attachCorrelationIds sets the correlation id of each event relating to a job with a correlation id annotation
+// and logs the event along with the correlation id. Jobs are looked up in txn or, for jobs deleted from the jobDb
+// upon becoming terminal, among updatedJobs. Events for jobs without the annotation are left unchanged.
+func attachCorrelationIds(ctx *armadacontext.Context, txn *jobdb.Txn, updatedJobs []*jobdb.Job, events []*armadaevents.EventSequence) {
+	var updatedJobsById map[string]*jobdb.Job
+	for _, sequence := range events {
+		for _, event := range sequence.Events {
+			protoJobId, err := armadaevents.JobIdFromEvent(event)
+			if err != nil {
+				continue
+			}
+			jobId, err := armadaevents.UlidStringFromProtoUuid(protoJobId)
+			if err != nil {
+				continue
+			}
+			job := txn.GetById(jobId)
+			if job == nil {
+				if updatedJobsById == nil {
+					updatedJobsById = make(map[string]*jobdb.Job, len(updatedJobs))
+					for _, updatedJob := range updatedJobs {
+						updatedJobsById[updatedJob.Id()] = updatedJob
+					}
+				}
+				job = updatedJobsById[jobId]
+			}
+			if job == nil {
+				continue
+			}
+			correlationId := job.GetAnnotations()[configuration.CorrelationIdAnnotation]
+			if correlationId == "" {
+				continue
+			}
+			event.CorrelationId = correlationId
+			ctx.WithFields(logrus.Fields{
+				"jobId":         jobId,
+				"queue":         sequence.Queue,
+				"jobSet":        sequence.JobSetName,
+				"correlationId": correlationId,
+			}).Debugf("publishing %T", event.Event)
+		}
+	}
+}
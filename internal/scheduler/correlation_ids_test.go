@@ -0,0 +1,144 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_CorrelationIds(t *testing.T) {
+	tests := map[string]struct {
+		annotations           map[string]string
+		expectedCorrelationId string
+	}{
+		"correlation id annotation": {
+			annotations:           map[string]string{configuration.CorrelationIdAnnotation: "client-request-1"},
+			expectedCorrelationId: "client-request-1",
+		},
+		"no correlation id annotation": {
+			annotations: map[string]string{"foo": "bar"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			testClock := clock.NewFakeClock(time.Now())
+			jobRepo := &testJobRepository{}
+			schedulingAlgo := &testSchedulingAlgo{}
+			publisher := &testPublisher{}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				jobRepo,
+				&testExecutorRepository{
+					executors: []*schedulerobjects.Executor{{Id: "testExecutor", LastUpdateTime: testClock.Now().Add(24 * time.Hour)}},
+				},
+				schedulingAlgo,
+				NewStandaloneLeaderController(),
+				publisher,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = testClock
+
+			job := testfixtures.JobDb.NewJob(
+				util.NewULID(),
+				"testJobset",
+				"testQueue",
+				uint32(10),
+				&schedulerobjects.JobSchedulingInfo{
+					ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+						{
+							Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+								PodRequirements: &schedulerobjects.PodRequirements{
+									Priority:    int32(10),
+									Annotations: tc.annotations,
+								},
+							},
+						},
+					},
+					Version: 1,
+				},
+				true,
+				1,
+				false,
+				false,
+				false,
+				1,
+			)
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+			txn.Commit()
+
+			// The job is leased.
+			schedulingAlgo.jobsToSchedule = []string{job.Id()}
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+			require.NoError(t, err)
+			schedulingAlgo.jobsToSchedule = nil
+			leasedEvents := collectEventsOfJob(publisher.events, job.Id())
+			require.NotEmpty(t, leasedEvents)
+			var leased bool
+			for _, event := range leasedEvents {
+				leased = leased || event.GetJobRunLeased() != nil
+				assert.Equal(t, tc.expectedCorrelationId, event.CorrelationId)
+			}
+			assert.True(t, leased)
+
+			// The run fails.
+			run := sched.jobDb.ReadTxn().GetById(job.Id()).LatestRun()
+			require.NotNil(t, run)
+			jobRepo.updatedRuns = []database.Run{{
+				RunID:    run.Id(),
+				JobID:    job.Id(),
+				JobSet:   "testJobSet",
+				Executor: "testExecutor",
+				Failed:   true,
+				Serial:   1,
+			}}
+			jobRepo.errors = map[uuid.UUID]*armadaevents.Error{run.Id(): defaultJobRunError}
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+			failedEvents := collectEventsOfJob(publisher.events, job.Id())
+			require.Len(t, failedEvents, 1)
+			assert.NotNil(t, failedEvents[0].GetJobErrors())
+			assert.Equal(t, tc.expectedCorrelationId, failedEvents[0].CorrelationId)
+		})
+	}
+}
+
+func collectEventsOfJob(eventSequences []*armadaevents.EventSequence, jobId string) []*armadaevents.EventSequence_Event {
+	var rv []*armadaevents.EventSequence_Event
+	for _, eventSequence := range eventSequences {
+		for _, event := range eventSequence.Events {
+			protoJobId, err := armadaevents.JobIdFromEvent(event)
+			if err != nil {
+				continue
+			}
+			if id, err := armadaevents.UlidStringFromProtoUuid(protoJobId); err == nil && id == jobId {
+				rv = append(rv, event)
+			}
+		}
+	}
+	return rv
+}
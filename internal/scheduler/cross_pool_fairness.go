@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+)
+
+// crossPoolUsageByQueue returns the usage share of each queue allocated resources in any pool,
+// in pool and across all pools; see configuration.CrossPoolFairnessConfig.
+// Pools without capacity, or with zero importance, don't contribute to the aggregate share.
+func (l *FairSchedulingAlgo) crossPoolUsageByQueue(fsctx *fairSchedulingAlgoContext, pool string) (map[string]*schedulercontext.CrossPoolUsage, error) {
+	config := l.schedulingConfig.CrossPoolFairness
+	// Usage share of each queue in each pool, multiplied by the importance of the pool.
+	weightedShareByQueueAndPool := make(map[string]map[string]float64)
+	shareByQueue := make(map[string]float64)
+	totalImportance := 0.0
+	for p, totalResources := range fsctx.totalCapacityByPool {
+		importance, ok := config.PoolImportance[p]
+		if !ok {
+			importance = 1
+		}
+		fairnessCostProvider, err := l.fairnessCostProvider(totalResources)
+		if err != nil {
+			return nil, err
+		}
+		totalCost := fairnessCostProvider.CostFromAllocationAndWeight(totalResources, 1)
+		if totalCost <= 0 || importance == 0 {
+			continue
+		}
+		totalImportance += importance
+		for queue, allocatedByPriorityClass := range fsctx.allocationByPoolAndQueueAndPriorityClass[p] {
+			share := fairnessCostProvider.CostFromAllocationAndWeight(allocatedByPriorityClass.AggregateByResource(), 1) / totalCost
+			if p == pool {
+				shareByQueue[queue] = share
+			}
+			weightedShareByPool := weightedShareByQueueAndPool[queue]
+			if weightedShareByPool == nil {
+				weightedShareByPool = make(map[string]float64)
+				weightedShareByQueueAndPool[queue] = weightedShareByPool
+			}
+			weightedShareByPool[p] = importance * share
+		}
+	}
+	rv := make(map[string]*schedulercontext.CrossPoolUsage, len(weightedShareByQueueAndPool))
+	if totalImportance == 0 {
+		return rv, nil
+	}
+	for queue, weightedShareByPool := range weightedShareByQueueAndPool {
+		usage := &schedulercontext.CrossPoolUsage{PoolShare: shareByQueue[queue]}
+		for p, weightedShare := range weightedShareByPool {
+			usage.AggregateShare += weightedShare / totalImportance
+			if p != pool {
+				usage.OtherPoolsShare += weightedShare / totalImportance
+			}
+		}
+		rv[queue] = usage
+	}
+	return rv, nil
+}
+
+// crossPoolEffectiveWeight returns the weight of a queue with the provided usage, reduced by its usage of other pools.
+func (l *FairSchedulingAlgo) crossPoolEffectiveWeight(weight float64, usage *schedulercontext.CrossPoolUsage) float64 {
+	return weight / (1 + l.schedulingConfig.CrossPoolFairness.Strength*usage.OtherPoolsShare)
+}
@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestCrossPoolFairness_QueueUsingOtherPoolReceivesLess(t *testing.T) {
+	tests := map[string]struct {
+		config configuration.CrossPoolFairnessConfig
+		// If true, the queue using all of pool-a is expected to be scheduled fewer jobs in pool-b than the other queue.
+		// Otherwise, both queues are expected to be scheduled an equal number of jobs in pool-b.
+		expectHeavyReceivesLess bool
+		// Expected usage of the queue using all of pool-a when scheduling pool-b; nil if cross-pool fairness is disabled.
+		expectedHeavyUsage *schedulercontext.CrossPoolUsage
+	}{
+		"disabled": {
+			config: configuration.CrossPoolFairnessConfig{Enabled: false, Strength: 1},
+		},
+		"enabled": {
+			config:                  configuration.CrossPoolFairnessConfig{Enabled: true, Strength: 1},
+			expectHeavyReceivesLess: true,
+			expectedHeavyUsage:      &schedulercontext.CrossPoolUsage{AggregateShare: 0.5, OtherPoolsShare: 0.5},
+		},
+		"other pool unimportant": {
+			config: configuration.CrossPoolFairnessConfig{
+				Enabled:        true,
+				Strength:       1,
+				PoolImportance: map[string]float64{"pool-a": 0},
+			},
+			expectedHeavyUsage: &schedulercontext.CrossPoolUsage{},
+		},
+		"zero strength": {
+			config:             configuration.CrossPoolFairnessConfig{Enabled: true, Strength: 0},
+			expectedHeavyUsage: &schedulercontext.CrossPoolUsage{AggregateShare: 0.5, OtherPoolsShare: 0.5},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := armadacontext.Background()
+			config := testfixtures.TestSchedulingConfig()
+			config.CrossPoolFairness = tc.config
+
+			var executors []*schedulerobjects.Executor
+			for _, pool := range []string{"pool-a", "pool-b"} {
+				nodes := testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)
+				nodes[0].Executor = pool + "-executor"
+				executors = append(executors, &schedulerobjects.Executor{
+					Id:             pool + "-executor",
+					Pool:           pool,
+					Nodes:          nodes,
+					LastUpdateTime: testfixtures.BaseTime,
+				})
+			}
+			ctrl := gomock.NewController(t)
+			mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+			mockExecutorRepo.EXPECT().GetExecutors(ctx).Return(executors, nil).AnyTimes()
+			mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+			mockQueueRepo.EXPECT().GetAllQueues().Return(
+				[]*database.Queue{{Name: "heavy", Weight: 1}, {Name: "light", Weight: 1}},
+				nil,
+			).AnyTimes()
+			algo, err := NewFairSchedulingAlgo(config, 0, mockExecutorRepo, mockQueueRepo, nil)
+			require.NoError(t, err)
+			algo.clock = clock.NewFakeClock(testfixtures.BaseTime)
+
+			// Queue heavy is running non-preemptible jobs on all of pool-a.
+			poolANode := executors[0].Nodes[0]
+			runningJobs := testfixtures.N1Cpu4GiJobs("heavy", testfixtures.PriorityClass2NonPreemptible, 32)
+			for i, job := range runningJobs {
+				runningJobs[i] = job.WithQueued(false).WithNewRun(executors[0].Id, poolANode.Id, poolANode.Name, 0, testfixtures.BaseTime)
+			}
+			// Both queues submit enough jobs to fill pool-b on their own.
+			heavyJobs := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("heavy", testfixtures.PriorityClass0, 32))
+			lightJobs := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("light", testfixtures.PriorityClass0, 32))
+			jobDb := testfixtures.NewJobDb()
+			txn := jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert(runningJobs))
+			require.NoError(t, txn.Upsert(heavyJobs))
+			require.NoError(t, txn.Upsert(lightJobs))
+
+			result, err := algo.Schedule(ctx, txn)
+			require.NoError(t, err)
+			numScheduledByQueue := make(map[string]int)
+			for _, job := range ScheduledJobsFromSchedulerResult[*jobdb.Job](result) {
+				assert.Equal(t, executors[1].Id, job.LatestRun().Executor())
+				numScheduledByQueue[job.Queue()]++
+			}
+			assert.Equal(t, 32, numScheduledByQueue["heavy"]+numScheduledByQueue["light"])
+			if tc.expectHeavyReceivesLess {
+				assert.Less(t, numScheduledByQueue["heavy"], numScheduledByQueue["light"])
+			} else {
+				assert.Equal(t, numScheduledByQueue["heavy"], numScheduledByQueue["light"])
+			}
+
+			var poolBSctx *schedulercontext.SchedulingContext
+			for _, sctx := range result.SchedulingContexts {
+				if sctx.Pool == "pool-b" {
+					poolBSctx = sctx
+				}
+			}
+			require.NotNil(t, poolBSctx)
+			heavyUsage := poolBSctx.QueueSchedulingContexts["heavy"].CrossPoolUsage
+			if tc.expectedHeavyUsage == nil {
+				assert.Nil(t, heavyUsage)
+			} else {
+				require.NotNil(t, heavyUsage)
+				assert.InDelta(t, tc.expectedHeavyUsage.PoolShare, heavyUsage.PoolShare, 1e-6)
+				assert.InDelta(t, tc.expectedHeavyUsage.AggregateShare, heavyUsage.AggregateShare, 1e-6)
+				assert.InDelta(t, tc.expectedHeavyUsage.OtherPoolsShare, heavyUsage.OtherPoolsShare, 1e-6)
+				assert.Equal(t, &schedulercontext.CrossPoolUsage{}, poolBSctx.QueueSchedulingContexts["light"].CrossPoolUsage)
+			}
+		})
+	}
+}
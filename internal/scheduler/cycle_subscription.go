@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// Number of cycle summaries buffered per subscriber.
+// Once a subscriber's buffer is full, its oldest unreceived summary is dropped to make room for the newest one.
+const cycleSubscriptionBufferSize = 16
+
+// CycleSummary summarises a completed scheduler cycle.
+type CycleSummary struct {
+	// Id of the cycle, as included in the logs of the cycle.
+	CycleId string
+	// Time at which the cycle completed, according to the scheduler's clock.
+	CompletedAt time.Time
+	// Time taken by the cycle.
+	Duration time.Duration
+	// True if the scheduler was leader for the cycle.
+	Leader bool
+	// True if the cycle included a scheduling round, as opposed to only reconciling state.
+	Scheduled bool
+	// Number of jobs scheduled, preempted, and failed by the scheduling round, if any.
+	NumScheduledJobs int
+	NumPreemptedJobs int
+	NumFailedJobs    int
+	// Error causing the cycle to fail, if any.
+	Err error
+}
+
+// cycleSubscribers delivers cycle summaries to subscribers.
+// Delivery never blocks, such that a slow subscriber can't hold up the scheduler.
+type cycleSubscribers struct {
+	mu          sync.Mutex
+	subscribers map[<-chan CycleSummary]chan CycleSummary
+}
+
+// Subscribe returns a channel on which a summary of each cycle completed from now on is received,
+// until the channel is passed to Unsubscribe. At most a bounded number of summaries is buffered;
+// subscribers that fall behind miss the oldest summaries they haven't yet received.
+// It's safe to call concurrently with Run.
+func (s *Scheduler) Subscribe() <-chan CycleSummary {
+	s.cycleSubscribers.mu.Lock()
+	defer s.cycleSubscribers.mu.Unlock()
+	if s.cycleSubscribers.subscribers == nil {
+		s.cycleSubscribers.subscribers = make(map[<-chan CycleSummary]chan CycleSummary)
+	}
+	c := make(chan CycleSummary, cycleSubscriptionBufferSize)
+	s.cycleSubscribers.subscribers[c] = c
+	return c
+}
+
+// Unsubscribe stops delivery of cycle summaries to a channel returned by Subscribe and closes it.
+// Summaries already buffered can still be received. Unsubscribing a channel more than once has no effect.
+func (s *Scheduler) Unsubscribe(c <-chan CycleSummary) {
+	s.cycleSubscribers.mu.Lock()
+	defer s.cycleSubscribers.mu.Unlock()
+	if subscriber, ok := s.cycleSubscribers.subscribers[c]; ok {
+		delete(s.cycleSubscribers.subscribers, c)
+		close(subscriber)
+	}
+}
+
+// publish delivers summary to all subscribers without blocking.
+func (cs *cycleSubscribers) publish(summary CycleSummary) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for _, subscriber := range cs.subscribers {
+		select {
+		case subscriber <- summary:
+			continue
+		default:
+		}
+		// The buffer is full; drop the oldest summary to make room.
+		// The subscriber may have drained the buffer in the meantime, in which case there's nothing to drop.
+		select {
+		case <-subscriber:
+		default:
+		}
+		// Summaries are only sent while holding cs.mu, so there's now room in the buffer.
+		subscriber <- summary
+	}
+}
+
+func newCycleSummary(cycleId string, completedAt time.Time, duration time.Duration, leaderToken LeaderToken, scheduled bool, result SchedulerResult, err error) CycleSummary {
+	return CycleSummary{
+		CycleId:     cycleId,
+		CompletedAt: completedAt,
+		Duration:    duration,
+		Leader:      leaderToken.leader,
+		Scheduled:   scheduled,
+		Err:         err,
+		// Results of failed cycles may be incomplete, but the number of jobs is still informative.
+		NumScheduledJobs: len(result.ScheduledJobs),
+		NumPreemptedJobs: len(result.PreemptedJobs),
+		NumFailedJobs:    len(result.FailedJobs),
+	}
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestScheduler_CycleSubscriptions(t *testing.T) {
+	testClock := clock.NewFakeClock(time.Now())
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&testJobRepository{numReceivedPartitions: 100},
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		&testSubmitChecker{checkSuccess: true},
+		1*time.Second,
+		15*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+
+	// The first subscriber receives every summary, the second never receives any.
+	fast := sched.Subscribe()
+	slow := sched.Subscribe()
+	unsubscribed := sched.Subscribe()
+	sched.Unsubscribe(unsubscribed)
+	sched.Unsubscribe(unsubscribed)
+
+	ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+	defer cancel()
+	//nolint:errcheck
+	go sched.Run(ctx)
+	time.Sleep(1 * time.Second)
+
+	// The slow subscriber must not hold up cycles, even once its buffer is full.
+	numCycles := 2 * cycleSubscriptionBufferSize
+	cycleIds := make([]string, numCycles)
+	for i := 0; i < numCycles; i++ {
+		testClock.Step(10 * time.Second)
+		select {
+		case summary := <-fast:
+			assert.True(t, summary.Leader)
+			assert.NoError(t, summary.Err)
+			cycleIds[i] = summary.CycleId
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for cycle to complete")
+		}
+	}
+	cancel()
+
+	// The slow subscriber is left with the most recent summaries.
+	sched.Unsubscribe(slow)
+	var slowCycleIds []string
+	for summary := range slow {
+		slowCycleIds = append(slowCycleIds, summary.CycleId)
+	}
+	assert.Equal(t, cycleIds[numCycles-cycleSubscriptionBufferSize:], slowCycleIds)
+
+	// Unsubscribed channels are closed and don't receive summaries.
+	_, ok := <-unsubscribed
+	assert.False(t, ok)
+}
+
+func TestNewCycleSummary(t *testing.T) {
+	now := time.Now()
+	result := SchedulerResult{
+		ScheduledJobs: make([]*schedulercontext.JobSchedulingContext, 3),
+		PreemptedJobs: make([]*schedulercontext.JobSchedulingContext, 2),
+		FailedJobs:    make([]*schedulercontext.JobSchedulingContext, 1),
+	}
+	assert.Equal(
+		t,
+		CycleSummary{
+			CycleId:          "cycle",
+			CompletedAt:      now,
+			Duration:         time.Second,
+			Leader:           true,
+			Scheduled:        true,
+			NumScheduledJobs: 3,
+			NumPreemptedJobs: 2,
+			NumFailedJobs:    1,
+		},
+		newCycleSummary("cycle", now, time.Second, NewLeaderToken(), true, result, nil),
+	)
+	err := errors.New("failed")
+	assert.Equal(
+		t,
+		CycleSummary{CycleId: "cycle", CompletedAt: now, Err: err},
+		newCycleSummary("cycle", now, 0, InvalidLeaderToken(), false, SchedulerResult{}, err),
+	)
+}
@@ -83,6 +83,8 @@ func PruneDb(ctx *armadacontext.Context, db *pgx.Conn, batchLimit int, keepAfter
 						DELETE FROM runs WHERE job_id in (SELECT job_id from batch);
 						DELETE FROM jobs WHERE job_id in (SELECT job_id from batch);
 						DELETE FROM job_run_errors WHERE job_id in (SELECT job_id from batch);
+						DELETE FROM job_run_resource_usage WHERE job_id in (SELECT job_id from batch);
+						DELETE FROM job_parameter_checkpoints WHERE job_id in (SELECT job_id from batch);
 						DELETE FROM rows_to_delete WHERE job_id in (SELECT job_id from batch);
 						TRUNCATE TABLE batch;`)
 			return err
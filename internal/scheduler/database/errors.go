@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+// ErrTransient is matched, via errors.Is, by errors returned by repositories if the operation may succeed if retried,
+// e.g., because the connection to the database was lost or because a transaction couldn't be serialised.
+var ErrTransient = errors.New("transient repository error")
+
+// ErrNotFound is returned by repositories if the requested object doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrCorruptRow is returned by repositories if a row couldn't be decoded.
+// Retrying won't help; the row has to be skipped or repaired.
+type ErrCorruptRow struct {
+	// Table the row is stored in.
+	Table string
+	// Id of the job the row relates to, if any.
+	JobID string
+	// Id of the run the row relates to, if any.
+	RunID uuid.UUID
+	// Id of the executor the row relates to, if any.
+	ExecutorID string
+	// Error encountered when decoding the row.
+	Err error
+}
+
+func (err *ErrCorruptRow) Error() string {
+	var ids []string
+	if err.JobID != "" {
+		ids = append(ids, "job "+err.JobID)
+	}
+	if err.RunID != uuid.Nil {
+		ids = append(ids, "run "+err.RunID.String())
+	}
+	if err.ExecutorID != "" {
+		ids = append(ids, "executor "+err.ExecutorID)
+	}
+	return fmt.Sprintf("corrupt row in table %s for %s: %s", err.Table, strings.Join(ids, ", "), err.Err)
+}
+
+func (err *ErrCorruptRow) Unwrap() error {
+	return err.Err
+}
+
+// transientError wraps errors for which retrying the operation may succeed, such that they match ErrTransient.
+type transientError struct {
+	err error
+}
+
+func (err *transientError) Error() string {
+	return err.err.Error()
+}
+
+func (err *transientError) Unwrap() error {
+	return err.err
+}
+
+func (err *transientError) Is(target error) bool {
+	return target == ErrTransient
+}
+
+// classifyError returns err wrapped such that it matches ErrTransient if retrying the operation that returned it
+// may succeed, and err unchanged otherwise. Cancellation is never considered transient.
+func classifyError(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	if isTransient(err) {
+		return &transientError{err: err}
+	}
+	return err
+}
+
+func isTransient(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case
+			"40001", // serialization_failure
+			"40P01", // deadlock_detected
+			"53300", // too_many_connections
+			"57P01", // admin_shutdown
+			"57P03": // cannot_connect_now
+			return true
+		}
+		// Class 08 - connection exception.
+		return strings.HasPrefix(pgErr.Code, "08")
+	}
+	if pgconn.SafeToRetry(err) || pgconn.Timeout(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := map[string]struct {
+		err               error
+		expectedTransient bool
+	}{
+		"nil": {
+			err: nil,
+		},
+		"serialization failure": {
+			err:               &pgconn.PgError{Code: "40001"},
+			expectedTransient: true,
+		},
+		"connection failure": {
+			err:               errors.WithStack(&pgconn.PgError{Code: "08006"}),
+			expectedTransient: true,
+		},
+		"unique violation": {
+			err: &pgconn.PgError{Code: "23505"},
+		},
+		"cancelled": {
+			err: errors.WithStack(context.Canceled),
+		},
+		"other": {
+			err: errors.New("something went wrong"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := classifyError(tc.err)
+			assert.Equal(t, tc.expectedTransient, errors.Is(err, ErrTransient))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestErrCorruptRow(t *testing.T) {
+	runId := uuid.New()
+	decodeErr := errors.New("invalid compressed data")
+	var err error = errors.WithStack(&ErrCorruptRow{Table: "job_run_errors", JobID: "foo", RunID: runId, Err: decodeErr})
+
+	var corruptRow *ErrCorruptRow
+	assert.True(t, errors.As(err, &corruptRow))
+	assert.Equal(t, runId, corruptRow.RunID)
+	assert.ErrorIs(t, err, decodeErr)
+	assert.False(t, errors.Is(err, ErrTransient))
+	assert.Equal(t, "corrupt row in table job_run_errors for job foo, run "+runId.String()+": invalid compressed data", corruptRow.Error())
+}
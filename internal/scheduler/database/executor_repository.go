@@ -4,17 +4,21 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	"github.com/armadaproject/armada/internal/common/compress"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/api"
 )
 
-// ExecutorRepository is an interface to be implemented by structs which provide executor information
+// ExecutorRepository is an interface to be implemented by structs which provide executor information.
+// Errors for which retrying may succeed match ErrTransient.
 type ExecutorRepository interface {
-	// GetExecutors returns all known executors, regardless of their last heartbeat time
+	// GetExecutors returns all known executors, regardless of their last heartbeat time.
+	// If the state of any executor can't be decoded, an *ErrCorruptRow identifying that executor is returned.
 	GetExecutors(ctx *armadacontext.Context) ([]*schedulerobjects.Executor, error)
 	// GetLastUpdateTimes returns a map of executor name -> last heartbeat time
 	GetLastUpdateTimes(ctx *armadacontext.Context) (map[string]time.Time, error)
@@ -22,6 +26,24 @@ type ExecutorRepository interface {
 	StoreExecutor(ctx *armadacontext.Context, executor *schedulerobjects.Executor) error
 }
 
+// ExecutorNodeDeltaRepository is an ExecutorRepository that can update the nodes of a stored executor individually,
+// such that executors with many nodes need only report those that changed.
+type ExecutorNodeDeltaRepository interface {
+	ExecutorRepository
+	// GetExecutor returns the executor with the provided id, or nil if no such executor is stored.
+	GetExecutor(ctx *armadacontext.Context, executorId string) (*schedulerobjects.Executor, error)
+	// StoreExecutorNodeDelta persists the latest executor state, together with the nodes added or changed since it was
+	// last stored, i.e., updatedNodes, and the names of those removed since, i.e., removedNodeNames.
+	// The nodes of executor must be all of its nodes once the delta is applied; they may be stored in place of the delta,
+	// e.g., if the nodes last stored for the executor can't be updated individually.
+	StoreExecutorNodeDelta(
+		ctx *armadacontext.Context,
+		executor *schedulerobjects.Executor,
+		updatedNodes []*schedulerobjects.Node,
+		removedNodeNames []string,
+	) error
+}
+
 // PostgresExecutorRepository is an implementation of ExecutorRepository that stores its state in postgres
 type PostgresExecutorRepository struct {
 	// pool of database connections
@@ -29,6 +51,11 @@ type PostgresExecutorRepository struct {
 	// proto objects are stored compressed
 	compressor   compress.Compressor
 	decompressor compress.Decompressor
+	// If true, the ids of nodes of executors read are recreated with api.LengthPrefixedNodeIdFromExecutorAndNodeName.
+	lengthPrefixedNodeIds bool
+	// If true, nodes are stored in the executor_nodes table, one row per node, rather than with the rest of the executor
+	// in executors.last_request.
+	nodeRows bool
 }
 
 func NewPostgresExecutorRepository(db *pgxpool.Pool) *PostgresExecutorRepository {
@@ -39,17 +66,36 @@ func NewPostgresExecutorRepository(db *pgxpool.Pool) *PostgresExecutorRepository
 	}
 }
 
+// EnableLengthPrefixedNodeIds causes the ids of the nodes of executors read to be recreated with
+// api.LengthPrefixedNodeIdFromExecutorAndNodeName. This migrates executor state stored before length-prefixed node ids
+// were enabled, such that node ids match those of runs without waiting for each executor to report its nodes again.
+func (r *PostgresExecutorRepository) EnableLengthPrefixedNodeIds() {
+	r.lengthPrefixedNodeIds = true
+}
+
+// EnableNodeRows causes executors to be stored with their nodes in the executor_nodes table, one row per node, such that
+// StoreExecutorNodeDelta can update them individually. Executors are read correctly however they were stored,
+// but schedulers that don't support the executor_nodes table read executors stored this way as having no nodes.
+// Hence, this should only be enabled once all schedulers sharing the database support it, and, when rolling back to
+// a scheduler that doesn't, it should first be disabled until each executor has been stored again.
+func (r *PostgresExecutorRepository) EnableNodeRows() {
+	r.nodeRows = true
+}
+
 // GetExecutors returns all known executors, regardless of their last heartbeat time
 func (r *PostgresExecutorRepository) GetExecutors(ctx *armadacontext.Context) ([]*schedulerobjects.Executor, error) {
 	queries := New(r.db)
 	requests, err := queries.SelectAllExecutors(ctx)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, errors.WithStack(classifyError(err))
+	}
+	nodesByExecutorId, err := r.selectExecutorNodes(ctx, `SELECT executor_id, node FROM executor_nodes ORDER BY executor_id, node_name`)
+	if err != nil {
+		return nil, err
 	}
 	executors := make([]*schedulerobjects.Executor, len(requests))
 	for i, request := range requests {
-		executor := &schedulerobjects.Executor{}
-		err := decompressAndMarshall(request.LastRequest, r.decompressor, executor)
+		executor, err := r.decodeExecutor(request.ExecutorID, request.LastRequest, nodesByExecutorId[request.ExecutorID])
 		if err != nil {
 			return nil, err
 		}
@@ -58,12 +104,78 @@ func (r *PostgresExecutorRepository) GetExecutors(ctx *armadacontext.Context) ([
 	return executors, nil
 }
 
+// GetExecutor returns the executor with the provided id, or nil if no such executor is stored.
+func (r *PostgresExecutorRepository) GetExecutor(ctx *armadacontext.Context, executorId string) (*schedulerobjects.Executor, error) {
+	var lastRequest []byte
+	err := r.db.QueryRow(ctx, `SELECT last_request FROM executors WHERE executor_id = $1`, executorId).Scan(&lastRequest)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.WithStack(classifyError(err))
+	}
+	nodesByExecutorId, err := r.selectExecutorNodes(
+		ctx, `SELECT executor_id, node FROM executor_nodes WHERE executor_id = $1 ORDER BY node_name`, executorId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.decodeExecutor(executorId, lastRequest, nodesByExecutorId[executorId])
+}
+
+// selectExecutorNodes runs sql, which must select the executor_id and node columns of executor_nodes,
+// and returns the nodes selected indexed by executor id.
+func (r *PostgresExecutorRepository) selectExecutorNodes(ctx *armadacontext.Context, sql string, args ...any) (map[string][][]byte, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.WithStack(classifyError(err))
+	}
+	defer rows.Close()
+	nodesByExecutorId := make(map[string][][]byte)
+	for rows.Next() {
+		var executorId string
+		var node []byte
+		if err := rows.Scan(&executorId, &node); err != nil {
+			return nil, errors.WithStack(classifyError(err))
+		}
+		nodesByExecutorId[executorId] = append(nodesByExecutorId[executorId], node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WithStack(classifyError(err))
+	}
+	return nodesByExecutorId, nil
+}
+
+// decodeExecutor decodes the executor stored in the executors table as lastRequest, with the nodes stored for it in
+// the executor_nodes table. Executors stored before nodes were stored separately have their nodes in lastRequest.
+func (r *PostgresExecutorRepository) decodeExecutor(executorId string, lastRequest []byte, nodes [][]byte) (*schedulerobjects.Executor, error) {
+	executor := &schedulerobjects.Executor{}
+	err := decompressAndMarshall(lastRequest, r.decompressor, executor)
+	if err != nil {
+		return nil, errors.WithStack(&ErrCorruptRow{Table: "executors", ExecutorID: executorId, Err: err})
+	}
+	if len(executor.Nodes) == 0 {
+		for _, b := range nodes {
+			node := &schedulerobjects.Node{}
+			if err := decompressAndMarshall(b, r.decompressor, node); err != nil {
+				return nil, errors.WithStack(&ErrCorruptRow{Table: "executor_nodes", ExecutorID: executorId, Err: err})
+			}
+			executor.Nodes = append(executor.Nodes, node)
+		}
+	}
+	if r.lengthPrefixedNodeIds {
+		for _, node := range executor.Nodes {
+			node.Id = api.LengthPrefixedNodeIdFromExecutorAndNodeName(executor.Id, node.Name)
+		}
+	}
+	return executor, nil
+}
+
 // GetLastUpdateTimes returns a map of executor name -> last heartbeat time
 func (r *PostgresExecutorRepository) GetLastUpdateTimes(ctx *armadacontext.Context) (map[string]time.Time, error) {
 	queries := New(r.db)
 	rows, err := queries.SelectExecutorUpdateTimes(ctx)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, errors.WithStack(classifyError(err))
 	}
 	lastUpdateTimes := make(map[string]time.Time, len(rows))
 	for _, row := range rows {
@@ -73,28 +185,156 @@ func (r *PostgresExecutorRepository) GetLastUpdateTimes(ctx *armadacontext.Conte
 	return lastUpdateTimes, nil
 }
 
-// StoreExecutor persists the latest executor state
+// StoreExecutor persists the latest executor state, replacing all nodes stored for the executor with its nodes.
+// Unless EnableNodeRows has been called, the executor is stored with its nodes in executors.last_request.
 func (r *PostgresExecutorRepository) StoreExecutor(ctx *armadacontext.Context, executor *schedulerobjects.Executor) error {
-	queries := New(r.db)
-	bytes, err := proto.Marshal(executor)
+	if !r.nodeRows {
+		return r.storeExecutorWithNodes(ctx, executor)
+	}
+	return r.storeExecutor(ctx, executor, executor.Nodes, nil, true)
+}
+
+// StoreExecutorNodeDelta persists the latest executor state other than its nodes, together with the nodes added or
+// changed and the names of those removed since it was last stored. The nodes of executor must be all of its nodes once
+// the delta is applied; these are stored in full if none are stored in the executor_nodes table for the executor,
+// e.g., since it was last stored by a scheduler storing nodes in executors.last_request.
+// Nodes are always stored in the executor_nodes table, whether EnableNodeRows has been called or not.
+func (r *PostgresExecutorRepository) StoreExecutorNodeDelta(
+	ctx *armadacontext.Context,
+	executor *schedulerobjects.Executor,
+	updatedNodes []*schedulerobjects.Node,
+	removedNodeNames []string,
+) error {
+	return r.storeExecutor(ctx, executor, updatedNodes, removedNodeNames, false)
+}
+
+// storeExecutorWithNodes stores executor, including its nodes, in executors.last_request, deleting any nodes stored for
+// it in the executor_nodes table, such that they can't be read should the executor later report having no nodes.
+func (r *PostgresExecutorRepository) storeExecutorWithNodes(ctx *armadacontext.Context, executor *schedulerobjects.Executor) error {
+	lastRequest, err := r.compress(executor)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
-	compressed, err := r.compressor.Compress(bytes)
+	err = pgx.BeginTxFunc(ctx, r.db, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadWrite,
+	}, func(tx pgx.Tx) error {
+		err := New(tx).UpsertExecutor(ctx, UpsertExecutorParams{
+			ExecutorID:  executor.Id,
+			LastRequest: lastRequest,
+			UpdateTime:  executor.LastUpdateTime,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM executor_nodes WHERE executor_id = $1`, executor.Id)
+		return err
+	})
+	if err != nil {
+		return errors.WithStack(classifyError(err))
+	}
+	return nil
+}
+
+// storeExecutor stores executor without its nodes and upserts nodes, each in its own row.
+// If replaceNodes is true, any other nodes stored for the executor are deleted; otherwise, those in removedNodeNames
+// are, unless no nodes are stored for the executor, in which case all nodes of executor are stored.
+func (r *PostgresExecutorRepository) storeExecutor(
+	ctx *armadacontext.Context,
+	executor *schedulerobjects.Executor,
+	nodes []*schedulerobjects.Node,
+	removedNodeNames []string,
+	replaceNodes bool,
+) error {
+	executorWithoutNodes := *executor
+	executorWithoutNodes.Nodes = nil
+	lastRequest, err := r.compress(&executorWithoutNodes)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
-	err = queries.UpsertExecutor(ctx, UpsertExecutorParams{
-		ExecutorID:  executor.Id,
-		LastRequest: compressed,
-		UpdateTime:  executor.LastUpdateTime,
+	err = pgx.BeginTxFunc(ctx, r.db, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadWrite,
+	}, func(tx pgx.Tx) error {
+		if !replaceNodes {
+			var hasNodeRows bool
+			err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM executor_nodes WHERE executor_id = $1)`, executor.Id).Scan(&hasNodeRows)
+			if err != nil {
+				return err
+			}
+			if !hasNodeRows {
+				nodes, replaceNodes = executor.Nodes, true
+			}
+		}
+		nodeNames, nodeBytes, err := r.compressNodes(nodes)
+		if err != nil {
+			return err
+		}
+		err = New(tx).UpsertExecutor(ctx, UpsertExecutorParams{
+			ExecutorID:  executor.Id,
+			LastRequest: lastRequest,
+			UpdateTime:  executor.LastUpdateTime,
+		})
+		if err != nil {
+			return err
+		}
+		if replaceNodes {
+			_, err = tx.Exec(ctx, `DELETE FROM executor_nodes WHERE executor_id = $1`, executor.Id)
+		} else if len(removedNodeNames) > 0 {
+			_, err = tx.Exec(ctx, `DELETE FROM executor_nodes WHERE executor_id = $1 AND node_name = ANY($2::text[])`, executor.Id, removedNodeNames)
+		}
+		if err != nil || len(nodeNames) == 0 {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO executor_nodes (executor_id, node_name, node)
+			SELECT $1, nodes.node_name, nodes.node
+			FROM unnest($2::text[], $3::bytea[]) AS nodes(node_name, node)
+			ON CONFLICT (executor_id, node_name) DO UPDATE SET node = EXCLUDED.node`,
+			executor.Id, nodeNames, nodeBytes,
+		)
+		return err
 	})
 	if err != nil {
-		return errors.WithStack(err)
+		return errors.WithStack(classifyError(err))
 	}
 	return nil
 }
 
+// compressNodes returns the names of nodes and the compressed nodes, in the same order.
+// Node names are unique within a cluster; should a name be repeated nonetheless, the last node with it is returned.
+func (r *PostgresExecutorRepository) compressNodes(nodes []*schedulerobjects.Node) ([]string, [][]byte, error) {
+	nodeIndexByName := make(map[string]int, len(nodes))
+	nodeNames := make([]string, 0, len(nodes))
+	nodeBytes := make([][]byte, 0, len(nodes))
+	for _, node := range nodes {
+		b, err := r.compress(node)
+		if err != nil {
+			return nil, nil, err
+		}
+		if i, ok := nodeIndexByName[node.Name]; ok {
+			nodeBytes[i] = b
+			continue
+		}
+		nodeIndexByName[node.Name] = len(nodeNames)
+		nodeNames = append(nodeNames, node.Name)
+		nodeBytes = append(nodeBytes, b)
+	}
+	return nodeNames, nodeBytes, nil
+}
+
+func (r *PostgresExecutorRepository) compress(msg proto.Message) ([]byte, error) {
+	bytes, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	compressed, err := r.compressor.Compress(bytes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return compressed, nil
+}
+
 func decompressAndMarshall(b []byte, decompressor compress.Decompressor, msg proto.Message) error {
 	decompressed, err := decompressor.Decompress(b)
 	if err != nil {
@@ -122,6 +122,69 @@ func TestExecutorRepository_GetLastUpdateTimes(t *testing.T) {
 	}
 }
 
+func TestExecutorRepository_NodeRows(t *testing.T) {
+	t1 := time.Now().UTC().Round(1 * time.Microsecond) // postgres only stores times with micro precision
+	node := func(name string, lastSeen time.Time) *schedulerobjects.Node {
+		return &schedulerobjects.Node{Id: "test-executor-" + name, Name: name, LastSeen: lastSeen}
+	}
+	executor := func(nodes ...*schedulerobjects.Node) *schedulerobjects.Executor {
+		return &schedulerobjects.Executor{Id: "test-executor", Pool: "test-pool", Nodes: nodes, LastUpdateTime: t1}
+	}
+	// storedNodes returns the number of nodes stored for the executor in executors.last_request and in executor_nodes.
+	storedNodes := func(ctx *armadacontext.Context, repo *PostgresExecutorRepository) (int, int) {
+		var lastRequest []byte
+		err := repo.db.QueryRow(ctx, `SELECT last_request FROM executors WHERE executor_id = 'test-executor'`).Scan(&lastRequest)
+		require.NoError(t, err)
+		stored := &schedulerobjects.Executor{}
+		require.NoError(t, decompressAndMarshall(lastRequest, repo.decompressor, stored))
+		var numRows int
+		err = repo.db.QueryRow(ctx, `SELECT count(*) FROM executor_nodes WHERE executor_id = 'test-executor'`).Scan(&numRows)
+		require.NoError(t, err)
+		return len(stored.Nodes), numRows
+	}
+
+	err := withExecutorRepository(func(repo *PostgresExecutorRepository) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+		defer cancel()
+
+		// Unless node rows are enabled, nodes are stored with the rest of the executor, as by schedulers predating them.
+		require.NoError(t, repo.StoreExecutor(ctx, executor(node("node-1", t1), node("node-2", t1))))
+		numInLastRequest, numRows := storedNodes(ctx, repo)
+		assert.Equal(t, 2, numInLastRequest)
+		assert.Equal(t, 0, numRows)
+
+		// A delta applied to an executor stored that way stores all of its nodes, not just those in the delta.
+		t2 := t1.Add(time.Second)
+		updated := executor(node("node-1", t1), node("node-2", t2))
+		require.NoError(t, repo.StoreExecutorNodeDelta(ctx, updated, []*schedulerobjects.Node{node("node-2", t2)}, nil))
+		numInLastRequest, numRows = storedNodes(ctx, repo)
+		assert.Equal(t, 0, numInLastRequest)
+		assert.Equal(t, 2, numRows)
+		retrieved, err := repo.GetExecutor(ctx, "test-executor")
+		require.NoError(t, err)
+		assert.Equal(t, updated, retrieved)
+
+		// Once node rows are enabled, nodes are stored one per row.
+		repo.EnableNodeRows()
+		require.NoError(t, repo.StoreExecutor(ctx, executor(node("node-1", t2))))
+		numInLastRequest, numRows = storedNodes(ctx, repo)
+		assert.Equal(t, 0, numInLastRequest)
+		assert.Equal(t, 1, numRows)
+
+		// Disabling node rows again, e.g., before rolling back, removes the rows of executors once stored.
+		repo.nodeRows = false
+		require.NoError(t, repo.StoreExecutor(ctx, executor()))
+		numInLastRequest, numRows = storedNodes(ctx, repo)
+		assert.Equal(t, 0, numInLastRequest)
+		assert.Equal(t, 0, numRows)
+		retrieved, err = repo.GetExecutor(ctx, "test-executor")
+		require.NoError(t, err)
+		assert.Empty(t, retrieved.Nodes)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func withExecutorRepository(action func(repository *PostgresExecutorRepository) error) error {
 	return WithTestDb(func(_ *Queries, db *pgxpool.Pool) error {
 		repo := NewPostgresExecutorRepository(db)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,6 +15,7 @@ import (
 	"github.com/armadaproject/armada/internal/common/database"
 	protoutil "github.com/armadaproject/armada/internal/common/proto"
 	armadaslices "github.com/armadaproject/armada/internal/common/slices"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/pkg/armadaevents"
 )
 
@@ -30,16 +32,42 @@ type JobRunLease struct {
 	Node          string
 	Groups        []byte
 	SubmitMessage []byte
+	// Scheduling info of the job at the time the lease was fetched.
+	SchedulingInfo []byte
 }
 
-// JobRepository is an interface to be implemented by structs which provide job and run information
+// JobRunSpecVersion contains the fields of an active run and its job that determine the spec sent to executors
+// for that run; the submit message of each job never changes.
+type JobRunSpecVersion struct {
+	RunID uuid.UUID
+	Node  string
+	// Scheduling info of the job, which reflects any updates made to it since the job was submitted.
+	SchedulingInfo []byte
+}
+
+// JobRunResourceUsage is the most recent resource usage of a run, as reported by the executor it's leased to.
+type JobRunResourceUsage struct {
+	RunID uuid.UUID
+	// Cpu time consumed by the run since it started.
+	CpuSeconds float64
+	// Memory currently in use by the run.
+	WorkingSetBytes int64
+	Serial          int64
+}
+
+// JobRepository is an interface to be implemented by structs which provide job and run information.
+// Errors for which retrying may succeed match ErrTransient.
 type JobRepository interface {
 	// FetchJobUpdates returns all jobs and job dbRuns that have been updated after jobSerial and jobRunSerial respectively
 	// These updates are guaranteed to be consistent with each other
 	FetchJobUpdates(ctx *armadacontext.Context, jobSerial int64, jobRunSerial int64) ([]Job, []Run, error)
 
+	// FetchJob returns the current state of the job with the provided id, or ErrNotFound if there's no such job.
+	FetchJob(ctx *armadacontext.Context, jobId string) (*Job, error)
+
 	// FetchJobRunErrors returns all armadaevents.JobRunErrors for the provided job run ids. The returned map is
 	// keyed by job run id. Any dbRuns which don't have errors wil be absent from the map.
+	// If the error of any run can't be decoded, an *ErrCorruptRow identifying that run is returned.
 	FetchJobRunErrors(ctx *armadacontext.Context, runIds []uuid.UUID) (map[uuid.UUID]*armadaevents.Error, error)
 
 	// CountReceivedPartitions returns a count of the number of partition messages present in the database corresponding
@@ -51,9 +79,75 @@ type JobRepository interface {
 	// Runs are inactive if they don't exist or if they have succeeded, failed or been cancelled
 	FindInactiveRuns(ctx *armadacontext.Context, runIds []uuid.UUID) ([]uuid.UUID, error)
 
+	// FindTerminalRuns returns a slice containing all dbRuns that exist and have succeeded, failed or been cancelled.
+	// Runs that don't exist are not returned.
+	FindTerminalRuns(ctx *armadacontext.Context, runIds []uuid.UUID) ([]uuid.UUID, error)
+
 	// FetchJobRunLeases fetches new job runs for a given executor.  A maximum of maxResults rows will be returned, while run
 	// in excludedRunIds will be excluded
 	FetchJobRunLeases(ctx *armadacontext.Context, executor string, maxResults uint, excludedRunIds []uuid.UUID) ([]*JobRunLease, error)
+
+	// CountJobRunLeases returns the number of runs FetchJobRunLeases would return for executor if not limited,
+	// i.e., the number of active runs assigned to executor other than those in excludedRunIds.
+	CountJobRunLeases(ctx *armadacontext.Context, executor string, excludedRunIds []uuid.UUID) (uint, error)
+
+	// FetchJobRunSpecVersions returns the spec version of each of the provided runs that's assigned to executor
+	// and hasn't succeeded, failed, or been cancelled.
+	FetchJobRunSpecVersions(ctx *armadacontext.Context, executor string, runIds []uuid.UUID) ([]JobRunSpecVersion, error)
+
+	// FetchJobRunLeasesByRunId fetches the leases of the provided runs that are assigned to executor
+	// and haven't succeeded, failed, or been cancelled.
+	FetchJobRunLeasesByRunId(ctx *armadacontext.Context, executor string, runIds []uuid.UUID) ([]*JobRunLease, error)
+
+	// FetchOldestUnprocessedUpdateTime returns the time at which the oldest job or run with a serial greater than
+	// jobSerial or jobRunSerial respectively was last modified, or nil if there's no such job or run.
+	FetchOldestUnprocessedUpdateTime(ctx *armadacontext.Context, jobSerial int64, jobRunSerial int64) (*time.Time, error)
+
+	// FetchMaxSerials returns the highest serial assigned to any job and run respectively, or 0 if none has been assigned.
+	// Serials are assigned from sequences, so these don't decrease when jobs or runs are deleted.
+	FetchMaxSerials(ctx *armadacontext.Context) (int64, int64, error)
+
+	// StoreJobRunResourceUsage stores the provided resource usage samples, replacing any previous sample for the same run.
+	// Samples of runs that don't exist are discarded.
+	StoreJobRunResourceUsage(ctx *armadacontext.Context, usage []JobRunResourceUsage) error
+
+	// FetchJobRunResourceUsageUpdates returns all resource usage samples stored after serial.
+	FetchJobRunResourceUsageUpdates(ctx *armadacontext.Context, serial int64) ([]JobRunResourceUsage, error)
+}
+
+// ScheduledAtPriorityRepository is implemented by job repositories able to store the priority runs were scheduled at
+// for runs stored without one.
+type ScheduledAtPriorityRepository interface {
+	// BackfillScheduledAtPriorities sets the scheduled-at priority of each run in priorityByRunId to the priority
+	// provided for it, unless the run already has one.
+	BackfillScheduledAtPriorities(ctx *armadacontext.Context, priorityByRunId map[uuid.UUID]int32) error
+}
+
+// ParameterCheckpointRepository is implemented by job repositories able to store the parameters the scheduler
+// checkpoints for each job when it's admitted, such that they survive restarts of the scheduler.
+type ParameterCheckpointRepository interface {
+	// FetchParameterCheckpoints returns the encoded checkpoint stored for each of the provided jobs, indexed by job id.
+	// Jobs without a checkpoint are absent from the map.
+	FetchParameterCheckpoints(ctx *armadacontext.Context, jobIds []string) (map[string][]byte, error)
+	// StoreParameterCheckpoints stores the encoded checkpoint of each job in checkpointByJobId,
+	// replacing any checkpoint stored for it before. Checkpoints of jobs that don't exist are discarded.
+	StoreParameterCheckpoints(ctx *armadacontext.Context, checkpointByJobId map[string][]byte) error
+}
+
+// BurstCreditRepository is implemented by job repositories able to store the debt queues accrue by exceeding their
+// fair share, such that it survives restarts and failovers of the scheduler; see configuration.BurstCreditsConfig.
+type BurstCreditRepository interface {
+	// FetchBurstCreditDebts returns the debt stored for each queue, indexed by pool and queue.
+	FetchBurstCreditDebts(ctx *armadacontext.Context) (map[string]map[string]float64, error)
+	// StoreBurstCreditDebts replaces the debts stored for pool with debtByQueue.
+	StoreBurstCreditDebts(ctx *armadacontext.Context, pool string, debtByQueue map[string]float64) error
+}
+
+// PriorityClassSampleRepository is implemented by job repositories able to sample the priority classes of jobs in flight.
+type PriorityClassSampleRepository interface {
+	// SamplePriorityClasses returns the number of jobs of each priority class among up to maxJobs of the jobs
+	// that haven't succeeded, failed, or been cancelled, most recently updated first, and the number of jobs sampled.
+	SamplePriorityClasses(ctx *armadacontext.Context, maxJobs int) (map[string]int, int, error)
 }
 
 // PostgresJobRepository is an implementation of JobRepository that stores its state in postgres
@@ -95,7 +189,7 @@ func (r *PostgresJobRepository) FetchJobRunErrors(ctx *armadacontext.Context, ru
 			}
 
 			query := `
-		SELECT  job_run_errors.run_id, job_run_errors.error
+		SELECT  job_run_errors.run_id, job_run_errors.job_id, job_run_errors.error
 		FROM %s as tmp
 		JOIN job_run_errors ON job_run_errors.run_id = tmp.run_id`
 
@@ -106,14 +200,15 @@ func (r *PostgresJobRepository) FetchJobRunErrors(ctx *armadacontext.Context, ru
 			defer rows.Close()
 			for rows.Next() {
 				var runId uuid.UUID
+				var jobId string
 				var errorBytes []byte
-				err := rows.Scan(&runId, &errorBytes)
+				err := rows.Scan(&runId, &jobId, &errorBytes)
 				if err != nil {
 					return errors.WithStack(err)
 				}
 				jobError, err := protoutil.DecompressAndUnmarshall(errorBytes, &armadaevents.Error{}, decompressor)
 				if err != nil {
-					return errors.WithStack(err)
+					return errors.WithStack(&ErrCorruptRow{Table: "job_run_errors", JobID: jobId, RunID: runId, Err: err})
 				}
 				errorsByRunId[runId] = jobError
 			}
@@ -121,7 +216,10 @@ func (r *PostgresJobRepository) FetchJobRunErrors(ctx *armadacontext.Context, ru
 		return nil
 	})
 
-	return errorsByRunId, err
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return errorsByRunId, nil
 }
 
 // FetchJobUpdates returns all jobs and job dbRuns that have been updated after jobSerial and jobRunSerial respectively
@@ -166,6 +264,8 @@ func (r *PostgresJobRepository) FetchJobUpdates(ctx *armadacontext.Context, jobS
 				SchedulingInfo:          row.SchedulingInfo,
 				SchedulingInfoVersion:   row.SchedulingInfoVersion,
 				Serial:                  row.Serial,
+				PreemptRequested:        row.PreemptRequested,
+				Acknowledged:            row.Acknowledged,
 			}
 		}
 
@@ -180,14 +280,75 @@ func (r *PostgresJobRepository) FetchJobUpdates(ctx *armadacontext.Context, jobS
 
 		return err
 	})
+	if err != nil {
+		return nil, nil, classifyError(err)
+	}
+	return updatedJobs, updatedRuns, nil
+}
 
-	return updatedJobs, updatedRuns, err
+// FetchJob returns the current state of the job with the provided id, or nil if there's no such job.
+func (r *PostgresJobRepository) FetchJob(ctx *armadacontext.Context, jobId string) (*Job, error) {
+	row := r.db.QueryRow(ctx, `
+		SELECT job_id, job_set, queue, priority, submitted, queued, queued_version, cancel_requested, cancel_by_jobset_requested, cancelled, succeeded, failed, scheduling_info, scheduling_info_version, serial, preempt_requested, acknowledged
+		FROM jobs
+		WHERE job_id = $1;`, jobId)
+	job := Job{}
+	err := row.Scan(
+		&job.JobID,
+		&job.JobSet,
+		&job.Queue,
+		&job.Priority,
+		&job.Submitted,
+		&job.Queued,
+		&job.QueuedVersion,
+		&job.CancelRequested,
+		&job.CancelByJobsetRequested,
+		&job.Cancelled,
+		&job.Succeeded,
+		&job.Failed,
+		&job.SchedulingInfo,
+		&job.SchedulingInfoVersion,
+		&job.Serial,
+		&job.PreemptRequested,
+		&job.Acknowledged,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.WithStack(ErrNotFound)
+	} else if err != nil {
+		return nil, errors.WithStack(classifyError(err))
+	}
+	return &job, nil
 }
 
 // FindInactiveRuns returns a slice containing all dbRuns that the scheduler does not currently consider active
 // Runs are inactive if they don't exist or if they have succeeded, failed or been cancelled
 func (r *PostgresJobRepository) FindInactiveRuns(ctx *armadacontext.Context, runIds []uuid.UUID) ([]uuid.UUID, error) {
-	var inactiveRuns []uuid.UUID
+	return r.findRuns(ctx, runIds, `
+		SELECT tmp.run_id
+		FROM %s as tmp
+		LEFT JOIN runs ON (tmp.run_id = runs.run_id)
+		WHERE runs.run_id IS NULL
+		OR runs.succeeded = true
+ 		OR runs.failed = true
+		OR runs.cancelled = true;`)
+}
+
+// FindTerminalRuns returns a slice containing all dbRuns that exist and have succeeded, failed or been cancelled.
+// Unlike FindInactiveRuns, runs that don't exist are not returned.
+func (r *PostgresJobRepository) FindTerminalRuns(ctx *armadacontext.Context, runIds []uuid.UUID) ([]uuid.UUID, error) {
+	return r.findRuns(ctx, runIds, `
+		SELECT tmp.run_id
+		FROM %s as tmp
+		JOIN runs ON (tmp.run_id = runs.run_id)
+		WHERE runs.succeeded = true
+ 		OR runs.failed = true
+		OR runs.cancelled = true;`)
+}
+
+// findRuns returns the ids selected by query, which must select run ids from a temporary table containing runIds.
+// The name of the temporary table is substituted into query.
+func (r *PostgresJobRepository) findRuns(ctx *armadacontext.Context, runIds []uuid.UUID, query string) ([]uuid.UUID, error) {
+	var foundRuns []uuid.UUID
 	err := pgx.BeginTxFunc(ctx, r.db, pgx.TxOptions{
 		IsoLevel:       pgx.ReadCommitted,
 		AccessMode:     pgx.ReadWrite,
@@ -198,15 +359,6 @@ func (r *PostgresJobRepository) FindInactiveRuns(ctx *armadacontext.Context, run
 			return err
 		}
 
-		query := `
-		SELECT tmp.run_id
-		FROM %s as tmp
-		LEFT JOIN runs ON (tmp.run_id = runs.run_id)
-		WHERE runs.run_id IS NULL
-		OR runs.succeeded = true
- 		OR runs.failed = true
-		OR runs.cancelled = true;`
-
 		rows, err := tx.Query(ctx, fmt.Sprintf(query, tmpTable))
 		if err != nil {
 			return err
@@ -218,11 +370,14 @@ func (r *PostgresJobRepository) FindInactiveRuns(ctx *armadacontext.Context, run
 			if err != nil {
 				return errors.WithStack(err)
 			}
-			inactiveRuns = append(inactiveRuns, runId)
+			foundRuns = append(foundRuns, runId)
 		}
 		return nil
 	})
-	return inactiveRuns, err
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return foundRuns, nil
 }
 
 // FetchJobRunLeases fetches new job runs for a given executor.  A maximum of maxResults rows will be returned, while run
@@ -243,7 +398,7 @@ func (r *PostgresJobRepository) FetchJobRunLeases(ctx *armadacontext.Context, ex
 		}
 
 		query := `
-				SELECT jr.run_id, jr.node, j.queue, j.job_set, j.user_id, j.groups, j.submit_message
+				SELECT jr.run_id, jr.node, j.queue, j.job_set, j.user_id, j.groups, j.submit_message, j.scheduling_info
 				FROM runs jr
 				LEFT JOIN %s as tmp ON (tmp.run_id = jr.run_id)
 			    JOIN jobs j
@@ -264,7 +419,7 @@ func (r *PostgresJobRepository) FetchJobRunLeases(ctx *armadacontext.Context, ex
 		defer rows.Close()
 		for rows.Next() {
 			run := JobRunLease{}
-			err = rows.Scan(&run.RunID, &run.Node, &run.Queue, &run.JobSet, &run.UserID, &run.Groups, &run.SubmitMessage)
+			err = rows.Scan(&run.RunID, &run.Node, &run.Queue, &run.JobSet, &run.UserID, &run.Groups, &run.SubmitMessage, &run.SchedulingInfo)
 			if err != nil {
 				return errors.WithStack(err)
 			}
@@ -272,7 +427,144 @@ func (r *PostgresJobRepository) FetchJobRunLeases(ctx *armadacontext.Context, ex
 		}
 		return nil
 	})
-	return newRuns, err
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return newRuns, nil
+}
+
+// CountJobRunLeases returns the number of runs FetchJobRunLeases would return for executor if not limited,
+// i.e., the number of active runs assigned to executor other than those in excludedRunIds.
+func (r *PostgresJobRepository) CountJobRunLeases(ctx *armadacontext.Context, executor string, excludedRunIds []uuid.UUID) (uint, error) {
+	var count int64
+	err := pgx.BeginTxFunc(ctx, r.db, pgx.TxOptions{
+		IsoLevel:       pgx.ReadCommitted,
+		AccessMode:     pgx.ReadWrite,
+		DeferrableMode: pgx.Deferrable,
+	}, func(tx pgx.Tx) error {
+		tmpTable, err := insertRunIdsToTmpTable(ctx, tx, excludedRunIds)
+		if err != nil {
+			return err
+		}
+
+		query := `
+				SELECT count(*)
+				FROM runs jr
+				LEFT JOIN %s as tmp ON (tmp.run_id = jr.run_id)
+				WHERE jr.executor = $1
+			    AND tmp.run_id IS NULL
+				AND jr.succeeded = false
+				AND jr.failed = false
+				AND jr.cancelled = false;
+`
+		return errors.WithStack(tx.QueryRow(ctx, fmt.Sprintf(query, tmpTable), executor).Scan(&count))
+	})
+	if err != nil {
+		return 0, classifyError(err)
+	}
+	return uint(count), nil
+}
+
+// FetchJobRunSpecVersions returns the spec version of each of the provided runs that's assigned to executor
+// and hasn't succeeded, failed, or been cancelled.
+func (r *PostgresJobRepository) FetchJobRunSpecVersions(ctx *armadacontext.Context, executor string, runIds []uuid.UUID) ([]JobRunSpecVersion, error) {
+	if len(runIds) == 0 {
+		return nil, nil
+	}
+	var specVersions []JobRunSpecVersion
+	err := pgx.BeginTxFunc(ctx, r.db, pgx.TxOptions{
+		IsoLevel:       pgx.ReadCommitted,
+		AccessMode:     pgx.ReadWrite,
+		DeferrableMode: pgx.Deferrable,
+	}, func(tx pgx.Tx) error {
+		tmpTable, err := insertRunIdsToTmpTable(ctx, tx, runIds)
+		if err != nil {
+			return err
+		}
+
+		query := `
+				SELECT jr.run_id, jr.node, j.scheduling_info
+				FROM runs jr
+				JOIN %s as tmp ON (tmp.run_id = jr.run_id)
+			    JOIN jobs j
+			    ON jr.job_id = j.job_id
+				WHERE jr.executor = $1
+				AND jr.succeeded = false
+				AND jr.failed = false
+				AND jr.cancelled = false
+				ORDER BY jr.serial;
+`
+
+		rows, err := tx.Query(ctx, fmt.Sprintf(query, tmpTable), executor)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			specVersion := JobRunSpecVersion{}
+			err = rows.Scan(&specVersion.RunID, &specVersion.Node, &specVersion.SchedulingInfo)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			specVersions = append(specVersions, specVersion)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return specVersions, nil
+}
+
+// FetchJobRunLeasesByRunId fetches the leases of the provided runs that are assigned to executor
+// and haven't succeeded, failed, or been cancelled.
+func (r *PostgresJobRepository) FetchJobRunLeasesByRunId(ctx *armadacontext.Context, executor string, runIds []uuid.UUID) ([]*JobRunLease, error) {
+	if len(runIds) == 0 {
+		return nil, nil
+	}
+	var leases []*JobRunLease
+	err := pgx.BeginTxFunc(ctx, r.db, pgx.TxOptions{
+		IsoLevel:       pgx.ReadCommitted,
+		AccessMode:     pgx.ReadWrite,
+		DeferrableMode: pgx.Deferrable,
+	}, func(tx pgx.Tx) error {
+		tmpTable, err := insertRunIdsToTmpTable(ctx, tx, runIds)
+		if err != nil {
+			return err
+		}
+
+		query := `
+				SELECT jr.run_id, jr.node, j.queue, j.job_set, j.user_id, j.groups, j.submit_message, j.scheduling_info
+				FROM runs jr
+				JOIN %s as tmp ON (tmp.run_id = jr.run_id)
+			    JOIN jobs j
+			    ON jr.job_id = j.job_id
+				WHERE jr.executor = $1
+				AND jr.succeeded = false
+				AND jr.failed = false
+				AND jr.cancelled = false
+				ORDER BY jr.serial;
+`
+
+		rows, err := tx.Query(ctx, fmt.Sprintf(query, tmpTable), executor)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			lease := JobRunLease{}
+			err = rows.Scan(&lease.RunID, &lease.Node, &lease.Queue, &lease.JobSet, &lease.UserID, &lease.Groups, &lease.SubmitMessage, &lease.SchedulingInfo)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			leases = append(leases, &lease)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return leases, nil
 }
 
 // CountReceivedPartitions returns a count of the number of partition messages present in the database corresponding
@@ -282,11 +574,262 @@ func (r *PostgresJobRepository) CountReceivedPartitions(ctx *armadacontext.Conte
 	queries := New(r.db)
 	count, err := queries.CountGroup(ctx, groupId)
 	if err != nil {
-		return 0, err
+		return 0, classifyError(err)
 	}
 	return uint32(count), nil
 }
 
+// FetchOldestUnprocessedUpdateTime returns the time at which the oldest job or run with a serial greater than
+// jobSerial or jobRunSerial respectively was last modified, or nil if there's no such job or run.
+// Since serials are assigned on insert and update, this is the time of the oldest update not yet seen by a scheduler
+// that has read all updates up to jobSerial and jobRunSerial.
+func (r *PostgresJobRepository) FetchOldestUnprocessedUpdateTime(ctx *armadacontext.Context, jobSerial int64, jobRunSerial int64) (*time.Time, error) {
+	var oldest *time.Time
+	err := r.db.QueryRow(ctx, `
+		SELECT min(last_modified) FROM (
+			(SELECT last_modified FROM jobs WHERE serial > $1 ORDER BY serial LIMIT 1)
+			UNION ALL
+			(SELECT last_modified FROM runs WHERE serial > $2 ORDER BY serial LIMIT 1)
+		) AS oldest`,
+		jobSerial, jobRunSerial,
+	).Scan(&oldest)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return oldest, nil
+}
+
+// FetchMaxSerials returns the highest serial assigned to any job and run respectively, or 0 if none has been assigned.
+// Serials are read from the sequences they're assigned from, so these don't decrease when jobs or runs are deleted,
+// but do if the sequences are reset, e.g., by restoring the database from a backup.
+func (r *PostgresJobRepository) FetchMaxSerials(ctx *armadacontext.Context) (int64, int64, error) {
+	var maxJobSerial, maxRunSerial int64
+	err := r.db.QueryRow(ctx, `
+		SELECT
+			(SELECT CASE WHEN is_called THEN last_value ELSE 0 END FROM jobs_serial_seq),
+			(SELECT CASE WHEN is_called THEN last_value ELSE 0 END FROM runs_serial_seq)`,
+	).Scan(&maxJobSerial, &maxRunSerial)
+	if err != nil {
+		return 0, 0, classifyError(err)
+	}
+	return maxJobSerial, maxRunSerial, nil
+}
+
+// StoreJobRunResourceUsage stores the provided resource usage samples, replacing any previous sample for the same run.
+// Samples of runs that don't exist are discarded.
+func (r *PostgresJobRepository) StoreJobRunResourceUsage(ctx *armadacontext.Context, usage []JobRunResourceUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+	runIds := make([]uuid.UUID, len(usage))
+	cpuSeconds := make([]float64, len(usage))
+	workingSetBytes := make([]int64, len(usage))
+	for i, u := range usage {
+		runIds[i] = u.RunID
+		cpuSeconds[i] = u.CpuSeconds
+		workingSetBytes[i] = u.WorkingSetBytes
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO job_run_resource_usage (run_id, job_id, cpu_seconds, working_set_bytes)
+		SELECT runs.run_id, runs.job_id, usage.cpu_seconds, usage.working_set_bytes
+		FROM unnest($1::uuid[], $2::double precision[], $3::bigint[]) AS usage(run_id, cpu_seconds, working_set_bytes)
+		JOIN runs ON runs.run_id = usage.run_id
+		ON CONFLICT (run_id) DO UPDATE
+		SET cpu_seconds = EXCLUDED.cpu_seconds, working_set_bytes = EXCLUDED.working_set_bytes`,
+		runIds, cpuSeconds, workingSetBytes,
+	)
+	return classifyError(err)
+}
+
+// BackfillScheduledAtPriorities sets the scheduled-at priority of each run in priorityByRunId to the priority
+// provided for it, unless the run already has one. Runs that don't exist are ignored.
+func (r *PostgresJobRepository) BackfillScheduledAtPriorities(ctx *armadacontext.Context, priorityByRunId map[uuid.UUID]int32) error {
+	if len(priorityByRunId) == 0 {
+		return nil
+	}
+	runIds := make([]uuid.UUID, 0, len(priorityByRunId))
+	priorities := make([]int32, 0, len(priorityByRunId))
+	for runId, priority := range priorityByRunId {
+		runIds = append(runIds, runId)
+		priorities = append(priorities, priority)
+	}
+	_, err := r.db.Exec(ctx, `
+		UPDATE runs SET scheduled_at_priority = backfill.priority
+		FROM unnest($1::uuid[], $2::integer[]) AS backfill(run_id, priority)
+		WHERE runs.run_id = backfill.run_id AND runs.scheduled_at_priority IS NULL`,
+		runIds, priorities,
+	)
+	return classifyError(err)
+}
+
+// FetchParameterCheckpoints returns the encoded checkpoint stored for each of the provided jobs, indexed by job id.
+// Jobs are looked up in batches of at most batchSize.
+func (r *PostgresJobRepository) FetchParameterCheckpoints(ctx *armadacontext.Context, jobIds []string) (map[string][]byte, error) {
+	checkpointByJobId := make(map[string][]byte)
+	for _, batch := range armadaslices.PartitionToMaxLen(jobIds, int(r.batchSize)) {
+		rows, err := r.db.Query(ctx, `
+			SELECT job_id, parameters
+			FROM job_parameter_checkpoints
+			WHERE job_id = ANY($1)`,
+			batch,
+		)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		for rows.Next() {
+			var jobId string
+			var parameters []byte
+			if err := rows.Scan(&jobId, &parameters); err != nil {
+				rows.Close()
+				return nil, classifyError(err)
+			}
+			checkpointByJobId[jobId] = parameters
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, classifyError(err)
+		}
+	}
+	return checkpointByJobId, nil
+}
+
+// StoreParameterCheckpoints stores the encoded checkpoint of each job in checkpointByJobId,
+// replacing any checkpoint stored for it before. Checkpoints of jobs that don't exist are discarded.
+func (r *PostgresJobRepository) StoreParameterCheckpoints(ctx *armadacontext.Context, checkpointByJobId map[string][]byte) error {
+	if len(checkpointByJobId) == 0 {
+		return nil
+	}
+	jobIds := make([]string, 0, len(checkpointByJobId))
+	checkpoints := make([][]byte, 0, len(checkpointByJobId))
+	for jobId, checkpoint := range checkpointByJobId {
+		jobIds = append(jobIds, jobId)
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO job_parameter_checkpoints (job_id, parameters)
+		SELECT jobs.job_id, checkpoint.parameters
+		FROM unnest($1::text[], $2::bytea[]) AS checkpoint(job_id, parameters)
+		JOIN jobs ON jobs.job_id = checkpoint.job_id
+		ON CONFLICT (job_id) DO UPDATE
+		SET parameters = EXCLUDED.parameters`,
+		jobIds, checkpoints,
+	)
+	return classifyError(err)
+}
+
+// FetchBurstCreditDebts returns the debt stored for each queue, indexed by pool and queue.
+func (r *PostgresJobRepository) FetchBurstCreditDebts(ctx *armadacontext.Context) (map[string]map[string]float64, error) {
+	rows, err := r.db.Query(ctx, `SELECT pool, queue, debt FROM burst_credit_debts`)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	defer rows.Close()
+	debtByPoolAndQueue := make(map[string]map[string]float64)
+	for rows.Next() {
+		var pool, queue string
+		var debt float64
+		if err := rows.Scan(&pool, &queue, &debt); err != nil {
+			return nil, classifyError(err)
+		}
+		if debtByPoolAndQueue[pool] == nil {
+			debtByPoolAndQueue[pool] = make(map[string]float64)
+		}
+		debtByPoolAndQueue[pool][queue] = debt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+	return debtByPoolAndQueue, nil
+}
+
+// StoreBurstCreditDebts replaces the debts stored for pool with debtByQueue.
+// Debts are replaced in a single transaction, such that debts are never read for only some of the queues of a pool.
+func (r *PostgresJobRepository) StoreBurstCreditDebts(ctx *armadacontext.Context, pool string, debtByQueue map[string]float64) error {
+	queues := make([]string, 0, len(debtByQueue))
+	debts := make([]float64, 0, len(debtByQueue))
+	for queue, debt := range debtByQueue {
+		queues = append(queues, queue)
+		debts = append(debts, debt)
+	}
+	err := pgx.BeginTxFunc(ctx, r.db, pgx.TxOptions{
+		IsoLevel:       pgx.ReadCommitted,
+		AccessMode:     pgx.ReadWrite,
+		DeferrableMode: pgx.Deferrable,
+	}, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM burst_credit_debts WHERE pool = $1`, pool); err != nil {
+			return err
+		}
+		if len(queues) == 0 {
+			return nil
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO burst_credit_debts (pool, queue, debt)
+			SELECT $1, debt.queue, debt.debt
+			FROM unnest($2::text[], $3::double precision[]) AS debt(queue, debt)`,
+			pool, queues, debts,
+		)
+		return err
+	})
+	return classifyError(err)
+}
+
+// SamplePriorityClasses returns the number of jobs of each priority class among up to maxJobs of the jobs
+// that haven't succeeded, failed, or been cancelled, most recently updated first, and the number of jobs sampled.
+// Jobs are read in reverse serial order, such that only the most recently updated jobs are scanned.
+// Jobs whose scheduling info can't be decoded are sampled but not counted towards any priority class.
+func (r *PostgresJobRepository) SamplePriorityClasses(ctx *armadacontext.Context, maxJobs int) (map[string]int, int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT scheduling_info
+		FROM jobs
+		WHERE NOT (succeeded OR failed OR cancelled)
+		ORDER BY serial DESC
+		LIMIT $1`,
+		maxJobs,
+	)
+	if err != nil {
+		return nil, 0, classifyError(err)
+	}
+	schedulingInfos, err := pgx.CollectRows(rows, pgx.RowTo[[]byte])
+	if err != nil {
+		return nil, 0, classifyError(err)
+	}
+	countByPriorityClass := make(map[string]int)
+	for _, serialisedSchedulingInfo := range schedulingInfos {
+		schedulingInfo := &schedulerobjects.JobSchedulingInfo{}
+		if err := proto.Unmarshal(serialisedSchedulingInfo, schedulingInfo); err != nil {
+			continue
+		}
+		countByPriorityClass[schedulingInfo.PriorityClassName]++
+	}
+	return countByPriorityClass, len(schedulingInfos), nil
+}
+
+// FetchJobRunResourceUsageUpdates returns all resource usage samples stored after serial.
+func (r *PostgresJobRepository) FetchJobRunResourceUsageUpdates(ctx *armadacontext.Context, serial int64) ([]JobRunResourceUsage, error) {
+	usage, err := fetch(serial, r.batchSize, func(from int64) ([]JobRunResourceUsage, error) {
+		rows, err := r.db.Query(ctx, `
+			SELECT run_id, cpu_seconds, working_set_bytes, serial
+			FROM job_run_resource_usage
+			WHERE serial > $1
+			ORDER BY serial
+			LIMIT $2`,
+			from, r.batchSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return pgx.CollectRows(rows, func(row pgx.CollectableRow) (JobRunResourceUsage, error) {
+			var u JobRunResourceUsage
+			err := row.Scan(&u.RunID, &u.CpuSeconds, &u.WorkingSetBytes, &u.Serial)
+			return u, err
+		})
+	})
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return usage, nil
+}
+
 // fetch gets all rows from the database with a serial greater than from.
 // Rows are fetched in batches using the supplied fetchBatch function
 func fetch[T hasSerial](from int64, batchSize int32, fetchBatch func(int64) ([]T, error)) ([]T, error) {
@@ -17,6 +17,7 @@ import (
 	"github.com/armadaproject/armada/internal/common/database"
 	protoutil "github.com/armadaproject/armada/internal/common/proto"
 	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/pkg/armadaevents"
 )
 
@@ -112,6 +113,277 @@ func TestFetchJobUpdates(t *testing.T) {
 	}
 }
 
+func TestFetchJob(t *testing.T) {
+	dbJobs, expectedJobs := createTestJobs(3)
+
+	tests := map[string]struct {
+		dbJobs      []Job
+		jobId       string
+		expectedJob *Job
+	}{
+		"job exists": {
+			dbJobs:      dbJobs,
+			jobId:       dbJobs[1].JobID,
+			expectedJob: &expectedJobs[1],
+		},
+		"job doesn't exist": {
+			dbJobs: dbJobs,
+			jobId:  util.NewULID(),
+		},
+		"empty db": {
+			jobId: dbJobs[0].JobID,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := withJobRepository(func(repo *PostgresJobRepository) error {
+				ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+
+				// Set up db
+				err := database.UpsertWithTransaction(ctx, repo.db, "jobs", tc.dbJobs)
+				require.NoError(t, err)
+
+				job, err := repo.FetchJob(ctx, tc.jobId)
+				if tc.expectedJob == nil {
+					assert.ErrorIs(t, err, ErrNotFound)
+				} else {
+					require.NoError(t, err)
+				}
+				assert.Equal(t, tc.expectedJob, job)
+				cancel()
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFetchOldestUnprocessedUpdateTime(t *testing.T) {
+	dbJobs, _ := createTestJobs(3)
+	dbRuns, _ := createTestRuns(3)
+	err := withJobRepository(func(repo *PostgresJobRepository) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+		defer cancel()
+
+		// Empty db.
+		oldest, err := repo.FetchOldestUnprocessedUpdateTime(ctx, 0, 0)
+		require.NoError(t, err)
+		assert.Nil(t, oldest)
+
+		// Jobs and runs are inserted in separate transactions, such that runs are modified after jobs.
+		require.NoError(t, database.UpsertWithTransaction(ctx, repo.db, "jobs", dbJobs))
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, database.UpsertWithTransaction(ctx, repo.db, "runs", dbRuns))
+		var maxJobSerial, maxRunSerial int64
+		var jobsModified, runsModified time.Time
+		require.NoError(t, repo.db.QueryRow(ctx, "SELECT max(serial), min(last_modified) FROM jobs").Scan(&maxJobSerial, &jobsModified))
+		require.NoError(t, repo.db.QueryRow(ctx, "SELECT max(serial), min(last_modified) FROM runs").Scan(&maxRunSerial, &runsModified))
+		require.True(t, jobsModified.Before(runsModified))
+
+		// Unprocessed jobs and runs.
+		oldest, err = repo.FetchOldestUnprocessedUpdateTime(ctx, 0, 0)
+		require.NoError(t, err)
+		require.NotNil(t, oldest)
+		assert.True(t, jobsModified.Equal(*oldest))
+
+		// Only unprocessed runs.
+		oldest, err = repo.FetchOldestUnprocessedUpdateTime(ctx, maxJobSerial, 0)
+		require.NoError(t, err)
+		require.NotNil(t, oldest)
+		assert.True(t, runsModified.Equal(*oldest))
+
+		// Everything processed.
+		oldest, err = repo.FetchOldestUnprocessedUpdateTime(ctx, maxJobSerial, maxRunSerial)
+		require.NoError(t, err)
+		assert.Nil(t, oldest)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestFetchMaxSerials(t *testing.T) {
+	dbJobs, _ := createTestJobs(3)
+	dbRuns, _ := createTestRuns(2)
+	err := withJobRepository(func(repo *PostgresJobRepository) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+		defer cancel()
+
+		// Empty db.
+		maxJobSerial, maxRunSerial, err := repo.FetchMaxSerials(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), maxJobSerial)
+		assert.Equal(t, int64(0), maxRunSerial)
+
+		require.NoError(t, database.UpsertWithTransaction(ctx, repo.db, "jobs", dbJobs))
+		require.NoError(t, database.UpsertWithTransaction(ctx, repo.db, "runs", dbRuns))
+		var expectedMaxJobSerial, expectedMaxRunSerial int64
+		require.NoError(t, repo.db.QueryRow(ctx, "SELECT max(serial) FROM jobs").Scan(&expectedMaxJobSerial))
+		require.NoError(t, repo.db.QueryRow(ctx, "SELECT max(serial) FROM runs").Scan(&expectedMaxRunSerial))
+		maxJobSerial, maxRunSerial, err = repo.FetchMaxSerials(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, expectedMaxJobSerial, maxJobSerial)
+		assert.Equal(t, expectedMaxRunSerial, maxRunSerial)
+
+		// Deleting rows doesn't decrease the max serials.
+		_, err = repo.db.Exec(ctx, "DELETE FROM jobs")
+		require.NoError(t, err)
+		maxJobSerial, _, err = repo.FetchMaxSerials(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, expectedMaxJobSerial, maxJobSerial)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestStoreAndFetchJobRunResourceUsage(t *testing.T) {
+	dbRuns, _ := createTestRuns(2)
+	err := withJobRepository(func(repo *PostgresJobRepository) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, database.UpsertWithTransaction(ctx, repo.db, "runs", dbRuns))
+
+		// Usage of runs that don't exist is discarded.
+		require.NoError(t, repo.StoreJobRunResourceUsage(ctx, []JobRunResourceUsage{
+			{RunID: dbRuns[0].RunID, CpuSeconds: 1, WorkingSetBytes: 10},
+			{RunID: uuid.New(), CpuSeconds: 2, WorkingSetBytes: 20},
+		}))
+		usage, err := repo.FetchJobRunResourceUsageUpdates(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, usage, 1)
+		assert.Equal(t, dbRuns[0].RunID, usage[0].RunID)
+		assert.Equal(t, 1.0, usage[0].CpuSeconds)
+		assert.Equal(t, int64(10), usage[0].WorkingSetBytes)
+		serial := usage[0].Serial
+
+		// Storing usage again replaces the previous sample and makes it visible as an update.
+		require.NoError(t, repo.StoreJobRunResourceUsage(ctx, []JobRunResourceUsage{
+			{RunID: dbRuns[0].RunID, CpuSeconds: 3, WorkingSetBytes: 30},
+			{RunID: dbRuns[1].RunID, CpuSeconds: 4, WorkingSetBytes: 40},
+		}))
+		usage, err = repo.FetchJobRunResourceUsageUpdates(ctx, serial)
+		require.NoError(t, err)
+		usageByRunId := make(map[uuid.UUID]JobRunResourceUsage)
+		for _, u := range usage {
+			assert.Greater(t, u.Serial, serial)
+			u.Serial = 0
+			usageByRunId[u.RunID] = u
+		}
+		assert.Equal(
+			t,
+			map[uuid.UUID]JobRunResourceUsage{
+				dbRuns[0].RunID: {RunID: dbRuns[0].RunID, CpuSeconds: 3, WorkingSetBytes: 30},
+				dbRuns[1].RunID: {RunID: dbRuns[1].RunID, CpuSeconds: 4, WorkingSetBytes: 40},
+			},
+			usageByRunId,
+		)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestBackfillScheduledAtPriorities(t *testing.T) {
+	dbRuns, _ := createTestRuns(2)
+	scheduledAtPriority := int32(1000)
+	dbRuns[1].ScheduledAtPriority = &scheduledAtPriority
+	err := withJobRepository(func(repo *PostgresJobRepository) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, database.UpsertWithTransaction(ctx, repo.db, "runs", dbRuns))
+
+		// Only runs without a scheduled-at priority are updated; runs that don't exist are ignored.
+		require.NoError(t, repo.BackfillScheduledAtPriorities(ctx, map[uuid.UUID]int32{
+			dbRuns[0].RunID: 2000,
+			dbRuns[1].RunID: 2000,
+			uuid.New():      2000,
+		}))
+		_, runs, err := repo.FetchJobUpdates(ctx, 0, 0)
+		require.NoError(t, err)
+		priorityByRunId := make(map[uuid.UUID]int32)
+		for _, run := range runs {
+			require.NotNil(t, run.ScheduledAtPriority)
+			priorityByRunId[run.RunID] = *run.ScheduledAtPriority
+		}
+		assert.Equal(t, map[uuid.UUID]int32{dbRuns[0].RunID: 2000, dbRuns[1].RunID: 1000}, priorityByRunId)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestParameterCheckpoints(t *testing.T) {
+	dbJobs, _ := createTestJobs(2)
+	err := withJobRepository(func(repo *PostgresJobRepository) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, database.UpsertWithTransaction(ctx, repo.db, "jobs", dbJobs))
+
+		// Checkpoints of jobs that don't exist are discarded.
+		require.NoError(t, repo.StoreParameterCheckpoints(ctx, map[string][]byte{
+			dbJobs[0].JobID: []byte("a"),
+			util.NewULID():  []byte("b"),
+		}))
+		// Stored checkpoints are replaced.
+		require.NoError(t, repo.StoreParameterCheckpoints(ctx, map[string][]byte{dbJobs[0].JobID: []byte("c")}))
+
+		checkpointByJobId, err := repo.FetchParameterCheckpoints(ctx, []string{dbJobs[0].JobID, dbJobs[1].JobID})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{dbJobs[0].JobID: []byte("c")}, checkpointByJobId)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestBurstCreditDebts(t *testing.T) {
+	err := withJobRepository(func(repo *PostgresJobRepository) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, repo.StoreBurstCreditDebts(ctx, "pool-a", map[string]float64{"A": 1.5, "B": 0.5}))
+		require.NoError(t, repo.StoreBurstCreditDebts(ctx, "pool-b", map[string]float64{"A": 2}))
+		// Stored debts of the pool are replaced.
+		require.NoError(t, repo.StoreBurstCreditDebts(ctx, "pool-a", map[string]float64{"B": 0.25}))
+
+		debtByPoolAndQueue, err := repo.FetchBurstCreditDebts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]map[string]float64{"pool-a": {"B": 0.25}, "pool-b": {"A": 2}}, debtByPoolAndQueue)
+
+		require.NoError(t, repo.StoreBurstCreditDebts(ctx, "pool-b", nil))
+		debtByPoolAndQueue, err = repo.FetchBurstCreditDebts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]map[string]float64{"pool-a": {"B": 0.25}}, debtByPoolAndQueue)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestSamplePriorityClasses(t *testing.T) {
+	dbJobs, _ := createTestJobs(5)
+	for i, priorityClassName := range []string{"armada-default", "armada-removed", "armada-removed", "armada-default", "armada-removed"} {
+		dbJobs[i].SchedulingInfo = protoutil.MustMarshall(&schedulerobjects.JobSchedulingInfo{PriorityClassName: priorityClassName})
+		dbJobs[i].Succeeded = false
+		dbJobs[i].Failed = false
+		dbJobs[i].Cancelled = false
+	}
+	// Terminal jobs aren't sampled.
+	dbJobs[4].Succeeded = true
+	err := withJobRepository(func(repo *PostgresJobRepository) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, database.UpsertWithTransaction(ctx, repo.db, "jobs", dbJobs))
+
+		countByPriorityClass, numSampled, err := repo.SamplePriorityClasses(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 4, numSampled)
+		assert.Equal(t, map[string]int{"armada-default": 2, "armada-removed": 2}, countByPriorityClass)
+
+		// Only the most recently updated jobs are sampled.
+		countByPriorityClass, numSampled, err = repo.SamplePriorityClasses(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, numSampled)
+		assert.Equal(t, map[string]int{"armada-default": 1}, countByPriorityClass)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func TestFetchJobRunErrors(t *testing.T) {
 	const numErrors = 10
 
@@ -377,6 +649,61 @@ func TestFindInactiveRuns(t *testing.T) {
 	}
 }
 
+func TestFindTerminalRuns(t *testing.T) {
+	uuids := make([]uuid.UUID, 3)
+	for i := 0; i < len(uuids); i++ {
+		uuids[i] = uuid.New()
+	}
+	tests := map[string]struct {
+		dbRuns           []Run
+		runsToCheck      []uuid.UUID
+		expectedTerminal []uuid.UUID
+	}{
+		"empty database": {
+			runsToCheck:      uuids,
+			expectedTerminal: nil,
+		},
+		"no terminal": {
+			runsToCheck: uuids,
+			dbRuns: []Run{
+				{RunID: uuids[0]},
+				{RunID: uuids[1]},
+				{RunID: uuids[2]},
+			},
+			expectedTerminal: nil,
+		},
+		"terminal and missing": {
+			runsToCheck: uuids,
+			dbRuns: []Run{
+				{RunID: uuids[0], Succeeded: true},
+				{RunID: uuids[1], Cancelled: true},
+			},
+			expectedTerminal: []uuid.UUID{uuids[0], uuids[1]},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := withJobRepository(func(repo *PostgresJobRepository) error {
+				ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 500*time.Second)
+
+				// Set up db
+				err := database.UpsertWithTransaction(ctx, repo.db, "runs", tc.dbRuns)
+				require.NoError(t, err)
+
+				terminal, err := repo.FindTerminalRuns(ctx, tc.runsToCheck)
+				require.NoError(t, err)
+				uuidSort := func(a uuid.UUID, b uuid.UUID) bool { return a.String() > b.String() }
+				slices.SortFunc(terminal, uuidSort)
+				slices.SortFunc(tc.expectedTerminal, uuidSort)
+				assert.Equal(t, tc.expectedTerminal, terminal)
+				cancel()
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestFetchJobRunLeases(t *testing.T) {
 	const executorName = "testExecutor"
 	dbJobs, _ := createTestJobs(5)
@@ -427,12 +754,13 @@ func TestFetchJobRunLeases(t *testing.T) {
 	expectedLeases := make([]*JobRunLease, 3)
 	for i := range expectedLeases {
 		expectedLeases[i] = &JobRunLease{
-			RunID:         dbRuns[i].RunID,
-			Queue:         dbJobs[i].Queue,
-			JobSet:        dbJobs[i].JobSet,
-			UserID:        dbJobs[i].UserID,
-			Groups:        dbJobs[i].Groups,
-			SubmitMessage: dbJobs[i].SubmitMessage,
+			RunID:          dbRuns[i].RunID,
+			Queue:          dbJobs[i].Queue,
+			JobSet:         dbJobs[i].JobSet,
+			UserID:         dbJobs[i].UserID,
+			Groups:         dbJobs[i].Groups,
+			SubmitMessage:  dbJobs[i].SubmitMessage,
+			SchedulingInfo: dbJobs[i].SchedulingInfo,
 		}
 	}
 	tests := map[string]struct {
@@ -442,6 +770,8 @@ func TestFetchJobRunLeases(t *testing.T) {
 		maxRowsToFetch uint
 		executor       string
 		expectedLeases []*JobRunLease
+		// Expected number of leases regardless of maxRowsToFetch.
+		expectedCount uint
 	}{
 		"all runs": {
 			dbJobs:         dbJobs,
@@ -450,6 +780,7 @@ func TestFetchJobRunLeases(t *testing.T) {
 			maxRowsToFetch: 100,
 			executor:       executorName,
 			expectedLeases: expectedLeases,
+			expectedCount:  3,
 		},
 		"limit rows": {
 			dbJobs:         dbJobs,
@@ -458,6 +789,7 @@ func TestFetchJobRunLeases(t *testing.T) {
 			maxRowsToFetch: 2,
 			executor:       executorName,
 			expectedLeases: []*JobRunLease{expectedLeases[0], expectedLeases[1]},
+			expectedCount:  3,
 		},
 		"exclude one run": {
 			dbJobs:         dbJobs,
@@ -466,6 +798,7 @@ func TestFetchJobRunLeases(t *testing.T) {
 			maxRowsToFetch: 100,
 			executor:       executorName,
 			expectedLeases: []*JobRunLease{expectedLeases[0], expectedLeases[2]},
+			expectedCount:  2,
 		},
 		"exclude everything": {
 			dbJobs:         dbJobs,
@@ -501,6 +834,10 @@ func TestFetchJobRunLeases(t *testing.T) {
 				slices.SortFunc(leases, leaseSort)
 				slices.SortFunc(tc.expectedLeases, leaseSort)
 				assert.Equal(t, tc.expectedLeases, leases)
+
+				count, err := repo.CountJobRunLeases(ctx, tc.executor, tc.excludedRuns)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedCount, count)
 				cancel()
 				return nil
 			})
@@ -509,6 +846,107 @@ func TestFetchJobRunLeases(t *testing.T) {
 	}
 }
 
+func TestFetchJobRunSpecVersionsAndLeasesByRunId(t *testing.T) {
+	const executorName = "testExecutor"
+	dbJobs, _ := createTestJobs(3)
+	dbRuns := []Run{
+		{
+			RunID:    uuid.New(),
+			JobID:    dbJobs[0].JobID,
+			JobSet:   "test-jobset",
+			Executor: executorName,
+			Node:     "node-0",
+		},
+		{
+			RunID:    uuid.New(),
+			JobID:    dbJobs[1].JobID,
+			JobSet:   "test-jobset",
+			Executor: executorName,
+			Node:     "node-1",
+		},
+		{
+			RunID:    uuid.New(),
+			JobID:    dbJobs[2].JobID,
+			JobSet:   "test-jobset",
+			Executor: executorName,
+			Failed:   true, // should be ignored as terminal
+		},
+	}
+	tests := map[string]struct {
+		runIds               []uuid.UUID
+		executor             string
+		expectedSpecVersions []JobRunSpecVersion
+		expectedLeases       []*JobRunLease
+	}{
+		"active runs": {
+			runIds:   []uuid.UUID{dbRuns[0].RunID, dbRuns[1].RunID},
+			executor: executorName,
+			expectedSpecVersions: []JobRunSpecVersion{
+				{RunID: dbRuns[0].RunID, Node: "node-0", SchedulingInfo: dbJobs[0].SchedulingInfo},
+				{RunID: dbRuns[1].RunID, Node: "node-1", SchedulingInfo: dbJobs[1].SchedulingInfo},
+			},
+			expectedLeases: []*JobRunLease{
+				{
+					RunID:          dbRuns[0].RunID,
+					Queue:          dbJobs[0].Queue,
+					JobSet:         dbJobs[0].JobSet,
+					UserID:         dbJobs[0].UserID,
+					Node:           "node-0",
+					Groups:         dbJobs[0].Groups,
+					SubmitMessage:  dbJobs[0].SubmitMessage,
+					SchedulingInfo: dbJobs[0].SchedulingInfo,
+				},
+				{
+					RunID:          dbRuns[1].RunID,
+					Queue:          dbJobs[1].Queue,
+					JobSet:         dbJobs[1].JobSet,
+					UserID:         dbJobs[1].UserID,
+					Node:           "node-1",
+					Groups:         dbJobs[1].Groups,
+					SubmitMessage:  dbJobs[1].SubmitMessage,
+					SchedulingInfo: dbJobs[1].SchedulingInfo,
+				},
+			},
+		},
+		"terminal run": {
+			runIds:   []uuid.UUID{dbRuns[2].RunID},
+			executor: executorName,
+		},
+		"unknown run": {
+			runIds:   []uuid.UUID{uuid.New()},
+			executor: executorName,
+		},
+		"another executor": {
+			runIds:   []uuid.UUID{dbRuns[0].RunID, dbRuns[1].RunID},
+			executor: "some other executor",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := withJobRepository(func(repo *PostgresJobRepository) error {
+				ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+				defer cancel()
+
+				// Set up db
+				err := database.UpsertWithTransaction(ctx, repo.db, "jobs", dbJobs)
+				require.NoError(t, err)
+				err = database.UpsertWithTransaction(ctx, repo.db, "runs", dbRuns)
+				require.NoError(t, err)
+
+				specVersions, err := repo.FetchJobRunSpecVersions(ctx, tc.executor, tc.runIds)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedSpecVersions, specVersions)
+
+				leases, err := repo.FetchJobRunLeasesByRunId(ctx, tc.executor, tc.runIds)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedLeases, leases)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
 func createTestRuns(numRuns int) ([]Run, []Run) {
 	dbRuns := make([]Run, numRuns)
 	expectedRuns := make([]Run, numRuns)
@@ -0,0 +1 @@
+ALTER TABLE queues ADD COLUMN max_queued_jobs bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+-- Latest resource usage reported by executors for each run.
+CREATE TABLE job_run_resource_usage (
+    run_id uuid PRIMARY KEY,
+    job_id text NOT NULL,
+    cpu_seconds double precision NOT NULL,
+    working_set_bytes bigint NOT NULL,
+    serial bigserial NOT NULL,
+    last_modified timestamptz NOT NULL
+);
+
+CREATE INDEX idx_job_run_resource_usage_serial ON job_run_resource_usage (serial);
+
+CREATE TRIGGER next_serial_on_insert_job_run_resource_usage
+    BEFORE INSERT or UPDATE ON job_run_resource_usage
+    FOR EACH ROW
+EXECUTE FUNCTION trg_increment_serial_set_last_modified();
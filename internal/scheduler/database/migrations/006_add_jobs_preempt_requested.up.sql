@@ -0,0 +1,2 @@
+-- Set to request that the active run of a job be preempted and the job requeued.
+ALTER TABLE jobs ADD COLUMN preempt_requested boolean NOT NULL DEFAULT false;
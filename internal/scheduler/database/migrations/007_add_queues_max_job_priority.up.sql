@@ -0,0 +1 @@
+ALTER TABLE queues ADD COLUMN max_job_priority bigint NOT NULL DEFAULT 0;
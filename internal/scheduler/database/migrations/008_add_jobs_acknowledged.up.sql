@@ -0,0 +1,2 @@
+-- Set once a JobValidated event has been published for the job, such that it's published at most once per job.
+ALTER TABLE jobs ADD COLUMN acknowledged boolean NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+ALTER TABLE queues ADD COLUMN preemption_policy text NOT NULL DEFAULT 'standard';
@@ -0,0 +1,9 @@
+-- Nodes of each executor, stored one row per node such that the nodes an executor reports as changed can be
+-- updated without rewriting all of its nodes. Executors stored beforehand have their nodes in executors.last_request.
+CREATE TABLE executor_nodes (
+    executor_id text NOT NULL,
+    node_name text NOT NULL,
+    -- the node as last reported by the executor.  Compressed.
+    node bytea NOT NULL,
+    PRIMARY KEY (executor_id, node_name)
+);
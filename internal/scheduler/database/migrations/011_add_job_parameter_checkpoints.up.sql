@@ -0,0 +1,7 @@
+-- Effective parameters of each job as checkpointed by the scheduler when it was admitted, such that they survive restarts
+-- of the scheduler; see jobdb.EffectiveParameters.
+CREATE TABLE job_parameter_checkpoints (
+    job_id text PRIMARY KEY,
+    -- the checkpointed parameters, json-encoded.
+    parameters bytea NOT NULL
+);
@@ -0,0 +1,8 @@
+-- Debt accrued by each queue in each pool by exceeding its fair share, such that it survives restarts and failovers
+-- of the scheduler; see configuration.BurstCreditsConfig. Queues without debt have no row.
+CREATE TABLE burst_credit_debts (
+    pool text NOT NULL,
+    queue text NOT NULL,
+    debt double precision NOT NULL,
+    PRIMARY KEY (pool, queue)
+);
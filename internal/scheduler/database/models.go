@@ -36,6 +36,8 @@ type Job struct {
 	SchedulingInfoVersion   int32     `db:"scheduling_info_version"`
 	Serial                  int64     `db:"serial"`
 	LastModified            time.Time `db:"last_modified"`
+	PreemptRequested        bool      `db:"preempt_requested"`
+	Acknowledged            bool      `db:"acknowledged"`
 }
 
 type JobRunError struct {
@@ -51,8 +53,12 @@ type Marker struct {
 }
 
 type Queue struct {
-	Name   string  `db:"name"`
-	Weight float64 `db:"weight"`
+	Name           string  `db:"name"`
+	Weight         float64 `db:"weight"`
+	MaxQueuedJobs  int64   `db:"max_queued_jobs"`
+	MaxJobPriority int64   `db:"max_job_priority"`
+	// One of "never", "standard", or "aggressive"; see queue.PreemptionPolicy.
+	PreemptionPolicy string `db:"preemption_policy"`
 }
 
 type Run struct {
@@ -81,6 +81,15 @@ func (q *Queries) MarkJobRunsAttemptedById(ctx context.Context, runIds []uuid.UU
 	return err
 }
 
+const markJobRunsCancelledById = `-- name: MarkJobRunsCancelledById :exec
+UPDATE runs SET cancelled = true WHERE run_id = ANY($1::UUID[])
+`
+
+func (q *Queries) MarkJobRunsCancelledById(ctx context.Context, runIds []uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markJobRunsCancelledById, runIds)
+	return err
+}
+
 const markJobRunsFailedById = `-- name: MarkJobRunsFailedById :exec
 UPDATE runs SET failed = true WHERE run_id = ANY($1::UUID[])
 `
@@ -117,6 +126,15 @@ func (q *Queries) MarkJobRunsSucceededById(ctx context.Context, runIds []uuid.UU
 	return err
 }
 
+const markJobsAcknowledgedById = `-- name: MarkJobsAcknowledgedById :exec
+UPDATE jobs SET acknowledged = true WHERE job_id = ANY($1::text[])
+`
+
+func (q *Queries) MarkJobsAcknowledgedById(ctx context.Context, jobIds []string) error {
+	_, err := q.db.Exec(ctx, markJobsAcknowledgedById, jobIds)
+	return err
+}
+
 const markJobsCancelRequestedById = `-- name: MarkJobsCancelRequestedById :exec
 UPDATE jobs SET cancel_requested = true WHERE job_id = ANY($1::text[])
 `
@@ -378,7 +396,7 @@ func (q *Queries) SelectJobsForExecutor(ctx context.Context, arg SelectJobsForEx
 }
 
 const selectNewJobs = `-- name: SelectNewJobs :many
-SELECT job_id, job_set, queue, user_id, submitted, groups, priority, queued, queued_version, cancel_requested, cancelled, cancel_by_jobset_requested, succeeded, failed, submit_message, scheduling_info, scheduling_info_version, serial, last_modified FROM jobs WHERE serial > $1 ORDER BY serial LIMIT $2
+SELECT job_id, job_set, queue, user_id, submitted, groups, priority, queued, queued_version, cancel_requested, cancelled, cancel_by_jobset_requested, succeeded, failed, submit_message, scheduling_info, scheduling_info_version, serial, last_modified, preempt_requested, acknowledged FROM jobs WHERE serial > $1 ORDER BY serial LIMIT $2
 `
 
 type SelectNewJobsParams struct {
@@ -415,6 +433,8 @@ func (q *Queries) SelectNewJobs(ctx context.Context, arg SelectNewJobsParams) ([
 			&i.SchedulingInfoVersion,
 			&i.Serial,
 			&i.LastModified,
+			&i.PreemptRequested,
+			&i.Acknowledged,
 		); err != nil {
 			return nil, err
 		}
@@ -550,7 +570,7 @@ func (q *Queries) SelectRunErrorsById(ctx context.Context, runIds []uuid.UUID) (
 }
 
 const selectUpdatedJobs = `-- name: SelectUpdatedJobs :many
-SELECT job_id, job_set, queue, priority, submitted, queued, queued_version, cancel_requested, cancel_by_jobset_requested, cancelled, succeeded, failed, scheduling_info, scheduling_info_version, serial FROM jobs WHERE serial > $1 ORDER BY serial LIMIT $2
+SELECT job_id, job_set, queue, priority, submitted, queued, queued_version, cancel_requested, cancel_by_jobset_requested, cancelled, succeeded, failed, scheduling_info, scheduling_info_version, serial, preempt_requested, acknowledged FROM jobs WHERE serial > $1 ORDER BY serial LIMIT $2
 `
 
 type SelectUpdatedJobsParams struct {
@@ -574,6 +594,8 @@ type SelectUpdatedJobsRow struct {
 	SchedulingInfo          []byte `db:"scheduling_info"`
 	SchedulingInfoVersion   int32  `db:"scheduling_info_version"`
 	Serial                  int64  `db:"serial"`
+	PreemptRequested        bool   `db:"preempt_requested"`
+	Acknowledged            bool   `db:"acknowledged"`
 }
 
 func (q *Queries) SelectUpdatedJobs(ctx context.Context, arg SelectUpdatedJobsParams) ([]SelectUpdatedJobsRow, error) {
@@ -601,6 +623,8 @@ func (q *Queries) SelectUpdatedJobs(ctx context.Context, arg SelectUpdatedJobsPa
 			&i.SchedulingInfo,
 			&i.SchedulingInfoVersion,
 			&i.Serial,
+			&i.PreemptRequested,
+			&i.Acknowledged,
 		); err != nil {
 			return nil, err
 		}
@@ -640,6 +664,20 @@ func (q *Queries) SetPendingTime(ctx context.Context, arg SetPendingTimeParams)
 	return err
 }
 
+const setRunNode = `-- name: SetRunNode :exec
+UPDATE runs SET node = $1 WHERE run_id = $2 AND node <> $1
+`
+
+type SetRunNodeParams struct {
+	Node  string    `db:"node"`
+	RunID uuid.UUID `db:"run_id"`
+}
+
+func (q *Queries) SetRunNode(ctx context.Context, arg SetRunNodeParams) error {
+	_, err := q.db.Exec(ctx, setRunNode, arg.Node, arg.RunID)
+	return err
+}
+
 const setRunningTime = `-- name: SetRunningTime :exec
 UPDATE runs SET running_timestamp = $1 WHERE run_id = $2
 `
@@ -5,7 +5,7 @@ SELECT * FROM jobs WHERE serial > $1 ORDER BY serial LIMIT $2;
 SELECT job_id FROM jobs;
 
 -- name: SelectUpdatedJobs :many
-SELECT job_id, job_set, queue, priority, submitted, queued, queued_version, cancel_requested, cancel_by_jobset_requested, cancelled, succeeded, failed, scheduling_info, scheduling_info_version, serial FROM jobs WHERE serial > $1 ORDER BY serial LIMIT $2;
+SELECT job_id, job_set, queue, priority, submitted, queued, queued_version, cancel_requested, cancel_by_jobset_requested, cancelled, succeeded, failed, scheduling_info, scheduling_info_version, serial, preempt_requested, acknowledged FROM jobs WHERE serial > $1 ORDER BY serial LIMIT $2;
 
 -- name: UpdateJobPriorityByJobSet :exec
 UPDATE jobs SET priority = $1 WHERE job_set = $2 and queue = $3;
@@ -25,6 +25,9 @@ UPDATE jobs SET cancelled = true WHERE job_id = ANY(sqlc.arg(job_ids)::text[]);
 -- name: MarkJobsFailedById :exec
 UPDATE jobs SET failed = true WHERE job_id = ANY(sqlc.arg(job_ids)::text[]);
 
+-- name: MarkJobsAcknowledgedById :exec
+UPDATE jobs SET acknowledged = true WHERE job_id = ANY(sqlc.arg(job_ids)::text[]);
+
 -- name: UpdateJobPriorityById :exec
 UPDATE jobs SET priority = $1 WHERE job_id = $2;
 
@@ -52,6 +55,9 @@ UPDATE runs SET run_attempted = true WHERE run_id = ANY(sqlc.arg(run_ids)::UUID[
 -- name: MarkJobRunsRunningById :exec
 UPDATE runs SET running = true WHERE run_id = ANY(sqlc.arg(run_ids)::UUID[]);
 
+-- name: MarkJobRunsCancelledById :exec
+UPDATE runs SET cancelled = true WHERE run_id = ANY(sqlc.arg(run_ids)::UUID[]);
+
 -- name: MarkRunsCancelledByJobId :exec
 UPDATE runs SET cancelled = true WHERE job_id = ANY(sqlc.arg(job_ids)::text[]);
 
@@ -107,6 +113,9 @@ UPDATE runs SET pending_timestamp = $1 WHERE run_id = $2;
 -- name: SetRunningTime :exec
 UPDATE runs SET running_timestamp = $1 WHERE run_id = $2;
 
+-- name: SetRunNode :exec
+UPDATE runs SET node = $1 WHERE run_id = $2 AND node <> $1;
+
 -- name: SetTerminatedTime :exec
 UPDATE runs SET terminated_timestamp = $1 WHERE run_id = $2;
 
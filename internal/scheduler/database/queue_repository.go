@@ -2,13 +2,17 @@ package database
 
 import (
 	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
 
 	legacyrepository "github.com/armadaproject/armada/internal/armada/repository"
+	"github.com/armadaproject/armada/pkg/client/queue"
 )
 
 // QueueRepository is an interface to be implemented by structs which provide queue information
 type QueueRepository interface {
 	GetAllQueues() ([]*Queue, error)
+	// CreateQueue creates the given queue. Creating a queue that already exists is not an error.
+	CreateQueue(queue *Queue) error
 }
 
 // LegacyQueueRepository is a QueueRepository which is backed by Armada's redis store
@@ -30,9 +34,35 @@ func (r *LegacyQueueRepository) GetAllQueues() ([]*Queue, error) {
 	queues := make([]*Queue, len(legacyQueues))
 	for i, legacyQueue := range legacyQueues {
 		queues[i] = &Queue{
-			Name:   legacyQueue.Name,
-			Weight: float64(legacyQueue.PriorityFactor),
+			Name:             legacyQueue.Name,
+			Weight:           float64(legacyQueue.PriorityFactor),
+			MaxQueuedJobs:    int64(legacyQueue.MaxQueuedJobs),
+			MaxJobPriority:   int64(legacyQueue.MaxJobPriority),
+			PreemptionPolicy: string(legacyQueue.PreemptionPolicy),
 		}
 	}
 	return queues, nil
 }
+
+func (r *LegacyQueueRepository) CreateQueue(q *Queue) error {
+	priorityFactor, err := queue.NewPriorityFactor(q.Weight)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	preemptionPolicy, err := queue.NewPreemptionPolicy(q.PreemptionPolicy)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = r.backingRepo.CreateQueue(queue.Queue{
+		Name:             q.Name,
+		PriorityFactor:   priorityFactor,
+		MaxQueuedJobs:    uint32(q.MaxQueuedJobs),
+		MaxJobPriority:   uint32(q.MaxJobPriority),
+		PreemptionPolicy: preemptionPolicy,
+	})
+	var alreadyExists *legacyrepository.ErrQueueAlreadyExists
+	if errors.As(err, &alreadyExists) {
+		return nil
+	}
+	return err
+}
@@ -65,3 +65,25 @@ func TestLegacyQueueRepository_GetAllQueues(t *testing.T) {
 		})
 	}
 }
+
+func TestLegacyQueueRepository_CreateQueue(t *testing.T) {
+	rc := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 10})
+	rc.FlushDB()
+	defer func() {
+		rc.FlushDB()
+		_ = rc.Close()
+	}()
+	repo := NewLegacyQueueRepository(rc)
+	queue := &Queue{Name: "test-queue", Weight: 2}
+	require.NoError(t, repo.CreateQueue(queue))
+
+	// Creating a queue that already exists is a no-op.
+	require.NoError(t, repo.CreateQueue(&Queue{Name: "test-queue", Weight: 3}))
+
+	// Queues must have a priority factor of at least one.
+	assert.Error(t, repo.CreateQueue(&Queue{Name: "invalid-queue", Weight: 0}))
+
+	retrievedQueues, err := repo.GetAllQueues()
+	require.NoError(t, err)
+	assert.Equal(t, []*Queue{queue}, retrievedQueues)
+}
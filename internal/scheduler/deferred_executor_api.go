@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+// DeferredExecutorApi allows the executor api to be registered with the gRPC server before it's been created.
+// Requests received before then are rejected with codes.Unavailable, which executors treat as retryable.
+type DeferredExecutorApi struct {
+	api *Dependency[executorapi.ExecutorApiServer]
+}
+
+func NewDeferredExecutorApi(api *Dependency[executorapi.ExecutorApiServer]) *DeferredExecutorApi {
+	return &DeferredExecutorApi{api: api}
+}
+
+func (srv *DeferredExecutorApi) LeaseJobRuns(stream executorapi.ExecutorApi_LeaseJobRunsServer) error {
+	api, err := srv.get()
+	if err != nil {
+		return err
+	}
+	return api.LeaseJobRuns(stream)
+}
+
+func (srv *DeferredExecutorApi) ReportEvents(ctx context.Context, list *executorapi.EventList) (*types.Empty, error) {
+	api, err := srv.get()
+	if err != nil {
+		return nil, err
+	}
+	return api.ReportEvents(ctx, list)
+}
+
+func (srv *DeferredExecutorApi) get() (executorapi.ExecutorApiServer, error) {
+	api, ok := srv.api.TryGet()
+	if !ok {
+		return nil, status.Error(codes.Unavailable, "executor api not ready yet")
+	}
+	return api, nil
+}
@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+)
+
+// Dependency is a value constructed in the background during startup,
+// such that services that don't need it can start serving before it's available.
+//
+// Dependency implements health.Checker; Check returns an error until the value has been constructed successfully.
+type Dependency[T any] struct {
+	name string
+	// Closed once construction has either succeeded or failed.
+	done  chan struct{}
+	value T
+	err   error
+	// If non-nil, called with values constructed after construction timed out; see EnableRelease.
+	release func(T)
+}
+
+func NewDependency[T any](name string) *Dependency[T] {
+	return &Dependency[T]{
+		name: name,
+		done: make(chan struct{}),
+	}
+}
+
+// EnableRelease causes values returned by construct after construction timed out, which are otherwise discarded,
+// to be passed to release, e.g., to close connections established late. Must be called before Start.
+func (d *Dependency[T]) EnableRelease(release func(T)) {
+	d.release = release
+}
+
+// Start calls construct in a new goroutine. If timeout is positive, construction fails if it takes longer than timeout;
+// any value returned by construct after that is discarded; see EnableRelease. Start must be called at most once.
+func (d *Dependency[T]) Start(ctx *armadacontext.Context, timeout time.Duration, construct func(*armadacontext.Context) (T, error)) {
+	go func() {
+		var cancel func()
+		if timeout > 0 {
+			ctx, cancel = armadacontext.WithTimeout(ctx, timeout)
+		} else {
+			ctx, cancel = armadacontext.WithCancel(ctx)
+		}
+		defer cancel()
+		type result struct {
+			value T
+			err   error
+		}
+		c := make(chan result, 1)
+		start := time.Now()
+		go func() {
+			value, err := construct(ctx)
+			c <- result{value: value, err: err}
+		}()
+		select {
+		case r := <-c:
+			d.value, d.err = r.value, errors.WithMessagef(r.err, "error creating %s", d.name)
+		case <-ctx.Done():
+			d.err = errors.WithMessagef(ctx.Err(), "error creating %s after %s", d.name, time.Since(start))
+			if d.release != nil {
+				go func() {
+					if r := <-c; r.err == nil {
+						d.release(r.value)
+					}
+				}()
+			}
+		}
+		if d.err != nil {
+			ctx.Errorf("%v", d.err)
+		} else {
+			ctx.Infof("created %s in %s", d.name, time.Since(start))
+		}
+		close(d.done)
+	}()
+}
+
+// Get blocks until construction has completed or ctx is cancelled.
+func (d *Dependency[T]) Get(ctx *armadacontext.Context) (T, error) {
+	select {
+	case <-d.done:
+		return d.value, d.err
+	case <-ctx.Done():
+		var zero T
+		return zero, errors.WithStack(ctx.Err())
+	}
+}
+
+// TryGet returns the value if it has been constructed successfully, without blocking.
+func (d *Dependency[T]) TryGet() (T, bool) {
+	select {
+	case <-d.done:
+		return d.value, d.err == nil
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+func (d *Dependency[T]) Check() error {
+	select {
+	case <-d.done:
+		return d.err
+	default:
+		return errors.Errorf("%s not ready yet", d.name)
+	}
+}
@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/health"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+func TestDependency_DelayedPulsar(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+
+	// Postgres is available immediately, whereas pulsar is only available once released.
+	releasePulsar := make(chan struct{})
+	postgres := NewDependency[string]("postgres connection")
+	postgres.Start(ctx, time.Second, func(_ *armadacontext.Context) (string, error) { return "postgres", nil })
+	pulsarConnection := NewDependency[string]("pulsar client")
+	pulsarConnection.Start(ctx, 0, func(_ *armadacontext.Context) (string, error) {
+		<-releasePulsar
+		return "pulsar", nil
+	})
+	executorApi := NewDependency[executorapi.ExecutorApiServer]("executor api")
+	executorApi.Start(ctx, 0, func(ctx *armadacontext.Context) (executorapi.ExecutorApiServer, error) {
+		if _, err := postgres.Get(ctx); err != nil {
+			return nil, err
+		}
+		if _, err := pulsarConnection.Get(ctx); err != nil {
+			return nil, err
+		}
+		return &ExecutorApi{}, nil
+	})
+
+	startupCompleteCheck := health.NewStartupCompleteChecker()
+	startupCompleteCheck.MarkComplete()
+	healthChecks := health.NewMultiChecker(startupCompleteCheck, postgres, pulsarConnection, executorApi)
+	mux := http.NewServeMux()
+	health.SetupHttpMux(mux, healthChecks)
+	mux.Handle("/metrics", promhttp.HandlerFor(prometheus.NewRegistry(), promhttp.HandlerOpts{}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Health and metrics are served while waiting for pulsar, but the app isn't healthy yet.
+	_, err := postgres.Get(ctx)
+	require.NoError(t, err)
+	assertHttpStatus(t, server.URL+"/metrics", http.StatusOK)
+	assertHttpStatus(t, server.URL+"/health", http.StatusServiceUnavailable)
+	assert.EqualError(t, pulsarConnection.Check(), "pulsar client not ready yet")
+	assert.NoError(t, postgres.Check())
+
+	// The executor api rejects requests with a retryable error until its dependencies are available.
+	_, err = NewDeferredExecutorApi(executorApi).ReportEvents(ctx, &executorapi.EventList{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	close(releasePulsar)
+	_, err = executorApi.Get(ctx)
+	require.NoError(t, err)
+	assertHttpStatus(t, server.URL+"/health", http.StatusNoContent)
+	_, ok := executorApi.TryGet()
+	assert.True(t, ok)
+}
+
+func TestDependency_Timeout(t *testing.T) {
+	ctx := armadacontext.Background()
+	d := NewDependency[string]("pulsar client")
+	d.Start(ctx, 10*time.Millisecond, func(ctx *armadacontext.Context) (string, error) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return "too late", nil
+	})
+	_, err := d.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Error(t, d.Check())
+	_, ok := d.TryGet()
+	assert.False(t, ok)
+}
+
+func TestDependency_ReleaseLateValue(t *testing.T) {
+	ctx := armadacontext.Background()
+	released := make(chan string, 1)
+	d := NewDependency[string]("pulsar client")
+	d.EnableRelease(func(value string) { released <- value })
+	d.Start(ctx, 10*time.Millisecond, func(ctx *armadacontext.Context) (string, error) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return "too late", nil
+	})
+	_, err := d.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The value constructed after the timeout is released rather than leaked.
+	select {
+	case value := <-released:
+		assert.Equal(t, "too late", value)
+	case <-time.After(5 * time.Second):
+		t.Fatal("value constructed after the timeout wasn't released")
+	}
+	_, ok := d.TryGet()
+	assert.False(t, ok)
+}
+
+func TestDependency_Error(t *testing.T) {
+	ctx := armadacontext.Background()
+	d := NewDependency[string]("redis connection")
+	d.Start(ctx, time.Second, func(_ *armadacontext.Context) (string, error) {
+		return "", errors.New("connection refused")
+	})
+	_, err := d.Get(ctx)
+	assert.EqualError(t, err, "error creating redis connection: connection refused")
+	assert.EqualError(t, d.Check(), "error creating redis connection: connection refused")
+}
+
+func assertHttpStatus(t *testing.T, url string, expected int) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, expected, resp.StatusCode)
+}
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// Maximum number of job ids listed per transition in the error returned if events diverge from the jobDb.
+const maxDivergentJobIdsReported = 10
+
+// ErrEventDivergence is returned by cycles aborted since the events to be published diverged from the state transitions
+// applied to the jobDb; see EnableEventConsistencyCheck.
+var ErrEventDivergence = errors.New("events diverge from jobDb state transitions")
+
+// EnableEventConsistencyCheck causes each cycle, before publishing, to compare the jobs whose state transitioned
+// in the jobDb with the jobs events were generated for, per transition, e.g., the jobs that became succeeded with
+// those for which a JobSucceeded event is to be published. If they diverge by more than config.Tolerance jobs for any
+// transition, the cycle is aborted with an error listing the divergent jobs rather than committing state that
+// downstream systems, which only see the events, would never learn of.
+// Must be called before the scheduler is run.
+func (s *Scheduler) EnableEventConsistencyCheck(config schedulerconfig.EventConsistencyConfig) {
+	s.eventConsistencyConfig = &config
+	s.jobDb.EnableTransitionTracking()
+}
+
+// checkEventConsistency returns an error wrapping ErrEventDivergence if the jobs events are to be published for
+// diverge from the jobs whose state transitioned in txn by more than the tolerance for any transition.
+// Divergences are reported per transition, whether or not they're tolerated.
+func (s *Scheduler) checkEventConsistency(ctx *armadacontext.Context, txn *jobdb.Txn, events []*armadaevents.EventSequence) error {
+	if s.eventConsistencyConfig == nil {
+		return nil
+	}
+	transitioned := txn.Transitions()
+	if transitioned == nil {
+		return nil
+	}
+	// Job ids are lower-cased when converted from events; see armadaevents.UlidStringFromProtoUuid.
+	for transition, jobIds := range transitioned {
+		transitioned[transition] = make(map[string]bool, len(jobIds))
+		for jobId := range jobIds {
+			transitioned[transition][strings.ToLower(jobId)] = true
+		}
+	}
+	published, err := jobIdsByTransitionFromEvents(events)
+	if err != nil {
+		return err
+	}
+	var sb strings.Builder
+	for _, transition := range jobdb.JobTransitions {
+		missing := setDifference(transitioned[transition], published[transition])
+		unexpected := setDifference(published[transition], transitioned[transition])
+		divergence := len(missing) + len(unexpected)
+		if divergence == 0 {
+			continue
+		}
+		s.metrics.ReportEventDivergence(string(transition), divergence)
+		if divergence <= s.eventConsistencyConfig.Tolerance {
+			ctx.Warnf(
+				"%d jobs %s in the jobDb without an event and %d with an event without having %s, which is within the tolerance of %d",
+				len(missing), transition, len(unexpected), transition, s.eventConsistencyConfig.Tolerance,
+			)
+			continue
+		}
+		fmt.Fprintf(
+			&sb, "; %s: %d jobs in the jobDb and %d in events, %d without an event %v and %d without a transition %v",
+			transition, len(transitioned[transition]), len(published[transition]),
+			len(missing), truncatedJobIds(missing), len(unexpected), truncatedJobIds(unexpected),
+		)
+	}
+	if sb.Len() == 0 {
+		return nil
+	}
+	s.metrics.ReportEventDivergenceAbort()
+	return errors.Wrapf(ErrEventDivergence, "tolerance %d exceeded%s", s.eventConsistencyConfig.Tolerance, sb.String())
+}
+
+// jobIdsByTransitionFromEvents returns the ids of the jobs each transition is published for by events.
+func jobIdsByTransitionFromEvents(events []*armadaevents.EventSequence) (map[jobdb.JobTransition]map[string]bool, error) {
+	rv := make(map[jobdb.JobTransition]map[string]bool, len(jobdb.JobTransitions))
+	for _, transition := range jobdb.JobTransitions {
+		rv[transition] = make(map[string]bool)
+	}
+	add := func(transition jobdb.JobTransition, protoJobId *armadaevents.Uuid) error {
+		jobId, err := armadaevents.UlidStringFromProtoUuid(protoJobId)
+		if err != nil {
+			return err
+		}
+		rv[transition][jobId] = true
+		return nil
+	}
+	for _, sequence := range events {
+		for _, event := range sequence.GetEvents() {
+			var err error
+			switch e := event.GetEvent().(type) {
+			case *armadaevents.EventSequence_Event_JobRunLeased:
+				err = add(jobdb.JobLeased, e.JobRunLeased.GetJobId())
+			case *armadaevents.EventSequence_Event_JobSucceeded:
+				err = add(jobdb.JobSucceeded, e.JobSucceeded.GetJobId())
+			case *armadaevents.EventSequence_Event_CancelledJob:
+				err = add(jobdb.JobCancelled, e.CancelledJob.GetJobId())
+			case *armadaevents.EventSequence_Event_JobErrors:
+				if hasTerminalJobError(e.JobErrors) {
+					err = add(jobdb.JobFailed, e.JobErrors.GetJobId())
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rv, nil
+}
+
+func hasTerminalJobError(jobErrors *armadaevents.JobErrors) bool {
+	for _, jobError := range jobErrors.GetErrors() {
+		if jobError.GetTerminal() {
+			return true
+		}
+	}
+	return false
+}
+
+// setDifference returns the sorted elements of a not in b.
+func setDifference(a, b map[string]bool) []string {
+	var rv []string
+	for id := range a {
+		if !b[id] {
+			rv = append(rv, id)
+		}
+	}
+	slices.Sort(rv)
+	return rv
+}
+
+func truncatedJobIds(jobIds []string) []string {
+	if len(jobIds) > maxDivergentJobIdsReported {
+		return jobIds[:maxDivergentJobIdsReported]
+	}
+	return jobIds
+}
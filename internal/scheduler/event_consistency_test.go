@@ -0,0 +1,183 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+// eventDroppingSchedulingAlgo is a testSchedulingAlgo that omits the first numDropped scheduled jobs from its result
+// after leasing them in the jobDb, such that no events are generated for them.
+type eventDroppingSchedulingAlgo struct {
+	testSchedulingAlgo
+	numDropped int
+}
+
+func (a *eventDroppingSchedulingAlgo) Schedule(ctx *armadacontext.Context, txn *jobdb.Txn) (*SchedulerResult, error) {
+	result, err := a.testSchedulingAlgo.Schedule(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	result.ScheduledJobs = result.ScheduledJobs[a.numDropped:]
+	return result, nil
+}
+
+func TestScheduler_EventConsistency(t *testing.T) {
+	tests := map[string]struct {
+		numDropped            int
+		tolerance             int
+		expectAbort           bool
+		expectedNumDivergence float64
+	}{
+		"consistent": {},
+		"dropped lease event": {
+			numDropped:            1,
+			expectAbort:           true,
+			expectedNumDivergence: 1,
+		},
+		"dropped lease events within tolerance": {
+			numDropped:            2,
+			tolerance:             2,
+			expectedNumDivergence: 2,
+		},
+		"dropped lease events exceeding tolerance": {
+			numDropped:            2,
+			tolerance:             1,
+			expectAbort:           true,
+			expectedNumDivergence: 2,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+			jobs := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 3)
+			for i, job := range jobs {
+				jobs[i] = job.WithQueued(true)
+			}
+			schedulingAlgo := &eventDroppingSchedulingAlgo{
+				testSchedulingAlgo: testSchedulingAlgo{jobsToSchedule: []string{jobs[0].Id(), jobs[1].Id(), jobs[2].Id()}},
+				numDropped:         tc.numDropped,
+			}
+			publisher := &testPublisher{}
+			metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+				ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+				ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+			}, prometheus.NewRegistry())
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				&testJobRepository{},
+				&testExecutorRepository{},
+				schedulingAlgo,
+				NewStandaloneLeaderController(),
+				publisher,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				metrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.EnableEventConsistencyCheck(schedulerconfig.EventConsistencyConfig{Enabled: true, Tolerance: tc.tolerance})
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert(jobs))
+			txn.Commit()
+
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+			assert.Equal(t, tc.expectedNumDivergence, testutil.ToFloat64(metrics.eventDivergences.WithLabelValues(string(jobdb.JobLeased))))
+			if tc.expectAbort {
+				assert.True(t, errors.Is(err, ErrEventDivergence))
+				assert.Contains(t, err.Error(), strings.ToLower(jobs[0].Id()))
+				assert.Equal(t, 1.0, testutil.ToFloat64(metrics.eventDivergenceAborts))
+				// Nothing was published or committed.
+				assert.Empty(t, publisher.events)
+				for _, job := range jobs {
+					assert.True(t, sched.jobDb.ReadTxn().GetById(job.Id()).Queued())
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 0.0, testutil.ToFloat64(metrics.eventDivergenceAborts))
+			assert.Len(t, publisher.events, 3-tc.numDropped)
+			for _, job := range jobs {
+				assert.False(t, sched.jobDb.ReadTxn().GetById(job.Id()).Queued())
+			}
+		})
+	}
+}
+
+func TestScheduler_EventConsistencyTerminalTransitions(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	jobs := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 2)
+	for i, job := range jobs {
+		jobs[i] = job.WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+	}
+	jobRepo := &testJobRepository{
+		updatedRuns: []database.Run{
+			{RunID: jobs[0].LatestRun().Id(), JobID: jobs[0].Id(), JobSet: jobs[0].Jobset(), Executor: "testExecutor", Succeeded: true, Serial: 1},
+		},
+		updatedJobs: []database.Job{
+			{JobID: jobs[1].Id(), JobSet: jobs[1].Jobset(), Queue: jobs[1].Queue(), CancelRequested: true, Serial: 1},
+		},
+	}
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+	}, prometheus.NewRegistry())
+	publisher := &testPublisher{}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		publisher,
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		metrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.EnableEventConsistencyCheck(schedulerconfig.EventConsistencyConfig{Enabled: true})
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(jobs))
+	txn.Commit()
+
+	// The job that succeeded and the job that was cancelled both transitioned in the jobDb and have an event.
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.NotEmpty(t, publisher.events)
+	for _, transition := range jobdb.JobTransitions {
+		assert.Equal(t, 0.0, testutil.ToFloat64(metrics.eventDivergences.WithLabelValues(string(transition))), transition)
+	}
+
+	// Dropping the event of a transition is detected.
+	txn = sched.jobDb.WriteTxn()
+	defer txn.Abort()
+	job := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 1)[0]
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job.WithQueued(false).WithFailed(true)}))
+	err = sched.checkEventConsistency(ctx, txn, nil)
+	assert.True(t, errors.Is(err, ErrEventDivergence))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.eventDivergences.WithLabelValues(string(jobdb.JobFailed))))
+}
@@ -0,0 +1,405 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	commonmetrics "github.com/armadaproject/armada/internal/common/metrics"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// jobEvent is a kind of job event whose rate is tracked by EventRateTracker.
+type jobEvent int
+
+const (
+	jobEventAdmitted jobEvent = iota
+	jobEventCancelled
+	jobEventRequeued
+	jobEventFailed
+	numJobEvents
+)
+
+// Names of job events, as used in config, metric labels, and reports.
+var jobEventNames = [numJobEvents]string{"admitted", "cancelled", "requeued", "failed"}
+
+var queueEventRateDesc = prometheus.NewDesc(
+	commonmetrics.MetricPrefix+"queue_event_rate",
+	"Rate, in events per second, at which jobs of a queue were admitted, cancelled, requeued, or failed over the event rate window",
+	[]string{"queueName", "event"},
+	nil,
+)
+
+var queueEventRateExceededDesc = prometheus.NewDesc(
+	commonmetrics.MetricPrefix+"queue_event_rate_exceeded",
+	"1 if the rate of an event of a queue exceeds the maximum configured for it and 0 otherwise",
+	[]string{"queueName", "event"},
+	nil,
+)
+
+// jobEventCounts is the number of events of each kind.
+type jobEventCounts [numJobEvents]int
+
+func (c jobEventCounts) sum() int {
+	n := 0
+	for _, v := range c {
+		n += v
+	}
+	return n
+}
+
+// slidingWindowCounts counts events over a sliding window divided into a fixed number of equal-width buckets.
+// The window advances one bucket at a time; events are counted until the bucket they were added to leaves the window.
+type slidingWindowCounts struct {
+	// Ring buffer of the counts of each bucket in the window.
+	buckets []jobEventCounts
+	// Sum over all buckets.
+	total jobEventCounts
+	// Number, counted from the epoch, of the bucket events are currently added to.
+	current int64
+}
+
+func newSlidingWindowCounts(numBuckets int) *slidingWindowCounts {
+	return &slidingWindowCounts{buckets: make([]jobEventCounts, numBuckets)}
+}
+
+// advance moves the window forward such that bucket is the current bucket, discarding the buckets leaving the window.
+// Takes time proportional to the number of buckets discarded, which is at most the number of buckets in the window;
+// the cost of adding an event is thus constant.
+func (c *slidingWindowCounts) advance(bucket int64) {
+	if bucket <= c.current {
+		return
+	}
+	n := int64(len(c.buckets))
+	if bucket-c.current >= n {
+		for i := range c.buckets {
+			c.buckets[i] = jobEventCounts{}
+		}
+		c.total = jobEventCounts{}
+	} else {
+		for b := c.current + 1; b <= bucket; b++ {
+			i := b % n
+			for event, count := range c.buckets[i] {
+				c.total[event] -= count
+			}
+			c.buckets[i] = jobEventCounts{}
+		}
+	}
+	c.current = bucket
+}
+
+// add counts an event in bucket. Events in buckets that already left the window are counted in the current bucket.
+func (c *slidingWindowCounts) add(bucket int64, event jobEvent) {
+	c.advance(bucket)
+	c.buckets[c.current%int64(len(c.buckets))][event]++
+	c.total[event]++
+}
+
+// counts returns the number of events in the window ending with bucket.
+func (c *slidingWindowCounts) counts(bucket int64) jobEventCounts {
+	c.advance(bucket)
+	return c.total
+}
+
+// EventRateTracker counts the jobs of each queue and job set admitted, cancelled, requeued, and failed
+// over a sliding window, such that job sets generating excessive numbers of events, e.g., by repeatedly submitting
+// and cancelling jobs, can be identified before they overwhelm downstream consumers of events.
+//
+// Events are recorded from the state transitions of each cycle; see StateTransitionListener.
+// Per-queue rates are exported as metrics, whereas the job sets with the most events are only included in reports,
+// since the number of job sets is unbounded.
+type EventRateTracker struct {
+	window      time.Duration
+	bucketWidth time.Duration
+	numBuckets  int
+	// Number of job sets included in reports unless the request specifies otherwise.
+	numJobSets int
+	// Maximum rate of each event per queue. Events with a maximum of zero have no maximum.
+	maxQueueRateByEvent [numJobEvents]float64
+	countsByQueue       map[string]*slidingWindowCounts
+	countsByJobSet      map[jobSetKey]*slidingWindowCounts
+	// Queues exceeding the maximum rate of each event as of the most recent check.
+	// Used to warn only when a queue starts exceeding a maximum.
+	exceeding map[string]*[numJobEvents]bool
+	// Bucket in which queues and job sets without events in the window were last discarded.
+	lastPruned int64
+	// Protects the fields in this struct.
+	mu sync.Mutex
+}
+
+func NewEventRateTracker(config schedulerconfig.EventRatesConfig) (*EventRateTracker, error) {
+	if config.Window <= 0 || config.NumBuckets <= 0 {
+		return nil, errors.Errorf("event rate window %s and number of buckets %d must be positive", config.Window, config.NumBuckets)
+	}
+	t := &EventRateTracker{
+		window:         config.Window,
+		bucketWidth:    config.Window / time.Duration(config.NumBuckets),
+		numBuckets:     config.NumBuckets,
+		numJobSets:     config.NumJobSets,
+		countsByQueue:  make(map[string]*slidingWindowCounts),
+		countsByJobSet: make(map[jobSetKey]*slidingWindowCounts),
+		exceeding:      make(map[string]*[numJobEvents]bool),
+	}
+	if t.bucketWidth <= 0 {
+		return nil, errors.Errorf("event rate window %s is too short for %d buckets", config.Window, config.NumBuckets)
+	}
+	for name, rate := range config.MaxQueueRateByEvent {
+		event := slices.Index(jobEventNames[:], name)
+		if event == -1 {
+			return nil, errors.Errorf("unknown event %s; must be one of %v", name, jobEventNames)
+		}
+		t.maxQueueRateByEvent[event] = rate
+	}
+	return t, nil
+}
+
+// OnStateTransitions records the events implied by the state transitions of a cycle.
+//
+// Jobs are counted as admitted when first seen queued without runs. On startup, all queued jobs are seen for the first
+// time; to avoid counting these, jobs created before the start of the window aren't counted as admitted.
+func (t *EventRateTracker) OnStateTransitions(ctx *armadacontext.Context, now time.Time, jsts []jobdb.JobStateTransitions) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	bucket := t.bucket(now)
+	for _, jst := range jsts {
+		job := jst.Job
+		if job == nil {
+			continue
+		}
+		if jst.Queued && !job.HasRuns() && now.Sub(time.Unix(0, job.Created())) < t.window {
+			t.record(bucket, job, jobEventAdmitted)
+		}
+		if jst.Queued && job.HasRuns() {
+			t.record(bucket, job, jobEventRequeued)
+		}
+		if jst.Cancelled {
+			t.record(bucket, job, jobEventCancelled)
+		}
+		if jst.Failed {
+			t.record(bucket, job, jobEventFailed)
+		}
+	}
+	t.warnOnExceededRates(ctx, bucket)
+	if bucket > t.lastPruned {
+		t.prune(bucket)
+	}
+}
+
+func (t *EventRateTracker) record(bucket int64, job *jobdb.Job, event jobEvent) {
+	queueCounts, ok := t.countsByQueue[job.Queue()]
+	if !ok {
+		queueCounts = newSlidingWindowCounts(t.numBuckets)
+		t.countsByQueue[job.Queue()] = queueCounts
+	}
+	queueCounts.add(bucket, event)
+	key := jobSetKey{queue: job.Queue(), jobSet: job.Jobset()}
+	jobSetCounts, ok := t.countsByJobSet[key]
+	if !ok {
+		jobSetCounts = newSlidingWindowCounts(t.numBuckets)
+		t.countsByJobSet[key] = jobSetCounts
+	}
+	jobSetCounts.add(bucket, event)
+}
+
+// warnOnExceededRates logs a warning for each queue that started exceeding the maximum rate of an event.
+func (t *EventRateTracker) warnOnExceededRates(ctx *armadacontext.Context, bucket int64) {
+	for queue, counts := range t.countsByQueue {
+		exceeded := t.exceeded(counts.counts(bucket))
+		previouslyExceeded := t.exceeding[queue]
+		for event := jobEvent(0); event < numJobEvents; event++ {
+			if exceeded[event] && (previouslyExceeded == nil || !previouslyExceeded[event]) {
+				ctx.Warnf(
+					"queue %s exceeds the maximum %s event rate: %.2f/s > %.2f/s",
+					queue, jobEventNames[event], t.rate(counts.total[event]), t.maxQueueRateByEvent[event],
+				)
+			}
+		}
+		if exceeded == ([numJobEvents]bool{}) {
+			delete(t.exceeding, queue)
+		} else {
+			t.exceeding[queue] = &exceeded
+		}
+	}
+}
+
+// prune discards the counts of queues and job sets without events in the window.
+func (t *EventRateTracker) prune(bucket int64) {
+	for queue, counts := range t.countsByQueue {
+		if counts.counts(bucket).sum() == 0 {
+			delete(t.countsByQueue, queue)
+			delete(t.exceeding, queue)
+		}
+	}
+	for key, counts := range t.countsByJobSet {
+		if counts.counts(bucket).sum() == 0 {
+			delete(t.countsByJobSet, key)
+		}
+	}
+	t.lastPruned = bucket
+}
+
+func (t *EventRateTracker) bucket(now time.Time) int64 {
+	return now.UnixNano() / int64(t.bucketWidth)
+}
+
+// rate returns the rate, in events per second, corresponding to count events in the window.
+func (t *EventRateTracker) rate(count int) float64 {
+	return float64(count) / t.window.Seconds()
+}
+
+func (t *EventRateTracker) exceeded(counts jobEventCounts) [numJobEvents]bool {
+	var exceeded [numJobEvents]bool
+	for event, count := range counts {
+		if maxRate := t.maxQueueRateByEvent[event]; maxRate > 0 && t.rate(count) > maxRate {
+			exceeded[event] = true
+		}
+	}
+	return exceeded
+}
+
+// QueueEventRates are the rates of each event of a queue over the window.
+type QueueEventRates struct {
+	Queue string
+	// Rate, in events per second, of each event, indexed by event name.
+	RateByEvent map[string]float64
+	// True for each event whose rate exceeds the maximum configured for it.
+	ExceededByEvent map[string]bool
+}
+
+// QueueRates returns the rates of each queue with events in the window ending at time now, sorted by queue.
+func (t *EventRateTracker) QueueRates(now time.Time) []QueueEventRates {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	bucket := t.bucket(now)
+	rates := make([]QueueEventRates, 0, len(t.countsByQueue))
+	for queue, counts := range t.countsByQueue {
+		c := counts.counts(bucket)
+		exceeded := t.exceeded(c)
+		r := QueueEventRates{
+			Queue:           queue,
+			RateByEvent:     make(map[string]float64, numJobEvents),
+			ExceededByEvent: make(map[string]bool, numJobEvents),
+		}
+		for event, count := range c {
+			r.RateByEvent[jobEventNames[event]] = t.rate(count)
+			r.ExceededByEvent[jobEventNames[event]] = exceeded[event]
+		}
+		rates = append(rates, r)
+	}
+	slices.SortFunc(rates, func(a, b QueueEventRates) bool { return a.Queue < b.Queue })
+	return rates
+}
+
+// JobSetEventCounts is the number of events of a job set in the window.
+type JobSetEventCounts struct {
+	Queue  string
+	JobSet string
+	// Total number of events.
+	Total int
+	// Number of each event, indexed by event name.
+	CountByEvent map[string]int
+}
+
+// TopJobSets returns the numJobSets job sets with the most events in the window ending at time now,
+// ordered by decreasing number of events. If queue is non-empty, only job sets of that queue are considered.
+func (t *EventRateTracker) TopJobSets(now time.Time, queue string, numJobSets int) []JobSetEventCounts {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	bucket := t.bucket(now)
+	top := make([]JobSetEventCounts, 0, len(t.countsByJobSet))
+	for key, counts := range t.countsByJobSet {
+		if queue != "" && key.queue != queue {
+			continue
+		}
+		c := counts.counts(bucket)
+		total := c.sum()
+		if total == 0 {
+			continue
+		}
+		countByEvent := make(map[string]int, numJobEvents)
+		for event, count := range c {
+			countByEvent[jobEventNames[event]] = count
+		}
+		top = append(top, JobSetEventCounts{Queue: key.queue, JobSet: key.jobSet, Total: total, CountByEvent: countByEvent})
+	}
+	slices.SortFunc(top, func(a, b JobSetEventCounts) bool {
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		if a.Queue != b.Queue {
+			return a.Queue < b.Queue
+		}
+		return a.JobSet < b.JobSet
+	})
+	if len(top) > numJobSets {
+		top = top[:numJobSets]
+	}
+	return top
+}
+
+// metrics returns the per-queue event rate metrics as of time now.
+func (t *EventRateTracker) metrics(now time.Time) []prometheus.Metric {
+	var metrics []prometheus.Metric
+	for _, rates := range t.QueueRates(now) {
+		for _, event := range jobEventNames {
+			exceeded := 0.0
+			if rates.ExceededByEvent[event] {
+				exceeded = 1
+			}
+			metrics = append(
+				metrics,
+				prometheus.MustNewConstMetric(queueEventRateDesc, prometheus.GaugeValue, rates.RateByEvent[event], rates.Queue, event),
+				prometheus.MustNewConstMetric(queueEventRateExceededDesc, prometheus.GaugeValue, exceeded, rates.Queue, event),
+			)
+		}
+	}
+	return metrics
+}
+
+func (t *EventRateTracker) reportString(now time.Time, queue string, numJobSets int) string {
+	if numJobSets <= 0 {
+		numJobSets = t.numJobSets
+	}
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
+	fmt.Fprintf(w, "Job event rates over the last %s, in events per second:\n", t.window)
+	fmt.Fprintf(w, "\tQueue\t%s\n", strings.Join(jobEventNames[:], "\t"))
+	for _, rates := range t.QueueRates(now) {
+		if queue != "" && rates.Queue != queue {
+			continue
+		}
+		fmt.Fprintf(w, "\t%s", rates.Queue)
+		for _, event := range jobEventNames {
+			exceeded := ""
+			if rates.ExceededByEvent[event] {
+				exceeded = " (exceeded)"
+			}
+			fmt.Fprintf(w, "\t%.3f%s", rates.RateByEvent[event], exceeded)
+		}
+		fmt.Fprint(w, "\n")
+	}
+	top := t.TopJobSets(now, queue, numJobSets)
+	fmt.Fprintf(w, "Top %d job sets by number of events over the last %s:\n", numJobSets, t.window)
+	if len(top) == 0 {
+		fmt.Fprint(w, "\tNone\n")
+	} else {
+		fmt.Fprintf(w, "\tQueue\tJob set\tTotal\t%s\n", strings.Join(jobEventNames[:], "\t"))
+		for _, counts := range top {
+			fmt.Fprintf(w, "\t%s\t%s\t%d", counts.Queue, counts.JobSet, counts.Total)
+			for _, event := range jobEventNames {
+				fmt.Fprintf(w, "\t%d", counts.CountByEvent[event])
+			}
+			fmt.Fprint(w, "\n")
+		}
+	}
+	w.Flush()
+	return sb.String()
+}
@@ -0,0 +1,170 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+var eventRatesTestConfig = schedulerconfig.EventRatesConfig{
+	Enabled:             true,
+	Window:              time.Minute,
+	NumBuckets:          6,
+	NumJobSets:          2,
+	MaxQueueRateByEvent: map[string]float64{"cancelled": 1},
+}
+
+// eventRateTestTransitions returns n state transitions of jobs of the given queue and job set created at time created.
+// If requeued is true, the jobs have runs.
+func eventRateTestTransitions(queue, jobSet string, n int, created time.Time, requeued bool, jst jobdb.JobStateTransitions) []jobdb.JobStateTransitions {
+	jsts := make([]jobdb.JobStateTransitions, n)
+	for i, job := range testfixtures.N1Cpu4GiJobs(queue, testfixtures.PriorityClass0, n) {
+		job = job.WithJobset(jobSet).WithCreated(created.UnixNano())
+		if requeued {
+			job = job.WithNewRun("testExecutor", "test-node", "node", 0, created)
+		}
+		jsts[i] = jst
+		jsts[i].Job = job
+	}
+	return jsts
+}
+
+func TestEventRateTracker_TopJobSetsAndThresholds(t *testing.T) {
+	tracker, err := NewEventRateTracker(eventRatesTestConfig)
+	require.NoError(t, err)
+	ctx := armadacontext.Background()
+	now := testfixtures.BaseTime
+
+	// A burst of submissions and cancellations from a single job set, alongside background activity.
+	var jsts []jobdb.JobStateTransitions
+	jsts = append(jsts, eventRateTestTransitions("queue-a", "noisy", 100, now, false, jobdb.JobStateTransitions{Queued: true})...)
+	jsts = append(jsts, eventRateTestTransitions("queue-a", "noisy", 100, now, false, jobdb.JobStateTransitions{Cancelled: true})...)
+	jsts = append(jsts, eventRateTestTransitions("queue-a", "quiet", 5, now, false, jobdb.JobStateTransitions{Queued: true})...)
+	jsts = append(jsts, eventRateTestTransitions("queue-b", "other", 10, now, true, jobdb.JobStateTransitions{Failed: true})...)
+	jsts = append(jsts, eventRateTestTransitions("queue-b", "other", 3, now, true, jobdb.JobStateTransitions{Queued: true})...)
+	// Jobs created before the window, e.g., loaded on startup, aren't counted as admitted.
+	jsts = append(jsts, eventRateTestTransitions("queue-b", "old", 50, now.Add(-time.Hour), false, jobdb.JobStateTransitions{Queued: true})...)
+	tracker.OnStateTransitions(ctx, now, jsts)
+
+	assert.Equal(t, []JobSetEventCounts{
+		{
+			Queue:        "queue-a",
+			JobSet:       "noisy",
+			Total:        200,
+			CountByEvent: map[string]int{"admitted": 100, "cancelled": 100, "requeued": 0, "failed": 0},
+		},
+		{
+			Queue:        "queue-b",
+			JobSet:       "other",
+			Total:        13,
+			CountByEvent: map[string]int{"admitted": 0, "cancelled": 0, "requeued": 3, "failed": 10},
+		},
+	}, tracker.TopJobSets(now, "", 2))
+	topOfQueue := tracker.TopJobSets(now, "queue-a", 10)
+	require.Len(t, topOfQueue, 2)
+	assert.Equal(t, "noisy", topOfQueue[0].JobSet)
+	assert.Equal(t, "quiet", topOfQueue[1].JobSet)
+
+	rates := tracker.QueueRates(now)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "queue-a", rates[0].Queue)
+	assert.InDelta(t, 105.0/60, rates[0].RateByEvent["admitted"], 1e-9)
+	assert.InDelta(t, 100.0/60, rates[0].RateByEvent["cancelled"], 1e-9)
+	assert.Equal(t, map[string]bool{"admitted": false, "cancelled": true, "requeued": false, "failed": false}, rates[0].ExceededByEvent)
+	assert.Equal(t, "queue-b", rates[1].Queue)
+	assert.Equal(t, 0.0, rates[1].RateByEvent["admitted"])
+	assert.False(t, rates[1].ExceededByEvent["cancelled"])
+
+	exceeded := map[string]float64{}
+	for _, m := range tracker.metrics(now) {
+		if m.Desc() != queueEventRateExceededDesc {
+			continue
+		}
+		var metric dto.Metric
+		require.NoError(t, m.Write(&metric))
+		labels := map[string]string{}
+		for _, label := range metric.Label {
+			labels[label.GetName()] = label.GetValue()
+		}
+		exceeded[labels["queueName"]+"/"+labels["event"]] = metric.Gauge.GetValue()
+	}
+	assert.Equal(t, 1.0, exceeded["queue-a/cancelled"])
+	assert.Equal(t, 0.0, exceeded["queue-a/admitted"])
+	assert.Equal(t, 0.0, exceeded["queue-b/cancelled"])
+
+	// Events leave the window once the bucket they were counted in does.
+	tracker.OnStateTransitions(ctx, now.Add(30*time.Second), eventRateTestTransitions("queue-b", "other", 1, now, true, jobdb.JobStateTransitions{Failed: true}))
+	later := now.Add(65 * time.Second)
+	tracker.OnStateTransitions(ctx, later, nil)
+	assert.Equal(t, []JobSetEventCounts{
+		{
+			Queue:        "queue-b",
+			JobSet:       "other",
+			Total:        1,
+			CountByEvent: map[string]int{"admitted": 0, "cancelled": 0, "requeued": 0, "failed": 1},
+		},
+	}, tracker.TopJobSets(later, "", 10))
+	rates = tracker.QueueRates(later)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "queue-b", rates[0].Queue)
+
+	// Queues and job sets without events in the window are discarded.
+	tracker.OnStateTransitions(ctx, now.Add(2*time.Minute), nil)
+	assert.Empty(t, tracker.countsByQueue)
+	assert.Empty(t, tracker.countsByJobSet)
+}
+
+func TestSlidingWindowCounts(t *testing.T) {
+	c := newSlidingWindowCounts(3)
+	c.add(10, jobEventAdmitted)
+	c.add(11, jobEventAdmitted)
+	c.add(12, jobEventFailed)
+	assert.Equal(t, jobEventCounts{2, 0, 0, 1}, c.counts(12))
+	assert.Equal(t, jobEventCounts{1, 0, 0, 1}, c.counts(13))
+	// Events in buckets that already left the window are counted in the current bucket.
+	c.add(5, jobEventCancelled)
+	assert.Equal(t, jobEventCounts{1, 1, 0, 1}, c.counts(13))
+	assert.Equal(t, jobEventCounts{0, 1, 0, 0}, c.counts(15))
+	assert.Equal(t, jobEventCounts{}, c.counts(100))
+}
+
+func TestNewEventRateTracker_UnknownEvent(t *testing.T) {
+	config := eventRatesTestConfig
+	config.MaxQueueRateByEvent = map[string]float64{"submitted": 1}
+	_, err := NewEventRateTracker(config)
+	assert.Error(t, err)
+}
+
+func TestSchedulingContextRepository_GetEventRateReport(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10)
+	require.NoError(t, err)
+	ctx := armadacontext.Background()
+	request := &schedulerobjects.EventRateReportRequest{Queue: "queue-a"}
+
+	report, err := repo.GetEventRateReport(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, "Event rate tracking is disabled\n", report.Report)
+
+	tracker, err := NewEventRateTracker(eventRatesTestConfig)
+	require.NoError(t, err)
+	now := time.Now()
+	tracker.OnStateTransitions(ctx, now, eventRateTestTransitions("queue-a", "noisy", 90, now, false, jobdb.JobStateTransitions{Cancelled: true}))
+	tracker.OnStateTransitions(ctx, now, eventRateTestTransitions("queue-b", "other", 1, now, false, jobdb.JobStateTransitions{Cancelled: true}))
+	repo.EnableEventRateReports(tracker)
+	report, err = repo.GetEventRateReport(ctx, request)
+	require.NoError(t, err)
+	assert.Regexp(t, `queue-a\s+0.000\s+1.500 \(exceeded\)`, report.Report)
+	assert.Regexp(t, `queue-a\s+noisy\s+90\s+0\s+90`, report.Report)
+	assert.NotContains(t, report.Report, "queue-b")
+}
+
+var _ StateTransitionListener = &EventRateTracker{}
@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+// unknownExecutorLabel is the executor label of requests of executors not known to the executor repository.
+const unknownExecutorLabel = "unknown"
+
+// ExecutorApiMetrics records the duration, request and response sizes, and errors of executor api requests,
+// labelled by method and executor. Only executors known to the executor repository are labelled by their id;
+// requests of any other executor, e.g., one that's yet to be stored, are labelled unknownExecutorLabel,
+// such that requests with arbitrary executor ids can't create an unbounded number of series.
+type ExecutorApiMetrics struct {
+	refreshInterval time.Duration
+	// Ids of the executors known to the executor repository as of the most recent refresh.
+	knownExecutors  map[string]bool
+	requestDuration *prometheus.HistogramVec
+	requestSize     *prometheus.HistogramVec
+	responseSize    *prometheus.HistogramVec
+	requestErrors   *prometheus.CounterVec
+	mu              sync.Mutex
+}
+
+func NewExecutorApiMetrics(refreshInterval time.Duration) *ExecutorApiMetrics {
+	labels := []string{"method", "executor"}
+	return &ExecutorApiMetrics{
+		refreshInterval: refreshInterval,
+		knownExecutors:  make(map[string]bool),
+		requestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: NAMESPACE,
+				Subsystem: SUBSYSTEM,
+				Name:      "executor_api_request_duration_seconds",
+				Help:      "Time taken to serve executor api requests, by method and executor.",
+				Buckets:   prometheus.ExponentialBuckets(0.005, 2, 15),
+			},
+			labels,
+		),
+		requestSize: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: NAMESPACE,
+				Subsystem: SUBSYSTEM,
+				Name:      "executor_api_request_bytes",
+				Help:      "Size of the messages received by executor api requests, by method and executor.",
+				Buckets:   prometheus.ExponentialBuckets(64, 4, 12),
+			},
+			labels,
+		),
+		responseSize: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: NAMESPACE,
+				Subsystem: SUBSYSTEM,
+				Name:      "executor_api_response_bytes",
+				Help:      "Size of the messages sent in response to executor api requests, by method and executor.",
+				Buckets:   prometheus.ExponentialBuckets(64, 4, 12),
+			},
+			labels,
+		),
+		requestErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: NAMESPACE,
+				Subsystem: SUBSYSTEM,
+				Name:      "executor_api_request_errors",
+				Help:      "Number of executor api requests that returned an error, by method and executor.",
+			},
+			labels,
+		),
+	}
+}
+
+// Run refreshes the known executors from executorRepository immediately and then once per refresh interval,
+// until ctx is cancelled. If the refresh interval is zero, known executors are only refreshed once.
+func (m *ExecutorApiMetrics) Run(ctx *armadacontext.Context, executorRepository database.ExecutorRepository) error {
+	if err := m.refreshKnownExecutors(ctx, executorRepository); err != nil {
+		logging.WithStacktrace(ctx, err).Error("failed to refresh executors known to the executor api metrics")
+	}
+	if m.refreshInterval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+	ticker := time.NewTicker(m.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.refreshKnownExecutors(ctx, executorRepository); err != nil {
+				logging.WithStacktrace(ctx, err).Error("failed to refresh executors known to the executor api metrics")
+			}
+		}
+	}
+}
+
+func (m *ExecutorApiMetrics) refreshKnownExecutors(ctx *armadacontext.Context, executorRepository database.ExecutorRepository) error {
+	lastUpdateTimes, err := executorRepository.GetLastUpdateTimes(ctx)
+	if err != nil {
+		return err
+	}
+	knownExecutors := make(map[string]bool, len(lastUpdateTimes))
+	for executorId := range lastUpdateTimes {
+		knownExecutors[executorId] = true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.knownExecutors = knownExecutors
+	return nil
+}
+
+// executorLabel returns the executor label of requests of executorId.
+func (m *ExecutorApiMetrics) executorLabel(executorId string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.knownExecutors[executorId] {
+		return executorId
+	}
+	return unknownExecutorLabel
+}
+
+// observe records a request to method made by executorId.
+func (m *ExecutorApiMetrics) observe(method, executorId string, duration time.Duration, requestBytes, responseBytes int, err error) {
+	executor := m.executorLabel(executorId)
+	m.requestDuration.WithLabelValues(method, executor).Observe(duration.Seconds())
+	m.requestSize.WithLabelValues(method, executor).Observe(float64(requestBytes))
+	m.responseSize.WithLabelValues(method, executor).Observe(float64(responseBytes))
+	if err != nil {
+		m.requestErrors.WithLabelValues(method, executor).Inc()
+	}
+}
+
+func (m *ExecutorApiMetrics) Describe(desc chan<- *prometheus.Desc) {
+	m.requestDuration.Describe(desc)
+	m.requestSize.Describe(desc)
+	m.responseSize.Describe(desc)
+	m.requestErrors.Describe(desc)
+}
+
+func (m *ExecutorApiMetrics) Collect(metrics chan<- prometheus.Metric) {
+	m.requestDuration.Collect(metrics)
+	m.requestSize.Collect(metrics)
+	m.responseSize.Collect(metrics)
+	m.requestErrors.Collect(metrics)
+}
+
+// EnableRequestMetrics causes the duration, request and response sizes, and errors of each request to be recorded.
+// ReportEvents requests don't include the id of the executor making them; they're attributed to the executor
+// named by the authenticated principal, if it's known, and otherwise to an unknown executor.
+func (srv *ExecutorApi) EnableRequestMetrics(metrics *ExecutorApiMetrics) {
+	srv.requestMetrics = metrics
+}
+
+// sizeRecordingLeaseStream records the executor id and the total size of the messages received and sent over a
+// lease stream.
+type sizeRecordingLeaseStream struct {
+	executorapi.ExecutorApi_LeaseJobRunsServer
+	executorId    string
+	requestBytes  int
+	responseBytes int
+}
+
+func (s *sizeRecordingLeaseStream) Recv() (*executorapi.LeaseRequest, error) {
+	req, err := s.ExecutorApi_LeaseJobRunsServer.Recv()
+	if req != nil {
+		s.executorId = req.ExecutorId
+		s.requestBytes += req.Size()
+	}
+	return req, err
+}
+
+func (s *sizeRecordingLeaseStream) Send(msg *executorapi.LeaseStreamMessage) error {
+	if err := s.ExecutorApi_LeaseJobRunsServer.Send(msg); err != nil {
+		return err
+	}
+	s.responseBytes += msg.Size()
+	return nil
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/mocks"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+func TestExecutorApiMetrics(t *testing.T) {
+	const (
+		knownExecutor   = "known-executor"
+		failingExecutor = "failing-executor"
+	)
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepository.EXPECT().GetLastUpdateTimes(gomock.Any()).Return(
+		map[string]time.Time{knownExecutor: time.Now(), failingExecutor: time.Now()}, nil,
+	).Times(1)
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *armadacontext.Context, executor *schedulerobjects.Executor) error {
+			if executor.GetId() == failingExecutor {
+				return errors.New("failed to store executor")
+			}
+			return nil
+		},
+	).AnyTimes()
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	server, err := NewExecutorApi(
+		mocks.NewMockProducer(ctrl),
+		mockJobRepository,
+		mockExecutorRepository,
+		mockExecutorRepository,
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+	metrics := NewExecutorApiMetrics(0)
+	require.NoError(t, prometheus.NewRegistry().Register(metrics))
+	server.EnableRequestMetrics(metrics)
+	require.NoError(t, metrics.refreshKnownExecutors(ctx, mockExecutorRepository))
+
+	// Serve the api in-process, such that requests go through the grpc stack.
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	executorapi.RegisterExecutorApiServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+	conn, err := grpc.DialContext(
+		ctx,
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := executorapi.NewExecutorApiClient(conn)
+
+	// leaseJobRuns makes a lease request as executorId and returns the number of messages received.
+	leaseJobRuns := func(executorId string) (int, error) {
+		stream, err := client.LeaseJobRuns(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&executorapi.LeaseRequest{ExecutorId: executorId, Pool: "test-pool"}))
+		numReceived := 0
+		for {
+			if _, err := stream.Recv(); err == io.EOF {
+				return numReceived, nil
+			} else if err != nil {
+				return numReceived, err
+			}
+			numReceived++
+		}
+	}
+
+	numReceived, err := leaseJobRuns(knownExecutor)
+	require.NoError(t, err)
+	require.Greater(t, numReceived, 0)
+	_, err = leaseJobRuns(failingExecutor)
+	require.Error(t, err)
+	_, err = leaseJobRuns("unregistered-executor")
+	require.NoError(t, err)
+
+	// The successful request is recorded against its executor, without an error.
+	assert.Equal(t, uint64(1), histogramSampleCount(t, metrics.requestDuration, "LeaseJobRuns", knownExecutor))
+	assert.Greater(t, histogramSampleSum(t, metrics.requestSize, "LeaseJobRuns", knownExecutor), 0.0)
+	assert.Greater(t, histogramSampleSum(t, metrics.responseSize, "LeaseJobRuns", knownExecutor), 0.0)
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.requestErrors.WithLabelValues("LeaseJobRuns", knownExecutor)))
+
+	// The failing request is recorded against its executor, with an error.
+	assert.Equal(t, uint64(1), histogramSampleCount(t, metrics.requestDuration, "LeaseJobRuns", failingExecutor))
+	assert.Equal(t, 0.0, histogramSampleSum(t, metrics.responseSize, "LeaseJobRuns", failingExecutor))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requestErrors.WithLabelValues("LeaseJobRuns", failingExecutor)))
+
+	// Requests of executors not known to the executor repository don't get their own label.
+	assert.Equal(t, uint64(1), histogramSampleCount(t, metrics.requestDuration, "LeaseJobRuns", unknownExecutorLabel))
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.requestDuration))
+}
+
+func histogramSampleCount(t *testing.T, histogram *prometheus.HistogramVec, labelValues ...string) uint64 {
+	return writeHistogram(t, histogram, labelValues...).GetSampleCount()
+}
+
+func histogramSampleSum(t *testing.T, histogram *prometheus.HistogramVec, labelValues ...string) float64 {
+	return writeHistogram(t, histogram, labelValues...).GetSampleSum()
+}
+
+func writeHistogram(t *testing.T, histogram *prometheus.HistogramVec, labelValues ...string) *dto.Histogram {
+	var metric dto.Metric
+	require.NoError(t, histogram.WithLabelValues(labelValues...).(prometheus.Histogram).Write(&metric))
+	return metric.Histogram
+}
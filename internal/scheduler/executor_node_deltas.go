@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"bytes"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+// Reasons for which executors are asked to report all of their nodes instead of a delta.
+const (
+	// The scheduler doesn't accept node deltas.
+	nodeResyncReasonUnsupported = "unsupported"
+	// No nodes are stored for the executor the delta could be applied to.
+	nodeResyncReasonUnknownExecutor = "unknownExecutor"
+	// The nodes resulting from the delta differ from those the executor reported having.
+	nodeResyncReasonHashMismatch = "hashMismatch"
+)
+
+// EnableExecutorNodeDeltas causes executors to be told they may report only the nodes added, changed, or removed since
+// their previous lease request, such that executors with many nodes needn't send and store all of them each time.
+// Deltas are applied to the nodes stored in repository, which replaces the executor repository of the api.
+// If the nodes resulting from a delta don't match the hash reported with it, e.g., since a previous request was lost,
+// the delta is discarded and the executor is asked to report all of its nodes instead, without being sent any leases.
+// Such resyncs are counted via metrics, which may be nil.
+func (srv *ExecutorApi) EnableExecutorNodeDeltas(repository database.ExecutorNodeDeltaRepository, metrics *SchedulerMetrics) {
+	srv.executorRepository = repository
+	srv.nodeDeltaRepository = repository
+	srv.metrics = metrics
+}
+
+// storeExecutorNodeDelta applies the delta reported in req to the nodes stored for its executor and stores the result.
+// Returns the resulting executor, or nil if the delta couldn't be applied, in which case the executor should be asked
+// to report all of its nodes.
+func (srv *ExecutorApi) storeExecutorNodeDelta(ctx *armadacontext.Context, req *executorapi.LeaseRequest) (*schedulerobjects.Executor, error) {
+	if srv.nodeDeltaRepository == nil {
+		srv.reportNodeResync(ctx, req.ExecutorId, nodeResyncReasonUnsupported)
+		return nil, nil
+	}
+	stored, err := srv.nodeDeltaRepository.GetExecutor(ctx, req.ExecutorId)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		srv.reportNodeResync(ctx, req.ExecutorId, nodeResyncReasonUnknownExecutor)
+		return nil, nil
+	}
+
+	executor := srv.executorFromLeaseRequest(ctx, req)
+	updatedNodes := executor.Nodes
+	isUpdatedOrRemoved := make(map[string]bool, len(updatedNodes)+len(req.RemovedNodeNames))
+	for _, node := range updatedNodes {
+		isUpdatedOrRemoved[node.Name] = true
+	}
+	for _, name := range req.RemovedNodeNames {
+		isUpdatedOrRemoved[name] = true
+	}
+	nodes := make([]*schedulerobjects.Node, 0, len(stored.Nodes)+len(updatedNodes))
+	nodeInfoHashByName := make(map[string][]byte, len(stored.Nodes)+len(updatedNodes))
+	for _, node := range stored.Nodes {
+		if !isUpdatedOrRemoved[node.Name] {
+			nodes = append(nodes, node)
+			nodeInfoHashByName[node.Name] = node.NodeInfoHash
+		}
+	}
+	for _, node := range updatedNodes {
+		nodes = append(nodes, node)
+		nodeInfoHashByName[node.Name] = node.NodeInfoHash
+	}
+	if !bytes.Equal(executorapi.NodesHash(nodeInfoHashByName), req.NodesHash) {
+		srv.reportNodeResync(ctx, req.ExecutorId, nodeResyncReasonHashMismatch)
+		return nil, nil
+	}
+	slices.SortFunc(nodes, func(a, b *schedulerobjects.Node) bool { return a.Name < b.Name })
+	executor.Nodes = nodes
+
+	if err := srv.nodeDeltaRepository.StoreExecutorNodeDelta(ctx, executor, updatedNodes, req.RemovedNodeNames); err != nil {
+		return nil, err
+	}
+	if err := srv.legacyExecutorRepository.StoreExecutor(ctx, executor); err != nil {
+		return nil, err
+	}
+	return executor, nil
+}
+
+func (srv *ExecutorApi) reportNodeResync(ctx *armadacontext.Context, executorId string, reason string) {
+	ctx.Warnf("discarding nodes delta reported by executor %s (%s); asking it to report all of its nodes", executorId, reason)
+	if srv.metrics != nil {
+		srv.metrics.ReportExecutorNodeResync(executorId, reason)
+	}
+}
+
+// runIdsFromExecutor returns the ids of all runs of executor, including any not yet assigned to a node.
+func runIdsFromExecutor(executor *schedulerobjects.Executor) ([]uuid.UUID, error) {
+	runIds := make([]uuid.UUID, 0, 256)
+	for _, node := range executor.Nodes {
+		for runIdStr := range node.StateByJobRunId {
+			runId, err := uuid.Parse(runIdStr)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			runIds = append(runIds, runId)
+		}
+	}
+	for _, runIdStr := range executor.UnassignedJobRuns {
+		runId, err := uuid.Parse(runIdStr)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		runIds = append(runIds, runId)
+	}
+	return runIds, nil
+}
@@ -0,0 +1,237 @@
+package scheduler
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/api"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+// testNodeDeltaExecutorRepository stores executors in memory, recording the nodes of each delta stored.
+type testNodeDeltaExecutorRepository struct {
+	executors        map[string]*schedulerobjects.Executor
+	updatedNodes     []*schedulerobjects.Node
+	removedNodeNames []string
+}
+
+func (r *testNodeDeltaExecutorRepository) GetExecutors(_ *armadacontext.Context) ([]*schedulerobjects.Executor, error) {
+	panic("not implemented")
+}
+
+func (r *testNodeDeltaExecutorRepository) GetLastUpdateTimes(_ *armadacontext.Context) (map[string]time.Time, error) {
+	panic("not implemented")
+}
+
+func (r *testNodeDeltaExecutorRepository) StoreExecutor(_ *armadacontext.Context, executor *schedulerobjects.Executor) error {
+	r.executors[executor.Id] = executor
+	return nil
+}
+
+func (r *testNodeDeltaExecutorRepository) GetExecutor(_ *armadacontext.Context, executorId string) (*schedulerobjects.Executor, error) {
+	return r.executors[executorId], nil
+}
+
+func (r *testNodeDeltaExecutorRepository) StoreExecutorNodeDelta(
+	_ *armadacontext.Context,
+	executor *schedulerobjects.Executor,
+	updatedNodes []*schedulerobjects.Node,
+	removedNodeNames []string,
+) error {
+	r.executors[executor.Id] = executor
+	r.updatedNodes = updatedNodes
+	r.removedNodeNames = removedNodeNames
+	return nil
+}
+
+func TestExecutorApi_LeaseJobRuns_NodeDeltas(t *testing.T) {
+	const executorId = "node-deltas-executor"
+	runIds := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockLegacyExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockLegacyExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	var requestRuns []uuid.UUID
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), executorId, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *armadacontext.Context, _ string, _ uint, excludedRunIds []uuid.UUID) ([]*database.JobRunLease, error) {
+			requestRuns = excludedRunIds
+			return nil, nil
+		}).AnyTimes()
+	repository := &testNodeDeltaExecutorRepository{executors: make(map[string]*schedulerobjects.Executor)}
+
+	server, err := NewExecutorApi(
+		mocks.NewMockProducer(ctrl),
+		mockJobRepository,
+		mockLegacyExecutorRepository,
+		mockLegacyExecutorRepository,
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+	server.EnableExecutorNodeDeltas(repository, schedulerMetrics)
+
+	// leaseJobRuns makes a lease request for nodes and returns the end marker of the response
+	// and whether any leases were requested from the job repository.
+	leaseJobRuns := func(req *executorapi.LeaseRequest) (*executorapi.EndMarker, bool) {
+		req.ExecutorId = executorId
+		req.Pool = "test-pool"
+		req.MaxJobsToLease = 10
+		requestRuns = nil
+		mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+		mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+		mockStream.EXPECT().Recv().Return(req, nil).Times(1)
+		var end *executorapi.EndMarker
+		mockStream.EXPECT().Send(gomock.Any()).
+			Do(func(msg *executorapi.LeaseStreamMessage) {
+				if msg.GetEnd() != nil {
+					end = msg.GetEnd()
+				}
+			}).AnyTimes()
+		require.NoError(t, server.LeaseJobRuns(mockStream))
+		require.NotNil(t, end)
+		return end, requestRuns != nil
+	}
+	// delta returns a delta request for the nodes changed, given the nodes of the executor after the change.
+	delta := func(nodes []*api.NodeInfo, changed []*api.NodeInfo, removedNodeNames ...string) *executorapi.LeaseRequest {
+		nodeInfoHashByName := make(map[string][]byte)
+		for _, node := range nodes {
+			nodeInfoHashByName[node.Name] = executorapi.NodeInfoHash(node)
+		}
+		return &executorapi.LeaseRequest{
+			Nodes:            changed,
+			NodesDelta:       true,
+			RemovedNodeNames: removedNodeNames,
+			NodesHash:        executorapi.NodesHash(nodeInfoHashByName),
+		}
+	}
+	storedNodeNames := func() []string {
+		var names []string
+		for _, node := range repository.executors[executorId].Nodes {
+			names = append(names, node.Name)
+		}
+		return names
+	}
+	resyncs := func(reason string) float64 {
+		return testutil.ToFloat64(schedulerMetrics.executorNodeResyncs.WithLabelValues(executorId, reason))
+	}
+	nodeA := &api.NodeInfo{Name: "node-a", RunIdsByState: map[string]api.JobState{runIds[0].String(): api.JobState_RUNNING}}
+	nodeB := &api.NodeInfo{Name: "node-b", RunIdsByState: map[string]api.JobState{runIds[1].String(): api.JobState_PENDING}}
+	nodeC := &api.NodeInfo{Name: "node-c"}
+
+	// A delta from an executor the scheduler has no nodes for can't be applied.
+	end, leased := leaseJobRuns(delta([]*api.NodeInfo{nodeA}, []*api.NodeInfo{nodeA}))
+	assert.False(t, end.AcceptsNodeDeltas)
+	assert.False(t, leased)
+	assert.Equal(t, 1.0, resyncs(nodeResyncReasonUnknownExecutor))
+
+	// All nodes are stored and the executor is told it may report deltas.
+	end, leased = leaseJobRuns(&executorapi.LeaseRequest{Nodes: []*api.NodeInfo{nodeA, nodeB}})
+	assert.True(t, end.AcceptsNodeDeltas)
+	assert.True(t, leased)
+	assert.Equal(t, []string{"node-a", "node-b"}, storedNodeNames())
+
+	// A run starts on node-b and node-c is added; node-a is unchanged, but its run is still held by the executor.
+	nodeB = &api.NodeInfo{Name: "node-b", RunIdsByState: map[string]api.JobState{
+		runIds[1].String(): api.JobState_RUNNING,
+		runIds[2].String(): api.JobState_PENDING,
+	}}
+	end, leased = leaseJobRuns(delta([]*api.NodeInfo{nodeA, nodeB, nodeC}, []*api.NodeInfo{nodeB, nodeC}))
+	assert.True(t, end.AcceptsNodeDeltas)
+	require.True(t, leased)
+	assert.ElementsMatch(t, runIds, requestRuns)
+	assert.Equal(t, []string{"node-a", "node-b", "node-c"}, storedNodeNames())
+	assert.Equal(t, []string{"node-b", "node-c"}, nodeNames(repository.updatedNodes))
+	assert.Equal(t, map[string]schedulerobjects.JobRunState{
+		runIds[1].String(): schedulerobjects.JobRunState_RUNNING,
+		runIds[2].String(): schedulerobjects.JobRunState_PENDING,
+	}, repository.executors[executorId].Nodes[1].StateByJobRunId)
+
+	// node-a is removed, together with its run.
+	end, leased = leaseJobRuns(delta([]*api.NodeInfo{nodeB, nodeC}, nil, "node-a"))
+	assert.True(t, end.AcceptsNodeDeltas)
+	require.True(t, leased)
+	assert.ElementsMatch(t, runIds[1:], requestRuns)
+	assert.Equal(t, []string{"node-b", "node-c"}, storedNodeNames())
+	assert.Empty(t, repository.updatedNodes)
+	assert.Equal(t, []string{"node-a"}, repository.removedNodeNames)
+
+	// The executor missed that node-c changed; the delta is discarded and the executor is asked to resync.
+	changedNodeC := &api.NodeInfo{Name: "node-c", Unschedulable: true}
+	end, leased = leaseJobRuns(delta([]*api.NodeInfo{nodeB, changedNodeC}, []*api.NodeInfo{nodeB}))
+	assert.False(t, end.AcceptsNodeDeltas)
+	assert.False(t, leased)
+	assert.Equal(t, 1.0, resyncs(nodeResyncReasonHashMismatch))
+	assert.False(t, repository.executors[executorId].Nodes[1].Unschedulable)
+
+	// Once it has, it may report deltas again.
+	end, _ = leaseJobRuns(&executorapi.LeaseRequest{Nodes: []*api.NodeInfo{nodeB, changedNodeC}})
+	assert.True(t, end.AcceptsNodeDeltas)
+	assert.True(t, repository.executors[executorId].Nodes[1].Unschedulable)
+	end, _ = leaseJobRuns(delta([]*api.NodeInfo{nodeB, changedNodeC}, nil))
+	assert.True(t, end.AcceptsNodeDeltas)
+	assert.Equal(t, 1.0, resyncs(nodeResyncReasonHashMismatch))
+}
+
+func TestExecutorApi_LeaseJobRuns_NodeDeltasUnsupported(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	server, err := NewExecutorApi(
+		mocks.NewMockProducer(ctrl),
+		mockJobRepository,
+		mockExecutorRepository,
+		mockExecutorRepository,
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+
+	for _, req := range []*executorapi.LeaseRequest{
+		{ExecutorId: "test-executor", Pool: "test-pool", Nodes: []*api.NodeInfo{{Name: "node-a"}}},
+		{ExecutorId: "test-executor", Pool: "test-pool", NodesDelta: true},
+	} {
+		mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+		mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+		mockStream.EXPECT().Recv().Return(req, nil).Times(1)
+		mockStream.EXPECT().Send(gomock.Any()).
+			Do(func(msg *executorapi.LeaseStreamMessage) {
+				if msg.GetEnd() != nil {
+					assert.False(t, msg.GetEnd().AcceptsNodeDeltas)
+				}
+			}).AnyTimes()
+		require.NoError(t, server.LeaseJobRuns(mockStream))
+	}
+}
+
+func nodeNames(nodes []*schedulerobjects.Node) []string {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.Name
+	}
+	sort.Strings(names)
+	return names
+}
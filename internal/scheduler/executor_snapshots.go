@@ -0,0 +1,243 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/common/metrics"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+var (
+	executorSnapshotVersionDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_executor_snapshot_version",
+		"Version of the most recent snapshot of executors, which is incremented each time executors are fetched.",
+		nil,
+		nil,
+	)
+	executorSnapshotAgeDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_executor_snapshot_age_seconds",
+		"Time since the executors of the most recent snapshot were fetched.",
+		nil,
+		nil,
+	)
+)
+
+// ExecutorSnapshotNode is the view of a node held by an ExecutorSnapshot.
+type ExecutorSnapshotNode struct {
+	Id          string
+	Name        string
+	Executor    string
+	Pool        string
+	Labels      map[string]string
+	Taints      []v1.Taint
+	Allocatable schedulerobjects.ResourceList
+}
+
+// ExecutorSnapshot is a versioned view of the executors and their nodes as fetched at some point in time.
+// Snapshots are shared between consumers and must not be modified.
+type ExecutorSnapshot struct {
+	// Incremented each time executors are fetched; the first snapshot has version 1.
+	Version uint64
+	// Time at which the executors were fetched.
+	Created       time.Time
+	executors     []*schedulerobjects.Executor
+	nodesById     map[string]*ExecutorSnapshotNode
+	nodeIdsByPool map[string][]string
+}
+
+func newExecutorSnapshot(version uint64, created time.Time, executors []*schedulerobjects.Executor) *ExecutorSnapshot {
+	snapshot := &ExecutorSnapshot{
+		Version:       version,
+		Created:       created,
+		executors:     executors,
+		nodesById:     make(map[string]*ExecutorSnapshotNode),
+		nodeIdsByPool: make(map[string][]string),
+	}
+	for _, executor := range executors {
+		for _, node := range executor.Nodes {
+			snapshot.nodesById[node.Id] = &ExecutorSnapshotNode{
+				Id:          node.Id,
+				Name:        node.Name,
+				Executor:    executor.Id,
+				Pool:        executor.Pool,
+				Labels:      node.Labels,
+				Taints:      node.Taints,
+				Allocatable: node.TotalResources,
+			}
+			snapshot.nodeIdsByPool[executor.Pool] = append(snapshot.nodeIdsByPool[executor.Pool], node.Id)
+		}
+	}
+	return snapshot
+}
+
+// Executors returns the executors of the snapshot, including those that have since become stale.
+func (s *ExecutorSnapshot) Executors() []*schedulerobjects.Executor {
+	return s.executors
+}
+
+// Node returns the node with the given id, if any.
+func (s *ExecutorSnapshot) Node(nodeId string) (*ExecutorSnapshotNode, bool) {
+	node, ok := s.nodesById[nodeId]
+	return node, ok
+}
+
+// NodesInPool returns the nodes of all executors in pool, in the order their executors reported them.
+func (s *ExecutorSnapshot) NodesInPool(pool string) []*ExecutorSnapshotNode {
+	nodeIds := s.nodeIdsByPool[pool]
+	nodes := make([]*ExecutorSnapshotNode, len(nodeIds))
+	for i, nodeId := range nodeIds {
+		nodes[i] = s.nodesById[nodeId]
+	}
+	return nodes
+}
+
+// ExecutorSnapshotProvider fetches executors from the database once per refresh interval, or on demand,
+// and hands out the resulting snapshot to the submit checker, the pool assigners, and the scheduling algorithm,
+// such that they agree about which executors and nodes exist and the database is queried once per refresh
+// rather than once per consumer.
+type ExecutorSnapshotProvider struct {
+	executorRepository database.ExecutorRepository
+	refreshInterval    time.Duration
+	clock              clock.Clock
+	// Most recent snapshot, or nil if executors haven't been fetched yet.
+	snapshot *ExecutorSnapshot
+	// Called with each new snapshot.
+	subscribers []func(ctx *armadacontext.Context, snapshot *ExecutorSnapshot)
+	// Serialises refreshes, such that subscribers are called with snapshots in order of increasing version.
+	refreshMu sync.Mutex
+	mu        sync.Mutex
+}
+
+func NewExecutorSnapshotProvider(executorRepository database.ExecutorRepository, refreshInterval time.Duration) *ExecutorSnapshotProvider {
+	return &ExecutorSnapshotProvider{
+		executorRepository: executorRepository,
+		refreshInterval:    refreshInterval,
+		clock:              clock.RealClock{},
+	}
+}
+
+// Subscribe causes f to be called with each new snapshot once it's been fetched, before it's returned by Snapshot.
+// Must be called before the provider is used.
+func (p *ExecutorSnapshotProvider) Subscribe(f func(ctx *armadacontext.Context, snapshot *ExecutorSnapshot)) {
+	p.subscribers = append(p.subscribers, f)
+}
+
+// Run refreshes the snapshot immediately and then once per refresh interval, until ctx is cancelled.
+// If the refresh interval is zero, the snapshot is only refreshed on demand.
+func (p *ExecutorSnapshotProvider) Run(ctx *armadacontext.Context) error {
+	if _, err := p.Refresh(ctx); err != nil {
+		logging.WithStacktrace(ctx, err).Error("failed to refresh executor snapshot")
+	}
+	if p.refreshInterval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+	ticker := time.NewTicker(p.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := p.Refresh(ctx); err != nil {
+				logging.WithStacktrace(ctx, err).Error("failed to refresh executor snapshot")
+			}
+		}
+	}
+}
+
+// Refresh fetches executors from the database and publishes them as a new snapshot, which is returned.
+// If executors can't be fetched, an error is returned and the previous snapshot is kept.
+func (p *ExecutorSnapshotProvider) Refresh(ctx *armadacontext.Context) (*ExecutorSnapshot, error) {
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+	executors, err := p.executorRepository.GetExecutors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	var version uint64 = 1
+	if p.snapshot != nil {
+		version = p.snapshot.Version + 1
+	}
+	snapshot := newExecutorSnapshot(version, p.clock.Now(), executors)
+	p.mu.Unlock()
+	for _, f := range p.subscribers {
+		f(ctx, snapshot)
+	}
+	p.mu.Lock()
+	p.snapshot = snapshot
+	p.mu.Unlock()
+	return snapshot, nil
+}
+
+// Snapshot returns the snapshot ctx is pinned to, if any; see withExecutorSnapshot.
+// Otherwise, it returns the most recent snapshot, fetching executors first if they haven't been fetched yet.
+func (p *ExecutorSnapshotProvider) Snapshot(ctx *armadacontext.Context) (*ExecutorSnapshot, error) {
+	if snapshot := executorSnapshotFromContext(ctx); snapshot != nil {
+		return snapshot, nil
+	}
+	p.mu.Lock()
+	snapshot := p.snapshot
+	p.mu.Unlock()
+	if snapshot != nil {
+		return snapshot, nil
+	}
+	return p.Refresh(ctx)
+}
+
+func (p *ExecutorSnapshotProvider) Describe(desc chan<- *prometheus.Desc) {
+	desc <- executorSnapshotVersionDesc
+	desc <- executorSnapshotAgeDesc
+}
+
+func (p *ExecutorSnapshotProvider) Collect(metrics chan<- prometheus.Metric) {
+	p.mu.Lock()
+	snapshot := p.snapshot
+	p.mu.Unlock()
+	if snapshot == nil {
+		return
+	}
+	metrics <- prometheus.MustNewConstMetric(executorSnapshotVersionDesc, prometheus.GaugeValue, float64(snapshot.Version))
+	metrics <- prometheus.MustNewConstMetric(executorSnapshotAgeDesc, prometheus.GaugeValue, p.clock.Since(snapshot.Created).Seconds())
+}
+
+type executorSnapshotKey struct{}
+
+// withExecutorSnapshot returns a copy of ctx pinned to snapshot, such that all consumers of executors
+// using ctx, e.g., for the duration of a scheduling round, see the same snapshot even if a newer one is fetched meanwhile.
+func withExecutorSnapshot(ctx *armadacontext.Context, snapshot *ExecutorSnapshot) *armadacontext.Context {
+	return armadacontext.WithValue(ctx, executorSnapshotKey{}, snapshot)
+}
+
+// executorSnapshotFromContext returns the snapshot ctx is pinned to, or nil if there's none.
+func executorSnapshotFromContext(ctx *armadacontext.Context) *ExecutorSnapshot {
+	snapshot, _ := ctx.Value(executorSnapshotKey{}).(*ExecutorSnapshot)
+	return snapshot
+}
+
+// getExecutors returns the executors of the snapshot provided by snapshots together with its version if snapshots
+// is non-nil, and otherwise fetches executors from executorRepository, in which case the version returned is zero.
+func getExecutors(
+	ctx *armadacontext.Context,
+	snapshots *ExecutorSnapshotProvider,
+	executorRepository database.ExecutorRepository,
+) ([]*schedulerobjects.Executor, uint64, error) {
+	if snapshots == nil {
+		executors, err := executorRepository.GetExecutors(ctx)
+		return executors, 0, err
+	}
+	snapshot, err := snapshots.Snapshot(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return snapshot.Executors(), snapshot.Version, nil
+}
@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+// countingExecutorRepository is a testExecutorRepository counting the number of times executors are fetched.
+type countingExecutorRepository struct {
+	testExecutorRepository
+	numFetches int
+}
+
+func (r *countingExecutorRepository) GetExecutors(ctx *armadacontext.Context) ([]*schedulerobjects.Executor, error) {
+	r.numFetches++
+	return r.testExecutorRepository.GetExecutors(ctx)
+}
+
+func TestExecutorSnapshotProvider_Refresh(t *testing.T) {
+	ctx := armadacontext.Background()
+	executorRepository := &countingExecutorRepository{
+		testExecutorRepository: testExecutorRepository{
+			executors: []*schedulerobjects.Executor{testfixtures.Test1Node32CoreExecutor("executor1")},
+		},
+	}
+	provider := NewExecutorSnapshotProvider(executorRepository, time.Minute)
+	provider.clock = clock.NewFakeClock(testfixtures.BaseTime)
+
+	// Executors are fetched on demand the first time a snapshot is requested, but not thereafter.
+	snapshot, err := provider.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), snapshot.Version)
+	assert.Equal(t, testfixtures.BaseTime, snapshot.Created)
+	snapshot, err = provider.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), snapshot.Version)
+	assert.Equal(t, 1, executorRepository.numFetches)
+
+	// Nodes are indexed by id and pool.
+	node, ok := snapshot.Node(executorRepository.executors[0].Nodes[0].Id)
+	require.True(t, ok)
+	assert.Equal(t, "executor1-node", node.Name)
+	assert.Equal(t, "executor1", node.Executor)
+	assert.Equal(t, testfixtures.TestPool, node.Pool)
+	assert.Equal(t, executorRepository.executors[0].Nodes[0].TotalResources, node.Allocatable)
+	assert.Len(t, snapshot.NodesInPool(testfixtures.TestPool), 1)
+	assert.Empty(t, snapshot.NodesInPool("does-not-exist"))
+
+	// Each refresh creates a new version.
+	executorRepository.executors = append(executorRepository.executors, testfixtures.Test1Node32CoreExecutor("executor2"))
+	snapshot, err = provider.Refresh(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), snapshot.Version)
+	assert.Len(t, snapshot.Executors(), 2)
+	assert.Len(t, snapshot.NodesInPool(testfixtures.TestPool), 2)
+
+	// The previous snapshot is kept if executors can't be fetched.
+	executorRepository.shouldError = true
+	_, err = provider.Refresh(ctx)
+	assert.Error(t, err)
+	snapshot, err = provider.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), snapshot.Version)
+}
+
+func TestExecutorSnapshotProvider_PinnedSnapshot(t *testing.T) {
+	ctx := armadacontext.Background()
+	executorRepository := &testExecutorRepository{
+		executors: []*schedulerobjects.Executor{testfixtures.Test1Node32CoreExecutor("executor1")},
+	}
+	provider := NewExecutorSnapshotProvider(executorRepository, time.Minute)
+	snapshot, err := provider.Refresh(ctx)
+	require.NoError(t, err)
+	pinnedCtx := withExecutorSnapshot(ctx, snapshot)
+
+	_, err = provider.Refresh(ctx)
+	require.NoError(t, err)
+	pinned, err := provider.Snapshot(pinnedCtx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), pinned.Version)
+	latest, err := provider.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), latest.Version)
+}
+
+func TestExecutorSnapshotProvider_ConsumersObserveSameSnapshot(t *testing.T) {
+	ctx := armadacontext.Background()
+	executorRepository := &countingExecutorRepository{
+		testExecutorRepository: testExecutorRepository{
+			executors: []*schedulerobjects.Executor{testfixtures.Test1Node32CoreExecutor("executor1")},
+		},
+	}
+	provider := NewExecutorSnapshotProvider(executorRepository, time.Minute)
+	schedulingConfig := testfixtures.TestSchedulingConfig()
+
+	// The consumers are given a repository that fails the test if used, since they should only use the provider.
+	ctrl := gomock.NewController(t)
+	unusedExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	queueRepository := schedulermocks.NewMockQueueRepository(ctrl)
+	queueRepository.EXPECT().GetAllQueues().Return([]*database.Queue{testfixtures.TestDbQueue()}, nil).AnyTimes()
+
+	submitChecker := NewSubmitChecker(30*time.Minute, schedulingConfig, unusedExecutorRepository)
+	submitChecker.EnableExecutorSnapshots(provider)
+	require.NoError(t, submitChecker.Run(ctx))
+	capacityPoolAssigner, err := NewPoolAssigner(schedulingConfig.ExecutorTimeout, schedulingConfig, unusedExecutorRepository)
+	require.NoError(t, err)
+	capacityPoolAssigner.EnableExecutorSnapshots(provider)
+	capacityPoolAssigner.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	metricsPoolAssigner, err := NewPoolAssigner(schedulingConfig.ExecutorTimeout, schedulingConfig, unusedExecutorRepository)
+	require.NoError(t, err)
+	metricsPoolAssigner.EnableExecutorSnapshots(provider)
+	metricsPoolAssigner.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	schedulingAlgo, err := NewFairSchedulingAlgo(schedulingConfig, 0, unusedExecutorRepository, queueRepository, nil)
+	require.NoError(t, err)
+	schedulingAlgo.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	schedulingAlgo.EnableExecutorSnapshots(provider)
+	schedulingAlgo.EnableCapacitySummary(capacityPoolAssigner)
+
+	// runCycle runs a scheduling round and refreshes the metrics pool assigner, as the scheduler would within a cycle,
+	// and asserts that each consumer observed the expected snapshot version.
+	runCycle := func(expectedVersion uint64) {
+		jobDb := testfixtures.NewJobDb()
+		txn := jobDb.WriteTxn()
+		require.NoError(t, txn.Upsert(testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 1)))
+		_, err := schedulingAlgo.Schedule(ctx, txn)
+		require.NoError(t, err)
+		require.NoError(t, metricsPoolAssigner.Refresh(ctx))
+
+		assert.Equal(t, expectedVersion, schedulingAlgo.executorSnapshotVersion)
+		assert.Equal(t, expectedVersion, capacityPoolAssigner.executorSnapshotVersion)
+		assert.Equal(t, expectedVersion, metricsPoolAssigner.executorSnapshotVersion)
+		assert.Equal(t, expectedVersion, submitChecker.executorSnapshotVersion)
+	}
+
+	_, err = provider.Refresh(ctx)
+	require.NoError(t, err)
+	runCycle(1)
+	assert.Contains(t, submitChecker.executorById, "executor1")
+	// Executors were fetched once for all consumers.
+	assert.Equal(t, 1, executorRepository.numFetches)
+
+	// An on-demand refresh propagates to all consumers.
+	executorRepository.executors = append(executorRepository.executors, testfixtures.Test1Node32CoreExecutor("executor2"))
+	_, err = provider.Refresh(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), submitChecker.executorSnapshotVersion)
+	assert.Contains(t, submitChecker.executorById, "executor2")
+	runCycle(2)
+	assert.Len(t, metricsPoolAssigner.poolByExecutorId, 2)
+	assert.Equal(t, 2, executorRepository.numFetches)
+}
+
+func TestExecutorSnapshotProvider_Metrics(t *testing.T) {
+	ctx := armadacontext.Background()
+	provider := NewExecutorSnapshotProvider(&testExecutorRepository{}, time.Minute)
+	fakeClock := clock.NewFakeClock(testfixtures.BaseTime)
+	provider.clock = fakeClock
+	assert.Empty(t, collectExecutorSnapshotMetrics(provider))
+
+	_, err := provider.Refresh(ctx)
+	require.NoError(t, err)
+	_, err = provider.Refresh(ctx)
+	require.NoError(t, err)
+	fakeClock.Step(30 * time.Second)
+	assert.Equal(
+		t,
+		[]prometheus.Metric{
+			prometheus.MustNewConstMetric(executorSnapshotVersionDesc, prometheus.GaugeValue, 2),
+			prometheus.MustNewConstMetric(executorSnapshotAgeDesc, prometheus.GaugeValue, 30),
+		},
+		collectExecutorSnapshotMetrics(provider),
+	)
+}
+
+func collectExecutorSnapshotMetrics(provider *ExecutorSnapshotProvider) []prometheus.Metric {
+	metricChan := make(chan prometheus.Metric, 1000)
+	provider.Collect(metricChan)
+	close(metricChan)
+	actual := make([]prometheus.Metric, 0)
+	for m := range metricChan {
+		actual = append(actual, m)
+	}
+	return actual
+}
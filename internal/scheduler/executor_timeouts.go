@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// ExecutorTimeouts determines how long to wait for a heartbeat from each executor before considering it stale.
+// The timeout of an executor is, in order of precedence:
+// 1. Any override set via the SetExecutorTimeout admin endpoint.
+// 2. The timeout provided by the executor when it last requested leases.
+// 3. The global default.
+// Admin overrides are held in memory and are hence lost on restart or failover.
+type ExecutorTimeouts struct {
+	defaultTimeout       time.Duration
+	overrideByExecutorId map[string]time.Duration
+	mu                   sync.Mutex
+}
+
+func NewExecutorTimeouts(defaultTimeout time.Duration) *ExecutorTimeouts {
+	return &ExecutorTimeouts{
+		defaultTimeout:       defaultTimeout,
+		overrideByExecutorId: make(map[string]time.Duration),
+	}
+}
+
+// TimeoutForExecutor returns the timeout in effect for the provided executor.
+func (t *ExecutorTimeouts) TimeoutForExecutor(executor *schedulerobjects.Executor) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if timeout, ok := t.overrideByExecutorId[executor.Id]; ok {
+		return timeout
+	}
+	if executor.Timeout > 0 {
+		return executor.Timeout
+	}
+	return t.defaultTimeout
+}
+
+// SetExecutorTimeout is a gRPC endpoint for overriding the timeout of an executor.
+// Setting a zero timeout removes any existing override.
+func (t *ExecutorTimeouts) SetExecutorTimeout(_ context.Context, request *schedulerobjects.SetExecutorTimeoutRequest) (*schedulerobjects.SetExecutorTimeoutResponse, error) {
+	executorId := strings.TrimSpace(request.GetExecutorId())
+	if executorId == "" {
+		return nil, &armadaerrors.ErrInvalidArgument{
+			Name:    "executorId",
+			Value:   request.GetExecutorId(),
+			Message: "executorId must not be empty",
+		}
+	}
+	if request.Timeout < 0 {
+		return nil, &armadaerrors.ErrInvalidArgument{
+			Name:    "timeout",
+			Value:   request.Timeout,
+			Message: "timeout must not be negative",
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	response := &schedulerobjects.SetExecutorTimeoutResponse{ExecutorId: executorId}
+	if request.Timeout == 0 {
+		delete(t.overrideByExecutorId, executorId)
+	} else {
+		t.overrideByExecutorId[executorId] = request.Timeout
+		response.Timeout = request.Timeout
+	}
+	return response, nil
+}
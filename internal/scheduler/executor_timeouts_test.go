@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestExecutorTimeouts_TimeoutForExecutor(t *testing.T) {
+	executorTimeouts := NewExecutorTimeouts(time.Minute)
+	withoutTimeout := &schedulerobjects.Executor{Id: "withoutTimeout"}
+	withTimeout := &schedulerobjects.Executor{Id: "withTimeout", Timeout: time.Hour}
+
+	assert.Equal(t, time.Minute, executorTimeouts.TimeoutForExecutor(withoutTimeout))
+	assert.Equal(t, time.Hour, executorTimeouts.TimeoutForExecutor(withTimeout))
+
+	// Admin overrides take precedence over the timeout provided by the executor.
+	response, err := executorTimeouts.SetExecutorTimeout(
+		context.Background(),
+		&schedulerobjects.SetExecutorTimeoutRequest{ExecutorId: "withTimeout", Timeout: 2 * time.Hour},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, &schedulerobjects.SetExecutorTimeoutResponse{ExecutorId: "withTimeout", Timeout: 2 * time.Hour}, response)
+	assert.Equal(t, 2*time.Hour, executorTimeouts.TimeoutForExecutor(withTimeout))
+	assert.Equal(t, time.Minute, executorTimeouts.TimeoutForExecutor(withoutTimeout))
+
+	// Setting a zero timeout removes the override.
+	response, err = executorTimeouts.SetExecutorTimeout(
+		context.Background(),
+		&schedulerobjects.SetExecutorTimeoutRequest{ExecutorId: "withTimeout"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, &schedulerobjects.SetExecutorTimeoutResponse{ExecutorId: "withTimeout"}, response)
+	assert.Equal(t, time.Hour, executorTimeouts.TimeoutForExecutor(withTimeout))
+}
+
+func TestExecutorTimeouts_SetExecutorTimeoutInvalidRequests(t *testing.T) {
+	executorTimeouts := NewExecutorTimeouts(time.Minute)
+	_, err := executorTimeouts.SetExecutorTimeout(
+		context.Background(),
+		&schedulerobjects.SetExecutorTimeoutRequest{ExecutorId: " ", Timeout: time.Hour},
+	)
+	assert.Error(t, err)
+	_, err = executorTimeouts.SetExecutorTimeout(
+		context.Background(),
+		&schedulerobjects.SetExecutorTimeoutRequest{ExecutorId: "executor", Timeout: -time.Hour},
+	)
+	assert.Error(t, err)
+}
+
+func TestScheduler_ExpiresJobsAccordingToPerExecutorTimeout(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+
+	// Both executors last heartbeat 10 minutes ago, which is longer than the timeout of the cloud executor
+	// but shorter than that of the on-prem executor.
+	heartbeatTime := testClock.Now().Add(-10 * time.Minute)
+	executorRepo := &testExecutorRepository{
+		executors: []*schedulerobjects.Executor{
+			{Id: "cloud", LastUpdateTime: heartbeatTime, Timeout: 5 * time.Minute},
+			{Id: "on-prem", LastUpdateTime: heartbeatTime, Timeout: 30 * time.Minute},
+		},
+	}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&testJobRepository{},
+		executorRepo,
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+
+	jobByExecutor := map[string]*jobdb.Job{
+		"cloud":   jobLeasedOnExecutor("cloud"),
+		"on-prem": jobLeasedOnExecutor("on-prem"),
+	}
+	txn := sched.jobDb.WriteTxn()
+	defer txn.Abort()
+	for _, job := range jobByExecutor {
+		require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	}
+
+	events, err := sched.expireJobsIfNecessary(ctx, txn)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	cloudJob := txn.GetById(jobByExecutor["cloud"].Id())
+	assert.True(t, cloudJob.Failed())
+	onPremJob := txn.GetById(jobByExecutor["on-prem"].Id())
+	assert.False(t, onPremJob.Failed())
+
+	// Staleness metrics reflect the timeout of each executor.
+	assert.Equal(t, 1.0, testutil.ToFloat64(schedulerMetrics.staleExecutors.WithLabelValues("cloud")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(schedulerMetrics.staleExecutors.WithLabelValues("on-prem")))
+	assert.Equal(t, (5 * time.Minute).Seconds(), testutil.ToFloat64(schedulerMetrics.executorTimeout.WithLabelValues("cloud")))
+	assert.Equal(t, (30 * time.Minute).Seconds(), testutil.ToFloat64(schedulerMetrics.executorTimeout.WithLabelValues("on-prem")))
+}
+
+func jobLeasedOnExecutor(executorId string) *jobdb.Job {
+	return testfixtures.JobDb.NewJob(
+		util.NewULID(),
+		"testJobset",
+		"testQueue",
+		uint32(10),
+		schedulingInfo,
+		false,
+		1,
+		false,
+		false,
+		false,
+		1,
+	).WithNewRun(executorId, executorId+"-node", "node", 5, testfixtures.BaseTime)
+}
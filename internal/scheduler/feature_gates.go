@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/metrics"
+)
+
+const (
+	// FeatureGateBackoffV2 causes jobs retried with backoff to be backed off for twice as long with each attempt,
+	// up to maxBackoffV2Multiplier times the backoff of the run error classification rule.
+	FeatureGateBackoffV2 = "backoffV2"
+)
+
+// knownFeatureGates are the feature gates that may be enabled per queue.
+var knownFeatureGates = []string{FeatureGateBackoffV2}
+
+// maxBackoffV2Multiplier bounds the backoff of jobs of queues with FeatureGateBackoffV2 enabled.
+const maxBackoffV2Multiplier = 32
+
+var queuesWithFeatureGateDesc = prometheus.NewDesc(
+	metrics.MetricPrefix+"scheduler_queues_with_feature_gate",
+	"Number of queues with a feature gate enabled.",
+	[]string{"gate"},
+	nil,
+)
+
+// FeatureGate records which feature gates are enabled for each queue, such that new scheduling behaviour can be
+// enabled for specific queues before it's rolled out to all of them. A nil FeatureGate has no gates enabled.
+type FeatureGate struct {
+	gatesByQueue map[string]map[string]bool
+}
+
+// NewFeatureGate returns a FeatureGate with the gates of gatesByQueue enabled. Gates not in knownFeatureGates are
+// ignored, with a warning logged, such that gates can be removed from the scheduler before they're removed from config.
+func NewFeatureGate(ctx *armadacontext.Context, gatesByQueue map[string][]string) *FeatureGate {
+	g := &FeatureGate{gatesByQueue: make(map[string]map[string]bool, len(gatesByQueue))}
+	for queue, gates := range gatesByQueue {
+		for _, gate := range gates {
+			if !slices.Contains(knownFeatureGates, gate) {
+				ctx.Warnf("ignoring unknown feature gate %s enabled for queue %s", gate, queue)
+				continue
+			}
+			if g.gatesByQueue[queue] == nil {
+				g.gatesByQueue[queue] = make(map[string]bool)
+			}
+			g.gatesByQueue[queue][gate] = true
+		}
+	}
+	return g
+}
+
+// Enabled returns true if gate is enabled for queue.
+func (g *FeatureGate) Enabled(queue, gate string) bool {
+	if g == nil {
+		return false
+	}
+	return g.gatesByQueue[queue][gate]
+}
+
+// EnabledGates returns the gates enabled for queue in lexicographical order.
+func (g *FeatureGate) EnabledGates(queue string) []string {
+	if g == nil || len(g.gatesByQueue[queue]) == 0 {
+		return nil
+	}
+	gates := maps.Keys(g.gatesByQueue[queue])
+	slices.Sort(gates)
+	return gates
+}
+
+// Backoff returns how long a job of queue retried after its numAttempts-th attempt is backed off for,
+// given the backoff of the run error classification rule that matched its error.
+func (g *FeatureGate) Backoff(queue string, numAttempts uint, backoff time.Duration) time.Duration {
+	if !g.Enabled(queue, FeatureGateBackoffV2) {
+		return backoff
+	}
+	multiplier := time.Duration(1)
+	for i := uint(1); i < numAttempts && multiplier < maxBackoffV2Multiplier; i++ {
+		multiplier *= 2
+	}
+	return backoff * multiplier
+}
+
+func (g *FeatureGate) Describe(desc chan<- *prometheus.Desc) {
+	desc <- queuesWithFeatureGateDesc
+}
+
+func (g *FeatureGate) Collect(metrics chan<- prometheus.Metric) {
+	for _, gate := range knownFeatureGates {
+		numQueues := 0
+		for _, gates := range g.gatesByQueue {
+			if gates[gate] {
+				numQueues++
+			}
+		}
+		metrics <- prometheus.MustNewConstMetric(queuesWithFeatureGateDesc, prometheus.GaugeValue, float64(numQueues), gate)
+	}
+}
@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestFeatureGate(t *testing.T) {
+	gates := NewFeatureGate(armadacontext.Background(), map[string][]string{
+		"gated-queue":   {"notAGate", FeatureGateBackoffV2},
+		"unknown-queue": {"notAGate"},
+	})
+
+	assert.True(t, gates.Enabled("gated-queue", FeatureGateBackoffV2))
+	assert.False(t, gates.Enabled("gated-queue", "notAGate"))
+	assert.False(t, gates.Enabled("ungated-queue", FeatureGateBackoffV2))
+	assert.Equal(t, []string{FeatureGateBackoffV2}, gates.EnabledGates("gated-queue"))
+	assert.Empty(t, gates.EnabledGates("unknown-queue"))
+	assert.Empty(t, gates.EnabledGates("ungated-queue"))
+
+	// Queues with only unknown gates don't count towards any gate.
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(gates))
+	assert.Equal(t, 1.0, testutil.ToFloat64(gates))
+
+	var nilGates *FeatureGate
+	assert.False(t, nilGates.Enabled("gated-queue", FeatureGateBackoffV2))
+	assert.Empty(t, nilGates.EnabledGates("gated-queue"))
+}
+
+func TestFeatureGate_Backoff(t *testing.T) {
+	gates := NewFeatureGate(armadacontext.Background(), map[string][]string{"gated-queue": {FeatureGateBackoffV2}})
+	tests := map[string]struct {
+		queue           string
+		numAttempts     uint
+		expectedBackoff time.Duration
+	}{
+		"ungated queue":               {queue: "ungated-queue", numAttempts: 3, expectedBackoff: time.Minute},
+		"gated queue, no attempts":    {queue: "gated-queue", numAttempts: 0, expectedBackoff: time.Minute},
+		"gated queue, first attempt":  {queue: "gated-queue", numAttempts: 1, expectedBackoff: time.Minute},
+		"gated queue, second attempt": {queue: "gated-queue", numAttempts: 2, expectedBackoff: 2 * time.Minute},
+		"gated queue, third attempt":  {queue: "gated-queue", numAttempts: 3, expectedBackoff: 4 * time.Minute},
+		"gated queue, many attempts":  {queue: "gated-queue", numAttempts: 100, expectedBackoff: maxBackoffV2Multiplier * time.Minute},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedBackoff, gates.Backoff(tc.queue, tc.numAttempts, time.Minute))
+		})
+	}
+}
+
+func TestFairSchedulingAlgo_FeatureGatesRecordedInQueueSchedulingContexts(t *testing.T) {
+	ctx := armadacontext.Background()
+	nodes := testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)
+	nodes[0].Executor = "test-executor"
+	executors := []*schedulerobjects.Executor{{
+		Id:             "test-executor",
+		Pool:           "test-pool",
+		Nodes:          nodes,
+		LastUpdateTime: testfixtures.BaseTime,
+	}}
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return(executors, nil).AnyTimes()
+	mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+	mockQueueRepo.EXPECT().GetAllQueues().Return(
+		[]*database.Queue{{Name: "gated-queue", Weight: 1}, {Name: "ungated-queue", Weight: 1}},
+		nil,
+	).AnyTimes()
+	algo, err := NewFairSchedulingAlgo(testfixtures.TestSchedulingConfig(), 0, mockExecutorRepo, mockQueueRepo, nil)
+	require.NoError(t, err)
+	algo.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	algo.EnableFeatureGates(NewFeatureGate(ctx, map[string][]string{"gated-queue": {FeatureGateBackoffV2}}))
+
+	txn := testfixtures.NewJobDb().WriteTxn()
+	require.NoError(t, txn.Upsert(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("gated-queue", testfixtures.PriorityClass0, 1))))
+	require.NoError(t, txn.Upsert(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("ungated-queue", testfixtures.PriorityClass0, 1))))
+	result, err := algo.Schedule(ctx, txn)
+	require.NoError(t, err)
+	require.Len(t, result.SchedulingContexts, 1)
+	qctxs := result.SchedulingContexts[0].QueueSchedulingContexts
+	assert.Equal(t, []string{FeatureGateBackoffV2}, qctxs["gated-queue"].FeatureGates)
+	assert.Empty(t, qctxs["ungated-queue"].FeatureGates)
+}
+
+func TestScheduler_FeatureGateBackoffV2(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	testClock := clock.NewFakeClock(time.Now())
+	jobRepo := &testJobRepository{errors: make(map[uuid.UUID]*armadaevents.Error)}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{
+			executors: []*schedulerobjects.Executor{{Id: "testExecutor", LastUpdateTime: testClock.Now().Add(24 * time.Hour)}},
+		},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		5,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+	classifier, err := NewRunErrorClassifier(testRunErrorClassificationRules)
+	require.NoError(t, err)
+	sched.EnableRunErrorClassification(classifier)
+	sched.EnableFeatureGates(NewFeatureGate(ctx, map[string][]string{"gated-queue": {FeatureGateBackoffV2}}))
+
+	// Each job has been attempted twice before its current run fails with an error retried with backoff.
+	jobIdByQueue := make(map[string]string)
+	txn := sched.jobDb.WriteTxn()
+	for i, queue := range []string{"gated-queue", "ungated-queue"} {
+		job := testfixtures.JobDb.NewJob(
+			util.NewULID(), "testJobset", queue, uint32(10), schedulingInfo, false, 2, false, false, false, 1,
+		).WithQueued(false)
+		for j := 0; j < 2; j++ {
+			job = job.WithNewRun("testExecutor", "test-node", "node", 5, testfixtures.BaseTime.Add(time.Duration(j)*time.Minute))
+			job = job.WithUpdatedRun(job.LatestRun().WithAttempted(true).WithFailed(true))
+		}
+		job = job.WithNewRun("testExecutor", "test-node", "node", 5, testfixtures.BaseTime.Add(time.Hour))
+		require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+		jobIdByQueue[queue] = job.Id()
+		jobRepo.updatedRuns = append(jobRepo.updatedRuns, database.Run{
+			RunID:    job.LatestRun().Id(),
+			JobID:    job.Id(),
+			JobSet:   "testJobset",
+			Executor: "testExecutor",
+			Failed:   true,
+			Serial:   int64(i + 1),
+		})
+		jobRepo.errors[job.LatestRun().Id()] = imagePullJobRunError
+	}
+	txn.Commit()
+
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+
+	// The backoff of the job of the gated queue is doubled for its second attempt; that of the other job isn't.
+	expectedBackoffByQueue := map[string]time.Duration{"gated-queue": 20 * time.Minute, "ungated-queue": 10 * time.Minute}
+	for queue, expectedBackoff := range expectedBackoffByQueue {
+		job := sched.jobDb.ReadTxn().GetById(jobIdByQueue[queue])
+		require.NotNil(t, job)
+		assert.True(t, job.Queued(), queue)
+		backedOffUntil, backedOff := job.BackedOffUntil()
+		assert.True(t, backedOff, queue)
+		assert.True(t, testClock.Now().Add(expectedBackoff).Equal(backedOffUntil), queue)
+	}
+}
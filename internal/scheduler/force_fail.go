@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/armada/permissions"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// forwardedPrincipalMetadataKey is the gRPC metadata key used to pass the name of the principal
+// that made a ForceFailJob or RebuildJobDb request to the leader when the request is proxied by another replica.
+const forwardedPrincipalMetadataKey = "armada-force-fail-principal"
+
+// JobForceFailer implements the ForceFailJob admin endpoint, which allows operators to fail jobs
+// no automated path resolves, e.g., jobs with corrupt scheduling info or zombie runs.
+// Requests are recorded in memory and applied by the next scheduling cycle; see FailJobs.
+type JobForceFailer struct {
+	jobDb             *jobdb.JobDb
+	permissionChecker authorization.PermissionChecker
+	// Requests not yet applied by a scheduling cycle, indexed by job id.
+	pendingByJobId map[string]*forceFailRequest
+	mu             sync.Mutex
+}
+
+type forceFailRequest struct {
+	reason    string
+	principal string
+}
+
+func NewJobForceFailer(jobDb *jobdb.JobDb, permissionChecker authorization.PermissionChecker) *JobForceFailer {
+	return &JobForceFailer{
+		jobDb:             jobDb,
+		permissionChecker: permissionChecker,
+		pendingByJobId:    make(map[string]*forceFailRequest),
+	}
+}
+
+// ForceFailJob is a gRPC endpoint for failing a job.
+// Requests for jobs already pending being failed return the original request without error,
+// whereas requests for jobs already in a terminal state are refused.
+func (f *JobForceFailer) ForceFailJob(ctx context.Context, request *schedulerobjects.ForceFailJobRequest) (*schedulerobjects.ForceFailJobResponse, error) {
+	principal, err := authorizeForceFailJob(ctx, f.permissionChecker)
+	if err != nil {
+		return nil, err
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// Proxied by another replica on behalf of the principal that made the original request.
+		if forwarded := md.Get(forwardedPrincipalMetadataKey); len(forwarded) > 0 && forwarded[0] != "" {
+			principal = fmt.Sprintf("%s (via %s)", forwarded[0], principal)
+		}
+	}
+	jobId := strings.TrimSpace(request.GetJobId())
+	if _, err := ulid.Parse(jobId); err != nil {
+		return nil, &armadaerrors.ErrInvalidArgument{
+			Name:    "jobId",
+			Value:   request.GetJobId(),
+			Message: fmt.Sprintf("%s is not a valid jobId", request.GetJobId()),
+		}
+	}
+	reason := strings.TrimSpace(request.GetReason())
+	if reason == "" {
+		return nil, &armadaerrors.ErrInvalidArgument{
+			Name:    "reason",
+			Value:   request.GetReason(),
+			Message: "a reason must be provided",
+		}
+	}
+
+	job := f.jobDb.ReadTxn().GetById(jobId)
+	if job == nil {
+		return nil, &armadaerrors.ErrNotFound{
+			Type:    "job",
+			Value:   jobId,
+			Message: "job is not active in the scheduler",
+		}
+	}
+	if job.InTerminalState() {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is already %s", jobId, strings.ToLower(jobDbState(job)))
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pending, ok := f.pendingByJobId[jobId]
+	if !ok {
+		pending = &forceFailRequest{reason: reason, principal: principal}
+		f.pendingByJobId[jobId] = pending
+		log.WithFields(log.Fields{
+			"jobId":     jobId,
+			"queue":     job.Queue(),
+			"jobSet":    job.Jobset(),
+			"principal": principal,
+			"reason":    reason,
+		}).Warn("job will be force-failed by the next scheduling cycle")
+	}
+	return &schedulerobjects.ForceFailJobResponse{
+		JobId:  jobId,
+		Queue:  job.Queue(),
+		JobSet: job.Jobset(),
+		State:  jobDbState(job),
+		Reason: pending.reason,
+	}, nil
+}
+
+// FailJobs fails all jobs for which a request is pending and returns the events to publish,
+// along with the ids of the jobs whose requests have been handled.
+// These ids should be passed to Resolve once the txn has been committed.
+// Requests for jobs no longer in the jobDb or already in a terminal state are dropped.
+func (f *JobForceFailer) FailJobs(ctx *armadacontext.Context, txn *jobdb.Txn, now time.Time) ([]*armadaevents.EventSequence, []string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.pendingByJobId) == 0 {
+		return nil, nil, nil
+	}
+	jobIds := make([]string, 0, len(f.pendingByJobId))
+	jobsToUpdate := make([]*jobdb.Job, 0, len(f.pendingByJobId))
+	events := make([]*armadaevents.EventSequence, 0, len(f.pendingByJobId))
+	for jobId, request := range f.pendingByJobId {
+		jobIds = append(jobIds, jobId)
+		job := txn.GetById(jobId)
+		if job == nil || job.InTerminalState() {
+			ctx.Infof("not force-failing job %s as requested by %s, since it's no longer active", jobId, request.principal)
+			continue
+		}
+		protoJobId, err := armadaevents.ProtoUuidFromUlidString(jobId)
+		if err != nil {
+			return nil, nil, err
+		}
+		forceFailedError := &armadaevents.Error{
+			Terminal: true,
+			Reason: &armadaevents.Error_JobForceFailed{
+				JobForceFailed: &armadaevents.JobForceFailed{
+					Reason:    request.reason,
+					Principal: request.principal,
+					Message:   fmt.Sprintf("job failed by %s: %s", request.principal, request.reason),
+				},
+			},
+		}
+		es := &armadaevents.EventSequence{
+			Queue:      job.Queue(),
+			JobSetName: job.Jobset(),
+		}
+		// Failing the active run causes its executor to cancel it once the failure has been written to the database.
+		if run := job.LatestRun(); run != nil && !run.InTerminalState() {
+			ctx.Warnf("force-failing job %s and its run %s on executor %s as requested by %s", jobId, run.Id(), run.Executor(), request.principal)
+			job = job.WithUpdatedRun(run.WithFailed(true))
+			es.Events = append(es.Events, &armadaevents.EventSequence_Event{
+				Created: &now,
+				Event: &armadaevents.EventSequence_Event_JobRunErrors{
+					JobRunErrors: &armadaevents.JobRunErrors{
+						RunId:  armadaevents.ProtoUuidFromUuid(run.Id()),
+						JobId:  protoJobId,
+						Errors: []*armadaevents.Error{forceFailedError},
+					},
+				},
+			})
+		} else {
+			ctx.Warnf("force-failing job %s as requested by %s", jobId, request.principal)
+		}
+		es.Events = append(es.Events, &armadaevents.EventSequence_Event{
+			Created: &now,
+			Event: &armadaevents.EventSequence_Event_JobErrors{
+				JobErrors: &armadaevents.JobErrors{
+					JobId:  protoJobId,
+					Errors: []*armadaevents.Error{forceFailedError},
+				},
+			},
+		})
+		jobsToUpdate = append(jobsToUpdate, job.WithQueued(false).WithFailed(true))
+		events = append(events, es)
+	}
+	if err := txn.Upsert(jobsToUpdate); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return events, jobIds, nil
+}
+
+// Resolve removes the requests for the provided jobs, which should have been returned by FailJobs.
+// If the cycle that called FailJobs didn't commit, Resolve should not be called, such that the requests are retried.
+func (f *JobForceFailer) Resolve(jobIds []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, jobId := range jobIds {
+		delete(f.pendingByJobId, jobId)
+	}
+}
+
+// authorizeForceFailJob returns the name of the principal in ctx,
+// or an error if that principal doesn't have permission to force-fail jobs.
+func authorizeForceFailJob(ctx context.Context, permissionChecker authorization.PermissionChecker) (string, error) {
+	principal := authorization.GetPrincipal(ctx)
+	if permissionChecker == nil || !permissionChecker.UserHasPermission(ctx, permissions.ForceFailJobs) {
+		return "", &armadaerrors.ErrUnauthorized{
+			Principal:  principal.GetName(),
+			Permission: string(permissions.ForceFailJobs),
+			Action:     "force-fail job",
+			Message:    fmt.Sprintf("user %s does not have permission to force-fail jobs", principal.GetName()),
+		}
+	}
+	return principal.GetName(), nil
+}
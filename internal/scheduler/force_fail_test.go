@@ -0,0 +1,231 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/armada/permissions"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/common/auth/permission"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestJobForceFailer_FailJobs(t *testing.T) {
+	jobs := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 2))
+	queuedJob := jobs[0]
+	leasedJob := jobs[1].WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+	tests := map[string]struct {
+		job               *jobdb.Job
+		expectedState     string
+		expectedRunErrors bool
+	}{
+		"queued": {
+			job:           queuedJob,
+			expectedState: "Queued",
+		},
+		"leased": {
+			job:               leasedJob,
+			expectedState:     "Leased",
+			expectedRunErrors: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobDb := testfixtures.NewJobDb()
+			txn := jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{tc.job}))
+			txn.Commit()
+			failer := NewJobForceFailer(jobDb, testForceFailPermissionChecker())
+
+			response, err := failer.ForceFailJob(forceFailContext("alice"), &schedulerobjects.ForceFailJobRequest{JobId: tc.job.Id(), Reason: "zombie run"})
+			require.NoError(t, err)
+			expectedResponse := &schedulerobjects.ForceFailJobResponse{
+				JobId:  tc.job.Id(),
+				Queue:  "A",
+				JobSet: tc.job.Jobset(),
+				State:  tc.expectedState,
+				Reason: "zombie run",
+			}
+			assert.Equal(t, expectedResponse, response)
+
+			// Repeated requests are idempotent and keep the original reason and principal.
+			response, err = failer.ForceFailJob(forceFailContext("bob"), &schedulerobjects.ForceFailJobRequest{JobId: tc.job.Id(), Reason: "something else"})
+			require.NoError(t, err)
+			assert.Equal(t, expectedResponse, response)
+
+			// The job isn't failed until the next cycle.
+			assert.False(t, jobDb.ReadTxn().GetById(tc.job.Id()).Failed())
+
+			ctx := armadacontext.Background()
+			txn = jobDb.WriteTxn()
+			events, jobIds, err := failer.FailJobs(ctx, txn, time.Now())
+			require.NoError(t, err)
+			txn.Commit()
+			failer.Resolve(jobIds)
+			assert.Equal(t, []string{tc.job.Id()}, jobIds)
+
+			job := jobDb.ReadTxn().GetById(tc.job.Id())
+			assert.True(t, job.Failed())
+			assert.False(t, job.Queued())
+			if tc.expectedRunErrors {
+				assert.True(t, job.LatestRun().Failed())
+			}
+
+			require.Len(t, events, 1)
+			var jobErrors *armadaevents.JobErrors
+			var jobRunErrors *armadaevents.JobRunErrors
+			for _, event := range events[0].Events {
+				if e := event.GetJobErrors(); e != nil {
+					jobErrors = e
+				}
+				if e := event.GetJobRunErrors(); e != nil {
+					jobRunErrors = e
+				}
+			}
+			require.NotNil(t, jobErrors)
+			require.Len(t, jobErrors.Errors, 1)
+			assert.True(t, jobErrors.Errors[0].Terminal)
+			forceFailed := jobErrors.Errors[0].GetJobForceFailed()
+			require.NotNil(t, forceFailed)
+			assert.Equal(t, "zombie run", forceFailed.Reason)
+			assert.Equal(t, "alice", forceFailed.Principal)
+			if tc.expectedRunErrors {
+				require.NotNil(t, jobRunErrors)
+				assert.Equal(t, armadaevents.ProtoUuidFromUuid(tc.job.LatestRun().Id()), jobRunErrors.RunId)
+			} else {
+				assert.Nil(t, jobRunErrors)
+			}
+
+			// Requests are only applied once.
+			txn = jobDb.WriteTxn()
+			events, jobIds, err = failer.FailJobs(ctx, txn, time.Now())
+			require.NoError(t, err)
+			txn.Abort()
+			assert.Empty(t, events)
+			assert.Empty(t, jobIds)
+
+			// Now the job is terminal, further requests are refused.
+			_, err = failer.ForceFailJob(forceFailContext("alice"), &schedulerobjects.ForceFailJobRequest{JobId: tc.job.Id(), Reason: "zombie run"})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+	}
+}
+
+func TestJobForceFailer_TerminalJob(t *testing.T) {
+	job := testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1)[0].WithQueued(false).WithSucceeded(true)
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	txn.Commit()
+	failer := NewJobForceFailer(jobDb, testForceFailPermissionChecker())
+
+	_, err := failer.ForceFailJob(forceFailContext("alice"), &schedulerobjects.ForceFailJobRequest{JobId: job.Id(), Reason: "zombie run"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	txn = jobDb.WriteTxn()
+	defer txn.Abort()
+	events, jobIds, err := failer.FailJobs(armadacontext.Background(), txn, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Empty(t, jobIds)
+}
+
+func TestJobForceFailer_JobFailedBeforeCycle(t *testing.T) {
+	job := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0]
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	txn.Commit()
+	failer := NewJobForceFailer(jobDb, testForceFailPermissionChecker())
+	_, err := failer.ForceFailJob(forceFailContext("alice"), &schedulerobjects.ForceFailJobRequest{JobId: job.Id(), Reason: "zombie run"})
+	require.NoError(t, err)
+
+	// The job reaches a terminal state by other means before the request is applied.
+	txn = jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job.WithQueued(false).WithCancelled(true)}))
+	events, jobIds, err := failer.FailJobs(armadacontext.Background(), txn, time.Now())
+	require.NoError(t, err)
+	txn.Commit()
+	failer.Resolve(jobIds)
+	assert.Empty(t, events)
+	assert.Equal(t, []string{job.Id()}, jobIds)
+	assert.True(t, jobDb.ReadTxn().GetById(job.Id()).Cancelled())
+}
+
+func TestJobForceFailer_InvalidRequests(t *testing.T) {
+	job := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0]
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	txn.Commit()
+	failer := NewJobForceFailer(jobDb, testForceFailPermissionChecker())
+
+	_, err := failer.ForceFailJob(forceFailContext("mallory"), &schedulerobjects.ForceFailJobRequest{JobId: job.Id(), Reason: "zombie run"})
+	var unauthorized *armadaerrors.ErrUnauthorized
+	assert.ErrorAs(t, err, &unauthorized)
+
+	_, err = failer.ForceFailJob(forceFailContext("alice"), &schedulerobjects.ForceFailJobRequest{JobId: job.Id()})
+	var invalidArgument *armadaerrors.ErrInvalidArgument
+	assert.ErrorAs(t, err, &invalidArgument)
+
+	_, err = failer.ForceFailJob(forceFailContext("alice"), &schedulerobjects.ForceFailJobRequest{JobId: "not a job id", Reason: "zombie run"})
+	assert.ErrorAs(t, err, &invalidArgument)
+
+	_, err = failer.ForceFailJob(forceFailContext("alice"), &schedulerobjects.ForceFailJobRequest{JobId: util.NewULID(), Reason: "zombie run"})
+	var notFound *armadaerrors.ErrNotFound
+	assert.ErrorAs(t, err, &notFound)
+
+	// None of the above should have been recorded.
+	txn = jobDb.WriteTxn()
+	defer txn.Abort()
+	events, _, err := failer.FailJobs(armadacontext.Background(), txn, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestJobForceFailer_ForwardedPrincipal(t *testing.T) {
+	job := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0]
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	txn.Commit()
+	failer := NewJobForceFailer(jobDb, testForceFailPermissionChecker())
+
+	ctx := metadata.NewIncomingContext(forceFailContext("alice"), metadata.Pairs(forwardedPrincipalMetadataKey, "bob"))
+	_, err := failer.ForceFailJob(ctx, &schedulerobjects.ForceFailJobRequest{JobId: job.Id(), Reason: "zombie run"})
+	require.NoError(t, err)
+
+	txn = jobDb.WriteTxn()
+	defer txn.Abort()
+	events, _, err := failer.FailJobs(armadacontext.Background(), txn, time.Now())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "bob (via alice)", events[0].Events[0].GetJobErrors().Errors[0].GetJobForceFailed().Principal)
+}
+
+// forceFailContext returns a context with a principal of the provided name.
+// Only alice and bob have permission to force-fail jobs.
+func forceFailContext(name string) context.Context {
+	return authorization.WithPrincipal(context.Background(), authorization.NewStaticPrincipal(name, []string{name}))
+}
+
+func testForceFailPermissionChecker() authorization.PermissionChecker {
+	return authorization.NewPrincipalPermissionChecker(
+		map[permission.Permission][]string{permissions.ForceFailJobs: {"alice", "bob"}},
+		nil,
+		nil,
+	)
+}
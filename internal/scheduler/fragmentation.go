@@ -0,0 +1,590 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/armadaproject/armada/internal/common/metrics"
+	"github.com/armadaproject/armada/internal/common/types"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+var (
+	poolLargestFreeAllocationDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_pool_largest_free_allocation",
+		"Largest amount of the resource free on any single schedulable node of the pool as of the most recent scheduling round.",
+		[]string{"pool", "resource"},
+		nil,
+	)
+	poolNodeFreeFractionDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_pool_node_free_fraction",
+		"Distribution across the schedulable nodes of the pool of the fraction of the resource free on each node as of the most recent scheduling round.",
+		[]string{"pool", "resource"},
+		nil,
+	)
+	poolFragmentationBlockedJobsDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_pool_fragmentation_blocked_jobs",
+		"Number of jobs that failed to schedule in the most recent scheduling round even though the nodes they could run on had enough of each resource free in total, since no single such node did.",
+		[]string{"pool"},
+		nil,
+	)
+)
+
+// Upper bounds of the buckets of the distribution of the fraction of each resource free on each node.
+var nodeFreeFractionBuckets = []float64{0, 0.1, 0.25, 0.5, 0.75, 1}
+
+// FragmentationTracker computes at the end of each scheduling round how fragmented the free capacity of the nodes
+// scheduled on is, exports it as metrics, and implements the fragmentation report. Free capacity is fragmented if it's
+// spread across nodes in amounts too small for the jobs that failed to schedule; the report recommends which running
+// preemptible jobs would need to move to other nodes to unblock the largest such job.
+// Recommendations are advisory only; no jobs are moved.
+type FragmentationTracker struct {
+	// Resources for which the largest free allocation and the distribution of free capacity are exported.
+	resources []string
+	// Fragmentation as of the most recent round of each executor group, indexed by the id of the group,
+	// i.e., the pool if executors are scheduled by pool and the executor otherwise.
+	fragmentationByExecutorGroup map[string]*ExecutorGroupFragmentation
+	mu                           sync.Mutex
+}
+
+// ExecutorGroupFragmentation describes how fragmented the free capacity of the schedulable nodes of an executor group
+// was at the end of a scheduling round.
+type ExecutorGroupFragmentation struct {
+	ExecutorGroup string
+	Pool          string
+	// Total amount of each resource free across all nodes.
+	TotalFree schedulerobjects.ResourceList
+	// Largest amount of each resource free on any single node.
+	LargestFree schedulerobjects.ResourceList
+	// Fraction of each tracked resource free on each node with a non-zero amount of it, indexed by resource.
+	FreeFractionsByResource map[string][]float64
+	// Jobs that failed to schedule solely since no single node they could run on had enough free capacity,
+	// largest first.
+	BlockedJobs []FragmentationBlockedJob
+	// Jobs to move to unblock the largest of BlockedJobs,
+	// or nil if no jobs are blocked or none could be moved to unblock the largest.
+	Recommendation *DefragmentationRecommendation
+}
+
+// FragmentationBlockedJob is a job that failed to schedule even though the nodes it could run on had enough of each
+// resource it requests free in total, since no single such node did.
+type FragmentationBlockedJob struct {
+	JobId    string
+	Queue    string
+	Requests schedulerobjects.ResourceList
+	// Largest fraction of the total amount of any resource of the executor group requested by the job.
+	DominantShare float64
+	jctx          *schedulercontext.JobSchedulingContext
+}
+
+// DefragmentationRecommendation lists the running preemptible jobs that would need to move off a node,
+// and the nodes they could move to, for the node to have enough free capacity for a blocked job.
+type DefragmentationRecommendation struct {
+	Job      FragmentationBlockedJob
+	NodeId   string
+	NodeName string
+	Executor string
+	Moves    []JobMove
+}
+
+// JobMove is a running job that would need to move to another node.
+type JobMove struct {
+	JobId      string
+	Queue      string
+	Requests   schedulerobjects.ResourceList
+	ToNodeId   string
+	ToNodeName string
+}
+
+func NewFragmentationTracker(config schedulerconfig.FragmentationConfig) *FragmentationTracker {
+	return &FragmentationTracker{
+		resources:                    slices.Clone(config.Resources),
+		fragmentationByExecutorGroup: make(map[string]*ExecutorGroupFragmentation),
+	}
+}
+
+// Update records the fragmentation of the nodes in nodeDb at the end of the scheduling round described by sctx.
+// Should be called once blocking causes have been classified. The jobs running on each node are looked up in txn;
+// those of queues for which isNeverPreemptQueue returns true are never recommended to be moved.
+func (t *FragmentationTracker) Update(
+	sctx *schedulercontext.SchedulingContext,
+	nodeDb *nodedb.NodeDb,
+	txn *jobdb.Txn,
+	isNeverPreemptQueue func(queue string) bool,
+) error {
+	nodes, err := schedulableNodes(nodeDb)
+	if err != nil {
+		return err
+	}
+	fragmentation := &ExecutorGroupFragmentation{
+		ExecutorGroup:           sctx.ExecutorId,
+		Pool:                    sctx.Pool,
+		TotalFree:               schedulerobjects.NewResourceListWithDefaultSize(),
+		LargestFree:             schedulerobjects.NewResourceListWithDefaultSize(),
+		FreeFractionsByResource: make(map[string][]float64, len(t.resources)),
+	}
+	for _, node := range nodes {
+		free := freeResources(node)
+		fragmentation.TotalFree.Add(free)
+		for resourceType, q := range free.Resources {
+			if largest := fragmentation.LargestFree.Get(resourceType); q.Cmp(largest) > 0 {
+				fragmentation.LargestFree.Set(resourceType, q.DeepCopy())
+			}
+		}
+		for _, resourceType := range t.resources {
+			total := node.TotalResources.Get(resourceType)
+			if total.IsZero() {
+				continue
+			}
+			q := free.Get(resourceType)
+			fragmentation.FreeFractionsByResource[resourceType] = append(
+				fragmentation.FreeFractionsByResource[resourceType],
+				float64(q.MilliValue())/float64(total.MilliValue()),
+			)
+		}
+	}
+	fragmentation.BlockedJobs, err = fragmentationBlockedJobs(sctx, nodes)
+	if err != nil {
+		return err
+	}
+	if len(fragmentation.BlockedJobs) > 0 {
+		fragmentation.Recommendation, err = defragmentationRecommendation(
+			fragmentation.BlockedJobs[0], nodes, sctx.PriorityClasses, txn, isNeverPreemptQueue,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fragmentationByExecutorGroup[sctx.ExecutorId] = fragmentation
+	return nil
+}
+
+// schedulableNodes returns the nodes in nodeDb not marked as unschedulable, ordered by id.
+func schedulableNodes(nodeDb *nodedb.NodeDb) ([]*nodedb.Node, error) {
+	it, err := nodedb.NewNodesIterator(nodeDb.Txn(false))
+	if err != nil {
+		return nil, err
+	}
+	unschedulableTaint := nodedb.UnschedulableTaint()
+	var nodes []*nodedb.Node
+	for node := it.NextNode(); node != nil; node = it.NextNode() {
+		if slices.IndexFunc(node.Taints, func(taint v1.Taint) bool { return taint.MatchTaint(&unschedulableTaint) }) >= 0 {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, func(a, b *nodedb.Node) bool { return a.Id < b.Id })
+	return nodes, nil
+}
+
+// freeResources returns the resources of node not allocated to any job, regardless of priority.
+func freeResources(node *nodedb.Node) schedulerobjects.ResourceList {
+	return node.AllocatableByPriority[nodedb.MinPriority]
+}
+
+// fragmentationBlockedJobs returns the jobs of sctx that failed to schedule since they didn't fit, even though
+// the nodes they could run on had enough of each resource they request free in total, largest first.
+func fragmentationBlockedJobs(sctx *schedulercontext.SchedulingContext, nodes []*nodedb.Node) ([]FragmentationBlockedJob, error) {
+	// Jobs with the same scheduling key have the same requirements; only the first of them is evaluated.
+	isBlockedBySchedulingKey := make(map[schedulerobjects.SchedulingKey]bool)
+	var blockedJobs []FragmentationBlockedJob
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		for _, jctx := range qctx.UnsuccessfulJobSchedulingContexts {
+			if jctx.IsEvicted || jctx.BlockingCause != schedulercontext.InfeasibleBlockingCause || jctx.PodRequirements == nil {
+				continue
+			}
+			schedulingKey, hasSchedulingKey := jctx.SchedulingKey()
+			isBlocked, ok := isBlockedBySchedulingKey[schedulingKey]
+			if !hasSchedulingKey || !ok {
+				var err error
+				isBlocked, err = isBlockedByFragmentation(jctx, nodes)
+				if err != nil {
+					return nil, err
+				}
+				if hasSchedulingKey {
+					isBlockedBySchedulingKey[schedulingKey] = isBlocked
+				}
+			}
+			if !isBlocked {
+				continue
+			}
+			requests := schedulerobjects.ResourceListFromV1ResourceList(jctx.PodRequirements.ResourceRequirements.Requests)
+			blockedJobs = append(blockedJobs, FragmentationBlockedJob{
+				JobId:         jctx.JobId,
+				Queue:         qctx.Queue,
+				Requests:      requests,
+				DominantShare: dominantShare(requests, sctx.TotalResources),
+				jctx:          jctx,
+			})
+		}
+	}
+	sort.Slice(blockedJobs, func(i, j int) bool {
+		if blockedJobs[i].DominantShare != blockedJobs[j].DominantShare {
+			return blockedJobs[i].DominantShare > blockedJobs[j].DominantShare
+		}
+		return blockedJobs[i].JobId < blockedJobs[j].JobId
+	})
+	return blockedJobs, nil
+}
+
+// isBlockedByFragmentation returns true if the job of jctx could run on some of nodes and, in total, those nodes have
+// enough of each resource it requests free, but no single one of them does.
+func isBlockedByFragmentation(jctx *schedulercontext.JobSchedulingContext, nodes []*nodedb.Node) (bool, error) {
+	requests := jctx.PodRequirements.ResourceRequirements.Requests
+	totalFree := schedulerobjects.NewResourceListWithDefaultSize()
+	matchesAnyNode := false
+	for _, node := range nodes {
+		matches, _, err := nodedb.StaticJobRequirementsMet(node.Taints, node.Labels, node.TotalResources, jctx)
+		if err != nil {
+			return false, err
+		}
+		if !matches {
+			continue
+		}
+		free := freeResources(node)
+		if fits, _ := nodedb.ResourceRequirementsMet(free, requests); fits {
+			return false, nil
+		}
+		matchesAnyNode = true
+		totalFree.Add(free)
+	}
+	if !matchesAnyNode {
+		return false, nil
+	}
+	fits, _ := nodedb.ResourceRequirementsMet(totalFree, requests)
+	return fits, nil
+}
+
+// dominantShare returns the largest fraction of the amount of any resource in total requested by requests.
+func dominantShare(requests, total schedulerobjects.ResourceList) float64 {
+	var rv float64
+	for resourceType, q := range requests.Resources {
+		available := total.Get(resourceType)
+		if available.IsZero() {
+			continue
+		}
+		if share := float64(q.MilliValue()) / float64(available.MilliValue()); share > rv {
+			rv = share
+		}
+	}
+	return rv
+}
+
+// defragmentationRecommendation returns the smallest set of running preemptible jobs that, moved off one of nodes
+// onto the others, would leave enough free capacity on that node for blockedJob, or nil if there's no such set.
+//
+// For each node blockedJob could run on, jobs are selected largest first until enough capacity is freed,
+// and each is then placed on the first other node with enough free capacity it could run on.
+// Nodes requiring fewer jobs to move are preferred, then those requiring less capacity to move.
+func defragmentationRecommendation(
+	blockedJob FragmentationBlockedJob,
+	nodes []*nodedb.Node,
+	priorityClasses map[string]types.PriorityClass,
+	txn *jobdb.Txn,
+	isNeverPreemptQueue func(queue string) bool,
+) (*DefragmentationRecommendation, error) {
+	var best *DefragmentationRecommendation
+	var bestMovedShare float64
+	for _, node := range nodes {
+		matches, _, err := nodedb.StaticJobRequirementsMet(node.Taints, node.Labels, node.TotalResources, blockedJob.jctx)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			continue
+		}
+		movable, err := movableJobs(node, priorityClasses, txn, isNeverPreemptQueue)
+		if err != nil {
+			return nil, err
+		}
+		jctxsToMove, movedShare := jobsToFree(node, blockedJob.Requests, movable)
+		if jctxsToMove == nil {
+			continue
+		}
+		if best != nil && (len(jctxsToMove) > len(best.Moves) || (len(jctxsToMove) == len(best.Moves) && movedShare >= bestMovedShare)) {
+			continue
+		}
+		moves, err := placeMovedJobs(node, jctxsToMove, nodes)
+		if err != nil {
+			return nil, err
+		}
+		if moves == nil {
+			continue
+		}
+		best = &DefragmentationRecommendation{
+			Job:      blockedJob,
+			NodeId:   node.Id,
+			NodeName: node.Name,
+			Executor: node.Executor,
+			Moves:    moves,
+		}
+		bestMovedShare = movedShare
+	}
+	return best, nil
+}
+
+// movableJobs returns scheduling contexts for the jobs running on node that could be moved elsewhere,
+// i.e., those that are preemptible, aren't part of a gang, and whose queue may be preempted, ordered by id.
+func movableJobs(
+	node *nodedb.Node,
+	priorityClasses map[string]types.PriorityClass,
+	txn *jobdb.Txn,
+	isNeverPreemptQueue func(queue string) bool,
+) ([]*schedulercontext.JobSchedulingContext, error) {
+	jobIds := maps.Keys(node.AllocatedByJobId)
+	slices.Sort(jobIds)
+	var rv []*schedulercontext.JobSchedulingContext
+	for _, jobId := range jobIds {
+		if node.EvictedJobRunIds[jobId] {
+			continue
+		}
+		job := txn.GetById(jobId)
+		if job == nil || !priorityClasses[job.GetPriorityClassName()].Preemptible || isNeverPreemptQueue(job.Queue()) {
+			continue
+		}
+		jctx := schedulercontext.JobSchedulingContextFromJob(priorityClasses, job, GangIdAndCardinalityFromAnnotations)
+		if jctx.GangCardinality > 1 {
+			continue
+		}
+		rv = append(rv, jctx)
+	}
+	return rv, nil
+}
+
+// jobsToFree returns the jobs of candidates, largest first, that would need to move off node for it to have requests
+// free, along with their combined share of the resources of the node, or nil if moving all of them wouldn't suffice.
+func jobsToFree(
+	node *nodedb.Node,
+	requests schedulerobjects.ResourceList,
+	candidates []*schedulercontext.JobSchedulingContext,
+) ([]*schedulercontext.JobSchedulingContext, float64) {
+	shareByJobId := make(map[string]float64, len(candidates))
+	for _, jctx := range candidates {
+		shareByJobId[jctx.JobId] = dominantShare(node.AllocatedByJobId[jctx.JobId], node.TotalResources)
+	}
+	candidates = slices.Clone(candidates)
+	slices.SortStableFunc(candidates, func(a, b *schedulercontext.JobSchedulingContext) bool {
+		return shareByJobId[a.JobId] > shareByJobId[b.JobId]
+	})
+	v1Requests := schedulerobjects.V1ResourceListFromResourceList(requests)
+	free := freeResources(node).DeepCopy()
+	var rv []*schedulercontext.JobSchedulingContext
+	var movedShare float64
+	for _, jctx := range candidates {
+		if fits, _ := nodedb.ResourceRequirementsMet(free, v1Requests); fits {
+			break
+		}
+		allocated := node.AllocatedByJobId[jctx.JobId]
+		if !freesShortResource(free, requests, allocated) {
+			continue
+		}
+		free.Add(allocated)
+		rv = append(rv, jctx)
+		movedShare += shareByJobId[jctx.JobId]
+	}
+	if fits, _ := nodedb.ResourceRequirementsMet(free, v1Requests); !fits {
+		return nil, 0
+	}
+	return rv, movedShare
+}
+
+// freesShortResource returns true if allocated includes some resource of which there's less free than requested.
+func freesShortResource(free, requests, allocated schedulerobjects.ResourceList) bool {
+	for resourceType, q := range requests.Resources {
+		if available := free.Get(resourceType); available.Cmp(q) < 0 {
+			if a := allocated.Get(resourceType); !a.IsZero() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// placeMovedJobs places each of jctxs on the first node of nodes other than from with enough free capacity
+// that the job could run on, accounting for the jobs already placed, or returns nil if some job can't be placed.
+func placeMovedJobs(from *nodedb.Node, jctxs []*schedulercontext.JobSchedulingContext, nodes []*nodedb.Node) ([]JobMove, error) {
+	freeByNodeId := make(map[string]schedulerobjects.ResourceList)
+	rv := make([]JobMove, 0, len(jctxs))
+	for _, jctx := range jctxs {
+		requests := jctx.PodRequirements.ResourceRequirements.Requests
+		var to *nodedb.Node
+		for _, node := range nodes {
+			if node.Id == from.Id {
+				continue
+			}
+			free, ok := freeByNodeId[node.Id]
+			if !ok {
+				free = freeResources(node)
+			}
+			if fits, _ := nodedb.ResourceRequirementsMet(free, requests); !fits {
+				continue
+			}
+			matches, _, err := nodedb.StaticJobRequirementsMet(node.Taints, node.Labels, node.TotalResources, jctx)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue
+			}
+			free = free.DeepCopy()
+			free.SubV1ResourceList(requests)
+			freeByNodeId[node.Id] = free
+			to = node
+			break
+		}
+		if to == nil {
+			return nil, nil
+		}
+		rv = append(rv, JobMove{
+			JobId:      jctx.JobId,
+			Queue:      jctx.Job.GetQueue(),
+			Requests:   from.AllocatedByJobId[jctx.JobId].DeepCopy(),
+			ToNodeId:   to.Id,
+			ToNodeName: to.Name,
+		})
+	}
+	return rv, nil
+}
+
+// Fragmentation returns the fragmentation of each executor group as of its most recent round, ordered by group id.
+func (t *FragmentationTracker) Fragmentation() []*ExecutorGroupFragmentation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	executorGroups := maps.Keys(t.fragmentationByExecutorGroup)
+	slices.Sort(executorGroups)
+	rv := make([]*ExecutorGroupFragmentation, len(executorGroups))
+	for i, executorGroup := range executorGroups {
+		rv[i] = t.fragmentationByExecutorGroup[executorGroup]
+	}
+	return rv
+}
+
+func (t *FragmentationTracker) reportString(pool string) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
+	numReported := 0
+	for _, fragmentation := range t.Fragmentation() {
+		if pool != "" && fragmentation.Pool != pool {
+			continue
+		}
+		numReported++
+		fmt.Fprintf(w, "Executor group %s of pool %s:\n", fragmentation.ExecutorGroup, fragmentation.Pool)
+		fmt.Fprintf(w, "\tTotal free:\t%s\n", fragmentation.TotalFree.CompactString())
+		fmt.Fprintf(w, "\tLargest free on a single node:\t%s\n", fragmentation.LargestFree.CompactString())
+		fmt.Fprintf(w, "\tJobs blocked solely by fragmentation:\t%d\n", len(fragmentation.BlockedJobs))
+		if len(fragmentation.BlockedJobs) == 0 {
+			continue
+		}
+		largest := fragmentation.BlockedJobs[0]
+		fmt.Fprintf(w, "\tLargest blocked job:\t%s of queue %s requesting %s\n", largest.JobId, largest.Queue, largest.Requests.CompactString())
+		recommendation := fragmentation.Recommendation
+		if recommendation == nil {
+			fmt.Fprint(w, "\tRecommendation:\tnone; no node can be freed by moving preemptible jobs elsewhere\n")
+			continue
+		}
+		fmt.Fprintf(
+			w, "\tRecommendation (advisory only; no jobs are moved):\tmove %d job(s) off node %s of executor %s\n",
+			len(recommendation.Moves), recommendation.NodeName, recommendation.Executor,
+		)
+		fmt.Fprint(w, "\t\tJob\tQueue\tRequests\tTo node\n")
+		for _, move := range recommendation.Moves {
+			fmt.Fprintf(w, "\t\t%s\t%s\t%s\t%s\n", move.JobId, move.Queue, move.Requests.CompactString(), move.ToNodeName)
+		}
+	}
+	if numReported == 0 {
+		fmt.Fprint(w, "No fragmentation recorded\n")
+	}
+	w.Flush()
+	return sb.String()
+}
+
+func (t *FragmentationTracker) Describe(desc chan<- *prometheus.Desc) {
+	desc <- poolLargestFreeAllocationDesc
+	desc <- poolNodeFreeFractionDesc
+	desc <- poolFragmentationBlockedJobsDesc
+}
+
+// Collect exports the fragmentation of each pool, aggregated across the executor groups of the pool.
+func (t *FragmentationTracker) Collect(metrics chan<- prometheus.Metric) {
+	largestFreeByPool := make(map[string]schedulerobjects.ResourceList)
+	freeFractionsByPoolAndResource := make(map[string]map[string][]float64)
+	numBlockedJobsByPool := make(map[string]int)
+	for _, fragmentation := range t.Fragmentation() {
+		largestFree, ok := largestFreeByPool[fragmentation.Pool]
+		if !ok {
+			largestFree = schedulerobjects.NewResourceListWithDefaultSize()
+			largestFreeByPool[fragmentation.Pool] = largestFree
+		}
+		for resourceType, q := range fragmentation.LargestFree.Resources {
+			if current := largestFree.Get(resourceType); q.Cmp(current) > 0 {
+				largestFree.Set(resourceType, q)
+			}
+		}
+		freeFractionsByResource, ok := freeFractionsByPoolAndResource[fragmentation.Pool]
+		if !ok {
+			freeFractionsByResource = make(map[string][]float64)
+			freeFractionsByPoolAndResource[fragmentation.Pool] = freeFractionsByResource
+		}
+		for resourceType, fractions := range fragmentation.FreeFractionsByResource {
+			freeFractionsByResource[resourceType] = append(freeFractionsByResource[resourceType], fractions...)
+		}
+		numBlockedJobsByPool[fragmentation.Pool] += len(fragmentation.BlockedJobs)
+	}
+	for pool, largestFree := range largestFreeByPool {
+		for _, resourceType := range t.resources {
+			q := largestFree.Get(resourceType)
+			metrics <- prometheus.MustNewConstMetric(
+				poolLargestFreeAllocationDesc, prometheus.GaugeValue, q.AsApproximateFloat64(), pool, resourceType,
+			)
+		}
+		metrics <- prometheus.MustNewConstMetric(
+			poolFragmentationBlockedJobsDesc, prometheus.GaugeValue, float64(numBlockedJobsByPool[pool]), pool,
+		)
+	}
+	for pool, freeFractionsByResource := range freeFractionsByPoolAndResource {
+		for resourceType, fractions := range freeFractionsByResource {
+			metrics <- freeFractionHistogram(fractions, pool, resourceType)
+		}
+	}
+}
+
+// freeFractionHistogram returns a histogram of fractions bucketed by nodeFreeFractionBuckets.
+func freeFractionHistogram(fractions []float64, labelValues ...string) prometheus.Metric {
+	buckets := make(map[float64]uint64, len(nodeFreeFractionBuckets))
+	for _, upperBound := range nodeFreeFractionBuckets {
+		buckets[upperBound] = 0
+	}
+	var sum float64
+	for _, fraction := range fractions {
+		sum += fraction
+		for _, upperBound := range nodeFreeFractionBuckets {
+			if fraction <= upperBound {
+				buckets[upperBound]++
+			}
+		}
+	}
+	return prometheus.MustNewConstHistogram(
+		poolNodeFreeFractionDesc, uint64(len(fractions)), sum, buckets, labelValues...,
+	)
+}
+
+// EnableFragmentationTracking causes tracker to be updated at the end of each scheduling round
+// with the fragmentation of the nodes scheduled on.
+func (l *FairSchedulingAlgo) EnableFragmentationTracking(tracker *FragmentationTracker) {
+	l.fragmentationTracker = tracker
+}
@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/types"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/fairness"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+// fragmentationTestJob returns a job of queue requesting requests.
+func fragmentationTestJob(queue, priorityClassName string, requests schedulerobjects.ResourceList) *jobdb.Job {
+	jobId := util.ULID()
+	priority := testfixtures.TestPriorityClasses[priorityClassName].Priority
+	reqs := testfixtures.TestPodReqs(queue, jobId, priority, schedulerobjects.V1ResourceListFromResourceList(requests))
+	return testfixtures.TestJob(queue, jobId, priorityClassName, reqs)
+}
+
+func cpuAndMemory(cpu, memory string) schedulerobjects.ResourceList {
+	return schedulerobjects.ResourceList{
+		Resources: map[string]resource.Quantity{
+			"cpu":    resource.MustParse(cpu),
+			"memory": resource.MustParse(memory),
+		},
+	}
+}
+
+// fragmentedFixture returns a nodeDb and jobDb txn for a pool with 8, 16, and 12 cpu free on nodes "node-a", "node-b",
+// and "node-c" respectively, and 32 cpu free on an unschedulable node. Of the jobs on node-a, two 8-cpu jobs and eight
+// 1-cpu jobs, all are preemptible; those on the other nodes aren't. Also returns the two 8-cpu jobs.
+func fragmentedFixture(t *testing.T) (*nodedb.NodeDb, *jobdb.Txn, []*jobdb.Job) {
+	config := testfixtures.TestSchedulingConfig()
+	priorities := types.AllowedPriorities(config.Preemption.PriorityClasses)
+	nodeDb, err := NewNodeDb(config)
+	require.NoError(t, err)
+	jobDbTxn := testfixtures.NewJobDb().WriteTxn()
+
+	largeJobs := []*jobdb.Job{
+		fragmentationTestJob("B", testfixtures.PriorityClass0, cpuAndMemory("8", "32Gi")),
+		fragmentationTestJob("B", testfixtures.PriorityClass0, cpuAndMemory("8", "32Gi")),
+	}
+	jobsByNodeName := map[string][]*jobdb.Job{
+		"node-a": append(largeJobs, testfixtures.N1Cpu4GiJobs("B", testfixtures.PriorityClass0, 8)...),
+		"node-b": testfixtures.N1Cpu4GiJobs("C", testfixtures.PriorityClass2NonPreemptible, 16),
+		"node-c": testfixtures.N1Cpu4GiJobs("C", testfixtures.PriorityClass2NonPreemptible, 20),
+		"node-d": nil,
+	}
+	nodeDbTxn := nodeDb.Txn(true)
+	for _, name := range []string{"node-a", "node-b", "node-c", "node-d"} {
+		node := testfixtures.Test32CpuNode(priorities)
+		node.Id = name
+		node.Name = name
+		node.Unschedulable = name == "node-d"
+		jobs := jobsByNodeName[name]
+		require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(nodeDbTxn, jobs, node))
+		require.NoError(t, jobDbTxn.Upsert(jobs))
+	}
+	nodeDbTxn.Commit()
+	return nodeDb, jobDbTxn, largeJobs
+}
+
+// fragmentationTestSchedulingContext returns a scheduling context in which jobs of a single queue failed to schedule
+// since they didn't fit.
+func fragmentationTestSchedulingContext(t *testing.T, jobs []*jobdb.Job) *schedulercontext.SchedulingContext {
+	totalResources := cpuAndMemory("128", "1024Gi")
+	fairnessCostProvider, err := fairness.NewDominantResourceFairness(totalResources, []string{"cpu", "memory"})
+	require.NoError(t, err)
+	sctx := schedulercontext.NewSchedulingContext(
+		"fragmented-pool",
+		"fragmented-pool",
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestDefaultPriorityClass,
+		fairnessCostProvider,
+		rate.NewLimiter(rate.Inf, 1),
+		totalResources,
+	)
+	require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, nil, rate.NewLimiter(rate.Inf, 1)))
+	for _, job := range jobs {
+		jctx := schedulercontext.JobSchedulingContextFromJob(testfixtures.TestPriorityClasses, job, GangIdAndCardinalityFromAnnotations)
+		jctx.Fail(JobDoesNotFitUnschedulableReason)
+		_, err := sctx.QueueSchedulingContexts["A"].AddJobSchedulingContext(jctx)
+		require.NoError(t, err)
+	}
+	ClassifyBlockingCauses(sctx)
+	return sctx
+}
+
+func TestFragmentationTracker(t *testing.T) {
+	nodeDb, jobDbTxn, largeJobs := fragmentedFixture(t)
+	blocked := fragmentationTestJob("A", testfixtures.PriorityClass0, cpuAndMemory("24", "64Gi"))
+	smallerBlocked := fragmentationTestJob("A", testfixtures.PriorityClass0, cpuAndMemory("20", "64Gi"))
+	// Fits on node-b, so failed to schedule for some other reason.
+	fits := fragmentationTestJob("A", testfixtures.PriorityClass0, cpuAndMemory("12", "64Gi"))
+	// Larger than any node.
+	tooLarge := fragmentationTestJob("A", testfixtures.PriorityClass0, cpuAndMemory("40", "64Gi"))
+	sctx := fragmentationTestSchedulingContext(t, []*jobdb.Job{blocked, smallerBlocked, fits, tooLarge})
+
+	tracker := NewFragmentationTracker(schedulerconfig.FragmentationConfig{Enabled: true, Resources: []string{"cpu"}})
+	require.NoError(t, tracker.Update(sctx, nodeDb, jobDbTxn, func(string) bool { return false }))
+
+	fragmentations := tracker.Fragmentation()
+	require.Len(t, fragmentations, 1)
+	fragmentation := fragmentations[0]
+	assert.Equal(t, "fragmented-pool", fragmentation.Pool)
+	// The unschedulable node isn't counted.
+	assert.True(t, fragmentation.TotalFree.Equal(cpuAndMemory("36", "528Gi")), fragmentation.TotalFree.CompactString())
+	largestFreeCpu := fragmentation.LargestFree.Get("cpu")
+	assert.Equal(t, 0, largestFreeCpu.Cmp(resource.MustParse("16")))
+	assert.ElementsMatch(t, []float64{0.25, 0.5, 0.375}, fragmentation.FreeFractionsByResource["cpu"])
+
+	require.Len(t, fragmentation.BlockedJobs, 2)
+	assert.Equal(t, blocked.Id(), fragmentation.BlockedJobs[0].JobId)
+	assert.Equal(t, smallerBlocked.Id(), fragmentation.BlockedJobs[1].JobId)
+
+	// Moving both 8-cpu jobs off node-a onto node-b leaves enough cpu free on node-a for the largest blocked job.
+	recommendation := fragmentation.Recommendation
+	require.NotNil(t, recommendation)
+	assert.Equal(t, blocked.Id(), recommendation.Job.JobId)
+	assert.Equal(t, "node-a", recommendation.NodeId)
+	require.Len(t, recommendation.Moves, 2)
+	movedJobIds := []string{recommendation.Moves[0].JobId, recommendation.Moves[1].JobId}
+	assert.ElementsMatch(t, []string{largeJobs[0].Id(), largeJobs[1].Id()}, movedJobIds)
+	for _, move := range recommendation.Moves {
+		assert.Equal(t, "B", move.Queue)
+		assert.Equal(t, "node-b", move.ToNodeId)
+		assert.True(t, move.Requests.Equal(cpuAndMemory("8", "32Gi")), move.Requests.CompactString())
+	}
+
+	// Jobs of queues that are never preempted aren't recommended to be moved.
+	require.NoError(t, tracker.Update(sctx, nodeDb, jobDbTxn, func(queue string) bool { return queue == "B" }))
+	fragmentation = tracker.Fragmentation()[0]
+	assert.Len(t, fragmentation.BlockedJobs, 2)
+	assert.Nil(t, fragmentation.Recommendation)
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(tracker))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	familiesByName := make(map[string]*dto.MetricFamily)
+	for _, family := range families {
+		familiesByName[family.GetName()] = family
+	}
+	largestFree := familiesByName["armada_scheduler_pool_largest_free_allocation"]
+	require.NotNil(t, largestFree)
+	require.Len(t, largestFree.Metric, 1)
+	assert.Equal(t, 16.0, largestFree.Metric[0].GetGauge().GetValue())
+	blockedJobs := familiesByName["armada_scheduler_pool_fragmentation_blocked_jobs"]
+	require.NotNil(t, blockedJobs)
+	assert.Equal(t, 2.0, blockedJobs.Metric[0].GetGauge().GetValue())
+	freeFraction := familiesByName["armada_scheduler_pool_node_free_fraction"]
+	require.NotNil(t, freeFraction)
+	histogram := freeFraction.Metric[0].GetHistogram()
+	assert.Equal(t, uint64(3), histogram.GetSampleCount())
+	cumulativeCountByUpperBound := make(map[float64]uint64)
+	for _, bucket := range histogram.GetBucket() {
+		cumulativeCountByUpperBound[bucket.GetUpperBound()] = bucket.GetCumulativeCount()
+	}
+	assert.Equal(t, map[float64]uint64{0: 0, 0.1: 0, 0.25: 1, 0.5: 3, 0.75: 3, 1: 3}, cumulativeCountByUpperBound)
+}
+
+func TestSchedulingContextRepository_GetFragmentationReport(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10)
+	require.NoError(t, err)
+	ctx := armadacontext.Background()
+
+	report, err := repo.GetFragmentationReport(ctx, &schedulerobjects.FragmentationReportRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "Fragmentation tracking is disabled\n", report.Report)
+
+	nodeDb, jobDbTxn, largeJobs := fragmentedFixture(t)
+	blocked := fragmentationTestJob("A", testfixtures.PriorityClass0, cpuAndMemory("24", "64Gi"))
+	tracker := NewFragmentationTracker(schedulerconfig.FragmentationConfig{Enabled: true, Resources: []string{"cpu"}})
+	require.NoError(t, tracker.Update(fragmentationTestSchedulingContext(t, []*jobdb.Job{blocked}), nodeDb, jobDbTxn, func(string) bool { return false }))
+	repo.EnableFragmentationReports(tracker)
+
+	report, err = repo.GetFragmentationReport(ctx, &schedulerobjects.FragmentationReportRequest{Pool: "fragmented-pool"})
+	require.NoError(t, err)
+	assert.Regexp(t, `Jobs blocked solely by fragmentation:\s+1\n`, report.Report)
+	assert.Contains(t, report.Report, blocked.Id())
+	assert.Regexp(t, `advisory only.*move 2 job\(s\) off node node-a`, report.Report)
+	assert.Regexp(t, largeJobs[0].Id()+`\s+B\s+.*node-b`, report.Report)
+
+	report, err = repo.GetFragmentationReport(ctx, &schedulerobjects.FragmentationReportRequest{Pool: "other-pool"})
+	require.NoError(t, err)
+	assert.Equal(t, "No fragmentation recorded\n", report.Report)
+}
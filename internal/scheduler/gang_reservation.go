@@ -0,0 +1,492 @@
+package scheduler
+
+import (
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	armadaslices "github.com/armadaproject/armada/internal/common/slices"
+	"github.com/armadaproject/armada/internal/common/types"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/interfaces"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// GangReservations records the nodes reserved for gangs that failed to schedule for lack of capacity.
+// Reserved nodes are tainted with nodedb.GangReservationTaint() when inserted into the nodeDb, such that no jobs other
+// than backfill jobs are scheduled on them and capacity accumulates across scheduling rounds as the jobs running on them
+// finish. At the start of each round, each gang is placed on its reserved nodes if it fits there once backfill jobs are
+// disregarded; backfill jobs that no longer fit alongside the gang are then preempted.
+//
+// Reservations are released once their gang is placed, once any of its jobs is no longer queued,
+// or once they've been held for the configured maximum duration.
+type GangReservations struct {
+	config          configuration.GangReservationsConfig
+	priorityClasses map[string]types.PriorityClass
+	// Priority classes of jobs that may backfill reserved capacity.
+	backfillPriorityClasses map[string]bool
+	// Reservations of each executor group, i.e., pool or executor, in the order they were created.
+	reservationsByGroup map[string][]*gangReservation
+	// Reservation holding each reserved node.
+	reservationByNodeId map[string]*gangReservation
+	// Reservation of each gang.
+	reservationByGangId map[string]*gangReservation
+}
+
+type gangReservation struct {
+	gangId string
+	queue  string
+	// Ids of the jobs that make up the gang.
+	jobIds []string
+	// Ids of the nodes held for the gang.
+	nodeIds []string
+	// Time at which the nodes were reserved.
+	created time.Time
+}
+
+func NewGangReservations(config configuration.GangReservationsConfig, priorityClasses map[string]types.PriorityClass) *GangReservations {
+	backfillPriorityClasses := make(map[string]bool, len(config.BackfillPriorityClasses))
+	for _, priorityClassName := range config.BackfillPriorityClasses {
+		backfillPriorityClasses[priorityClassName] = true
+	}
+	return &GangReservations{
+		config:                  config,
+		priorityClasses:         priorityClasses,
+		backfillPriorityClasses: backfillPriorityClasses,
+		reservationsByGroup:     make(map[string][]*gangReservation),
+		reservationByNodeId:     make(map[string]*gangReservation),
+		reservationByGangId:     make(map[string]*gangReservation),
+	}
+}
+
+// IsReserved returns true if the node with the given id is reserved for a gang.
+func (r *GangReservations) IsReserved(nodeId string) bool {
+	_, ok := r.reservationByNodeId[nodeId]
+	return ok
+}
+
+// MayBackfill returns true if job may run in capacity reserved for gangs, i.e., if it's not part of a gang,
+// its priority class is a preemptible backfill priority class, and its MaxExpectedRuntimeAnnotation is positive and at
+// most the configured maximum backfill runtime.
+func (r *GangReservations) MayBackfill(job interfaces.LegacySchedulerJob) bool {
+	priorityClassName := job.GetPriorityClassName()
+	if !r.backfillPriorityClasses[priorityClassName] || !r.priorityClasses[priorityClassName].Preemptible {
+		return false
+	}
+	annotations := job.GetAnnotations()
+	if _, ok := annotations[configuration.GangIdAnnotation]; ok {
+		return false
+	}
+	maxExpectedRuntime, err := time.ParseDuration(annotations[configuration.MaxExpectedRuntimeAnnotation])
+	if err != nil {
+		return false
+	}
+	return maxExpectedRuntime > 0 && maxExpectedRuntime <= r.config.MaxBackfillRuntime
+}
+
+func (r *GangReservations) reserve(group string, reservation *gangReservation) {
+	r.reservationsByGroup[group] = append(r.reservationsByGroup[group], reservation)
+	r.reservationByGangId[reservation.gangId] = reservation
+	for _, nodeId := range reservation.nodeIds {
+		r.reservationByNodeId[nodeId] = reservation
+	}
+}
+
+func (r *GangReservations) release(group string, reservation *gangReservation) {
+	r.reservationsByGroup[group] = armadaslices.Filter(
+		r.reservationsByGroup[group],
+		func(other *gangReservation) bool { return other != reservation },
+	)
+	if len(r.reservationsByGroup[group]) == 0 {
+		delete(r.reservationsByGroup, group)
+	}
+	delete(r.reservationByGangId, reservation.gangId)
+	for _, nodeId := range reservation.nodeIds {
+		delete(r.reservationByNodeId, nodeId)
+	}
+}
+
+// placeReservedGangs places the gangs for which nodes of the provided executor group are reserved on those nodes,
+// if they fit there once backfill jobs are disregarded, and preempts the backfill jobs that then no longer fit.
+// Reservations that have expired or whose gang is no longer queued are released.
+// The jobDb transaction and fsctx are updated to reflect the jobs placed and preempted,
+// such that the rest of the round schedules around them.
+func (l *FairSchedulingAlgo) placeReservedGangs(
+	ctx *armadacontext.Context,
+	fsctx *fairSchedulingAlgoContext,
+	group string,
+	pool string,
+	executors []*schedulerobjects.Executor,
+) (*SchedulerResult, error) {
+	result := &SchedulerResult{
+		PlacementByJobId: make(map[string]Placement),
+	}
+	now := l.clock.Now()
+	for _, reservation := range slices.Clone(l.gangReservations.reservationsByGroup[group]) {
+		if maxDuration := l.gangReservations.config.MaxReservationDuration; maxDuration > 0 && now.Sub(reservation.created) >= maxDuration {
+			ctx.Infof("releasing %d nodes reserved for gang %s of queue %s, since it hasn't been placed within %s", len(reservation.nodeIds), reservation.gangId, reservation.queue, maxDuration)
+			l.gangReservations.release(group, reservation)
+			continue
+		}
+		jobs := make([]*jobdb.Job, 0, len(reservation.jobIds))
+		for _, jobId := range reservation.jobIds {
+			if job := fsctx.txn.GetById(jobId); job != nil && job.Queued() {
+				jobs = append(jobs, job)
+			}
+		}
+		if len(jobs) < len(reservation.jobIds) {
+			ctx.Infof("releasing %d nodes reserved for gang %s of queue %s, since not all its jobs are queued", len(reservation.nodeIds), reservation.gangId, reservation.queue)
+			l.gangReservations.release(group, reservation)
+			continue
+		}
+		ok, err := l.placeReservedGang(ctx, fsctx, pool, executors, reservation, jobs, result)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			l.gangReservations.release(group, reservation)
+		}
+	}
+	return result, nil
+}
+
+// placeReservedGang places the jobs of the gang on its reserved nodes, if they all fit there once backfill jobs running
+// on these nodes are disregarded. Backfill jobs are then kept in order of their run creation time as long as they fit
+// alongside the gang; the rest are preempted. The jobs placed and preempted are added to result.
+// Returns false if the gang doesn't fit yet, in which case nothing is changed.
+func (l *FairSchedulingAlgo) placeReservedGang(
+	ctx *armadacontext.Context,
+	fsctx *fairSchedulingAlgoContext,
+	pool string,
+	executors []*schedulerobjects.Executor,
+	reservation *gangReservation,
+	jobs []*jobdb.Job,
+	result *SchedulerResult,
+) (bool, error) {
+	nodeDb, err := nodedb.NewNodeDb(
+		l.schedulingConfig.Preemption.PriorityClasses,
+		l.schedulingConfig.MaxExtraNodesToConsider,
+		l.schedulingConfig.IndexedResources,
+		l.schedulingConfig.IndexedTaints,
+		l.schedulingConfig.IndexedNodeLabels,
+		l.schedulingConfig.WellKnownNodeTypes,
+	)
+	if err != nil {
+		return false, err
+	}
+	nodeDb.EnableBackfill(l.gangReservations.MayBackfill)
+	nodeDb.EnableNeverPreemptQueues(fsctx.isNeverPreemptQueue)
+	isReservedNode := make(map[string]bool, len(reservation.nodeIds))
+	for _, nodeId := range reservation.nodeIds {
+		isReservedNode[nodeId] = true
+	}
+	var backfillJobs []*jobdb.Job
+	for _, executor := range executors {
+		nodes := armadaslices.Filter(executor.Nodes, func(node *schedulerobjects.Node) bool { return isReservedNode[node.Id] })
+		if len(nodes) == 0 {
+			continue
+		}
+		var nonBackfillJobs []*jobdb.Job
+		for _, job := range fsctx.jobsByExecutorId[executor.Id] {
+			if job.InTerminalState() || !job.HasRuns() || !isReservedNode[job.LatestRun().NodeId()] {
+				continue
+			}
+			if l.gangReservations.MayBackfill(job) {
+				backfillJobs = append(backfillJobs, job)
+			} else {
+				nonBackfillJobs = append(nonBackfillJobs, job)
+			}
+		}
+		if err := l.addExecutorToNodeDb(nodeDb, nonBackfillJobs, nodes, pool); err != nil {
+			return false, err
+		}
+	}
+
+	txn := nodeDb.Txn(true)
+	defer txn.Abort()
+	gangJctxs := make([]*schedulercontext.JobSchedulingContext, len(jobs))
+	gangNodes := make([]*nodedb.Node, len(jobs))
+	for i, job := range jobs {
+		jctx := schedulercontext.JobSchedulingContextFromJob(l.schedulingConfig.Preemption.PriorityClasses, job, GangIdAndCardinalityFromAnnotations)
+		jctx.AdditionalTolerations = append(jctx.AdditionalTolerations, nodedb.GangReservationToleration())
+		node, err := nodeDb.ScheduleWithoutPreemptionWithTxn(txn, jctx)
+		if err != nil {
+			return false, err
+		}
+		if node == nil {
+			return false, nil
+		}
+		gangJctxs[i] = jctx
+		gangNodes[i] = node
+	}
+	slices.SortFunc(backfillJobs, func(a, b *jobdb.Job) bool {
+		if a.LatestRun().Created() != b.LatestRun().Created() {
+			return a.LatestRun().Created() < b.LatestRun().Created()
+		}
+		return a.Id() < b.Id()
+	})
+	var preemptedJobs []*jobdb.Job
+	for _, job := range backfillJobs {
+		jctx := schedulercontext.JobSchedulingContextFromJob(l.schedulingConfig.Preemption.PriorityClasses, job, GangIdAndCardinalityFromAnnotations)
+		jctx.AddNodeSelector(schedulerconfig.NodeIdLabel, job.LatestRun().NodeId())
+		node, err := nodeDb.ScheduleWithoutPreemptionWithTxn(txn, jctx)
+		if err != nil {
+			return false, err
+		}
+		if node == nil {
+			preemptedJobs = append(preemptedJobs, job)
+		}
+	}
+
+	now := l.clock.Now()
+	scheduledJobs := make([]*jobdb.Job, len(jobs))
+	for i, job := range jobs {
+		jctx := gangJctxs[i]
+		node := gangNodes[i]
+		placement := Placement{
+			Executor:            node.Executor,
+			NodeId:              node.Id,
+			NodeName:            node.Name,
+			ScheduledAtPriority: jctx.PodSchedulingContext.ScheduledAtPriority,
+			Pool:                pool,
+		}
+		job = job.
+			WithQueuedVersion(job.QueuedVersion()+1).
+			WithQueued(false).
+			WithNewRun(placement.Executor, placement.NodeId, placement.NodeName, placement.ScheduledAtPriority, now)
+		if l.jobSetPlacementTracker != nil && !l.shadow {
+			l.jobSetPlacementTracker.RecordRun(job.Queue(), job.Jobset(), job.Id(), node.Executor, node.Name, node.Labels, job.GetAnnotations()[configuration.PreferredExecutorAnnotation])
+		}
+		jctx.Job = job
+		scheduledJobs[i] = job
+		result.ScheduledJobs = append(result.ScheduledJobs, jctx)
+		result.PlacementByJobId[job.Id()] = placement
+	}
+	for i, job := range preemptedJobs {
+		job = job.WithUpdatedRun(job.LatestRun().WithFailed(true)).WithQueued(false).WithFailed(true)
+		preemptedJobs[i] = job
+		result.PreemptedJobs = append(
+			result.PreemptedJobs,
+			schedulercontext.JobSchedulingContextFromJob(l.schedulingConfig.Preemption.PriorityClasses, job, GangIdAndCardinalityFromAnnotations),
+		)
+	}
+	if err := fsctx.txn.Upsert(scheduledJobs); err != nil {
+		return false, err
+	}
+	if err := fsctx.txn.Upsert(preemptedJobs); err != nil {
+		return false, err
+	}
+	fsctx.updateRunningJobs(pool, scheduledJobs, preemptedJobs)
+	ctx.Infof(
+		"placed gang %s of queue %s on its %d reserved nodes, preempting %d of %d backfill jobs",
+		reservation.gangId, reservation.queue, len(reservation.nodeIds), len(preemptedJobs), len(backfillJobs),
+	)
+	return true, nil
+}
+
+// updateRunningJobs updates fsctx to account for the jobs in scheduledJobs now running in pool
+// and for the jobs in preemptedJobs no longer running there.
+func (fsctx *fairSchedulingAlgoContext) updateRunningJobs(pool string, scheduledJobs []*jobdb.Job, preemptedJobs []*jobdb.Job) {
+	allocationByQueue := fsctx.allocationByPoolAndQueueAndPriorityClass[pool]
+	if allocationByQueue == nil {
+		allocationByQueue = make(map[string]schedulerobjects.QuantityByTAndResourceType[string])
+		fsctx.allocationByPoolAndQueueAndPriorityClass[pool] = allocationByQueue
+	}
+	for _, job := range scheduledJobs {
+		run := job.LatestRun()
+		fsctx.jobsByExecutorId[run.Executor()] = append(fsctx.jobsByExecutorId[run.Executor()], job)
+		fsctx.nodeIdByJobId[job.Id()] = run.NodeId()
+		if gangId := job.GetAnnotations()[configuration.GangIdAnnotation]; gangId != "" {
+			jobIds := fsctx.jobIdsByGangId[gangId]
+			if jobIds == nil {
+				jobIds = make(map[string]bool)
+				fsctx.jobIdsByGangId[gangId] = jobIds
+			}
+			jobIds[job.Id()] = true
+			fsctx.gangIdByJobId[job.Id()] = gangId
+		}
+		allocation := allocationByQueue[job.Queue()]
+		if allocation == nil {
+			allocation = make(schedulerobjects.QuantityByTAndResourceType[string])
+			allocationByQueue[job.Queue()] = allocation
+		}
+		allocation.AddV1ResourceList(job.GetPriorityClassName(), job.GetResourceRequirements().Requests)
+	}
+	isPreempted := make(map[string]bool, len(preemptedJobs))
+	for _, job := range preemptedJobs {
+		isPreempted[job.Id()] = true
+		delete(fsctx.nodeIdByJobId, job.Id())
+		if allocation := allocationByQueue[job.Queue()]; allocation != nil {
+			allocation.SubV1ResourceList(job.GetPriorityClassName(), job.GetResourceRequirements().Requests)
+		}
+	}
+	for _, job := range preemptedJobs {
+		executorId := job.LatestRun().Executor()
+		fsctx.jobsByExecutorId[executorId] = armadaslices.Filter(
+			fsctx.jobsByExecutorId[executorId],
+			func(job *jobdb.Job) bool { return !isPreempted[job.Id()] },
+		)
+	}
+}
+
+// reserveNodesForGangs reserves nodes of the provided executor group for gangs that failed to schedule in sctx since
+// too few of their jobs fit, up to the configured maximum number of reservations per group. Nodes are reserved for a
+// gang only if they could fit all its jobs once the jobs running on them finish, preferring the least-allocated nodes,
+// such that the gang can be placed as soon as possible.
+func (l *FairSchedulingAlgo) reserveNodesForGangs(
+	ctx *armadacontext.Context,
+	fsctx *fairSchedulingAlgoContext,
+	group string,
+	pool string,
+	executors []*schedulerobjects.Executor,
+	sctx *schedulercontext.SchedulingContext,
+) error {
+	config := l.gangReservations.config
+	if len(l.gangReservations.reservationsByGroup[group]) >= config.MaxReservationsPerPool {
+		return nil
+	}
+	jctxsByGangId := make(map[string][]*schedulercontext.JobSchedulingContext)
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		for _, jctx := range qctx.UnsuccessfulJobSchedulingContexts {
+			if jctx.GangId == "" || jctx.GangCardinality < 2 || jctx.GangCardinality < config.MinGangCardinality {
+				continue
+			}
+			if jctx.UnschedulableReason != GangMinimumCardinalityNotMetUnschedulableReason {
+				continue
+			}
+			if jctx.Job.GetAnnotations()[configuration.GangNodeUniformityLabelAnnotation] != "" {
+				// Reserving nodes with uniform labels isn't supported.
+				continue
+			}
+			jctxsByGangId[jctx.GangId] = append(jctxsByGangId[jctx.GangId], jctx)
+		}
+	}
+	gangIds := maps.Keys(jctxsByGangId)
+	slices.Sort(gangIds)
+	now := l.clock.Now()
+	for _, gangId := range gangIds {
+		if len(l.gangReservations.reservationsByGroup[group]) >= config.MaxReservationsPerPool {
+			break
+		}
+		jctxs := jctxsByGangId[gangId]
+		if _, ok := l.gangReservations.reservationByGangId[gangId]; ok || len(jctxs) != jctxs[0].GangCardinality {
+			continue
+		}
+		slices.SortFunc(jctxs, func(a, b *schedulercontext.JobSchedulingContext) bool { return a.JobId < b.JobId })
+		nodeIds, err := l.selectNodesToReserve(fsctx, pool, executors, jctxs)
+		if err != nil {
+			return err
+		}
+		if len(nodeIds) == 0 {
+			continue
+		}
+		reservation := &gangReservation{
+			gangId:  gangId,
+			queue:   jctxs[0].Job.GetQueue(),
+			jobIds:  make([]string, len(jctxs)),
+			nodeIds: nodeIds,
+			created: now,
+		}
+		for i, jctx := range jctxs {
+			reservation.jobIds[i] = jctx.JobId
+		}
+		l.gangReservations.reserve(group, reservation)
+		ctx.Infof("reserved %d nodes for gang %s of queue %s", len(nodeIds), gangId, reservation.queue)
+	}
+	return nil
+}
+
+// selectNodesToReserve returns the ids of nodes on which each of jctxs could be placed if these nodes were empty,
+// or nil if there are no such nodes. Nodes already reserved and unschedulable nodes aren't considered.
+// Nodes with the largest fraction of their resources unallocated are considered first.
+func (l *FairSchedulingAlgo) selectNodesToReserve(
+	fsctx *fairSchedulingAlgoContext,
+	pool string,
+	executors []*schedulerobjects.Executor,
+	jctxs []*schedulercontext.JobSchedulingContext,
+) ([]string, error) {
+	type candidate struct {
+		node *schedulerobjects.Node
+		// Fraction of the resources of the node not allocated to jobs, summed over resources.
+		unallocatedFraction float64
+		// Resources of the node reserved for jobs of the gang so far.
+		reserved schedulerobjects.ResourceList
+	}
+	overcommitFactors := l.schedulingConfig.GetOvercommitFactors(pool)
+	var candidates []*candidate
+	for _, executor := range executors {
+		unallocatedByNodeId := make(map[string]schedulerobjects.ResourceList, len(executor.Nodes))
+		for _, node := range executor.Nodes {
+			node = node.WithOvercommit(overcommitFactors)
+			if node.Unschedulable || l.gangReservations.IsReserved(node.Id) {
+				continue
+			}
+			unallocatedByNodeId[node.Id] = node.TotalResources.DeepCopy()
+			candidates = append(candidates, &candidate{node: node, reserved: schedulerobjects.NewResourceListWithDefaultSize()})
+		}
+		for _, job := range fsctx.jobsByExecutorId[executor.Id] {
+			if job.InTerminalState() || !job.HasRuns() {
+				continue
+			}
+			if unallocated, ok := unallocatedByNodeId[job.LatestRun().NodeId()]; ok {
+				unallocated.SubV1ResourceList(job.GetResourceRequirements().Requests)
+			}
+		}
+		for _, c := range candidates {
+			if unallocated, ok := unallocatedByNodeId[c.node.Id]; ok {
+				c.unallocatedFraction = unallocatedFraction(c.node.TotalResources, unallocated)
+			}
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b *candidate) bool {
+		if a.unallocatedFraction != b.unallocatedFraction {
+			return a.unallocatedFraction > b.unallocatedFraction
+		}
+		return a.node.Id < b.node.Id
+	})
+
+	var nodeIds []string
+	for _, jctx := range jctxs {
+		var selected *candidate
+		for _, c := range candidates {
+			if matches, _, err := nodedb.StaticJobRequirementsMet(c.node.Taints, c.node.Labels, c.node.TotalResources, jctx); err != nil {
+				return nil, err
+			} else if !matches {
+				continue
+			}
+			reserved := c.reserved.DeepCopy()
+			reserved.AddV1ResourceList(jctx.PodRequirements.ResourceRequirements.Requests)
+			if reserved.IsStrictlyLessOrEqual(c.node.TotalResources) {
+				selected = c
+				selected.reserved = reserved
+				break
+			}
+		}
+		if selected == nil {
+			return nil, nil
+		}
+		if !slices.Contains(nodeIds, selected.node.Id) {
+			nodeIds = append(nodeIds, selected.node.Id)
+		}
+	}
+	return nodeIds, nil
+}
+
+// unallocatedFraction returns the fraction of total that is unallocated, summed over resources.
+func unallocatedFraction(total schedulerobjects.ResourceList, unallocated schedulerobjects.ResourceList) float64 {
+	rv := 0.0
+	for t, q := range total.Resources {
+		if q.IsZero() {
+			continue
+		}
+		u := unallocated.Get(t)
+		rv += u.AsApproximateFloat64() / q.AsApproximateFloat64()
+	}
+	return rv
+}
@@ -0,0 +1,225 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestGangReservations_MayBackfill(t *testing.T) {
+	reservations := NewGangReservations(
+		configuration.GangReservationsConfig{
+			Enabled:                 true,
+			BackfillPriorityClasses: []string{testfixtures.PriorityClass0, testfixtures.PriorityClass3},
+			MaxBackfillRuntime:      time.Hour,
+		},
+		testfixtures.TestPriorityClasses,
+	)
+	withMaxExpectedRuntime := func(maxExpectedRuntime string, jobs []*jobdb.Job) []*jobdb.Job {
+		return testfixtures.WithAnnotationsJobs(map[string]string{configuration.MaxExpectedRuntimeAnnotation: maxExpectedRuntime}, jobs)
+	}
+	tests := map[string]struct {
+		job      *jobdb.Job
+		expected bool
+	}{
+		"backfill priority class with short expected runtime": {
+			job:      withMaxExpectedRuntime("10m", testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0],
+			expected: true,
+		},
+		"expected runtime equal to maximum": {
+			job:      withMaxExpectedRuntime("1h", testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0],
+			expected: true,
+		},
+		"expected runtime exceeding maximum": {
+			job:      withMaxExpectedRuntime("2h", testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0],
+			expected: false,
+		},
+		"invalid expected runtime": {
+			job:      withMaxExpectedRuntime("soon", testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0],
+			expected: false,
+		},
+		"no expected runtime": {
+			job:      testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1)[0],
+			expected: false,
+		},
+		"other priority class": {
+			job:      withMaxExpectedRuntime("10m", testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass1, 1))[0],
+			expected: false,
+		},
+		"non-preemptible priority class": {
+			job:      withMaxExpectedRuntime("10m", testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass3, 1))[0],
+			expected: false,
+		},
+		"gang job": {
+			job:      withMaxExpectedRuntime("10m", testfixtures.WithGangAnnotationsJobs(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 2)))[0],
+			expected: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, reservations.MayBackfill(tc.job))
+		})
+	}
+}
+
+func TestGangReservations_GangPlacedOnceReservedCapacityIsFree(t *testing.T) {
+	tests := map[string]struct {
+		// Cpu requested by the backfill job.
+		backfillCpu string
+		// If true, the backfill job is expected to be preempted once the gang is placed.
+		expectBackfillPreempted bool
+	}{
+		"backfill job fitting alongside the gang is kept": {
+			backfillCpu:             "1",
+			expectBackfillPreempted: false,
+		},
+		"backfill job not fitting alongside the gang is preempted": {
+			backfillCpu:             "2",
+			expectBackfillPreempted: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := armadacontext.Background()
+			config := testfixtures.TestSchedulingConfig()
+			config.GangReservations = configuration.GangReservationsConfig{
+				Enabled:                 true,
+				MinGangCardinality:      2,
+				MaxReservationsPerPool:  1,
+				MaxReservationDuration:  time.Hour,
+				BackfillPriorityClasses: []string{testfixtures.PriorityClass0},
+				MaxBackfillRuntime:      time.Hour,
+			}
+
+			nodes := testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)
+			for _, node := range nodes {
+				node.Executor = "executor-1"
+			}
+			executor := &schedulerobjects.Executor{
+				Id:             "executor-1",
+				Pool:           testfixtures.TestPool,
+				Nodes:          nodes,
+				LastUpdateTime: testfixtures.BaseTime,
+			}
+			ctrl := gomock.NewController(t)
+			mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+			mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{executor}, nil).AnyTimes()
+			mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+			mockQueueRepo.EXPECT().GetAllQueues().Return(
+				[]*database.Queue{{Name: "A", Weight: 1}, {Name: "B", Weight: 1}, {Name: "C", Weight: 1}},
+				nil,
+			).AnyTimes()
+			algo, err := NewFairSchedulingAlgo(config, 0, mockExecutorRepo, mockQueueRepo, nil)
+			require.NoError(t, err)
+			testClock := clock.NewFakeClock(testfixtures.BaseTime)
+			algo.clock = testClock
+
+			jobDb := testfixtures.NewJobDb()
+			runCycle := func() {
+				txn := jobDb.WriteTxn()
+				_, err := algo.Schedule(ctx, txn)
+				require.NoError(t, err)
+				var terminalJobIds []string
+				for _, job := range txn.GetAll() {
+					if job.InTerminalState() {
+						terminalJobIds = append(terminalJobIds, job.Id())
+					}
+				}
+				require.NoError(t, txn.BatchDelete(terminalJobIds))
+				txn.Commit()
+				testClock.Step(10 * time.Second)
+			}
+			submit := func(jobs ...*jobdb.Job) {
+				txn := jobDb.WriteTxn()
+				require.NoError(t, txn.Upsert(jobs))
+				txn.Commit()
+			}
+			finish := func(jobs ...*jobdb.Job) {
+				txn := jobDb.WriteTxn()
+				require.NoError(t, txn.BatchDelete(util.Map(jobs, func(job *jobdb.Job) string { return job.Id() })))
+				txn.Commit()
+			}
+			getJob := func(job *jobdb.Job) *jobdb.Job {
+				return jobDb.ReadTxn().GetById(job.Id())
+			}
+
+			// Each node is half-allocated to a non-preemptible job, such that the gang doesn't fit.
+			runningJobs := testfixtures.N16Cpu128GiJobs("A", testfixtures.PriorityClass3, 2)
+			for i, job := range runningJobs {
+				runningJobs[i] = job.WithQueued(false).WithNewRun("executor-1", nodes[i].Id, nodes[i].Name, 3, testfixtures.BaseTime)
+			}
+			gang := testfixtures.WithGangAnnotationsJobs(
+				testfixtures.WithRequestsJobs(
+					schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("31")}},
+					testfixtures.N16Cpu128GiJobs("B", testfixtures.PriorityClass2, 2),
+				),
+			)
+			submit(append(runningJobs, queuedJobsForWaitTimeTest(gang)...)...)
+
+			runCycle()
+			for _, job := range gang {
+				assert.True(t, getJob(job).Queued())
+			}
+			assert.True(t, algo.gangReservations.IsReserved(nodes[0].Id))
+			assert.True(t, algo.gangReservations.IsReserved(nodes[1].Id))
+
+			// Only backfill jobs are scheduled on the reserved nodes.
+			backfillJob := testfixtures.WithAnnotationsJobs(
+				map[string]string{configuration.MaxExpectedRuntimeAnnotation: "10m"},
+				testfixtures.WithRequestsJobs(
+					schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse(tc.backfillCpu)}},
+					testfixtures.N1Cpu4GiJobs("C", testfixtures.PriorityClass0, 1),
+				),
+			)[0].WithQueued(true)
+			otherJob := testfixtures.Test1Cpu4GiJob("C", testfixtures.PriorityClass0).WithQueued(true)
+			submit(backfillJob, otherJob)
+			runCycle()
+			assert.False(t, getJob(backfillJob).Queued())
+			assert.True(t, getJob(otherJob).Queued())
+			finish(otherJob)
+
+			// The gang doesn't fit while either node is half-allocated, so nothing is preempted.
+			finish(runningJobs[0])
+			runCycle()
+			for _, job := range gang {
+				assert.True(t, getJob(job).Queued())
+			}
+			require.NotNil(t, getJob(backfillJob))
+			assert.False(t, getJob(backfillJob).Queued())
+
+			// Once both nodes are free of non-backfill jobs, the gang is placed on them.
+			finish(runningJobs[1])
+			runCycle()
+			nodeIds := make(map[string]bool)
+			for _, job := range gang {
+				job = getJob(job)
+				require.NotNil(t, job)
+				assert.False(t, job.Queued())
+				nodeIds[job.LatestRun().NodeId()] = true
+			}
+			assert.Len(t, nodeIds, 2)
+			if tc.expectBackfillPreempted {
+				assert.Nil(t, getJob(backfillJob))
+			} else {
+				require.NotNil(t, getJob(backfillJob))
+				assert.False(t, getJob(backfillJob).Queued())
+			}
+			assert.False(t, algo.gangReservations.IsReserved(nodes[0].Id))
+			assert.False(t, algo.gangReservations.IsReserved(nodes[1].Id))
+		})
+	}
+}
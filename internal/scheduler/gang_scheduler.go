@@ -14,6 +14,15 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
+const (
+	// Indicates that no node had enough free capacity for a job.
+	JobDoesNotFitUnschedulableReason = "job does not fit on any node"
+	// Indicates that, for each value of its node uniformity label, at least one job of a gang didn't fit on any node.
+	GangDoesNotFitUnschedulableReason = "at least one job in the gang does not fit on any node"
+	// Indicates that fewer than the minimum number of jobs of a gang could be scheduled.
+	GangMinimumCardinalityNotMetUnschedulableReason = "unable to schedule gang since minimum cardinality not met"
+)
+
 // GangScheduler schedules one gang at a time. GangScheduler is not aware of queues.
 type GangScheduler struct {
 	constraints       schedulerconstraints.SchedulingConstraints
@@ -45,6 +54,19 @@ func (sch *GangScheduler) updateGangSchedulingContextOnSuccess(gctx *schedulerco
 		return nil
 	}
 
+	// Record scheduling key collisions, i.e., jobs opted out of scheduling key-based skipping that were scheduled
+	// despite a job with the same scheduling key having been found unschedulable.
+	if gctx.Cardinality() == 1 && len(sch.schedulingContext.UnfeasibleSchedulingKeys) > 0 {
+		jctx := gctx.JobSchedulingContexts[0]
+		if schedulingKey, ok := jctx.SchedulingKey(); ok && jctx.IsSuccessful() {
+			if _, ok := sch.schedulingContext.UnfeasibleSchedulingKeys[schedulingKey]; ok {
+				if qctx := sch.schedulingContext.QueueSchedulingContexts[gctx.Queue]; qctx != nil {
+					qctx.NumSchedulingKeyCollisions++
+				}
+			}
+		}
+	}
+
 	// Evict any jobs added to the context marked as unsuccessful.
 	// This is necessary to support min-max gang-scheduling,
 	// where the gang is scheduled successfully if at least min of its members scheduled successfully.
@@ -81,7 +103,8 @@ func (sch *GangScheduler) updateGangSchedulingContextOnFailure(gctx *schedulerco
 	//
 	// Only record unfeasible scheduling keys for single-job gangs.
 	// Since a gang may be unschedulable even if all its members are individually schedulable.
-	if !sch.skipUnsuccessfulSchedulingKeyCheck && gctx.Cardinality() == 1 {
+	// Jobs opted out of scheduling key-based skipping may differ from other jobs with the same key; don't record those.
+	if !sch.skipUnsuccessfulSchedulingKeyCheck && gctx.Cardinality() == 1 && !gctx.JobSchedulingContexts[0].SchedulingKeySkippingDisabled() {
 		jctx := gctx.JobSchedulingContexts[0]
 		schedulingKey, ok := jctx.SchedulingKey()
 		if ok && schedulingKey != schedulerobjects.EmptySchedulingKey {
@@ -135,6 +158,14 @@ func (sch *GangScheduler) Schedule(ctx *armadacontext.Context, gctx *schedulerco
 		if ok, unschedulableReason, err = sch.constraints.CheckConstraints(sch.schedulingContext, gctx); err != nil || !ok {
 			return
 		}
+		// Jobs larger than any node can never be scheduled; report which resource is too large rather than trying.
+		for _, jctx := range gctx.JobSchedulingContexts {
+			if oversized, isOversized := sch.nodeDb.FindOversizedResource(jctx.PodRequirements.ResourceRequirements.Requests); isOversized {
+				ok = false
+				unschedulableReason = oversized.Reason()
+				return
+			}
+		}
 	}
 	return sch.trySchedule(ctx, gctx)
 }
@@ -197,7 +228,7 @@ func (sch *GangScheduler) trySchedule(ctx *armadacontext.Context, gctx *schedule
 	}
 	if bestValue == "" {
 		ok = false
-		unschedulableReason = "at least one job in the gang does not fit on any node"
+		unschedulableReason = GangDoesNotFitUnschedulableReason
 		return
 	}
 	addNodeSelectorToGctx(gctx, gctx.NodeUniformityLabel, bestValue)
@@ -218,15 +249,15 @@ func (sch *GangScheduler) tryScheduleGangWithTxn(_ *armadacontext.Context, txn *
 	if ok, err = sch.nodeDb.ScheduleManyWithTxn(txn, gctx.JobSchedulingContexts); err == nil {
 		if !ok {
 			if gctx.Cardinality() > 1 {
-				unschedulableReason = "unable to schedule gang since minimum cardinality not met"
+				unschedulableReason = GangMinimumCardinalityNotMetUnschedulableReason
 			} else {
-				unschedulableReason = "job does not fit on any node"
+				unschedulableReason = JobDoesNotFitUnschedulableReason
 			}
 		} else {
 			// When a gang schedules successfully, update state for failed jobs if they exist.
 			for _, jctx := range gctx.JobSchedulingContexts {
 				if jctx.ShouldFail {
-					jctx.Fail("job does not fit on any node")
+					jctx.Fail(JobDoesNotFitUnschedulableReason)
 				}
 			}
 		}
@@ -47,6 +47,8 @@ func TestGangScheduler(t *testing.T) {
 		ExpectedNodeUniformity map[int]string
 		// The expected number of jobs we successfully scheduled between min gang cardinality and gang cardinality.
 		ExpectedRuntimeGangCardinality []int
+		// If present, assert that gang `i` is unschedulable with reason `ExpectedUnschedulableReasons[i]`.
+		ExpectedUnschedulableReasons map[int]string
 	}{
 		"simple success": {
 			SchedulingConfig: testfixtures.TestSchedulingConfig(),
@@ -517,6 +519,23 @@ func TestGangScheduler(t *testing.T) {
 			ExpectedScheduledJobs:          []int{0, 1},
 			ExpectedRuntimeGangCardinality: []int{0, 1},
 		},
+		"job exceeding memory on all nodes while cpu fits": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithRequestsJobs(
+					schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"memory": resource.MustParse("512Gi")}},
+					testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1),
+				),
+				testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1),
+			},
+			ExpectedScheduledIndices:       []int{1},
+			ExpectedScheduledJobs:          []int{0, 1},
+			ExpectedRuntimeGangCardinality: []int{0, 1},
+			ExpectedUnschedulableReasons: map[int]string{
+				0: "exceeds largest node: job requests 512Gi memory, but the largest node has 256Gi memory allocatable",
+			},
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -644,6 +663,9 @@ func TestGangScheduler(t *testing.T) {
 					require.Equal(t, 0, sch.schedulingContext.NumEvictedJobs)
 				} else {
 					require.NotEmpty(t, reason)
+					if expectedReason, ok := tc.ExpectedUnschedulableReasons[i]; ok {
+						require.Equal(t, expectedReason, reason)
+					}
 
 					// Verify all jobs have been correctly unbound from nodes
 					for _, jctx := range jctxs {
@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/interfaces"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// crossQueueGangDiagnostic returns a message listing the queues and job sets spanned by the members of the gang
+// with the given id, together with the ids of the members in each, if there's more than one queue or job set.
+// Since a gang can only be scheduled atomically within a single queue and job set, such a gang can never be scheduled.
+// Returns the empty string if all members are in the same queue and job set.
+func crossQueueGangDiagnostic[T interfaces.LegacySchedulerJob](gangId string, members []T) string {
+	jobIdsByQueueAndJobSet := make(map[string][]string)
+	for _, member := range members {
+		key := fmt.Sprintf("queue %s, job set %s", member.GetQueue(), member.GetJobSet())
+		jobIdsByQueueAndJobSet[key] = append(jobIdsByQueueAndJobSet[key], member.GetId())
+	}
+	if len(jobIdsByQueueAndJobSet) <= 1 {
+		return ""
+	}
+	keys := maps.Keys(jobIdsByQueueAndJobSet)
+	slices.Sort(keys)
+	var sb strings.Builder
+	fmt.Fprintf(
+		&sb,
+		"members of gang %s span %d queue/job set combinations, but all members of a gang must be submitted to the same queue and job set:",
+		gangId, len(keys),
+	)
+	for _, key := range keys {
+		jobIds := jobIdsByQueueAndJobSet[key]
+		slices.Sort(jobIds)
+		fmt.Fprintf(&sb, " %s: jobs [%s];", key, strings.Join(jobIds, ", "))
+	}
+	return strings.TrimSuffix(sb.String(), ";")
+}
+
+// rejectCrossQueueGangs records in s.crossQueueGangJobs the new queued members of gangs of jsts that aren't in the
+// same queue and job set as the rest of their gang; a gang can only be scheduled atomically within a single queue and
+// job set. If members of the gang were already admitted, these determine its queue and job set, such that a new job
+// reusing the id of an existing gang in another queue or job set doesn't affect that gang. Otherwise, all new members
+// are recorded if they don't share a queue and job set. Leased members are left to run.
+// To bound memory, gang membership is only tracked for the jobs updated by this call;
+// members already in the jobDb are looked up via its gang index.
+// The recorded jobs are still added to the jobDb, such that they're failed by whichever replica is leader,
+// even if this replica isn't or its cycle fails; see failCrossQueueGangJobs.
+func (s *Scheduler) rejectCrossQueueGangs(ctx *armadacontext.Context, txn *jobdb.Txn, jsts []jobdb.JobStateTransitions) {
+	newJobsByGangId := make(map[string][]*jobdb.Job)
+	updatedJobsById := make(map[string]*jobdb.Job, len(jsts))
+	for _, jst := range jsts {
+		if jst.Job == nil {
+			continue
+		}
+		updatedJobsById[jst.Job.Id()] = jst.Job
+		if gangId := jst.Job.GangId(); gangId != "" && isNewQueuedJob(txn, jst.Job) {
+			newJobsByGangId[gangId] = append(newJobsByGangId[gangId], jst.Job)
+		}
+	}
+
+	gangIds := maps.Keys(newJobsByGangId)
+	slices.Sort(gangIds)
+	for _, gangId := range gangIds {
+		newJobs := newJobsByGangId[gangId]
+		var admittedJobs []*jobdb.Job
+		for _, job := range txn.GetGangJobs(gangId) {
+			if updatedJob, ok := updatedJobsById[job.Id()]; ok {
+				job = updatedJob
+			}
+			if !job.InTerminalState() && !s.isCrossQueueGangJob(job.Id()) {
+				admittedJobs = append(admittedJobs, job)
+			}
+		}
+		rejectedJobs := newJobs
+		if len(admittedJobs) > 0 {
+			rejectedJobs = nil
+			for _, job := range newJobs {
+				if job.Queue() != admittedJobs[0].Queue() || job.Jobset() != admittedJobs[0].Jobset() {
+					rejectedJobs = append(rejectedJobs, job)
+				} else {
+					admittedJobs = append(admittedJobs, job)
+				}
+			}
+		}
+		diagnostic := crossQueueGangDiagnostic(gangId, append(admittedJobs, rejectedJobs...))
+		if len(rejectedJobs) == 0 || diagnostic == "" {
+			continue
+		}
+		jobIds := util.Map(rejectedJobs, func(job *jobdb.Job) string { return job.Id() })
+		ctx.Warnf("failing new members %v of gang %s: %s", jobIds, gangId, diagnostic)
+		for _, jobId := range jobIds {
+			s.crossQueueGangJobs[jobId] = diagnostic
+		}
+	}
+}
+
+// pruneCrossQueueGangJobs forgets the jobs recorded in s.crossQueueGangJobs that are no longer in the jobDb,
+// e.g., since the leader failed them.
+func (s *Scheduler) pruneCrossQueueGangJobs(txn *jobdb.Txn) {
+	for jobId := range s.crossQueueGangJobs {
+		if txn.GetById(jobId) == nil {
+			delete(s.crossQueueGangJobs, jobId)
+		}
+	}
+}
+
+// isCrossQueueGangJob returns true if the job was rejected since its gang spans multiple queues or job sets
+// and is yet to be failed.
+func (s *Scheduler) isCrossQueueGangJob(jobId string) bool {
+	_, ok := s.crossQueueGangJobs[jobId]
+	return ok
+}
+
+// failCrossQueueGangJobs fails the queued jobs recorded in s.crossQueueGangJobs in txn and returns the events to
+// publish, together with the ids of the jobs failed, which should be passed to resolveCrossQueueGangJobs once txn
+// is committed.
+func (s *Scheduler) failCrossQueueGangJobs(txn *jobdb.Txn) ([]*armadaevents.EventSequence, []string, error) {
+	events := make([]*armadaevents.EventSequence, 0, len(s.crossQueueGangJobs))
+	jobsToFail := make([]*jobdb.Job, 0, len(s.crossQueueGangJobs))
+	for jobId, diagnostic := range s.crossQueueGangJobs {
+		job := txn.GetById(jobId)
+		if job == nil || !job.Queued() || job.InTerminalState() {
+			continue
+		}
+		protoJobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
+		if err != nil {
+			return nil, nil, err
+		}
+		jobsToFail = append(jobsToFail, job.WithQueued(false).WithFailed(true))
+		events = append(events, &armadaevents.EventSequence{
+			Queue:      job.Queue(),
+			JobSetName: job.Jobset(),
+			Events: []*armadaevents.EventSequence_Event{
+				{
+					Created: s.now(),
+					Event: &armadaevents.EventSequence_Event_JobErrors{
+						JobErrors: &armadaevents.JobErrors{
+							JobId: protoJobId,
+							Errors: []*armadaevents.Error{
+								{
+									Terminal: true,
+									Reason: &armadaevents.Error_GangJobUnschedulable{
+										GangJobUnschedulable: &armadaevents.GangJobUnschedulable{
+											Message: diagnostic,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if err := txn.Upsert(jobsToFail); err != nil {
+		return nil, nil, err
+	}
+	return events, util.Map(jobsToFail, func(job *jobdb.Job) string { return job.Id() }), nil
+}
+
+// resolveCrossQueueGangJobs forgets the jobs failed by a committed cycle.
+func (s *Scheduler) resolveCrossQueueGangJobs(jobIds []string) {
+	for _, jobId := range jobIds {
+		delete(s.crossQueueGangJobs, jobId)
+	}
+}
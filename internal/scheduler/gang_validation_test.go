@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	protoutil "github.com/armadaproject/armada/internal/common/proto"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_RejectCrossQueueGangs(t *testing.T) {
+	type gangMember struct {
+		queue  string
+		jobSet string
+	}
+	type cycle struct {
+		// New members of the gang submitted this cycle.
+		members []gangMember
+		// If true, the members submitted in previous cycles are cancelled this cycle.
+		cancelPreviousMembers bool
+	}
+	tests := map[string]struct {
+		cycles              []cycle
+		expectedNumQueued   map[string]int
+		expectedNumRejected int
+	}{
+		"gang in a single queue": {
+			cycles: []cycle{
+				{members: []gangMember{{"queueA", "jobSetA"}, {"queueA", "jobSetA"}}},
+			},
+			expectedNumQueued: map[string]int{"queueA": 2},
+		},
+		"gang split across queues": {
+			cycles: []cycle{
+				{members: []gangMember{{"queueA", "jobSetA"}, {"queueB", "jobSetA"}}},
+			},
+			expectedNumRejected: 2,
+		},
+		"gang split across job sets": {
+			cycles: []cycle{
+				{members: []gangMember{{"queueA", "jobSetA"}, {"queueA", "jobSetB"}}},
+			},
+			expectedNumRejected: 2,
+		},
+		"gang split across queues over multiple cycles": {
+			cycles: []cycle{
+				{members: []gangMember{{"queueA", "jobSetA"}}},
+				{members: []gangMember{{"queueB", "jobSetA"}}},
+			},
+			expectedNumQueued:   map[string]int{"queueA": 1},
+			expectedNumRejected: 1,
+		},
+		"gang id reused in another queue": {
+			cycles: []cycle{
+				{members: []gangMember{{"queueA", "jobSetA"}, {"queueA", "jobSetA"}}},
+				{members: []gangMember{{"queueB", "jobSetB"}}},
+			},
+			expectedNumQueued:   map[string]int{"queueA": 2},
+			expectedNumRejected: 1,
+		},
+		"new members split between the queue of the gang and another queue": {
+			cycles: []cycle{
+				{members: []gangMember{{"queueA", "jobSetA"}}},
+				{members: []gangMember{{"queueA", "jobSetA"}, {"queueB", "jobSetA"}}},
+			},
+			expectedNumQueued:   map[string]int{"queueA": 2},
+			expectedNumRejected: 1,
+		},
+		"gang resubmitted into one queue": {
+			cycles: []cycle{
+				{members: []gangMember{{"queueA", "jobSetA"}, {"queueA", "jobSetA"}}},
+				{members: []gangMember{{"queueB", "jobSetB"}, {"queueB", "jobSetB"}}, cancelPreviousMembers: true},
+			},
+			expectedNumQueued: map[string]int{"queueB": 2},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			jobRepo := &testJobRepository{}
+			publisher := &testPublisher{}
+			sched := newTestSchedulerWithPublisher(t, jobRepo, publisher)
+
+			serial := int64(0)
+			var previousMembers []database.Job
+			rejectedJobIds := make(map[string]bool)
+			for _, cycle := range tc.cycles {
+				jobRepo.updatedJobs = nil
+				if cycle.cancelPreviousMembers {
+					for _, job := range previousMembers {
+						serial++
+						job.Queued = false
+						job.Cancelled = true
+						job.Serial = serial
+						jobRepo.updatedJobs = append(jobRepo.updatedJobs, job)
+					}
+				}
+				for _, member := range cycle.members {
+					serial++
+					job := gangJobRepoJob(serial, "gang", member.queue, member.jobSet)
+					jobRepo.updatedJobs = append(jobRepo.updatedJobs, job)
+					previousMembers = append(previousMembers, job)
+				}
+				for _, eventSequence := range runCycleCollectingEvents(t, ctx, sched, publisher, true) {
+					for _, event := range eventSequence.Events {
+						jobErrors := event.GetJobErrors()
+						if jobErrors == nil {
+							continue
+						}
+						require.Len(t, jobErrors.Errors, 1)
+						assert.True(t, jobErrors.Errors[0].Terminal)
+						jobId, err := armadaevents.UlidStringFromProtoUuid(jobErrors.JobId)
+						require.NoError(t, err)
+						rejectedJobIds[jobId] = true
+
+						// The diagnostic lists the queues and job sets spanned by the gang, together with their members.
+						message := jobErrors.Errors[0].GetGangJobUnschedulable().GetMessage()
+						assert.Contains(t, message, "members of gang gang span 2 queue/job set combinations")
+						assert.Contains(t, message, jobId)
+					}
+				}
+			}
+
+			txn := sched.jobDb.ReadTxn()
+			for _, queue := range []string{"queueA", "queueB"} {
+				assert.Equal(t, tc.expectedNumQueued[queue], txn.NumQueuedJobs(queue), queue)
+			}
+			assert.Equal(t, tc.expectedNumRejected, len(rejectedJobIds))
+			for jobId := range rejectedJobIds {
+				job := txn.GetById(jobId)
+				require.NotNil(t, job)
+				assert.True(t, job.Failed())
+			}
+		})
+	}
+}
+
+func TestScheduler_RejectCrossQueueGangs_FailedByLeader(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	jobRepo := &testJobRepository{}
+	publisher := &testPublisher{}
+	sched := newTestSchedulerWithPublisher(t, jobRepo, publisher)
+
+	jobRepo.updatedJobs = []database.Job{
+		gangJobRepoJob(1, "gang", "queueA", "jobSetA"),
+		gangJobRepoJob(2, "gang", "queueB", "jobSetA"),
+	}
+	rejectedJobIds := []string{jobRepo.updatedJobs[0].JobID, jobRepo.updatedJobs[1].JobID}
+
+	// Followers keep the jobs in the jobDb, such that they're failed once they become leader.
+	assert.Empty(t, runCycleCollectingEvents(t, ctx, sched, publisher, false))
+	jobRepo.updatedJobs = nil
+	assert.Empty(t, runCycleCollectingEvents(t, ctx, sched, publisher, false))
+	for _, jobId := range rejectedJobIds {
+		job := sched.jobDb.ReadTxn().GetById(jobId)
+		require.NotNil(t, job)
+		assert.True(t, job.Queued())
+	}
+
+	// If publishing fails, the jobs are failed again by the next cycle.
+	publisher.shouldError = true
+	_, err := sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.Error(t, err)
+	publisher.shouldError = false
+	events := runCycleCollectingEvents(t, ctx, sched, publisher, true)
+	assert.Len(t, events, 2)
+	for _, jobId := range rejectedJobIds {
+		assert.True(t, sched.jobDb.ReadTxn().GetById(jobId).Failed())
+	}
+	assert.Empty(t, sched.crossQueueGangJobs)
+}
+
+func gangJobRepoJob(serial int64, gangId string, queue string, jobSet string) database.Job {
+	gangSchedulingInfo := proto.Clone(schedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+	gangSchedulingInfo.GetPodRequirements().Annotations = map[string]string{
+		configuration.GangIdAnnotation:          gangId,
+		configuration.GangCardinalityAnnotation: "2",
+	}
+	return database.Job{
+		JobID:          util.NewULID(),
+		JobSet:         jobSet,
+		Queue:          queue,
+		Queued:         true,
+		QueuedVersion:  0,
+		SchedulingInfo: protoutil.MustMarshall(gangSchedulingInfo),
+		Serial:         serial,
+	}
+}
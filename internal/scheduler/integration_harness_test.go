@@ -0,0 +1,676 @@
+//go:build integration
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/compress"
+	"github.com/armadaproject/armada/internal/common/eventutil"
+	"github.com/armadaproject/armada/internal/common/ingest"
+	ingestmetrics "github.com/armadaproject/armada/internal/common/ingest/metrics"
+	"github.com/armadaproject/armada/internal/common/pulsarutils"
+	"github.com/armadaproject/armada/internal/common/schedulers"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/internal/scheduleringester"
+	"github.com/armadaproject/armada/pkg/api"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+const (
+	integrationTopic            = "events"
+	integrationNumPartitions    = 3
+	integrationPool             = testfixtures.TestPool
+	integrationMaxAttemptedRuns = 3
+	integrationIngestTimeout    = 30 * time.Second
+	integrationMaxMessageSize   = 4 * 1024 * 1024
+)
+
+// Created once, since the ingester metrics can't be registered more than once.
+var integrationIngesterMetrics = ingestmetrics.NewMetrics(ingestmetrics.ArmadaEventIngesterMetricsPrefix + "integration_test_")
+
+// inProcessBroker is an in-memory stand-in for a partitioned Pulsar topic.
+// Like Pulsar, it only guarantees that messages are consumed in the order they were published to each partition;
+// messages are routed to partitions by the producer's MessageRouter or, if there is none, by hashing the message key.
+// Consumed messages are ingested into the scheduler database in the same way the scheduler ingester does.
+type inProcessBroker struct {
+	numPartitions int
+	// Messages published to each partition that have yet to be consumed.
+	pending [][]*pulsar.ProducerMessage
+	// Partition to consume from next; partitions are consumed from round-robin.
+	nextPartition int
+	// Notified whenever a message is published.
+	published chan struct{}
+	// All event sequences published, in the order they were published.
+	sequences []*armadaevents.EventSequence
+	// Number of messages published and consumed.
+	numPublished int
+	numConsumed  int
+	// First error encountered when consuming.
+	err error
+	mu  sync.Mutex
+}
+
+func newInProcessBroker(numPartitions int) *inProcessBroker {
+	return &inProcessBroker{
+		numPartitions: numPartitions,
+		pending:       make([][]*pulsar.ProducerMessage, numPartitions),
+		published:     make(chan struct{}, 1),
+	}
+}
+
+// NumPartitions implements pulsar.TopicMetadata.
+func (b *inProcessBroker) NumPartitions() uint32 {
+	return uint32(b.numPartitions)
+}
+
+func (b *inProcessBroker) publish(partition int, msg *pulsar.ProducerMessage) error {
+	if partition < 0 || partition >= b.numPartitions {
+		return errors.Errorf("partition %d is not in the range 0-%d", partition, b.numPartitions-1)
+	}
+	sequence, err := eventutil.UnmarshalEventSequence(armadacontext.Background(), msg.Payload)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	b.pending[partition] = append(b.pending[partition], msg)
+	b.sequences = append(b.sequences, sequence)
+	b.numPublished++
+	b.mu.Unlock()
+	select {
+	case b.published <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// next blocks until a message is available or ctx is cancelled.
+func (b *inProcessBroker) next(ctx *armadacontext.Context) (*pulsar.ProducerMessage, bool) {
+	for {
+		b.mu.Lock()
+		for i := 0; i < b.numPartitions; i++ {
+			partition := (b.nextPartition + i) % b.numPartitions
+			if len(b.pending[partition]) > 0 {
+				msg := b.pending[partition][0]
+				b.pending[partition] = b.pending[partition][1:]
+				b.nextPartition = (partition + 1) % b.numPartitions
+				b.mu.Unlock()
+				return msg, true
+			}
+		}
+		b.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-b.published:
+		}
+	}
+}
+
+// consume ingests published messages until ctx is cancelled or ingesting a message fails.
+// Only messages intended for this scheduler are stored, as done by the scheduler ingester.
+func (b *inProcessBroker) consume(
+	ctx *armadacontext.Context,
+	converter ingest.InstructionConverter[*scheduleringester.DbOperationsWithMessageIds],
+	sink ingest.Sink[*scheduleringester.DbOperationsWithMessageIds],
+) {
+	for {
+		msg, ok := b.next(ctx)
+		if !ok {
+			return
+		}
+		var err error
+		if scheduler := msg.Properties[schedulers.PropertyName]; scheduler == schedulers.PulsarSchedulerAttribute || scheduler == schedulers.AllSchedulersAttribute {
+			var sequence *armadaevents.EventSequence
+			sequence, err = eventutil.UnmarshalEventSequence(ctx, msg.Payload)
+			if err == nil {
+				ops := converter.Convert(ctx, &ingest.EventSequencesWithIds{EventSequences: []*armadaevents.EventSequence{sequence}})
+				err = sink.Store(ctx, ops)
+			}
+		}
+		b.mu.Lock()
+		b.numConsumed++
+		if err != nil && b.err == nil {
+			b.err = err
+		}
+		b.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// awaitIngested blocks until all messages published so far have been ingested.
+func (b *inProcessBroker) awaitIngested(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		b.mu.Lock()
+		numPublished, numConsumed, err := b.numPublished, b.numConsumed, b.err
+		b.mu.Unlock()
+		if err != nil {
+			return errors.WithMessage(err, "error ingesting message")
+		}
+		if numConsumed == numPublished {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out after %s with %d of %d messages ingested", timeout, numConsumed, numPublished)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// publishedSequences returns all event sequences published so far.
+func (b *inProcessBroker) publishedSequences() []*armadaevents.EventSequence {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*armadaevents.EventSequence(nil), b.sequences...)
+}
+
+// inProcessClient is a pulsar.Client creating producers publishing to an inProcessBroker.
+// Methods not needed by the scheduler panic.
+type inProcessClient struct {
+	pulsar.Client
+	broker *inProcessBroker
+}
+
+func (c *inProcessClient) CreateProducer(options pulsar.ProducerOptions) (pulsar.Producer, error) {
+	return &inProcessProducer{
+		broker: c.broker,
+		topic:  options.Topic,
+		name:   options.Name,
+		router: options.MessageRouter,
+	}, nil
+}
+
+func (c *inProcessClient) TopicPartitions(topic string) ([]string, error) {
+	partitions := make([]string, c.broker.numPartitions)
+	for i := range partitions {
+		partitions[i] = fmt.Sprintf("%s-partition-%d", topic, i)
+	}
+	return partitions, nil
+}
+
+func (c *inProcessClient) Close() {}
+
+// inProcessProducer is a pulsar.Producer publishing to an inProcessBroker.
+// Messages are published synchronously, even when sent with SendAsync.
+type inProcessProducer struct {
+	pulsar.Producer
+	broker *inProcessBroker
+	topic  string
+	name   string
+	router func(*pulsar.ProducerMessage, pulsar.TopicMetadata) int
+}
+
+func (p *inProcessProducer) Topic() string {
+	return p.topic
+}
+
+func (p *inProcessProducer) Name() string {
+	return p.name
+}
+
+func (p *inProcessProducer) Send(_ context.Context, msg *pulsar.ProducerMessage) (pulsar.MessageID, error) {
+	partition := int(JavaStringHash(msg.Key) % uint32(p.broker.numPartitions))
+	if p.router != nil {
+		partition = p.router(msg, p.broker)
+	}
+	return nil, p.broker.publish(partition, msg)
+}
+
+func (p *inProcessProducer) SendAsync(ctx context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+	id, err := p.Send(ctx, msg)
+	callback(id, msg, err)
+}
+
+func (p *inProcessProducer) Flush() error {
+	return nil
+}
+
+func (p *inProcessProducer) Close() {}
+
+// integrationJob identifies a job submitted via an integrationHarness.
+type integrationJob struct {
+	id     string
+	queue  string
+	jobSet string
+}
+
+// integrationHarness runs the scheduler, the executor api, and the ingester against a postgres database and an
+// inProcessBroker. Jobs are submitted and cancelled by publishing the events the Armada server would,
+// and executors are simulated by calling the executor api.
+type integrationHarness struct {
+	t       *testing.T
+	ctx     *armadacontext.Context
+	queries *database.Queries
+	broker  *inProcessBroker
+	// Used to publish the events the Armada server would.
+	serverProducer pulsar.Producer
+	scheduler      *Scheduler
+	executorApi    *ExecutorApi
+	initialised    bool
+	// Nodes of each executor registered with the harness.
+	nodesByExecutor map[string][]*api.NodeInfo
+	// Runs leased to each executor that have yet to terminate.
+	runsByExecutor map[string]map[uuid.UUID]bool
+}
+
+// withIntegrationHarness calls action with a harness backed by a fresh test database.
+// The queues the scheduler knows about are given by queues.
+func withIntegrationHarness(t *testing.T, queues []string, action func(h *integrationHarness)) {
+	err := database.WithTestDb(func(queries *database.Queries, db *pgxpool.Pool) error {
+		ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 2*time.Minute)
+		defer cancel()
+
+		config := testfixtures.TestSchedulingConfig()
+		config.Preemption.NodeIdLabel = testfixtures.TestHostnameLabel
+
+		// Ingest everything published until the test is over.
+		broker := newInProcessBroker(integrationNumPartitions)
+		compressor, err := compress.NewZlibCompressor(1024)
+		if err != nil {
+			return err
+		}
+		converter := scheduleringester.NewInstructionConverter(integrationIngesterMetrics, config.Preemption.PriorityClasses, compressor, false)
+		sink := scheduleringester.NewSchedulerDb(db, integrationIngesterMetrics, 100*time.Millisecond, time.Second, 10*time.Second)
+		consumerCtx, stopConsumer := armadacontext.WithCancel(ctx)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			broker.consume(consumerCtx, converter, sink)
+		}()
+		defer func() {
+			stopConsumer()
+			wg.Wait()
+		}()
+
+		client := &inProcessClient{broker: broker}
+		serverProducer, err := client.CreateProducer(pulsar.ProducerOptions{Name: "armada-server", Topic: integrationTopic})
+		if err != nil {
+			return err
+		}
+		apiProducer, err := client.CreateProducer(pulsar.ProducerOptions{Name: "executor-api", Topic: integrationTopic})
+		if err != nil {
+			return err
+		}
+		publisher, err := NewPulsarPublisher(client, pulsar.ProducerOptions{Name: "scheduler", Topic: integrationTopic}, 5*time.Second)
+		if err != nil {
+			return err
+		}
+
+		jobRepository := database.NewPostgresJobRepository(db, 1000)
+		executorRepository := database.NewPostgresExecutorRepository(db)
+		queueRepository := &testQueueRepository{}
+		for _, queue := range queues {
+			queueRepository.queues = append(queueRepository.queues, &database.Queue{Name: queue, Weight: 1})
+		}
+		schedulingAlgo, err := NewFairSchedulingAlgo(config, 0, executorRepository, queueRepository, nil)
+		if err != nil {
+			return err
+		}
+		scheduler, err := NewScheduler(
+			testfixtures.NewJobDb(),
+			jobRepository,
+			executorRepository,
+			schedulingAlgo,
+			NewStandaloneLeaderController(),
+			publisher,
+			&testSubmitChecker{checkSuccess: true},
+			time.Second,
+			time.Second,
+			time.Hour,
+			integrationMaxAttemptedRuns,
+			config.Preemption.NodeIdLabel,
+			schedulerMetrics,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+		executorApi, err := NewExecutorApi(
+			apiProducer,
+			jobRepository,
+			executorRepository,
+			executorRepository,
+			testfixtures.TestPriorities,
+			config.Preemption.NodeIdLabel,
+			nil,
+			integrationMaxMessageSize,
+		)
+		if err != nil {
+			return err
+		}
+
+		action(&integrationHarness{
+			t:               t,
+			ctx:             ctx,
+			queries:         queries,
+			broker:          broker,
+			serverProducer:  serverProducer,
+			scheduler:       scheduler,
+			executorApi:     executorApi,
+			nodesByExecutor: make(map[string][]*api.NodeInfo),
+			runsByExecutor:  make(map[string]map[uuid.UUID]bool),
+		})
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+// publish publishes events for job as the Armada server would and waits for them to be ingested.
+func (h *integrationHarness) publish(job integrationJob, events ...*armadaevents.EventSequence_Event) {
+	sequence := integrationEventSequence(job, events...)
+	err := pulsarutils.CompactAndPublishSequences(
+		h.ctx, []*armadaevents.EventSequence{sequence}, h.serverProducer, integrationMaxMessageSize, schedulers.Pulsar,
+	)
+	require.NoError(h.t, err)
+	h.awaitIngested()
+}
+
+// submit submits a job requesting 1 cpu and 4Gi of memory.
+func (h *integrationHarness) submit(queue, jobSet string) integrationJob {
+	job := integrationJob{id: util.NewULID(), queue: queue, jobSet: jobSet}
+	jobId, err := armadaevents.ProtoUuidFromUlidString(job.id)
+	require.NoError(h.t, err)
+	resources := v1.ResourceList{
+		"cpu":    resource.MustParse("1"),
+		"memory": resource.MustParse("4Gi"),
+	}
+	h.publish(job, &armadaevents.EventSequence_Event{
+		Created: integrationNow(),
+		Event: &armadaevents.EventSequence_Event_SubmitJob{
+			SubmitJob: &armadaevents.SubmitJob{
+				JobId:    jobId,
+				Priority: 1,
+				ObjectMeta: &armadaevents.ObjectMeta{
+					Namespace: "test-namespace",
+					Name:      "test-job",
+				},
+				MainObject: &armadaevents.KubernetesMainObject{
+					Object: &armadaevents.KubernetesMainObject_PodSpec{
+						PodSpec: &armadaevents.PodSpecWithAvoidList{
+							PodSpec: &v1.PodSpec{
+								PriorityClassName: testfixtures.PriorityClass3,
+								Containers: []v1.Container{
+									{
+										Name:      "container",
+										Image:     "alpine:latest",
+										Resources: v1.ResourceRequirements{Requests: resources, Limits: resources},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	return job
+}
+
+// cancel requests that job be cancelled.
+func (h *integrationHarness) cancel(job integrationJob) {
+	h.publish(job, &armadaevents.EventSequence_Event{
+		Created: integrationNow(),
+		Event: &armadaevents.EventSequence_Event_CancelJob{
+			CancelJob: &armadaevents.CancelJob{JobId: h.protoJobId(job)},
+		},
+	})
+}
+
+// registerExecutor makes an initial lease request on behalf of an executor with the named nodes,
+// each with 32 cpu and 256Gi of memory.
+func (h *integrationHarness) registerExecutor(executorId string, nodeNames ...string) {
+	nodes := make([]*api.NodeInfo, len(nodeNames))
+	for i, nodeName := range nodeNames {
+		nodes[i] = &api.NodeInfo{
+			Name:   nodeName,
+			Labels: map[string]string{testfixtures.TestHostnameLabel: nodeName},
+			TotalResources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("32"),
+				"memory": resource.MustParse("256Gi"),
+			},
+		}
+	}
+	h.nodesByExecutor[executorId] = nodes
+	h.runsByExecutor[executorId] = make(map[uuid.UUID]bool)
+	h.lease(executorId)
+}
+
+// lease makes a lease request on behalf of executorId, reporting the runs it holds, and returns the messages received.
+// Leased runs are added to and cancelled runs removed from those held by the executor.
+func (h *integrationHarness) lease(executorId string) []*executorapi.LeaseStreamMessage {
+	req := &executorapi.LeaseRequest{
+		ExecutorId:     executorId,
+		Pool:           integrationPool,
+		Nodes:          h.nodesByExecutor[executorId],
+		MaxJobsToLease: 100,
+	}
+	for runId := range h.runsByExecutor[executorId] {
+		req.UnassignedJobRunIds = append(req.UnassignedJobRunIds, *armadaevents.ProtoUuidFromUuid(runId))
+	}
+
+	ctrl := gomock.NewController(h.t)
+	stream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+	stream.EXPECT().Context().Return(h.ctx).AnyTimes()
+	stream.EXPECT().Recv().Return(req, nil).Times(1)
+	var msgs []*executorapi.LeaseStreamMessage
+	stream.EXPECT().Send(gomock.Any()).
+		Do(func(msg *executorapi.LeaseStreamMessage) {
+			msgs = append(msgs, msg)
+		}).AnyTimes()
+	require.NoError(h.t, h.executorApi.LeaseJobRuns(stream))
+
+	for _, lease := range leasesIn(msgs) {
+		h.runsByExecutor[executorId][armadaevents.UuidFromProtoUuid(lease.JobRunId)] = true
+	}
+	for _, runId := range cancelledRunsIn(msgs) {
+		delete(h.runsByExecutor[executorId], runId)
+	}
+	return msgs
+}
+
+// report reports events for job as an executor would and waits for them to be ingested.
+// Runs that terminate are removed from those held by executors.
+func (h *integrationHarness) report(job integrationJob, events ...*armadaevents.EventSequence_Event) {
+	_, err := h.executorApi.ReportEvents(h.ctx, &executorapi.EventList{
+		Events: []*armadaevents.EventSequence{integrationEventSequence(job, events...)},
+	})
+	require.NoError(h.t, err)
+	for _, event := range events {
+		var runId *armadaevents.Uuid
+		switch e := event.Event.(type) {
+		case *armadaevents.EventSequence_Event_JobRunSucceeded:
+			runId = e.JobRunSucceeded.RunId
+		case *armadaevents.EventSequence_Event_JobRunErrors:
+			if hasTerminalError(e.JobRunErrors.Errors) {
+				runId = e.JobRunErrors.RunId
+			}
+		}
+		if runId != nil {
+			for _, runs := range h.runsByExecutor {
+				delete(runs, armadaevents.UuidFromProtoUuid(runId))
+			}
+		}
+	}
+	h.awaitIngested()
+}
+
+// cycle runs a scheduler cycle and waits for the events it publishes to be ingested.
+// Before the first cycle, the scheduler is initialised as it would be on becoming leader.
+func (h *integrationHarness) cycle() {
+	updateAll := false
+	if !h.initialised {
+		require.NoError(h.t, h.scheduler.initialise(h.ctx))
+		require.NoError(h.t, h.scheduler.ensureDbUpToDate(h.ctx, 10*time.Millisecond))
+		h.initialised = true
+		updateAll = true
+	}
+	_, err := h.scheduler.cycle(h.ctx, updateAll, h.scheduler.leaderController.GetToken(), true)
+	require.NoError(h.t, err)
+	h.awaitIngested()
+}
+
+func (h *integrationHarness) awaitIngested() {
+	require.NoError(h.t, h.broker.awaitIngested(integrationIngestTimeout))
+}
+
+// dbJob returns the row of the jobs table for job.
+func (h *integrationHarness) dbJob(job integrationJob) database.Job {
+	jobs, err := h.queries.SelectNewJobs(h.ctx, database.SelectNewJobsParams{Serial: -1, Limit: 10000})
+	require.NoError(h.t, err)
+	for _, dbJob := range jobs {
+		if dbJob.JobID == job.id {
+			return dbJob
+		}
+	}
+	require.FailNow(h.t, "job not found in database", "job %s", job.id)
+	return database.Job{}
+}
+
+// dbRuns returns the rows of the runs table for job, in the order they were created.
+func (h *integrationHarness) dbRuns(job integrationJob) []database.Run {
+	runs, err := h.queries.SelectNewRunsForJobs(h.ctx, database.SelectNewRunsForJobsParams{Serial: -1, JobIds: []string{job.id}})
+	require.NoError(h.t, err)
+	return runs
+}
+
+// eventTypes returns the types of all events published for job, in the order they were published,
+// e.g., "SubmitJob" for *armadaevents.EventSequence_Event_SubmitJob.
+func (h *integrationHarness) eventTypes(job integrationJob) []string {
+	var eventTypes []string
+	for _, sequence := range h.broker.publishedSequences() {
+		for _, event := range sequence.Events {
+			protoJobId, err := armadaevents.JobIdFromEvent(event)
+			if err != nil {
+				continue
+			}
+			if jobId, err := armadaevents.UlidStringFromProtoUuid(protoJobId); err != nil || jobId != job.id {
+				continue
+			}
+			eventTypes = append(eventTypes, strings.TrimPrefix(fmt.Sprintf("%T", event.Event), "*armadaevents.EventSequence_Event_"))
+		}
+	}
+	return eventTypes
+}
+
+func (h *integrationHarness) protoJobId(job integrationJob) *armadaevents.Uuid {
+	jobId, err := armadaevents.ProtoUuidFromUlidString(job.id)
+	require.NoError(h.t, err)
+	return jobId
+}
+
+func (h *integrationHarness) runningEvent(job integrationJob, runId uuid.UUID) *armadaevents.EventSequence_Event {
+	return &armadaevents.EventSequence_Event{
+		Created: integrationNow(),
+		Event: &armadaevents.EventSequence_Event_JobRunRunning{
+			JobRunRunning: &armadaevents.JobRunRunning{
+				RunId: armadaevents.ProtoUuidFromUuid(runId),
+				JobId: h.protoJobId(job),
+			},
+		},
+	}
+}
+
+func (h *integrationHarness) succeededEvent(job integrationJob, runId uuid.UUID) *armadaevents.EventSequence_Event {
+	return &armadaevents.EventSequence_Event{
+		Created: integrationNow(),
+		Event: &armadaevents.EventSequence_Event_JobRunSucceeded{
+			JobRunSucceeded: &armadaevents.JobRunSucceeded{
+				RunId: armadaevents.ProtoUuidFromUuid(runId),
+				JobId: h.protoJobId(job),
+			},
+		},
+	}
+}
+
+func (h *integrationHarness) runErrorsEvent(job integrationJob, runId uuid.UUID, runError *armadaevents.Error) *armadaevents.EventSequence_Event {
+	return &armadaevents.EventSequence_Event{
+		Created: integrationNow(),
+		Event: &armadaevents.EventSequence_Event_JobRunErrors{
+			JobRunErrors: &armadaevents.JobRunErrors{
+				RunId:  armadaevents.ProtoUuidFromUuid(runId),
+				JobId:  h.protoJobId(job),
+				Errors: []*armadaevents.Error{runError},
+			},
+		},
+	}
+}
+
+func integrationEventSequence(job integrationJob, events ...*armadaevents.EventSequence_Event) *armadaevents.EventSequence {
+	return &armadaevents.EventSequence{
+		Queue:      job.queue,
+		JobSetName: job.jobSet,
+		UserId:     "test-user",
+		Groups:     []string{"test-group"},
+		Events:     events,
+	}
+}
+
+func integrationNow() *time.Time {
+	now := time.Now().UTC()
+	return &now
+}
+
+func hasTerminalError(errs []*armadaevents.Error) bool {
+	for _, err := range errs {
+		if err.Terminal {
+			return true
+		}
+	}
+	return false
+}
+
+// leasesIn returns the leases in msgs.
+func leasesIn(msgs []*executorapi.LeaseStreamMessage) []*executorapi.JobRunLease {
+	var leases []*executorapi.JobRunLease
+	for _, msg := range msgs {
+		if lease := msg.GetLease(); lease != nil {
+			leases = append(leases, lease)
+		}
+	}
+	return leases
+}
+
+// cancelledRunsIn returns the ids of the runs cancelled by msgs.
+func cancelledRunsIn(msgs []*executorapi.LeaseStreamMessage) []uuid.UUID {
+	var runIds []uuid.UUID
+	for _, msg := range msgs {
+		for _, runId := range msg.GetCancelRuns().GetJobRunIdsToCancel() {
+			runIds = append(runIds, armadaevents.UuidFromProtoUuid(runId))
+		}
+	}
+	return runIds
+}
+
+// requireSubsequence fails the test unless expected appears in actual in order, possibly interleaved with other elements.
+func requireSubsequence(t *testing.T, expected, actual []string) {
+	i := 0
+	for _, s := range actual {
+		if i < len(expected) && s == expected[i] {
+			i++
+		}
+	}
+	require.Equal(t, len(expected), i, "expected %v to be a subsequence of %v", expected, actual)
+}
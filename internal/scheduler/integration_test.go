@@ -0,0 +1,141 @@
+//go:build integration
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/pkg/api"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestSchedulerIntegration_JobIsLeasedAndSucceeds(t *testing.T) {
+	withIntegrationHarness(t, []string{"queue-a"}, func(h *integrationHarness) {
+		h.registerExecutor("executor-1", "node-1")
+		job := h.submit("queue-a", "job-set-a")
+		assert.True(t, h.dbJob(job).Queued)
+
+		h.cycle()
+		runs := h.dbRuns(job)
+		require.Len(t, runs, 1)
+		assert.Equal(t, "executor-1", runs[0].Executor)
+		assert.Equal(t, api.NodeIdFromExecutorAndNodeName("executor-1", "node-1"), runs[0].Node)
+		assert.False(t, h.dbJob(job).Queued)
+
+		leases := leasesIn(h.lease("executor-1"))
+		require.Len(t, leases, 1)
+		assert.Equal(t, runs[0].RunID, armadaevents.UuidFromProtoUuid(leases[0].JobRunId))
+		// The lease isn't sent again while the executor holds the run.
+		assert.Empty(t, leasesIn(h.lease("executor-1")))
+
+		h.report(job, h.runningEvent(job, runs[0].RunID))
+		h.report(job, h.succeededEvent(job, runs[0].RunID))
+		h.cycle()
+		assert.True(t, h.dbJob(job).Succeeded)
+		assert.True(t, h.dbRuns(job)[0].Succeeded)
+		requireSubsequence(t, []string{"SubmitJob", "JobRunLeased", "JobRunRunning", "JobRunSucceeded", "JobSucceeded"}, h.eventTypes(job))
+	})
+}
+
+func TestSchedulerIntegration_ReturnedLeaseIsRetriedOnAnotherNode(t *testing.T) {
+	withIntegrationHarness(t, []string{"queue-a"}, func(h *integrationHarness) {
+		h.registerExecutor("executor-1", "node-1", "node-2")
+		job := h.submit("queue-a", "job-set-a")
+		h.cycle()
+		h.lease("executor-1")
+		firstRun := h.dbRuns(job)[0]
+
+		h.report(job, h.runErrorsEvent(job, firstRun.RunID, &armadaevents.Error{
+			Terminal: true,
+			Reason: &armadaevents.Error_PodLeaseReturned{
+				PodLeaseReturned: &armadaevents.PodLeaseReturned{Message: "lease returned", RunAttempted: true},
+			},
+		}))
+		h.cycle()
+		h.cycle()
+
+		runs := h.dbRuns(job)
+		require.Len(t, runs, 2)
+		assert.True(t, runs[0].Failed)
+		assert.True(t, runs[0].Returned)
+		assert.False(t, runs[1].Failed)
+		assert.NotEqual(t, runs[0].Node, runs[1].Node)
+		dbJob := h.dbJob(job)
+		assert.False(t, dbJob.Queued)
+		assert.False(t, dbJob.Failed)
+		requireSubsequence(t, []string{"SubmitJob", "JobRunLeased", "JobRunErrors", "JobRequeued", "JobRunLeased"}, h.eventTypes(job))
+
+		leases := leasesIn(h.lease("executor-1"))
+		require.Len(t, leases, 1)
+		assert.Equal(t, runs[1].RunID, armadaevents.UuidFromProtoUuid(leases[0].JobRunId))
+	})
+}
+
+func TestSchedulerIntegration_RunFailureFailsJob(t *testing.T) {
+	withIntegrationHarness(t, []string{"queue-a"}, func(h *integrationHarness) {
+		h.registerExecutor("executor-1", "node-1")
+		job := h.submit("queue-a", "job-set-a")
+		h.cycle()
+		h.lease("executor-1")
+		run := h.dbRuns(job)[0]
+
+		h.report(job, h.runningEvent(job, run.RunID))
+		h.report(job, h.runErrorsEvent(job, run.RunID, &armadaevents.Error{
+			Terminal: true,
+			Reason: &armadaevents.Error_PodError{
+				PodError: &armadaevents.PodError{
+					Message:         "container exited",
+					NodeName:        "node-1",
+					ContainerErrors: []*armadaevents.ContainerError{{ExitCode: 1}},
+				},
+			},
+		}))
+		h.cycle()
+
+		require.Len(t, h.dbRuns(job), 1)
+		assert.True(t, h.dbRuns(job)[0].Failed)
+		assert.True(t, h.dbJob(job).Failed)
+		requireSubsequence(t, []string{"SubmitJob", "JobRunLeased", "JobRunRunning", "JobRunErrors", "JobErrors"}, h.eventTypes(job))
+		assert.NotContains(t, h.eventTypes(job), "JobRequeued")
+	})
+}
+
+func TestSchedulerIntegration_CancelledJobRunIsCancelledOnExecutor(t *testing.T) {
+	withIntegrationHarness(t, []string{"queue-a"}, func(h *integrationHarness) {
+		h.registerExecutor("executor-1", "node-1")
+		job := h.submit("queue-a", "job-set-a")
+		h.cycle()
+		h.lease("executor-1")
+		run := h.dbRuns(job)[0]
+
+		h.cancel(job)
+		assert.True(t, h.dbJob(job).CancelRequested)
+		h.cycle()
+
+		assert.True(t, h.dbJob(job).Cancelled)
+		requireSubsequence(t, []string{"SubmitJob", "JobRunLeased", "CancelJob", "CancelledJob"}, h.eventTypes(job))
+		assert.Equal(t, []uuid.UUID{run.RunID}, cancelledRunsIn(h.lease("executor-1")))
+	})
+}
+
+func TestSchedulerIntegration_JobsOfDifferentQueuesAreLeased(t *testing.T) {
+	withIntegrationHarness(t, []string{"queue-a", "queue-b"}, func(h *integrationHarness) {
+		h.registerExecutor("executor-1", "node-1")
+		jobs := []integrationJob{
+			h.submit("queue-a", "job-set-a"),
+			h.submit("queue-a", "job-set-b"),
+			h.submit("queue-b", "job-set-a"),
+		}
+		h.cycle()
+
+		for _, job := range jobs {
+			require.Len(t, h.dbRuns(job), 1)
+			requireSubsequence(t, []string{"SubmitJob", "JobRunLeased"}, h.eventTypes(job))
+		}
+		assert.Len(t, leasesIn(h.lease("executor-1")), len(jobs))
+	})
+}
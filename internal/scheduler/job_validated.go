@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// EnableJobValidatedEvents causes a JobValidated event to be published for each job the first time it's admitted,
+// i.e., found queued without ever having been leased. Whether the event was published is stored in the database
+// by the ingester, such that it's published at most once per job, including across failovers.
+func (s *Scheduler) EnableJobValidatedEvents() {
+	s.publishJobValidatedEvents = true
+}
+
+// unacknowledgedJobIds returns the ids of the jobs among jobs that are queued, have never been leased,
+// and for which no JobValidated event has been published yet.
+func unacknowledgedJobIds(jobs []*jobdb.Job) []string {
+	var jobIds []string
+	for _, job := range jobs {
+		if job.Acknowledged() || !job.Queued() || job.EverLeased() || job.InTerminalState() {
+			continue
+		}
+		jobIds = append(jobIds, job.Id())
+	}
+	return jobIds
+}
+
+// generateJobValidatedEvents returns a JobValidated event for each job with an id in jobIds that's still in txn
+// and not in a terminal state, and marks these jobs as acknowledged in txn.
+// Jobs leased since their id was collected, e.g., by urgent scheduling, are acknowledged too.
+func (s *Scheduler) generateJobValidatedEvents(ctx *armadacontext.Context, txn *jobdb.Txn, jobIds []string) ([]*armadaevents.EventSequence, error) {
+	cycleId := cycleIdFromContext(ctx)
+	events := make([]*armadaevents.EventSequence, 0, len(jobIds))
+	acknowledgedJobs := make([]*jobdb.Job, 0, len(jobIds))
+	for _, jobId := range jobIds {
+		job := txn.GetById(jobId)
+		if job == nil || job.Acknowledged() || job.InTerminalState() {
+			continue
+		}
+		protoJobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
+		if err != nil {
+			return nil, err
+		}
+		priorityClassName, defaultPriorityClassApplied := s.jobDb.EffectivePriorityClassName(job)
+		events = append(events, &armadaevents.EventSequence{
+			Queue:      job.Queue(),
+			JobSetName: job.Jobset(),
+			Events: []*armadaevents.EventSequence_Event{
+				{
+					Created: s.now(),
+					Event: &armadaevents.EventSequence_Event_JobValidated{
+						JobValidated: &armadaevents.JobValidated{
+							JobId:                       protoJobId,
+							EffectivePriority:           job.Priority(),
+							PriorityClassName:           priorityClassName,
+							DefaultPriorityClassApplied: defaultPriorityClassApplied,
+							PriorityClamped:             job.PriorityClamped(),
+							CycleId:                     cycleId,
+						},
+					},
+				},
+			},
+		})
+		acknowledgedJobs = append(acknowledgedJobs, job.WithAcknowledged(true))
+	}
+	if err := txn.Upsert(acknowledgedJobs); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
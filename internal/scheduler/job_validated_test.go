@@ -0,0 +1,235 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	protoutil "github.com/armadaproject/armada/internal/common/proto"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_JobValidatedEvents(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+
+	newJobRepoJob := func(priorityClassName string) database.Job {
+		schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+			PriorityClassName: priorityClassName,
+			ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+				{
+					Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+						PodRequirements: &schedulerobjects.PodRequirements{},
+					},
+				},
+			},
+			Version: 1,
+		}
+		return database.Job{
+			JobID:          util.NewULID(),
+			JobSet:         "testJobset",
+			Queue:          "testQueue",
+			Priority:       3,
+			Queued:         true,
+			Submitted:      testfixtures.BaseTime.UnixNano(),
+			SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+		}
+	}
+	// Admitted jobs; the first is leased in the cycle in which it's admitted.
+	leasedJob := newJobRepoJob(testfixtures.PriorityClass0)
+	defaultedJob := newJobRepoJob("")
+	// Jobs that aren't acknowledged, since they were leased before, have been acknowledged before, or were cancelled.
+	requeuedJob := newJobRepoJob(testfixtures.PriorityClass0)
+	requeuedJob.QueuedVersion = 2
+	acknowledgedJob := newJobRepoJob(testfixtures.PriorityClass0)
+	acknowledgedJob.Acknowledged = true
+	cancelledJob := newJobRepoJob(testfixtures.PriorityClass0)
+	cancelledJob.Cancelled = true
+	jobRepo := &testJobRepository{
+		updatedJobs: []database.Job{leasedJob, defaultedJob, requeuedJob, acknowledgedJob, cancelledJob},
+	}
+
+	publisher := &testPublisher{}
+	newScheduler := func() *Scheduler {
+		sched, err := NewScheduler(
+			testfixtures.NewJobDb(),
+			jobRepo,
+			&testExecutorRepository{},
+			&testSchedulingAlgo{jobsToSchedule: []string{leasedJob.JobID}},
+			NewStandaloneLeaderController(),
+			publisher,
+			&testSubmitChecker{checkSuccess: true},
+			1*time.Second,
+			5*time.Second,
+			1*time.Hour,
+			maxNumberOfAttempts,
+			nodeIdLabel,
+			schedulerMetrics,
+			nil,
+		)
+		require.NoError(t, err)
+		sched.clock = clock.NewFakeClock(testfixtures.BaseTime)
+		sched.EnableJobValidatedEvents()
+		return sched
+	}
+	// Returns the JobValidated events published by the last cycle by job id,
+	// failing if more than one was published for any job.
+	jobValidatedEvents := func() map[string]*armadaevents.JobValidated {
+		rv := make(map[string]*armadaevents.JobValidated)
+		for _, sequence := range publisher.events {
+			for _, event := range sequence.Events {
+				if jobValidated := event.GetJobValidated(); jobValidated != nil {
+					jobId := ulidStringFromProtoUuid(t, jobValidated.JobId)
+					require.NotContains(t, rv, jobId)
+					rv[jobId] = jobValidated
+				}
+			}
+		}
+		return rv
+	}
+
+	// The jobs are acknowledged in the cycle in which they're admitted; the one leased in the same cycle is
+	// acknowledged before it's leased.
+	sched := newScheduler()
+	_, err := sched.cycle(withCycleId(ctx, "cycle-0"), false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		map[string]*armadaevents.JobValidated{
+			leasedJob.JobID: {
+				JobId:             protoUuidFromUlidString(t, leasedJob.JobID),
+				EffectivePriority: 3,
+				PriorityClassName: testfixtures.PriorityClass0,
+				CycleId:           "cycle-0",
+			},
+			defaultedJob.JobID: {
+				JobId:                       protoUuidFromUlidString(t, defaultedJob.JobID),
+				EffectivePriority:           3,
+				PriorityClassName:           testfixtures.TestDefaultPriorityClass,
+				DefaultPriorityClassApplied: true,
+				CycleId:                     "cycle-0",
+			},
+		},
+		jobValidatedEvents(),
+	)
+	var eventTypes []string
+	for _, sequence := range publisher.events {
+		for _, event := range sequence.Events {
+			if jobId, err := armadaevents.JobIdFromEvent(event); err == nil && ulidStringFromProtoUuid(t, jobId) == leasedJob.JobID {
+				eventTypes = append(eventTypes, fmt.Sprintf("%T", event.Event))
+			}
+		}
+	}
+	assert.Equal(t, []string{"*armadaevents.EventSequence_Event_JobValidated", "*armadaevents.EventSequence_Event_JobRunLeased"}, eventTypes)
+
+	// The jobs aren't acknowledged again while the database doesn't yet record that they were acknowledged,
+	// including when generating events for all jobs.
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.Empty(t, jobValidatedEvents())
+	_, err = sched.cycle(ctx, true, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.Empty(t, jobValidatedEvents())
+
+	// After a failover, the new leader loads the jobs once the database records that they were acknowledged.
+	for i := range jobRepo.updatedJobs {
+		if jobRepo.updatedJobs[i].JobID == leasedJob.JobID || jobRepo.updatedJobs[i].JobID == defaultedJob.JobID {
+			jobRepo.updatedJobs[i].Acknowledged = true
+		}
+	}
+	sched = newScheduler()
+	_, err = sched.cycle(ctx, true, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.Empty(t, jobValidatedEvents())
+}
+
+func TestScheduler_JobValidatedEventsRetriedIfPublishingFails(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		PriorityClassName: testfixtures.PriorityClass0,
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+		Version: 1,
+	}
+	jobId := util.NewULID()
+	jobRepo := &testJobRepository{
+		updatedJobs: []database.Job{
+			{
+				JobID:          jobId,
+				JobSet:         "testJobset",
+				Queue:          "testQueue",
+				Queued:         true,
+				SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+			},
+		},
+	}
+	publisher := &testPublisher{shouldError: true}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		publisher,
+		&testSubmitChecker{checkSuccess: true},
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	sched.EnableJobValidatedEvents()
+
+	numJobValidatedEvents := func() int {
+		n := 0
+		for _, sequence := range publisher.events {
+			for _, event := range sequence.Events {
+				if event.GetJobValidated() != nil {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	// The job isn't marked as acknowledged if publishing fails, such that the event is published by the next cycle.
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.Error(t, err)
+	assert.False(t, sched.jobDb.ReadTxn().GetById(jobId).Acknowledged())
+
+	publisher.shouldError = false
+	_, err = sched.cycle(ctx, true, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, numJobValidatedEvents())
+	assert.True(t, sched.jobDb.ReadTxn().GetById(jobId).Acknowledged())
+
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, numJobValidatedEvents())
+}
+
+func protoUuidFromUlidString(t *testing.T, id string) *armadaevents.Uuid {
+	protoId, err := armadaevents.ProtoUuidFromUlidString(id)
+	require.NoError(t, err)
+	return protoId
+}
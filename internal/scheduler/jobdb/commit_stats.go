@@ -0,0 +1,25 @@
+package jobdb
+
+import "time"
+
+// CommitStats describes the changes made by a committed write transaction.
+type CommitStats struct {
+	// Time taken to commit, including waiting for readers copying the jobDb to finish.
+	Duration time.Duration
+	// Number of jobs upserted by the transaction. Jobs upserted several times are counted once for each upsert.
+	NumUpserted int
+	// Number of jobs deleted by the transaction.
+	NumDeleted int
+	// Time spent inserting jobs into, and deleting jobs from, the tree storing jobs by id.
+	// Only measured if the commit breakdown is enabled; zero otherwise.
+	TreeCopyDuration time.Duration
+	// Time spent updating the indices of the jobDb, i.e., of runs, queued jobs, and gangs, summed over the indices.
+	// Since indices are updated in parallel, this may exceed the wall-clock time taken.
+	// Only measured if the commit breakdown is enabled; zero otherwise.
+	IndexUpdateDuration time.Duration
+}
+
+// CommitObserver is notified of each write transaction committed to a jobDb.
+type CommitObserver interface {
+	ObserveCommit(stats CommitStats)
+}
@@ -1,7 +1,7 @@
 package jobdb
 
 import (
-	"time"
+	"bytes"
 
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
 )
@@ -9,12 +9,16 @@ import (
 type (
 	JobPriorityComparer struct{}
 	JobQueueTtlComparer struct{}
+	// JobSubmitTimeComparer orders jobs by submission time, earliest first, tie-breaking by id.
+	JobSubmitTimeComparer struct{}
+	// JobRunCreatedComparer orders runs by creation time, earliest first, tie-breaking by id.
+	JobRunCreatedComparer struct{}
 )
 
-// Compare jobs by their remaining queue time before expiry
+// Compare jobs by their remaining queue time before expiry,
+// i.e., by the time at which their queue ttl expires if they remain queued.
 // Invariants:
 //   - Job.queueTtl must be > 0
-//   - Job.created must be < `t`
 func (j JobQueueTtlComparer) Compare(a, b *Job) int {
 	// Jobs with equal id are always considered equal.
 	// This ensures at most one job with a particular id can exist in the jobDb.
@@ -22,17 +26,11 @@ func (j JobQueueTtlComparer) Compare(a, b *Job) int {
 		return 0
 	}
 
-	// TODO: Calling time.Now() here doesn't sound right. We should probably sort by earliest expiry time.
-	timeSeconds := time.Now().UTC().Unix()
-	aDuration := timeSeconds - (a.submittedTime / 1_000_000_000)
-	bDuration := timeSeconds - (b.submittedTime / 1_000_000_000)
-
-	aRemaining := max(0, a.GetQueueTtlSeconds()-aDuration)
-	bRemaining := max(0, b.GetQueueTtlSeconds()-bDuration)
-
 	// If jobs have different ttl remaining, they are ordered by remaining queue ttl - the smallest ttl first.
-	if aRemaining != bRemaining {
-		if aRemaining < bRemaining {
+	aExpiry := a.queueTtlExpiry()
+	bExpiry := b.queueTtlExpiry()
+	if aExpiry != bExpiry {
+		if aExpiry < bExpiry {
 			return -1
 		} else {
 			return 1
@@ -48,6 +46,35 @@ func (j JobQueueTtlComparer) Compare(a, b *Job) int {
 	panic("We should never get here. Since we check for job id equality at the top of this function.")
 }
 
+func (JobSubmitTimeComparer) Compare(a, b *Job) int {
+	if a.id == b.id {
+		return 0
+	}
+	if a.submittedTime != b.submittedTime {
+		if a.submittedTime < b.submittedTime {
+			return -1
+		}
+		return 1
+	}
+	if a.id < b.id {
+		return -1
+	}
+	return 1
+}
+
+func (JobRunCreatedComparer) Compare(a, b *JobRun) int {
+	if a.id == b.id {
+		return 0
+	}
+	if a.created != b.created {
+		if a.created < b.created {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(a.id[:], b.id[:])
+}
+
 func max(x, y int64) int64 {
 	if x < y {
 		return y
@@ -73,6 +100,26 @@ func (job *Job) SchedulingOrderCompare(other interfaces.LegacySchedulerJob) int
 //   - -1 if job should be scheduled before other,
 //   - +1 if other should be scheduled before other.
 func SchedulingOrderCompare(job, other *Job) int {
+	return schedulingOrderCompare(job, other, false, false)
+}
+
+// UsageAwareSchedulingOrderCompare is like SchedulingOrderCompare, except that active jobs for which executors
+// have reported resource usage are ordered by that usage instead of by how long they've been running.
+// Jobs that have consumed more resources are rescheduled first, such that preemption favours victims
+// that have done the least work. Usage is advisory; jobs without reported usage are ordered as by SchedulingOrderCompare.
+func UsageAwareSchedulingOrderCompare(job, other *Job) int {
+	return schedulingOrderCompare(job, other, true, false)
+}
+
+// GangAwareSchedulingOrderCompare is like SchedulingOrderCompare, or UsageAwareSchedulingOrderCompare if usageAware is true,
+// except that active gang jobs are rescheduled before active non-gang jobs of equal priority.
+// Since the evicted jobs rescheduled last are the ones preempted, this causes preemption to favour non-gang victims,
+// since preempting a gang wastes the work done by all its members.
+func GangAwareSchedulingOrderCompare(job, other *Job, usageAware bool) int {
+	return schedulingOrderCompare(job, other, usageAware, true)
+}
+
+func schedulingOrderCompare(job, other *Job, usageAware, gangAware bool) int {
 	// Jobs with equal id are always considered equal.
 	// This ensures at most one job with a particular id can exist in the jobDb.
 	if job.id == other.id {
@@ -104,6 +151,43 @@ func SchedulingOrderCompare(job, other *Job) int {
 		return 1
 	}
 
+	// Nudged jobs come first within their priority band.
+	if job.nudged && !other.nudged {
+		return -1
+	} else if !job.nudged && other.nudged {
+		return 1
+	}
+
+	// If both jobs are active, gang jobs come first.
+	if jobIsActive && otherIsActive && gangAware {
+		jobIsGangJob := job.GangId() != ""
+		otherIsGangJob := other.GangId() != ""
+		if jobIsGangJob && !otherIsGangJob {
+			return -1
+		} else if !jobIsGangJob && otherIsGangJob {
+			return 1
+		}
+	}
+
+	// If both jobs are active and have reported usage, jobs that have consumed more cpu come first,
+	// followed by those with a larger working set.
+	if jobIsActive && otherIsActive && usageAware {
+		jobUsage, jobHasUsage := job.activeRun.ResourceUsage()
+		otherUsage, otherHasUsage := other.activeRun.ResourceUsage()
+		if jobHasUsage && otherHasUsage {
+			if jobUsage.CpuSeconds > otherUsage.CpuSeconds {
+				return -1
+			} else if jobUsage.CpuSeconds < otherUsage.CpuSeconds {
+				return 1
+			}
+			if jobUsage.WorkingSetBytes > otherUsage.WorkingSetBytes {
+				return -1
+			} else if jobUsage.WorkingSetBytes < otherUsage.WorkingSetBytes {
+				return 1
+			}
+		}
+	}
+
 	// If both jobs are active, order by time since the job was scheduled.
 	// This ensures jobs that have been running for longer are rescheduled first,
 	// which reduces wasted compute time when preempting.
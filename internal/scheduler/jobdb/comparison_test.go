@@ -2,10 +2,13 @@ package jobdb
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/types"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
 func TestJobPriorityComparer(t *testing.T) {
@@ -34,6 +37,16 @@ func TestJobPriorityComparer(t *testing.T) {
 			b:        &Job{id: "b", priority: 1, priorityClass: types.PriorityClass{Priority: 1}},
 			expected: 1,
 		},
+		"Nudged jobs come first within their priority band": {
+			a:        &Job{id: "a", priority: 1, priorityClass: types.PriorityClass{Priority: 1}, submittedTime: 1},
+			b:        &Job{id: "b", priority: 1, priorityClass: types.PriorityClass{Priority: 1}, submittedTime: 2, nudged: true},
+			expected: 1,
+		},
+		"Nudged jobs don't jump ahead of higher priority jobs": {
+			a:        &Job{id: "a", priority: 1, priorityClass: types.PriorityClass{Priority: 1}},
+			b:        &Job{id: "b", priority: 2, priorityClass: types.PriorityClass{Priority: 1}, nudged: true},
+			expected: -1,
+		},
 		"Queued jobs are ordered third by decreasing submit time": {
 			a:        &Job{id: "a", priority: 1, priorityClass: types.PriorityClass{Priority: 1}, submittedTime: 2},
 			b:        &Job{id: "b", priority: 1, priorityClass: types.PriorityClass{Priority: 1}, submittedTime: 1},
@@ -51,7 +64,7 @@ func TestJobPriorityComparer(t *testing.T) {
 		},
 		"Running jobs come before queued jobs": {
 			a:        &Job{id: "a", priority: 1},
-			b:        (&Job{id: "b", priority: 2}).WithNewRun("", "", "", 0),
+			b:        (&Job{id: "b", priority: 2}).WithNewRun("", "", "", 0, time.Now()),
 			expected: 1,
 		},
 		"Running jobs are ordered third by runtime": {
@@ -70,3 +83,152 @@ func TestJobPriorityComparer(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageAwareSchedulingOrderCompare(t *testing.T) {
+	runningJob := func(id string, created int64, usage *RunResourceUsage) *Job {
+		run := &JobRun{created: created}
+		if usage != nil {
+			run = run.WithResourceUsage(*usage)
+		}
+		return (&Job{id: id, priority: 1, priorityClass: types.PriorityClass{Priority: 1}}).WithUpdatedRun(run)
+	}
+	tests := map[string]struct {
+		a                  *Job
+		b                  *Job
+		expected           int
+		expectedUsageAware int
+	}{
+		"Running jobs without usage are ordered by runtime": {
+			a:                  runningJob("a", 1, nil),
+			b:                  runningJob("b", 0, nil),
+			expected:           1,
+			expectedUsageAware: 1,
+		},
+		"Running jobs are ordered by runtime if only one has usage": {
+			a:                  runningJob("a", 1, &RunResourceUsage{CpuSeconds: 100}),
+			b:                  runningJob("b", 0, nil),
+			expected:           1,
+			expectedUsageAware: 1,
+		},
+		"Running jobs that have used more cpu come first": {
+			a:                  runningJob("a", 1, &RunResourceUsage{CpuSeconds: 100}),
+			b:                  runningJob("b", 0, &RunResourceUsage{CpuSeconds: 10}),
+			expected:           1,
+			expectedUsageAware: -1,
+		},
+		"Running jobs with a larger working set come first if they've used the same cpu": {
+			a:                  runningJob("a", 1, &RunResourceUsage{CpuSeconds: 10, WorkingSetBytes: 2}),
+			b:                  runningJob("b", 0, &RunResourceUsage{CpuSeconds: 10, WorkingSetBytes: 1}),
+			expected:           1,
+			expectedUsageAware: -1,
+		},
+		"Running jobs with equal usage are ordered by runtime": {
+			a:                  runningJob("a", 1, &RunResourceUsage{CpuSeconds: 10, WorkingSetBytes: 1}),
+			b:                  runningJob("b", 0, &RunResourceUsage{CpuSeconds: 10, WorkingSetBytes: 1}),
+			expected:           1,
+			expectedUsageAware: 1,
+		},
+		"Usage doesn't take precedence over priority": {
+			a:                  (&Job{id: "a", priority: 2, priorityClass: types.PriorityClass{Priority: 1}}).WithUpdatedRun((&JobRun{created: 0}).WithResourceUsage(RunResourceUsage{CpuSeconds: 100})),
+			b:                  runningJob("b", 1, &RunResourceUsage{CpuSeconds: 10}),
+			expected:           1,
+			expectedUsageAware: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SchedulingOrderCompare(tc.a, tc.b))
+			assert.Equal(t, tc.expectedUsageAware, UsageAwareSchedulingOrderCompare(tc.a, tc.b))
+			assert.Equal(t, -tc.expectedUsageAware, UsageAwareSchedulingOrderCompare(tc.b, tc.a))
+		})
+	}
+}
+
+func TestGangAwareSchedulingOrderCompare(t *testing.T) {
+	runningJob := func(id string, created int64, gangId string) *Job {
+		schedulingInfo := &schedulerobjects.JobSchedulingInfo{}
+		if gangId != "" {
+			schedulingInfo.ObjectRequirements = []*schedulerobjects.ObjectRequirements{
+				{
+					Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+						PodRequirements: &schedulerobjects.PodRequirements{
+							Annotations: map[string]string{configuration.GangIdAnnotation: gangId},
+						},
+					},
+				},
+			}
+		}
+		job := &Job{id: id, priority: 1, priorityClass: types.PriorityClass{Priority: 1}, jobSchedulingInfo: schedulingInfo}
+		return job.WithUpdatedRun(&JobRun{created: created})
+	}
+	tests := map[string]struct {
+		a                 *Job
+		b                 *Job
+		expected          int
+		expectedGangAware int
+	}{
+		"Gang jobs come before non-gang jobs": {
+			a:                 runningJob("a", 1, "gang"),
+			b:                 runningJob("b", 0, ""),
+			expected:          1,
+			expectedGangAware: -1,
+		},
+		"Gang jobs are ordered by runtime": {
+			a:                 runningJob("a", 1, "gang-a"),
+			b:                 runningJob("b", 0, "gang-b"),
+			expected:          1,
+			expectedGangAware: 1,
+		},
+		"Gang membership doesn't take precedence over priority": {
+			a:                 runningJob("a", 0, "gang").WithPriority(2),
+			b:                 runningJob("b", 1, ""),
+			expected:          1,
+			expectedGangAware: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SchedulingOrderCompare(tc.a, tc.b))
+			assert.Equal(t, tc.expectedGangAware, GangAwareSchedulingOrderCompare(tc.a, tc.b, false))
+			assert.Equal(t, -tc.expectedGangAware, GangAwareSchedulingOrderCompare(tc.b, tc.a, false))
+		})
+	}
+}
+
+func TestJobQueueTtlComparer(t *testing.T) {
+	ttl := func(seconds int64) *schedulerobjects.JobSchedulingInfo {
+		return &schedulerobjects.JobSchedulingInfo{QueueTtlSeconds: seconds}
+	}
+	tests := map[string]struct {
+		a        *Job
+		b        *Job
+		expected int
+	}{
+		"Jobs with equal id are considered equal": {
+			a:        &Job{id: "a", jobSchedulingInfo: ttl(1), queuedPeriodOpen: true},
+			b:        &Job{id: "a", jobSchedulingInfo: ttl(2), queuedPeriodOpen: true},
+			expected: 0,
+		},
+		"Jobs are ordered by the time their ttl expires": {
+			a:        &Job{id: "a", jobSchedulingInfo: ttl(2), queuedPeriodOpen: true},
+			b:        &Job{id: "b", jobSchedulingInfo: ttl(1), queuedPeriodOpen: true},
+			expected: 1,
+		},
+		"Time spent queued before being requeued counts towards the ttl": {
+			a:        &Job{id: "a", jobSchedulingInfo: ttl(10), queuedSince: 5 * int64(time.Second), queuedPeriodOpen: true},
+			b:        &Job{id: "b", jobSchedulingInfo: ttl(10), queuedSince: 5 * int64(time.Second), queuedPeriodOpen: true, queuedDuration: int64(time.Second)},
+			expected: 1,
+		},
+		"Jobs with equal expiry are ordered by id": {
+			a:        &Job{id: "b", jobSchedulingInfo: ttl(1), queuedPeriodOpen: true},
+			b:        &Job{id: "a", jobSchedulingInfo: ttl(1), queuedPeriodOpen: true},
+			expected: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, JobQueueTtlComparer{}.Compare(tc.a, tc.b))
+			assert.Equal(t, -tc.expected, JobQueueTtlComparer{}.Compare(tc.b, tc.a))
+		})
+	}
+}
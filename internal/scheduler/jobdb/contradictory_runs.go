@@ -0,0 +1,83 @@
+package jobdb
+
+import (
+	"github.com/armadaproject/armada/internal/scheduler/database"
+)
+
+// ContradictoryRunPolicy determines how runs the job repository reports as both succeeded and failed are reconciled.
+type ContradictoryRunPolicy int
+
+const (
+	// Such runs are reconciled as failed.
+	ContradictoryRunsPreferFailed ContradictoryRunPolicy = iota
+	// Such runs are reconciled as succeeded.
+	ContradictoryRunsPreferSucceeded
+	// Such runs are left non-terminal and flagged as contradictory; see JobRun.OutcomeContradictory.
+	// They're reconciled as usual once the job repository no longer reports them as both succeeded and failed.
+	ContradictoryRunsQuarantine
+)
+
+func (policy ContradictoryRunPolicy) String() string {
+	switch policy {
+	case ContradictoryRunsPreferSucceeded:
+		return "PreferSucceeded"
+	case ContradictoryRunsQuarantine:
+		return "Quarantine"
+	default:
+		return "PreferFailed"
+	}
+}
+
+// EnableContradictoryRunPolicy causes runs the job repository reports as both succeeded and failed, e.g., due to
+// ingestion bugs, to be reconciled according to policy rather than as failed, which is the default.
+// This applies whether both are reported by a single update or one is already held by the jobDb.
+func (jobDb *JobDb) EnableContradictoryRunPolicy(policy ContradictoryRunPolicy) {
+	jobDb.contradictoryRunPolicy = policy
+}
+
+// ContradictoryRunPolicy returns the policy runs reported as both succeeded and failed are reconciled according to.
+func (jobDb *JobDb) ContradictoryRunPolicy() ContradictoryRunPolicy {
+	return jobDb.contradictoryRunPolicy
+}
+
+// resolveContradictoryOutcome returns a copy of jobRepoRun with its succeeded and failed flags set according to
+// the policy of the jobDb and true if jobRepoRun, combined with jobRun, reports the run as both succeeded and failed.
+// Otherwise, returns jobRepoRun and false. jobRun is nil if the run is new to the jobDb.
+func (jobDb *JobDb) resolveContradictoryOutcome(jobRun *JobRun, jobRepoRun *database.Run) (*database.Run, bool) {
+	succeeded := jobRepoRun.Succeeded || jobRun != nil && jobRun.Succeeded()
+	failed := jobRepoRun.Failed || jobRun != nil && jobRun.Failed()
+	if !succeeded || !failed {
+		return jobRepoRun, false
+	}
+	resolved := *jobRepoRun
+	switch jobDb.contradictoryRunPolicy {
+	case ContradictoryRunsPreferSucceeded:
+		resolved.Succeeded, resolved.Failed = true, false
+	case ContradictoryRunsQuarantine:
+		resolved.Succeeded, resolved.Failed = false, false
+	default:
+		resolved.Succeeded, resolved.Failed = false, true
+	}
+	return &resolved, true
+}
+
+// OutcomeContradictory returns true if the job repository reports the run as both succeeded and failed
+// and it's therefore left non-terminal, such that consistency checks can find it; see ContradictoryRunsQuarantine.
+func (run *JobRun) OutcomeContradictory() bool {
+	return run.outcomeContradictory
+}
+
+// withOutcomeContradictory returns a copy of the run with the outcomeContradictory flag updated.
+func (run *JobRun) withOutcomeContradictory(outcomeContradictory bool) *JobRun {
+	updated := run.DeepCopy()
+	updated.outcomeContradictory = outcomeContradictory
+	return updated
+}
+
+// withOutcome returns a copy of the run with its succeeded and failed flags updated as a single state transition.
+func (run *JobRun) withOutcome(succeeded, failed bool) *JobRun {
+	updated := run.DeepCopy()
+	updated.succeeded = succeeded
+	updated.failed = failed
+	return run.transitionTo(updated)
+}
@@ -1,13 +1,16 @@
 package jobdb
 
 import (
+	"math"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	v1 "k8s.io/api/core/v1"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	armadamaps "github.com/armadaproject/armada/internal/common/maps"
 	"github.com/armadaproject/armada/internal/common/types"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
@@ -27,9 +30,18 @@ type Job struct {
 	// Requested per queue priority of this job.
 	// This is used when syncing the postgres database with the scheduler-internal database.
 	requestedPriority uint32
+	// True if priority is lower than requestedPriority, since the latter exceeds the maximum job priority of the queue.
+	priorityClamped bool
 	// Job submission time in nanoseconds since the epoch.
 	// I.e., the value returned by time.UnixNano().
 	submittedTime int64
+	// Nanoseconds the job spent queued before its current queued period, accumulated across requeues.
+	queuedDuration int64
+	// If queuedPeriodOpen is true, the time at which the current queued period of the job started,
+	// in nanoseconds since the epoch. A queued period starts when the job is submitted or requeued
+	// and ends when a run is created for it.
+	queuedSince      int64
+	queuedPeriodOpen bool
 	// Hash of the scheduling requirements of the job.
 	schedulingKey schedulerobjects.SchedulingKey
 	// True if the job is currently queued.
@@ -38,13 +50,18 @@ type Job struct {
 	// The current version of the queued state.
 	queuedVersion int32
 	// Scheduling requirements of this job.
-	jobSchedulingInfo *schedulerobjects.JobSchedulingInfo
+	// Exactly one of jobSchedulingInfo and lazySchedulingInfo is set, depending on whether the job was created
+	// by a jobDb storing scheduling info lazily; use JobSchedulingInfo to access it.
+	jobSchedulingInfo  *schedulerobjects.JobSchedulingInfo
+	lazySchedulingInfo *lazySchedulingInfo
 	// Priority class of this job. Populated automatically on job creation.
 	priorityClass types.PriorityClass
 	// True if the user has requested this job be cancelled
 	cancelRequested bool
 	// True if the user has requested this job's jobSet be cancelled
 	cancelByJobSetRequested bool
+	// True if the user has requested the active run of this job be preempted and the job requeued
+	preemptRequested bool
 	// True if the scheduler has cancelled the job
 	cancelled bool
 	// True if the scheduler has failed the job
@@ -53,10 +70,39 @@ type Job struct {
 	succeeded bool
 	// Job Runs by run id
 	runsById map[uuid.UUID]*JobRun
+	// True if a run was ever created for the job.
+	// Set when a run is added and never cleared, such that it doesn't depend on the runs the job still holds.
+	everLeased bool
+	// True if a JobValidated event has been published for the job.
+	acknowledged bool
 	// The currently active run. The run with the latest timestamp is the active run.
 	activeRun *JobRun
 	// The timestamp of the currently active run.
 	activeRunTimestamp int64
+	// If non-zero, the job isn't considered for scheduling until this time, in nanoseconds since the epoch.
+	backedOffUntil int64
+	// True if the job has been nudged, i.e., it should be scheduled before other jobs in its priority band.
+	// Cleared by the scheduler once the job has been considered by a scheduling round.
+	nudged bool
+	// Summary of the error of the most recent run that failed with a known error.
+	// Retained once the job is retried, such that users can see why it was.
+	lastRunError string
+	// Default tolerations of the queue of the job, which are added to those in its scheduling info.
+	// Not considered by Equal, since it's derived from the config of the jobDb.
+	queueDefaultTolerations []v1.Toleration
+	// Hash of the serialised scheduling info this job was last loaded with from the job repository.
+	// Zero if unknown, e.g., because the scheduling info has since been updated in memory.
+	// Not considered by Equal, since it's derived from jobSchedulingInfo.
+	schedulingInfoHash uint64
+	// If non-nil, checks the state transitions of the job; see JobDb.EnableStateMachine.
+	// Not considered by Equal, since it's derived from the config of the jobDb.
+	stateMachine *stateMachine
+	// If non-nil, derives the effective parameters of the job; see JobDb.EnableParameterCheckpoints.
+	// Not considered by Equal, since it's derived from the config of the jobDb.
+	parameterCheckpointer *parameterCheckpointer
+	// If non-nil, the effective parameters of the job, as derived when it was admitted or its scheduling info
+	// last changed version. Not considered by Equal, since it's derived from jobSchedulingInfo.
+	effectiveParameters *EffectiveParameters
 }
 
 func EmptyJob(id string) *Job {
@@ -110,6 +156,15 @@ func (job *Job) Equal(other *Job) bool {
 	if job.submittedTime != other.submittedTime {
 		return false
 	}
+	if job.queuedDuration != other.queuedDuration {
+		return false
+	}
+	if job.queuedPeriodOpen != other.queuedPeriodOpen {
+		return false
+	}
+	if job.queuedPeriodOpen && job.queuedSince != other.queuedSince {
+		return false
+	}
 	if job.schedulingKey != other.schedulingKey {
 		// We assume jobSchedulingInfo is equal if schedulingKey is equal.
 		return false
@@ -135,6 +190,12 @@ func (job *Job) Equal(other *Job) bool {
 	if job.cancelByJobSetRequested != other.cancelByJobSetRequested {
 		return false
 	}
+	if job.preemptRequested != other.preemptRequested {
+		return false
+	}
+	if job.priorityClamped != other.priorityClamped {
+		return false
+	}
 	if job.cancelled != other.cancelled {
 		return false
 	}
@@ -144,6 +205,12 @@ func (job *Job) Equal(other *Job) bool {
 	if job.succeeded != other.succeeded {
 		return false
 	}
+	if job.everLeased != other.everLeased {
+		return false
+	}
+	if job.acknowledged != other.acknowledged {
+		return false
+	}
 	if !armadamaps.DeepEqual(job.runsById, other.runsById) {
 		return false
 	}
@@ -153,6 +220,15 @@ func (job *Job) Equal(other *Job) bool {
 	if job.activeRunTimestamp != other.activeRunTimestamp {
 		return false
 	}
+	if job.backedOffUntil != other.backedOffUntil {
+		return false
+	}
+	if job.nudged != other.nudged {
+		return false
+	}
+	if job.lastRunError != other.lastRunError {
+		return false
+	}
 	return true
 }
 
@@ -208,10 +284,14 @@ func (job *Job) GetPerQueuePriority() uint32 {
 
 // GetSubmitTime exists for compatibility with the LegacyJob interface.
 func (job *Job) GetSubmitTime() time.Time {
-	if job.jobSchedulingInfo == nil {
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.submitTime
+	}
+	info := job.JobSchedulingInfo()
+	if info == nil {
 		return time.Time{}
 	}
-	return job.jobSchedulingInfo.SubmitTime
+	return info.SubmitTime
 }
 
 // RequestedPriority returns the requested priority of the job.
@@ -233,22 +313,85 @@ func (job *Job) WithRequestedPriority(priority uint32) *Job {
 	return j
 }
 
+// PriorityClamped returns true if the priority of the job was clamped to the maximum job priority of its queue,
+// in which case Priority returns the clamped priority and RequestedPriority the priority requested for the job.
+func (job *Job) PriorityClamped() bool {
+	return job.priorityClamped
+}
+
+// WithPriorityClamped returns a copy of the job with the priorityClamped flag updated.
+func (job *Job) WithPriorityClamped(priorityClamped bool) *Job {
+	j := copyJob(*job)
+	j.priorityClamped = priorityClamped
+	return j
+}
+
 // JobSchedulingInfo returns the scheduling requirements associated with the job
 func (job *Job) JobSchedulingInfo() *schedulerobjects.JobSchedulingInfo {
+	if job.lazySchedulingInfo != nil {
+		return job.lazySchedulingInfo.get()
+	}
 	return job.jobSchedulingInfo
 }
 
+// schedulingInfoSummary returns a summary of the job's scheduling info if it's stored lazily and hasn't yet been
+// unmarshalled, and nil otherwise, in which case fields should be read from JobSchedulingInfo.
+func (job *Job) schedulingInfoSummary() *schedulingInfoSummary {
+	if job.lazySchedulingInfo == nil {
+		return nil
+	}
+	return job.lazySchedulingInfo.unmarshalledSummary()
+}
+
+// SchedulingInfoVersion returns the version of the scheduling requirements associated with the job.
+func (job *Job) SchedulingInfoVersion() uint32 {
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.version
+	}
+	return job.JobSchedulingInfo().Version
+}
+
 // GetAnnotations returns the annotations on the job.
 // This is needed for compatibility with interfaces.LegacySchedulerJob
 func (job *Job) GetAnnotations() map[string]string {
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.getAnnotations()
+	}
 	if req := job.PodRequirements(); req != nil {
 		return req.Annotations
 	}
 	return nil
 }
 
+// annotation returns the value of the annotation of the job with the given key, or the empty string if it has none.
+func (job *Job) annotation(key string) string {
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.getAnnotation(key)
+	}
+	return job.GetAnnotations()[key]
+}
+
+// GangId returns the id of the gang the job is a member of, or the empty string if the job isn't part of a gang.
+func (job *Job) GangId() string {
+	if job.effectiveParameters != nil {
+		return job.effectiveParameters.GangId
+	}
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.getAnnotation(configuration.GangIdAnnotation)
+	}
+	return job.GetAnnotations()[configuration.GangIdAnnotation]
+}
+
 // Needed for compatibility with interfaces.LegacySchedulerJob
 func (job *Job) GetPriorityClassName() string {
+	return job.schedulingInfoPriorityClassName()
+}
+
+// schedulingInfoPriorityClassName returns the priority class name in the scheduling info of the job.
+func (job *Job) schedulingInfoPriorityClassName() string {
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.priorityClassName
+	}
 	return job.JobSchedulingInfo().PriorityClassName
 }
 
@@ -266,6 +409,9 @@ func (job *Job) GetScheduledAtPriority() (int32, bool) {
 
 // Needed for compatibility with interfaces.LegacySchedulerJob
 func (job *Job) GetNodeSelector() map[string]string {
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.getNodeSelector()
+	}
 	if req := job.PodRequirements(); req != nil {
 		return req.NodeSelector
 	}
@@ -282,14 +428,35 @@ func (job *Job) GetAffinity() *v1.Affinity {
 
 // Needed for compatibility with interfaces.LegacySchedulerJob
 func (job *Job) GetTolerations() []v1.Toleration {
+	req := job.PodRequirements()
+	if req == nil {
+		return nil
+	}
+	if injected := job.InjectedTolerations(); len(injected) > 0 {
+		return append(slices.Clone(req.Tolerations), injected...)
+	}
+	return req.Tolerations
+}
+
+// InjectedTolerations returns the default tolerations of the queue of the job that the job doesn't have already,
+// which the scheduler adds to those in its scheduling info when matching the job against nodes and when leasing it.
+// The scheduling info of the job itself is left unchanged.
+func (job *Job) InjectedTolerations() []v1.Toleration {
+	if len(job.queueDefaultTolerations) == 0 {
+		return nil
+	}
+	var tolerations []v1.Toleration
 	if req := job.PodRequirements(); req != nil {
-		return req.Tolerations
+		tolerations = req.Tolerations
 	}
-	return nil
+	return schedulerobjects.MissingTolerations(tolerations, job.queueDefaultTolerations)
 }
 
 // Needed for compatibility with interfaces.LegacySchedulerJob
 func (job *Job) GetResourceRequirements() v1.ResourceRequirements {
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.getResourceRequirements()
+	}
 	if req := job.PodRequirements(); req != nil {
 		return req.ResourceRequirements
 	}
@@ -298,11 +465,22 @@ func (job *Job) GetResourceRequirements() v1.ResourceRequirements {
 
 // Needed for compatibility with interfaces.LegacySchedulerJob
 func (job *Job) GetQueueTtlSeconds() int64 {
-	return job.jobSchedulingInfo.QueueTtlSeconds
+	if job.effectiveParameters != nil {
+		return job.effectiveParameters.QueueTtlSeconds
+	}
+	return job.schedulingInfoQueueTtlSeconds()
+}
+
+// schedulingInfoQueueTtlSeconds returns the queueTtl in the scheduling info of the job, ignoring any checkpoint.
+func (job *Job) schedulingInfoQueueTtlSeconds() int64 {
+	if summary := job.schedulingInfoSummary(); summary != nil {
+		return summary.queueTtlSeconds
+	}
+	return job.JobSchedulingInfo().QueueTtlSeconds
 }
 
 func (job *Job) PodRequirements() *schedulerobjects.PodRequirements {
-	return job.jobSchedulingInfo.GetPodRequirements()
+	return job.JobSchedulingInfo().GetPodRequirements()
 }
 
 // GetPodRequirements is needed for compatibility with interfaces.LegacySchedulerJob.
@@ -316,9 +494,65 @@ func (job *Job) Queued() bool {
 }
 
 // WithQueued returns a copy of the job with the queued status updated.
+// Requeueing a job in a terminal phase is illegal even though its phase is unchanged, since terminal flags take
+// precedence over the queued flag.
 func (job *Job) WithQueued(queued bool) *Job {
 	j := copyJob(*job)
 	j.queued = queued
+	if queued {
+		return job.transitionTo(j, JobPhaseQueued)
+	}
+	return job.transitionTo(j, j.Phase())
+}
+
+// IsBackedOff returns true if the job shouldn't be considered for scheduling at time t.
+func (job *Job) IsBackedOff(t time.Time) bool {
+	return job.backedOffUntil > t.UnixNano()
+}
+
+// BackedOffUntil returns the time until which the job isn't considered for scheduling.
+// The second return value is false if the job isn't backed off.
+func (job *Job) BackedOffUntil() (time.Time, bool) {
+	if job.backedOffUntil == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, job.backedOffUntil), true
+}
+
+// WithBackedOffUntil returns a copy of the job that isn't considered for scheduling until t.
+// Passing the zero time clears any backoff.
+func (job *Job) WithBackedOffUntil(t time.Time) *Job {
+	j := copyJob(*job)
+	if t.IsZero() {
+		j.backedOffUntil = 0
+	} else {
+		j.backedOffUntil = t.UnixNano()
+	}
+	return j
+}
+
+// Nudged returns true if the job should be scheduled before other jobs in its priority band.
+func (job *Job) Nudged() bool {
+	return job.nudged
+}
+
+// WithNudged returns a copy of the job with the nudged flag updated.
+func (job *Job) WithNudged(nudged bool) *Job {
+	j := copyJob(*job)
+	j.nudged = nudged
+	return j
+}
+
+// LastRunError returns a summary of the error of the most recent run of the job that failed with a known error,
+// or the empty string if there's no such run.
+func (job *Job) LastRunError() string {
+	return job.lastRunError
+}
+
+// WithLastRunError returns a copy of the job with the summary of the error of its most recent failed run updated.
+func (job *Job) WithLastRunError(lastRunError string) *Job {
+	j := copyJob(*job)
+	j.lastRunError = lastRunError
 	return j
 }
 
@@ -358,6 +592,19 @@ func (job *Job) WithCancelByJobsetRequested(cancelByJobsetRequested bool) *Job {
 	return j
 }
 
+// PreemptRequested returns true if the user has requested the active run of this job be preempted
+// and the job requeued.
+func (job *Job) PreemptRequested() bool {
+	return job.preemptRequested
+}
+
+// WithPreemptRequested returns a copy of the job with the preemptRequested status updated.
+func (job *Job) WithPreemptRequested(preemptRequested bool) *Job {
+	j := copyJob(*job)
+	j.preemptRequested = preemptRequested
+	return j
+}
+
 // Cancelled Returns true if the scheduler has cancelled the job
 func (job *Job) Cancelled() bool {
 	return job.cancelled
@@ -367,7 +614,7 @@ func (job *Job) Cancelled() bool {
 func (job *Job) WithCancelled(cancelled bool) *Job {
 	j := copyJob(*job)
 	j.cancelled = cancelled
-	return j
+	return job.transitionTo(j, j.Phase())
 }
 
 // Succeeded Returns true if the scheduler has marked the job as succeeded
@@ -379,7 +626,7 @@ func (job *Job) Succeeded() bool {
 func (job *Job) WithSucceeded(succeeded bool) *Job {
 	j := copyJob(*job)
 	j.succeeded = succeeded
-	return j
+	return job.transitionTo(j, j.Phase())
 }
 
 // Failed Returns true if the scheduler has marked the job as failed
@@ -391,7 +638,7 @@ func (job *Job) Failed() bool {
 func (job *Job) WithFailed(failed bool) *Job {
 	j := copyJob(*job)
 	j.failed = failed
-	return j
+	return job.transitionTo(j, j.Phase())
 }
 
 // Created Returns the creation time of the job
@@ -401,7 +648,7 @@ func (job *Job) Created() int64 {
 
 // InTerminalState returns true if the job  is in a terminal state
 func (job *Job) InTerminalState() bool {
-	return job.succeeded || job.cancelled || job.failed
+	return job.Phase().IsTerminal()
 }
 
 // HasRuns returns true if the job has been run
@@ -410,25 +657,57 @@ func (job *Job) HasRuns() bool {
 	return job.activeRun != nil
 }
 
-// WithNewRun creates a copy of the job with a new run on the given executor.
-func (job *Job) WithNewRun(executor string, nodeId, nodeName string, scheduledAtPriority int32) *Job {
+// EverLeased returns true if a run was ever created for the job, even if the job has since been requeued.
+func (job *Job) EverLeased() bool {
+	return job.everLeased
+}
+
+// WithEverLeased returns a copy of the job with the everLeased flag updated.
+func (job *Job) WithEverLeased(everLeased bool) *Job {
+	j := copyJob(*job)
+	j.everLeased = everLeased
+	return j
+}
+
+// Acknowledged returns true if a JobValidated event has been published for the job.
+func (job *Job) Acknowledged() bool {
+	return job.acknowledged
+}
+
+// WithAcknowledged returns a copy of the job with the acknowledged flag updated.
+func (job *Job) WithAcknowledged(acknowledged bool) *Job {
+	j := copyJob(*job)
+	j.acknowledged = acknowledged
+	return j
+}
+
+// WithNewRun creates a copy of the job with a new run on the given executor, created at the provided time.
+// Callers should pass the time of their injected clock, such that time-dependent behaviour is testable.
+func (job *Job) WithNewRun(executor string, nodeId, nodeName string, scheduledAtPriority int32, created time.Time) *Job {
 	run := &JobRun{
 		id:                  uuid.New(),
 		jobId:               job.id,
-		created:             time.Now().UnixNano(),
+		created:             created.UnixNano(),
 		executor:            executor,
 		nodeId:              nodeId,
 		nodeName:            nodeName,
 		scheduledAtPriority: &scheduledAtPriority,
+		stateMachine:        job.stateMachine,
 	}
 	return job.WithUpdatedRun(run)
 }
 
 // WithUpdatedRun creates a copy of the job with run details updated.
+// Adding a new run ends the current queued period of the job, if any, and marks the job as ever leased.
 func (job *Job) WithUpdatedRun(run *JobRun) *Job {
 	j := copyJob(*job)
+	j.everLeased = true
+	if _, ok := j.runsById[run.id]; !ok && j.queuedPeriodOpen && run.created >= j.queuedSince {
+		j.queuedDuration += run.created - j.queuedSince
+		j.queuedPeriodOpen = false
+	}
 	j.runsById = maps.Clone(j.runsById)
-	if run.created >= j.activeRunTimestamp {
+	if j.activeRun == nil || run.created >= j.activeRunTimestamp {
 		j.activeRunTimestamp = run.created
 		j.activeRun = run
 	}
@@ -479,21 +758,58 @@ func (job *Job) RunById(id uuid.UUID) *JobRun {
 	return job.runsById[id]
 }
 
-// HasQueueTtlExpired returns true if the given job has reached its queueTtl expiry.
-// Invariants:
-//   - job.created < `t`
-func (job *Job) HasQueueTtlExpired() bool {
-	ttlSeconds := job.GetQueueTtlSeconds()
-	if ttlSeconds > 0 {
-		timeSeconds := time.Now().UTC().Unix()
-
-		// job.Created is populated from the `Submitted` field in postgres, which is a UnixNano time hence the conversion.
-		createdSeconds := job.submittedTime / 1_000_000_000
-		duration := timeSeconds - createdSeconds
-		return duration > ttlSeconds
-	} else {
+// HasQueueTtlExpired returns true if the given job has reached its queueTtl expiry at the provided time.
+// The queueTtl is compared to the total time the job has spent queued, across requeues,
+// unless the job opts into comparing it to the time since submission via QueueTtlSinceSubmissionAnnotation.
+func (job *Job) HasQueueTtlExpired(now time.Time) bool {
+	if !job.HasQueueTtlSet() {
 		return false
 	}
+	return now.UnixNano() > job.queueTtlExpiry()
+}
+
+// QueueTtlSinceSubmission returns true if the queueTtl of the job is compared to the time since it was submitted,
+// rather than to the total time it has spent queued.
+func (job *Job) QueueTtlSinceSubmission() bool {
+	if job.effectiveParameters != nil {
+		return job.effectiveParameters.QueueTtlSinceSubmission
+	}
+	return job.annotation(configuration.QueueTtlSinceSubmissionAnnotation) == "true"
+}
+
+// queueTtlExpiry returns the time at which the queueTtl of the job expires, in nanoseconds since the epoch,
+// assuming it remains queued until then. Since this doesn't depend on the current time,
+// it's safe to use for ordering jobs in the jobDb.
+func (job *Job) queueTtlExpiry() int64 {
+	ttl := job.GetQueueTtlSeconds() * int64(time.Second)
+	if job.QueueTtlSinceSubmission() {
+		return job.submittedTime + ttl
+	}
+	if !job.queuedPeriodOpen {
+		return math.MaxInt64
+	}
+	return job.queuedSince + ttl - job.queuedDuration
+}
+
+// QueuedDuration returns the total time the job has spent queued as of now, across requeues.
+func (job *Job) QueuedDuration(now time.Time) time.Duration {
+	duration := job.queuedDuration
+	if job.queuedPeriodOpen && now.UnixNano() > job.queuedSince {
+		duration += now.UnixNano() - job.queuedSince
+	}
+	return time.Duration(duration)
+}
+
+// WithQueuedSince returns a copy of the job with a new queued period starting at t,
+// e.g., since the job was requeued at t. Has no effect if the current queued period of the job hasn't yet ended.
+func (job *Job) WithQueuedSince(t time.Time) *Job {
+	if job.queuedPeriodOpen {
+		return job
+	}
+	j := copyJob(*job)
+	j.queuedSince = t.UnixNano()
+	j.queuedPeriodOpen = true
+	return j
 }
 
 // HasQueueTtlSet returns true if the given job has a queueTtl set.
@@ -519,20 +835,45 @@ func (job *Job) WithQueue(queue string) *Job {
 func (job *Job) WithCreated(created int64) *Job {
 	j := copyJob(*job)
 	j.submittedTime = created
+	if len(j.runsById) == 0 {
+		// The first queued period of the job starts when it's submitted.
+		j.queuedSince = created
+		j.queuedPeriodOpen = true
+	}
 	return j
 }
 
 // WithJobSchedulingInfo returns a copy of the job with the job scheduling info updated.
+// If the version of the scheduling info changed, the effective parameters of the job are re-checkpointed.
 func (job *Job) WithJobSchedulingInfo(jobSchedulingInfo *schedulerobjects.JobSchedulingInfo) *Job {
 	j := copyJob(*job)
 	j.jobSchedulingInfo = jobSchedulingInfo
+	j.lazySchedulingInfo = nil
 	j.ensureJobSchedulingInfoFieldsInitialised()
+	j.schedulingInfoHash = 0
+	if j.effectiveParameters == nil || j.effectiveParameters.SchedulingInfoVersion != jobSchedulingInfo.GetVersion() {
+		j.checkpoint()
+	}
+	return j
+}
+
+// SchedulingInfoHash returns the hash of the serialised scheduling info the job was last loaded with from the job repository,
+// or zero if unknown.
+func (job *Job) SchedulingInfoHash() uint64 {
+	return job.schedulingInfoHash
+}
+
+// WithSchedulingInfoHash returns a copy of the job with the hash of the serialised scheduling info it was loaded with updated.
+func (job *Job) WithSchedulingInfoHash(hash uint64) *Job {
+	j := copyJob(*job)
+	j.schedulingInfoHash = hash
 	return j
 }
 
 func (job *Job) DeepCopy() *Job {
 	copiedSchedulingInfo := proto.Clone(job.JobSchedulingInfo()).(*schedulerobjects.JobSchedulingInfo)
 	j := job.WithJobSchedulingInfo(copiedSchedulingInfo)
+	j.schedulingInfoHash = job.schedulingInfoHash
 
 	j.runsById = maps.Clone(j.runsById)
 	for key, run := range j.runsById {
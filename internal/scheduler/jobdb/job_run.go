@@ -34,6 +34,23 @@ type JobRun struct {
 	returned bool
 	// True if the job has been returned and the job was given a chance to run.
 	runAttempted bool
+	// Most recent resource usage reported by the executor, if any.
+	resourceUsage    RunResourceUsage
+	hasResourceUsage bool
+	// True if the job repository reports the run as both succeeded and failed and it was left non-terminal;
+	// see ContradictoryRunsQuarantine.
+	outcomeContradictory bool
+	// If non-nil, checks the state transitions of the run; see JobDb.EnableStateMachine.
+	stateMachine *stateMachine
+}
+
+// RunResourceUsage is the resource usage of a run as reported by the executor it's leased to.
+// It's advisory only; executors may not report it, and it may be out of date.
+type RunResourceUsage struct {
+	// Cpu time consumed by the run since it started.
+	CpuSeconds float64
+	// Memory currently in use by the run.
+	WorkingSetBytes int64
 }
 
 func (run *JobRun) Equal(other *JobRun) bool {
@@ -87,6 +104,7 @@ func (jobDb *JobDb) CreateRun(
 		cancelled:           cancelled,
 		returned:            returned,
 		runAttempted:        runAttempted,
+		stateMachine:        jobDb.stateMachine,
 	}
 }
 
@@ -115,10 +133,26 @@ func (run *JobRun) NodeName() string {
 	return run.nodeName
 }
 
+// WithNode returns a copy of the job run assigned to the node with the given id and name,
+// e.g., since the executor rescheduled its pod onto another node.
+func (run *JobRun) WithNode(nodeId string, nodeName string) *JobRun {
+	run = run.DeepCopy()
+	run.nodeId = nodeId
+	run.nodeName = nodeName
+	return run
+}
+
 func (run *JobRun) ScheduledAtPriority() *int32 {
 	return run.scheduledAtPriority
 }
 
+// WithScheduledAtPriority returns a copy of the job run scheduled at the given priority.
+func (run *JobRun) WithScheduledAtPriority(priority int32) *JobRun {
+	run = run.DeepCopy()
+	run.scheduledAtPriority = &priority
+	return run
+}
+
 // Succeeded Returns true if the executor has reported the job run as successful
 func (run *JobRun) Succeeded() bool {
 	return run.succeeded
@@ -126,9 +160,9 @@ func (run *JobRun) Succeeded() bool {
 
 // WithSucceeded returns a copy of the job run with the succeeded status updated.
 func (run *JobRun) WithSucceeded(succeeded bool) *JobRun {
-	run = run.DeepCopy()
-	run.succeeded = succeeded
-	return run
+	updated := run.DeepCopy()
+	updated.succeeded = succeeded
+	return run.transitionTo(updated)
 }
 
 // Failed Returns true if the executor has reported the job run as failed
@@ -138,9 +172,9 @@ func (run *JobRun) Failed() bool {
 
 // WithFailed returns a copy of the job run with the failed status updated.
 func (run *JobRun) WithFailed(failed bool) *JobRun {
-	run = run.DeepCopy()
-	run.failed = failed
-	return run
+	updated := run.DeepCopy()
+	updated.failed = failed
+	return run.transitionTo(updated)
 }
 
 // Cancelled Returns true if the user has cancelled the job run
@@ -150,9 +184,9 @@ func (run *JobRun) Cancelled() bool {
 
 // WithCancelled returns a copy of the job run with the cancelled status updated.
 func (run *JobRun) WithCancelled(cancelled bool) *JobRun {
-	run = run.DeepCopy()
-	run.cancelled = cancelled
-	return run
+	updated := run.DeepCopy()
+	updated.cancelled = cancelled
+	return run.transitionTo(updated)
 }
 
 // Running Returns true if the executor has reported the job run as running
@@ -162,9 +196,9 @@ func (run *JobRun) Running() bool {
 
 // WithRunning returns a copy of the job run with the running status updated.
 func (run *JobRun) WithRunning(running bool) *JobRun {
-	run = run.DeepCopy()
-	run.running = running
-	return run
+	updated := run.DeepCopy()
+	updated.running = running
+	return run.transitionTo(updated)
 }
 
 // Returned Returns true if the executor has returned the job run.
@@ -173,9 +207,9 @@ func (run *JobRun) Returned() bool {
 }
 
 func (run *JobRun) WithReturned(returned bool) *JobRun {
-	run = run.DeepCopy()
-	run.returned = returned
-	return run
+	updated := run.DeepCopy()
+	updated.returned = returned
+	return run.transitionTo(updated)
 }
 
 // RunAttempted Returns true if the executor has attempted to run the job.
@@ -190,6 +224,20 @@ func (run *JobRun) WithAttempted(attempted bool) *JobRun {
 	return run
 }
 
+// ResourceUsage returns the most recent resource usage reported for the job run
+// and true, or false if no usage has been reported.
+func (run *JobRun) ResourceUsage() (RunResourceUsage, bool) {
+	return run.resourceUsage, run.hasResourceUsage
+}
+
+// WithResourceUsage returns a copy of the job run with the reported resource usage updated.
+func (run *JobRun) WithResourceUsage(usage RunResourceUsage) *JobRun {
+	run = run.DeepCopy()
+	run.resourceUsage = usage
+	run.hasResourceUsage = true
+	return run
+}
+
 // Created Returns the creation time of the job run
 func (run *JobRun) Created() int64 {
 	return run.created
@@ -197,7 +245,7 @@ func (run *JobRun) Created() int64 {
 
 // InTerminalState returns true if the JobRun is in a terminal state
 func (run *JobRun) InTerminalState() bool {
-	return run.succeeded || run.failed || run.cancelled || run.returned
+	return run.Phase().IsTerminal()
 }
 
 func (run *JobRun) DeepCopy() *JobRun {
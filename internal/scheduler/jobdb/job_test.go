@@ -2,11 +2,16 @@ package jobdb
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
@@ -98,6 +103,19 @@ func TestJob_TestCancelByJobsetRequested(t *testing.T) {
 	assert.Equal(t, true, newJob.CancelByJobsetRequested())
 }
 
+func TestJob_TestPreemptRequested(t *testing.T) {
+	newJob := baseJob.WithPreemptRequested(true)
+	assert.Equal(t, false, baseJob.PreemptRequested())
+	assert.Equal(t, true, newJob.PreemptRequested())
+}
+
+func TestJob_TestPriorityClamped(t *testing.T) {
+	newJob := baseJob.WithPriorityClamped(true)
+	assert.Equal(t, false, baseJob.PriorityClamped())
+	assert.Equal(t, true, newJob.PriorityClamped())
+	assert.False(t, baseJob.Equal(newJob))
+}
+
 func TestJob_TestCancelled(t *testing.T) {
 	newJob := baseJob.WithCancelled(true)
 	assert.Equal(t, false, baseJob.Cancelled())
@@ -125,12 +143,26 @@ func TestJob_TestInTerminalState(t *testing.T) {
 
 func TestJob_TestHasRuns(t *testing.T) {
 	assert.Equal(t, false, baseJob.HasRuns())
-	assert.Equal(t, true, baseJob.WithNewRun("test-executor", "test-nodeId", "nodeId", 5).HasRuns())
+	assert.Equal(t, true, baseJob.WithNewRun("test-executor", "test-nodeId", "nodeId", 5, time.Now()).HasRuns())
+}
+
+func TestJob_TestEverLeased(t *testing.T) {
+	assert.Equal(t, false, baseJob.EverLeased())
+	jobWithRun := baseJob.WithNewRun("test-executor", "test-nodeId", "nodeId", 5, time.Now())
+	assert.Equal(t, true, jobWithRun.EverLeased())
+	// Requeueing the job doesn't clear the flag.
+	assert.Equal(t, true, jobWithRun.WithQueued(true).EverLeased())
+	assert.Equal(t, true, baseJob.WithEverLeased(true).EverLeased())
+}
+
+func TestJob_TestAcknowledged(t *testing.T) {
+	assert.Equal(t, false, baseJob.Acknowledged())
+	assert.Equal(t, true, baseJob.WithAcknowledged(true).Acknowledged())
 }
 
 func TestJob_TestWithNewRun(t *testing.T) {
 	scheduledAtPriority := int32(10)
-	jobWithRun := baseJob.WithNewRun("test-executor", "test-nodeId", "nodeId", scheduledAtPriority)
+	jobWithRun := baseJob.WithNewRun("test-executor", "test-nodeId", "nodeId", scheduledAtPriority, time.Now())
 	assert.Equal(t, true, jobWithRun.HasRuns())
 	run := jobWithRun.LatestRun()
 	assert.NotNil(t, run)
@@ -362,3 +394,151 @@ func TestJobSchedulingInfoFieldsInitialised(t *testing.T) {
 	assert.NotNil(t, updatedJob.GetNodeSelector())
 	assert.NotNil(t, updatedJob.GetAnnotations())
 }
+
+func TestJob_QueuedDuration(t *testing.T) {
+	submitted := time.Unix(0, baseJob.Created())
+	job := baseJob
+	assert.Equal(t, time.Minute, job.QueuedDuration(submitted.Add(time.Minute)))
+
+	// Creating a run ends the queued period.
+	job = job.WithQueued(false).WithNewRun("test-executor", "test-node-id", "node", 5, submitted.Add(time.Minute))
+	assert.Equal(t, time.Minute, job.QueuedDuration(submitted.Add(time.Hour)))
+
+	// Updating the run doesn't affect the queued duration.
+	job = job.WithUpdatedRun(job.LatestRun().WithReturned(true))
+	assert.Equal(t, time.Minute, job.QueuedDuration(submitted.Add(time.Hour)))
+
+	// Time spent queued after being requeued is added to that spent queued before.
+	job = job.WithQueued(true).WithQueuedSince(submitted.Add(time.Hour))
+	assert.Equal(t, 3*time.Minute, job.QueuedDuration(submitted.Add(time.Hour+2*time.Minute)))
+
+	// Requeueing a job that's still queued doesn't restart the queued period.
+	assert.Equal(t, job, job.WithQueuedSince(submitted.Add(2*time.Hour)))
+
+	job = job.WithQueued(false).WithNewRun("test-executor", "test-node-id", "node", 5, submitted.Add(time.Hour+5*time.Minute))
+	assert.Equal(t, 6*time.Minute, job.QueuedDuration(submitted.Add(48*time.Hour)))
+}
+
+func TestJob_HasQueueTtlExpired(t *testing.T) {
+	submitted := time.Unix(0, baseJob.Created())
+	withTtl := func(annotations map[string]string) *Job {
+		return baseJob.WithJobSchedulingInfo(&schedulerobjects.JobSchedulingInfo{
+			ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+				{
+					Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+						PodRequirements: &schedulerobjects.PodRequirements{Annotations: annotations},
+					},
+				},
+			},
+			QueueTtlSeconds: 600,
+		})
+	}
+	requeued := func(job *Job) *Job {
+		return job.
+			WithQueued(false).
+			WithNewRun("test-executor", "test-node-id", "node", 5, submitted.Add(5*time.Minute)).
+			WithQueued(true).
+			WithQueuedSince(submitted.Add(48 * time.Hour))
+	}
+
+	assert.False(t, baseJob.HasQueueTtlExpired(submitted.Add(48*time.Hour)))
+
+	job := withTtl(nil)
+	assert.False(t, job.HasQueueTtlExpired(submitted.Add(10*time.Minute)))
+	assert.True(t, job.HasQueueTtlExpired(submitted.Add(11*time.Minute)))
+	job = requeued(job)
+	assert.False(t, job.HasQueueTtlExpired(submitted.Add(48*time.Hour+5*time.Minute)))
+	assert.True(t, job.HasQueueTtlExpired(submitted.Add(48*time.Hour+6*time.Minute)))
+
+	job = requeued(withTtl(map[string]string{configuration.QueueTtlSinceSubmissionAnnotation: "true"}))
+	assert.True(t, job.QueueTtlSinceSubmission())
+	assert.True(t, job.HasQueueTtlExpired(submitted.Add(48*time.Hour)))
+}
+
+func TestJob_LazySchedulingInfo(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		Version:           2,
+		PriorityClassName: PriorityClass1,
+		SubmitTime:        time.Now().UTC(),
+		QueueTtlSeconds:   10,
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{
+						Annotations:  map[string]string{"foo": "bar"},
+						NodeSelector: map[string]string{"baz": "qux"},
+						Tolerations:  []v1.Toleration{{Key: "foo", Operator: v1.TolerationOpExists}},
+						ResourceRequirements: v1.ResourceRequirements{
+							Requests: v1.ResourceList{"cpu": resource.MustParse("1")},
+						},
+						PreemptionPolicy: "PreemptLowerPriority",
+					},
+				},
+			},
+		},
+	}
+	lazyJobDb := NewJobDbWithSchedulingKeyGenerator(TestPriorityClasses, TestDefaultPriorityClass, SchedulingKeyGenerator, 1024)
+	lazyJobDb.EnableLazySchedulingInfo()
+	eager := jobDb.NewJob("test-job", "test-jobSet", "test-queue", 2, schedulingInfo, true, 0, false, false, false, 3)
+	lazy := lazyJobDb.NewJob("test-job", "test-jobSet", "test-queue", 2, schedulingInfo, true, 0, false, false, false, 3)
+	require.NotNil(t, lazy.lazySchedulingInfo)
+
+	// Fields read while the job is queued are served from the summary.
+	assert.Equal(t, eager.SchedulingInfoVersion(), lazy.SchedulingInfoVersion())
+	assert.Equal(t, eager.GetPriorityClassName(), lazy.GetPriorityClassName())
+	assert.Equal(t, eager.GetSubmitTime(), lazy.GetSubmitTime())
+	assert.Equal(t, eager.GetQueueTtlSeconds(), lazy.GetQueueTtlSeconds())
+	assert.Equal(t, eager.GetAnnotations(), lazy.GetAnnotations())
+	assert.Equal(t, eager.GetNodeSelector(), lazy.GetNodeSelector())
+	assert.Equal(t, eager.GetResourceRequirements(), lazy.GetResourceRequirements())
+	assert.Equal(t, eager.schedulingKey, lazy.schedulingKey)
+	assert.Nil(t, lazy.lazySchedulingInfo.info)
+
+	// All other fields require unmarshalling the scheduling info.
+	assert.Equal(t, eager.GetTolerations(), lazy.GetTolerations())
+	assert.Equal(t, eager.JobSchedulingInfo(), lazy.JobSchedulingInfo())
+	assert.NotNil(t, lazy.lazySchedulingInfo.info)
+	assert.Nil(t, lazy.lazySchedulingInfo.serialised)
+
+	// Once unmarshalled, accessors read from the scheduling info, which callers may mutate.
+	lazy.GetAnnotations()["new"] = "annotation"
+	assert.Equal(t, "annotation", lazy.PodRequirements().Annotations["new"])
+
+	// Copies share the lazy scheduling info.
+	assert.Same(t, lazy.lazySchedulingInfo, lazy.WithQueued(false).lazySchedulingInfo)
+}
+
+func TestJob_LazySchedulingInfoWithoutMaps(t *testing.T) {
+	schedulingInfo := func() *schedulerobjects.JobSchedulingInfo {
+		return &schedulerobjects.JobSchedulingInfo{
+			Version:           1,
+			PriorityClassName: PriorityClass1,
+			ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+				{
+					Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+						PodRequirements: &schedulerobjects.PodRequirements{},
+					},
+				},
+			},
+		}
+	}
+
+	// Nil maps are summarised as nil rather than as empty maps.
+	lazyInfo, err := newLazySchedulingInfo(schedulingInfo(), nil)
+	require.NoError(t, err)
+	assert.Nil(t, lazyInfo.summary.getAnnotations())
+	assert.Nil(t, lazyInfo.summary.getNodeSelector())
+	assert.Equal(t, schedulingInfo(), lazyInfo.get())
+
+	// Jobs compare equal whether their scheduling info is stored eagerly or lazily.
+	lazyJobDb := NewJobDbWithSchedulingKeyGenerator(TestPriorityClasses, TestDefaultPriorityClass, SchedulingKeyGenerator, 1024)
+	lazyJobDb.EnableLazySchedulingInfo()
+	eager := jobDb.NewJob("test-job", "test-jobSet", "test-queue", 2, schedulingInfo(), true, 0, false, false, false, 3)
+	lazy := lazyJobDb.NewJob("test-job", "test-jobSet", "test-queue", 2, schedulingInfo(), true, 0, false, false, false, 3)
+	require.NotNil(t, lazy.lazySchedulingInfo)
+	assert.Equal(t, eager.GetAnnotations(), lazy.GetAnnotations())
+	assert.Equal(t, eager.GetNodeSelector(), lazy.GetNodeSelector())
+	assert.Equal(t, eager.JobSchedulingInfo(), lazy.JobSchedulingInfo())
+	assert.Equal(t, eager.GetAnnotations(), lazy.GetAnnotations())
+	assert.Equal(t, eager.GetNodeSelector(), lazy.GetNodeSelector())
+}
@@ -3,11 +3,14 @@ package jobdb
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/benbjohnson/immutable"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
 
 	"github.com/armadaproject/armada/internal/common/stringinterner"
 	"github.com/armadaproject/armada/internal/common/types"
@@ -18,6 +21,8 @@ import (
 var (
 	emptyList            = immutable.NewSortedSet[*Job](JobPriorityComparer{})
 	emptyQueuedJobsByTtl = immutable.NewSortedSet[*Job](JobQueueTtlComparer{})
+	emptyQueuedJobsByAge = immutable.NewSortedSet[*Job](JobSubmitTimeComparer{})
+	emptyActiveRuns      = immutable.NewSortedSet[*JobRun](JobRunCreatedComparer{})
 )
 
 type JobDb struct {
@@ -25,15 +30,50 @@ type JobDb struct {
 	jobsByRunId     *immutable.Map[uuid.UUID, string]
 	jobsByQueue     map[string]immutable.SortedSet[*Job]
 	queuedJobsByTtl *immutable.SortedSet[*Job]
+	// Queued jobs of each priority class, oldest first.
+	queuedJobsByPriorityClass map[string]immutable.SortedSet[*Job]
+	jobsByGangId              *immutable.Map[string, immutable.Set[string]]
+	// Active runs assigned to each executor, oldest first; see Txn.ActiveRuns.
+	activeRunsByExecutor *immutable.Map[string, immutable.SortedSet[*JobRun]]
+	// Number of queued jobs in each group; only maintained if queuedJobBucket is non-nil.
+	queuedJobCounts *immutable.Map[QueuedJobCountKey, int]
+	// Outcome of the most recent scheduling round in which each job was evaluated.
+	schedulingOutcomesByJobId *immutable.Map[string, SchedulingOutcome]
 	// Configured priority classes.
 	priorityClasses map[string]types.PriorityClass
 	// Priority class assigned to jobs with a priorityClassName not in jobDb.priorityClasses.
-	defaultPriorityClass   types.PriorityClass
-	schedulingKeyGenerator *schedulerobjects.SchedulingKeyGenerator
+	defaultPriorityClass     types.PriorityClass
+	defaultPriorityClassName string
+	// If non-empty, priority class that jobs loaded from the database with a priorityClassName not in
+	// jobDb.priorityClasses are assigned instead of the default priority class; see EnablePriorityClassFallback.
+	fallbackPriorityClassName string
+	schedulingKeyGenerator    *schedulerobjects.SchedulingKeyGenerator
 	// We intern strings to save memory.
 	stringInterner *stringinterner.StringInterner
-	copyMutex      sync.Mutex
-	writerMutex    sync.Mutex
+	// If true, the scheduling info of jobs created by this jobDb is stored in serialised form
+	// and only unmarshalled when needed, to save memory.
+	lazySchedulingInfo bool
+	// If non-nil, notified of each committed write transaction.
+	commitObserver CommitObserver
+	// If true, the time write transactions spend updating each part of the jobDb is measured.
+	commitBreakdown bool
+	// If true, the node ids of runs created from the database are created with
+	// api.LengthPrefixedNodeIdFromExecutorAndNodeName.
+	lengthPrefixedNodeIds bool
+	// Tolerations added to those of jobs in each queue; see Job.InjectedTolerations.
+	defaultTolerationsByQueue map[string][]v1.Toleration
+	// If true, write transactions record the ids of the jobs upserted into them; see Txn.Transitions.
+	transitionTracking bool
+	// If non-nil, returns the bucket queued jobs are counted in; see EnableQueuedJobCounts.
+	queuedJobBucket func(job *Job) string
+	// If non-nil, checks the state transitions of the jobs and runs created by the jobDb.
+	stateMachine *stateMachine
+	// If non-nil, derives the effective parameters checkpointed on the jobs created by the jobDb.
+	parameterCheckpointer *parameterCheckpointer
+	// How runs the job repository reports as both succeeded and failed are reconciled.
+	contradictoryRunPolicy ContradictoryRunPolicy
+	copyMutex              sync.Mutex
+	writerMutex            sync.Mutex
 }
 
 func NewJobDb(priorityClasses map[string]types.PriorityClass, defaultPriorityClassName string, stringInternerCacheSize uint32) *JobDb {
@@ -57,17 +97,90 @@ func NewJobDbWithSchedulingKeyGenerator(
 		panic(fmt.Sprintf("unknown default priority class %s", defaultPriorityClassName))
 	}
 	return &JobDb{
-		jobsById:               immutable.NewMap[string, *Job](nil),
-		jobsByRunId:            immutable.NewMap[uuid.UUID, string](&UUIDHasher{}),
-		jobsByQueue:            map[string]immutable.SortedSet[*Job]{},
-		queuedJobsByTtl:        &emptyQueuedJobsByTtl,
-		priorityClasses:        priorityClasses,
-		defaultPriorityClass:   defaultPriorityClass,
-		schedulingKeyGenerator: skg,
-		stringInterner:         stringinterner.New(stringInternerCacheSize),
+		jobsById:                  immutable.NewMap[string, *Job](nil),
+		jobsByRunId:               immutable.NewMap[uuid.UUID, string](&UUIDHasher{}),
+		jobsByQueue:               map[string]immutable.SortedSet[*Job]{},
+		queuedJobsByTtl:           &emptyQueuedJobsByTtl,
+		queuedJobsByPriorityClass: map[string]immutable.SortedSet[*Job]{},
+		jobsByGangId:              immutable.NewMap[string, immutable.Set[string]](nil),
+		activeRunsByExecutor:      immutable.NewMap[string, immutable.SortedSet[*JobRun]](nil),
+		queuedJobCounts:           newQueuedJobCounts(),
+		schedulingOutcomesByJobId: immutable.NewMap[string, SchedulingOutcome](nil),
+		priorityClasses:           priorityClasses,
+		defaultPriorityClass:      defaultPriorityClass,
+		defaultPriorityClassName:  defaultPriorityClassName,
+		schedulingKeyGenerator:    skg,
+		stringInterner:            stringinterner.New(stringInternerCacheSize),
 	}
 }
 
+// EnableLazySchedulingInfo causes the scheduling info of jobs subsequently created by the jobDb to be stored
+// in serialised form, along with the fields needed while jobs are queued, and to only be unmarshalled in full
+// once needed, e.g., when the job is matched against nodes. This reduces the memory used by queued jobs.
+func (jobDb *JobDb) EnableLazySchedulingInfo() {
+	jobDb.lazySchedulingInfo = true
+}
+
+// EnableQueueDefaultTolerations causes jobs subsequently created by the jobDb to tolerate, in addition to the tolerations
+// in their scheduling info, the tolerations of their queue in tolerationsByQueue; see Job.InjectedTolerations.
+func (jobDb *JobDb) EnableQueueDefaultTolerations(tolerationsByQueue map[string][]v1.Toleration) {
+	jobDb.defaultTolerationsByQueue = tolerationsByQueue
+}
+
+// EnableCommitObserver causes observer to be notified of each committed write transaction.
+// If breakdown is true, the time spent copying the jobs tree and updating indices is additionally measured,
+// which adds some overhead to each write.
+func (jobDb *JobDb) EnableCommitObserver(observer CommitObserver, breakdown bool) {
+	jobDb.commitObserver = observer
+	jobDb.commitBreakdown = breakdown
+}
+
+// EnableTransitionTracking causes write transactions to record the ids of the jobs upserted into them,
+// such that the state transitions they apply can be listed by Txn.Transitions.
+func (jobDb *JobDb) EnableTransitionTracking() {
+	jobDb.transitionTracking = true
+}
+
+// EnableStateMachine causes the state transitions of jobs and runs subsequently created by the jobDb, as applied by
+// their With* builders, to be checked against the legal transitions between their phases; see IsLegalJobTransition
+// and IsLegalRunTransition. Illegal transitions are reported to observer, if non-nil, and refused if mode is
+// StateMachineStrict, such that no job or run created by the jobDb can end up in an invalid phase.
+func (jobDb *JobDb) EnableStateMachine(mode StateMachineMode, observer IllegalTransitionObserver) {
+	jobDb.stateMachine = &stateMachine{mode: mode, observer: observer}
+}
+
+// EnableLengthPrefixedNodeIds causes the node ids of runs subsequently created from the database to be created with
+// api.LengthPrefixedNodeIdFromExecutorAndNodeName, matching those of nodes reported by executors.
+func (jobDb *JobDb) EnableLengthPrefixedNodeIds() {
+	jobDb.lengthPrefixedNodeIds = true
+}
+
+// EnablePriorityClassFallback causes jobs subsequently loaded from the database with a non-empty priorityClassName
+// that isn't configured to be assigned the priority class with the given name instead of the default priority class.
+// The priority class name in the scheduling info of such jobs is replaced, such that every component of the scheduler
+// resolves them to the same priority class.
+func (jobDb *JobDb) EnablePriorityClassFallback(priorityClassName string) error {
+	if _, ok := jobDb.priorityClasses[priorityClassName]; !ok {
+		return errors.Errorf("unknown fallback priority class %s", priorityClassName)
+	}
+	jobDb.fallbackPriorityClassName = priorityClassName
+	return nil
+}
+
+// withPriorityClassFallback replaces the priority class name of schedulingInfo, which must not yet be shared,
+// with the fallback priority class if one is enabled and the priority class of schedulingInfo isn't configured.
+// Returns serialisedSchedulingInfo if schedulingInfo is unchanged and nil otherwise, since it no longer matches.
+func (jobDb *JobDb) withPriorityClassFallback(schedulingInfo *schedulerobjects.JobSchedulingInfo, serialisedSchedulingInfo []byte) []byte {
+	if jobDb.fallbackPriorityClassName == "" || schedulingInfo.PriorityClassName == "" {
+		return serialisedSchedulingInfo
+	}
+	if _, ok := jobDb.priorityClasses[schedulingInfo.PriorityClassName]; ok {
+		return serialisedSchedulingInfo
+	}
+	schedulingInfo.PriorityClassName = jobDb.fallbackPriorityClassName
+	return nil
+}
+
 // NewJob creates a new scheduler job.
 // The new job is not automatically inserted into the jobDb; call jobDb.Upsert to upsert it.
 func (jobDb *JobDb) NewJob(
@@ -82,6 +195,41 @@ func (jobDb *JobDb) NewJob(
 	cancelByJobSetRequested bool,
 	cancelled bool,
 	created int64,
+) *Job {
+	return jobDb.newJob(
+		jobId, jobSet, queue, priority, schedulingInfo, nil, queued, queuedVersion, cancelRequested, cancelByJobSetRequested, cancelled, created,
+	)
+}
+
+// EffectivePriorityClassName returns the name of the priority class applied to job and true if that's the default
+// priority class, since the job has a priorityClassName not in jobDb.priorityClasses.
+// If the job has been checkpointed, its priority class is that resolved when it was checkpointed.
+func (jobDb *JobDb) EffectivePriorityClassName(job *Job) (string, bool) {
+	if p, ok := job.EffectiveParameters(); ok {
+		return p.PriorityClassName, p.DefaultPriorityClassApplied
+	}
+	name := job.GetPriorityClassName()
+	if _, ok := jobDb.priorityClasses[name]; ok {
+		return name, false
+	}
+	return jobDb.defaultPriorityClassName, true
+}
+
+// newJob is like NewJob, except that if non-nil, serialisedSchedulingInfo must be the serialised form of schedulingInfo,
+// which saves having to marshal it if the jobDb stores scheduling info lazily.
+func (jobDb *JobDb) newJob(
+	jobId string,
+	jobSet string,
+	queue string,
+	priority uint32,
+	schedulingInfo *schedulerobjects.JobSchedulingInfo,
+	serialisedSchedulingInfo []byte,
+	queued bool,
+	queuedVersion int32,
+	cancelRequested bool,
+	cancelByJobSetRequested bool,
+	cancelled bool,
+	created int64,
 ) *Job {
 	priorityClass, ok := jobDb.priorityClasses[schedulingInfo.PriorityClassName]
 	if !ok {
@@ -96,15 +244,38 @@ func (jobDb *JobDb) NewJob(
 		queuedVersion:           queuedVersion,
 		requestedPriority:       priority,
 		submittedTime:           created,
+		queuedSince:             created,
+		queuedPeriodOpen:        true,
 		jobSchedulingInfo:       jobDb.internJobSchedulingInfoStrings(schedulingInfo),
 		priorityClass:           priorityClass,
 		cancelRequested:         cancelRequested,
 		cancelByJobSetRequested: cancelByJobSetRequested,
 		cancelled:               cancelled,
 		runsById:                map[uuid.UUID]*JobRun{},
+		queueDefaultTolerations: jobDb.defaultTolerationsByQueue[queue],
+		stateMachine:            jobDb.stateMachine,
+		parameterCheckpointer:   jobDb.parameterCheckpointer,
 	}
 	job.ensureJobSchedulingInfoFieldsInitialised()
+	job.checkpoint()
 	job.schedulingKey = interfaces.SchedulingKeyFromLegacySchedulerJob(jobDb.schedulingKeyGenerator, job)
+	return jobDb.withLazySchedulingInfo(job, serialisedSchedulingInfo)
+}
+
+// withLazySchedulingInfo returns job with its scheduling info stored lazily if the jobDb is configured to do so,
+// and job unchanged otherwise. If non-nil, serialisedSchedulingInfo must be the serialised form of its scheduling info.
+// Mutates job, which must not yet be shared.
+func (jobDb *JobDb) withLazySchedulingInfo(job *Job, serialisedSchedulingInfo []byte) *Job {
+	if !jobDb.lazySchedulingInfo || job.jobSchedulingInfo == nil {
+		return job
+	}
+	lazy, err := newLazySchedulingInfo(job.jobSchedulingInfo, serialisedSchedulingInfo)
+	if err != nil {
+		// Scheduling info that can't be marshalled is kept unmarshalled.
+		return job
+	}
+	job.jobSchedulingInfo = nil
+	job.lazySchedulingInfo = lazy
 	return job
 }
 
@@ -130,13 +301,18 @@ func (jobDb *JobDb) ReadTxn() *Txn {
 	jobDb.copyMutex.Lock()
 	defer jobDb.copyMutex.Unlock()
 	return &Txn{
-		readOnly:        true,
-		jobsById:        jobDb.jobsById,
-		jobsByRunId:     jobDb.jobsByRunId,
-		jobsByQueue:     jobDb.jobsByQueue,
-		queuedJobsByTtl: jobDb.queuedJobsByTtl,
-		active:          true,
-		jobDb:           jobDb,
+		readOnly:                  true,
+		jobsById:                  jobDb.jobsById,
+		jobsByRunId:               jobDb.jobsByRunId,
+		jobsByQueue:               jobDb.jobsByQueue,
+		queuedJobsByTtl:           jobDb.queuedJobsByTtl,
+		queuedJobsByPriorityClass: jobDb.queuedJobsByPriorityClass,
+		jobsByGangId:              jobDb.jobsByGangId,
+		activeRunsByExecutor:      jobDb.activeRunsByExecutor,
+		queuedJobCounts:           jobDb.queuedJobCounts,
+		schedulingOutcomesByJobId: jobDb.schedulingOutcomesByJobId,
+		active:                    true,
+		jobDb:                     jobDb,
 	}
 }
 
@@ -147,14 +323,68 @@ func (jobDb *JobDb) WriteTxn() *Txn {
 	jobDb.writerMutex.Lock()
 	jobDb.copyMutex.Lock()
 	defer jobDb.copyMutex.Unlock()
+	var upsertedJobIds map[string]bool
+	if jobDb.transitionTracking {
+		upsertedJobIds = make(map[string]bool)
+	}
 	return &Txn{
-		readOnly:        false,
-		jobsById:        jobDb.jobsById,
-		jobsByRunId:     jobDb.jobsByRunId,
-		jobsByQueue:     maps.Clone(jobDb.jobsByQueue),
-		queuedJobsByTtl: jobDb.queuedJobsByTtl,
-		active:          true,
-		jobDb:           jobDb,
+		readOnly:                  false,
+		jobsById:                  jobDb.jobsById,
+		jobsByRunId:               jobDb.jobsByRunId,
+		jobsByQueue:               maps.Clone(jobDb.jobsByQueue),
+		queuedJobsByTtl:           jobDb.queuedJobsByTtl,
+		queuedJobsByPriorityClass: maps.Clone(jobDb.queuedJobsByPriorityClass),
+		jobsByGangId:              jobDb.jobsByGangId,
+		activeRunsByExecutor:      jobDb.activeRunsByExecutor,
+		queuedJobCounts:           jobDb.queuedJobCounts,
+		schedulingOutcomesByJobId: jobDb.schedulingOutcomesByJobId,
+		active:                    true,
+		jobDb:                     jobDb,
+		upsertedJobIds:            upsertedJobIds,
+	}
+}
+
+// DetachedWriteTxn returns a writeable transaction starting from an empty jobDb, which doesn't block or get blocked by
+// other transactions and can't be committed. Jobs created by the jobDb can be upserted into it to construct a state
+// that's later swapped in by Txn.ReplaceContents, e.g., a jobDb rebuilt from the database while the jobDb is in use.
+func (jobDb *JobDb) DetachedWriteTxn() *Txn {
+	return &Txn{
+		readOnly:                  false,
+		detached:                  true,
+		jobsById:                  immutable.NewMap[string, *Job](nil),
+		jobsByRunId:               immutable.NewMap[uuid.UUID, string](&UUIDHasher{}),
+		jobsByQueue:               map[string]immutable.SortedSet[*Job]{},
+		queuedJobsByTtl:           &emptyQueuedJobsByTtl,
+		queuedJobsByPriorityClass: map[string]immutable.SortedSet[*Job]{},
+		jobsByGangId:              immutable.NewMap[string, immutable.Set[string]](nil),
+		activeRunsByExecutor:      immutable.NewMap[string, immutable.SortedSet[*JobRun]](nil),
+		queuedJobCounts:           newQueuedJobCounts(),
+		schedulingOutcomesByJobId: immutable.NewMap[string, SchedulingOutcome](nil),
+		active:                    true,
+		jobDb:                     jobDb,
+	}
+}
+
+// ShadowWriteTxn returns a writeable transaction starting from the committed contents of the jobDb, which doesn't block
+// or get blocked by other transactions and can't be committed, e.g., to run a scheduling round whose decisions are
+// discarded. Changes made to it are never visible to other transactions.
+func (jobDb *JobDb) ShadowWriteTxn() *Txn {
+	jobDb.copyMutex.Lock()
+	defer jobDb.copyMutex.Unlock()
+	return &Txn{
+		readOnly:                  false,
+		detached:                  true,
+		jobsById:                  jobDb.jobsById,
+		jobsByRunId:               jobDb.jobsByRunId,
+		jobsByQueue:               maps.Clone(jobDb.jobsByQueue),
+		queuedJobsByTtl:           jobDb.queuedJobsByTtl,
+		queuedJobsByPriorityClass: maps.Clone(jobDb.queuedJobsByPriorityClass),
+		jobsByGangId:              jobDb.jobsByGangId,
+		activeRunsByExecutor:      jobDb.activeRunsByExecutor,
+		queuedJobCounts:           jobDb.queuedJobCounts,
+		schedulingOutcomesByJobId: jobDb.schedulingOutcomesByJobId,
+		active:                    true,
+		jobDb:                     jobDb,
 	}
 }
 
@@ -164,6 +394,8 @@ func (jobDb *JobDb) WriteTxn() *Txn {
 // until the transaction is committed.
 type Txn struct {
 	readOnly bool
+	// If true, the transaction was created by DetachedWriteTxn or ShadowWriteTxn and committing it has no effect.
+	detached bool
 	// Map from job ids to jobs.
 	jobsById *immutable.Map[string, *Job]
 	// Map from run ids to jobs.
@@ -172,16 +404,43 @@ type Txn struct {
 	// Queued jobs for each queue. Stored in the order in which they should be scheduled.
 	jobsByQueue map[string]immutable.SortedSet[*Job]
 	// Queued jobs for each queue ordered by remaining time-to-live.
-	// TODO: The ordering is wrong. Since we call time.Now() in the compare function.
 	queuedJobsByTtl *immutable.SortedSet[*Job]
-	jobDb           *JobDb
-	active          bool
+	// Queued jobs for each effective priority class ordered by submission time, oldest first.
+	queuedJobsByPriorityClass map[string]immutable.SortedSet[*Job]
+	// Ids of the jobs in each gang, by gang id. Jobs not in a gang aren't indexed.
+	jobsByGangId *immutable.Map[string, immutable.Set[string]]
+	// Active runs assigned to each executor, ordered by creation time; see ActiveRuns.
+	activeRunsByExecutor *immutable.Map[string, immutable.SortedSet[*JobRun]]
+	// Number of queued jobs in each group; see QueuedJobCounts.
+	queuedJobCounts *immutable.Map[QueuedJobCountKey, int]
+	// Outcome of the most recent scheduling round in which each job was evaluated, by job id.
+	// Stored separately from jobs since they're updated every round.
+	schedulingOutcomesByJobId *immutable.Map[string, SchedulingOutcome]
+	jobDb                     *JobDb
+	active                    bool
+	// Stats of the changes made by this transaction, reported to the commitObserver of the jobDb on commit.
+	commitStats CommitStats
+	// Ids of the jobs upserted by this transaction; nil unless transition tracking is enabled.
+	upsertedJobIds map[string]bool
+	// Ids of the jobs upserted or deleted by this transaction since TrackDirtyJobIds was called; nil until then.
+	dirtyJobIds map[string]bool
 }
 
 func (txn *Txn) Commit() {
 	if txn.readOnly || !txn.active {
 		return
 	}
+	if txn.detached {
+		txn.active = false
+		return
+	}
+	if observer := txn.jobDb.commitObserver; observer != nil {
+		start := time.Now()
+		defer func() {
+			txn.commitStats.Duration = time.Since(start)
+			observer.ObserveCommit(txn.commitStats)
+		}()
+	}
 	txn.jobDb.copyMutex.Lock()
 	defer txn.jobDb.copyMutex.Unlock()
 	defer txn.jobDb.writerMutex.Unlock()
@@ -189,6 +448,11 @@ func (txn *Txn) Commit() {
 	txn.jobDb.jobsByRunId = txn.jobsByRunId
 	txn.jobDb.jobsByQueue = txn.jobsByQueue
 	txn.jobDb.queuedJobsByTtl = txn.queuedJobsByTtl
+	txn.jobDb.queuedJobsByPriorityClass = txn.queuedJobsByPriorityClass
+	txn.jobDb.jobsByGangId = txn.jobsByGangId
+	txn.jobDb.activeRunsByExecutor = txn.activeRunsByExecutor
+	txn.jobDb.queuedJobCounts = txn.queuedJobCounts
+	txn.jobDb.schedulingOutcomesByJobId = txn.schedulingOutcomesByJobId
 	txn.active = false
 }
 
@@ -197,15 +461,116 @@ func (txn *Txn) Abort() {
 		return
 	}
 	txn.active = false
+	if txn.detached {
+		return
+	}
 	txn.jobDb.writerMutex.Unlock()
 }
 
+// ReplaceContents replaces all jobs and scheduling outcomes held by txn with those held by source,
+// which must be a transaction of the same jobDb, e.g., one created by DetachedWriteTxn.
+// Once txn is committed, readers see either the previous contents or those of source, never a mix.
+func (txn *Txn) ReplaceContents(source *Txn) error {
+	if txn.readOnly {
+		return errors.New("cannot replace the contents of a read-only transaction")
+	}
+	if source.jobDb != txn.jobDb {
+		return errors.New("cannot replace the contents of a transaction with those of a transaction of another jobDb")
+	}
+	txn.jobsById = source.jobsById
+	txn.jobsByRunId = source.jobsByRunId
+	txn.jobsByQueue = maps.Clone(source.jobsByQueue)
+	txn.queuedJobsByTtl = source.queuedJobsByTtl
+	txn.queuedJobsByPriorityClass = maps.Clone(source.queuedJobsByPriorityClass)
+	txn.jobsByGangId = source.jobsByGangId
+	txn.activeRunsByExecutor = source.activeRunsByExecutor
+	txn.queuedJobCounts = source.queuedJobCounts
+	txn.schedulingOutcomesByJobId = source.schedulingOutcomesByJobId
+	return nil
+}
+
+// Savepoint is the state of a write transaction at some point, to which the transaction can later be rolled back.
+type Savepoint struct {
+	jobsById                  *immutable.Map[string, *Job]
+	jobsByRunId               *immutable.Map[uuid.UUID, string]
+	jobsByQueue               map[string]immutable.SortedSet[*Job]
+	queuedJobsByTtl           *immutable.SortedSet[*Job]
+	queuedJobsByPriorityClass map[string]immutable.SortedSet[*Job]
+	jobsByGangId              *immutable.Map[string, immutable.Set[string]]
+	activeRunsByExecutor      *immutable.Map[string, immutable.SortedSet[*JobRun]]
+	queuedJobCounts           *immutable.Map[QueuedJobCountKey, int]
+	schedulingOutcomesByJobId *immutable.Map[string, SchedulingOutcome]
+}
+
+// Savepoint returns the current state of txn, such that changes made to txn from here on can be undone
+// without aborting it by calling RollbackTo.
+func (txn *Txn) Savepoint() *Savepoint {
+	return &Savepoint{
+		jobsById:                  txn.jobsById,
+		jobsByRunId:               txn.jobsByRunId,
+		jobsByQueue:               maps.Clone(txn.jobsByQueue),
+		queuedJobsByTtl:           txn.queuedJobsByTtl,
+		queuedJobsByPriorityClass: maps.Clone(txn.queuedJobsByPriorityClass),
+		jobsByGangId:              txn.jobsByGangId,
+		activeRunsByExecutor:      txn.activeRunsByExecutor,
+		queuedJobCounts:           txn.queuedJobCounts,
+		schedulingOutcomesByJobId: txn.schedulingOutcomesByJobId,
+	}
+}
+
+// RollbackTo undoes all changes made to txn since savepoint was taken from it.
+func (txn *Txn) RollbackTo(savepoint *Savepoint) error {
+	if err := txn.checkWritableTransaction(); err != nil {
+		return err
+	}
+	txn.jobsById = savepoint.jobsById
+	txn.jobsByRunId = savepoint.jobsByRunId
+	txn.jobsByQueue = maps.Clone(savepoint.jobsByQueue)
+	txn.queuedJobsByTtl = savepoint.queuedJobsByTtl
+	txn.queuedJobsByPriorityClass = maps.Clone(savepoint.queuedJobsByPriorityClass)
+	txn.jobsByGangId = savepoint.jobsByGangId
+	txn.activeRunsByExecutor = savepoint.activeRunsByExecutor
+	txn.queuedJobCounts = savepoint.queuedJobCounts
+	txn.schedulingOutcomesByJobId = savepoint.schedulingOutcomesByJobId
+	return nil
+}
+
+// TrackDirtyJobIds causes the ids of the jobs subsequently upserted or deleted by txn to be recorded,
+// discarding any recorded previously; see DirtyJobIds.
+func (txn *Txn) TrackDirtyJobIds() {
+	txn.dirtyJobIds = make(map[string]bool)
+}
+
+// DirtyJobIds returns the ids of the jobs upserted or deleted by txn since TrackDirtyJobIds was last called,
+// including those of jobs upserted unchanged or whose changes were since rolled back to a savepoint.
+// Returns nil if TrackDirtyJobIds hasn't been called.
+func (txn *Txn) DirtyJobIds() map[string]bool {
+	return txn.dirtyJobIds
+}
+
 // Upsert will insert the given jobs if they don't already exist or update them if they do.
 func (txn *Txn) Upsert(jobs []*Job) error {
 	if err := txn.checkWritableTransaction(); err != nil {
 		return err
 	}
 
+	txn.commitStats.NumUpserted += len(jobs)
+	if txn.upsertedJobIds != nil {
+		for _, job := range jobs {
+			txn.upsertedJobIds[job.id] = true
+		}
+	}
+	if txn.dirtyJobIds != nil {
+		for _, job := range jobs {
+			txn.dirtyJobIds[job.id] = true
+		}
+	}
+	breakdown := txn.jobDb.commitBreakdown
+	var start time.Time
+	if breakdown {
+		start = time.Now()
+	}
+
 	hasJobs := txn.jobsById.Len() > 0
 
 	// First, delete any jobs to be upserted from the set of queued jobs.
@@ -222,17 +587,44 @@ func (txn *Txn) Upsert(jobs []*Job) error {
 
 				newQueuedJobsByTtl := txn.queuedJobsByTtl.Delete(existingJob)
 				txn.queuedJobsByTtl = &newQueuedJobsByTtl
+
+				if existingJob.Queued() {
+					txn.deleteFromPriorityClassIndex(existingJob)
+					txn.addToQueuedJobCounts(existingJob, -1)
+				}
+
+				if existingGangId := existingJob.GangId(); existingGangId != job.GangId() {
+					txn.deleteFromGangIndex(existingGangId, existingJob.id)
+				}
+
+				txn.deleteFromActiveRunIndex(existingJob)
 			}
 		}
 	}
 
+	if breakdown {
+		txn.commitStats.IndexUpdateDuration += time.Since(start)
+	}
+
 	// Now need to insert jobs, runs and queuedJobs. This can be done in parallel.
+	// If measuring, each goroutine records the time it took; the time spent on indices is summed.
 	wg := sync.WaitGroup{}
-	wg.Add(3)
+	wg.Add(5)
+	var treeCopyDuration time.Duration
+	var indexUpdateDurations [4]time.Duration
+	timed := func(d *time.Duration, f func()) {
+		defer wg.Done()
+		if !breakdown {
+			f()
+			return
+		}
+		start := time.Now()
+		f()
+		*d = time.Since(start)
+	}
 
 	// jobs
-	go func() {
-		defer wg.Done()
+	go timed(&treeCopyDuration, func() {
 		if hasJobs {
 			for _, job := range jobs {
 				txn.jobsById = txn.jobsById.Set(job.id, job)
@@ -244,11 +636,10 @@ func (txn *Txn) Upsert(jobs []*Job) error {
 			}
 			txn.jobsById = jobsById.Map()
 		}
-	}()
+	})
 
 	// runs
-	go func() {
-		defer wg.Done()
+	go timed(&indexUpdateDurations[0], func() {
 		if hasJobs {
 			for _, job := range jobs {
 				for _, run := range job.runsById {
@@ -264,12 +655,11 @@ func (txn *Txn) Upsert(jobs []*Job) error {
 			}
 			txn.jobsByRunId = jobsByRunId.Map()
 		}
-	}()
+	})
 
 	// Queued jobs are additionally stored in an ordered set.
 	// To enable iterating over them in the order they should be scheduled.
-	go func() {
-		defer wg.Done()
+	go timed(&indexUpdateDurations[1], func() {
 		for _, job := range jobs {
 			if job.Queued() {
 				newQueue, ok := txn.jobsByQueue[job.queue]
@@ -284,13 +674,116 @@ func (txn *Txn) Upsert(jobs []*Job) error {
 					queuedJobsByTtl := txn.queuedJobsByTtl.Add(job)
 					txn.queuedJobsByTtl = &queuedJobsByTtl
 				}
+
+				priorityClassName, _ := txn.jobDb.EffectivePriorityClassName(job)
+				queuedJobs, ok := txn.queuedJobsByPriorityClass[priorityClassName]
+				if !ok {
+					queuedJobs = emptyQueuedJobsByAge
+				}
+				txn.queuedJobsByPriorityClass[priorityClassName] = queuedJobs.Add(job)
+
+				txn.addToQueuedJobCounts(job, 1)
 			}
 		}
-	}()
+	})
+
+	// gangs
+	go timed(&indexUpdateDurations[2], func() {
+		for _, job := range jobs {
+			txn.addToGangIndex(job.GangId(), job.id)
+		}
+	})
+
+	// active runs
+	go timed(&indexUpdateDurations[3], func() {
+		for _, job := range jobs {
+			txn.addToActiveRunIndex(job)
+		}
+	})
 	wg.Wait()
+	if breakdown {
+		txn.commitStats.TreeCopyDuration += treeCopyDuration
+		for _, d := range indexUpdateDurations {
+			txn.commitStats.IndexUpdateDuration += d
+		}
+	}
 	return nil
 }
 
+// deleteFromPriorityClassIndex removes job, which must be queued, from the queued jobs of its priority class.
+func (txn *Txn) deleteFromPriorityClassIndex(job *Job) {
+	priorityClassName, _ := txn.jobDb.EffectivePriorityClassName(job)
+	if queuedJobs, ok := txn.queuedJobsByPriorityClass[priorityClassName]; ok {
+		txn.queuedJobsByPriorityClass[priorityClassName] = queuedJobs.Delete(job)
+	}
+}
+
+func (txn *Txn) addToGangIndex(gangId string, jobId string) {
+	if gangId == "" {
+		return
+	}
+	jobIds, ok := txn.jobsByGangId.Get(gangId)
+	if !ok {
+		jobIds = immutable.NewSet[string](nil)
+	}
+	txn.jobsByGangId = txn.jobsByGangId.Set(gangId, jobIds.Add(jobId))
+}
+
+// addToActiveRunIndex adds the active runs of job to the active runs of their executors.
+func (txn *Txn) addToActiveRunIndex(job *Job) {
+	if job.InTerminalState() {
+		return
+	}
+	for _, run := range job.runsById {
+		if run.InTerminalState() {
+			continue
+		}
+		runs, ok := txn.activeRunsByExecutor.Get(run.executor)
+		if !ok {
+			runs = emptyActiveRuns
+		}
+		txn.activeRunsByExecutor = txn.activeRunsByExecutor.Set(run.executor, runs.Add(run))
+	}
+}
+
+// deleteFromActiveRunIndex removes the active runs of job from the active runs of their executors.
+func (txn *Txn) deleteFromActiveRunIndex(job *Job) {
+	if job.InTerminalState() {
+		return
+	}
+	for _, run := range job.runsById {
+		if run.InTerminalState() {
+			continue
+		}
+		runs, ok := txn.activeRunsByExecutor.Get(run.executor)
+		if !ok {
+			continue
+		}
+		runs = runs.Delete(run)
+		if runs.Len() == 0 {
+			txn.activeRunsByExecutor = txn.activeRunsByExecutor.Delete(run.executor)
+		} else {
+			txn.activeRunsByExecutor = txn.activeRunsByExecutor.Set(run.executor, runs)
+		}
+	}
+}
+
+func (txn *Txn) deleteFromGangIndex(gangId string, jobId string) {
+	if gangId == "" {
+		return
+	}
+	jobIds, ok := txn.jobsByGangId.Get(gangId)
+	if !ok {
+		return
+	}
+	jobIds = jobIds.Delete(jobId)
+	if jobIds.Len() == 0 {
+		txn.jobsByGangId = txn.jobsByGangId.Delete(gangId)
+	} else {
+		txn.jobsByGangId = txn.jobsByGangId.Set(gangId, jobIds)
+	}
+}
+
 // GetById returns the job with the given Id or nil if no such job exists
 // The Job returned by this function *must not* be subsequently modified
 func (txn *Txn) GetById(id string) *Job {
@@ -305,6 +798,24 @@ func (txn *Txn) GetByRunId(runId uuid.UUID) *Job {
 	return txn.GetById(jobId)
 }
 
+// GetGangJobs returns the jobs in the database that are members of the gang with the given id, ordered by id.
+// The Jobs returned by this function *must not* be subsequently modified
+func (txn *Txn) GetGangJobs(gangId string) []*Job {
+	jobIds, ok := txn.jobsByGangId.Get(gangId)
+	if !ok {
+		return nil
+	}
+	ids := jobIds.Items()
+	slices.Sort(ids)
+	jobs := make([]*Job, 0, len(ids))
+	for _, id := range ids {
+		if job := txn.GetById(id); job != nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
 // HasQueuedJobs returns true if the queue has any jobs in the running state or false otherwise
 func (txn *Txn) HasQueuedJobs(queue string) bool {
 	queuedJobs, ok := txn.jobsByQueue[queue]
@@ -314,6 +825,15 @@ func (txn *Txn) HasQueuedJobs(queue string) bool {
 	return queuedJobs.Len() > 0
 }
 
+// NumQueuedJobs returns the number of queued jobs in the given queue.
+func (txn *Txn) NumQueuedJobs(queue string) int {
+	queuedJobs, ok := txn.jobsByQueue[queue]
+	if !ok {
+		return 0
+	}
+	return queuedJobs.Len()
+}
+
 // QueuedJobs returns true if the queue has any jobs in the running state or false otherwise
 func (txn *Txn) QueuedJobs(queue string) *immutable.SortedSetIterator[*Job] {
 	jobQueue, ok := txn.jobsByQueue[queue]
@@ -324,11 +844,48 @@ func (txn *Txn) QueuedJobs(queue string) *immutable.SortedSetIterator[*Job] {
 	}
 }
 
+// QueuesWithQueuedJobs returns the names of all queues with at least one queued job, in lexicographical order.
+func (txn *Txn) QueuesWithQueuedJobs() []string {
+	queues := make([]string, 0, len(txn.jobsByQueue))
+	for queue, queuedJobs := range txn.jobsByQueue {
+		if queuedJobs.Len() > 0 {
+			queues = append(queues, queue)
+		}
+	}
+	slices.Sort(queues)
+	return queues
+}
+
+// ActiveRuns returns an iterator over the active runs assigned to executor, i.e., the runs that haven't succeeded,
+// failed, been returned, or been cancelled, of jobs that haven't, ordered by creation time, oldest first.
+// The runs returned by this function *must not* be subsequently modified.
+func (txn *Txn) ActiveRuns(executor string) *immutable.SortedSetIterator[*JobRun] {
+	runs, ok := txn.activeRunsByExecutor.Get(executor)
+	if !ok {
+		return emptyActiveRuns.Iterator()
+	}
+	return runs.Iterator()
+}
+
 // QueuedJobsByTtl returns an iterator for jobs ordered by queue ttl time - the closest to expiry first
 func (txn *Txn) QueuedJobsByTtl() *immutable.SortedSetIterator[*Job] {
 	return txn.queuedJobsByTtl.Iterator()
 }
 
+// OldestQueuedJobByPriorityClass returns, for each configured priority class, the queued job of that class
+// submitted the longest ago, or nil if there are no queued jobs of that class.
+// Jobs are assigned the priority class returned by JobDb.EffectivePriorityClassName.
+func (txn *Txn) OldestQueuedJobByPriorityClass() map[string]*Job {
+	rv := make(map[string]*Job, len(txn.jobDb.priorityClasses))
+	for priorityClassName := range txn.jobDb.priorityClasses {
+		rv[priorityClassName] = nil
+		if queuedJobs, ok := txn.queuedJobsByPriorityClass[priorityClassName]; ok && queuedJobs.Len() > 0 {
+			rv[priorityClassName], _ = queuedJobs.Iterator().Next()
+		}
+	}
+	return rv
+}
+
 // GetAll returns all jobs in the database.
 // The Jobs returned by this function *must not* be subsequently modified
 func (txn *Txn) GetAll() []*Job {
@@ -347,10 +904,23 @@ func (txn *Txn) BatchDelete(ids []string) error {
 	if err := txn.checkWritableTransaction(); err != nil {
 		return err
 	}
+	breakdown := txn.jobDb.commitBreakdown
 	for _, id := range ids {
 		job, present := txn.jobsById.Get(id)
+		if present && txn.dirtyJobIds != nil {
+			txn.dirtyJobIds[id] = true
+		}
 		if present {
+			txn.commitStats.NumDeleted++
+			var start time.Time
+			if breakdown {
+				start = time.Now()
+			}
 			txn.jobsById = txn.jobsById.Delete(id)
+			if breakdown {
+				txn.commitStats.TreeCopyDuration += time.Since(start)
+				start = time.Now()
+			}
 			for _, run := range job.runsById {
 				txn.jobsByRunId = txn.jobsByRunId.Delete(run.id)
 			}
@@ -365,6 +935,18 @@ func (txn *Txn) BatchDelete(ids []string) error {
 				newQueuedJobsByExpiry := txn.queuedJobsByTtl.Delete(job)
 				txn.queuedJobsByTtl = &newQueuedJobsByExpiry
 			}
+
+			if job.Queued() {
+				txn.deleteFromPriorityClassIndex(job)
+				txn.addToQueuedJobCounts(job, -1)
+			}
+
+			txn.deleteFromGangIndex(job.GangId(), job.id)
+			txn.deleteFromActiveRunIndex(job)
+			txn.schedulingOutcomesByJobId = txn.schedulingOutcomesByJobId.Delete(id)
+			if breakdown {
+				txn.commitStats.IndexUpdateDuration += time.Since(start)
+			}
 		}
 	}
 	return nil
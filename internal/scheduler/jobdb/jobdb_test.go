@@ -2,7 +2,9 @@ package jobdb
 
 import (
 	"math/rand"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
@@ -14,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/types"
 	"github.com/armadaproject/armada/internal/common/util"
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
@@ -73,8 +76,8 @@ func TestJobDb_TestGetById(t *testing.T) {
 
 func TestJobDb_TestGetByRunId(t *testing.T) {
 	jobDb := NewTestJobDb()
-	job1 := newJob().WithNewRun("executor", "nodeId", "nodeName", 5)
-	job2 := newJob().WithNewRun("executor", "nodeId", "nodeName", 10)
+	job1 := newJob().WithNewRun("executor", "nodeId", "nodeName", 5, time.Now())
+	job2 := newJob().WithNewRun("executor", "nodeId", "nodeName", 10, time.Now())
 	txn := jobDb.WriteTxn()
 
 	err := txn.Upsert([]*Job{job1, job2})
@@ -90,8 +93,8 @@ func TestJobDb_TestGetByRunId(t *testing.T) {
 
 func TestJobDb_TestHasQueuedJobs(t *testing.T) {
 	jobDb := NewTestJobDb()
-	job1 := newJob().WithNewRun("executor", "nodeId", "nodeName", 5)
-	job2 := newJob().WithNewRun("executor", "nodeId", "nodeName", 10)
+	job1 := newJob().WithNewRun("executor", "nodeId", "nodeName", 5, time.Now())
+	job2 := newJob().WithNewRun("executor", "nodeId", "nodeName", 10, time.Now())
 	txn := jobDb.WriteTxn()
 
 	err := txn.Upsert([]*Job{job1, job2})
@@ -105,6 +108,44 @@ func TestJobDb_TestHasQueuedJobs(t *testing.T) {
 	assert.False(t, txn.HasQueuedJobs("non-existent-queue"))
 }
 
+func TestJobDb_TestQueuesWithQueuedJobs(t *testing.T) {
+	jobDb := NewTestJobDb()
+	job1 := newJob().WithQueue("queue-b").WithQueued(true)
+	job2 := newJob().WithQueue("queue-a").WithQueued(true)
+	job3 := newJob().WithQueue("queue-c")
+	txn := jobDb.WriteTxn()
+
+	err := txn.Upsert([]*Job{job1, job2, job3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"queue-a", "queue-b"}, txn.QueuesWithQueuedJobs())
+
+	err = txn.Upsert([]*Job{job1.WithQueued(false)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"queue-a"}, txn.QueuesWithQueuedJobs())
+}
+
+func TestJobDb_TestNumQueuedJobs(t *testing.T) {
+	jobDb := NewTestJobDb()
+	job1 := newJob().WithQueue("queue-a").WithQueued(true)
+	job2 := newJob().WithQueue("queue-a").WithQueued(true)
+	job3 := newJob().WithQueue("queue-b")
+	txn := jobDb.WriteTxn()
+
+	err := txn.Upsert([]*Job{job1, job2, job3})
+	require.NoError(t, err)
+	assert.Equal(t, 2, txn.NumQueuedJobs("queue-a"))
+	assert.Equal(t, 0, txn.NumQueuedJobs("queue-b"))
+	assert.Equal(t, 0, txn.NumQueuedJobs("non-existent-queue"))
+
+	err = txn.Upsert([]*Job{job1.WithQueued(false)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, txn.NumQueuedJobs("queue-a"))
+
+	err = txn.BatchDelete([]string{job2.id})
+	require.NoError(t, err)
+	assert.Equal(t, 0, txn.NumQueuedJobs("queue-a"))
+}
+
 func TestJobDb_TestQueuedJobs(t *testing.T) {
 	jobDb := NewTestJobDb()
 	jobs := make([]*Job, 10)
@@ -161,8 +202,8 @@ func TestJobDb_TestQueuedJobs(t *testing.T) {
 
 func TestJobDb_TestGetAll(t *testing.T) {
 	jobDb := NewTestJobDb()
-	job1 := newJob().WithNewRun("executor", "nodeId", "nodeName", 5)
-	job2 := newJob().WithNewRun("executor", "nodeId", "nodeName", 10)
+	job1 := newJob().WithNewRun("executor", "nodeId", "nodeName", 5, time.Now())
+	job2 := newJob().WithNewRun("executor", "nodeId", "nodeName", 10, time.Now())
 	txn := jobDb.WriteTxn()
 	assert.Equal(t, []*Job{}, txn.GetAll())
 
@@ -198,10 +239,70 @@ func TestJobDb_TestTransactions(t *testing.T) {
 	assert.Error(t, txn1.Upsert([]*Job{job})) // should be error as you can't insert after committing
 }
 
+func TestJobDb_TestSavepoint(t *testing.T) {
+	jobDb := NewTestJobDb()
+	job1 := newJob().WithQueued(true)
+	job2 := newJob().WithQueued(true)
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*Job{job1}))
+
+	savepoint := txn.Savepoint()
+	require.NoError(t, txn.Upsert([]*Job{job2, job1.WithQueued(false).WithNewRun("executor", "nodeId", "nodeName", 0, time.Now())}))
+	assert.NotNil(t, txn.GetById(job2.Id()))
+	assert.False(t, txn.GetById(job1.Id()).Queued())
+
+	// Changes made since the savepoint are undone, but those made before it are retained.
+	require.NoError(t, txn.RollbackTo(savepoint))
+	assert.Nil(t, txn.GetById(job2.Id()))
+	assert.Equal(t, job1, txn.GetById(job1.Id()))
+	assert.Equal(t, 1, txn.NumQueuedJobs(job1.Queue()))
+
+	// The transaction remains usable after rolling back.
+	require.NoError(t, txn.Upsert([]*Job{job2}))
+	txn.Commit()
+	assert.NotNil(t, jobDb.ReadTxn().GetById(job1.Id()))
+	assert.NotNil(t, jobDb.ReadTxn().GetById(job2.Id()))
+
+	// Can't roll back a read only transaction.
+	assert.Error(t, jobDb.ReadTxn().RollbackTo(savepoint))
+}
+
+func TestJobDb_TestTransitions(t *testing.T) {
+	jobDb := NewTestJobDb()
+	queued := newJob().WithQueued(true)
+	leased := newJob().WithNewRun("executor", "nodeId", "nodeName", 0, time.Now())
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*Job{queued, leased}))
+	// Transitions aren't tracked unless enabled.
+	assert.Nil(t, txn.Transitions())
+	txn.Commit()
+
+	jobDb.EnableTransitionTracking()
+	deleted := newJob().WithNewRun("executor", "nodeId", "nodeName", 0, time.Now())
+	txn = jobDb.WriteTxn()
+	defer txn.Abort()
+	require.NoError(t, txn.Upsert([]*Job{
+		queued.WithQueued(false).WithNewRun("executor", "nodeId", "nodeName", 0, time.Now()),
+		leased.WithSucceeded(true),
+		deleted,
+	}))
+	require.NoError(t, txn.BatchDelete([]string{deleted.Id()}))
+	assert.Equal(
+		t,
+		map[JobTransition]map[string]bool{
+			JobLeased:    {queued.Id(): true},
+			JobSucceeded: {leased.Id(): true},
+			JobFailed:    {},
+			JobCancelled: {},
+		},
+		txn.Transitions(),
+	)
+}
+
 func TestJobDb_TestBatchDelete(t *testing.T) {
 	jobDb := NewTestJobDb()
-	job1 := newJob().WithQueued(true).WithNewRun("executor", "nodeId", "nodeName", 5)
-	job2 := newJob().WithQueued(true).WithNewRun("executor", "nodeId", "nodeName", 10)
+	job1 := newJob().WithQueued(true).WithNewRun("executor", "nodeId", "nodeName", 5, time.Now())
+	job2 := newJob().WithQueued(true).WithNewRun("executor", "nodeId", "nodeName", 10, time.Now())
 	txn := jobDb.WriteTxn()
 
 	// Insert Job
@@ -217,6 +318,236 @@ func TestJobDb_TestBatchDelete(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestJobDb_TestDirtyJobIds(t *testing.T) {
+	jobDb := NewTestJobDb()
+	job1 := newJob().WithQueued(true)
+	job2 := newJob().WithQueued(true)
+	job3 := newJob().WithQueued(true)
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*Job{job1, job2, job3}))
+	assert.Nil(t, txn.DirtyJobIds())
+
+	// Only jobs written after tracking starts are recorded.
+	txn.TrackDirtyJobIds()
+	assert.Empty(t, txn.DirtyJobIds())
+	require.NoError(t, txn.Upsert([]*Job{job1.WithQueued(false)}))
+	require.NoError(t, txn.BatchDelete([]string{job2.Id(), "missing"}))
+	assert.Equal(t, map[string]bool{job1.Id(): true, job2.Id(): true}, txn.DirtyJobIds())
+
+	// Restarting tracking discards the jobs recorded previously.
+	txn.TrackDirtyJobIds()
+	require.NoError(t, txn.Upsert([]*Job{job3}))
+	assert.Equal(t, map[string]bool{job3.Id(): true}, txn.DirtyJobIds())
+}
+
+func TestJobDb_TestOldestQueuedJobByPriorityClass(t *testing.T) {
+	jobDb := NewTestJobDb()
+	barSchedulingInfo := proto.Clone(jobSchedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+	barSchedulingInfo.PriorityClassName = "bar"
+	newJobSubmittedAt := func(submittedTime int64, schedulingInfo *schedulerobjects.JobSchedulingInfo) *Job {
+		job := newJob().WithQueued(true)
+		job.submittedTime = submittedTime
+		job.jobSchedulingInfo = schedulingInfo
+		return job
+	}
+	fooOld := newJobSubmittedAt(1, jobSchedulingInfo)
+	fooNew := newJobSubmittedAt(3, jobSchedulingInfo)
+	barOld := newJobSubmittedAt(2, barSchedulingInfo)
+	// Jobs of unknown priority classes are assigned the default priority class.
+	unknownSchedulingInfo := proto.Clone(jobSchedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+	unknownSchedulingInfo.PriorityClassName = "unknown"
+	unknownNew := newJobSubmittedAt(4, unknownSchedulingInfo)
+
+	txn := jobDb.WriteTxn()
+	assert.Equal(t, map[string]*Job{"foo": nil, "bar": nil}, txn.OldestQueuedJobByPriorityClass())
+	require.NoError(t, txn.Upsert([]*Job{fooNew, unknownNew, barOld, fooOld}))
+	assert.Equal(t, map[string]*Job{"foo": fooOld, "bar": barOld}, txn.OldestQueuedJobByPriorityClass())
+
+	// Jobs leave the index once leased or deleted, leaving empty classes without an oldest job.
+	leased := fooOld.WithQueued(false)
+	require.NoError(t, txn.Upsert([]*Job{leased}))
+	require.NoError(t, txn.BatchDelete([]string{barOld.Id()}))
+	assert.Equal(t, map[string]*Job{"foo": fooNew, "bar": nil}, txn.OldestQueuedJobByPriorityClass())
+
+	// Requeued jobs re-enter the index.
+	savepoint := txn.Savepoint()
+	require.NoError(t, txn.Upsert([]*Job{leased.WithQueued(true)}))
+	assert.Equal(t, fooOld.Id(), txn.OldestQueuedJobByPriorityClass()["foo"].Id())
+	require.NoError(t, txn.RollbackTo(savepoint))
+	assert.Equal(t, fooNew, txn.OldestQueuedJobByPriorityClass()["foo"])
+
+	// Changes are only visible to other transactions once committed.
+	assert.Equal(t, map[string]*Job{"foo": nil, "bar": nil}, jobDb.ReadTxn().OldestQueuedJobByPriorityClass())
+	txn.Commit()
+	assert.Equal(t, map[string]*Job{"foo": fooNew, "bar": nil}, jobDb.ReadTxn().OldestQueuedJobByPriorityClass())
+}
+
+type commitStatsRecorder struct {
+	stats []CommitStats
+}
+
+func (r *commitStatsRecorder) ObserveCommit(stats CommitStats) {
+	r.stats = append(r.stats, stats)
+}
+
+type commitStatsDiscarder struct{}
+
+func (commitStatsDiscarder) ObserveCommit(CommitStats) {}
+
+func TestJobDb_CommitObserver(t *testing.T) {
+	for name, breakdown := range map[string]bool{"withoutBreakdown": false, "withBreakdown": true} {
+		t.Run(name, func(t *testing.T) {
+			jobDb := NewTestJobDb()
+			recorder := &commitStatsRecorder{}
+			jobDb.EnableCommitObserver(recorder, breakdown)
+
+			jobs := make([]*Job, 10)
+			for i := range jobs {
+				jobs[i] = newJob().WithQueued(true)
+			}
+			txn := jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert(jobs))
+			txn.Commit()
+
+			txn = jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert(jobs[:2]))
+			require.NoError(t, txn.BatchDelete([]string{jobs[2].Id(), jobs[3].Id(), util.NewULID()}))
+			txn.Commit()
+
+			// Aborted and read-only transactions aren't reported.
+			txn = jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert(jobs))
+			txn.Abort()
+			jobDb.ReadTxn().Commit()
+
+			require.Len(t, recorder.stats, 2)
+			assert.Equal(t, 10, recorder.stats[0].NumUpserted)
+			assert.Equal(t, 0, recorder.stats[0].NumDeleted)
+			assert.Equal(t, 2, recorder.stats[1].NumUpserted)
+			assert.Equal(t, 2, recorder.stats[1].NumDeleted)
+			for _, stats := range recorder.stats {
+				assert.Positive(t, stats.Duration)
+				if breakdown {
+					assert.Positive(t, stats.TreeCopyDuration)
+					assert.Positive(t, stats.IndexUpdateDuration)
+				} else {
+					assert.Zero(t, stats.TreeCopyDuration)
+					assert.Zero(t, stats.IndexUpdateDuration)
+				}
+			}
+		})
+	}
+}
+
+func TestJobDb_TestGetGangJobs(t *testing.T) {
+	for name, lazy := range map[string]bool{"eager": false, "lazy": true} {
+		t.Run(name, func(t *testing.T) {
+			jobDb := NewTestJobDb()
+			if lazy {
+				jobDb.EnableLazySchedulingInfo()
+			}
+			newGangJob := func(gangId string) *Job {
+				schedulingInfo := proto.Clone(jobSchedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+				schedulingInfo.GetPodRequirements().Annotations = map[string]string{configuration.GangIdAnnotation: gangId}
+				return jobDb.NewJob(util.NewULID(), "jobSet", "queue", 0, schedulingInfo, true, 0, false, false, false, 0)
+			}
+			job1 := newGangJob("gang")
+			job2 := newGangJob("gang")
+			job3 := newGangJob("otherGang")
+			job4 := newJob()
+			txn := jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*Job{job1, job2, job3, job4}))
+
+			expected := []*Job{job1, job2}
+			slices.SortFunc(expected, func(a, b *Job) bool { return a.Id() < b.Id() })
+			assert.Equal(t, expected, txn.GetGangJobs("gang"))
+			assert.Equal(t, []*Job{job3}, txn.GetGangJobs("otherGang"))
+			assert.Empty(t, txn.GetGangJobs(""))
+			assert.Empty(t, txn.GetGangJobs("missing"))
+
+			// Updated jobs remain in their gang and deleted jobs are removed from it.
+			job1 = job1.WithQueued(false)
+			require.NoError(t, txn.Upsert([]*Job{job1}))
+			require.NoError(t, txn.BatchDelete([]string{job2.Id(), job3.Id()}))
+			assert.Equal(t, []*Job{job1}, txn.GetGangJobs("gang"))
+			assert.Empty(t, txn.GetGangJobs("otherGang"))
+
+			// The index is only visible to other transactions once committed.
+			assert.Empty(t, jobDb.ReadTxn().GetGangJobs("gang"))
+			txn.Commit()
+			assert.Equal(t, []*Job{job1}, jobDb.ReadTxn().GetGangJobs("gang"))
+		})
+	}
+}
+
+func TestJobDb_TestActiveRuns(t *testing.T) {
+	activeRunIds := func(txn *Txn, executor string) []uuid.UUID {
+		var rv []uuid.UUID
+		it := txn.ActiveRuns(executor)
+		for !it.Done() {
+			run, _ := it.Next()
+			rv = append(rv, run.Id())
+		}
+		return rv
+	}
+	jobDb := NewTestJobDb()
+	second := newJob().WithNewRun("executor", "nodeId", "nodeName", 0, time.Unix(0, 2))
+	first := newJob().WithNewRun("executor", "nodeId", "nodeName", 0, time.Unix(0, 1))
+	otherExecutor := newJob().WithNewRun("otherExecutor", "nodeId", "nodeName", 0, time.Unix(0, 1))
+	succeeded := newJob().WithNewRun("executor", "nodeId", "nodeName", 0, time.Unix(0, 1))
+	succeeded = succeeded.WithUpdatedRun(succeeded.LatestRun().WithSucceeded(true))
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*Job{second, first, otherExecutor, succeeded, newJob().WithQueued(true)}))
+	assert.Equal(t, []uuid.UUID{first.LatestRun().Id(), second.LatestRun().Id()}, activeRunIds(txn, "executor"))
+	assert.Equal(t, []uuid.UUID{otherExecutor.LatestRun().Id()}, activeRunIds(txn, "otherExecutor"))
+	assert.Empty(t, activeRunIds(txn, "missing"))
+
+	// Runs are removed once they or their job become terminal, and the new runs of requeued jobs are added.
+	savepoint := txn.Savepoint()
+	retried := first.WithUpdatedRun(first.LatestRun().WithReturned(true)).WithNewRun("otherExecutor", "nodeId", "nodeName", 0, time.Unix(0, 3))
+	require.NoError(t, txn.Upsert([]*Job{retried, second.WithCancelled(true)}))
+	require.NoError(t, txn.BatchDelete([]string{otherExecutor.Id()}))
+	assert.Empty(t, activeRunIds(txn, "executor"))
+	assert.Equal(t, []uuid.UUID{retried.LatestRun().Id()}, activeRunIds(txn, "otherExecutor"))
+
+	// The index is rolled back with the rest of the transaction and only visible to others once committed.
+	require.NoError(t, txn.RollbackTo(savepoint))
+	assert.Equal(t, []uuid.UUID{first.LatestRun().Id(), second.LatestRun().Id()}, activeRunIds(txn, "executor"))
+	assert.Empty(t, activeRunIds(jobDb.ReadTxn(), "executor"))
+	txn.Commit()
+	assert.Equal(t, []uuid.UUID{first.LatestRun().Id(), second.LatestRun().Id()}, activeRunIds(jobDb.ReadTxn(), "executor"))
+}
+
+func TestJobDb_TestQueuedJobCounts(t *testing.T) {
+	jobDb := NewTestJobDb()
+	// Counts aren't maintained unless enabled.
+	assert.Nil(t, jobDb.ReadTxn().QueuedJobCounts())
+
+	jobDb.EnableQueuedJobCounts(func(job *Job) string { return job.Jobset() })
+	job1 := newJob().WithQueued(true)
+	job2 := newJob().WithQueued(true)
+	job3 := newJob().WithQueued(true).WithPriority(1)
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*Job{job1, job2, job3, newJob()}))
+	key := QueuedJobCountKey{Queue: job1.Queue(), Bucket: job1.Jobset(), Priority: job1.Priority()}
+	otherKey := QueuedJobCountKey{Queue: job1.Queue(), Bucket: job1.Jobset(), Priority: 1}
+	assert.Equal(t, map[QueuedJobCountKey]int{key: 2, otherKey: 1}, txn.QueuedJobCounts())
+
+	// Jobs are counted in the group they're in after being updated and uncounted once leased or deleted.
+	savepoint := txn.Savepoint()
+	require.NoError(t, txn.Upsert([]*Job{job1.WithPriority(1), job2.WithQueued(false), job3}))
+	assert.Equal(t, map[QueuedJobCountKey]int{otherKey: 2}, txn.QueuedJobCounts())
+	require.NoError(t, txn.BatchDelete([]string{job1.Id(), job3.Id()}))
+	assert.Empty(t, txn.QueuedJobCounts())
+
+	// Counts are rolled back with the rest of the transaction and only visible to others once committed.
+	require.NoError(t, txn.RollbackTo(savepoint))
+	assert.Equal(t, map[QueuedJobCountKey]int{key: 2, otherKey: 1}, txn.QueuedJobCounts())
+	assert.Empty(t, jobDb.ReadTxn().QueuedJobCounts())
+	txn.Commit()
+	assert.Equal(t, map[QueuedJobCountKey]int{key: 2, otherKey: 1}, jobDb.ReadTxn().QueuedJobCounts())
+}
+
 func TestJobDb_SchedulingKeyIsPopulated(t *testing.T) {
 	podRequirements := &schedulerobjects.PodRequirements{
 		NodeSelector: map[string]string{"foo": "bar"},
@@ -240,6 +571,46 @@ func TestJobDb_SchedulingKeyIsPopulated(t *testing.T) {
 	assert.Equal(t, interfaces.SchedulingKeyFromLegacySchedulerJob(jobDb.schedulingKeyGenerator, job), actualSchedulingKey)
 }
 
+func TestJobDb_QueueDefaultTolerations(t *testing.T) {
+	userToleration := v1.Toleration{Key: "gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule}
+	defaultToleration := v1.Toleration{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "batch", Effect: v1.TaintEffectNoSchedule}
+	jobSchedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		PriorityClassName: "foo",
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{
+						Tolerations: []v1.Toleration{userToleration},
+					},
+				},
+			},
+		},
+	}
+	jobDb := NewTestJobDb()
+	jobDb.EnableQueueDefaultTolerations(map[string][]v1.Toleration{
+		// The toleration the job already has differs only in toleration seconds, so it isn't added again.
+		"queue": {defaultToleration, {Key: "gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule, TolerationSeconds: pointer.Int64(10)}},
+	})
+
+	job := jobDb.NewJob("jobId", "jobSet", "queue", 1, jobSchedulingInfo, false, 0, false, false, false, 2)
+	assert.Equal(t, []v1.Toleration{defaultToleration}, job.InjectedTolerations())
+	assert.Equal(t, []v1.Toleration{userToleration, defaultToleration}, job.GetTolerations())
+	// The scheduling info of the job is left unchanged.
+	assert.Equal(t, []v1.Toleration{userToleration}, job.PodRequirements().Tolerations)
+
+	// Jobs in other queues get no tolerations.
+	otherJob := jobDb.NewJob("otherJobId", "jobSet", "otherQueue", 1, jobSchedulingInfo, false, 0, false, false, false, 2)
+	assert.Empty(t, otherJob.InjectedTolerations())
+	assert.Equal(t, []v1.Toleration{userToleration}, otherJob.GetTolerations())
+
+	// The tolerations are part of the scheduling key.
+	jobKey, ok := job.GetSchedulingKey()
+	require.True(t, ok)
+	otherJobKey, ok := otherJob.GetSchedulingKey()
+	require.True(t, ok)
+	assert.NotEqual(t, jobKey, otherJobKey)
+}
+
 func TestJobDb_SchedulingKey(t *testing.T) {
 	tests := map[string]struct {
 		podRequirementsA   *schedulerobjects.PodRequirements
@@ -1249,6 +1620,67 @@ func TestJobDb_SchedulingKey(t *testing.T) {
 	}
 }
 
+func TestJobDb_DetachedWriteTxnReplaceContents(t *testing.T) {
+	jobDb := NewTestJobDb()
+	stale := newJob().WithQueued(true)
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*Job{stale}))
+	txn.Commit()
+
+	// The detached transaction starts out empty and neither blocks nor is blocked by write transactions.
+	rebuilt := jobDb.DetachedWriteTxn()
+	assert.Empty(t, rebuilt.GetAll())
+	live := jobDb.WriteTxn()
+	queued := newJob().WithQueued(true)
+	running := newJob().WithNewRun("executor", "nodeId", "nodeName", 5, time.Now())
+	require.NoError(t, rebuilt.Upsert([]*Job{queued, running}))
+	rebuilt.Commit()
+	assert.Equal(t, stale, live.GetById(stale.Id()))
+	assert.Nil(t, jobDb.ReadTxn().GetById(queued.Id()))
+
+	// Contents are only visible to readers once the transaction they were swapped into is committed.
+	require.NoError(t, live.ReplaceContents(rebuilt))
+	assert.Nil(t, live.GetById(stale.Id()))
+	assert.Equal(t, stale, jobDb.ReadTxn().GetById(stale.Id()))
+	live.Commit()
+	readTxn := jobDb.ReadTxn()
+	assert.Nil(t, readTxn.GetById(stale.Id()))
+	assert.Equal(t, queued, readTxn.GetById(queued.Id()))
+	assert.Equal(t, running, readTxn.GetByRunId(running.LatestRun().Id()))
+	assert.True(t, readTxn.HasQueuedJobs("test-queue"))
+
+	// Contents of transactions of other jobDbs can't be swapped in.
+	live = jobDb.WriteTxn()
+	defer live.Abort()
+	assert.Error(t, live.ReplaceContents(NewTestJobDb().DetachedWriteTxn()))
+	assert.Error(t, jobDb.ReadTxn().ReplaceContents(jobDb.DetachedWriteTxn()))
+}
+
+func TestJobDb_ShadowWriteTxn(t *testing.T) {
+	jobDb := NewTestJobDb()
+	queued := newJob().WithQueued(true)
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*Job{queued}))
+	txn.Commit()
+
+	// The shadow transaction starts from the committed contents and neither blocks nor is blocked by write transactions.
+	live := jobDb.WriteTxn()
+	shadow := jobDb.ShadowWriteTxn()
+	assert.Equal(t, queued, shadow.GetById(queued.Id()))
+	leased := queued.WithQueued(false).WithNewRun("executor", "nodeId", "nodeName", 5, time.Now())
+	added := newJob().WithQueued(true)
+	require.NoError(t, shadow.Upsert([]*Job{leased, added}))
+	shadow.Commit()
+	live.Commit()
+
+	// Changes made to the shadow transaction are never visible to other transactions, even once it's committed.
+	readTxn := jobDb.ReadTxn()
+	assert.Equal(t, queued, readTxn.GetById(queued.Id()))
+	assert.Nil(t, readTxn.GetById(added.Id()))
+	assert.Nil(t, readTxn.GetByRunId(leased.LatestRun().Id()))
+	assert.True(t, readTxn.HasQueuedJobs("test-queue"))
+}
+
 func newJob() *Job {
 	return &Job{
 		id:                util.NewULID(),
@@ -1260,3 +1692,121 @@ func newJob() *Job {
 		jobSchedulingInfo: jobSchedulingInfo,
 	}
 }
+
+// BenchmarkJobDb_SchedulingInfoMemory reports the heap used by a jobDb holding a large number of queued jobs,
+// with scheduling info stored either eagerly or lazily.
+func BenchmarkJobDb_SchedulingInfoMemory(b *testing.B) {
+	const numJobs = 1_000_000
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		Lifetime:          1,
+		AtMostOnce:        true,
+		Preemptible:       true,
+		PriorityClassName: "foo",
+		SubmitTime:        time.Now(),
+		Version:           1,
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{
+						NodeSelector: map[string]string{"pool": "cpu", "zone": "a"},
+						Affinity: &v1.Affinity{
+							NodeAffinity: &v1.NodeAffinity{
+								RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+									NodeSelectorTerms: []v1.NodeSelectorTerm{
+										{
+											MatchExpressions: []v1.NodeSelectorRequirement{
+												{Key: "instance-type", Operator: v1.NodeSelectorOpIn, Values: []string{"a", "b", "c"}},
+											},
+										},
+									},
+								},
+							},
+						},
+						Tolerations: []v1.Toleration{
+							{Key: "example.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+							{Key: "example.com/dedicated", Operator: v1.TolerationOpEqual, Value: "batch", Effect: v1.TaintEffectNoSchedule},
+						},
+						Annotations: map[string]string{
+							"armadaproject.io/foo": "bar",
+							"armadaproject.io/baz": "qux",
+						},
+						Priority:         1,
+						PreemptionPolicy: string(v1.PreemptLowerPriority),
+						ResourceRequirements: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								"cpu":               resource.MustParse("1"),
+								"memory":            resource.MustParse("4Gi"),
+								"ephemeral-storage": resource.MustParse("8Gi"),
+							},
+							Limits: v1.ResourceList{
+								"cpu":               resource.MustParse("1"),
+								"memory":            resource.MustParse("4Gi"),
+								"ephemeral-storage": resource.MustParse("8Gi"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	serialised, err := proto.Marshal(schedulingInfo)
+	require.NoError(b, err)
+
+	for name, lazy := range map[string]bool{"Eager": false, "Lazy": true} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				jobDb := NewTestJobDb()
+				if lazy {
+					jobDb.EnableLazySchedulingInfo()
+				}
+				before := heapAlloc()
+				txn := jobDb.WriteTxn()
+				for j := 0; j < numJobs; j++ {
+					// Mimic loading jobs from the database, which creates a separate copy of the scheduling info for each job.
+					bytes := slices.Clone(serialised)
+					info := &schedulerobjects.JobSchedulingInfo{}
+					require.NoError(b, proto.Unmarshal(bytes, info))
+					job := jobDb.newJob(util.NewULID(), "jobSet", "queue", 0, info, bytes, true, 1, false, false, false, 0)
+					require.NoError(b, txn.Upsert([]*Job{job}))
+				}
+				txn.Commit()
+				after := heapAlloc()
+				b.ReportMetric(float64(after-before)/numJobs, "heap-bytes/job")
+				runtime.KeepAlive(jobDb)
+			}
+		})
+	}
+}
+
+func heapAlloc() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// BenchmarkJobDb_UpsertAndCommit measures the overhead of reporting commit stats, with and without the breakdown.
+func BenchmarkJobDb_UpsertAndCommit(b *testing.B) {
+	jobs := make([]*Job, 100)
+	for i := range jobs {
+		jobs[i] = newJob().WithQueued(true)
+	}
+	for name, enable := range map[string]func(jobDb *JobDb){
+		"noObserver":            func(_ *JobDb) {},
+		"observer":              func(jobDb *JobDb) { jobDb.EnableCommitObserver(commitStatsDiscarder{}, false) },
+		"observerWithBreakdown": func(jobDb *JobDb) { jobDb.EnableCommitObserver(commitStatsDiscarder{}, true) },
+	} {
+		b.Run(name, func(b *testing.B) {
+			jobDb := NewTestJobDb()
+			enable(jobDb)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				txn := jobDb.WriteTxn()
+				if err := txn.Upsert(jobs); err != nil {
+					b.Fatal(err)
+				}
+				txn.Commit()
+			}
+		})
+	}
+}
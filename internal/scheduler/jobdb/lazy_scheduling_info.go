@@ -0,0 +1,193 @@
+package jobdb
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// lazySchedulingInfo holds the scheduling info of a job in serialised form, together with a compact summary of the
+// fields read for all queued jobs, e.g., when computing metrics. The full scheduling info is only unmarshalled when
+// first needed, e.g., when the job is matched against nodes, and is then memoised. Since each scheduling round only
+// considers the jobs at the front of each queue, this saves having to keep the full scheduling info of every queued job
+// in memory.
+//
+// The scheduling info represented by a lazySchedulingInfo never changes, so it may be shared between copies of a job.
+type lazySchedulingInfo struct {
+	// Serialised scheduling info. Discarded once unmarshalled.
+	serialised []byte
+	// Fields of the scheduling info available without unmarshalling it.
+	summary schedulingInfoSummary
+	// Unmarshalled scheduling info, populated on first use, after which unmarshalled is set.
+	// Once populated, it takes precedence over the summary, since callers may have mutated it.
+	once         sync.Once
+	info         *schedulerobjects.JobSchedulingInfo
+	unmarshalled atomic.Bool
+}
+
+// schedulingInfoSummary contains the fields of a JobSchedulingInfo read by the scheduler for queued jobs.
+// Maps are stored as slices, which take up considerably less memory for the handful of entries typical of jobs.
+type schedulingInfoSummary struct {
+	version           uint32
+	priorityClassName string
+	submitTime        time.Time
+	queueTtlSeconds   int64
+	// True if the scheduling info contains pod requirements, in which case the below fields are populated from them.
+	hasPodRequirements bool
+	// Nil if the corresponding map or resource list is nil.
+	annotations  []stringPair
+	nodeSelector []stringPair
+	requests     []resourceQuantity
+	limits       []resourceQuantity
+}
+
+type stringPair struct {
+	key   string
+	value string
+}
+
+type resourceQuantity struct {
+	name     v1.ResourceName
+	quantity resource.Quantity
+}
+
+// newLazySchedulingInfo returns a lazySchedulingInfo for info, which must have been unmarshalled from serialised
+// or, if serialised is nil, is marshalled to create it. Strings in info are expected to already be interned.
+func newLazySchedulingInfo(info *schedulerobjects.JobSchedulingInfo, serialised []byte) (*lazySchedulingInfo, error) {
+	if serialised == nil {
+		var err error
+		if serialised, err = proto.Marshal(info); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	summary := schedulingInfoSummary{
+		version:           info.Version,
+		priorityClassName: info.PriorityClassName,
+		submitTime:        info.SubmitTime,
+		queueTtlSeconds:   info.QueueTtlSeconds,
+	}
+	if req := info.GetPodRequirements(); req != nil {
+		summary.hasPodRequirements = true
+		summary.annotations = stringPairsFromMap(req.Annotations)
+		summary.nodeSelector = stringPairsFromMap(req.NodeSelector)
+		summary.requests = resourceQuantitiesFromResourceList(req.ResourceRequirements.Requests)
+		summary.limits = resourceQuantitiesFromResourceList(req.ResourceRequirements.Limits)
+	}
+	return &lazySchedulingInfo{
+		serialised: serialised,
+		summary:    summary,
+	}, nil
+}
+
+// get returns the full scheduling info, unmarshalling it on first use.
+func (lazy *lazySchedulingInfo) get() *schedulerobjects.JobSchedulingInfo {
+	lazy.once.Do(func() {
+		info := &schedulerobjects.JobSchedulingInfo{}
+		if err := proto.Unmarshal(lazy.serialised, info); err != nil {
+			// The serialised scheduling info was either unmarshalled or marshalled successfully on creation.
+			panic(errors.Wrap(err, "error unmarshalling scheduling info"))
+		}
+		// Use the interned strings and original quantities of the summary,
+		// such that the result is identical to the scheduling info the job was created with.
+		info.PriorityClassName = lazy.summary.priorityClassName
+		if req := info.GetPodRequirements(); req != nil {
+			req.Annotations = lazy.summary.getAnnotations()
+			req.NodeSelector = lazy.summary.getNodeSelector()
+			req.ResourceRequirements = lazy.summary.getResourceRequirements()
+		}
+		lazy.info = info
+		lazy.unmarshalled.Store(true)
+		// The serialised form is no longer needed.
+		lazy.serialised = nil
+	})
+	return lazy.info
+}
+
+// unmarshalledSummary returns the summary if the scheduling info hasn't yet been unmarshalled, and nil otherwise.
+func (lazy *lazySchedulingInfo) unmarshalledSummary() *schedulingInfoSummary {
+	if lazy.unmarshalled.Load() {
+		return nil
+	}
+	return &lazy.summary
+}
+
+func (summary *schedulingInfoSummary) getAnnotations() map[string]string {
+	if !summary.hasPodRequirements {
+		return nil
+	}
+	return mapFromStringPairs(summary.annotations)
+}
+
+func (summary *schedulingInfoSummary) getAnnotation(key string) string {
+	for _, pair := range summary.annotations {
+		if pair.key == key {
+			return pair.value
+		}
+	}
+	return ""
+}
+
+func (summary *schedulingInfoSummary) getNodeSelector() map[string]string {
+	if !summary.hasPodRequirements {
+		return nil
+	}
+	return mapFromStringPairs(summary.nodeSelector)
+}
+
+func (summary *schedulingInfoSummary) getResourceRequirements() v1.ResourceRequirements {
+	return v1.ResourceRequirements{
+		Requests: resourceListFromResourceQuantities(summary.requests),
+		Limits:   resourceListFromResourceQuantities(summary.limits),
+	}
+}
+
+func stringPairsFromMap(m map[string]string) []stringPair {
+	if m == nil {
+		return nil
+	}
+	rv := make([]stringPair, 0, len(m))
+	for k, v := range m {
+		rv = append(rv, stringPair{key: k, value: v})
+	}
+	return rv
+}
+
+func mapFromStringPairs(pairs []stringPair) map[string]string {
+	if pairs == nil {
+		return nil
+	}
+	rv := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		rv[pair.key] = pair.value
+	}
+	return rv
+}
+
+func resourceQuantitiesFromResourceList(rl v1.ResourceList) []resourceQuantity {
+	if rl == nil {
+		return nil
+	}
+	rv := make([]resourceQuantity, 0, len(rl))
+	for name, quantity := range rl {
+		rv = append(rv, resourceQuantity{name: name, quantity: quantity})
+	}
+	return rv
+}
+
+func resourceListFromResourceQuantities(quantities []resourceQuantity) v1.ResourceList {
+	if quantities == nil {
+		return nil
+	}
+	rv := make(v1.ResourceList, len(quantities))
+	for _, q := range quantities {
+		rv[q.name] = q.quantity
+	}
+	return rv
+}
@@ -0,0 +1,245 @@
+package jobdb
+
+import (
+	"sync/atomic"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+)
+
+// Names of the parameters of EffectiveParameters, as reported to ParameterDriftObserver.
+const (
+	QueueTtlParameter         = "queueTtl"
+	DeadlineParameter         = "deadline"
+	MaxAttemptedRunsParameter = "maxAttemptedRuns"
+	GangParameter             = "gang"
+	PriorityClassParameter    = "priorityClass"
+)
+
+// EffectiveParameterNames are the names of all parameters of EffectiveParameters.
+var EffectiveParameterNames = []string{QueueTtlParameter, DeadlineParameter, MaxAttemptedRunsParameter, GangParameter, PriorityClassParameter}
+
+// EffectiveParameters are the parameters of a job that the scheduler derives from its scheduling info,
+// e.g., by parsing its annotations, and that determine how the job is treated over its lifetime.
+type EffectiveParameters struct {
+	// Version of the scheduling info the parameters were derived from.
+	SchedulingInfoVersion uint32
+	// Seconds the job may remain queued for before it's cancelled, or zero if it may remain queued indefinitely.
+	QueueTtlSeconds int64
+	// True if the queueTtl is compared to the time since the job was submitted, i.e., the job has a deadline,
+	// rather than to the total time it has spent queued.
+	QueueTtlSinceSubmission bool
+	// True if the job must not be retried.
+	FailFast bool
+	// Maximum number of runs of the job that may be attempted.
+	MaxAttemptedRuns uint
+	// Id of the gang the job is a member of, or the empty string if the job isn't part of a gang.
+	GangId string
+	// Cardinality and minimum cardinality of the gang of the job, which are 1 for jobs that aren't part of a gang.
+	// Zero if the gang annotations of the job are invalid.
+	GangCardinality        int
+	GangMinimumCardinality int
+	// Name of the priority class applied to the job and true if that's the default priority class,
+	// since the job has a priorityClassName not among the configured priority classes.
+	PriorityClassName           string
+	DefaultPriorityClassApplied bool
+}
+
+// DriftedParameters returns the names of the parameters that differ between p and other,
+// ignoring the scheduling info versions they were derived from.
+func (p EffectiveParameters) DriftedParameters(other EffectiveParameters) []string {
+	var rv []string
+	if p.QueueTtlSeconds != other.QueueTtlSeconds {
+		rv = append(rv, QueueTtlParameter)
+	}
+	if p.QueueTtlSinceSubmission != other.QueueTtlSinceSubmission {
+		rv = append(rv, DeadlineParameter)
+	}
+	if p.FailFast != other.FailFast || p.MaxAttemptedRuns != other.MaxAttemptedRuns {
+		rv = append(rv, MaxAttemptedRunsParameter)
+	}
+	if p.GangId != other.GangId || p.GangCardinality != other.GangCardinality || p.GangMinimumCardinality != other.GangMinimumCardinality {
+		rv = append(rv, GangParameter)
+	}
+	if p.PriorityClassName != other.PriorityClassName || p.DefaultPriorityClassApplied != other.DefaultPriorityClassApplied {
+		rv = append(rv, PriorityClassParameter)
+	}
+	return rv
+}
+
+// ParameterDriftObserver is notified of the outcome of each drift check; see JobDb.CheckParameterDrift.
+type ParameterDriftObserver interface {
+	// ObserveParameterDrift is called with the number of jobs checked whose re-derived parameters differ from their
+	// checkpoint, indexed by parameter name. Parameters no job has drifted on are omitted.
+	ObserveParameterDrift(numJobsByParameter map[string]int)
+}
+
+// parameterCheckpointer derives the effective parameters of the jobs created by a jobDb;
+// see JobDb.EnableParameterCheckpoints.
+type parameterCheckpointer struct {
+	// Derives the effective parameters of a job from its scheduling info.
+	derive func(job *Job) EffectiveParameters
+	// If true, drift checks replace checkpoints with re-derived parameters.
+	forceRederivation bool
+	// If non-nil, notified of the outcome of each drift check.
+	observer ParameterDriftObserver
+	// Maximum number of attempted runs of jobs that aren't fail-fast, as of when they're checkpointed.
+	maxAttemptedRuns atomic.Uint32
+}
+
+// EnableParameterCheckpoints causes the effective parameters of jobs subsequently created by the jobDb, e.g., their
+// queueTtl and gang, to be derived from their scheduling info once, when they're admitted, and checkpointed on the job.
+// The checkpoint is authoritative for the lifetime of the job, unless the version of its scheduling info changes,
+// such that changes to how scheduling info is interpreted, e.g., by a new release, don't change the treatment of jobs
+// mid-flight. extractGangInfo parses the gang annotations of jobs, maxAttemptedRuns is the maximum number of attempted
+// runs of jobs that aren't fail-fast, and observer, if non-nil, is notified of the jobs whose parameters have drifted
+// from their checkpoint; see JobDb.CheckParameterDrift. If forceRederivation is true, such drifted parameters replace
+// the checkpoint, e.g., to migrate jobs to a deliberately changed interpretation.
+func (jobDb *JobDb) EnableParameterCheckpoints(
+	extractGangInfo func(map[string]string) (string, int, int, bool, error),
+	maxAttemptedRuns uint,
+	forceRederivation bool,
+	observer ParameterDriftObserver,
+) {
+	checkpointer := &parameterCheckpointer{
+		forceRederivation: forceRederivation,
+		observer:          observer,
+	}
+	checkpointer.derive = func(job *Job) EffectiveParameters {
+		return jobDb.deriveEffectiveParameters(job, extractGangInfo, uint(checkpointer.maxAttemptedRuns.Load()))
+	}
+	checkpointer.maxAttemptedRuns.Store(uint32(maxAttemptedRuns))
+	jobDb.parameterCheckpointer = checkpointer
+}
+
+// SetMaxAttemptedRuns sets the maximum number of attempted runs of jobs checkpointed from now on,
+// e.g., since the config of the scheduler was reloaded. Jobs already checkpointed are unaffected.
+func (jobDb *JobDb) SetMaxAttemptedRuns(maxAttemptedRuns uint) {
+	if jobDb.parameterCheckpointer != nil {
+		jobDb.parameterCheckpointer.maxAttemptedRuns.Store(uint32(maxAttemptedRuns))
+	}
+}
+
+// deriveEffectiveParameters derives the effective parameters of job from its scheduling info,
+// ignoring any checkpoint it has.
+func (jobDb *JobDb) deriveEffectiveParameters(
+	job *Job,
+	extractGangInfo func(map[string]string) (string, int, int, bool, error),
+	maxAttemptedRuns uint,
+) EffectiveParameters {
+	p := EffectiveParameters{
+		SchedulingInfoVersion:   job.SchedulingInfoVersion(),
+		QueueTtlSeconds:         job.schedulingInfoQueueTtlSeconds(),
+		QueueTtlSinceSubmission: job.annotation(configuration.QueueTtlSinceSubmissionAnnotation) == "true",
+		FailFast:                job.annotation(configuration.FailFastAnnotation) == "true",
+		MaxAttemptedRuns:        maxAttemptedRuns,
+	}
+	if p.FailFast {
+		p.MaxAttemptedRuns = 1
+	}
+	gangId, gangCardinality, gangMinimumCardinality, _, err := extractGangInfo(job.GetAnnotations())
+	if err != nil {
+		// The gang index of the jobDb is keyed by the annotation, whether or not the gang is valid.
+		p.GangId = job.annotation(configuration.GangIdAnnotation)
+	} else {
+		p.GangId, p.GangCardinality, p.GangMinimumCardinality = gangId, gangCardinality, gangMinimumCardinality
+	}
+	requestedPriorityClassName := job.schedulingInfoPriorityClassName()
+	if _, ok := jobDb.priorityClasses[requestedPriorityClassName]; ok {
+		p.PriorityClassName = requestedPriorityClassName
+	} else {
+		p.PriorityClassName, p.DefaultPriorityClassApplied = jobDb.defaultPriorityClassName, true
+	}
+	return p
+}
+
+// EffectiveParameters returns the parameters checkpointed for the job and true,
+// or false if the job hasn't been checkpointed, since the jobDb that created it doesn't checkpoint parameters.
+func (job *Job) EffectiveParameters() (EffectiveParameters, bool) {
+	if job.effectiveParameters == nil {
+		return EffectiveParameters{}, false
+	}
+	return *job.effectiveParameters, true
+}
+
+// checkpoint derives the effective parameters of the job and checkpoints them.
+// Mutates the job, which must not yet be shared.
+func (job *Job) checkpoint() {
+	if job.parameterCheckpointer == nil {
+		return
+	}
+	p := job.parameterCheckpointer.derive(job)
+	job.effectiveParameters = &p
+}
+
+// CheckpointedGangInfo returns the checkpointed gang id, cardinality, and minimum cardinality of the job and true,
+// or false if the job hasn't been checkpointed or its gang annotations are invalid,
+// in which case they should be parsed from its annotations.
+func (job *Job) CheckpointedGangInfo() (string, int, int, bool) {
+	if job.effectiveParameters == nil || job.effectiveParameters.GangCardinality == 0 {
+		return "", 0, 0, false
+	}
+	return job.effectiveParameters.GangId, job.effectiveParameters.GangCardinality, job.effectiveParameters.GangMinimumCardinality, true
+}
+
+// FailFast returns true if the job must not be retried.
+func (job *Job) FailFast() bool {
+	if job.effectiveParameters != nil {
+		return job.effectiveParameters.FailFast
+	}
+	return job.annotation(configuration.FailFastAnnotation) == "true"
+}
+
+// MaxAttemptedRuns returns the maximum number of runs of the job that may be attempted, where defaultMaxAttemptedRuns
+// is that of jobs that aren't fail-fast and haven't been checkpointed.
+func (job *Job) MaxAttemptedRuns(defaultMaxAttemptedRuns uint) uint {
+	if job.effectiveParameters != nil {
+		return job.effectiveParameters.MaxAttemptedRuns
+	}
+	if job.FailFast() {
+		return 1
+	}
+	return defaultMaxAttemptedRuns
+}
+
+// WithEffectiveParameters returns a copy of the job with p checkpointed, e.g., as persisted when the job was admitted
+// by an earlier instance of the scheduler, provided p was derived from the current version of its scheduling info.
+// Returns the job unchanged if the version differs or the jobDb that created it doesn't checkpoint parameters.
+func (job *Job) WithEffectiveParameters(p EffectiveParameters) *Job {
+	if job.parameterCheckpointer == nil || p.SchedulingInfoVersion != job.SchedulingInfoVersion() {
+		return job
+	}
+	j := copyJob(*job)
+	j.effectiveParameters = &p
+	return j
+}
+
+// CheckParameterDrift re-derives the effective parameters of the provided jobs, e.g., those loaded or updated
+// since the last check, and notifies the observer of the jobDb of those that differ from their checkpoint.
+// Returns the jobs, where, if the jobDb forces re-derivation, such jobs are replaced by copies re-checkpointed with
+// their re-derived parameters. Has no effect if the jobDb doesn't checkpoint parameters.
+func (jobDb *JobDb) CheckParameterDrift(jobs []*Job) []*Job {
+	checkpointer := jobDb.parameterCheckpointer
+	if checkpointer == nil {
+		return jobs
+	}
+	numJobsByParameter := make(map[string]int)
+	for i, job := range jobs {
+		if job.effectiveParameters == nil {
+			continue
+		}
+		rederived := checkpointer.derive(job)
+		drifted := job.effectiveParameters.DriftedParameters(rederived)
+		for _, parameter := range drifted {
+			numJobsByParameter[parameter]++
+		}
+		if len(drifted) > 0 && checkpointer.forceRederivation {
+			j := copyJob(*job)
+			j.effectiveParameters = &rederived
+			jobs[i] = j
+		}
+	}
+	if checkpointer.observer != nil {
+		checkpointer.observer.ObserveParameterDrift(numJobsByParameter)
+	}
+	return jobs
+}
@@ -0,0 +1,230 @@
+package jobdb
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+type parameterDriftRecorder struct {
+	observations []map[string]int
+}
+
+func (r *parameterDriftRecorder) ObserveParameterDrift(numJobsByParameter map[string]int) {
+	r.observations = append(r.observations, numJobsByParameter)
+}
+
+func (r *parameterDriftRecorder) last() map[string]int {
+	return r.observations[len(r.observations)-1]
+}
+
+// gangParser parses gang annotations, reading the cardinality from the annotation with key cardinalityAnnotation,
+// such that tests can change how annotations are parsed, as a new release of the scheduler might.
+type gangParser struct {
+	cardinalityAnnotation string
+}
+
+func (p *gangParser) parse(annotations map[string]string) (string, int, int, bool, error) {
+	gangId, ok := annotations[configuration.GangIdAnnotation]
+	if !ok {
+		return "", 1, 1, false, nil
+	}
+	cardinality, err := strconv.Atoi(annotations[p.cardinalityAnnotation])
+	if err != nil {
+		cardinality = 1
+	}
+	return gangId, cardinality, cardinality, true, nil
+}
+
+func newCheckpointTestJob(jobDb *JobDb, version uint32, priorityClassName string, annotations map[string]string) *Job {
+	return jobDb.NewJob(
+		util.NewULID(),
+		"jobSet",
+		"queue",
+		0,
+		&schedulerobjects.JobSchedulingInfo{
+			Version:           version,
+			PriorityClassName: priorityClassName,
+			QueueTtlSeconds:   100,
+			ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+				{
+					Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+						PodRequirements: &schedulerobjects.PodRequirements{Annotations: annotations},
+					},
+				},
+			},
+		},
+		true,
+		0,
+		false,
+		false,
+		false,
+		0,
+	)
+}
+
+func gangAnnotations() map[string]string {
+	return map[string]string{
+		configuration.GangIdAnnotation:                  "gang",
+		configuration.GangCardinalityAnnotation:         "2",
+		configuration.QueueTtlSinceSubmissionAnnotation: "true",
+	}
+}
+
+func TestJobDb_ParameterCheckpoints(t *testing.T) {
+	jobDb := NewTestJobDb()
+	parser := &gangParser{cardinalityAnnotation: configuration.GangCardinalityAnnotation}
+	recorder := &parameterDriftRecorder{}
+	jobDb.EnableParameterCheckpoints(parser.parse, 3, false, recorder)
+
+	job := newCheckpointTestJob(jobDb, 1, "unknown", gangAnnotations())
+	expected := EffectiveParameters{
+		SchedulingInfoVersion:       1,
+		QueueTtlSeconds:             100,
+		QueueTtlSinceSubmission:     true,
+		MaxAttemptedRuns:            3,
+		GangId:                      "gang",
+		GangCardinality:             2,
+		GangMinimumCardinality:      2,
+		PriorityClassName:           "foo",
+		DefaultPriorityClassApplied: true,
+	}
+	actual, ok := job.EffectiveParameters()
+	require.True(t, ok)
+	assert.Equal(t, expected, actual)
+	name, defaultApplied := jobDb.EffectivePriorityClassName(job)
+	assert.Equal(t, "foo", name)
+	assert.True(t, defaultApplied)
+
+	// First check; nothing has drifted.
+	jobDb.CheckParameterDrift([]*Job{job})
+	assert.Empty(t, recorder.last())
+
+	// A new release reads the gang cardinality from a different annotation and ignores the deadline annotation.
+	parser.cardinalityAnnotation = "armadaproject.io/renamedGangCardinality"
+	derive := jobDb.parameterCheckpointer.derive
+	jobDb.parameterCheckpointer.derive = func(job *Job) EffectiveParameters {
+		p := derive(job)
+		p.QueueTtlSinceSubmission = false
+		return p
+	}
+
+	// Second check; the drift is counted, but the job is still treated as it was admitted.
+	job = jobDb.CheckParameterDrift([]*Job{job})[0]
+	assert.Equal(t, map[string]int{GangParameter: 1, DeadlineParameter: 1}, recorder.last())
+	actual, ok = job.EffectiveParameters()
+	require.True(t, ok)
+	assert.Equal(t, expected, actual)
+	assert.True(t, job.QueueTtlSinceSubmission())
+	// The job has spent no time queued since it isn't queued, but its deadline has passed.
+	job = job.WithQueued(false).WithNewRun("executor", "node", "node", 0, time.Unix(0, 0))
+	assert.True(t, job.HasQueueTtlExpired(time.Unix(101, 0)))
+	gangId, gangCardinality, gangMinimumCardinality, ok := job.CheckpointedGangInfo()
+	require.True(t, ok)
+	assert.Equal(t, "gang", gangId)
+	assert.Equal(t, 2, gangCardinality)
+	assert.Equal(t, 2, gangMinimumCardinality)
+
+	// Jobs admitted after the release are checkpointed as it interprets them.
+	newJob := newCheckpointTestJob(jobDb, 1, "bar", gangAnnotations())
+	actual, ok = newJob.EffectiveParameters()
+	require.True(t, ok)
+	assert.Equal(t, 1, actual.GangCardinality)
+	assert.False(t, actual.QueueTtlSinceSubmission)
+	assert.Equal(t, "bar", actual.PriorityClassName)
+
+	// As are jobs whose scheduling info changes version.
+	schedulingInfo := *job.JobSchedulingInfo()
+	schedulingInfo.Version = 2
+	job = job.WithJobSchedulingInfo(&schedulingInfo)
+	actual, ok = job.EffectiveParameters()
+	require.True(t, ok)
+	assert.Equal(t, uint32(2), actual.SchedulingInfoVersion)
+	assert.Equal(t, 1, actual.GangCardinality)
+	assert.False(t, job.QueueTtlSinceSubmission())
+	assert.False(t, job.HasQueueTtlExpired(time.Unix(101, 0)))
+}
+
+func TestJobDb_ParameterCheckpoints_ForceRederivation(t *testing.T) {
+	jobDb := NewTestJobDb()
+	parser := &gangParser{cardinalityAnnotation: configuration.GangCardinalityAnnotation}
+	recorder := &parameterDriftRecorder{}
+	jobDb.EnableParameterCheckpoints(parser.parse, 3, true, recorder)
+	job := newCheckpointTestJob(jobDb, 1, "foo", gangAnnotations())
+
+	parser.cardinalityAnnotation = "armadaproject.io/renamedGangCardinality"
+	job = jobDb.CheckParameterDrift([]*Job{job})[0]
+	assert.Equal(t, map[string]int{GangParameter: 1}, recorder.last())
+	_, gangCardinality, _, ok := job.CheckpointedGangInfo()
+	require.True(t, ok)
+	assert.Equal(t, 1, gangCardinality)
+
+	// Once re-checkpointed, the job no longer drifts.
+	jobDb.CheckParameterDrift([]*Job{job})
+	assert.Empty(t, recorder.last())
+}
+
+func TestJobDb_ParameterCheckpoints_MaxAttemptedRuns(t *testing.T) {
+	jobDb := NewTestJobDb()
+	parser := &gangParser{cardinalityAnnotation: configuration.GangCardinalityAnnotation}
+	jobDb.EnableParameterCheckpoints(parser.parse, 3, false, nil)
+	job := newCheckpointTestJob(jobDb, 1, "foo", nil)
+	failFastJob := newCheckpointTestJob(jobDb, 1, "foo", map[string]string{configuration.FailFastAnnotation: "true"})
+
+	// Jobs keep the maximum they were admitted with once it changes.
+	jobDb.SetMaxAttemptedRuns(5)
+	assert.Equal(t, uint(3), job.MaxAttemptedRuns(5))
+	assert.Equal(t, uint(5), newCheckpointTestJob(jobDb, 1, "foo", nil).MaxAttemptedRuns(5))
+	assert.True(t, failFastJob.FailFast())
+	assert.Equal(t, uint(1), failFastJob.MaxAttemptedRuns(5))
+
+	// No drift observer is required.
+	jobDb.CheckParameterDrift([]*Job{job})
+}
+
+func TestJobDb_ParameterCheckpoints_Restore(t *testing.T) {
+	jobDb := NewTestJobDb()
+	parser := &gangParser{cardinalityAnnotation: configuration.GangCardinalityAnnotation}
+	jobDb.EnableParameterCheckpoints(parser.parse, 3, false, nil)
+	job := newCheckpointTestJob(jobDb, 2, "foo", gangAnnotations())
+	persisted, ok := job.EffectiveParameters()
+	require.True(t, ok)
+	persisted.GangCardinality = 4
+
+	// A checkpoint derived from the current version of the scheduling info of the job is restored.
+	restored, ok := job.WithEffectiveParameters(persisted).EffectiveParameters()
+	require.True(t, ok)
+	assert.Equal(t, persisted, restored)
+
+	// One derived from another version isn't.
+	stale := persisted
+	stale.SchedulingInfoVersion = 1
+	actual, ok := job.WithEffectiveParameters(stale).EffectiveParameters()
+	require.True(t, ok)
+	assert.Equal(t, 2, actual.GangCardinality)
+
+	// Nor are checkpoints restored if the jobDb doesn't checkpoint parameters.
+	_, ok = newCheckpointTestJob(NewTestJobDb(), 2, "foo", nil).WithEffectiveParameters(persisted).EffectiveParameters()
+	assert.False(t, ok)
+}
+
+func TestJobDb_ParameterCheckpointsDisabled(t *testing.T) {
+	jobDb := NewTestJobDb()
+	job := newCheckpointTestJob(jobDb, 1, "foo", gangAnnotations())
+	_, ok := job.EffectiveParameters()
+	assert.False(t, ok)
+	_, _, _, ok = job.CheckpointedGangInfo()
+	assert.False(t, ok)
+	assert.Equal(t, "gang", job.GangId())
+	assert.Equal(t, int64(100), job.GetQueueTtlSeconds())
+	assert.True(t, job.QueueTtlSinceSubmission())
+	assert.Equal(t, uint(5), job.MaxAttemptedRuns(5))
+	assert.Equal(t, []*Job{job}, jobDb.CheckParameterDrift([]*Job{job}))
+}
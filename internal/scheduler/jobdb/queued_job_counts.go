@@ -0,0 +1,72 @@
+package jobdb
+
+import (
+	"github.com/benbjohnson/immutable"
+)
+
+// QueuedJobCountKey identifies a group of queued jobs counted by the jobDb; see JobDb.EnableQueuedJobCounts.
+type QueuedJobCountKey struct {
+	Queue string
+	// Bucket assigned to the jobs of the group by the function passed to JobDb.EnableQueuedJobCounts.
+	Bucket   string
+	Priority uint32
+}
+
+// queuedJobCountKeyHasher is an implementation of Hasher for QueuedJobCountKey.
+type queuedJobCountKeyHasher struct{}
+
+func (h queuedJobCountKeyHasher) Hash(key QueuedJobCountKey) uint32 {
+	hash := key.Priority
+	for i := 0; i < len(key.Queue); i++ {
+		hash = hash*31 + uint32(key.Queue[i])
+	}
+	for i := 0; i < len(key.Bucket); i++ {
+		hash = hash*31 + uint32(key.Bucket[i])
+	}
+	return hash
+}
+
+func (h queuedJobCountKeyHasher) Equal(a, b QueuedJobCountKey) bool {
+	return a == b
+}
+
+// EnableQueuedJobCounts causes write transactions to maintain the number of queued jobs by queue, priority,
+// and the bucket returned by bucket, such that they can be read without iterating over the queued jobs;
+// see Txn.QueuedJobCounts. Must be called before any jobs are upserted.
+func (jobDb *JobDb) EnableQueuedJobCounts(bucket func(job *Job) string) {
+	jobDb.queuedJobBucket = bucket
+}
+
+// QueuedJobCounts returns the number of queued jobs in each group with at least one queued job,
+// or nil unless queued job counts are enabled; see JobDb.EnableQueuedJobCounts.
+func (txn *Txn) QueuedJobCounts() map[QueuedJobCountKey]int {
+	if txn.jobDb.queuedJobBucket == nil {
+		return nil
+	}
+	rv := make(map[QueuedJobCountKey]int, txn.queuedJobCounts.Len())
+	it := txn.queuedJobCounts.Iterator()
+	for !it.Done() {
+		key, n, _ := it.Next()
+		rv[key] = n
+	}
+	return rv
+}
+
+// addToQueuedJobCounts adds n, which is 1 or -1, to the count of the group of job, which must be queued.
+func (txn *Txn) addToQueuedJobCounts(job *Job, n int) {
+	bucket := txn.jobDb.queuedJobBucket
+	if bucket == nil {
+		return
+	}
+	key := QueuedJobCountKey{Queue: job.queue, Bucket: bucket(job), Priority: job.priority}
+	count, _ := txn.queuedJobCounts.Get(key)
+	if count+n == 0 {
+		txn.queuedJobCounts = txn.queuedJobCounts.Delete(key)
+	} else {
+		txn.queuedJobCounts = txn.queuedJobCounts.Set(key, count+n)
+	}
+}
+
+func newQueuedJobCounts() *immutable.Map[QueuedJobCountKey, int] {
+	return immutable.NewMap[QueuedJobCountKey, int](queuedJobCountKeyHasher{})
+}
@@ -1,7 +1,11 @@
 package jobdb
 
 import (
+	"hash/fnv"
+	"time"
+
 	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
 	armadamath "github.com/armadaproject/armada/internal/common/math"
@@ -26,6 +30,28 @@ type JobStateTransitions struct {
 	Preempted bool
 	Failed    bool
 	Succeeded bool
+	// True if a run of the job was reported on a node other than that held for it in the jobDb.
+	NodeReassigned bool
+	// True if a run of the job was stored without the priority it was scheduled at, which was back-filled.
+	ScheduledAtPriorityBackfilled bool
+	// True if the job repository reported a run of the job as both succeeded and failed.
+	ContradictoryRunOutcome bool
+	// Ids of the runs of the job that entered a terminal phase in this transition, i.e., that weren't already held
+	// in a terminal phase by the jobDb.
+	TerminatedRunIds []uuid.UUID
+
+	// Non-nil if the job repository provided scheduling info inconsistent with that in the jobDb.
+	SchedulingInfoConflict *SchedulingInfoConflict
+}
+
+// SchedulingInfoConflict describes scheduling info provided by the job repository that's inconsistent with that
+// stored in the jobDb, i.e., that has a lower version, or the same version but different contents.
+// The scheduling info stored in the jobDb is left unchanged when this happens.
+type SchedulingInfoConflict struct {
+	// Version of the scheduling info stored in the jobDb.
+	JobDbVersion uint32
+	// Version of the scheduling info provided by the job repository.
+	JobRepoVersion uint32
 }
 
 // applyRunStateTransitions applies the state transitions of a run to that of the associated job.
@@ -38,6 +64,9 @@ func (jst JobStateTransitions) applyRunStateTransitions(rst RunStateTransitions)
 	jst.Preempted = jst.Preempted || rst.Preempted
 	jst.Failed = jst.Failed || rst.Failed
 	jst.Succeeded = jst.Succeeded || rst.Succeeded
+	jst.NodeReassigned = jst.NodeReassigned || rst.NodeReassigned
+	jst.ScheduledAtPriorityBackfilled = jst.ScheduledAtPriorityBackfilled || rst.ScheduledAtPriorityBackfilled
+	jst.ContradictoryRunOutcome = jst.ContradictoryRunOutcome || rst.ContradictoryOutcome
 	return jst
 }
 
@@ -54,6 +83,15 @@ type RunStateTransitions struct {
 	Preempted bool
 	Failed    bool
 	Succeeded bool
+	// True if the run was reported on a node other than that held for it in the jobDb,
+	// e.g., since the executor rescheduled its pod onto another node after a node failure without returning the lease.
+	NodeReassigned bool
+	// True if the run was stored without the priority it was scheduled at, e.g., by an older version of the scheduler,
+	// and was assigned the priority of the priority class of its job instead.
+	ScheduledAtPriorityBackfilled bool
+	// True if the job repository reported the run as both succeeded and failed, either in a single update
+	// or in an update contradicting the outcome already held by the jobDb; see ContradictoryRunPolicy.
+	ContradictoryOutcome bool
 }
 
 // ReconcileDifferences reconciles any differences between jobs stored in the jobDb with those provided to this function
@@ -101,6 +139,7 @@ func (jobDb *JobDb) ReconcileDifferences(txn *Txn, jobRepoJobs []database.Job, j
 // TODO(albin): Pending, running, and preempted are not supported yet.
 func (jobDb *JobDb) reconcileJobDifferences(job *Job, jobRepoJob *database.Job, jobRepoRuns []*database.Run) (jst JobStateTransitions, err error) {
 	defer func() { jst.Job = job }()
+	isNewJob := false
 	if job == nil && jobRepoJob == nil {
 		return
 	} else if job == nil && jobRepoJob != nil {
@@ -108,6 +147,7 @@ func (jobDb *JobDb) reconcileJobDifferences(job *Job, jobRepoJob *database.Job,
 			return
 		}
 		jst.Queued = true
+		isNewJob = true
 	} else if job != nil && jobRepoJob == nil {
 		// No direct updates to the job; just process any updated runs below.
 	} else if job != nil && jobRepoJob != nil {
@@ -117,6 +157,21 @@ func (jobDb *JobDb) reconcileJobDifferences(job *Job, jobRepoJob *database.Job,
 		if jobRepoJob.CancelByJobsetRequested && !job.CancelByJobsetRequested() {
 			job = job.WithCancelByJobsetRequested(true)
 		}
+		// Preemption requests apply to the run the job had when they were made;
+		// those made before the job was last requeued or leased are ignored.
+		if jobRepoJob.PreemptRequested && !job.PreemptRequested() && jobRepoJob.QueuedVersion >= job.QueuedVersion() {
+			job = job.WithPreemptRequested(true)
+		}
+		if jobRepoJob.QueuedVersion > job.QueuedVersion() {
+			if jobRepoJob.Queued && !job.Queued() {
+				// The job was requeued, e.g., by another replica; the requeue was the last modification of the job.
+				job = job.WithQueuedSince(jobRepoJob.LastModified)
+			}
+			job = job.WithQueuedVersion(jobRepoJob.QueuedVersion)
+			job = job.WithQueued(jobRepoJob.Queued)
+		}
+		// Terminal phases are entered after the queued state is reconciled, such that a job leased and finished
+		// by another replica transitions via the leased phase, as required by the job state machine.
 		if jobRepoJob.Cancelled && !job.Cancelled() {
 			job = job.WithCancelled(true)
 		}
@@ -129,33 +184,92 @@ func (jobDb *JobDb) reconcileJobDifferences(job *Job, jobRepoJob *database.Job,
 		if uint32(jobRepoJob.Priority) != job.RequestedPriority() {
 			job = job.WithRequestedPriority(uint32(jobRepoJob.Priority))
 		}
-		if uint32(jobRepoJob.SchedulingInfoVersion) > job.JobSchedulingInfo().Version {
-			schedulingInfo := &schedulerobjects.JobSchedulingInfo{}
-			if err = proto.Unmarshal(jobRepoJob.SchedulingInfo, schedulingInfo); err != nil {
-				err = errors.Wrapf(err, "error unmarshalling scheduling info for job %s", jobRepoJob.JobID)
+		jobDbVersion := job.SchedulingInfoVersion()
+		jobRepoVersion := uint32(jobRepoJob.SchedulingInfoVersion)
+		if jobRepoVersion > jobDbVersion {
+			if job, err = jobDb.WithSchedulingInfoFromJobRepo(job, jobRepoJob); err != nil {
 				return
 			}
-			job = job.WithJobSchedulingInfo(schedulingInfo)
+		} else if jobRepoVersion < jobDbVersion {
+			jst.SchedulingInfoConflict = &SchedulingInfoConflict{JobDbVersion: jobDbVersion, JobRepoVersion: jobRepoVersion}
+		} else if hash := HashSchedulingInfo(jobRepoJob.SchedulingInfo); job.schedulingInfoHash == 0 {
+			// The scheduling info was updated in memory; this is the first time we see it from the job repository.
+			job = job.WithSchedulingInfoHash(hash)
+		} else if hash != job.schedulingInfoHash {
+			jst.SchedulingInfoConflict = &SchedulingInfoConflict{JobDbVersion: jobDbVersion, JobRepoVersion: jobRepoVersion}
 		}
-		if jobRepoJob.QueuedVersion > job.QueuedVersion() {
-			job = job.WithQueuedVersion(jobRepoJob.QueuedVersion)
-			job = job.WithQueued(jobRepoJob.Queued)
+		if jobRepoJob.QueuedVersion > 0 && !job.EverLeased() {
+			job = job.WithEverLeased(true)
+		}
+		if jobRepoJob.Acknowledged && !job.Acknowledged() {
+			job = job.WithAcknowledged(true)
 		}
 	}
 
 	// Reconcile run state transitions.
 	for _, jobRepoRun := range jobRepoRuns {
-		rst := jobDb.reconcileRunDifferences(job.RunById(jobRepoRun.RunID), jobRepoRun)
+		jobRun := job.RunById(jobRepoRun.RunID)
+		rst := jobDb.reconcileRunDifferences(jobRun, jobRepoRun)
+		if rst.JobRun != nil && rst.JobRun.InTerminalState() && (jobRun == nil || !jobRun.InTerminalState()) {
+			jst.TerminatedRunIds = append(jst.TerminatedRunIds, rst.JobRun.Id())
+		}
+		// Runs without a scheduled-at priority would otherwise be considered to have been scheduled at the lowest
+		// priority, making them likely preemption victims; assume they were scheduled at that of their priority class.
+		if rst.JobRun != nil && rst.JobRun.ScheduledAtPriority() == nil {
+			rst.JobRun = rst.JobRun.WithScheduledAtPriority(job.priorityClass.Priority)
+			rst.ScheduledAtPriorityBackfilled = true
+		}
 		jst = jst.applyRunStateTransitions(rst)
 		job = job.WithUpdatedRun(rst.JobRun)
 	}
 
+	// The runs of a job new to the jobDb end its first queued period.
+	// If it has since been requeued, the current queued period started when the job was last modified.
+	if isNewJob && job.Phase() == JobPhaseQueued && job.HasRuns() {
+		requeued := jobRepoJob.LastModified
+		if latestRunCreated := time.Unix(0, job.LatestRun().Created()); requeued.Before(latestRunCreated) {
+			requeued = latestRunCreated
+		}
+		job = job.WithQueuedSince(requeued)
+	}
+
 	return
 }
 
+// WithSchedulingInfoFromJobRepo returns a copy of job with its scheduling info replaced by that of jobRepoJob.
+func (jobDb *JobDb) WithSchedulingInfoFromJobRepo(job *Job, jobRepoJob *database.Job) (*Job, error) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{}
+	if err := proto.Unmarshal(jobRepoJob.SchedulingInfo, schedulingInfo); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling scheduling info for job %s", jobRepoJob.JobID)
+	}
+	serialisedSchedulingInfo := jobDb.withPriorityClassFallback(schedulingInfo, jobRepoJob.SchedulingInfo)
+	job = job.WithJobSchedulingInfo(schedulingInfo).WithSchedulingInfoHash(HashSchedulingInfo(jobRepoJob.SchedulingInfo))
+	return jobDb.withLazySchedulingInfo(job, serialisedSchedulingInfo), nil
+}
+
+// HashSchedulingInfo returns a hash of serialised scheduling info,
+// used to detect scheduling info with the same version but different contents.
+func HashSchedulingInfo(schedulingInfo []byte) uint64 {
+	h := fnv.New64a()
+	// Writing to a hash never returns an error.
+	_, _ = h.Write(schedulingInfo)
+	return h.Sum64()
+}
+
 // TODO(albin): Preempted is not supported.
 func (jobDb *JobDb) reconcileRunDifferences(jobRun *JobRun, jobRepoRun *database.Run) (rst RunStateTransitions) {
 	defer func() { rst.JobRun = jobRun }()
+	// Runs reported as both succeeded and failed are resolved up front, such that they're reconciled the same way
+	// regardless of which of the two is reported first.
+	if jobRepoRun != nil {
+		jobRepoRun, rst.ContradictoryOutcome = jobDb.resolveContradictoryOutcome(jobRun, jobRepoRun)
+		quarantined := rst.ContradictoryOutcome && jobDb.contradictoryRunPolicy == ContradictoryRunsQuarantine
+		defer func() {
+			if jobRun.OutcomeContradictory() != quarantined {
+				jobRun = jobRun.withOutcomeContradictory(quarantined)
+			}
+		}()
+	}
 	if jobRun == nil && jobRepoRun == nil {
 		return
 	} else if jobRun == nil && jobRepoRun != nil {
@@ -169,6 +283,14 @@ func (jobDb *JobDb) reconcileRunDifferences(jobRun *JobRun, jobRepoRun *database
 	} else if jobRun != nil && jobRepoRun == nil {
 		return
 	} else if jobRun != nil && jobRepoRun != nil {
+		if rst.ContradictoryOutcome && (jobRepoRun.Succeeded != jobRun.Succeeded() || jobRepoRun.Failed != jobRun.Failed()) {
+			// The jobDb may already hold the outcome that wasn't preferred, which is replaced in a single transition.
+			updated := jobRun.withOutcome(jobRepoRun.Succeeded, jobRepoRun.Failed)
+			rst.Succeeded = updated.Succeeded() && !jobRun.Succeeded()
+			rst.Failed = updated.Failed() && !jobRun.Failed()
+			jobRun = updated
+			jobRepoRun.Succeeded, jobRepoRun.Failed = jobRun.Succeeded(), jobRun.Failed()
+		}
 		if jobRepoRun.Running && !jobRun.Running() {
 			jobRun = jobRun.WithRunning(true)
 			rst.Running = true
@@ -192,6 +314,10 @@ func (jobDb *JobDb) reconcileRunDifferences(jobRun *JobRun, jobRepoRun *database
 		if jobRepoRun.RunAttempted && !jobRun.RunAttempted() {
 			jobRun = jobRun.WithAttempted(true)
 		}
+		if jobRepoRun.Node != "" && jobRepoRun.Node != jobRun.NodeName() {
+			jobRun = jobRun.WithNode(jobDb.nodeIdFromExecutorAndNodeName(jobRepoRun.Executor, jobRepoRun.Node), jobRepoRun.Node)
+			rst.NodeReassigned = true
+		}
 	}
 	return
 }
@@ -202,30 +328,49 @@ func (jobDb *JobDb) schedulerJobFromDatabaseJob(dbJob *database.Job) (*Job, erro
 	if err := proto.Unmarshal(dbJob.SchedulingInfo, schedulingInfo); err != nil {
 		return nil, errors.Wrapf(err, "error unmarshalling scheduling info for job %s", dbJob.JobID)
 	}
-	return jobDb.NewJob(
+	serialisedSchedulingInfo := jobDb.withPriorityClassFallback(schedulingInfo, dbJob.SchedulingInfo)
+	// The queued state of a job only changes when it's leased or requeued, and it's only requeued after being leased.
+	// Hence, the job was leased if its queued version is non-zero, regardless of which of its runs are still stored.
+	everLeased := dbJob.QueuedVersion > 0
+	return jobDb.newJob(
 		dbJob.JobID,
 		dbJob.JobSet,
 		dbJob.Queue,
 		uint32(dbJob.Priority),
 		schedulingInfo,
+		serialisedSchedulingInfo,
 		dbJob.Queued,
 		dbJob.QueuedVersion,
 		dbJob.CancelRequested,
 		dbJob.CancelByJobsetRequested,
 		dbJob.Cancelled,
 		dbJob.Submitted,
-	), nil
+	).
+		WithPreemptRequested(dbJob.PreemptRequested).
+		// Jobs already failed or succeeded may be updated again, e.g., if cancellation is requested after they've finished.
+		WithFailed(dbJob.Failed).
+		WithSucceeded(dbJob.Succeeded).
+		WithEverLeased(everLeased).
+		WithAcknowledged(dbJob.Acknowledged).
+		WithSchedulingInfoHash(HashSchedulingInfo(dbJob.SchedulingInfo)), nil
+}
+
+// nodeIdFromExecutorAndNodeName returns the id of the node with the given name on the given executor.
+func (jobDb *JobDb) nodeIdFromExecutorAndNodeName(executor, nodeName string) string {
+	if jobDb.lengthPrefixedNodeIds {
+		return api.LengthPrefixedNodeIdFromExecutorAndNodeName(executor, nodeName)
+	}
+	return api.NodeIdFromExecutorAndNodeName(executor, nodeName)
 }
 
 // schedulerRunFromDatabaseRun creates a new scheduler job run from a database job run
 func (jobDb *JobDb) schedulerRunFromDatabaseRun(dbRun *database.Run) *JobRun {
-	nodeId := api.NodeIdFromExecutorAndNodeName(dbRun.Executor, dbRun.Node)
 	return jobDb.CreateRun(
 		dbRun.RunID,
 		dbRun.JobID,
 		dbRun.Created,
 		dbRun.Executor,
-		nodeId,
+		jobDb.nodeIdFromExecutorAndNodeName(dbRun.Executor, dbRun.Node),
 		dbRun.Node,
 		dbRun.ScheduledAtPriority,
 		dbRun.Running,
@@ -0,0 +1,756 @@
+package jobdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+
+	protoutil "github.com/armadaproject/armada/internal/common/proto"
+	"github.com/armadaproject/armada/internal/common/types"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+func TestJobDb_ReconcileSchedulingInfoVersions(t *testing.T) {
+	schedulingInfoWithVersionAndPriority := func(version uint32, priority int32) *schedulerobjects.JobSchedulingInfo {
+		return &schedulerobjects.JobSchedulingInfo{
+			Version: version,
+			ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+				{
+					Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+						PodRequirements: &schedulerobjects.PodRequirements{Priority: priority},
+					},
+				},
+			},
+		}
+	}
+	initialSchedulingInfo := schedulingInfoWithVersionAndPriority(2, 1)
+	tests := map[string]struct {
+		// Scheduling info provided by the job repository.
+		updatedSchedulingInfo *schedulerobjects.JobSchedulingInfo
+		// If true, the scheduling info of the job in the jobDb was updated in memory since it was loaded.
+		updatedInMemory                bool
+		expectedSchedulingInfo         *schedulerobjects.JobSchedulingInfo
+		expectedSchedulingInfoConflict *SchedulingInfoConflict
+	}{
+		"normal progression": {
+			updatedSchedulingInfo:  schedulingInfoWithVersionAndPriority(3, 2),
+			expectedSchedulingInfo: schedulingInfoWithVersionAndPriority(3, 2),
+		},
+		"same version and contents": {
+			updatedSchedulingInfo:  initialSchedulingInfo,
+			expectedSchedulingInfo: initialSchedulingInfo,
+		},
+		"version regression": {
+			updatedSchedulingInfo:          schedulingInfoWithVersionAndPriority(1, 2),
+			expectedSchedulingInfo:         initialSchedulingInfo,
+			expectedSchedulingInfoConflict: &SchedulingInfoConflict{JobDbVersion: 2, JobRepoVersion: 1},
+		},
+		"same version but different contents": {
+			updatedSchedulingInfo:          schedulingInfoWithVersionAndPriority(2, 2),
+			expectedSchedulingInfo:         initialSchedulingInfo,
+			expectedSchedulingInfoConflict: &SchedulingInfoConflict{JobDbVersion: 2, JobRepoVersion: 2},
+		},
+		"same version but different contents after update in memory": {
+			updatedSchedulingInfo:  schedulingInfoWithVersionAndPriority(2, 2),
+			updatedInMemory:        true,
+			expectedSchedulingInfo: initialSchedulingInfo,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobDb := NewTestJobDb()
+			jobRepoJob := database.Job{
+				JobID:                 util.NewULID(),
+				JobSet:                "test-jobset",
+				Queue:                 "test-queue",
+				Queued:                true,
+				SchedulingInfo:        protoutil.MustMarshall(initialSchedulingInfo),
+				SchedulingInfoVersion: int32(initialSchedulingInfo.Version),
+			}
+			txn := jobDb.WriteTxn()
+			jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+			require.NoError(t, err)
+			require.Len(t, jsts, 1)
+			job := jsts[0].Job
+			if tc.updatedInMemory {
+				job = job.WithJobSchedulingInfo(initialSchedulingInfo)
+			}
+			require.NoError(t, txn.Upsert([]*Job{job}))
+
+			jobRepoJob.SchedulingInfo = protoutil.MustMarshall(tc.updatedSchedulingInfo)
+			jobRepoJob.SchedulingInfoVersion = int32(tc.updatedSchedulingInfo.Version)
+			jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+			require.NoError(t, err)
+			require.Len(t, jsts, 1)
+			assert.Equal(t, tc.expectedSchedulingInfo.Version, jsts[0].Job.JobSchedulingInfo().Version)
+			assert.Equal(t, tc.expectedSchedulingInfo.GetPodRequirements().Priority, jsts[0].Job.PodRequirements().Priority)
+			assert.Equal(t, tc.expectedSchedulingInfoConflict, jsts[0].SchedulingInfoConflict)
+		})
+	}
+}
+
+func TestJobDb_ReconcileQueuedDuration(t *testing.T) {
+	submitted := time.Unix(0, 0).Add(time.Hour)
+	leased := submitted.Add(5 * time.Minute)
+	requeued := leased.Add(48 * time.Hour)
+	now := requeued.Add(time.Minute)
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	jobRepoJob := database.Job{
+		JobID:          util.NewULID(),
+		JobSet:         "test-jobset",
+		Queue:          "test-queue",
+		Queued:         true,
+		Submitted:      submitted.UnixNano(),
+		SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+	}
+	jobRepoRun := database.Run{
+		RunID:    uuid.New(),
+		JobID:    jobRepoJob.JobID,
+		JobSet:   "test-jobset",
+		Executor: "test-executor",
+		Node:     "test-node",
+		Created:  leased.UnixNano(),
+	}
+
+	// The job is leased to a run created by another replica.
+	jobDb := NewTestJobDb()
+	txn := jobDb.WriteTxn()
+	jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+	require.NoError(t, err)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+	jobRepoJob.Queued = false
+	jobRepoJob.QueuedVersion = 1
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+	assert.Equal(t, 5*time.Minute, jsts[0].Job.QueuedDuration(now))
+
+	// The run is returned and the job requeued by another replica.
+	jobRepoRun.Failed = true
+	jobRepoRun.Returned = true
+	jobRepoJob.Queued = true
+	jobRepoJob.QueuedVersion = 2
+	jobRepoJob.LastModified = requeued
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Minute, jsts[0].Job.QueuedDuration(now))
+
+	// The same job loaded into an empty jobDb, e.g., after a restart.
+	jsts, err = NewTestJobDb().ReconcileDifferences(NewTestJobDb().WriteTxn(), []database.Job{jobRepoJob}, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Minute, jsts[0].Job.QueuedDuration(now))
+}
+
+func TestJobDb_ReconcileNodeIds(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	for name, lengthPrefixedNodeIds := range map[string]bool{"legacy": false, "length-prefixed": true} {
+		t.Run(name, func(t *testing.T) {
+			jobRepoJob := database.Job{
+				JobID:          util.NewULID(),
+				JobSet:         "test-jobset",
+				Queue:          "test-queue",
+				SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+			}
+			jobRepoRun := database.Run{
+				RunID:    uuid.New(),
+				JobID:    jobRepoJob.JobID,
+				JobSet:   "test-jobset",
+				Executor: "test-executor",
+				Node:     "test-node",
+			}
+			jobDb := NewTestJobDb()
+			expectedNodeId := "test-executor-test-node"
+			if lengthPrefixedNodeIds {
+				jobDb.EnableLengthPrefixedNodeIds()
+				expectedNodeId = "13:test-executor-test-node"
+			}
+			jsts, err := jobDb.ReconcileDifferences(jobDb.WriteTxn(), []database.Job{jobRepoJob}, []database.Run{jobRepoRun})
+			require.NoError(t, err)
+			require.Len(t, jsts, 1)
+			assert.Equal(t, expectedNodeId, jsts[0].Job.LatestRun().NodeId())
+		})
+	}
+}
+
+func TestJobDb_ReconcileNodeReassignment(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	jobRepoJob := database.Job{
+		JobID:          util.NewULID(),
+		JobSet:         "test-jobset",
+		Queue:          "test-queue",
+		SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+	}
+	jobRepoRun := database.Run{
+		RunID:    uuid.New(),
+		JobID:    jobRepoJob.JobID,
+		JobSet:   "test-jobset",
+		Executor: "test-executor",
+		Node:     "test-node",
+		Running:  true,
+	}
+	jobDb := NewTestJobDb()
+	txn := jobDb.WriteTxn()
+	jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.False(t, jsts[0].NodeReassigned)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+
+	// Updates to the run that leave its node unchanged aren't reassignments.
+	jobRepoRun.RunAttempted = true
+	jsts, err = jobDb.ReconcileDifferences(txn, nil, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.False(t, jsts[0].NodeReassigned)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+
+	// The executor reports the running run on another node.
+	jobRepoRun.Node = "other-node"
+	jsts, err = jobDb.ReconcileDifferences(txn, nil, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.True(t, jsts[0].NodeReassigned)
+	assert.False(t, jsts[0].Running)
+	run := jsts[0].Job.LatestRun()
+	assert.Equal(t, jobRepoRun.RunID, run.Id())
+	assert.Equal(t, "other-node", run.NodeName())
+	assert.Equal(t, "test-executor-other-node", run.NodeId())
+	assert.Equal(t, "test-executor", run.Executor())
+	assert.True(t, run.Running())
+}
+
+func TestJobDb_ReconcileTerminatedRunIds(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	jobRepoJob := database.Job{
+		JobID:          util.NewULID(),
+		JobSet:         "test-jobset",
+		Queue:          "test-queue",
+		SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+	}
+	jobRepoRun := database.Run{
+		RunID:    uuid.New(),
+		JobID:    jobRepoJob.JobID,
+		JobSet:   "test-jobset",
+		Executor: "test-executor",
+		Node:     "test-node",
+		Running:  true,
+	}
+	jobDb := NewTestJobDb()
+	txn := jobDb.WriteTxn()
+	jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.Empty(t, jsts[0].TerminatedRunIds)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+
+	// The run is returned and the job requeued with a new run, which fails immediately.
+	jobRepoRun.Returned = true
+	newJobRepoRun := database.Run{
+		RunID:    uuid.New(),
+		JobID:    jobRepoJob.JobID,
+		JobSet:   "test-jobset",
+		Executor: "test-executor",
+		Node:     "test-node",
+		Failed:   true,
+	}
+	jsts, err = jobDb.ReconcileDifferences(txn, nil, []database.Run{jobRepoRun, newJobRepoRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.ElementsMatch(t, []uuid.UUID{jobRepoRun.RunID, newJobRepoRun.RunID}, jsts[0].TerminatedRunIds)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+
+	// Further updates to runs already in a terminal phase don't terminate them again.
+	jobRepoJob.CancelRequested = true
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, []database.Run{jobRepoRun, newJobRepoRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.Empty(t, jsts[0].TerminatedRunIds)
+}
+
+func TestJobDb_ReconcileScheduledAtPriorityBackfill(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		PriorityClassName: "high",
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	jobRepoJob := database.Job{
+		JobID:          util.NewULID(),
+		JobSet:         "test-jobset",
+		Queue:          "test-queue",
+		SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+	}
+	// Stored without the priority it was scheduled at.
+	jobRepoRun := database.Run{
+		RunID:    uuid.New(),
+		JobID:    jobRepoJob.JobID,
+		JobSet:   "test-jobset",
+		Executor: "test-executor",
+		Node:     "test-node",
+		Running:  true,
+	}
+	jobDb := NewJobDb(
+		map[string]types.PriorityClass{
+			"low":  {Priority: 1},
+			"high": {Priority: 10},
+		},
+		"low",
+		1024,
+	)
+	txn := jobDb.WriteTxn()
+	jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.True(t, jsts[0].ScheduledAtPriorityBackfilled)
+	run := jsts[0].Job.LatestRun()
+	require.NotNil(t, run.ScheduledAtPriority())
+	assert.Equal(t, int32(10), *run.ScheduledAtPriority())
+	// Preemption considers the job to have been scheduled at the back-filled priority.
+	priority, ok := jsts[0].Job.GetScheduledAtPriority()
+	assert.True(t, ok)
+	assert.Equal(t, int32(10), priority)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+
+	// Once stored, the priority is no longer back-filled.
+	jobRepoRun.ScheduledAtPriority = pointer.Int32(10)
+	jsts, err = jobDb.ReconcileDifferences(txn, nil, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.False(t, jsts[0].ScheduledAtPriorityBackfilled)
+
+	// Priorities stored explicitly are left unchanged.
+	otherRun := database.Run{
+		RunID:               uuid.New(),
+		JobID:               jobRepoJob.JobID,
+		JobSet:              "test-jobset",
+		Executor:            "test-executor",
+		Node:                "test-node",
+		ScheduledAtPriority: pointer.Int32(1),
+	}
+	jsts, err = jobDb.ReconcileDifferences(txn, nil, []database.Run{otherRun})
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.False(t, jsts[0].ScheduledAtPriorityBackfilled)
+	assert.Equal(t, int32(1), *jsts[0].Job.RunById(otherRun.RunID).ScheduledAtPriority())
+}
+
+func TestJobDb_ReconcilePriorityClassFallback(t *testing.T) {
+	jobRepoJob := func(priorityClassName string, version int32) database.Job {
+		return database.Job{
+			JobID:  util.NewULID(),
+			JobSet: "test-jobset",
+			Queue:  "test-queue",
+			SchedulingInfo: protoutil.MustMarshall(&schedulerobjects.JobSchedulingInfo{
+				PriorityClassName: priorityClassName,
+				Version:           uint32(version),
+				ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+					{
+						Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+							PodRequirements: &schedulerobjects.PodRequirements{},
+						},
+					},
+				},
+			}),
+			SchedulingInfoVersion: version,
+			Queued:                true,
+		}
+	}
+	for name, lazy := range map[string]bool{"eager": false, "lazy": true} {
+		t.Run(name, func(t *testing.T) {
+			jobDb := NewJobDb(
+				map[string]types.PriorityClass{
+					"low":      {Priority: 1},
+					"high":     {Priority: 10},
+					"fallback": {Priority: 5},
+				},
+				"low",
+				1024,
+			)
+			assert.Error(t, jobDb.EnablePriorityClassFallback("removed"))
+			require.NoError(t, jobDb.EnablePriorityClassFallback("fallback"))
+			if lazy {
+				jobDb.EnableLazySchedulingInfo()
+			}
+			removed, configured, unset := jobRepoJob("removed", 0), jobRepoJob("high", 0), jobRepoJob("", 0)
+			txn := jobDb.WriteTxn()
+			jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{removed, configured, unset}, nil)
+			require.NoError(t, err)
+			require.Len(t, jsts, 3)
+			jobs := make(map[string]*Job)
+			for _, jst := range jsts {
+				jobs[jst.Job.Id()] = jst.Job
+			}
+
+			// Jobs of priority classes that aren't configured are assigned the fallback priority class.
+			assert.Equal(t, "fallback", jobs[removed.JobID].GetPriorityClassName())
+			assert.Equal(t, "fallback", jobs[removed.JobID].JobSchedulingInfo().PriorityClassName)
+			assert.Equal(t, int32(5), jobs[removed.JobID].priorityClass.Priority)
+			assert.Equal(t, "high", jobs[configured.JobID].GetPriorityClassName())
+			assert.Equal(t, int32(10), jobs[configured.JobID].priorityClass.Priority)
+			// Jobs without a priority class are still assigned the default priority class.
+			assert.Equal(t, "", jobs[unset.JobID].GetPriorityClassName())
+			assert.Equal(t, int32(1), jobs[unset.JobID].priorityClass.Priority)
+
+			// As are those whose updated scheduling info has a priority class that isn't configured.
+			require.NoError(t, txn.Upsert([]*Job{jobs[configured.JobID]}))
+			updated := jobRepoJob("removed", 1)
+			updated.JobID = configured.JobID
+			jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{updated}, nil)
+			require.NoError(t, err)
+			require.Len(t, jsts, 1)
+			assert.Equal(t, "fallback", jsts[0].Job.GetPriorityClassName())
+			assert.Equal(t, "fallback", jsts[0].Job.JobSchedulingInfo().PriorityClassName)
+		})
+	}
+}
+
+func TestJobDb_ReconcileEverLeased(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	newJobRepoJob := func(queuedVersion int32) database.Job {
+		return database.Job{
+			JobID:          util.NewULID(),
+			JobSet:         "test-jobset",
+			Queue:          "test-queue",
+			Queued:         true,
+			QueuedVersion:  queuedVersion,
+			SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+		}
+	}
+
+	jobDb := NewTestJobDb()
+	pristineJob := newJobRepoJob(0)
+	// A job requeued after being leased, whose runs are no longer stored.
+	requeuedJob := newJobRepoJob(2)
+	// A job with a run, e.g., one that was leased but whose lease has yet to be reflected in its queued state.
+	jobWithRun := newJobRepoJob(0)
+	jobRepoRun := database.Run{
+		RunID:    uuid.New(),
+		JobID:    jobWithRun.JobID,
+		JobSet:   "test-jobset",
+		Executor: "test-executor",
+		Node:     "test-node",
+	}
+	txn := jobDb.WriteTxn()
+	jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{pristineJob, requeuedJob, jobWithRun}, []database.Run{jobRepoRun})
+	require.NoError(t, err)
+	everLeased := make(map[string]bool)
+	for _, jst := range jsts {
+		everLeased[jst.Job.Id()] = jst.Job.EverLeased()
+	}
+	assert.Equal(
+		t,
+		map[string]bool{pristineJob.JobID: false, requeuedJob.JobID: true, jobWithRun.JobID: true},
+		everLeased,
+	)
+
+	// A job already in the jobDb is marked as leased once its queued version is updated.
+	job, err := jobDb.schedulerJobFromDatabaseJob(&pristineJob)
+	require.NoError(t, err)
+	require.NoError(t, txn.Upsert([]*Job{job}))
+	pristineJob.QueuedVersion = 1
+	pristineJob.Queued = false
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{pristineJob}, nil)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.True(t, jsts[0].Job.EverLeased())
+}
+
+func TestJobDb_ReconcileAcknowledged(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	jobRepoJob := database.Job{
+		JobID:          util.NewULID(),
+		JobSet:         "test-jobset",
+		Queue:          "test-queue",
+		Queued:         true,
+		SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+	}
+
+	jobDb := NewTestJobDb()
+	txn := jobDb.WriteTxn()
+	jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.False(t, jsts[0].Job.Acknowledged())
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+
+	// The job is marked as acknowledged once the database records that a JobValidated event was published for it.
+	jobRepoJob.Acknowledged = true
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.True(t, jsts[0].Job.Acknowledged())
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+
+	// Updates made before the job was acknowledged don't clear the flag.
+	jobRepoJob.Acknowledged = false
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.True(t, jsts[0].Job.Acknowledged())
+
+	// Jobs new to the jobDb are loaded with the flag stored in the database.
+	jobRepoJob.JobID = util.NewULID()
+	jobRepoJob.Acknowledged = true
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.True(t, jsts[0].Job.Acknowledged())
+}
+
+func TestJobDb_ReconcileTerminalJobs(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	tests := map[string]database.Job{
+		"failed":    {Failed: true, CancelRequested: true},
+		"succeeded": {Succeeded: true, CancelRequested: true},
+	}
+	for name, jobRepoJob := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobRepoJob.JobID = util.NewULID()
+			jobRepoJob.JobSet = "test-jobset"
+			jobRepoJob.Queue = "test-queue"
+			jobRepoJob.QueuedVersion = 1
+			jobRepoJob.SchedulingInfo = protoutil.MustMarshall(schedulingInfo)
+
+			// A job removed from the jobDb once terminal that's updated again, e.g., by a late cancellation request,
+			// is loaded in its terminal state, such that it isn't cancelled after having finished.
+			jobDb := NewTestJobDb()
+			jsts, err := jobDb.ReconcileDifferences(jobDb.WriteTxn(), []database.Job{jobRepoJob}, nil)
+			require.NoError(t, err)
+			require.Len(t, jsts, 1)
+			assert.True(t, jsts[0].Job.InTerminalState())
+			assert.Equal(t, jobRepoJob.Failed, jsts[0].Job.Failed())
+			assert.Equal(t, jobRepoJob.Succeeded, jsts[0].Job.Succeeded())
+		})
+	}
+}
+
+func TestJobDb_ReconcileStrictStateMachine(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	recorder := &illegalTransitionRecorder{}
+	jobDb := NewTestJobDb()
+	jobDb.EnableStateMachine(StateMachineStrict, recorder)
+	jobRepoJob := database.Job{
+		JobID:          util.NewULID(),
+		JobSet:         "test-jobset",
+		Queue:          "test-queue",
+		Queued:         true,
+		SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+	}
+	txn := jobDb.WriteTxn()
+	jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+	assert.Equal(t, JobPhaseQueued, jsts[0].Job.Phase())
+
+	// A job leased and finished by another replica transitions via the leased phase.
+	jobRepoJob.Queued = false
+	jobRepoJob.QueuedVersion = 1
+	jobRepoJob.Succeeded = true
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+	assert.Equal(t, JobPhaseSucceeded, jsts[0].Job.Phase())
+
+	// A job can't fail once it's succeeded.
+	jobRepoJob.Failed = true
+	jsts, err = jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, nil)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.Equal(t, JobPhaseSucceeded, jsts[0].Job.Phase())
+	assert.False(t, jsts[0].Job.Failed())
+	require.Len(t, recorder.errs, 1)
+	assert.Equal(t, "invalid", recorder.errs[0].To)
+}
+
+func TestJobDb_ReconcileContradictoryRuns(t *testing.T) {
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{},
+				},
+			},
+		},
+	}
+	type outcome struct{ succeeded, failed bool }
+	contradictory := outcome{succeeded: true, failed: true}
+	updatesByName := map[string][]outcome{
+		"single update":          {contradictory},
+		"succeeded, then failed": {{succeeded: true}, contradictory},
+		"failed, then succeeded": {{failed: true}, contradictory},
+		// Rows not reporting the outcome already held by the jobDb still contradict it.
+		"succeeded, then only failed": {{succeeded: true}, {failed: true}},
+	}
+	expectedPhaseByPolicy := map[ContradictoryRunPolicy]RunPhase{
+		ContradictoryRunsPreferFailed:    RunPhaseFailed,
+		ContradictoryRunsPreferSucceeded: RunPhaseSucceeded,
+		ContradictoryRunsQuarantine:      RunPhaseRunning,
+	}
+	for policy, expectedPhase := range expectedPhaseByPolicy {
+		for name, updates := range updatesByName {
+			t.Run(policy.String()+"/"+name, func(t *testing.T) {
+				jobDb := NewTestJobDb()
+				jobDb.EnableContradictoryRunPolicy(policy)
+				jobRepoJob := database.Job{
+					JobID:          util.NewULID(),
+					JobSet:         "test-jobset",
+					Queue:          "test-queue",
+					QueuedVersion:  1,
+					SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+				}
+				jobRepoRun := database.Run{
+					RunID:    uuid.New(),
+					JobID:    jobRepoJob.JobID,
+					JobSet:   "test-jobset",
+					Executor: "test-executor",
+					Node:     "test-node",
+					Running:  true,
+				}
+				txn := jobDb.WriteTxn()
+				jsts, err := jobDb.ReconcileDifferences(txn, []database.Job{jobRepoJob}, []database.Run{jobRepoRun})
+				require.NoError(t, err)
+				require.Len(t, jsts, 1)
+				require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+
+				for i, update := range updates {
+					jobRepoRun.Succeeded, jobRepoRun.Failed = update.succeeded, update.failed
+					jsts, err = jobDb.ReconcileDifferences(txn, nil, []database.Run{jobRepoRun})
+					require.NoError(t, err)
+					require.Len(t, jsts, 1)
+					require.NoError(t, txn.Upsert([]*Job{jsts[0].Job}))
+					assert.Equal(t, i == len(updates)-1, jsts[0].ContradictoryRunOutcome, "update %d", i)
+				}
+				run := jsts[0].Job.LatestRun()
+				assert.Equal(t, expectedPhase, run.Phase())
+				assert.Equal(t, policy == ContradictoryRunsQuarantine, run.OutcomeContradictory())
+
+				// Rows reporting both are resolved identically if reconciled again, whereas rows reporting only one
+				// no longer contradict the jobDb once the other has been resolved away.
+				if updates[len(updates)-1] == contradictory {
+					jsts, err = jobDb.ReconcileDifferences(txn, nil, []database.Run{jobRepoRun})
+					require.NoError(t, err)
+					assert.True(t, jsts[0].ContradictoryRunOutcome)
+					assert.Equal(t, expectedPhase, jsts[0].Job.LatestRun().Phase())
+				}
+
+				// Quarantined runs are reconciled as usual once the contradiction is resolved.
+				if policy == ContradictoryRunsQuarantine {
+					jobRepoRun.Succeeded, jobRepoRun.Failed = false, true
+					jsts, err = jobDb.ReconcileDifferences(txn, nil, []database.Run{jobRepoRun})
+					require.NoError(t, err)
+					assert.False(t, jsts[0].ContradictoryRunOutcome)
+					assert.True(t, jsts[0].Failed)
+					assert.Equal(t, RunPhaseFailed, jsts[0].Job.LatestRun().Phase())
+					assert.False(t, jsts[0].Job.LatestRun().OutcomeContradictory())
+				}
+			})
+		}
+	}
+}
+
+func TestJobDb_ReconcileContradictoryNewRuns(t *testing.T) {
+	jobRepoRun := database.Run{
+		RunID:     uuid.New(),
+		JobID:     util.NewULID(),
+		JobSet:    "test-jobset",
+		Executor:  "test-executor",
+		Node:      "test-node",
+		Succeeded: true,
+		Failed:    true,
+	}
+	for policy, expected := range map[ContradictoryRunPolicy]struct{ succeeded, failed bool }{
+		ContradictoryRunsPreferFailed:    {failed: true},
+		ContradictoryRunsPreferSucceeded: {succeeded: true},
+		ContradictoryRunsQuarantine:      {},
+	} {
+		t.Run(policy.String(), func(t *testing.T) {
+			jobDb := NewTestJobDb()
+			jobDb.EnableContradictoryRunPolicy(policy)
+			rst := jobDb.reconcileRunDifferences(nil, &jobRepoRun)
+			assert.True(t, rst.ContradictoryOutcome)
+			assert.Equal(t, expected.succeeded, rst.Succeeded)
+			assert.Equal(t, expected.failed, rst.Failed)
+			assert.Equal(t, expected.succeeded, rst.JobRun.Succeeded())
+			assert.Equal(t, expected.failed, rst.JobRun.Failed())
+			assert.Equal(t, policy == ContradictoryRunsQuarantine, rst.JobRun.OutcomeContradictory())
+		})
+	}
+	// The database run isn't modified.
+	assert.True(t, jobRepoRun.Succeeded && jobRepoRun.Failed)
+}
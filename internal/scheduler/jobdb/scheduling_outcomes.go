@@ -0,0 +1,71 @@
+package jobdb
+
+import (
+	"fmt"
+	"time"
+)
+
+// SchedulingOutcomeKind is the kind of outcome of evaluating a job in a scheduling round.
+type SchedulingOutcomeKind uint8
+
+const (
+	// The job was scheduled.
+	SchedulingOutcomeScheduled SchedulingOutcomeKind = iota + 1
+	// The job could not be scheduled and remains queued.
+	SchedulingOutcomeUnschedulable
+	// The job could not be scheduled and was failed, e.g., since its gang couldn't be scheduled.
+	SchedulingOutcomeFailed
+)
+
+func (kind SchedulingOutcomeKind) String() string {
+	switch kind {
+	case SchedulingOutcomeScheduled:
+		return "Scheduled"
+	case SchedulingOutcomeUnschedulable:
+		return "Unschedulable"
+	case SchedulingOutcomeFailed:
+		return "Failed"
+	default:
+		return fmt.Sprintf("Unknown(%d)", kind)
+	}
+}
+
+// SchedulingOutcome is the outcome of the most recent scheduling round in which a job was evaluated.
+// Unlike the scheduling contexts stored for reports, which roll over as new rounds complete,
+// outcomes are retained until replaced or the job is deleted from the jobDb.
+type SchedulingOutcome struct {
+	// Id of the scheduler cycle in which the job was evaluated.
+	CycleId string
+	Time    time.Time
+	Kind    SchedulingOutcomeKind
+	// Short explanation of the outcome, e.g., why the job couldn't be scheduled.
+	Reason string
+}
+
+func (outcome SchedulingOutcome) String() string {
+	if outcome.Reason == "" {
+		return fmt.Sprintf("%s at %s (cycle %s)", outcome.Kind, outcome.Time, outcome.CycleId)
+	}
+	return fmt.Sprintf("%s at %s (cycle %s): %s", outcome.Kind, outcome.Time, outcome.CycleId, outcome.Reason)
+}
+
+// SetSchedulingOutcomes records the outcome of evaluating each of the jobs with the given ids,
+// replacing any outcome previously recorded. Outcomes of jobs not in the jobDb are ignored.
+// Outcomes are stored separately from jobs, such that recording them doesn't require copying the jobs.
+func (txn *Txn) SetSchedulingOutcomes(outcomesByJobId map[string]SchedulingOutcome) error {
+	if err := txn.checkWritableTransaction(); err != nil {
+		return err
+	}
+	for jobId, outcome := range outcomesByJobId {
+		if _, ok := txn.jobsById.Get(jobId); ok {
+			txn.schedulingOutcomesByJobId = txn.schedulingOutcomesByJobId.Set(jobId, outcome)
+		}
+	}
+	return nil
+}
+
+// GetSchedulingOutcome returns the outcome of the most recent scheduling round in which the job with the given id
+// was evaluated, and false if there's no such job or it hasn't been evaluated.
+func (txn *Txn) GetSchedulingOutcome(jobId string) (SchedulingOutcome, bool) {
+	return txn.schedulingOutcomesByJobId.Get(jobId)
+}
@@ -0,0 +1,236 @@
+package jobdb
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// JobPhase is the phase of its lifecycle a job is in, derived from its state flags; see Job.Phase.
+type JobPhase string
+
+const (
+	// The job is waiting to be scheduled.
+	JobPhaseQueued JobPhase = "queued"
+	// The job isn't queued and hasn't finished, i.e., it's been leased to an executor.
+	JobPhaseLeased JobPhase = "leased"
+	// The job succeeded.
+	JobPhaseSucceeded JobPhase = "succeeded"
+	// The job failed.
+	JobPhaseFailed JobPhase = "failed"
+	// The job was cancelled.
+	JobPhaseCancelled JobPhase = "cancelled"
+	// More than one of succeeded, failed, and cancelled is set.
+	// Jobs can only end up in this phase if illegal transitions aren't enforced; see JobDb.EnableStateMachine.
+	JobPhaseInvalid JobPhase = "invalid"
+)
+
+// JobPhases are all phases a job may be in.
+var JobPhases = []JobPhase{JobPhaseQueued, JobPhaseLeased, JobPhaseSucceeded, JobPhaseFailed, JobPhaseCancelled, JobPhaseInvalid}
+
+// IsTerminal returns true if jobs in this phase are finished, i.e., will never be scheduled again.
+func (phase JobPhase) IsTerminal() bool {
+	switch phase {
+	case JobPhaseSucceeded, JobPhaseFailed, JobPhaseCancelled, JobPhaseInvalid:
+		return true
+	default:
+		return false
+	}
+}
+
+// legalJobTransitions maps each phase to the phases jobs in it may transition to, other than itself.
+// Terminal phases can't be left.
+var legalJobTransitions = map[JobPhase]map[JobPhase]bool{
+	JobPhaseQueued: {JobPhaseLeased: true, JobPhaseFailed: true, JobPhaseCancelled: true},
+	JobPhaseLeased: {JobPhaseQueued: true, JobPhaseSucceeded: true, JobPhaseFailed: true, JobPhaseCancelled: true},
+}
+
+// IsLegalJobTransition returns true if a job may transition from phase from to phase to.
+// Remaining in the same phase is always legal.
+func IsLegalJobTransition(from, to JobPhase) bool {
+	return from == to || legalJobTransitions[from][to]
+}
+
+// RunPhase is the phase of its lifecycle a run is in, derived from its state flags; see JobRun.Phase.
+type RunPhase string
+
+const (
+	// The run has been leased to an executor, which hasn't yet reported it as running.
+	RunPhaseLeased RunPhase = "leased"
+	// The executor reported the run as running.
+	RunPhaseRunning RunPhase = "running"
+	// The run succeeded.
+	RunPhaseSucceeded RunPhase = "succeeded"
+	// The run failed, including if it was returned by the executor.
+	RunPhaseFailed RunPhase = "failed"
+	// The run was cancelled.
+	RunPhaseCancelled RunPhase = "cancelled"
+	// More than one of succeeded, failed or returned, and cancelled is set.
+	// Runs can only end up in this phase if illegal transitions aren't enforced; see JobDb.EnableStateMachine.
+	RunPhaseInvalid RunPhase = "invalid"
+)
+
+// RunPhases are all phases a run may be in.
+var RunPhases = []RunPhase{RunPhaseLeased, RunPhaseRunning, RunPhaseSucceeded, RunPhaseFailed, RunPhaseCancelled, RunPhaseInvalid}
+
+// IsTerminal returns true if runs in this phase are finished.
+func (phase RunPhase) IsTerminal() bool {
+	switch phase {
+	case RunPhaseSucceeded, RunPhaseFailed, RunPhaseCancelled, RunPhaseInvalid:
+		return true
+	default:
+		return false
+	}
+}
+
+// legalRunTransitions maps each phase to the phases runs in it may transition to, other than itself.
+// Runs can't stop running without finishing, and terminal phases can't be left.
+var legalRunTransitions = map[RunPhase]map[RunPhase]bool{
+	RunPhaseLeased:  {RunPhaseRunning: true, RunPhaseSucceeded: true, RunPhaseFailed: true, RunPhaseCancelled: true},
+	RunPhaseRunning: {RunPhaseSucceeded: true, RunPhaseFailed: true, RunPhaseCancelled: true},
+}
+
+// IsLegalRunTransition returns true if a run may transition from phase from to phase to.
+// Remaining in the same phase is always legal.
+func IsLegalRunTransition(from, to RunPhase) bool {
+	return from == to || legalRunTransitions[from][to]
+}
+
+// ErrIllegalStateTransition is wrapped by the errors reported for illegal transitions; see IllegalTransitionError.
+var ErrIllegalStateTransition = errors.New("illegal state transition")
+
+// IllegalTransitionError describes a job or run state transition not in the transition table.
+type IllegalTransitionError struct {
+	// Either "job" or "run".
+	Entity string
+	// Id of the job or run.
+	Id string
+	// Phase before the transition.
+	From string
+	// Phase the transition would have resulted in.
+	To string
+	// True if the transition was refused, i.e., the job or run was left unchanged.
+	Enforced bool
+}
+
+func (err *IllegalTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s %s from %s to %s", ErrIllegalStateTransition, err.Entity, err.Id, err.From, err.To)
+}
+
+func (err *IllegalTransitionError) Unwrap() error {
+	return ErrIllegalStateTransition
+}
+
+// StateMachineMode determines how illegal state transitions of jobs and runs are handled.
+type StateMachineMode int
+
+const (
+	// Illegal transitions are applied, i.e., state flags are combined as requested, as they were before transitions
+	// were checked. They're still reported, such that callers relying on them can be found.
+	StateMachineCompatibility StateMachineMode = iota
+	// Illegal transitions are refused, i.e., the builder applying them returns the job or run unchanged,
+	// and reported.
+	StateMachineStrict
+)
+
+// IllegalTransitionObserver is notified of each illegal state transition of a job or run.
+type IllegalTransitionObserver interface {
+	ObserveIllegalTransition(err *IllegalTransitionError)
+}
+
+// stateMachine checks the state transitions of the jobs and runs created by a jobDb; see JobDb.EnableStateMachine.
+type stateMachine struct {
+	mode StateMachineMode
+	// If non-nil, notified of each illegal transition.
+	observer IllegalTransitionObserver
+}
+
+// allow returns true if a transition that was found to be illegal should be applied,
+// after notifying the observer of it.
+func (sm *stateMachine) allow(entity, id, from, to string) bool {
+	enforced := sm.mode == StateMachineStrict
+	if sm.observer != nil {
+		sm.observer.ObserveIllegalTransition(&IllegalTransitionError{Entity: entity, Id: id, From: from, To: to, Enforced: enforced})
+	}
+	return !enforced
+}
+
+// Phase returns the phase of the job, derived from its state flags.
+// Terminal flags take precedence over the queued flag.
+func (job *Job) Phase() JobPhase {
+	switch numSet(job.succeeded, job.failed, job.cancelled) {
+	case 0:
+		if job.queued {
+			return JobPhaseQueued
+		}
+		return JobPhaseLeased
+	case 1:
+		if job.succeeded {
+			return JobPhaseSucceeded
+		} else if job.failed {
+			return JobPhaseFailed
+		}
+		return JobPhaseCancelled
+	default:
+		return JobPhaseInvalid
+	}
+}
+
+// transitionTo returns updated, a copy of job with its state flags changed such that it's in phase to,
+// unless the transition is illegal and the jobDb that created job enforces legal transitions,
+// in which case job is returned unchanged.
+func (job *Job) transitionTo(updated *Job, to JobPhase) *Job {
+	if job.stateMachine == nil {
+		return updated
+	}
+	from := job.Phase()
+	if IsLegalJobTransition(from, to) || job.stateMachine.allow("job", job.id, string(from), string(to)) {
+		return updated
+	}
+	return job
+}
+
+// Phase returns the phase of the run, derived from its state flags.
+// Terminal flags take precedence over the running flag, and returned runs are failed.
+func (run *JobRun) Phase() RunPhase {
+	failed := run.failed || run.returned
+	switch numSet(run.succeeded, failed, run.cancelled) {
+	case 0:
+		if run.running {
+			return RunPhaseRunning
+		}
+		return RunPhaseLeased
+	case 1:
+		if run.succeeded {
+			return RunPhaseSucceeded
+		} else if failed {
+			return RunPhaseFailed
+		}
+		return RunPhaseCancelled
+	default:
+		return RunPhaseInvalid
+	}
+}
+
+// transitionTo returns updated, a copy of run with its state flags changed, unless the resulting transition is illegal
+// and the jobDb that created run enforces legal transitions, in which case run is returned unchanged.
+func (run *JobRun) transitionTo(updated *JobRun) *JobRun {
+	if run.stateMachine == nil {
+		return updated
+	}
+	from, to := run.Phase(), updated.Phase()
+	if IsLegalRunTransition(from, to) || run.stateMachine.allow("run", run.id.String(), string(from), string(to)) {
+		return updated
+	}
+	return run
+}
+
+func numSet(flags ...bool) int {
+	n := 0
+	for _, flag := range flags {
+		if flag {
+			n++
+		}
+	}
+	return n
+}
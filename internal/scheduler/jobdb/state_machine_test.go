@@ -0,0 +1,342 @@
+package jobdb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+)
+
+type illegalTransitionRecorder struct {
+	errs []*IllegalTransitionError
+}
+
+func (r *illegalTransitionRecorder) ObserveIllegalTransition(err *IllegalTransitionError) {
+	r.errs = append(r.errs, err)
+}
+
+func TestJob_Phase(t *testing.T) {
+	tests := map[string]struct {
+		queued, succeeded, failed, cancelled bool
+		expected                             JobPhase
+	}{
+		"queued":                 {queued: true, expected: JobPhaseQueued},
+		"leased":                 {expected: JobPhaseLeased},
+		"succeeded":              {succeeded: true, expected: JobPhaseSucceeded},
+		"failed":                 {failed: true, expected: JobPhaseFailed},
+		"cancelled":              {cancelled: true, expected: JobPhaseCancelled},
+		"cancelled while queued": {queued: true, cancelled: true, expected: JobPhaseCancelled},
+		"succeeded and failed":   {succeeded: true, failed: true, expected: JobPhaseInvalid},
+		"failed and cancelled":   {failed: true, cancelled: true, expected: JobPhaseInvalid},
+		"all terminal":           {queued: true, succeeded: true, failed: true, cancelled: true, expected: JobPhaseInvalid},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := &Job{queued: tc.queued, succeeded: tc.succeeded, failed: tc.failed, cancelled: tc.cancelled}
+			assert.Equal(t, tc.expected, job.Phase())
+			assert.Equal(t, tc.expected.IsTerminal(), job.InTerminalState())
+		})
+	}
+}
+
+func TestJobRun_Phase(t *testing.T) {
+	tests := map[string]struct {
+		running, succeeded, failed, cancelled, returned bool
+		expected                                        RunPhase
+	}{
+		"leased":                 {expected: RunPhaseLeased},
+		"running":                {running: true, expected: RunPhaseRunning},
+		"succeeded":              {running: true, succeeded: true, expected: RunPhaseSucceeded},
+		"failed":                 {failed: true, expected: RunPhaseFailed},
+		"returned":               {failed: true, returned: true, expected: RunPhaseFailed},
+		"returned without fail":  {returned: true, expected: RunPhaseFailed},
+		"cancelled":              {cancelled: true, expected: RunPhaseCancelled},
+		"succeeded and failed":   {succeeded: true, failed: true, expected: RunPhaseInvalid},
+		"succeeded and returned": {succeeded: true, returned: true, expected: RunPhaseInvalid},
+		"failed and cancelled":   {failed: true, cancelled: true, expected: RunPhaseInvalid},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			run := &JobRun{running: tc.running, succeeded: tc.succeeded, failed: tc.failed, cancelled: tc.cancelled, returned: tc.returned}
+			assert.Equal(t, tc.expected, run.Phase())
+			assert.Equal(t, tc.expected.IsTerminal(), run.InTerminalState())
+		})
+	}
+}
+
+func TestIsLegalJobTransition(t *testing.T) {
+	legal := map[JobPhase][]JobPhase{
+		JobPhaseQueued:    {JobPhaseQueued, JobPhaseLeased, JobPhaseFailed, JobPhaseCancelled},
+		JobPhaseLeased:    {JobPhaseLeased, JobPhaseQueued, JobPhaseSucceeded, JobPhaseFailed, JobPhaseCancelled},
+		JobPhaseSucceeded: {JobPhaseSucceeded},
+		JobPhaseFailed:    {JobPhaseFailed},
+		JobPhaseCancelled: {JobPhaseCancelled},
+		JobPhaseInvalid:   {JobPhaseInvalid},
+	}
+	for _, from := range JobPhases {
+		for _, to := range JobPhases {
+			expected := false
+			for _, phase := range legal[from] {
+				expected = expected || phase == to
+			}
+			assert.Equal(t, expected, IsLegalJobTransition(from, to), "%s -> %s", from, to)
+		}
+	}
+}
+
+func TestIsLegalRunTransition(t *testing.T) {
+	legal := map[RunPhase][]RunPhase{
+		RunPhaseLeased:    {RunPhaseLeased, RunPhaseRunning, RunPhaseSucceeded, RunPhaseFailed, RunPhaseCancelled},
+		RunPhaseRunning:   {RunPhaseRunning, RunPhaseSucceeded, RunPhaseFailed, RunPhaseCancelled},
+		RunPhaseSucceeded: {RunPhaseSucceeded},
+		RunPhaseFailed:    {RunPhaseFailed},
+		RunPhaseCancelled: {RunPhaseCancelled},
+		RunPhaseInvalid:   {RunPhaseInvalid},
+	}
+	for _, from := range RunPhases {
+		for _, to := range RunPhases {
+			expected := false
+			for _, phase := range legal[from] {
+				expected = expected || phase == to
+			}
+			assert.Equal(t, expected, IsLegalRunTransition(from, to), "%s -> %s", from, to)
+		}
+	}
+}
+
+// jobTransitions are the builders that change the state flags of jobs, along with the phase they transition jobs to,
+// given the job with its flags changed.
+var jobTransitions = map[string]func(job *Job) (*Job, JobPhase){
+	"WithQueued(true)": func(job *Job) (*Job, JobPhase) {
+		return job.WithQueued(true), JobPhaseQueued
+	},
+	"WithQueued(false)":    func(job *Job) (*Job, JobPhase) { return job.WithQueued(false), withQueued(job, false).Phase() },
+	"WithSucceeded(true)":  func(job *Job) (*Job, JobPhase) { return job.WithSucceeded(true), withSucceeded(job, true).Phase() },
+	"WithSucceeded(false)": func(job *Job) (*Job, JobPhase) { return job.WithSucceeded(false), withSucceeded(job, false).Phase() },
+	"WithFailed(true)":     func(job *Job) (*Job, JobPhase) { return job.WithFailed(true), withFailed(job, true).Phase() },
+	"WithFailed(false)":    func(job *Job) (*Job, JobPhase) { return job.WithFailed(false), withFailed(job, false).Phase() },
+	"WithCancelled(true)":  func(job *Job) (*Job, JobPhase) { return job.WithCancelled(true), withCancelled(job, true).Phase() },
+	"WithCancelled(false)": func(job *Job) (*Job, JobPhase) { return job.WithCancelled(false), withCancelled(job, false).Phase() },
+}
+
+func withQueued(job *Job, queued bool) *Job {
+	j := copyJob(*job)
+	j.queued = queued
+	return j
+}
+
+func withSucceeded(job *Job, succeeded bool) *Job {
+	j := copyJob(*job)
+	j.succeeded = succeeded
+	return j
+}
+
+func withFailed(job *Job, failed bool) *Job {
+	j := copyJob(*job)
+	j.failed = failed
+	return j
+}
+
+func withCancelled(job *Job, cancelled bool) *Job {
+	j := copyJob(*job)
+	j.cancelled = cancelled
+	return j
+}
+
+// jobInPhase returns a job created by jobDb in the given phase.
+func jobInPhase(jobDb *JobDb, phase JobPhase) *Job {
+	job := jobDb.NewJob("test-job", "test-jobSet", "test-queue", 0, jobSchedulingInfo, phase == JobPhaseQueued, 0, false, false, false, 0)
+	j := copyJob(*job)
+	switch phase {
+	case JobPhaseSucceeded:
+		j.succeeded = true
+	case JobPhaseFailed:
+		j.failed = true
+	case JobPhaseCancelled:
+		j.cancelled = true
+	case JobPhaseInvalid:
+		j.succeeded, j.failed = true, true
+	}
+	return j
+}
+
+func TestJob_StateTransitions(t *testing.T) {
+	for _, mode := range []StateMachineMode{StateMachineCompatibility, StateMachineStrict} {
+		for _, from := range JobPhases {
+			for name, transition := range jobTransitions {
+				t.Run(fmt.Sprintf("mode %d: %s from %s", mode, name, from), func(t *testing.T) {
+					recorder := &illegalTransitionRecorder{}
+					jobDb := NewTestJobDb()
+					jobDb.EnableStateMachine(mode, recorder)
+					job := jobInPhase(jobDb, from)
+					require.Equal(t, from, job.Phase())
+
+					updated, to := transition(job)
+					if IsLegalJobTransition(from, to) {
+						assert.Empty(t, recorder.errs)
+						assert.Equal(t, to, updated.Phase())
+						return
+					}
+					require.Len(t, recorder.errs, 1)
+					err := recorder.errs[0]
+					assert.ErrorIs(t, err, ErrIllegalStateTransition)
+					assert.Equal(t, &IllegalTransitionError{
+						Entity:   "job",
+						Id:       job.Id(),
+						From:     string(from),
+						To:       string(to),
+						Enforced: mode == StateMachineStrict,
+					}, err)
+					if mode == StateMachineStrict {
+						assert.Same(t, job, updated)
+					} else {
+						assert.NotSame(t, job, updated)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestJob_StateTransitionsWithoutStateMachine(t *testing.T) {
+	job := jobInPhase(NewTestJobDb(), JobPhaseSucceeded).WithFailed(true).WithQueued(true)
+	assert.Equal(t, JobPhaseInvalid, job.Phase())
+	assert.True(t, job.Queued())
+}
+
+// runTransitions are the builders that change the state flags of runs.
+var runTransitions = map[string]func(run *JobRun) *JobRun{
+	"WithRunning(true)":    func(run *JobRun) *JobRun { return run.WithRunning(true) },
+	"WithRunning(false)":   func(run *JobRun) *JobRun { return run.WithRunning(false) },
+	"WithSucceeded(true)":  func(run *JobRun) *JobRun { return run.WithSucceeded(true) },
+	"WithSucceeded(false)": func(run *JobRun) *JobRun { return run.WithSucceeded(false) },
+	"WithFailed(true)":     func(run *JobRun) *JobRun { return run.WithFailed(true) },
+	"WithFailed(false)":    func(run *JobRun) *JobRun { return run.WithFailed(false) },
+	"WithCancelled(true)":  func(run *JobRun) *JobRun { return run.WithCancelled(true) },
+	"WithCancelled(false)": func(run *JobRun) *JobRun { return run.WithCancelled(false) },
+	"WithReturned(true)":   func(run *JobRun) *JobRun { return run.WithReturned(true) },
+	"WithReturned(false)":  func(run *JobRun) *JobRun { return run.WithReturned(false) },
+}
+
+// runInPhase returns a run created by jobDb in the given phase.
+func runInPhase(jobDb *JobDb, phase RunPhase) *JobRun {
+	return jobDb.CreateRun(
+		uuid.New(), "test-job", 0, "test-executor", "test-node", "test-node", nil,
+		phase == RunPhaseRunning,
+		phase == RunPhaseSucceeded || phase == RunPhaseInvalid,
+		phase == RunPhaseFailed || phase == RunPhaseInvalid,
+		phase == RunPhaseCancelled,
+		false,
+		false,
+	)
+}
+
+func TestJobRun_StateTransitions(t *testing.T) {
+	for _, mode := range []StateMachineMode{StateMachineCompatibility, StateMachineStrict} {
+		for _, from := range RunPhases {
+			for name, transition := range runTransitions {
+				t.Run(fmt.Sprintf("mode %d: %s from %s", mode, name, from), func(t *testing.T) {
+					recorder := &illegalTransitionRecorder{}
+					jobDb := NewTestJobDb()
+					jobDb.EnableStateMachine(mode, recorder)
+					run := runInPhase(jobDb, from)
+					require.Equal(t, from, run.Phase())
+
+					// The phase the run would transition to, as determined without checking the transition.
+					to := transition(runInPhase(NewTestJobDb(), from)).Phase()
+					updated := transition(run)
+					if IsLegalRunTransition(from, to) {
+						assert.Empty(t, recorder.errs)
+						assert.Equal(t, to, updated.Phase())
+						return
+					}
+					require.Len(t, recorder.errs, 1)
+					assert.Equal(t, &IllegalTransitionError{
+						Entity:   "run",
+						Id:       run.Id().String(),
+						From:     string(from),
+						To:       string(to),
+						Enforced: mode == StateMachineStrict,
+					}, recorder.errs[0])
+					if mode == StateMachineStrict {
+						assert.Same(t, run, updated)
+					} else {
+						assert.Equal(t, to, updated.Phase())
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestJob_WithNewRunInheritsStateMachine(t *testing.T) {
+	jobDb := NewTestJobDb()
+	jobDb.EnableStateMachine(StateMachineStrict, nil)
+	job := jobInPhase(jobDb, JobPhaseQueued).WithQueued(false).WithNewRun("test-executor", "test-node", "test-node", 0, time.Unix(0, 0))
+	run := job.LatestRun().WithSucceeded(true)
+	assert.Same(t, run, run.WithFailed(true))
+	assert.Equal(t, RunPhaseSucceeded, run.Phase())
+}
+
+// FuzzJob_StrictStateMachine applies sequences of state updates, each selected by a byte of the input, to a job and
+// its runs, and asserts that no job or run created by a jobDb enforcing legal transitions ends up in an invalid phase
+// or transitions illegally.
+func FuzzJob_StrictStateMachine(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1, 14, 10, 11, 2})
+	f.Add([]byte{1, 14, 12, 0, 14, 17, 3, 5, 7})
+	f.Add([]byte{1, 3, 5, 7, 0, 2, 4, 6, 14, 9, 11, 13, 15, 16, 17, 18})
+	jobNames := make([]string, 0, len(jobTransitions))
+	for name := range jobTransitions {
+		jobNames = append(jobNames, name)
+	}
+	// Map iteration order is random; sort such that each input applies the same sequence of updates.
+	slices.Sort(jobNames)
+	runNames := make([]string, 0, len(runTransitions))
+	for name := range runTransitions {
+		runNames = append(runNames, name)
+	}
+	slices.Sort(runNames)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		recorder := &illegalTransitionRecorder{}
+		jobDb := NewTestJobDb()
+		jobDb.EnableStateMachine(StateMachineStrict, recorder)
+		job := jobInPhase(jobDb, JobPhaseQueued)
+		for _, op := range ops {
+			from := job.Phase()
+			var fromRun RunPhase
+			if job.HasRuns() {
+				fromRun = job.LatestRun().Phase()
+			}
+			switch i := int(op) % (len(jobNames) + len(runNames) + 1); {
+			case i < len(jobNames):
+				job, _ = jobTransitions[jobNames[i]](job)
+			case i < len(jobNames)+len(runNames):
+				if job.HasRuns() {
+					job = job.WithUpdatedRun(runTransitions[runNames[i-len(jobNames)]](job.LatestRun()))
+				}
+			default:
+				job = job.WithNewRun("test-executor", "test-node", "test-node", 0, time.Unix(0, 0))
+				fromRun = RunPhaseLeased
+			}
+			require.NotEqual(t, JobPhaseInvalid, job.Phase())
+			require.True(t, IsLegalJobTransition(from, job.Phase()), "%s -> %s", from, job.Phase())
+			if job.Phase().IsTerminal() && from.IsTerminal() {
+				require.Equal(t, from, job.Phase())
+			}
+			for _, run := range job.AllRuns() {
+				require.NotEqual(t, RunPhaseInvalid, run.Phase())
+			}
+			if job.HasRuns() {
+				require.True(t, IsLegalRunTransition(fromRun, job.LatestRun().Phase()), "%s -> %s", fromRun, job.LatestRun().Phase())
+			}
+		}
+		for _, err := range recorder.errs {
+			require.True(t, err.Enforced)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package jobdb
+
+// JobTransition is a change to the state of a job that is published as an event.
+type JobTransition string
+
+const (
+	// The job was leased, i.e., a new run was created for it.
+	JobLeased JobTransition = "leased"
+	// The job succeeded.
+	JobSucceeded JobTransition = "succeeded"
+	// The job failed.
+	JobFailed JobTransition = "failed"
+	// The job was cancelled.
+	JobCancelled JobTransition = "cancelled"
+)
+
+// JobTransitions are the transitions for which Txn.Transitions lists jobs.
+var JobTransitions = []JobTransition{JobLeased, JobSucceeded, JobFailed, JobCancelled}
+
+// Transitions returns, for each transition, the ids of the jobs upserted by txn whose state transitioned
+// relative to the state committed to the jobDb when txn was created. Jobs deleted by txn are ignored.
+// Returns nil unless transition tracking is enabled; see JobDb.EnableTransitionTracking.
+func (txn *Txn) Transitions() map[JobTransition]map[string]bool {
+	if txn.upsertedJobIds == nil {
+		return nil
+	}
+	rv := make(map[JobTransition]map[string]bool, len(JobTransitions))
+	for _, transition := range JobTransitions {
+		rv[transition] = make(map[string]bool)
+	}
+	// The committed state can't change while txn is active, since there's at most one write transaction at a time.
+	committedJobsById := txn.jobDb.jobsById
+	for jobId := range txn.upsertedJobIds {
+		job, ok := txn.jobsById.Get(jobId)
+		if !ok {
+			continue
+		}
+		committedJob, _ := committedJobsById.Get(jobId)
+		if run := job.LatestRun(); run != nil && (committedJob == nil || committedJob.RunById(run.Id()) == nil) {
+			rv[JobLeased][jobId] = true
+		}
+		if job.Succeeded() && (committedJob == nil || !committedJob.Succeeded()) {
+			rv[JobSucceeded][jobId] = true
+		}
+		if job.Failed() && (committedJob == nil || !committedJob.Failed()) {
+			rv[JobFailed][jobId] = true
+		}
+		if job.Cancelled() && (committedJob == nil || !committedJob.Cancelled()) {
+			rv[JobCancelled][jobId] = true
+		}
+	}
+	return rv
+}
@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// JobDbLeaseSnapshots holds the most recent snapshot of the jobDb of the leader known to only contain runs whose events
+// have been published, from which the executor api can decide which runs to lease to and cancel on each executor
+// without querying postgres.
+//
+// The scheduler stores a new snapshot after each cycle in which it successfully published its events and committed
+// its jobDb transaction; since the transaction is only committed once publishing succeeded, no snapshot ever contains
+// a run whose JobRunLeased event hasn't been published. Reading the latest snapshot is wait-free.
+type JobDbLeaseSnapshots struct {
+	latest atomic.Pointer[jobDbLeaseSnapshot]
+}
+
+// jobDbLeaseSnapshot is an immutable snapshot of the jobDb.
+type jobDbLeaseSnapshot struct {
+	// Read transaction of the jobDb taken immediately after committing.
+	txn *jobdb.Txn
+	// Token of the leader that took the snapshot. The snapshot must not be served from once this token is invalid,
+	// since another replica may have become leader and leased or cancelled runs since.
+	leaderToken LeaderToken
+	// Time at which the snapshot was taken.
+	takenAt time.Time
+}
+
+func NewJobDbLeaseSnapshots() *JobDbLeaseSnapshots {
+	return &JobDbLeaseSnapshots{}
+}
+
+// Store replaces the latest snapshot with one of txn, which must be a read transaction of the jobDb taken after
+// committing the changes of a cycle whose events leaderToken published successfully.
+// The active runs of each executor are indexed by the jobDb, so storing a snapshot doesn't depend on its size.
+func (s *JobDbLeaseSnapshots) Store(txn *jobdb.Txn, leaderToken LeaderToken, now time.Time) {
+	s.latest.Store(&jobDbLeaseSnapshot{
+		txn:         txn,
+		leaderToken: leaderToken,
+		takenAt:     now,
+	})
+}
+
+// servable returns the latest snapshot if it was taken by the current leader no longer than maxStaleness before now,
+// and nil otherwise.
+func (s *JobDbLeaseSnapshots) servable(leaderController LeaderController, now time.Time, maxStaleness time.Duration) *jobDbLeaseSnapshot {
+	snapshot := s.latest.Load()
+	if snapshot == nil || !leaderController.ValidateToken(snapshot.leaderToken) {
+		return nil
+	}
+	if maxStaleness > 0 && now.Sub(snapshot.takenAt) > maxStaleness {
+		return nil
+	}
+	return snapshot
+}
+
+// isActiveJobDbRun returns true if run, a run of job, hasn't succeeded, failed, or been cancelled.
+// This corresponds to the runs postgres considers active and to those returned by jobdb.Txn.ActiveRuns.
+func isActiveJobDbRun(job *jobdb.Job, run *jobdb.JobRun) bool {
+	return !job.InTerminalState() && !run.Phase().IsTerminal()
+}
+
+// EnableJobDbLeaseSnapshots causes a snapshot of the jobDb to be stored in snapshots after each cycle in which the
+// scheduler published its events successfully, such that the executor api can serve leases from it.
+func (s *Scheduler) EnableJobDbLeaseSnapshots(snapshots *JobDbLeaseSnapshots) {
+	s.jobDbLeaseSnapshots = snapshots
+}
+
+// EnableJobDbLeases causes the leader to decide which runs to lease to and cancel on executors from the latest snapshot
+// in snapshots, provided it's no older than maxStaleness, instead of by querying postgres.
+// The specs of newly leased runs are still fetched from postgres, by run id.
+func (srv *ExecutorApi) EnableJobDbLeases(snapshots *JobDbLeaseSnapshots, leaderController LeaderController, maxStaleness time.Duration) {
+	srv.jobDbLeaseSnapshots = snapshots
+	srv.leaderController = leaderController
+	srv.jobDbLeasesMaxStaleness = maxStaleness
+}
+
+// servableJobDbLeaseSnapshot returns the snapshot of the jobDb to serve leases from, or nil if leases should be served
+// from postgres, e.g., since this replica isn't leader or no sufficiently recent snapshot exists.
+func (srv *ExecutorApi) servableJobDbLeaseSnapshot() *jobDbLeaseSnapshot {
+	if srv.jobDbLeaseSnapshots == nil {
+		return nil
+	}
+	return srv.jobDbLeaseSnapshots.servable(srv.leaderController, srv.clock.Now(), srv.jobDbLeasesMaxStaleness)
+}
+
+// findInactiveRunsInSnapshot returns the subset of runIds that are inactive, i.e., that don't exist or that have
+// succeeded, failed, or been cancelled. Runs not found in the snapshot, e.g., since their job has been removed from the
+// jobDb after becoming terminal, are looked up in postgres.
+func (srv *ExecutorApi) findInactiveRunsInSnapshot(ctx *armadacontext.Context, snapshot *jobDbLeaseSnapshot, runIds []uuid.UUID) ([]uuid.UUID, error) {
+	var inactiveRunIds []uuid.UUID
+	var unknownRunIds []uuid.UUID
+	for _, runId := range runIds {
+		job := snapshot.txn.GetByRunId(runId)
+		if job == nil {
+			unknownRunIds = append(unknownRunIds, runId)
+			continue
+		}
+		run := job.RunById(runId)
+		if run == nil {
+			unknownRunIds = append(unknownRunIds, runId)
+		} else if !isActiveJobDbRun(job, run) {
+			inactiveRunIds = append(inactiveRunIds, runId)
+		}
+	}
+	if len(unknownRunIds) > 0 {
+		inactiveUnknownRunIds, err := srv.jobRepository.FindInactiveRuns(ctx, unknownRunIds)
+		if err != nil {
+			return nil, err
+		}
+		inactiveRunIds = append(inactiveRunIds, inactiveUnknownRunIds...)
+	}
+	return inactiveRunIds, nil
+}
+
+// fetchJobRunLeasesInSnapshot returns up to maxResults leases for executor of runs that are active in the snapshot,
+// excluding those in excludedRunIds, in the order the runs were created in.
+// Runs not yet written to postgres by the ingester are skipped; they're leased by a subsequent request.
+func (srv *ExecutorApi) fetchJobRunLeasesInSnapshot(
+	ctx *armadacontext.Context,
+	snapshot *jobDbLeaseSnapshot,
+	executor string,
+	maxResults uint,
+	excludedRunIds []uuid.UUID,
+) ([]*database.JobRunLease, error) {
+	if maxResults == 0 {
+		return []*database.JobRunLease{}, nil
+	}
+	excluded := make(map[uuid.UUID]bool, len(excludedRunIds))
+	for _, runId := range excludedRunIds {
+		excluded[runId] = true
+	}
+	runIds := make([]uuid.UUID, 0)
+	it := snapshot.txn.ActiveRuns(executor)
+	for !it.Done() && uint(len(runIds)) < maxResults {
+		run, _ := it.Next()
+		if !excluded[run.Id()] {
+			runIds = append(runIds, run.Id())
+		}
+	}
+	if len(runIds) == 0 {
+		return []*database.JobRunLease{}, nil
+	}
+	leases, err := srv.jobRepository.FetchJobRunLeasesByRunId(ctx, executor, runIds)
+	if err != nil {
+		return nil, err
+	}
+	if uint(len(leases)) < uint(len(runIds)) {
+		ctx.Infof("%d of %d runs leased in the jobDb aren't yet in postgres; they'll be leased later", len(runIds)-len(leases), len(runIds))
+	}
+	return leases, nil
+}
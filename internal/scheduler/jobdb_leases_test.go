@@ -0,0 +1,291 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/mocks"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+// newJobDbLeaseTestJob returns a new job with a run on executor created at the given time.
+func newJobDbLeaseTestJob(executor string, created int64) *jobdb.Job {
+	return testfixtures.JobDb.NewJob(
+		util.NewULID(), "testJobset", "testQueue", uint32(10), schedulingInfo, false, 1, false, false, false, 1,
+	).WithNewRun(executor, "test-node", "node", 5, time.Unix(0, created))
+}
+
+// activeJobDbRunIds returns the ids of the active runs assigned to executor in txn, oldest first.
+func activeJobDbRunIds(txn *jobdb.Txn, executor string) []uuid.UUID {
+	var rv []uuid.UUID
+	it := txn.ActiveRuns(executor)
+	for !it.Done() {
+		run, _ := it.Next()
+		rv = append(rv, run.Id())
+	}
+	return rv
+}
+
+func TestJobDbLeaseSnapshots_Store(t *testing.T) {
+	jobDb := testfixtures.NewJobDb()
+	second := newJobDbLeaseTestJob("executor-1", 2)
+	first := newJobDbLeaseTestJob("executor-1", 1)
+	otherExecutor := newJobDbLeaseTestJob("executor-2", 1)
+	// A job whose first run failed and was requeued and leased again; only its latest run is active.
+	retried := newJobDbLeaseTestJob("executor-1", 3)
+	retried = retried.WithUpdatedRun(retried.LatestRun().WithFailed(true)).WithNewRun("executor-1", "test-node", "node", 5, time.Unix(0, 4))
+	succeeded := newJobDbLeaseTestJob("executor-1", 1)
+	succeeded = succeeded.WithUpdatedRun(succeeded.LatestRun().WithSucceeded(true))
+	cancelled := newJobDbLeaseTestJob("executor-1", 1).WithCancelled(true)
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{second, first, otherExecutor, retried, succeeded, cancelled, queuedJob}))
+	txn.Commit()
+
+	snapshots := NewJobDbLeaseSnapshots()
+	snapshots.Store(jobDb.ReadTxn(), NewLeaderToken(), testfixtures.BaseTime)
+	snapshot := snapshots.latest.Load()
+	require.NotNil(t, snapshot)
+	assert.Equal(t, []uuid.UUID{first.LatestRun().Id(), second.LatestRun().Id(), retried.LatestRun().Id()}, activeJobDbRunIds(snapshot.txn, "executor-1"))
+	assert.Equal(t, []uuid.UUID{otherExecutor.LatestRun().Id()}, activeJobDbRunIds(snapshot.txn, "executor-2"))
+	assert.Empty(t, activeJobDbRunIds(snapshot.txn, "executor-3"))
+}
+
+func TestJobDbLeaseSnapshots_Servable(t *testing.T) {
+	tests := map[string]struct {
+		stored       bool
+		isLeader     bool
+		age          time.Duration
+		maxStaleness time.Duration
+		expected     bool
+	}{
+		"no snapshot": {
+			isLeader:     true,
+			maxStaleness: time.Minute,
+		},
+		"fresh snapshot of the leader": {
+			stored:       true,
+			isLeader:     true,
+			age:          time.Minute,
+			maxStaleness: time.Minute,
+			expected:     true,
+		},
+		"stale snapshot": {
+			stored:       true,
+			isLeader:     true,
+			age:          time.Minute + time.Second,
+			maxStaleness: time.Minute,
+		},
+		"no max staleness": {
+			stored:   true,
+			isLeader: true,
+			age:      time.Hour,
+			expected: true,
+		},
+		"no longer leader": {
+			stored:       true,
+			maxStaleness: time.Minute,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			snapshots := NewJobDbLeaseSnapshots()
+			if tc.stored {
+				snapshots.Store(testfixtures.NewJobDb().ReadTxn(), NewLeaderToken(), testfixtures.BaseTime)
+			}
+			snapshot := snapshots.servable(
+				&FakeLeaderController{IsCurrentlyLeader: tc.isLeader},
+				testfixtures.BaseTime.Add(tc.age),
+				tc.maxStaleness,
+			)
+			assert.Equal(t, tc.expected, snapshot != nil)
+		})
+	}
+}
+
+func TestExecutorApi_LeaseJobRuns_JobDbLeases(t *testing.T) {
+	const executorId = "test-executor"
+	const maxStaleness = 10 * time.Second
+	_, compressedSubmit := submitMsg(t, "node-id")
+
+	// Runs held by the executor: one active, one cancelled in the jobDb, and one of a job no longer in the jobDb.
+	activeRunJob := newJobDbLeaseTestJob(executorId, 1)
+	cancelledRunJob := newJobDbLeaseTestJob(executorId, 2)
+	cancelledRunJob = cancelledRunJob.WithUpdatedRun(cancelledRunJob.LatestRun().WithCancelled(true)).WithCancelled(true)
+	removedRunId := uuid.New()
+	// Runs not yet held by the executor, in the order they were created in.
+	newRunJobs := []*jobdb.Job{newJobDbLeaseTestJob(executorId, 3), newJobDbLeaseTestJob(executorId, 4)}
+	heldRunIds := []uuid.UUID{activeRunJob.LatestRun().Id(), cancelledRunJob.LatestRun().Id(), removedRunId}
+
+	tests := map[string]struct {
+		isLeader bool
+		// Time passed since the snapshot was taken.
+		age time.Duration
+		// If true, the runs to lease are added to the jobDb after the snapshot was taken,
+		// e.g., since the cycle that created them failed to publish its events.
+		leasedAfterSnapshot   bool
+		expectServedFromJobDb bool
+	}{
+		"leader serves from the jobDb": {
+			isLeader:              true,
+			expectServedFromJobDb: true,
+		},
+		"followers serve from postgres": {
+			isLeader: false,
+		},
+		"stale snapshots aren't served from": {
+			isLeader: true,
+			age:      maxStaleness + time.Second,
+		},
+		"runs committed after the snapshot aren't leased": {
+			isLeader:              true,
+			leasedAfterSnapshot:   true,
+			expectServedFromJobDb: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+			testClock := clock.NewFakeClock(testfixtures.BaseTime)
+			ctrl := gomock.NewController(t)
+			mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+			mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+			mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			jobDb := testfixtures.NewJobDb()
+			txn := jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{activeRunJob, cancelledRunJob}))
+			if !tc.leasedAfterSnapshot {
+				require.NoError(t, txn.Upsert(newRunJobs))
+			}
+			txn.Commit()
+			snapshots := NewJobDbLeaseSnapshots()
+			snapshots.Store(jobDb.ReadTxn(), NewLeaderToken(), testClock.Now())
+			if tc.leasedAfterSnapshot {
+				txn := jobDb.WriteTxn()
+				require.NoError(t, txn.Upsert(newRunJobs))
+				txn.Commit()
+			}
+			testClock.Step(tc.age)
+
+			newRunIds := []uuid.UUID{newRunJobs[0].LatestRun().Id(), newRunJobs[1].LatestRun().Id()}
+			leases := []*database.JobRunLease{
+				{RunID: newRunIds[0], Node: "node-id", SubmitMessage: compressedSubmit},
+				{RunID: newRunIds[1], Node: "node-id", SubmitMessage: compressedSubmit},
+			}
+			var expectedLeasedRunIds []uuid.UUID
+			var expectedCancelledRunIds []uuid.UUID
+			if tc.expectServedFromJobDb {
+				// Only runs missing from the jobDb are looked up in postgres, and only the specs of runs to lease are fetched.
+				mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), []uuid.UUID{removedRunId}).Return([]uuid.UUID{removedRunId}, nil).Times(1)
+				expectedCancelledRunIds = []uuid.UUID{cancelledRunJob.LatestRun().Id(), removedRunId}
+				if !tc.leasedAfterSnapshot {
+					mockJobRepository.EXPECT().FetchJobRunLeasesByRunId(gomock.Any(), executorId, newRunIds).Return(leases, nil).Times(1)
+					expectedLeasedRunIds = newRunIds
+				}
+			} else {
+				mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), heldRunIds).Return([]uuid.UUID{removedRunId}, nil).Times(1)
+				mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), executorId, uint(100), heldRunIds).Return(leases, nil).Times(1)
+				expectedCancelledRunIds = []uuid.UUID{removedRunId}
+				expectedLeasedRunIds = newRunIds
+			}
+
+			server, err := NewExecutorApi(
+				mocks.NewMockProducer(ctrl),
+				mockJobRepository,
+				mockExecutorRepository,
+				mockExecutorRepository,
+				[]int32{1000, 2000},
+				"kubernetes.io/hostname",
+				nil,
+				4*1024*1024,
+			)
+			require.NoError(t, err)
+			server.clock = testClock
+			server.EnableJobDbLeases(snapshots, &FakeLeaderController{IsCurrentlyLeader: tc.isLeader}, maxStaleness)
+
+			request := &executorapi.LeaseRequest{ExecutorId: executorId, Pool: "test-pool", MaxJobsToLease: 100}
+			for _, runId := range heldRunIds {
+				request.UnassignedJobRunIds = append(request.UnassignedJobRunIds, *armadaevents.ProtoUuidFromUuid(runId))
+			}
+			mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+			mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+			mockStream.EXPECT().Recv().Return(request, nil).Times(1)
+			var cancelled, leased []uuid.UUID
+			mockStream.EXPECT().Send(gomock.Any()).
+				Do(func(msg *executorapi.LeaseStreamMessage) {
+					for _, runId := range msg.GetCancelRuns().GetJobRunIdsToCancel() {
+						cancelled = append(cancelled, armadaevents.UuidFromProtoUuid(runId))
+					}
+					if lease := msg.GetLease(); lease != nil {
+						leased = append(leased, armadaevents.UuidFromProtoUuid(lease.JobRunId))
+					}
+				}).AnyTimes()
+			require.NoError(t, server.LeaseJobRuns(mockStream))
+			assert.Equal(t, expectedCancelledRunIds, cancelled)
+			assert.Equal(t, expectedLeasedRunIds, leased)
+		})
+	}
+}
+
+func TestScheduler_JobDbLeaseSnapshots(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+
+	publisher := &testPublisher{shouldError: true}
+	schedulingAlgo := &testSchedulingAlgo{jobsToSchedule: []string{queuedJob.Id()}}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&testJobRepository{},
+		&testExecutorRepository{
+			executors: []*schedulerobjects.Executor{{Id: "test-executor", LastUpdateTime: testfixtures.BaseTime.Add(24 * time.Hour)}},
+		},
+		schedulingAlgo,
+		NewStandaloneLeaderController(),
+		publisher,
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	snapshots := NewJobDbLeaseSnapshots()
+	sched.EnableJobDbLeaseSnapshots(snapshots)
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{queuedJob}))
+	txn.Commit()
+
+	// No snapshot is stored by cycles that fail to publish their events.
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.Error(t, err)
+	assert.Nil(t, snapshots.latest.Load())
+
+	// Once the events are published, the run is in the snapshot.
+	publisher.shouldError = false
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+	snapshot := snapshots.servable(sched.leaderController, testfixtures.BaseTime, time.Minute)
+	require.NotNil(t, snapshot)
+	job := sched.jobDb.ReadTxn().GetById(queuedJob.Id())
+	require.NotNil(t, job.LatestRun())
+	assert.Equal(t, []uuid.UUID{job.LatestRun().Id()}, activeJobDbRunIds(snapshot.txn, "test-executor"))
+}
@@ -0,0 +1,343 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/armadaproject/armada/internal/armada/permissions"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+const (
+	// Number of jobs replayed into the rebuilt jobDb at a time, after each of which progress is reported.
+	jobDbRebuildBatchSize = 10000
+	// Maximum time to wait for the database to contain all events published before the rebuilt jobDb is swapped in.
+	jobDbRebuildSwapTimeout = 5 * time.Minute
+)
+
+// JobDbRebuilder implements the RebuildJobDb admin endpoint, which replaces the jobDb of the leader with one rebuilt
+// by replaying the job repository from scratch, e.g., if the jobDb is suspected to be corrupt, without a restart.
+// Requests are recorded in memory and carried out by the scheduler; see Scheduler.EnableJobDbRebuilds.
+type JobDbRebuilder struct {
+	permissionChecker authorization.PermissionChecker
+	// True if a rebuild has been requested that the scheduler hasn't started yet.
+	requested bool
+	// Rebuild in progress, if any.
+	rebuild *jobDbRebuild
+	// Fraction of the job repository replayed by the rebuild in progress.
+	progress float64
+	mu       sync.Mutex
+}
+
+func NewJobDbRebuilder(permissionChecker authorization.PermissionChecker) *JobDbRebuilder {
+	return &JobDbRebuilder{permissionChecker: permissionChecker}
+}
+
+// RebuildJobDb is a gRPC endpoint for rebuilding the jobDb.
+// Requests made while a rebuild is already pending or in progress have no further effect.
+func (r *JobDbRebuilder) RebuildJobDb(ctx context.Context, request *schedulerobjects.RebuildJobDbRequest) (*schedulerobjects.RebuildJobDbResponse, error) {
+	principal, err := authorizeRebuildJobDb(ctx, r.permissionChecker)
+	if err != nil {
+		return nil, err
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// Proxied by another replica on behalf of the principal that made the original request.
+		if forwarded := md.Get(forwardedPrincipalMetadataKey); len(forwarded) > 0 && forwarded[0] != "" {
+			principal = fmt.Sprintf("%s (via %s)", forwarded[0], principal)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.requested || r.rebuild != nil {
+		return &schedulerobjects.RebuildJobDbResponse{AlreadyInProgress: true, Progress: r.progress}, nil
+	}
+	r.requested = true
+	log.WithFields(log.Fields{
+		"principal": principal,
+		"reason":    strings.TrimSpace(request.GetReason()),
+	}).Warn("jobDb will be rebuilt from the job repository; scheduling is paused until the rebuilt jobDb is swapped in")
+	return &schedulerobjects.RebuildJobDbResponse{}, nil
+}
+
+// authorizeRebuildJobDb returns the name of the principal associated with ctx
+// if it has permission to rebuild the jobDb and an error otherwise.
+func authorizeRebuildJobDb(ctx context.Context, permissionChecker authorization.PermissionChecker) (string, error) {
+	principal := authorization.GetPrincipal(ctx)
+	if permissionChecker == nil || !permissionChecker.UserHasPermission(ctx, permissions.RebuildJobDb) {
+		return "", &armadaerrors.ErrUnauthorized{
+			Principal:  principal.GetName(),
+			Permission: string(permissions.RebuildJobDb),
+			Action:     "rebuild jobDb",
+			Message:    fmt.Sprintf("user %s does not have permission to rebuild the jobDb", principal.GetName()),
+		}
+	}
+	return principal.GetName(), nil
+}
+
+// state returns the rebuild in progress, if any, and whether a rebuild has been requested that hasn't been started.
+func (r *JobDbRebuilder) state() (*jobDbRebuild, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rebuild, r.requested
+}
+
+func (r *JobDbRebuilder) started(rebuild *jobDbRebuild) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requested = false
+	r.rebuild = rebuild
+	r.progress = 0
+}
+
+func (r *JobDbRebuilder) setProgress(progress float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.progress = progress
+}
+
+// finished clears the rebuild in progress and any request that hasn't been started.
+func (r *JobDbRebuilder) finished() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requested = false
+	r.rebuild = nil
+	r.progress = 0
+}
+
+// jobDbRebuild is a rebuild of the jobDb in progress.
+type jobDbRebuild struct {
+	// Transaction the job repository is replayed into, detached from the jobDb until its contents are swapped in.
+	txn *jobdb.Txn
+	// Serials up to which the job repository has been replayed.
+	jobsSerial int64
+	runsSerial int64
+	// Closed once the job repository has been replayed in the background, after which err is set if that failed.
+	done chan struct{}
+	err  error
+	// Stops replaying the job repository in the background.
+	cancel context.CancelFunc
+}
+
+// EnableJobDbRebuilds causes the jobDb to be rebuilt from the job repository when requested via rebuilder.
+// The job repository is replayed from scratch in the background into a jobDb transaction detached from the jobDb,
+// during which the scheduler only reconciles; it doesn't schedule. Once replayed, the scheduler waits for the database
+// to contain all events published, as when becoming leader, such that no state it has published is missing from the
+// rebuilt jobDb, catches the rebuilt jobDb up, swaps it in atomically, and generates events for all jobs. Only then
+// is publishing resumed, such that no event is published twice. Rebuilds are abandoned if leadership is lost.
+//
+// State held by the jobDb that isn't derived from the job repository, e.g., scheduling outcomes, is discarded.
+func (s *Scheduler) EnableJobDbRebuilds(rebuilder *JobDbRebuilder) {
+	s.jobDbRebuilder = rebuilder
+}
+
+// advanceJobDbRebuild starts a requested rebuild of the jobDb or, if the job repository has been replayed,
+// swaps the rebuilt jobDb in. Returns true if a rebuild is in progress, in which case the cycle mustn't schedule,
+// and true if the rebuilt jobDb was swapped in, in which case the cycle must generate events for all jobs.
+func (s *Scheduler) advanceJobDbRebuild(ctx *armadacontext.Context, leaderToken LeaderToken) (bool, bool) {
+	rebuild, requested := s.jobDbRebuilder.state()
+	if rebuild == nil && !requested {
+		return false, false
+	}
+	if !s.leaderController.ValidateToken(leaderToken) {
+		ctx.Warnf("abandoning jobDb rebuild since this replica isn't leader")
+		s.abandonJobDbRebuild(rebuild)
+		return false, false
+	}
+	if rebuild == nil {
+		ctx.Infof("rebuilding jobDb from the job repository; scheduling is paused until it's swapped in")
+		s.jobDbRebuilder.started(s.startJobDbRebuild(ctx))
+		return true, false
+	}
+	select {
+	case <-rebuild.done:
+	default:
+		return true, false
+	}
+	if rebuild.err != nil {
+		logging.WithStacktrace(ctx, rebuild.err).Error("failed to rebuild jobDb; resuming scheduling with the existing jobDb")
+		s.jobDbRebuilder.finished()
+		s.metrics.ReportJobDbRebuild("failed")
+		return false, false
+	}
+	if err := s.swapRebuiltJobDb(ctx, rebuild); err != nil {
+		logging.WithStacktrace(ctx, err).Error("failed to swap in rebuilt jobDb; resuming scheduling with the existing jobDb")
+		s.jobDbRebuilder.finished()
+		s.metrics.ReportJobDbRebuild("failed")
+		return false, false
+	}
+	s.jobDbRebuilder.finished()
+	s.metrics.ReportJobDbRebuild("swapped")
+	return true, true
+}
+
+// startJobDbRebuild starts replaying the job repository from scratch in the background.
+func (s *Scheduler) startJobDbRebuild(ctx *armadacontext.Context) *jobDbRebuild {
+	replayCtx, cancel := armadacontext.WithCancel(ctx)
+	rebuild := &jobDbRebuild{
+		txn:        s.jobDb.DetachedWriteTxn(),
+		jobsSerial: -1,
+		runsSerial: -1,
+		done:       make(chan struct{}),
+		cancel:     cancel,
+	}
+	s.metrics.ReportJobDbRebuildProgress(0)
+	go func() {
+		defer close(rebuild.done)
+		rebuild.err = s.replayJobRepository(replayCtx, rebuild, func(progress float64) {
+			s.jobDbRebuilder.setProgress(progress)
+			s.metrics.ReportJobDbRebuildProgress(progress)
+		})
+	}()
+	return rebuild
+}
+
+func (s *Scheduler) abandonJobDbRebuild(rebuild *jobDbRebuild) {
+	if rebuild != nil {
+		rebuild.cancel()
+		<-rebuild.done
+	}
+	s.jobDbRebuilder.finished()
+	s.metrics.ReportJobDbRebuild("abandoned")
+}
+
+// swapRebuiltJobDb replaces the contents of the jobDb with those of the rebuilt jobDb.
+// Nothing is published before the database contains all events published so far, which the rebuilt jobDb is then
+// caught up to, such that decisions already published are reflected in the rebuilt jobDb and not made again.
+func (s *Scheduler) swapRebuiltJobDb(ctx *armadacontext.Context, rebuild *jobDbRebuild) error {
+	syncCtx, cancel := armadacontext.WithTimeout(ctx, jobDbRebuildSwapTimeout)
+	defer cancel()
+	if err := s.ensureDbUpToDate(syncCtx, 1*time.Second); err != nil {
+		return err
+	}
+	if err := s.replayJobRepository(syncCtx, rebuild, func(float64) {}); err != nil {
+		return err
+	}
+	txn := s.jobDb.WriteTxn()
+	defer txn.Abort()
+	numJobsBefore := len(txn.GetAll())
+	if err := txn.ReplaceContents(rebuild.txn); err != nil {
+		return err
+	}
+	txn.Commit()
+	ctx.Infof(
+		"swapped in rebuilt jobDb with %d jobs in place of %d; jobs serial %d -> %d, runs serial %d -> %d",
+		len(rebuild.txn.GetAll()), numJobsBefore, s.jobsSerial, rebuild.jobsSerial, s.runsSerial, rebuild.runsSerial,
+	)
+	s.jobsSerial = rebuild.jobsSerial
+	s.runsSerial = rebuild.runsSerial
+	return nil
+}
+
+// replayJobRepository replays the updates to the job repository after the serials of rebuild into its transaction,
+// until there are no further updates, calling reportProgress with the fraction of the updates fetched replayed so far.
+func (s *Scheduler) replayJobRepository(ctx *armadacontext.Context, rebuild *jobDbRebuild, reportProgress func(float64)) error {
+	for {
+		fetchedJobs, fetchedRuns, err := s.jobRepository.FetchJobUpdates(ctx, rebuild.jobsSerial, rebuild.runsSerial)
+		if err != nil {
+			return err
+		}
+		jobsSerial, runsSerial := rebuild.jobsSerial, rebuild.runsSerial
+		if len(fetchedJobs) > 0 {
+			jobsSerial = fetchedJobs[len(fetchedJobs)-1].Serial
+		}
+		if len(fetchedRuns) > 0 {
+			runsSerial = fetchedRuns[len(fetchedRuns)-1].Serial
+		}
+		if jobsSerial <= rebuild.jobsSerial && runsSerial <= rebuild.runsSerial {
+			return nil
+		}
+		updatedJobs, updatedRuns := fetchedJobs, fetchedRuns
+		if s.shardAssignment != nil {
+			updatedJobs, updatedRuns = s.filterUpdatesOfOtherShards(rebuild.txn, updatedJobs, updatedRuns)
+		}
+		if err := s.replayJobUpdates(ctx, rebuild.txn, updatedJobs, updatedRuns, reportProgress); err != nil {
+			return err
+		}
+		rebuild.jobsSerial, rebuild.runsSerial = jobsSerial, runsSerial
+	}
+}
+
+// replayJobUpdates reconciles the provided updates into txn in batches of jobs, together with their runs,
+// upserting the resulting jobs and deleting those in a terminal state.
+func (s *Scheduler) replayJobUpdates(
+	ctx *armadacontext.Context,
+	txn *jobdb.Txn,
+	updatedJobs []database.Job,
+	updatedRuns []database.Run,
+	reportProgress func(float64),
+) error {
+	runsByJobId := make(map[string][]database.Run)
+	for _, run := range updatedRuns {
+		runsByJobId[run.JobID] = append(runsByJobId[run.JobID], run)
+	}
+	for i := 0; i < len(updatedJobs); i += jobDbRebuildBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := i + jobDbRebuildBatchSize
+		if end > len(updatedJobs) {
+			end = len(updatedJobs)
+		}
+		batch := updatedJobs[i:end]
+		var batchRuns []database.Run
+		for _, job := range batch {
+			batchRuns = append(batchRuns, runsByJobId[job.JobID]...)
+			delete(runsByJobId, job.JobID)
+		}
+		if err := s.reconcileIntoTxn(txn, batch, batchRuns); err != nil {
+			return err
+		}
+		reportProgress(float64(end) / float64(len(updatedJobs)))
+	}
+
+	// Runs of jobs not updated, i.e., already replayed.
+	var remainingRuns []database.Run
+	for _, run := range updatedRuns {
+		if _, ok := runsByJobId[run.JobID]; ok {
+			remainingRuns = append(remainingRuns, run)
+		}
+	}
+	if err := s.reconcileIntoTxn(txn, nil, remainingRuns); err != nil {
+		return err
+	}
+	reportProgress(1)
+	return nil
+}
+
+func (s *Scheduler) reconcileIntoTxn(txn *jobdb.Txn, updatedJobs []database.Job, updatedRuns []database.Run) error {
+	if len(updatedJobs) == 0 && len(updatedRuns) == 0 {
+		return nil
+	}
+	jsts, err := s.jobDb.ReconcileDifferences(txn, updatedJobs, updatedRuns)
+	if err != nil {
+		return err
+	}
+	jobs := make([]*jobdb.Job, 0, len(jsts))
+	idsOfJobsToDelete := make([]string, 0)
+	for _, jst := range jsts {
+		if jst.Job == nil {
+			continue
+		}
+		jobs = append(jobs, jst.Job)
+		if jst.Job.InTerminalState() {
+			idsOfJobsToDelete = append(idsOfJobsToDelete, jst.Job.Id())
+		}
+	}
+	if err := txn.Upsert(jobs); err != nil {
+		return err
+	}
+	return txn.BatchDelete(idsOfJobsToDelete)
+}
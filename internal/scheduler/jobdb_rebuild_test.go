@@ -0,0 +1,239 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/armada/permissions"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/common/auth/permission"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// serialAwareJobRepository is a testJobRepository that only returns updates after the serials provided,
+// as the postgres job repository does.
+type serialAwareJobRepository struct {
+	*testJobRepository
+}
+
+func (r *serialAwareJobRepository) FetchJobUpdates(ctx *armadacontext.Context, jobSerial int64, jobRunSerial int64) ([]database.Job, []database.Run, error) {
+	updatedJobs, updatedRuns, err := r.testJobRepository.FetchJobUpdates(ctx, jobSerial, jobRunSerial)
+	if err != nil {
+		return nil, nil, err
+	}
+	var jobs []database.Job
+	for _, job := range updatedJobs {
+		if job.Serial > jobSerial {
+			jobs = append(jobs, job)
+		}
+	}
+	var runs []database.Run
+	for _, run := range updatedRuns {
+		if run.Serial > jobRunSerial {
+			runs = append(runs, run)
+		}
+	}
+	return jobs, runs, nil
+}
+
+func TestJobDbRebuilder_RebuildJobDb(t *testing.T) {
+	rebuilder := NewJobDbRebuilder(testRebuildJobDbPermissionChecker())
+
+	_, err := rebuilder.RebuildJobDb(rebuildJobDbContext("mallory"), &schedulerobjects.RebuildJobDbRequest{})
+	var unauthorizedErr *armadaerrors.ErrUnauthorized
+	assert.ErrorAs(t, err, &unauthorizedErr)
+	_, requested := rebuilder.state()
+	assert.False(t, requested)
+
+	response, err := rebuilder.RebuildJobDb(rebuildJobDbContext("alice"), &schedulerobjects.RebuildJobDbRequest{Reason: "suspected corruption"})
+	require.NoError(t, err)
+	assert.False(t, response.AlreadyInProgress)
+	_, requested = rebuilder.state()
+	assert.True(t, requested)
+
+	// Proxied on behalf of another principal.
+	ctx := metadata.NewIncomingContext(rebuildJobDbContext("alice"), metadata.Pairs(forwardedPrincipalMetadataKey, "bob"))
+	response, err = rebuilder.RebuildJobDb(ctx, &schedulerobjects.RebuildJobDbRequest{})
+	require.NoError(t, err)
+	assert.True(t, response.AlreadyInProgress)
+}
+
+func TestScheduler_RebuildJobDb(t *testing.T) {
+	queued := urgentSchedulingTestJob(util.NewULID(), testfixtures.PriorityClass0, 1)
+	running := urgentSchedulingTestJob(util.NewULID(), testfixtures.PriorityClass0, 2)
+	running.Queued = false
+	runningRun := database.Run{
+		RunID:    uuid.New(),
+		JobID:    running.JobID,
+		JobSet:   running.JobSet,
+		Executor: "testExecutor",
+		Node:     "test-node",
+		Running:  true,
+		Serial:   1,
+	}
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	publisher := &recordingPublisher{}
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+	}, prometheus.NewRegistry())
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&serialAwareJobRepository{&testJobRepository{
+			updatedJobs:           []database.Job{queued, running},
+			updatedRuns:           []database.Run{runningRun},
+			numReceivedPartitions: 100,
+		}},
+		&testExecutorRepository{},
+		&testSchedulingAlgo{jobsToSchedule: []string{queued.JobID}},
+		NewStandaloneLeaderController(),
+		publisher,
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		metrics,
+		nil,
+	)
+	require.NoError(t, err)
+	rebuilder := NewJobDbRebuilder(testRebuildJobDbPermissionChecker())
+	sched.EnableJobDbRebuilds(rebuilder)
+	leaderToken := sched.leaderController.GetToken()
+
+	// Load the jobs, then corrupt the jobDb by losing the queued job and changing the priority of the running job.
+	_, err = sched.cycle(ctx, false, leaderToken, false)
+	require.NoError(t, err)
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.BatchDelete([]string{queued.JobID}))
+	require.NoError(t, txn.Upsert([]*jobdb.Job{txn.GetById(running.JobID).WithPriority(7)}))
+	txn.Commit()
+
+	_, err = rebuilder.RebuildJobDb(rebuildJobDbContext("alice"), &schedulerobjects.RebuildJobDbRequest{})
+	require.NoError(t, err)
+
+	// The rebuild is started and scheduling paused until it's swapped in.
+	_, err = sched.cycle(ctx, false, leaderToken, true)
+	require.NoError(t, err)
+	rebuild, _ := rebuilder.state()
+	require.NotNil(t, rebuild)
+	<-rebuild.done
+	require.NoError(t, rebuild.err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.jobDbRebuildProgress))
+	assert.Nil(t, sched.jobDb.ReadTxn().GetById(queued.JobID))
+
+	// The rebuilt jobDb is swapped in; scheduling is still paused for this cycle.
+	_, err = sched.cycle(ctx, false, leaderToken, true)
+	require.NoError(t, err)
+	rebuild, requested := rebuilder.state()
+	assert.Nil(t, rebuild)
+	assert.False(t, requested)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.jobDbRebuilds.WithLabelValues("swapped")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.jobDbRebuildProgress))
+	rebuilt := sched.jobDb.ReadTxn()
+	require.NotNil(t, rebuilt.GetById(queued.JobID))
+	assert.True(t, rebuilt.GetById(queued.JobID).Queued())
+	assert.Equal(t, uint32(0), rebuilt.GetById(running.JobID).Priority())
+	assert.Equal(t, runningRun.RunID, rebuilt.GetById(running.JobID).LatestRun().Id())
+	assert.Equal(t, running.Serial, sched.jobsSerial)
+	assert.Equal(t, runningRun.Serial, sched.runsSerial)
+	assert.Empty(t, publishedEvents(publisher), "nothing should be published while the jobDb is rebuilt and swapped in")
+
+	// Scheduling resumes, leasing the queued job exactly once.
+	_, err = sched.cycle(ctx, false, leaderToken, true)
+	require.NoError(t, err)
+	numLeasesByJobId := make(map[string]int)
+	for _, event := range publishedEvents(publisher) {
+		if leased := event.GetJobRunLeased(); leased != nil {
+			jobId, err := armadaevents.UlidStringFromProtoUuid(leased.JobId)
+			require.NoError(t, err)
+			numLeasesByJobId[jobId]++
+		}
+	}
+	assert.Equal(t, map[string]int{queued.JobID: 1}, numLeasesByJobId)
+}
+
+func TestScheduler_RebuildJobDbAbandonedWhenNotLeader(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+	}, prometheus.NewRegistry())
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&serialAwareJobRepository{&testJobRepository{
+			updatedJobs: []database.Job{urgentSchedulingTestJob(util.NewULID(), testfixtures.PriorityClass0, 1)},
+		}},
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&recordingPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		metrics,
+		nil,
+	)
+	require.NoError(t, err)
+	rebuilder := NewJobDbRebuilder(testRebuildJobDbPermissionChecker())
+	sched.EnableJobDbRebuilds(rebuilder)
+	_, err = rebuilder.RebuildJobDb(rebuildJobDbContext("alice"), &schedulerobjects.RebuildJobDbRequest{})
+	require.NoError(t, err)
+
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+	rebuild, _ := rebuilder.state()
+	require.NotNil(t, rebuild)
+
+	_, err = sched.cycle(ctx, false, InvalidLeaderToken(), true)
+	require.NoError(t, err)
+	rebuild, requested := rebuilder.state()
+	assert.Nil(t, rebuild)
+	assert.False(t, requested)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.jobDbRebuilds.WithLabelValues("abandoned")))
+}
+
+// publishedEvents returns all events published to publisher so far.
+func publishedEvents(publisher *recordingPublisher) []*armadaevents.EventSequence_Event {
+	var events []*armadaevents.EventSequence_Event
+	for _, batch := range publisher.publishedBatches {
+		for _, sequence := range batch {
+			events = append(events, sequence.Events...)
+		}
+	}
+	return events
+}
+
+func rebuildJobDbContext(name string) context.Context {
+	return authorization.WithPrincipal(context.Background(), authorization.NewStaticPrincipal(name, []string{name}))
+}
+
+func testRebuildJobDbPermissionChecker() authorization.PermissionChecker {
+	return authorization.NewPrincipalPermissionChecker(
+		map[permission.Permission][]string{permissions.RebuildJobDb: {"alice"}},
+		nil,
+		nil,
+	)
+}
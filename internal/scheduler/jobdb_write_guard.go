@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// ErrIllegalJobDbWrites is returned by cycles aborted since the scheduling algorithm wrote jobs to the jobDb
+// it wasn't allowed to; see EnableJobDbWriteGuard.
+var ErrIllegalJobDbWrites = errors.New("scheduling algorithm wrote jobs it wasn't allowed to")
+
+// PreemptionCandidateDeclarer is implemented by scheduling algorithms that declare which jobs other than queued jobs
+// a scheduling round may write to the jobDb, e.g., to preempt them. Algorithms that don't may only write queued jobs.
+type PreemptionCandidateDeclarer interface {
+	// PreemptionCandidates returns the ids of the jobs, other than queued jobs,
+	// that a scheduling round run on txn may write to it.
+	PreemptionCandidates(txn *jobdb.Txn) []string
+}
+
+// PreemptionCandidates returns the ids of the jobs with an active run, all of which the algorithm may preempt.
+func (l *FairSchedulingAlgo) PreemptionCandidates(txn *jobdb.Txn) []string {
+	var rv []string
+	for _, job := range txn.GetAll() {
+		if job.InTerminalState() {
+			continue
+		}
+		if run := job.LatestRun(); run != nil && !run.InTerminalState() {
+			rv = append(rv, job.Id())
+		}
+	}
+	return rv
+}
+
+// EnableJobDbWriteGuard causes each scheduling round to record the jobs the scheduling algorithm may write to the jobDb,
+// i.e., queued jobs and any preemption candidates it declares; see PreemptionCandidateDeclarer. If the algorithm writes
+// any other job, the cycle is aborted with an error listing these jobs rather than committing changes to jobs
+// the algorithm had no reason to touch.
+func (s *Scheduler) EnableJobDbWriteGuard() {
+	s.jobDbWriteGuard = true
+}
+
+// jobDbWriteGuard records the jobs a scheduling round may write and checks the jobs it wrote against these.
+type jobDbWriteGuard struct {
+	allowedJobIds map[string]bool
+	// Jobs as of the start of the round, by id, to describe how jobs written illegally changed.
+	jobsById map[string]*jobdb.Job
+}
+
+// guardJobDbWrites returns a guard recording the jobs the scheduling algorithm may write to txn and starts tracking
+// the jobs written to txn, or nil if the guard isn't enabled.
+func (s *Scheduler) guardJobDbWrites(txn *jobdb.Txn) *jobDbWriteGuard {
+	if !s.jobDbWriteGuard {
+		return nil
+	}
+	allowedJobIds := make(map[string]bool)
+	jobsById := make(map[string]*jobdb.Job)
+	for _, job := range txn.GetAll() {
+		jobsById[job.Id()] = job
+		if job.Queued() {
+			allowedJobIds[job.Id()] = true
+		}
+	}
+	if declarer, ok := s.schedulingAlgo.(PreemptionCandidateDeclarer); ok {
+		for _, jobId := range declarer.PreemptionCandidates(txn) {
+			allowedJobIds[jobId] = true
+		}
+	}
+	txn.TrackDirtyJobIds()
+	return &jobDbWriteGuard{allowedJobIds: allowedJobIds, jobsById: jobsById}
+}
+
+// check returns an error wrapping ErrIllegalJobDbWrites if jobs were written to txn since the guard was created
+// that the scheduling algorithm wasn't allowed to write.
+func (guard *jobDbWriteGuard) check(s *Scheduler, txn *jobdb.Txn) error {
+	if guard == nil {
+		return nil
+	}
+	illegal := setDifference(txn.DirtyJobIds(), guard.allowedJobIds)
+	if len(illegal) == 0 {
+		return nil
+	}
+	s.metrics.ReportJobDbWriteGuardAbort(len(illegal))
+	var diffs []string
+	for _, jobId := range truncatedJobIds(illegal) {
+		diffs = append(diffs, jobDbWriteDiff(guard.jobsById[jobId], txn.GetById(jobId), jobId))
+	}
+	return errors.Wrapf(
+		ErrIllegalJobDbWrites,
+		"%d of %d jobs written aren't queued or declared preemption candidates: %v",
+		len(illegal), len(txn.DirtyJobIds()), diffs,
+	)
+}
+
+// jobDbWriteDiff describes how the job with the given id changed from before to after, which are nil if the job
+// didn't exist before or was deleted respectively.
+func jobDbWriteDiff(before, after *jobdb.Job, jobId string) string {
+	switch {
+	case after == nil:
+		return jobId + ": deleted"
+	case before == nil:
+		return jobId + ": inserted"
+	}
+	var changes []string
+	addChange := func(field string, before, after any) {
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s %v -> %v", field, before, after))
+		}
+	}
+	addChange("queued", before.Queued(), after.Queued())
+	addChange("queuedVersion", before.QueuedVersion(), after.QueuedVersion())
+	addChange("priority", before.Priority(), after.Priority())
+	addChange("cancelled", before.Cancelled(), after.Cancelled())
+	addChange("failed", before.Failed(), after.Failed())
+	addChange("succeeded", before.Succeeded(), after.Succeeded())
+	addChange("numRuns", len(before.AllRuns()), len(after.AllRuns()))
+	if beforeRun, afterRun := before.LatestRun(), after.LatestRun(); beforeRun != nil && afterRun != nil && beforeRun.Id() == afterRun.Id() {
+		addChange("latestRun.failed", beforeRun.Failed(), afterRun.Failed())
+		addChange("latestRun.cancelled", beforeRun.Cancelled(), afterRun.Cancelled())
+		addChange("latestRun.returned", beforeRun.Returned(), afterRun.Returned())
+	}
+	if len(changes) == 0 {
+		return jobId + ": rewritten unchanged"
+	}
+	return jobId + ": " + strings.Join(changes, ", ")
+}
@@ -0,0 +1,150 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+// jobTouchingSchedulingAlgo is a testSchedulingAlgo that additionally reprioritises the jobs in jobsToTouch and
+// declares the jobs it's asked to preempt, and those in declared, as preemption candidates.
+type jobTouchingSchedulingAlgo struct {
+	testSchedulingAlgo
+	jobsToTouch []string
+	declared    []string
+}
+
+func (a *jobTouchingSchedulingAlgo) Schedule(ctx *armadacontext.Context, txn *jobdb.Txn) (*SchedulerResult, error) {
+	result, err := a.testSchedulingAlgo.Schedule(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	for _, jobId := range a.jobsToTouch {
+		job := txn.GetById(jobId)
+		if err := txn.Upsert([]*jobdb.Job{job.WithPriority(job.Priority() + 1)}); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (a *jobTouchingSchedulingAlgo) PreemptionCandidates(*jobdb.Txn) []string {
+	return append(append([]string{}, a.jobsToPreempt...), a.declared...)
+}
+
+func TestScheduler_JobDbWriteGuard(t *testing.T) {
+	queued := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 1)[0].WithQueued(true)
+	running := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 2)
+	for i, job := range running {
+		running[i] = job.WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+	}
+	tests := map[string]struct {
+		guardDisabled bool
+		jobsToPreempt []string
+		jobsToTouch   []string
+		declared      []string
+		expectAbort   bool
+	}{
+		"queued jobs and declared preemption candidates": {
+			jobsToPreempt: []string{running[0].Id()},
+		},
+		"declared job touched": {
+			jobsToTouch: []string{running[1].Id()},
+			declared:    []string{running[1].Id()},
+		},
+		"undeclared job touched": {
+			jobsToPreempt: []string{running[0].Id()},
+			jobsToTouch:   []string{running[1].Id()},
+			expectAbort:   true,
+		},
+		"undeclared job touched with the guard disabled": {
+			guardDisabled: true,
+			jobsToTouch:   []string{running[1].Id()},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+			schedulingAlgo := &jobTouchingSchedulingAlgo{
+				testSchedulingAlgo: testSchedulingAlgo{jobsToSchedule: []string{queued.Id()}, jobsToPreempt: tc.jobsToPreempt},
+				jobsToTouch:        tc.jobsToTouch,
+				declared:           tc.declared,
+			}
+			publisher := &testPublisher{}
+			metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+				ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+				ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+			}, prometheus.NewRegistry())
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				&testJobRepository{},
+				&testExecutorRepository{},
+				schedulingAlgo,
+				NewStandaloneLeaderController(),
+				publisher,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				metrics,
+				nil,
+			)
+			require.NoError(t, err)
+			if !tc.guardDisabled {
+				sched.EnableJobDbWriteGuard()
+			}
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert(append([]*jobdb.Job{queued}, running...)))
+			txn.Commit()
+
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+			if tc.expectAbort {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrIllegalJobDbWrites))
+				assert.Contains(t, err.Error(), "1 of 3 jobs written")
+				assert.Contains(t, err.Error(), fmt.Sprintf("%s: priority %d -> %d", running[1].Id(), running[1].Priority(), running[1].Priority()+1))
+				assert.NotContains(t, err.Error(), running[0].Id())
+				assert.Equal(t, 1.0, testutil.ToFloat64(metrics.illegalJobDbWrites))
+				assert.Equal(t, 1.0, testutil.ToFloat64(metrics.jobDbWriteGuardAborts))
+				// Nothing was published or committed.
+				assert.Empty(t, publisher.events)
+				assert.True(t, sched.jobDb.ReadTxn().GetById(queued.Id()).Queued())
+				assert.Equal(t, running[1].Priority(), sched.jobDb.ReadTxn().GetById(running[1].Id()).Priority())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 0.0, testutil.ToFloat64(metrics.jobDbWriteGuardAborts))
+			assert.NotEmpty(t, publisher.events)
+			assert.False(t, sched.jobDb.ReadTxn().GetById(queued.Id()).Queued())
+		})
+	}
+}
+
+func TestFairSchedulingAlgo_PreemptionCandidates(t *testing.T) {
+	jobs := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 4)
+	queued := jobs[0].WithQueued(true)
+	running := jobs[1].WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+	requeued := jobs[2].WithQueued(true).WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+	requeued = requeued.WithUpdatedRun(requeued.LatestRun().WithFailed(true))
+	succeeded := jobs[3].WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime).WithSucceeded(true)
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{queued, running, requeued, succeeded}))
+
+	algo := &FairSchedulingAlgo{}
+	assert.Equal(t, []string{running.Id()}, algo.PreemptionCandidates(txn))
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/armadaproject/armada/internal/common/util"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
 type JobIterator interface {
@@ -44,14 +46,24 @@ func (it *InMemoryJobIterator) Next() (*schedulercontext.JobSchedulingContext, e
 type InMemoryJobRepository struct {
 	jctxsByQueue map[string][]*schedulercontext.JobSchedulingContext
 	jctxsById    map[string]*schedulercontext.JobSchedulingContext
+	// Orders the jobs of each queue. Defaults to the SchedulingOrderCompare method of the jobs.
+	compare func(a, b interfaces.LegacySchedulerJob) int
 	// Protects the above fields.
 	mu sync.Mutex
 }
 
 func NewInMemoryJobRepository() *InMemoryJobRepository {
+	return NewInMemoryJobRepositoryWithCompare(func(a, b interfaces.LegacySchedulerJob) int {
+		return a.SchedulingOrderCompare(b)
+	})
+}
+
+// NewInMemoryJobRepositoryWithCompare returns a repository that orders the jobs of each queue using compare.
+func NewInMemoryJobRepositoryWithCompare(compare func(a, b interfaces.LegacySchedulerJob) int) *InMemoryJobRepository {
 	return &InMemoryJobRepository{
 		jctxsByQueue: make(map[string][]*schedulercontext.JobSchedulingContext),
 		jctxsById:    make(map[string]*schedulercontext.JobSchedulingContext),
+		compare:      compare,
 	}
 }
 
@@ -73,7 +85,7 @@ func (repo *InMemoryJobRepository) EnqueueMany(jctxs []*schedulercontext.JobSche
 // sortQueue sorts jobs in a specified queue by the order in which they should be scheduled.
 func (repo *InMemoryJobRepository) sortQueue(queue string) {
 	slices.SortFunc(repo.jctxsByQueue[queue], func(a, b *schedulercontext.JobSchedulingContext) bool {
-		return a.Job.SchedulingOrderCompare(b.Job) == -1
+		return repo.compare(a.Job, b.Job) == -1
 	})
 }
 
@@ -217,3 +229,87 @@ func (it *MultiJobsIterator) Next() (*schedulercontext.JobSchedulingContext, err
 		return v, nil
 	}
 }
+
+// InsufficientCapacityUnschedulableReason indicates a job was skipped since no node could have had enough free capacity for it.
+const InsufficientCapacityUnschedulableReason = "no node has enough free capacity"
+
+// CapacityPruningJobsIterator is an iterator over queued jobs that skips jobs that can't fit within a capacity bound,
+// adding each job skipped to the scheduling context as unschedulable.
+// To never skip jobs that could be scheduled, evicted jobs, gang jobs, and jobs that may be scheduled on away nodes,
+// all of which may be bound to nodes at a priority other than their own, are never skipped.
+// Jobs skipped don't count towards the lookback limit of their queue.
+type CapacityPruningJobsIterator struct {
+	sctx          *schedulercontext.SchedulingContext
+	it            JobIterator
+	capacityBound *nodedb.CapacityBound
+	// Reason jobs with a given scheduling key can't fit within capacityBound, or the empty string if they may fit.
+	// May be shared between iterators using the same bound.
+	reasonBySchedulingKey map[schedulerobjects.SchedulingKey]string
+}
+
+func NewCapacityPruningJobsIterator(
+	sctx *schedulercontext.SchedulingContext,
+	it JobIterator,
+	capacityBound *nodedb.CapacityBound,
+	reasonBySchedulingKey map[schedulerobjects.SchedulingKey]string,
+) *CapacityPruningJobsIterator {
+	if reasonBySchedulingKey == nil {
+		reasonBySchedulingKey = make(map[schedulerobjects.SchedulingKey]string)
+	}
+	return &CapacityPruningJobsIterator{
+		sctx:                  sctx,
+		it:                    it,
+		capacityBound:         capacityBound,
+		reasonBySchedulingKey: reasonBySchedulingKey,
+	}
+}
+
+func (it *CapacityPruningJobsIterator) Next() (*schedulercontext.JobSchedulingContext, error) {
+	for {
+		jctx, err := it.it.Next()
+		if err != nil {
+			return nil, err
+		} else if jctx == nil {
+			return nil, nil
+		}
+		reason := it.capacityPruningReason(jctx)
+		if reason == "" {
+			return jctx, nil
+		}
+		jctx.UnschedulableReason = reason
+		if _, err := it.sctx.AddJobSchedulingContext(jctx); err != nil {
+			return nil, err
+		}
+		it.sctx.QueueSchedulingContexts[jctx.Job.GetQueue()].NumCapacityPrunedJobs++
+	}
+}
+
+// capacityPruningReason returns the reason jctx should be skipped, or the empty string if it shouldn't be.
+func (it *CapacityPruningJobsIterator) capacityPruningReason(jctx *schedulercontext.JobSchedulingContext) string {
+	if jctx.IsEvicted || jctx.GangCardinality > 1 || jctx.PodRequirements == nil {
+		return ""
+	}
+	priorityClass := interfaces.PriorityClassFromLegacySchedulerJob(it.sctx.PriorityClasses, it.sctx.DefaultPriorityClass, jctx.Job)
+	if len(priorityClass.AwayNodeTypes) > 0 {
+		return ""
+	}
+	schedulingKey, ok := jctx.SchedulingKey()
+	ok = ok && schedulingKey != schedulerobjects.EmptySchedulingKey && !jctx.SchedulingKeySkippingDisabled()
+	if ok {
+		if reason, ok := it.reasonBySchedulingKey[schedulingKey]; ok {
+			return reason
+		}
+	}
+	reason := ""
+	if mayFit, notMetReason := it.capacityBound.MayFit(jctx, jctx.PodRequirements.Priority); mayFit {
+		// The job may fit; don't skip it.
+	} else if notMetReason != nil {
+		reason = InsufficientCapacityUnschedulableReason + ": " + notMetReason.String()
+	} else {
+		reason = InsufficientCapacityUnschedulableReason
+	}
+	if ok {
+		it.reasonBySchedulingKey[schedulingKey] = reason
+	}
+	return reason
+}
@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+)
+
+// JobSetPlacementTracker records how spread out the runs of each job set are,
+// i.e., the number of distinct nodes and executors they were placed on and how they're distributed across zones.
+//
+// Stats are kept for a bounded number of job sets, evicting the least recently updated job set first.
+// Stats for a job set are discarded once all its jobs with runs have been terminal for longer than the retention period.
+//
+// The executor the first run of a job set is created on, or that given by the PreferredExecutorAnnotation of a job of
+// the job set, is recorded as the preferred executor of the job set, such that later jobs may be placed on it too.
+// The preference is reset once all jobs of the job set with runs have become terminal.
+type JobSetPlacementTracker struct {
+	// Node label indicating which zone a node is in.
+	zoneLabel string
+	// How long to keep stats for after all jobs with runs in a job set have become terminal.
+	retention time.Duration
+	// Score bonus given to nodes of the preferred executor of a job set when scheduling its jobs.
+	preferredExecutorWeight int
+	// Maps jobSetKey to *JobSetPlacementStats.
+	statsByJobSet *lru.Cache
+	// Protects the stats stored in statsByJobSet.
+	mu sync.Mutex
+}
+
+type jobSetKey struct {
+	queue  string
+	jobSet string
+}
+
+// JobSetPlacementStats are the placement stats for the runs of a single job set.
+type JobSetPlacementStats struct {
+	NumRuns           int
+	NumRunsByNode     map[string]int
+	NumRunsByExecutor map[string]int
+	// Runs on nodes without the zone label are not included.
+	NumRunsByZone map[string]int
+	// Executor on which the job set would preferably be placed, or the empty string if there's none.
+	PreferredExecutor string
+	// Ids of jobs with runs that haven't yet become terminal.
+	activeJobIds map[string]bool
+	// Time at which the last active job became terminal.
+	terminalSince time.Time
+}
+
+func NewJobSetPlacementTracker(config schedulerconfig.JobSetPlacementConfig) (*JobSetPlacementTracker, error) {
+	statsByJobSet, err := lru.New(config.MaxJobSets)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &JobSetPlacementTracker{
+		zoneLabel:               config.ZoneLabel,
+		retention:               config.Retention,
+		preferredExecutorWeight: config.PreferredExecutorWeight,
+		statsByJobSet:           statsByJobSet,
+	}, nil
+}
+
+// RecordRun records that a run of the given job was created on a node with the given name and labels.
+// If non-empty, preferredExecutor, i.e., the PreferredExecutorAnnotation of the job, becomes the preferred executor
+// of the job set. Otherwise, executor does if the job set has no preferred executor yet.
+func (t *JobSetPlacementTracker) RecordRun(queue, jobSet, jobId, executor, nodeName string, nodeLabels map[string]string, preferredExecutor string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := jobSetKey{queue: queue, jobSet: jobSet}
+	var stats *JobSetPlacementStats
+	if v, ok := t.statsByJobSet.Get(key); ok {
+		stats = v.(*JobSetPlacementStats)
+	} else {
+		stats = &JobSetPlacementStats{
+			NumRunsByNode:     make(map[string]int),
+			NumRunsByExecutor: make(map[string]int),
+			NumRunsByZone:     make(map[string]int),
+			activeJobIds:      make(map[string]bool),
+		}
+		t.statsByJobSet.Add(key, stats)
+	}
+	stats.NumRuns++
+	stats.NumRunsByNode[nodeName]++
+	stats.NumRunsByExecutor[executor]++
+	if zone, ok := nodeLabels[t.zoneLabel]; ok && t.zoneLabel != "" {
+		stats.NumRunsByZone[zone]++
+	}
+	stats.activeJobIds[jobId] = true
+	stats.terminalSince = time.Time{}
+	if preferredExecutor != "" {
+		stats.PreferredExecutor = preferredExecutor
+	} else if stats.PreferredExecutor == "" {
+		stats.PreferredExecutor = executor
+	}
+}
+
+// RecordJobTerminal records that the given job became terminal at time now.
+func (t *JobSetPlacementTracker) RecordJobTerminal(now time.Time, queue, jobSet, jobId string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	v, ok := t.statsByJobSet.Peek(jobSetKey{queue: queue, jobSet: jobSet})
+	if !ok {
+		return
+	}
+	stats := v.(*JobSetPlacementStats)
+	if !stats.activeJobIds[jobId] {
+		return
+	}
+	delete(stats.activeJobIds, jobId)
+	if len(stats.activeJobIds) == 0 {
+		stats.terminalSince = now
+		stats.PreferredExecutor = ""
+	}
+}
+
+// Prune discards stats for job sets for which all jobs have been terminal for longer than the retention period.
+func (t *JobSetPlacementTracker) Prune(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range t.statsByJobSet.Keys() {
+		v, ok := t.statsByJobSet.Peek(key)
+		if !ok {
+			continue
+		}
+		stats := v.(*JobSetPlacementStats)
+		if len(stats.activeJobIds) == 0 && now.Sub(stats.terminalSince) > t.retention {
+			t.statsByJobSet.Remove(key)
+		}
+	}
+}
+
+// Stats returns a copy of the stats for the given job set.
+// The second return value is false if there are no stats for this job set.
+func (t *JobSetPlacementTracker) Stats(queue, jobSet string) (JobSetPlacementStats, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	v, ok := t.statsByJobSet.Peek(jobSetKey{queue: queue, jobSet: jobSet})
+	if !ok {
+		return JobSetPlacementStats{}, false
+	}
+	stats := v.(*JobSetPlacementStats)
+	return JobSetPlacementStats{
+		NumRuns:           stats.NumRuns,
+		NumRunsByNode:     maps.Clone(stats.NumRunsByNode),
+		NumRunsByExecutor: maps.Clone(stats.NumRunsByExecutor),
+		NumRunsByZone:     maps.Clone(stats.NumRunsByZone),
+		PreferredExecutor: stats.PreferredExecutor,
+	}, true
+}
+
+// PreferredExecutor returns the preferred executor of the given job set, or the empty string if it has none.
+func (t *JobSetPlacementTracker) PreferredExecutor(queue, jobSet string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	v, ok := t.statsByJobSet.Peek(jobSetKey{queue: queue, jobSet: jobSet})
+	if !ok {
+		return ""
+	}
+	return v.(*JobSetPlacementStats).PreferredExecutor
+}
+
+func (t *JobSetPlacementTracker) reportString(queue, jobSet string) string {
+	stats, ok := t.Stats(queue, jobSet)
+	if !ok {
+		return fmt.Sprintf("No placement information for job set %s in queue %s\n", jobSet, queue)
+	}
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
+	fmt.Fprintf(w, "Placement of job set %s in queue %s:\n", jobSet, queue)
+	fmt.Fprintf(w, "\tRuns:\t%d\n", stats.NumRuns)
+	fmt.Fprintf(w, "\tDistinct nodes:\t%d\n", len(stats.NumRunsByNode))
+	fmt.Fprintf(w, "\tDistinct executors:\t%d\n", len(stats.NumRunsByExecutor))
+	if stats.PreferredExecutor != "" {
+		fmt.Fprintf(w, "\tPreferred executor:\t%s\n", stats.PreferredExecutor)
+	}
+	if len(stats.NumRunsByZone) > 0 {
+		fmt.Fprint(w, "\tRuns by zone:\n")
+		zones := maps.Keys(stats.NumRunsByZone)
+		slices.Sort(zones)
+		for _, zone := range zones {
+			fmt.Fprintf(w, "\t\t%s:\t%d\n", zone, stats.NumRunsByZone[zone])
+		}
+	}
+	w.Flush()
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+const testZoneLabel = "topology.kubernetes.io/zone"
+
+func TestJobSetPlacementTracker_RecordRun(t *testing.T) {
+	tracker, err := NewJobSetPlacementTracker(testJobSetPlacementConfig(10, time.Hour))
+	require.NoError(t, err)
+
+	zoneA := map[string]string{testZoneLabel: "a"}
+	zoneB := map[string]string{testZoneLabel: "b"}
+	tracker.RecordRun("queue", "jobSet", "job1", "executor1", "node1", zoneA, "")
+	tracker.RecordRun("queue", "jobSet", "job2", "executor1", "node1", zoneA, "")
+	tracker.RecordRun("queue", "jobSet", "job3", "executor1", "node2", zoneB, "")
+	tracker.RecordRun("queue", "jobSet", "job4", "executor2", "node3", zoneB, "")
+	tracker.RecordRun("queue", "jobSet", "job5", "executor2", "node4", nil, "")
+	tracker.RecordRun("queue", "otherJobSet", "job6", "executor2", "node4", zoneA, "")
+	tracker.RecordRun("otherQueue", "jobSet", "job7", "executor2", "node4", zoneA, "")
+
+	stats, ok := tracker.Stats("queue", "jobSet")
+	require.True(t, ok)
+	assert.Equal(t, 5, stats.NumRuns)
+	assert.Equal(t, map[string]int{"node1": 2, "node2": 1, "node3": 1, "node4": 1}, stats.NumRunsByNode)
+	assert.Equal(t, map[string]int{"executor1": 3, "executor2": 2}, stats.NumRunsByExecutor)
+	assert.Equal(t, map[string]int{"a": 2, "b": 2}, stats.NumRunsByZone)
+	assert.Equal(t, "executor1", stats.PreferredExecutor)
+
+	report := tracker.reportString("queue", "jobSet")
+	assert.Contains(t, report, "Placement of job set jobSet in queue queue:")
+	assert.Regexp(t, `Runs:\s+5`, report)
+	assert.Regexp(t, `Distinct nodes:\s+4`, report)
+	assert.Regexp(t, `Distinct executors:\s+2`, report)
+	assert.Regexp(t, `(?s)a:\s+2.*b:\s+2`, report)
+
+	assert.Equal(t, "No placement information for job set missing in queue queue\n", tracker.reportString("queue", "missing"))
+}
+
+func TestJobSetPlacementTracker_EvictsLeastRecentlyUpdated(t *testing.T) {
+	tracker, err := NewJobSetPlacementTracker(testJobSetPlacementConfig(2, time.Hour))
+	require.NoError(t, err)
+
+	tracker.RecordRun("queue", "jobSet1", "job1", "executor", "node", nil, "")
+	tracker.RecordRun("queue", "jobSet2", "job2", "executor", "node", nil, "")
+	tracker.RecordRun("queue", "jobSet1", "job3", "executor", "node", nil, "")
+	tracker.RecordRun("queue", "jobSet3", "job4", "executor", "node", nil, "")
+
+	_, ok := tracker.Stats("queue", "jobSet2")
+	assert.False(t, ok)
+	stats, ok := tracker.Stats("queue", "jobSet1")
+	require.True(t, ok)
+	assert.Equal(t, 2, stats.NumRuns)
+	_, ok = tracker.Stats("queue", "jobSet3")
+	assert.True(t, ok)
+}
+
+func TestJobSetPlacementTracker_PrunesTerminalJobSetsAfterRetention(t *testing.T) {
+	tracker, err := NewJobSetPlacementTracker(testJobSetPlacementConfig(10, time.Hour))
+	require.NoError(t, err)
+	now := time.Now()
+
+	tracker.RecordRun("queue", "jobSet", "job1", "executor", "node", nil, "")
+	tracker.RecordRun("queue", "jobSet", "job2", "executor", "node", nil, "")
+
+	// Not all jobs are terminal, so nothing is pruned.
+	tracker.RecordJobTerminal(now, "queue", "jobSet", "job1")
+	tracker.Prune(now.Add(2 * time.Hour))
+	_, ok := tracker.Stats("queue", "jobSet")
+	assert.True(t, ok)
+
+	// All jobs terminal, but still within the retention period.
+	tracker.RecordJobTerminal(now, "queue", "jobSet", "job2")
+	tracker.Prune(now.Add(time.Minute))
+	_, ok = tracker.Stats("queue", "jobSet")
+	assert.True(t, ok)
+
+	tracker.Prune(now.Add(2 * time.Hour))
+	_, ok = tracker.Stats("queue", "jobSet")
+	assert.False(t, ok)
+}
+
+func TestSchedulingContextRepository_GetJobSetReport(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10)
+	require.NoError(t, err)
+	ctx := armadacontext.Background()
+	request := &schedulerobjects.JobSetReportRequest{Queue: "queue", JobSetId: "jobSet"}
+
+	report, err := repo.GetJobSetReport(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, "Job set placement tracking is disabled\n", report.Report)
+
+	tracker, err := NewJobSetPlacementTracker(testJobSetPlacementConfig(10, time.Hour))
+	require.NoError(t, err)
+	tracker.RecordRun("queue", "jobSet", "job1", "executor", "node", nil, "")
+	repo.EnableJobSetReports(tracker)
+	report, err = repo.GetJobSetReport(ctx, request)
+	require.NoError(t, err)
+	assert.Regexp(t, `Runs:\s+1`, report.Report)
+}
+
+func TestJobSetPlacementTracker_PreferredExecutor(t *testing.T) {
+	tracker, err := NewJobSetPlacementTracker(testJobSetPlacementConfig(10, time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "", tracker.PreferredExecutor("queue", "jobSet"))
+
+	// The executor of the first run becomes the preferred executor.
+	tracker.RecordRun("queue", "jobSet", "job1", "executor1", "node1", nil, "")
+	tracker.RecordRun("queue", "jobSet", "job2", "executor2", "node2", nil, "")
+	assert.Equal(t, "executor1", tracker.PreferredExecutor("queue", "jobSet"))
+
+	// An explicitly preferred executor overrides it.
+	tracker.RecordRun("queue", "jobSet", "job3", "executor2", "node2", nil, "executor3")
+	assert.Equal(t, "executor3", tracker.PreferredExecutor("queue", "jobSet"))
+
+	// The preference is reset once the job set goes terminal.
+	now := time.Now()
+	for _, jobId := range []string{"job1", "job2", "job3"} {
+		tracker.RecordJobTerminal(now, "queue", "jobSet", jobId)
+	}
+	assert.Equal(t, "", tracker.PreferredExecutor("queue", "jobSet"))
+	tracker.RecordRun("queue", "jobSet", "job4", "executor2", "node2", nil, "")
+	assert.Equal(t, "executor2", tracker.PreferredExecutor("queue", "jobSet"))
+}
+
+func testJobSetPlacementConfig(maxJobSets int, retention time.Duration) schedulerconfig.JobSetPlacementConfig {
+	return schedulerconfig.JobSetPlacementConfig{
+		Enabled:    true,
+		ZoneLabel:  testZoneLabel,
+		MaxJobSets: maxJobSets,
+		Retention:  retention,
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/armadaproject/armada/internal/common/util"
 )
 
+// AddNodeAntiAffinity ensures nodes with label labelName set to labelValue are avoided, by adding labelValue to a
+// NotIn expression for labelName in each required node selector term not already avoiding such nodes.
 func AddNodeAntiAffinity(affinity *v1.Affinity, labelName string, labelValue string) error {
 	if affinity == nil {
 		return errors.Errorf("failed to add not anti affinity, as provided affinity is nil")
@@ -41,6 +43,10 @@ func addAvoidNodeAffinityToNodeSelectorTerms(terms []v1.NodeSelectorTerm, labelN
 }
 
 func addAvoidNodeAffinityToNodeSelectorTerm(term *v1.NodeSelectorTerm, labelName string, labelValue string) {
+	if termAvoids(term, labelName, labelValue) {
+		// Avoid adding duplicates, e.g., when a job fails on the same node several times.
+		return
+	}
 	mexp := findMatchExpression(term.MatchExpressions, labelName, v1.NodeSelectorOpNotIn)
 	if mexp == nil {
 		term.MatchExpressions = append(term.MatchExpressions, v1.NodeSelectorRequirement{
@@ -64,3 +70,107 @@ func findMatchExpression(matchExpressions []v1.NodeSelectorRequirement, key stri
 	}
 	return nil
 }
+
+// ListNodeAntiAffinityValues returns the values of labelName nodes are avoided for, in the order they first appear.
+// Since a node matches the required node affinity if it matches any node selector term, a value is only included
+// if every term contains a NotIn expression for labelName including it.
+func ListNodeAntiAffinityValues(affinity *v1.Affinity, labelName string) []string {
+	terms := requiredNodeSelectorTerms(affinity)
+	if len(terms) == 0 {
+		return nil
+	}
+	var values []string
+	for _, mexp := range terms[0].MatchExpressions {
+		if mexp.Key != labelName || mexp.Operator != v1.NodeSelectorOpNotIn {
+			continue
+		}
+		for _, value := range mexp.Values {
+			if !util.ContainsString(values, value) && HasNodeAntiAffinity(affinity, labelName, value) {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// HasNodeAntiAffinity returns true if nodes with label labelName set to labelValue are avoided,
+// i.e., if every required node selector term contains a NotIn expression for labelName including labelValue.
+func HasNodeAntiAffinity(affinity *v1.Affinity, labelName string, labelValue string) bool {
+	terms := requiredNodeSelectorTerms(affinity)
+	if len(terms) == 0 {
+		return false
+	}
+	for i := range terms {
+		if !termAvoids(&terms[i], labelName, labelValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoveNodeAntiAffinity removes labelValue from all NotIn expressions for labelName in the required node selector
+// terms of affinity and returns true if any expression was changed. Expressions left without values are removed.
+// A term left without expressions or fields would match all nodes, but an empty term matches no nodes.
+// Hence, the required node selector is removed in that case, since all nodes match it; structs left empty are set to nil.
+func RemoveNodeAntiAffinity(affinity *v1.Affinity, labelName string, labelValue string) bool {
+	terms := requiredNodeSelectorTerms(affinity)
+	removed := false
+	matchesAllNodes := false
+	for i := range terms {
+		term := &terms[i]
+		if !termAvoids(term, labelName, labelValue) {
+			continue
+		}
+		removed = true
+		matchExpressions := make([]v1.NodeSelectorRequirement, 0, len(term.MatchExpressions))
+		for _, mexp := range term.MatchExpressions {
+			if mexp.Key == labelName && mexp.Operator == v1.NodeSelectorOpNotIn {
+				mexp.Values = removeString(mexp.Values, labelValue)
+				if len(mexp.Values) == 0 {
+					continue
+				}
+			}
+			matchExpressions = append(matchExpressions, mexp)
+		}
+		if len(matchExpressions) == 0 {
+			matchExpressions = nil
+			matchesAllNodes = matchesAllNodes || len(term.MatchFields) == 0
+		}
+		term.MatchExpressions = matchExpressions
+	}
+	if matchesAllNodes {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = nil
+		if affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution == nil {
+			affinity.NodeAffinity = nil
+		}
+	}
+	return removed
+}
+
+func requiredNodeSelectorTerms(affinity *v1.Affinity) []v1.NodeSelectorTerm {
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+	return affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+}
+
+// termAvoids returns true if any NotIn expression for labelName in term includes labelValue.
+func termAvoids(term *v1.NodeSelectorTerm, labelName string, labelValue string) bool {
+	for _, mexp := range term.MatchExpressions {
+		if mexp.Key == labelName && mexp.Operator == v1.NodeSelectorOpNotIn && util.ContainsString(mexp.Values, labelValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeString returns a copy of list with all occurrences of val removed.
+func removeString(list []string, val string) []string {
+	rv := make([]string, 0, len(list))
+	for _, elem := range list {
+		if elem != val {
+			rv = append(rv, elem)
+		}
+	}
+	return rv
+}
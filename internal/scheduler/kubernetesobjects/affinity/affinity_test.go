@@ -57,6 +57,293 @@ func TestAddNodeAntiAffinity_WhenDifferentLabelAlreadyThere_IncludesBothLabels(t
 	assert.Equal(t, expected, affinity)
 }
 
+func TestAddNodeAntiAffinity_WhenValueInLaterDuplicateExpression_DoesNothing(t *testing.T) {
+	affinity := affinityWithTerms(
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b"), notIn("a", "c")}},
+	)
+	expected := affinityWithTerms(
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b"), notIn("a", "c")}},
+	)
+
+	err := AddNodeAntiAffinity(affinity, "a", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, affinity)
+}
+
+func TestAddNodeAntiAffinity_WhenRepeated_DoesNotAddDuplicates(t *testing.T) {
+	affinity := &v1.Affinity{}
+	for i := 0; i < 3; i++ {
+		err := AddNodeAntiAffinity(affinity, "a", "b")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, vanillaAvoidLabelAffinity("a", "b"), affinity)
+}
+
+func TestAddNodeAntiAffinity_WhenMultipleTerms_AddsOnlyToTermsMissingValue(t *testing.T) {
+	affinity := affinityWithTerms(
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b")}},
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}},
+	)
+	expected := affinityWithTerms(
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b")}},
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1"), notIn("a", "b")}},
+	)
+
+	err := AddNodeAntiAffinity(affinity, "a", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, affinity)
+}
+
+func TestHasNodeAntiAffinity(t *testing.T) {
+	tests := map[string]struct {
+		affinity *v1.Affinity
+		expected bool
+	}{
+		"nil affinity": {
+			affinity: nil,
+		},
+		"nil node affinity": {
+			affinity: &v1.Affinity{},
+		},
+		"nil required node selector": {
+			affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{}},
+		},
+		"no terms": {
+			affinity: affinityWithTerms(),
+		},
+		"empty term": {
+			affinity: affinityWithTerms(v1.NodeSelectorTerm{}),
+		},
+		"single term": {
+			affinity: vanillaAvoidLabelAffinity("a", "b"),
+			expected: true,
+		},
+		"other value": {
+			affinity: vanillaAvoidLabelAffinity("a", "c"),
+		},
+		"other key": {
+			affinity: vanillaAvoidLabelAffinity("aa", "b"),
+		},
+		"In rather than NotIn": {
+			affinity: affinityWithTerms(v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("a", "b")}}),
+		},
+		"value in later duplicate expression": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "c"), notIn("a", "b")}},
+			),
+			expected: true,
+		},
+		"value in all terms": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b")}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1"), notIn("a", "c", "b")}},
+			),
+			expected: true,
+		},
+		"value missing from one term": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b")}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}},
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, HasNodeAntiAffinity(tc.affinity, "a", "b"))
+		})
+	}
+}
+
+func TestListNodeAntiAffinityValues(t *testing.T) {
+	tests := map[string]struct {
+		affinity *v1.Affinity
+		expected []string
+	}{
+		"nil affinity": {
+			affinity: nil,
+		},
+		"nil node affinity": {
+			affinity: &v1.Affinity{},
+		},
+		"nil required node selector": {
+			affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{}},
+		},
+		"no terms": {
+			affinity: affinityWithTerms(),
+		},
+		"single expression": {
+			affinity: affinityWithTerms(v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b", "c")}}),
+			expected: []string{"b", "c"},
+		},
+		"duplicate expressions and values": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b", "b"), in("a", "x"), notIn("aa", "y"), notIn("a", "c", "b")}},
+			),
+			expected: []string{"b", "c"},
+		},
+		"only values in all terms": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b", "c", "d")}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "d"), notIn("a", "b")}},
+			),
+			expected: []string{"b", "d"},
+		},
+		"term without expressions for key": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b")}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}},
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ListNodeAntiAffinityValues(tc.affinity, "a"))
+		})
+	}
+}
+
+func TestRemoveNodeAntiAffinity(t *testing.T) {
+	tests := map[string]struct {
+		affinity        *v1.Affinity
+		expected        *v1.Affinity
+		expectedRemoved bool
+	}{
+		"nil affinity": {
+			affinity: nil,
+			expected: nil,
+		},
+		"nil node affinity": {
+			affinity: &v1.Affinity{},
+			expected: &v1.Affinity{},
+		},
+		"nil required node selector": {
+			affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{}},
+			expected: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{}},
+		},
+		"value not present": {
+			affinity: vanillaAvoidLabelAffinity("a", "c"),
+			expected: vanillaAvoidLabelAffinity("a", "c"),
+		},
+		"In expression left unchanged": {
+			affinity: affinityWithTerms(v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("a", "b")}}),
+			expected: affinityWithTerms(v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("a", "b")}}),
+		},
+		"other values kept": {
+			affinity:        affinityWithTerms(v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "c", "b", "d", "b")}}),
+			expected:        affinityWithTerms(v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "c", "d")}}),
+			expectedRemoved: true,
+		},
+		"removed from duplicate expressions": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b"), in("zone", "z1"), notIn("a", "c", "b")}},
+			),
+			expected: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1"), notIn("a", "c")}},
+			),
+			expectedRemoved: true,
+		},
+		"removed from multiple terms": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1"), notIn("a", "b")}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z2")}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z3"), notIn("a", "b", "c")}},
+			),
+			expected: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z2")}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z3"), notIn("a", "c")}},
+			),
+			expectedRemoved: true,
+		},
+		"term left with match fields kept": {
+			affinity: affinityWithTerms(
+				v1.NodeSelectorTerm{
+					MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b")},
+					MatchFields:      []v1.NodeSelectorRequirement{in("metadata.name", "node")},
+				},
+			),
+			expected: affinityWithTerms(
+				v1.NodeSelectorTerm{MatchFields: []v1.NodeSelectorRequirement{in("metadata.name", "node")}},
+			),
+			expectedRemoved: true,
+		},
+		"only anti-affinity removes node affinity": {
+			affinity:        vanillaAvoidLabelAffinity("a", "b"),
+			expected:        &v1.Affinity{},
+			expectedRemoved: true,
+		},
+		"term left empty removes required node selector": {
+			affinity: &v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{
+							{MatchExpressions: []v1.NodeSelectorRequirement{notIn("a", "b")}},
+							{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}},
+						},
+					},
+					PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{
+						{Weight: 1, Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}}},
+					},
+				},
+				PodAntiAffinity: &v1.PodAntiAffinity{},
+			},
+			expected: &v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{
+						{Weight: 1, Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}}},
+					},
+				},
+				PodAntiAffinity: &v1.PodAntiAffinity{},
+			},
+			expectedRemoved: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedRemoved, RemoveNodeAntiAffinity(tc.affinity, "a", "b"))
+			assert.Equal(t, tc.expected, tc.affinity)
+			assert.False(t, HasNodeAntiAffinity(tc.affinity, "a", "b"))
+		})
+	}
+}
+
+func TestRemoveNodeAntiAffinity_UndoesAddNodeAntiAffinity(t *testing.T) {
+	affinity := affinityWithTerms(
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}},
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z2"), notIn("a", "c")}},
+	)
+	expected := affinityWithTerms(
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z1")}},
+		v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{in("zone", "z2"), notIn("a", "c")}},
+	)
+
+	err := AddNodeAntiAffinity(affinity, "a", "b")
+	assert.NoError(t, err)
+	assert.True(t, HasNodeAntiAffinity(affinity, "a", "b"))
+	assert.Equal(t, []string{"b"}, ListNodeAntiAffinityValues(affinity, "a"))
+
+	assert.True(t, RemoveNodeAntiAffinity(affinity, "a", "b"))
+	assert.Equal(t, expected, affinity)
+}
+
+func affinityWithTerms(terms ...v1.NodeSelectorTerm) *v1.Affinity {
+	return &v1.Affinity{
+		NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: terms,
+			},
+		},
+	}
+}
+
+func notIn(key string, values ...string) v1.NodeSelectorRequirement {
+	return v1.NodeSelectorRequirement{Key: key, Operator: v1.NodeSelectorOpNotIn, Values: values}
+}
+
+func in(key string, values ...string) v1.NodeSelectorRequirement {
+	return v1.NodeSelectorRequirement{Key: key, Operator: v1.NodeSelectorOpIn, Values: values}
+}
+
 func vanillaAvoidLabelAffinity(key string, val string) *v1.Affinity {
 	return vanillaAvoidLabelAffinites([]*api.StringKeyValuePair{{Key: key, Value: val}})
 }
@@ -12,12 +12,14 @@ import (
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
 	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 )
 
 // LeaderController is an interface to be implemented by structs that control which scheduler is leader
 type LeaderController interface {
-	// GetToken returns a LeaderToken which allows you to determine if you are leader or not
+	// GetToken returns a LeaderToken which allows you to determine if you are leader or not,
+	// and the leadership epoch of the leader if you are; see LeaderToken.Epoch.
 	GetToken() LeaderToken
 	// ValidateToken allows a caller to determine whether a previously obtained token is still valid.
 	// Returns true if the token is a leader and false otherwise
@@ -37,6 +39,14 @@ type LeaderReport struct {
 type LeaderToken struct {
 	leader bool
 	id     uuid.UUID
+	epoch  uint64
+}
+
+// Epoch returns the leadership epoch of the token, which increases each time a different replica becomes leader,
+// or zero if the epoch is unknown or this instance isn't leader.
+// Published events are stamped with the epoch, such that consumers can discard events published by a previous leader.
+func (t LeaderToken) Epoch() uint64 {
+	return t.epoch
 }
 
 // InvalidLeaderToken returns a LeaderToken indicating this instance is not leader.
@@ -49,14 +59,34 @@ func InvalidLeaderToken() LeaderToken {
 
 // NewLeaderToken returns a LeaderToken indicating this instance is the leader.
 func NewLeaderToken() LeaderToken {
+	return NewLeaderTokenWithEpoch(0)
+}
+
+// NewLeaderTokenWithEpoch returns a LeaderToken indicating this instance is the leader with the given leadership epoch.
+func NewLeaderTokenWithEpoch(epoch uint64) LeaderToken {
 	return LeaderToken{
 		leader: true,
 		id:     uuid.New(),
+		epoch:  epoch,
 	}
 }
 
+type leaderEpochKey struct{}
+
+// withLeaderEpoch returns a copy of ctx associated with the leadership epoch events published with it are stamped with.
+func withLeaderEpoch(ctx *armadacontext.Context, epoch uint64) *armadacontext.Context {
+	return armadacontext.WithValue(ctx, leaderEpochKey{}, epoch)
+}
+
+// leaderEpochFromContext returns the leadership epoch associated with ctx, or zero if there's none.
+func leaderEpochFromContext(ctx *armadacontext.Context) uint64 {
+	epoch, _ := ctx.Value(leaderEpochKey{}).(uint64)
+	return epoch
+}
+
 // StandaloneLeaderController returns a token that always indicates you are leader
-// This can be used when only a single instance of the scheduler is needed
+// This can be used when only a single instance of the scheduler is needed.
+// Since there's never another leader, the epoch of the token is zero, i.e., published events aren't stamped with an epoch.
 type StandaloneLeaderController struct {
 	token LeaderToken
 }
@@ -89,6 +119,32 @@ func (lc *StandaloneLeaderController) Run(ctx *armadacontext.Context) error {
 	return nil
 }
 
+// ObserverLeaderController returns a token that always indicates you are not leader.
+// This is used by observer replicas, which must never become leader.
+type ObserverLeaderController struct{}
+
+func NewObserverLeaderController() *ObserverLeaderController {
+	return &ObserverLeaderController{}
+}
+
+func (lc *ObserverLeaderController) GetToken() LeaderToken {
+	return InvalidLeaderToken()
+}
+
+func (lc *ObserverLeaderController) GetLeaderReport() LeaderReport {
+	return LeaderReport{
+		IsCurrentProcessLeader: false,
+	}
+}
+
+func (lc *ObserverLeaderController) ValidateToken(tok LeaderToken) bool {
+	return false
+}
+
+func (lc *ObserverLeaderController) Run(ctx *armadacontext.Context) error {
+	return nil
+}
+
 // LeaseListener allows clients to listen for lease events.
 type LeaseListener interface {
 	// Called when the client has started leading.
@@ -99,6 +155,7 @@ type LeaseListener interface {
 
 // KubernetesLeaderController uses the Kubernetes leader election mechanism to determine who is leader.
 // This allows multiple instances of the scheduler to be run for high availability.
+// The leadership epoch is one plus the number of times the lease changed holder, as of when this instance became leader.
 //
 // TODO: Move into package in common.
 type KubernetesLeaderController struct {
@@ -154,8 +211,9 @@ func (lc *KubernetesLeaderController) Run(ctx *armadacontext.Context) error {
 				RetryPeriod:     lc.config.RetryPeriod,
 				Callbacks: leaderelection.LeaderCallbacks{
 					OnStartedLeading: func(c context.Context) {
-						ctx.Infof("I am now leader")
-						lc.token.Store(NewLeaderToken())
+						epoch := lc.leaseEpoch(ctx, lock)
+						ctx.Infof("I am now leader with epoch %d", epoch)
+						lc.token.Store(NewLeaderTokenWithEpoch(epoch))
 						for _, listener := range lc.listeners {
 							listener.onStartedLeading(ctx)
 						}
@@ -188,6 +246,17 @@ func (lc *KubernetesLeaderController) GetLeaderReport() LeaderReport {
 	}
 }
 
+// leaseEpoch returns the leadership epoch of the holder of lock, or zero if the lease can't be read,
+// in which case events published during this term aren't stamped with an epoch.
+func (lc *KubernetesLeaderController) leaseEpoch(ctx *armadacontext.Context, lock *resourcelock.LeaseLock) uint64 {
+	record, _, err := lock.Get(ctx)
+	if err != nil {
+		logging.WithStacktrace(ctx, err).Warn("failed to read lease; events published as leader won't be stamped with an epoch")
+		return 0
+	}
+	return uint64(record.LeaderTransitions) + 1
+}
+
 // getNewLock returns a resourcelock.LeaseLock which is the resource used for locking when attempting leader election
 func (lc *KubernetesLeaderController) getNewLock() *resourcelock.LeaseLock {
 	return &resourcelock.LeaseLock{
@@ -49,6 +49,20 @@ func (l *LeaderConnectionProvider) GetCurrentLeaderClientConnection() (bool, *gr
 	return false, leaderClient, err
 }
 
+// GetCurrentLeader returns a report about the current leader together with the address at which it can be reached.
+// The address is empty if no leader is known or no leader connection is configured.
+func (l *LeaderConnectionProvider) GetCurrentLeader() (LeaderReport, string) {
+	currentLeader := l.leaderController.GetLeaderReport()
+	if currentLeader.LeaderName == "" || l.leaderConfig.LeaderConnection.ArmadaUrl == "" {
+		return currentLeader, ""
+	}
+	return currentLeader, l.leaderAddress(currentLeader.LeaderName)
+}
+
+func (l *LeaderConnectionProvider) leaderAddress(currentLeaderName string) string {
+	return strings.ReplaceAll(l.leaderConfig.LeaderConnection.ArmadaUrl, leaseHolderNameToken, currentLeaderName)
+}
+
 func (l *LeaderConnectionProvider) getClientByName(currentLeaderName string) (*grpc.ClientConn, error) {
 	l.connectionLock.Lock()
 	defer l.connectionLock.Unlock()
@@ -58,7 +72,7 @@ func (l *LeaderConnectionProvider) getClientByName(currentLeaderName string) (*g
 	}
 
 	leaderConnectionDetails := l.leaderConfig.LeaderConnection
-	leaderConnectionDetails.ArmadaUrl = strings.ReplaceAll(leaderConnectionDetails.ArmadaUrl, leaseHolderNameToken, currentLeaderName)
+	leaderConnectionDetails.ArmadaUrl = l.leaderAddress(currentLeaderName)
 
 	apiConnection, err := createApiConnection(leaderConnectionDetails)
 	if err != nil {
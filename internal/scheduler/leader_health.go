@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// LeaderHealthServiceName is the service name under which leadership is reported via the gRPC health service.
+// Its status is SERVING on the leader and NOT_SERVING on all other replicas,
+// such that gRPC-aware load balancers can route leader-only calls to the leader.
+const LeaderHealthServiceName = "leader"
+
+// LeaderHealthReporter periodically updates the status of LeaderHealthServiceName on a gRPC health server
+// to reflect whether this process is leader.
+type LeaderHealthReporter struct {
+	leaderController LeaderController
+	healthServer     *health.Server
+	// How often to check for changes in leadership.
+	updatePeriod time.Duration
+}
+
+func NewLeaderHealthReporter(leaderController LeaderController, healthServer *health.Server, updatePeriod time.Duration) *LeaderHealthReporter {
+	reporter := &LeaderHealthReporter{
+		leaderController: leaderController,
+		healthServer:     healthServer,
+		updatePeriod:     updatePeriod,
+	}
+	reporter.update()
+	return reporter
+}
+
+// Run updates the leader health status until ctx is cancelled.
+func (r *LeaderHealthReporter) Run(ctx *armadacontext.Context) error {
+	ticker := time.NewTicker(r.updatePeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			r.update()
+		}
+	}
+}
+
+func (r *LeaderHealthReporter) update() {
+	servingStatus := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if r.leaderController.ValidateToken(r.leaderController.GetToken()) {
+		servingStatus = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	r.healthServer.SetServingStatus(LeaderHealthServiceName, servingStatus)
+}
+
+// LeaderDiscoveryServer implements the WhoIsLeader endpoint, which returns the identity and address of the leader.
+type LeaderDiscoveryServer struct {
+	leaderConnectionProvider *LeaderConnectionProvider
+}
+
+func NewLeaderDiscoveryServer(leaderConnectionProvider *LeaderConnectionProvider) *LeaderDiscoveryServer {
+	return &LeaderDiscoveryServer{
+		leaderConnectionProvider: leaderConnectionProvider,
+	}
+}
+
+func (s *LeaderDiscoveryServer) WhoIsLeader(_ context.Context, _ *schedulerobjects.WhoIsLeaderRequest) (*schedulerobjects.WhoIsLeaderResponse, error) {
+	currentLeader, address := s.leaderConnectionProvider.GetCurrentLeader()
+	if currentLeader.LeaderName == "" {
+		return nil, status.Error(codes.Unavailable, "no leader found")
+	}
+	return &schedulerobjects.WhoIsLeaderResponse{
+		LeaderName:             currentLeader.LeaderName,
+		LeaderAddress:          address,
+		IsCurrentProcessLeader: currentLeader.IsCurrentProcessLeader,
+	}, nil
+}
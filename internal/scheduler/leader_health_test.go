@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+func TestLeaderHealthReporter_StatusFollowsLeadership(t *testing.T) {
+	leaderController := NewStandaloneLeaderController()
+	healthServer := health.NewServer()
+	reporter := NewLeaderHealthReporter(leaderController, healthServer, time.Second)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, leaderHealthStatus(t, healthServer))
+
+	leaderController.token = InvalidLeaderToken()
+	reporter.update()
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, leaderHealthStatus(t, healthServer))
+
+	leaderController.token = NewLeaderToken()
+	reporter.update()
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, leaderHealthStatus(t, healthServer))
+
+	// Leadership doesn't affect the overall health of the server.
+	response, err := healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, response.Status)
+}
+
+func TestLeaderDiscoveryServer_WhoIsLeader(t *testing.T) {
+	tests := map[string]struct {
+		leaderController *FakeLeaderController
+		expectedResponse *schedulerobjects.WhoIsLeaderResponse
+		expectedCode     codes.Code
+	}{
+		"other replica is leader": {
+			leaderController: &FakeLeaderController{LeaderName: "new-leader"},
+			expectedResponse: &schedulerobjects.WhoIsLeaderResponse{
+				LeaderName:    "new-leader",
+				LeaderAddress: "new-leader.localhost:50052",
+			},
+		},
+		"current process is leader": {
+			leaderController: &FakeLeaderController{LeaderName: currentProcessPodName, IsCurrentlyLeader: true},
+			expectedResponse: &schedulerobjects.WhoIsLeaderResponse{
+				LeaderName:             currentProcessPodName,
+				LeaderAddress:          currentProcessPodName + ".localhost:50052",
+				IsCurrentProcessLeader: true,
+			},
+		},
+		"no leader": {
+			leaderController: &FakeLeaderController{},
+			expectedCode:     codes.Unavailable,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := NewLeaderDiscoveryServer(NewLeaderConnectionProvider(tc.leaderController, templatedLeader))
+			response, err := server.WhoIsLeader(context.Background(), &schedulerobjects.WhoIsLeaderRequest{})
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func leaderHealthStatus(t *testing.T, healthServer *health.Server) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	response, err := healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: LeaderHealthServiceName})
+	require.NoError(t, err)
+	return response.Status
+}
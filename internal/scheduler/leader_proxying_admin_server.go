@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// LeaderProxyingSchedulerAdminServer serves admin requests locally if this process is the leader
+// and forwards them to the leader otherwise, since only the leader's jobDb is used for scheduling.
+type LeaderProxyingSchedulerAdminServer struct {
+	localAdminServer             schedulerobjects.SchedulerAdminServer
+	leaderClientProvider         LeaderClientConnectionProvider
+	schedulerAdminClientProvider adminClientProvider
+	// Used to authorize requests before forwarding them for endpoints that require specific permissions,
+	// since forwarded requests are authenticated by the leader using the credentials of this process.
+	permissionChecker authorization.PermissionChecker
+}
+
+func NewLeaderProxyingSchedulerAdminServer(
+	localAdminServer schedulerobjects.SchedulerAdminServer,
+	leaderClientProvider LeaderClientConnectionProvider,
+	permissionChecker authorization.PermissionChecker,
+) *LeaderProxyingSchedulerAdminServer {
+	return &LeaderProxyingSchedulerAdminServer{
+		localAdminServer:             localAdminServer,
+		leaderClientProvider:         leaderClientProvider,
+		schedulerAdminClientProvider: &schedulerAdminClientProvider{},
+		permissionChecker:            permissionChecker,
+	}
+}
+
+func (s *LeaderProxyingSchedulerAdminServer) NudgeJob(ctx context.Context, request *schedulerobjects.NudgeJobRequest) (*schedulerobjects.NudgeJobResponse, error) {
+	isCurrentProcessLeader, leaderConnection, err := s.leaderClientProvider.GetCurrentLeaderClientConnection()
+	if isCurrentProcessLeader {
+		return s.localAdminServer.NudgeJob(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	leaderClient := s.schedulerAdminClientProvider.GetSchedulerAdminClient(leaderConnection)
+	return leaderClient.NudgeJob(ctx, request)
+}
+
+func (s *LeaderProxyingSchedulerAdminServer) SetExecutorTimeout(ctx context.Context, request *schedulerobjects.SetExecutorTimeoutRequest) (*schedulerobjects.SetExecutorTimeoutResponse, error) {
+	isCurrentProcessLeader, leaderConnection, err := s.leaderClientProvider.GetCurrentLeaderClientConnection()
+	if isCurrentProcessLeader {
+		return s.localAdminServer.SetExecutorTimeout(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	leaderClient := s.schedulerAdminClientProvider.GetSchedulerAdminClient(leaderConnection)
+	return leaderClient.SetExecutorTimeout(ctx, request)
+}
+
+func (s *LeaderProxyingSchedulerAdminServer) ForceFailJob(ctx context.Context, request *schedulerobjects.ForceFailJobRequest) (*schedulerobjects.ForceFailJobResponse, error) {
+	isCurrentProcessLeader, leaderConnection, err := s.leaderClientProvider.GetCurrentLeaderClientConnection()
+	if isCurrentProcessLeader {
+		return s.localAdminServer.ForceFailJob(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	principal, err := authorizeForceFailJob(ctx, s.permissionChecker)
+	if err != nil {
+		return nil, err
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, forwardedPrincipalMetadataKey, principal)
+	leaderClient := s.schedulerAdminClientProvider.GetSchedulerAdminClient(leaderConnection)
+	return leaderClient.ForceFailJob(ctx, request)
+}
+
+func (s *LeaderProxyingSchedulerAdminServer) ScaleHints(ctx context.Context, request *schedulerobjects.ScaleHintsRequest) (*schedulerobjects.ScaleHintsResponse, error) {
+	isCurrentProcessLeader, leaderConnection, err := s.leaderClientProvider.GetCurrentLeaderClientConnection()
+	if isCurrentProcessLeader {
+		return s.localAdminServer.ScaleHints(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	leaderClient := s.schedulerAdminClientProvider.GetSchedulerAdminClient(leaderConnection)
+	return leaderClient.ScaleHints(ctx, request)
+}
+
+// InspectSubmitChecker is always served locally, since each replica has its own submit checker.
+func (s *LeaderProxyingSchedulerAdminServer) InspectSubmitChecker(ctx context.Context, request *schedulerobjects.InspectSubmitCheckerRequest) (*schedulerobjects.InspectSubmitCheckerResponse, error) {
+	return s.localAdminServer.InspectSubmitChecker(ctx, request)
+}
+
+// RebuildJobDb is only served by the leader, since only the leader's jobDb is rebuilt.
+func (s *LeaderProxyingSchedulerAdminServer) RebuildJobDb(ctx context.Context, request *schedulerobjects.RebuildJobDbRequest) (*schedulerobjects.RebuildJobDbResponse, error) {
+	isCurrentProcessLeader, leaderConnection, err := s.leaderClientProvider.GetCurrentLeaderClientConnection()
+	if isCurrentProcessLeader {
+		return s.localAdminServer.RebuildJobDb(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	principal, err := authorizeRebuildJobDb(ctx, s.permissionChecker)
+	if err != nil {
+		return nil, err
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, forwardedPrincipalMetadataKey, principal)
+	leaderClient := s.schedulerAdminClientProvider.GetSchedulerAdminClient(leaderConnection)
+	return leaderClient.RebuildJobDb(ctx, request)
+}
+
+// SchedulerAdminServer serves admin requests locally by delegating to the component responsible for each endpoint.
+type SchedulerAdminServer struct {
+	*JobNudger
+	*ExecutorTimeouts
+	*JobForceFailer
+	*ExecutorIdlenessTracker
+	*SubmitCheckerInspector
+	*JobDbRebuilder
+}
+
+func NewSchedulerAdminServer(
+	jobNudger *JobNudger,
+	executorTimeouts *ExecutorTimeouts,
+	jobForceFailer *JobForceFailer,
+	executorIdlenessTracker *ExecutorIdlenessTracker,
+	submitCheckerInspector *SubmitCheckerInspector,
+	jobDbRebuilder *JobDbRebuilder,
+) *SchedulerAdminServer {
+	return &SchedulerAdminServer{
+		JobNudger:               jobNudger,
+		ExecutorTimeouts:        executorTimeouts,
+		JobForceFailer:          jobForceFailer,
+		ExecutorIdlenessTracker: executorIdlenessTracker,
+		SubmitCheckerInspector:  submitCheckerInspector,
+		JobDbRebuilder:          jobDbRebuilder,
+	}
+}
+
+type adminClientProvider interface {
+	GetSchedulerAdminClient(conn *grpc.ClientConn) schedulerobjects.SchedulerAdminClient
+}
+
+type schedulerAdminClientProvider struct{}
+
+func (s *schedulerAdminClientProvider) GetSchedulerAdminClient(conn *grpc.ClientConn) schedulerobjects.SchedulerAdminClient {
+	return schedulerobjects.NewSchedulerAdminClient(conn)
+}
@@ -12,6 +12,8 @@ type LeaderProxyingSchedulingReportsServer struct {
 	localReportsServer               schedulerobjects.SchedulerReportingServer
 	leaderClientProvider             LeaderClientConnectionProvider
 	schedulerReportingClientProvider reportingClientProvider
+	// If true, reports are always served by localReportsServer.
+	serveLocally bool
 }
 
 func NewLeaderProxyingSchedulingReportsServer(
@@ -25,8 +27,14 @@ func NewLeaderProxyingSchedulingReportsServer(
 	}
 }
 
+// ServeLocally causes all reports to be served from local data rather than being proxied to the leader.
+// Used by observer replicas, which are never leader but keep their own state up to date.
+func (s *LeaderProxyingSchedulingReportsServer) ServeLocally() {
+	s.serveLocally = true
+}
+
 func (s *LeaderProxyingSchedulingReportsServer) GetSchedulingReport(ctx context.Context, request *schedulerobjects.SchedulingReportRequest) (*schedulerobjects.SchedulingReport, error) {
-	isCurrentProcessLeader, leaderConnection, err := s.leaderClientProvider.GetCurrentLeaderClientConnection()
+	isCurrentProcessLeader, leaderConnection, err := s.getCurrentLeaderClientConnection()
 	if isCurrentProcessLeader {
 		return s.localReportsServer.GetSchedulingReport(ctx, request)
 	}
@@ -38,7 +46,7 @@ func (s *LeaderProxyingSchedulingReportsServer) GetSchedulingReport(ctx context.
 }
 
 func (s *LeaderProxyingSchedulingReportsServer) GetQueueReport(ctx context.Context, request *schedulerobjects.QueueReportRequest) (*schedulerobjects.QueueReport, error) {
-	isCurrentProcessLeader, leaderConnection, err := s.leaderClientProvider.GetCurrentLeaderClientConnection()
+	isCurrentProcessLeader, leaderConnection, err := s.getCurrentLeaderClientConnection()
 	if isCurrentProcessLeader {
 		return s.localReportsServer.GetQueueReport(ctx, request)
 	}
@@ -50,7 +58,7 @@ func (s *LeaderProxyingSchedulingReportsServer) GetQueueReport(ctx context.Conte
 }
 
 func (s *LeaderProxyingSchedulingReportsServer) GetJobReport(ctx context.Context, request *schedulerobjects.JobReportRequest) (*schedulerobjects.JobReport, error) {
-	isCurrentProcessLeader, leaderConnection, err := s.leaderClientProvider.GetCurrentLeaderClientConnection()
+	isCurrentProcessLeader, leaderConnection, err := s.getCurrentLeaderClientConnection()
 	if isCurrentProcessLeader {
 		return s.localReportsServer.GetJobReport(ctx, request)
 	}
@@ -61,6 +69,51 @@ func (s *LeaderProxyingSchedulingReportsServer) GetJobReport(ctx context.Context
 	return leaderClient.GetJobReport(ctx, request)
 }
 
+func (s *LeaderProxyingSchedulingReportsServer) GetJobSetReport(ctx context.Context, request *schedulerobjects.JobSetReportRequest) (*schedulerobjects.JobSetReport, error) {
+	isCurrentProcessLeader, leaderConnection, err := s.getCurrentLeaderClientConnection()
+	if isCurrentProcessLeader {
+		return s.localReportsServer.GetJobSetReport(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	leaderClient := s.schedulerReportingClientProvider.GetSchedulerReportingClient(leaderConnection)
+	return leaderClient.GetJobSetReport(ctx, request)
+}
+
+func (s *LeaderProxyingSchedulingReportsServer) GetEventRateReport(ctx context.Context, request *schedulerobjects.EventRateReportRequest) (*schedulerobjects.EventRateReport, error) {
+	isCurrentProcessLeader, leaderConnection, err := s.getCurrentLeaderClientConnection()
+	if isCurrentProcessLeader {
+		return s.localReportsServer.GetEventRateReport(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	leaderClient := s.schedulerReportingClientProvider.GetSchedulerReportingClient(leaderConnection)
+	return leaderClient.GetEventRateReport(ctx, request)
+}
+
+func (s *LeaderProxyingSchedulingReportsServer) GetFragmentationReport(ctx context.Context, request *schedulerobjects.FragmentationReportRequest) (*schedulerobjects.FragmentationReport, error) {
+	isCurrentProcessLeader, leaderConnection, err := s.getCurrentLeaderClientConnection()
+	if isCurrentProcessLeader {
+		return s.localReportsServer.GetFragmentationReport(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	leaderClient := s.schedulerReportingClientProvider.GetSchedulerReportingClient(leaderConnection)
+	return leaderClient.GetFragmentationReport(ctx, request)
+}
+
+// getCurrentLeaderClientConnection is like LeaderClientConnectionProvider.GetCurrentLeaderClientConnection,
+// except that the current process is considered leader if reports are served locally.
+func (s *LeaderProxyingSchedulingReportsServer) getCurrentLeaderClientConnection() (bool, *grpc.ClientConn, error) {
+	if s.serveLocally {
+		return true, nil, nil
+	}
+	return s.leaderClientProvider.GetCurrentLeaderClientConnection()
+}
+
 type reportingClientProvider interface {
 	GetSchedulerReportingClient(conn *grpc.ClientConn) schedulerobjects.SchedulerReportingClient
 }
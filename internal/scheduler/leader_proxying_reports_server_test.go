@@ -17,6 +17,7 @@ func TestLeaderProxyingSchedulingReportsServer_GetJobReports(t *testing.T) {
 	tests := map[string]struct {
 		err                          error
 		isCurrentProcessLeader       bool
+		serveLocally                 bool
 		expectedNumReportServerCalls int
 		expectedNumReportClientCalls int
 	}{
@@ -46,6 +47,14 @@ func TestLeaderProxyingSchedulingReportsServer_GetJobReports(t *testing.T) {
 			expectedNumReportServerCalls: 0,
 			expectedNumReportClientCalls: 1,
 		},
+		// Should send all requests to local reports server when serving locally, e.g., on observer replicas
+		"remote process is leader but serving locally": {
+			err:                          nil,
+			isCurrentProcessLeader:       false,
+			serveLocally:                 true,
+			expectedNumReportServerCalls: 1,
+			expectedNumReportClientCalls: 0,
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -54,6 +63,9 @@ func TestLeaderProxyingSchedulingReportsServer_GetJobReports(t *testing.T) {
 
 			sut, clientProvider, jobReportsServer, jobReportsClient := setupLeaderProxyingSchedulerReportsServerTest(t)
 			clientProvider.IsCurrentProcessLeader = tc.isCurrentProcessLeader
+			if tc.serveLocally {
+				sut.ServeLocally()
+			}
 
 			request := &schedulerobjects.JobReportRequest{JobId: "job-1"}
 
@@ -208,6 +220,201 @@ func TestLeaderProxyingSchedulingReportsServer_GetQueueReport(t *testing.T) {
 	}
 }
 
+func TestLeaderProxyingSchedulingReportsServer_GetJobSetReport(t *testing.T) {
+	tests := map[string]struct {
+		err                          error
+		isCurrentProcessLeader       bool
+		expectedNumReportServerCalls int
+		expectedNumReportClientCalls int
+	}{
+		// Should send all requests to local reports server when leader
+		"current process leader": {
+			err:                          nil,
+			isCurrentProcessLeader:       true,
+			expectedNumReportServerCalls: 1,
+			expectedNumReportClientCalls: 0,
+		},
+		"current process leader return error": {
+			err:                          fmt.Errorf("error"),
+			isCurrentProcessLeader:       true,
+			expectedNumReportServerCalls: 1,
+			expectedNumReportClientCalls: 0,
+		},
+		// Should send all requests to remote server when not leader
+		"remote process is leader": {
+			err:                          nil,
+			isCurrentProcessLeader:       false,
+			expectedNumReportServerCalls: 0,
+			expectedNumReportClientCalls: 1,
+		},
+		"remote process is leader return error": {
+			err:                          fmt.Errorf("error"),
+			isCurrentProcessLeader:       false,
+			expectedNumReportServerCalls: 0,
+			expectedNumReportClientCalls: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			sut, clientProvider, jobReportsServer, jobReportsClient := setupLeaderProxyingSchedulerReportsServerTest(t)
+			clientProvider.IsCurrentProcessLeader = tc.isCurrentProcessLeader
+
+			request := &schedulerobjects.JobSetReportRequest{Queue: "queue-1", JobSetId: "job-set-1"}
+
+			expectedResult := &schedulerobjects.JobSetReport{Report: "report"}
+
+			if tc.err == nil {
+				expectedResult = nil
+			}
+
+			jobReportsServer.GetJobSetReportResponse = expectedResult
+			jobReportsServer.Err = tc.err
+			jobReportsClient.GetJobSetReportResponse = expectedResult
+			jobReportsClient.Err = tc.err
+
+			result, err := sut.GetJobSetReport(ctx, request)
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, expectedResult, result)
+			assert.Len(t, jobReportsServer.GetJobSetReportCalls, tc.expectedNumReportServerCalls)
+			assert.Len(t, jobReportsClient.GetJobSetReportCalls, tc.expectedNumReportClientCalls)
+		})
+	}
+}
+
+func TestLeaderProxyingSchedulingReportsServer_GetEventRateReport(t *testing.T) {
+	tests := map[string]struct {
+		err                          error
+		isCurrentProcessLeader       bool
+		expectedNumReportServerCalls int
+		expectedNumReportClientCalls int
+	}{
+		// Should send all requests to local reports server when leader
+		"current process leader": {
+			err:                          nil,
+			isCurrentProcessLeader:       true,
+			expectedNumReportServerCalls: 1,
+			expectedNumReportClientCalls: 0,
+		},
+		"current process leader return error": {
+			err:                          fmt.Errorf("error"),
+			isCurrentProcessLeader:       true,
+			expectedNumReportServerCalls: 1,
+			expectedNumReportClientCalls: 0,
+		},
+		// Should send all requests to remote server when not leader
+		"remote process is leader": {
+			err:                          nil,
+			isCurrentProcessLeader:       false,
+			expectedNumReportServerCalls: 0,
+			expectedNumReportClientCalls: 1,
+		},
+		"remote process is leader return error": {
+			err:                          fmt.Errorf("error"),
+			isCurrentProcessLeader:       false,
+			expectedNumReportServerCalls: 0,
+			expectedNumReportClientCalls: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			sut, clientProvider, jobReportsServer, jobReportsClient := setupLeaderProxyingSchedulerReportsServerTest(t)
+			clientProvider.IsCurrentProcessLeader = tc.isCurrentProcessLeader
+
+			request := &schedulerobjects.EventRateReportRequest{Queue: "queue-1", NumJobSets: 5}
+
+			expectedResult := &schedulerobjects.EventRateReport{Report: "report"}
+
+			if tc.err == nil {
+				expectedResult = nil
+			}
+
+			jobReportsServer.GetEventRateReportResponse = expectedResult
+			jobReportsServer.Err = tc.err
+			jobReportsClient.GetEventRateReportResponse = expectedResult
+			jobReportsClient.Err = tc.err
+
+			result, err := sut.GetEventRateReport(ctx, request)
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, expectedResult, result)
+			assert.Len(t, jobReportsServer.GetEventRateReportCalls, tc.expectedNumReportServerCalls)
+			assert.Len(t, jobReportsClient.GetEventRateReportCalls, tc.expectedNumReportClientCalls)
+		})
+	}
+}
+
+func TestLeaderProxyingSchedulingReportsServer_GetFragmentationReport(t *testing.T) {
+	tests := map[string]struct {
+		err                          error
+		isCurrentProcessLeader       bool
+		expectedNumReportServerCalls int
+		expectedNumReportClientCalls int
+	}{
+		// Should send all requests to local reports server when leader
+		"current process leader": {
+			err:                          nil,
+			isCurrentProcessLeader:       true,
+			expectedNumReportServerCalls: 1,
+			expectedNumReportClientCalls: 0,
+		},
+		"current process leader return error": {
+			err:                          fmt.Errorf("error"),
+			isCurrentProcessLeader:       true,
+			expectedNumReportServerCalls: 1,
+			expectedNumReportClientCalls: 0,
+		},
+		// Should send all requests to remote server when not leader
+		"remote process is leader": {
+			err:                          nil,
+			isCurrentProcessLeader:       false,
+			expectedNumReportServerCalls: 0,
+			expectedNumReportClientCalls: 1,
+		},
+		"remote process is leader return error": {
+			err:                          fmt.Errorf("error"),
+			isCurrentProcessLeader:       false,
+			expectedNumReportServerCalls: 0,
+			expectedNumReportClientCalls: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			sut, clientProvider, jobReportsServer, jobReportsClient := setupLeaderProxyingSchedulerReportsServerTest(t)
+			clientProvider.IsCurrentProcessLeader = tc.isCurrentProcessLeader
+
+			request := &schedulerobjects.FragmentationReportRequest{Pool: "pool-1"}
+
+			expectedResult := &schedulerobjects.FragmentationReport{Report: "report"}
+
+			if tc.err == nil {
+				expectedResult = nil
+			}
+
+			jobReportsServer.GetFragmentationReportResponse = expectedResult
+			jobReportsServer.Err = tc.err
+			jobReportsClient.GetFragmentationReportResponse = expectedResult
+			jobReportsClient.Err = tc.err
+
+			result, err := sut.GetFragmentationReport(ctx, request)
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, expectedResult, result)
+			assert.Len(t, jobReportsServer.GetFragmentationReportCalls, tc.expectedNumReportServerCalls)
+			assert.Len(t, jobReportsClient.GetFragmentationReportCalls, tc.expectedNumReportClientCalls)
+		})
+	}
+}
+
 func setupLeaderProxyingSchedulerReportsServerTest(t *testing.T) (*LeaderProxyingSchedulingReportsServer, *FakeClientProvider, *FakeSchedulerReportingServer, *FakeSchedulerReportingClient) {
 	jobReportsServer := NewFakeSchedulerReportingServer()
 	jobReportsClient := NewFakeSchedulerReportingClient()
@@ -237,6 +444,21 @@ type GetJobReportCall struct {
 	Request *schedulerobjects.JobReportRequest
 }
 
+type GetJobSetReportCall struct {
+	Context context.Context
+	Request *schedulerobjects.JobSetReportRequest
+}
+
+type GetEventRateReportCall struct {
+	Context context.Context
+	Request *schedulerobjects.EventRateReportRequest
+}
+
+type GetFragmentationReportCall struct {
+	Context context.Context
+	Request *schedulerobjects.FragmentationReportRequest
+}
+
 type FakeSchedulerReportingServer struct {
 	GetSchedulingReportCalls    []GetSchedulingReportCall
 	GetSchedulingReportResponse *schedulerobjects.SchedulingReport
@@ -246,7 +468,16 @@ type FakeSchedulerReportingServer struct {
 
 	GetJobReportCalls    []GetJobReportCall
 	GetJobReportResponse *schedulerobjects.JobReport
-	Err                  error
+
+	GetJobSetReportCalls    []GetJobSetReportCall
+	GetJobSetReportResponse *schedulerobjects.JobSetReport
+
+	GetEventRateReportCalls    []GetEventRateReportCall
+	GetEventRateReportResponse *schedulerobjects.EventRateReport
+
+	GetFragmentationReportCalls    []GetFragmentationReportCall
+	GetFragmentationReportResponse *schedulerobjects.FragmentationReport
+	Err                            error
 }
 
 func NewFakeSchedulerReportingServer() *FakeSchedulerReportingServer {
@@ -254,6 +485,10 @@ func NewFakeSchedulerReportingServer() *FakeSchedulerReportingServer {
 		GetSchedulingReportCalls: []GetSchedulingReportCall{},
 		GetQueueReportCalls:      []GetQueueReportCall{},
 		GetJobReportCalls:        []GetJobReportCall{},
+		GetJobSetReportCalls:     []GetJobSetReportCall{},
+		GetEventRateReportCalls:  []GetEventRateReportCall{},
+
+		GetFragmentationReportCalls: []GetFragmentationReportCall{},
 	}
 }
 
@@ -272,6 +507,21 @@ func (f *FakeSchedulerReportingServer) GetJobReport(ctx context.Context, request
 	return f.GetJobReportResponse, f.Err
 }
 
+func (f *FakeSchedulerReportingServer) GetJobSetReport(ctx context.Context, request *schedulerobjects.JobSetReportRequest) (*schedulerobjects.JobSetReport, error) {
+	f.GetJobSetReportCalls = append(f.GetJobSetReportCalls, GetJobSetReportCall{Context: ctx, Request: request})
+	return f.GetJobSetReportResponse, f.Err
+}
+
+func (f *FakeSchedulerReportingServer) GetEventRateReport(ctx context.Context, request *schedulerobjects.EventRateReportRequest) (*schedulerobjects.EventRateReport, error) {
+	f.GetEventRateReportCalls = append(f.GetEventRateReportCalls, GetEventRateReportCall{Context: ctx, Request: request})
+	return f.GetEventRateReportResponse, f.Err
+}
+
+func (f *FakeSchedulerReportingServer) GetFragmentationReport(ctx context.Context, request *schedulerobjects.FragmentationReportRequest) (*schedulerobjects.FragmentationReport, error) {
+	f.GetFragmentationReportCalls = append(f.GetFragmentationReportCalls, GetFragmentationReportCall{Context: ctx, Request: request})
+	return f.GetFragmentationReportResponse, f.Err
+}
+
 type FakeSchedulerReportingClient struct {
 	GetSchedulingReportCalls    []GetSchedulingReportCall
 	GetSchedulingReportResponse *schedulerobjects.SchedulingReport
@@ -281,7 +531,16 @@ type FakeSchedulerReportingClient struct {
 
 	GetJobReportCalls    []GetJobReportCall
 	GetJobReportResponse *schedulerobjects.JobReport
-	Err                  error
+
+	GetJobSetReportCalls    []GetJobSetReportCall
+	GetJobSetReportResponse *schedulerobjects.JobSetReport
+
+	GetEventRateReportCalls    []GetEventRateReportCall
+	GetEventRateReportResponse *schedulerobjects.EventRateReport
+
+	GetFragmentationReportCalls    []GetFragmentationReportCall
+	GetFragmentationReportResponse *schedulerobjects.FragmentationReport
+	Err                            error
 }
 
 func NewFakeSchedulerReportingClient() *FakeSchedulerReportingClient {
@@ -289,6 +548,10 @@ func NewFakeSchedulerReportingClient() *FakeSchedulerReportingClient {
 		GetSchedulingReportCalls: []GetSchedulingReportCall{},
 		GetQueueReportCalls:      []GetQueueReportCall{},
 		GetJobReportCalls:        []GetJobReportCall{},
+		GetJobSetReportCalls:     []GetJobSetReportCall{},
+		GetEventRateReportCalls:  []GetEventRateReportCall{},
+
+		GetFragmentationReportCalls: []GetFragmentationReportCall{},
 	}
 }
 
@@ -307,6 +570,21 @@ func (f *FakeSchedulerReportingClient) GetJobReport(ctx context.Context, request
 	return f.GetJobReportResponse, f.Err
 }
 
+func (f *FakeSchedulerReportingClient) GetJobSetReport(ctx context.Context, request *schedulerobjects.JobSetReportRequest, opts ...grpc.CallOption) (*schedulerobjects.JobSetReport, error) {
+	f.GetJobSetReportCalls = append(f.GetJobSetReportCalls, GetJobSetReportCall{Context: ctx, Request: request})
+	return f.GetJobSetReportResponse, f.Err
+}
+
+func (f *FakeSchedulerReportingClient) GetEventRateReport(ctx context.Context, request *schedulerobjects.EventRateReportRequest, opts ...grpc.CallOption) (*schedulerobjects.EventRateReport, error) {
+	f.GetEventRateReportCalls = append(f.GetEventRateReportCalls, GetEventRateReportCall{Context: ctx, Request: request})
+	return f.GetEventRateReportResponse, f.Err
+}
+
+func (f *FakeSchedulerReportingClient) GetFragmentationReport(ctx context.Context, request *schedulerobjects.FragmentationReportRequest, opts ...grpc.CallOption) (*schedulerobjects.FragmentationReport, error) {
+	f.GetFragmentationReportCalls = append(f.GetFragmentationReportCalls, GetFragmentationReportCall{Context: ctx, Request: request})
+	return f.GetFragmentationReportResponse, f.Err
+}
+
 type FakeClientProvider struct {
 	Error                  error
 	IsCurrentProcessLeader bool
@@ -83,7 +83,8 @@ func TestK8sLeaderController_BecomingLeader(t *testing.T) {
 					}
 					lease = &v1.Lease{
 						Spec: v1.LeaseSpec{
-							HolderIdentity: pointer.String(holderIdentity),
+							HolderIdentity:   pointer.String(holderIdentity),
+							LeaseTransitions: pointer.Int32(int32(idx)),
 						},
 					}
 					idx++
@@ -134,6 +135,7 @@ func TestK8sLeaderController_BecomingLeader(t *testing.T) {
 
 			// Assert the results
 			require.Equal(t, len(tc.expectedStates), len(testListener.tokens))
+			prevEpoch := uint64(0)
 			for i, state := range tc.expectedStates {
 				tok := testListener.tokens[i]
 				validation := testListener.validations[i]
@@ -141,9 +143,13 @@ func TestK8sLeaderController_BecomingLeader(t *testing.T) {
 				case Leader:
 					assert.True(t, tok.leader)
 					assert.True(t, validation)
+					// Each term as leader has a greater epoch than the previous one.
+					assert.Greater(t, tok.Epoch(), prevEpoch)
+					prevEpoch = tok.Epoch()
 				case NotLeader:
 					assert.False(t, tok.leader)
 					assert.False(t, validation)
+					assert.Equal(t, uint64(0), tok.Epoch())
 				}
 			}
 
@@ -153,6 +159,16 @@ func TestK8sLeaderController_BecomingLeader(t *testing.T) {
 	}
 }
 
+func TestObserverLeaderController(t *testing.T) {
+	controller := NewObserverLeaderController()
+	token := controller.GetToken()
+	assert.False(t, token.leader)
+	assert.False(t, controller.ValidateToken(token))
+	// Tokens minted elsewhere aren't valid either.
+	assert.False(t, controller.ValidateToken(NewLeaderToken()))
+	assert.False(t, controller.GetLeaderReport().IsCurrentProcessLeader)
+}
+
 func testLeaderConfig() schedulerconfig.LeaderConfig {
 	return schedulerconfig.LeaderConfig{
 		LeaseLockName:      lockName,
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+)
+
+// EnableLeaseFanOutLimit causes at most maxLeases new leases to be sent to an executor per lease request, such that
+// executors aren't overwhelmed after many jobs are scheduled onto them at once. The remaining leases are sent in
+// subsequent requests, in the order their runs were created in, i.e., in scheduling order.
+// Run cancellations, including those of preempted runs, are never held back.
+// The number of leases yet to be sent to each executor is reported via metrics.
+func (srv *ExecutorApi) EnableLeaseFanOutLimit(maxLeases uint, metrics *SchedulerMetrics) {
+	srv.maxLeasesPerRequest = maxLeases
+	srv.metrics = metrics
+}
+
+// maxLeasesToSend returns the maximum number of new leases to send in response to a request for maxRequested leases.
+func (srv *ExecutorApi) maxLeasesToSend(maxRequested uint) uint {
+	if srv.maxLeasesPerRequest > 0 && srv.maxLeasesPerRequest < maxRequested {
+		return srv.maxLeasesPerRequest
+	}
+	return maxRequested
+}
+
+// reportPendingLeases reports the number of runs leased to executor that it neither had at the time of its request,
+// i.e., requestRuns, nor is about to be sent, i.e., newRuns, of which there were at most maxLeases.
+func (srv *ExecutorApi) reportPendingLeases(
+	ctx *armadacontext.Context,
+	executor string,
+	requestRuns []uuid.UUID,
+	newRuns []*database.JobRunLease,
+	maxLeases uint,
+) {
+	if srv.metrics == nil {
+		return
+	}
+	pending := uint(0)
+	if uint(len(newRuns)) >= maxLeases {
+		// Leases may have been held back; count those not sent.
+		count, err := srv.jobRepository.CountJobRunLeases(ctx, executor, requestRuns)
+		if err != nil {
+			// The metric is advisory; failing to count leases shouldn't prevent the executor from receiving them.
+			logging.WithStacktrace(ctx, err).Warnf("failed to count pending leases")
+			return
+		}
+		if count > uint(len(newRuns)) {
+			pending = count - uint(len(newRuns))
+		}
+	}
+	if pending > 0 {
+		ctx.Infof("%d leases pending delivery after this request", pending)
+	}
+	srv.metrics.ReportPendingLeases(executor, pending)
+}
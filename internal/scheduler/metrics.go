@@ -55,6 +55,10 @@ type MetricsCollector struct {
 	refreshPeriod      time.Duration
 	clock              clock.Clock
 	state              atomic.Value
+	// If non-nil, the event rates of each queue recorded here are exported.
+	eventRateTracker *EventRateTracker
+	// If non-nil, refreshPeriod is jittered.
+	refreshJitter PeriodJitter
 }
 
 func NewMetricsCollector(
@@ -75,9 +79,23 @@ func NewMetricsCollector(
 	}
 }
 
+// EnableEventRates causes the rates at which jobs of each queue are admitted, cancelled, requeued, and failed,
+// as recorded by tracker, to be exported, together with whether these exceed their configured maximum.
+// Must be called before the collector is run.
+func (c *MetricsCollector) EnableEventRates(tracker *EventRateTracker) {
+	c.eventRateTracker = tracker
+}
+
+// EnableRefreshJitter causes the time between refreshes to be jittered by jitter,
+// with the first refresh delayed by jitter.Phase. Must be called before the collector is run.
+func (c *MetricsCollector) EnableRefreshJitter(jitter PeriodJitter) {
+	c.refreshJitter = jitter
+}
+
 // Run enters s a loop which updates the metrics every refreshPeriod until the supplied context is cancelled
 func (c *MetricsCollector) Run(ctx *armadacontext.Context) error {
-	ticker := c.clock.NewTicker(c.refreshPeriod)
+	ticker := newTicker(c.clock, c.refreshPeriod, c.refreshJitter)
+	defer ticker.Stop()
 	ctx.Infof("Will update metrics every %s", c.refreshPeriod)
 	for {
 		select {
@@ -98,6 +116,10 @@ func (c *MetricsCollector) Run(ctx *armadacontext.Context) error {
 // Describe returns all descriptions of the collector.
 func (c *MetricsCollector) Describe(out chan<- *prometheus.Desc) {
 	commonmetrics.Describe(out)
+	if c.eventRateTracker != nil {
+		out <- queueEventRateDesc
+		out <- queueEventRateExceededDesc
+	}
 }
 
 // Collect returns the current state of all metrics of the collector.
@@ -122,6 +144,9 @@ func (c *MetricsCollector) refresh(ctx *armadacontext.Context) error {
 		return err
 	}
 	allMetrics := append(queueMetrics, clusterMetrics...)
+	if c.eventRateTracker != nil {
+		allMetrics = append(allMetrics, c.eventRateTracker.metrics(c.clock.Now())...)
+	}
 	c.state.Store(allMetrics)
 	ctx.Debugf("Refreshed prometheus metrics in %s", time.Since(start))
 	return nil
@@ -165,8 +190,8 @@ func (c *MetricsCollector) updateQueueMetrics(ctx *armadacontext.Context) ([]pro
 			return nil, err
 		}
 
-		priorityClass := job.JobSchedulingInfo().PriorityClassName
-		resourceRequirements := job.JobSchedulingInfo().GetObjectRequirements()[0].GetPodRequirements().GetResourceRequirements().Requests
+		priorityClass := job.GetPriorityClassName()
+		resourceRequirements := job.GetResourceRequirements().Requests
 		jobResources := make(map[string]float64)
 		for key, value := range resourceRequirements {
 			jobResources[string(key)] = resource.QuantityAsFloat64(value)
@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// JobDbMetrics exposes stats of the write transactions committed to a jobDb.
+// It's a jobdb.CommitObserver; see jobDb.EnableCommitObserver.
+// It's also a jobdb.IllegalTransitionObserver; see jobDb.EnableStateMachine,
+// and a jobdb.ParameterDriftObserver; see jobDb.EnableParameterCheckpoints.
+type JobDbMetrics struct {
+	commitDuration      prometheus.Histogram
+	jobsPerCommit       prometheus.Histogram
+	jobsUpserted        prometheus.Counter
+	jobsDeleted         prometheus.Counter
+	treeCopyDuration    prometheus.Histogram
+	indexUpdateDuration prometheus.Histogram
+	illegalTransitions  *prometheus.CounterVec
+	parameterDrift      *prometheus.CounterVec
+	// If true, the tree copy and index update durations are exposed.
+	breakdown bool
+}
+
+// NewJobDbMetrics returns metrics for jobDb commits.
+// If breakdown is true, the time spent copying the jobs tree and updating indices is additionally exposed;
+// the jobDb must then be configured to measure these.
+func NewJobDbMetrics(breakdown bool) *JobDbMetrics {
+	return &JobDbMetrics{
+		commitDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "jobdb_commit_duration_seconds",
+			Help:      "Time taken to commit jobDb write transactions.",
+			Buckets:   prometheus.ExponentialBuckets(1e-6, 4, 12),
+		}),
+		jobsPerCommit: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "jobdb_jobs_per_commit",
+			Help:      "Number of jobs upserted or deleted by each committed jobDb write transaction.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+		}),
+		jobsUpserted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "jobdb_jobs_upserted_total",
+			Help:      "Jobs upserted by committed jobDb write transactions.",
+		}),
+		jobsDeleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "jobdb_jobs_deleted_total",
+			Help:      "Jobs deleted by committed jobDb write transactions.",
+		}),
+		treeCopyDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "jobdb_commit_tree_copy_duration_seconds",
+			Help:      "Time committed jobDb write transactions spent updating the tree storing jobs by id.",
+			Buckets:   prometheus.ExponentialBuckets(1e-6, 4, 12),
+		}),
+		indexUpdateDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "jobdb_commit_index_update_duration_seconds",
+			Help:      "Time committed jobDb write transactions spent updating jobDb indices, summed over indices.",
+			Buckets:   prometheus.ExponentialBuckets(1e-6, 4, 12),
+		}),
+		illegalTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "jobdb_illegal_state_transitions_total",
+			Help:      "Illegal job and run state transitions, by whether they were refused.",
+		}, []string{"entity", "from", "to", "enforced"}),
+		parameterDrift: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "jobdb_parameter_drift_total",
+			Help:      "Jobs whose parameters, as derived from their scheduling info when they were loaded or updated, differed from those checkpointed when they were admitted, by parameter.",
+		}, []string{"parameter"}),
+		breakdown: breakdown,
+	}
+}
+
+func (m *JobDbMetrics) ObserveCommit(stats jobdb.CommitStats) {
+	m.commitDuration.Observe(stats.Duration.Seconds())
+	m.jobsPerCommit.Observe(float64(stats.NumUpserted + stats.NumDeleted))
+	m.jobsUpserted.Add(float64(stats.NumUpserted))
+	m.jobsDeleted.Add(float64(stats.NumDeleted))
+	if m.breakdown {
+		m.treeCopyDuration.Observe(stats.TreeCopyDuration.Seconds())
+		m.indexUpdateDuration.Observe(stats.IndexUpdateDuration.Seconds())
+	}
+}
+
+func (m *JobDbMetrics) ObserveIllegalTransition(err *jobdb.IllegalTransitionError) {
+	m.illegalTransitions.WithLabelValues(err.Entity, err.From, err.To, strconv.FormatBool(err.Enforced)).Inc()
+}
+
+func (m *JobDbMetrics) ObserveParameterDrift(numJobsByParameter map[string]int) {
+	for _, parameter := range jobdb.EffectiveParameterNames {
+		m.parameterDrift.WithLabelValues(parameter).Add(float64(numJobsByParameter[parameter]))
+	}
+}
+
+func (m *JobDbMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.commitDuration.Describe(ch)
+	m.jobsPerCommit.Describe(ch)
+	m.jobsUpserted.Describe(ch)
+	m.jobsDeleted.Describe(ch)
+	m.illegalTransitions.Describe(ch)
+	m.parameterDrift.Describe(ch)
+	if m.breakdown {
+		m.treeCopyDuration.Describe(ch)
+		m.indexUpdateDuration.Describe(ch)
+	}
+}
+
+func (m *JobDbMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.commitDuration.Collect(ch)
+	m.jobsPerCommit.Collect(ch)
+	m.jobsUpserted.Collect(ch)
+	m.jobsDeleted.Collect(ch)
+	m.illegalTransitions.Collect(ch)
+	m.parameterDrift.Collect(ch)
+	if m.breakdown {
+		m.treeCopyDuration.Collect(ch)
+		m.indexUpdateDuration.Collect(ch)
+	}
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestJobDbMetrics(t *testing.T) {
+	for name, breakdown := range map[string]bool{"withoutBreakdown": false, "withBreakdown": true} {
+		t.Run(name, func(t *testing.T) {
+			m := NewJobDbMetrics(breakdown)
+			jobDb := testfixtures.NewJobDb()
+			jobDb.EnableCommitObserver(m, breakdown)
+
+			jobs := testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 10)
+			txn := jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert(jobs))
+			txn.Commit()
+			txn = jobDb.WriteTxn()
+			require.NoError(t, txn.BatchDelete([]string{jobs[0].Id(), jobs[1].Id(), jobs[2].Id()}))
+			txn.Commit()
+
+			assert.Equal(t, 10.0, testutil.ToFloat64(m.jobsUpserted))
+			assert.Equal(t, 3.0, testutil.ToFloat64(m.jobsDeleted))
+			assert.Equal(t, 1, testutil.CollectAndCount(m, "armada_scheduler_jobdb_commit_duration_seconds"))
+			assert.Equal(t, 1, testutil.CollectAndCount(m, "armada_scheduler_jobdb_jobs_per_commit"))
+			if breakdown {
+				assert.Equal(t, 1, testutil.CollectAndCount(m, "armada_scheduler_jobdb_commit_tree_copy_duration_seconds"))
+			} else {
+				assert.Equal(t, 0, testutil.CollectAndCount(m, "armada_scheduler_jobdb_commit_tree_copy_duration_seconds"))
+			}
+		})
+	}
+}
+
+func TestJobDbMetrics_IllegalTransitions(t *testing.T) {
+	m := NewJobDbMetrics(false)
+	m.ObserveIllegalTransition(&jobdb.IllegalTransitionError{Entity: "job", From: "succeeded", To: "invalid", Enforced: true})
+	m.ObserveIllegalTransition(&jobdb.IllegalTransitionError{Entity: "job", From: "succeeded", To: "invalid", Enforced: true})
+	m.ObserveIllegalTransition(&jobdb.IllegalTransitionError{Entity: "run", From: "running", To: "leased"})
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.illegalTransitions.WithLabelValues("job", "succeeded", "invalid", "true")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.illegalTransitions.WithLabelValues("run", "running", "leased", "false")))
+}
+
+func TestJobDbMetrics_ParameterDrift(t *testing.T) {
+	m := NewJobDbMetrics(false)
+	m.ObserveParameterDrift(map[string]int{jobdb.GangParameter: 2})
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.parameterDrift.WithLabelValues(jobdb.GangParameter)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.parameterDrift.WithLabelValues(jobdb.QueueTtlParameter)))
+	assert.Equal(t, len(jobdb.EffectiveParameterNames), testutil.CollectAndCount(m, "armada_scheduler_jobdb_parameter_drift_total"))
+
+	// Jobs found drifting by later checks are added.
+	m.ObserveParameterDrift(map[string]int{jobdb.GangParameter: 1})
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.parameterDrift.WithLabelValues(jobdb.GangParameter)))
+}
@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// MetricsRegistry is a prometheus.Registerer through which all scheduler metrics are registered.
+// Collectors are registered with an underlying registerer, e.g., the default one, such that they're exposed as usual,
+// as well as with a registry of their own, from which a catalogue of the metrics exposed by the scheduler is generated.
+type MetricsRegistry struct {
+	registerer prometheus.Registerer
+	registry   *prometheus.Registry
+}
+
+// MetricDescription describes a metric exposed by the scheduler.
+type MetricDescription struct {
+	Name string `json:"name"`
+	// One of counter, gauge, summary, histogram, or untyped.
+	Type string `json:"type"`
+	Help string `json:"help"`
+	// Keys of the labels of the current series of the metric, sorted.
+	Labels []string `json:"labels"`
+	// Labels of each current series of the metric.
+	LabelSets []map[string]string `json:"labelSets"`
+}
+
+func NewMetricsRegistry(registerer prometheus.Registerer) *MetricsRegistry {
+	return &MetricsRegistry{
+		registerer: registerer,
+		registry:   prometheus.NewRegistry(),
+	}
+}
+
+// Register registers c with both the underlying registerer and the registry of scheduler metrics.
+func (r *MetricsRegistry) Register(c prometheus.Collector) error {
+	if err := r.registry.Register(c); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := r.registerer.Register(c); err != nil {
+		r.registry.Unregister(c)
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// MustRegister registers the provided collectors, panicking on the first error.
+func (r *MetricsRegistry) MustRegister(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Unregister unregisters c from both the underlying registerer and the registry of scheduler metrics.
+func (r *MetricsRegistry) Unregister(c prometheus.Collector) bool {
+	unregistered := r.registerer.Unregister(c)
+	return r.registry.Unregister(c) && unregistered
+}
+
+// Catalogue returns a description of each metric exposed by the scheduler, sorted by name,
+// generated from the current series of the registered collectors.
+// Hence, metrics without any series, e.g., vectors no labels have been observed for yet, aren't included.
+func (r *MetricsRegistry) Catalogue() ([]MetricDescription, error) {
+	families, err := r.registry.Gather()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	catalogue := make([]MetricDescription, len(families))
+	for i, family := range families {
+		labels := make(map[string]bool)
+		labelSets := make([]map[string]string, len(family.GetMetric()))
+		for j, metric := range family.GetMetric() {
+			labelSet := make(map[string]string, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labelSet[label.GetName()] = label.GetValue()
+				labels[label.GetName()] = true
+			}
+			labelSets[j] = labelSet
+		}
+		labelKeys := maps.Keys(labels)
+		slices.Sort(labelKeys)
+		catalogue[i] = MetricDescription{
+			Name:      family.GetName(),
+			Type:      strings.ToLower(family.GetType().String()),
+			Help:      family.GetHelp(),
+			Labels:    labelKeys,
+			LabelSets: labelSets,
+		}
+	}
+	return catalogue, nil
+}
+
+// ServeHTTP responds with the catalogue of scheduler metrics, encoded as json.
+func (r *MetricsRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	catalogue, err := r.Catalogue()
+	if err != nil {
+		log.Warnf("Failed to generate metrics catalogue: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(catalogue); err != nil {
+		log.Errorf("Failed to write metrics catalogue response: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+)
+
+func TestMetricsRegistry_Catalogue(t *testing.T) {
+	underlying := prometheus.NewRegistry()
+	registry := NewMetricsRegistry(underlying)
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 10},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 10},
+	}, registry)
+	metrics.ReportExecutorStaleness("executor-1", time.Minute, false)
+	metrics.ReportExecutorStaleness("executor-2", time.Minute, true)
+	metrics.ReportSerialRegression("jobs")
+
+	// Metrics are also registered with the underlying registerer.
+	underlyingFamilies, err := underlying.Gather()
+	require.NoError(t, err)
+	assert.NotEmpty(t, underlyingFamilies)
+
+	// Collectors of metrics already registered are rejected.
+	require.NoError(t, registry.Register(NewLeaderStatusMetricsCollector("instance")))
+	assert.Error(t, registry.Register(NewLeaderStatusMetricsCollector("instance")))
+
+	catalogue, err := registry.Catalogue()
+	require.NoError(t, err)
+	descriptionsByName := make(map[string]MetricDescription, len(catalogue))
+	for _, description := range catalogue {
+		descriptionsByName[description.Name] = description
+	}
+	assert.Equal(
+		t,
+		MetricDescription{
+			Name:   "armada_scheduler_stale_executors",
+			Type:   "gauge",
+			Help:   "1 if an executor hasn't provided a heartbeat within its timeout and 0 otherwise.",
+			Labels: []string{"executor"},
+			LabelSets: []map[string]string{
+				{"executor": "executor-1"},
+				{"executor": "executor-2"},
+			},
+		},
+		descriptionsByName["armada_scheduler_stale_executors"],
+	)
+	assert.Equal(t, "counter", descriptionsByName["armada_scheduler_serial_regressions"].Type)
+	assert.Equal(t, []string{"table"}, descriptionsByName["armada_scheduler_serial_regressions"].Labels)
+	assert.Equal(t, "armada_scheduler_leader_status", descriptionsByName["armada_scheduler_leader_status"].Name)
+
+	// The catalogue is served as json.
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics/catalogue", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var served []MetricDescription
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&served))
+	assert.Equal(t, catalogue, served)
+}
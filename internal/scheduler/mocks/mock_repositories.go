@@ -106,6 +106,20 @@ func (m *MockQueueRepository) EXPECT() *MockQueueRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateQueue mocks base method.
+func (m *MockQueueRepository) CreateQueue(arg0 *database.Queue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQueue", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateQueue indicates an expected call of CreateQueue.
+func (mr *MockQueueRepositoryMockRecorder) CreateQueue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueue", reflect.TypeOf((*MockQueueRepository)(nil).CreateQueue), arg0)
+}
+
 // GetAllQueues mocks base method.
 func (m *MockQueueRepository) GetAllQueues() ([]*database.Queue, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +158,21 @@ func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountJobRunLeases mocks base method.
+func (m *MockJobRepository) CountJobRunLeases(arg0 *armadacontext.Context, arg1 string, arg2 []uuid.UUID) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountJobRunLeases", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountJobRunLeases indicates an expected call of CountJobRunLeases.
+func (mr *MockJobRepositoryMockRecorder) CountJobRunLeases(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountJobRunLeases", reflect.TypeOf((*MockJobRepository)(nil).CountJobRunLeases), arg0, arg1, arg2)
+}
+
 // CountReceivedPartitions mocks base method.
 func (m *MockJobRepository) CountReceivedPartitions(arg0 *armadacontext.Context, arg1 uuid.UUID) (uint32, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +188,21 @@ func (mr *MockJobRepositoryMockRecorder) CountReceivedPartitions(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReceivedPartitions", reflect.TypeOf((*MockJobRepository)(nil).CountReceivedPartitions), arg0, arg1)
 }
 
+// FetchJob mocks base method.
+func (m *MockJobRepository) FetchJob(arg0 *armadacontext.Context, arg1 string) (*database.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchJob", arg0, arg1)
+	ret0, _ := ret[0].(*database.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJob indicates an expected call of FetchJob.
+func (mr *MockJobRepositoryMockRecorder) FetchJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJob", reflect.TypeOf((*MockJobRepository)(nil).FetchJob), arg0, arg1)
+}
+
 // FetchJobRunErrors mocks base method.
 func (m *MockJobRepository) FetchJobRunErrors(arg0 *armadacontext.Context, arg1 []uuid.UUID) (map[uuid.UUID]*armadaevents.Error, error) {
 	m.ctrl.T.Helper()
@@ -189,6 +233,51 @@ func (mr *MockJobRepositoryMockRecorder) FetchJobRunLeases(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJobRunLeases", reflect.TypeOf((*MockJobRepository)(nil).FetchJobRunLeases), arg0, arg1, arg2, arg3)
 }
 
+// FetchJobRunLeasesByRunId mocks base method.
+func (m *MockJobRepository) FetchJobRunLeasesByRunId(arg0 *armadacontext.Context, arg1 string, arg2 []uuid.UUID) ([]*database.JobRunLease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchJobRunLeasesByRunId", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*database.JobRunLease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJobRunLeasesByRunId indicates an expected call of FetchJobRunLeasesByRunId.
+func (mr *MockJobRepositoryMockRecorder) FetchJobRunLeasesByRunId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJobRunLeasesByRunId", reflect.TypeOf((*MockJobRepository)(nil).FetchJobRunLeasesByRunId), arg0, arg1, arg2)
+}
+
+// FetchJobRunResourceUsageUpdates mocks base method.
+func (m *MockJobRepository) FetchJobRunResourceUsageUpdates(arg0 *armadacontext.Context, arg1 int64) ([]database.JobRunResourceUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchJobRunResourceUsageUpdates", arg0, arg1)
+	ret0, _ := ret[0].([]database.JobRunResourceUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJobRunResourceUsageUpdates indicates an expected call of FetchJobRunResourceUsageUpdates.
+func (mr *MockJobRepositoryMockRecorder) FetchJobRunResourceUsageUpdates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJobRunResourceUsageUpdates", reflect.TypeOf((*MockJobRepository)(nil).FetchJobRunResourceUsageUpdates), arg0, arg1)
+}
+
+// FetchJobRunSpecVersions mocks base method.
+func (m *MockJobRepository) FetchJobRunSpecVersions(arg0 *armadacontext.Context, arg1 string, arg2 []uuid.UUID) ([]database.JobRunSpecVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchJobRunSpecVersions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]database.JobRunSpecVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJobRunSpecVersions indicates an expected call of FetchJobRunSpecVersions.
+func (mr *MockJobRepositoryMockRecorder) FetchJobRunSpecVersions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJobRunSpecVersions", reflect.TypeOf((*MockJobRepository)(nil).FetchJobRunSpecVersions), arg0, arg1, arg2)
+}
+
 // FetchJobUpdates mocks base method.
 func (m *MockJobRepository) FetchJobUpdates(arg0 *armadacontext.Context, arg1, arg2 int64) ([]database.Job, []database.Run, error) {
 	m.ctrl.T.Helper()
@@ -205,6 +294,37 @@ func (mr *MockJobRepositoryMockRecorder) FetchJobUpdates(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJobUpdates", reflect.TypeOf((*MockJobRepository)(nil).FetchJobUpdates), arg0, arg1, arg2)
 }
 
+// FetchMaxSerials mocks base method.
+func (m *MockJobRepository) FetchMaxSerials(arg0 *armadacontext.Context) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMaxSerials", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchMaxSerials indicates an expected call of FetchMaxSerials.
+func (mr *MockJobRepositoryMockRecorder) FetchMaxSerials(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMaxSerials", reflect.TypeOf((*MockJobRepository)(nil).FetchMaxSerials), arg0)
+}
+
+// FetchOldestUnprocessedUpdateTime mocks base method.
+func (m *MockJobRepository) FetchOldestUnprocessedUpdateTime(arg0 *armadacontext.Context, arg1, arg2 int64) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchOldestUnprocessedUpdateTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchOldestUnprocessedUpdateTime indicates an expected call of FetchOldestUnprocessedUpdateTime.
+func (mr *MockJobRepositoryMockRecorder) FetchOldestUnprocessedUpdateTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOldestUnprocessedUpdateTime", reflect.TypeOf((*MockJobRepository)(nil).FetchOldestUnprocessedUpdateTime), arg0, arg1, arg2)
+}
+
 // FindInactiveRuns mocks base method.
 func (m *MockJobRepository) FindInactiveRuns(arg0 *armadacontext.Context, arg1 []uuid.UUID) ([]uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -219,3 +339,32 @@ func (mr *MockJobRepositoryMockRecorder) FindInactiveRuns(arg0, arg1 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInactiveRuns", reflect.TypeOf((*MockJobRepository)(nil).FindInactiveRuns), arg0, arg1)
 }
+
+// FindTerminalRuns mocks base method.
+func (m *MockJobRepository) FindTerminalRuns(arg0 *armadacontext.Context, arg1 []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTerminalRuns", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTerminalRuns indicates an expected call of FindTerminalRuns.
+func (mr *MockJobRepositoryMockRecorder) FindTerminalRuns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTerminalRuns", reflect.TypeOf((*MockJobRepository)(nil).FindTerminalRuns), arg0, arg1)
+}
+
+// StoreJobRunResourceUsage mocks base method.
+func (m *MockJobRepository) StoreJobRunResourceUsage(arg0 *armadacontext.Context, arg1 []database.JobRunResourceUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreJobRunResourceUsage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreJobRunResourceUsage indicates an expected call of StoreJobRunResourceUsage.
+func (mr *MockJobRepositoryMockRecorder) StoreJobRunResourceUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreJobRunResourceUsage", reflect.TypeOf((*MockJobRepository)(nil).StoreJobRunResourceUsage), arg0, arg1)
+}
@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// NodeQuarantine correlates run failures across nodes and quarantines nodes on which many runs fail within a short
+// period of time, since such failures are more likely caused by the node than by the jobs.
+// No new jobs are scheduled on quarantined nodes and their executor is asked to taint them.
+//
+// Nodes are released once they've been quarantined for the configured duration,
+// or once a run on them succeeds, e.g., a run that was already running when the node was quarantined.
+//
+// It's shared by the scheduler, which records run outcomes, the scheduling algorithm, which skips quarantined nodes,
+// and the executor api, which tells executors which of their nodes are quarantined.
+type NodeQuarantine struct {
+	// Number of failures within window at which a node is quarantined.
+	failureThreshold int
+	// Failures older than this are no longer counted.
+	window time.Duration
+	// How long nodes remain quarantined for. If zero, nodes are only released once a run on them succeeds.
+	duration time.Duration
+	// Key of the taint executors are asked to add to quarantined nodes.
+	taintKey string
+	// If non-nil, used to classify run errors.
+	runErrorClassifier *RunErrorClassifier
+	// Runs failing with errors matching these classifications aren't counted against their node.
+	excludedErrorClassifications map[string]bool
+	// If non-nil, the set of quarantined nodes is reported here.
+	metrics *SchedulerMetrics
+	// Times at which runs failed on each node, in ascending order.
+	failureTimesByNode map[nodeQuarantineKey][]time.Time
+	// Time at which each quarantined node was quarantined.
+	quarantinedAtByNode map[nodeQuarantineKey]time.Time
+	// Protects the above maps.
+	mu sync.Mutex
+}
+
+// EnableNodeQuarantine causes the outcome of each run to be recorded by q,
+// such that nodes on which many runs fail are quarantined.
+func (s *Scheduler) EnableNodeQuarantine(q *NodeQuarantine) {
+	s.nodeQuarantine = q
+}
+
+type nodeQuarantineKey struct {
+	executorId string
+	nodeName   string
+}
+
+func NewNodeQuarantine(config schedulerconfig.NodeQuarantineConfig, runErrorClassifier *RunErrorClassifier, metrics *SchedulerMetrics) (*NodeQuarantine, error) {
+	excludedErrorClassifications := make(map[string]bool, len(config.ExcludedErrorClassifications))
+	for _, name := range config.ExcludedErrorClassifications {
+		if runErrorClassifier == nil || !runErrorClassifier.hasRule(name) {
+			return nil, errors.Errorf("node quarantine excludes unknown run error classification %s", name)
+		}
+		excludedErrorClassifications[name] = true
+	}
+	if config.FailureThreshold < 1 {
+		config.FailureThreshold = 1
+	}
+	return &NodeQuarantine{
+		failureThreshold:             config.FailureThreshold,
+		window:                       config.Window,
+		duration:                     config.Duration,
+		taintKey:                     config.TaintKey,
+		runErrorClassifier:           runErrorClassifier,
+		excludedErrorClassifications: excludedErrorClassifications,
+		metrics:                      metrics,
+		failureTimesByNode:           make(map[nodeQuarantineKey][]time.Time),
+		quarantinedAtByNode:          make(map[nodeQuarantineKey]time.Time),
+	}, nil
+}
+
+// RecordRunFailure records that a run failed at time now on the given node with the given error,
+// quarantining the node if this brings the number of failures on it within the window up to the threshold.
+// Failures with errors matching an excluded classification, i.e., failures caused by the job, are ignored.
+func (q *NodeQuarantine) RecordRunFailure(ctx *armadacontext.Context, now time.Time, executorId, nodeName string, runError *armadaevents.Error) {
+	if nodeName == "" {
+		return
+	}
+	if q.runErrorClassifier != nil && len(q.excludedErrorClassifications) > 0 {
+		if classification := q.runErrorClassifier.Classify(runError); classification != nil && q.excludedErrorClassifications[classification.Name] {
+			return
+		}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	key := nodeQuarantineKey{executorId: executorId, nodeName: nodeName}
+	failureTimes := append(q.pruneFailureTimes(q.failureTimesByNode[key], now), now)
+	q.failureTimesByNode[key] = failureTimes
+	if _, ok := q.quarantinedAtByNode[key]; ok || len(failureTimes) < q.failureThreshold {
+		return
+	}
+	q.quarantinedAtByNode[key] = now
+	if q.metrics != nil {
+		q.metrics.ReportNodeQuarantine(executorId, nodeName, true)
+	}
+	if q.duration > 0 {
+		ctx.Errorf(
+			"QUARANTINED NODE %s of executor %s: %d runs failed on it within %s; no jobs will be scheduled on it for %s",
+			nodeName, executorId, len(failureTimes), q.window, q.duration,
+		)
+	} else {
+		ctx.Errorf(
+			"QUARANTINED NODE %s of executor %s: %d runs failed on it within %s; no jobs will be scheduled on it until a run on it succeeds",
+			nodeName, executorId, len(failureTimes), q.window,
+		)
+	}
+}
+
+// RecordRunSuccess records that a run succeeded on the given node,
+// which resets its failure count and releases it from quarantine if it's quarantined.
+func (q *NodeQuarantine) RecordRunSuccess(ctx *armadacontext.Context, executorId, nodeName string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	key := nodeQuarantineKey{executorId: executorId, nodeName: nodeName}
+	delete(q.failureTimesByNode, key)
+	if _, ok := q.quarantinedAtByNode[key]; ok {
+		q.release(key)
+		ctx.Warnf("released node %s of executor %s from quarantine since a run on it succeeded", nodeName, executorId)
+	}
+}
+
+// ReleaseExpired releases any node that has been quarantined for longer than the quarantine duration
+// and discards failures that have dropped out of the window.
+func (q *NodeQuarantine) ReleaseExpired(ctx *armadacontext.Context, now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.duration > 0 {
+		for key, quarantinedAt := range q.quarantinedAtByNode {
+			if now.Sub(quarantinedAt) >= q.duration {
+				q.release(key)
+				delete(q.failureTimesByNode, key)
+				ctx.Warnf("released node %s of executor %s from quarantine after %s", key.nodeName, key.executorId, now.Sub(quarantinedAt))
+			}
+		}
+	}
+	for key, failureTimes := range q.failureTimesByNode {
+		if failureTimes = q.pruneFailureTimes(failureTimes, now); len(failureTimes) == 0 {
+			delete(q.failureTimesByNode, key)
+		} else {
+			q.failureTimesByNode[key] = failureTimes
+		}
+	}
+}
+
+// IsQuarantined returns true if the given node is quarantined.
+func (q *NodeQuarantine) IsQuarantined(executorId, nodeName string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.quarantinedAtByNode[nodeQuarantineKey{executorId: executorId, nodeName: nodeName}]
+	return ok
+}
+
+// QuarantinedNodes returns the sorted names of the quarantined nodes of the given executor.
+func (q *NodeQuarantine) QuarantinedNodes(executorId string) []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	nodeNames := make([]string, 0)
+	for key := range q.quarantinedAtByNode {
+		if key.executorId == executorId {
+			nodeNames = append(nodeNames, key.nodeName)
+		}
+	}
+	slices.Sort(nodeNames)
+	return nodeNames
+}
+
+// TaintKey returns the key of the taint executors are asked to add to quarantined nodes.
+func (q *NodeQuarantine) TaintKey() string {
+	return q.taintKey
+}
+
+// release removes key from quarantine. The caller must hold q.mu.
+func (q *NodeQuarantine) release(key nodeQuarantineKey) {
+	delete(q.quarantinedAtByNode, key)
+	if q.metrics != nil {
+		q.metrics.ReportNodeQuarantine(key.executorId, key.nodeName, false)
+	}
+}
+
+// pruneFailureTimes returns the suffix of failureTimes that is within the window of now.
+func (q *NodeQuarantine) pruneFailureTimes(failureTimes []time.Time, now time.Time) []time.Time {
+	if q.window <= 0 {
+		return failureTimes
+	}
+	cutoff := now.Add(-q.window)
+	i := 0
+	for i < len(failureTimes) && !failureTimes[i].After(cutoff) {
+		i++
+	}
+	return failureTimes[i:]
+}
@@ -0,0 +1,307 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+var testNodeQuarantineConfig = schedulerconfig.NodeQuarantineConfig{
+	Enabled:          true,
+	FailureThreshold: 3,
+	Window:           10 * time.Minute,
+	Duration:         time.Hour,
+	TaintKey:         "armadaproject.io/quarantined",
+}
+
+func TestNodeQuarantine_FailuresPastThreshold(t *testing.T) {
+	ctx := armadacontext.Background()
+	q, err := NewNodeQuarantine(testNodeQuarantineConfig, nil, schedulerMetrics)
+	require.NoError(t, err)
+	quarantinedMetric := func(executorId, nodeName string) float64 {
+		return testutil.ToFloat64(schedulerMetrics.quarantinedNodes.WithLabelValues(executorId, nodeName))
+	}
+
+	now := testfixtures.BaseTime
+	for i := 0; i < testNodeQuarantineConfig.FailureThreshold-1; i++ {
+		q.RecordRunFailure(ctx, now, "executor-1", "node-1", defaultJobRunError)
+		now = now.Add(time.Minute)
+	}
+	assert.False(t, q.IsQuarantined("executor-1", "node-1"))
+	assert.Empty(t, q.QuarantinedNodes("executor-1"))
+
+	// Failures on other nodes, including nodes with the same name of other executors, aren't counted.
+	q.RecordRunFailure(ctx, now, "executor-1", "node-2", defaultJobRunError)
+	q.RecordRunFailure(ctx, now, "executor-2", "node-1", defaultJobRunError)
+	assert.False(t, q.IsQuarantined("executor-1", "node-1"))
+
+	q.RecordRunFailure(ctx, now, "executor-1", "node-1", defaultJobRunError)
+	assert.True(t, q.IsQuarantined("executor-1", "node-1"))
+	assert.False(t, q.IsQuarantined("executor-1", "node-2"))
+	assert.False(t, q.IsQuarantined("executor-2", "node-1"))
+	assert.Equal(t, []string{"node-1"}, q.QuarantinedNodes("executor-1"))
+	assert.Empty(t, q.QuarantinedNodes("executor-2"))
+	assert.Equal(t, float64(1), quarantinedMetric("executor-1", "node-1"))
+
+	// Releasing the node resets the metric.
+	q.RecordRunSuccess(ctx, "executor-1", "node-1")
+	assert.False(t, q.IsQuarantined("executor-1", "node-1"))
+	assert.Equal(t, float64(0), quarantinedMetric("executor-1", "node-1"))
+}
+
+func TestNodeQuarantine_Window(t *testing.T) {
+	ctx := armadacontext.Background()
+	q, err := NewNodeQuarantine(testNodeQuarantineConfig, nil, nil)
+	require.NoError(t, err)
+
+	// Failures spaced further apart than the window never add up to the threshold.
+	now := testfixtures.BaseTime
+	for i := 0; i < 10; i++ {
+		q.RecordRunFailure(ctx, now, "executor-1", "node-1", defaultJobRunError)
+		now = now.Add(testNodeQuarantineConfig.Window / 2)
+		q.ReleaseExpired(ctx, now)
+	}
+	assert.False(t, q.IsQuarantined("executor-1", "node-1"))
+
+	// Whereas failures within the window do.
+	for i := 0; i < testNodeQuarantineConfig.FailureThreshold-1; i++ {
+		q.RecordRunFailure(ctx, now, "executor-1", "node-1", defaultJobRunError)
+	}
+	assert.True(t, q.IsQuarantined("executor-1", "node-1"))
+}
+
+func TestNodeQuarantine_Release(t *testing.T) {
+	tests := map[string]struct {
+		duration time.Duration
+		// Time after being quarantined at which the node is checked.
+		elapsed time.Duration
+		// If true, a run succeeds on the node before it's checked.
+		runSucceeds    bool
+		expectReleased bool
+	}{
+		"not yet expired": {
+			duration: time.Hour,
+			elapsed:  59 * time.Minute,
+		},
+		"expired": {
+			duration:       time.Hour,
+			elapsed:        time.Hour,
+			expectReleased: true,
+		},
+		"no duration never expires": {
+			elapsed: 24 * time.Hour,
+		},
+		"successful run releases the node": {
+			duration:       time.Hour,
+			runSucceeds:    true,
+			expectReleased: true,
+		},
+		"successful run releases the node if there's no duration": {
+			runSucceeds:    true,
+			expectReleased: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := armadacontext.Background()
+			config := testNodeQuarantineConfig
+			config.Duration = tc.duration
+			config.Window = 0
+			q, err := NewNodeQuarantine(config, nil, nil)
+			require.NoError(t, err)
+
+			for i := 0; i < config.FailureThreshold; i++ {
+				q.RecordRunFailure(ctx, testfixtures.BaseTime, "executor-1", "node-1", defaultJobRunError)
+			}
+			require.True(t, q.IsQuarantined("executor-1", "node-1"))
+
+			if tc.runSucceeds {
+				q.RecordRunSuccess(ctx, "executor-1", "node-1")
+			}
+			q.ReleaseExpired(ctx, testfixtures.BaseTime.Add(tc.elapsed))
+			assert.Equal(t, !tc.expectReleased, q.IsQuarantined("executor-1", "node-1"))
+
+			// Released nodes start again from zero failures.
+			if tc.expectReleased {
+				q.RecordRunFailure(ctx, testfixtures.BaseTime.Add(tc.elapsed), "executor-1", "node-1", defaultJobRunError)
+				assert.False(t, q.IsQuarantined("executor-1", "node-1"))
+			}
+		})
+	}
+}
+
+func TestNodeQuarantine_ExcludedErrorClassifications(t *testing.T) {
+	ctx := armadacontext.Background()
+	classifier, err := NewRunErrorClassifier(testRunErrorClassificationRules)
+	require.NoError(t, err)
+	config := testNodeQuarantineConfig
+	config.ExcludedErrorClassifications = []string{"oom"}
+	q, err := NewNodeQuarantine(config, classifier, nil)
+	require.NoError(t, err)
+
+	// Failures caused by the job aren't counted against the node.
+	for i := 0; i < 2*config.FailureThreshold; i++ {
+		q.RecordRunFailure(ctx, testfixtures.BaseTime, "executor-1", "node-1", oomKilledJobRunError)
+	}
+	assert.False(t, q.IsQuarantined("executor-1", "node-1"))
+
+	// Other failures are, including those matching classifications that aren't excluded.
+	q.RecordRunFailure(ctx, testfixtures.BaseTime, "executor-1", "node-1", defaultJobRunError)
+	q.RecordRunFailure(ctx, testfixtures.BaseTime, "executor-1", "node-1", nodePreemptedJobRunError)
+	q.RecordRunFailure(ctx, testfixtures.BaseTime, "executor-1", "node-1", nil)
+	assert.True(t, q.IsQuarantined("executor-1", "node-1"))
+}
+
+func TestNewNodeQuarantine_UnknownErrorClassification(t *testing.T) {
+	classifier, err := NewRunErrorClassifier(testRunErrorClassificationRules)
+	require.NoError(t, err)
+	config := testNodeQuarantineConfig
+	config.ExcludedErrorClassifications = []string{"does-not-exist"}
+	_, err = NewNodeQuarantine(config, classifier, nil)
+	assert.Error(t, err)
+
+	config.ExcludedErrorClassifications = []string{"oom"}
+	_, err = NewNodeQuarantine(config, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestScheduler_NodeQuarantine(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+	jobRepo := &testJobRepository{}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{
+			executors: []*schedulerobjects.Executor{{Id: "testExecutor", LastUpdateTime: testClock.Now().Add(24 * time.Hour)}},
+		},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+	config := testNodeQuarantineConfig
+	config.FailureThreshold = 2
+	q, err := NewNodeQuarantine(config, nil, nil)
+	require.NoError(t, err)
+	sched.EnableNodeQuarantine(q)
+
+	// failRunOnNode leases a new job to "node" and fails its run in the next cycle.
+	serial := int64(0)
+	failRunOnNode := func(runAttempted bool) {
+		job := testfixtures.JobDb.NewJob(
+			util.NewULID(), "testJobset", "testQueue", uint32(10), schedulingInfo, false, 2, false, false, false, 1,
+		).WithNewRun("testExecutor", "test-node", "node", 5, testfixtures.BaseTime)
+		txn := sched.jobDb.WriteTxn()
+		require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+		txn.Commit()
+		serial++
+		jobRepo.updatedRuns = []database.Run{{
+			RunID:        job.LatestRun().Id(),
+			JobID:        job.Id(),
+			JobSet:       "testJobSet",
+			Executor:     "testExecutor",
+			Node:         "node",
+			Failed:       true,
+			RunAttempted: runAttempted,
+			Serial:       serial,
+		}}
+		jobRepo.errors = map[uuid.UUID]*armadaevents.Error{job.LatestRun().Id(): defaultJobRunError}
+		_, err := sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+		require.NoError(t, err)
+	}
+
+	// Runs that never started aren't counted against their node.
+	for i := 0; i < 2*config.FailureThreshold; i++ {
+		failRunOnNode(false)
+	}
+	assert.False(t, q.IsQuarantined("testExecutor", "node"))
+
+	for i := 0; i < config.FailureThreshold; i++ {
+		failRunOnNode(true)
+	}
+	assert.True(t, q.IsQuarantined("testExecutor", "node"))
+
+	// The quarantine is lifted by the first cycle after it expires.
+	testClock.Step(config.Duration)
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.False(t, q.IsQuarantined("testExecutor", "node"))
+}
+
+func TestFairSchedulingAlgo_NodeQuarantine(t *testing.T) {
+	ctx := armadacontext.Background()
+	schedulingConfig := testfixtures.TestSchedulingConfig()
+	algo, err := NewFairSchedulingAlgo(schedulingConfig, time.Second*5, nil, nil, nil)
+	require.NoError(t, err)
+	q, err := NewNodeQuarantine(schedulerconfig.NodeQuarantineConfig{FailureThreshold: 1}, nil, nil)
+	require.NoError(t, err)
+	algo.EnableNodeQuarantine(q)
+
+	nodes := testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)
+	for _, node := range nodes {
+		node.Executor = "executor-1"
+	}
+	q.RecordRunFailure(ctx, testfixtures.BaseTime, "executor-1", nodes[0].Name, defaultJobRunError)
+
+	nodeDb, err := nodedb.NewNodeDb(
+		schedulingConfig.Preemption.PriorityClasses,
+		schedulingConfig.MaxExtraNodesToConsider,
+		schedulingConfig.IndexedResources,
+		schedulingConfig.IndexedTaints,
+		schedulingConfig.IndexedNodeLabels,
+		schedulingConfig.WellKnownNodeTypes,
+	)
+	require.NoError(t, err)
+	require.NoError(t, algo.addExecutorToNodeDb(nodeDb, nil, nodes, testfixtures.TestPool))
+
+	isUnschedulable := func(nodeId string) bool {
+		node, err := nodeDb.GetNode(nodeId)
+		require.NoError(t, err)
+		unschedulableTaint := nodedb.UnschedulableTaint()
+		return slices.IndexFunc(node.Taints, func(taint v1.Taint) bool { return taint.MatchTaint(&unschedulableTaint) }) != -1
+	}
+	assert.True(t, isUnschedulable(nodes[0].Id))
+	assert.False(t, isUnschedulable(nodes[1].Id))
+	// The nodes provided aren't mutated.
+	assert.False(t, nodes[0].Unschedulable)
+
+	// No new jobs are scheduled onto quarantined nodes.
+	jobs := testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1)
+	jctxs := schedulercontext.JobSchedulingContextsFromJobs(schedulingConfig.Preemption.PriorityClasses, jobs, GangIdAndCardinalityFromAnnotations)
+	txn := nodeDb.Txn(true)
+	defer txn.Abort()
+	ok, err := nodeDb.ScheduleManyWithTxn(txn, jctxs)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, nodes[1].Id, jctxs[0].PodSchedulingContext.NodeId)
+}
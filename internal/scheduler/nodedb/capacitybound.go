@@ -0,0 +1,108 @@
+package nodedb
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-memdb"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// CapacityBound is an upper bound on the resources a single pod may be bound to a node with.
+// Nodes are grouped by their taints and, for each group and priority, the bound is the element-wise maximum
+// over all nodes in the group of the resources allocatable at that priority.
+//
+// Binding jobs to nodes only ever decreases the resources allocatable at non-negative priorities.
+// Hence, a bound computed after evicting jobs remains valid until more jobs are evicted.
+type CapacityBound struct {
+	groups []*capacityBoundGroup
+}
+
+// capacityBoundGroup is the bound of a set of nodes with identical taints.
+type capacityBoundGroup struct {
+	taints                   []v1.Taint
+	maxAllocatableByPriority map[int32]schedulerobjects.ResourceList
+}
+
+// CapacityBoundWithTxn computes a CapacityBound from the nodes in the db as seen by txn.
+func (nodeDb *NodeDb) CapacityBoundWithTxn(txn *memdb.Txn) (*CapacityBound, error) {
+	it, err := NewNodesIterator(txn)
+	if err != nil {
+		return nil, err
+	}
+	groupByTaints := make(map[string]*capacityBoundGroup)
+	var groups []*capacityBoundGroup
+	for node := it.NextNode(); node != nil; node = it.NextNode() {
+		key := taintsKey(node.Taints)
+		group, ok := groupByTaints[key]
+		if !ok {
+			group = &capacityBoundGroup{
+				taints:                   node.Taints,
+				maxAllocatableByPriority: make(map[int32]schedulerobjects.ResourceList),
+			}
+			groupByTaints[key] = group
+			groups = append(groups, group)
+		}
+		for priority, allocatable := range node.AllocatableByPriority {
+			maxAllocatable, ok := group.maxAllocatableByPriority[priority]
+			if !ok {
+				maxAllocatable = schedulerobjects.ResourceList{Resources: make(map[string]resource.Quantity)}
+				group.maxAllocatableByPriority[priority] = maxAllocatable
+			}
+			setElementWiseMax(maxAllocatable, allocatable)
+		}
+	}
+	return &CapacityBound{groups: groups}, nil
+}
+
+// MayFit returns false if no node tolerated by the job has enough resources allocatable at the given priority
+// for the job to be bound to it, in which case the job can't be scheduled at that priority or any lower priority
+// without first evicting more jobs. The returned reason, which may be nil, explains why.
+// Only taints and resource requests are considered; node selectors and affinities are not, such that MayFit may
+// return true for jobs that can't be scheduled, but never returns false for jobs that can.
+func (bound *CapacityBound) MayFit(jctx *schedulercontext.JobSchedulingContext, priority int32) (bool, PodRequirementsNotMetReason) {
+	var taintReason, resourceReason PodRequirementsNotMetReason
+	for _, group := range bound.groups {
+		if matches, reason := TolerationRequirementsMet(group.taints, jctx.AdditionalTolerations, jctx.PodRequirements.GetTolerations()); !matches {
+			taintReason = reason
+			continue
+		}
+		maxAllocatable, ok := group.maxAllocatableByPriority[priority]
+		if !ok {
+			// Nothing is known about this priority; assume the job may fit.
+			return true, nil
+		}
+		if matches, reason := ResourceRequirementsMet(maxAllocatable, jctx.PodRequirements.ResourceRequirements.Requests); matches {
+			return true, nil
+		} else {
+			resourceReason = reason
+		}
+	}
+	if resourceReason != nil {
+		return false, resourceReason
+	}
+	return false, taintReason
+}
+
+// setElementWiseMax sets each resource in a to the larger of its value in a and b,
+// where resources missing from either list are considered to be zero.
+// a must have been initialised.
+func setElementWiseMax(a, b schedulerobjects.ResourceList) {
+	for t, q := range b.Resources {
+		if q.Cmp(a.Resources[t]) > 0 {
+			a.Resources[t] = q.DeepCopy()
+		}
+	}
+}
+
+func taintsKey(taints []v1.Taint) string {
+	var sb strings.Builder
+	for _, taint := range taints {
+		sb.WriteString(taint.ToString())
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package nodedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestCapacityBound_MayFit(t *testing.T) {
+	gpus := func(n string) schedulerobjects.ResourceList {
+		return schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"gpu": resource.MustParse(n)}}
+	}
+	tests := map[string]struct {
+		nodes       []*schedulerobjects.Node
+		job         *jobdb.Job
+		expectedFit bool
+	}{
+		"exactly enough free gpus on one node": {
+			nodes: testfixtures.WithUsedResourcesNodes(
+				3, gpus("7"), testfixtures.N8GpuNodes(2, testfixtures.TestPriorities),
+			),
+			job:         testfixtures.Test1GpuJob("A", testfixtures.PriorityClass3),
+			expectedFit: true,
+		},
+		"one gpu more than is free on any node": {
+			nodes: testfixtures.WithUsedResourcesNodes(
+				3, gpus("7"), testfixtures.N8GpuNodes(2, testfixtures.TestPriorities),
+			),
+			job:         testfixtures.WithRequestsJobs(gpus("2"), testfixtures.N1GpuJobs("A", testfixtures.PriorityClass3, 1))[0],
+			expectedFit: false,
+		},
+		"free gpus aren't summed across nodes": {
+			nodes: testfixtures.WithUsedResourcesNodes(
+				3, gpus("4"), testfixtures.N8GpuNodes(2, testfixtures.TestPriorities),
+			),
+			job:         testfixtures.WithRequestsJobs(gpus("5"), testfixtures.N1GpuJobs("A", testfixtures.PriorityClass3, 1))[0],
+			expectedFit: false,
+		},
+		"gpus used at a lower priority may be preempted": {
+			nodes: testfixtures.WithUsedResourcesNodes(
+				0, gpus("8"), testfixtures.N8GpuNodes(1, testfixtures.TestPriorities),
+			),
+			job:         testfixtures.Test1GpuJob("A", testfixtures.PriorityClass1),
+			expectedFit: true,
+		},
+		"gpus used at the same priority": {
+			nodes: testfixtures.WithUsedResourcesNodes(
+				1, gpus("8"), testfixtures.N8GpuNodes(1, testfixtures.TestPriorities),
+			),
+			job:         testfixtures.Test1GpuJob("A", testfixtures.PriorityClass1),
+			expectedFit: false,
+		},
+		"no gpu nodes": {
+			nodes:       testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			job:         testfixtures.Test1GpuJob("A", testfixtures.PriorityClass3),
+			expectedFit: false,
+		},
+		"only free nodes have untolerated taints": {
+			nodes: append(
+				testfixtures.WithUsedResourcesNodes(
+					3, testfixtures.Test32CpuNode(nil).TotalResources, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+				),
+				testfixtures.NTainted32CpuNodes(1, testfixtures.TestPriorities)...,
+			),
+			job:         testfixtures.Test1Cpu4GiJob("A", testfixtures.PriorityClass3),
+			expectedFit: false,
+		},
+		"no nodes": {
+			job:         testfixtures.Test1Cpu4GiJob("A", testfixtures.PriorityClass3),
+			expectedFit: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodeDb, err := newNodeDbWithNodes(tc.nodes)
+			require.NoError(t, err)
+			bound, err := nodeDb.CapacityBoundWithTxn(nodeDb.Txn(false))
+			require.NoError(t, err)
+
+			jctx := schedulercontext.JobSchedulingContextFromJob(
+				testfixtures.TestPriorityClasses,
+				tc.job,
+				func(_ map[string]string) (string, int, int, bool, error) { return "", 1, 1, true, nil },
+			)
+			mayFit, _ := bound.MayFit(jctx, jctx.PodRequirements.Priority)
+			assert.Equal(t, tc.expectedFit, mayFit)
+
+			// The bound must never rule out jobs the nodeDb could schedule.
+			node, err := nodeDb.SelectNodeForJobWithTxn(nodeDb.Txn(false), jctx)
+			require.NoError(t, err)
+			if node != nil {
+				assert.True(t, mayFit)
+			}
+		})
+	}
+}
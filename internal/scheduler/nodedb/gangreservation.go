@@ -0,0 +1,27 @@
+package nodedb
+
+import v1 "k8s.io/api/core/v1"
+
+const (
+	gangReservationTaintKey    string         = "armadaproject.io/gangReservation"
+	gangReservationTaintValue  string         = "true"
+	gangReservationTaintEffect v1.TaintEffect = v1.TaintEffectNoSchedule
+)
+
+// GangReservationTaint returns the taint added to nodes reserved for a gang,
+// such that only the gang and jobs backfilling the reserved capacity are scheduled on them.
+func GangReservationTaint() v1.Taint {
+	return v1.Taint{
+		Key:    gangReservationTaintKey,
+		Value:  gangReservationTaintValue,
+		Effect: gangReservationTaintEffect,
+	}
+}
+
+// GangReservationToleration returns a toleration that tolerates GangReservationTaint().
+func GangReservationToleration() v1.Toleration {
+	return v1.Toleration{
+		Key:   gangReservationTaintKey,
+		Value: gangReservationTaintValue,
+	}
+}
@@ -0,0 +1,74 @@
+package nodedb
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// ExceedsLargestNodeUnschedulableReasonPrefix prefixes the unschedulable reason of jobs requesting more of some resource
+// than the largest node has allocatable.
+const ExceedsLargestNodeUnschedulableReasonPrefix = "exceeds largest node"
+
+// OversizedResource is a resource of which a job requests more than the largest node has allocatable.
+// Such jobs can never be scheduled unless larger nodes are added.
+type OversizedResource struct {
+	// Name of the resource, e.g., "memory".
+	Resource string
+	// Amount requested by the job.
+	Requested resource.Quantity
+	// Largest amount allocatable on any node.
+	Largest resource.Quantity
+}
+
+// Reason returns an unschedulable reason naming the resource, the amount requested, and the size of the largest node.
+func (r OversizedResource) Reason() string {
+	return fmt.Sprintf(
+		"%s: job requests %s %s, but the largest node has %s %s allocatable",
+		ExceedsLargestNodeUnschedulableReasonPrefix, r.Requested.String(), r.Resource, r.Largest.String(), r.Resource,
+	)
+}
+
+// FindOversizedResource returns the first resource, in lexicographical order, of which requests exceed
+// largestNodeResources. Resources missing from largestNodeResources are considered to be available in amount zero.
+// If largestNodeResources is empty, e.g., since there are no nodes, no resource is considered oversized.
+func FindOversizedResource(requests v1.ResourceList, largestNodeResources schedulerobjects.ResourceList) (OversizedResource, bool) {
+	if len(largestNodeResources.Resources) == 0 {
+		return OversizedResource{}, false
+	}
+	resourceNames := maps.Keys(requests)
+	slices.Sort(resourceNames)
+	for _, name := range resourceNames {
+		requested := requests[name]
+		largest := largestNodeResources.Get(string(name))
+		if requested.Cmp(largest) > 0 {
+			return OversizedResource{Resource: string(name), Requested: requested, Largest: largest}, true
+		}
+	}
+	return OversizedResource{}, false
+}
+
+// FindOversizedKnownResource is like FindOversizedResource, except that resources missing from largestNodeResources
+// are never considered oversized, since the nodes providing them may just not be known yet.
+func FindOversizedKnownResource(requests v1.ResourceList, largestNodeResources schedulerobjects.ResourceList) (OversizedResource, bool) {
+	knownRequests := make(v1.ResourceList, len(requests))
+	for name, requested := range requests {
+		if _, ok := largestNodeResources.Resources[string(name)]; ok {
+			knownRequests[name] = requested
+		}
+	}
+	return FindOversizedResource(knownRequests, largestNodeResources)
+}
+
+// FindOversizedResource returns the first resource of which requests exceed the amount allocatable on the largest node
+// in the db; see FindOversizedResource.
+func (nodeDb *NodeDb) FindOversizedResource(requests v1.ResourceList) (OversizedResource, bool) {
+	nodeDb.mu.Lock()
+	defer nodeDb.mu.Unlock()
+	return FindOversizedResource(requests, nodeDb.largestNodeResources)
+}
@@ -0,0 +1,77 @@
+package nodedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestFindOversizedResource(t *testing.T) {
+	nodeDb, err := newNodeDbWithNodes([]*schedulerobjects.Node{})
+	require.NoError(t, err)
+
+	// No resource is oversized while there are no nodes.
+	_, ok := nodeDb.FindOversizedResource(v1.ResourceList{"memory": resource.MustParse("1Ti")})
+	assert.False(t, ok)
+
+	// Memory is largest on one node and cpu on another.
+	nodes := []*schedulerobjects.Node{
+		testfixtures.TestNode(testfixtures.TestPriorities, map[string]resource.Quantity{
+			"cpu":    resource.MustParse("64"),
+			"memory": resource.MustParse("128Gi"),
+		}),
+		testfixtures.TestNode(testfixtures.TestPriorities, map[string]resource.Quantity{
+			"cpu":    resource.MustParse("32"),
+			"memory": resource.MustParse("256Gi"),
+		}),
+	}
+	txn := nodeDb.Txn(true)
+	for _, node := range nodes {
+		require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, nil, node))
+	}
+	txn.Commit()
+	assert.True(
+		t,
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+			"cpu":    resource.MustParse("64"),
+			"memory": resource.MustParse("256Gi"),
+		}}.Equal(nodeDb.LargestNodeResources()),
+	)
+
+	_, ok = nodeDb.FindOversizedResource(v1.ResourceList{"cpu": resource.MustParse("64"), "memory": resource.MustParse("256Gi")})
+	assert.False(t, ok)
+
+	// Memory exceeding all nodes while cpu fits.
+	oversized, ok := nodeDb.FindOversizedResource(v1.ResourceList{"cpu": resource.MustParse("1"), "memory": resource.MustParse("512Gi")})
+	require.True(t, ok)
+	assert.Equal(t, "memory", oversized.Resource)
+	assert.Equal(t, "exceeds largest node: job requests 512Gi memory, but the largest node has 256Gi memory allocatable", oversized.Reason())
+
+	// Resources no node has are oversized if requested.
+	oversized, ok = nodeDb.FindOversizedResource(v1.ResourceList{"cpu": resource.MustParse("1"), "nvidia.com/gpu": resource.MustParse("1")})
+	require.True(t, ok)
+	assert.Equal(t, "nvidia.com/gpu", oversized.Resource)
+	assert.Equal(t, "0", oversized.Largest.String())
+}
+
+func TestFindOversizedKnownResource(t *testing.T) {
+	largestNodeResources := schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+		"cpu":    resource.MustParse("64"),
+		"memory": resource.MustParse("256Gi"),
+	}}
+
+	// Resources no node has are never oversized.
+	_, ok := FindOversizedKnownResource(v1.ResourceList{"cpu": resource.MustParse("1"), "nvidia.com/gpu": resource.MustParse("1")}, largestNodeResources)
+	assert.False(t, ok)
+
+	// Known resources are checked as usual.
+	oversized, ok := FindOversizedKnownResource(v1.ResourceList{"memory": resource.MustParse("512Gi"), "nvidia.com/gpu": resource.MustParse("1")}, largestNodeResources)
+	require.True(t, ok)
+	assert.Equal(t, "memory", oversized.Resource)
+}
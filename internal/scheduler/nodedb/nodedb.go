@@ -3,6 +3,7 @@ package nodedb
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"strings"
 	"sync"
 	"text/tabwriter"
@@ -97,7 +98,9 @@ func (nodeDb *NodeDb) create(node *schedulerobjects.Node) (*Node, error) {
 	}
 	labels[schedulerconfig.NodeIdLabel] = node.Id
 
-	totalResources := node.TotalResources
+	// Resources are rounded down to the precision with which the kubelet accounts for them,
+	// such that jobs placed on the node by the scheduler also fit according to the kubelet.
+	totalResources := node.TotalResources.RoundedDownToKubeletPrecision()
 
 	nodeType := schedulerobjects.NewNodeType(
 		taints,
@@ -106,7 +109,10 @@ func (nodeDb *NodeDb) create(node *schedulerobjects.Node) (*Node, error) {
 		nodeDb.indexedNodeLabels,
 	)
 
-	allocatableByPriority := schedulerobjects.AllocatableByPriorityAndResourceType(node.AllocatableByPriorityAndResource).DeepCopy()
+	allocatableByPriority := make(schedulerobjects.AllocatableByPriorityAndResourceType, len(node.AllocatableByPriorityAndResource))
+	for p, rl := range node.AllocatableByPriorityAndResource {
+		allocatableByPriority[p] = rl.RoundedDownToKubeletPrecision()
+	}
 	minimumPriority := int32(math.MaxInt32)
 	for p := range allocatableByPriority {
 		if p < minimumPriority {
@@ -142,7 +148,17 @@ func (nodeDb *NodeDb) create(node *schedulerobjects.Node) (*Node, error) {
 	nodeDb.numNodes++
 	nodeDb.numNodesByNodeType[nodeType.Id]++
 	nodeDb.totalResources.Add(totalResources)
+	// Allocatable resources are largest at the highest priority, but each resource is considered separately in case
+	// the executor reports some resources only at some priorities.
+	for _, allocatable := range node.AllocatableByPriorityAndResource {
+		for t, q := range allocatable.RoundedDownToKubeletPrecision().Resources {
+			if largest, ok := nodeDb.largestNodeResources.Resources[t]; !ok || q.Cmp(largest) > 0 {
+				nodeDb.largestNodeResources.Resources[t] = q.DeepCopy()
+			}
+		}
+	}
 	nodeDb.nodeTypes[nodeType.Id] = nodeType
+	nodeDb.nodeIndex.add(node.Id, nodeType.Id, labels, taints)
 	nodeDb.mu.Unlock()
 
 	entry := &Node{
@@ -199,6 +215,9 @@ func (nodeDb *NodeDb) CreateAndInsertWithJobDbJobsWithTxn(txn *memdb.Txn, jobs [
 			priorityClass := interfaces.PriorityClassFromLegacySchedulerJob(nodeDb.priorityClasses, nodeDb.defaultPriorityClass, job)
 			priority = priorityClass.Priority
 		}
+		if nodeDb.isNeverPreemptQueue != nil && nodeDb.isNeverPreemptQueue(job.GetQueue()) {
+			priority = nodeDb.nodeDbPriorities[len(nodeDb.nodeDbPriorities)-1]
+		}
 		if err := nodeDb.bindJobToNodeInPlace(entry, job, priority); err != nil {
 			return err
 		}
@@ -221,6 +240,8 @@ type EvictedJobSchedulingContext struct {
 	// nodeDb choses the node that would prevent re-scheduling jobs with as a large an index as possible.
 	Index                int
 	JobSchedulingContext *schedulercontext.JobSchedulingContext
+	// Id of the gang the evicted job is a member of, or the empty string if it isn't a gang job.
+	GangId string
 }
 
 // NodeDb is the scheduler-internal system used to efficiently find nodes on which a pod could be scheduled.
@@ -284,9 +305,13 @@ type NodeDb struct {
 	numNodesByNodeType map[uint64]int
 	// Total amount of resources, e.g., "cpu", "memory", "gpu", across all nodes in the db.
 	totalResources schedulerobjects.ResourceList
+	// Largest amount of each resource allocatable on any single node in the db.
+	largestNodeResources schedulerobjects.ResourceList
 	// Set of node types. Populated automatically as nodes are inserted.
 	// Node types are not cleaned up if all nodes of that type are removed from the NodeDb.
 	nodeTypes map[uint64]*schedulerobjects.NodeType
+	// Inverted index over the labels and taints of all nodes in the db. Populated automatically as nodes are created.
+	nodeIndex *nodeIndex
 
 	wellKnownNodeTypes map[string]*configuration.WellKnownNodeType
 
@@ -296,6 +321,29 @@ type NodeDb struct {
 
 	// If true, use experimental preemption strategy.
 	enableNewPreemptionStrategy bool
+	// If true, preventing an evicted gang job from being re-scheduled prevents its entire gang from being re-scheduled.
+	// Only used with the new preemption strategy.
+	enableGangAwarePreemption bool
+	// If positive, this is added to the score of nodes of the preferred executor of the job set of the job being scheduled,
+	// such that jobs of the same job set are preferably placed on the same executor.
+	preferredExecutorWeight int
+	// Returns the preferred executor of a job set as of the start of the scheduling round, or the empty string if none.
+	preferredExecutorByJobSet func(queue, jobSet string) string
+	// Preferred executors of job sets established by jobs scheduled since, keyed by queue and job set.
+	// Protected by mu.
+	establishedPreferredExecutors map[[2]string]string
+	// Determines on which of several nodes that score equally for a job the job is placed.
+	nodeOrdering configuration.NodeOrdering
+	// Used to choose among nodes that score equally with RandomNodeOrdering.
+	random *rand.Rand
+	// If non-empty, nodes matching a job are ranked by the weighted sum of the scores of these scorers.
+	nodeScoringPipeline NodeScoringPipeline
+	// If non-nil, jobs for which this returns true tolerate GangReservationTaint(),
+	// i.e., may be scheduled on nodes reserved for gangs.
+	mayBackfill func(job interfaces.LegacySchedulerJob) bool
+	// If non-nil, running jobs of queues for which this returns true are bound at the highest priority,
+	// such that the resources they occupy are never allocatable to other jobs.
+	isNeverPreemptQueue func(queue string) bool
 
 	// Map from job ID to the priority class priority at which the job was scheduled.
 	//
@@ -362,13 +410,16 @@ func NewNodeDb(
 			func(v configuration.IndexedResource) int64 { return v.Resolution.MilliValue() },
 		),
 		indexNameByPriority:    indexNameByPriority,
+		nodeOrdering:           configuration.PackedNodeOrdering,
 		indexedTaints:          mapFromSlice(indexedTaints),
 		indexedNodeLabels:      mapFromSlice(indexedNodeLabels),
 		indexedNodeLabelValues: indexedNodeLabelValues,
 		nodeTypes:              make(map[uint64]*schedulerobjects.NodeType),
+		nodeIndex:              newNodeIndex(),
 		wellKnownNodeTypes:     make(map[string]*configuration.WellKnownNodeType),
 		numNodesByNodeType:     make(map[uint64]int),
 		totalResources:         schedulerobjects.ResourceList{Resources: make(map[string]resource.Quantity)},
+		largestNodeResources:   schedulerobjects.ResourceList{Resources: make(map[string]resource.Quantity)},
 		db:                     db,
 		// Set the initial capacity (somewhat arbitrarily) to 128 reasons.
 		podRequirementsNotMetReasonStringCache: make(map[uint64]string, 128),
@@ -406,6 +457,101 @@ func (nodeDb *NodeDb) EnableNewPreemptionStrategy() {
 	nodeDb.enableNewPreemptionStrategy = true
 }
 
+func (nodeDb *NodeDb) EnableGangAwarePreemption() {
+	nodeDb.enableGangAwarePreemption = true
+}
+
+// EnableJobSetExecutorPreference causes nodes of the preferred executor of the job set of each job being scheduled
+// to be preferred over nodes of other executors, by adding weight to their score. Jobs are still placed on other
+// executors if no node of the preferred executor can fit them.
+//
+// The preferred executor of a job is given by its PreferredExecutorAnnotation, if set, and otherwise by that of its job
+// set. The preferred executor of a job set is that returned by preferredExecutorByJobSet or, if that returns the empty
+// string, that of the first job of the job set scheduled using this nodeDb, or the executor that job was placed on.
+func (nodeDb *NodeDb) EnableJobSetExecutorPreference(weight int, preferredExecutorByJobSet func(queue, jobSet string) string) {
+	nodeDb.preferredExecutorWeight = weight
+	nodeDb.preferredExecutorByJobSet = preferredExecutorByJobSet
+	nodeDb.establishedPreferredExecutors = make(map[[2]string]string)
+}
+
+// EnableNodeOrdering sets on which of several nodes that score equally for a job the job is placed.
+// With BalancedNodeOrdering and RandomNodeOrdering, all nodes matching a job are considered,
+// regardless of maxExtraNodesToConsider. With RandomNodeOrdering, nodes are chosen using a source seeded with seed,
+// such that placements are reproducible given the same seed.
+func (nodeDb *NodeDb) EnableNodeOrdering(nodeOrdering configuration.NodeOrdering, seed int64) {
+	nodeDb.nodeOrdering = nodeOrdering
+	nodeDb.random = rand.New(rand.NewSource(seed))
+}
+
+// EnableNodeScoring causes all nodes matching each job to be considered, regardless of maxExtraNodesToConsider,
+// and the job to be placed on the node with the highest weighted sum of the scores of pipeline.
+// Nodes of the preferred executor of the job set are still preferred, and nodes that score equally are chosen
+// among according to the node ordering. The weighted score of the selected node by each scorer is recorded
+// in the pod scheduling context of the job.
+func (nodeDb *NodeDb) EnableNodeScoring(pipeline NodeScoringPipeline) {
+	nodeDb.nodeScoringPipeline = pipeline
+}
+
+// EnableBackfill causes jobs for which mayBackfill returns true to be scheduled as if they tolerated
+// GangReservationTaint(), such that they may backfill the capacity of nodes reserved for gangs.
+func (nodeDb *NodeDb) EnableBackfill(mayBackfill func(job interfaces.LegacySchedulerJob) bool) {
+	nodeDb.mayBackfill = mayBackfill
+}
+
+// EnableNeverPreemptQueues causes running jobs of queues for which isNeverPreemptQueue returns true,
+// as added by CreateAndInsertWithJobDbJobsWithTxn, to be bound at the highest priority of any priority class.
+// Hence, no job may be scheduled onto the resources they occupy by urgency-based preemption.
+// Must be called before adding nodes.
+func (nodeDb *NodeDb) EnableNeverPreemptQueues(isNeverPreemptQueue func(queue string) bool) {
+	nodeDb.isNeverPreemptQueue = isNeverPreemptQueue
+}
+
+// withBackfillToleration adds GangReservationToleration() to the additional tolerations of jctx if it may backfill
+// reserved capacity. Returns a function that restores the additional tolerations of jctx.
+func (nodeDb *NodeDb) withBackfillToleration(jctx *schedulercontext.JobSchedulingContext) func() {
+	if nodeDb.mayBackfill == nil || !nodeDb.mayBackfill(jctx.Job) {
+		return func() {}
+	}
+	numAdditionalTolerations := len(jctx.AdditionalTolerations)
+	jctx.AdditionalTolerations = append(jctx.AdditionalTolerations, GangReservationToleration())
+	return func() {
+		jctx.AdditionalTolerations = jctx.AdditionalTolerations[:numAdditionalTolerations]
+	}
+}
+
+// NodeOrdering returns the order in which nodes that score equally for a job are considered.
+func (nodeDb *NodeDb) NodeOrdering() configuration.NodeOrdering {
+	return nodeDb.nodeOrdering
+}
+
+// preferredExecutor returns the executor on which jctx would preferably be placed, or the empty string if there's none.
+// The second return value is false if the job set of jctx has no preferred executor yet.
+func (nodeDb *NodeDb) preferredExecutor(jctx *schedulercontext.JobSchedulingContext) (string, bool) {
+	key := [2]string{jctx.Job.GetQueue(), jctx.Job.GetJobSet()}
+	nodeDb.mu.Lock()
+	establishedExecutor, established := nodeDb.establishedPreferredExecutors[key]
+	nodeDb.mu.Unlock()
+	if executor := jctx.Job.GetAnnotations()[configuration.PreferredExecutorAnnotation]; executor != "" {
+		return executor, established
+	}
+	if established {
+		return establishedExecutor, true
+	}
+	if nodeDb.preferredExecutorByJobSet != nil {
+		if executor := nodeDb.preferredExecutorByJobSet(key[0], key[1]); executor != "" {
+			return executor, true
+		}
+	}
+	return "", false
+}
+
+// establishPreferredExecutor records executor as the preferred executor of the job set of jctx.
+func (nodeDb *NodeDb) establishPreferredExecutor(jctx *schedulercontext.JobSchedulingContext, executor string) {
+	nodeDb.mu.Lock()
+	defer nodeDb.mu.Unlock()
+	nodeDb.establishedPreferredExecutors[[2]string{jctx.Job.GetQueue(), jctx.Job.GetJobSet()}] = executor
+}
+
 func (nodeDb *NodeDb) GetScheduledAtPriority(jobId string) (int32, bool) {
 	priority, ok := nodeDb.scheduledAtPriorityByJobId[jobId]
 	return priority, ok
@@ -448,6 +594,14 @@ func (nodeDb *NodeDb) TotalResources() schedulerobjects.ResourceList {
 	return nodeDb.totalResources.DeepCopy()
 }
 
+// LargestNodeResources returns the largest amount of each resource allocatable on any single node in the db.
+// Different resources may be largest on different nodes.
+func (nodeDb *NodeDb) LargestNodeResources() schedulerobjects.ResourceList {
+	nodeDb.mu.Lock()
+	defer nodeDb.mu.Unlock()
+	return nodeDb.largestNodeResources.DeepCopy()
+}
+
 func (nodeDb *NodeDb) Txn(write bool) *memdb.Txn {
 	return nodeDb.db.Txn(write)
 }
@@ -581,6 +735,47 @@ func (nodeDb *NodeDb) ScheduleManyWithTxn(txn *memdb.Txn, jctxs []*schedulercont
 	return true, nil
 }
 
+// ScheduleWithoutPreemptionWithTxn binds the job to a node with enough free capacity for it, if there is one,
+// without considering preempting any other jobs or away node types.
+// Returns the node the job was bound to, or nil if there's no node with enough free capacity.
+func (nodeDb *NodeDb) ScheduleWithoutPreemptionWithTxn(txn *memdb.Txn, jctx *schedulercontext.JobSchedulingContext) (*Node, error) {
+	pctx := &schedulercontext.PodSchedulingContext{
+		Created:                  time.Now(),
+		ScheduledAtPriority:      -1,
+		PreemptedAtPriority:      MinPriority,
+		NumNodes:                 nodeDb.numNodes,
+		NumExcludedNodesByReason: make(map[string]int),
+	}
+	jctx.PodSchedulingContext = pctx
+	defer nodeDb.withBackfillToleration(jctx)()
+
+	matchingNodeTypeIds, numExcludedNodesByReason, err := nodeDb.NodeTypesMatchingJob(jctx)
+	if err != nil {
+		return nil, err
+	}
+	pctx.NumExcludedNodesByReason = numExcludedNodesByReason
+	pctx.ScheduledAtPriority = jctx.PodRequirements.Priority
+	candidates := nodeDb.candidateNodes(jctx, matchingNodeTypeIds)
+
+	// Resources allocatable at evictedPriority are those not allocated to any job.
+	node, err := nodeDb.selectNodeForPodAtPriority(txn, jctx, matchingNodeTypeIds, candidates, evictedPriority)
+	if err != nil {
+		return nil, err
+	} else if err := assertPodSchedulingContextNode(pctx, node); err != nil {
+		return nil, err
+	} else if node == nil {
+		return nil, nil
+	}
+	node, err = nodeDb.bindJobToNode(node, jctx.Job, pctx.ScheduledAtPriority)
+	if err != nil {
+		return nil, err
+	}
+	if err := nodeDb.UpsertWithTxn(txn, node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
 func deleteEvictedJobSchedulingContextIfExistsWithTxn(txn *memdb.Txn, jobId string) error {
 	if err := txn.Delete("evictedJobs", &EvictedJobSchedulingContext{JobId: jobId}); err == memdb.ErrNotFound {
 		return nil
@@ -592,7 +787,7 @@ func deleteEvictedJobSchedulingContextIfExistsWithTxn(txn *memdb.Txn, jobId stri
 }
 
 // SelectNodeForJobWithTxn selects a node on which the job can be scheduled.
-func (nodeDb *NodeDb) SelectNodeForJobWithTxn(txn *memdb.Txn, jctx *schedulercontext.JobSchedulingContext) (*Node, error) {
+func (nodeDb *NodeDb) SelectNodeForJobWithTxn(txn *memdb.Txn, jctx *schedulercontext.JobSchedulingContext) (selectedNode *Node, err error) {
 	req := jctx.PodRequirements
 
 	priorityClass := interfaces.PriorityClassFromLegacySchedulerJob(nodeDb.priorityClasses, nodeDb.defaultPriorityClass, jctx.Job)
@@ -605,6 +800,7 @@ func (nodeDb *NodeDb) SelectNodeForJobWithTxn(txn *memdb.Txn, jctx *schedulercon
 		NumExcludedNodesByReason: make(map[string]int),
 	}
 	jctx.PodSchedulingContext = pctx
+	defer nodeDb.withBackfillToleration(jctx)()
 
 	// For pods that failed to schedule, add an exclusion reason for implicitly excluded nodes.
 	defer func() {
@@ -621,6 +817,25 @@ func (nodeDb *NodeDb) SelectNodeForJobWithTxn(txn *memdb.Txn, jctx *schedulercon
 		}
 	}()
 
+	// Prefer nodes of the preferred executor of the job set of the job, if any;
+	// otherwise, the executor the job is placed on becomes the preferred executor of its job set.
+	if nodeDb.preferredExecutorWeight > 0 {
+		preferredExecutor, established := nodeDb.preferredExecutor(jctx)
+		pctx.PreferredExecutor = preferredExecutor
+		if !established {
+			defer func() {
+				if selectedNode == nil {
+					return
+				}
+				if preferredExecutor != "" {
+					nodeDb.establishPreferredExecutor(jctx, preferredExecutor)
+				} else {
+					nodeDb.establishPreferredExecutor(jctx, selectedNode.Executor)
+				}
+			}()
+		}
+	}
+
 	// If the nodeIdLabel selector is set, consider only that node.
 	if nodeId, ok := jctx.GetNodeSelector(schedulerconfig.NodeIdLabel); ok {
 		if it, err := txn.Get("nodes", "id", nodeId); err != nil {
@@ -634,7 +849,7 @@ func (nodeDb *NodeDb) SelectNodeForJobWithTxn(txn *memdb.Txn, jctx *schedulercon
 				priority = req.Priority
 			}
 			pctx.ScheduledAtPriority = priority
-			if node, err := nodeDb.selectNodeForPodWithItAtPriority(it, jctx, priority, true); err != nil {
+			if node, err := nodeDb.selectNodeForPodWithItAtPriority(it, jctx, nil, priority, true); err != nil {
 				return nil, err
 			} else {
 				return node, nil
@@ -700,13 +915,14 @@ func (nodeDb *NodeDb) selectNodeForJobWithTxnAtPriority(
 	if err != nil {
 		return nil, err
 	}
+	candidates := nodeDb.candidateNodes(jctx, matchingNodeTypeIds)
 
 	pctx := jctx.PodSchedulingContext
 	pctx.ScheduledAtPriority = priority
 
 	// Try scheduling at evictedPriority. If this succeeds, no preemption is necessary.
 	pctx.NumExcludedNodesByReason = maps.Clone(numExcludedNodesByReason)
-	if node, err := nodeDb.selectNodeForPodAtPriority(txn, jctx, matchingNodeTypeIds, evictedPriority); err != nil {
+	if node, err := nodeDb.selectNodeForPodAtPriority(txn, jctx, matchingNodeTypeIds, candidates, evictedPriority); err != nil {
 		return nil, err
 	} else if err := assertPodSchedulingContextNode(pctx, node); err != nil {
 		return nil, err
@@ -717,7 +933,7 @@ func (nodeDb *NodeDb) selectNodeForJobWithTxnAtPriority(
 	// Try scheduling at the job priority. If this fails, scheduling is impossible and we return.
 	// This is an optimisation to avoid looking for preemption targets for unschedulable jobs.
 	pctx.NumExcludedNodesByReason = maps.Clone(numExcludedNodesByReason)
-	if node, err := nodeDb.selectNodeForPodAtPriority(txn, jctx, matchingNodeTypeIds, req.Priority); err != nil {
+	if node, err := nodeDb.selectNodeForPodAtPriority(txn, jctx, matchingNodeTypeIds, candidates, req.Priority); err != nil {
 		return nil, err
 	} else if err := assertPodSchedulingContextNode(pctx, node); err != nil {
 		return nil, err
@@ -743,7 +959,7 @@ func (nodeDb *NodeDb) selectNodeForJobWithTxnAtPriority(
 
 	// Schedule by kicking off jobs currently bound to a node.
 	// This method does not respect fairness when choosing on which node to schedule the job.
-	if node, err := nodeDb.selectNodeForJobWithUrgencyPreemption(txn, jctx, matchingNodeTypeIds); err != nil {
+	if node, err := nodeDb.selectNodeForJobWithUrgencyPreemption(txn, jctx, matchingNodeTypeIds, candidates); err != nil {
 		return nil, err
 	} else if err := assertPodSchedulingContextNode(pctx, node); err != nil {
 		return nil, err
@@ -772,6 +988,7 @@ func (nodeDb *NodeDb) selectNodeForJobWithUrgencyPreemption(
 	txn *memdb.Txn,
 	jctx *schedulercontext.JobSchedulingContext,
 	matchingNodeTypeIds []uint64,
+	candidates *candidateNodes,
 ) (*Node, error) {
 	req := jctx.PodRequirements
 	pctx := jctx.PodSchedulingContext
@@ -791,7 +1008,7 @@ func (nodeDb *NodeDb) selectNodeForJobWithUrgencyPreemption(
 		pctx.NumExcludedNodesByReason = maps.Clone(numExcludedNodesByReason)
 
 		// Try to find a node at this priority.
-		if node, err := nodeDb.selectNodeForPodAtPriority(txn, jctx, matchingNodeTypeIds, priority); err != nil {
+		if node, err := nodeDb.selectNodeForPodAtPriority(txn, jctx, matchingNodeTypeIds, candidates, priority); err != nil {
 			return nil, err
 		} else if err := assertPodSchedulingContextNode(pctx, node); err != nil {
 			return nil, err
@@ -806,13 +1023,22 @@ func (nodeDb *NodeDb) selectNodeForPodAtPriority(
 	txn *memdb.Txn,
 	jctx *schedulercontext.JobSchedulingContext,
 	matchingNodeTypeIds []uint64,
+	candidates *candidateNodes,
 	priority int32,
 ) (*Node, error) {
 	req := jctx.PodRequirements
 
+	if candidates != nil && candidates.nodes.isEmpty() {
+		// No need to iterate over the nodes of the matching node types; the job can't be scheduled on any of them.
+		for reason, count := range candidates.numExcludedNodesByReason {
+			jctx.PodSchedulingContext.NumExcludedNodesByReason[reason] += count
+		}
+		return nil, nil
+	}
+
 	indexResourceRequests := make([]resource.Quantity, len(nodeDb.indexedResources))
 	for i, t := range nodeDb.indexedResources {
-		indexResourceRequests[i] = req.ResourceRequirements.Requests[v1.ResourceName(t)]
+		indexResourceRequests[i] = schedulerobjects.RoundUpToKubeletPrecision(t, req.ResourceRequirements.Requests[v1.ResourceName(t)])
 	}
 	indexName, ok := nodeDb.indexNameByPriority[priority]
 	if !ok {
@@ -831,7 +1057,7 @@ func (nodeDb *NodeDb) selectNodeForPodAtPriority(
 		return nil, err
 	}
 
-	if node, err := nodeDb.selectNodeForPodWithItAtPriority(it, jctx, priority, false); err != nil {
+	if node, err := nodeDb.selectNodeForPodWithItAtPriority(it, jctx, candidates, priority, false); err != nil {
 		return nil, err
 	} else if node != nil {
 		return node, nil
@@ -843,23 +1069,41 @@ func (nodeDb *NodeDb) selectNodeForPodAtPriority(
 func (nodeDb *NodeDb) selectNodeForPodWithItAtPriority(
 	it memdb.ResultIterator,
 	jctx *schedulercontext.JobSchedulingContext,
+	candidates *candidateNodes,
 	priority int32,
 	onlyCheckDynamicRequirements bool,
 ) (*Node, error) {
+	// Nodes of the preferred executor, if any, score higher than others.
+	// Since this is the only score, we keep looking for such a node until one is found, regardless of maxExtraNodesToConsider.
+	preferredExecutor := ""
+	if jctx.PodSchedulingContext != nil {
+		preferredExecutor = jctx.PodSchedulingContext.PreferredExecutor
+	}
+	bestScore := SchedulableBestScore
+	if preferredExecutor != "" {
+		bestScore += nodeDb.preferredExecutorWeight
+	}
+	// Unless packing without node scoring, choose among all matching nodes with the best score.
+	scoreNodes := len(nodeDb.nodeScoringPipeline) > 0
+	considerAllNodes := scoreNodes || nodeDb.nodeOrdering == configuration.BalancedNodeOrdering || nodeDb.nodeOrdering == configuration.RandomNodeOrdering
 	var selectedNode *Node
 	var selectedNodeScore int
+	// Weighted sum of the scores of the node scoring pipeline for selectedNode; zero unless scoring nodes.
+	var selectedNodePipelineScore float64
 	var numExtraNodes uint
+	// Number of matching nodes with score equal to selectedNodeScore.
+	var numTiedNodes int
 	for obj := it.Next(); obj != nil; obj = it.Next() {
 		if selectedNode != nil {
 			numExtraNodes++
-			if numExtraNodes > nodeDb.maxExtraNodesToConsider {
+			if numExtraNodes > nodeDb.maxExtraNodesToConsider && preferredExecutor == "" && !considerAllNodes {
 				break
 			}
 		}
 
 		node := obj.(*Node)
 		if node == nil {
-			return nil, nil
+			break
 		}
 
 		var matches bool
@@ -876,12 +1120,34 @@ func (nodeDb *NodeDb) selectNodeForPodWithItAtPriority(
 		}
 
 		if matches {
-			if selectedNode == nil || score > selectedNodeScore {
+			if preferredExecutor != "" && node.Executor == preferredExecutor {
+				score += nodeDb.preferredExecutorWeight
+			}
+			var pipelineScore float64
+			if scoreNodes {
+				pipelineScore = nodeDb.nodeScoringPipeline.score(nodeDb, jctx, node, priority)
+			}
+			if selectedNode == nil || score > selectedNodeScore || score == selectedNodeScore && pipelineScore > selectedNodePipelineScore {
 				selectedNode = node
 				selectedNodeScore = score
-				if selectedNodeScore == SchedulableBestScore {
+				selectedNodePipelineScore = pipelineScore
+				numTiedNodes = 1
+				if selectedNodeScore == bestScore && !considerAllNodes {
 					break
 				}
+			} else if score == selectedNodeScore && pipelineScore == selectedNodePipelineScore && considerAllNodes {
+				numTiedNodes++
+				switch nodeDb.nodeOrdering {
+				case configuration.BalancedNodeOrdering:
+					if nodeDb.allocatableFraction(node, priority) > nodeDb.allocatableFraction(selectedNode, priority) {
+						selectedNode = node
+					}
+				case configuration.RandomNodeOrdering:
+					// Reservoir sampling, such that each tied node is selected with equal probability.
+					if nodeDb.random.Intn(numTiedNodes) == 0 {
+						selectedNode = node
+					}
+				}
 			}
 		} else {
 			s := nodeDb.stringFromPodRequirementsNotMetReason(reason)
@@ -892,21 +1158,45 @@ func (nodeDb *NodeDb) selectNodeForPodWithItAtPriority(
 	if selectedNode != nil {
 		jctx.PodSchedulingContext.NodeId = selectedNode.Id
 		jctx.PodSchedulingContext.PreemptedAtPriority = priority
+		if scoreNodes {
+			jctx.PodSchedulingContext.NodeScores = nodeDb.nodeScoringPipeline.contributions(nodeDb, jctx, selectedNode, priority)
+		}
 	}
 	return selectedNode, nil
 }
 
+// allocatableFraction returns the fraction of the indexed resources of node allocatable at priority,
+// summed over all indexed resources. Less-allocated nodes have a larger allocatable fraction.
+func (nodeDb *NodeDb) allocatableFraction(node *Node, priority int32) float64 {
+	allocatable := node.AllocatableByPriority[priority]
+	var rv float64
+	for _, t := range nodeDb.indexedResources {
+		total := node.TotalResources.Get(t)
+		if total.IsZero() {
+			continue
+		}
+		available := allocatable.Get(t)
+		rv += float64(available.MilliValue()) / float64(total.MilliValue())
+	}
+	return rv
+}
+
 // selectNodeForJobWithFairPreemption returns a node onto which the provided job could be scheduled, or nil if none can be found.
 // Specifically, it returns the node for which scheduling would result in the most "fair" preemptions.
 //
 // It does this by considering all evicted jobs in the reverse order they would be scheduled in and preventing
 // from being re-scheduled the jobs that would be scheduled last.
+//
+// If gang-aware preemption is enabled, preventing a gang job from being re-scheduled prevents its entire gang from
+// being re-scheduled, and the resources of all its members, which may be spread over several nodes, are released.
 func (nodeDb *NodeDb) selectNodeForJobWithFairPreemption(txn *memdb.Txn, jctx *schedulercontext.JobSchedulingContext) (*Node, error) {
 	pctx := jctx.PodSchedulingContext
 
 	var selectedNode *Node
 	nodesById := make(map[string]*Node)
 	evictedJobSchedulingContextsByNodeId := make(map[string][]*EvictedJobSchedulingContext)
+	// Ids of evicted jobs already considered as part of their gang.
+	consideredJobIds := make(map[string]bool)
 	it, err := txn.ReverseLowerBound("evictedJobs", "index", math.MaxInt)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -914,42 +1204,73 @@ func (nodeDb *NodeDb) selectNodeForJobWithFairPreemption(txn *memdb.Txn, jctx *s
 	maxPriority := MinPriority
 	for obj := it.Next(); obj != nil && selectedNode == nil; obj = it.Next() {
 		evictedJobSchedulingContext := obj.(*EvictedJobSchedulingContext)
-		evictedJctx := evictedJobSchedulingContext.JobSchedulingContext
-		nodeId, ok := evictedJctx.GetNodeSelector(schedulerconfig.NodeIdLabel)
-		if !ok {
-			return nil, errors.Errorf("evicted job %s does not have a nodeIdLabel", evictedJctx.JobId)
+		if consideredJobIds[evictedJobSchedulingContext.JobId] {
+			continue
 		}
-		node, ok := nodesById[nodeId]
-		if !ok {
-			node, err = nodeDb.GetNodeWithTxn(txn, nodeId)
+		evictedJobSchedulingContexts := []*EvictedJobSchedulingContext{evictedJobSchedulingContext}
+		if nodeDb.enableGangAwarePreemption && evictedJobSchedulingContext.GangId != "" {
+			if jctx.GangCardinality > 1 && evictedJobSchedulingContext.GangId == jctx.GangId {
+				// Jobs never prevent other members of their own gang from being re-scheduled.
+				continue
+			}
+			gangJobSchedulingContexts, err := evictedGangJobSchedulingContextsWithTxn(txn, evictedJobSchedulingContext.GangId)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, err
+			}
+			// The node of the job that would be re-scheduled last is considered first.
+			for _, gangJobSchedulingContext := range gangJobSchedulingContexts {
+				if gangJobSchedulingContext.JobId != evictedJobSchedulingContext.JobId {
+					evictedJobSchedulingContexts = append(evictedJobSchedulingContexts, gangJobSchedulingContext)
+				}
 			}
 		}
-		node, err = nodeDb.UnbindJobFromNode(nodeDb.priorityClasses, evictedJctx.Job, node)
-		if err != nil {
-			return nil, err
-		}
-		nodesById[nodeId] = node
-		evictedJobSchedulingContextsByNodeId[nodeId] = append(evictedJobSchedulingContextsByNodeId[nodeId], evictedJobSchedulingContext)
-		if priority := evictedJctx.PodRequirements.Priority; priority > maxPriority {
-			maxPriority = priority
-		}
-		matches, _, reason, err := JobRequirementsMet(
-			node.Taints,
-			node.Labels,
-			node.TotalResources,
-			node.AllocatableByPriority[evictedPriority],
-			jctx,
-		)
-		if err != nil {
-			return nil, err
+		nodeIds := make([]string, 0, 1)
+		for _, evictedJobSchedulingContext := range evictedJobSchedulingContexts {
+			consideredJobIds[evictedJobSchedulingContext.JobId] = true
+			evictedJctx := evictedJobSchedulingContext.JobSchedulingContext
+			nodeId, ok := evictedJctx.GetNodeSelector(schedulerconfig.NodeIdLabel)
+			if !ok {
+				return nil, errors.Errorf("evicted job %s does not have a nodeIdLabel", evictedJctx.JobId)
+			}
+			node, ok := nodesById[nodeId]
+			if !ok {
+				node, err = nodeDb.GetNodeWithTxn(txn, nodeId)
+				if err != nil {
+					return nil, errors.WithStack(err)
+				}
+			}
+			node, err = nodeDb.UnbindJobFromNode(nodeDb.priorityClasses, evictedJctx.Job, node)
+			if err != nil {
+				return nil, err
+			}
+			nodesById[nodeId] = node
+			evictedJobSchedulingContextsByNodeId[nodeId] = append(evictedJobSchedulingContextsByNodeId[nodeId], evictedJobSchedulingContext)
+			if priority := evictedJctx.PodRequirements.Priority; priority > maxPriority {
+				maxPriority = priority
+			}
+			if !slices.Contains(nodeIds, nodeId) {
+				nodeIds = append(nodeIds, nodeId)
+			}
 		}
-		if matches {
-			selectedNode = node
-		} else {
-			s := nodeDb.stringFromPodRequirementsNotMetReason(reason)
-			pctx.NumExcludedNodesByReason[s] += 1
+		for _, nodeId := range nodeIds {
+			node := nodesById[nodeId]
+			matches, _, reason, err := JobRequirementsMet(
+				node.Taints,
+				node.Labels,
+				node.TotalResources,
+				node.AllocatableByPriority[evictedPriority],
+				jctx,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if matches {
+				selectedNode = node
+				break
+			} else {
+				s := nodeDb.stringFromPodRequirementsNotMetReason(reason)
+				pctx.NumExcludedNodesByReason[s] += 1
+			}
 		}
 	}
 	if selectedNode != nil {
@@ -960,10 +1281,63 @@ func (nodeDb *NodeDb) selectNodeForJobWithFairPreemption(txn *memdb.Txn, jctx *s
 				return nil, errors.WithStack(err)
 			}
 		}
+		releasedGangIds := make(map[string]bool)
+		for _, evictedJobSchedulingContext := range evictedJobSchedulingContextsByNodeId[selectedNode.Id] {
+			if gangId := evictedJobSchedulingContext.GangId; nodeDb.enableGangAwarePreemption && gangId != "" && !releasedGangIds[gangId] {
+				if err := nodeDb.releaseEvictedGangWithTxn(txn, gangId); err != nil {
+					return nil, err
+				}
+				releasedGangIds[gangId] = true
+			}
+		}
 	}
 	return selectedNode, nil
 }
 
+// evictedGangJobSchedulingContextsWithTxn returns the evicted jobs of the gang with the given id.
+func evictedGangJobSchedulingContextsWithTxn(txn *memdb.Txn, gangId string) ([]*EvictedJobSchedulingContext, error) {
+	it, err := txn.Get("evictedJobs", "gangId", gangId)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var rv []*EvictedJobSchedulingContext
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		rv = append(rv, obj.(*EvictedJobSchedulingContext))
+	}
+	return rv, nil
+}
+
+// releaseEvictedGangWithTxn prevents the remaining evicted jobs of the gang with the given id from being re-scheduled
+// and releases the resources allocated to them on their nodes.
+func (nodeDb *NodeDb) releaseEvictedGangWithTxn(txn *memdb.Txn, gangId string) error {
+	evictedJobSchedulingContexts, err := evictedGangJobSchedulingContextsWithTxn(txn, gangId)
+	if err != nil {
+		return err
+	}
+	for _, evictedJobSchedulingContext := range evictedJobSchedulingContexts {
+		evictedJctx := evictedJobSchedulingContext.JobSchedulingContext
+		nodeId, ok := evictedJctx.GetNodeSelector(schedulerconfig.NodeIdLabel)
+		if !ok {
+			return errors.Errorf("evicted job %s does not have a nodeIdLabel", evictedJctx.JobId)
+		}
+		node, err := nodeDb.GetNodeWithTxn(txn, nodeId)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		node, err = nodeDb.UnbindJobFromNode(nodeDb.priorityClasses, evictedJctx.Job, node)
+		if err != nil {
+			return err
+		}
+		if err := nodeDb.UpsertWithTxn(txn, node); err != nil {
+			return err
+		}
+		if err := txn.Delete("evictedJobs", evictedJobSchedulingContext); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
 // bindJobToNode returns a copy of node with job bound to it.
 func (nodeDb *NodeDb) bindJobToNode(node *Node, job interfaces.LegacySchedulerJob, priority int32) (*Node, error) {
 	node = node.UnsafeCopy()
@@ -976,7 +1350,7 @@ func (nodeDb *NodeDb) bindJobToNode(node *Node, job interfaces.LegacySchedulerJo
 // bindJobToNodeInPlace is like bindJobToNode, but doesn't make a copy of node.
 func (nodeDb *NodeDb) bindJobToNodeInPlace(node *Node, job interfaces.LegacySchedulerJob, priority int32) error {
 	jobId := job.GetId()
-	requests := job.GetResourceRequirements().Requests
+	requests := schedulerobjects.RoundUpV1ResourceListToKubeletPrecision(job.GetResourceRequirements().Requests)
 
 	_, isEvicted := node.EvictedJobRunIds[jobId]
 	delete(node.EvictedJobRunIds, jobId)
@@ -1066,7 +1440,7 @@ func (nodeDb *NodeDb) evictJobFromNodeInPlace(priorityClasses map[string]types.P
 	if !ok {
 		return errors.Errorf("job %s not mapped to a priority", jobId)
 	}
-	requests := job.GetResourceRequirements().Requests
+	requests := schedulerobjects.RoundUpV1ResourceListToKubeletPrecision(job.GetResourceRequirements().Requests)
 	allocatable.MarkAllocatableV1ResourceList(priority, requests)
 	allocatable.MarkAllocatedV1ResourceList(evictedPriority, requests)
 
@@ -1096,7 +1470,7 @@ func (nodeDb *NodeDb) UnbindJobFromNode(priorityClasses map[string]types.Priorit
 // unbindPodFromNodeInPlace is like UnbindJobFromNode, but doesn't make a copy of node.
 func (nodeDb *NodeDb) unbindJobFromNodeInPlace(priorityClasses map[string]types.PriorityClass, job interfaces.LegacySchedulerJob, node *Node) error {
 	jobId := job.GetId()
-	requests := job.GetResourceRequirements().Requests
+	requests := schedulerobjects.RoundUpV1ResourceListToKubeletPrecision(job.GetResourceRequirements().Requests)
 
 	_, isEvicted := node.EvictedJobRunIds[jobId]
 	delete(node.EvictedJobRunIds, jobId)
@@ -1134,6 +1508,36 @@ func (nodeDb *NodeDb) unbindJobFromNodeInPlace(priorityClasses map[string]types.
 	return nil
 }
 
+// candidateNodes is the set of nodes a job may be scheduled on as far as its tolerations and node selectors
+// are concerned. If there are no such nodes, it also holds the number of nodes of the node types the job matches
+// by reason for exclusion.
+type candidateNodes struct {
+	nodes                    nodeSet
+	numExcludedNodesByReason map[string]int
+}
+
+// candidateNodes resolves the tolerations and node selectors of the job to the set of nodes of the given types
+// the job may be scheduled on, via set intersection over the label and taint index of the db.
+// If there are no candidates, the job is unschedulable and iterating over the nodes of those types can be skipped.
+func (nodeDb *NodeDb) candidateNodes(jctx *schedulercontext.JobSchedulingContext, matchingNodeTypeIds []uint64) *candidateNodes {
+	nodeDb.mu.Lock()
+	nodes, _ := nodeDb.nodeIndex.candidateNodes(jctx, matchingNodeTypeIds, false)
+	var excluded []reasonCount
+	if nodes.isEmpty() {
+		// Only compute why nodes are excluded if none are left, since we don't iterate over nodes in that case.
+		_, excluded = nodeDb.nodeIndex.candidateNodes(jctx, matchingNodeTypeIds, true)
+	}
+	nodeDb.mu.Unlock()
+	numExcludedNodesByReason := make(map[string]int, len(excluded))
+	for _, e := range excluded {
+		numExcludedNodesByReason[nodeDb.stringFromPodRequirementsNotMetReason(e.reason)] += e.count
+	}
+	return &candidateNodes{
+		nodes:                    nodes,
+		numExcludedNodesByReason: numExcludedNodesByReason,
+	}
+}
+
 // NodeTypesMatchingJob returns a slice with all node types a pod could be scheduled on.
 // It also returns the number of nodes excluded by reason for exclusion.
 func (nodeDb *NodeDb) NodeTypesMatchingJob(jctx *schedulercontext.JobSchedulingContext) ([]uint64, map[string]int, error) {
@@ -1225,7 +1629,11 @@ func (nodeDb *NodeDb) AddEvictedJobSchedulingContextWithTxn(txn *memdb.Txn, inde
 	} else if obj := it.Next(); obj != nil {
 		return errors.Errorf("tried to insert evicted job %s with duplicate index %d", jctx.JobId, index)
 	}
-	if err := txn.Insert("evictedJobs", &EvictedJobSchedulingContext{JobId: jctx.JobId, Index: index, JobSchedulingContext: jctx}); err != nil {
+	evictedJobSchedulingContext := &EvictedJobSchedulingContext{JobId: jctx.JobId, Index: index, JobSchedulingContext: jctx}
+	if jctx.GangCardinality > 1 {
+		evictedJobSchedulingContext.GangId = jctx.GangId
+	}
+	if err := txn.Insert("evictedJobs", evictedJobSchedulingContext); err != nil {
 		return errors.WithStack(err)
 	}
 	return nil
@@ -1279,6 +1687,12 @@ func evictionsTableSchema() *memdb.TableSchema {
 				Unique:  true,
 				Indexer: &memdb.IntFieldIndex{Field: "Index"},
 			},
+			"gangId": {
+				Name:         "gangId",
+				Unique:       false,
+				AllowMissing: true,
+				Indexer:      &memdb.StringFieldIndex{Field: "GangId"},
+			},
 		},
 	}
 }
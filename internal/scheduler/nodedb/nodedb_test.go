@@ -2,8 +2,10 @@ package nodedb
 
 import (
 	"fmt"
+	"math/rand"
 	"strconv"
 	"testing"
+	"testing/quick"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -118,6 +120,120 @@ func TestSelectNodeForPod_NodeIdLabel_Failure(t *testing.T) {
 	}
 }
 
+func TestScheduleMany_JobSetExecutorPreference(t *testing.T) {
+	nodes := testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)
+	nodes[0].Executor = "executor-a"
+	nodes[1].Executor = "executor-b"
+	db, err := newNodeDbWithNodes(nodes)
+	require.NoError(t, err)
+	db.EnableJobSetExecutorPreference(1, func(_, _ string) string { return "" })
+
+	schedule := func(job *jobdb.Job) string {
+		jctxs := schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, []*jobdb.Job{job}, func(_ map[string]string) (string, int, int, bool, error) { return "", 1, 1, true, nil })
+		txn := db.Txn(true)
+		ok, err := db.ScheduleManyWithTxn(txn, jctxs)
+		require.NoError(t, err)
+		require.True(t, ok)
+		txn.Commit()
+		node, err := db.GetNode(jctxs[0].PodSchedulingContext.NodeId)
+		require.NoError(t, err)
+		return node.Executor
+	}
+
+	// Load one executor such that its node is the most tightly packed, and hence the one considered first.
+	var loadedExecutor string
+	for _, job := range testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 2) {
+		loadedExecutor = schedule(job.WithJobset("filler"))
+	}
+	otherExecutor := "executor-a"
+	if loadedExecutor == otherExecutor {
+		otherExecutor = "executor-b"
+	}
+
+	// The first job of the job set establishes the other executor as preferred via its annotation.
+	jobs := testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 4)
+	testfixtures.WithAnnotationsJobs(map[string]string{configuration.PreferredExecutorAnnotation: otherExecutor}, jobs[:1])
+	assert.Equal(t, otherExecutor, schedule(jobs[0]))
+
+	// Later members of the job set are co-located with it, even though the loaded node is considered first.
+	for _, job := range jobs[1:] {
+		assert.Equal(t, otherExecutor, schedule(job))
+	}
+
+	// Jobs that don't fit on the preferred executor spill over onto other executors.
+	spillover := testfixtures.WithRequestsJobs(
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("29")}},
+		testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1),
+	)
+	assert.Equal(t, loadedExecutor, schedule(spillover[0]))
+}
+
+func TestScheduleMany_NodeOrdering(t *testing.T) {
+	const numNodes = 10
+	const numJobs = 1000
+	tests := map[string]struct {
+		nodeOrdering configuration.NodeOrdering
+		// Expected number of nodes jobs are placed on.
+		expectedNumNodes int
+		// If positive, every node must be assigned within this many jobs of numJobs / numNodes.
+		tolerance int
+	}{
+		"packed": {
+			nodeOrdering: configuration.PackedNodeOrdering,
+			// Each node fits 320 jobs.
+			expectedNumNodes: 4,
+		},
+		"balanced": {
+			nodeOrdering:     configuration.BalancedNodeOrdering,
+			expectedNumNodes: numNodes,
+			tolerance:        1,
+		},
+		"random": {
+			nodeOrdering:     configuration.RandomNodeOrdering,
+			expectedNumNodes: numNodes,
+			tolerance:        50,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodes := testfixtures.N32CpuNodes(numNodes, testfixtures.TestPriorities)
+			schedule := func(seed int64) map[string]int {
+				db, err := newNodeDbWithNodes(nodes)
+				require.NoError(t, err)
+				db.EnableNodeOrdering(tc.nodeOrdering, seed)
+				jobs := testfixtures.WithRequestsJobs(
+					schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+						"cpu":    resource.MustParse("100m"),
+						"memory": resource.MustParse("100Mi"),
+					}},
+					testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, numJobs),
+				)
+				numJobsByNodeId := make(map[string]int)
+				for _, jctx := range schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, jobs, func(_ map[string]string) (string, int, int, bool, error) { return "", 1, 1, true, nil }) {
+					txn := db.Txn(true)
+					ok, err := db.ScheduleManyWithTxn(txn, []*schedulercontext.JobSchedulingContext{jctx})
+					require.NoError(t, err)
+					require.True(t, ok)
+					txn.Commit()
+					numJobsByNodeId[jctx.PodSchedulingContext.NodeId]++
+				}
+				return numJobsByNodeId
+			}
+
+			numJobsByNodeId := schedule(42)
+			assert.Len(t, numJobsByNodeId, tc.expectedNumNodes)
+			if tc.tolerance > 0 {
+				for nodeId, n := range numJobsByNodeId {
+					assert.InDelta(t, numJobs/numNodes, n, float64(tc.tolerance), "node %s", nodeId)
+				}
+			}
+
+			// Placements are reproducible given the same seed.
+			assert.Equal(t, numJobsByNodeId, schedule(42))
+		})
+	}
+}
+
 func TestNodeBindingEvictionUnbinding(t *testing.T) {
 	node := testfixtures.Test8GpuNode(testfixtures.TestPriorities)
 	nodeDb, err := newNodeDbWithNodes([]*schedulerobjects.Node{node})
@@ -560,6 +676,99 @@ func TestScheduleMany(t *testing.T) {
 	}
 }
 
+func TestScheduleMany_GangAwareFairPreemption(t *testing.T) {
+	tests := map[string]struct {
+		enableGangAwarePreemption bool
+		// Amount of cpu expected to be free on the first node after scheduling.
+		expectedFreeCpu string
+		// Indices of the jobs expected to remain evicted, i.e., eligible for being re-scheduled, after scheduling.
+		expectedEvictedJobIndices []int
+	}{
+		"the resources of the rest of the gang are released with gang-aware preemption": {
+			enableGangAwarePreemption: true,
+			expectedFreeCpu:           "16",
+			expectedEvictedJobIndices: []int{0, 2},
+		},
+		"only the resources of the job preempted are released without gang-aware preemption": {
+			expectedFreeCpu:           "0",
+			expectedEvictedJobIndices: []int{0, 1, 2},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodes := testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)
+			nodeDb, err := newNodeDbWithNodes(nil)
+			require.NoError(t, err)
+			nodeDb.EnableNewPreemptionStrategy()
+			if tc.enableGangAwarePreemption {
+				nodeDb.EnableGangAwarePreemption()
+			}
+
+			// A non-gang job and a member of a gang spanning both nodes are evicted from each node.
+			// The gang would be re-scheduled last.
+			gangJobs := testfixtures.WithGangAnnotationsJobs(testfixtures.N16Cpu128GiJobs("A", testfixtures.PriorityClass0, 2))
+			nonGangJobs := testfixtures.N16Cpu128GiJobs("A", testfixtures.PriorityClass0, 2)
+			evictedJobs := []*jobdb.Job{nonGangJobs[0], gangJobs[0], nonGangJobs[1], gangJobs[1]}
+			jobsByNode := [][]*jobdb.Job{evictedJobs[:2], evictedJobs[2:]}
+			noGangInfo := func(_ map[string]string) (string, int, int, bool, error) { return "", 1, 1, false, nil }
+			txn := nodeDb.Txn(true)
+			index := 0
+			for i, node := range nodes {
+				require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, jobsByNode[i], node))
+				entry, err := nodeDb.GetNodeWithTxn(txn, node.Id)
+				require.NoError(t, err)
+				jobs := make([]interfaces.LegacySchedulerJob, len(jobsByNode[i]))
+				for j, job := range jobsByNode[i] {
+					jobs[j] = job
+				}
+				_, entry, err = nodeDb.EvictJobsFromNode(testfixtures.TestPriorityClasses, nil, jobs, entry)
+				require.NoError(t, err)
+				require.NoError(t, nodeDb.UpsertWithTxn(txn, entry))
+				for _, job := range jobsByNode[i] {
+					jctx := schedulercontext.JobSchedulingContextFromJob(testfixtures.TestPriorityClasses, job, noGangInfo)
+					if gangId := job.GangId(); gangId != "" {
+						jctx.GangId = gangId
+						jctx.GangCardinality = len(gangJobs)
+					}
+					jctx.AddNodeSelector(schedulerconfig.NodeIdLabel, node.Id)
+					require.NoError(t, nodeDb.AddEvictedJobSchedulingContextWithTxn(txn, index, jctx))
+					index++
+				}
+			}
+			txn.Commit()
+
+			// Schedule a job that can only be scheduled by preventing evicted jobs from being re-scheduled.
+			jctx := schedulercontext.JobSchedulingContextFromJob(
+				testfixtures.TestPriorityClasses,
+				testfixtures.Test16Cpu128GiJob("B", testfixtures.PriorityClass0),
+				noGangInfo,
+			)
+			ok, err := nodeDb.ScheduleMany([]*schedulercontext.JobSchedulingContext{jctx})
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, nodes[1].Id, jctx.PodSchedulingContext.NodeId)
+
+			node, err := nodeDb.GetNode(nodes[0].Id)
+			require.NoError(t, err)
+			allocatable := node.AllocatableByPriority[evictedPriority]
+			freeCpu := allocatable.Get("cpu")
+			assert.True(t, freeCpu.Equal(resource.MustParse(tc.expectedFreeCpu)), "expected %s free cpu, but got %s", tc.expectedFreeCpu, freeCpu.String())
+
+			expectedEvictedJobIds := make([]string, len(tc.expectedEvictedJobIndices))
+			for i, j := range tc.expectedEvictedJobIndices {
+				expectedEvictedJobIds[i] = evictedJobs[j].Id()
+			}
+			it, err := nodeDb.Txn(false).Get("evictedJobs", "id")
+			require.NoError(t, err)
+			var actualEvictedJobIds []string
+			for obj := it.Next(); obj != nil; obj = it.Next() {
+				actualEvictedJobIds = append(actualEvictedJobIds, obj.(*EvictedJobSchedulingContext).JobId)
+			}
+			assert.ElementsMatch(t, expectedEvictedJobIds, actualEvictedJobIds)
+		})
+	}
+}
+
 func benchmarkUpsert(nodes []*schedulerobjects.Node, b *testing.B) {
 	nodeDb, err := NewNodeDb(
 		testfixtures.TestPriorityClasses,
@@ -723,11 +932,176 @@ func BenchmarkScheduleManyResourceConstrained(b *testing.B) {
 	)
 }
 
+// Resources indexed with the precision the kubelet accounts for them with,
+// such that the coarseness of the index doesn't prevent jobs from being scheduled.
+var kubeletPrecisionIndexedResources = []configuration.IndexedResource{
+	{Name: "cpu", Resolution: resource.MustParse("1m")},
+	{Name: "memory", Resolution: resource.MustParse("1")},
+}
+
+func TestScheduleIndividually_KubeletPrecision(t *testing.T) {
+	tests := map[string]struct {
+		// Cpu and overcommit factor of the node to schedule on.
+		NodeCpu         string
+		CpuOvercommit   float64
+		JobCpu          []string
+		ExpectedSuccess []bool
+	}{
+		"whole millicores": {
+			NodeCpu:         "1",
+			JobCpu:          []string{"500m", "500m", "1m"},
+			ExpectedSuccess: []bool{true, true, false},
+		},
+		// 499.5m + 500.5m is exactly 1 cpu, but the kubelet rounds each request up to 500m + 501m.
+		"fractional millicores": {
+			NodeCpu:         "1",
+			JobCpu:          []string{"499500u", "500500u"},
+			ExpectedSuccess: []bool{true, false},
+		},
+		"fractional millicores that fit after rounding": {
+			NodeCpu:         "1",
+			JobCpu:          []string{"499500u", "499500u", "1m"},
+			ExpectedSuccess: []bool{true, true, false},
+		},
+		// Fractional millicores of the node are unusable, since the kubelet only accounts for whole millicores.
+		"fractional node millicores": {
+			NodeCpu:         "1000999u",
+			JobCpu:          []string{"1", "1m"},
+			ExpectedSuccess: []bool{true, false},
+		},
+		"overcommit": {
+			NodeCpu:         "1",
+			CpuOvercommit:   1.5,
+			JobCpu:          []string{"1", "500m", "1m"},
+			ExpectedSuccess: []bool{true, true, false},
+		},
+		"overcommit rounded down to millicores": {
+			NodeCpu:         "1",
+			CpuOvercommit:   1.0 / 3,
+			JobCpu:          []string{"333m", "1m"},
+			ExpectedSuccess: []bool{true, false},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			node := testfixtures.TestNode(
+				testfixtures.TestPriorities,
+				map[string]resource.Quantity{
+					"cpu":    resource.MustParse(tc.NodeCpu),
+					"memory": resource.MustParse("256Gi"),
+				},
+			)
+			if tc.CpuOvercommit != 0 {
+				node = node.WithOvercommit(map[string]float64{"cpu": tc.CpuOvercommit})
+			}
+			nodeDb, err := newNodeDbWithNodesAndResources([]*schedulerobjects.Node{node}, kubeletPrecisionIndexedResources)
+			require.NoError(t, err)
+
+			for i, cpu := range tc.JobCpu {
+				jobs := testfixtures.WithRequestsJobs(
+					schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse(cpu)}},
+					testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1),
+				)
+				jctxs := schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, jobs, func(_ map[string]string) (string, int, int, bool, error) { return "", 1, 1, true, nil })
+				ok, err := nodeDb.ScheduleMany(jctxs)
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedSuccess[i], ok, "job %d requesting %s cpu", i, cpu)
+			}
+		})
+	}
+}
+
+// Any job the nodeDb places on a node must also fit on that node according to the kubelet,
+// which accounts for cpu in whole millicores and for memory in whole bytes, rounding the request of each pod up.
+func TestScheduleIndividually_KubeletFitProperty(t *testing.T) {
+	const numJobs = 40
+	property := func(seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+
+		// Node resources with sub-millicore and sub-byte precision,
+		// and overcommit factors that are multiples of 1/64 such that they're represented exactly.
+		numNodes := 1 + r.Intn(3)
+		nodes := make([]*schedulerobjects.Node, numNodes)
+		kubeletCpuMillisByNodeId := make(map[string]int64, numNodes)
+		kubeletMemoryByNodeId := make(map[string]int64, numNodes)
+		for i := range nodes {
+			cpuNanos := 1_000_000_000 + r.Int63n(4_000_000_000)
+			memoryMillis := 1_000_000_000_000 + r.Int63n(4_000_000_000_000)
+			cpuFactor64 := 64 + r.Int63n(65)
+			memoryFactor64 := 64 + r.Int63n(33)
+			node := testfixtures.TestNode(
+				testfixtures.TestPriorities,
+				map[string]resource.Quantity{
+					"cpu":    *resource.NewScaledQuantity(cpuNanos, resource.Nano),
+					"memory": *resource.NewScaledQuantity(memoryMillis, resource.Milli),
+				},
+			).WithOvercommit(map[string]float64{
+				"cpu":    float64(cpuFactor64) / 64,
+				"memory": float64(memoryFactor64) / 64,
+			})
+			nodes[i] = node
+			kubeletCpuMillisByNodeId[node.Id] = cpuNanos * cpuFactor64 / 64 / 1_000_000
+			kubeletMemoryByNodeId[node.Id] = memoryMillis * memoryFactor64 / 64 / 1000
+		}
+		nodeDb, err := newNodeDbWithNodesAndResources(nodes, kubeletPrecisionIndexedResources)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		// Jobs requesting sub-millicore cpu and fractional bytes of memory.
+		cpuMillisByNodeId := make(map[string]int64, numNodes)
+		memoryByNodeId := make(map[string]int64, numNodes)
+		for i := 0; i < numJobs; i++ {
+			cpu := *resource.NewScaledQuantity(1_000_000+r.Int63n(1_000_000_000), resource.Nano)
+			memory := *resource.NewScaledQuantity(1_000_000+r.Int63n(1_000_000_000_000), resource.Milli)
+			jobs := testfixtures.WithRequestsJobs(
+				schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": cpu, "memory": memory}},
+				testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1),
+			)
+			jctxs := schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, jobs, func(_ map[string]string) (string, int, int, bool, error) { return "", 1, 1, true, nil })
+			ok, err := nodeDb.ScheduleMany(jctxs)
+			if err != nil {
+				t.Log(err)
+				return false
+			}
+			if !ok {
+				continue
+			}
+			nodeId := jctxs[0].PodSchedulingContext.NodeId
+			// MilliValue and Value round up.
+			cpuMillisByNodeId[nodeId] += cpu.MilliValue()
+			memoryByNodeId[nodeId] += memory.Value()
+		}
+
+		for nodeId, cpuMillis := range cpuMillisByNodeId {
+			if cpuMillis > kubeletCpuMillisByNodeId[nodeId] {
+				t.Logf("node %s: %dm cpu requested, but only %dm allocatable", nodeId, cpuMillis, kubeletCpuMillisByNodeId[nodeId])
+				return false
+			}
+		}
+		for nodeId, memory := range memoryByNodeId {
+			if memory > kubeletMemoryByNodeId[nodeId] {
+				t.Logf("node %s: %d memory requested, but only %d allocatable", nodeId, memory, kubeletMemoryByNodeId[nodeId])
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func newNodeDbWithNodes(nodes []*schedulerobjects.Node) (*NodeDb, error) {
+	return newNodeDbWithNodesAndResources(nodes, testfixtures.TestResources)
+}
+
+func newNodeDbWithNodesAndResources(nodes []*schedulerobjects.Node, indexedResources []configuration.IndexedResource) (*NodeDb, error) {
 	nodeDb, err := NewNodeDb(
 		testfixtures.TestPriorityClasses,
 		testfixtures.TestMaxExtraNodesToConsider,
-		testfixtures.TestResources,
+		indexedResources,
 		testfixtures.TestIndexedTaints,
 		testfixtures.TestIndexedNodeLabels,
 		testfixtures.TestWellKnownNodeTypes,
@@ -766,3 +1140,28 @@ func randomString(n int) string {
 	}
 	return s
 }
+
+func TestCreateAndInsertWithJobDbJobsWithTxn_NeverPreemptQueues(t *testing.T) {
+	node := testfixtures.Test32CpuNode(testfixtures.TestPriorities)
+	nodeDb, err := newNodeDbWithNodes(nil)
+	require.NoError(t, err)
+	nodeDb.EnableNeverPreemptQueues(func(queue string) bool { return queue == "A" })
+	neverPreemptJob := testfixtures.Test16Cpu128GiJob("A", testfixtures.PriorityClass0).WithQueued(false).WithNewRun("executor", node.Id, node.Name, 0, testfixtures.BaseTime)
+	job := testfixtures.Test16Cpu128GiJob("B", testfixtures.PriorityClass0).WithQueued(false).WithNewRun("executor", node.Id, node.Name, 0, testfixtures.BaseTime)
+	txn := nodeDb.Txn(true)
+	require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, []*jobdb.Job{neverPreemptJob, job}, node))
+	txn.Commit()
+
+	// The job of the never-preempt queue is bound at the highest priority, such that its resources are allocatable to no job.
+	maxPriority := testfixtures.TestPriorities[len(testfixtures.TestPriorities)-1]
+	priority, ok := nodeDb.GetScheduledAtPriority(neverPreemptJob.GetId())
+	require.True(t, ok)
+	assert.Equal(t, maxPriority, priority)
+	priority, ok = nodeDb.GetScheduledAtPriority(job.GetId())
+	require.True(t, ok)
+	assert.Equal(t, int32(0), priority)
+	entry, err := nodeDb.GetNode(node.Id)
+	require.NoError(t, err)
+	allocatable := entry.AllocatableByPriority[maxPriority]
+	assert.True(t, allocatable.Get("cpu").Equal(resource.MustParse("16")), allocatable.Get("cpu"))
+}
@@ -0,0 +1,244 @@
+package nodedb
+
+import (
+	"math/bits"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+)
+
+// nodeSet is a set of nodes, represented as a bitset over node positions in a nodeIndex.
+type nodeSet []uint64
+
+func (s nodeSet) contains(i int) bool {
+	word := i / 64
+	return word < len(s) && s[word]&(1<<(uint(i)%64)) != 0
+}
+
+func (s *nodeSet) add(i int) {
+	word := i / 64
+	for len(*s) <= word {
+		*s = append(*s, 0)
+	}
+	(*s)[word] |= 1 << (uint(i) % 64)
+}
+
+func (s nodeSet) remove(i int) {
+	if word := i / 64; word < len(s) {
+		s[word] &^= 1 << (uint(i) % 64)
+	}
+}
+
+func (s nodeSet) len() int {
+	n := 0
+	for _, w := range s {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+func (s nodeSet) isEmpty() bool {
+	for _, w := range s {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// forEach calls f with each node in the set, in increasing order.
+func (s nodeSet) forEach(f func(i int)) {
+	for word, w := range s {
+		for w != 0 {
+			f(word*64 + bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+}
+
+func (s nodeSet) clone() nodeSet {
+	rv := make(nodeSet, len(s))
+	copy(rv, s)
+	return rv
+}
+
+// union sets s to the union of s and other.
+func (s *nodeSet) union(other nodeSet) {
+	for len(*s) < len(other) {
+		*s = append(*s, 0)
+	}
+	for i, w := range other {
+		(*s)[i] |= w
+	}
+}
+
+// intersect sets s to the intersection of s and other.
+func (s nodeSet) intersect(other nodeSet) {
+	for i := range s {
+		if i < len(other) {
+			s[i] &= other[i]
+		} else {
+			s[i] = 0
+		}
+	}
+}
+
+// subtract removes from s all nodes in other.
+func (s nodeSet) subtract(other nodeSet) {
+	for i := 0; i < len(s) && i < len(other); i++ {
+		s[i] &^= other[i]
+	}
+}
+
+// nodeIndex is an inverted index over the labels and taints of the nodes in a NodeDb.
+// It's populated as nodes are created, i.e., when the NodeDb is constructed from an executor snapshot,
+// and is used to resolve the node selectors and tolerations of a job to a set of candidate nodes
+// by set intersection before checking the remaining requirements of the job node by node.
+//
+// The labels and taints of nodes must not be mutated after the node has been created.
+type nodeIndex struct {
+	// Position of each node in the index, by node id.
+	positionById map[string]int
+	// Labels of each node, by position.
+	labelsByPosition []map[string]string
+	// Nodes by label key and label value.
+	nodesByLabelValue map[string]map[string]nodeSet
+	// Nodes grouped by their taints.
+	// Tolerations are evaluated once per group rather than once per node.
+	taintGroups     []*taintGroup
+	taintGroupByKey map[string]*taintGroup
+	// Nodes by node type id.
+	nodesByNodeType map[uint64]nodeSet
+}
+
+// taintGroup is a set of nodes with identical taints.
+type taintGroup struct {
+	taints []v1.Taint
+	nodes  nodeSet
+}
+
+// reasonCount is the number of nodes excluded for a particular reason.
+type reasonCount struct {
+	reason PodRequirementsNotMetReason
+	count  int
+}
+
+func newNodeIndex() *nodeIndex {
+	return &nodeIndex{
+		positionById:      make(map[string]int),
+		nodesByLabelValue: make(map[string]map[string]nodeSet),
+		taintGroupByKey:   make(map[string]*taintGroup),
+		nodesByNodeType:   make(map[uint64]nodeSet),
+	}
+}
+
+// add indexes the node with the given id, node type, labels, and taints.
+// If a node with the same id was added previously, it's re-indexed at the same position.
+func (index *nodeIndex) add(id string, nodeTypeId uint64, labels map[string]string, taints []v1.Taint) {
+	i, ok := index.positionById[id]
+	if ok {
+		index.remove(i)
+		index.labelsByPosition[i] = labels
+	} else {
+		i = len(index.positionById)
+		index.positionById[id] = i
+		index.labelsByPosition = append(index.labelsByPosition, labels)
+	}
+	for key, value := range labels {
+		nodesByValue, ok := index.nodesByLabelValue[key]
+		if !ok {
+			nodesByValue = make(map[string]nodeSet)
+			index.nodesByLabelValue[key] = nodesByValue
+		}
+		nodes := nodesByValue[value]
+		nodes.add(i)
+		nodesByValue[value] = nodes
+	}
+	key := taintsKey(taints)
+	group, ok := index.taintGroupByKey[key]
+	if !ok {
+		group = &taintGroup{taints: taints}
+		index.taintGroupByKey[key] = group
+		index.taintGroups = append(index.taintGroups, group)
+	}
+	group.nodes.add(i)
+	nodes := index.nodesByNodeType[nodeTypeId]
+	nodes.add(i)
+	index.nodesByNodeType[nodeTypeId] = nodes
+}
+
+func (index *nodeIndex) remove(i int) {
+	for _, nodesByValue := range index.nodesByLabelValue {
+		for _, nodes := range nodesByValue {
+			nodes.remove(i)
+		}
+	}
+	for _, group := range index.taintGroups {
+		group.nodes.remove(i)
+	}
+	for _, nodes := range index.nodesByNodeType {
+		nodes.remove(i)
+	}
+}
+
+// candidateNodes returns the nodes of the given node types whose taints are tolerated by the job
+// and whose labels match its node selectors. If countExcluded is true, it also returns the number of other nodes
+// of those types by reason for exclusion, where nodes are excluded for the same reason StaticJobRequirementsMet
+// would return for them. Requirements not covered by the index, i.e., node affinity and resources,
+// must still be checked for each candidate.
+func (index *nodeIndex) candidateNodes(jctx *schedulercontext.JobSchedulingContext, nodeTypeIds []uint64, countExcluded bool) (nodeSet, []reasonCount) {
+	var candidates nodeSet
+	for _, nodeTypeId := range nodeTypeIds {
+		candidates.union(index.nodesByNodeType[nodeTypeId])
+	}
+	var excluded []reasonCount
+	for _, group := range index.taintGroups {
+		if len(group.taints) == 0 {
+			continue
+		}
+		if matches, reason := TolerationRequirementsMet(group.taints, jctx.AdditionalTolerations, jctx.PodRequirements.GetTolerations()); !matches {
+			if countExcluded {
+				nodes := group.nodes.clone()
+				nodes.intersect(candidates)
+				if n := nodes.len(); n > 0 {
+					excluded = append(excluded, reasonCount{reason: reason, count: n})
+				}
+			}
+			candidates.subtract(group.nodes)
+		}
+	}
+	for _, nodeSelector := range []map[string]string{jctx.AdditionalNodeSelectors, jctx.PodRequirements.GetNodeSelector()} {
+		for key, podValue := range nodeSelector {
+			if !countExcluded {
+				candidates.intersect(index.nodesByLabelValue[key][podValue])
+				continue
+			}
+			unmatched := candidates.clone()
+			unmatched.subtract(index.nodesByLabelValue[key][podValue])
+			if unmatched.isEmpty() {
+				continue
+			}
+			numMissing := 0
+			numUnmatchedByNodeValue := make(map[string]int)
+			unmatched.forEach(func(i int) {
+				if nodeValue, ok := index.labelsByPosition[i][key]; ok {
+					numUnmatchedByNodeValue[nodeValue]++
+				} else {
+					numMissing++
+				}
+			})
+			if numMissing > 0 {
+				excluded = append(excluded, reasonCount{reason: &MissingLabel{Label: key}, count: numMissing})
+			}
+			for nodeValue, n := range numUnmatchedByNodeValue {
+				excluded = append(
+					excluded,
+					reasonCount{reason: &UnmatchedLabel{Label: key, PodValue: podValue, NodeValue: nodeValue}, count: n},
+				)
+			}
+			candidates.subtract(unmatched)
+		}
+	}
+	return candidates, excluded
+}
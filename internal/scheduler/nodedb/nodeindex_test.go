@@ -0,0 +1,204 @@
+package nodedb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestNodeSet(t *testing.T) {
+	var s nodeSet
+	for _, i := range []int{0, 63, 64, 200} {
+		s.add(i)
+	}
+	assert.Equal(t, 4, s.len())
+	assert.True(t, s.contains(64))
+	assert.False(t, s.contains(65))
+	assert.False(t, s.contains(1000))
+
+	other := s.clone()
+	other.remove(0)
+	assert.True(t, s.contains(0))
+
+	var t2 nodeSet
+	t2.add(63)
+	t2.add(200)
+	s.intersect(t2)
+	assert.Equal(t, 2, s.len())
+	s.subtract(t2)
+	assert.Equal(t, 0, s.len())
+}
+
+func TestNodeIndex_ReAddingNodeReplacesLabelsAndTaints(t *testing.T) {
+	index := newNodeIndex()
+	index.add("node", 1, map[string]string{"zone": "a"}, []v1.Taint{{Key: "dedicated", Value: "x", Effect: v1.TaintEffectNoSchedule}})
+	index.add("node", 2, map[string]string{"zone": "b"}, nil)
+	i := index.positionById["node"]
+	assert.False(t, index.nodesByLabelValue["zone"]["a"].contains(i))
+	assert.True(t, index.nodesByLabelValue["zone"]["b"].contains(i))
+	assert.False(t, index.taintGroups[0].nodes.contains(i))
+	assert.True(t, index.taintGroups[1].nodes.contains(i))
+	assert.False(t, index.nodesByNodeType[1].contains(i))
+	assert.True(t, index.nodesByNodeType[2].contains(i))
+}
+
+// Candidate nodes resolved via the index should be exactly those for which checking tolerations and node selectors
+// node by node succeeds, and other nodes should be excluded for the same reasons.
+func TestNodeIndex_CandidateNodesMatchBruteForce(t *testing.T) {
+	nodes := nodeIndexTestNodes(1000)
+	nodeDb, err := newNodeDbWithNodes(nodes)
+	require.NoError(t, err)
+	for name, jctx := range nodeIndexTestJobs() {
+		t.Run(name, func(t *testing.T) {
+			matchingNodeTypeIds, _, err := nodeDb.NodeTypesMatchingJob(jctx)
+			require.NoError(t, err)
+			candidates, excluded := nodeDb.nodeIndex.candidateNodes(jctx, matchingNodeTypeIds, true)
+			candidatesWithoutCounting, _ := nodeDb.nodeIndex.candidateNodes(jctx, matchingNodeTypeIds, false)
+			assert.Equal(t, candidates, candidatesWithoutCounting)
+			numExcludedNodesByReason := make(map[string]int)
+			for _, e := range excluded {
+				numExcludedNodesByReason[e.reason.String()] += e.count
+			}
+
+			numCandidates := 0
+			expectedNumExcludedNodesByReason := make(map[string]int)
+			for _, node := range nodes {
+				entry, err := nodeDb.GetNode(node.Id)
+				require.NoError(t, err)
+				if !slices.Contains(matchingNodeTypeIds, entry.NodeTypeId) {
+					assert.False(t, candidates.contains(nodeDb.nodeIndex.positionById[entry.Id]), "node %s", entry.Id)
+					continue
+				}
+				matches, reason := bruteForceTaintsAndSelectorsMet(entry, jctx)
+				if matches {
+					numCandidates++
+				} else {
+					expectedNumExcludedNodesByReason[reason.String()]++
+				}
+				assert.Equal(t, matches, candidates.contains(nodeDb.nodeIndex.positionById[entry.Id]), "node %s", entry.Id)
+			}
+			assert.Equal(t, numCandidates, candidates.len())
+			assert.Equal(t, expectedNumExcludedNodesByReason, numExcludedNodesByReason)
+		})
+	}
+}
+
+// Scheduling with the index should select the same node as without it.
+// If there's no such node, all nodes are considered either way, and should be excluded for the same reasons.
+func TestNodeIndex_SelectNodeMatchesBruteForce(t *testing.T) {
+	nodes := nodeIndexTestNodes(1000)
+	nodeDb, err := newNodeDbWithNodes(nodes)
+	require.NoError(t, err)
+	for name, jctx := range nodeIndexTestJobs() {
+		t.Run(name, func(t *testing.T) {
+			matchingNodeTypeIds, _, err := nodeDb.NodeTypesMatchingJob(jctx)
+			require.NoError(t, err)
+			for _, priority := range []int32{evictedPriority, jctx.PodRequirements.Priority} {
+				indexedNode, indexedExcluded := selectNodeForPodWithCandidates(t, nodeDb, jctx, nodeDb.candidateNodes(jctx, matchingNodeTypeIds), priority)
+				bruteForceNode, bruteForceExcluded := selectNodeForPodWithCandidates(t, nodeDb, jctx, nil, priority)
+				if bruteForceNode == nil {
+					assert.Nil(t, indexedNode)
+					assert.Equal(t, bruteForceExcluded, indexedExcluded)
+				} else if assert.NotNil(t, indexedNode) {
+					assert.Equal(t, bruteForceNode.Id, indexedNode.Id)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSelectNodeForJob10000Nodes(b *testing.B) {
+	nodeDb, err := newNodeDbWithNodes(nodeIndexTestNodes(10000))
+	require.NoError(b, err)
+	for name, jctx := range nodeIndexTestJobs() {
+		b.Run(name, func(b *testing.B) {
+			b.Run("indexed", func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					matchingNodeTypeIds, _, err := nodeDb.NodeTypesMatchingJob(jctx)
+					require.NoError(b, err)
+					selectNodeForPodWithCandidates(b, nodeDb, jctx, nodeDb.candidateNodes(jctx, matchingNodeTypeIds), evictedPriority)
+				}
+			})
+			b.Run("brute force", func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					selectNodeForPodWithCandidates(b, nodeDb, jctx, nil, evictedPriority)
+				}
+			})
+		})
+	}
+}
+
+func selectNodeForPodWithCandidates(t require.TestingT, nodeDb *NodeDb, jctx *schedulercontext.JobSchedulingContext, candidates *candidateNodes, priority int32) (*Node, map[string]int) {
+	matchingNodeTypeIds, numExcludedNodesByReason, err := nodeDb.NodeTypesMatchingJob(jctx)
+	require.NoError(t, err)
+	jctx.PodSchedulingContext = &schedulercontext.PodSchedulingContext{
+		NumExcludedNodesByReason: numExcludedNodesByReason,
+	}
+	txn := nodeDb.Txn(false)
+	defer txn.Abort()
+	node, err := nodeDb.selectNodeForPodAtPriority(txn, jctx, matchingNodeTypeIds, candidates, priority)
+	require.NoError(t, err)
+	return node, jctx.PodSchedulingContext.NumExcludedNodesByReason
+}
+
+func bruteForceTaintsAndSelectorsMet(node *Node, jctx *schedulercontext.JobSchedulingContext) (bool, PodRequirementsNotMetReason) {
+	if matches, reason := TolerationRequirementsMet(node.Taints, jctx.AdditionalTolerations, jctx.PodRequirements.GetTolerations()); !matches {
+		return false, reason
+	}
+	if matches, reason := NodeSelectorRequirementsMet(node.Labels, nil, jctx.AdditionalNodeSelectors); !matches {
+		return false, reason
+	}
+	return NodeSelectorRequirementsMet(node.Labels, nil, jctx.PodRequirements.GetNodeSelector())
+}
+
+// nodeIndexTestNodes returns n nodes spread over 10 zones, some of which are missing the zone label,
+// some of which are dedicated to a team via an unindexed taint, and some of which are tainted with an indexed taint.
+func nodeIndexTestNodes(n int) []*schedulerobjects.Node {
+	nodes := make([]*schedulerobjects.Node, n)
+	for i := range nodes {
+		if i%10 == 9 {
+			nodes[i] = testfixtures.TestTainted32CpuNode(testfixtures.TestPriorities)
+		} else {
+			nodes[i] = testfixtures.Test32CpuNode(testfixtures.TestPriorities)
+		}
+		if i%7 != 0 {
+			nodes[i].Labels["zone"] = fmt.Sprintf("zone-%d", i%10)
+		}
+		if i%5 == 0 {
+			nodes[i].Taints = append(nodes[i].Taints, v1.Taint{Key: "dedicated", Value: "team-a", Effect: v1.TaintEffectNoSchedule})
+		}
+	}
+	return nodes
+}
+
+// nodeIndexTestJobs returns jobs with node selectors and tolerations matching varying subsets of nodeIndexTestNodes.
+// Each job selects at most one label such that the reason nodes are excluded by is deterministic.
+func nodeIndexTestJobs() map[string]*schedulercontext.JobSchedulingContext {
+	dedicatedToleration := v1.Toleration{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "team-a", Effect: v1.TaintEffectNoSchedule}
+	newJctx := func(selector map[string]string, tolerations ...v1.Toleration) *schedulercontext.JobSchedulingContext {
+		job := testfixtures.Test1Cpu4GiJob("A", testfixtures.PriorityClass0)
+		if selector != nil {
+			job = testfixtures.WithNodeSelectorJob(selector, job)
+		}
+		jctx := schedulercontext.JobSchedulingContextFromJob(testfixtures.TestPriorityClasses, job, func(_ map[string]string) (string, int, int, bool, error) { return "", 1, 1, true, nil })
+		jctx.AdditionalTolerations = tolerations
+		return jctx
+	}
+	return map[string]*schedulercontext.JobSchedulingContext{
+		"no selector":                      newJctx(nil),
+		"zone selector":                    newJctx(map[string]string{"zone": "zone-3"}),
+		"zone selector with toleration":    newJctx(map[string]string{"zone": "zone-5"}, dedicatedToleration),
+		"zone selector matching no nodes":  newJctx(map[string]string{"zone": "zone-42"}),
+		"unknown label selector":           newJctx(map[string]string{"rack": "rack-1"}),
+		"tainted zone selector":            newJctx(map[string]string{"zone": "zone-9"}),
+		"tainted zone selector toleration": newJctx(map[string]string{"zone": "zone-9"}, dedicatedToleration),
+	}
+}
@@ -274,10 +274,12 @@ func ResourceRequirementsMet(available schedulerobjects.ResourceList, required v
 
 // findGreaterQuantity returns the name of a resource in required with non-zero quantity such that
 // the corresponding quantity in available is smaller, or returns false if no such resource can be found.
+// Required quantities are rounded up to kubelet precision before comparing, in the same way as when binding jobs to nodes.
 func findGreaterQuantity(available schedulerobjects.ResourceList, required v1.ResourceList) (string, resource.Quantity, resource.Quantity, bool) {
 	for t, requiredQuantity := range required {
 		availableQuantity := available.Get(string(t))
-		if requiredQuantity.Cmp(availableQuantity) == 1 {
+		roundedRequiredQuantity := schedulerobjects.RoundUpToKubeletPrecision(string(t), requiredQuantity)
+		if roundedRequiredQuantity.Cmp(availableQuantity) == 1 {
 			return string(t), availableQuantity, requiredQuantity, true
 		}
 	}
@@ -0,0 +1,126 @@
+package nodedb
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+)
+
+const (
+	// LeastAllocatedNodeScorerName is the name of the scorer preferring nodes with the largest fraction of their
+	// resources allocatable. Used alone, with any node ordering but RandomNodeOrdering, it places jobs as
+	// BalancedNodeOrdering does.
+	LeastAllocatedNodeScorerName = "leastAllocated"
+	// MostAllocatedNodeScorerName is the name of the scorer preferring nodes with the least resources allocatable,
+	// i.e., bin-packing jobs onto as few nodes as possible. Used alone with PackedNodeOrdering, it places jobs as
+	// PackedNodeOrdering does without node scoring.
+	MostAllocatedNodeScorerName = "mostAllocated"
+)
+
+// NodeScorer scores the nodes a job may be scheduled on, once they've been found to meet its requirements.
+// Among those nodes, the job is placed on that with the highest weighted sum of scores across a NodeScoringPipeline.
+type NodeScorer interface {
+	// Score returns the score of node, stored in nodeDb, for the job of jctx scheduled at priority. Higher is better.
+	// Scores should be in [0, 1], such that the weights of a pipeline determine the importance of each scorer.
+	Score(nodeDb *NodeDb, jctx *schedulercontext.JobSchedulingContext, node *Node, priority int32) float64
+}
+
+var (
+	nodeScorersMu sync.Mutex
+	// Scorers that may be referred to by name in NodeScorerConfig.
+	nodeScorersByName = map[string]NodeScorer{
+		LeastAllocatedNodeScorerName: leastAllocatedNodeScorer{},
+		MostAllocatedNodeScorerName:  mostAllocatedNodeScorer{},
+	}
+)
+
+// RegisterNodeScorer makes scorer available to pipelines under name.
+// Returns an error if a scorer with that name is already registered.
+func RegisterNodeScorer(name string, scorer NodeScorer) error {
+	nodeScorersMu.Lock()
+	defer nodeScorersMu.Unlock()
+	if _, ok := nodeScorersByName[name]; ok {
+		return errors.Errorf("node scorer %s is already registered", name)
+	}
+	nodeScorersByName[name] = scorer
+	return nil
+}
+
+// WeightedNodeScorer is a NodeScorer along with the name it's registered under and the weight of its scores.
+type WeightedNodeScorer struct {
+	Name   string
+	Weight float64
+	Scorer NodeScorer
+}
+
+// NodeScoringPipeline is an ordered list of scorers, the weighted sum of whose scores nodes are ranked by;
+// see NodeDb.EnableNodeScoring.
+type NodeScoringPipeline []WeightedNodeScorer
+
+// NewNodeScoringPipeline returns the pipeline of the registered scorers named by configs, in the same order.
+func NewNodeScoringPipeline(configs []configuration.NodeScorerConfig) (NodeScoringPipeline, error) {
+	nodeScorersMu.Lock()
+	defer nodeScorersMu.Unlock()
+	pipeline := make(NodeScoringPipeline, len(configs))
+	for i, config := range configs {
+		scorer, ok := nodeScorersByName[config.Name]
+		if !ok {
+			return nil, errors.Errorf("unknown node scorer %s", config.Name)
+		}
+		pipeline[i] = WeightedNodeScorer{Name: config.Name, Weight: config.Weight, Scorer: scorer}
+	}
+	return pipeline, nil
+}
+
+// score returns the weighted sum of the scores of node.
+func (pipeline NodeScoringPipeline) score(nodeDb *NodeDb, jctx *schedulercontext.JobSchedulingContext, node *Node, priority int32) float64 {
+	var rv float64
+	for _, scorer := range pipeline {
+		rv += scorer.Weight * scorer.Scorer.Score(nodeDb, jctx, node, priority)
+	}
+	return rv
+}
+
+// contributions returns the weighted score of node by each scorer, indexed by scorer name.
+func (pipeline NodeScoringPipeline) contributions(nodeDb *NodeDb, jctx *schedulercontext.JobSchedulingContext, node *Node, priority int32) map[string]float64 {
+	rv := make(map[string]float64, len(pipeline))
+	for _, scorer := range pipeline {
+		rv[scorer.Name] += scorer.Weight * scorer.Scorer.Score(nodeDb, jctx, node, priority)
+	}
+	return rv
+}
+
+// leastAllocatedNodeScorer scores nodes by the fraction of their indexed resources allocatable,
+// averaged over indexed resources.
+type leastAllocatedNodeScorer struct{}
+
+func (leastAllocatedNodeScorer) Score(nodeDb *NodeDb, _ *schedulercontext.JobSchedulingContext, node *Node, priority int32) float64 {
+	if len(nodeDb.indexedResources) == 0 {
+		return 0
+	}
+	return nodeDb.allocatableFraction(node, priority) / float64(len(nodeDb.indexedResources))
+}
+
+// mostAllocatedNodeScorer scores nodes by how little of the first indexed resource, e.g., cpu, they have allocatable,
+// relative to the largest amount of it on any node. Allocatable amounts are rounded to the resolution of the index,
+// such that nodes score in the order they're indexed in; see nodeTypesIteratorPQ.less.
+type mostAllocatedNodeScorer struct{}
+
+func (mostAllocatedNodeScorer) Score(nodeDb *NodeDb, _ *schedulercontext.JobSchedulingContext, node *Node, priority int32) float64 {
+	if len(nodeDb.indexedResources) == 0 {
+		return 0
+	}
+	t := nodeDb.indexedResources[0]
+	nodeDb.mu.Lock()
+	largest := nodeDb.largestNodeResources.Get(t)
+	nodeDb.mu.Unlock()
+	if largest.IsZero() {
+		return 0
+	}
+	allocatableByPriority := node.AllocatableByPriority[priority]
+	allocatable := roundQuantityToResolution(allocatableByPriority.Get(t), nodeDb.indexedResourceResolutionMillis[0])
+	return 1 - float64(allocatable.MilliValue())/float64(largest.MilliValue())
+}
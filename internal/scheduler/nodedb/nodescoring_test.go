@@ -0,0 +1,203 @@
+package nodedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+// nodeScoringTestNodes returns nodes of different sizes, ordered differently by cpu and by memory.
+func nodeScoringTestNodes() []*schedulerobjects.Node {
+	var nodes []*schedulerobjects.Node
+	for _, resources := range []map[string]string{
+		{"cpu": "16", "memory": "64Gi"},
+		{"cpu": "16", "memory": "256Gi"},
+		{"cpu": "32", "memory": "256Gi"},
+		{"cpu": "64", "memory": "128Gi"},
+		{"cpu": "64", "memory": "128Gi"},
+	} {
+		nodes = append(nodes, testfixtures.TestNode(testfixtures.TestPriorities, map[string]resource.Quantity{
+			"cpu":    resource.MustParse(resources["cpu"]),
+			"memory": resource.MustParse(resources["memory"]),
+		}))
+	}
+	return append(nodes, testfixtures.N8GpuNodes(2, testfixtures.TestPriorities)...)
+}
+
+// nodeScoringTestJobs returns jobs of different sizes, interleaved.
+func nodeScoringTestJobs() []*jobdb.Job {
+	var jobs []*jobdb.Job
+	for i := 0; i < 20; i++ {
+		jobs = append(jobs, testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 3)...)
+		jobs = append(jobs, testfixtures.WithRequestsJobs(
+			schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("100m"),
+				"memory": resource.MustParse("6Gi"),
+			}},
+			testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 2),
+		)...)
+		if i%4 == 0 {
+			jobs = append(jobs, testfixtures.N16Cpu128GiJobs("A", testfixtures.PriorityClass0, 1)...)
+		}
+	}
+	return jobs
+}
+
+// scheduleOneByOne schedules jobs one at a time onto nodes using a new nodeDb configured by configure,
+// and returns the id of the node each job is placed on, indexed by job id, and the scheduling context of each job.
+func scheduleOneByOne(t *testing.T, nodes []*schedulerobjects.Node, jobs []*jobdb.Job, configure func(nodeDb *NodeDb)) (map[string]string, []*schedulercontext.JobSchedulingContext) {
+	nodeDb, err := newNodeDbWithNodes(nodes)
+	require.NoError(t, err)
+	configure(nodeDb)
+	nodeIdByJobId := make(map[string]string, len(jobs))
+	jctxs := schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, jobs, func(_ map[string]string) (string, int, int, bool, error) { return "", 1, 1, true, nil })
+	for _, jctx := range jctxs {
+		txn := nodeDb.Txn(true)
+		_, err := nodeDb.ScheduleManyWithTxn(txn, []*schedulercontext.JobSchedulingContext{jctx})
+		require.NoError(t, err)
+		txn.Commit()
+		nodeIdByJobId[jctx.JobId] = jctx.PodSchedulingContext.NodeId
+	}
+	return nodeIdByJobId, jctxs
+}
+
+func TestNodeScoring_BuiltInScorersReproduceNodeOrderings(t *testing.T) {
+	tests := map[string]struct {
+		nodeOrdering configuration.NodeOrdering
+		// Pipeline used with PackedNodeOrdering that should place jobs as nodeOrdering does without node scoring.
+		scorers []configuration.NodeScorerConfig
+	}{
+		"default pipeline": {
+			nodeOrdering: configuration.PackedNodeOrdering,
+		},
+		"mostAllocated": {
+			nodeOrdering: configuration.PackedNodeOrdering,
+			scorers:      []configuration.NodeScorerConfig{{Name: MostAllocatedNodeScorerName, Weight: 1}},
+		},
+		"leastAllocated": {
+			nodeOrdering: configuration.BalancedNodeOrdering,
+			scorers:      []configuration.NodeScorerConfig{{Name: LeastAllocatedNodeScorerName, Weight: 2}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodes := nodeScoringTestNodes()
+			jobs := nodeScoringTestJobs()
+			expected, expectedJctxs := scheduleOneByOne(t, nodes, jobs, func(nodeDb *NodeDb) {
+				nodeDb.EnableNodeOrdering(tc.nodeOrdering, 0)
+			})
+			pipeline, err := NewNodeScoringPipeline(tc.scorers)
+			require.NoError(t, err)
+			actual, actualJctxs := scheduleOneByOne(t, nodes, jobs, func(nodeDb *NodeDb) {
+				nodeDb.EnableNodeOrdering(configuration.PackedNodeOrdering, 0)
+				nodeDb.EnableNodeScoring(pipeline)
+			})
+			assert.Equal(t, expected, actual)
+			// Jobs are spread over several nodes, such that the fixture distinguishes orderings.
+			assert.Greater(t, len(uniqueValues(expected)), 1)
+			if len(tc.scorers) == 0 {
+				// Other than the placement, nothing about the decision changes either.
+				for i := range expectedJctxs {
+					assert.Equal(t, expectedJctxs[i].PodSchedulingContext.String(), actualJctxs[i].PodSchedulingContext.String())
+				}
+			}
+		})
+	}
+}
+
+func TestNodeScoring_WeightsChangePlacements(t *testing.T) {
+	nodes := testfixtures.N32CpuNodes(4, testfixtures.TestPriorities)
+	jobs := testfixtures.WithRequestsJobs(
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+			"cpu":    resource.MustParse("1"),
+			"memory": resource.MustParse("1Gi"),
+		}},
+		testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 40),
+	)
+	schedule := func(leastAllocatedWeight, mostAllocatedWeight float64) (map[string]string, []*schedulercontext.JobSchedulingContext) {
+		pipeline, err := NewNodeScoringPipeline([]configuration.NodeScorerConfig{
+			{Name: LeastAllocatedNodeScorerName, Weight: leastAllocatedWeight},
+			{Name: MostAllocatedNodeScorerName, Weight: mostAllocatedWeight},
+		})
+		require.NoError(t, err)
+		return scheduleOneByOne(t, nodes, jobs, func(nodeDb *NodeDb) { nodeDb.EnableNodeScoring(pipeline) })
+	}
+
+	// Bin-packing dominates; jobs fill one node before the next.
+	packed, jctxs := schedule(1, 3)
+	assert.Len(t, uniqueValues(packed), 2)
+	// Spreading dominates; jobs are evenly spread.
+	spread, _ := schedule(3, 1)
+	assert.Len(t, uniqueValues(spread), 4)
+	for _, n := range countValues(spread) {
+		assert.Equal(t, 10, n)
+	}
+
+	// The contribution of each scorer to the score of the selected node is recorded.
+	// The second job is placed on the node of the first, of which 1 of 32 cpu and 1 of 256Gi memory are allocated.
+	// The node has none of the other indexed resources, which count as fully allocated.
+	nodeScores := jctxs[1].PodSchedulingContext.NodeScores
+	require.Len(t, nodeScores, 2)
+	assert.InDelta(t, (31.0/32+255.0/256)/float64(len(testfixtures.TestResources)), nodeScores[LeastAllocatedNodeScorerName], 1e-9)
+	assert.InDelta(t, 3*(1.0/32), nodeScores[MostAllocatedNodeScorerName], 1e-9)
+	assert.Contains(t, jctxs[1].PodSchedulingContext.String(), "Node scores:")
+}
+
+type nodeIdScorer string
+
+func (s nodeIdScorer) Score(_ *NodeDb, _ *schedulercontext.JobSchedulingContext, node *Node, _ int32) float64 {
+	if node.Id == string(s) {
+		return 1
+	}
+	return 0
+}
+
+func TestNodeScoring_RegisterNodeScorer(t *testing.T) {
+	nodes := testfixtures.N32CpuNodes(3, testfixtures.TestPriorities)
+	_, err := NewNodeScoringPipeline([]configuration.NodeScorerConfig{{Name: "preferLastNode", Weight: 1}})
+	assert.Error(t, err)
+
+	require.NoError(t, RegisterNodeScorer("preferLastNode", nodeIdScorer(nodes[2].Id)))
+	t.Cleanup(func() {
+		nodeScorersMu.Lock()
+		defer nodeScorersMu.Unlock()
+		delete(nodeScorersByName, "preferLastNode")
+	})
+	assert.Error(t, RegisterNodeScorer("preferLastNode", nodeIdScorer(nodes[1].Id)))
+	assert.Error(t, RegisterNodeScorer(MostAllocatedNodeScorerName, nodeIdScorer(nodes[1].Id)))
+	pipeline, err := NewNodeScoringPipeline([]configuration.NodeScorerConfig{
+		{Name: MostAllocatedNodeScorerName, Weight: 1},
+		{Name: "preferLastNode", Weight: 10},
+	})
+	require.NoError(t, err)
+	nodeIdByJobId, _ := scheduleOneByOne(t, nodes, testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 5), func(nodeDb *NodeDb) {
+		nodeDb.EnableNodeScoring(pipeline)
+	})
+	for _, nodeId := range nodeIdByJobId {
+		assert.Equal(t, nodes[2].Id, nodeId)
+	}
+}
+
+func uniqueValues(m map[string]string) map[string]bool {
+	rv := make(map[string]bool)
+	for _, v := range m {
+		rv[v] = true
+	}
+	return rv
+}
+
+func countValues(m map[string]string) map[string]int {
+	rv := make(map[string]int)
+	for _, v := range m {
+		rv[v]++
+	}
+	return rv
+}
@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// JobNudger implements the NudgeJob admin endpoint, which forces the scheduler to reconsider a queued job immediately.
+// Nudging a job clears any backoff imposed on it by the scheduler and moves it to the front of its priority band.
+// The latter only lasts for one scheduling round; see ClearNudges.
+type JobNudger struct {
+	jobDb *jobdb.JobDb
+	// Nudges are rate-limited per queue to prevent users from starving other jobs in their queue.
+	maximumPerQueueRate  float64
+	maximumPerQueueBurst int
+	limiterByQueue       map[string]*rate.Limiter
+	// Ids of jobs nudged since the last scheduling round.
+	nudgedJobIds map[string]bool
+	clock        clock.Clock
+	mu           sync.Mutex
+}
+
+func NewJobNudger(jobDb *jobdb.JobDb, config schedulerconfig.JobNudgesConfig) *JobNudger {
+	return &JobNudger{
+		jobDb:                jobDb,
+		maximumPerQueueRate:  config.MaximumPerQueueRate,
+		maximumPerQueueBurst: config.MaximumPerQueueBurst,
+		limiterByQueue:       make(map[string]*rate.Limiter),
+		nudgedJobIds:         make(map[string]bool),
+		clock:                clock.RealClock{},
+	}
+}
+
+// NudgeJob is a gRPC endpoint for nudging a job.
+// It returns the state of the job in the jobDb after the nudge has been applied.
+func (n *JobNudger) NudgeJob(_ context.Context, request *schedulerobjects.NudgeJobRequest) (*schedulerobjects.NudgeJobResponse, error) {
+	jobId := strings.TrimSpace(request.GetJobId())
+	if _, err := ulid.Parse(jobId); err != nil {
+		return nil, &armadaerrors.ErrInvalidArgument{
+			Name:    "jobId",
+			Value:   request.GetJobId(),
+			Message: fmt.Sprintf("%s is not a valid jobId", request.GetJobId()),
+		}
+	}
+
+	txn := n.jobDb.WriteTxn()
+	defer txn.Abort()
+	job := txn.GetById(jobId)
+	if job == nil {
+		return nil, &armadaerrors.ErrNotFound{
+			Type:    "job",
+			Value:   jobId,
+			Message: "job is not active in the scheduler",
+		}
+	}
+	if !job.Queued() {
+		// Only queued jobs can be nudged; return the current state without consuming rate limit.
+		return nudgeJobResponseFromJob(job), nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.limiterForQueue(job.Queue()).AllowN(n.clock.Now(), 1) {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many jobs nudged in queue %s; try again later", job.Queue())
+	}
+	job = job.WithBackedOffUntil(time.Time{}).WithNudged(true)
+	if err := txn.Upsert([]*jobdb.Job{job}); err != nil {
+		return nil, err
+	}
+	txn.Commit()
+	n.nudgedJobIds[jobId] = true
+	return nudgeJobResponseFromJob(job), nil
+}
+
+func (n *JobNudger) limiterForQueue(queue string) *rate.Limiter {
+	limiter, ok := n.limiterByQueue[queue]
+	if !ok {
+		// Create per-queue limiters lazily.
+		limiter = rate.NewLimiter(rate.Limit(n.maximumPerQueueRate), n.maximumPerQueueBurst)
+		n.limiterByQueue[queue] = limiter
+	}
+	return limiter
+}
+
+// ClearNudges un-nudges all jobs nudged since the previous call, such that nudges only affect one scheduling round.
+// Should be called by the scheduler after each scheduling round, within the same transaction.
+func (n *JobNudger) ClearNudges(txn *jobdb.Txn) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	jobs := make([]*jobdb.Job, 0, len(n.nudgedJobIds))
+	for jobId := range n.nudgedJobIds {
+		if job := txn.GetById(jobId); job != nil && job.Nudged() {
+			jobs = append(jobs, job.WithNudged(false))
+		}
+	}
+	if err := txn.Upsert(jobs); err != nil {
+		return errors.WithStack(err)
+	}
+	n.nudgedJobIds = make(map[string]bool)
+	return nil
+}
+
+func nudgeJobResponseFromJob(job *jobdb.Job) *schedulerobjects.NudgeJobResponse {
+	return &schedulerobjects.NudgeJobResponse{
+		JobId:    job.Id(),
+		Queue:    job.Queue(),
+		JobSet:   job.Jobset(),
+		State:    jobDbState(job),
+		Priority: job.Priority(),
+		Nudged:   job.Nudged(),
+	}
+}
+
+// jobDbState returns a human-readable summary of the state of a job in the jobDb.
+func jobDbState(job *jobdb.Job) string {
+	switch {
+	case job.Succeeded():
+		return "Succeeded"
+	case job.Failed():
+		return "Failed"
+	case job.Cancelled():
+		return "Cancelled"
+	case job.Queued():
+		return "Queued"
+	case job.HasRuns() && job.LatestRun().Running():
+		return "Running"
+	case job.HasRuns():
+		return "Leased"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestJobNudger_NudgedJobBecomesEligibleImmediately(t *testing.T) {
+	now := time.Now()
+	jobs := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 3))
+	backedOffJob := jobs[2].WithBackedOffUntil(now.Add(time.Hour))
+	jobs[2] = backedOffJob
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(jobs))
+	txn.Commit()
+
+	// Backed-off jobs aren't considered for scheduling.
+	assert.Equal(t, []string{jobs[0].Id(), jobs[1].Id()}, queuedJobIdsAt(t, jobDb, now))
+
+	nudger := NewJobNudger(jobDb, testJobNudgesConfig())
+	nudger.clock = clock.NewFakeClock(now)
+	response, err := nudger.NudgeJob(armadacontext.Background(), &schedulerobjects.NudgeJobRequest{JobId: backedOffJob.Id()})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		&schedulerobjects.NudgeJobResponse{
+			JobId:    backedOffJob.Id(),
+			Queue:    "A",
+			JobSet:   backedOffJob.Jobset(),
+			State:    "Queued",
+			Priority: backedOffJob.Priority(),
+			Nudged:   true,
+		},
+		response,
+	)
+
+	// Once nudged, the job is eligible and comes first in its priority band.
+	assert.Equal(t, []string{backedOffJob.Id(), jobs[0].Id(), jobs[1].Id()}, queuedJobIdsAt(t, jobDb, now))
+	_, backedOff := jobDb.ReadTxn().GetById(backedOffJob.Id()).BackedOffUntil()
+	assert.False(t, backedOff)
+
+	// After the next scheduling round, the job returns to its usual position.
+	txn = jobDb.WriteTxn()
+	require.NoError(t, nudger.ClearNudges(txn))
+	txn.Commit()
+	assert.Equal(t, []string{jobs[0].Id(), jobs[1].Id(), backedOffJob.Id()}, queuedJobIdsAt(t, jobDb, now))
+}
+
+func TestJobNudger_RateLimitedPerQueue(t *testing.T) {
+	config := testJobNudgesConfig()
+	jobsA := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, config.MaximumPerQueueBurst+1))
+	jobsB := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("B", testfixtures.PriorityClass0, 1))
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(append(jobsA, jobsB...)))
+	txn.Commit()
+	fakeClock := clock.NewFakeClock(time.Now())
+	nudger := NewJobNudger(jobDb, config)
+	nudger.clock = fakeClock
+
+	nudge := func(job *jobdb.Job) error {
+		_, err := nudger.NudgeJob(armadacontext.Background(), &schedulerobjects.NudgeJobRequest{JobId: job.Id()})
+		return err
+	}
+	for _, job := range jobsA[:config.MaximumPerQueueBurst] {
+		require.NoError(t, nudge(job))
+	}
+	err := nudge(jobsA[config.MaximumPerQueueBurst])
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.False(t, jobDb.ReadTxn().GetById(jobsA[config.MaximumPerQueueBurst].Id()).Nudged())
+
+	// Other queues are unaffected.
+	assert.NoError(t, nudge(jobsB[0]))
+
+	// Nudges are allowed again once the limiter has refilled.
+	fakeClock.Step(time.Duration(float64(time.Second) / config.MaximumPerQueueRate))
+	assert.NoError(t, nudge(jobsA[config.MaximumPerQueueBurst]))
+}
+
+func TestJobNudger_UnknownJob(t *testing.T) {
+	nudger := NewJobNudger(testfixtures.NewJobDb(), testJobNudgesConfig())
+	_, err := nudger.NudgeJob(armadacontext.Background(), &schedulerobjects.NudgeJobRequest{JobId: util.NewULID()})
+	assert.Error(t, err)
+	_, err = nudger.NudgeJob(armadacontext.Background(), &schedulerobjects.NudgeJobRequest{JobId: "not a job id"})
+	assert.Error(t, err)
+}
+
+func queuedJobIdsAt(t *testing.T, jobDb *jobdb.JobDb, now time.Time) []string {
+	repo := NewSchedulerJobRepositoryAdapter(jobDb.ReadTxn())
+	repo.ExcludeBackedOffJobs(now)
+	jobIds, err := repo.GetQueueJobIds("A")
+	require.NoError(t, err)
+	return jobIds
+}
+
+func testJobNudgesConfig() schedulerconfig.JobNudgesConfig {
+	return schedulerconfig.JobNudgesConfig{
+		MaximumPerQueueRate:  0.1,
+		MaximumPerQueueBurst: 2,
+	}
+}
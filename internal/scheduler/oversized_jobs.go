@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// LargestNodeResourcesProvider provides the largest amount of each resource allocatable on any single node across all pools.
+// It's implemented by SubmitChecker.
+type LargestNodeResourcesProvider interface {
+	// LargestNodeResources returns the largest amount of each resource allocatable on any single node,
+	// or an empty list if the nodes aren't known.
+	LargestNodeResources() schedulerobjects.ResourceList
+}
+
+// EnableOversizedJobFailure causes queued jobs requesting more of some resource than the largest node, as provided by
+// provider, has allocatable to be failed instead of remaining queued until they expire. Jobs are only failed once the
+// largest node resources have remained unchanged for stabilisationPeriod.
+func (s *Scheduler) EnableOversizedJobFailure(provider LargestNodeResourcesProvider, stabilisationPeriod time.Duration) {
+	s.largestNodeResourcesProvider = provider
+	s.largestNodeStabilisationPeriod = stabilisationPeriod
+}
+
+// failOversizedJobs fails queued jobs that request more of some resource than the largest node has allocatable
+// and returns the events to publish. Only updatedJobs are checked, unless the largest node resources changed
+// since the last check, in which case all queued jobs are. No jobs are failed while the nodes aren't known or the
+// largest node resources haven't been stable for the stabilisation period, and jobs are never failed for requesting
+// resources no known node provides, since the nodes providing them may belong to executors not currently known.
+func (s *Scheduler) failOversizedJobs(ctx *armadacontext.Context, txn *jobdb.Txn, updatedJobs []*jobdb.Job) ([]*armadaevents.EventSequence, error) {
+	if s.largestNodeResourcesProvider == nil {
+		return nil, nil
+	}
+	largestNodeResources := s.largestNodeResourcesProvider.LargestNodeResources()
+	if len(largestNodeResources.Resources) == 0 {
+		return nil, nil
+	}
+	now := s.clock.Now()
+	if !largestNodeResources.Equal(s.observedLargestNodeResources) {
+		s.observedLargestNodeResources = largestNodeResources
+		s.largestNodeResourcesObservedSince = now
+	}
+	if now.Sub(s.largestNodeResourcesObservedSince) < s.largestNodeStabilisationPeriod {
+		// Ensure all queued jobs are checked once the view has stabilised.
+		s.lastCheckedLargestNodeResources = schedulerobjects.ResourceList{}
+		return nil, nil
+	}
+	jobsToCheck := updatedJobs
+	if !largestNodeResources.Equal(s.lastCheckedLargestNodeResources) {
+		jobsToCheck = nil
+		for _, queue := range txn.QueuesWithQueuedJobs() {
+			jobsToCheck = append(jobsToCheck, queuedJobs(txn, queue)...)
+		}
+		s.lastCheckedLargestNodeResources = largestNodeResources
+	}
+
+	var jobsToFail []*jobdb.Job
+	var events []*armadaevents.EventSequence
+	for _, job := range jobsToCheck {
+		// updatedJobs may have been modified earlier in the cycle.
+		job = txn.GetById(job.Id())
+		if job == nil || !job.Queued() || job.InTerminalState() {
+			continue
+		}
+		oversized, ok := nodedb.FindOversizedKnownResource(job.GetResourceRequirements().Requests, largestNodeResources)
+		if !ok {
+			continue
+		}
+		ctx.Warnf("failing job %s: %s", job.Id(), oversized.Reason())
+		jobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
+		if err != nil {
+			return nil, err
+		}
+		jobsToFail = append(jobsToFail, job.WithQueued(false).WithFailed(true))
+		events = append(events, &armadaevents.EventSequence{
+			Queue:      job.Queue(),
+			JobSetName: job.Jobset(),
+			Events: []*armadaevents.EventSequence_Event{
+				{
+					Created: s.now(),
+					Event: &armadaevents.EventSequence_Event_JobErrors{
+						JobErrors: &armadaevents.JobErrors{
+							JobId: jobId,
+							Errors: []*armadaevents.Error{
+								{
+									Terminal: true,
+									Reason: &armadaevents.Error_JobExceedsLargestNode{
+										JobExceedsLargestNode: &armadaevents.JobExceedsLargestNode{
+											Resource:  oversized.Resource,
+											Requested: oversized.Requested.String(),
+											Largest:   oversized.Largest.String(),
+											Message:   oversized.Reason(),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if err := txn.Upsert(jobsToFail); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+type testLargestNodeResourcesProvider struct {
+	resources schedulerobjects.ResourceList
+}
+
+func (p *testLargestNodeResourcesProvider) LargestNodeResources() schedulerobjects.ResourceList {
+	return p.resources
+}
+
+func TestScheduler_FailOversizedJobs(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		nil,
+		nil,
+		nil,
+		NewStandaloneLeaderController(),
+		nil,
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	testClock := clock.NewFakeClock(time.Now())
+	sched.clock = testClock
+	provider := &testLargestNodeResourcesProvider{}
+	sched.EnableOversizedJobFailure(provider, time.Minute)
+
+	newJobWithRequests := func(resources map[string]string) *jobdb.Job {
+		requests := schedulerobjects.ResourceList{Resources: make(map[string]resource.Quantity)}
+		for name, quantity := range resources {
+			requests.Resources[name] = resource.MustParse(quantity)
+		}
+		return testfixtures.WithRequestsJobs(
+			requests,
+			testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1),
+		)[0].WithQueued(true)
+	}
+	newJob := func(memory string) *jobdb.Job {
+		return newJobWithRequests(map[string]string{"memory": memory})
+	}
+	// Requests less cpu, but more memory, than the largest node has.
+	oversizedJob := newJob("512Gi")
+	fittingJob := newJob("4Gi")
+	// Requests a resource no known node provides, which may be provided by nodes of executors not currently known.
+	gpuJob := newJobWithRequests(map[string]string{"nvidia.com/gpu": "8"})
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{oversizedJob, fittingJob, gpuJob}))
+	txn.Commit()
+
+	failOversizedJobs := func(updatedJobs []*jobdb.Job) map[string]*armadaevents.JobExceedsLargestNode {
+		txn := sched.jobDb.WriteTxn()
+		eventSequences, err := sched.failOversizedJobs(ctx, txn, updatedJobs)
+		require.NoError(t, err)
+		txn.Commit()
+		errorsByJobId := make(map[string]*armadaevents.JobExceedsLargestNode)
+		for _, eventSequence := range eventSequences {
+			for _, event := range eventSequence.Events {
+				jobErrors := event.GetJobErrors()
+				require.NotNil(t, jobErrors)
+				require.Len(t, jobErrors.Errors, 1)
+				assert.True(t, jobErrors.Errors[0].Terminal)
+				jobId, err := armadaevents.UlidStringFromProtoUuid(jobErrors.JobId)
+				require.NoError(t, err)
+				errorsByJobId[strings.ToUpper(jobId)] = jobErrors.Errors[0].GetJobExceedsLargestNode()
+			}
+		}
+		return errorsByJobId
+	}
+
+	// No jobs are failed while the nodes aren't known.
+	assert.Empty(t, failOversizedJobs([]*jobdb.Job{oversizedJob, fittingJob}))
+
+	// No jobs are failed until the largest node resources have been stable for the stabilisation period.
+	provider.resources = schedulerobjects.ResourceList{
+		Resources: map[string]resource.Quantity{"cpu": resource.MustParse("32"), "memory": resource.MustParse("1Ti")},
+	}
+	assert.Empty(t, failOversizedJobs([]*jobdb.Job{oversizedJob, fittingJob}))
+	testClock.Step(30 * time.Second)
+	provider.resources = schedulerobjects.ResourceList{
+		Resources: map[string]resource.Quantity{"cpu": resource.MustParse("32"), "memory": resource.MustParse("256Gi")},
+	}
+	assert.Empty(t, failOversizedJobs([]*jobdb.Job{oversizedJob, fittingJob}))
+	testClock.Step(30 * time.Second)
+	assert.Empty(t, failOversizedJobs([]*jobdb.Job{oversizedJob, fittingJob}))
+
+	// Once stable, all queued jobs are checked.
+	testClock.Step(30 * time.Second)
+	assert.Equal(
+		t,
+		map[string]*armadaevents.JobExceedsLargestNode{
+			oversizedJob.Id(): {
+				Resource:  "memory",
+				Requested: "512Gi",
+				Largest:   "256Gi",
+				Message:   "exceeds largest node: job requests 512Gi memory, but the largest node has 256Gi memory allocatable",
+			},
+		},
+		failOversizedJobs(nil),
+	)
+	readTxn := sched.jobDb.ReadTxn()
+	assert.True(t, readTxn.GetById(oversizedJob.Id()).Failed())
+	assert.False(t, readTxn.GetById(oversizedJob.Id()).Queued())
+	assert.True(t, readTxn.GetById(fittingJob.Id()).Queued())
+	assert.True(t, readTxn.GetById(gpuJob.Id()).Queued())
+
+	// Subsequently, only updated jobs are checked.
+	newOversizedJob := newJob("1Ti")
+	txn = sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{newOversizedJob}))
+	txn.Commit()
+	assert.Empty(t, failOversizedJobs(nil))
+	failed := failOversizedJobs([]*jobdb.Job{fittingJob, newOversizedJob})
+	assert.Len(t, failed, 1)
+	assert.Contains(t, failed, newOversizedJob.Id())
+}
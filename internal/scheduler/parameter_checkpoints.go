@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"encoding/json"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// syncParameterCheckpoints restores the checkpointed parameters of jobs new to the jobDb from the job repository,
+// if it supports it, such that jobs admitted before a restart keep the parameters they were admitted with,
+// and checks jobs whose checkpoint wasn't derived just now for drift; see jobdb.JobDb.EnableParameterCheckpoints.
+// The jobs of jsts are updated accordingly. Returns the encoded checkpoints to persist once the jobDb transaction is
+// committed, i.e., those of jobs newly admitted, whose scheduling info changed version, or that were re-checkpointed.
+func (s *Scheduler) syncParameterCheckpoints(ctx *armadacontext.Context, txn *jobdb.Txn, jsts []jobdb.JobStateTransitions) (map[string][]byte, error) {
+	repo, persistent := s.jobRepository.(database.ParameterCheckpointRepository)
+	var newJobIds []string
+	for _, jst := range jsts {
+		if jst.Job == nil || jst.Job.InTerminalState() {
+			continue
+		}
+		if _, ok := jst.Job.EffectiveParameters(); ok && txn.GetById(jst.Job.Id()) == nil {
+			newJobIds = append(newJobIds, jst.Job.Id())
+		}
+	}
+	persistedByJobId := make(map[string][]byte)
+	if persistent && len(newJobIds) > 0 {
+		err := s.retryTransient(ctx, "fetching parameter checkpoints", func() error {
+			var err error
+			persistedByJobId, err = repo.FetchParameterCheckpoints(ctx, newJobIds)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	checkpointsToStore := make(map[string]jobdb.EffectiveParameters)
+	var indicesToCheck []int
+	var jobsToCheck []*jobdb.Job
+	for i, jst := range jsts {
+		if jst.Job == nil || jst.Job.InTerminalState() {
+			continue
+		}
+		checkpoint, ok := jst.Job.EffectiveParameters()
+		if !ok {
+			continue
+		}
+		if existing := txn.GetById(jst.Job.Id()); existing != nil {
+			if existingCheckpoint, _ := existing.EffectiveParameters(); existingCheckpoint != checkpoint {
+				// Re-checkpointed, since the version of its scheduling info changed.
+				checkpointsToStore[jst.Job.Id()] = checkpoint
+				continue
+			}
+		} else {
+			persisted, ok := persistedByJobId[jst.Job.Id()]
+			if !ok {
+				checkpointsToStore[jst.Job.Id()] = checkpoint
+				continue
+			}
+			var persistedCheckpoint jobdb.EffectiveParameters
+			if err := json.Unmarshal(persisted, &persistedCheckpoint); err != nil {
+				ctx.Warnf("discarding undecodable parameter checkpoint of job %s: %s", jst.Job.Id(), err)
+				checkpointsToStore[jst.Job.Id()] = checkpoint
+				continue
+			}
+			jsts[i].Job = jst.Job.WithEffectiveParameters(persistedCheckpoint)
+			if restored, _ := jsts[i].Job.EffectiveParameters(); restored != persistedCheckpoint {
+				// Persisted for an earlier version of its scheduling info.
+				checkpointsToStore[jst.Job.Id()] = checkpoint
+				continue
+			}
+		}
+		indicesToCheck = append(indicesToCheck, i)
+		jobsToCheck = append(jobsToCheck, jsts[i].Job)
+	}
+
+	for j, job := range s.jobDb.CheckParameterDrift(jobsToCheck) {
+		i := indicesToCheck[j]
+		checkpoint, _ := job.EffectiveParameters()
+		if previous, _ := jsts[i].Job.EffectiveParameters(); previous != checkpoint {
+			checkpointsToStore[job.Id()] = checkpoint
+		}
+		jsts[i].Job = job
+	}
+
+	if !persistent || len(checkpointsToStore) == 0 {
+		return nil, nil
+	}
+	encodedByJobId := make(map[string][]byte, len(checkpointsToStore))
+	for jobId, checkpoint := range checkpointsToStore {
+		encoded, err := json.Marshal(checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		encodedByJobId[jobId] = encoded
+	}
+	return encodedByJobId, nil
+}
+
+// storeParameterCheckpoints persists the encoded checkpoints returned by syncParameterCheckpoints.
+// Failing to store them is logged rather than returned, since the parameters of jobs without a persisted checkpoint
+// are derived anew when they're next loaded.
+func (s *Scheduler) storeParameterCheckpoints(ctx *armadacontext.Context, encodedByJobId map[string][]byte) {
+	if len(encodedByJobId) == 0 {
+		return
+	}
+	repo, ok := s.jobRepository.(database.ParameterCheckpointRepository)
+	if !ok {
+		return
+	}
+	if err := repo.StoreParameterCheckpoints(ctx, encodedByJobId); err != nil {
+		logging.
+			WithStacktrace(ctx, err).
+			Warnf("error storing the parameter checkpoints of %d jobs", len(encodedByJobId))
+	}
+}
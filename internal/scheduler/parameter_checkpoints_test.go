@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+// parameterCheckpointTestRepository is a testJobRepository that stores parameter checkpoints.
+type parameterCheckpointTestRepository struct {
+	*testJobRepository
+	checkpointByJobId map[string][]byte
+}
+
+func (r *parameterCheckpointTestRepository) FetchParameterCheckpoints(_ *armadacontext.Context, jobIds []string) (map[string][]byte, error) {
+	rv := make(map[string][]byte)
+	for _, jobId := range jobIds {
+		if checkpoint, ok := r.checkpointByJobId[jobId]; ok {
+			rv[jobId] = checkpoint
+		}
+	}
+	return rv, nil
+}
+
+func (r *parameterCheckpointTestRepository) StoreParameterCheckpoints(_ *armadacontext.Context, checkpointByJobId map[string][]byte) error {
+	for jobId, checkpoint := range checkpointByJobId {
+		r.checkpointByJobId[jobId] = checkpoint
+	}
+	return nil
+}
+
+type parameterDriftCounter struct {
+	numJobsByParameter map[string]int
+}
+
+func (c *parameterDriftCounter) ObserveParameterDrift(numJobsByParameter map[string]int) {
+	for parameter, numJobs := range numJobsByParameter {
+		c.numJobsByParameter[parameter] += numJobs
+	}
+}
+
+func TestScheduler_ParameterCheckpoints(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	jobId := util.NewULID()
+	repo := &parameterCheckpointTestRepository{
+		testJobRepository: &testJobRepository{
+			updatedJobs: []database.Job{
+				{
+					JobID:                 jobId,
+					JobSet:                "testJobSet",
+					Queue:                 "testQueue",
+					Queued:                true,
+					SchedulingInfo:        schedulingInfoBytes,
+					SchedulingInfoVersion: int32(schedulingInfo.Version),
+					Serial:                1,
+				},
+			},
+		},
+		checkpointByJobId: make(map[string][]byte),
+	}
+	newScheduler := func(maxAttemptedRuns uint, observer jobdb.ParameterDriftObserver) *Scheduler {
+		jobDb := testfixtures.NewJobDb()
+		jobDb.EnableParameterCheckpoints(GangIdAndCardinalityFromAnnotations, maxAttemptedRuns, false, observer)
+		sched, err := NewScheduler(
+			jobDb,
+			repo,
+			&testExecutorRepository{},
+			&testSchedulingAlgo{},
+			NewStandaloneLeaderController(),
+			&testPublisher{},
+			nil,
+			1*time.Second,
+			5*time.Second,
+			1*time.Hour,
+			maxNumberOfAttempts,
+			nodeIdLabel,
+			schedulerMetrics,
+			nil,
+		)
+		require.NoError(t, err)
+		return sched
+	}
+
+	// The checkpoint of a newly admitted job is persisted.
+	sched := newScheduler(3, nil)
+	_, _, _, err := sched.syncState(ctx)
+	require.NoError(t, err)
+	require.Contains(t, repo.checkpointByJobId, jobId)
+	var persisted jobdb.EffectiveParameters
+	require.NoError(t, json.Unmarshal(repo.checkpointByJobId[jobId], &persisted))
+	assert.Equal(t, uint(3), persisted.MaxAttemptedRuns)
+
+	// A scheduler started later, which derives a different maximum number of attempted runs, restores the checkpoint
+	// rather than re-deriving it, and counts the drift.
+	counter := &parameterDriftCounter{numJobsByParameter: make(map[string]int)}
+	sched = newScheduler(5, counter)
+	_, _, _, err = sched.syncState(ctx)
+	require.NoError(t, err)
+	job := sched.jobDb.ReadTxn().GetById(jobId)
+	require.NotNil(t, job)
+	assert.Equal(t, uint(3), job.MaxAttemptedRuns(5))
+	assert.Equal(t, map[string]int{jobdb.MaxAttemptedRunsParameter: 1}, counter.numJobsByParameter)
+
+	// A checkpoint persisted for an earlier version of the scheduling info of the job is replaced.
+	persisted.SchedulingInfoVersion = schedulingInfo.Version - 1
+	stale, err := json.Marshal(persisted)
+	require.NoError(t, err)
+	repo.checkpointByJobId[jobId] = stale
+	sched = newScheduler(5, nil)
+	_, _, _, err = sched.syncState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), sched.jobDb.ReadTxn().GetById(jobId).MaxAttemptedRuns(5))
+	require.NoError(t, json.Unmarshal(repo.checkpointByJobId[jobId], &persisted))
+	assert.Equal(t, schedulingInfo.Version, persisted.SchedulingInfoVersion)
+	assert.Equal(t, uint(5), persisted.MaxAttemptedRuns)
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+)
+
+// PeriodJitter determines how long to actually wait for periods, e.g., the cycle period, such that scheduler replicas
+// and the components of each replica don't load the database in lockstep.
+type PeriodJitter interface {
+	// Jitter returns the duration to wait instead of period.
+	Jitter(period time.Duration) time.Duration
+	// Phase returns the duration to wait before the first of a series of periods.
+	Phase(period time.Duration) time.Duration
+}
+
+// RandomPeriodJitter jitters periods by a random fraction of their length, drawn from the configured distribution.
+type RandomPeriodJitter struct {
+	config schedulerconfig.CycleJitterConfig
+	random *rand.Rand
+	// Guards random, which isn't safe for concurrent use.
+	mu sync.Mutex
+}
+
+func NewRandomPeriodJitter(config schedulerconfig.CycleJitterConfig, random *rand.Rand) *RandomPeriodJitter {
+	return &RandomPeriodJitter{config: config, random: random}
+}
+
+// Jitter returns period lengthened or shortened by a random fraction of it. Uniform jitter is drawn uniformly from
+// [-MaxFraction, MaxFraction]. Exponential jitter only lengthens periods, by an exponentially distributed fraction
+// with mean a quarter of MaxFraction, capped at MaxFraction, such that most periods are only lengthened slightly.
+func (j *RandomPeriodJitter) Jitter(period time.Duration) time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var fraction float64
+	switch j.config.Distribution {
+	case schedulerconfig.CycleJitterDistributionExponential:
+		fraction = math.Min(j.random.ExpFloat64()*j.config.MaxFraction/4, j.config.MaxFraction)
+	default:
+		fraction = (2*j.random.Float64() - 1) * j.config.MaxFraction
+	}
+	return period + time.Duration(fraction*float64(period))
+}
+
+// Phase returns a duration drawn uniformly from [0, period) if phase smearing is enabled and zero otherwise.
+func (j *RandomPeriodJitter) Phase(period time.Duration) time.Duration {
+	if !j.config.SmearPhase {
+		return 0
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return time.Duration(j.random.Float64() * float64(period))
+}
+
+// jitteredTicker is a clock.Ticker that ticks after jitter.Jitter(period), drawn anew for each tick,
+// with the first tick additionally delayed by jitter.Phase(period).
+// Like time.Ticker, ticks are dropped if the previous tick hasn't been received.
+type jitteredTicker struct {
+	c    chan time.Time
+	stop chan struct{}
+	once sync.Once
+}
+
+// newTicker returns a ticker that ticks every period if jitter is nil and a jitteredTicker otherwise.
+func newTicker(clk clock.Clock, period time.Duration, jitter PeriodJitter) clock.Ticker {
+	if jitter == nil {
+		return clk.NewTicker(period)
+	}
+	t := &jitteredTicker{
+		c:    make(chan time.Time, 1),
+		stop: make(chan struct{}),
+	}
+	timer := clk.NewTimer(jitter.Phase(period) + jitter.Jitter(period))
+	go func() {
+		defer timer.Stop()
+		for {
+			select {
+			case <-t.stop:
+				return
+			case tick := <-timer.C():
+				select {
+				case t.c <- tick:
+				default:
+				}
+				timer.Reset(jitter.Jitter(period))
+			}
+		}
+	}()
+	return t
+}
+
+func (t *jitteredTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *jitteredTicker) Stop() {
+	t.once.Do(func() { close(t.stop) })
+}
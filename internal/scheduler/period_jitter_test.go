@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+)
+
+// fixedPeriodJitter jitters periods by fixed offsets, cycling through them, such that tests are deterministic.
+type fixedPeriodJitter struct {
+	phase   time.Duration
+	offsets []time.Duration
+	i       int
+}
+
+func (j *fixedPeriodJitter) Jitter(period time.Duration) time.Duration {
+	offset := j.offsets[j.i%len(j.offsets)]
+	j.i++
+	return period + offset
+}
+
+func (j *fixedPeriodJitter) Phase(time.Duration) time.Duration {
+	return j.phase
+}
+
+// proportionalPeriodJitter lengthens every period by a fixed fraction of it.
+type proportionalPeriodJitter struct {
+	fraction float64
+}
+
+func (j *proportionalPeriodJitter) Jitter(period time.Duration) time.Duration {
+	return period + time.Duration(j.fraction*float64(period))
+}
+
+func (j *proportionalPeriodJitter) Phase(time.Duration) time.Duration {
+	return 0
+}
+
+func TestRandomPeriodJitter_Bounds(t *testing.T) {
+	period := 10 * time.Second
+	tests := map[string]struct {
+		distribution schedulerconfig.CycleJitterDistribution
+		min          time.Duration
+		max          time.Duration
+	}{
+		"uniform": {
+			distribution: schedulerconfig.CycleJitterDistributionUniform,
+			min:          8 * time.Second,
+			max:          12 * time.Second,
+		},
+		"uniform is the default": {
+			min: 8 * time.Second,
+			max: 12 * time.Second,
+		},
+		"exponential": {
+			distribution: schedulerconfig.CycleJitterDistributionExponential,
+			min:          period,
+			max:          12 * time.Second,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jitter := NewRandomPeriodJitter(
+				schedulerconfig.CycleJitterConfig{Enabled: true, Distribution: tc.distribution, MaxFraction: 0.2, SmearPhase: true},
+				rand.New(rand.NewSource(1)),
+			)
+			var shortened, lengthened bool
+			for i := 0; i < 10000; i++ {
+				jittered := jitter.Jitter(period)
+				require.GreaterOrEqual(t, jittered, tc.min)
+				require.LessOrEqual(t, jittered, tc.max)
+				shortened = shortened || jittered < period
+				lengthened = lengthened || jittered > period
+				phase := jitter.Phase(period)
+				require.GreaterOrEqual(t, phase, time.Duration(0))
+				require.Less(t, phase, period)
+			}
+			assert.Equal(t, tc.min < period, shortened)
+			assert.True(t, lengthened)
+		})
+	}
+}
+
+func TestRandomPeriodJitter_Deterministic(t *testing.T) {
+	config := schedulerconfig.CycleJitterConfig{Enabled: true, MaxFraction: 0.5, SmearPhase: true}
+	jitter := NewRandomPeriodJitter(config, rand.New(rand.NewSource(42)))
+	other := NewRandomPeriodJitter(config, rand.New(rand.NewSource(42)))
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, jitter.Phase(time.Second), other.Phase(time.Second))
+		assert.Equal(t, jitter.Jitter(time.Second), other.Jitter(time.Second))
+	}
+
+	// Without phase smearing, the first of a series of periods isn't delayed.
+	config.SmearPhase = false
+	assert.Equal(t, time.Duration(0), NewRandomPeriodJitter(config, rand.New(rand.NewSource(42))).Phase(time.Second))
+}
+
+// awaitTick steps testClock by d once the ticker is waiting on it and returns the tick, if any, delivered as a result.
+func awaitTick(t *testing.T, testClock *clock.FakeClock, ticker clock.Ticker, d time.Duration) (time.Time, bool) {
+	require.Eventually(t, testClock.HasWaiters, time.Second, time.Millisecond)
+	testClock.Step(d)
+	select {
+	case tick := <-ticker.C():
+		return tick, true
+	case <-time.After(100 * time.Millisecond):
+		return time.Time{}, false
+	}
+}
+
+func TestNewTicker_Jittered(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testClock := clock.NewFakeClock(start)
+	jitter := &fixedPeriodJitter{phase: 3 * time.Second, offsets: []time.Duration{time.Second, -2 * time.Second}}
+	ticker := newTicker(testClock, 10*time.Second, jitter)
+	defer ticker.Stop()
+
+	// The first tick is delayed by the phase in addition to the jittered period.
+	_, ok := awaitTick(t, testClock, ticker, 13*time.Second)
+	assert.False(t, ok)
+	tick, ok := awaitTick(t, testClock, ticker, time.Second)
+	require.True(t, ok)
+	assert.Equal(t, start.Add(14*time.Second), tick)
+
+	// Subsequent ticks are each a jittered period after the previous.
+	tick, ok = awaitTick(t, testClock, ticker, 8*time.Second)
+	require.True(t, ok)
+	assert.Equal(t, start.Add(22*time.Second), tick)
+	_, ok = awaitTick(t, testClock, ticker, 10*time.Second)
+	assert.False(t, ok)
+	tick, ok = awaitTick(t, testClock, ticker, time.Second)
+	require.True(t, ok)
+	assert.Equal(t, start.Add(33*time.Second), tick)
+}
+
+func TestNewTicker_WithoutJitter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testClock := clock.NewFakeClock(start)
+	ticker := newTicker(testClock, 10*time.Second, nil)
+	defer ticker.Stop()
+
+	// Without jitter, ticks are delivered exactly every period.
+	for i := 1; i <= 3; i++ {
+		_, ok := awaitTick(t, testClock, ticker, 9*time.Second)
+		assert.False(t, ok)
+		tick, ok := awaitTick(t, testClock, ticker, time.Second)
+		require.True(t, ok)
+		assert.Equal(t, start.Add(time.Duration(i)*10*time.Second), tick)
+	}
+}
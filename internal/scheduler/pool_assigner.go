@@ -33,19 +33,24 @@ type executor struct {
 }
 
 type DefaultPoolAssigner struct {
-	executorTimeout        time.Duration
-	priorityClasses        map[string]types.PriorityClass
-	priorities             []int32
-	indexedResources       []configuration.IndexedResource
-	indexedTaints          []string
-	indexedNodeLabels      []string
-	wellKnownNodeTypes     []configuration.WellKnownNodeType
-	poolByExecutorId       map[string]string
-	executorsByPool        map[string][]*executor
-	executorRepository     database.ExecutorRepository
-	schedulingKeyGenerator *schedulerobjects.SchedulingKeyGenerator
-	poolCache              *lru.Cache
-	clock                  clock.Clock
+	executorTimeout         time.Duration
+	priorityClasses         map[string]types.PriorityClass
+	priorities              []int32
+	indexedResources        []configuration.IndexedResource
+	indexedTaints           []string
+	indexedNodeLabels       []string
+	wellKnownNodeTypes      []configuration.WellKnownNodeType
+	overcommitFactorsByPool map[string]map[string]float64
+	poolByExecutorId        map[string]string
+	executorsByPool         map[string][]*executor
+	executorRepository      database.ExecutorRepository
+	schedulingKeyGenerator  *schedulerobjects.SchedulingKeyGenerator
+	poolCache               *lru.Cache
+	clock                   clock.Clock
+	// If non-nil, executors are taken from the snapshots of this provider rather than fetched on each refresh.
+	executorSnapshots *ExecutorSnapshotProvider
+	// Version of the snapshot executors were last refreshed from.
+	executorSnapshotVersion uint64
 }
 
 func NewPoolAssigner(executorTimeout time.Duration,
@@ -57,25 +62,32 @@ func NewPoolAssigner(executorTimeout time.Duration,
 		return nil, errors.Wrap(err, "error  creating PoolAssigner pool cache")
 	}
 	return &DefaultPoolAssigner{
-		executorTimeout:        executorTimeout,
-		priorityClasses:        schedulingConfig.Preemption.PriorityClasses,
-		executorsByPool:        map[string][]*executor{},
-		poolByExecutorId:       map[string]string{},
-		priorities:             types.AllowedPriorities(schedulingConfig.Preemption.PriorityClasses),
-		indexedResources:       schedulingConfig.IndexedResources,
-		indexedTaints:          schedulingConfig.IndexedTaints,
-		wellKnownNodeTypes:     schedulingConfig.WellKnownNodeTypes,
-		indexedNodeLabels:      schedulingConfig.IndexedNodeLabels,
-		executorRepository:     executorRepository,
-		schedulingKeyGenerator: schedulerobjects.NewSchedulingKeyGenerator(),
-		poolCache:              poolCache,
-		clock:                  clock.RealClock{},
+		executorTimeout:         executorTimeout,
+		priorityClasses:         schedulingConfig.Preemption.PriorityClasses,
+		executorsByPool:         map[string][]*executor{},
+		poolByExecutorId:        map[string]string{},
+		priorities:              types.AllowedPriorities(schedulingConfig.Preemption.PriorityClasses),
+		indexedResources:        schedulingConfig.IndexedResources,
+		indexedTaints:           schedulingConfig.IndexedTaints,
+		wellKnownNodeTypes:      schedulingConfig.WellKnownNodeTypes,
+		indexedNodeLabels:       schedulingConfig.IndexedNodeLabels,
+		overcommitFactorsByPool: schedulingConfig.OvercommitFactorsByPool,
+		executorRepository:      executorRepository,
+		schedulingKeyGenerator:  schedulerobjects.NewSchedulingKeyGenerator(),
+		poolCache:               poolCache,
+		clock:                   clock.RealClock{},
 	}, nil
 }
 
+// EnableExecutorSnapshots causes executors to be taken from the snapshots of provider on each refresh,
+// i.e., from the snapshot the context passed to Refresh is pinned to, if any, and otherwise from the most recent one.
+func (p *DefaultPoolAssigner) EnableExecutorSnapshots(provider *ExecutorSnapshotProvider) {
+	p.executorSnapshots = provider
+}
+
 // Refresh updates executor state
 func (p *DefaultPoolAssigner) Refresh(ctx *armadacontext.Context) error {
-	executors, err := p.executorRepository.GetExecutors(ctx)
+	executors, snapshotVersion, err := getExecutors(ctx, p.executorSnapshots, p.executorRepository)
 	executorsByPool := map[string][]*executor{}
 	poolByExecutorId := map[string]string{}
 	if err != nil {
@@ -84,7 +96,7 @@ func (p *DefaultPoolAssigner) Refresh(ctx *armadacontext.Context) error {
 	for _, e := range executors {
 		if p.clock.Since(e.LastUpdateTime) < p.executorTimeout {
 			poolByExecutorId[e.Id] = e.Pool
-			nodeDb, err := p.constructNodeDb(e.Nodes)
+			nodeDb, err := p.constructNodeDb(e.Nodes, e.Pool)
 			if err != nil {
 				return errors.WithMessagef(err, "could not construct node db for executor %s", e.Id)
 			}
@@ -96,6 +108,7 @@ func (p *DefaultPoolAssigner) Refresh(ctx *armadacontext.Context) error {
 	}
 	p.executorsByPool = executorsByPool
 	p.poolByExecutorId = poolByExecutorId
+	p.executorSnapshotVersion = snapshotVersion
 	p.schedulingKeyGenerator = schedulerobjects.NewSchedulingKeyGenerator()
 	p.poolCache.Purge()
 	return nil
@@ -131,11 +144,12 @@ func (p *DefaultPoolAssigner) AssignPool(j *jobdb.Job) (string, error) {
 			nodeDb := e.nodeDb
 			txn := nodeDb.Txn(true)
 			jctx := &schedulercontext.JobSchedulingContext{
-				Created:            time.Now(),
-				JobId:              j.GetId(),
-				Job:                j,
-				PodRequirements:    j.GetPodRequirements(p.priorityClasses),
-				GangMinCardinality: 1,
+				Created:               time.Now(),
+				JobId:                 j.GetId(),
+				Job:                   j,
+				PodRequirements:       j.GetPodRequirements(p.priorityClasses),
+				AdditionalTolerations: j.InjectedTolerations(),
+				GangMinCardinality:    1,
 			}
 			node, err := nodeDb.SelectNodeForJobWithTxn(txn, jctx)
 			txn.Abort()
@@ -151,7 +165,7 @@ func (p *DefaultPoolAssigner) AssignPool(j *jobdb.Job) (string, error) {
 	return "", nil
 }
 
-func (p *DefaultPoolAssigner) constructNodeDb(nodes []*schedulerobjects.Node) (*nodedb.NodeDb, error) {
+func (p *DefaultPoolAssigner) constructNodeDb(nodes []*schedulerobjects.Node, pool string) (*nodedb.NodeDb, error) {
 	// Nodes to be considered by the scheduler.
 	nodeDb, err := nodedb.NewNodeDb(
 		p.priorityClasses,
@@ -167,7 +181,7 @@ func (p *DefaultPoolAssigner) constructNodeDb(nodes []*schedulerobjects.Node) (*
 	txn := nodeDb.Txn(true)
 	defer txn.Abort()
 	for _, node := range nodes {
-		if err := nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, nil, node); err != nil {
+		if err := nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, nil, node.WithOvercommit(p.overcommitFactorsByPool[pool])); err != nil {
 			return nil, err
 		}
 	}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	armadamaps "github.com/armadaproject/armada/internal/common/maps"
@@ -23,6 +24,7 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
 	"github.com/armadaproject/armada/internal/scheduler/nodedb"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	clientQueue "github.com/armadaproject/armada/pkg/client/queue"
 )
 
 // PreemptingQueueScheduler is a scheduler that makes a unified decisions on which jobs to preempt and schedule.
@@ -49,6 +51,16 @@ type PreemptingQueueScheduler struct {
 	enableAssertions bool
 	// If true, a newer preemption strategy is used.
 	enableNewPreemptionStrategy bool
+	// If true, queued jobs that provably can't fit on any node are skipped without attempting to schedule them.
+	enableCapacityPruning bool
+	// If true, evicted jobs are rescheduled in order of reported resource usage where available,
+	// such that jobs that have done the least work are preempted first.
+	enableUsageAwarePreemption bool
+	// If true, evicted gangs are rescheduled before evicted non-gang jobs of equal priority,
+	// such that preemption favours non-gang victims, and gangs are displaced as a whole when preempting evicted jobs.
+	enableGangAwarePreemption bool
+	// Used to measure how long evaluating the candidate jobs of each queue takes.
+	clock clock.Clock
 }
 
 func NewPreemptingQueueScheduler(
@@ -87,6 +99,7 @@ func NewPreemptingQueueScheduler(
 		nodeIdByJobId:                           maps.Clone(initialNodeIdByJobId),
 		jobIdsByGangId:                          initialJobIdsByGangId,
 		gangIdByJobId:                           maps.Clone(initialGangIdByJobId),
+		clock:                                   clock.RealClock{},
 	}
 }
 
@@ -103,6 +116,24 @@ func (sch *PreemptingQueueScheduler) EnableNewPreemptionStrategy() {
 	sch.nodeDb.EnableNewPreemptionStrategy()
 }
 
+func (sch *PreemptingQueueScheduler) EnableCapacityPruning() {
+	sch.enableCapacityPruning = true
+}
+
+func (sch *PreemptingQueueScheduler) EnableUsageAwarePreemption() {
+	sch.enableUsageAwarePreemption = true
+}
+
+func (sch *PreemptingQueueScheduler) EnableGangAwarePreemption() {
+	sch.enableGangAwarePreemption = true
+	sch.nodeDb.EnableGangAwarePreemption()
+}
+
+// SetClock sets the clock used to measure how long evaluating the candidate jobs of each queue takes.
+func (sch *PreemptingQueueScheduler) SetClock(clock clock.Clock) {
+	sch.clock = clock
+}
+
 // Schedule
 // - preempts jobs belonging to queues with total allocation above their fair share and
 // - schedules new jobs belonging to queues with total allocation less than their fair share.
@@ -119,7 +150,12 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx *armadacontext.Context) (*Sche
 	snapshot := sch.nodeDb.Txn(false)
 
 	// Evict preemptible jobs.
+	// Jobs of queues below their protected fraction of fair share are protected from eviction,
+	// unless a queue is using less than its reservation, since such jobs may be occupying the reserved resources.
+	// Jobs of queues with preemption policy never are never evicted,
+	// whereas jobs of queues with preemption policy aggressive aren't protected by their fair share.
 	totalCost := sch.schedulingContext.TotalCost()
+	protectFairShare := !sch.anyReservationUnused()
 	evictorResult, inMemoryJobRepo, err := sch.evict(
 		armadacontext.WithLogField(ctx, "stage", "evict for resource balancing"),
 		NewNodeEvictor(
@@ -136,7 +172,11 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx *armadacontext.Context) (*Sche
 					ctx.Errorf("can't evict job %s: nodeSelector not initialised", job.GetId())
 					return false
 				}
-				if qctx, ok := sch.schedulingContext.QueueSchedulingContexts[job.GetQueue()]; ok {
+				preemptionPolicy := sch.preemptionPolicy(job)
+				if preemptionPolicy == clientQueue.PreemptionPolicyNever {
+					return false
+				}
+				if qctx, ok := sch.schedulingContext.QueueSchedulingContexts[job.GetQueue()]; ok && protectFairShare && preemptionPolicy != clientQueue.PreemptionPolicyAggressive {
 					fairShare := qctx.Weight / sch.schedulingContext.WeightSum
 					actualShare := sch.schedulingContext.FairnessCostProvider.CostFromQueue(qctx) / totalCost
 					fractionOfFairShare := actualShare / fairShare
@@ -174,19 +214,27 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx *armadacontext.Context) (*Sche
 			scheduledJobsById[jctx.JobId] = jctx
 		}
 	}
-	maps.Copy(sch.nodeIdByJobId, schedulerResult.NodeIdByJobId)
+	maps.Copy(sch.nodeIdByJobId, schedulerResult.NodeIdByJobId())
 
 	// Evict jobs on oversubscribed nodes.
+	// Jobs of queues with preemption policy never are excluded here too.
+	oversubscribedEvictor := NewOversubscribedEvictor(
+		sch.jobRepo,
+		sch.nodeDb,
+		sch.schedulingContext.PriorityClasses,
+		sch.schedulingContext.DefaultPriorityClass,
+		sch.nodeOversubscriptionEvictionProbability,
+		nil,
+	)
+	if oversubscribedEvictor != nil {
+		jobFilter := oversubscribedEvictor.jobFilter
+		oversubscribedEvictor.jobFilter = func(ctx *armadacontext.Context, job interfaces.LegacySchedulerJob) bool {
+			return jobFilter(ctx, job) && sch.preemptionPolicy(job) != clientQueue.PreemptionPolicyNever
+		}
+	}
 	evictorResult, inMemoryJobRepo, err = sch.evict(
 		armadacontext.WithLogField(ctx, "stage", "evict oversubscribed"),
-		NewOversubscribedEvictor(
-			sch.jobRepo,
-			sch.nodeDb,
-			sch.schedulingContext.PriorityClasses,
-			sch.schedulingContext.DefaultPriorityClass,
-			sch.nodeOversubscriptionEvictionProbability,
-			nil,
-		),
+		oversubscribedEvictor,
 	)
 	if err != nil {
 		return nil, err
@@ -202,6 +250,7 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx *armadacontext.Context) (*Sche
 		if _, ok := scheduledJobsById[jobId]; ok {
 			delete(scheduledJobsById, jobId)
 		} else {
+			jctx.IsUrgencyPreempted = true
 			preemptedJobsById[jobId] = jctx
 		}
 	}
@@ -229,7 +278,7 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx *armadacontext.Context) (*Sche
 			}
 			delete(scheduledAndEvictedJobsById, jctx.JobId)
 		}
-		maps.Copy(sch.nodeIdByJobId, schedulerResult.NodeIdByJobId)
+		maps.Copy(sch.nodeIdByJobId, schedulerResult.NodeIdByJobId())
 	}
 
 	preemptedJobs := maps.Values(preemptedJobsById)
@@ -258,16 +307,49 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx *armadacontext.Context) (*Sche
 			return nil, err
 		}
 	}
+	placementByJobId := make(map[string]Placement, len(preemptedJobs)+len(scheduledJobs))
+	for _, jctxs := range [][]*schedulercontext.JobSchedulingContext{preemptedJobs, scheduledJobs} {
+		if err := addPlacementsFromNodeDb(
+			placementByJobId, sch.nodeDb, sch.schedulingContext.Pool, sch.nodeIdByJobId, jctxs,
+		); err != nil {
+			return nil, err
+		}
+	}
 	return &SchedulerResult{
 		PreemptedJobs:                preemptedJobs,
 		ScheduledJobs:                scheduledJobs,
 		FailedJobs:                   schedulerResult.FailedJobs,
-		NodeIdByJobId:                sch.nodeIdByJobId,
+		PlacementByJobId:             placementByJobId,
 		AdditionalAnnotationsByJobId: schedulerResult.AdditionalAnnotationsByJobId,
 		SchedulingContexts:           []*schedulercontext.SchedulingContext{sch.schedulingContext},
 	}, nil
 }
 
+// preemptionPolicy returns the preemption policy of the queue job belongs to,
+// as of the start of the round, such that policy changes take effect the round after they're made.
+func (sch *PreemptingQueueScheduler) preemptionPolicy(job interfaces.LegacySchedulerJob) clientQueue.PreemptionPolicy {
+	if qctx, ok := sch.schedulingContext.QueueSchedulingContexts[job.GetQueue()]; ok && qctx.PreemptionPolicy != "" {
+		return qctx.PreemptionPolicy
+	}
+	return clientQueue.PreemptionPolicyStandard
+}
+
+// anyReservationUnused returns true if any queue considered in this round is using less than its reservation.
+func (sch *PreemptingQueueScheduler) anyReservationUnused() bool {
+	for queue, reserved := range sch.constraints.ReservedResourcesByQueue {
+		qctx, ok := sch.schedulingContext.QueueSchedulingContexts[queue]
+		if !ok {
+			continue
+		}
+		for t, q := range reserved.Resources {
+			if q.Cmp(qctx.Allocated.Get(t)) == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (sch *PreemptingQueueScheduler) evict(ctx *armadacontext.Context, evictor *Evictor) (*EvictorResult, *InMemoryJobRepository, error) {
 	if evictor == nil {
 		return &EvictorResult{}, NewInMemoryJobRepository(), nil
@@ -312,6 +394,9 @@ func (sch *PreemptingQueueScheduler) evict(ctx *armadacontext.Context, evictor *
 		return nil, nil, err
 	}
 	inMemoryJobRepo := NewInMemoryJobRepository()
+	if sch.enableUsageAwarePreemption || sch.enableGangAwarePreemption {
+		inMemoryJobRepo = NewInMemoryJobRepositoryWithCompare(sch.evictedJobsSchedulingOrderCompare)
+	}
 	inMemoryJobRepo.EnqueueMany(evictedJctxs)
 	txn.Commit()
 
@@ -483,6 +568,21 @@ func (q MinimalQueue) GetWeight() float64 {
 	return q.weight
 }
 
+// evictedJobsSchedulingOrderCompare orders evicted jobs as jobdb.UsageAwareSchedulingOrderCompare
+// or jobdb.GangAwareSchedulingOrderCompare, depending on which of these are enabled, if both are jobDb jobs,
+// and by their SchedulingOrderCompare method otherwise.
+func (sch *PreemptingQueueScheduler) evictedJobsSchedulingOrderCompare(a, b interfaces.LegacySchedulerJob) int {
+	aJob, aOk := a.(*jobdb.Job)
+	bJob, bOk := b.(*jobdb.Job)
+	if !aOk || !bOk {
+		return a.SchedulingOrderCompare(b)
+	}
+	if sch.enableGangAwarePreemption {
+		return jobdb.GangAwareSchedulingOrderCompare(aJob, bJob, sch.enableUsageAwarePreemption)
+	}
+	return jobdb.UsageAwareSchedulingOrderCompare(aJob, bJob)
+}
+
 // addEvictedJobsToNodeDb adds evicted jobs to the NodeDb.
 // Needed to enable the nodeDb accounting for these when preempting.
 func addEvictedJobsToNodeDb(ctx *armadacontext.Context, sctx *schedulercontext.SchedulingContext, nodeDb *nodedb.NodeDb, inMemoryJobRepo *InMemoryJobRepository) error {
@@ -527,16 +627,31 @@ func addEvictedJobsToNodeDb(ctx *armadacontext.Context, sctx *schedulercontext.S
 }
 
 func (sch *PreemptingQueueScheduler) schedule(ctx *armadacontext.Context, inMemoryJobRepo *InMemoryJobRepository, jobRepo JobRepository) (*SchedulerResult, error) {
+	var capacityBound *nodedb.CapacityBound
+	capacityPruningReasonBySchedulingKey := make(map[schedulerobjects.SchedulingKey]string)
+	if sch.enableCapacityPruning && jobRepo != nil && !reflect.ValueOf(jobRepo).IsNil() {
+		// Jobs are only evicted before this point, after which the resources allocatable on each node only decrease.
+		// Hence, the bound computed here holds for all queued jobs considered in this round.
+		var err error
+		capacityBound, err = sch.nodeDb.CapacityBoundWithTxn(sch.nodeDb.Txn(false))
+		if err != nil {
+			return nil, err
+		}
+	}
 	jobIteratorByQueue := make(map[string]JobIterator)
 	for _, qctx := range sch.schedulingContext.QueueSchedulingContexts {
 		evictedIt := inMemoryJobRepo.GetJobIterator(qctx.Queue)
 		if jobRepo == nil || reflect.ValueOf(jobRepo).IsNil() {
 			jobIteratorByQueue[qctx.Queue] = evictedIt
 		} else {
-			queueIt, err := NewQueuedJobsIterator(ctx, qctx.Queue, jobRepo, sch.schedulingContext.PriorityClasses)
+			queuedJobsIt, err := NewQueuedJobsIterator(ctx, qctx.Queue, jobRepo, sch.schedulingContext.PriorityClasses)
 			if err != nil {
 				return nil, err
 			}
+			var queueIt JobIterator = queuedJobsIt
+			if capacityBound != nil {
+				queueIt = NewCapacityPruningJobsIterator(sch.schedulingContext, queueIt, capacityBound, capacityPruningReasonBySchedulingKey)
+			}
 			jobIteratorByQueue[qctx.Queue] = NewMultiJobsIterator(evictedIt, queueIt)
 		}
 	}
@@ -556,6 +671,7 @@ func (sch *PreemptingQueueScheduler) schedule(ctx *armadacontext.Context, inMemo
 	if sch.skipUnsuccessfulSchedulingKeyCheck {
 		sched.SkipUnsuccessfulSchedulingKeyCheck()
 	}
+	sched.clock = sch.clock
 	result, err := sched.Schedule(ctx)
 	if err != nil {
 		return nil, err
@@ -2,7 +2,9 @@ package scheduler
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/nodedb"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	clientQueue "github.com/armadaproject/armada/pkg/client/queue"
 )
 
 func TestEvictOversubscribed(t *testing.T) {
@@ -104,6 +107,108 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 		// Minimum job size.
 		MinimumJobSize map[string]resource.Quantity
 	}{
+		"capacity pruning doesn't skip jobs that fit after balancing eviction": {
+			SchedulingConfig: testfixtures.WithCapacityPruningConfig(testfixtures.TestSchedulingConfig()),
+			Nodes:            testfixtures.N8GpuNodes(1, testfixtures.TestPriorities),
+			Rounds: []SchedulingRound{
+				{
+					JobsByQueue: map[string][]*jobdb.Job{
+						"A": testfixtures.N1GpuJobs("A", testfixtures.PriorityClass0, 8),
+					},
+					ExpectedScheduledIndices: map[string][]int{
+						"A": testfixtures.IntRange(0, 7),
+					},
+				},
+				{
+					JobsByQueue: map[string][]*jobdb.Job{
+						"B": testfixtures.N1GpuJobs("B", testfixtures.PriorityClass0, 8),
+					},
+					ExpectedScheduledIndices: map[string][]int{
+						"B": testfixtures.IntRange(0, 3),
+					},
+					ExpectedPreemptedIndices: map[string]map[int][]int{
+						"A": {
+							0: testfixtures.IntRange(4, 7),
+						},
+					},
+				},
+			},
+			PriorityFactorByQueue: map[string]float64{
+				"A": 1,
+				"B": 1,
+			},
+		},
+		"non-preemptible jobs of other queues don't use reserved resources": {
+			SchedulingConfig: testfixtures.WithReservedResourcesConfig(
+				map[string]map[string]resource.Quantity{"R": {"cpu": resource.MustParse("16")}},
+				testfixtures.TestSchedulingConfig(),
+			),
+			Nodes: testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Rounds: []SchedulingRound{
+				{
+					JobsByQueue: map[string][]*jobdb.Job{
+						"A": testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass3, 32),
+					},
+					ExpectedScheduledIndices: map[string][]int{
+						"A": testfixtures.IntRange(0, 15),
+					},
+				},
+				{
+					JobsByQueue: map[string][]*jobdb.Job{
+						"R": testfixtures.N1Cpu4GiJobs("R", testfixtures.PriorityClass3, 32),
+					},
+					ExpectedScheduledIndices: map[string][]int{
+						"R": testfixtures.IntRange(0, 15),
+					},
+				},
+			},
+			PriorityFactorByQueue: map[string]float64{
+				"A": 1,
+				"R": 1,
+			},
+		},
+		"reserved resources are reclaimed from preemptible jobs of other queues": {
+			SchedulingConfig: testfixtures.WithReservedResourcesConfig(
+				map[string]map[string]resource.Quantity{"R": {"cpu": resource.MustParse("16")}},
+				testfixtures.TestSchedulingConfig(),
+			),
+			Nodes: testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Rounds: []SchedulingRound{
+				{
+					JobsByQueue: map[string][]*jobdb.Job{
+						"A": testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 32),
+					},
+					ExpectedScheduledIndices: map[string][]int{
+						"A": testfixtures.IntRange(0, 31),
+					},
+				},
+				{
+					// R is given a tiny fair share, such that it's only scheduled due to its reservation.
+					JobsByQueue: map[string][]*jobdb.Job{
+						"R": testfixtures.N1Cpu4GiJobs("R", testfixtures.PriorityClass0, 32),
+					},
+					ExpectedScheduledIndices: map[string][]int{
+						"R": testfixtures.IntRange(0, 15),
+					},
+					ExpectedPreemptedIndices: map[string]map[int][]int{
+						"A": {
+							0: testfixtures.IntRange(16, 31),
+						},
+					},
+				},
+				{
+					// The system should be in steady-state; nothing should be scheduled/preempted.
+					JobsByQueue: map[string][]*jobdb.Job{
+						"A": testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1),
+						"R": testfixtures.N1Cpu4GiJobs("R", testfixtures.PriorityClass0, 1),
+					},
+				},
+			},
+			PriorityFactorByQueue: map[string]float64{
+				"A": 1,
+				"R": 100,
+			},
+		},
 		"balancing three queues": {
 			SchedulingConfig: testfixtures.TestSchedulingConfig(),
 			Nodes:            testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
@@ -1890,6 +1995,9 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 				if tc.SchedulingConfig.EnableNewPreemptionStrategy {
 					sch.EnableNewPreemptionStrategy()
 				}
+				if tc.SchedulingConfig.EnableCapacityPruning {
+					sch.EnableCapacityPruning()
+				}
 				result, err := sch.Schedule(ctx)
 				require.NoError(t, err)
 				jobIdsByGangId = sch.jobIdsByGangId
@@ -1927,7 +2035,7 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 				// Test that jobs are mapped to nodes correctly.
 				for _, jctx := range result.PreemptedJobs {
 					job := jctx.Job
-					nodeId, ok := result.NodeIdByJobId[job.GetId()]
+					nodeId, ok := result.NodeIdByJobId()[job.GetId()]
 					assert.True(t, ok)
 					assert.NotEmpty(t, nodeId)
 
@@ -1937,7 +2045,7 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 				}
 				for _, jctx := range result.ScheduledJobs {
 					job := jctx.Job
-					nodeId, ok := result.NodeIdByJobId[job.GetId()]
+					nodeId, ok := result.NodeIdByJobId()[job.GetId()]
 					assert.True(t, ok)
 					assert.NotEmpty(t, nodeId)
 
@@ -1949,7 +2057,7 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 						nodeIdByJobId[job.GetId()] = nodeId
 					}
 				}
-				for jobId, nodeId := range result.NodeIdByJobId {
+				for jobId, nodeId := range result.NodeIdByJobId() {
 					if expectedNodeId, ok := nodeIdByJobId[jobId]; ok {
 						assert.Equal(t, expectedNodeId, nodeId, "job %s preempted from/scheduled onto unexpected node", jobId)
 					}
@@ -2034,7 +2142,7 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 				for _, jctx := range result.ScheduledJobs {
 					job := jctx.Job.(*jobdb.Job)
 					jobId := job.GetId()
-					node, err := nodeDb.GetNode(result.NodeIdByJobId[jobId])
+					node, err := nodeDb.GetNode(result.NodeIdByJobId()[jobId])
 					require.NotNil(t, node)
 					require.NoError(t, err)
 					priority, ok := nodeDb.GetScheduledAtPriority(jobId)
@@ -2044,7 +2152,7 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 						job.
 							WithQueuedVersion(job.QueuedVersion()+1).
 							WithQueued(false).
-							WithNewRun(node.Executor, node.Id, node.Name, priority),
+							WithNewRun(node.Executor, node.Id, node.Name, priority, testfixtures.BaseTime),
 					)
 				}
 				err = jobDbTxn.Upsert(scheduledJobs)
@@ -2054,6 +2162,148 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 	}
 }
 
+func TestPreemptingQueueScheduler_CapacityPruning(t *testing.T) {
+	gpus := func(n string) schedulerobjects.ResourceList {
+		return schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"gpu": resource.MustParse(n)}}
+	}
+	nGpuJob := func(queue string, n string) *jobdb.Job {
+		jobId := util.ULID()
+		req := testfixtures.Test1GpuPodReqs(queue, jobId, 3)
+		req.ResourceRequirements.Requests["gpu"] = resource.MustParse(n)
+		return testfixtures.TestJob(queue, jobId, testfixtures.PriorityClass3, req)
+	}
+	tests := map[string]struct {
+		// Nodes, on which resources may be allocated at the highest priority to jobs that can't be preempted.
+		Nodes                    []*schedulerobjects.Node
+		Jobs                     []*jobdb.Job
+		ExpectedScheduledByQueue map[string]int
+		// Number of jobs skipped due to insufficient capacity in each queue when pruning is enabled.
+		ExpectedPrunedByQueue map[string]int
+	}{
+		"exactly enough free gpus for one job": {
+			Nodes: testfixtures.WithUsedResourcesNodes(
+				3, gpus("7"), testfixtures.N8GpuNodes(2, testfixtures.TestPriorities),
+			),
+			Jobs:                     testfixtures.N1GpuJobs("A", testfixtures.PriorityClass3, 3),
+			ExpectedScheduledByQueue: map[string]int{"A": 2},
+			ExpectedPrunedByQueue:    map[string]int{},
+		},
+		"no free gpus": {
+			Nodes: testfixtures.WithUsedResourcesNodes(
+				3, gpus("8"), testfixtures.N8GpuNodes(2, testfixtures.TestPriorities),
+			),
+			Jobs: append(
+				testfixtures.N1GpuJobs("A", testfixtures.PriorityClass3, 3),
+				testfixtures.N1Cpu4GiJobs("B", testfixtures.PriorityClass3, 2)...,
+			),
+			ExpectedScheduledByQueue: map[string]int{"B": 2},
+			ExpectedPrunedByQueue:    map[string]int{"A": 3},
+		},
+		"jobs requesting more gpus than are free on any node": {
+			Nodes: testfixtures.WithUsedResourcesNodes(
+				3, gpus("6"), testfixtures.N8GpuNodes(2, testfixtures.TestPriorities),
+			),
+			Jobs: []*jobdb.Job{
+				nGpuJob("A", "3"),
+				nGpuJob("A", "3"),
+				nGpuJob("A", "2"),
+			},
+			ExpectedScheduledByQueue: map[string]int{"A": 1},
+			ExpectedPrunedByQueue:    map[string]int{"A": 2},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheduledByQueueByPruning := make(map[bool]map[string]int)
+			for _, enableCapacityPruning := range []bool{false, true} {
+				config := testfixtures.TestSchedulingConfig()
+				nodeDb, err := NewNodeDb(config)
+				require.NoError(t, err)
+				txn := nodeDb.Txn(true)
+				for _, node := range tc.Nodes {
+					require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, nil, node.DeepCopy()))
+				}
+				txn.Commit()
+
+				jobDb := testfixtures.NewJobDb()
+				jobDbTxn := jobDb.WriteTxn()
+				require.NoError(t, jobDbTxn.Upsert(util.Map(tc.Jobs, func(job *jobdb.Job) *jobdb.Job { return job.WithQueued(true) })))
+
+				fairnessCostProvider, err := fairness.NewDominantResourceFairness(
+					nodeDb.TotalResources(),
+					config.DominantResourceFairnessResourcesToConsider,
+				)
+				require.NoError(t, err)
+				sctx := schedulercontext.NewSchedulingContext(
+					"executor",
+					"pool",
+					config.Preemption.PriorityClasses,
+					config.Preemption.DefaultPriorityClass,
+					fairnessCostProvider,
+					rate.NewLimiter(rate.Inf, math.MaxInt),
+					nodeDb.TotalResources(),
+				)
+				for _, queue := range []string{"A", "B"} {
+					require.NoError(t, sctx.AddQueueSchedulingContext(queue, 1, nil, rate.NewLimiter(rate.Inf, math.MaxInt)))
+				}
+				constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+					"pool",
+					nodeDb.TotalResources(),
+					schedulerobjects.ResourceList{},
+					config,
+				)
+				sch := NewPreemptingQueueScheduler(
+					sctx,
+					constraints,
+					config.Preemption.NodeEvictionProbability,
+					config.Preemption.NodeOversubscriptionEvictionProbability,
+					config.Preemption.ProtectedFractionOfFairShare,
+					NewSchedulerJobRepositoryAdapter(jobDbTxn),
+					nodeDb,
+					nil,
+					nil,
+					nil,
+				)
+				sch.EnableAssertions()
+				sch.EnableNewPreemptionStrategy()
+				if enableCapacityPruning {
+					sch.EnableCapacityPruning()
+				}
+				result, err := sch.Schedule(armadacontext.Background())
+				require.NoError(t, err)
+
+				scheduledByQueue := make(map[string]int)
+				for _, jctx := range result.ScheduledJobs {
+					scheduledByQueue[jctx.Job.GetQueue()]++
+				}
+				scheduledByQueueByPruning[enableCapacityPruning] = scheduledByQueue
+
+				prunedByQueue := make(map[string]int)
+				for queue, qctx := range sctx.QueueSchedulingContexts {
+					if qctx.NumCapacityPrunedJobs > 0 {
+						prunedByQueue[queue] = qctx.NumCapacityPrunedJobs
+					}
+					numPrunedJctxs := 0
+					for _, jctx := range qctx.UnsuccessfulJobSchedulingContexts {
+						if strings.HasPrefix(jctx.UnschedulableReason, InsufficientCapacityUnschedulableReason) {
+							numPrunedJctxs++
+						}
+					}
+					assert.Equal(t, qctx.NumCapacityPrunedJobs, numPrunedJctxs)
+				}
+				if enableCapacityPruning {
+					assert.Equal(t, tc.ExpectedPrunedByQueue, prunedByQueue)
+				} else {
+					assert.Empty(t, prunedByQueue)
+				}
+			}
+			assert.Equal(t, tc.ExpectedScheduledByQueue, scheduledByQueueByPruning[true])
+			// Pruning must never change which jobs are scheduled.
+			assert.Equal(t, scheduledByQueueByPruning[false], scheduledByQueueByPruning[true])
+		})
+	}
+}
+
 func jobIdsByQueueFromJobContexts(jctxs []*schedulercontext.JobSchedulingContext) map[string][]string {
 	rv := make(map[string][]string)
 	for _, jctx := range jctxs {
@@ -2249,7 +2499,7 @@ func BenchmarkPreemptingQueueScheduler(b *testing.B) {
 
 			jobsByNodeId := make(map[string][]*jobdb.Job)
 			for _, job := range ScheduledJobsFromSchedulerResult[*jobdb.Job](result) {
-				nodeId := result.NodeIdByJobId[job.GetId()]
+				nodeId := result.NodeIdByJobId()[job.GetId()]
 				jobsByNodeId[nodeId] = append(jobsByNodeId[nodeId], job)
 			}
 			nodeDb, err = NewNodeDb(tc.SchedulingConfig)
@@ -2301,3 +2551,373 @@ func BenchmarkPreemptingQueueScheduler(b *testing.B) {
 		})
 	}
 }
+
+func TestPreemptingQueueScheduler_UsageAwarePreemption(t *testing.T) {
+	tests := map[string]struct {
+		// Usage reported for the run of each running job, by index; nil if no usage is reported.
+		UsageByIndex []*jobdb.RunResourceUsage
+		// Whether to take usage into account when selecting victims.
+		EnableUsageAwarePreemption bool
+		// Index of the running job expected to be preempted.
+		ExpectedPreemptedIndex int
+	}{
+		"run age ordering without usage-aware preemption": {
+			UsageByIndex: []*jobdb.RunResourceUsage{
+				{CpuSeconds: 10, WorkingSetBytes: 1},
+				{CpuSeconds: 1000, WorkingSetBytes: 1},
+			},
+			ExpectedPreemptedIndex: 1,
+		},
+		"run age ordering without reported usage": {
+			EnableUsageAwarePreemption: true,
+			ExpectedPreemptedIndex:     1,
+		},
+		"run age ordering if usage is reported for only some runs": {
+			UsageByIndex: []*jobdb.RunResourceUsage{
+				nil,
+				{CpuSeconds: 1000, WorkingSetBytes: 1},
+			},
+			EnableUsageAwarePreemption: true,
+			ExpectedPreemptedIndex:     1,
+		},
+		"job with least cpu used is preempted": {
+			UsageByIndex: []*jobdb.RunResourceUsage{
+				{CpuSeconds: 10, WorkingSetBytes: 1},
+				{CpuSeconds: 1000, WorkingSetBytes: 1},
+			},
+			EnableUsageAwarePreemption: true,
+			ExpectedPreemptedIndex:     0,
+		},
+		"job with smallest working set is preempted if cpu used is equal": {
+			UsageByIndex: []*jobdb.RunResourceUsage{
+				{CpuSeconds: 10, WorkingSetBytes: 1},
+				{CpuSeconds: 10, WorkingSetBytes: 2},
+			},
+			EnableUsageAwarePreemption: true,
+			ExpectedPreemptedIndex:     0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testfixtures.TestSchedulingConfig()
+			node := testfixtures.Test32CpuNode(testfixtures.TestPriorities)
+
+			// Queue A is running two jobs that fill the node; the first of which has been running for longer.
+			runningJobs := testfixtures.N16Cpu128GiJobs("A", testfixtures.PriorityClass0, 2)
+			allocatedByPriorityClass := make(schedulerobjects.QuantityByTAndResourceType[string])
+			nodeIdByJobId := make(map[string]string)
+			for i, job := range runningJobs {
+				job = job.WithQueued(false).WithNewRun("executor", node.Id, node.Name, 0, testfixtures.BaseTime.Add(time.Duration(i)*time.Minute))
+				if i < len(tc.UsageByIndex) && tc.UsageByIndex[i] != nil {
+					job = job.WithUpdatedRun(job.LatestRun().WithResourceUsage(*tc.UsageByIndex[i]))
+				}
+				runningJobs[i] = job
+				allocatedByPriorityClass.AddV1ResourceList(job.GetPriorityClassName(), job.GetResourceRequirements().Requests)
+				nodeIdByJobId[job.GetId()] = node.Id
+			}
+			nodeDb, err := NewNodeDb(config)
+			require.NoError(t, err)
+			txn := nodeDb.Txn(true)
+			require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, runningJobs, node))
+			txn.Commit()
+
+			// Queue B submits a job, which can only be scheduled by preempting one of the jobs of queue A.
+			jobDb := testfixtures.NewJobDb()
+			jobDbTxn := jobDb.WriteTxn()
+			require.NoError(t, jobDbTxn.Upsert(append(
+				[]*jobdb.Job{testfixtures.Test16Cpu128GiJob("B", testfixtures.PriorityClass0).WithQueued(true)},
+				runningJobs...,
+			)))
+
+			fairnessCostProvider, err := fairness.NewDominantResourceFairness(
+				nodeDb.TotalResources(),
+				config.DominantResourceFairnessResourcesToConsider,
+			)
+			require.NoError(t, err)
+			sctx := schedulercontext.NewSchedulingContext(
+				"executor",
+				"pool",
+				config.Preemption.PriorityClasses,
+				config.Preemption.DefaultPriorityClass,
+				fairnessCostProvider,
+				rate.NewLimiter(rate.Inf, math.MaxInt),
+				nodeDb.TotalResources(),
+			)
+			require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, allocatedByPriorityClass, rate.NewLimiter(rate.Inf, math.MaxInt)))
+			require.NoError(t, sctx.AddQueueSchedulingContext("B", 1, nil, rate.NewLimiter(rate.Inf, math.MaxInt)))
+			constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+				"pool",
+				nodeDb.TotalResources(),
+				schedulerobjects.ResourceList{},
+				config,
+			)
+			sch := NewPreemptingQueueScheduler(
+				sctx,
+				constraints,
+				config.Preemption.NodeEvictionProbability,
+				config.Preemption.NodeOversubscriptionEvictionProbability,
+				config.Preemption.ProtectedFractionOfFairShare,
+				NewSchedulerJobRepositoryAdapter(jobDbTxn),
+				nodeDb,
+				nodeIdByJobId,
+				nil,
+				nil,
+			)
+			sch.EnableAssertions()
+			sch.EnableNewPreemptionStrategy()
+			if tc.EnableUsageAwarePreemption {
+				sch.EnableUsageAwarePreemption()
+			}
+			result, err := sch.Schedule(armadacontext.Background())
+			require.NoError(t, err)
+
+			require.Len(t, result.PreemptedJobs, 1)
+			assert.Equal(t, runningJobs[tc.ExpectedPreemptedIndex].GetId(), result.PreemptedJobs[0].Job.GetId())
+			require.Len(t, result.ScheduledJobs, 1)
+			assert.Equal(t, "B", result.ScheduledJobs[0].Job.GetQueue())
+		})
+	}
+}
+
+func TestPreemptingQueueScheduler_GangAwarePreemption(t *testing.T) {
+	tests := map[string]struct {
+		// Whether to treat gangs as a unit when selecting victims.
+		EnableGangAwarePreemption bool
+		// If true, the gang is expected to be preempted. Otherwise, the non-gang jobs are.
+		ExpectGangPreempted bool
+	}{
+		"the most recently started jobs are preempted without gang-aware preemption": {
+			ExpectGangPreempted: true,
+		},
+		"non-gang jobs are preempted with gang-aware preemption": {
+			EnableGangAwarePreemption: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testfixtures.TestSchedulingConfig()
+			nodes := []*schedulerobjects.Node{testfixtures.Test32CpuNode(testfixtures.TestPriorities), testfixtures.Test32CpuNode(testfixtures.TestPriorities)}
+			nodes[0].Executor = "executor-1"
+			nodes[1].Executor = "executor-2"
+
+			// Queue A is running a gang spanning both nodes, on different executors, and a non-gang job on each node.
+			// The non-gang jobs have been running for longer, such that they'd be rescheduled before the gang.
+			nonGangJobs := testfixtures.N16Cpu128GiJobs("A", testfixtures.PriorityClass0, 2)
+			gangJobs := testfixtures.WithGangAnnotationsJobs(testfixtures.N16Cpu128GiJobs("A", testfixtures.PriorityClass0, 2))
+			allocatedByPriorityClass := make(schedulerobjects.QuantityByTAndResourceType[string])
+			nodeIdByJobId := make(map[string]string)
+			jobIdsByGangId := make(map[string]map[string]bool)
+			gangIdByJobId := make(map[string]string)
+			jobsByNodeId := make(map[string][]*jobdb.Job)
+			var runningJobs []*jobdb.Job
+			for i, job := range append(nonGangJobs, gangJobs...) {
+				node := nodes[i%2]
+				job = job.WithQueued(false).WithNewRun(node.Executor, node.Id, node.Name, 0, testfixtures.BaseTime.Add(time.Duration(i)*time.Minute))
+				runningJobs = append(runningJobs, job)
+				jobsByNodeId[node.Id] = append(jobsByNodeId[node.Id], job)
+				allocatedByPriorityClass.AddV1ResourceList(job.GetPriorityClassName(), job.GetResourceRequirements().Requests)
+				nodeIdByJobId[job.GetId()] = node.Id
+				if gangId := job.GangId(); gangId != "" {
+					if jobIdsByGangId[gangId] == nil {
+						jobIdsByGangId[gangId] = make(map[string]bool)
+					}
+					jobIdsByGangId[gangId][job.GetId()] = true
+					gangIdByJobId[job.GetId()] = gangId
+				}
+			}
+			nodeDb, err := NewNodeDb(config)
+			require.NoError(t, err)
+			txn := nodeDb.Txn(true)
+			for _, node := range nodes {
+				require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, jobsByNodeId[node.Id], node))
+			}
+			txn.Commit()
+
+			// Queue B submits two jobs, which can be scheduled by preempting either the gang or the two non-gang jobs.
+			jobDb := testfixtures.NewJobDb()
+			jobDbTxn := jobDb.WriteTxn()
+			queuedJobs := testfixtures.N16Cpu128GiJobs("B", testfixtures.PriorityClass0, 2)
+			for i, job := range queuedJobs {
+				queuedJobs[i] = job.WithQueued(true)
+			}
+			require.NoError(t, jobDbTxn.Upsert(append(queuedJobs, runningJobs...)))
+
+			fairnessCostProvider, err := fairness.NewDominantResourceFairness(
+				nodeDb.TotalResources(),
+				config.DominantResourceFairnessResourcesToConsider,
+			)
+			require.NoError(t, err)
+			sctx := schedulercontext.NewSchedulingContext(
+				"executor",
+				"pool",
+				config.Preemption.PriorityClasses,
+				config.Preemption.DefaultPriorityClass,
+				fairnessCostProvider,
+				rate.NewLimiter(rate.Inf, math.MaxInt),
+				nodeDb.TotalResources(),
+			)
+			require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, allocatedByPriorityClass, rate.NewLimiter(rate.Inf, math.MaxInt)))
+			require.NoError(t, sctx.AddQueueSchedulingContext("B", 1, nil, rate.NewLimiter(rate.Inf, math.MaxInt)))
+			constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+				"pool",
+				nodeDb.TotalResources(),
+				schedulerobjects.ResourceList{},
+				config,
+			)
+			sch := NewPreemptingQueueScheduler(
+				sctx,
+				constraints,
+				config.Preemption.NodeEvictionProbability,
+				config.Preemption.NodeOversubscriptionEvictionProbability,
+				config.Preemption.ProtectedFractionOfFairShare,
+				NewSchedulerJobRepositoryAdapter(jobDbTxn),
+				nodeDb,
+				nodeIdByJobId,
+				jobIdsByGangId,
+				gangIdByJobId,
+			)
+			sch.EnableAssertions()
+			sch.EnableNewPreemptionStrategy()
+			if tc.EnableGangAwarePreemption {
+				sch.EnableGangAwarePreemption()
+			}
+			result, err := sch.Schedule(armadacontext.Background())
+			require.NoError(t, err)
+
+			expectedPreemptedJobs := nonGangJobs
+			if tc.ExpectGangPreempted {
+				expectedPreemptedJobs = gangJobs
+			}
+			expectedPreemptedJobIds := make([]string, len(expectedPreemptedJobs))
+			for i, job := range expectedPreemptedJobs {
+				expectedPreemptedJobIds[i] = job.GetId()
+			}
+			assert.ElementsMatch(t, expectedPreemptedJobIds, jobIdsByQueueFromJobContexts(result.PreemptedJobs)["A"])
+			require.Len(t, result.ScheduledJobs, 2)
+			for _, jctx := range result.ScheduledJobs {
+				assert.Equal(t, "B", jctx.Job.GetQueue())
+			}
+		})
+	}
+}
+
+func TestPreemptingQueueScheduler_PreemptionPolicies(t *testing.T) {
+	tests := map[string]struct {
+		PreemptionPolicyByQueue map[string]clientQueue.PreemptionPolicy
+		// Number of running jobs of each queue expected to be preempted.
+		ExpectedNumPreemptedByQueue map[string]int
+	}{
+		"queue above its protected fraction of fair share is preempted without policies": {
+			ExpectedNumPreemptedByQueue: map[string]int{"never": 1},
+		},
+		"never-preempt and aggressive queues": {
+			PreemptionPolicyByQueue: map[string]clientQueue.PreemptionPolicy{
+				"never":      clientQueue.PreemptionPolicyNever,
+				"standard":   clientQueue.PreemptionPolicyStandard,
+				"aggressive": clientQueue.PreemptionPolicyAggressive,
+			},
+			ExpectedNumPreemptedByQueue: map[string]int{"aggressive": 1},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testfixtures.WithProtectedFractionOfFairShareConfig(2, testfixtures.TestSchedulingConfig())
+			nodes := testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)
+
+			// Queue never is running two jobs filling the first node, at 2.5 times its fair share,
+			// whereas queues standard and aggressive each run a job on the second node, at 1.25 times their fair share.
+			// Hence, only queue never is above its protected fraction of fair share.
+			runningJobsByQueue := map[string][]*jobdb.Job{
+				"never":      testfixtures.N16Cpu128GiJobs("never", testfixtures.PriorityClass0, 2),
+				"standard":   testfixtures.N16Cpu128GiJobs("standard", testfixtures.PriorityClass0, 1),
+				"aggressive": testfixtures.N16Cpu128GiJobs("aggressive", testfixtures.PriorityClass0, 1),
+			}
+			nodeByQueue := map[string]*schedulerobjects.Node{"never": nodes[0], "standard": nodes[1], "aggressive": nodes[1]}
+			allocatedByQueueAndPriorityClass := make(map[string]schedulerobjects.QuantityByTAndResourceType[string])
+			nodeIdByJobId := make(map[string]string)
+			jobsByNodeId := make(map[string][]*jobdb.Job)
+			var runningJobs []*jobdb.Job
+			for queue, jobs := range runningJobsByQueue {
+				node := nodeByQueue[queue]
+				allocatedByQueueAndPriorityClass[queue] = make(schedulerobjects.QuantityByTAndResourceType[string])
+				for i, job := range jobs {
+					job = job.WithQueued(false).WithNewRun("executor", node.Id, node.Name, 0, testfixtures.BaseTime)
+					jobs[i] = job
+					runningJobs = append(runningJobs, job)
+					jobsByNodeId[node.Id] = append(jobsByNodeId[node.Id], job)
+					allocatedByQueueAndPriorityClass[queue].AddV1ResourceList(job.GetPriorityClassName(), job.GetResourceRequirements().Requests)
+					nodeIdByJobId[job.GetId()] = node.Id
+				}
+			}
+			nodeDb, err := NewNodeDb(config)
+			require.NoError(t, err)
+			nodeDb.EnableNeverPreemptQueues(func(queue string) bool {
+				return tc.PreemptionPolicyByQueue[queue] == clientQueue.PreemptionPolicyNever
+			})
+			txn := nodeDb.Txn(true)
+			for _, node := range nodes {
+				require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, jobsByNodeId[node.Id], node))
+			}
+			txn.Commit()
+
+			// Queue new, with twice the weight of the other queues, submits two jobs that can only be scheduled by preempting.
+			jobDb := testfixtures.NewJobDb()
+			jobDbTxn := jobDb.WriteTxn()
+			queuedJobs := testfixtures.N16Cpu128GiJobs("new", testfixtures.PriorityClass0, 2)
+			for i, job := range queuedJobs {
+				queuedJobs[i] = job.WithQueued(true)
+			}
+			require.NoError(t, jobDbTxn.Upsert(append(queuedJobs, runningJobs...)))
+
+			fairnessCostProvider, err := fairness.NewDominantResourceFairness(
+				nodeDb.TotalResources(),
+				config.DominantResourceFairnessResourcesToConsider,
+			)
+			require.NoError(t, err)
+			sctx := schedulercontext.NewSchedulingContext(
+				"executor",
+				"pool",
+				config.Preemption.PriorityClasses,
+				config.Preemption.DefaultPriorityClass,
+				fairnessCostProvider,
+				rate.NewLimiter(rate.Inf, math.MaxInt),
+				nodeDb.TotalResources(),
+			)
+			for _, queue := range []string{"never", "standard", "aggressive"} {
+				require.NoError(t, sctx.AddQueueSchedulingContext(queue, 1, allocatedByQueueAndPriorityClass[queue], rate.NewLimiter(rate.Inf, math.MaxInt)))
+				sctx.QueueSchedulingContexts[queue].PreemptionPolicy = tc.PreemptionPolicyByQueue[queue]
+			}
+			require.NoError(t, sctx.AddQueueSchedulingContext("new", 2, nil, rate.NewLimiter(rate.Inf, math.MaxInt)))
+			constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+				"pool",
+				nodeDb.TotalResources(),
+				schedulerobjects.ResourceList{},
+				config,
+			)
+			sch := NewPreemptingQueueScheduler(
+				sctx,
+				constraints,
+				config.Preemption.NodeEvictionProbability,
+				config.Preemption.NodeOversubscriptionEvictionProbability,
+				config.Preemption.ProtectedFractionOfFairShare,
+				NewSchedulerJobRepositoryAdapter(jobDbTxn),
+				nodeDb,
+				nodeIdByJobId,
+				nil,
+				nil,
+			)
+			sch.EnableAssertions()
+			sch.EnableNewPreemptionStrategy()
+			result, err := sch.Schedule(armadacontext.Background())
+			require.NoError(t, err)
+
+			numPreemptedByQueue := make(map[string]int)
+			for queue, jobIds := range jobIdsByQueueFromJobContexts(result.PreemptedJobs) {
+				numPreemptedByQueue[queue] = len(jobIds)
+			}
+			assert.Equal(t, tc.ExpectedNumPreemptedByQueue, numPreemptedByQueue)
+			require.Len(t, result.ScheduledJobs, 1)
+			assert.Equal(t, "new", result.ScheduledJobs[0].Job.GetQueue())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// PreemptionBudget records the work lost to preemption within a trailing window, per pool and across all pools;
+// see configuration.PreemptionBudgetConfig. Preemptions are kept in memory and hence forgotten on failover.
+type PreemptionBudget struct {
+	config configuration.PreemptionBudgetConfig
+	// Work preempted within the window, in ascending order of preemption time.
+	preemptions []preemptedWork
+}
+
+// preemptedWork is the work, in resource-seconds, lost by preempting a job in pool at time.
+type preemptedWork struct {
+	time            time.Time
+	pool            string
+	resourceSeconds float64
+}
+
+func NewPreemptionBudget(config configuration.PreemptionBudgetConfig) *PreemptionBudget {
+	return &PreemptionBudget{
+		config: config,
+	}
+}
+
+// Usage returns the work preempted within the window ending at now.
+// The budget is exhausted if either the work preempted in pool or the work preempted across all pools reached its limit.
+func (b *PreemptionBudget) Usage(pool string, now time.Time) *schedulercontext.PreemptionBudgetUsage {
+	b.prune(now)
+	usage := &schedulercontext.PreemptionBudgetUsage{}
+	for _, preemption := range b.preemptions {
+		usage.ConsumedGlobally += preemption.resourceSeconds
+		if preemption.pool == pool {
+			usage.Consumed += preemption.resourceSeconds
+		}
+	}
+	if limit := b.config.MaxGlobalResourceSeconds; limit > 0 && usage.ConsumedGlobally >= limit {
+		usage.Exhausted = true
+	}
+	if limit, ok := b.config.MaxResourceSecondsByPool[pool]; ok && usage.Consumed >= limit {
+		usage.Exhausted = true
+	}
+	return usage
+}
+
+// RecordPreemptions records the work lost by preempting jctxs from pool at time now.
+// The work of each job is its request for the budgeted resource multiplied by the time since its latest run was leased.
+// Urgency-based preemptions are ignored if they're exempt from the budget.
+func (b *PreemptionBudget) RecordPreemptions(pool string, jctxs []*schedulercontext.JobSchedulingContext, now time.Time) {
+	for _, jctx := range jctxs {
+		if jctx.IsUrgencyPreempted && b.config.ExemptUrgencyPreemptions {
+			continue
+		}
+		job, ok := jctx.Job.(*jobdb.Job)
+		if !ok {
+			continue
+		}
+		run := job.LatestRun()
+		if run == nil {
+			continue
+		}
+		runningDuration := now.Sub(time.Unix(0, run.Created()))
+		if runningDuration <= 0 {
+			continue
+		}
+		request := job.GetResourceRequirements().Requests[v1.ResourceName(b.config.ResourceName)]
+		if request.IsZero() {
+			continue
+		}
+		b.preemptions = append(b.preemptions, preemptedWork{
+			time:            now,
+			pool:            pool,
+			resourceSeconds: request.AsApproximateFloat64() * runningDuration.Seconds(),
+		})
+	}
+}
+
+// prune forgets preemptions that happened before the window ending at now.
+func (b *PreemptionBudget) prune(now time.Time) {
+	cutoff := now.Add(-b.config.Window)
+	i := 0
+	for i < len(b.preemptions) && !b.preemptions[i].time.After(cutoff) {
+		i++
+	}
+	b.preemptions = b.preemptions[i:]
+}
@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestPreemptionBudget_Usage(t *testing.T) {
+	// Each job requests 1 cpu and was leased 10 minutes before being preempted, i.e., is worth 600 cpu-seconds.
+	preempted := func(pool string, isUrgencyPreempted bool) (string, *schedulercontext.JobSchedulingContext) {
+		job := testfixtures.Test1Cpu4GiJob("A", testfixtures.PriorityClass0).WithNewRun("executor", "node", "node", 0, testfixtures.BaseTime)
+		return pool, &schedulercontext.JobSchedulingContext{JobId: job.Id(), Job: job, IsUrgencyPreempted: isUrgencyPreempted}
+	}
+	tests := map[string]struct {
+		config                   configuration.PreemptionBudgetConfig
+		preemptions              []func() (string, *schedulercontext.JobSchedulingContext)
+		expectedConsumed         float64
+		expectedConsumedGlobally float64
+		expectedExhausted        bool
+	}{
+		"below pool budget": {
+			config: configuration.PreemptionBudgetConfig{MaxResourceSecondsByPool: map[string]float64{"pool": 1200}},
+			preemptions: []func() (string, *schedulercontext.JobSchedulingContext){
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("pool", false) },
+			},
+			expectedConsumed:         600,
+			expectedConsumedGlobally: 600,
+			expectedExhausted:        false,
+		},
+		"pool budget reached": {
+			config: configuration.PreemptionBudgetConfig{MaxResourceSecondsByPool: map[string]float64{"pool": 1200}},
+			preemptions: []func() (string, *schedulercontext.JobSchedulingContext){
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("pool", false) },
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("pool", false) },
+			},
+			expectedConsumed:         1200,
+			expectedConsumedGlobally: 1200,
+			expectedExhausted:        true,
+		},
+		"preemptions in other pools don't count towards pool budget": {
+			config: configuration.PreemptionBudgetConfig{MaxResourceSecondsByPool: map[string]float64{"pool": 1200}},
+			preemptions: []func() (string, *schedulercontext.JobSchedulingContext){
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("pool", false) },
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("other", false) },
+			},
+			expectedConsumed:         600,
+			expectedConsumedGlobally: 1200,
+			expectedExhausted:        false,
+		},
+		"global budget reached by preemptions across pools": {
+			config: configuration.PreemptionBudgetConfig{MaxGlobalResourceSeconds: 1200},
+			preemptions: []func() (string, *schedulercontext.JobSchedulingContext){
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("pool", false) },
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("other", false) },
+			},
+			expectedConsumed:         600,
+			expectedConsumedGlobally: 1200,
+			expectedExhausted:        true,
+		},
+		"urgency-based preemptions count towards budget": {
+			config: configuration.PreemptionBudgetConfig{MaxResourceSecondsByPool: map[string]float64{"pool": 600}},
+			preemptions: []func() (string, *schedulercontext.JobSchedulingContext){
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("pool", true) },
+			},
+			expectedConsumed:         600,
+			expectedConsumedGlobally: 600,
+			expectedExhausted:        true,
+		},
+		"exempt urgency-based preemptions": {
+			config: configuration.PreemptionBudgetConfig{MaxResourceSecondsByPool: map[string]float64{"pool": 600}, ExemptUrgencyPreemptions: true},
+			preemptions: []func() (string, *schedulercontext.JobSchedulingContext){
+				func() (string, *schedulercontext.JobSchedulingContext) { return preempted("pool", true) },
+			},
+			expectedConsumed:         0,
+			expectedConsumedGlobally: 0,
+			expectedExhausted:        false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.config.Enabled = true
+			tc.config.Window = time.Hour
+			tc.config.ResourceName = "cpu"
+			budget := NewPreemptionBudget(tc.config)
+			now := testfixtures.BaseTime.Add(10 * time.Minute)
+			for _, f := range tc.preemptions {
+				pool, jctx := f()
+				budget.RecordPreemptions(pool, []*schedulercontext.JobSchedulingContext{jctx}, now)
+			}
+			usage := budget.Usage("pool", now)
+			assert.InDelta(t, tc.expectedConsumed, usage.Consumed, 1e-6)
+			assert.InDelta(t, tc.expectedConsumedGlobally, usage.ConsumedGlobally, 1e-6)
+			assert.Equal(t, tc.expectedExhausted, usage.Exhausted)
+
+			// Preemptions are forgotten once they leave the window.
+			usage = budget.Usage("pool", now.Add(time.Hour))
+			assert.Equal(t, &schedulercontext.PreemptionBudgetUsage{}, usage)
+		})
+	}
+}
+
+func TestPreemptionBudget_FairnessPreemptionDeferredUntilBudgetRecovers(t *testing.T) {
+	ctx := armadacontext.Background()
+	config := testfixtures.TestSchedulingConfig()
+	config.PreemptionBudget = configuration.PreemptionBudgetConfig{
+		Enabled:                  true,
+		Window:                   time.Hour,
+		ResourceName:             "cpu",
+		MaxResourceSecondsByPool: map[string]float64{testfixtures.TestPool: 5000},
+	}
+
+	nodes := testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)
+	nodes[0].Executor = "executor-1"
+	executor := &schedulerobjects.Executor{
+		Id:             "executor-1",
+		Pool:           testfixtures.TestPool,
+		Nodes:          nodes,
+		LastUpdateTime: testfixtures.BaseTime,
+	}
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{executor}, nil).AnyTimes()
+	mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+	mockQueueRepo.EXPECT().GetAllQueues().Return(
+		[]*database.Queue{{Name: "A", Weight: 1}, {Name: "B", Weight: 1}, {Name: "C", Weight: 1}},
+		nil,
+	).AnyTimes()
+	algo, err := NewFairSchedulingAlgo(config, 0, mockExecutorRepo, mockQueueRepo, nil)
+	require.NoError(t, err)
+	testClock := clock.NewFakeClock(testfixtures.BaseTime.Add(10 * time.Minute))
+	algo.clock = testClock
+
+	jobDb := testfixtures.NewJobDb()
+	runCycle := func() *schedulercontext.SchedulingContext {
+		// Such that the executor isn't considered stale once the clock has moved past the window.
+		executor.LastUpdateTime = testClock.Now()
+		txn := jobDb.WriteTxn()
+		result, err := algo.Schedule(ctx, txn)
+		require.NoError(t, err)
+		var terminalJobIds []string
+		for _, job := range txn.GetAll() {
+			if job.InTerminalState() {
+				terminalJobIds = append(terminalJobIds, job.Id())
+			}
+		}
+		require.NoError(t, txn.BatchDelete(terminalJobIds))
+		txn.Commit()
+		testClock.Step(10 * time.Second)
+		require.Len(t, result.SchedulingContexts, 1)
+		return result.SchedulingContexts[0]
+	}
+	submit := func(jobs ...*jobdb.Job) {
+		txn := jobDb.WriteTxn()
+		require.NoError(t, txn.Upsert(jobs))
+		txn.Commit()
+	}
+	numQueued := func(jobs ...*jobdb.Job) int {
+		n := 0
+		for _, job := range jobs {
+			if job := jobDb.ReadTxn().GetById(job.Id()); job != nil && job.Queued() {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Queue A has been running on the entire node for 10 minutes.
+	runningJobs := testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 32)
+	for i, job := range runningJobs {
+		runningJobs[i] = job.WithQueued(false).WithNewRun("executor-1", nodes[0].Id, nodes[0].Name, 0, testfixtures.BaseTime)
+	}
+	submit(runningJobs...)
+
+	// Balancing resource usage with queue B preempts 16 cpu running for 10 minutes, which exhausts the budget.
+	jobsB := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("B", testfixtures.PriorityClass0, 32))
+	submit(jobsB...)
+	sctx := runCycle()
+	assert.Equal(t, 16, numQueued(jobsB...))
+	assert.False(t, sctx.PreemptionBudget.Exhausted)
+	assert.InDelta(t, 16*600, sctx.PreemptionBudget.Consumed, 1e-6)
+
+	// Queue C is below its fair share, but nothing is preempted for it while the budget is exhausted.
+	jobsC := queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("C", testfixtures.PriorityClass0, 32))
+	submit(jobsC...)
+	for i := 0; i < 3; i++ {
+		sctx = runCycle()
+		assert.Equal(t, 32, numQueued(jobsC...))
+		assert.True(t, sctx.PreemptionBudget.Exhausted)
+		assert.Equal(t, 0, sctx.NumEvictedJobs)
+	}
+
+	// Once the preemptions have left the window, jobs are preempted for queue C.
+	testClock.Step(time.Hour)
+	sctx = runCycle()
+	assert.Less(t, numQueued(jobsC...), 32)
+	assert.False(t, sctx.PreemptionBudget.Exhausted)
+	assert.Greater(t, sctx.PreemptionBudget.Consumed, 0.0)
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/types"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+)
+
+// MissingPriorityClass is a priority class of jobs in flight that's missing from the config.
+type MissingPriorityClass struct {
+	Name string
+	// Number of sampled jobs of this priority class.
+	NumJobs int
+}
+
+// PriorityClassCheckResult is the outcome of checking the priority classes of a sample of the jobs in flight
+// against those configured.
+type PriorityClassCheckResult struct {
+	// Number of jobs sampled.
+	NumSampledJobs int
+	// Priority classes of sampled jobs that are missing from the config, sorted by name.
+	Missing []MissingPriorityClass
+}
+
+func (r PriorityClassCheckResult) String() string {
+	missing := make([]string, len(r.Missing))
+	for i, priorityClass := range r.Missing {
+		missing[i] = fmt.Sprintf("%s (%d jobs)", priorityClass.Name, priorityClass.NumJobs)
+	}
+	return fmt.Sprintf(
+		"%d of %d sampled jobs in flight have priority classes missing from the config: %s",
+		r.NumMissingJobs(), r.NumSampledJobs, strings.Join(missing, ", "),
+	)
+}
+
+// NumMissingJobs returns the number of sampled jobs with priority classes missing from the config.
+func (r PriorityClassCheckResult) NumMissingJobs() int {
+	rv := 0
+	for _, priorityClass := range r.Missing {
+		rv += priorityClass.NumJobs
+	}
+	return rv
+}
+
+// SamplePriorityClasses compares the priority classes of up to maxJobs of the jobs in flight, most recently updated
+// first, with priorityClasses. Jobs without a priority class are assigned the default priority class, so never missing.
+func SamplePriorityClasses(
+	ctx *armadacontext.Context,
+	repository database.PriorityClassSampleRepository,
+	priorityClasses map[string]types.PriorityClass,
+	maxJobs int,
+) (PriorityClassCheckResult, error) {
+	countByPriorityClass, numSampled, err := repository.SamplePriorityClasses(ctx, maxJobs)
+	if err != nil {
+		return PriorityClassCheckResult{}, err
+	}
+	result := PriorityClassCheckResult{NumSampledJobs: numSampled}
+	for name, count := range countByPriorityClass {
+		if _, ok := priorityClasses[name]; ok || name == "" {
+			continue
+		}
+		result.Missing = append(result.Missing, MissingPriorityClass{Name: name, NumJobs: count})
+	}
+	sort.Slice(result.Missing, func(i, j int) bool { return result.Missing[i].Name < result.Missing[j].Name })
+	return result, nil
+}
+
+// CheckPriorityClasses checks that a sample of the jobs in flight don't have priority classes missing from config.
+// If any do, an error listing these priority classes is returned if config.PriorityClassCheck.Mode is Refuse.
+// If the mode is Fallback, they're instead logged as a warning, since such jobs are assigned the fallback priority class.
+func CheckPriorityClasses(ctx *armadacontext.Context, repository database.PriorityClassSampleRepository, config schedulerconfig.Configuration) error {
+	checkConfig := config.PriorityClassCheck
+	priorityClasses := config.Scheduling.Preemption.PriorityClasses
+	fallback := checkConfig.Mode == schedulerconfig.PriorityClassCheckModeFallback
+	if _, ok := priorityClasses[checkConfig.FallbackPriorityClass]; fallback && !ok {
+		return errors.Errorf("fallback priority class %s is missing from the config", checkConfig.FallbackPriorityClass)
+	}
+	result, err := SamplePriorityClasses(ctx, repository, priorityClasses, checkConfig.MaxSampledJobs)
+	if err != nil {
+		return errors.WithMessage(err, "failed to sample the priority classes of jobs in flight")
+	}
+	if len(result.Missing) == 0 {
+		ctx.Infof("checked the priority classes of %d sampled jobs in flight; all are configured", result.NumSampledJobs)
+		return nil
+	}
+	if fallback {
+		ctx.Warnf("%s; these jobs are assigned priority class %s", result, checkConfig.FallbackPriorityClass)
+		return nil
+	}
+	return errors.Errorf("%s; restore these priority classes or set the priority class check mode to Fallback", result)
+}
@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+// testPriorityClassSampleRepository returns countByPriorityClass as the sampled priority classes.
+type testPriorityClassSampleRepository struct {
+	countByPriorityClass map[string]int
+	err                  error
+	// maxJobs of the most recent call.
+	maxJobs int
+}
+
+func (r *testPriorityClassSampleRepository) SamplePriorityClasses(_ *armadacontext.Context, maxJobs int) (map[string]int, int, error) {
+	r.maxJobs = maxJobs
+	numSampled := 0
+	for _, count := range r.countByPriorityClass {
+		numSampled += count
+	}
+	return r.countByPriorityClass, numSampled, r.err
+}
+
+func priorityClassCheckTestConfig(mode schedulerconfig.PriorityClassCheckMode, fallbackPriorityClass string) schedulerconfig.Configuration {
+	return schedulerconfig.Configuration{
+		Scheduling: testfixtures.TestSchedulingConfig(),
+		PriorityClassCheck: schedulerconfig.PriorityClassCheckConfig{
+			Enabled:               true,
+			Mode:                  mode,
+			FallbackPriorityClass: fallbackPriorityClass,
+			MaxSampledJobs:        100,
+		},
+	}
+}
+
+func TestCheckPriorityClasses(t *testing.T) {
+	inFlight := map[string]int{
+		testfixtures.PriorityClass0: 10,
+		"removed-b":                 2,
+		"removed-a":                 3,
+		// Jobs without a priority class are assigned the default priority class.
+		"": 4,
+	}
+	tests := map[string]struct {
+		config               schedulerconfig.Configuration
+		countByPriorityClass map[string]int
+		expectedError        string
+	}{
+		"clean config": {
+			config:               priorityClassCheckTestConfig(schedulerconfig.PriorityClassCheckModeRefuse, ""),
+			countByPriorityClass: map[string]int{testfixtures.PriorityClass0: 10, testfixtures.PriorityClass1: 5, "": 1},
+		},
+		"no jobs in flight": {
+			config: priorityClassCheckTestConfig(schedulerconfig.PriorityClassCheckModeRefuse, ""),
+		},
+		"refuse": {
+			config:               priorityClassCheckTestConfig(schedulerconfig.PriorityClassCheckModeRefuse, ""),
+			countByPriorityClass: inFlight,
+			expectedError:        "5 of 19 sampled jobs in flight have priority classes missing from the config: removed-a (3 jobs), removed-b (2 jobs)",
+		},
+		"refuse is the default": {
+			config:               priorityClassCheckTestConfig("", ""),
+			countByPriorityClass: inFlight,
+			expectedError:        "removed-a (3 jobs), removed-b (2 jobs)",
+		},
+		"fallback": {
+			config:               priorityClassCheckTestConfig(schedulerconfig.PriorityClassCheckModeFallback, testfixtures.PriorityClass1),
+			countByPriorityClass: inFlight,
+		},
+		"unknown fallback priority class": {
+			config:               priorityClassCheckTestConfig(schedulerconfig.PriorityClassCheckModeFallback, "removed-a"),
+			countByPriorityClass: map[string]int{testfixtures.PriorityClass0: 10},
+			expectedError:        "fallback priority class removed-a is missing from the config",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			repository := &testPriorityClassSampleRepository{countByPriorityClass: tc.countByPriorityClass}
+			err := CheckPriorityClasses(armadacontext.Background(), repository, tc.config)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 100, repository.maxJobs)
+		})
+	}
+}
+
+func TestCheckPriorityClasses_RepositoryError(t *testing.T) {
+	repository := &testPriorityClassSampleRepository{err: errors.New("connection refused")}
+	err := CheckPriorityClasses(armadacontext.Background(), repository, priorityClassCheckTestConfig(schedulerconfig.PriorityClassCheckModeFallback, testfixtures.PriorityClass1))
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestConfigReloader_PriorityClassCheck(t *testing.T) {
+	ctx := armadacontext.Background()
+	var initialConfig schedulerconfig.Configuration
+	_, err := common.ReadConfig(&initialConfig, "../../config/scheduler", nil)
+	require.NoError(t, err)
+	initialConfig.PriorityClassCheck.Mode = schedulerconfig.PriorityClassCheckModeRefuse
+	reloadedConfig := initialConfig
+	reloadedConfig.Scheduling.MaxRetries = initialConfig.Scheduling.MaxRetries + 1
+	reloader := NewConfigReloader(initialConfig, func() (schedulerconfig.Configuration, error) { return reloadedConfig, nil })
+	repository := &testPriorityClassSampleRepository{countByPriorityClass: map[string]int{"removed": 1}}
+	reloader.EnablePriorityClassCheck(repository)
+
+	// Configs the scheduler would refuse to start with are rejected.
+	err = reloader.Reload(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "removed (1 jobs)")
+	_, generation := reloader.Config()
+	assert.Equal(t, uint64(0), generation)
+	assert.Equal(t, 1.0, testutil.ToFloat64(reloader.failures))
+
+	// Unless jobs of missing priority classes are assigned a fallback.
+	reloadedConfig.PriorityClassCheck.Mode = schedulerconfig.PriorityClassCheckModeFallback
+	reloadedConfig.PriorityClassCheck.FallbackPriorityClass = initialConfig.Scheduling.Preemption.DefaultPriorityClass
+	require.NoError(t, reloader.Reload(ctx))
+	config, generation := reloader.Config()
+	assert.Equal(t, uint64(1), generation)
+	assert.Equal(t, reloadedConfig.Scheduling.MaxRetries, config.Scheduling.MaxRetries)
+}
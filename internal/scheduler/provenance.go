@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// SchedulingConfigHash returns a hex-encoded sha256 hash of config,
+// such that events published by schedulers running with different scheduling configs can be told apart.
+//
+// The config is hashed field by field rather than via its json representation, since json can't represent the
+// infinite rate limits commonly used in scheduling configs. Map entries are hashed in key order, such that the hash
+// is stable across restarts.
+func SchedulingConfigHash(config configuration.SchedulingConfig) (string, error) {
+	h := sha256.New()
+	if err := writeConfigHash(h, reflect.ValueOf(config)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+func writeConfigHash(h hash.Hash, v reflect.Value) error {
+	if v.Type().Implements(jsonMarshalerType) && (v.Kind() != reflect.Pointer || !v.IsNil()) {
+		// E.g., resource.Quantity, whose fields may hold the same quantity in different forms.
+		bytes, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		h.Write(bytes)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		h.Write([]byte(strconv.FormatBool(v.Bool())))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.Write([]byte(strconv.FormatInt(v.Int(), 10)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.Write([]byte(strconv.FormatUint(v.Uint(), 10)))
+	case reflect.Float32, reflect.Float64:
+		h.Write([]byte(strconv.FormatFloat(v.Float(), 'g', -1, 64)))
+	case reflect.String:
+		h.Write([]byte(strconv.Quote(v.String())))
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			h.Write([]byte("nil"))
+			return nil
+		}
+		return writeConfigHash(h, v.Elem())
+	case reflect.Slice, reflect.Array:
+		h.Write([]byte("["))
+		for i := 0; i < v.Len(); i++ {
+			if err := writeConfigHash(h, v.Index(i)); err != nil {
+				return err
+			}
+			h.Write([]byte(","))
+		}
+		h.Write([]byte("]"))
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		h.Write([]byte("{"))
+		for _, key := range keys {
+			if err := writeConfigHash(h, key); err != nil {
+				return err
+			}
+			h.Write([]byte(":"))
+			if err := writeConfigHash(h, v.MapIndex(key)); err != nil {
+				return err
+			}
+			h.Write([]byte(","))
+		}
+		h.Write([]byte("}"))
+	case reflect.Struct:
+		h.Write([]byte("{"))
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			h.Write([]byte(v.Type().Field(i).Name + ":"))
+			if err := writeConfigHash(h, v.Field(i)); err != nil {
+				return err
+			}
+			h.Write([]byte(","))
+		}
+		h.Write([]byte("}"))
+	default:
+		return errors.Errorf("can't hash config value of kind %s", v.Kind())
+	}
+	return nil
+}
+
+// EnableProvenance causes a provenance block to be attached to each event sequence published,
+// recording the build and scheduling config of this scheduler, the name of the leader, and the id of the cycle
+// in which the events were created. The values are injected by schedulerapp.Run.
+func (p *PulsarPublisher) EnableProvenance(buildVersion, buildCommit, configHash, leaderName string) {
+	p.provenance = &armadaevents.Provenance{
+		BuildVersion: buildVersion,
+		BuildCommit:  buildCommit,
+		ConfigHash:   configHash,
+		LeaderName:   leaderName,
+	}
+}
+
+// provenanceForCycle returns the provenance to attach to sequences published in the cycle with the given id,
+// or nil if provenance isn't enabled.
+func (p *PulsarPublisher) provenanceForCycle(cycleId string) *armadaevents.Provenance {
+	if p.provenance == nil {
+		return nil
+	}
+	provenance := *p.provenance
+	provenance.CycleId = cycleId
+	return &provenance
+}
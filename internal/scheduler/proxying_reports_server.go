@@ -35,6 +35,24 @@ func (s *ProxyingSchedulingReportsServer) GetJobReport(ctx context.Context, requ
 	return s.client.GetJobReport(ctx, request)
 }
 
+func (s *ProxyingSchedulingReportsServer) GetJobSetReport(ctx context.Context, request *schedulerobjects.JobSetReportRequest) (*schedulerobjects.JobSetReport, error) {
+	ctx, cancel := reduceTimeout(ctx)
+	defer cancel()
+	return s.client.GetJobSetReport(ctx, request)
+}
+
+func (s *ProxyingSchedulingReportsServer) GetEventRateReport(ctx context.Context, request *schedulerobjects.EventRateReportRequest) (*schedulerobjects.EventRateReport, error) {
+	ctx, cancel := reduceTimeout(ctx)
+	defer cancel()
+	return s.client.GetEventRateReport(ctx, request)
+}
+
+func (s *ProxyingSchedulingReportsServer) GetFragmentationReport(ctx context.Context, request *schedulerobjects.FragmentationReportRequest) (*schedulerobjects.FragmentationReport, error) {
+	ctx, cancel := reduceTimeout(ctx)
+	defer cancel()
+	return s.client.GetFragmentationReport(ctx, request)
+}
+
 // We reduce the context deadline here, to prevent our call and the caller who called us from timing out at the same time
 // This should mean our caller gets the real error message rather than a generic timeout error from client side
 func reduceTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
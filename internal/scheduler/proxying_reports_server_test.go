@@ -125,6 +125,120 @@ func TestProxyingSchedulingReportsServer_GetQueueReport(t *testing.T) {
 	}
 }
 
+func TestProxyingSchedulingReportsServer_GetJobSetReport(t *testing.T) {
+	tests := map[string]struct {
+		err error
+	}{
+		"no error": {
+			err: nil,
+		},
+		"on error": {
+			err: fmt.Errorf("error"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			sut, jobReportsClient := setupProxyingSchedulerReportsServerTest(t)
+
+			request := &schedulerobjects.JobSetReportRequest{Queue: "queue-1", JobSetId: "job-set-1"}
+
+			expectedResult := &schedulerobjects.JobSetReport{Report: "report"}
+
+			if tc.err == nil {
+				expectedResult = nil
+			}
+
+			jobReportsClient.GetJobSetReportResponse = expectedResult
+			jobReportsClient.Err = tc.err
+
+			result, err := sut.GetJobSetReport(ctx, request)
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, expectedResult, result)
+			assert.Len(t, jobReportsClient.GetJobSetReportCalls, 1)
+		})
+	}
+}
+
+func TestProxyingSchedulingReportsServer_GetEventRateReport(t *testing.T) {
+	tests := map[string]struct {
+		err error
+	}{
+		"no error": {
+			err: nil,
+		},
+		"on error": {
+			err: fmt.Errorf("error"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			sut, jobReportsClient := setupProxyingSchedulerReportsServerTest(t)
+
+			request := &schedulerobjects.EventRateReportRequest{Queue: "queue-1", NumJobSets: 5}
+
+			expectedResult := &schedulerobjects.EventRateReport{Report: "report"}
+
+			if tc.err == nil {
+				expectedResult = nil
+			}
+
+			jobReportsClient.GetEventRateReportResponse = expectedResult
+			jobReportsClient.Err = tc.err
+
+			result, err := sut.GetEventRateReport(ctx, request)
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, expectedResult, result)
+			assert.Len(t, jobReportsClient.GetEventRateReportCalls, 1)
+		})
+	}
+}
+
+func TestProxyingSchedulingReportsServer_GetFragmentationReport(t *testing.T) {
+	tests := map[string]struct {
+		err error
+	}{
+		"no error": {
+			err: nil,
+		},
+		"on error": {
+			err: fmt.Errorf("error"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			sut, jobReportsClient := setupProxyingSchedulerReportsServerTest(t)
+
+			request := &schedulerobjects.FragmentationReportRequest{Pool: "pool-1"}
+
+			expectedResult := &schedulerobjects.FragmentationReport{Report: "report"}
+
+			if tc.err == nil {
+				expectedResult = nil
+			}
+
+			jobReportsClient.GetFragmentationReportResponse = expectedResult
+			jobReportsClient.Err = tc.err
+
+			result, err := sut.GetFragmentationReport(ctx, request)
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, expectedResult, result)
+			assert.Len(t, jobReportsClient.GetFragmentationReportCalls, 1)
+		})
+	}
+}
+
 func setupProxyingSchedulerReportsServerTest(t *testing.T) (*ProxyingSchedulingReportsServer, *FakeSchedulerReportingClient) {
 	schedulerReportsClient := NewFakeSchedulerReportingClient()
 	sut := NewProxyingSchedulingReportsServer(schedulerReportsClient)
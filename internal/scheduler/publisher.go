@@ -1,19 +1,25 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	"github.com/armadaproject/armada/internal/common/eventutil"
 	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/common/metrics"
 	"github.com/armadaproject/armada/internal/common/schedulers"
 	"github.com/armadaproject/armada/pkg/armadaevents"
 )
@@ -22,12 +28,28 @@ const (
 	// This is half the default pulsar BatchingMaxSize
 	defaultMaxMessageBatchSize = 64 * 1024
 	explicitPartitionKey       = "armada_pulsar_partition"
+	// Minimum time between recreations of a closed producer.
+	defaultProducerRecreationInterval = 10 * time.Second
+)
+
+var (
+	producerRecreationsDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_pulsar_producer_recreations",
+		"Number of times the scheduler recreated its Pulsar producer after it was closed.",
+		nil, nil,
+	)
+	inFlightHighWaterMarkDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_pulsar_in_flight_messages_high_water_mark",
+		"Maximum number of messages sent to Pulsar but not yet acknowledged at any point during the most recent publish.",
+		nil, nil,
+	)
 )
 
 // Publisher is an interface to be implemented by structs that handle publishing messages to pulsar
 type Publisher interface {
 	// PublishMessages will publish the supplied messages. A LeaderToken is provided and the
-	// implementor may decide whether to publish based on the status of this token
+	// implementor may decide whether to publish based on the status of this token.
+	// Messages should be stamped with the leadership epoch associated with ctx, if any.
 	PublishMessages(ctx *armadacontext.Context, events []*armadaevents.EventSequence, shouldPublish func() bool) error
 
 	// PublishMarkers publishes a single marker message for each Pulsar partition.  Each marker
@@ -36,10 +58,60 @@ type Publisher interface {
 	PublishMarkers(ctx *armadacontext.Context, groupId uuid.UUID) (uint32, error)
 }
 
-// PulsarPublisher is the default implementation of Publisher
+// ErrObserverPublish is returned by ObserverPublisher.
+var ErrObserverPublish = errors.New("observer replicas never publish")
+
+// PublishIncompleteError is returned by PulsarPublisher.PublishMessages if not all messages were acknowledged,
+// recording which event sequences were. Messages not acknowledged may nonetheless have been published,
+// e.g., if they were acknowledged after timing out; hence, this is for diagnostics only.
+type PublishIncompleteError struct {
+	// Event sequences whose messages were acknowledged, in the order they were sent.
+	// These are the sequences as published, i.e., after being compacted and split by size.
+	Acknowledged []*armadaevents.EventSequence
+	// Number of messages that weren't acknowledged, including those never sent.
+	NumUnacknowledged int
+	// First error encountered.
+	Err error
+}
+
+func (e *PublishIncompleteError) Error() string {
+	return fmt.Sprintf(
+		"%d of %d messages were acknowledged: %s",
+		len(e.Acknowledged), len(e.Acknowledged)+e.NumUnacknowledged, e.Err,
+	)
+}
+
+func (e *PublishIncompleteError) Unwrap() error {
+	return e.Err
+}
+
+// ObserverPublisher is used by observer replicas in place of a PulsarPublisher.
+// Since observers are never leader, they should never attempt to publish; if they do, an error is returned.
+type ObserverPublisher struct{}
+
+func (p ObserverPublisher) PublishMessages(_ *armadacontext.Context, _ []*armadaevents.EventSequence, _ func() bool) error {
+	return errors.WithStack(ErrObserverPublish)
+}
+
+func (p ObserverPublisher) PublishMarkers(_ *armadacontext.Context, _ uuid.UUID) (uint32, error) {
+	return 0, errors.WithStack(ErrObserverPublish)
+}
+
+// PulsarPublisher is the default implementation of Publisher.
+// If the producer is closed, e.g., because the broker unloaded the topic, it's recreated before the next publish.
 type PulsarPublisher struct {
+	// Used to create producers.
+	pulsarClient pulsar.Client
+	// Options used to create producers.
+	producerOptions pulsar.ProducerOptions
 	// Used to send messages to pulsar
 	producer pulsar.Producer
+	// True if the producer has been closed and must be recreated before publishing.
+	producerClosed bool
+	// Bounds the rate at which closed producers are recreated.
+	producerRecreationLimiter *rate.Limiter
+	// Number of times the producer has been recreated.
+	producerRecreations atomic.Uint64
 	// Number of partitions on the pulsar topic
 	numPartitions int
 	// Timeout after which async messages sends will be considered failed
@@ -47,6 +119,17 @@ type PulsarPublisher struct {
 	// Maximum size (in bytes) of produced pulsar messages.
 	// This must be below 4MB which is the pulsar message size limit
 	maxMessageBatchSize uint
+	// If non-nil, attached to each published event sequence, with the id of the cycle set.
+	provenance *armadaevents.Provenance
+	// If positive, the maximum number of messages in flight at any point during a publish.
+	maxInFlightMessages int
+	// If positive, time after which a message that's been sent but not acknowledged is considered failed.
+	messageSendTimeout time.Duration
+	// Maximum number of messages in flight during the most recent publish.
+	inFlightHighWaterMark atomic.Int64
+	// Time from each message being sent until it's acknowledged or fails.
+	sendLatency prometheus.Histogram
+	clock       clock.Clock
 }
 
 func NewPulsarPublisher(
@@ -68,13 +151,31 @@ func NewPulsarPublisher(
 		maxMessageBatchSize = defaultMaxMessageBatchSize
 	}
 	return &PulsarPublisher{
-		producer:            producer,
-		pulsarSendTimeout:   pulsarSendTimeout,
-		maxMessageBatchSize: maxMessageBatchSize,
-		numPartitions:       len(partitions),
+		pulsarClient:              pulsarClient,
+		producerOptions:           producerOptions,
+		producer:                  producer,
+		producerRecreationLimiter: rate.NewLimiter(rate.Every(defaultProducerRecreationInterval), 1),
+		pulsarSendTimeout:         pulsarSendTimeout,
+		maxMessageBatchSize:       maxMessageBatchSize,
+		numPartitions:             len(partitions),
+		clock:                     clock.RealClock{},
+		sendLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    metrics.MetricPrefix + "scheduler_pulsar_send_latency_seconds",
+			Help:    "Time from each message being sent to Pulsar until it's acknowledged or fails.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+		}),
 	}, nil
 }
 
+// EnableSendLimits bounds the number of messages in flight at any point during a publish to maxInFlightMessages,
+// such that a slow broker results in messages being sent progressively rather than piling up in the producer,
+// and causes messages not acknowledged within messageSendTimeout to be considered failed.
+// Zero values leave the corresponding limit disabled.
+func (p *PulsarPublisher) EnableSendLimits(maxInFlightMessages int, messageSendTimeout time.Duration) {
+	p.maxInFlightMessages = maxInFlightMessages
+	p.messageSendTimeout = messageSendTimeout
+}
+
 // PublishMessages publishes all event sequences to pulsar. Event sequences for a given jobset will be combined into
 // single event sequences up to maxMessageBatchSize.
 func (p *PulsarPublisher) PublishMessages(ctx *armadacontext.Context, events []*armadaevents.EventSequence, shouldPublish func() bool) error {
@@ -83,8 +184,18 @@ func (p *PulsarPublisher) PublishMessages(ctx *armadacontext.Context, events []*
 	if err != nil {
 		return err
 	}
+	// Attached after compacting, since compacting creates new sequences.
+	if provenance := p.provenanceForCycle(cycleIdFromContext(ctx)); provenance != nil {
+		for _, sequence := range sequences {
+			sequence.Provenance = provenance
+		}
+	}
+	// The epoch is stamped both on sequences, such that it's persisted with the events,
+	// and on messages, such that messages can be filtered without unmarshalling them.
+	leaderEpoch := leaderEpochFromContext(ctx)
 	msgs := make([]*pulsar.ProducerMessage, len(sequences))
 	for i, sequence := range sequences {
+		sequence.LeaderEpoch = leaderEpoch
 		bytes, err := proto.Marshal(sequence)
 		if err != nil {
 			return err
@@ -96,41 +207,172 @@ func (p *PulsarPublisher) PublishMessages(ctx *armadacontext.Context, events []*
 				schedulers.PropertyName: schedulers.PulsarSchedulerAttribute,
 			},
 		}
+		if leaderEpoch != 0 {
+			msgs[i].Properties[schedulers.LeaderEpochPropertyName] = schedulers.MsgPropertyFromLeaderEpoch(leaderEpoch)
+		}
 	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(len(msgs))
-
 	// Send messages
 	if shouldPublish() {
 		ctx.Debugf("Am leader so will publish")
+		if err := p.recreateProducerIfClosed(ctx); err != nil {
+			return err
+		}
 		sendCtx, cancel := armadacontext.WithTimeout(ctx, p.pulsarSendTimeout)
-		errored := false
-		for _, msg := range msgs {
-			p.producer.SendAsync(sendCtx, msg, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+		defer cancel()
+		acknowledged, sendErr := p.sendMessages(sendCtx, msgs)
+		if sendErr != nil {
+			p.markProducerClosedIfNecessary(ctx, sendErr)
+			incompleteErr := &PublishIncompleteError{Err: sendErr}
+			for i, sequence := range sequences {
+				if acknowledged[i] {
+					incompleteErr.Acknowledged = append(incompleteErr.Acknowledged, sequence)
+				} else {
+					incompleteErr.NumUnacknowledged++
+				}
+			}
+			return errors.WithMessage(incompleteErr, "One or more messages failed to send to Pulsar")
+		}
+	} else {
+		ctx.Debugf("No longer leader so not publishing")
+	}
+	return nil
+}
+
+// sendMessages sends msgs asynchronously, with at most maxInFlightMessages in flight at any time,
+// and waits until each has been acknowledged, has failed, or has timed out.
+// Messages are sent in order; once ctx expires, no further messages are sent.
+// Returns which messages were acknowledged, together with the first error encountered, if any.
+// Messages not acknowledged may nonetheless have been persisted, e.g., if they were acknowledged after timing out.
+func (p *PulsarPublisher) sendMessages(ctx *armadacontext.Context, msgs []*pulsar.ProducerMessage) ([]bool, error) {
+	acknowledged := make([]bool, len(msgs))
+	maxInFlight := p.maxInFlightMessages
+	if maxInFlight <= 0 || maxInFlight > len(msgs) {
+		maxInFlight = len(msgs)
+	}
+	inFlightSlots := make(chan struct{}, maxInFlight)
+	wg := sync.WaitGroup{}
+	var mu sync.Mutex
+	var sendErr error
+	recordErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if sendErr == nil {
+			sendErr = err
+		}
+	}
+
+	// The timeout is enforced here rather than left to the producer,
+	// such that a broker that never acknowledges a message can't hold up the publish.
+	sent := make(chan *sentMessage, len(msgs))
+	timeoutsDone := make(chan struct{})
+	go func() {
+		defer close(timeoutsDone)
+		p.timeOutMessages(ctx, sent)
+	}()
+
+	var highWaterMark int64
+	for i, msg := range msgs {
+		if ctx.Err() == nil {
+			select {
+			case inFlightSlots <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			recordErr(errors.WithMessagef(ctx.Err(), "%d messages weren't sent before the publish timed out", len(msgs)-i))
+			break
+		}
+		if numInFlight := int64(len(inFlightSlots)); numInFlight > highWaterMark {
+			highWaterMark = numInFlight
+		}
+
+		i := i
+		sentMsg := &sentMessage{sentAt: p.clock.Now(), done: make(chan struct{})}
+		sentMsg.complete = func(err error) {
+			sentMsg.once.Do(func() {
+				close(sentMsg.done)
+				p.sendLatency.Observe(p.clock.Since(sentMsg.sentAt).Seconds())
 				if err != nil {
 					logging.
 						WithStacktrace(ctx, err).
 						Error("error sending message to Pulsar")
-					errored = true
+					recordErr(err)
+				} else {
+					mu.Lock()
+					acknowledged[i] = true
+					mu.Unlock()
 				}
+				<-inFlightSlots
 				wg.Done()
 			})
 		}
-		wg.Wait()
-		cancel()
-		if errored {
-			return errors.New("One or more messages failed to send to Pulsar")
+		wg.Add(1)
+		sent <- sentMsg
+		p.producer.SendAsync(ctx, msg, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+			sentMsg.complete(err)
+		})
+	}
+	close(sent)
+	wg.Wait()
+	<-timeoutsDone
+	p.inFlightHighWaterMark.Store(highWaterMark)
+	mu.Lock()
+	defer mu.Unlock()
+	return acknowledged, sendErr
+}
+
+// sentMessage is a message sent by sendMessages that may not yet have been acknowledged.
+type sentMessage struct {
+	sentAt time.Time
+	// Marks the message as acknowledged if err is nil and as failed otherwise; only the first call has any effect.
+	complete func(err error)
+	// Closed once complete has been called.
+	done chan struct{}
+	once sync.Once
+}
+
+// timeOutMessages fails each message received on sent that isn't acknowledged within messageSendTimeout,
+// or before ctx expires, until sent is closed. Since all messages have the same timeout and are received in the order
+// they were sent, they time out in that order; hence, a single timer suffices.
+func (p *PulsarPublisher) timeOutMessages(ctx *armadacontext.Context, sent <-chan *sentMessage) {
+	var timer clock.Timer
+	for msg := range sent {
+		var timeout <-chan time.Time
+		if p.messageSendTimeout > 0 {
+			d := msg.sentAt.Add(p.messageSendTimeout).Sub(p.clock.Now())
+			if timer == nil {
+				timer = p.clock.NewTimer(d)
+			} else {
+				timer.Reset(d)
+			}
+			timeout = timer.C()
+		}
+		select {
+		case <-msg.done:
+			if timer != nil && !timer.Stop() {
+				<-timer.C()
+			}
+		case <-timeout:
+			msg.complete(errors.WithMessage(context.DeadlineExceeded, "message wasn't acknowledged in time"))
+		case <-ctx.Done():
+			if timer != nil && !timer.Stop() {
+				<-timer.C()
+			}
+			msg.complete(errors.WithMessage(ctx.Err(), "message wasn't acknowledged in time"))
 		}
-	} else {
-		ctx.Debugf("No longer leader so not publishing")
 	}
-	return nil
+	if timer != nil {
+		timer.Stop()
+	}
 }
 
 // PublishMarkers sends one pulsar message (containing an armadaevents.PartitionMarker) to each partition
 // of the producer's Pulsar topic.
 func (p *PulsarPublisher) PublishMarkers(ctx *armadacontext.Context, groupId uuid.UUID) (uint32, error) {
+	if err := p.recreateProducerIfClosed(ctx); err != nil {
+		return 0, err
+	}
 	for i := 0; i < p.numPartitions; i++ {
 		pm := &armadaevents.PartitionMarker{
 			GroupId:   armadaevents.ProtoUuidFromUuid(groupId),
@@ -163,12 +405,74 @@ func (p *PulsarPublisher) PublishMarkers(ctx *armadacontext.Context, groupId uui
 		// We send relatively few position markers so the performance penalty shouldn't be meaningful
 		_, err = p.producer.Send(ctx, msg)
 		if err != nil {
+			p.markProducerClosedIfNecessary(ctx, err)
 			return 0, err
 		}
 	}
 	return uint32(p.numPartitions), nil
 }
 
+// markProducerClosedIfNecessary marks the producer for recreation if err indicates it was closed.
+// Other errors, including permanent configuration errors such as the topic not existing, are left to the caller.
+func (p *PulsarPublisher) markProducerClosedIfNecessary(ctx *armadacontext.Context, err error) {
+	if isProducerClosedError(err) && !p.producerClosed {
+		ctx.Warnf("pulsar producer was closed; it will be recreated before the next publish")
+		p.producerClosed = true
+	}
+}
+
+// recreateProducerIfClosed replaces a closed producer with a new one created with the same options.
+// Recreation is rate-limited; if the producer was recreated too recently, an error is returned.
+func (p *PulsarPublisher) recreateProducerIfClosed(ctx *armadacontext.Context) error {
+	if !p.producerClosed {
+		return nil
+	}
+	if !p.producerRecreationLimiter.Allow() {
+		return errors.New("pulsar producer is closed and was recreated too recently to be recreated again")
+	}
+	producer, err := p.pulsarClient.CreateProducer(p.producerOptions)
+	if err != nil {
+		return errors.WithMessage(err, "error recreating pulsar producer")
+	}
+	p.producer.Close()
+	p.producer = producer
+	p.producerClosed = false
+	p.producerRecreations.Add(1)
+	ctx.Infof("recreated pulsar producer")
+	return nil
+}
+
+// resultError is implemented by errors returned by the Pulsar client, e.g., *pulsar.Error.
+type resultError interface {
+	Result() pulsar.Result
+}
+
+// isProducerClosedError returns true if err indicates the producer was closed and should be recreated.
+func isProducerClosedError(err error) bool {
+	var resultErr resultError
+	if !errors.As(err, &resultErr) {
+		return false
+	}
+	switch resultErr.Result() {
+	case pulsar.ProducerClosed, pulsar.AlreadyClosedError:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *PulsarPublisher) Describe(desc chan<- *prometheus.Desc) {
+	desc <- producerRecreationsDesc
+	desc <- inFlightHighWaterMarkDesc
+	p.sendLatency.Describe(desc)
+}
+
+func (p *PulsarPublisher) Collect(metrics chan<- prometheus.Metric) {
+	metrics <- prometheus.MustNewConstMetric(producerRecreationsDesc, prometheus.CounterValue, float64(p.producerRecreations.Load()))
+	metrics <- prometheus.MustNewConstMetric(inFlightHighWaterMarkDesc, prometheus.GaugeValue, float64(p.inFlightHighWaterMark.Load()))
+	p.sendLatency.Collect(metrics)
+}
+
 // createMessageRouter returns a custom Pulsar message router that routes the message to the partition given by the
 // explicitPartitionKey msg property. If this property isn't present then it will fall back to the default Pulsar
 // message routing logic
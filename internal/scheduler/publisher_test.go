@@ -1,8 +1,10 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -11,12 +13,16 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/metrics"
 	"github.com/armadaproject/armada/internal/common/mocks"
 	"github.com/armadaproject/armada/internal/common/pulsarutils"
+	"github.com/armadaproject/armada/internal/common/schedulers"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
 	"github.com/armadaproject/armada/pkg/armadaevents"
 )
 
@@ -211,6 +217,131 @@ func TestPulsarPublisher_TestPublishMarkers(t *testing.T) {
 	}
 }
 
+func TestPulsarPublisher_RecreatesClosedProducer(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	closedProducer := mocks.NewMockProducer(ctrl)
+	recreatedProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+	gomock.InOrder(
+		mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(closedProducer, nil).Times(1),
+		mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).DoAndReturn(func(options pulsar.ProducerOptions) (pulsar.Producer, error) {
+			// The configured options must be re-applied.
+			assert.Equal(t, topic, options.Topic)
+			assert.NotNil(t, options.MessageRouter)
+			return recreatedProducer, nil
+		}).Times(1),
+	)
+
+	// The first producer is closed by the broker mid-run.
+	closedProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *armadacontext.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			callback(nil, msg, &testPulsarError{result: pulsar.ProducerClosed})
+		}).Times(1)
+	closedProducer.EXPECT().Close().Times(1)
+	numPublished := 0
+	recreatedProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *armadacontext.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			numPublished++
+			callback(pulsarutils.NewMessageId(numPublished), msg, nil)
+		}).AnyTimes()
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+	eventSequences := []*armadaevents.EventSequence{{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}}}
+	shouldPublish := func() bool { return true }
+
+	// Publishing fails while the producer is closed.
+	err = publisher.PublishMessages(ctx, eventSequences, shouldPublish)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(0), publisher.producerRecreations.Load())
+
+	// Subsequent publishes resume on a recreated producer.
+	err = publisher.PublishMessages(ctx, eventSequences, shouldPublish)
+	assert.NoError(t, err)
+	err = publisher.PublishMessages(ctx, eventSequences, shouldPublish)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, numPublished)
+	assert.Equal(t, uint64(1), publisher.producerRecreations.Load())
+}
+
+func TestPulsarPublisher_ProducerRecreationIsRateLimited(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(2)
+	mockPulsarProducer.EXPECT().Close().Times(1)
+	mockPulsarProducer.
+		EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		Return(nil, &testPulsarError{result: pulsar.AlreadyClosedError}).
+		Times(2)
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+
+	// The first closure results in the producer being recreated.
+	_, err = publisher.PublishMarkers(ctx, uuid.New())
+	assert.Error(t, err)
+	_, err = publisher.PublishMarkers(ctx, uuid.New())
+	assert.Error(t, err)
+	assert.Equal(t, uint64(1), publisher.producerRecreations.Load())
+
+	// The recreated producer is immediately closed again; it's not recreated again until the limiter allows it.
+	_, err = publisher.PublishMarkers(ctx, uuid.New())
+	assert.ErrorContains(t, err, "recreated too recently")
+	assert.Equal(t, uint64(1), publisher.producerRecreations.Load())
+}
+
+func TestPulsarPublisher_PermanentErrorsDontRecreateProducer(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *armadacontext.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			callback(nil, msg, &testPulsarError{result: pulsar.TopicNotFound})
+		}).Times(2)
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+	eventSequences := []*armadaevents.EventSequence{{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}}}
+	for i := 0; i < 2; i++ {
+		err = publisher.PublishMessages(ctx, eventSequences, func() bool { return true })
+		var resultErr *testPulsarError
+		require.ErrorAs(t, err, &resultErr)
+		assert.Equal(t, pulsar.TopicNotFound, resultErr.Result())
+	}
+	assert.Equal(t, uint64(0), publisher.producerRecreations.Load())
+}
+
+// testPulsarError mimics *pulsar.Error, which can't be created outside the pulsar package.
+type testPulsarError struct {
+	result pulsar.Result
+}
+
+func (e *testPulsarError) Error() string {
+	return fmt.Sprintf("pulsar error with result %d", e.result)
+}
+
+func (e *testPulsarError) Result() pulsar.Result {
+	return e.result
+}
+
 type TopicMetadata struct{}
 
 func (t TopicMetadata) NumPartitions() uint32 {
@@ -260,3 +391,269 @@ func countEvents(es []*armadaevents.EventSequence) map[string]int {
 	}
 	return countsById
 }
+
+func TestPulsarPublisher_Provenance(t *testing.T) {
+	publish := func(t *testing.T, publisher *PulsarPublisher, producer *mocks.MockProducer, cycleId string) []*armadaevents.EventSequence {
+		var capturedEvents []*armadaevents.EventSequence
+		producer.
+			EXPECT().
+			SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *armadacontext.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+				es := &armadaevents.EventSequence{}
+				require.NoError(t, proto.Unmarshal(msg.Payload, es))
+				capturedEvents = append(capturedEvents, es)
+				callback(pulsarutils.NewMessageId(len(capturedEvents)), msg, nil)
+			}).Times(2)
+		ctx := withCycleId(armadacontext.Background(), cycleId)
+		err := publisher.PublishMessages(ctx, []*armadaevents.EventSequence{
+			{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}, {}}},
+			{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}},
+			{JobSetName: "jobset2", Events: []*armadaevents.EventSequence_Event{{}}},
+		}, func() bool { return true })
+		require.NoError(t, err)
+		require.Len(t, capturedEvents, 2)
+		return capturedEvents
+	}
+
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).AnyTimes()
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil).AnyTimes()
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+
+	// Provenance is only attached once enabled.
+	for _, sequence := range publish(t, publisher, mockPulsarProducer, "cycle-0") {
+		assert.Nil(t, sequence.Provenance)
+	}
+
+	config := testfixtures.TestSchedulingConfig()
+	configHash, err := SchedulingConfigHash(config)
+	require.NoError(t, err)
+	publisher.EnableProvenance("v1.2.3", "abcdef", configHash, "scheduler-0")
+	expected := &armadaevents.Provenance{
+		BuildVersion: "v1.2.3",
+		BuildCommit:  "abcdef",
+		ConfigHash:   configHash,
+		LeaderName:   "scheduler-0",
+		CycleId:      "cycle-1",
+	}
+	for _, sequence := range publish(t, publisher, mockPulsarProducer, "cycle-1") {
+		assert.Equal(t, expected, sequence.Provenance)
+	}
+
+	// The cycle id changes between cycles.
+	expected.CycleId = "cycle-2"
+	for _, sequence := range publish(t, publisher, mockPulsarProducer, "cycle-2") {
+		assert.Equal(t, expected, sequence.Provenance)
+	}
+
+	// The config hash is stable for a given config and changes with it.
+	sameConfigHash, err := SchedulingConfigHash(testfixtures.TestSchedulingConfig())
+	require.NoError(t, err)
+	assert.Equal(t, configHash, sameConfigHash)
+	config.MaximumSchedulingBurst++
+	otherConfigHash, err := SchedulingConfigHash(config)
+	require.NoError(t, err)
+	assert.NotEqual(t, configHash, otherConfigHash)
+	publisher.EnableProvenance("v1.2.3", "abcdef", otherConfigHash, "scheduler-0")
+	for _, sequence := range publish(t, publisher, mockPulsarProducer, "cycle-2") {
+		assert.Equal(t, otherConfigHash, sequence.Provenance.ConfigHash)
+		assert.Equal(t, "cycle-2", sequence.Provenance.CycleId)
+	}
+}
+
+func TestPulsarPublisher_LeaderEpoch(t *testing.T) {
+	// Messages landing on the broker, in the order they land.
+	var landed []*pulsar.ProducerMessage
+	newPublisher := func() *PulsarPublisher {
+		ctrl := gomock.NewController(t)
+		mockPulsarClient := mocks.NewMockClient(ctrl)
+		mockPulsarProducer := mocks.NewMockProducer(ctrl)
+		mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).AnyTimes()
+		mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil).AnyTimes()
+		mockPulsarProducer.
+			EXPECT().
+			SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *armadacontext.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+				landed = append(landed, msg)
+				callback(pulsarutils.NewMessageId(len(landed)), msg, nil)
+			}).AnyTimes()
+		publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+		require.NoError(t, err)
+		return publisher
+	}
+	publish := func(publisher *PulsarPublisher, token LeaderToken) {
+		ctx := withLeaderEpoch(armadacontext.Background(), token.Epoch())
+		err := publisher.PublishMessages(ctx, []*armadaevents.EventSequence{
+			{JobSetName: "jobset", Events: []*armadaevents.EventSequence_Event{{}}},
+		}, func() bool { return true })
+		require.NoError(t, err)
+	}
+
+	// The old leader's second message is in flight when leadership changes, and lands after the new leader's first message.
+	oldLeader, newLeader := newPublisher(), newPublisher()
+	oldToken, newToken := NewLeaderTokenWithEpoch(1), NewLeaderTokenWithEpoch(2)
+	publish(oldLeader, oldToken)
+	publish(newLeader, newToken)
+	publish(oldLeader, oldToken)
+	publish(newLeader, newToken)
+	// Messages published without an epoch, e.g., by a standalone scheduler, aren't stamped.
+	publish(newLeader, NewLeaderToken())
+	require.Len(t, landed, 5)
+
+	ctrl := gomock.NewController(t)
+	filter, err := schedulers.NewLeaderEpochFilter(10)
+	require.NoError(t, err)
+	var epochs []uint64
+	var accepted []bool
+	for _, producerMsg := range landed {
+		es := &armadaevents.EventSequence{}
+		require.NoError(t, proto.Unmarshal(producerMsg.Payload, es))
+		epochs = append(epochs, es.LeaderEpoch)
+
+		msg := mocks.NewMockMessage(ctrl)
+		msg.EXPECT().Properties().Return(producerMsg.Properties).AnyTimes()
+		msg.EXPECT().Key().Return(producerMsg.Key).AnyTimes()
+		msg.EXPECT().ID().Return(nil).AnyTimes()
+		assert.Equal(t, es.LeaderEpoch, schedulers.LeaderEpochFromMsg(msg))
+		accepted = append(accepted, filter.Filter(msg))
+	}
+	assert.Equal(t, []uint64{1, 2, 1, 2, 0}, epochs)
+	assert.Equal(t, []bool{true, true, false, true, true}, accepted)
+	assert.NotContains(t, landed[4].Properties, schedulers.LeaderEpochPropertyName)
+}
+
+func TestPulsarPublisher_BoundedInFlightMessages(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+
+	// The broker is slow to acknowledge messages.
+	var numInFlight, maxNumInFlight, numSent atomic.Int64
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *armadacontext.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			n := numInFlight.Add(1)
+			for {
+				if current := maxNumInFlight.Load(); n <= current || maxNumInFlight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			id := numSent.Add(1)
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				numInFlight.Add(-1)
+				callback(pulsarutils.NewMessageId(int(id)), msg, nil)
+			}()
+		}).Times(6)
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+	publisher.EnableSendLimits(2, time.Second)
+	err = publisher.PublishMessages(ctx, eventSequencesForJobSets(6), func() bool { return true })
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), maxNumInFlight.Load())
+	highWaterMark, numSendLatencies := gatherPublisherMetrics(t, publisher)
+	assert.Equal(t, 2.0, highWaterMark)
+	assert.Equal(t, uint64(6), numSendLatencies)
+}
+
+func TestPulsarPublisher_PartialCompletion(t *testing.T) {
+	tests := map[string]struct {
+		maxInFlightMessages int
+		messageSendTimeout  time.Duration
+		pulsarSendTimeout   time.Duration
+		// Messages for job sets not in this set are never acknowledged.
+		acknowledgedJobSets  map[string]bool
+		expectedNumSent      int
+		expectedAcknowledged []string
+	}{
+		"Unacknowledged messages time out individually": {
+			messageSendTimeout:   50 * time.Millisecond,
+			pulsarSendTimeout:    5 * time.Second,
+			acknowledgedJobSets:  map[string]bool{"jobset0": true, "jobset2": true},
+			expectedNumSent:      4,
+			expectedAcknowledged: []string{"jobset0", "jobset2"},
+		},
+		"Messages aren't sent once the publish times out": {
+			maxInFlightMessages:  1,
+			pulsarSendTimeout:    50 * time.Millisecond,
+			acknowledgedJobSets:  map[string]bool{"jobset0": true, "jobset2": true},
+			expectedNumSent:      2,
+			expectedAcknowledged: []string{"jobset0"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+			mockPulsarProducer.
+				EXPECT().
+				SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ *armadacontext.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+					if tc.acknowledgedJobSets[msg.Key] {
+						callback(pulsarutils.NewMessageId(1), msg, nil)
+					}
+				}).Times(tc.expectedNumSent)
+
+			publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, tc.pulsarSendTimeout)
+			require.NoError(t, err)
+			publisher.EnableSendLimits(tc.maxInFlightMessages, tc.messageSendTimeout)
+			err = publisher.PublishMessages(ctx, eventSequencesForJobSets(4), func() bool { return true })
+
+			var incompleteErr *PublishIncompleteError
+			require.ErrorAs(t, err, &incompleteErr)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			acknowledged := make([]string, len(incompleteErr.Acknowledged))
+			for i, sequence := range incompleteErr.Acknowledged {
+				acknowledged[i] = sequence.JobSetName
+			}
+			assert.Equal(t, tc.expectedAcknowledged, acknowledged)
+			assert.Equal(t, 4-len(tc.expectedAcknowledged), incompleteErr.NumUnacknowledged)
+		})
+	}
+}
+
+// eventSequencesForJobSets returns n event sequences for distinct job sets, such that each is published as a separate message.
+func eventSequencesForJobSets(n int) []*armadaevents.EventSequence {
+	sequences := make([]*armadaevents.EventSequence, n)
+	for i := range sequences {
+		sequences[i] = &armadaevents.EventSequence{
+			JobSetName: fmt.Sprintf("jobset%d", i),
+			Events:     []*armadaevents.EventSequence_Event{{}},
+		}
+	}
+	return sequences
+}
+
+// gatherPublisherMetrics returns the in-flight high-water mark and the number of send latencies reported by publisher.
+func gatherPublisherMetrics(t *testing.T, publisher *PulsarPublisher) (float64, uint64) {
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(publisher))
+	metricFamilies, err := registry.Gather()
+	require.NoError(t, err)
+	var highWaterMark float64
+	var numSendLatencies uint64
+	for _, metricFamily := range metricFamilies {
+		switch metricFamily.GetName() {
+		case metrics.MetricPrefix + "scheduler_pulsar_in_flight_messages_high_water_mark":
+			highWaterMark = metricFamily.GetMetric()[0].GetGauge().GetValue()
+		case metrics.MetricPrefix + "scheduler_pulsar_send_latency_seconds":
+			numSendLatencies = metricFamily.GetMetric()[0].GetHistogram().GetSampleCount()
+		}
+	}
+	return highWaterMark, numSendLatencies
+}
@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// EnableQueueBacklogLimits causes new jobs that would take the number of queued jobs in their queue above its limit
+// to be failed instead of being scheduled.
+// The limit of each queue is provided by queueRepository, falling back to defaultMaxQueuedJobs if not set there.
+// A limit of zero means the number of queued jobs isn't limited.
+func (s *Scheduler) EnableQueueBacklogLimits(defaultMaxQueuedJobs uint32, queueRepository database.QueueRepository) {
+	s.queueBacklogLimitsEnabled = true
+	s.defaultMaxQueuedJobs = defaultMaxQueuedJobs
+	s.queueRepository = queueRepository
+	s.backlogLimitedJobs = make(map[string]uint32)
+}
+
+// applyQueueBacklogLimits records in s.backlogLimitedJobs any jobs of jsts not yet in the jobDb for which admitting them
+// would take the number of queued jobs in their queue above its limit. Jobs already in the jobDb are never recorded,
+// such that lowering the limit of a queue doesn't affect jobs already queued; for the same reason, it mustn't be called
+// for the sync initially loading the jobDb, e.g., after a restart, since all jobs loaded then were admitted before.
+// New jobs are admitted in the order of their serials in updatedJobs, i.e., in the order in which they were submitted.
+// The recorded jobs are still added to the jobDb, such that they're failed by whichever replica is leader,
+// even if this replica isn't or its cycle fails; see failBacklogLimitedJobs. Until then, they don't count towards
+// the number of queued jobs of their queue and aren't scheduled.
+func (s *Scheduler) applyQueueBacklogLimits(
+	ctx *armadacontext.Context,
+	txn *jobdb.Txn,
+	jsts []jobdb.JobStateTransitions,
+	updatedJobs []database.Job,
+) error {
+	var newJobs []*jobdb.Job
+	for _, jst := range jsts {
+		if isNewQueuedJob(txn, jst.Job) && !s.isCrossQueueGangJob(jst.Job.Id()) {
+			newJobs = append(newJobs, jst.Job)
+		}
+	}
+	if len(newJobs) == 0 {
+		return nil
+	}
+	serialByJobId := make(map[string]int64, len(updatedJobs))
+	for _, job := range updatedJobs {
+		serialByJobId[job.JobID] = job.Serial
+	}
+	slices.SortFunc(newJobs, func(a, b *jobdb.Job) bool {
+		return serialByJobId[a.Id()] < serialByJobId[b.Id()]
+	})
+	queues, err := s.queueRepository.GetAllQueues()
+	if err != nil {
+		return err
+	}
+	limitByQueue := make(map[string]uint32, len(queues))
+	for _, queue := range queues {
+		if queue.MaxQueuedJobs > 0 {
+			limitByQueue[queue.Name] = uint32(queue.MaxQueuedJobs)
+		}
+	}
+	// Jobs yet to be failed don't count towards the number of queued jobs of their queue.
+	numRejectedByQueue := make(map[string]int)
+	for _, jobIds := range [][]string{maps.Keys(s.backlogLimitedJobs), maps.Keys(s.crossQueueGangJobs)} {
+		for _, jobId := range jobIds {
+			if job := txn.GetById(jobId); job != nil && job.Queued() {
+				numRejectedByQueue[job.Queue()]++
+			}
+		}
+	}
+
+	// The count of each queue is taken from the jobDb, to which the jobs admitted so far this cycle are added.
+	numAdmittedByQueue := make(map[string]int)
+	for _, job := range newJobs {
+		limit, ok := limitByQueue[job.Queue()]
+		if !ok {
+			limit = s.defaultMaxQueuedJobs
+		}
+		numQueued := txn.NumQueuedJobs(job.Queue()) - numRejectedByQueue[job.Queue()] + numAdmittedByQueue[job.Queue()]
+		if limit == 0 || numQueued < int(limit) {
+			numAdmittedByQueue[job.Queue()]++
+			continue
+		}
+		ctx.Warnf("failing job %s as queue %s already has %d queued jobs and its limit is %d", job.Id(), job.Queue(), numQueued, limit)
+		s.backlogLimitedJobs[job.Id()] = limit
+		s.metrics.ReportQueueBacklogLimitedJob(job.Queue())
+	}
+	return nil
+}
+
+// pruneBacklogLimitedJobs forgets the jobs recorded in s.backlogLimitedJobs that are no longer in the jobDb,
+// e.g., since the leader failed them.
+func (s *Scheduler) pruneBacklogLimitedJobs(txn *jobdb.Txn) {
+	for jobId := range s.backlogLimitedJobs {
+		if txn.GetById(jobId) == nil {
+			delete(s.backlogLimitedJobs, jobId)
+		}
+	}
+}
+
+// isBacklogLimited returns true if job was found to exceed the backlog limit of its queue and is yet to be failed.
+func (s *Scheduler) isBacklogLimited(jobId string) bool {
+	_, ok := s.backlogLimitedJobs[jobId]
+	return ok
+}
+
+// isNewQueuedJob returns true if job is queued and isn't yet in the jobDb.
+func isNewQueuedJob(txn *jobdb.Txn, job *jobdb.Job) bool {
+	return job != nil && job.Queued() && !job.InTerminalState() && txn.GetById(job.Id()) == nil
+}
+
+// failBacklogLimitedJobs fails the queued jobs recorded in s.backlogLimitedJobs in txn and returns the events to
+// publish, together with the ids of the jobs failed, which should be passed to resolveBacklogLimitedJobs once txn
+// is committed.
+func (s *Scheduler) failBacklogLimitedJobs(txn *jobdb.Txn) ([]*armadaevents.EventSequence, []string, error) {
+	events := make([]*armadaevents.EventSequence, 0, len(s.backlogLimitedJobs))
+	jobsToFail := make([]*jobdb.Job, 0, len(s.backlogLimitedJobs))
+	for jobId, limit := range s.backlogLimitedJobs {
+		job := txn.GetById(jobId)
+		if job == nil || !job.Queued() || job.InTerminalState() {
+			continue
+		}
+		protoJobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
+		if err != nil {
+			return nil, nil, err
+		}
+		jobsToFail = append(jobsToFail, job.WithQueued(false).WithFailed(true))
+		events = append(events, &armadaevents.EventSequence{
+			Queue:      job.Queue(),
+			JobSetName: job.Jobset(),
+			Events: []*armadaevents.EventSequence_Event{
+				{
+					Created: s.now(),
+					Event: &armadaevents.EventSequence_Event_JobErrors{
+						JobErrors: &armadaevents.JobErrors{
+							JobId: protoJobId,
+							Errors: []*armadaevents.Error{
+								{
+									Terminal: true,
+									Reason: &armadaevents.Error_QueueBacklogLimitReached{
+										QueueBacklogLimitReached: &armadaevents.QueueBacklogLimitReached{
+											Queue: job.Queue(),
+											Limit: limit,
+											Message: fmt.Sprintf(
+												"Queue backlog limit reached: queue %s may have at most %d queued jobs",
+												job.Queue(), limit,
+											),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if err := txn.Upsert(jobsToFail); err != nil {
+		return nil, nil, err
+	}
+	return events, util.Map(jobsToFail, func(job *jobdb.Job) string { return job.Id() }), nil
+}
+
+// resolveBacklogLimitedJobs forgets the jobs failed by a committed cycle.
+func (s *Scheduler) resolveBacklogLimitedJobs(jobIds []string) {
+	for _, jobId := range jobIds {
+		delete(s.backlogLimitedJobs, jobId)
+	}
+}
@@ -0,0 +1,240 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_QueueBacklogLimits(t *testing.T) {
+	tests := map[string]struct {
+		// Limit of the queue in the queue repository. If zero, the default limit applies.
+		queueLimit          int64
+		defaultLimit        uint32
+		numJobsByCycle      []int
+		queueLimitByCycle   []int64
+		expectedNumQueued   int
+		expectedNumRejected int
+	}{
+		"below limit": {
+			queueLimit:        3,
+			numJobsByCycle:    []int{1, 1},
+			expectedNumQueued: 2,
+		},
+		"at limit": {
+			queueLimit:        3,
+			numJobsByCycle:    []int{2, 1},
+			expectedNumQueued: 3,
+		},
+		"above limit": {
+			queueLimit:          3,
+			numJobsByCycle:      []int{2, 2},
+			expectedNumQueued:   3,
+			expectedNumRejected: 1,
+		},
+		"above limit within a single cycle": {
+			queueLimit:          3,
+			numJobsByCycle:      []int{5, 0},
+			expectedNumQueued:   3,
+			expectedNumRejected: 2,
+		},
+		"default limit": {
+			defaultLimit:        2,
+			numJobsByCycle:      []int{1, 2},
+			expectedNumQueued:   2,
+			expectedNumRejected: 1,
+		},
+		"queue limit takes precedence over default limit": {
+			queueLimit:        3,
+			defaultLimit:      1,
+			numJobsByCycle:    []int{2, 1},
+			expectedNumQueued: 3,
+		},
+		"no limit": {
+			numJobsByCycle:    []int{10, 10},
+			expectedNumQueued: 20,
+		},
+		"lowering the limit doesn't affect jobs already queued": {
+			queueLimit:          3,
+			queueLimitByCycle:   []int64{3, 1},
+			numJobsByCycle:      []int{3, 1},
+			expectedNumQueued:   3,
+			expectedNumRejected: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			queueRepository := &testQueueRepository{queues: []*database.Queue{{Name: "testQueue", Weight: 1, MaxQueuedJobs: tc.queueLimit}}}
+			jobRepo := &testJobRepository{}
+			publisher := &testPublisher{}
+			sched := newTestSchedulerWithPublisher(t, jobRepo, publisher)
+			sched.EnableQueueBacklogLimits(tc.defaultLimit, queueRepository)
+			loadInitialJobDb(t, ctx, sched, publisher)
+
+			serial := int64(0)
+			rejectedJobIds := make(map[string]bool)
+			for i, numJobs := range tc.numJobsByCycle {
+				if tc.queueLimitByCycle != nil {
+					queueRepository.queues[0].MaxQueuedJobs = tc.queueLimitByCycle[i]
+				}
+				jobRepo.updatedJobs = make([]database.Job, numJobs)
+				for j := range jobRepo.updatedJobs {
+					serial++
+					jobRepo.updatedJobs[j] = queuedJobRepoJob(serial)
+				}
+				for jobId := range backlogLimitedJobIds(t, runCycleCollectingEvents(t, ctx, sched, publisher, true)) {
+					rejectedJobIds[jobId] = true
+				}
+			}
+
+			txn := sched.jobDb.ReadTxn()
+			assert.Equal(t, tc.expectedNumQueued, txn.NumQueuedJobs("testQueue"))
+			assert.Equal(t, tc.expectedNumRejected, len(rejectedJobIds))
+			for jobId := range rejectedJobIds {
+				job := txn.GetById(jobId)
+				require.NotNil(t, job)
+				assert.True(t, job.Failed())
+			}
+		})
+	}
+}
+
+func TestScheduler_QueueBacklogLimits_FailedByLeader(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	queueRepository := &testQueueRepository{queues: []*database.Queue{{Name: "testQueue", Weight: 1, MaxQueuedJobs: 1}}}
+	jobRepo := &testJobRepository{}
+	publisher := &testPublisher{}
+	sched := newTestSchedulerWithPublisher(t, jobRepo, publisher)
+	sched.EnableQueueBacklogLimits(0, queueRepository)
+	loadInitialJobDb(t, ctx, sched, publisher)
+
+	jobRepo.updatedJobs = []database.Job{queuedJobRepoJob(1), queuedJobRepoJob(2)}
+	limitedJobId := jobRepo.updatedJobs[1].JobID
+
+	// Followers keep the job in the jobDb, such that it's failed once they become leader.
+	assert.Empty(t, backlogLimitedJobIds(t, runCycleCollectingEvents(t, ctx, sched, publisher, false)))
+	jobRepo.updatedJobs = nil
+	assert.Empty(t, backlogLimitedJobIds(t, runCycleCollectingEvents(t, ctx, sched, publisher, false)))
+	job := sched.jobDb.ReadTxn().GetById(limitedJobId)
+	require.NotNil(t, job)
+	assert.True(t, job.Queued())
+	assert.Equal(t, 1, sched.jobDb.ReadTxn().NumQueuedJobs("testQueue")-len(sched.backlogLimitedJobs))
+
+	// If publishing fails, the job is failed again by the next cycle.
+	publisher.shouldError = true
+	_, err := sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.Error(t, err)
+	assert.True(t, sched.jobDb.ReadTxn().GetById(limitedJobId).Queued())
+	publisher.shouldError = false
+
+	assert.Equal(t, map[string]bool{limitedJobId: true}, backlogLimitedJobIds(t, runCycleCollectingEvents(t, ctx, sched, publisher, true)))
+	assert.True(t, sched.jobDb.ReadTxn().GetById(limitedJobId).Failed())
+	assert.Empty(t, sched.backlogLimitedJobs)
+}
+
+func TestScheduler_QueueBacklogLimits_Restart(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	// The limit of the queue was lowered below the number of jobs already queued before the restart.
+	queueRepository := &testQueueRepository{queues: []*database.Queue{{Name: "testQueue", Weight: 1, MaxQueuedJobs: 1}}}
+	jobRepo := &testJobRepository{}
+	publisher := &testPublisher{}
+	sched := newTestSchedulerWithPublisher(t, jobRepo, publisher)
+	sched.EnableQueueBacklogLimits(0, queueRepository)
+
+	// Jobs loaded initially were admitted before the restart, so are unaffected.
+	jobRepo.updatedJobs = []database.Job{queuedJobRepoJob(1), queuedJobRepoJob(2), queuedJobRepoJob(3)}
+	assert.Empty(t, backlogLimitedJobIds(t, runCycleCollectingEvents(t, ctx, sched, publisher, true)))
+	assert.Equal(t, 3, sched.jobDb.ReadTxn().NumQueuedJobs("testQueue"))
+	assert.Empty(t, sched.backlogLimitedJobs)
+
+	// Jobs submitted after the restart are subject to the limit.
+	jobRepo.updatedJobs = []database.Job{queuedJobRepoJob(4)}
+	limitedJobId := jobRepo.updatedJobs[0].JobID
+	assert.Equal(t, map[string]bool{limitedJobId: true}, backlogLimitedJobIds(t, runCycleCollectingEvents(t, ctx, sched, publisher, true)))
+	assert.Equal(t, 3, sched.jobDb.ReadTxn().NumQueuedJobs("testQueue"))
+}
+
+// loadInitialJobDb runs a cycle loading the jobDb from an empty job repository, as on startup,
+// such that jobs in later cycles are new.
+func loadInitialJobDb(t *testing.T, ctx *armadacontext.Context, sched *Scheduler, publisher *testPublisher) {
+	runCycleCollectingEvents(t, ctx, sched, publisher, true)
+}
+
+func newTestSchedulerWithPublisher(t *testing.T, jobRepo *testJobRepository, publisher *testPublisher) *Scheduler {
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		publisher,
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	return sched
+}
+
+// runCycleCollectingEvents runs a cycle, as leader if leader is true, and returns the events published.
+func runCycleCollectingEvents(t *testing.T, ctx *armadacontext.Context, sched *Scheduler, publisher *testPublisher, leader bool) []*armadaevents.EventSequence {
+	publisher.Reset()
+	token := InvalidLeaderToken()
+	if leader {
+		token = sched.leaderController.GetToken()
+	}
+	_, err := sched.cycle(ctx, false, token, true)
+	require.NoError(t, err)
+	return publisher.events
+}
+
+// backlogLimitedJobIds returns the ids of the jobs failed by eventSequences because their queue reached its backlog limit.
+func backlogLimitedJobIds(t *testing.T, eventSequences []*armadaevents.EventSequence) map[string]bool {
+	jobIds := make(map[string]bool)
+	for _, eventSequence := range eventSequences {
+		for _, event := range eventSequence.Events {
+			jobErrors := event.GetJobErrors()
+			if jobErrors == nil {
+				continue
+			}
+			require.Len(t, jobErrors.Errors, 1)
+			assert.True(t, jobErrors.Errors[0].Terminal)
+			require.NotNil(t, jobErrors.Errors[0].GetQueueBacklogLimitReached())
+			assert.Equal(t, "testQueue", jobErrors.Errors[0].GetQueueBacklogLimitReached().GetQueue())
+			jobId, err := armadaevents.UlidStringFromProtoUuid(jobErrors.JobId)
+			require.NoError(t, err)
+			jobIds[jobId] = true
+		}
+	}
+	return jobIds
+}
+
+func queuedJobRepoJob(serial int64) database.Job {
+	return database.Job{
+		JobID:          util.NewULID(),
+		JobSet:         "testJobset",
+		Queue:          "testQueue",
+		Queued:         true,
+		QueuedVersion:  0,
+		SchedulingInfo: schedulingInfoBytes,
+		Serial:         serial,
+	}
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"fmt"
+
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// EnableQueuePriorityCaps causes jobs submitted or reprioritised above the maximum job priority of their queue,
+// as provided by queueRepository, to be given that priority instead. Since jobs with a smaller priority value are
+// scheduled first, this means jobs with a priority value below the cap have it raised to the cap.
+// The requested priority of clamped jobs is retained, such that it's applied once the cap is lifted.
+func (s *Scheduler) EnableQueuePriorityCaps(config schedulerconfig.QueuePriorityCapsConfig, queueRepository database.QueueRepository) {
+	s.queuePriorityCapsConfig = &config
+	s.queueRepository = queueRepository
+}
+
+// updateMaxJobPriorities reloads the maximum job priority of each queue from the queue repository and returns
+// the jobs in txn of any queue the cap of which has changed, excluding those in updatedJobs.
+// Since the priority of a job is only reconsidered when it's updated, such jobs must be treated as updated.
+func (s *Scheduler) updateMaxJobPriorities(txn *jobdb.Txn, updatedJobs []*jobdb.Job) ([]*jobdb.Job, error) {
+	queues, err := s.queueRepository.GetAllQueues()
+	if err != nil {
+		return nil, err
+	}
+	maxJobPriorityByQueue := make(map[string]uint32, len(queues))
+	for _, queue := range queues {
+		if queue.MaxJobPriority > 0 {
+			maxJobPriorityByQueue[queue.Name] = uint32(queue.MaxJobPriority)
+		}
+	}
+	changedQueues := make(map[string]bool)
+	for queue, maxJobPriority := range maxJobPriorityByQueue {
+		if previous, ok := s.maxJobPriorityByQueue[queue]; !ok || previous != maxJobPriority {
+			changedQueues[queue] = true
+		}
+	}
+	for queue := range s.maxJobPriorityByQueue {
+		if _, ok := maxJobPriorityByQueue[queue]; !ok {
+			changedQueues[queue] = true
+		}
+	}
+	s.maxJobPriorityByQueue = maxJobPriorityByQueue
+	if len(changedQueues) == 0 {
+		return nil, nil
+	}
+
+	isUpdated := make(map[string]bool, len(updatedJobs))
+	for _, job := range updatedJobs {
+		isUpdated[job.Id()] = true
+	}
+	var jobs []*jobdb.Job
+	for _, job := range txn.GetAll() {
+		if changedQueues[job.Queue()] && !isUpdated[job.Id()] && !job.InTerminalState() {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// effectivePriority returns the priority job should be given, i.e., its requested priority capped at the maximum job
+// priority of its queue, and true if the requested priority exceeds the cap.
+func (s *Scheduler) effectivePriority(job *jobdb.Job) (uint32, bool) {
+	maxJobPriority, ok := s.maxJobPriorityByQueue[job.Queue()]
+	if !ok || job.RequestedPriority() >= maxJobPriority {
+		return job.RequestedPriority(), false
+	}
+	return maxJobPriority, true
+}
+
+// priorityClampMessage returns a message explaining why job was given priority instead of its requested priority.
+func priorityClampMessage(job *jobdb.Job, priority uint32) string {
+	return fmt.Sprintf(
+		"Requested priority %d exceeds the maximum job priority of queue %s; the job was given priority %d instead",
+		job.RequestedPriority(), job.Queue(), priority,
+	)
+}
@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestScheduler_QueuePriorityCaps(t *testing.T) {
+	tests := map[string]struct {
+		warning schedulerconfig.PriorityClampWarning
+		// Maximum job priority of the queue and priority requested for the job in each cycle.
+		maxJobPriorityByCycle    []int64
+		requestedPriorityByCycle []int64
+		// Priority of the job and whether it's clamped after each cycle.
+		expectedPriorityByCycle []uint32
+		expectedClampedByCycle  []bool
+		// Whether a ReprioritisedJob event is expected to be published in each cycle.
+		expectedReprioritisedByCycle []bool
+	}{
+		"submitted below the cap": {
+			maxJobPriorityByCycle:        []int64{5},
+			requestedPriorityByCycle:     []int64{10},
+			expectedPriorityByCycle:      []uint32{10},
+			expectedClampedByCycle:       []bool{false},
+			expectedReprioritisedByCycle: []bool{false},
+		},
+		"submitted above the cap": {
+			maxJobPriorityByCycle:        []int64{5},
+			requestedPriorityByCycle:     []int64{2},
+			expectedPriorityByCycle:      []uint32{5},
+			expectedClampedByCycle:       []bool{true},
+			expectedReprioritisedByCycle: []bool{true},
+		},
+		"submitted above the cap with event warnings": {
+			warning:                      schedulerconfig.PriorityClampWarningEvent,
+			maxJobPriorityByCycle:        []int64{5},
+			requestedPriorityByCycle:     []int64{2},
+			expectedPriorityByCycle:      []uint32{5},
+			expectedClampedByCycle:       []bool{true},
+			expectedReprioritisedByCycle: []bool{true},
+		},
+		"no cap": {
+			maxJobPriorityByCycle:        []int64{0},
+			requestedPriorityByCycle:     []int64{2},
+			expectedPriorityByCycle:      []uint32{2},
+			expectedClampedByCycle:       []bool{false},
+			expectedReprioritisedByCycle: []bool{false},
+		},
+		"cap lowered after the job was queued": {
+			maxJobPriorityByCycle:        []int64{1, 5, 5},
+			requestedPriorityByCycle:     []int64{2, 2, 2},
+			expectedPriorityByCycle:      []uint32{2, 5, 5},
+			expectedClampedByCycle:       []bool{false, true, true},
+			expectedReprioritisedByCycle: []bool{false, true, false},
+		},
+		"cap set after the job was queued": {
+			maxJobPriorityByCycle:        []int64{0, 5},
+			requestedPriorityByCycle:     []int64{2, 2},
+			expectedPriorityByCycle:      []uint32{2, 5},
+			expectedClampedByCycle:       []bool{false, true},
+			expectedReprioritisedByCycle: []bool{false, true},
+		},
+		"cap removed after the job was clamped": {
+			maxJobPriorityByCycle:        []int64{5, 0},
+			requestedPriorityByCycle:     []int64{2, 2},
+			expectedPriorityByCycle:      []uint32{5, 2},
+			expectedClampedByCycle:       []bool{true, false},
+			expectedReprioritisedByCycle: []bool{true, true},
+		},
+		"reprioritised above the cap": {
+			maxJobPriorityByCycle:        []int64{5, 5},
+			requestedPriorityByCycle:     []int64{10, 1},
+			expectedPriorityByCycle:      []uint32{10, 5},
+			expectedClampedByCycle:       []bool{false, true},
+			expectedReprioritisedByCycle: []bool{false, true},
+		},
+		"reprioritised below the cap after being clamped": {
+			maxJobPriorityByCycle:        []int64{5, 5},
+			requestedPriorityByCycle:     []int64{2, 10},
+			expectedPriorityByCycle:      []uint32{5, 10},
+			expectedClampedByCycle:       []bool{true, false},
+			expectedReprioritisedByCycle: []bool{true, true},
+		},
+		"reprioritised to the cap after being clamped": {
+			maxJobPriorityByCycle:        []int64{5, 5},
+			requestedPriorityByCycle:     []int64{2, 5},
+			expectedPriorityByCycle:      []uint32{5, 5},
+			expectedClampedByCycle:       []bool{true, false},
+			expectedReprioritisedByCycle: []bool{true, false},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			queueRepository := &testQueueRepository{queues: []*database.Queue{{Name: "testQueue", Weight: 1}}}
+			jobRepo := &testJobRepository{}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				jobRepo,
+				&testExecutorRepository{},
+				&testSchedulingAlgo{},
+				NewStandaloneLeaderController(),
+				&testPublisher{},
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.EnableQueuePriorityCaps(schedulerconfig.QueuePriorityCapsConfig{Enabled: true, Warning: tc.warning}, queueRepository)
+
+			dbJob := queuedJobRepoJob(1)
+			for i, requestedPriority := range tc.requestedPriorityByCycle {
+				queueRepository.queues[0].MaxJobPriority = tc.maxJobPriorityByCycle[i]
+				jobRepo.updatedJobs = nil
+				if i == 0 || requestedPriority != dbJob.Priority {
+					dbJob.Priority = requestedPriority
+					dbJob.Serial = int64(i + 1)
+					jobRepo.updatedJobs = []database.Job{dbJob}
+				}
+				updatedJobs, _, _, err := sched.syncState(ctx)
+				require.NoError(t, err)
+
+				txn := sched.jobDb.WriteTxn()
+				eventSequences, err := sched.generateUpdateMessages(ctx, txn, updatedJobs, nil)
+				require.NoError(t, err)
+				txn.Commit()
+
+				job := sched.jobDb.ReadTxn().GetById(dbJob.JobID)
+				require.NotNil(t, job)
+				assert.Equal(t, uint32(requestedPriority), job.RequestedPriority(), "cycle %d", i)
+				assert.Equal(t, tc.expectedPriorityByCycle[i], job.Priority(), "cycle %d", i)
+				assert.Equal(t, tc.expectedClampedByCycle[i], job.PriorityClamped(), "cycle %d", i)
+
+				numReprioritised := 0
+				for _, eventSequence := range eventSequences {
+					for _, event := range eventSequence.Events {
+						reprioritisedJob := event.GetReprioritisedJob()
+						require.NotNil(t, reprioritisedJob)
+						numReprioritised++
+						assert.Equal(t, tc.expectedPriorityByCycle[i], reprioritisedJob.Priority, "cycle %d", i)
+						if tc.warning == schedulerconfig.PriorityClampWarningEvent && tc.expectedClampedByCycle[i] {
+							assert.Equal(t, uint32(requestedPriority), reprioritisedJob.RequestedPriority, "cycle %d", i)
+							assert.NotEmpty(t, reprioritisedJob.ClampMessage, "cycle %d", i)
+						} else {
+							assert.Empty(t, reprioritisedJob.ClampMessage, "cycle %d", i)
+						}
+					}
+				}
+				if tc.expectedReprioritisedByCycle[i] {
+					assert.Equal(t, 1, numReprioritised, "cycle %d", i)
+				} else {
+					assert.Equal(t, 0, numReprioritised, "cycle %d", i)
+				}
+			}
+		})
+	}
+}
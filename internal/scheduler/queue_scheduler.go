@@ -8,6 +8,7 @@ import (
 	"github.com/armadaproject/armada/internal/armada/configuration"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	schedulerconstraints "github.com/armadaproject/armada/internal/scheduler/constraints"
@@ -23,6 +24,8 @@ type QueueScheduler struct {
 	schedulingContext     *schedulercontext.SchedulingContext
 	candidateGangIterator *CandidateGangIterator
 	gangScheduler         *GangScheduler
+	// Used to measure how long evaluating the candidate jobs of each queue takes.
+	clock clock.Clock
 }
 
 func NewQueueScheduler(
@@ -42,16 +45,26 @@ func NewQueueScheduler(
 	}
 	gangIteratorsByQueue := make(map[string]*QueuedGangIterator)
 	for queue, it := range jobIteratorByQueue {
-		gangIteratorsByQueue[queue] = NewQueuedGangIterator(sctx, it, constraints.MaxQueueLookback, true)
+		gangIt := NewQueuedGangIterator(sctx, it, constraints.MaxQueueLookback, true)
+		if constraints.EnableGangPriorityInheritance {
+			gangIt.EnableGangPriorityInheritance()
+		}
+		gangIteratorsByQueue[queue] = gangIt
 	}
 	candidateGangIterator, err := NewCandidateGangIterator(sctx, sctx.FairnessCostProvider, gangIteratorsByQueue)
 	if err != nil {
 		return nil, err
 	}
+	if len(constraints.ReservedResourcesByQueue) > 0 {
+		if err := candidateGangIterator.PrioritiseReservations(constraints.ReservedResourcesByQueue); err != nil {
+			return nil, err
+		}
+	}
 	return &QueueScheduler{
 		schedulingContext:     sctx,
 		candidateGangIterator: candidateGangIterator,
 		gangScheduler:         gangScheduler,
+		clock:                 clock.RealClock{},
 	}, nil
 }
 
@@ -89,7 +102,12 @@ func (sch *QueueScheduler) Schedule(ctx *armadacontext.Context) (*SchedulerResul
 			return nil, err
 		default:
 		}
-		if ok, unschedulableReason, err := sch.gangScheduler.Schedule(ctx, gctx); err != nil {
+		start := sch.clock.Now()
+		ok, unschedulableReason, err := sch.gangScheduler.Schedule(ctx, gctx)
+		if qctx := sch.schedulingContext.QueueSchedulingContexts[gctx.Queue]; qctx != nil {
+			qctx.SchedulingDuration += sch.clock.Since(start)
+		}
+		if err != nil {
 			return nil, err
 		} else if ok {
 			// We scheduled the minimum number of gang jobs required.
@@ -132,11 +150,17 @@ func (sch *QueueScheduler) Schedule(ctx *armadacontext.Context) (*SchedulerResul
 	if len(scheduledJobs) != len(nodeIdByJobId) {
 		return nil, errors.Errorf("only %d out of %d jobs mapped to a node", len(nodeIdByJobId), len(scheduledJobs))
 	}
+	placementByJobId := make(map[string]Placement, len(scheduledJobs))
+	if err := addPlacementsFromNodeDb(
+		placementByJobId, sch.gangScheduler.nodeDb, sch.schedulingContext.Pool, nodeIdByJobId, scheduledJobs,
+	); err != nil {
+		return nil, err
+	}
 	return &SchedulerResult{
 		PreemptedJobs:                nil,
 		ScheduledJobs:                scheduledJobs,
 		FailedJobs:                   failedJobs,
-		NodeIdByJobId:                nodeIdByJobId,
+		PlacementByJobId:             placementByJobId,
 		AdditionalAnnotationsByJobId: additionalAnnotationsByJobId,
 		SchedulingContexts:           []*schedulercontext.SchedulingContext{sch.schedulingContext},
 	}, nil
@@ -156,7 +180,13 @@ type QueuedGangIterator struct {
 	skipKnownUnschedulableJobs bool
 	// Number of jobs we have seen so far.
 	jobsSeen uint
-	next     *schedulercontext.GangSchedulingContext
+	// If true, gangs are yielded as soon as their highest-priority member would have been
+	// and all members inherit the priority of that member.
+	enableGangPriorityInheritance bool
+	// Jobs read from queuedJobsIterator while looking ahead for the remaining members of a gang.
+	// These are processed, in order, before reading any more jobs from queuedJobsIterator.
+	lookaheadBuffer []*schedulercontext.JobSchedulingContext
+	next            *schedulercontext.GangSchedulingContext
 }
 
 func NewQueuedGangIterator(sctx *schedulercontext.SchedulingContext, it JobIterator, maxLookback uint, skipKnownUnschedulableJobs bool) *QueuedGangIterator {
@@ -185,55 +215,51 @@ func (it *QueuedGangIterator) Clear() error {
 	return nil
 }
 
+func (it *QueuedGangIterator) EnableGangPriorityInheritance() {
+	it.enableGangPriorityInheritance = true
+}
+
 func (it *QueuedGangIterator) Peek() (*schedulercontext.GangSchedulingContext, error) {
-	if it.hitLookbackLimit() {
-		return nil, nil
-	}
 	if it.next != nil {
 		return it.next, nil
 	}
+	// Jobs in the lookahead buffer were counted towards the lookback limit when read and are within it,
+	// so are still yielded once the limit is reached.
+	if len(it.lookaheadBuffer) == 0 && it.hitLookbackLimit() {
+		return nil, nil
+	}
 
 	// Get one job at a time from the underlying iterator until we either
 	// 1. get a job that isn't part of a gang, in which case we yield it immediately, or
 	// 2. get the final job in a gang, in which case we yield the entire gang.
 	for {
-		jctx, err := it.queuedJobsIterator.Next()
+		jctx, err := it.nextJobSchedulingContext()
 		if err != nil {
 			return nil, err
-		} else if jctx == nil || reflect.ValueOf(jctx).IsNil() {
+		} else if jctx == nil {
 			return nil, nil
 		}
-
-		// Queue lookback limits. Rescheduled jobs don't count towards the limit.
-		if !jctx.IsEvicted {
-			it.jobsSeen++
-		}
-		if it.hitLookbackLimit() {
-			return nil, nil
-		}
-
-		// Skip this job if it's known to be unschedulable.
-		if it.skipKnownUnschedulableJobs && len(it.schedulingContext.UnfeasibleSchedulingKeys) > 0 {
-			schedulingKey, ok := jctx.SchedulingKey()
-			if ok && schedulingKey != schedulerobjects.EmptySchedulingKey {
-				if unsuccessfulJctx, ok := it.schedulingContext.UnfeasibleSchedulingKeys[schedulingKey]; ok {
-					// Since jctx would fail to schedule for the same reason as unsuccessfulJctx,
-					// set the unschedulable reason and pctx equal to that of unsuccessfulJctx.
-					jctx.UnschedulableReason = unsuccessfulJctx.UnschedulableReason
-					jctx.PodSchedulingContext = unsuccessfulJctx.PodSchedulingContext
-					if _, err := it.schedulingContext.AddJobSchedulingContext(jctx); err != nil {
-						return nil, err
-					}
-					continue
-				}
-			}
+		if skip, err := it.skipIfKnownUnschedulable(jctx); err != nil {
+			return nil, err
+		} else if skip {
+			continue
 		}
 		if jctx.GangCardinality > 1 {
 			gang := it.jctxsByGangId[jctx.GangId]
 			gang = append(gang, jctx)
 			it.jctxsByGangId[jctx.GangId] = gang
+			if len(gang) < jctx.GangCardinality && len(gang) == 1 && it.enableGangPriorityInheritance {
+				// This is the highest-priority member of the gang, since jobs are received in priority order.
+				// Look ahead for the remaining members so the gang can be yielded at the position of this job.
+				if gang, err = it.lookaheadForGang(jctx.GangId); err != nil {
+					return nil, err
+				}
+			}
 			if len(gang) == jctx.GangCardinality {
 				delete(it.jctxsByGangId, jctx.GangId)
+				if it.enableGangPriorityInheritance {
+					inheritGangPriority(gang)
+				}
 				it.next = schedulercontext.NewGangSchedulingContext(gang)
 				return it.next, nil
 			}
@@ -244,6 +270,130 @@ func (it *QueuedGangIterator) Peek() (*schedulercontext.GangSchedulingContext, e
 	}
 }
 
+// nextJobSchedulingContext returns the next job to consider, taking jobs from the lookahead buffer before the
+// underlying iterator. Returns nil if there are no more jobs or if the lookback limit has been reached.
+// Each job counts towards the lookback limit once, when read from the underlying iterator.
+func (it *QueuedGangIterator) nextJobSchedulingContext() (*schedulercontext.JobSchedulingContext, error) {
+	if len(it.lookaheadBuffer) > 0 {
+		jctx := it.lookaheadBuffer[0]
+		it.lookaheadBuffer = it.lookaheadBuffer[1:]
+		return jctx, nil
+	}
+	jctx, err := it.queuedJobsIterator.Next()
+	if err != nil {
+		return nil, err
+	} else if jctx == nil || reflect.ValueOf(jctx).IsNil() {
+		return nil, nil
+	}
+
+	// Queue lookback limits. Rescheduled jobs don't count towards the limit.
+	if !jctx.IsEvicted {
+		it.jobsSeen++
+	}
+	if it.hitLookbackLimit() {
+		return nil, nil
+	}
+	return jctx, nil
+}
+
+// skipIfKnownUnschedulable returns true if jctx is known to be unschedulable,
+// in which case it's added to the scheduling context as such.
+func (it *QueuedGangIterator) skipIfKnownUnschedulable(jctx *schedulercontext.JobSchedulingContext) (bool, error) {
+	if !it.skipKnownUnschedulableJobs || len(it.schedulingContext.UnfeasibleSchedulingKeys) == 0 {
+		return false, nil
+	}
+	if jctx.SchedulingKeySkippingDisabled() {
+		return false, nil
+	}
+	schedulingKey, ok := jctx.SchedulingKey()
+	if !ok || schedulingKey == schedulerobjects.EmptySchedulingKey {
+		return false, nil
+	}
+	unsuccessfulJctx, ok := it.schedulingContext.UnfeasibleSchedulingKeys[schedulingKey]
+	if !ok {
+		return false, nil
+	}
+	// Since jctx would fail to schedule for the same reason as unsuccessfulJctx,
+	// set the unschedulable reason and pctx equal to that of unsuccessfulJctx.
+	jctx.UnschedulableReason = unsuccessfulJctx.UnschedulableReason
+	jctx.PodSchedulingContext = unsuccessfulJctx.PodSchedulingContext
+	jctx.IdenticalToUnschedulableJobId = unsuccessfulJctx.JobId
+	if _, err := it.schedulingContext.AddJobSchedulingContext(jctx); err != nil {
+		return false, err
+	}
+	if qctx := it.schedulingContext.QueueSchedulingContexts[jctx.Job.GetQueue()]; qctx != nil {
+		qctx.NumSchedulingKeySkippedJobs++
+	}
+	return true, nil
+}
+
+// lookaheadForGang collects the remaining members of the gang with the given id,
+// first from the lookahead buffer and then from the underlying iterator.
+// Jobs not part of this gang are appended to the lookahead buffer.
+// Returns the members of the gang found, which may be fewer than its cardinality if the underlying iterator
+// is exhausted or the lookback limit is reached before all members are found.
+func (it *QueuedGangIterator) lookaheadForGang(gangId string) ([]*schedulercontext.JobSchedulingContext, error) {
+	gang := it.jctxsByGangId[gangId]
+	cardinality := gang[0].GangCardinality
+	addToGang := func(jctx *schedulercontext.JobSchedulingContext) error {
+		if skip, err := it.skipIfKnownUnschedulable(jctx); err != nil {
+			return err
+		} else if !skip {
+			gang = append(gang, jctx)
+		}
+		return nil
+	}
+
+	buffer := it.lookaheadBuffer
+	it.lookaheadBuffer = make([]*schedulercontext.JobSchedulingContext, 0, len(buffer))
+	for _, jctx := range buffer {
+		if jctx.GangId == gangId && jctx.GangCardinality > 1 {
+			if err := addToGang(jctx); err != nil {
+				return nil, err
+			}
+		} else {
+			it.lookaheadBuffer = append(it.lookaheadBuffer, jctx)
+		}
+	}
+	for len(gang) < cardinality && !it.hitLookbackLimit() {
+		jctx, err := it.queuedJobsIterator.Next()
+		if err != nil {
+			return nil, err
+		} else if jctx == nil || reflect.ValueOf(jctx).IsNil() {
+			break
+		}
+		if !jctx.IsEvicted {
+			it.jobsSeen++
+		}
+		if it.hitLookbackLimit() {
+			break
+		}
+		if jctx.GangId == gangId && jctx.GangCardinality > 1 {
+			if err := addToGang(jctx); err != nil {
+				return nil, err
+			}
+		} else {
+			it.lookaheadBuffer = append(it.lookaheadBuffer, jctx)
+		}
+	}
+	it.jctxsByGangId[gangId] = gang
+	return gang, nil
+}
+
+// inheritGangPriority sets the priority class priority of each member of the gang
+// to the highest priority class priority of any member.
+func inheritGangPriority(gang []*schedulercontext.JobSchedulingContext) {
+	priority := gang[0].PodRequirements.Priority
+	for _, jctx := range gang[1:] {
+		if jctx.PodRequirements.Priority > priority {
+			priority = jctx.PodRequirements.Priority
+		}
+	}
+	for _, jctx := range gang {
+		jctx.InheritGangPriority(priority)
+	}
+}
+
 func (it *QueuedGangIterator) hitLookbackLimit() bool {
 	if it.maxLookback == 0 {
 		return false
@@ -262,6 +412,9 @@ type CandidateGangIterator struct {
 	// If, e.g., onlyYieldEvictedByQueue["A"] is true,
 	// this iterator only yields gangs where all jobs are evicted for queue A.
 	onlyYieldEvictedByQueue map[string]bool
+	// Resources reserved for specific queues.
+	// Queues using less than their reservation are yielded from ahead of all other queues.
+	reservedResourcesByQueue map[string]schedulerobjects.ResourceList
 	// Reusable buffer to avoid allocations.
 	buffer schedulerobjects.ResourceList
 	// Priority queue containing per-queue iterators.
@@ -289,6 +442,19 @@ func NewCandidateGangIterator(
 	return it, nil
 }
 
+// PrioritiseReservations causes queues using less than their reservation to be yielded from
+// ahead of all other queues, such that the reservation is reclaimed whenever the reserving queue has jobs to schedule.
+func (it *CandidateGangIterator) PrioritiseReservations(reservedResourcesByQueue map[string]schedulerobjects.ResourceList) error {
+	it.reservedResourcesByQueue = reservedResourcesByQueue
+	for _, item := range it.pq {
+		if err := it.updatePQItem(item); err != nil {
+			return err
+		}
+	}
+	heap.Init(&it.pq)
+	return nil
+}
+
 func (it *CandidateGangIterator) OnlyYieldEvicted() {
 	it.onlyYieldEvicted = true
 }
@@ -362,6 +528,7 @@ func (it *CandidateGangIterator) updateAndPushPQItem(item *QueueCandidateGangIte
 func (it *CandidateGangIterator) updatePQItem(item *QueueCandidateGangIteratorItem) error {
 	item.gctx = nil
 	item.queueCost = 0
+	item.belowReservation = false
 	gctx, err := item.it.Peek()
 	if err != nil {
 		return err
@@ -378,6 +545,17 @@ func (it *CandidateGangIterator) updatePQItem(item *QueueCandidateGangIteratorIt
 		return err
 	}
 	item.queueCost = cost
+	if reserved, ok := it.reservedResourcesByQueue[gctx.Queue]; ok {
+		if queue, ok := it.queueRepository.GetQueue(gctx.Queue); ok {
+			allocation := queue.GetAllocation()
+			for t, q := range reserved.Resources {
+				if q.Cmp(allocation.Get(t)) == 1 {
+					item.belowReservation = true
+					break
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -407,6 +585,9 @@ type QueueCandidateGangIteratorItem struct {
 	// Cost associated with the queue if the topmost gang in the queue were to be scheduled.
 	// Used to order queues fairly.
 	queueCost float64
+	// True if the queue is using less than its reservation.
+	// Such queues are ordered ahead of all others.
+	belowReservation bool
 	// The index of the item in the heap.
 	// maintained by the heap.Interface methods.
 	index int
@@ -415,6 +596,9 @@ type QueueCandidateGangIteratorItem struct {
 func (pq QueueCandidateGangIteratorPQ) Len() int { return len(pq) }
 
 func (pq QueueCandidateGangIteratorPQ) Less(i, j int) bool {
+	if pq[i].belowReservation != pq[j].belowReservation {
+		return pq[i].belowReservation
+	}
 	// Tie-break by queue name.
 	if pq[i].queueCost == pq[j].queueCost {
 		return pq[i].queue < pq[j].queue
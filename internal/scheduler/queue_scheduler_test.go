@@ -456,6 +456,48 @@ func TestQueueScheduler(t *testing.T) {
 			PriorityFactorByQueue:    map[string]float64{"A": 1},
 			ExpectedScheduledIndices: []int{1},
 		},
+		"mixed-priority gang": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			Jobs: armadaslices.Concatenate(
+				testfixtures.WithAnnotationsJobs(map[string]string{
+					configuration.GangIdAnnotation:                 "my-gang",
+					configuration.GangCardinalityAnnotation:        "2",
+					configuration.GangMinimumCardinalityAnnotation: "2",
+				},
+					testfixtures.WithPriorityJobs(1, testfixtures.N32Cpu256GiJobs("A", testfixtures.PriorityClass0, 1))),
+				testfixtures.WithPriorityJobs(10, testfixtures.N32Cpu256GiJobs("A", testfixtures.PriorityClass0, 1)),
+				testfixtures.WithAnnotationsJobs(map[string]string{
+					configuration.GangIdAnnotation:                 "my-gang",
+					configuration.GangCardinalityAnnotation:        "2",
+					configuration.GangMinimumCardinalityAnnotation: "2",
+				},
+					testfixtures.WithPriorityJobs(20, testfixtures.N32Cpu256GiJobs("A", testfixtures.PriorityClass0, 1))),
+			),
+			PriorityFactorByQueue:    map[string]float64{"A": 1},
+			ExpectedScheduledIndices: []int{1},
+		},
+		"mixed-priority gang with gang priority inheritance": {
+			SchedulingConfig: testfixtures.WithGangPriorityInheritanceConfig(testfixtures.TestSchedulingConfig()),
+			Nodes:            testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			Jobs: armadaslices.Concatenate(
+				testfixtures.WithAnnotationsJobs(map[string]string{
+					configuration.GangIdAnnotation:                 "my-gang",
+					configuration.GangCardinalityAnnotation:        "2",
+					configuration.GangMinimumCardinalityAnnotation: "2",
+				},
+					testfixtures.WithPriorityJobs(1, testfixtures.N32Cpu256GiJobs("A", testfixtures.PriorityClass0, 1))),
+				testfixtures.WithPriorityJobs(10, testfixtures.N32Cpu256GiJobs("A", testfixtures.PriorityClass0, 1)),
+				testfixtures.WithAnnotationsJobs(map[string]string{
+					configuration.GangIdAnnotation:                 "my-gang",
+					configuration.GangCardinalityAnnotation:        "2",
+					configuration.GangMinimumCardinalityAnnotation: "2",
+				},
+					testfixtures.WithPriorityJobs(20, testfixtures.N32Cpu256GiJobs("A", testfixtures.PriorityClass0, 1))),
+			),
+			PriorityFactorByQueue:    map[string]float64{"A": 1},
+			ExpectedScheduledIndices: []int{0, 2},
+		},
 		"job priority": {
 			SchedulingConfig: testfixtures.TestSchedulingConfig(),
 			Nodes:            testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
@@ -660,7 +702,7 @@ func TestQueueScheduler(t *testing.T) {
 			for _, qctx := range sctx.QueueSchedulingContexts {
 				for _, jctx := range qctx.SuccessfulJobSchedulingContexts {
 					assert.NotNil(t, jctx.PodSchedulingContext)
-					assert.Equal(t, result.NodeIdByJobId[jctx.JobId], jctx.PodSchedulingContext.NodeId)
+					assert.Equal(t, result.NodeIdByJobId()[jctx.JobId], jctx.PodSchedulingContext.NodeId)
 				}
 				for _, jctx := range qctx.UnsuccessfulJobSchedulingContexts {
 					if jctx.PodSchedulingContext != nil {
@@ -671,7 +713,7 @@ func TestQueueScheduler(t *testing.T) {
 
 			// Check that each scheduled job was allocated a node.
 			for _, jctx := range result.ScheduledJobs {
-				nodeId, ok := result.NodeIdByJobId[jctx.JobId]
+				nodeId, ok := result.NodeIdByJobId()[jctx.JobId]
 				assert.True(t, ok)
 				assert.NotEmpty(t, nodeId)
 			}
@@ -704,6 +746,103 @@ func TestQueueScheduler(t *testing.T) {
 	}
 }
 
+// Resources allocated to a queue earlier in a scheduling round must count against its share when other queues are
+// considered later in the same round, such that the outcome doesn't depend on the order in which queues are considered.
+func TestQueueScheduler_QueueOrderDoesNotAffectOutcome(t *testing.T) {
+	config := testfixtures.TestSchedulingConfig()
+	priorityFactorByQueue := map[string]float64{"A": 1, "B": 1, "C": 2}
+	initialAllocatedByQueueAndPriorityClass := map[string]schedulerobjects.QuantityByTAndResourceType[string]{
+		"A": {
+			testfixtures.PriorityClass0: schedulerobjects.ResourceList{
+				Resources: map[string]resource.Quantity{"cpu": resource.MustParse("8"), "memory": resource.MustParse("32Gi")},
+			},
+		},
+	}
+	jobs := armadaslices.Concatenate(
+		testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 32),
+		testfixtures.N1Cpu4GiJobs("B", testfixtures.PriorityClass0, 32),
+		testfixtures.N1Cpu4GiJobs("C", testfixtures.PriorityClass0, 32),
+	)
+	legacySchedulerJobs := make([]interfaces.LegacySchedulerJob, len(jobs))
+	for i, job := range jobs {
+		legacySchedulerJobs[i] = job
+	}
+
+	var expectedScheduledJobIds []string
+	var expectedShares map[string]float64
+	for _, queues := range [][]string{
+		{"A", "B", "C"},
+		{"A", "C", "B"},
+		{"B", "A", "C"},
+		{"B", "C", "A"},
+		{"C", "A", "B"},
+		{"C", "B", "A"},
+	} {
+		nodeDb, err := NewNodeDb(config)
+		require.NoError(t, err)
+		txn := nodeDb.Txn(true)
+		for _, node := range testfixtures.N32CpuNodes(1, testfixtures.TestPriorities) {
+			require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, nil, node))
+		}
+		txn.Commit()
+		totalResources := nodeDb.TotalResources()
+
+		jobRepo := NewInMemoryJobRepository()
+		jobRepo.EnqueueMany(
+			schedulercontext.JobSchedulingContextsFromJobs(config.Preemption.PriorityClasses, legacySchedulerJobs, GangIdAndCardinalityFromAnnotations),
+		)
+		fairnessCostProvider, err := fairness.NewDominantResourceFairness(totalResources, config.DominantResourceFairnessResourcesToConsider)
+		require.NoError(t, err)
+		sctx := schedulercontext.NewSchedulingContext(
+			"executor",
+			"pool",
+			config.Preemption.PriorityClasses,
+			config.Preemption.DefaultPriorityClass,
+			fairnessCostProvider,
+			rate.NewLimiter(rate.Limit(config.MaximumSchedulingRate), config.MaximumSchedulingBurst),
+			totalResources,
+		)
+		jobIteratorByQueue := make(map[string]JobIterator)
+		for _, queue := range queues {
+			err := sctx.AddQueueSchedulingContext(
+				queue, 1/priorityFactorByQueue[queue],
+				initialAllocatedByQueueAndPriorityClass[queue],
+				rate.NewLimiter(rate.Limit(config.MaximumPerQueueSchedulingRate), config.MaximumPerQueueSchedulingBurst),
+			)
+			require.NoError(t, err)
+			jobIteratorByQueue[queue] = jobRepo.GetJobIterator(queue)
+		}
+		constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+			"pool", totalResources, schedulerobjects.ResourceList{}, config,
+		)
+		sch, err := NewQueueScheduler(sctx, constraints, nodeDb, jobIteratorByQueue)
+		require.NoError(t, err)
+		result, err := sch.Schedule(armadacontext.Background())
+		require.NoError(t, err)
+
+		scheduledJobIds := util.Map(result.ScheduledJobs, func(jctx *schedulercontext.JobSchedulingContext) string { return jctx.JobId })
+		slices.Sort(scheduledJobIds)
+		shares := make(map[string]float64)
+		for queue, qctx := range sctx.QueueSchedulingContexts {
+			shares[queue] = qctx.Share()
+		}
+		if expectedScheduledJobIds == nil {
+			// The 32 cpu are divided such that A, which already had 8, ends up with as many as B, and twice as many as C.
+			numScheduledByQueue := make(map[string]int)
+			for _, jctx := range result.ScheduledJobs {
+				numScheduledByQueue[jctx.Job.GetQueue()]++
+			}
+			assert.Equal(t, map[string]int{"A": 8, "B": 16, "C": 8}, numScheduledByQueue)
+			assert.Equal(t, 1.0, sctx.QueueSchedulingContexts["A"].InitialShare())
+			expectedScheduledJobIds = scheduledJobIds
+			expectedShares = shares
+		} else {
+			assert.Equal(t, expectedScheduledJobIds, scheduledJobIds, "queue order %v", queues)
+			assert.Equal(t, expectedShares, shares, "queue order %v", queues)
+		}
+	}
+}
+
 func NewNodeDb(config configuration.SchedulingConfig) (*nodedb.NodeDb, error) {
 	nodeDb, err := nodedb.NewNodeDb(
 		config.Preemption.PriorityClasses,
@@ -718,3 +857,226 @@ func NewNodeDb(config configuration.SchedulingConfig) (*nodedb.NodeDb, error) {
 	}
 	return nodeDb, nil
 }
+
+func TestQueuedGangIterator_GangPriorityInheritance(t *testing.T) {
+	gangAnnotations := map[string]string{
+		configuration.GangIdAnnotation:                 "my-gang",
+		configuration.GangCardinalityAnnotation:        "2",
+		configuration.GangMinimumCardinalityAnnotation: "2",
+	}
+	highPriorityGangJob := testfixtures.WithAnnotationsJobs(gangAnnotations, testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass2, 1))[0]
+	middlePriorityJob := testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass1, 1)[0]
+	lowPriorityGangJob := testfixtures.WithAnnotationsJobs(gangAnnotations, testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0]
+
+	tests := map[string]struct {
+		enableGangPriorityInheritance bool
+		expectedJobIds                [][]string
+	}{
+		"without gang priority inheritance": {
+			expectedJobIds: [][]string{
+				{middlePriorityJob.Id()},
+				{highPriorityGangJob.Id(), lowPriorityGangJob.Id()},
+			},
+		},
+		"with gang priority inheritance": {
+			enableGangPriorityInheritance: true,
+			expectedJobIds: [][]string{
+				{highPriorityGangJob.Id(), lowPriorityGangJob.Id()},
+				{middlePriorityJob.Id()},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobRepo := NewInMemoryJobRepository()
+			jobRepo.EnqueueMany(
+				schedulercontext.JobSchedulingContextsFromJobs(
+					testfixtures.TestPriorityClasses,
+					[]*jobdb.Job{lowPriorityGangJob, middlePriorityJob, highPriorityGangJob},
+					GangIdAndCardinalityFromAnnotations,
+				),
+			)
+			it := NewQueuedGangIterator(nil, jobRepo.GetJobIterator("A"), 0, false)
+			if tc.enableGangPriorityInheritance {
+				it.EnableGangPriorityInheritance()
+			}
+
+			var actualJobIds [][]string
+			for {
+				gctx, err := it.Next()
+				require.NoError(t, err)
+				if gctx == nil {
+					break
+				}
+				jobIds := make([]string, 0, gctx.Cardinality())
+				for _, jctx := range gctx.JobSchedulingContexts {
+					jobIds = append(jobIds, jctx.JobId)
+					if gctx.Cardinality() > 1 && tc.enableGangPriorityInheritance {
+						assert.True(t, jctx.HasInheritedGangPriority)
+						assert.Equal(t, highPriorityGangJob.PodRequirements().Priority, jctx.PodRequirements.Priority)
+					} else {
+						assert.False(t, jctx.HasInheritedGangPriority)
+						assert.Equal(t, jctx.Job.GetPodRequirements(nil).Priority, jctx.PodRequirements.Priority)
+					}
+				}
+				actualJobIds = append(actualJobIds, jobIds)
+			}
+			assert.Equal(t, tc.expectedJobIds, actualJobIds)
+
+			// Inherited priorities must not be written back to the jobs themselves.
+			assert.Equal(t, int32(0), lowPriorityGangJob.PodRequirements().Priority)
+		})
+	}
+}
+
+func TestQueuedGangIterator_GangPriorityInheritanceWithLookback(t *testing.T) {
+	gangAnnotations := map[string]string{
+		configuration.GangIdAnnotation:                 "my-gang",
+		configuration.GangCardinalityAnnotation:        "2",
+		configuration.GangMinimumCardinalityAnnotation: "2",
+	}
+	highPriorityGangJob := testfixtures.WithAnnotationsJobs(gangAnnotations, testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass2, 1))[0]
+	middlePriorityJobs := testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass1, 2)
+	lowPriorityGangJob := testfixtures.WithAnnotationsJobs(gangAnnotations, testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 1))[0]
+
+	tests := map[string]struct {
+		maxLookback    uint
+		expectedJobIds [][]string
+	}{
+		"gang within lookback": {
+			maxLookback: 4,
+			expectedJobIds: [][]string{
+				{highPriorityGangJob.Id(), lowPriorityGangJob.Id()},
+				{middlePriorityJobs[0].Id()},
+				{middlePriorityJobs[1].Id()},
+			},
+		},
+		"lookahead for gang reaches lookback limit": {
+			// The jobs read while looking ahead are within the limit, so are still yielded,
+			// but the final member of the gang isn't, so the gang isn't.
+			maxLookback: 3,
+			expectedJobIds: [][]string{
+				{middlePriorityJobs[0].Id()},
+				{middlePriorityJobs[1].Id()},
+			},
+		},
+		"lookahead for gang exceeds lookback limit": {
+			maxLookback: 2,
+			expectedJobIds: [][]string{
+				{middlePriorityJobs[0].Id()},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobRepo := NewInMemoryJobRepository()
+			jobRepo.EnqueueMany(
+				schedulercontext.JobSchedulingContextsFromJobs(
+					testfixtures.TestPriorityClasses,
+					append([]*jobdb.Job{lowPriorityGangJob, highPriorityGangJob}, middlePriorityJobs...),
+					GangIdAndCardinalityFromAnnotations,
+				),
+			)
+			it := NewQueuedGangIterator(nil, jobRepo.GetJobIterator("A"), tc.maxLookback, false)
+			it.EnableGangPriorityInheritance()
+
+			var actualJobIds [][]string
+			for {
+				// Peeking repeatedly mustn't affect the gangs yielded.
+				_, err := it.Peek()
+				require.NoError(t, err)
+				gctx, err := it.Next()
+				require.NoError(t, err)
+				if gctx == nil {
+					break
+				}
+				jobIds := make([]string, 0, gctx.Cardinality())
+				for _, jctx := range gctx.JobSchedulingContexts {
+					jobIds = append(jobIds, jctx.JobId)
+				}
+				actualJobIds = append(actualJobIds, jobIds)
+			}
+			assert.Equal(t, tc.expectedJobIds, actualJobIds)
+		})
+	}
+}
+
+func TestQueueScheduler_SchedulingKeySkipping(t *testing.T) {
+	config := testfixtures.TestSchedulingConfig()
+	jobs := testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 3)
+	unschedulableJob := jobs[0]
+	skippedJob := jobs[1]
+	optedOutJob := testfixtures.WithAnnotationsJobs(
+		map[string]string{configuration.DisableSchedulingKeySkippingAnnotation: "true"},
+		jobs[2:],
+	)[0]
+	unschedulableKey, ok := unschedulableJob.GetSchedulingKey()
+	require.True(t, ok)
+	for _, job := range []*jobdb.Job{skippedJob, optedOutJob} {
+		key, ok := job.GetSchedulingKey()
+		require.True(t, ok)
+		require.Equal(t, unschedulableKey, key)
+	}
+
+	nodeDb, err := NewNodeDb(config)
+	require.NoError(t, err)
+	txn := nodeDb.Txn(true)
+	for _, node := range testfixtures.N32CpuNodes(1, testfixtures.TestPriorities) {
+		require.NoError(t, nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, nil, node))
+	}
+	txn.Commit()
+	totalResources := nodeDb.TotalResources()
+
+	fairnessCostProvider, err := fairness.NewDominantResourceFairness(totalResources, config.DominantResourceFairnessResourcesToConsider)
+	require.NoError(t, err)
+	sctx := schedulercontext.NewSchedulingContext(
+		"executor",
+		"pool",
+		config.Preemption.PriorityClasses,
+		config.Preemption.DefaultPriorityClass,
+		fairnessCostProvider,
+		rate.NewLimiter(rate.Limit(config.MaximumSchedulingRate), config.MaximumSchedulingBurst),
+		totalResources,
+	)
+	err = sctx.AddQueueSchedulingContext(
+		"A", 1, nil,
+		rate.NewLimiter(rate.Limit(config.MaximumPerQueueSchedulingRate), config.MaximumPerQueueSchedulingBurst),
+	)
+	require.NoError(t, err)
+
+	// Register a job with the same scheduling key as found unschedulable, e.g., for a reason not captured by the key.
+	unschedulableJctx := schedulercontext.JobSchedulingContextFromJob(config.Preemption.PriorityClasses, unschedulableJob, GangIdAndCardinalityFromAnnotations)
+	unschedulableJctx.Fail("unschedulable for reasons not captured by the scheduling key")
+	sctx.UnfeasibleSchedulingKeys[unschedulableKey] = unschedulableJctx
+
+	jobRepo := NewInMemoryJobRepository()
+	jobRepo.EnqueueMany(
+		schedulercontext.JobSchedulingContextsFromJobs(
+			config.Preemption.PriorityClasses,
+			[]interfaces.LegacySchedulerJob{skippedJob, optedOutJob},
+			GangIdAndCardinalityFromAnnotations,
+		),
+	)
+	constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+		"pool", totalResources, schedulerobjects.ResourceList{}, config,
+	)
+	sch, err := NewQueueScheduler(sctx, constraints, nodeDb, map[string]JobIterator{"A": jobRepo.GetJobIterator("A")})
+	require.NoError(t, err)
+	result, err := sch.Schedule(armadacontext.Background())
+	require.NoError(t, err)
+
+	// The job that opted out is evaluated individually and scheduled.
+	scheduledJobIds := util.Map(result.ScheduledJobs, func(jctx *schedulercontext.JobSchedulingContext) string { return jctx.JobId })
+	assert.Equal(t, []string{optedOutJob.Id()}, scheduledJobIds)
+
+	// The other job is skipped, and its context says why.
+	qctx := sctx.QueueSchedulingContexts["A"]
+	require.Contains(t, qctx.UnsuccessfulJobSchedulingContexts, skippedJob.Id())
+	skippedJctx := qctx.UnsuccessfulJobSchedulingContexts[skippedJob.Id()]
+	assert.Equal(t, unschedulableJob.Id(), skippedJctx.IdenticalToUnschedulableJobId)
+	assert.Equal(t, unschedulableJctx.UnschedulableReason, skippedJctx.UnschedulableReason)
+	assert.Contains(t, skippedJctx.String(), fmt.Sprintf("identical to job %s found unschedulable", unschedulableJob.Id()))
+
+	assert.Equal(t, 1, qctx.NumSchedulingKeySkippedJobs)
+	assert.Equal(t, 1, qctx.NumSchedulingKeyCollisions)
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// recentLeases tracks the ids of runs leased in the current and previous cycle.
+//
+// If a job is cancelled at about the same time as it's leased, the executor may receive the lease before learning
+// that the job was cancelled. Hence, when such a job is cancelled, the scheduler also cancels its recent runs
+// explicitly, such that the executor tears down any pods it's already created for them.
+type recentLeases struct {
+	current  map[uuid.UUID]bool
+	previous map[uuid.UUID]bool
+}
+
+// startCycle forgets runs leased before the previous cycle.
+func (l *recentLeases) startCycle() {
+	l.previous = l.current
+	l.current = nil
+}
+
+// addFromSchedulerResult records the runs of all jobs scheduled in result.
+func (l *recentLeases) addFromSchedulerResult(result *SchedulerResult) {
+	if result == nil {
+		return
+	}
+	for _, job := range ScheduledJobsFromSchedulerResult[*jobdb.Job](result) {
+		run := job.LatestRun()
+		if run == nil {
+			continue
+		}
+		if l.current == nil {
+			l.current = make(map[uuid.UUID]bool)
+		}
+		l.current[run.Id()] = true
+	}
+}
+
+// contains returns true if the run with the provided id was leased in the current or previous cycle.
+func (l *recentLeases) contains(runId uuid.UUID) bool {
+	return l.current[runId] || l.previous[runId]
+}
+
+// cancelRecentRuns marks all runs of job cancelled and returns a JobRunCancelled event for each active run
+// leased in the current or previous cycle, along with the updated job.
+// If cancellation enforcement is enabled, all active runs are tracked until their executor stops them.
+func (s *Scheduler) cancelRecentRuns(job *jobdb.Job, jobId *armadaevents.Uuid) (*jobdb.Job, []*armadaevents.EventSequence_Event) {
+	var events []*armadaevents.EventSequence_Event
+	for _, run := range job.AllRuns() {
+		if s.cancellationEnforcer != nil && !run.InTerminalState() && run.Executor() != "" {
+			s.cancellationEnforcer.add(s.clock.Now(), job, jobId, run)
+		}
+		if !run.InTerminalState() && s.recentLeases.contains(run.Id()) {
+			events = append(events, &armadaevents.EventSequence_Event{
+				Created: s.now(),
+				Event: &armadaevents.EventSequence_Event_JobRunCancelled{
+					JobRunCancelled: &armadaevents.JobRunCancelled{
+						RunId: armadaevents.ProtoUuidFromUuid(run.Id()),
+						JobId: jobId,
+					},
+				},
+			})
+		}
+		job = job.WithUpdatedRun(run.WithCancelled(true))
+	}
+	return job, events
+}
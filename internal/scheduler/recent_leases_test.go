@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_CancelRecentlyLeasedJob(t *testing.T) {
+	tests := map[string]struct {
+		// Number of cycles between the cycle in which the job is leased and that in which it's cancelled.
+		cyclesBetweenLeaseAndCancel int
+		cancelByJobSet              bool
+		expectRunCancelled          bool
+	}{
+		"cancelled the cycle after being leased": {
+			expectRunCancelled: true,
+		},
+		"cancelled by job set the cycle after being leased": {
+			cancelByJobSet:     true,
+			expectRunCancelled: true,
+		},
+		"cancelled two cycles after being leased": {
+			cyclesBetweenLeaseAndCancel: 1,
+			expectRunCancelled:          false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			testClock := clock.NewFakeClock(time.Now())
+			jobRepo := &testJobRepository{}
+			schedulingAlgo := &testSchedulingAlgo{jobsToSchedule: []string{queuedJob.Id()}}
+			publisher := &testPublisher{}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				jobRepo,
+				&testExecutorRepository{
+					executors: []*schedulerobjects.Executor{{Id: "testExecutor", LastUpdateTime: testClock.Now()}},
+				},
+				schedulingAlgo,
+				NewStandaloneLeaderController(),
+				publisher,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = testClock
+
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{queuedJob}))
+			txn.Commit()
+
+			// Lease the job.
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+			require.NoError(t, err)
+			leasedRun := sched.jobDb.ReadTxn().GetById(queuedJob.Id()).LatestRun()
+			require.NotNil(t, leasedRun)
+			schedulingAlgo.jobsToSchedule = nil
+			for i := 0; i < tc.cyclesBetweenLeaseAndCancel; i++ {
+				_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+				require.NoError(t, err)
+			}
+
+			// Cancel the job.
+			jobRepo.updatedJobs = []database.Job{
+				{
+					JobID:                   queuedJob.Id(),
+					JobSet:                  queuedJob.Jobset(),
+					Queue:                   queuedJob.Queue(),
+					CancelRequested:         !tc.cancelByJobSet,
+					CancelByJobsetRequested: tc.cancelByJobSet,
+					Serial:                  1,
+				},
+			}
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+			require.NoError(t, err)
+
+			var numJobsCancelled int
+			var runsCancelled []*armadaevents.JobRunCancelled
+			for _, eventSequence := range publisher.events {
+				for _, event := range eventSequence.Events {
+					if event.GetCancelledJob() != nil {
+						numJobsCancelled++
+					}
+					if runCancelled := event.GetJobRunCancelled(); runCancelled != nil {
+						runsCancelled = append(runsCancelled, runCancelled)
+					}
+				}
+			}
+			assert.Equal(t, 1, numJobsCancelled)
+			if tc.expectRunCancelled {
+				require.Len(t, runsCancelled, 1)
+				assert.Equal(t, armadaevents.ProtoUuidFromUuid(leasedRun.Id()), runsCancelled[0].RunId)
+				jobId, err := armadaevents.ProtoUuidFromUlidString(queuedJob.Id())
+				require.NoError(t, err)
+				assert.Equal(t, jobId, runsCancelled[0].JobId)
+			} else {
+				assert.Empty(t, runsCancelled)
+			}
+
+			// Either way, the run is marked cancelled in the jobDb.
+			job := sched.jobDb.ReadTxn().GetById(queuedJob.Id())
+			require.NotNil(t, job)
+			assert.True(t, job.InTerminalState())
+			assert.True(t, job.RunById(leasedRun.Id()).Cancelled())
+		})
+	}
+}
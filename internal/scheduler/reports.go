@@ -3,10 +3,12 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"text/tabwriter"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/oklog/ulid"
@@ -14,9 +16,13 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/kubernetesobjects/affinity"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
@@ -51,6 +57,30 @@ type SchedulingContextRepository struct {
 	// All executors in sorted order.
 	sortedExecutorIds atomic.Pointer[[]string]
 
+	// If non-nil, queue reports include estimated wait times.
+	waitTimeEstimator *WaitTimeEstimator
+	// If non-nil, used to serve job set reports.
+	jobSetPlacementTracker *JobSetPlacementTracker
+	// If non-nil, used to serve event rate reports.
+	eventRateTracker *EventRateTracker
+	// If non-nil, used to serve fragmentation reports.
+	fragmentationTracker *FragmentationTracker
+	// If non-nil, job reports include the outcome of the most recent scheduling round in which the job was evaluated,
+	// as recorded in this jobDb.
+	schedulingOutcomesJobDb *jobdb.JobDb
+	// If non-nil, job reports include the state of the job as held by this jobDb.
+	jobStateJobDb *jobdb.JobDb
+	// If non-nil, scheduling reports include the age of the oldest update not yet processed by the scheduler.
+	updateStalenessTracker *UpdateStalenessTracker
+	// If non-nil, job reports include the retry bookkeeping of the job as held by this jobDb.
+	retryReportsJobDb *jobdb.JobDb
+	// Maximum number of times jobs are attempted, which may be changed by reloading the config.
+	maxAttemptedRuns atomic.Uint32
+	// Label identifying the nodes jobs avoid after having been attempted on them.
+	nodeIdLabel string
+	// Used to determine whether jobs are still backed off.
+	clock clock.Clock
+
 	// Protects the fields in this struct from concurrent and dirty writes.
 	mu sync.Mutex
 }
@@ -65,6 +95,7 @@ func NewSchedulingContextRepository(jobCacheSize uint) (*SchedulingContextReposi
 	rv := &SchedulingContextRepository{
 		mostRecentByExecutorByJobId: mostRecentByExecutorByJobId,
 		executorIds:                 make(map[string]bool),
+		clock:                       clock.RealClock{},
 	}
 
 	mostRecentByExecutor := make(SchedulingContextByExecutor)
@@ -90,6 +121,68 @@ func NewSchedulingContextRepository(jobCacheSize uint) (*SchedulingContextReposi
 	return rv, nil
 }
 
+// EnableWaitTimeEstimates causes queue reports to include the wait times estimated by estimator.
+// Must be called before the repo is used.
+func (repo *SchedulingContextRepository) EnableWaitTimeEstimates(estimator *WaitTimeEstimator) {
+	repo.waitTimeEstimator = estimator
+}
+
+// EnableJobSetReports causes job set reports to include the placement stats recorded by tracker.
+// Must be called before the repo is used.
+func (repo *SchedulingContextRepository) EnableJobSetReports(tracker *JobSetPlacementTracker) {
+	repo.jobSetPlacementTracker = tracker
+}
+
+// EnableSchedulingOutcomeReports causes job reports to include the outcome of the most recent scheduling round
+// in which the job was evaluated, as recorded in jobDb. Unlike scheduling contexts, which are evicted from the repo
+// as new ones are added, outcomes are retained for as long as the job is in the jobDb.
+// Must be called before the repo is used.
+func (repo *SchedulingContextRepository) EnableSchedulingOutcomeReports(jobDb *jobdb.JobDb) {
+	repo.schedulingOutcomesJobDb = jobDb
+}
+
+// EnableJobStateReports causes job reports to include the state of the job as held by jobDb, e.g., whether it's queued
+// or which node it's running on, such that they're informative even on replicas that never schedule.
+// Must be called before the repo is used.
+func (repo *SchedulingContextRepository) EnableJobStateReports(jobDb *jobdb.JobDb) {
+	repo.jobStateJobDb = jobDb
+}
+
+// EnableRetryReports causes job reports to include the retry bookkeeping of the job as held by jobDb:
+// how many times it's been attempted out of maxAttemptedRuns, until when it's backed off, the nodes it avoids
+// since earlier runs were attempted on them, as identified by nodeIdLabel, and the error of its most recent failed run.
+// Must be called before the repo is used.
+func (repo *SchedulingContextRepository) EnableRetryReports(jobDb *jobdb.JobDb, maxAttemptedRuns uint, nodeIdLabel string) {
+	repo.retryReportsJobDb = jobDb
+	repo.maxAttemptedRuns.Store(uint32(maxAttemptedRuns))
+	repo.nodeIdLabel = nodeIdLabel
+}
+
+// ReloadConfig updates the maximum number of attempts included in retry reports.
+func (repo *SchedulingContextRepository) ReloadConfig(config schedulerconfig.Configuration) error {
+	repo.maxAttemptedRuns.Store(uint32(config.Scheduling.MaxRetries + 1))
+	return nil
+}
+
+// EnableEventRateReports causes event rate reports to include the rates recorded by tracker.
+// Must be called before the repo is used.
+func (repo *SchedulingContextRepository) EnableEventRateReports(tracker *EventRateTracker) {
+	repo.eventRateTracker = tracker
+}
+
+// EnableFragmentationReports causes fragmentation reports to include the fragmentation recorded by tracker.
+// Must be called before the repo is used.
+func (repo *SchedulingContextRepository) EnableFragmentationReports(tracker *FragmentationTracker) {
+	repo.fragmentationTracker = tracker
+}
+
+// EnableUpdateStalenessReports causes scheduling reports to include the age of the oldest job or run update
+// not yet processed by the scheduler, as recorded by tracker.
+// Must be called before the repo is used.
+func (repo *SchedulingContextRepository) EnableUpdateStalenessReports(tracker *UpdateStalenessTracker) {
+	repo.updateStalenessTracker = tracker
+}
+
 // AddSchedulingContext adds a scheduling context to the repo.
 // It also extracts the queue and job scheduling contexts it contains and stores those separately.
 //
@@ -293,6 +386,13 @@ func (repo *SchedulingContextRepository) getSchedulingReportString(verbosity int
 	mostRecentPreemptingByExecutor := repo.GetMostRecentPreemptingSchedulingContextByExecutor()
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
+	if repo.updateStalenessTracker != nil {
+		if staleness, sampledAt := repo.updateStalenessTracker.Staleness(); sampledAt.IsZero() {
+			fmt.Fprint(w, "Oldest unprocessed update age:\tnot yet sampled\n")
+		} else {
+			fmt.Fprintf(w, "Oldest unprocessed update age:\t%s (sampled at %s)\n", staleness, sampledAt.Format(time.RFC3339))
+		}
+	}
 	for _, executorId := range repo.GetSortedExecutorIds() {
 		fmt.Fprintf(w, "%s:\n", executorId)
 		if sctx := mostRecentByExecutor[executorId]; sctx != nil {
@@ -465,6 +565,16 @@ func (repo *SchedulingContextRepository) getQueueReportString(queue string, verb
 			fmt.Fprintf(w, "\tMost recent scheduling round that preempted a job from queue %s: none\n", queue)
 		}
 	}
+	if repo.waitTimeEstimator != nil {
+		if estimates := repo.waitTimeEstimator.EstimatesForQueue(queue); len(estimates) > 0 {
+			fmt.Fprintf(w, "Estimated wait times for queue %s:\n", queue)
+			for _, estimate := range estimates {
+				fmt.Fprintf(w, "\t%s:\t%s\n", estimate.Bucket, estimate)
+			}
+		} else {
+			fmt.Fprintf(w, "Estimated wait times for queue %s: unknown\n", queue)
+		}
+	}
 	w.Flush()
 	return sb.String()
 }
@@ -485,10 +595,67 @@ func (repo *SchedulingContextRepository) GetJobReport(_ context.Context, request
 	}, nil
 }
 
+// GetJobSetReport is a gRPC endpoint for querying job set placement reports.
+func (repo *SchedulingContextRepository) GetJobSetReport(_ context.Context, request *schedulerobjects.JobSetReportRequest) (*schedulerobjects.JobSetReport, error) {
+	queue := strings.TrimSpace(request.GetQueue())
+	jobSet := strings.TrimSpace(request.GetJobSetId())
+	if repo.jobSetPlacementTracker == nil {
+		return &schedulerobjects.JobSetReport{
+			Report: "Job set placement tracking is disabled\n",
+		}, nil
+	}
+	return &schedulerobjects.JobSetReport{
+		Report: repo.jobSetPlacementTracker.reportString(queue, jobSet),
+	}, nil
+}
+
+// GetFragmentationReport is a gRPC endpoint for querying how fragmented the free capacity of each pool is
+// and which preemptible jobs would need to move to unblock the largest job blocked solely by fragmentation.
+func (repo *SchedulingContextRepository) GetFragmentationReport(_ context.Context, request *schedulerobjects.FragmentationReportRequest) (*schedulerobjects.FragmentationReport, error) {
+	if repo.fragmentationTracker == nil {
+		return &schedulerobjects.FragmentationReport{
+			Report: "Fragmentation tracking is disabled\n",
+		}, nil
+	}
+	pool := strings.TrimSpace(request.GetPool())
+	return &schedulerobjects.FragmentationReport{
+		Report: repo.fragmentationTracker.reportString(pool),
+	}, nil
+}
+
+// GetEventRateReport is a gRPC endpoint for querying the rates of job events of each queue and the job sets
+// with the most events.
+func (repo *SchedulingContextRepository) GetEventRateReport(_ context.Context, request *schedulerobjects.EventRateReportRequest) (*schedulerobjects.EventRateReport, error) {
+	if repo.eventRateTracker == nil {
+		return &schedulerobjects.EventRateReport{
+			Report: "Event rate tracking is disabled\n",
+		}, nil
+	}
+	queue := strings.TrimSpace(request.GetQueue())
+	return &schedulerobjects.EventRateReport{
+		Report: repo.eventRateTracker.reportString(repo.clock.Now(), queue, int(request.GetNumJobSets())),
+	}, nil
+}
+
 func (repo *SchedulingContextRepository) getJobReportString(jobId string) string {
 	byExecutor, _ := repo.GetMostRecentSchedulingContextByExecutorForJob(jobId)
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
+	if repo.jobStateJobDb != nil {
+		fmt.Fprintf(w, "State:\t%s\n", jobStateString(repo.jobStateJobDb.ReadTxn().GetById(jobId)))
+	}
+	if repo.schedulingOutcomesJobDb != nil {
+		if outcome, ok := repo.schedulingOutcomesJobDb.ReadTxn().GetSchedulingOutcome(jobId); ok {
+			fmt.Fprintf(w, "Last scheduling outcome:\t%s\n", outcome)
+		} else {
+			fmt.Fprintf(w, "Last scheduling outcome:\tnone recorded\n")
+		}
+	}
+	if repo.retryReportsJobDb != nil {
+		if job := repo.retryReportsJobDb.ReadTxn().GetById(jobId); job != nil {
+			repo.writeRetryReport(w, job)
+		}
+	}
 	for _, executorId := range repo.GetSortedExecutorIds() {
 		if sr := getSchedulingReportForJob(byExecutor[executorId], jobId); sr.jobSchedulingContext != nil {
 			fmt.Fprintf(w, "%s:\n", executorId)
@@ -501,6 +668,56 @@ func (repo *SchedulingContextRepository) getJobReportString(jobId string) string
 	return sb.String()
 }
 
+// writeRetryReport writes the retry bookkeeping of job to w, e.g.,
+//
+//	Attempts:         3 of 5
+//	Next eligible at: 2024-01-01T14:32:00Z
+//	Excluded nodes:   node-a, node-b
+//	Last run error:   OOMKilled
+func (repo *SchedulingContextRepository) writeRetryReport(w io.Writer, job *jobdb.Job) {
+	maxAttemptedRuns := job.MaxAttemptedRuns(uint(repo.maxAttemptedRuns.Load()))
+	if attempts := job.NumAttempts(); attempts >= maxAttemptedRuns {
+		fmt.Fprintf(w, "Attempts:\t%d of %d (no retries remaining)\n", attempts, maxAttemptedRuns)
+	} else {
+		fmt.Fprintf(w, "Attempts:\t%d of %d\n", attempts, maxAttemptedRuns)
+	}
+	if until, ok := job.BackedOffUntil(); ok && until.After(repo.clock.Now()) {
+		fmt.Fprintf(w, "Next eligible at:\t%s\n", until.UTC().Format(time.RFC3339))
+	}
+	if excludedNodes := affinity.ListNodeAntiAffinityValues(job.PodRequirements().GetAffinity(), repo.nodeIdLabel); len(excludedNodes) > 0 {
+		fmt.Fprintf(w, "Excluded nodes:\t%s\n", strings.Join(excludedNodes, ", "))
+	} else {
+		fmt.Fprintf(w, "Excluded nodes:\tnone\n")
+	}
+	if lastRunError := job.LastRunError(); lastRunError != "" {
+		fmt.Fprintf(w, "Last run error:\t%s\n", lastRunError)
+	}
+}
+
+// jobStateString returns a description of the state of job, which is nil if it's not in the jobDb.
+func jobStateString(job *jobdb.Job) string {
+	switch {
+	case job == nil:
+		return "not found"
+	case job.Succeeded():
+		return "succeeded"
+	case job.Failed():
+		return "failed"
+	case job.Cancelled():
+		return "cancelled"
+	case job.Queued():
+		return "queued"
+	}
+	run := job.LatestRun()
+	if run == nil {
+		return "leased"
+	}
+	if run.Running() {
+		return fmt.Sprintf("running on node %s of executor %s", run.NodeName(), run.Executor())
+	}
+	return fmt.Sprintf("leased to node %s of executor %s", run.NodeName(), run.Executor())
+}
+
 func (repo *SchedulingContextRepository) GetMostRecentSchedulingContextByExecutor() SchedulingContextByExecutor {
 	return *repo.mostRecentByExecutor.Load()
 }
@@ -2,17 +2,29 @@ package scheduler
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/kubernetesobjects/affinity"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
 )
 
 func TestAddGetSchedulingContext(t *testing.T) {
@@ -312,3 +324,152 @@ func testSchedulingContext(executorId string) *schedulercontext.SchedulingContex
 	sctx.SchedulingKeyGenerator = nil
 	return sctx
 }
+
+func TestJobReport_Retries(t *testing.T) {
+	now := testfixtures.BaseTime
+	// attemptedJob returns a job attempted the given number of times on nodes node-0, node-1, etc., avoiding those nodes thereafter.
+	attemptedJob := func(t *testing.T, attempts int) *jobdb.Job {
+		info := proto.Clone(schedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+		info.GetPodRequirements().Affinity = &v1.Affinity{}
+		job := testfixtures.JobDb.NewJob(util.NewULID(), "testJobset", "testQueue", 0, info, true, 0, false, false, false, 1)
+		for i := 0; i < attempts; i++ {
+			nodeName := fmt.Sprintf("node-%d", i)
+			job = job.WithNewRun("testExecutor", nodeName, nodeName, 0, now)
+			job = job.WithUpdatedRun(job.LatestRun().WithFailed(true).WithReturned(true).WithAttempted(true))
+			require.NoError(t, affinity.AddNodeAntiAffinity(info.GetPodRequirements().Affinity, nodeIdLabel, nodeName))
+		}
+		return job.WithJobSchedulingInfo(info)
+	}
+	tests := map[string]struct {
+		job              *jobdb.Job
+		expectedLines    []string
+		notExpectedLines []string
+	}{
+		"backed off": {
+			job: attemptedJob(t, 1).WithBackedOffUntil(now.Add(5 * time.Minute)).WithLastRunError("OOMKilled"),
+			expectedLines: []string{
+				"Attempts: 1 of 2",
+				"Next eligible at: " + now.Add(5*time.Minute).UTC().Format(time.RFC3339),
+				"Excluded nodes: node-0",
+				"Last run error: OOMKilled",
+			},
+		},
+		"backoff elapsed": {
+			job: attemptedJob(t, 1).WithBackedOffUntil(now.Add(-time.Minute)),
+			expectedLines: []string{
+				"Attempts: 1 of 2",
+				"Excluded nodes: node-0",
+			},
+			notExpectedLines: []string{"Next eligible at:", "Last run error:"},
+		},
+		"retries exhausted": {
+			job: attemptedJob(t, maxNumberOfAttempts).WithLastRunError("lease returned"),
+			expectedLines: []string{
+				"Attempts: 2 of 2 (no retries remaining)",
+				"Excluded nodes: node-0, node-1",
+				"Last run error: lease returned",
+			},
+			notExpectedLines: []string{"Next eligible at:"},
+		},
+		"never attempted": {
+			job: attemptedJob(t, 0),
+			expectedLines: []string{
+				"Attempts: 0 of 2",
+				"Excluded nodes: none",
+			},
+			notExpectedLines: []string{"Next eligible at:", "Last run error:"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobDb := testfixtures.NewJobDb()
+			txn := jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{tc.job}))
+			txn.Commit()
+
+			repo, err := NewSchedulingContextRepository(1024)
+			require.NoError(t, err)
+			repo.clock = clock.NewFakeClock(now)
+			repo.EnableRetryReports(jobDb, maxNumberOfAttempts, nodeIdLabel)
+
+			report, err := repo.GetJobReport(armadacontext.Background(), &schedulerobjects.JobReportRequest{JobId: tc.job.Id()})
+			require.NoError(t, err)
+			// Collapse the padding added to align columns.
+			reportString := regexp.MustCompile(` +`).ReplaceAllString(report.Report, " ")
+			for _, line := range tc.expectedLines {
+				assert.Contains(t, reportString, line)
+			}
+			for _, line := range tc.notExpectedLines {
+				assert.NotContains(t, reportString, line)
+			}
+		})
+	}
+}
+
+func TestJobReport_RetriesReloadConfig(t *testing.T) {
+	job := testfixtures.JobDb.NewJob(util.NewULID(), "testJobset", "testQueue", 0, schedulingInfo, true, 0, false, false, false, 1)
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	txn.Commit()
+
+	repo, err := NewSchedulingContextRepository(1024)
+	require.NoError(t, err)
+	repo.EnableRetryReports(jobDb, maxNumberOfAttempts, nodeIdLabel)
+	require.NoError(t, repo.ReloadConfig(schedulerconfig.Configuration{Scheduling: configuration.SchedulingConfig{MaxRetries: 4}}))
+
+	report, err := repo.GetJobReport(armadacontext.Background(), &schedulerobjects.JobReportRequest{JobId: job.Id()})
+	require.NoError(t, err)
+	assert.Regexp(t, `Attempts: +0 of 5`, report.Report)
+}
+
+func TestScheduler_LastRunErrorTracking(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	job := testfixtures.JobDb.NewJob(util.NewULID(), "testJobset", "testQueue", 0, schedulingInfo, false, 1, false, false, false, 1).
+		WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+	runId := job.LatestRun().Id()
+	jobRepo := &testJobRepository{
+		updatedRuns: []database.Run{
+			{
+				RunID:        runId,
+				JobID:        job.Id(),
+				JobSet:       "testJobset",
+				Executor:     "testExecutor",
+				Node:         "test-node",
+				Failed:       true,
+				Returned:     true,
+				RunAttempted: true,
+				Serial:       1,
+			},
+		},
+		errors: map[uuid.UUID]*armadaevents.Error{runId: defaultJobRunError},
+	}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.EnableLastRunErrorTracking()
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	txn.Commit()
+
+	_, _, _, err = sched.syncState(ctx)
+	require.NoError(t, err)
+	updatedJob := sched.jobDb.ReadTxn().GetById(job.Id())
+	require.NotNil(t, updatedJob)
+	assert.Equal(t, runErrorSummary(defaultJobRunError), updatedJob.LastRunError())
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// transientErrorRetryInterval is how long to wait between attempts of repository operations that failed transiently.
+const transientErrorRetryInterval = 100 * time.Millisecond
+
+// EnableCycleHealthCheck causes the scheduler, which implements health.Checker, to report unhealthy once
+// maxConsecutiveTransientFailures consecutive cycles have failed due to transient repository errors,
+// e.g., because the database is unreachable.
+func (s *Scheduler) EnableCycleHealthCheck(maxConsecutiveTransientFailures int) {
+	s.maxConsecutiveTransientCycleFailures = maxConsecutiveTransientFailures
+}
+
+// Check returns an error if cycle health checking is enabled and too many consecutive cycles have failed
+// due to transient repository errors, if the oldest unprocessed update is staler than allowed,
+// or if the scheduler has halted due to a serial regression.
+func (s *Scheduler) Check() error {
+	if err := s.checkUpdateStaleness(); err != nil {
+		return err
+	}
+	if s.haltedBySerialRegression.Load() {
+		return errors.WithStack(ErrSerialRegression)
+	}
+	if s.maxConsecutiveTransientCycleFailures <= 0 {
+		return nil
+	}
+	if n := s.consecutiveTransientCycleFailures.Load(); n >= int64(s.maxConsecutiveTransientCycleFailures) {
+		return errors.Errorf("the last %d scheduler cycles failed due to transient repository errors", n)
+	}
+	return nil
+}
+
+// recordCycleOutcome updates the number of consecutive cycles that failed due to transient repository errors.
+func (s *Scheduler) recordCycleOutcome(err error) {
+	if err == nil {
+		s.consecutiveTransientCycleFailures.Store(0)
+	} else if errors.Is(err, database.ErrTransient) {
+		s.consecutiveTransientCycleFailures.Add(1)
+	}
+}
+
+// retryTransient calls f until it returns an error not matching database.ErrTransient,
+// or until another attempt would exceed the cycle period, and returns the last error.
+func (s *Scheduler) retryTransient(ctx *armadacontext.Context, description string, f func() error) error {
+	start := s.clock.Now()
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if !errors.Is(err, database.ErrTransient) {
+			return err
+		}
+		if s.clock.Since(start)+transientErrorRetryInterval > s.cyclePeriod {
+			return errors.WithMessagef(err, "%s failed after %d attempts", description, attempt)
+		}
+		logging.WithStacktrace(ctx, err).Warnf("%s failed transiently; retrying", description)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		s.clock.Sleep(transientErrorRetryInterval)
+	}
+}
+
+// fetchJobRunErrors returns the errors of the runs with the provided ids, retrying transient errors.
+// Runs whose error can't be decoded are given a terminal error instead, such that the job is failed
+// rather than the corrupt row failing every cycle.
+func (s *Scheduler) fetchJobRunErrors(ctx *armadacontext.Context, runIds []uuid.UUID) (map[uuid.UUID]*armadaevents.Error, error) {
+	corruptRows := make(map[uuid.UUID]*database.ErrCorruptRow)
+	for {
+		idsToFetch := runIds
+		if len(corruptRows) > 0 {
+			idsToFetch = make([]uuid.UUID, 0, len(runIds))
+			for _, runId := range runIds {
+				if corruptRows[runId] == nil {
+					idsToFetch = append(idsToFetch, runId)
+				}
+			}
+		}
+		var runErrorsByRunId map[uuid.UUID]*armadaevents.Error
+		err := s.retryTransient(ctx, "fetching job run errors", func() error {
+			var err error
+			runErrorsByRunId, err = s.jobRepository.FetchJobRunErrors(ctx, idsToFetch)
+			return err
+		})
+		var corruptRow *database.ErrCorruptRow
+		if errors.As(err, &corruptRow) && corruptRow.RunID != uuid.Nil && corruptRows[corruptRow.RunID] == nil {
+			logging.WithStacktrace(ctx, err).Errorf("failing job %s since the error of run %s is corrupt", corruptRow.JobID, corruptRow.RunID)
+			corruptRows[corruptRow.RunID] = corruptRow
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for runId, corruptRow := range corruptRows {
+			if runErrorsByRunId == nil {
+				runErrorsByRunId = make(map[uuid.UUID]*armadaevents.Error, len(corruptRows))
+			}
+			runErrorsByRunId[runId] = corruptRunError(corruptRow)
+		}
+		return runErrorsByRunId, nil
+	}
+}
+
+func corruptRunError(corruptRow *database.ErrCorruptRow) *armadaevents.Error {
+	return &armadaevents.Error{
+		Terminal: true,
+		Reason: &armadaevents.Error_PodError{
+			PodError: &armadaevents.PodError{
+				Message: fmt.Sprintf("Job run failed but its error couldn't be read from the database: %s", corruptRow.Err),
+			},
+		},
+	}
+}
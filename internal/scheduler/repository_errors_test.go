@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_RetryTransient(t *testing.T) {
+	tests := map[string]struct {
+		errs             []error
+		expectedAttempts int
+		expectedErr      error
+	}{
+		"success": {
+			expectedAttempts: 1,
+		},
+		"transient errors then success": {
+			errs:             []error{errors.WithStack(database.ErrTransient), errors.WithStack(database.ErrTransient)},
+			expectedAttempts: 3,
+		},
+		"non-transient error isn't retried": {
+			errs:             []error{database.ErrNotFound},
+			expectedAttempts: 1,
+			expectedErr:      database.ErrNotFound,
+		},
+		"transient errors exceeding the cycle period": {
+			errs: func() []error {
+				errs := make([]error, 100)
+				for i := range errs {
+					errs[i] = errors.WithStack(database.ErrTransient)
+				}
+				return errs
+			}(),
+			expectedAttempts: 11,
+			expectedErr:      database.ErrTransient,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sched := &Scheduler{clock: clock.NewFakeClock(time.Now()), cyclePeriod: time.Second}
+			attempts := 0
+			err := sched.retryTransient(armadacontext.Background(), "test", func() error {
+				attempts++
+				if attempts <= len(tc.errs) {
+					return tc.errs[attempts-1]
+				}
+				return nil
+			})
+			assert.Equal(t, tc.expectedAttempts, attempts)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestScheduler_FetchJobRunErrors_CorruptRows(t *testing.T) {
+	okRunId := uuid.New()
+	corruptRunId := uuid.New()
+	sched := &Scheduler{
+		clock:       clock.NewFakeClock(time.Now()),
+		cyclePeriod: time.Second,
+		jobRepository: &testJobRepository{
+			errors:        map[uuid.UUID]*armadaevents.Error{okRunId: defaultJobRunError},
+			corruptRunIds: map[uuid.UUID]bool{corruptRunId: true},
+		},
+	}
+	runErrors, err := sched.fetchJobRunErrors(armadacontext.Background(), []uuid.UUID{okRunId, corruptRunId})
+	require.NoError(t, err)
+	assert.Equal(t, defaultJobRunError, runErrors[okRunId])
+	require.NotNil(t, runErrors[corruptRunId])
+	assert.True(t, runErrors[corruptRunId].Terminal)
+}
+
+func TestScheduler_CycleHealthCheck(t *testing.T) {
+	sched := &Scheduler{}
+	transientErr := errors.Wrap(database.ErrTransient, "connection refused")
+
+	// Disabled by default.
+	for i := 0; i < 5; i++ {
+		sched.recordCycleOutcome(transientErr)
+	}
+	assert.NoError(t, sched.Check())
+
+	sched = &Scheduler{}
+	sched.EnableCycleHealthCheck(3)
+	sched.recordCycleOutcome(transientErr)
+	sched.recordCycleOutcome(transientErr)
+	assert.NoError(t, sched.Check())
+
+	// Other errors don't affect the count.
+	sched.recordCycleOutcome(errors.New("publish failed"))
+	assert.NoError(t, sched.Check())
+
+	sched.recordCycleOutcome(transientErr)
+	assert.Error(t, sched.Check())
+
+	// A successful cycle resets the count.
+	sched.recordCycleOutcome(nil)
+	assert.NoError(t, sched.Check())
+}
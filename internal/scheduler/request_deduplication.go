@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+// responseCache remembers the response to each request for a fixed window, such that repeated requests,
+// e.g., requests retried by executors that timed out waiting for a response the server did send,
+// can be answered without serving them again. At most maxEntries responses are remembered at once;
+// once that limit is reached, the oldest responses are forgotten first.
+type responseCache[T any] struct {
+	window     time.Duration
+	maxEntries int
+	// Remembered responses, indexed by the key of the request.
+	responsesByKey map[string]cachedResponse[T]
+	// Keys of remembered responses in the order they were added, which is also the order in which they expire.
+	// May contain keys of responses already forgotten or replaced, in which case added doesn't match.
+	keys  []cachedResponseKey
+	clock clock.Clock
+	mu    sync.Mutex
+}
+
+type cachedResponse[T any] struct {
+	response T
+	added    time.Time
+}
+
+type cachedResponseKey struct {
+	key   string
+	added time.Time
+}
+
+func newResponseCache[T any](window time.Duration, maxEntries int) *responseCache[T] {
+	return &responseCache[T]{
+		window:         window,
+		maxEntries:     maxEntries,
+		responsesByKey: make(map[string]cachedResponse[T]),
+		clock:          clock.RealClock{},
+	}
+}
+
+// Get returns the response remembered for key, if it hasn't expired.
+func (c *responseCache[T]) Get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expire(c.clock.Now())
+	entry, ok := c.responsesByKey[key]
+	return entry.response, ok
+}
+
+// Put remembers response as the response for key.
+func (c *responseCache[T]) Put(key string, response T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	c.expire(now)
+	for len(c.responsesByKey) >= c.maxEntries && len(c.keys) > 0 {
+		c.popOldest()
+	}
+	c.responsesByKey[key] = cachedResponse[T]{response: response, added: now}
+	c.keys = append(c.keys, cachedResponseKey{key: key, added: now})
+}
+
+// expire forgets all responses that have been remembered for at least the window as of now.
+func (c *responseCache[T]) expire(now time.Time) {
+	for len(c.keys) > 0 && now.Sub(c.keys[0].added) >= c.window {
+		c.popOldest()
+	}
+}
+
+func (c *responseCache[T]) popOldest() {
+	oldest := c.keys[0]
+	c.keys[0] = cachedResponseKey{}
+	c.keys = c.keys[1:]
+	if entry, ok := c.responsesByKey[oldest.key]; ok && entry.added.Equal(oldest.added) {
+		delete(c.responsesByKey, oldest.key)
+	}
+}
+
+// recordingLeaseStream records the messages sent on a lease stream, such that they can be re-sent in response to
+// repeated lease requests.
+type recordingLeaseStream struct {
+	executorapi.ExecutorApi_LeaseJobRunsServer
+	messages []*executorapi.LeaseStreamMessage
+}
+
+func (stream *recordingLeaseStream) Send(msg *executorapi.LeaseStreamMessage) error {
+	if err := stream.ExecutorApi_LeaseJobRunsServer.Send(msg); err != nil {
+		return err
+	}
+	stream.messages = append(stream.messages, msg)
+	return nil
+}
+
+// EnableRequestDeduplication causes lease requests and event reports repeated within window to be answered with the
+// response to the original request, without computing leases or publishing events again.
+// Lease requests are considered repeated if made by the same executor with the same request id, which executors only
+// reuse when retrying a request they received no response to; event reports if they contain identical events.
+// At most maxEntries responses of each kind are remembered at once.
+func (srv *ExecutorApi) EnableRequestDeduplication(window time.Duration, maxEntries int) {
+	srv.leaseResponses = newResponseCache[[]*executorapi.LeaseStreamMessage](window, maxEntries)
+	srv.reportedEvents = newResponseCache[struct{}](window, maxEntries)
+}
+
+// leaseJobRunsDeduplicated re-sends the response to the previous request with the same id, if it was served within the
+// deduplication window, and otherwise serves req using leaseJobRuns and remembers the response.
+// In either case, the state of the executor is stored as of req.
+func (srv *ExecutorApi) leaseJobRunsDeduplicated(
+	ctx *armadacontext.Context,
+	stream executorapi.ExecutorApi_LeaseJobRunsServer,
+	req *executorapi.LeaseRequest,
+) error {
+	if req.RequestId == 0 {
+		// Executors that don't set request ids can't be told apart from retries.
+		return srv.leaseJobRuns(ctx, stream, req)
+	}
+	key := leaseRequestKey(req)
+	messages, ok := srv.leaseResponses.Get(key)
+	if !ok {
+		recorder := &recordingLeaseStream{ExecutorApi_LeaseJobRunsServer: stream}
+		if err := srv.leaseJobRuns(ctx, recorder, req); err != nil {
+			return err
+		}
+		srv.leaseResponses.Put(key, recorder.messages)
+		return nil
+	}
+
+	// The executor's state may have changed since the original request, e.g., since runs finished.
+	if _, ok, err := srv.storeExecutor(ctx, req); err != nil {
+		return err
+	} else if !ok {
+		return sendEndMarkerOnly(stream)
+	}
+	ctx.Infof("lease request %d repeated within %s; re-sending the previous response", req.RequestId, srv.leaseResponses.window)
+	for _, msg := range messages {
+		if err := stream.Send(msg); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// leaseRequestKey returns a key identifying lease requests made by the same executor with the same request id.
+func leaseRequestKey(req *executorapi.LeaseRequest) string {
+	return fmt.Sprintf("%s/%d", req.ExecutorId, req.RequestId)
+}
+
+// eventListKey returns a key identifying event reports containing identical events.
+func eventListKey(list *executorapi.EventList) (string, error) {
+	b, err := proto.Marshal(list)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	h := fnv.New128a()
+	h.Write(b)
+	return string(h.Sum(nil)), nil
+}
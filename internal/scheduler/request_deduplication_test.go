@@ -0,0 +1,211 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/mocks"
+	"github.com/armadaproject/armada/internal/common/pulsarutils"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+	"github.com/armadaproject/armada/pkg/executorapi"
+)
+
+func TestResponseCache(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	cache := newResponseCache[int](time.Minute, 2)
+	cache.clock = fakeClock
+
+	cache.Put("a", 1)
+	fakeClock.Step(30 * time.Second)
+	cache.Put("b", 2)
+	response, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, response)
+
+	// The oldest response is forgotten once the cache is full.
+	fakeClock.Step(15 * time.Second)
+	cache.Put("c", 3)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+
+	// Responses are forgotten once the window has passed.
+	fakeClock.Step(45 * time.Second)
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	response, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, response)
+
+	// Replacing a response restarts its window.
+	cache.Put("c", 4)
+	fakeClock.Step(45 * time.Second)
+	response, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 4, response)
+	assert.Len(t, cache.responsesByKey, 1)
+}
+
+func TestExecutorApi_LeaseJobRuns_RequestDeduplication(t *testing.T) {
+	const executorId = "test-executor"
+	_, compressedSubmit := submitMsg(t, "node-id")
+	lease := &database.JobRunLease{
+		RunID:         uuid.New(),
+		Queue:         "test-queue",
+		JobSet:        "test-jobset",
+		UserID:        "test-user",
+		Node:          "node-id",
+		SubmitMessage: compressedSubmit,
+	}
+	heldRunId := uuid.New()
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	server, err := NewExecutorApi(
+		mocks.NewMockProducer(ctrl),
+		mockJobRepository,
+		mockExecutorRepository,
+		mockExecutorRepository,
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+	server.EnableRequestDeduplication(time.Minute, 10)
+	fakeClock := clock.NewFakeClock(time.Now())
+	server.leaseResponses.clock = fakeClock
+
+	// leaseJobRuns makes a lease request with requestId as an executor holding the provided runs
+	// and returns the messages received.
+	leaseJobRuns := func(requestId uint64, runIds ...uuid.UUID) []*executorapi.LeaseStreamMessage {
+		request := &executorapi.LeaseRequest{ExecutorId: executorId, Pool: "test-pool", MaxJobsToLease: 10, RequestId: requestId}
+		for _, runId := range runIds {
+			request.UnassignedJobRunIds = append(request.UnassignedJobRunIds, *armadaevents.ProtoUuidFromUuid(runId))
+		}
+		mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+		mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+		mockStream.EXPECT().Recv().Return(request, nil).Times(1)
+		var messages []*executorapi.LeaseStreamMessage
+		mockStream.EXPECT().Send(gomock.Any()).
+			Do(func(msg *executorapi.LeaseStreamMessage) {
+				messages = append(messages, msg)
+			}).AnyTimes()
+		require.NoError(t, server.LeaseJobRuns(mockStream))
+		return messages
+	}
+
+	// The first request is served as usual.
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), []uuid.UUID{heldRunId}).Return(nil, nil).Times(1)
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), executorId, uint(10), []uuid.UUID{heldRunId}).Return([]*database.JobRunLease{lease}, nil).Times(1)
+	messages := leaseJobRuns(1, heldRunId)
+	require.Len(t, messages, 2)
+	require.NotNil(t, messages[0].GetLease())
+	assert.Equal(t, armadaevents.ProtoUuidFromUuid(lease.RunID), messages[0].GetLease().JobRunId)
+
+	// A retried request is answered with the same response without computing leases again,
+	// but the state of the executor as of the retry is still stored.
+	var storedExecutors []*schedulerobjects.Executor
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).
+		Do(func(_ *armadacontext.Context, executor *schedulerobjects.Executor) {
+			storedExecutors = append(storedExecutors, executor)
+		}).Return(nil).Times(2)
+	assert.Equal(t, messages, leaseJobRuns(1))
+	require.Len(t, storedExecutors, 2)
+	assert.Empty(t, storedExecutors[0].UnassignedJobRuns)
+
+	// Requests with other ids are served as usual, even if the executor holds the same runs.
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), []uuid.UUID{heldRunId}).Return(nil, nil).Times(1)
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), executorId, uint(10), []uuid.UUID{heldRunId}).Return(nil, nil).Times(1)
+	assert.Len(t, leaseJobRuns(2, heldRunId), 1)
+
+	// Requests without an id are never deduplicated.
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), []uuid.UUID{heldRunId}).Return(nil, nil).Times(2)
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), executorId, uint(10), []uuid.UUID{heldRunId}).Return(nil, nil).Times(2)
+	assert.Len(t, leaseJobRuns(0, heldRunId), 1)
+	assert.Len(t, leaseJobRuns(0, heldRunId), 1)
+
+	// Retried requests are served again once the window has passed.
+	fakeClock.Step(time.Minute)
+	mockExecutorRepository.EXPECT().StoreExecutor(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), []uuid.UUID{heldRunId}).Return(nil, nil).Times(1)
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), executorId, uint(10), []uuid.UUID{heldRunId}).Return(nil, nil).Times(1)
+	assert.Len(t, leaseJobRuns(2, heldRunId), 1)
+}
+
+func TestExecutorApi_ReportEvents_RequestDeduplication(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	numSent := 0
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			numSent++
+			callback(pulsarutils.NewMessageId(1), msg, nil)
+		}).AnyTimes()
+	server, err := NewExecutorApi(
+		mockPulsarProducer,
+		schedulermocks.NewMockJobRepository(ctrl),
+		schedulermocks.NewMockExecutorRepository(ctrl),
+		schedulermocks.NewMockExecutorRepository(ctrl),
+		[]int32{1000, 2000},
+		"kubernetes.io/hostname",
+		nil,
+		4*1024*1024,
+	)
+	require.NoError(t, err)
+	server.EnableRequestDeduplication(time.Minute, 10)
+
+	eventList := func() *executorapi.EventList {
+		return &executorapi.EventList{
+			Events: []*armadaevents.EventSequence{
+				{
+					Queue:      "queue",
+					JobSetName: "jobset",
+					Events: []*armadaevents.EventSequence_Event{
+						{
+							Event: &armadaevents.EventSequence_Event_JobRunSucceeded{
+								JobRunSucceeded: &armadaevents.JobRunSucceeded{
+									RunId: armadaevents.ProtoUuidFromUuid(uuid.New()),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	list := eventList()
+	_, err = server.ReportEvents(ctx, list)
+	require.NoError(t, err)
+	assert.Equal(t, 1, numSent)
+
+	// Replaying an identical report doesn't publish its events again.
+	_, err = server.ReportEvents(ctx, list)
+	require.NoError(t, err)
+	assert.Equal(t, 1, numSent)
+
+	// Other reports are published as usual.
+	_, err = server.ReportEvents(ctx, eventList())
+	require.NoError(t, err)
+	assert.Equal(t, 2, numSent)
+}
@@ -1,10 +1,29 @@
 package scheduler
 
 import (
+	"github.com/pkg/errors"
+
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
 )
 
+// Placement describes the node a job should be scheduled on or, for preempted jobs, the node it was running on.
+// Runs created for scheduled jobs and the events announcing their leases are both derived from it,
+// such that the two can't disagree.
+type Placement struct {
+	// Id of the executor the node belongs to.
+	Executor string
+	// Id of the node within the scheduler.
+	NodeId string
+	// Name of the node within the executor cluster.
+	NodeName string
+	// Priority at which the job is scheduled on the node.
+	ScheduledAtPriority int32
+	// Pool the job is scheduled in.
+	Pool string
+}
+
 // SchedulerResult is returned by Rescheduler.Schedule().
 type SchedulerResult struct {
 	// Running jobs that should be preempted.
@@ -14,9 +33,9 @@ type SchedulerResult struct {
 	// Queued jobs that could not be scheduled.
 	// This is used to fail jobs that could not schedule above `minimumGangCardinality`.
 	FailedJobs []*schedulercontext.JobSchedulingContext
-	// For each preempted job, maps the job id to the id of the node on which the job was running.
-	// For each scheduled job, maps the job id to the id of the node on which the job should be scheduled.
-	NodeIdByJobId map[string]string
+	// For each preempted job, maps the job id to where the job was running.
+	// For each scheduled job, maps the job id to where the job should be scheduled.
+	PlacementByJobId map[string]Placement
 	// Each result may bundle the result of several scheduling decisions.
 	// These are the corresponding scheduling contexts.
 	// TODO: This doesn't seem like the right approach.
@@ -26,6 +45,52 @@ type SchedulerResult struct {
 	AdditionalAnnotationsByJobId map[string]map[string]string
 }
 
+// NodeIdByJobId returns, for each preempted or scheduled job, the id of the node the job was running on
+// or should be scheduled on, respectively.
+func (sr *SchedulerResult) NodeIdByJobId() map[string]string {
+	rv := make(map[string]string, len(sr.PlacementByJobId))
+	for jobId, placement := range sr.PlacementByJobId {
+		rv[jobId] = placement.NodeId
+	}
+	return rv
+}
+
+// addPlacementsFromNodeDb adds to placementByJobId the placement of each of jctxs on the node nodeIdByJobId maps it to,
+// with node details and scheduled-at priority taken from nodeDb.
+func addPlacementsFromNodeDb(
+	placementByJobId map[string]Placement,
+	nodeDb *nodedb.NodeDb,
+	pool string,
+	nodeIdByJobId map[string]string,
+	jctxs []*schedulercontext.JobSchedulingContext,
+) error {
+	for _, jctx := range jctxs {
+		nodeId := nodeIdByJobId[jctx.JobId]
+		if nodeId == "" {
+			return errors.Errorf("job %s not mapped to a node", jctx.JobId)
+		}
+		node, err := nodeDb.GetNode(nodeId)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return errors.Errorf("job %s mapped to node %s, which doesn't exist", jctx.JobId, nodeId)
+		}
+		priority, ok := nodeDb.GetScheduledAtPriority(jctx.JobId)
+		if !ok {
+			return errors.Errorf("job %s not mapped to a priority", jctx.JobId)
+		}
+		placementByJobId[jctx.JobId] = Placement{
+			Executor:            node.Executor,
+			NodeId:              node.Id,
+			NodeName:            node.Name,
+			ScheduledAtPriority: priority,
+			Pool:                pool,
+		}
+	}
+	return nil
+}
+
 // PreemptedJobsFromSchedulerResult returns the slice of preempted jobs in the result cast to type T.
 func PreemptedJobsFromSchedulerResult[T interfaces.LegacySchedulerJob](sr *SchedulerResult) []T {
 	rv := make([]T, len(sr.PreemptedJobs))
@@ -0,0 +1,280 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// Run error summaries longer than this are truncated when included in notifications.
+const maxRetryExhaustionErrorLength = 1024
+
+// RetryExhaustion describes a job failed since it was attempted the maximum number of times.
+// It's the body posted to the webhook.
+type RetryExhaustion struct {
+	Queue        string `json:"queue"`
+	JobSet       string `json:"jobSet"`
+	JobId        string `json:"jobId"`
+	Attempts     uint   `json:"attempts"`
+	LastRunId    string `json:"lastRunId"`
+	LastRunError string `json:"lastRunError,omitempty"`
+}
+
+// RetryExhaustionNotifier notifies about jobs failed since they were attempted the maximum number of times,
+// by publishing a JobRetriesExhausted event, posting to a webhook, or both; see schedulerconfig.RetryExhaustionNotificationsConfig.
+// Notifications are rate-limited per job set, such that a job set in which many jobs fail at once doesn't cause a storm.
+//
+// Webhook notifications are staged while a cycle generates its events and only queued for posting once the cycle
+// has published them, such that a failed cycle doesn't post the same notification twice.
+// Notifications are posted in the background; failing to post one is logged and never affects the cycle.
+type RetryExhaustionNotifier struct {
+	mode    schedulerconfig.RetryExhaustionNotificationMode
+	webhook schedulerconfig.RetryExhaustionWebhookConfig
+	// Maximum rate and burst of notifications per job set.
+	rateLimit rate.Limit
+	burst     int
+	// Maps jobSetKey to the *rate.Limiter of that job set.
+	limiterByJobSet *lru.Cache
+	// Webhook notifications of the current cycle, queued for posting once its events are published.
+	staged []*RetryExhaustion
+	// Webhook notifications waiting to be posted.
+	pending chan *RetryExhaustion
+	client  *http.Client
+	mu      sync.Mutex
+}
+
+func NewRetryExhaustionNotifier(config schedulerconfig.RetryExhaustionNotificationsConfig) (*RetryExhaustionNotifier, error) {
+	limiterByJobSet, err := lru.New(config.MaxJobSets)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	mode := config.Mode
+	if mode == "" {
+		mode = schedulerconfig.RetryExhaustionNotificationEvent
+	}
+	n := &RetryExhaustionNotifier{
+		mode:            mode,
+		webhook:         config.Webhook,
+		rateLimit:       rate.Limit(config.MaxPerJobSetRate),
+		burst:           config.MaxPerJobSetBurst,
+		limiterByJobSet: limiterByJobSet,
+		client:          &http.Client{},
+	}
+	if n.postsToWebhook() {
+		if config.Webhook.Url == "" {
+			return nil, errors.Errorf("retry exhaustion notifications are posted to a webhook, but no webhook url is configured")
+		}
+		n.pending = make(chan *RetryExhaustion, config.Webhook.MaxPending)
+	}
+	return n, nil
+}
+
+func (n *RetryExhaustionNotifier) publishesEvents() bool {
+	return n.mode == schedulerconfig.RetryExhaustionNotificationEvent || n.mode == schedulerconfig.RetryExhaustionNotificationBoth
+}
+
+func (n *RetryExhaustionNotifier) postsToWebhook() bool {
+	return n.mode == schedulerconfig.RetryExhaustionNotificationWebhook || n.mode == schedulerconfig.RetryExhaustionNotificationBoth
+}
+
+// Notify notifies about job having been failed at time now since it was attempted the maximum number of times,
+// where runError is the error of its last run, if any, unless the job set of the job has exceeded its rate limit.
+// Returns the events to publish alongside those failing the job; webhook notifications are staged.
+func (n *RetryExhaustionNotifier) Notify(
+	now time.Time,
+	job *jobdb.Job,
+	jobId *armadaevents.Uuid,
+	runId uuid.UUID,
+	runError *armadaevents.Error,
+) []*armadaevents.EventSequence_Event {
+	if !n.allow(now, job.Queue(), job.Jobset()) {
+		return nil
+	}
+	lastRunError := runErrorSummary(runError)
+	if n.postsToWebhook() {
+		n.mu.Lock()
+		n.staged = append(n.staged, &RetryExhaustion{
+			Queue:        job.Queue(),
+			JobSet:       job.Jobset(),
+			JobId:        job.Id(),
+			Attempts:     job.NumAttempts(),
+			LastRunId:    runId.String(),
+			LastRunError: lastRunError,
+		})
+		n.mu.Unlock()
+	}
+	if !n.publishesEvents() {
+		return nil
+	}
+	return []*armadaevents.EventSequence_Event{
+		{
+			Created: &now,
+			Event: &armadaevents.EventSequence_Event_JobRetriesExhausted{
+				JobRetriesExhausted: &armadaevents.JobRetriesExhausted{
+					JobId:        jobId,
+					Attempts:     uint32(job.NumAttempts()),
+					LastRunId:    armadaevents.ProtoUuidFromUuid(runId),
+					LastRunError: lastRunError,
+				},
+			},
+		},
+	}
+}
+
+// allow returns true if a notification for the given job set at time now is within its rate limit.
+func (n *RetryExhaustionNotifier) allow(now time.Time, queue, jobSet string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	key := jobSetKey{queue: queue, jobSet: jobSet}
+	var limiter *rate.Limiter
+	if v, ok := n.limiterByJobSet.Get(key); ok {
+		limiter = v.(*rate.Limiter)
+	} else {
+		limiter = rate.NewLimiter(n.rateLimit, n.burst)
+		n.limiterByJobSet.Add(key, limiter)
+	}
+	return limiter.AllowN(now, 1)
+}
+
+// DiscardStaged discards the webhook notifications staged by a cycle that failed to publish its events.
+// Jobs are failed again by the next cycle, which notifies about them again, subject to the rate limit.
+func (n *RetryExhaustionNotifier) DiscardStaged() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.staged = nil
+}
+
+// Flush queues the staged webhook notifications for posting, once the cycle that staged them has published its events.
+// Notifications beyond the maximum number pending are dropped.
+func (n *RetryExhaustionNotifier) Flush(ctx *armadacontext.Context) {
+	n.mu.Lock()
+	staged := n.staged
+	n.staged = nil
+	n.mu.Unlock()
+	for _, notification := range staged {
+		select {
+		case n.pending <- notification:
+		default:
+			ctx.Warnf("dropping retry exhaustion notification for job %s, since too many notifications are pending", notification.JobId)
+		}
+	}
+}
+
+// Run posts queued notifications to the webhook, at most MaxConcurrency at once,
+// until ctx is cancelled. Returns immediately if notifications aren't posted to a webhook.
+func (n *RetryExhaustionNotifier) Run(ctx *armadacontext.Context) error {
+	if !n.postsToWebhook() {
+		return nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < n.webhook.MaxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case notification := <-n.pending:
+					if err := n.post(ctx, notification); err != nil {
+						logging.
+							WithStacktrace(ctx, err).
+							Warnf("failed to post retry exhaustion notification for job %s", notification.JobId)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// post posts notification to the webhook, retrying with exponential backoff up to MaxRetries times.
+func (n *RetryExhaustionNotifier) post(ctx *armadacontext.Context, notification *RetryExhaustion) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	backoff := n.webhook.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err = n.postOnce(ctx, body)
+		if err == nil || attempt >= n.webhook.MaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (n *RetryExhaustionNotifier) postOnce(ctx *armadacontext.Context, body []byte) error {
+	var requestCtx context.Context = ctx
+	if n.webhook.Timeout > 0 {
+		var cancel context.CancelFunc
+		requestCtx, cancel = context.WithTimeout(ctx, n.webhook.Timeout)
+		defer cancel()
+	}
+	request, err := http.NewRequestWithContext(requestCtx, http.MethodPost, n.webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := n.client.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %s", response.Status)
+	}
+	return nil
+}
+
+// runErrorSummary returns the messages of runError, truncated, or the empty string if runError is nil.
+func runErrorSummary(runError *armadaevents.Error) string {
+	var messages []string
+	switch reason := runError.GetReason().(type) {
+	case *armadaevents.Error_PodError:
+		messages = append(messages, reason.PodError.GetMessage())
+		for _, containerError := range reason.PodError.GetContainerErrors() {
+			messages = append(messages, containerError.GetMessage())
+		}
+	case *armadaevents.Error_PodLeaseReturned:
+		messages = append(messages, reason.PodLeaseReturned.GetMessage())
+	case *armadaevents.Error_PodTerminated:
+		messages = append(messages, reason.PodTerminated.GetMessage())
+	case *armadaevents.Error_PodUnschedulable:
+		messages = append(messages, reason.PodUnschedulable.GetMessage())
+	case *armadaevents.Error_MaxRunsExceeded:
+		messages = append(messages, reason.MaxRunsExceeded.GetMessage())
+	}
+	var nonEmptyMessages []string
+	for _, message := range messages {
+		if message != "" {
+			nonEmptyMessages = append(nonEmptyMessages, message)
+		}
+	}
+	summary := strings.Join(nonEmptyMessages, "; ")
+	if len(summary) > maxRetryExhaustionErrorLength {
+		summary = summary[:maxRetryExhaustionErrorLength-3] + "..."
+	}
+	return summary
+}
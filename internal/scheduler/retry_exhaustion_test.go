@@ -0,0 +1,273 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func testRetryExhaustionNotificationsConfig() schedulerconfig.RetryExhaustionNotificationsConfig {
+	return schedulerconfig.RetryExhaustionNotificationsConfig{
+		Enabled:           true,
+		Mode:              schedulerconfig.RetryExhaustionNotificationEvent,
+		MaxPerJobSetRate:  1.0 / 60,
+		MaxPerJobSetBurst: 2,
+		MaxJobSets:        10,
+		Webhook: schedulerconfig.RetryExhaustionWebhookConfig{
+			Timeout:        time.Second,
+			MaxConcurrency: 2,
+			MaxRetries:     2,
+			RetryBackoff:   time.Millisecond,
+			MaxPending:     10,
+		},
+	}
+}
+
+// retriesExhaustedJob returns a job of the given job set that was returned from attempted runs maxNumberOfAttempts times.
+func retriesExhaustedJob(jobSet string) *jobdb.Job {
+	job := testfixtures.JobDb.NewJob(
+		util.NewULID(), jobSet, "testQueue", 0, schedulingInfo, false, 0, false, false, false, 1,
+	)
+	for i := 0; i < maxNumberOfAttempts; i++ {
+		job = job.WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+		job = job.WithUpdatedRun(job.LatestRun().WithFailed(true).WithReturned(true).WithAttempted(true))
+	}
+	return job
+}
+
+func TestRetryExhaustionNotifier_RateLimitedPerJobSet(t *testing.T) {
+	notifier, err := NewRetryExhaustionNotifier(testRetryExhaustionNotificationsConfig())
+	require.NoError(t, err)
+	notify := func(now time.Time, jobSet string) bool {
+		job := retriesExhaustedJob(jobSet)
+		jobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
+		require.NoError(t, err)
+		return len(notifier.Notify(now, job, jobId, job.LatestRun().Id(), nil)) > 0
+	}
+
+	// Up to the burst is notified about at once; further jobs of the same job set aren't.
+	now := testfixtures.BaseTime
+	assert.True(t, notify(now, "jobSet-a"))
+	assert.True(t, notify(now, "jobSet-a"))
+	assert.False(t, notify(now, "jobSet-a"))
+	// Other job sets have their own limit.
+	assert.True(t, notify(now, "jobSet-b"))
+	// Another notification is allowed once the rate allows.
+	assert.False(t, notify(now.Add(30*time.Second), "jobSet-a"))
+	assert.True(t, notify(now.Add(time.Minute), "jobSet-a"))
+	assert.False(t, notify(now.Add(time.Minute), "jobSet-a"))
+}
+
+func TestRetryExhaustionNotifier_Webhook(t *testing.T) {
+	tests := map[string]struct {
+		// Number of requests the webhook fails before succeeding.
+		numFailures int
+		// Number of requests expected to be made.
+		expectedRequests int
+		// If true, the webhook is expected to have eventually received the notification.
+		expectedDelivered bool
+	}{
+		"succeeds first time": {
+			numFailures:       0,
+			expectedRequests:  1,
+			expectedDelivered: true,
+		},
+		"succeeds on retry": {
+			numFailures:       2,
+			expectedRequests:  3,
+			expectedDelivered: true,
+		},
+		"retries exhausted": {
+			numFailures:       5,
+			expectedRequests:  3,
+			expectedDelivered: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			numRequests := 0
+			var delivered []RetryExhaustion
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				numRequests++
+				if numRequests <= tc.numFailures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				var notification RetryExhaustion
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+				delivered = append(delivered, notification)
+			}))
+			defer server.Close()
+
+			config := testRetryExhaustionNotificationsConfig()
+			config.Mode = schedulerconfig.RetryExhaustionNotificationWebhook
+			config.Webhook.Url = server.URL
+			notifier, err := NewRetryExhaustionNotifier(config)
+			require.NoError(t, err)
+			ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+			defer cancel()
+			go func() {
+				assert.NoError(t, notifier.Run(ctx))
+			}()
+
+			job := retriesExhaustedJob("jobSet")
+			jobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
+			require.NoError(t, err)
+			runError := &armadaevents.Error{
+				Reason: &armadaevents.Error_PodLeaseReturned{PodLeaseReturned: &armadaevents.PodLeaseReturned{Message: "lease returned"}},
+			}
+			// No event is published in webhook-only mode, and nothing is posted until the staged notification is flushed.
+			assert.Empty(t, notifier.Notify(testfixtures.BaseTime, job, jobId, job.LatestRun().Id(), runError))
+			notifier.Flush(ctx)
+
+			assert.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return numRequests >= tc.expectedRequests
+			}, 5*time.Second, time.Millisecond)
+			// Give the notifier the chance to make requests beyond those expected.
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, tc.expectedRequests, numRequests)
+			if tc.expectedDelivered {
+				assert.Equal(
+					t,
+					[]RetryExhaustion{
+						{
+							Queue:        "testQueue",
+							JobSet:       "jobSet",
+							JobId:        job.Id(),
+							Attempts:     maxNumberOfAttempts,
+							LastRunId:    job.LatestRun().Id().String(),
+							LastRunError: "lease returned",
+						},
+					},
+					delivered,
+				)
+			} else {
+				assert.Empty(t, delivered)
+			}
+		})
+	}
+}
+
+func TestRetryExhaustionNotifier_DiscardStaged(t *testing.T) {
+	config := testRetryExhaustionNotificationsConfig()
+	config.Mode = schedulerconfig.RetryExhaustionNotificationBoth
+	config.Webhook.Url = "http://localhost"
+	notifier, err := NewRetryExhaustionNotifier(config)
+	require.NoError(t, err)
+
+	job := retriesExhaustedJob("jobSet")
+	jobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
+	require.NoError(t, err)
+	assert.Len(t, notifier.Notify(testfixtures.BaseTime, job, jobId, job.LatestRun().Id(), nil), 1)
+
+	// Notifications of a cycle that failed to publish are never posted.
+	notifier.DiscardStaged()
+	notifier.Flush(armadacontext.Background())
+	assert.Empty(t, notifier.pending)
+}
+
+func TestScheduler_RetryExhaustionEvents(t *testing.T) {
+	tests := map[string]struct {
+		job *jobdb.Job
+		// If true, a JobRetriesExhausted event is expected alongside the JobErrors event failing the job.
+		expectNotification bool
+	}{
+		"attempted maximum number of times": {
+			job:                retriesExhaustedJob("jobSet"),
+			expectNotification: true,
+		},
+		"failed without being returned": {
+			job: func() *jobdb.Job {
+				job := retriesExhaustedJob("jobSet").WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+				return job.WithUpdatedRun(job.LatestRun().WithFailed(true))
+			}(),
+			expectNotification: false,
+		},
+		"fail fast": {
+			job: func() *jobdb.Job {
+				info := proto.Clone(schedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+				info.GetPodRequirements().Annotations = map[string]string{configuration.FailFastAnnotation: "true"}
+				return retriesExhaustedJob("jobSet").WithJobSchedulingInfo(info)
+			}(),
+			expectNotification: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				nil,
+				nil,
+				nil,
+				NewStandaloneLeaderController(),
+				nil,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = clock.NewFakeClock(testfixtures.BaseTime)
+			notifier, err := NewRetryExhaustionNotifier(testRetryExhaustionNotificationsConfig())
+			require.NoError(t, err)
+			sched.EnableRetryExhaustionNotifications(notifier)
+
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{tc.job}))
+			runErrors := map[uuid.UUID]*armadaevents.Error{tc.job.LatestRun().Id(): defaultJobRunError}
+			sequence, err := sched.generateUpdateMessagesFromJob(armadacontext.Background(), tc.job, runErrors, txn)
+			require.NoError(t, err)
+			require.NotNil(t, sequence)
+
+			var jobErrors []*armadaevents.JobErrors
+			var retriesExhausted []*armadaevents.JobRetriesExhausted
+			for _, event := range sequence.Events {
+				if e := event.GetJobErrors(); e != nil {
+					jobErrors = append(jobErrors, e)
+				}
+				if e := event.GetJobRetriesExhausted(); e != nil {
+					retriesExhausted = append(retriesExhausted, e)
+				}
+			}
+			assert.Len(t, jobErrors, 1)
+			if tc.expectNotification {
+				require.Len(t, retriesExhausted, 1)
+				assert.Equal(t, tc.job.Id(), ulidStringFromProtoUuid(t, retriesExhausted[0].JobId))
+				assert.Equal(t, uint32(maxNumberOfAttempts), retriesExhausted[0].Attempts)
+				assert.Equal(t, armadaevents.ProtoUuidFromUuid(tc.job.LatestRun().Id()), retriesExhausted[0].LastRunId)
+				assert.Equal(t, runErrorSummary(defaultJobRunError), retriesExhausted[0].LastRunError)
+			} else {
+				assert.Empty(t, retriesExhausted)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	parquetWriter "github.com/xitongsys/parquet-go/writer"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/common/metrics"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// RunAnalyticsSchemaVersion is the version of the schema of RunAnalyticsRecord. It must be incremented whenever columns
+// are changed or removed, such that files written with different schemas are kept apart; see RunAnalyticsConfig.Path.
+const RunAnalyticsSchemaVersion = 1
+
+// StateTransitionListener is notified of the state transitions of the jobs reconciled with the job repository
+// in each cycle the scheduler is leader in; see Scheduler.AddStateTransitionListener.
+type StateTransitionListener interface {
+	// OnStateTransitions is called with the state transitions of a cycle, as observed at time now.
+	// It's called on the cycle goroutine, and so mustn't block.
+	OnStateTransitions(ctx *armadacontext.Context, now time.Time, jsts []jobdb.JobStateTransitions)
+}
+
+// AddStateTransitionListener causes listener to be notified of the state transitions of each cycle.
+// Must be called before the scheduler is run.
+func (s *Scheduler) AddStateTransitionListener(listener StateTransitionListener) {
+	s.stateTransitionListeners = append(s.stateTransitionListeners, listener)
+}
+
+// RunAnalyticsRecord describes a completed run; see RunAnalyticsExporter.
+type RunAnalyticsRecord struct {
+	SchemaVersion int32  `parquet:"name=schema_version, type=INT32"`
+	Queue         string `parquet:"name=queue, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	JobSet        string `parquet:"name=job_set, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	JobId         string `parquet:"name=job_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	RunId         string `parquet:"name=run_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Executor      string `parquet:"name=executor, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Node          string `parquet:"name=node, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	PriorityClass string `parquet:"name=priority_class, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	// Resources requested by the job.
+	Cpu              float64 `parquet:"name=cpu, type=DOUBLE"`
+	Memory           float64 `parquet:"name=memory, type=DOUBLE"`
+	Gpu              float64 `parquet:"name=gpu, type=DOUBLE"`
+	EphemeralStorage float64 `parquet:"name=ephemeral_storage, type=DOUBLE"`
+	// Time at which the run was created, in milliseconds since the epoch.
+	Created int64 `parquet:"name=created, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	// Total time the job spent queued before the run was created, across requeues.
+	QueuedSeconds float64 `parquet:"name=queued_seconds, type=DOUBLE"`
+	// Time from the creation of the run until the scheduler observed its completion.
+	RunSeconds float64 `parquet:"name=run_seconds, type=DOUBLE"`
+	// One of "succeeded", "failed", "returned", or "cancelled".
+	Outcome string `parquet:"name=outcome, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+}
+
+// runAnalyticsRecordFromRun returns a record of run, a run of job, as observed at time now,
+// and false if run is nil or hasn't completed.
+func runAnalyticsRecordFromRun(now time.Time, job *jobdb.Job, run *jobdb.JobRun) (*RunAnalyticsRecord, bool) {
+	if run == nil || !run.Phase().IsTerminal() {
+		return nil, false
+	}
+	outcome := string(run.Phase())
+	if run.Returned() {
+		outcome = "returned"
+	}
+	created := time.Unix(0, run.Created())
+	requests := job.GetResourceRequirements().Requests
+	cpu := requests[v1.ResourceCPU]
+	memory := requests[v1.ResourceMemory]
+	gpu := requests["nvidia.com/gpu"]
+	ephemeralStorage := requests[v1.ResourceEphemeralStorage]
+	return &RunAnalyticsRecord{
+		SchemaVersion:    RunAnalyticsSchemaVersion,
+		Queue:            job.Queue(),
+		JobSet:           job.Jobset(),
+		JobId:            job.Id(),
+		RunId:            run.Id().String(),
+		Executor:         run.Executor(),
+		Node:             run.NodeName(),
+		PriorityClass:    job.GetPriorityClassName(),
+		Cpu:              cpu.AsApproximateFloat64(),
+		Memory:           memory.AsApproximateFloat64(),
+		Gpu:              gpu.AsApproximateFloat64(),
+		EphemeralStorage: ephemeralStorage.AsApproximateFloat64(),
+		Created:          created.UnixMilli(),
+		// Once the run was created, the queued period preceding it was added to the queued duration of the job.
+		QueuedSeconds: job.QueuedDuration(created).Seconds(),
+		RunSeconds:    now.Sub(created).Seconds(),
+		Outcome:       outcome,
+	}, true
+}
+
+// RunAnalyticsSink stores the files written by a RunAnalyticsExporter.
+type RunAnalyticsSink interface {
+	// Write stores data under name, which is a relative, slash-separated path.
+	Write(ctx *armadacontext.Context, name string, data []byte) error
+}
+
+// NewRunAnalyticsSink returns the sink for the local directory or object store prefix at location;
+// see RunAnalyticsConfig.Path.
+func NewRunAnalyticsSink(location string, timeout time.Duration) (RunAnalyticsSink, error) {
+	if location == "" {
+		return nil, errors.New("no run analytics path is configured")
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	switch u.Scheme {
+	case "":
+		return &localRunAnalyticsSink{dir: location}, nil
+	case "file":
+		return &localRunAnalyticsSink{dir: u.Path}, nil
+	case "http", "https":
+		return &objectStoreRunAnalyticsSink{prefix: u, timeout: timeout, client: &http.Client{}}, nil
+	default:
+		return nil, errors.Errorf("unsupported run analytics path scheme %s", u.Scheme)
+	}
+}
+
+// localRunAnalyticsSink writes files below a local directory.
+type localRunAnalyticsSink struct {
+	dir string
+}
+
+func (sink *localRunAnalyticsSink) Write(_ *armadacontext.Context, name string, data []byte) error {
+	filename := filepath.Join(sink.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return errors.WithStack(err)
+	}
+	// Files are renamed into place once written, such that readers never see partially written files.
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp, filename))
+}
+
+// objectStoreRunAnalyticsSink PUTs files below a url prefix of an object store.
+type objectStoreRunAnalyticsSink struct {
+	prefix  *url.URL
+	timeout time.Duration
+	client  *http.Client
+}
+
+func (sink *objectStoreRunAnalyticsSink) Write(ctx *armadacontext.Context, name string, data []byte) error {
+	var requestCtx context.Context = ctx
+	if sink.timeout > 0 {
+		var cancel context.CancelFunc
+		requestCtx, cancel = context.WithTimeout(ctx, sink.timeout)
+		defer cancel()
+	}
+	u := *sink.prefix
+	u.Path = path.Join(u.Path, name)
+	request, err := http.NewRequestWithContext(requestCtx, http.MethodPut, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/vnd.apache.parquet")
+	response, err := sink.client.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("object store responded with status %s", response.Status)
+	}
+	return nil
+}
+
+// RunAnalyticsExporter writes a record of each run the scheduler observes completing to Parquet files,
+// for offline analysis. It's a StateTransitionListener; records are handed to Run, which writes them asynchronously,
+// and dropped if too many are waiting to be written.
+type RunAnalyticsExporter struct {
+	sink              RunAnalyticsSink
+	maxRecordsPerFile int
+	flushInterval     time.Duration
+	pending           chan *RunAnalyticsRecord
+	clock             clock.Clock
+	// Number of files written, used to name files written within the same millisecond apart.
+	numFiles       int
+	recordsWritten prometheus.Counter
+	recordsDropped prometheus.Counter
+	filesWritten   prometheus.Counter
+	writeFailures  prometheus.Counter
+}
+
+func NewRunAnalyticsExporter(config schedulerconfig.RunAnalyticsConfig) (*RunAnalyticsExporter, error) {
+	if config.MaxRecordsPerFile <= 0 || config.FlushInterval <= 0 || config.MaxPending <= 0 {
+		return nil, errors.Errorf(
+			"run analytics maxRecordsPerFile, flushInterval, and maxPending must be positive, but are %d, %s, and %d",
+			config.MaxRecordsPerFile, config.FlushInterval, config.MaxPending,
+		)
+	}
+	sink, err := NewRunAnalyticsSink(config.Path, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return newRunAnalyticsExporter(config, sink, clock.RealClock{}), nil
+}
+
+func newRunAnalyticsExporter(config schedulerconfig.RunAnalyticsConfig, sink RunAnalyticsSink, clock clock.Clock) *RunAnalyticsExporter {
+	return &RunAnalyticsExporter{
+		sink:              sink,
+		maxRecordsPerFile: config.MaxRecordsPerFile,
+		flushInterval:     config.FlushInterval,
+		pending:           make(chan *RunAnalyticsRecord, config.MaxPending),
+		clock:             clock,
+		recordsWritten: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: metrics.MetricPrefix + "scheduler_run_analytics_records_written",
+			Help: "Number of completed run records written to run analytics files.",
+		}),
+		recordsDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: metrics.MetricPrefix + "scheduler_run_analytics_records_dropped",
+			Help: "Number of completed run records dropped, either since too many were waiting to be written or since the file they were in couldn't be written.",
+		}),
+		filesWritten: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: metrics.MetricPrefix + "scheduler_run_analytics_files_written",
+			Help: "Number of run analytics files written.",
+		}),
+		writeFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: metrics.MetricPrefix + "scheduler_run_analytics_write_failures",
+			Help: "Number of run analytics files that couldn't be written.",
+		}),
+	}
+}
+
+// OnStateTransitions hands a record of each run completed in jsts to Run, dropping records if too many are pending.
+// Runs are only recorded in the transition in which they entered a terminal phase, such that each is recorded once.
+func (e *RunAnalyticsExporter) OnStateTransitions(_ *armadacontext.Context, now time.Time, jsts []jobdb.JobStateTransitions) {
+	for _, jst := range jsts {
+		for _, runId := range jst.TerminatedRunIds {
+			record, ok := runAnalyticsRecordFromRun(now, jst.Job, jst.Job.RunById(runId))
+			if !ok {
+				continue
+			}
+			select {
+			case e.pending <- record:
+			default:
+				e.recordsDropped.Inc()
+			}
+		}
+	}
+}
+
+// Run buffers pending records and writes them to a file once MaxRecordsPerFile are buffered or FlushInterval
+// has passed since the last file was written, until ctx is cancelled, at which point the records buffered and pending
+// are written before returning.
+func (e *RunAnalyticsExporter) Run(ctx *armadacontext.Context) error {
+	ticker := e.clock.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+	var buffered []*RunAnalyticsRecord
+	for {
+		select {
+		case <-ctx.Done():
+			// Records handed over before the context was cancelled are written too.
+			for len(e.pending) > 0 {
+				buffered = append(buffered, <-e.pending)
+			}
+			// The context is already cancelled, so the last file is written with a context of its own.
+			e.flush(&armadacontext.Context{Context: context.Background(), FieldLogger: ctx.FieldLogger}, buffered)
+			return nil
+		case record := <-e.pending:
+			buffered = append(buffered, record)
+			if len(buffered) >= e.maxRecordsPerFile {
+				e.flush(ctx, buffered)
+				buffered = nil
+			}
+		case <-ticker.C():
+			e.flush(ctx, buffered)
+			buffered = nil
+		}
+	}
+}
+
+// flush writes records to a new file. Records that can't be written are dropped.
+func (e *RunAnalyticsExporter) flush(ctx *armadacontext.Context, records []*RunAnalyticsRecord) {
+	if len(records) == 0 {
+		return
+	}
+	name := fmt.Sprintf(
+		"schema_version=%d/runs-%d-%d.parquet",
+		RunAnalyticsSchemaVersion, e.clock.Now().UnixMilli(), e.numFiles,
+	)
+	e.numFiles++
+	data, err := runAnalyticsParquet(records)
+	if err == nil {
+		err = e.sink.Write(ctx, name, data)
+	}
+	if err != nil {
+		e.writeFailures.Inc()
+		e.recordsDropped.Add(float64(len(records)))
+		logging.WithStacktrace(ctx, err).Warnf("failed to write %d run analytics records to %s", len(records), name)
+		return
+	}
+	e.filesWritten.Inc()
+	e.recordsWritten.Add(float64(len(records)))
+}
+
+// runAnalyticsParquet returns records encoded as a Parquet file.
+func runAnalyticsParquet(records []*RunAnalyticsRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	pw, err := parquetWriter.NewParquetWriterFromWriter(&buf, new(RunAnalyticsRecord), 1)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, record := range records {
+		if err := pw.Write(*record); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (e *RunAnalyticsExporter) Describe(desc chan<- *prometheus.Desc) {
+	e.recordsWritten.Describe(desc)
+	e.recordsDropped.Describe(desc)
+	e.filesWritten.Describe(desc)
+	e.writeFailures.Describe(desc)
+}
+
+func (e *RunAnalyticsExporter) Collect(metrics chan<- prometheus.Metric) {
+	e.recordsWritten.Collect(metrics)
+	e.recordsDropped.Collect(metrics)
+	e.filesWritten.Collect(metrics)
+	e.writeFailures.Collect(metrics)
+}
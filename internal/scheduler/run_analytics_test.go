@@ -0,0 +1,301 @@
+package scheduler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	parquetReader "github.com/xitongsys/parquet-go/reader"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+var runAnalyticsTestConfig = schedulerconfig.RunAnalyticsConfig{
+	MaxRecordsPerFile: 3,
+	FlushInterval:     time.Minute,
+	MaxPending:        100,
+}
+
+// completedRunJobs returns a job for each of the given outcomes, with a run created a minute after the job was
+// submitted and completed, or still running if the outcome is empty, an hour after that.
+func completedRunJobs(outcomes ...string) []jobdb.JobStateTransitions {
+	jobs := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, len(outcomes))
+	jsts := make([]jobdb.JobStateTransitions, len(jobs))
+	for i, job := range jobs {
+		job = job.WithCreated(testfixtures.BaseTime.UnixNano()).WithQueued(false).
+			WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime.Add(time.Minute))
+		run := job.LatestRun().WithRunning(true)
+		jst := jobdb.JobStateTransitions{Running: true}
+		switch outcomes[i] {
+		case "succeeded":
+			run = run.WithSucceeded(true)
+			jst.Succeeded = true
+		case "failed":
+			run = run.WithFailed(true)
+			jst.Failed = true
+		case "returned":
+			run = run.WithReturned(true)
+			jst.Queued = true
+		case "cancelled":
+			run = run.WithCancelled(true)
+			jst.Cancelled = true
+		}
+		if outcomes[i] != "" {
+			jst.TerminatedRunIds = []uuid.UUID{run.Id()}
+		}
+		jst.Job = job.WithUpdatedRun(run)
+		jsts[i] = jst
+	}
+	return jsts
+}
+
+// readRunAnalyticsFiles returns the records in the Parquet files below dir, indexed by file name relative to dir.
+func readRunAnalyticsFiles(t *testing.T, dir string) map[string][]RunAnalyticsRecord {
+	rv := make(map[string][]RunAnalyticsRecord)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		name, err := filepath.Rel(dir, path)
+		require.NoError(t, err)
+		rv[filepath.ToSlash(name)] = readRunAnalyticsParquet(t, data)
+		return nil
+	})
+	require.NoError(t, err)
+	return rv
+}
+
+func readRunAnalyticsParquet(t *testing.T, data []byte) []RunAnalyticsRecord {
+	file, err := buffer.NewBufferFile(data)
+	require.NoError(t, err)
+	pr, err := parquetReader.NewParquetReader(file, new(RunAnalyticsRecord), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	records := make([]RunAnalyticsRecord, pr.GetNumRows())
+	require.NoError(t, pr.Read(&records))
+	return records
+}
+
+func TestRunAnalyticsExporter_WritesCompletedRuns(t *testing.T) {
+	dir := t.TempDir()
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+	exporter := newRunAnalyticsExporter(runAnalyticsTestConfig, &localRunAnalyticsSink{dir: dir}, testClock)
+	ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+	done := make(chan error)
+	go func() { done <- exporter.Run(ctx) }()
+
+	// The running job is ignored; the others fill a file.
+	jsts := completedRunJobs("succeeded", "", "failed", "returned", "cancelled")
+	exporter.OnStateTransitions(ctx, testfixtures.BaseTime.Add(61*time.Minute), jsts)
+	require.Eventually(t, func() bool { return testutil.ToFloat64(exporter.filesWritten) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// The cancelled job is written once the exporter is shut down.
+	cancel()
+	require.NoError(t, <-done)
+	files := readRunAnalyticsFiles(t, dir)
+	require.Len(t, files, 2)
+	var names []string
+	for name := range files {
+		assert.Regexp(t, `^schema_version=1/runs-\d+-\d\.parquet$`, name)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	require.Len(t, files[names[0]], 3)
+	require.Len(t, files[names[1]], 1)
+
+	var outcomes []string
+	for i, record := range append(files[names[0]], files[names[1]]...) {
+		job := jsts[[]int{0, 2, 3, 4}[i]].Job
+		assert.Equal(t, RunAnalyticsRecord{
+			SchemaVersion: RunAnalyticsSchemaVersion,
+			Queue:         testfixtures.TestQueue,
+			JobSet:        job.Jobset(),
+			JobId:         job.Id(),
+			RunId:         job.LatestRun().Id().String(),
+			Executor:      "testExecutor",
+			Node:          "node",
+			PriorityClass: testfixtures.PriorityClass0,
+			Cpu:           1,
+			Memory:        4 * 1024 * 1024 * 1024,
+			Created:       testfixtures.BaseTime.Add(time.Minute).UnixMilli(),
+			QueuedSeconds: 60,
+			RunSeconds:    3600,
+			Outcome:       record.Outcome,
+		}, record)
+		outcomes = append(outcomes, record.Outcome)
+	}
+	assert.Equal(t, []string{"succeeded", "failed", "returned", "cancelled"}, outcomes)
+	assert.Equal(t, 4.0, testutil.ToFloat64(exporter.recordsWritten))
+	assert.Equal(t, 0.0, testutil.ToFloat64(exporter.recordsDropped))
+}
+
+func TestRunAnalyticsExporter_RecordsRunsOnce(t *testing.T) {
+	exporter := newRunAnalyticsExporter(runAnalyticsTestConfig, &localRunAnalyticsSink{dir: t.TempDir()}, clock.NewFakeClock(testfixtures.BaseTime))
+	jsts := completedRunJobs("failed")
+	exporter.OnStateTransitions(armadacontext.Background(), testfixtures.BaseTime, jsts)
+	assert.Len(t, exporter.pending, 1)
+
+	// The job is cancelled in a later cycle; its run had already failed, so isn't recorded again.
+	jst := jobdb.JobStateTransitions{Job: jsts[0].Job.WithCancelled(true), Cancelled: true}
+	exporter.OnStateTransitions(armadacontext.Background(), testfixtures.BaseTime, []jobdb.JobStateTransitions{jst})
+	assert.Len(t, exporter.pending, 1)
+}
+
+func TestRunAnalyticsExporter_FlushInterval(t *testing.T) {
+	dir := t.TempDir()
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+	exporter := newRunAnalyticsExporter(runAnalyticsTestConfig, &localRunAnalyticsSink{dir: dir}, testClock)
+	ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+	defer cancel()
+	go func() { _ = exporter.Run(ctx) }()
+
+	exporter.OnStateTransitions(ctx, testfixtures.BaseTime, completedRunJobs("succeeded"))
+	require.Eventually(t, func() bool { return testClock.HasWaiters() && len(exporter.pending) == 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0.0, testutil.ToFloat64(exporter.filesWritten))
+	testClock.Step(time.Minute)
+	require.Eventually(t, func() bool { return testutil.ToFloat64(exporter.filesWritten) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, readRunAnalyticsFiles(t, dir), 1)
+}
+
+func TestRunAnalyticsExporter_DropsRecordsIfTooManyArePending(t *testing.T) {
+	config := runAnalyticsTestConfig
+	config.MaxPending = 2
+	exporter := newRunAnalyticsExporter(config, &localRunAnalyticsSink{dir: t.TempDir()}, clock.NewFakeClock(testfixtures.BaseTime))
+
+	// The exporter isn't running, so records aren't picked up; the cycle isn't delayed.
+	exporter.OnStateTransitions(armadacontext.Background(), testfixtures.BaseTime, completedRunJobs("succeeded", "failed", "succeeded", "failed"))
+	assert.Len(t, exporter.pending, 2)
+	assert.Equal(t, 2.0, testutil.ToFloat64(exporter.recordsDropped))
+}
+
+func TestRunAnalyticsExporter_ObjectStore(t *testing.T) {
+	var mu sync.Mutex
+	dataByPath := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, r.Method)
+		mu.Lock()
+		dataByPath[r.URL.Path] = data
+		mu.Unlock()
+	}))
+	defer server.Close()
+	sink, err := NewRunAnalyticsSink(server.URL+"/bucket/runs", time.Second)
+	require.NoError(t, err)
+	exporter := newRunAnalyticsExporter(runAnalyticsTestConfig, sink, clock.NewFakeClock(testfixtures.BaseTime))
+	ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+	exporter.OnStateTransitions(ctx, testfixtures.BaseTime, completedRunJobs("succeeded", "failed"))
+	cancel()
+	require.NoError(t, exporter.Run(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, dataByPath, 1)
+	for path, data := range dataByPath {
+		assert.Regexp(t, `^/bucket/runs/schema_version=1/runs-\d+-0\.parquet$`, path)
+		assert.Len(t, readRunAnalyticsParquet(t, data), 2)
+	}
+}
+
+func TestRunAnalyticsExporter_WriteFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	sink, err := NewRunAnalyticsSink(server.URL, time.Second)
+	require.NoError(t, err)
+	exporter := newRunAnalyticsExporter(runAnalyticsTestConfig, sink, clock.NewFakeClock(testfixtures.BaseTime))
+	ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+	exporter.OnStateTransitions(ctx, testfixtures.BaseTime, completedRunJobs("succeeded"))
+	cancel()
+	require.NoError(t, exporter.Run(ctx))
+	assert.Equal(t, 1.0, testutil.ToFloat64(exporter.writeFailures))
+	assert.Equal(t, 1.0, testutil.ToFloat64(exporter.recordsDropped))
+}
+
+func TestNewRunAnalyticsSink(t *testing.T) {
+	sink, err := NewRunAnalyticsSink("/tmp/runs", 0)
+	require.NoError(t, err)
+	assert.Equal(t, &localRunAnalyticsSink{dir: "/tmp/runs"}, sink)
+	sink, err = NewRunAnalyticsSink("file:///tmp/runs", 0)
+	require.NoError(t, err)
+	assert.Equal(t, &localRunAnalyticsSink{dir: "/tmp/runs"}, sink)
+	_, err = NewRunAnalyticsSink("", 0)
+	assert.Error(t, err)
+	_, err = NewRunAnalyticsSink("ftp://host/runs", 0)
+	assert.Error(t, err)
+	_, err = NewRunAnalyticsExporter(schedulerconfig.RunAnalyticsConfig{Path: "/tmp/runs"})
+	assert.Error(t, err)
+}
+
+type stateTransitionRecorder struct {
+	jsts []jobdb.JobStateTransitions
+}
+
+func (r *stateTransitionRecorder) OnStateTransitions(_ *armadacontext.Context, _ time.Time, jsts []jobdb.JobStateTransitions) {
+	r.jsts = append(r.jsts, jsts...)
+}
+
+func TestScheduler_StateTransitionListeners(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	job := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 1)[0].
+		WithQueued(false).
+		WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+	jobRepo := &testJobRepository{
+		updatedRuns: []database.Run{
+			{RunID: job.LatestRun().Id(), JobID: job.Id(), JobSet: job.Jobset(), Executor: "testExecutor", Succeeded: true, Serial: 1},
+		},
+	}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	recorder := &stateTransitionRecorder{}
+	sched.AddStateTransitionListener(recorder)
+	exporter := newRunAnalyticsExporter(runAnalyticsTestConfig, &localRunAnalyticsSink{dir: t.TempDir()}, clock.NewFakeClock(testfixtures.BaseTime))
+	sched.AddStateTransitionListener(exporter)
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	txn.Commit()
+
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	require.Len(t, recorder.jsts, 1)
+	assert.True(t, recorder.jsts[0].Succeeded)
+	require.Len(t, exporter.pending, 1)
+	record := <-exporter.pending
+	assert.Equal(t, job.LatestRun().Id().String(), record.RunId)
+	assert.Equal(t, "succeeded", record.Outcome)
+}
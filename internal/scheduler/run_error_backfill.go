@@ -0,0 +1,178 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// runErrorPlaceholderMessage is the message of the error jobs are failed with if the error of their failed run
+// hasn't been written to the database by the time the run is marked failed.
+const runErrorPlaceholderMessage = "Job run failed but details of the failure were not yet available; " +
+	"these will be published separately once they become available"
+
+// EnableRunErrorBackfill causes jobs whose failed run has no error in the database to be failed with a placeholder
+// error instead of crashing the scheduler. The run of each such job is tracked, and once its error is written to
+// the database the scheduler publishes a JobRunErrors event carrying the error.
+// At most maxPending runs are tracked, each for at most ttl; the oldest runs are dropped first.
+func (s *Scheduler) EnableRunErrorBackfill(maxPending int, ttl time.Duration) {
+	s.runErrorBackfill = newRunErrorBackfill(maxPending, ttl)
+}
+
+// pendingRunError is a failed run for which a placeholder error was published.
+type pendingRunError struct {
+	jobId    *armadaevents.Uuid
+	runId    uuid.UUID
+	queue    string
+	jobSet   string
+	failedAt time.Time
+}
+
+// runErrorBackfill tracks runs failed with a placeholder error whose actual error hasn't been published yet.
+type runErrorBackfill struct {
+	maxPending int
+	ttl        time.Duration
+	// Pending runs in the order in which they were failed.
+	pending        []*pendingRunError
+	pendingByRunId map[uuid.UUID]*pendingRunError
+}
+
+func newRunErrorBackfill(maxPending int, ttl time.Duration) *runErrorBackfill {
+	return &runErrorBackfill{
+		maxPending:     maxPending,
+		ttl:            ttl,
+		pendingByRunId: make(map[uuid.UUID]*pendingRunError),
+	}
+}
+
+// add tracks the run of job with id runId, dropping the oldest tracked run if already at capacity.
+func (b *runErrorBackfill) add(now time.Time, job *jobdb.Job, jobId *armadaevents.Uuid, runId uuid.UUID) {
+	if _, ok := b.pendingByRunId[runId]; ok || b.maxPending <= 0 {
+		return
+	}
+	for len(b.pending) >= b.maxPending {
+		b.remove(b.pending[0].runId)
+	}
+	p := &pendingRunError{
+		jobId:    jobId,
+		runId:    runId,
+		queue:    job.Queue(),
+		jobSet:   job.Jobset(),
+		failedAt: now,
+	}
+	b.pending = append(b.pending, p)
+	b.pendingByRunId[runId] = p
+}
+
+// expire stops tracking runs failed more than ttl ago and returns the number of runs no longer tracked.
+func (b *runErrorBackfill) expire(now time.Time) int {
+	n := 0
+	for len(b.pending) > 0 && now.Sub(b.pending[0].failedAt) > b.ttl {
+		b.remove(b.pending[0].runId)
+		n++
+	}
+	return n
+}
+
+func (b *runErrorBackfill) remove(runId uuid.UUID) {
+	if _, ok := b.pendingByRunId[runId]; !ok {
+		return
+	}
+	delete(b.pendingByRunId, runId)
+	for i, p := range b.pending {
+		if p.runId == runId {
+			b.pending = append(b.pending[:i], b.pending[i+1:]...)
+			break
+		}
+	}
+}
+
+func (b *runErrorBackfill) runIds() []uuid.UUID {
+	runIds := make([]uuid.UUID, len(b.pending))
+	for i, p := range b.pending {
+		runIds[i] = p.runId
+	}
+	return runIds
+}
+
+// placeholderRunError returns the error jobs are failed with if the error of their failed run isn't available.
+// If run error backfill is enabled, the run is tracked such that its error is published once available.
+func (s *Scheduler) placeholderRunError(job *jobdb.Job, jobId *armadaevents.Uuid, runId uuid.UUID) *armadaevents.Error {
+	if s.runErrorBackfill == nil {
+		panic(
+			fmt.Sprintf("No run error found for run %s (job id = %s), this must mean we're out of sync with the database",
+				runId.String(), job.Id()),
+		)
+	}
+	s.runErrorBackfill.add(s.clock.Now(), job, jobId, runId)
+	return &armadaevents.Error{
+		Terminal: true,
+		Reason: &armadaevents.Error_PodError{
+			PodError: &armadaevents.PodError{
+				Message: runErrorPlaceholderMessage,
+			},
+		},
+	}
+}
+
+// generateBackfilledRunErrorEvents returns a JobRunErrors event for each run failed with a placeholder error
+// whose error has since been written to the database, along with the ids of those runs.
+// Runs are only untracked once resolveBackfilledRunErrors is called, such that events are retried if publishing fails.
+func (s *Scheduler) generateBackfilledRunErrorEvents(ctx *armadacontext.Context) ([]*armadaevents.EventSequence, []uuid.UUID, error) {
+	if s.runErrorBackfill == nil {
+		return nil, nil, nil
+	}
+	if n := s.runErrorBackfill.expire(s.clock.Now()); n > 0 {
+		ctx.Warnf("gave up waiting for the errors of %d failed runs", n)
+	}
+	runIds := s.runErrorBackfill.runIds()
+	if len(runIds) == 0 {
+		return nil, nil, nil
+	}
+	runErrorsByRunId, err := s.fetchJobRunErrors(ctx, runIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	var events []*armadaevents.EventSequence
+	var resolvedRunIds []uuid.UUID
+	for _, runId := range runIds {
+		runError, ok := runErrorsByRunId[runId]
+		if !ok || runError == nil {
+			continue
+		}
+		p := s.runErrorBackfill.pendingByRunId[runId]
+		events = append(events, &armadaevents.EventSequence{
+			Queue:      p.queue,
+			JobSetName: p.jobSet,
+			Events: []*armadaevents.EventSequence_Event{
+				{
+					Created: s.now(),
+					Event: &armadaevents.EventSequence_Event_JobRunErrors{
+						JobRunErrors: &armadaevents.JobRunErrors{
+							JobId:  p.jobId,
+							RunId:  armadaevents.ProtoUuidFromUuid(runId),
+							Errors: []*armadaevents.Error{runError},
+						},
+					},
+				},
+			},
+		})
+		resolvedRunIds = append(resolvedRunIds, runId)
+	}
+	return events, resolvedRunIds, nil
+}
+
+// resolveBackfilledRunErrors stops tracking runs whose error has been published.
+func (s *Scheduler) resolveBackfilledRunErrors(runIds []uuid.UUID) {
+	if s.runErrorBackfill == nil {
+		return
+	}
+	for _, runId := range runIds {
+		s.runErrorBackfill.remove(runId)
+	}
+}
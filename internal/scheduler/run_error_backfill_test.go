@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_RunErrorBackfill(t *testing.T) {
+	tests := map[string]struct {
+		// If true, the run error is available in the cycle in which the run is marked failed.
+		// Otherwise, it's available from the next cycle.
+		errorAvailableImmediately bool
+		// Time between the cycle in which the run is marked failed and the next cycle.
+		delay                       time.Duration
+		maxPendingRuns              int
+		expectPlaceholder           bool
+		expectedNumBackfilledErrors int
+	}{
+		"error available one cycle later": {
+			maxPendingRuns:              10,
+			expectPlaceholder:           true,
+			expectedNumBackfilledErrors: 1,
+		},
+		"error available immediately": {
+			errorAvailableImmediately: true,
+			maxPendingRuns:            10,
+		},
+		"error available after ttl": {
+			delay:             2 * time.Hour,
+			maxPendingRuns:    10,
+			expectPlaceholder: true,
+		},
+		"no pending runs allowed": {
+			maxPendingRuns:    0,
+			expectPlaceholder: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			testClock := clock.NewFakeClock(time.Now())
+			jobRepo := &testJobRepository{}
+			publisher := &testPublisher{}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				jobRepo,
+				&testExecutorRepository{
+					executors: []*schedulerobjects.Executor{{Id: "testExecutor", LastUpdateTime: testClock.Now().Add(24 * time.Hour)}},
+				},
+				&testSchedulingAlgo{},
+				NewStandaloneLeaderController(),
+				publisher,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = testClock
+			sched.EnableRunErrorBackfill(tc.maxPendingRuns, time.Hour)
+
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{leasedJob}))
+			txn.Commit()
+			runId := leasedJob.LatestRun().Id()
+			runErrors := map[uuid.UUID]*armadaevents.Error{runId: defaultJobRunError}
+
+			// The run is marked failed.
+			jobRepo.updatedRuns = []database.Run{{
+				RunID:    runId,
+				JobID:    leasedJob.Id(),
+				JobSet:   "testJobSet",
+				Executor: "testExecutor",
+				Failed:   true,
+				Serial:   1,
+			}}
+			if tc.errorAvailableImmediately {
+				jobRepo.errors = runErrors
+			}
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+			jobErrors := collectJobErrors(publisher.events)
+			require.Len(t, jobErrors, 1)
+			if tc.expectPlaceholder {
+				assert.Equal(t, runErrorPlaceholderMessage, jobErrors[0].Errors[0].GetPodError().GetMessage())
+			} else {
+				assert.Equal(t, defaultJobRunError, jobErrors[0].Errors[0])
+			}
+			assert.Empty(t, collectJobRunErrors(publisher.events))
+
+			// The run error is written to the database.
+			jobRepo.updatedRuns = nil
+			jobRepo.errors = runErrors
+			testClock.Step(tc.delay)
+			var backfilledErrors []*armadaevents.JobRunErrors
+			for i := 0; i < 3; i++ {
+				_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+				require.NoError(t, err)
+				assert.Empty(t, collectJobErrors(publisher.events))
+				backfilledErrors = append(backfilledErrors, collectJobRunErrors(publisher.events)...)
+			}
+			require.Len(t, backfilledErrors, tc.expectedNumBackfilledErrors)
+			for _, backfilledError := range backfilledErrors {
+				assert.Equal(t, armadaevents.ProtoUuidFromUuid(runId), backfilledError.RunId)
+				assert.Equal(t, []*armadaevents.Error{defaultJobRunError}, backfilledError.Errors)
+			}
+		})
+	}
+}
+
+func TestRunErrorBackfill_DropsOldestRunsWhenFull(t *testing.T) {
+	now := time.Now()
+	backfill := newRunErrorBackfill(2, time.Hour)
+	runIds := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for i, runId := range runIds {
+		backfill.add(now.Add(time.Duration(i)*time.Minute), leasedJob, nil, runId)
+	}
+	assert.Equal(t, runIds[1:], backfill.runIds())
+
+	assert.Equal(t, 1, backfill.expire(now.Add(time.Hour+90*time.Second)))
+	assert.Equal(t, runIds[2:], backfill.runIds())
+}
+
+func collectJobErrors(eventSequences []*armadaevents.EventSequence) []*armadaevents.JobErrors {
+	var rv []*armadaevents.JobErrors
+	for _, eventSequence := range eventSequences {
+		for _, event := range eventSequence.Events {
+			if jobErrors := event.GetJobErrors(); jobErrors != nil {
+				rv = append(rv, jobErrors)
+			}
+		}
+	}
+	return rv
+}
+
+func collectJobRunErrors(eventSequences []*armadaevents.EventSequence) []*armadaevents.JobRunErrors {
+	var rv []*armadaevents.JobRunErrors
+	for _, eventSequence := range eventSequences {
+		for _, event := range eventSequence.Events {
+			if jobRunErrors := event.GetJobRunErrors(); jobRunErrors != nil {
+				rv = append(rv, jobRunErrors)
+			}
+		}
+	}
+	return rv
+}
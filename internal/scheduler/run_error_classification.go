@@ -0,0 +1,144 @@
+package scheduler
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// RunErrorClass determines whether the job of a failed run is retried.
+type RunErrorClass string
+
+const (
+	// The job is retried if it hasn't yet been attempted the maximum number of times.
+	RetryableRunError RunErrorClass = "Retryable"
+	// The job is failed.
+	NonRetryableRunError RunErrorClass = "NonRetryable"
+	// As RetryableRunError, but the job isn't considered for scheduling again until its backoff has passed.
+	RetryWithBackoffRunError RunErrorClass = "RetryWithBackoff"
+)
+
+// EnableRunErrorClassification causes the errors of failed runs to be classified by classifier.
+// For errors matching a rule, the class of the rule determines whether the job is retried,
+// instead of whether the run was returned.
+func (s *Scheduler) EnableRunErrorClassification(classifier *RunErrorClassifier) {
+	s.runErrorClassifier = classifier
+}
+
+// RunErrorClassification is a rule that classifies run errors.
+type RunErrorClassification struct {
+	Name              string
+	Class             RunErrorClass
+	Backoff           time.Duration
+	messageRegex      *regexp.Regexp
+	exitCodes         []int32
+	kubernetesReasons []armadaevents.KubernetesReason
+}
+
+// RunErrorClassifier classifies run errors according to an ordered list of rules.
+type RunErrorClassifier struct {
+	rules []*RunErrorClassification
+}
+
+func NewRunErrorClassifier(rules []schedulerconfig.RunErrorClassificationRule) (*RunErrorClassifier, error) {
+	classifier := &RunErrorClassifier{rules: make([]*RunErrorClassification, len(rules))}
+	for i, rule := range rules {
+		classification := &RunErrorClassification{
+			Name:      rule.Name,
+			Class:     RunErrorClass(rule.Class),
+			Backoff:   rule.Backoff,
+			exitCodes: rule.ExitCodes,
+		}
+		if rule.Name == "" {
+			return nil, errors.Errorf("run error classification rule %d has no name", i)
+		}
+		switch classification.Class {
+		case RetryableRunError, NonRetryableRunError:
+		case RetryWithBackoffRunError:
+			if rule.Backoff <= 0 {
+				return nil, errors.Errorf("run error classification rule %s retries with backoff but has no backoff", rule.Name)
+			}
+		default:
+			return nil, errors.Errorf("run error classification rule %s has unknown class %s", rule.Name, rule.Class)
+		}
+		if rule.MessageRegex != "" {
+			messageRegex, err := regexp.Compile(rule.MessageRegex)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid message regex for run error classification rule %s", rule.Name)
+			}
+			classification.messageRegex = messageRegex
+		}
+		for _, reason := range rule.KubernetesReasons {
+			value, ok := armadaevents.KubernetesReason_value[reason]
+			if !ok {
+				return nil, errors.Errorf("run error classification rule %s has unknown kubernetes reason %s", rule.Name, reason)
+			}
+			classification.kubernetesReasons = append(classification.kubernetesReasons, armadaevents.KubernetesReason(value))
+		}
+		if classification.messageRegex == nil && len(classification.exitCodes) == 0 && len(classification.kubernetesReasons) == 0 {
+			return nil, errors.Errorf("run error classification rule %s matches all errors", rule.Name)
+		}
+		classifier.rules[i] = classification
+	}
+	return classifier, nil
+}
+
+// Classify returns the first rule matching runError, or nil if there's no such rule.
+func (c *RunErrorClassifier) Classify(runError *armadaevents.Error) *RunErrorClassification {
+	if runError == nil {
+		return nil
+	}
+	for _, rule := range c.rules {
+		if rule.matches(runError) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// hasRule returns true if the classifier has a rule with the given name.
+func (c *RunErrorClassifier) hasRule(name string) bool {
+	return slices.IndexFunc(c.rules, func(rule *RunErrorClassification) bool { return rule.Name == name }) != -1
+}
+
+// matches returns true if runError meets all conditions of the rule.
+func (rule *RunErrorClassification) matches(runError *armadaevents.Error) bool {
+	var messages []string
+	var exitCodes []int32
+	var kubernetesReasons []armadaevents.KubernetesReason
+	switch reason := runError.Reason.(type) {
+	case *armadaevents.Error_PodError:
+		messages = append(messages, reason.PodError.GetMessage())
+		kubernetesReasons = append(kubernetesReasons, reason.PodError.GetKubernetesReason())
+		for _, containerError := range reason.PodError.GetContainerErrors() {
+			messages = append(messages, containerError.GetMessage(), containerError.GetReason())
+			exitCodes = append(exitCodes, containerError.GetExitCode())
+			kubernetesReasons = append(kubernetesReasons, containerError.GetKubernetesReason())
+		}
+	case *armadaevents.Error_PodLeaseReturned:
+		messages = append(messages, reason.PodLeaseReturned.GetMessage())
+	case *armadaevents.Error_PodTerminated:
+		messages = append(messages, reason.PodTerminated.GetMessage())
+	case *armadaevents.Error_PodUnschedulable:
+		messages = append(messages, reason.PodUnschedulable.GetMessage())
+	}
+	if rule.messageRegex != nil && slices.IndexFunc(messages, rule.messageRegex.MatchString) == -1 {
+		return false
+	}
+	if len(rule.exitCodes) > 0 && slices.IndexFunc(exitCodes, func(exitCode int32) bool {
+		return slices.Contains(rule.exitCodes, exitCode)
+	}) == -1 {
+		return false
+	}
+	if len(rule.kubernetesReasons) > 0 && slices.IndexFunc(kubernetesReasons, func(reason armadaevents.KubernetesReason) bool {
+		return slices.Contains(rule.kubernetesReasons, reason)
+	}) == -1 {
+		return false
+	}
+	return true
+}
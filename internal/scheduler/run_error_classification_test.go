@@ -0,0 +1,266 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+var oomKilledJobRunError = &armadaevents.Error{
+	Terminal: true,
+	Reason: &armadaevents.Error_PodError{
+		PodError: &armadaevents.PodError{
+			Message:          "pod failed",
+			KubernetesReason: armadaevents.KubernetesReason_AppError,
+			ContainerErrors: []*armadaevents.ContainerError{
+				{
+					ExitCode:         137,
+					Message:          "container was OOMKilled",
+					Reason:           "OOMKilled",
+					KubernetesReason: armadaevents.KubernetesReason_OOM,
+				},
+			},
+		},
+	},
+}
+
+var nodePreemptedJobRunError = &armadaevents.Error{
+	Terminal: true,
+	Reason: &armadaevents.Error_PodError{
+		PodError: &armadaevents.PodError{
+			Message:          "node was preempted by the cloud provider",
+			KubernetesReason: armadaevents.KubernetesReason_Evicted,
+		},
+	},
+}
+
+var imagePullJobRunError = &armadaevents.Error{
+	Terminal: true,
+	Reason: &armadaevents.Error_PodError{
+		PodError: &armadaevents.PodError{
+			Message: "failed to pull image: registry rate limit exceeded",
+		},
+	},
+}
+
+var testRunErrorClassificationRules = []schedulerconfig.RunErrorClassificationRule{
+	{
+		Name:              "oom",
+		Class:             string(NonRetryableRunError),
+		KubernetesReasons: []string{"OOM"},
+	},
+	{
+		Name:         "node-preempted",
+		Class:        string(RetryableRunError),
+		MessageRegex: "node was preempted",
+	},
+	{
+		Name:         "registry-rate-limited",
+		Class:        string(RetryWithBackoffRunError),
+		MessageRegex: "rate limit",
+		Backoff:      10 * time.Minute,
+	},
+}
+
+func TestScheduler_RunErrorClassification(t *testing.T) {
+	tests := map[string]struct {
+		runError               *armadaevents.Error
+		returned               bool
+		expectedClassification string
+		expectRequeued         bool
+		expectedBackoff        time.Duration
+	}{
+		"unclassified pod error fails the job": {
+			runError: defaultJobRunError,
+		},
+		"unclassified returned run requeues the job": {
+			runError:       defaultJobRunError,
+			returned:       true,
+			expectRequeued: true,
+		},
+		"non-retryable pod error fails the job": {
+			runError:               oomKilledJobRunError,
+			expectedClassification: "oom",
+		},
+		"non-retryable returned run fails the job": {
+			runError:               oomKilledJobRunError,
+			returned:               true,
+			expectedClassification: "oom",
+		},
+		"retryable pod error requeues the job": {
+			runError:               nodePreemptedJobRunError,
+			expectedClassification: "node-preempted",
+			expectRequeued:         true,
+		},
+		"retry-with-backoff pod error requeues the job with backoff": {
+			runError:               imagePullJobRunError,
+			expectedClassification: "registry-rate-limited",
+			expectRequeued:         true,
+			expectedBackoff:        10 * time.Minute,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			testClock := clock.NewFakeClock(time.Now())
+			jobRepo := &testJobRepository{}
+			publisher := &testPublisher{}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				jobRepo,
+				&testExecutorRepository{
+					executors: []*schedulerobjects.Executor{{Id: "testExecutor", LastUpdateTime: testClock.Now().Add(24 * time.Hour)}},
+				},
+				&testSchedulingAlgo{},
+				NewStandaloneLeaderController(),
+				publisher,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = testClock
+			classifier, err := NewRunErrorClassifier(testRunErrorClassificationRules)
+			require.NoError(t, err)
+			sched.EnableRunErrorClassification(classifier)
+
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{leasedJob}))
+			txn.Commit()
+			runId := leasedJob.LatestRun().Id()
+
+			classifiedRunErrors := func() float64 {
+				var sum float64
+				for _, rule := range classifier.rules {
+					sum += testutil.ToFloat64(schedulerMetrics.classifiedRunErrors.WithLabelValues(leasedJob.Queue(), rule.Name, string(rule.Class)))
+				}
+				return sum
+			}
+			initialClassifiedRunErrors := classifiedRunErrors()
+
+			jobRepo.updatedRuns = []database.Run{{
+				RunID:    runId,
+				JobID:    leasedJob.Id(),
+				JobSet:   "testJobSet",
+				Executor: "testExecutor",
+				Failed:   true,
+				Returned: tc.returned,
+				Serial:   1,
+			}}
+			jobRepo.errors = map[uuid.UUID]*armadaevents.Error{runId: tc.runError}
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+
+			job := sched.jobDb.ReadTxn().GetById(leasedJob.Id())
+			require.NotNil(t, job)
+			jobErrors := collectJobErrors(publisher.events)
+			if tc.expectRequeued {
+				assert.Empty(t, jobErrors)
+				assert.True(t, job.Queued())
+				backedOffUntil, backedOff := job.BackedOffUntil()
+				if tc.expectedBackoff > 0 {
+					assert.True(t, backedOff)
+					assert.True(t, testClock.Now().Add(tc.expectedBackoff).Equal(backedOffUntil))
+				} else {
+					assert.False(t, backedOff)
+				}
+			} else {
+				assert.True(t, job.Failed())
+				require.Len(t, jobErrors, 1)
+				require.Len(t, jobErrors[0].Errors, 1)
+				assert.Equal(t, tc.expectedClassification, jobErrors[0].Errors[0].Classification)
+				if tc.expectedClassification != "" && tc.returned {
+					assert.Contains(t, jobErrors[0].Errors[0].GetMaxRunsExceeded().GetMessage(), tc.expectedClassification)
+				}
+			}
+			if tc.expectedClassification != "" {
+				assert.Equal(t, initialClassifiedRunErrors+1, classifiedRunErrors())
+			} else {
+				assert.Equal(t, initialClassifiedRunErrors, classifiedRunErrors())
+			}
+		})
+	}
+}
+
+func TestRunErrorClassifier_Classify(t *testing.T) {
+	classifier, err := NewRunErrorClassifier([]schedulerconfig.RunErrorClassificationRule{
+		{
+			Name:      "oom-exit-code",
+			Class:     string(NonRetryableRunError),
+			ExitCodes: []int32{137},
+		},
+		{
+			Name:              "oom-reason",
+			Class:             string(NonRetryableRunError),
+			KubernetesReasons: []string{"OOM"},
+		},
+	})
+	require.NoError(t, err)
+
+	// Rules are tried in order.
+	classification := classifier.Classify(oomKilledJobRunError)
+	require.NotNil(t, classification)
+	assert.Equal(t, "oom-exit-code", classification.Name)
+
+	assert.Nil(t, classifier.Classify(defaultJobRunError))
+	assert.Nil(t, classifier.Classify(nil))
+}
+
+func TestNewRunErrorClassifier_InvalidRules(t *testing.T) {
+	tests := map[string]schedulerconfig.RunErrorClassificationRule{
+		"no name": {
+			Class:        string(RetryableRunError),
+			MessageRegex: "error",
+		},
+		"unknown class": {
+			Name:         "test",
+			Class:        "Sometimes",
+			MessageRegex: "error",
+		},
+		"backoff class without backoff": {
+			Name:         "test",
+			Class:        string(RetryWithBackoffRunError),
+			MessageRegex: "error",
+		},
+		"invalid regex": {
+			Name:         "test",
+			Class:        string(RetryableRunError),
+			MessageRegex: "(",
+		},
+		"unknown kubernetes reason": {
+			Name:              "test",
+			Class:             string(RetryableRunError),
+			KubernetesReasons: []string{"Exploded"},
+		},
+		"no conditions": {
+			Name:  "test",
+			Class: string(RetryableRunError),
+		},
+	}
+	for name, rule := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRunErrorClassifier([]schedulerconfig.RunErrorClassificationRule{rule})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// Reasons reported by the dropped run updates metric.
+const (
+	droppedRunUpdateReasonExpired        = "expired"
+	droppedRunUpdateReasonQuarantineFull = "quarantineFull"
+)
+
+// RunUpdateQuarantine holds updates of runs of orphaned jobs, i.e., jobs neither in the jobDb nor among the job updates
+// received alongside the run updates. Such updates are usually of jobs whose row just hasn't been read yet, since rows
+// may become visible in a different order to that in which their serials were assigned. Hence, they're retried in
+// subsequent cycles, and only dropped if the job is still unknown after the configured number of cycles,
+// e.g., since it's in a queue that's been deleted or is owned by another shard.
+type RunUpdateQuarantine struct {
+	// Maximum number of run updates quarantined at a time. Further orphaned run updates are dropped immediately.
+	maxRuns int
+	// Number of cycles for which quarantined run updates are retried before being dropped.
+	maxCycles uint
+	// If non-nil, the number of quarantined and dropped run updates is reported here.
+	metrics *SchedulerMetrics
+	// Quarantined run updates by run id.
+	runsById map[uuid.UUID]*quarantinedRunUpdate
+}
+
+type quarantinedRunUpdate struct {
+	run database.Run
+	// Number of cycles for which the update has been retried.
+	numCycles uint
+}
+
+func NewRunUpdateQuarantine(config schedulerconfig.RunUpdateQuarantineConfig, metrics *SchedulerMetrics) *RunUpdateQuarantine {
+	return &RunUpdateQuarantine{
+		maxRuns:   config.MaxRuns,
+		maxCycles: config.MaxCycles,
+		metrics:   metrics,
+		runsById:  make(map[uuid.UUID]*quarantinedRunUpdate),
+	}
+}
+
+// EnableRunUpdateQuarantine causes updates of runs of orphaned jobs to be retried in subsequent cycles by q,
+// rather than ignored.
+func (s *Scheduler) EnableRunUpdateQuarantine(q *RunUpdateQuarantine) {
+	s.runUpdateQuarantine = q
+}
+
+// withQuarantinedRuns returns updatedRuns preceded by the quarantined run updates, such that they're retried.
+// Quarantined updates of runs also in updatedRuns are omitted, since those in updatedRuns are more recent.
+func (q *RunUpdateQuarantine) withQuarantinedRuns(updatedRuns []database.Run) []database.Run {
+	if len(q.runsById) == 0 {
+		return updatedRuns
+	}
+	updatedRunIds := make(map[uuid.UUID]bool, len(updatedRuns))
+	for _, run := range updatedRuns {
+		updatedRunIds[run.RunID] = true
+	}
+	runs := make([]database.Run, 0, len(q.runsById)+len(updatedRuns))
+	for runId, quarantined := range q.runsById {
+		if !updatedRunIds[runId] {
+			runs = append(runs, quarantined.run)
+		}
+	}
+	return append(runs, updatedRuns...)
+}
+
+// quarantineOrphanedRuns returns the updates of runs of jobs either in txn or in updatedJobs and quarantines the others.
+// Quarantined updates that are no longer orphaned are released, and those retried for maxCycles are dropped.
+func (q *RunUpdateQuarantine) quarantineOrphanedRuns(ctx *armadacontext.Context, txn *jobdb.Txn, updatedJobs []database.Job, updatedRuns []database.Run) []database.Run {
+	updatedJobIds := make(map[string]bool, len(updatedJobs))
+	for _, job := range updatedJobs {
+		updatedJobIds[job.JobID] = true
+	}
+	runs := make([]database.Run, 0, len(updatedRuns))
+	orphanedRunIds := make([]uuid.UUID, 0)
+	orphanedRunsById := make(map[uuid.UUID]database.Run)
+	for _, run := range updatedRuns {
+		if updatedJobIds[run.JobID] || txn.GetById(run.JobID) != nil {
+			runs = append(runs, run)
+			continue
+		}
+		if _, ok := orphanedRunsById[run.RunID]; !ok {
+			orphanedRunIds = append(orphanedRunIds, run.RunID)
+		}
+		orphanedRunsById[run.RunID] = run
+	}
+
+	// Release updates whose job has since become known.
+	for runId := range q.runsById {
+		if _, ok := orphanedRunsById[runId]; !ok {
+			delete(q.runsById, runId)
+		}
+	}
+
+	for _, runId := range orphanedRunIds {
+		run := orphanedRunsById[runId]
+		if quarantined, ok := q.runsById[runId]; ok {
+			quarantined.run = run
+			quarantined.numCycles++
+			if quarantined.numCycles >= q.maxCycles {
+				delete(q.runsById, runId)
+				ctx.Warnf(
+					"dropping update of run %s on executor %s, since its job %s is still unknown after %d cycles",
+					run.RunID, run.Executor, run.JobID, quarantined.numCycles,
+				)
+				q.reportDropped(droppedRunUpdateReasonExpired)
+			}
+		} else if len(q.runsById) >= q.maxRuns {
+			ctx.Warnf(
+				"dropping update of run %s on executor %s of unknown job %s, since %d run updates are already quarantined",
+				run.RunID, run.Executor, run.JobID, len(q.runsById),
+			)
+			q.reportDropped(droppedRunUpdateReasonQuarantineFull)
+		} else {
+			q.runsById[runId] = &quarantinedRunUpdate{run: run}
+		}
+	}
+	if q.metrics != nil {
+		q.metrics.ReportQuarantinedRunUpdates(len(q.runsById))
+	}
+	return runs
+}
+
+func (q *RunUpdateQuarantine) reportDropped(reason string) {
+	if q.metrics != nil {
+		q.metrics.ReportDroppedRunUpdate(reason)
+	}
+}
@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func newRunUpdateQuarantineTestScheduler(t *testing.T, jobRepo *testJobRepository, maxCycles uint) *Scheduler {
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.EnableRunUpdateQuarantine(NewRunUpdateQuarantine(
+		schedulerconfig.RunUpdateQuarantineConfig{Enabled: true, MaxRuns: 10, MaxCycles: maxCycles},
+		schedulerMetrics,
+	))
+	return sched
+}
+
+func TestRunUpdateQuarantine_LaggingJobRow(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	jobId := util.NewULID()
+	runId := uuid.New()
+	jobRepo := &testJobRepository{
+		updatedRuns: []database.Run{
+			{
+				RunID:    runId,
+				JobID:    jobId,
+				JobSet:   "testJobSet",
+				Executor: "testExecutor",
+				Node:     "testNode",
+				Serial:   1,
+			},
+		},
+	}
+	sched := newRunUpdateQuarantineTestScheduler(t, jobRepo, 3)
+
+	// The row of the job hasn't been read yet; the run update is quarantined.
+	_, _, _, err := sched.syncState(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, sched.jobDb.ReadTxn().GetById(jobId))
+	assert.Len(t, sched.runUpdateQuarantine.runsById, 1)
+	assert.Equal(t, 1.0, testutil.ToFloat64(schedulerMetrics.quarantinedRunUpdates))
+
+	// The job row is read in a later cycle, without the run update, which was read already.
+	jobRepo.updatedRuns = nil
+	jobRepo.updatedJobs = []database.Job{
+		{
+			JobID:                 jobId,
+			JobSet:                "testJobSet",
+			Queue:                 "testQueue",
+			Queued:                false,
+			QueuedVersion:         1,
+			SchedulingInfo:        schedulingInfoBytes,
+			SchedulingInfoVersion: int32(schedulingInfo.Version),
+			Serial:                1,
+		},
+	}
+	_, _, _, err = sched.syncState(ctx)
+	require.NoError(t, err)
+	job := sched.jobDb.ReadTxn().GetById(jobId)
+	require.NotNil(t, job)
+	require.NotNil(t, job.RunById(runId))
+	assert.Equal(t, "testExecutor", job.RunById(runId).Executor())
+	assert.Empty(t, sched.runUpdateQuarantine.runsById)
+	assert.Equal(t, 0.0, testutil.ToFloat64(schedulerMetrics.quarantinedRunUpdates))
+}
+
+func TestRunUpdateQuarantine_PermanentOrphan(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	jobRepo := &testJobRepository{
+		updatedRuns: []database.Run{
+			{
+				RunID:    uuid.New(),
+				JobID:    util.NewULID(),
+				JobSet:   "testJobSet",
+				Executor: "testExecutor",
+				Node:     "testNode",
+				Serial:   1,
+			},
+		},
+	}
+	const maxCycles = 3
+	sched := newRunUpdateQuarantineTestScheduler(t, jobRepo, maxCycles)
+	droppedBefore := testutil.ToFloat64(schedulerMetrics.droppedRunUpdates.WithLabelValues(droppedRunUpdateReasonExpired))
+
+	_, _, _, err := sched.syncState(ctx)
+	require.NoError(t, err)
+	jobRepo.updatedRuns = nil
+
+	// The update is retried for maxCycles cycles, after which it's dropped.
+	for i := 1; i < maxCycles; i++ {
+		_, _, _, err = sched.syncState(ctx)
+		require.NoError(t, err)
+		assert.Len(t, sched.runUpdateQuarantine.runsById, 1)
+	}
+	_, _, _, err = sched.syncState(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, sched.runUpdateQuarantine.runsById)
+	assert.Empty(t, sched.jobDb.ReadTxn().GetAll())
+	assert.Equal(t, droppedBefore+1, testutil.ToFloat64(schedulerMetrics.droppedRunUpdates.WithLabelValues(droppedRunUpdateReasonExpired)))
+}
+
+func TestRunUpdateQuarantine_Full(t *testing.T) {
+	ctx := armadacontext.Background()
+	q := NewRunUpdateQuarantine(schedulerconfig.RunUpdateQuarantineConfig{Enabled: true, MaxRuns: 2, MaxCycles: 3}, nil)
+	runs := make([]database.Run, 3)
+	for i := range runs {
+		runs[i] = database.Run{RunID: uuid.New(), JobID: util.NewULID(), Executor: "testExecutor"}
+	}
+	txn := testfixtures.NewJobDb().ReadTxn()
+	assert.Empty(t, q.quarantineOrphanedRuns(ctx, txn, nil, runs))
+	assert.Len(t, q.runsById, 2)
+	assert.Contains(t, q.runsById, runs[0].RunID)
+	assert.Contains(t, q.runsById, runs[1].RunID)
+}
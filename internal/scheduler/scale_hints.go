@@ -0,0 +1,195 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/common/metrics"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+var (
+	executorIdleCyclesDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_executor_idle_cycles",
+		"Number of consecutive cycles the executor has had no active runs and no queued jobs only it could run.",
+		[]string{"executor", "pool"},
+		nil,
+	)
+	executorIdleDesc = prometheus.NewDesc(
+		metrics.MetricPrefix+"scheduler_executor_idle",
+		"1 if the executor has been idle for long enough to be considered safe to shrink and 0 otherwise.",
+		[]string{"executor", "pool"},
+		nil,
+	)
+)
+
+// ExecutorFeasibilityChecker determines which executors queued jobs could run on. It's implemented by SubmitChecker.
+type ExecutorFeasibilityChecker interface {
+	// Executors returns the capacity of each executor that isn't stale, indexed by executor id.
+	Executors() map[string]ExecutorCapacity
+	// FeasibleExecutors returns the ids of the executors that job could be scheduled on if they were empty.
+	FeasibleExecutors(job *jobdb.Job) []string
+}
+
+// ExecutorIdlenessTracker tracks for how many consecutive cycles each executor has been idle, i.e., has had no active
+// runs and no queued jobs that could only run on that executor, and implements the ScaleHints admin endpoint,
+// which lists executors that have been idle for long enough to be considered safe to shrink.
+// Queued jobs that could run on several executors don't prevent any of them from being idle;
+// instead, their requests are reported as the resources each idle executor should retain.
+type ExecutorIdlenessTracker struct {
+	enabled bool
+	// Executors idle for at least this many consecutive cycles are considered safe to shrink.
+	idleCycles uint
+	// Idleness of each executor as of the most recent update, indexed by executor id.
+	idlenessByExecutorId map[string]*executorIdleness
+	mu                   sync.Mutex
+}
+
+type executorIdleness struct {
+	capacity ExecutorCapacity
+	// Number of consecutive updates the executor has been idle for.
+	idleCycles uint
+	// Resources requested by queued jobs that could run on the executor or elsewhere,
+	// up to the total resources of the executor.
+	retainedResources schedulerobjects.ResourceList
+}
+
+func NewExecutorIdlenessTracker(config schedulerconfig.ScaleHintsConfig) *ExecutorIdlenessTracker {
+	return &ExecutorIdlenessTracker{
+		enabled:              config.Enabled,
+		idleCycles:           config.IdleCycles,
+		idlenessByExecutorId: make(map[string]*executorIdleness),
+	}
+}
+
+// Update records which of the executors provided by checker are idle given the jobs in txn.
+// Should be called by the scheduler once per cycle.
+func (t *ExecutorIdlenessTracker) Update(txn *jobdb.Txn, checker ExecutorFeasibilityChecker) {
+	executors := checker.Executors()
+
+	neededExecutorIds := make(map[string]bool)
+	for _, job := range txn.GetAll() {
+		if job.Queued() || job.InTerminalState() || !job.HasRuns() {
+			continue
+		}
+		if run := job.LatestRun(); !run.InTerminalState() {
+			neededExecutorIds[run.Executor()] = true
+		}
+	}
+	sharedDemandByExecutorId := make(map[string]schedulerobjects.ResourceList)
+	for _, queue := range txn.QueuesWithQueuedJobs() {
+		for _, job := range queuedJobs(txn, queue) {
+			var feasibleExecutorIds []string
+			for _, executorId := range checker.FeasibleExecutors(job) {
+				if _, ok := executors[executorId]; ok {
+					feasibleExecutorIds = append(feasibleExecutorIds, executorId)
+				}
+			}
+			if len(feasibleExecutorIds) == 1 {
+				neededExecutorIds[feasibleExecutorIds[0]] = true
+				continue
+			}
+			for _, executorId := range feasibleExecutorIds {
+				demand := sharedDemandByExecutorId[executorId]
+				demand.AddV1ResourceList(job.GetResourceRequirements().Requests)
+				sharedDemandByExecutorId[executorId] = demand
+			}
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	idlenessByExecutorId := make(map[string]*executorIdleness, len(executors))
+	for executorId, capacity := range executors {
+		idleness := &executorIdleness{
+			capacity:          capacity,
+			retainedResources: retainedResources(sharedDemandByExecutorId[executorId], capacity.TotalResources),
+		}
+		if !neededExecutorIds[executorId] {
+			idleness.idleCycles = 1
+			if previous, ok := t.idlenessByExecutorId[executorId]; ok {
+				idleness.idleCycles += previous.idleCycles
+			}
+		}
+		idlenessByExecutorId[executorId] = idleness
+	}
+	t.idlenessByExecutorId = idlenessByExecutorId
+}
+
+// retainedResources returns demand, limited to the amount of each resource in total.
+func retainedResources(demand, total schedulerobjects.ResourceList) schedulerobjects.ResourceList {
+	rv := schedulerobjects.ResourceList{Resources: make(map[string]resource.Quantity, len(demand.Resources))}
+	for t, q := range demand.Resources {
+		if available := total.Get(t); q.Cmp(available) > 0 {
+			q = available
+		}
+		rv.Resources[t] = q.DeepCopy()
+	}
+	return rv
+}
+
+// ScaleHints is a gRPC endpoint listing the executors that have been idle for long enough to be considered safe to shrink.
+func (t *ExecutorIdlenessTracker) ScaleHints(_ context.Context, request *schedulerobjects.ScaleHintsRequest) (*schedulerobjects.ScaleHintsResponse, error) {
+	if !t.enabled {
+		return nil, status.Error(codes.FailedPrecondition, "scale hints are disabled")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	executorIds := maps.Keys(t.idlenessByExecutorId)
+	slices.Sort(executorIds)
+	response := &schedulerobjects.ScaleHintsResponse{}
+	for _, executorId := range executorIds {
+		idleness := t.idlenessByExecutorId[executorId]
+		if idleness.idleCycles < t.idleCycles {
+			continue
+		}
+		if request.GetPool() != "" && idleness.capacity.Pool != request.GetPool() {
+			continue
+		}
+		response.Executors = append(response.Executors, &schedulerobjects.ExecutorScaleHint{
+			ExecutorId:        executorId,
+			Pool:              idleness.capacity.Pool,
+			IdleCycles:        uint32(idleness.idleCycles),
+			TotalResources:    idleness.capacity.TotalResources.DeepCopy(),
+			RetainedResources: idleness.retainedResources.DeepCopy(),
+		})
+	}
+	return response, nil
+}
+
+func (t *ExecutorIdlenessTracker) Describe(desc chan<- *prometheus.Desc) {
+	desc <- executorIdleCyclesDesc
+	desc <- executorIdleDesc
+}
+
+func (t *ExecutorIdlenessTracker) Collect(metrics chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for executorId, idleness := range t.idlenessByExecutorId {
+		metrics <- prometheus.MustNewConstMetric(
+			executorIdleCyclesDesc, prometheus.GaugeValue, float64(idleness.idleCycles), executorId, idleness.capacity.Pool,
+		)
+		idle := 0.0
+		if idleness.idleCycles >= t.idleCycles {
+			idle = 1
+		}
+		metrics <- prometheus.MustNewConstMetric(
+			executorIdleDesc, prometheus.GaugeValue, idle, executorId, idleness.capacity.Pool,
+		)
+	}
+}
+
+// EnableScaleHints causes tracker to be updated at the end of each cycle with the executors provided by checker.
+func (s *Scheduler) EnableScaleHints(tracker *ExecutorIdlenessTracker, checker ExecutorFeasibilityChecker) {
+	s.executorIdlenessTracker = tracker
+	s.executorFeasibilityChecker = checker
+}
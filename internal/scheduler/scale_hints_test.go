@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestExecutorIdlenessTracker(t *testing.T) {
+	ctx := armadacontext.Background()
+	executor1 := testfixtures.Test1Node32CoreExecutor("executor1")
+	executor2 := testfixtures.Test1Node32CoreExecutor("executor2")
+	executor2.Nodes = testfixtures.WithLabelsNodes(map[string]string{"gpu": "true"}, executor2.Nodes)
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{executor1, executor2}, nil).AnyTimes()
+	submitChecker := NewSubmitChecker(testfixtures.TestSchedulingConfig().ExecutorTimeout, testfixtures.TestSchedulingConfig(), mockExecutorRepo)
+	submitChecker.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	submitChecker.updateExecutors(ctx)
+
+	tracker := NewExecutorIdlenessTracker(schedulerconfig.ScaleHintsConfig{Enabled: true, IdleCycles: 2})
+	jobDb := testfixtures.NewJobDb()
+	upsert := func(jobs ...*jobdb.Job) {
+		txn := jobDb.WriteTxn()
+		require.NoError(t, txn.Upsert(jobs))
+		txn.Commit()
+	}
+	idleExecutorIds := func() []string {
+		response, err := tracker.ScaleHints(context.Background(), &schedulerobjects.ScaleHintsRequest{})
+		require.NoError(t, err)
+		return util.Map(response.Executors, func(hint *schedulerobjects.ExecutorScaleHint) string { return hint.ExecutorId })
+	}
+
+	// Executors are only considered safe to shrink once idle for the configured number of cycles.
+	tracker.Update(jobDb.ReadTxn(), submitChecker)
+	assert.Empty(t, idleExecutorIds())
+	tracker.Update(jobDb.ReadTxn(), submitChecker)
+	assert.Equal(t, []string{"executor1", "executor2"}, idleExecutorIds())
+	assert.Equal(t, 4, testutil.CollectAndCount(tracker))
+
+	// A queued job that could only run on executor2 makes it needed again.
+	gpuJob := testfixtures.WithNodeSelectorJob(
+		map[string]string{"gpu": "true"},
+		testfixtures.Test1Cpu4GiJob("A", testfixtures.PriorityClass0),
+	).WithQueued(true)
+	upsert(gpuJob)
+	tracker.Update(jobDb.ReadTxn(), submitChecker)
+	assert.Equal(t, []string{"executor1"}, idleExecutorIds())
+
+	// A queued job that could run on either executor doesn't prevent executor1 from being idle,
+	// but its requests are reported as resources to retain.
+	upsert(testfixtures.Test16Cpu128GiJob("A", testfixtures.PriorityClass0).WithQueued(true))
+	tracker.Update(jobDb.ReadTxn(), submitChecker)
+	response, err := tracker.ScaleHints(context.Background(), &schedulerobjects.ScaleHintsRequest{Pool: testfixtures.TestPool})
+	require.NoError(t, err)
+	require.Len(t, response.Executors, 1)
+	assert.Equal(t, "executor1", response.Executors[0].ExecutorId)
+	assert.Equal(t, uint32(4), response.Executors[0].IdleCycles)
+	assert.True(t, resource.MustParse("16").Equal(response.Executors[0].RetainedResources.Get("cpu")))
+	assert.True(t, resource.MustParse("32").Equal(response.Executors[0].TotalResources.Get("cpu")))
+
+	// Executors with running jobs are needed.
+	upsert(
+		gpuJob.WithQueued(false).WithNewRun("executor2", "executor2-node", "executor2-node", 0, testfixtures.BaseTime),
+		testfixtures.Test1Cpu4GiJob("A", testfixtures.PriorityClass0).WithNewRun("executor1", "executor1-node", "executor1-node", 0, testfixtures.BaseTime),
+	)
+	tracker.Update(jobDb.ReadTxn(), submitChecker)
+	assert.Empty(t, idleExecutorIds())
+	tracker.Update(jobDb.ReadTxn(), submitChecker)
+	assert.Empty(t, idleExecutorIds())
+
+	// Hints aren't available if disabled.
+	_, err = NewExecutorIdlenessTracker(schedulerconfig.ScaleHintsConfig{IdleCycles: 2}).ScaleHints(context.Background(), &schedulerobjects.ScaleHintsRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
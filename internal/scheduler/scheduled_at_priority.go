@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// storeBackfilledScheduledAtPriorities stores the scheduled-at priorities back-filled for the runs of jsts, if the job
+// repository supports it, such that runs stored without one needn't be back-filled each time they're loaded.
+// Failing to store them is logged rather than returned, since they're back-filled again when the runs are next loaded.
+func (s *Scheduler) storeBackfilledScheduledAtPriorities(ctx *armadacontext.Context, jsts []jobdb.JobStateTransitions) {
+	repo, ok := s.jobRepository.(database.ScheduledAtPriorityRepository)
+	if !ok {
+		return
+	}
+	priorityByRunId := make(map[uuid.UUID]int32)
+	for _, jst := range jsts {
+		if !jst.ScheduledAtPriorityBackfilled || jst.Job == nil {
+			continue
+		}
+		for _, run := range jst.Job.AllRuns() {
+			if priority := run.ScheduledAtPriority(); priority != nil {
+				priorityByRunId[run.Id()] = *priority
+			}
+		}
+	}
+	if len(priorityByRunId) == 0 {
+		return
+	}
+	if err := repo.BackfillScheduledAtPriorities(ctx, priorityByRunId); err != nil {
+		logging.
+			WithStacktrace(ctx, err).
+			Warnf("error storing back-filled scheduled-at priorities of %d runs", len(priorityByRunId))
+	}
+}
@@ -1,20 +1,23 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/renstrom/shortuuid"
+	"golang.org/x/exp/maps"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
 
-	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	"github.com/armadaproject/armada/internal/common/logging"
 	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	"github.com/armadaproject/armada/internal/scheduler/database"
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
 	"github.com/armadaproject/armada/internal/scheduler/kubernetesobjects/affinity"
@@ -27,10 +30,12 @@ import (
 // It periodically performs the following cycle:
 // 1. Update state from postgres (via the jobRepository).
 // 2. Determine if leader and exit if not.
-// 3. Generate any necessary events resulting from the state update.
-// 4. Expire any jobs assigned to clusters that have timed out.
-// 5. Schedule jobs.
-// 6. Publish any Armada events resulting from the scheduling cycle.
+// 3. Lease any newly submitted jobs of urgent priority classes, if enabled.
+// 4. Generate any necessary events resulting from the state update.
+// 5. Expire any jobs assigned to clusters that have timed out.
+// 6. Handle any jobs submitted to queues that don't exist.
+// 7. Schedule jobs.
+// 8. Publish any Armada events resulting from the scheduling cycle.
 type Scheduler struct {
 	// Provides job updates from Postgres.
 	jobRepository database.JobRepository
@@ -54,9 +59,9 @@ type Scheduler struct {
 	maxAttemptedRuns uint
 	// The label used when setting node anti affinities.
 	nodeIdLabel string
-	// If an executor fails to report in for this amount of time,
+	// If an executor fails to report in for longer than its timeout,
 	// all jobs assigne to that executor are cancelled.
-	executorTimeout time.Duration
+	executorTimeouts *ExecutorTimeouts
 	// The time the previous scheduling round ended
 	previousSchedulingRoundEnd time.Time
 	// Used for timing decisions (e.g., sleep).
@@ -68,12 +73,157 @@ type Scheduler struct {
 	jobsSerial int64
 	// Highest offset we've read from Postgres on the job runs table.
 	runsSerial int64
-	// Function that is called every time a cycle is completed. Useful for testing.
-	onCycleCompleted func()
+	// True once the jobDb has been loaded from scratch, i.e., once syncState has succeeded after startup or after
+	// the jobDb was last cleared; jobs loaded before then aren't new to the system, e.g., after a restart.
+	jobDbLoaded bool
+	// Receive a summary of each completed cycle.
+	cycleSubscribers cycleSubscribers
 	// metrics set for the scheduler.
 	metrics *SchedulerMetrics
 	// New scheduler metrics due to replace the above.
 	schedulerMetrics *metrics.Metrics
+	// Determines how jobs submitted to queues that don't exist are handled.
+	unknownQueuesConfig schedulerconfig.UnknownQueuesConfig
+	// Used to look up (and possibly create) queues when handling jobs in unknown queues
+	// and to look up the backlog limit of each queue.
+	// If nil, jobs in unknown queues are left queued.
+	queueRepository database.QueueRepository
+	// Ids of jobs currently held in unknown queues.
+	heldJobIds map[string]bool
+	// Time at which each unknown queue with held jobs was first found not to exist.
+	unknownQueuesMissingSince map[string]time.Time
+	// If true, new jobs are failed if their queue already has as many queued jobs as its backlog limit.
+	queueBacklogLimitsEnabled bool
+	// Backlog limit of queues for which none is set in the queue repository. Zero means no limit.
+	defaultMaxQueuedJobs uint32
+	// If non-nil, the priority of jobs is capped at the maximum job priority of their queue.
+	queuePriorityCapsConfig *schedulerconfig.QueuePriorityCapsConfig
+	// Maximum job priority of each queue with one set, as of the last call to syncState.
+	maxJobPriorityByQueue map[string]uint32
+	// Backlog limits of the queues of the jobs in the jobDb found to exceed them when admitted, by job id.
+	// Such jobs are failed by the leader and forgotten once the cycle failing them is committed.
+	backlogLimitedJobs map[string]uint32
+	// Diagnostics of the jobs in the jobDb rejected since they're in a different queue or job set to the rest of their
+	// gang, by job id. Such jobs are failed by the leader and forgotten once the cycle failing them is committed.
+	crossQueueGangJobs map[string]string
+	// If non-nil, set while catching up after becoming leader, so that the executor api can hold back new leases.
+	catchUpState *CatchUpState
+	// The scheduler has caught up once a cycle reads at most this many new serials from postgres.
+	maxCatchUpSerialLag int64
+	// The time at which the scheduler started catching up.
+	catchUpStarted time.Time
+	// If non-nil, updated each cycle to estimate queue wait times.
+	waitTimeEstimator *WaitTimeEstimator
+	// If non-nil, updated each cycle with the executors provided by executorFeasibilityChecker to track idle executors.
+	executorIdlenessTracker    *ExecutorIdlenessTracker
+	executorFeasibilityChecker ExecutorFeasibilityChecker
+	// If non-nil, nudges applied by this nudger are cleared after each scheduling round.
+	jobNudger *JobNudger
+	// If non-nil, jobs force-failed via the admin API are failed each cycle.
+	jobForceFailer *JobForceFailer
+	// Used to coalesce warnings logged repeatedly by the cycle, e.g., for each job running on a stale executor.
+	warnings *logging.WarningCoalescer
+	// If true, at-most-once jobs are retried once if the executor reports it never acted on the lease.
+	retryUnacknowledgedAtMostOnceJobs bool
+	// If non-nil, notified of jobs becoming terminal so that stats for finished job sets are eventually discarded.
+	jobSetPlacementTracker *JobSetPlacementTracker
+	// If non-nil, queued jobs of urgentPriorityClasses are leased by this algo as soon as they're discovered,
+	// before the rest of the cycle runs.
+	urgentSchedulingAlgo  UrgentSchedulingAlgo
+	urgentPriorityClasses []string
+	// If true, jobs for which the job repository provides inconsistent scheduling info are re-fetched
+	// to determine whether the jobDb or the job repository is right.
+	refetchOnSchedulingInfoConflict bool
+	// If non-nil, jobs whose failed run has no error in the database are failed with a placeholder error
+	// and the run error is published once it becomes available.
+	runErrorBackfill *runErrorBackfill
+	// Runs leased in the current and previous cycle.
+	recentLeases recentLeases
+	// If non-nil, the errors of failed runs are classified to determine whether their job is retried.
+	runErrorClassifier *RunErrorClassifier
+	// If non-nil, only jobs of queues owned by this shard are loaded into the jobDb.
+	shardAssignment *ShardAssignment
+	// If sharding is enabled, records the runs of jobs of other shards.
+	foreignAllocations *ForeignAllocations
+	// If true, resource usage reported by executors is loaded onto the runs in the jobDb.
+	runResourceUsageEnabled bool
+	// Highest offset we've read from Postgres on the job run resource usage table.
+	runResourceUsageSerial int64
+	// If non-nil, the age of the oldest unprocessed job or run update is sampled and recorded here.
+	updateStalenessTracker         *UpdateStalenessTracker
+	updateStalenessSamplePeriod    time.Duration
+	maxUpdateStaleness             time.Duration
+	failReadinessOnUpdateStaleness bool
+	// If non-nil, cancelled runs are tracked until their executor stops them and escalated if it doesn't do so in time.
+	cancellationEnforcer *cancellationEnforcer
+	// Number of consecutive cycles that failed due to transient repository errors.
+	consecutiveTransientCycleFailures atomic.Int64
+	// If positive, the scheduler reports unhealthy once this many consecutive cycles have failed
+	// due to transient repository errors.
+	maxConsecutiveTransientCycleFailures int
+	// If non-nil, configs reloaded by this reloader are applied at the start of each cycle.
+	configReloader *ConfigReloader
+	// Generation of the most recently applied reloaded config.
+	configGeneration uint64
+	// If non-nil, the serials read up to are checked against those assigned by the database before each fetch.
+	serialRegressionConfig *schedulerconfig.SerialRegressionConfig
+	// Set once the scheduler has halted due to a serial regression.
+	haltedBySerialRegression atomic.Bool
+	// Number of consecutive scheduling rounds in which the scheduling algorithm panicked.
+	consecutiveSchedulingPanics int
+	// If non-nil, queues that cause the scheduling algorithm to panic are quarantined.
+	queueQuarantineConfig *schedulerconfig.QueueQuarantineConfig
+	// Time at which each quarantined queue was quarantined.
+	quarantinedQueues map[string]time.Time
+	// If non-nil, run outcomes are recorded here such that nodes on which many runs fail are quarantined.
+	nodeQuarantine *NodeQuarantine
+	// If non-nil, a snapshot of the jobDb is stored here after each cycle that published its events,
+	// from which the executor api serves leases.
+	jobDbLeaseSnapshots *JobDbLeaseSnapshots
+	// If non-nil, queued jobs requesting more of some resource than the largest node has allocatable are failed.
+	largestNodeResourcesProvider LargestNodeResourcesProvider
+	// Jobs are only failed once the largest node resources have remained unchanged for this long.
+	largestNodeStabilisationPeriod time.Duration
+	// Largest node resources as of the last cycle and the time at which they were first observed.
+	observedLargestNodeResources      schedulerobjects.ResourceList
+	largestNodeResourcesObservedSince time.Time
+	// Largest node resources as of the last time all queued jobs were checked against them.
+	lastCheckedLargestNodeResources schedulerobjects.ResourceList
+	// If non-nil, updates of runs of jobs unknown to the scheduler are quarantined and retried in subsequent cycles.
+	runUpdateQuarantine *RunUpdateQuarantine
+	// If true, a JobValidated event is published for each job the first time it's admitted.
+	publishJobValidatedEvents bool
+	// If non-nil, used to notify about jobs failed since they were attempted the maximum number of times.
+	retryExhaustionNotifier *RetryExhaustionNotifier
+	// If true, the error of the most recent failed run of each job is recorded in the jobDb.
+	lastRunErrorTracking bool
+	// Clock skew of each executor as of the last time executors were checked for staleness,
+	// used to correct the run phase latencies computed from timestamps reported by executors.
+	executorClockSkews map[string]time.Duration
+	// If positive, executors whose clock skew exceeds this are reported as such.
+	executorClockSkewWarningThreshold time.Duration
+	// If non-nil, events are checked against the state transitions applied to the jobDb before being published.
+	eventConsistencyConfig *schedulerconfig.EventConsistencyConfig
+	// Notified of the state transitions of each cycle the scheduler is leader in.
+	stateTransitionListeners []StateTransitionListener
+	// If true, each scheduling round is aborted if the scheduling algorithm writes jobs it wasn't allowed to;
+	// see EnableJobDbWriteGuard.
+	jobDbWriteGuard bool
+	// If non-nil, the cycle and schedule periods are jittered; see EnableCycleJitter.
+	cycleJitter PeriodJitter
+	// Time to wait after the end of the previous scheduling round before the next,
+	// i.e., schedulePeriod, jittered anew after each round if cycleJitter is non-nil.
+	jitteredSchedulePeriod time.Duration
+	// If non-nil, the jobDb is rebuilt from the job repository when requested via the admin api.
+	jobDbRebuilder *JobDbRebuilder
+	// Feature gates enabled for each queue; a nil FeatureGate has no gates enabled.
+	featureGates *FeatureGate
+	// If true, scheduling rounds are run and discarded while not leader; see shadowSchedule.
+	shadowScheduling bool
+	// Time at which the most recent shadow scheduling round ended.
+	previousShadowRoundEnd time.Time
+	// If non-nil, burst credit debts are restored from the job repository on becoming leader.
+	burstCredits *BurstCreditLedger
 }
 
 func NewScheduler(
@@ -103,14 +253,16 @@ func NewScheduler(
 		clock:                      clock.RealClock{},
 		cyclePeriod:                cyclePeriod,
 		schedulePeriod:             schedulePeriod,
+		jitteredSchedulePeriod:     schedulePeriod,
 		previousSchedulingRoundEnd: time.Time{},
-		executorTimeout:            executorTimeout,
+		executorTimeouts:           NewExecutorTimeouts(executorTimeout),
 		maxAttemptedRuns:           maxAttemptedRuns,
 		nodeIdLabel:                nodeIdLabel,
 		jobsSerial:                 -1,
 		runsSerial:                 -1,
 		metrics:                    metrics,
 		schedulerMetrics:           schedulerMetrics,
+		crossQueueGangJobs:         make(map[string]string),
 	}, nil
 }
 
@@ -126,7 +278,8 @@ func (s *Scheduler) Run(ctx *armadacontext.Context) error {
 	}
 	ctx.Infof("JobDb initialised in %s", s.clock.Since(start))
 
-	ticker := s.clock.NewTicker(s.cyclePeriod)
+	ticker := newTicker(s.clock, s.cyclePeriod, s.cycleJitter)
+	defer ticker.Stop()
 	prevLeaderToken := InvalidLeaderToken()
 	for {
 		select {
@@ -135,7 +288,8 @@ func (s *Scheduler) Run(ctx *armadacontext.Context) error {
 			return ctx.Err()
 		case <-ticker.C():
 			start := s.clock.Now()
-			ctx := armadacontext.WithLogField(ctx, "cycleId", shortuuid.New())
+			cycleId := shortuuid.New()
+			ctx := withCycleId(armadacontext.WithLogField(ctx, "cycleId", cycleId), cycleId)
 			leaderToken := s.leaderController.GetToken()
 			fullUpdate := false
 			ctx.Infof("received leaderToken; leader status is %t", leaderToken.leader)
@@ -162,13 +316,24 @@ func (s *Scheduler) Run(ctx *armadacontext.Context) error {
 			//
 			// TODO: Once the Pulsar client supports transactions, we can guarantee consistency even in case of errors.
 
-			shouldSchedule := s.clock.Now().Sub(s.previousSchedulingRoundEnd) > s.schedulePeriod
+			shouldSchedule := s.clock.Now().Sub(s.previousSchedulingRoundEnd) > s.jitteredSchedulePeriod
+			if shouldSchedule && s.cycleJitter != nil {
+				s.jitteredSchedulePeriod = s.cycleJitter.Jitter(s.schedulePeriod)
+			}
 
+			prevJobsSerial, prevRunsSerial := s.jobsSerial, s.runsSerial
+			cycleLeaderToken := leaderToken
 			result, err := s.cycle(ctx, fullUpdate, leaderToken, shouldSchedule)
+			if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+				ctx.Infof("context cancelled during scheduling cycle; returning.")
+				return ctx.Err()
+			}
+			s.recordCycleOutcome(err)
 			if err != nil {
 				logging.WithStacktrace(ctx, err).Error("scheduling cycle failure")
 				leaderToken = InvalidLeaderToken()
 			}
+			s.updateCatchUpState(ctx, leaderToken, fullUpdate, (s.jobsSerial-prevJobsSerial)+(s.runsSerial-prevRunsSerial))
 
 			cycleTime := s.clock.Since(start)
 
@@ -185,19 +350,130 @@ func (s *Scheduler) Run(ctx *armadacontext.Context) error {
 			}
 
 			prevLeaderToken = leaderToken
-			if s.onCycleCompleted != nil {
-				s.onCycleCompleted()
-			}
+			s.cycleSubscribers.publish(newCycleSummary(
+				cycleId, s.clock.Now(), cycleTime, cycleLeaderToken, shouldSchedule && cycleLeaderToken.leader, result, err,
+			))
 		}
 	}
 }
 
+// EnableCycleJitter causes the time between cycles and the time between scheduling rounds to be jittered by jitter,
+// with the first cycle delayed by jitter.Phase. Must be called before Run.
+func (s *Scheduler) EnableCycleJitter(jitter PeriodJitter) {
+	s.cycleJitter = jitter
+}
+
+// EnableWaitTimeEstimation causes estimator to be updated with the jobs scheduled and queued at the end of each cycle
+// and the resulting estimates to be exported as metrics. Must be called before any jobs are loaded into the jobDb.
+func (s *Scheduler) EnableWaitTimeEstimation(estimator *WaitTimeEstimator) {
+	estimator.CountQueuedJobs(s.jobDb)
+	s.waitTimeEstimator = estimator
+}
+
+// EnableAtMostOnceSafeRetry allows at-most-once jobs to be retried once if their first run is returned
+// by an executor that never acted on the lease, i.e., never created a pod for it.
+func (s *Scheduler) EnableAtMostOnceSafeRetry() {
+	s.retryUnacknowledgedAtMostOnceJobs = true
+}
+
+// EnableJobSetPlacementTracking causes tracker to be notified of jobs becoming terminal
+// and to be pruned at the end of each cycle.
+func (s *Scheduler) EnableJobSetPlacementTracking(tracker *JobSetPlacementTracker) {
+	s.jobSetPlacementTracker = tracker
+}
+
+// EnableUrgentScheduling causes queued jobs of the given priority classes to be leased by algo
+// in each cycle in which they're received, ahead of and independently of the regular scheduling rounds.
+func (s *Scheduler) EnableUrgentScheduling(algo UrgentSchedulingAlgo, priorityClasses []string) {
+	s.urgentSchedulingAlgo = algo
+	s.urgentPriorityClasses = priorityClasses
+}
+
+// EnableSchedulingInfoConflictRefetch causes jobs for which the job repository provides scheduling info
+// inconsistent with that in the jobDb to be re-fetched from the job repository.
+// The scheduling info in the jobDb is replaced by that re-fetched unless the re-fetched version is lower.
+func (s *Scheduler) EnableSchedulingInfoConflictRefetch() {
+	s.refetchOnSchedulingInfoConflict = true
+}
+
+// EnableExecutorTimeoutOverrides causes the timeout of each executor to be looked up in executorTimeouts,
+// such that overrides set via the admin API take effect.
+func (s *Scheduler) EnableExecutorTimeoutOverrides(executorTimeouts *ExecutorTimeouts) {
+	s.executorTimeouts = executorTimeouts
+}
+
+// EnableJobNudges causes jobs nudged via nudger to be un-nudged after the scheduling round following the nudge.
+func (s *Scheduler) EnableJobNudges(nudger *JobNudger) {
+	s.jobNudger = nudger
+}
+
+// EnableJobForceFailure causes jobs force-failed via failer to be failed by the cycle following the request.
+func (s *Scheduler) EnableJobForceFailure(failer *JobForceFailer) {
+	s.jobForceFailer = failer
+}
+
+// EnableWarningCoalescing causes identical warnings logged by the cycle to be coalesced by coalescer.
+// Warnings suppressed in windows that have ended are logged at the end of each cycle.
+func (s *Scheduler) EnableWarningCoalescing(coalescer *logging.WarningCoalescer) {
+	s.warnings = coalescer
+}
+
+// EnableRetryExhaustionNotifications causes notifier to be notified about each job failed
+// since it was attempted the maximum number of times.
+func (s *Scheduler) EnableRetryExhaustionNotifications(notifier *RetryExhaustionNotifier) {
+	s.retryExhaustionNotifier = notifier
+}
+
+// EnableLastRunErrorTracking causes a summary of the error of the most recent failed run of each job
+// to be recorded in the jobDb, such that job reports can include it.
+func (s *Scheduler) EnableLastRunErrorTracking() {
+	s.lastRunErrorTracking = true
+}
+
+// EnableRunResourceUsage causes the most recent resource usage reported by executors for each run
+// to be loaded onto the runs in the jobDb, such that it can be taken into account when selecting preemption victims.
+func (s *Scheduler) EnableRunResourceUsage() {
+	s.runResourceUsageEnabled = true
+	s.runResourceUsageSerial = -1
+}
+
+// EnableExecutorClockSkewWarnings causes executors whose clock is skewed relative to that of the scheduler
+// by more than threshold to be reported as such.
+func (s *Scheduler) EnableExecutorClockSkewWarnings(threshold time.Duration) {
+	s.executorClockSkewWarningThreshold = threshold
+}
+
+// EnableFeatureGates causes new behaviour to be enabled for the queues with the corresponding gate enabled in gates.
+func (s *Scheduler) EnableFeatureGates(gates *FeatureGate) {
+	s.featureGates = gates
+}
+
+// EnableBurstCreditPersistence causes the burst credit debts of ledger to be stored in the job repository,
+// and restored from it on becoming leader, such that they survive restarts and failovers.
+func (s *Scheduler) EnableBurstCreditPersistence(ledger *BurstCreditLedger, repository database.BurstCreditRepository) {
+	ledger.EnablePersistence(repository)
+	s.burstCredits = ledger
+}
+
 // cycle is a single iteration of the main scheduling loop.
 // If updateAll is true, we generate events from all jobs in the jobDb.
 // Otherwise, we only generate events from jobs updated since the last cycle.
 func (s *Scheduler) cycle(ctx *armadacontext.Context, updateAll bool, leaderToken LeaderToken, shouldSchedule bool) (SchedulerResult, error) {
 	// TODO: Consider returning a slice of these instead.
 	overallSchedulerResult := SchedulerResult{}
+	// Per-queue cycle metrics of queues no longer considered expire whether or not this cycle schedules or succeeds.
+	defer func() { s.metrics.ExpireQueueCycleMetrics(s.clock.Now()) }()
+	s.applyReloadedConfig(ctx)
+	s.recentLeases.startCycle()
+	s.sampleUpdateStaleness(ctx)
+
+	// Scheduling is paused while the jobDb is being rebuilt.
+	// Once the rebuilt jobDb has been swapped in, we don't know which of its jobs need events, as when becoming leader.
+	if s.jobDbRebuilder != nil {
+		rebuilding, swapped := s.advanceJobDbRebuild(ctx, leaderToken)
+		shouldSchedule = shouldSchedule && !rebuilding
+		updateAll = updateAll || swapped
+	}
 
 	// Update job state.
 	updatedJobs, jsts, jobRepoRunErrorsByRunId, err := s.syncState(ctx)
@@ -209,15 +485,51 @@ func (s *Scheduler) cycle(ctx *armadacontext.Context, updateAll bool, leaderToke
 	// Only export metrics if leader.
 	if !s.leaderController.ValidateToken(leaderToken) {
 		s.schedulerMetrics.Disable()
+		if s.shadowScheduling && s.clock.Now().Sub(s.previousShadowRoundEnd) > s.jitteredSchedulePeriod {
+			s.shadowSchedule(ctx)
+		}
 		return overallSchedulerResult, err
 	} else {
 		s.schedulerMetrics.Enable()
 	}
 
+	// Other replicas may have updated the stored burst credit debts while not leader.
+	if updateAll && s.burstCredits != nil {
+		s.burstCredits.Invalidate()
+	}
+
 	// Update metrics.
 	if err := s.schedulerMetrics.UpdateMany(ctx, jsts, jobRepoRunErrorsByRunId); err != nil {
 		return overallSchedulerResult, err
 	}
+	for _, listener := range s.stateTransitionListeners {
+		listener.OnStateTransitions(ctx, s.clock.Now(), jsts)
+	}
+	s.storeBackfilledScheduledAtPriorities(ctx, jsts)
+
+	// Collect newly admitted jobs before urgent jobs are leased, such that urgent jobs are acknowledged too.
+	var jobIdsToAcknowledge []string
+	if s.publishJobValidatedEvents {
+		if updateAll {
+			jobIdsToAcknowledge = unacknowledgedJobIds(s.jobDb.ReadTxn().GetAll())
+		} else {
+			jobIdsToAcknowledge = unacknowledgedJobIds(updatedJobs)
+		}
+	}
+
+	// Lease urgent jobs straight away, so that they don't have to wait for the next scheduling round.
+	// These jobs are no longer queued by the time the scheduling round runs.
+	// Results of the rounds that leased jobs in this cycle, the latencies of which are reported once it's committed.
+	var leasingResults []*SchedulerResult
+	var urgentSchedulerResult *SchedulerResult
+	if s.urgentSchedulingAlgo != nil {
+		updatedJobs, urgentSchedulerResult, err = s.scheduleUrgentJobs(ctx, updatedJobs, leaderToken)
+		if err != nil {
+			return overallSchedulerResult, err
+		}
+		s.recentLeases.addFromSchedulerResult(urgentSchedulerResult)
+		leasingResults = append(leasingResults, urgentSchedulerResult)
+	}
 
 	// If we've been asked to generate messages for all jobs, do so.
 	// Otherwise, generate messages only for jobs updated this cycle.
@@ -227,11 +539,28 @@ func (s *Scheduler) cycle(ctx *armadacontext.Context, updateAll bool, leaderToke
 		updatedJobs = txn.GetAll()
 	}
 
+	// Publish the errors of runs failed in previous cycles before their error was written to the database.
+	// This happens before generating update messages, such that runs failed this cycle are only checked from the next.
+	events, backfilledRunIds, err := s.generateBackfilledRunErrorEvents(ctx)
+	if err != nil {
+		return overallSchedulerResult, err
+	}
+
+	// Release quarantined nodes whose quarantine has expired.
+	if s.nodeQuarantine != nil {
+		s.nodeQuarantine.ReleaseExpired(ctx, s.clock.Now())
+	}
+
 	// Generate any events that came out of synchronising the db state.
-	events, err := s.generateUpdateMessages(ctx, txn, updatedJobs, jobRepoRunErrorsByRunId)
+	// Notifications staged by earlier cycles that failed to publish are discarded, since those jobs are failed again.
+	if s.retryExhaustionNotifier != nil {
+		s.retryExhaustionNotifier.DiscardStaged()
+	}
+	updateEvents, err := s.generateUpdateMessages(ctx, txn, updatedJobs, jobRepoRunErrorsByRunId)
 	if err != nil {
 		return overallSchedulerResult, err
 	}
+	events = append(events, updateEvents...)
 
 	// Expire any jobs running on clusters that haven't heartbeated within the configured deadline.
 	expirationEvents, err := s.expireJobsIfNecessary(ctx, txn)
@@ -240,6 +569,24 @@ func (s *Scheduler) cycle(ctx *armadacontext.Context, updateAll bool, leaderToke
 	}
 	events = append(events, expirationEvents...)
 
+	// Fail any jobs operators have requested be failed via the admin API.
+	var forceFailedJobIds []string
+	if s.jobForceFailer != nil {
+		var forceFailEvents []*armadaevents.EventSequence
+		forceFailEvents, forceFailedJobIds, err = s.jobForceFailer.FailJobs(ctx, txn, s.clock.Now())
+		if err != nil {
+			return overallSchedulerResult, err
+		}
+		events = append(events, forceFailEvents...)
+	}
+
+	// Re-publish or fail cancelled runs executors haven't stopped within the cancellation deadline.
+	cancellationEvents, forceFailedRunIds, err := s.enforceCancellations(ctx, txn)
+	if err != nil {
+		return overallSchedulerResult, err
+	}
+	events = append(events, cancellationEvents...)
+
 	// Request cancel for any jobs that exceed queueTtl
 	queueTtlCancelEvents, err := s.cancelQueuedJobsIfExpired(txn)
 	if err != nil {
@@ -247,13 +594,58 @@ func (s *Scheduler) cycle(ctx *armadacontext.Context, updateAll bool, leaderToke
 	}
 	events = append(events, queueTtlCancelEvents...)
 
+	// Fail, hold, or create queues for any jobs submitted to queues that don't exist.
+	unknownQueueEvents, err := s.handleJobsInUnknownQueues(ctx, txn)
+	if err != nil {
+		return overallSchedulerResult, err
+	}
+	events = append(events, unknownQueueEvents...)
+
+	// Fail any new jobs found to exceed the backlog limit of their queue when admitted.
+	backlogLimitedJobEvents, backlogLimitedJobIds, err := s.failBacklogLimitedJobs(txn)
+	if err != nil {
+		return overallSchedulerResult, err
+	}
+	events = append(events, backlogLimitedJobEvents...)
+
+	// Fail any jobs whose gang has members in multiple queues or job sets.
+	crossQueueGangJobEvents, crossQueueGangJobIds, err := s.failCrossQueueGangJobs(txn)
+	if err != nil {
+		return overallSchedulerResult, err
+	}
+	events = append(events, crossQueueGangJobEvents...)
+
+	// Fail any queued jobs that request more of some resource than the largest node has allocatable.
+	oversizedJobEvents, err := s.failOversizedJobs(ctx, txn, updatedJobs)
+	if err != nil {
+		return overallSchedulerResult, err
+	}
+	events = append(events, oversizedJobEvents...)
+
+	// Acknowledge newly admitted jobs not failed above.
+	if s.publishJobValidatedEvents {
+		jobValidatedEvents, err := s.generateJobValidatedEvents(ctx, txn, jobIdsToAcknowledge)
+		if err != nil {
+			return overallSchedulerResult, err
+		}
+		events = append(events, jobValidatedEvents...)
+	}
+
 	// Schedule jobs.
 	if shouldSchedule {
+		// Panics in the scheduling algorithm are returned as errors, such that the txn is rolled back.
 		var result *SchedulerResult
-		result, err = s.schedulingAlgo.Schedule(ctx, txn)
+		guard := s.guardJobDbWrites(txn)
+		result, err = s.schedule(ctx, txn)
 		if err != nil {
 			return overallSchedulerResult, err
 		}
+		if err := guard.check(s, txn); err != nil {
+			return overallSchedulerResult, err
+		}
+		if err := s.recordSchedulingOutcomes(ctx, txn, result); err != nil {
+			return overallSchedulerResult, err
+		}
 
 		var resultEvents []*armadaevents.EventSequence
 		resultEvents, err = s.eventsFromSchedulerResult(result)
@@ -261,30 +653,124 @@ func (s *Scheduler) cycle(ctx *armadacontext.Context, updateAll bool, leaderToke
 			return overallSchedulerResult, err
 		}
 		events = append(events, resultEvents...)
+		s.recentLeases.addFromSchedulerResult(result)
+		leasingResults = append(leasingResults, result)
 		s.previousSchedulingRoundEnd = s.clock.Now()
 
+		// Nudged jobs are only moved to the front of their priority band for a single scheduling round.
+		if s.jobNudger != nil {
+			if err := s.jobNudger.ClearNudges(txn); err != nil {
+				return overallSchedulerResult, err
+			}
+		}
+
 		overallSchedulerResult = *result
 	}
+	if urgentSchedulerResult != nil {
+		overallSchedulerResult.ScheduledJobs = append(overallSchedulerResult.ScheduledJobs, urgentSchedulerResult.ScheduledJobs...)
+	}
+
+	// Abort rather than commit state transitions downstream systems wouldn't learn of.
+	if err := s.checkEventConsistency(ctx, txn, events); err != nil {
+		return overallSchedulerResult, err
+	}
 
 	// Publish to Pulsar.
+	attachCorrelationIds(ctx, txn, updatedJobs, events)
 	isLeader := func() bool {
 		return s.leaderController.ValidateToken(leaderToken)
 	}
 	start := s.clock.Now()
-	if err = s.publisher.PublishMessages(ctx, events, isLeader); err != nil {
+	// Events are stamped with the epoch of the leader that made the decisions.
+	if err = s.publisher.PublishMessages(withLeaderEpoch(ctx, leaderToken.Epoch()), events, isLeader); err != nil {
+		var incompleteErr *PublishIncompleteError
+		if errors.As(err, &incompleteErr) {
+			ctx.Warnf(
+				"%d event sequences were acknowledged by pulsar and %d weren't before publishing failed in %s",
+				len(incompleteErr.Acknowledged), incompleteErr.NumUnacknowledged, s.clock.Since(start),
+			)
+		}
 		return overallSchedulerResult, err
 	}
 	ctx.Infof("published %d events to pulsar in %s", len(events), s.clock.Since(start))
 	txn.Commit()
+	if s.jobDbLeaseSnapshots != nil {
+		s.jobDbLeaseSnapshots.Store(s.jobDb.ReadTxn(), leaderToken, s.clock.Now())
+	}
+	s.resolveBackfilledRunErrors(backfilledRunIds)
+	s.resolveEnforcedCancellations(forceFailedRunIds)
+	s.resolveBacklogLimitedJobs(backlogLimitedJobIds)
+	s.resolveCrossQueueGangJobs(crossQueueGangJobIds)
+	if s.retryExhaustionNotifier != nil {
+		s.retryExhaustionNotifier.Flush(ctx)
+	}
+	if s.jobForceFailer != nil {
+		s.jobForceFailer.Resolve(forceFailedJobIds)
+	}
+
+	// Report how long leased jobs waited and how long the oldest queued jobs have been waiting.
+	s.reportQueueToLeaseLatencies(leasingResults)
+	if shouldSchedule {
+		s.metrics.ReportQueueCycleBreakdown(s.clock.Now(), overallSchedulerResult)
+	}
+	s.reportOldestQueuedJobAges(s.jobDb.ReadTxn())
+
+	// Refresh wait time estimates.
+	if s.waitTimeEstimator != nil {
+		s.waitTimeEstimator.Update(s.clock.Now(), s.jobDb.ReadTxn(), overallSchedulerResult)
+		s.metrics.ReportEstimatedWaitTimes(s.waitTimeEstimator.Estimates())
+	}
+
+	// Refresh which executors are idle.
+	if s.executorIdlenessTracker != nil {
+		s.executorIdlenessTracker.Update(s.jobDb.ReadTxn(), s.executorFeasibilityChecker)
+	}
 
 	// Update metrics based on overallSchedulerResult.
 	if err := s.updateMetricsFromSchedulerResult(ctx, overallSchedulerResult); err != nil {
 		return overallSchedulerResult, err
 	}
 
+	// Log warnings suppressed in windows that have ended.
+	s.warnings.Flush(ctx)
+
 	return overallSchedulerResult, nil
 }
 
+// reportQueueToLeaseLatencies reports, for each job leased by results, the time from the job being submitted
+// until its run was created, as measured by the clock of the scheduling algorithm.
+func (s *Scheduler) reportQueueToLeaseLatencies(results []*SchedulerResult) {
+	for _, result := range results {
+		for _, jctx := range result.ScheduledJobs {
+			job := jctx.Job.(*jobdb.Job)
+			run := job.LatestRun()
+			if run == nil {
+				continue
+			}
+			priorityClassName, _ := s.jobDb.EffectivePriorityClassName(job)
+			s.metrics.ReportQueueToLeaseLatency(
+				job.Queue(),
+				priorityClassName,
+				result.PlacementByJobId[job.Id()].Pool,
+				time.Duration(run.Created()-job.Created()),
+			)
+		}
+	}
+}
+
+// reportOldestQueuedJobAges reports the time since the oldest queued job of each priority class was submitted,
+// which is zero for priority classes without queued jobs.
+func (s *Scheduler) reportOldestQueuedJobAges(txn *jobdb.Txn) {
+	now := s.clock.Now()
+	for priorityClassName, job := range txn.OldestQueuedJobByPriorityClass() {
+		var age time.Duration
+		if job != nil && now.After(time.Unix(0, job.Created())) {
+			age = now.Sub(time.Unix(0, job.Created()))
+		}
+		s.metrics.ReportOldestQueuedJobAge(priorityClassName, age)
+	}
+}
+
 func (s *Scheduler) updateMetricsFromSchedulerResult(ctx *armadacontext.Context, overallSchedulerResult SchedulerResult) error {
 	for _, jctx := range overallSchedulerResult.ScheduledJobs {
 		if err := s.schedulerMetrics.UpdateScheduled(jctx); err != nil {
@@ -317,23 +803,104 @@ func (s *Scheduler) syncState(ctx *armadacontext.Context) ([]*jobdb.Job, []jobdb
 	defer txn.Abort()
 
 	// Load new and updated jobs from the jobRepo.
-	updatedJobs, updatedRuns, err := s.jobRepository.FetchJobUpdates(ctx, s.jobsSerial, s.runsSerial)
+	if err := s.checkSerialRegression(ctx, txn); err != nil {
+		return nil, nil, nil, err
+	}
+	var updatedJobs []database.Job
+	var updatedRuns []database.Run
+	err := s.retryTransient(ctx, "fetching job updates", func() error {
+		var err error
+		updatedJobs, updatedRuns, err = s.jobRepository.FetchJobUpdates(ctx, s.jobsSerial, s.runsSerial)
+		return err
+	})
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	// Serials are updated based on all updates received, including those of jobs owned by other shards.
+	fetchedJobs, fetchedRuns := updatedJobs, updatedRuns
+	if s.runUpdateQuarantine != nil {
+		updatedRuns = s.runUpdateQuarantine.withQuarantinedRuns(updatedRuns)
+	}
+	if s.shardAssignment != nil {
+		updatedJobs, updatedRuns = s.filterUpdatesOfOtherShards(txn, updatedJobs, updatedRuns)
+	}
+	if s.runUpdateQuarantine != nil {
+		updatedRuns = s.runUpdateQuarantine.quarantineOrphanedRuns(ctx, txn, updatedJobs, updatedRuns)
+	}
+
 	// Load any error associated with updated runs.
 	jobRunIds := util.Map(updatedRuns, func(jobRepoRun database.Run) uuid.UUID { return jobRepoRun.RunID })
-	jobRepoRunErrorsByRunId, err := s.jobRepository.FetchJobRunErrors(ctx, jobRunIds)
+	jobRepoRunErrorsByRunId, err := s.fetchJobRunErrors(ctx, jobRunIds)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	s.reportRunPhaseLatencies(txn, updatedRuns)
+
 	// Reconcile any differences between the updated jobs and runs.
 	jsts, err := s.jobDb.ReconcileDifferences(txn, updatedJobs, updatedRuns)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	for i, jst := range jsts {
+		if jst.SchedulingInfoConflict != nil {
+			jsts[i].Job = s.handleSchedulingInfoConflict(ctx, jst.Job, jst.SchedulingInfoConflict)
+		}
+	}
+
+	// Restore the parameters checkpointed for jobs when they were admitted and count those that have drifted,
+	// before the checkpoints are relied on, e.g., to expire them.
+	parameterCheckpoints, err := s.syncParameterCheckpoints(ctx, txn, jsts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Node and fair-share accounting follow the node of each run in the jobDb, so need no further updates.
+	for _, jst := range jsts {
+		if jst.NodeReassigned && jst.Job != nil && jst.Job.HasRuns() {
+			run := jst.Job.LatestRun()
+			ctx.Infof("run %s of job %s was reassigned to node %s of executor %s", run.Id(), jst.Job.Id(), run.NodeName(), run.Executor())
+			s.metrics.ReportRunNodeReassignment(run.Executor())
+		}
+		if jst.ScheduledAtPriorityBackfilled && jst.Job != nil {
+			s.metrics.ReportScheduledAtPriorityBackfill(jst.Job.Queue())
+		}
+		if jst.ContradictoryRunOutcome && jst.Job != nil {
+			policy := s.jobDb.ContradictoryRunPolicy()
+			if policy == jobdb.ContradictoryRunsQuarantine {
+				ctx.Errorf("a run of job %s is reported as both succeeded and failed; leaving it non-terminal until this is resolved", jst.Job.Id())
+			} else {
+				ctx.Warnf("a run of job %s is reported as both succeeded and failed; reconciling it according to policy %s", jst.Job.Id(), policy)
+			}
+			s.metrics.ReportContradictoryRunOutcome(policy.String())
+		}
+	}
+
+	// Record the errors of runs that failed, such that job reports can include them.
+	if s.lastRunErrorTracking {
+		for i, jst := range jsts {
+			if jst.Job == nil {
+				continue
+			}
+			if run := jst.Job.LatestRun(); run != nil {
+				if runError, ok := jobRepoRunErrorsByRunId[run.Id()]; ok {
+					jsts[i].Job = jst.Job.WithLastRunError(runErrorSummary(runError))
+				}
+			}
+		}
+	}
+
+	// Reject new gang members in a different queue or job set to the rest of their gang, which can never be scheduled.
+	s.rejectCrossQueueGangs(ctx, txn, jsts)
+
+	// Reject new jobs if their queue has reached its backlog limit.
+	// Jobs loaded initially were admitted before, e.g., by the previous instance of this scheduler, so are unaffected.
+	if s.queueBacklogLimitsEnabled && s.jobDbLoaded {
+		if err := s.applyQueueBacklogLimits(ctx, txn, jsts, updatedJobs); err != nil {
+			return nil, nil, nil, err
+		}
+	}
 
 	// Upsert updated jobs (including associated runs).
 	jobDbJobs := make([]*jobdb.Job, 0, len(jsts))
@@ -358,20 +925,142 @@ func (s *Scheduler) syncState(ctx *armadacontext.Context) ([]*jobdb.Job, []jobdb
 	if err := txn.BatchDelete(idsOfJobsToDelete); err != nil {
 		return nil, nil, nil, err
 	}
+	s.pruneCrossQueueGangJobs(txn)
+	if s.queueBacklogLimitsEnabled {
+		s.pruneBacklogLimitedJobs(txn)
+	}
+
+	if s.runResourceUsageEnabled {
+		if err := s.syncRunResourceUsage(ctx, txn); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	// Jobs in queues the maximum job priority of which has changed are treated as updated, such that their
+	// priority is reconsidered.
+	if s.queuePriorityCapsConfig != nil {
+		jobsWithChangedCaps, err := s.updateMaxJobPriorities(txn, jobDbJobs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		jobDbJobs = append(jobDbJobs, jobsWithChangedCaps...)
+	}
 
 	txn.Commit()
+	s.storeParameterCheckpoints(ctx, parameterCheckpoints)
+
+	if s.jobSetPlacementTracker != nil {
+		now := s.clock.Now()
+		for _, jobDbJob := range jobDbJobs {
+			if jobDbJob.InTerminalState() {
+				s.jobSetPlacementTracker.RecordJobTerminal(now, jobDbJob.Queue(), jobDbJob.Jobset(), jobDbJob.Id())
+			}
+		}
+		s.jobSetPlacementTracker.Prune(now)
+	}
 
 	// Update serial to include these updates.
-	if len(updatedJobs) > 0 {
-		s.jobsSerial = updatedJobs[len(updatedJobs)-1].Serial
+	if len(fetchedJobs) > 0 {
+		s.jobsSerial = fetchedJobs[len(fetchedJobs)-1].Serial
 	}
-	if len(updatedRuns) > 0 {
-		s.runsSerial = updatedRuns[len(updatedRuns)-1].Serial
+	if len(fetchedRuns) > 0 {
+		s.runsSerial = fetchedRuns[len(fetchedRuns)-1].Serial
 	}
+	s.jobDbLoaded = true
 
 	return jobDbJobs, jsts, jobRepoRunErrorsByRunId, nil
 }
 
+// syncRunResourceUsage loads the resource usage reported since the last call onto the runs in the jobDb.
+// Usage is advisory; jobs aren't considered updated as a result, and usage of runs not in the jobDb is discarded.
+func (s *Scheduler) syncRunResourceUsage(ctx *armadacontext.Context, txn *jobdb.Txn) error {
+	var updatedUsage []database.JobRunResourceUsage
+	err := s.retryTransient(ctx, "fetching run resource usage", func() error {
+		var err error
+		updatedUsage, err = s.jobRepository.FetchJobRunResourceUsageUpdates(ctx, s.runResourceUsageSerial)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	jobsById := make(map[string]*jobdb.Job)
+	for _, usage := range updatedUsage {
+		job := txn.GetByRunId(usage.RunID)
+		if job == nil {
+			continue
+		}
+		if updatedJob, ok := jobsById[job.Id()]; ok {
+			job = updatedJob
+		}
+		run := job.RunById(usage.RunID)
+		if run == nil {
+			continue
+		}
+		jobsById[job.Id()] = job.WithUpdatedRun(run.WithResourceUsage(jobdb.RunResourceUsage{
+			CpuSeconds:      usage.CpuSeconds,
+			WorkingSetBytes: usage.WorkingSetBytes,
+		}))
+	}
+	if err := txn.Upsert(maps.Values(jobsById)); err != nil {
+		return err
+	}
+	if len(updatedUsage) > 0 {
+		s.runResourceUsageSerial = updatedUsage[len(updatedUsage)-1].Serial
+	}
+	return nil
+}
+
+// handleSchedulingInfoConflict reports that the job repository provided scheduling info for job inconsistent with
+// that in the jobDb and, if enabled, re-fetches the job to resolve the conflict.
+// Returns the job with its scheduling info resolved.
+func (s *Scheduler) handleSchedulingInfoConflict(ctx *armadacontext.Context, job *jobdb.Job, conflict *jobdb.SchedulingInfoConflict) *jobdb.Job {
+	if conflict.JobRepoVersion < conflict.JobDbVersion {
+		s.metrics.ReportSchedulingInfoConflict("version_regression")
+		ctx.Warnf(
+			"received scheduling info version %d for job %s, which is lower than version %d held by the jobDb",
+			conflict.JobRepoVersion, job.Id(), conflict.JobDbVersion,
+		)
+	} else {
+		s.metrics.ReportSchedulingInfoConflict("content_mismatch")
+		ctx.Warnf(
+			"received scheduling info version %d for job %s, which differs from that held by the jobDb despite having the same version",
+			conflict.JobRepoVersion, job.Id(),
+		)
+	}
+	if !s.refetchOnSchedulingInfoConflict {
+		return job
+	}
+
+	jobRepoJob, err := s.jobRepository.FetchJob(ctx, job.Id())
+	if errors.Is(err, database.ErrNotFound) {
+		ctx.Warnf("failed to re-fetch job %s, since it no longer exists; keeping scheduling info held by the jobDb", job.Id())
+		return job
+	} else if err != nil {
+		logging.WithStacktrace(ctx, err).Warnf("failed to re-fetch job %s; keeping scheduling info held by the jobDb", job.Id())
+		return job
+	}
+	jobDbVersion := job.SchedulingInfoVersion()
+	jobRepoVersion := uint32(jobRepoJob.SchedulingInfoVersion)
+	if jobRepoVersion < jobDbVersion {
+		// The jobDb may be ahead of the job repository, e.g., if an update made by the scheduler hasn't been ingested yet.
+		ctx.Infof(
+			"re-fetched scheduling info version %d for job %s; keeping version %d held by the jobDb",
+			jobRepoVersion, job.Id(), jobDbVersion,
+		)
+		return job
+	}
+	resolvedJob, err := s.jobDb.WithSchedulingInfoFromJobRepo(job, jobRepoJob)
+	if err != nil {
+		logging.WithStacktrace(ctx, err).Warnf("failed to resolve scheduling info of job %s; keeping scheduling info held by the jobDb", job.Id())
+		return job
+	}
+	ctx.Infof(
+		"re-fetched scheduling info version %d for job %s; replacing version %d held by the jobDb",
+		jobRepoVersion, job.Id(), jobDbVersion,
+	)
+	return resolvedJob
+}
+
 func (s *Scheduler) createSchedulingInfoWithNodeAntiAffinityForAttemptedRuns(job *jobdb.Job) (*schedulerobjects.JobSchedulingInfo, error) {
 	newSchedulingInfo := proto.Clone(job.JobSchedulingInfo()).(*schedulerobjects.JobSchedulingInfo)
 	newSchedulingInfo.Version = job.JobSchedulingInfo().Version + 1
@@ -384,14 +1073,20 @@ func (s *Scheduler) createSchedulingInfoWithNodeAntiAffinityForAttemptedRuns(job
 		newAffinity = &v1.Affinity{}
 	}
 
+	added := false
 	for _, run := range job.AllRuns() {
-		if run.RunAttempted() {
+		if run.RunAttempted() && !affinity.HasNodeAntiAffinity(newAffinity, s.nodeIdLabel, run.NodeName()) {
 			err := affinity.AddNodeAntiAffinity(newAffinity, s.nodeIdLabel, run.NodeName())
 			if err != nil {
 				return nil, err
 			}
+			added = true
 		}
 	}
+	if !added {
+		// All attempted nodes are already avoided; keep the current scheduling info rather than bumping its version.
+		return job.JobSchedulingInfo(), nil
+	}
 	podRequirements.Affinity = newAffinity
 	return newSchedulingInfo, nil
 }
@@ -418,7 +1113,7 @@ func EventsFromSchedulerResult(result *SchedulerResult, time time.Time) ([]*arma
 	if err != nil {
 		return nil, err
 	}
-	eventSequences, err = AppendEventSequencesFromScheduledJobs(eventSequences, ScheduledJobsFromSchedulerResult[*jobdb.Job](result), result.AdditionalAnnotationsByJobId, time)
+	eventSequences, err = AppendEventSequencesFromScheduledJobs(eventSequences, ScheduledJobsFromSchedulerResult[*jobdb.Job](result), result.PlacementByJobId, result.AdditionalAnnotationsByJobId, time)
 	if err != nil {
 		return nil, err
 	}
@@ -491,7 +1186,15 @@ func AppendEventSequencesFromPreemptedJobs(eventSequences []*armadaevents.EventS
 	return eventSequences, nil
 }
 
-func AppendEventSequencesFromScheduledJobs(eventSequences []*armadaevents.EventSequence, jobs []*jobdb.Job, additionalAnnotationsByJobId map[string]map[string]string, time time.Time) ([]*armadaevents.EventSequence, error) {
+// AppendEventSequencesFromScheduledJobs appends a JobRunLeased event for each of jobs,
+// with the executor, node, and scheduled-at priority of the event taken from the placement of the job.
+func AppendEventSequencesFromScheduledJobs(
+	eventSequences []*armadaevents.EventSequence,
+	jobs []*jobdb.Job,
+	placementByJobId map[string]Placement,
+	additionalAnnotationsByJobId map[string]map[string]string,
+	time time.Time,
+) ([]*armadaevents.EventSequence, error) {
 	for _, job := range jobs {
 		jobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
 		if err != nil {
@@ -505,7 +1208,10 @@ func AppendEventSequencesFromScheduledJobs(eventSequences []*armadaevents.EventS
 		if run == nil {
 			return nil, errors.Errorf("attempting to generate lease events for job %s with no associated runs", job.Id())
 		}
-		scheduledAtPriority, hasScheduledAtPriority := job.GetScheduledAtPriority()
+		placement, ok := placementByJobId[job.Id()]
+		if !ok {
+			return nil, errors.Errorf("attempting to generate lease events for job %s with no placement", job.Id())
+		}
 		eventSequences = append(eventSequences, &armadaevents.EventSequence{
 			Queue:      job.Queue(),
 			JobSetName: job.Jobset(), // TODO: Rename to JobSet.
@@ -516,13 +1222,13 @@ func AppendEventSequencesFromScheduledJobs(eventSequences []*armadaevents.EventS
 						JobRunLeased: &armadaevents.JobRunLeased{
 							RunId:      armadaevents.ProtoUuidFromUuid(run.Id()),
 							JobId:      jobId,
-							ExecutorId: run.Executor(),
+							ExecutorId: placement.Executor,
 							// NodeId here refers to the unique identifier of the node in an executor cluster,
 							// which is referred to as the NodeName within the scheduler.
-							NodeId:                 run.NodeName(),
+							NodeId:                 placement.NodeName,
 							UpdateSequenceNumber:   job.QueuedVersion(),
-							HasScheduledAtPriority: hasScheduledAtPriority,
-							ScheduledAtPriority:    scheduledAtPriority,
+							HasScheduledAtPriority: true,
+							ScheduledAtPriority:    placement.ScheduledAtPriority,
 							AdditionalAnnotations:  additionalAnnotations,
 						},
 					},
@@ -565,7 +1271,7 @@ func (s *Scheduler) generateUpdateMessages(ctx *armadacontext.Context, txn *jobd
 	// Generate any events that came out of synchronising the db state.
 	var events []*armadaevents.EventSequence
 	for _, job := range updatedJobs {
-		jobEvents, err := s.generateUpdateMessagesFromJob(job, jobRunErrors, txn)
+		jobEvents, err := s.generateUpdateMessagesFromJob(ctx, job, jobRunErrors, txn)
 		if err != nil {
 			return nil, err
 		}
@@ -578,7 +1284,7 @@ func (s *Scheduler) generateUpdateMessages(ctx *armadacontext.Context, txn *jobd
 
 // generateUpdateMessages generates EventSequence representing the state change on a single jobs
 // If there are no state changes then nil will be returned
-func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors map[uuid.UUID]*armadaevents.Error, txn *jobdb.Txn) (*armadaevents.EventSequence, error) {
+func (s *Scheduler) generateUpdateMessagesFromJob(ctx *armadacontext.Context, job *jobdb.Job, jobRunErrors map[uuid.UUID]*armadaevents.Error, txn *jobdb.Txn) (*armadaevents.EventSequence, error) {
 	var events []*armadaevents.EventSequence_Event
 
 	// Is the job already in a terminal state? If so then don't send any more messages
@@ -593,9 +1299,8 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 	origJob := job
 	// Has the job been requested cancelled. If so, cancel the job
 	if job.CancelRequested() {
-		for _, run := range job.AllRuns() {
-			job = job.WithUpdatedRun(run.WithCancelled(true))
-		}
+		var runCancellations []*armadaevents.EventSequence_Event
+		job, runCancellations = s.cancelRecentRuns(job, jobId)
 		job = job.WithCancelled(true).WithQueued(false)
 		cancel := &armadaevents.EventSequence_Event{
 			Created: s.now(),
@@ -604,10 +1309,10 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 			},
 		}
 		events = append(events, cancel)
+		events = append(events, runCancellations...)
 	} else if job.CancelByJobsetRequested() {
-		for _, run := range job.AllRuns() {
-			job = job.WithUpdatedRun(run.WithCancelled(true))
-		}
+		var runCancellations []*armadaevents.EventSequence_Event
+		job, runCancellations = s.cancelRecentRuns(job, jobId)
 		job = job.WithCancelled(true).WithQueued(false)
 		cancelRequest := &armadaevents.EventSequence_Event{
 			Created: s.now(),
@@ -622,11 +1327,19 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 			},
 		}
 		events = append(events, cancelRequest, cancel)
+		events = append(events, runCancellations...)
+	} else if job.PreemptRequested() && job.Phase() == jobdb.JobPhaseLeased && job.HasRuns() && !job.LatestRun().Phase().IsTerminal() {
+		var preemptionEvents []*armadaevents.EventSequence_Event
+		job, preemptionEvents = s.preemptAndRequeue(job, jobId)
+		events = append(events, preemptionEvents...)
 	} else if job.HasRuns() {
 		lastRun := job.LatestRun()
-		// InTerminalState states. Can only have one of these
-		if lastRun.Succeeded() {
-			job = job.WithSucceeded(true).WithQueued(false)
+		// Runs in the invalid phase, i.e., with more than one terminal state, are left for an operator to resolve.
+		if lastRun.Phase() == jobdb.RunPhaseSucceeded {
+			if s.nodeQuarantine != nil {
+				s.nodeQuarantine.RecordRunSuccess(ctx, lastRun.Executor(), lastRun.NodeName())
+			}
+			job = job.WithQueued(false).WithSucceeded(true)
 			jobSucceeded := &armadaevents.EventSequence_Event{
 				Created: s.now(),
 				Event: &armadaevents.EventSequence_Event_JobSucceeded{
@@ -636,9 +1349,36 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 				},
 			}
 			events = append(events, jobSucceeded)
-		} else if lastRun.Failed() && !job.Queued() {
-			failFast := job.GetAnnotations()[configuration.FailFastAnnotation] == "true"
-			requeueJob := !failFast && lastRun.Returned() && job.NumAttempts() < s.maxAttemptedRuns
+		} else if lastRun.Phase() == jobdb.RunPhaseFailed && job.Phase() == jobdb.JobPhaseLeased {
+			// Only runs that got as far as starting on their node count towards quarantining it.
+			if s.nodeQuarantine != nil && lastRun.RunAttempted() {
+				s.nodeQuarantine.RecordRunFailure(ctx, s.clock.Now(), lastRun.Executor(), lastRun.NodeName(), jobRunErrors[lastRun.Id()])
+			}
+			failFast := job.FailFast()
+			maxAttemptedRuns := job.MaxAttemptedRuns(s.maxAttemptedRuns)
+			requeueJob := !failFast && lastRun.Returned() && job.NumAttempts() < maxAttemptedRuns
+
+			// If the run error matches a classification rule, the rule determines whether the job is retried instead.
+			var classification *RunErrorClassification
+			if s.runErrorClassifier != nil {
+				classification = s.runErrorClassifier.Classify(jobRunErrors[lastRun.Id()])
+			}
+			if classification != nil {
+				s.metrics.ReportRunErrorClassification(job.Queue(), classification)
+				if classification.Class == NonRetryableRunError {
+					requeueJob = false
+				} else {
+					requeueJob = !failFast && job.NumAttempts() < maxAttemptedRuns
+				}
+			}
+
+			// Jobs that must run at most once are never retried, since we can't be sure the returned run didn't start,
+			// unless the executor reports it never acted on the lease and this is the job's first run.
+			atMostOnce := job.JobSchedulingInfo().GetAtMostOnce()
+			if requeueJob && atMostOnce {
+				leaseNeverAcknowledged := jobRunErrors[lastRun.Id()].GetPodLeaseReturned().GetLeaseNeverAcknowledged()
+				requeueJob = s.retryUnacknowledgedAtMostOnceJobs && leaseNeverAcknowledged && len(job.AllRuns()) == 1
+			}
 
 			if requeueJob && lastRun.RunAttempted() {
 				jobWithAntiAffinity, schedulable, err := s.addNodeAntiAffinitiesForAttemptedRunsIfSchedulable(job)
@@ -655,8 +1395,12 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 			}
 
 			if requeueJob {
-				job = job.WithQueued(true)
+				job = job.WithQueued(true).WithQueuedSince(s.clock.Now())
 				job = job.WithQueuedVersion(job.QueuedVersion() + 1)
+				if classification != nil && classification.Class == RetryWithBackoffRunError {
+					backoff := s.featureGates.Backoff(job.Queue(), job.NumAttempts(), classification.Backoff)
+					job = job.WithBackedOffUntil(s.clock.Now().Add(backoff))
+				}
 
 				requeueJobEvent := &armadaevents.EventSequence_Event{
 					Created: s.now(),
@@ -672,15 +1416,28 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 				events = append(events, requeueJobEvent)
 			} else {
 				runError := jobRunErrors[lastRun.Id()]
-				job = job.WithFailed(true).WithQueued(false)
+				// Whether the job is failed only since it was attempted the maximum number of times.
+				retriesExhausted := !failFast && job.NumAttempts() >= maxAttemptedRuns &&
+					(lastRun.Returned() || classification != nil && classification.Class != NonRetryableRunError)
+				var retryExhaustionEvents []*armadaevents.EventSequence_Event
+				if retriesExhausted && s.retryExhaustionNotifier != nil {
+					retryExhaustionEvents = s.retryExhaustionNotifier.Notify(s.clock.Now(), job, jobId, lastRun.Id(), runError)
+				}
+				job = job.WithQueued(false).WithFailed(true)
 				if lastRun.Returned() {
-					errorMessage := fmt.Sprintf("Maximum number of attempts (%d) reached - this job will no longer be retried", s.maxAttemptedRuns)
-					if job.NumAttempts() < s.maxAttemptedRuns {
+					errorMessage := fmt.Sprintf("Maximum number of attempts (%d) reached - this job will no longer be retried", maxAttemptedRuns)
+					if job.NumAttempts() < maxAttemptedRuns {
 						errorMessage = fmt.Sprintf("Job was attempted %d times, and has been tried once on all nodes it can run on - this job will no longer be retried", job.NumAttempts())
 					}
 					if failFast {
 						errorMessage = fmt.Sprintf("Job has fail fast flag set - this job will no longer be retried")
 					}
+					if atMostOnce {
+						errorMessage = "Job must run at most once and its lease was returned after it may have started - this job will no longer be retried"
+					}
+					if classification != nil && classification.Class == NonRetryableRunError {
+						errorMessage = fmt.Sprintf("Job run error was classified as %s, which is not retryable - this job will no longer be retried", classification.Name)
+					}
 
 					if runError.GetPodLeaseReturned() != nil && runError.GetPodLeaseReturned().GetMessage() != "" {
 						errorMessage += "\n\n" + "Final run error:"
@@ -697,10 +1454,11 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 					}
 				}
 				if runError == nil {
-					panic(
-						fmt.Sprintf("No run error found for run %s (job id = %s), this must mean we're out of sync with the database",
-							lastRun.Id().String(), job.Id()),
-					)
+					runError = s.placeholderRunError(job, jobId, lastRun.Id())
+				}
+				if classification != nil {
+					runError = proto.Clone(runError).(*armadaevents.Error)
+					runError.Classification = classification.Name
 				}
 				jobErrors := &armadaevents.EventSequence_Event{
 					Created: s.now(),
@@ -713,20 +1471,37 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 				}
 
 				events = append(events, jobErrors)
+				events = append(events, retryExhaustionEvents...)
 			}
 		}
-	} else if job.RequestedPriority() != job.Priority() {
-		job = job.WithPriority(job.RequestedPriority())
-		jobReprioritised := &armadaevents.EventSequence_Event{
-			Created: s.now(),
-			Event: &armadaevents.EventSequence_Event_ReprioritisedJob{
-				ReprioritisedJob: &armadaevents.ReprioritisedJob{
-					JobId:    jobId,
-					Priority: job.Priority(),
+	} else if priority, clamped := s.effectivePriority(job); priority != job.Priority() || clamped != job.PriorityClamped() {
+		reprioritised := priority != job.Priority()
+		job = job.WithPriority(priority).WithPriorityClamped(clamped)
+		reprioritisedJob := &armadaevents.ReprioritisedJob{
+			JobId:    jobId,
+			Priority: job.Priority(),
+		}
+		if clamped {
+			message := priorityClampMessage(job, priority)
+			s.warnings.Warnf(ctx, job.Queue(), "job %s: %s", job.Id(), message)
+			if s.queuePriorityCapsConfig.Warning == schedulerconfig.PriorityClampWarningEvent {
+				reprioritisedJob.RequestedPriority = job.RequestedPriority()
+				reprioritisedJob.ClampMessage = message
+			}
+		}
+		if reprioritised {
+			events = append(events, &armadaevents.EventSequence_Event{
+				Created: s.now(),
+				Event: &armadaevents.EventSequence_Event_ReprioritisedJob{
+					ReprioritisedJob: reprioritisedJob,
 				},
-			},
+			})
 		}
-		events = append(events, jobReprioritised)
+	}
+
+	// Preemption requests are acted on at most once; requests for jobs without an active run have no effect.
+	if job.PreemptRequested() {
+		job = job.WithPreemptRequested(false)
 	}
 
 	if origJob != job {
@@ -747,26 +1522,76 @@ func (s *Scheduler) generateUpdateMessagesFromJob(job *jobdb.Job, jobRunErrors m
 	return nil, nil
 }
 
+// preemptAndRequeue preempts the active run of job, the preemption of which was requested by the user,
+// and requeues the job. Unlike runs that fail, the preempted run neither counts as an attempt
+// nor causes the job to avoid the node it ran on.
+func (s *Scheduler) preemptAndRequeue(job *jobdb.Job, jobId *armadaevents.Uuid) (*jobdb.Job, []*armadaevents.EventSequence_Event) {
+	run := job.LatestRun()
+	runId := armadaevents.ProtoUuidFromUuid(run.Id())
+	job = job.WithUpdatedRun(run.WithCancelled(true))
+	job = job.WithQueued(true).WithQueuedSince(s.clock.Now())
+	job = job.WithQueuedVersion(job.QueuedVersion() + 1)
+	events := []*armadaevents.EventSequence_Event{
+		{
+			Created: s.now(),
+			Event: &armadaevents.EventSequence_Event_JobRunPreempted{
+				JobRunPreempted: &armadaevents.JobRunPreempted{
+					PreemptedRunId: runId,
+					PreemptedJobId: jobId,
+				},
+			},
+		},
+		{
+			// Marks the run as cancelled, such that the executor stops it.
+			Created: s.now(),
+			Event: &armadaevents.EventSequence_Event_JobRunCancelled{
+				JobRunCancelled: &armadaevents.JobRunCancelled{
+					RunId: runId,
+					JobId: jobId,
+				},
+			},
+		},
+		{
+			Created: s.now(),
+			Event: &armadaevents.EventSequence_Event_JobRequeued{
+				JobRequeued: &armadaevents.JobRequeued{
+					JobId:                jobId,
+					SchedulingInfo:       job.JobSchedulingInfo(),
+					UpdateSequenceNumber: job.QueuedVersion(),
+				},
+			},
+		},
+	}
+	return job, events
+}
+
 // expireJobsIfNecessary removes any jobs from the JobDb which are running on stale executors.
 // It also generates an EventSequence for each job, indicating that both the run and the job has failed
 // Note that this is different behaviour from the old scheduler which would allow expired jobs to be rerun
 func (s *Scheduler) expireJobsIfNecessary(ctx *armadacontext.Context, txn *jobdb.Txn) ([]*armadaevents.EventSequence, error) {
-	heartbeatTimes, err := s.executorRepository.GetLastUpdateTimes(ctx)
+	executors, err := s.executorRepository.GetExecutors(ctx)
 	if err != nil {
 		return nil, err
 	}
 	staleExecutors := make(map[string]bool, 0)
-	cutOff := s.clock.Now().Add(-s.executorTimeout)
+	now := s.clock.Now()
 
 	jobsToUpdate := make([]*jobdb.Job, 0)
 
 	// TODO: this will only detect stale clusters if they exist in the database
 	// Right now this is fine because nothing will delete this jobs, but we should consider the case where an executor
 	// has been completely removed
-	for executor, heartbeat := range heartbeatTimes {
-		if heartbeat.Before(cutOff) {
-			ctx.Warnf("Executor %s has not reported a hearbeart since %v. Will expire all jobs running on this executor", executor, heartbeat)
-			staleExecutors[executor] = true
+	for _, executor := range executors {
+		// Each executor is considered stale according to its own timeout.
+		timeout := s.executorTimeouts.TimeoutForExecutor(executor)
+		// The heartbeat is the time the scheduler received it, such that executors with skewed clocks aren't considered stale.
+		heartbeat := executor.LastUpdateTime
+		isStale := heartbeat.Before(now.Add(-timeout))
+		s.metrics.ReportExecutorStaleness(executor.Id, timeout, isStale)
+		s.recordExecutorClockSkew(ctx, executor)
+		if isStale {
+			s.warnings.Warnf(ctx, executor.Id, "Executor %s has not reported a hearbeart since %v (timeout %s). Will expire all jobs running on this executor", executor.Id, heartbeat, timeout)
+			staleExecutors[executor.Id] = true
 		}
 	}
 
@@ -788,8 +1613,8 @@ func (s *Scheduler) expireJobsIfNecessary(ctx *armadacontext.Context, txn *jobdb
 		}
 
 		run := job.LatestRun()
-		if run != nil && !job.Queued() && staleExecutors[run.Executor()] {
-			ctx.Warnf("Cancelling job %s as it is running on lost executor %s", job.Id(), run.Executor())
+		if run != nil && job.Phase() == jobdb.JobPhaseLeased && staleExecutors[run.Executor()] {
+			s.warnings.Warnf(ctx, run.Executor(), "Cancelling job %s as it is running on lost executor %s", job.Id(), run.Executor())
 			jobsToUpdate = append(jobsToUpdate, job.WithQueued(false).WithFailed(true).WithUpdatedRun(run.WithFailed(true)))
 
 			jobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
@@ -844,7 +1669,7 @@ func (s *Scheduler) cancelQueuedJobsIfExpired(txn *jobdb.Txn) ([]*armadaevents.E
 	it := txn.QueuedJobsByTtl()
 
 	// `it` is ordered such that the jobs with the least ttl remaining come first, hence we exit early if we find a job that is not expired.
-	for job, _ := it.Next(); job != nil && job.HasQueueTtlExpired(); job, _ = it.Next() {
+	for job, _ := it.Next(); job != nil && job.HasQueueTtlExpired(s.clock.Now()); job, _ = it.Next() {
 		if job.InTerminalState() {
 			continue
 		}
@@ -855,7 +1680,7 @@ func (s *Scheduler) cancelQueuedJobsIfExpired(txn *jobdb.Txn) ([]*armadaevents.E
 			return nil, err
 		}
 
-		reason := "Expired queue ttl"
+		reason := s.abandonedQueuedJobMessage(job, abandonedReasonQueueTtl, "Expired queue ttl")
 		cancel := &armadaevents.EventSequence{
 			Queue:      job.Queue(),
 			JobSetName: job.Jobset(),
@@ -1,13 +1,16 @@
 package scheduler
 
 import (
+	"math"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/resource"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
 const (
@@ -30,9 +33,107 @@ type SchedulerMetrics struct {
 	fairSharePerQueue prometheus.GaugeVec
 	// Actual share of each queue.
 	actualSharePerQueue prometheus.GaugeVec
+	// Number of jobs submitted to queues that don't exist, by policy and outcome.
+	unknownQueueJobs prometheus.CounterVec
+	// Time spent catching up after each failover.
+	catchingUpTime prometheus.Histogram
+	// Estimated time until all jobs currently queued are scheduled, by queue and resource bucket.
+	estimatedWaitTime prometheus.GaugeVec
+	// Number of times the job repository provided scheduling info inconsistent with that in the jobDb, by kind.
+	schedulingInfoConflicts prometheus.CounterVec
+	// Number of new jobs failed because their queue had reached its backlog limit, per queue.
+	queueBacklogLimitedJobs prometheus.CounterVec
+	// Number of queued jobs cancelled or failed by the scheduler, by reason and whether they were ever leased.
+	abandonedQueuedJobs prometheus.CounterVec
+	// Timeout in effect for each executor, i.e., how long without a heartbeat before it's considered stale.
+	executorTimeout prometheus.GaugeVec
+	// 1 if an executor is considered stale according to its timeout and 0 otherwise.
+	staleExecutors prometheus.GaugeVec
+	// By how much the clock of each executor is ahead of that of the scheduler, as of its most recent heartbeat.
+	executorClockSkew prometheus.GaugeVec
+	// 1 if the clock skew of an executor exceeds the warning threshold and 0 otherwise.
+	executorClockSkewExceeded prometheus.GaugeVec
+	// Time from runs being leased until they became pending or running, per executor and phase,
+	// corrected for the clock skew of the executor reporting the time of each phase.
+	runPhaseLatency prometheus.HistogramVec
+	// Time from jobs being submitted until a run was created for them, per queue, priority class, and pool.
+	queueToLeaseLatency prometheus.HistogramVec
+	// Time since the oldest queued job of each priority class was submitted, as of the most recent cycle.
+	oldestQueuedJobAge prometheus.GaugeVec
+	// Number of runs the executor reported on a node other than that the scheduler held for them, per executor.
+	runNodeReassignments prometheus.CounterVec
+	// Number of runs stored without the priority they were scheduled at, which was back-filled, per queue.
+	scheduledAtPriorityBackfills prometheus.CounterVec
+	// Number of jobs skipped since a job with the same scheduling key was found unschedulable, per queue/pool.
+	schedulingKeySkippedJobs prometheus.CounterVec
+	// Number of jobs scheduled despite a job with the same scheduling key having been found unschedulable, per queue/pool.
+	schedulingKeyCollisions prometheus.CounterVec
+	// Number of runs whose cancellation was re-published since the executor hadn't acted on it in time, per executor.
+	ignoredCancellations prometheus.CounterVec
+	// Number of failed runs whose error matched each classification rule, per queue.
+	classifiedRunErrors prometheus.CounterVec
+	// Resources reserved for each queue and pool, together with the part of the reservation the queue isn't using.
+	reservedResources       prometheus.GaugeVec
+	unusedReservedResources prometheus.GaugeVec
+	// Capacity and demand of each pool at the start of the most recent scheduling round, per resource.
+	poolAllocatableResources prometheus.GaugeVec
+	poolAllocatedResources   prometheus.GaugeVec
+	poolQueuedDemand         prometheus.GaugeVec
+	poolUtilisation          prometheus.GaugeVec
+	// Work, in resource-seconds, preempted within the preemption budget window in each pool and across all pools,
+	// together with whether the budget of each pool was exhausted, as of the most recent scheduling round.
+	preemptionBudgetConsumed       prometheus.GaugeVec
+	globalPreemptionBudgetConsumed prometheus.Gauge
+	preemptionBudgetExhausted      prometheus.GaugeVec
+	// Age of the oldest job or run update in postgres not yet processed by the scheduler, as of the most recent sample.
+	oldestUnprocessedUpdateAge prometheus.Gauge
+	// Number of times the database's serials were found to have regressed below those read by the scheduler.
+	serialRegressions prometheus.CounterVec
+	// Number of jobs for which a state transition was applied to the jobDb without an event or vice versa, per transition.
+	eventDivergences prometheus.CounterVec
+	// Number of cycles aborted since events diverged from the jobDb by more than the tolerance.
+	eventDivergenceAborts prometheus.Counter
+	// Number of jobs the scheduling algorithm wrote to the jobDb without being allowed to.
+	illegalJobDbWrites prometheus.Counter
+	// Number of jobs with a run the database reported as both succeeded and failed, by the policy applied to it.
+	contradictoryRunOutcomes prometheus.CounterVec
+	// Number of cycles aborted since the scheduling algorithm wrote jobs to the jobDb it wasn't allowed to.
+	jobDbWriteGuardAborts prometheus.Counter
+	// Fraction of the job repository replayed by the jobDb rebuild in progress, or zero if there's none.
+	jobDbRebuildProgress prometheus.Gauge
+	// Number of jobDb rebuilds, by outcome.
+	jobDbRebuilds prometheus.CounterVec
+	// Number of runs leased to each executor not yet delivered to it, as of its most recent lease request.
+	pendingLeases prometheus.GaugeVec
+	// Number of times each executor was asked to report all of its nodes since a nodes delta it reported was discarded.
+	executorNodeResyncs prometheus.CounterVec
+	// Number of times the scheduling algorithm panicked.
+	schedulingPanics prometheus.Counter
+	// 1 if a queue is quarantined since it caused the scheduling algorithm to panic and 0 otherwise.
+	quarantinedQueues prometheus.GaugeVec
+	// 1 if a node is quarantined since many runs failed on it in a row and 0 otherwise.
+	quarantinedNodes prometheus.GaugeVec
+	// Number of updates of runs of unknown jobs currently quarantined.
+	quarantinedRunUpdates prometheus.Gauge
+	// Number of updates of runs of unknown jobs dropped, by reason.
+	droppedRunUpdates prometheus.CounterVec
+	// Number of jobs found unschedulable in the most recent round, per queue/pool and blocking cause.
+	blockedJobs prometheus.GaugeVec
+	// Time spent evaluating candidate jobs of each queue, and the number of jobs of each queue examined and scheduled,
+	// per scheduling cycle. Series of queues not considered for queueCycleMetricsExpiry are deleted.
+	queueCycleSchedulingTime prometheus.HistogramVec
+	queueCycleExaminedJobs   prometheus.CounterVec
+	queueCycleScheduledJobs  prometheus.CounterVec
+	queueCycleMetricsExpiry  time.Duration
+	// Time at which each queue with per-queue cycle metrics was most recently considered.
+	queueCycleLastSeen map[string]time.Time
+	// Duration of shadow scheduling rounds, run while not leader, and the number of jobs the most recent one would have leased.
+	shadowScheduleTime     prometheus.Histogram
+	shadowScheduledJobs    prometheus.Gauge
+	shadowScheduleFailures prometheus.Counter
 }
 
-func NewSchedulerMetrics(config configuration.SchedulerMetricsConfig) *SchedulerMetrics {
+func NewSchedulerMetrics(config configuration.SchedulerMetricsConfig, registerer prometheus.Registerer) *SchedulerMetrics {
 	scheduleCycleTime := prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: NAMESPACE,
@@ -124,28 +225,744 @@ func NewSchedulerMetrics(config configuration.SchedulerMetricsConfig) *Scheduler
 		},
 	)
 
-	prometheus.MustRegister(scheduleCycleTime)
-	prometheus.MustRegister(reconcileCycleTime)
-	prometheus.MustRegister(scheduledJobs)
-	prometheus.MustRegister(preemptedJobs)
-	prometheus.MustRegister(consideredJobs)
-	prometheus.MustRegister(fairSharePerQueue)
-	prometheus.MustRegister(actualSharePerQueue)
+	unknownQueueJobs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "unknown_queue_jobs",
+			Help:      "Number of jobs submitted to queues that don't exist, by unknown queue policy and outcome.",
+		},
+		[]string{
+			"policy",
+			"outcome",
+		},
+	)
+
+	oldestUnprocessedUpdateAge := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "oldest_unprocessed_update_age_seconds",
+			Help:      "Age of the oldest job or run update in postgres not yet processed by the scheduler; 0 if there's no such update.",
+		},
+	)
+
+	serialRegressions := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "serial_regressions",
+			Help:      "Number of times the highest serial in the database was found to be lower than that read up to by the scheduler.",
+		},
+		[]string{
+			"table",
+		},
+	)
+
+	eventDivergences := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "event_divergences",
+			Help:      "Number of jobs whose state transitioned in the jobDb without an event being generated for it, or vice versa.",
+		},
+		[]string{
+			"transition",
+		},
+	)
+
+	eventDivergenceAborts := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "event_divergence_aborts",
+			Help:      "Number of cycles aborted since the events to be published diverged from the jobDb by more than the tolerance.",
+		},
+	)
+
+	pendingLeases := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "pending_leases",
+			Help:      "Number of runs leased to each executor that are yet to be sent to it, as of its most recent lease request.",
+		},
+		[]string{
+			"executor",
+		},
+	)
+
+	executorNodeResyncs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "executor_node_resyncs",
+			Help:      "Number of times an executor was asked to report all of its nodes since a nodes delta it reported was discarded.",
+		},
+		[]string{
+			"executor",
+			"reason",
+		},
+	)
+
+	registerer.MustRegister(scheduleCycleTime)
+	registerer.MustRegister(reconcileCycleTime)
+	registerer.MustRegister(scheduledJobs)
+	registerer.MustRegister(preemptedJobs)
+	registerer.MustRegister(consideredJobs)
+	registerer.MustRegister(fairSharePerQueue)
+	registerer.MustRegister(actualSharePerQueue)
+	catchingUpTime := prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "catching_up_times",
+			Help:      "Time spent catching up after becoming leader, during which no new leases are distributed.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		},
+	)
+
+	estimatedWaitTime := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "estimated_queue_wait_time_seconds",
+			Help:      "Estimated time until all jobs currently queued are scheduled. Absent if the queue had no recent throughput.",
+		},
+		[]string{
+			"queue",
+			"bucket",
+		},
+	)
+
+	schedulingInfoConflicts := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "scheduling_info_conflicts",
+			Help:      "Number of times the database provided job scheduling info with a lower version than, or the same version but different contents to, that held by the scheduler.",
+		},
+		[]string{
+			"kind",
+		},
+	)
+
+	queueBacklogLimitedJobs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "queue_backlog_limited_jobs",
+			Help:      "Number of new jobs failed because their queue already had as many queued jobs as its backlog limit.",
+		},
+		[]string{
+			"queue",
+		},
+	)
+
+	abandonedQueuedJobs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "abandoned_queued_jobs",
+			Help:      "Number of queued jobs cancelled or failed by the scheduler, by reason and by whether they were never scheduled or retried and abandoned.",
+		},
+		[]string{
+			"reason",
+			"history",
+		},
+	)
+
+	executorTimeout := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "executor_timeout_seconds",
+			Help:      "How long without a heartbeat before each executor is considered stale.",
+		},
+		[]string{
+			"executor",
+		},
+	)
+
+	staleExecutors := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "stale_executors",
+			Help:      "1 if an executor hasn't provided a heartbeat within its timeout and 0 otherwise.",
+		},
+		[]string{
+			"executor",
+		},
+	)
+
+	executorClockSkew := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "executor_clock_skew_seconds",
+			Help:      "By how much the clock of each executor was ahead of that of the scheduler as of its most recent heartbeat; negative if it was behind.",
+		},
+		[]string{
+			"executor",
+		},
+	)
+
+	executorClockSkewExceeded := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "executor_clock_skew_exceeded",
+			Help:      "1 if the clock skew of an executor exceeds the configured warning threshold and 0 otherwise.",
+		},
+		[]string{
+			"executor",
+		},
+	)
+
+	runPhaseLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "run_phase_latency_seconds",
+			Help:      "Time from runs being leased until they became pending or running, corrected for the clock skew of the executor.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+		},
+		[]string{
+			"executor",
+			"phase",
+		},
+	)
+
+	queueToLeaseLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "queue_to_lease_latency_seconds",
+			Help:      "Time from jobs being submitted until a run was created for them.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 18),
+		},
+		[]string{
+			"queue",
+			"priority_class",
+			"pool",
+		},
+	)
+
+	oldestQueuedJobAge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "oldest_queued_job_age_seconds",
+			Help:      "Time since the oldest queued job of each priority class was submitted; zero if there are no such jobs.",
+		},
+		[]string{
+			"priority_class",
+		},
+	)
+
+	runNodeReassignments := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "run_node_reassignments",
+			Help:      "Number of runs the executor reported on a node other than that the scheduler held for them, e.g., since the executor rescheduled their pod after a node failure.",
+		},
+		[]string{
+			"executor",
+		},
+	)
+
+	scheduledAtPriorityBackfills := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "scheduled_at_priority_backfills",
+			Help:      "Number of runs stored without the priority they were scheduled at, which were assumed to have been scheduled at the priority of their priority class.",
+		},
+		[]string{
+			"queue",
+		},
+	)
+
+	schedulingKeySkippedJobs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "scheduling_key_skipped_jobs",
+			Help:      "Number of jobs skipped without being evaluated since a job with the same scheduling key was found unschedulable, per queue and pool.",
+		},
+		[]string{
+			"queue",
+			"pool",
+		},
+	)
+
+	schedulingKeyCollisions := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "scheduling_key_collisions",
+			Help:      "Number of jobs opted out of scheduling key-based skipping that were scheduled despite a job with the same scheduling key having been found unschedulable, per queue and pool.",
+		},
+		[]string{
+			"queue",
+			"pool",
+		},
+	)
+
+	ignoredCancellations := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "ignored_cancellations",
+			Help:      "Number of cancelled runs the executor hadn't stopped within the cancellation deadline, per executor.",
+		},
+		[]string{
+			"executor",
+		},
+	)
+
+	classifiedRunErrors := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "classified_run_errors",
+			Help:      "Number of failed runs whose error matched each run error classification rule, per queue.",
+		},
+		[]string{
+			"queue",
+			"classification",
+			"class",
+		},
+	)
+
+	reservedResources := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "reserved_resources",
+			Help:      "Resources reserved for each queue and pool.",
+		},
+		[]string{
+			"queue",
+			"pool",
+			"resource",
+		},
+	)
+
+	unusedReservedResources := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "unused_reserved_resources",
+			Help:      "Resources reserved for each queue and pool not allocated to jobs of that queue.",
+		},
+		[]string{
+			"queue",
+			"pool",
+			"resource",
+		},
+	)
+
+	poolAllocatableResources := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "pool_allocatable_resources",
+			Help:      "Resources across all nodes of each pool.",
+		},
+		[]string{
+			"pool",
+			"resource",
+		},
+	)
+
+	poolAllocatedResources := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "pool_allocated_resources",
+			Help:      "Resources requested by jobs running in each pool.",
+		},
+		[]string{
+			"pool",
+			"resource",
+		},
+	)
+
+	poolQueuedDemand := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "pool_queued_demand",
+			Help:      "Resources requested by queued jobs attributed to each pool.",
+		},
+		[]string{
+			"pool",
+			"resource",
+		},
+	)
+
+	poolUtilisation := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "pool_utilisation",
+			Help:      "Fraction of the allocatable resources of each pool allocated to running jobs.",
+		},
+		[]string{
+			"pool",
+			"resource",
+		},
+	)
+
+	preemptionBudgetConsumed := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "preemption_budget_consumed",
+			Help:      "Work, in resource-seconds, preempted in each pool within the preemption budget window.",
+		},
+		[]string{
+			"pool",
+		},
+	)
+
+	globalPreemptionBudgetConsumed := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "global_preemption_budget_consumed",
+			Help:      "Work, in resource-seconds, preempted across all pools within the preemption budget window.",
+		},
+	)
+
+	preemptionBudgetExhausted := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "preemption_budget_exhausted",
+			Help:      "1 if jobs weren't evicted to balance resource usage in each pool in the most recent round since the preemption budget was exhausted and 0 otherwise.",
+		},
+		[]string{
+			"pool",
+		},
+	)
+
+	blockedJobs := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "blocked_jobs",
+			Help:      "Number of jobs found unschedulable in the most recent round, by the primary cause of them not being scheduled.",
+		},
+		[]string{
+			"queue",
+			"pool",
+			"cause",
+		},
+	)
+
+	illegalJobDbWrites := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "illegal_jobdb_writes",
+			Help:      "Number of jobs the scheduling algorithm wrote to the jobDb without being allowed to.",
+		},
+	)
+
+	contradictoryRunOutcomes := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "contradictory_run_outcomes",
+			Help:      "Number of jobs with a run the database reported as both succeeded and failed, by the policy applied to it.",
+		},
+		[]string{
+			"policy",
+		},
+	)
+
+	jobDbWriteGuardAborts := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "jobdb_write_guard_aborts",
+			Help:      "Number of cycles aborted since the scheduling algorithm wrote jobs to the jobDb it wasn't allowed to.",
+		},
+	)
+
+	schedulingPanics := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "scheduling_panics",
+			Help:      "Number of times the scheduling algorithm panicked.",
+		},
+	)
+
+	quarantinedQueues := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "quarantined_queues",
+			Help:      "1 if a queue is quarantined since scheduling it caused the scheduling algorithm to panic and 0 otherwise.",
+		},
+		[]string{
+			"queue",
+		},
+	)
+
+	quarantinedNodes := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "quarantined_nodes",
+			Help:      "1 if a node is quarantined since many runs failed on it in a row and 0 otherwise.",
+		},
+		[]string{
+			"executor",
+			"node",
+		},
+	)
+
+	quarantinedRunUpdates := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "quarantined_run_updates",
+			Help:      "Number of updates of runs of unknown jobs quarantined to be retried.",
+		},
+	)
+
+	droppedRunUpdates := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "dropped_run_updates",
+			Help:      "Number of updates of runs of unknown jobs dropped, by reason.",
+		},
+		[]string{
+			"reason",
+		},
+	)
+
+	registerer.MustRegister(unknownQueueJobs)
+	registerer.MustRegister(catchingUpTime)
+	registerer.MustRegister(estimatedWaitTime)
+	registerer.MustRegister(schedulingInfoConflicts)
+	registerer.MustRegister(queueBacklogLimitedJobs)
+	registerer.MustRegister(abandonedQueuedJobs)
+	registerer.MustRegister(executorTimeout)
+	registerer.MustRegister(staleExecutors)
+	registerer.MustRegister(executorClockSkew)
+	registerer.MustRegister(executorClockSkewExceeded)
+	registerer.MustRegister(runPhaseLatency)
+	registerer.MustRegister(queueToLeaseLatency)
+	registerer.MustRegister(oldestQueuedJobAge)
+	registerer.MustRegister(runNodeReassignments)
+	registerer.MustRegister(scheduledAtPriorityBackfills)
+	registerer.MustRegister(schedulingKeySkippedJobs)
+	registerer.MustRegister(schedulingKeyCollisions)
+	registerer.MustRegister(ignoredCancellations)
+	registerer.MustRegister(classifiedRunErrors)
+	registerer.MustRegister(reservedResources)
+	registerer.MustRegister(unusedReservedResources)
+	registerer.MustRegister(poolAllocatableResources)
+	registerer.MustRegister(poolAllocatedResources)
+	registerer.MustRegister(poolQueuedDemand)
+	jobDbRebuildProgress := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "jobdb_rebuild_progress",
+			Help:      "Fraction of the job repository replayed by the jobDb rebuild in progress, or zero if there's none.",
+		},
+	)
+
+	jobDbRebuilds := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "jobdb_rebuilds",
+			Help:      "Number of jobDb rebuilds, by outcome, i.e., whether the rebuilt jobDb was swapped in, failed, or was abandoned.",
+		},
+		[]string{
+			"outcome",
+		},
+	)
+
+	queueCycleSchedulingTime := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "queue_cycle_scheduling_time_seconds",
+			Help:      "Time spent evaluating candidate jobs of a queue in a scheduling cycle, across all pools.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		},
+		[]string{
+			"queue",
+		},
+	)
+
+	queueCycleExaminedJobs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "queue_cycle_examined_jobs",
+			Help:      "Number of jobs of a queue examined by scheduling cycles, across all pools.",
+		},
+		[]string{
+			"queue",
+		},
+	)
+
+	queueCycleScheduledJobs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "queue_cycle_scheduled_jobs",
+			Help:      "Number of jobs of a queue scheduled by scheduling cycles, across all pools.",
+		},
+		[]string{
+			"queue",
+		},
+	)
+
+	shadowScheduleTime := prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "shadow_schedule_time_seconds",
+			Help:      "Duration of scheduling rounds run while not leader, the decisions of which are discarded.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+		},
+	)
+
+	shadowScheduledJobs := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "shadow_scheduled_jobs",
+			Help:      "Number of jobs the most recent scheduling round run while not leader would have leased.",
+		},
+	)
+
+	shadowScheduleFailures := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Subsystem: SUBSYSTEM,
+			Name:      "shadow_schedule_failures",
+			Help:      "Number of scheduling rounds run while not leader that failed.",
+		},
+	)
+
+	registerer.MustRegister(poolUtilisation)
+	registerer.MustRegister(preemptionBudgetConsumed)
+	registerer.MustRegister(globalPreemptionBudgetConsumed)
+	registerer.MustRegister(preemptionBudgetExhausted)
+	registerer.MustRegister(blockedJobs)
+	registerer.MustRegister(oldestUnprocessedUpdateAge)
+	registerer.MustRegister(serialRegressions)
+	registerer.MustRegister(eventDivergences)
+	registerer.MustRegister(eventDivergenceAborts)
+	registerer.MustRegister(illegalJobDbWrites)
+	registerer.MustRegister(contradictoryRunOutcomes)
+	registerer.MustRegister(jobDbWriteGuardAborts)
+	registerer.MustRegister(jobDbRebuildProgress)
+	registerer.MustRegister(jobDbRebuilds)
+	registerer.MustRegister(queueCycleSchedulingTime)
+	registerer.MustRegister(queueCycleExaminedJobs)
+	registerer.MustRegister(queueCycleScheduledJobs)
+	registerer.MustRegister(shadowScheduleTime)
+	registerer.MustRegister(shadowScheduledJobs)
+	registerer.MustRegister(shadowScheduleFailures)
+	registerer.MustRegister(pendingLeases)
+	registerer.MustRegister(executorNodeResyncs)
+	registerer.MustRegister(schedulingPanics)
+	registerer.MustRegister(quarantinedQueues)
+	registerer.MustRegister(quarantinedNodes)
+	registerer.MustRegister(quarantinedRunUpdates)
+	registerer.MustRegister(droppedRunUpdates)
 
 	return &SchedulerMetrics{
-		scheduleCycleTime:     scheduleCycleTime,
-		reconcileCycleTime:    reconcileCycleTime,
-		scheduledJobsPerQueue: *scheduledJobs,
-		preemptedJobsPerQueue: *preemptedJobs,
-		consideredJobs:        *consideredJobs,
-		fairSharePerQueue:     *fairSharePerQueue,
-		actualSharePerQueue:   *actualSharePerQueue,
+		scheduleCycleTime:              scheduleCycleTime,
+		reconcileCycleTime:             reconcileCycleTime,
+		scheduledJobsPerQueue:          *scheduledJobs,
+		preemptedJobsPerQueue:          *preemptedJobs,
+		consideredJobs:                 *consideredJobs,
+		fairSharePerQueue:              *fairSharePerQueue,
+		actualSharePerQueue:            *actualSharePerQueue,
+		unknownQueueJobs:               *unknownQueueJobs,
+		catchingUpTime:                 catchingUpTime,
+		estimatedWaitTime:              *estimatedWaitTime,
+		schedulingInfoConflicts:        *schedulingInfoConflicts,
+		queueBacklogLimitedJobs:        *queueBacklogLimitedJobs,
+		abandonedQueuedJobs:            *abandonedQueuedJobs,
+		executorTimeout:                *executorTimeout,
+		staleExecutors:                 *staleExecutors,
+		executorClockSkew:              *executorClockSkew,
+		executorClockSkewExceeded:      *executorClockSkewExceeded,
+		runPhaseLatency:                *runPhaseLatency,
+		queueToLeaseLatency:            *queueToLeaseLatency,
+		oldestQueuedJobAge:             *oldestQueuedJobAge,
+		runNodeReassignments:           *runNodeReassignments,
+		scheduledAtPriorityBackfills:   *scheduledAtPriorityBackfills,
+		schedulingKeySkippedJobs:       *schedulingKeySkippedJobs,
+		schedulingKeyCollisions:        *schedulingKeyCollisions,
+		ignoredCancellations:           *ignoredCancellations,
+		classifiedRunErrors:            *classifiedRunErrors,
+		reservedResources:              *reservedResources,
+		unusedReservedResources:        *unusedReservedResources,
+		poolAllocatableResources:       *poolAllocatableResources,
+		poolAllocatedResources:         *poolAllocatedResources,
+		poolQueuedDemand:               *poolQueuedDemand,
+		poolUtilisation:                *poolUtilisation,
+		preemptionBudgetConsumed:       *preemptionBudgetConsumed,
+		globalPreemptionBudgetConsumed: globalPreemptionBudgetConsumed,
+		preemptionBudgetExhausted:      *preemptionBudgetExhausted,
+		oldestUnprocessedUpdateAge:     oldestUnprocessedUpdateAge,
+		serialRegressions:              *serialRegressions,
+		eventDivergences:               *eventDivergences,
+		eventDivergenceAborts:          eventDivergenceAborts,
+		illegalJobDbWrites:             illegalJobDbWrites,
+		contradictoryRunOutcomes:       *contradictoryRunOutcomes,
+		jobDbWriteGuardAborts:          jobDbWriteGuardAborts,
+		jobDbRebuildProgress:           jobDbRebuildProgress,
+		jobDbRebuilds:                  *jobDbRebuilds,
+		pendingLeases:                  *pendingLeases,
+		executorNodeResyncs:            *executorNodeResyncs,
+		schedulingPanics:               schedulingPanics,
+		quarantinedQueues:              *quarantinedQueues,
+		quarantinedNodes:               *quarantinedNodes,
+		quarantinedRunUpdates:          quarantinedRunUpdates,
+		droppedRunUpdates:              *droppedRunUpdates,
+		blockedJobs:                    *blockedJobs,
+		queueCycleSchedulingTime:       *queueCycleSchedulingTime,
+		queueCycleExaminedJobs:         *queueCycleExaminedJobs,
+		queueCycleScheduledJobs:        *queueCycleScheduledJobs,
+		queueCycleMetricsExpiry:        config.QueueCycleMetricsExpiry,
+		queueCycleLastSeen:             make(map[string]time.Time),
+		shadowScheduleTime:             shadowScheduleTime,
+		shadowScheduledJobs:            shadowScheduledJobs,
+		shadowScheduleFailures:         shadowScheduleFailures,
 	}
 }
 
 func (metrics *SchedulerMetrics) ResetGaugeMetrics() {
 	metrics.fairSharePerQueue.Reset()
 	metrics.actualSharePerQueue.Reset()
+	metrics.reservedResources.Reset()
+	metrics.unusedReservedResources.Reset()
+	metrics.poolAllocatableResources.Reset()
+	metrics.poolAllocatedResources.Reset()
+	metrics.poolQueuedDemand.Reset()
+	metrics.poolUtilisation.Reset()
+	metrics.preemptionBudgetConsumed.Reset()
+	metrics.preemptionBudgetExhausted.Reset()
+	metrics.blockedJobs.Reset()
 }
 
 func (metrics *SchedulerMetrics) ReportScheduleCycleTime(cycleTime time.Duration) {
@@ -156,6 +973,166 @@ func (metrics *SchedulerMetrics) ReportReconcileCycleTime(cycleTime time.Duratio
 	metrics.reconcileCycleTime.Observe(float64(cycleTime.Milliseconds()))
 }
 
+func (metrics *SchedulerMetrics) ReportUnknownQueueJobs(policy string, outcome string, count int) {
+	if count == 0 {
+		return
+	}
+	metrics.unknownQueueJobs.WithLabelValues(policy, outcome).Add(float64(count))
+}
+
+func (metrics *SchedulerMetrics) ReportCatchingUpTime(catchingUpTime time.Duration) {
+	metrics.catchingUpTime.Observe(catchingUpTime.Seconds())
+}
+
+func (metrics *SchedulerMetrics) ReportOldestUnprocessedUpdateAge(age time.Duration) {
+	metrics.oldestUnprocessedUpdateAge.Set(age.Seconds())
+}
+
+func (metrics *SchedulerMetrics) ReportSerialRegression(table string) {
+	metrics.serialRegressions.WithLabelValues(table).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportEventDivergence(transition string, numJobs int) {
+	metrics.eventDivergences.WithLabelValues(transition).Add(float64(numJobs))
+}
+
+func (metrics *SchedulerMetrics) ReportEventDivergenceAbort() {
+	metrics.eventDivergenceAborts.Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportJobDbWriteGuardAbort(numIllegalWrites int) {
+	metrics.illegalJobDbWrites.Add(float64(numIllegalWrites))
+	metrics.jobDbWriteGuardAborts.Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportContradictoryRunOutcome(policy string) {
+	metrics.contradictoryRunOutcomes.WithLabelValues(policy).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportJobDbRebuildProgress(progress float64) {
+	metrics.jobDbRebuildProgress.Set(progress)
+}
+
+func (metrics *SchedulerMetrics) ReportJobDbRebuild(outcome string) {
+	metrics.jobDbRebuildProgress.Set(0)
+	metrics.jobDbRebuilds.WithLabelValues(outcome).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportShadowSchedulingRound(duration time.Duration, numScheduledJobs int) {
+	metrics.shadowScheduleTime.Observe(duration.Seconds())
+	metrics.shadowScheduledJobs.Set(float64(numScheduledJobs))
+}
+
+func (metrics *SchedulerMetrics) ReportShadowSchedulingFailure() {
+	metrics.shadowScheduleFailures.Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportSchedulingPanic() {
+	metrics.schedulingPanics.Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportQueueQuarantine(queue string, isQuarantined bool) {
+	if isQuarantined {
+		metrics.quarantinedQueues.WithLabelValues(queue).Set(1)
+	} else {
+		metrics.quarantinedQueues.WithLabelValues(queue).Set(0)
+	}
+}
+
+func (metrics *SchedulerMetrics) ReportNodeQuarantine(executorId string, nodeName string, isQuarantined bool) {
+	if isQuarantined {
+		metrics.quarantinedNodes.WithLabelValues(executorId, nodeName).Set(1)
+	} else {
+		metrics.quarantinedNodes.WithLabelValues(executorId, nodeName).Set(0)
+	}
+}
+
+func (metrics *SchedulerMetrics) ReportQuarantinedRunUpdates(numQuarantined int) {
+	metrics.quarantinedRunUpdates.Set(float64(numQuarantined))
+}
+
+func (metrics *SchedulerMetrics) ReportDroppedRunUpdate(reason string) {
+	metrics.droppedRunUpdates.WithLabelValues(reason).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportPendingLeases(executorId string, numPending uint) {
+	metrics.pendingLeases.WithLabelValues(executorId).Set(float64(numPending))
+}
+
+func (metrics *SchedulerMetrics) ReportExecutorNodeResync(executorId string, reason string) {
+	metrics.executorNodeResyncs.WithLabelValues(executorId, reason).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportSchedulingInfoConflict(kind string) {
+	metrics.schedulingInfoConflicts.WithLabelValues(kind).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportQueueBacklogLimitedJob(queue string) {
+	metrics.queueBacklogLimitedJobs.WithLabelValues(queue).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportAbandonedQueuedJob(reason string, history string) {
+	metrics.abandonedQueuedJobs.WithLabelValues(reason, history).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportIgnoredCancellation(executorId string) {
+	metrics.ignoredCancellations.WithLabelValues(executorId).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportRunErrorClassification(queue string, classification *RunErrorClassification) {
+	metrics.classifiedRunErrors.WithLabelValues(queue, classification.Name, string(classification.Class)).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportExecutorStaleness(executorId string, timeout time.Duration, isStale bool) {
+	metrics.executorTimeout.WithLabelValues(executorId).Set(timeout.Seconds())
+	if isStale {
+		metrics.staleExecutors.WithLabelValues(executorId).Set(1)
+	} else {
+		metrics.staleExecutors.WithLabelValues(executorId).Set(0)
+	}
+}
+
+func (metrics *SchedulerMetrics) ReportExecutorClockSkew(executorId string, skew time.Duration, exceeded bool) {
+	metrics.executorClockSkew.WithLabelValues(executorId).Set(skew.Seconds())
+	if exceeded {
+		metrics.executorClockSkewExceeded.WithLabelValues(executorId).Set(1)
+	} else {
+		metrics.executorClockSkewExceeded.WithLabelValues(executorId).Set(0)
+	}
+}
+
+func (metrics *SchedulerMetrics) ReportRunPhaseLatency(executorId string, phase string, latency time.Duration) {
+	metrics.runPhaseLatency.WithLabelValues(executorId, phase).Observe(latency.Seconds())
+}
+
+func (metrics *SchedulerMetrics) ReportQueueToLeaseLatency(queue, priorityClassName, pool string, latency time.Duration) {
+	metrics.queueToLeaseLatency.WithLabelValues(queue, priorityClassName, pool).Observe(latency.Seconds())
+}
+
+func (metrics *SchedulerMetrics) ReportOldestQueuedJobAge(priorityClassName string, age time.Duration) {
+	metrics.oldestQueuedJobAge.WithLabelValues(priorityClassName).Set(age.Seconds())
+}
+
+func (metrics *SchedulerMetrics) ReportRunNodeReassignment(executorId string) {
+	metrics.runNodeReassignments.WithLabelValues(executorId).Inc()
+}
+
+func (metrics *SchedulerMetrics) ReportScheduledAtPriorityBackfill(queue string) {
+	metrics.scheduledAtPriorityBackfills.WithLabelValues(queue).Inc()
+}
+
+// ReportEstimatedWaitTimes replaces all previously reported wait time estimates.
+func (metrics *SchedulerMetrics) ReportEstimatedWaitTimes(estimatesByQueue map[string][]WaitTimeEstimate) {
+	metrics.estimatedWaitTime.Reset()
+	for queue, estimates := range estimatesByQueue {
+		for _, estimate := range estimates {
+			if estimate.Known {
+				metrics.estimatedWaitTime.WithLabelValues(queue, estimate.Bucket).Set(estimate.WaitTime.Seconds())
+			}
+		}
+	}
+}
+
 func (metrics *SchedulerMetrics) ReportSchedulerResult(ctx *armadacontext.Context, result SchedulerResult) {
 	// Report the total scheduled jobs (possibly we can get these out of contexts?)
 	metrics.reportScheduledJobs(ctx, result.ScheduledJobs)
@@ -165,6 +1142,50 @@ func (metrics *SchedulerMetrics) ReportSchedulerResult(ctx *armadacontext.Contex
 	// Report the number of considered jobs.
 	metrics.reportNumberOfJobsConsidered(ctx, result.SchedulingContexts)
 	metrics.reportQueueShares(ctx, result.SchedulingContexts)
+	metrics.reportSchedulingKeySkips(result.SchedulingContexts)
+	metrics.reportReservedResources(result.SchedulingContexts)
+	metrics.reportBlockedJobs(result.SchedulingContexts)
+	metrics.reportPoolCapacity(result.SchedulingContexts)
+	metrics.reportPreemptionBudget(result.SchedulingContexts)
+}
+
+// ReportQueueCycleBreakdown reports, for each queue considered by the scheduling cycle that produced result,
+// the time spent evaluating its candidate jobs and the number of its jobs examined and scheduled, summed over all pools.
+func (metrics *SchedulerMetrics) ReportQueueCycleBreakdown(now time.Time, result SchedulerResult) {
+	schedulingTimeByQueue := make(map[string]time.Duration)
+	numExaminedByQueue := make(map[string]int)
+	for _, sctx := range result.SchedulingContexts {
+		for queue, qctx := range sctx.QueueSchedulingContexts {
+			schedulingTimeByQueue[queue] += qctx.SchedulingDuration
+			numExaminedByQueue[queue] += len(qctx.SuccessfulJobSchedulingContexts) + len(qctx.UnsuccessfulJobSchedulingContexts)
+		}
+	}
+	numScheduledByQueue := make(map[string]int)
+	for _, jctx := range result.ScheduledJobs {
+		numScheduledByQueue[jctx.Job.GetQueue()]++
+	}
+	for queue, schedulingTime := range schedulingTimeByQueue {
+		metrics.queueCycleSchedulingTime.WithLabelValues(queue).Observe(schedulingTime.Seconds())
+		metrics.queueCycleExaminedJobs.WithLabelValues(queue).Add(float64(numExaminedByQueue[queue]))
+		metrics.queueCycleScheduledJobs.WithLabelValues(queue).Add(float64(numScheduledByQueue[queue]))
+		metrics.queueCycleLastSeen[queue] = now
+	}
+}
+
+// ExpireQueueCycleMetrics deletes the per-queue cycle metrics of queues not considered for queueCycleMetricsExpiry as of now.
+// Called at the end of every cycle, such that series are also expired while not leader or if the cycle fails.
+func (metrics *SchedulerMetrics) ExpireQueueCycleMetrics(now time.Time) {
+	if metrics.queueCycleMetricsExpiry <= 0 {
+		return
+	}
+	for queue, lastSeen := range metrics.queueCycleLastSeen {
+		if now.Sub(lastSeen) > metrics.queueCycleMetricsExpiry {
+			metrics.queueCycleSchedulingTime.DeleteLabelValues(queue)
+			metrics.queueCycleExaminedJobs.DeleteLabelValues(queue)
+			metrics.queueCycleScheduledJobs.DeleteLabelValues(queue)
+			delete(metrics.queueCycleLastSeen, queue)
+		}
+	}
 }
 
 func (metrics *SchedulerMetrics) reportScheduledJobs(ctx *armadacontext.Context, scheduledJobs []*schedulercontext.JobSchedulingContext) {
@@ -234,6 +1255,82 @@ func (metrics *SchedulerMetrics) reportNumberOfJobsConsidered(ctx *armadacontext
 	}
 }
 
+func (metrics *SchedulerMetrics) reportSchedulingKeySkips(schedulingContexts []*schedulercontext.SchedulingContext) {
+	for _, sctx := range schedulingContexts {
+		for queue, qctx := range sctx.QueueSchedulingContexts {
+			if qctx.NumSchedulingKeySkippedJobs > 0 {
+				metrics.schedulingKeySkippedJobs.WithLabelValues(queue, sctx.Pool).Add(float64(qctx.NumSchedulingKeySkippedJobs))
+			}
+			if qctx.NumSchedulingKeyCollisions > 0 {
+				metrics.schedulingKeyCollisions.WithLabelValues(queue, sctx.Pool).Add(float64(qctx.NumSchedulingKeyCollisions))
+			}
+		}
+	}
+}
+
+func (metrics *SchedulerMetrics) reportReservedResources(schedulingContexts []*schedulercontext.SchedulingContext) {
+	for _, sctx := range schedulingContexts {
+		for queue, reserved := range sctx.ReservedResourcesByQueue {
+			var allocated schedulerobjects.ResourceList
+			if qctx, ok := sctx.QueueSchedulingContexts[queue]; ok {
+				allocated = qctx.Allocated
+			}
+			for t, q := range reserved.Resources {
+				metrics.reservedResources.WithLabelValues(queue, sctx.Pool, t).Set(resource.QuantityAsFloat64(q))
+				unused := q.DeepCopy()
+				unused.Sub(allocated.Get(t))
+				metrics.unusedReservedResources.WithLabelValues(queue, sctx.Pool, t).Set(math.Max(resource.QuantityAsFloat64(unused), 0))
+			}
+		}
+	}
+}
+
+func (metrics *SchedulerMetrics) reportPoolCapacity(schedulingContexts []*schedulercontext.SchedulingContext) {
+	for _, sctx := range schedulingContexts {
+		capacity := sctx.Capacity
+		if capacity == nil {
+			continue
+		}
+		for t, q := range capacity.Allocatable.Resources {
+			metrics.poolAllocatableResources.WithLabelValues(sctx.Pool, t).Set(resource.QuantityAsFloat64(q))
+			metrics.poolUtilisation.WithLabelValues(sctx.Pool, t).Set(capacity.Utilisation(t))
+		}
+		for t, q := range capacity.Allocated.Resources {
+			metrics.poolAllocatedResources.WithLabelValues(sctx.Pool, t).Set(resource.QuantityAsFloat64(q))
+		}
+		for t, q := range capacity.Demand.Resources {
+			metrics.poolQueuedDemand.WithLabelValues(sctx.Pool, t).Set(resource.QuantityAsFloat64(q))
+		}
+	}
+}
+
+func (metrics *SchedulerMetrics) reportPreemptionBudget(schedulingContexts []*schedulercontext.SchedulingContext) {
+	for _, sctx := range schedulingContexts {
+		usage := sctx.PreemptionBudget
+		if usage == nil {
+			continue
+		}
+		metrics.preemptionBudgetConsumed.WithLabelValues(sctx.Pool).Set(usage.Consumed)
+		metrics.globalPreemptionBudgetConsumed.Set(usage.ConsumedGlobally)
+		if usage.Exhausted {
+			metrics.preemptionBudgetExhausted.WithLabelValues(sctx.Pool).Set(1)
+		} else {
+			metrics.preemptionBudgetExhausted.WithLabelValues(sctx.Pool).Set(0)
+		}
+	}
+}
+
+func (metrics *SchedulerMetrics) reportBlockedJobs(schedulingContexts []*schedulercontext.SchedulingContext) {
+	for _, sctx := range schedulingContexts {
+		for queue, qctx := range sctx.QueueSchedulingContexts {
+			numBlockedJobsByCause := qctx.NumBlockedJobsByCause()
+			for _, cause := range schedulercontext.BlockingCauses {
+				metrics.blockedJobs.WithLabelValues(queue, sctx.Pool, string(cause)).Set(float64(numBlockedJobsByCause[cause]))
+			}
+		}
+	}
+}
+
 func (metrics *SchedulerMetrics) reportQueueShares(ctx *armadacontext.Context, schedulingContexts []*schedulercontext.SchedulingContext) {
 	for _, schedContext := range schedulingContexts {
 		totalCost := schedContext.TotalCost()
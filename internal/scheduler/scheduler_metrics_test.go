@@ -2,11 +2,19 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
 )
 
@@ -32,3 +40,97 @@ func TestAggregateJobs(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestReportReservedResources(t *testing.T) {
+	sctx := schedulercontext.NewSchedulingContext(
+		"executor",
+		"reserved-pool",
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestDefaultPriorityClass,
+		nil,
+		rate.NewLimiter(rate.Inf, 1),
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("32")}},
+	)
+	allocated := schedulerobjects.QuantityByTAndResourceType[string]{
+		testfixtures.PriorityClass0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("4")}},
+	}
+	require.NoError(t, sctx.AddQueueSchedulingContext("R", 1, allocated, rate.NewLimiter(rate.Inf, 1)))
+	sctx.ReservedResourcesByQueue = map[string]schedulerobjects.ResourceList{
+		"R": {Resources: map[string]resource.Quantity{"cpu": resource.MustParse("16")}},
+		// Queues without a scheduling context aren't using any of their reservation.
+		"S": {Resources: map[string]resource.Quantity{"cpu": resource.MustParse("8")}},
+	}
+
+	schedulerMetrics.reportReservedResources([]*schedulercontext.SchedulingContext{sctx})
+
+	assert.Equal(t, 16.0, testutil.ToFloat64(schedulerMetrics.reservedResources.WithLabelValues("R", "reserved-pool", "cpu")))
+	assert.Equal(t, 12.0, testutil.ToFloat64(schedulerMetrics.unusedReservedResources.WithLabelValues("R", "reserved-pool", "cpu")))
+	assert.Equal(t, 8.0, testutil.ToFloat64(schedulerMetrics.reservedResources.WithLabelValues("S", "reserved-pool", "cpu")))
+	assert.Equal(t, 8.0, testutil.ToFloat64(schedulerMetrics.unusedReservedResources.WithLabelValues("S", "reserved-pool", "cpu")))
+}
+
+func TestReportQueueCycleBreakdown(t *testing.T) {
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		QueueCycleMetricsExpiry:             time.Hour,
+	}, prometheus.NewRegistry())
+
+	// cycleResult returns the result of a cycle that spent schedulingTimeByQueue evaluating the jobs of each queue
+	// in each of two pools, scheduling one job of queue A per pool.
+	cycleResult := func(schedulingTimeByQueue map[string]time.Duration) SchedulerResult {
+		var result SchedulerResult
+		for _, pool := range []string{"pool-a", "pool-b"} {
+			sctx := schedulercontext.NewSchedulingContext(
+				"executor",
+				pool,
+				testfixtures.TestPriorityClasses,
+				testfixtures.TestDefaultPriorityClass,
+				nil,
+				rate.NewLimiter(rate.Inf, 1),
+				schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("32")}},
+			)
+			for queue, schedulingTime := range schedulingTimeByQueue {
+				require.NoError(t, sctx.AddQueueSchedulingContext(queue, 1, nil, rate.NewLimiter(rate.Inf, 1)))
+				qctx := sctx.QueueSchedulingContexts[queue]
+				qctx.SchedulingDuration = schedulingTime
+				jctxs := schedulercontext.JobSchedulingContextsFromJobs(
+					testfixtures.TestPriorityClasses,
+					testfixtures.N1Cpu4GiJobs(queue, testfixtures.PriorityClass0, 2),
+					GangIdAndCardinalityFromAnnotations,
+				)
+				qctx.UnsuccessfulJobSchedulingContexts[jctxs[0].JobId] = jctxs[0]
+				if queue == "A" {
+					qctx.SuccessfulJobSchedulingContexts[jctxs[1].JobId] = jctxs[1]
+					result.ScheduledJobs = append(result.ScheduledJobs, jctxs[1])
+				}
+			}
+			result.SchedulingContexts = append(result.SchedulingContexts, sctx)
+		}
+		return result
+	}
+
+	metrics.ReportQueueCycleBreakdown(testfixtures.BaseTime, cycleResult(map[string]time.Duration{"A": 3 * time.Second, "B": time.Second}))
+	assert.Equal(t, 6.0, histogramSampleSum(t, &metrics.queueCycleSchedulingTime, "A"))
+	assert.Equal(t, 2.0, histogramSampleSum(t, &metrics.queueCycleSchedulingTime, "B"))
+	assert.Equal(t, 4.0, testutil.ToFloat64(metrics.queueCycleExaminedJobs.WithLabelValues("A")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.queueCycleScheduledJobs.WithLabelValues("A")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.queueCycleExaminedJobs.WithLabelValues("B")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.queueCycleScheduledJobs.WithLabelValues("B")))
+
+	// Queue A is no longer considered; its series are kept until they expire.
+	metrics.ReportQueueCycleBreakdown(testfixtures.BaseTime.Add(time.Hour), cycleResult(map[string]time.Duration{"B": time.Second}))
+	metrics.ExpireQueueCycleMetrics(testfixtures.BaseTime.Add(time.Hour))
+	assert.Equal(t, 2, testutil.CollectAndCount(&metrics.queueCycleExaminedJobs))
+	assert.Equal(t, uint64(2), histogramSampleCount(t, &metrics.queueCycleSchedulingTime, "B"))
+	metrics.ReportQueueCycleBreakdown(testfixtures.BaseTime.Add(time.Hour+time.Minute), cycleResult(map[string]time.Duration{"B": time.Second}))
+	metrics.ExpireQueueCycleMetrics(testfixtures.BaseTime.Add(time.Hour + time.Minute))
+	assert.Equal(t, 1, testutil.CollectAndCount(&metrics.queueCycleSchedulingTime))
+	assert.Equal(t, 1, testutil.CollectAndCount(&metrics.queueCycleExaminedJobs))
+	assert.Equal(t, 1, testutil.CollectAndCount(&metrics.queueCycleScheduledJobs))
+	assert.Equal(t, 6.0, testutil.ToFloat64(metrics.queueCycleExaminedJobs.WithLabelValues("B")))
+
+	// Series also expire in cycles without a breakdown, e.g., while not leader.
+	metrics.ExpireQueueCycleMetrics(testfixtures.BaseTime.Add(3 * time.Hour))
+	assert.Equal(t, 0, testutil.CollectAndCount(&metrics.queueCycleExaminedJobs))
+}
@@ -0,0 +1,848 @@
+package scheduler
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	protoutil "github.com/armadaproject/armada/internal/common/proto"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// Number of seeds each simulation scenario is run with.
+const numSimulationSeeds = 5
+
+const simulationExecutor = "test-executor"
+
+var simulationSchedulingInfoWithQueueTtl = &schedulerobjects.JobSchedulingInfo{
+	ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+		{
+			Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+				PodRequirements: &schedulerobjects.PodRequirements{
+					Priority: int32(10),
+				},
+			},
+		},
+	},
+	QueueTtlSeconds: int64((10 * time.Minute).Seconds()),
+	Version:         1,
+}
+
+// State of a job in the simulated job repository.
+type simulatedJobState string
+
+const (
+	simulatedJobQueued    simulatedJobState = "queued"
+	simulatedJobLeased    simulatedJobState = "leased"
+	simulatedJobCancelled simulatedJobState = "cancelled"
+	simulatedJobFailed    simulatedJobState = "failed"
+	simulatedJobSucceeded simulatedJobState = "succeeded"
+)
+
+// simulationScenario is a sequence of steps, each of which is followed by a scheduler cycle.
+// Jobs are referred to by the order in which they're submitted.
+type simulationScenario struct {
+	// Scheduling info of submitted jobs; defaults to schedulingInfo.
+	schedulingInfo *schedulerobjects.JobSchedulingInfo
+	steps          []simulationStep
+	// Names of the events expected to be published for each job over the whole simulation, in order.
+	expectedEvents map[int][]string
+	// State of each job expected in the job repository at the end of the simulation.
+	expectedStates map[int]simulatedJobState
+}
+
+type simulationStep struct {
+	// Time passed since the previous step.
+	advance time.Duration
+	// Updates made by users and executors before the cycle, applied in a random order.
+	updates []simulationUpdate
+	// If true, the cycle schedules, leasing all queued jobs.
+	schedule bool
+}
+
+type simulationUpdate func(sim *simulation)
+
+// simulation drives a Scheduler through several cycles against a simulated job repository.
+// Events published by the scheduler are written to the simulated job repository the way the scheduler ingester would,
+// such that later cycles observe the decisions of earlier ones. Global invariants are checked after every cycle.
+//
+// Interleavings are randomised using a seeded source:
+//   - the updates of a step are applied in a random order,
+//   - events published by earlier cycles are ingested before or after users' updates,
+//   - idle cycles, which neither schedule nor see time pass, are inserted between steps, and
+//     events may not be ingested until after an idle cycle.
+//
+// Idle cycles are never inserted straight after a cycle that leased jobs, since the scheduler explicitly cancels
+// runs leased in the previous cycle and scenarios depend on which runs those are.
+type simulation struct {
+	t            *testing.T
+	ctx          *armadacontext.Context
+	rand         *rand.Rand
+	clock        *clock.FakeClock
+	sched        *Scheduler
+	jobRepo      *testJobRepository
+	executorRepo *testExecutorRepository
+	publisher    *testPublisher
+	// If true, the executor has stopped heartbeating.
+	executorStale bool
+	// Rows of the simulated job repository.
+	jobs           map[string]*database.Job
+	runs           map[uuid.UUID]*database.Run
+	runErrors      map[uuid.UUID]*armadaevents.Error
+	latestRunIds   map[string]uuid.UUID
+	serial         int64
+	fetchedSerial  int64
+	defaultInfo    *schedulerobjects.JobSchedulingInfo
+	jobIds         []string
+	pendingIngest  []*armadaevents.EventSequence
+	numCycles      int
+	leasedInCycle  bool
+	eventsByJobId  map[string][]string
+	queuedVersions map[string]int32
+	// Update sequence number of the event last published for each job that changed its queued state.
+	updateSequenceNumbers map[string]int32
+	// Fingerprint of each job at the time each event, identified by eventKey, was last published for it.
+	fingerprintsByEventKey map[string]string
+}
+
+func newSimulation(t *testing.T, seed int64, defaultInfo *schedulerobjects.JobSchedulingInfo) *simulation {
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+	jobRepo := &testJobRepository{}
+	executorRepo := &testExecutorRepository{
+		executors: []*schedulerobjects.Executor{{Id: simulationExecutor, Pool: testfixtures.TestPool, LastUpdateTime: testClock.Now()}},
+	}
+	publisher := &testPublisher{}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		executorRepo,
+		&simulationSchedulingAlgo{clock: testClock},
+		NewStandaloneLeaderController(),
+		publisher,
+		&testSubmitChecker{checkSuccess: true},
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+	return &simulation{
+		t:                      t,
+		ctx:                    armadacontext.Background(),
+		rand:                   rand.New(rand.NewSource(seed)),
+		clock:                  testClock,
+		sched:                  sched,
+		jobRepo:                jobRepo,
+		executorRepo:           executorRepo,
+		publisher:              publisher,
+		jobs:                   make(map[string]*database.Job),
+		runs:                   make(map[uuid.UUID]*database.Run),
+		runErrors:              make(map[uuid.UUID]*armadaevents.Error),
+		latestRunIds:           make(map[string]uuid.UUID),
+		defaultInfo:            defaultInfo,
+		eventsByJobId:          make(map[string][]string),
+		queuedVersions:         make(map[string]int32),
+		updateSequenceNumbers:  make(map[string]int32),
+		fingerprintsByEventKey: make(map[string]string),
+	}
+}
+
+// run runs the steps of the scenario, followed by a final cycle once all events have been ingested,
+// such that jobs in a terminal state are removed from the jobDb.
+func (sim *simulation) run(steps []simulationStep) {
+	for i, step := range steps {
+		if i > 0 && !sim.leasedInCycle {
+			for n := sim.rand.Intn(3); n > 0; n-- {
+				if sim.rand.Intn(2) == 0 {
+					sim.ingestPending()
+				}
+				sim.cycle(false)
+			}
+		}
+		sim.clock.Step(step.advance)
+		updates := append([]simulationUpdate(nil), step.updates...)
+		sim.rand.Shuffle(len(updates), func(i, j int) { updates[i], updates[j] = updates[j], updates[i] })
+		ingestFirst := sim.rand.Intn(2) == 0
+		if ingestFirst {
+			sim.ingestPending()
+		}
+		for _, update := range updates {
+			update(sim)
+		}
+		sim.ingestPending()
+		sim.cycle(step.schedule)
+	}
+	sim.ingestPending()
+	sim.cycle(false)
+	sim.ingestPending()
+}
+
+func (sim *simulation) cycle(schedule bool) {
+	sim.numCycles++
+	before := make(map[string]*jobdb.Job)
+	for _, job := range sim.sched.jobDb.ReadTxn().GetAll() {
+		before[job.Id()] = job
+	}
+	if !sim.executorStale {
+		sim.executorRepo.executors[0].LastUpdateTime = sim.clock.Now()
+	}
+	sim.jobRepo.updatedJobs, sim.jobRepo.updatedRuns = sim.fetchUpdates()
+	sim.jobRepo.errors = sim.runErrors
+	sim.publisher.Reset()
+
+	_, err := sim.sched.cycle(sim.ctx, false, sim.sched.leaderController.GetToken(), schedule)
+	require.NoError(sim.t, err, "cycle %d", sim.numCycles)
+
+	sim.leasedInCycle = false
+	for _, sequence := range sim.publisher.events {
+		for _, event := range sequence.Events {
+			if event.GetJobRunLeased() != nil {
+				sim.leasedInCycle = true
+			}
+			jobId := simulationJobId(sim.t, event)
+			sim.eventsByJobId[jobId] = append(sim.eventsByJobId[jobId], simulationEventName(event))
+		}
+	}
+	sim.checkInvariants(before, sim.publisher.events)
+	sim.pendingIngest = append(sim.pendingIngest, sim.publisher.events...)
+}
+
+// checkInvariants checks that the events published by a cycle are consistent with the jobDb after the cycle,
+// that no job's queued version decreased, and that no event was published twice for a job the state of which didn't change.
+func (sim *simulation) checkInvariants(before map[string]*jobdb.Job, sequences []*armadaevents.EventSequence) {
+	t := sim.t
+	txn := sim.sched.jobDb.ReadTxn()
+	publishedInCycle := make(map[string]bool)
+	terminalEventJobIds := make(map[string]bool)
+	for _, sequence := range sequences {
+		for _, event := range sequence.Events {
+			jobId := simulationJobId(t, event)
+			name := simulationEventName(event)
+			job := txn.GetById(jobId)
+			if !assert.NotNil(t, job, "cycle %d: %s published for job %s not in the jobDb", sim.numCycles, name, jobId) {
+				continue
+			}
+			var updateSequenceNumber int32
+			switch e := event.Event.(type) {
+			case *armadaevents.EventSequence_Event_JobRunLeased:
+				updateSequenceNumber = e.JobRunLeased.UpdateSequenceNumber
+				assert.False(t, job.Queued(), "cycle %d: job %s leased but queued", sim.numCycles, jobId)
+				if assert.NotNil(t, job.LatestRun(), "cycle %d: job %s leased without a run", sim.numCycles, jobId) {
+					assert.Equal(t, armadaevents.UuidFromProtoUuid(e.JobRunLeased.RunId), job.LatestRun().Id(), "cycle %d: job %s", sim.numCycles, jobId)
+				}
+				assert.Equal(t, job.QueuedVersion(), updateSequenceNumber, "cycle %d: job %s", sim.numCycles, jobId)
+			case *armadaevents.EventSequence_Event_JobRequeued:
+				updateSequenceNumber = e.JobRequeued.UpdateSequenceNumber
+				assert.LessOrEqual(t, updateSequenceNumber, job.QueuedVersion(), "cycle %d: job %s", sim.numCycles, jobId)
+				if updateSequenceNumber == job.QueuedVersion() {
+					assert.True(t, job.Queued(), "cycle %d: job %s requeued but not queued", sim.numCycles, jobId)
+				}
+			case *armadaevents.EventSequence_Event_CancelJob, *armadaevents.EventSequence_Event_CancelledJob:
+				assert.True(t, job.Cancelled(), "cycle %d: %s published for job %s not cancelled", sim.numCycles, name, jobId)
+				terminalEventJobIds[jobId] = true
+			case *armadaevents.EventSequence_Event_JobErrors:
+				assert.True(t, job.Failed(), "cycle %d: %s published for job %s not failed", sim.numCycles, name, jobId)
+				terminalEventJobIds[jobId] = true
+			case *armadaevents.EventSequence_Event_JobSucceeded:
+				assert.True(t, job.Succeeded(), "cycle %d: %s published for job %s not succeeded", sim.numCycles, name, jobId)
+				terminalEventJobIds[jobId] = true
+			case *armadaevents.EventSequence_Event_JobRunCancelled,
+				*armadaevents.EventSequence_Event_JobRunPreempted,
+				*armadaevents.EventSequence_Event_JobRunErrors:
+				run := job.RunById(simulationRunId(event))
+				if assert.NotNil(t, run, "cycle %d: %s published for unknown run of job %s", sim.numCycles, name, jobId) {
+					assert.True(t, run.InTerminalState(), "cycle %d: %s published for active run of job %s", sim.numCycles, name, jobId)
+				}
+			}
+			if updateSequenceNumber != 0 {
+				assert.Greater(t, updateSequenceNumber, sim.updateSequenceNumbers[jobId], "cycle %d: %s published for job %s", sim.numCycles, name, jobId)
+				sim.updateSequenceNumbers[jobId] = updateSequenceNumber
+			}
+
+			key := simulationEventKey(event, jobId)
+			assert.False(t, publishedInCycle[key], "cycle %d: %s published twice", sim.numCycles, key)
+			publishedInCycle[key] = true
+			fingerprint := simulationJobFingerprint(job)
+			if previous, ok := sim.fingerprintsByEventKey[key]; ok {
+				assert.NotEqual(t, previous, fingerprint, "cycle %d: %s published again without the job's state changing", sim.numCycles, key)
+			}
+			sim.fingerprintsByEventKey[key] = fingerprint
+		}
+	}
+
+	after := make(map[string]bool)
+	for _, job := range txn.GetAll() {
+		after[job.Id()] = true
+		assert.GreaterOrEqual(t, job.QueuedVersion(), sim.queuedVersions[job.Id()], "cycle %d: queued version of job %s decreased", sim.numCycles, job.Id())
+		sim.queuedVersions[job.Id()] = job.QueuedVersion()
+		if job.InTerminalState() {
+			for _, run := range job.AllRuns() {
+				assert.True(t, run.InTerminalState(), "cycle %d: job %s in a terminal state has an active run", sim.numCycles, job.Id())
+			}
+			if previous, ok := before[job.Id()]; !ok || !previous.InTerminalState() {
+				assert.True(t, terminalEventJobIds[job.Id()], "cycle %d: job %s reached a terminal state without an event", sim.numCycles, job.Id())
+			}
+		} else if job.Queued() {
+			if run := job.LatestRun(); run != nil {
+				assert.True(t, run.InTerminalState(), "cycle %d: queued job %s has an active run", sim.numCycles, job.Id())
+			}
+		} else if assert.NotNil(t, job.LatestRun(), "cycle %d: leased job %s has no run", sim.numCycles, job.Id()) {
+			assert.False(t, job.LatestRun().InTerminalState(), "cycle %d: leased job %s has no active run", sim.numCycles, job.Id())
+		}
+	}
+	// Jobs are only removed from the jobDb once the job repository reports them as being in a terminal state.
+	for jobId := range before {
+		if !after[jobId] {
+			state := simulatedJobStateOf(sim.jobs[jobId])
+			assert.Contains(
+				t,
+				[]simulatedJobState{simulatedJobCancelled, simulatedJobFailed, simulatedJobSucceeded},
+				state,
+				"cycle %d: job %s removed from the jobDb while %s", sim.numCycles, jobId, state,
+			)
+		}
+	}
+}
+
+// fetchUpdates returns the rows of the simulated job repository updated since it was last called, in order of serial.
+func (sim *simulation) fetchUpdates() ([]database.Job, []database.Run) {
+	var jobs []database.Job
+	for _, job := range sim.jobs {
+		if job.Serial > sim.fetchedSerial {
+			jobs = append(jobs, *job)
+		}
+	}
+	var runs []database.Run
+	for _, run := range sim.runs {
+		if run.Serial > sim.fetchedSerial {
+			runs = append(runs, *run)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Serial < jobs[j].Serial })
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Serial < runs[j].Serial })
+	sim.fetchedSerial = sim.serial
+	return jobs, runs
+}
+
+func (sim *simulation) touchJob(job *database.Job) {
+	sim.serial++
+	job.Serial = sim.serial
+	job.LastModified = sim.clock.Now()
+	sim.jobs[job.JobID] = job
+}
+
+func (sim *simulation) touchRun(run *database.Run) {
+	sim.serial++
+	run.Serial = sim.serial
+	run.LastModified = sim.clock.Now()
+	sim.runs[run.RunID] = run
+}
+
+// ingestPending writes the events published but not yet ingested to the simulated job repository,
+// in the same way as the scheduler ingester.
+func (sim *simulation) ingestPending() {
+	t := sim.t
+	for _, sequence := range sim.pendingIngest {
+		for _, event := range sequence.Events {
+			jobId := simulationJobId(t, event)
+			job := sim.jobs[jobId]
+			require.NotNil(t, job, "ingesting event for unknown job %s", jobId)
+			switch e := event.Event.(type) {
+			case *armadaevents.EventSequence_Event_JobRunLeased:
+				runId := armadaevents.UuidFromProtoUuid(e.JobRunLeased.RunId)
+				scheduledAtPriority := e.JobRunLeased.ScheduledAtPriority
+				sim.touchRun(&database.Run{
+					RunID:               runId,
+					JobID:               jobId,
+					JobSet:              sequence.JobSetName,
+					Executor:            e.JobRunLeased.ExecutorId,
+					Node:                e.JobRunLeased.NodeId,
+					Created:             sim.clock.Now().UnixNano(),
+					ScheduledAtPriority: &scheduledAtPriority,
+				})
+				sim.latestRunIds[jobId] = runId
+				sim.setQueuedState(job, false, e.JobRunLeased.UpdateSequenceNumber)
+			case *armadaevents.EventSequence_Event_JobRequeued:
+				job.SchedulingInfo = protoutil.MustMarshall(e.JobRequeued.SchedulingInfo)
+				job.SchedulingInfoVersion = int32(e.JobRequeued.SchedulingInfo.Version)
+				sim.setQueuedState(job, true, e.JobRequeued.UpdateSequenceNumber)
+			case *armadaevents.EventSequence_Event_JobRunErrors:
+				for _, runError := range e.JobRunErrors.Errors {
+					if runError.Terminal {
+						run := sim.runs[armadaevents.UuidFromProtoUuid(e.JobRunErrors.RunId)]
+						require.NotNil(t, run)
+						run.Failed = true
+						sim.touchRun(run)
+						break
+					}
+				}
+			case *armadaevents.EventSequence_Event_JobErrors:
+				for _, jobError := range e.JobErrors.Errors {
+					if jobError.Terminal {
+						job.Failed = true
+						sim.touchJob(job)
+						break
+					}
+				}
+			case *armadaevents.EventSequence_Event_JobSucceeded:
+				job.Succeeded = true
+				sim.touchJob(job)
+			case *armadaevents.EventSequence_Event_CancelJob:
+				job.CancelRequested = true
+				sim.touchJob(job)
+			case *armadaevents.EventSequence_Event_CancelledJob:
+				job.Cancelled = true
+				sim.touchJob(job)
+			case *armadaevents.EventSequence_Event_JobRunCancelled:
+				run := sim.runs[armadaevents.UuidFromProtoUuid(e.JobRunCancelled.RunId)]
+				require.NotNil(t, run)
+				run.Cancelled = true
+				sim.touchRun(run)
+			}
+		}
+	}
+	sim.pendingIngest = nil
+}
+
+// setQueuedState updates the queued state of job, unless it's been updated to a newer version already.
+// As with the scheduler ingester, outstanding preemption requests are cleared.
+func (sim *simulation) setQueuedState(job *database.Job, queued bool, queuedVersion int32) {
+	if !assert.Greater(sim.t, queuedVersion, job.QueuedVersion, "stale queued version published for job %s", job.JobID) {
+		return
+	}
+	job.Queued = queued
+	job.QueuedVersion = queuedVersion
+	job.PreemptRequested = false
+	sim.touchJob(job)
+}
+
+// job returns the row of the i-th job submitted.
+func (sim *simulation) job(i int) *database.Job {
+	require.Less(sim.t, i, len(sim.jobIds), "job %d not submitted", i)
+	return sim.jobs[sim.jobIds[i]]
+}
+
+// latestRun returns the row of the latest run of the i-th job submitted, as seen by executors.
+// Executors only learn about leases once they're ingested.
+func (sim *simulation) latestRun(i int) *database.Run {
+	sim.ingestPending()
+	runId, ok := sim.latestRunIds[sim.job(i).JobID]
+	require.True(sim.t, ok, "job %d has no runs", i)
+	return sim.runs[runId]
+}
+
+func submit(info *schedulerobjects.JobSchedulingInfo) simulationUpdate {
+	return func(sim *simulation) {
+		if info == nil {
+			info = sim.defaultInfo
+		}
+		jobId := util.NewULID()
+		sim.jobIds = append(sim.jobIds, jobId)
+		sim.touchJob(&database.Job{
+			JobID:                 jobId,
+			JobSet:                "testJobset",
+			Queue:                 "testQueue",
+			Submitted:             sim.clock.Now().UnixNano(),
+			Queued:                true,
+			SchedulingInfo:        protoutil.MustMarshall(info),
+			SchedulingInfoVersion: int32(info.Version),
+		})
+	}
+}
+
+func requestCancel(i int) simulationUpdate {
+	return func(sim *simulation) {
+		job := sim.job(i)
+		job.CancelRequested = true
+		sim.touchJob(job)
+	}
+}
+
+func requestCancelByJobSet(i int) simulationUpdate {
+	return func(sim *simulation) {
+		job := sim.job(i)
+		job.CancelByJobsetRequested = true
+		sim.touchJob(job)
+	}
+}
+
+func requestPreemption(i int) simulationUpdate {
+	return func(sim *simulation) {
+		job := sim.job(i)
+		job.PreemptRequested = true
+		sim.touchJob(job)
+	}
+}
+
+func runRunning(i int) simulationUpdate {
+	return func(sim *simulation) {
+		run := sim.latestRun(i)
+		run.Running = true
+		sim.touchRun(run)
+	}
+}
+
+func runSucceeded(i int) simulationUpdate {
+	return func(sim *simulation) {
+		run := sim.latestRun(i)
+		run.Succeeded = true
+		sim.touchRun(run)
+	}
+}
+
+// runFailed fails the latest run of the i-th job with a pod error.
+func runFailed(i int) simulationUpdate {
+	return func(sim *simulation) {
+		run := sim.latestRun(i)
+		run.Failed = true
+		run.RunAttempted = true
+		sim.runErrors[run.RunID] = defaultJobRunError
+		sim.touchRun(run)
+	}
+}
+
+// runReturned returns the lease of the latest run of the i-th job, which is considered attempted if attempted is true.
+func runReturned(i int, attempted bool) simulationUpdate {
+	return func(sim *simulation) {
+		run := sim.latestRun(i)
+		run.Failed = true
+		run.Returned = true
+		run.RunAttempted = attempted
+		sim.runErrors[run.RunID] = &armadaevents.Error{
+			Terminal: true,
+			Reason: &armadaevents.Error_PodLeaseReturned{
+				PodLeaseReturned: &armadaevents.PodLeaseReturned{Message: "lease returned", RunAttempted: attempted},
+			},
+		}
+		sim.touchRun(run)
+	}
+}
+
+// redeliverRun makes the latest run of the i-th job appear updated again without changing it.
+func redeliverRun(i int) simulationUpdate {
+	return func(sim *simulation) {
+		sim.touchRun(sim.latestRun(i))
+	}
+}
+
+func executorStopsHeartbeating() simulationUpdate {
+	return func(sim *simulation) {
+		sim.executorStale = true
+	}
+}
+
+func simulatedJobStateOf(job *database.Job) simulatedJobState {
+	switch {
+	case job.Cancelled:
+		return simulatedJobCancelled
+	case job.Failed:
+		return simulatedJobFailed
+	case job.Succeeded:
+		return simulatedJobSucceeded
+	case job.Queued:
+		return simulatedJobQueued
+	default:
+		return simulatedJobLeased
+	}
+}
+
+func simulationJobId(t *testing.T, event *armadaevents.EventSequence_Event) string {
+	protoJobId, err := armadaevents.JobIdFromEvent(event)
+	require.NoError(t, err)
+	jobId, err := armadaevents.UlidStringFromProtoUuid(protoJobId)
+	require.NoError(t, err)
+	return jobId
+}
+
+// simulationEventName returns the name of the type of event, e.g., JobRunLeased.
+func simulationEventName(event *armadaevents.EventSequence_Event) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", event.Event), "*armadaevents.EventSequence_Event_")
+}
+
+// simulationRunId returns the id of the run event refers to, or the nil uuid if it doesn't refer to a run.
+func simulationRunId(event *armadaevents.EventSequence_Event) uuid.UUID {
+	switch e := event.Event.(type) {
+	case *armadaevents.EventSequence_Event_JobRunLeased:
+		return armadaevents.UuidFromProtoUuid(e.JobRunLeased.RunId)
+	case *armadaevents.EventSequence_Event_JobRunErrors:
+		return armadaevents.UuidFromProtoUuid(e.JobRunErrors.RunId)
+	case *armadaevents.EventSequence_Event_JobRunCancelled:
+		return armadaevents.UuidFromProtoUuid(e.JobRunCancelled.RunId)
+	case *armadaevents.EventSequence_Event_JobRunPreempted:
+		return armadaevents.UuidFromProtoUuid(e.JobRunPreempted.PreemptedRunId)
+	}
+	return uuid.Nil
+}
+
+// simulationEventKey identifies events that must only be published again once the state of the job has changed.
+func simulationEventKey(event *armadaevents.EventSequence_Event, jobId string) string {
+	key := simulationEventName(event) + "/" + jobId
+	if runId := simulationRunId(event); runId != uuid.Nil {
+		key += "/" + runId.String()
+	}
+	return key
+}
+
+func simulationJobFingerprint(job *jobdb.Job) string {
+	fingerprint := fmt.Sprintf(
+		"queued=%t queuedVersion=%d cancelled=%t failed=%t succeeded=%t runs=%d",
+		job.Queued(), job.QueuedVersion(), job.Cancelled(), job.Failed(), job.Succeeded(), len(job.AllRuns()),
+	)
+	if run := job.LatestRun(); run != nil {
+		fingerprint += fmt.Sprintf(
+			" latestRun=%s running=%t cancelled=%t failed=%t succeeded=%t",
+			run.Id(), run.Running(), run.Cancelled(), run.Failed(), run.Succeeded(),
+		)
+	}
+	return fingerprint
+}
+
+// simulationSchedulingAlgo leases all queued jobs to the same node.
+type simulationSchedulingAlgo struct {
+	clock clock.Clock
+}
+
+func (a *simulationSchedulingAlgo) Schedule(ctx *armadacontext.Context, txn *jobdb.Txn) (*SchedulerResult, error) {
+	var scheduledJobs []*jobdb.Job
+	placementByJobId := make(map[string]Placement)
+	for _, job := range txn.GetAll() {
+		if !job.Queued() || job.InTerminalState() {
+			continue
+		}
+		placement := Placement{
+			Executor:            simulationExecutor,
+			NodeId:              "test-node",
+			NodeName:            "node",
+			ScheduledAtPriority: job.PodRequirements().GetPriority(),
+			Pool:                testfixtures.TestPool,
+		}
+		job = job.
+			WithQueuedVersion(job.QueuedVersion()+1).
+			WithQueued(false).
+			WithNewRun(placement.Executor, placement.NodeId, placement.NodeName, placement.ScheduledAtPriority, a.clock.Now())
+		placementByJobId[job.Id()] = placement
+		scheduledJobs = append(scheduledJobs, job)
+	}
+	if err := txn.Upsert(scheduledJobs); err != nil {
+		return nil, err
+	}
+	return NewSchedulerResultForTest([]*jobdb.Job{}, scheduledJobs, []*jobdb.Job{}, placementByJobId), nil
+}
+
+// Multi-cycle scenarios, each run under several seeded interleavings.
+func TestScheduler_Simulation(t *testing.T) {
+	tests := map[string]simulationScenario{
+		"lease, acknowledge, cancel, error": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runRunning(0)}},
+				{updates: []simulationUpdate{requestCancel(0)}},
+				{updates: []simulationUpdate{runFailed(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "CancelledJob"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobCancelled},
+		},
+		"lease, acknowledge, error, cancel": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runRunning(0)}},
+				{updates: []simulationUpdate{runFailed(0)}},
+				{updates: []simulationUpdate{requestCancel(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobErrors"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobFailed},
+		},
+		"lease, cancel, acknowledge, error": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{requestCancel(0)}},
+				{updates: []simulationUpdate{runRunning(0)}},
+				{updates: []simulationUpdate{runFailed(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "CancelledJob", "JobRunCancelled"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobCancelled},
+		},
+		"lease, cancel, error, acknowledge": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{requestCancel(0)}},
+				{updates: []simulationUpdate{runFailed(0)}},
+				{updates: []simulationUpdate{runRunning(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "CancelledJob", "JobRunCancelled"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobCancelled},
+		},
+		"lease, error, acknowledge, cancel": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runFailed(0)}},
+				{updates: []simulationUpdate{runRunning(0)}},
+				{updates: []simulationUpdate{requestCancel(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobErrors"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobFailed},
+		},
+		"lease, error, cancel, acknowledge": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runFailed(0)}},
+				{updates: []simulationUpdate{requestCancel(0)}},
+				{updates: []simulationUpdate{runRunning(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobErrors"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobFailed},
+		},
+		"lease, then cancel and error in the same cycle": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{requestCancel(0), runFailed(0)}},
+			},
+			// The run has already failed, so isn't cancelled explicitly despite having been leased in the previous cycle.
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "CancelledJob"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobCancelled},
+		},
+		"cancellation racing a lease": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}},
+				{updates: []simulationUpdate{requestCancel(0)}, schedule: true},
+				{schedule: true},
+			},
+			expectedEvents: map[int][]string{0: {"CancelledJob"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobCancelled},
+		},
+		"job set cancellation racing a lease": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{submit(nil), requestCancelByJobSet(0)}},
+				{updates: []simulationUpdate{requestCancelByJobSet(1)}, schedule: true},
+			},
+			expectedEvents: map[int][]string{
+				0: {"JobRunLeased", "CancelJob", "CancelledJob", "JobRunCancelled"},
+				1: {"CancelJob", "CancelledJob"},
+			},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobCancelled, 1: simulatedJobCancelled},
+		},
+		"requeue then queue ttl expiry": {
+			schedulingInfo: simulationSchedulingInfoWithQueueTtl,
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{advance: time.Minute, updates: []simulationUpdate{runReturned(0, false)}},
+				{advance: 5 * time.Minute},
+				{advance: 6 * time.Minute},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobRequeued", "CancelJob", "CancelledJob"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobCancelled},
+		},
+		"requeue then lease again before queue ttl expiry": {
+			schedulingInfo: simulationSchedulingInfoWithQueueTtl,
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{advance: time.Minute, updates: []simulationUpdate{runReturned(0, false)}},
+				{advance: 5 * time.Minute, schedule: true},
+				{advance: time.Hour, updates: []simulationUpdate{runRunning(0)}},
+				{updates: []simulationUpdate{runSucceeded(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobRequeued", "JobRunLeased", "JobSucceeded"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobSucceeded},
+		},
+		"retries exhausted": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runReturned(0, true)}},
+				{schedule: true},
+				{updates: []simulationUpdate{runReturned(0, true)}},
+				{schedule: true},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobRequeued", "JobRunLeased", "JobErrors"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobFailed},
+		},
+		"redelivered run update of a returned run": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runReturned(0, false)}},
+				{updates: []simulationUpdate{redeliverRun(0)}},
+				{updates: []simulationUpdate{redeliverRun(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobRequeued"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobQueued},
+		},
+		"preemption request then lease again": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runRunning(0)}},
+				{updates: []simulationUpdate{requestPreemption(0)}},
+				{schedule: true},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobRunPreempted", "JobRunCancelled", "JobRequeued", "JobRunLeased"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobLeased},
+		},
+		"executor lost while job is leased": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runRunning(0)}},
+				{advance: 2 * time.Hour, updates: []simulationUpdate{executorStopsHeartbeating()}},
+				{advance: time.Minute, updates: []simulationUpdate{runFailed(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobRunErrors", "JobErrors"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobFailed},
+		},
+		"success then late cancellation": {
+			steps: []simulationStep{
+				{updates: []simulationUpdate{submit(nil)}, schedule: true},
+				{updates: []simulationUpdate{runRunning(0)}},
+				{updates: []simulationUpdate{runSucceeded(0)}},
+				{updates: []simulationUpdate{requestCancel(0)}},
+			},
+			expectedEvents: map[int][]string{0: {"JobRunLeased", "JobSucceeded"}},
+			expectedStates: map[int]simulatedJobState{0: simulatedJobSucceeded},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for seed := int64(0); seed < numSimulationSeeds; seed++ {
+				t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+					info := tc.schedulingInfo
+					if info == nil {
+						info = schedulingInfo
+					}
+					sim := newSimulation(t, seed, info)
+					sim.run(tc.steps)
+
+					for i, jobId := range sim.jobIds {
+						assert.Equal(t, tc.expectedEvents[i], sim.eventsByJobId[jobId], "events of job %d", i)
+						assert.Equal(t, tc.expectedStates[i], simulatedJobStateOf(sim.jobs[jobId]), "state of job %d", i)
+						// Jobs in a terminal state have been removed from the jobDb, whereas all other jobs are in the same state as in the job repository.
+						job := sim.sched.jobDb.ReadTxn().GetById(jobId)
+						switch tc.expectedStates[i] {
+						case simulatedJobCancelled, simulatedJobFailed, simulatedJobSucceeded:
+							assert.Nil(t, job, "job %d", i)
+						default:
+							if assert.NotNil(t, job, "job %d", i) {
+								assert.Equal(t, tc.expectedStates[i] == simulatedJobQueued, job.Queued(), "job %d", i)
+								assert.Equal(t, sim.jobs[jobId].QueuedVersion, job.QueuedVersion(), "job %d", i)
+							}
+						}
+					}
+				})
+			}
+		})
+	}
+}
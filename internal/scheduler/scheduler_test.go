@@ -2,12 +2,14 @@ package scheduler
 
 import (
 	"fmt"
-	"sync"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
@@ -22,6 +24,7 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
 	"github.com/armadaproject/armada/internal/scheduler/kubernetesobjects/affinity"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
 	"github.com/armadaproject/armada/pkg/api"
@@ -51,7 +54,6 @@ var (
 		Version: 1,
 	}
 	schedulingInfo = &schedulerobjects.JobSchedulingInfo{
-		AtMostOnce: true,
 		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
 			{
 				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
@@ -65,7 +67,6 @@ var (
 	}
 	schedulingInfoBytes   = protoutil.MustMarshall(schedulingInfo)
 	updatedSchedulingInfo = &schedulerobjects.JobSchedulingInfo{
-		AtMostOnce: true,
 		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
 			{
 				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
@@ -78,6 +79,19 @@ var (
 		Version: 2,
 	}
 	updatedSchedulingInfoBytes = protoutil.MustMarshall(updatedSchedulingInfo)
+	atMostOnceSchedulingInfo   = &schedulerobjects.JobSchedulingInfo{
+		AtMostOnce: true,
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{
+						Priority: int32(10),
+					},
+				},
+			},
+		},
+		Version: 1,
+	}
 	schedulingInfoWithQueueTtl = &schedulerobjects.JobSchedulingInfo{
 		AtMostOnce: true,
 		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
@@ -104,7 +118,7 @@ var (
 			Factor: 1.1,
 			Count:  100,
 		},
-	})
+	}, prometheus.DefaultRegisterer)
 )
 
 var queuedJob = testfixtures.JobDb.NewJob(
@@ -145,7 +159,19 @@ var leasedJob = testfixtures.JobDb.NewJob(
 	false,
 	false,
 	1,
-).WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 5)
+).WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 5, testfixtures.BaseTime)
+
+// leasedJobAvoidingItsNode is like leasedJob, except its scheduling info already has node anti-affinity for the node
+// it's leased on, e.g., since it previously failed there.
+var leasedJobAvoidingItsNode = func() *jobdb.Job {
+	info := proto.Clone(schedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+	podRequirements := info.GetPodRequirements()
+	podRequirements.Affinity = &v1.Affinity{}
+	if err := affinity.AddNodeAntiAffinity(podRequirements.Affinity, nodeIdLabel, leasedJob.LatestRun().NodeName()); err != nil {
+		panic(err)
+	}
+	return leasedJob.WithJobSchedulingInfo(info)
+}()
 
 var defaultJobRunError = &armadaevents.Error{
 	Terminal: true,
@@ -156,6 +182,17 @@ var defaultJobRunError = &armadaevents.Error{
 	},
 }
 
+var leaseNeverAcknowledgedError = &armadaevents.Error{
+	Terminal: true,
+	Reason: &armadaevents.Error_PodLeaseReturned{
+		PodLeaseReturned: &armadaevents.PodLeaseReturned{
+			Message:                "failed to create pod",
+			RunAttempted:           true,
+			LeaseNeverAcknowledged: true,
+		},
+	},
+}
+
 var leasedFailFastJob = testfixtures.JobDb.NewJob(
 	util.NewULID(),
 	"testJobset",
@@ -168,7 +205,21 @@ var leasedFailFastJob = testfixtures.JobDb.NewJob(
 	false,
 	false,
 	1,
-).WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 5)
+).WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 5, testfixtures.BaseTime)
+
+var leasedAtMostOnceJob = testfixtures.JobDb.NewJob(
+	util.NewULID(),
+	"testJobset",
+	"testQueue",
+	uint32(10),
+	atMostOnceSchedulingInfo,
+	false,
+	2,
+	false,
+	false,
+	false,
+	1,
+).WithQueued(false).WithNewRun("testExecutor", "test-node", "node", 5, testfixtures.BaseTime)
 
 var scheduledAtPriority = int32(5)
 
@@ -214,6 +265,8 @@ func TestScheduler_TestCycle(t *testing.T) {
 		jobRunErrors                     map[uuid.UUID]*armadaevents.Error // job run errors in the database
 		staleExecutor                    bool                              // if true then the executorRepository will report the executor as stale
 		fetchError                       bool                              // if true then the jobRepository will throw an error
+		transientFetchErrors             int                               // number of times the jobRepository will throw a transient error before succeeding
+		corruptJobRunErrors              bool                              // if true then the jobRepository will report the errors of all runs as corrupt
 		scheduleError                    bool                              // if true then the scheduling algo will throw an error
 		publishError                     bool                              // if true the publisher will throw an error
 		submitCheckerFailure             bool                              // if true the submit checker will say the job is unschedulable
@@ -222,6 +275,8 @@ func TestScheduler_TestCycle(t *testing.T) {
 		expectedJobErrors                []string                          // ids of jobs we expect to have produced jobErrors messages
 		expectedJobsToFail               []string                          // ids of jobs we expect to fail without having failed the overall scheduling cycle
 		expectedJobRunPreempted          []string                          // ids of jobs we expect to have produced jobRunPreempted messages
+		expectedPreemptedOnRequest       []string                          // ids of jobs we expect to have produced jobRunPreempted messages since preemption was requested
+		expectedJobRunCancelled          []string                          // ids of jobs we expect to have produced jobRunCancelled messages
 		expectedJobCancelled             []string                          // ids of jobs we expect to have  produced cancelled messages
 		expectedJobRequestCancel         []string                          // ids of jobs we expect to have produced request cancel
 		expectedJobReprioritised         []string                          // ids of jobs we expect to have  produced reprioritised messages
@@ -234,6 +289,7 @@ func TestScheduler_TestCycle(t *testing.T) {
 		expectedNodeAntiAffinities       []string                          // list of nodes there is expected to be anti affinities for on job scheduling info
 		expectedJobSchedulingInfoVersion int                               // expected scheduling info version of jobs at the end of the cycle
 		expectedQueuedVersion            int32                             // expected queued version of jobs at the end of the cycle
+		retryUnacknowledgedAtMostOnce    bool                              // if true then at-most-once jobs are retried if their lease was never acknowledged
 	}{
 		"Lease a single job already in the db": {
 			initialJobs:           []*jobdb.Job{queuedJob},
@@ -355,6 +411,26 @@ func TestScheduler_TestCycle(t *testing.T) {
 			expectedJobSchedulingInfoVersion: 2,
 			expectedQueuedVersion:            leasedJob.QueuedVersion() + 1,
 		},
+		"Lease returned and re-queued without duplicating node anti-affinity": {
+			initialJobs: []*jobdb.Job{leasedJobAvoidingItsNode},
+			runUpdates: []database.Run{
+				{
+					RunID:        leasedJobAvoidingItsNode.LatestRun().Id(),
+					JobID:        leasedJobAvoidingItsNode.Id(),
+					JobSet:       "testJobSet",
+					Executor:     "testExecutor",
+					Failed:       true,
+					Returned:     true,
+					RunAttempted: true,
+					Serial:       1,
+				},
+			},
+			expectedQueued:   []string{leasedJobAvoidingItsNode.Id()},
+			expectedRequeued: []string{leasedJobAvoidingItsNode.Id()},
+			// The node is already avoided; neither the affinity nor the scheduling info version should change.
+			expectedNodeAntiAffinities: []string{leasedJobAvoidingItsNode.LatestRun().NodeName()},
+			expectedQueuedVersion:      leasedJobAvoidingItsNode.QueuedVersion() + 1,
+		},
 		"Lease returned and re-queued when run not attempted": {
 			initialJobs: []*jobdb.Job{leasedJob},
 			runUpdates: []database.Run{
@@ -442,6 +518,87 @@ func TestScheduler_TestCycle(t *testing.T) {
 			expectedTerminal:      []string{leasedFailFastJob.Id()},
 			expectedQueuedVersion: leasedFailFastJob.QueuedVersion(),
 		},
+		"Lease returned for at-most-once job when run attempted": {
+			initialJobs: []*jobdb.Job{leasedAtMostOnceJob},
+			runUpdates: []database.Run{
+				{
+					RunID:        leasedAtMostOnceJob.LatestRun().Id(),
+					JobID:        leasedAtMostOnceJob.Id(),
+					JobSet:       "testJobSet",
+					Executor:     "testExecutor",
+					Failed:       true,
+					Returned:     true,
+					RunAttempted: true,
+					Serial:       1,
+				},
+			},
+			retryUnacknowledgedAtMostOnce: true,
+			expectedJobErrors:             []string{leasedAtMostOnceJob.Id()},
+			expectedTerminal:              []string{leasedAtMostOnceJob.Id()},
+			expectedQueuedVersion:         leasedAtMostOnceJob.QueuedVersion(),
+		},
+		"Lease returned for at-most-once job when run not attempted": {
+			initialJobs: []*jobdb.Job{leasedAtMostOnceJob},
+			runUpdates: []database.Run{
+				{
+					RunID:        leasedAtMostOnceJob.LatestRun().Id(),
+					JobID:        leasedAtMostOnceJob.Id(),
+					JobSet:       "testJobSet",
+					Executor:     "testExecutor",
+					Failed:       true,
+					Returned:     true,
+					RunAttempted: false,
+					Serial:       1,
+				},
+			},
+			retryUnacknowledgedAtMostOnce: true,
+			expectedJobErrors:             []string{leasedAtMostOnceJob.Id()},
+			expectedTerminal:              []string{leasedAtMostOnceJob.Id()},
+			expectedQueuedVersion:         leasedAtMostOnceJob.QueuedVersion(),
+		},
+		"Lease returned for at-most-once job when lease never acknowledged": {
+			initialJobs: []*jobdb.Job{leasedAtMostOnceJob},
+			runUpdates: []database.Run{
+				{
+					RunID:        leasedAtMostOnceJob.LatestRun().Id(),
+					JobID:        leasedAtMostOnceJob.Id(),
+					JobSet:       "testJobSet",
+					Executor:     "testExecutor",
+					Failed:       true,
+					Returned:     true,
+					RunAttempted: false,
+					Serial:       1,
+				},
+			},
+			jobRunErrors: map[uuid.UUID]*armadaevents.Error{
+				leasedAtMostOnceJob.LatestRun().Id(): leaseNeverAcknowledgedError,
+			},
+			retryUnacknowledgedAtMostOnce: true,
+			expectedQueued:                []string{leasedAtMostOnceJob.Id()},
+			expectedRequeued:              []string{leasedAtMostOnceJob.Id()},
+			expectedQueuedVersion:         leasedAtMostOnceJob.QueuedVersion() + 1,
+		},
+		"Lease returned for at-most-once job when lease never acknowledged and retry disabled": {
+			initialJobs: []*jobdb.Job{leasedAtMostOnceJob},
+			runUpdates: []database.Run{
+				{
+					RunID:        leasedAtMostOnceJob.LatestRun().Id(),
+					JobID:        leasedAtMostOnceJob.Id(),
+					JobSet:       "testJobSet",
+					Executor:     "testExecutor",
+					Failed:       true,
+					Returned:     true,
+					RunAttempted: false,
+					Serial:       1,
+				},
+			},
+			jobRunErrors: map[uuid.UUID]*armadaevents.Error{
+				leasedAtMostOnceJob.LatestRun().Id(): leaseNeverAcknowledgedError,
+			},
+			expectedJobErrors:     []string{leasedAtMostOnceJob.Id()},
+			expectedTerminal:      []string{leasedAtMostOnceJob.Id()},
+			expectedQueuedVersion: leasedAtMostOnceJob.QueuedVersion(),
+		},
 		"Job cancelled": {
 			initialJobs: []*jobdb.Job{leasedJob},
 			jobUpdates: []database.Job{
@@ -533,6 +690,65 @@ func TestScheduler_TestCycle(t *testing.T) {
 			expectedQueuedVersion: queuedJobWithExpiredTtl.QueuedVersion(),
 			expectedTerminal:      []string{queuedJobWithExpiredTtl.Id()},
 		},
+		"Leased job with preemption requested is preempted and requeued": {
+			initialJobs: []*jobdb.Job{leasedJob},
+			jobUpdates: []database.Job{
+				{
+					JobID:                 leasedJob.Id(),
+					JobSet:                "testJobSet",
+					Queue:                 "testQueue",
+					Priority:              int64(leasedJob.Priority()),
+					QueuedVersion:         leasedJob.QueuedVersion(),
+					SchedulingInfo:        schedulingInfoBytes,
+					SchedulingInfoVersion: int32(schedulingInfo.Version),
+					PreemptRequested:      true,
+					Serial:                1,
+				},
+			},
+			expectedPreemptedOnRequest: []string{leasedJob.Id()},
+			expectedJobRunCancelled:    []string{leasedJob.Id()},
+			expectedRequeued:           []string{leasedJob.Id()},
+			expectedQueued:             []string{leasedJob.Id()},
+			expectedQueuedVersion:      leasedJob.QueuedVersion() + 1,
+		},
+		"Preemption requested before the job was last leased is ignored": {
+			initialJobs: []*jobdb.Job{leasedJob},
+			jobUpdates: []database.Job{
+				{
+					JobID:                 leasedJob.Id(),
+					JobSet:                "testJobSet",
+					Queue:                 "testQueue",
+					Priority:              int64(leasedJob.Priority()),
+					Queued:                true,
+					QueuedVersion:         leasedJob.QueuedVersion() - 1,
+					SchedulingInfo:        schedulingInfoBytes,
+					SchedulingInfoVersion: int32(schedulingInfo.Version),
+					PreemptRequested:      true,
+					Serial:                1,
+				},
+			},
+			expectedLeased:        []string{leasedJob.Id()},
+			expectedQueuedVersion: leasedJob.QueuedVersion(),
+		},
+		"Preemption requested for a queued job has no effect": {
+			initialJobs: []*jobdb.Job{queuedJob},
+			jobUpdates: []database.Job{
+				{
+					JobID:                 queuedJob.Id(),
+					JobSet:                "testJobSet",
+					Queue:                 "testQueue",
+					Priority:              int64(queuedJob.Priority()),
+					Queued:                true,
+					QueuedVersion:         queuedJob.QueuedVersion(),
+					SchedulingInfo:        schedulingInfoBytes,
+					SchedulingInfoVersion: int32(schedulingInfo.Version),
+					PreemptRequested:      true,
+					Serial:                1,
+				},
+			},
+			expectedQueued:        []string{queuedJob.Id()},
+			expectedQueuedVersion: queuedJob.QueuedVersion(),
+		},
 		"Job reprioritised": {
 			initialJobs: []*jobdb.Job{queuedJob},
 			jobUpdates: []database.Job{
@@ -606,6 +822,50 @@ func TestScheduler_TestCycle(t *testing.T) {
 			expectedLeased:        []string{leasedJob.Id()},
 			expectedQueuedVersion: leasedJob.QueuedVersion(),
 		},
+		"Fetch fails transiently": {
+			jobUpdates: []database.Job{
+				{
+					JobID:                 queuedJob.Id(),
+					JobSet:                "testJobSet",
+					Queue:                 "testQueue",
+					Queued:                true,
+					QueuedVersion:         1,
+					SchedulingInfo:        schedulingInfoBytes,
+					SchedulingInfoVersion: int32(schedulingInfo.Version),
+					Serial:                1,
+				},
+			},
+			transientFetchErrors:  3,
+			expectedJobRunLeased:  []string{queuedJob.Id()},
+			expectedLeased:        []string{queuedJob.Id()},
+			expectedQueuedVersion: queuedJob.QueuedVersion() + 1,
+		},
+		"Fetch fails transiently for longer than the cycle period": {
+			initialJobs:           []*jobdb.Job{leasedJob},
+			transientFetchErrors:  1000,
+			expectedLeased:        []string{leasedJob.Id()},
+			expectedQueuedVersion: leasedJob.QueuedVersion(),
+		},
+		"Job failed with corrupt run error": {
+			initialJobs: []*jobdb.Job{leasedJob},
+			runUpdates: []database.Run{
+				{
+					RunID:    leasedJob.LatestRun().Id(),
+					JobID:    leasedJob.Id(),
+					JobSet:   "testJobSet",
+					Executor: "testExecutor",
+					Failed:   true,
+					Serial:   1,
+				},
+			},
+			jobRunErrors: map[uuid.UUID]*armadaevents.Error{
+				leasedJob.LatestRun().Id(): defaultJobRunError,
+			},
+			corruptJobRunErrors:   true,
+			expectedJobErrors:     []string{leasedJob.Id()},
+			expectedTerminal:      []string{leasedJob.Id()},
+			expectedQueuedVersion: leasedJob.QueuedVersion(),
+		},
 		"Schedule fails": {
 			initialJobs:           []*jobdb.Job{leasedJob},
 			scheduleError:         true,
@@ -620,153 +880,169 @@ func TestScheduler_TestCycle(t *testing.T) {
 		},
 	}
 	for name, tc := range tests {
-		t.Run(name, func(t *testing.T) {
-			clusterTimeout := 1 * time.Hour
-
-			// Test objects
-			jobRepo := &testJobRepository{
-				updatedJobs: tc.jobUpdates,
-				updatedRuns: tc.runUpdates,
-				errors:      tc.jobRunErrors,
-				shouldError: tc.fetchError,
-			}
-			testClock := clock.NewFakeClock(time.Now())
-			schedulingAlgo := &testSchedulingAlgo{
-				jobsToSchedule: tc.expectedJobRunLeased,
-				jobsToPreempt:  tc.expectedJobRunPreempted,
-				jobsToFail:     tc.expectedJobsToFail,
-				shouldError:    tc.scheduleError,
-			}
-			publisher := &testPublisher{shouldError: tc.publishError}
-			submitChecker := &testSubmitChecker{checkSuccess: !tc.submitCheckerFailure}
-
-			heartbeatTime := testClock.Now()
-			if tc.staleExecutor {
-				heartbeatTime = heartbeatTime.Add(-2 * clusterTimeout)
-			}
-			clusterRepo := &testExecutorRepository{
-				updateTimes: map[string]time.Time{"testExecutor": heartbeatTime},
-			}
-			sched, err := NewScheduler(
-				testfixtures.NewJobDb(),
-				jobRepo,
-				clusterRepo,
-				schedulingAlgo,
-				NewStandaloneLeaderController(),
-				publisher,
-				submitChecker,
-				1*time.Second,
-				5*time.Second,
-				clusterTimeout,
-				maxNumberOfAttempts,
-				nodeIdLabel,
-				schedulerMetrics,
-				nil,
-			)
-			require.NoError(t, err)
-
-			sched.clock = testClock
-
-			// insert initial jobs
-			txn := sched.jobDb.WriteTxn()
-			err = txn.Upsert(tc.initialJobs)
-			require.NoError(t, err)
-			txn.Commit()
+		for mode, newJobDb := range newJobDbBySchedulingInfoMode {
+			t.Run(name+"/"+mode, func(t *testing.T) {
+				clusterTimeout := 1 * time.Hour
+
+				// Test objects
+				jobRepo := &testJobRepository{
+					updatedJobs:        tc.jobUpdates,
+					updatedRuns:        tc.runUpdates,
+					errors:             tc.jobRunErrors,
+					shouldError:        tc.fetchError,
+					numTransientErrors: tc.transientFetchErrors,
+				}
+				if tc.corruptJobRunErrors {
+					jobRepo.corruptRunIds = make(map[uuid.UUID]bool)
+					for runId := range tc.jobRunErrors {
+						jobRepo.corruptRunIds[runId] = true
+					}
+				}
+				testClock := clock.NewFakeClock(time.Now())
+				schedulingAlgo := &testSchedulingAlgo{
+					jobsToSchedule: tc.expectedJobRunLeased,
+					jobsToPreempt:  tc.expectedJobRunPreempted,
+					jobsToFail:     tc.expectedJobsToFail,
+					shouldError:    tc.scheduleError,
+				}
+				publisher := &testPublisher{shouldError: tc.publishError}
+				submitChecker := &testSubmitChecker{checkSuccess: !tc.submitCheckerFailure}
 
-			// run a scheduler cycle
-			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
-			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
-			if tc.fetchError || tc.publishError || tc.scheduleError {
-				assert.Error(t, err)
-			} else {
+				heartbeatTime := testClock.Now()
+				if tc.staleExecutor {
+					heartbeatTime = heartbeatTime.Add(-2 * clusterTimeout)
+				}
+				clusterRepo := &testExecutorRepository{
+					executors: []*schedulerobjects.Executor{{Id: "testExecutor", LastUpdateTime: heartbeatTime}},
+				}
+				sched, err := NewScheduler(
+					newJobDb(),
+					jobRepo,
+					clusterRepo,
+					schedulingAlgo,
+					NewStandaloneLeaderController(),
+					publisher,
+					submitChecker,
+					1*time.Second,
+					5*time.Second,
+					clusterTimeout,
+					maxNumberOfAttempts,
+					nodeIdLabel,
+					schedulerMetrics,
+					nil,
+				)
 				require.NoError(t, err)
-			}
-
-			// Assert that all expected events are generated and that all events are expected.
-			outstandingEventsByType := map[string]map[string]bool{
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRunLeased{}):     stringSet(tc.expectedJobRunLeased),
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobErrors{}):        stringSet(tc.expectedJobErrors),
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRunErrors{}):     stringSet(tc.expectedJobRunErrors),
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRunPreempted{}):  stringSet(tc.expectedJobRunPreempted),
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_CancelledJob{}):     stringSet(tc.expectedJobCancelled),
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_ReprioritisedJob{}): stringSet(tc.expectedJobReprioritised),
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobSucceeded{}):     stringSet(tc.expectedJobSucceeded),
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRequeued{}):      stringSet(tc.expectedRequeued),
-				fmt.Sprintf("%T", &armadaevents.EventSequence_Event_CancelJob{}):        stringSet(tc.expectedJobRequestCancel),
-			}
-			err = subtractEventsFromOutstandingEventsByType(publisher.events, outstandingEventsByType)
-			require.NoError(t, err)
-			for eventType, m := range outstandingEventsByType {
-				assert.Empty(t, m, "%d outstanding events of type %s", len(m), eventType)
-			}
 
-			// assert that the serials are where we expect them to be
-			if len(tc.jobUpdates) > 0 {
-				assert.Equal(t, tc.jobUpdates[len(tc.jobUpdates)-1].Serial, sched.jobsSerial)
-			} else {
-				assert.Equal(t, int64(-1), sched.jobsSerial)
-			}
-			if len(tc.runUpdates) > 0 {
-				assert.Equal(t, tc.runUpdates[len(tc.runUpdates)-1].Serial, sched.runsSerial)
-			} else {
-				assert.Equal(t, int64(-1), sched.runsSerial)
-			}
+				sched.clock = testClock
+				if tc.retryUnacknowledgedAtMostOnce {
+					sched.EnableAtMostOnceSafeRetry()
+				}
 
-			// assert that the job db is in the state we expect
-			jobs := sched.jobDb.ReadTxn().GetAll()
-			remainingLeased := stringSet(tc.expectedLeased)
-			remainingQueued := stringSet(tc.expectedQueued)
-			remainingTerminal := stringSet(tc.expectedTerminal)
-			for _, job := range jobs {
-				if job.InTerminalState() {
-					_, ok := remainingTerminal[job.Id()]
-					assert.True(t, ok)
-					allRunsTerminal := true
-					for _, run := range job.AllRuns() {
-						if !run.InTerminalState() {
-							allRunsTerminal = false
-						}
-					}
-					assert.True(t, allRunsTerminal)
-					delete(remainingTerminal, job.Id())
-				} else if job.Queued() {
-					_, ok := remainingQueued[job.Id()]
-					assert.True(t, ok)
-					delete(remainingQueued, job.Id())
+				// insert initial jobs
+				txn := sched.jobDb.WriteTxn()
+				err = txn.Upsert(tc.initialJobs)
+				require.NoError(t, err)
+				txn.Commit()
+
+				// run a scheduler cycle
+				ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+				_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+				persistentTransientFetchError := tc.transientFetchErrors > int(sched.cyclePeriod/transientErrorRetryInterval)
+				if tc.fetchError || persistentTransientFetchError || tc.publishError || tc.scheduleError {
+					assert.Error(t, err)
 				} else {
-					_, ok := remainingLeased[job.Id()]
-					assert.True(t, ok)
-					delete(remainingLeased, job.Id())
+					require.NoError(t, err)
 				}
-				if expectedPriority, ok := tc.expectedJobPriority[job.Id()]; ok {
-					assert.Equal(t, expectedPriority, job.Priority())
+
+				// Assert that all expected events are generated and that all events are expected.
+				outstandingEventsByType := map[string]map[string]bool{
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRunLeased{}):     stringSet(tc.expectedJobRunLeased),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobErrors{}):        stringSet(tc.expectedJobErrors),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRunErrors{}):     stringSet(tc.expectedJobRunErrors),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRunPreempted{}):  stringSet(append(tc.expectedJobRunPreempted, tc.expectedPreemptedOnRequest...)),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRunCancelled{}):  stringSet(tc.expectedJobRunCancelled),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_CancelledJob{}):     stringSet(tc.expectedJobCancelled),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_ReprioritisedJob{}): stringSet(tc.expectedJobReprioritised),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobSucceeded{}):     stringSet(tc.expectedJobSucceeded),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_JobRequeued{}):      stringSet(tc.expectedRequeued),
+					fmt.Sprintf("%T", &armadaevents.EventSequence_Event_CancelJob{}):        stringSet(tc.expectedJobRequestCancel),
 				}
-				if len(tc.expectedNodeAntiAffinities) > 0 {
-					assert.Len(t, job.JobSchedulingInfo().ObjectRequirements, 1)
-					affinity := job.JobSchedulingInfo().ObjectRequirements[0].GetPodRequirements().Affinity
-					assert.NotNil(t, affinity)
-					expectedAffinity := createAntiAffinity(t, nodeIdLabel, tc.expectedNodeAntiAffinities)
-					assert.Equal(t, expectedAffinity, affinity)
+				err = subtractEventsFromOutstandingEventsByType(publisher.events, outstandingEventsByType)
+				require.NoError(t, err)
+				for eventType, m := range outstandingEventsByType {
+					assert.Empty(t, m, "%d outstanding events of type %s", len(m), eventType)
 				}
-				podRequirements := job.PodRequirements()
-				assert.NotNil(t, podRequirements)
 
-				expectedQueuedVersion := int32(1)
-				if tc.expectedQueuedVersion != 0 {
-					expectedQueuedVersion = tc.expectedQueuedVersion
+				// assert that the serials are where we expect them to be
+				if len(tc.jobUpdates) > 0 {
+					assert.Equal(t, tc.jobUpdates[len(tc.jobUpdates)-1].Serial, sched.jobsSerial)
+				} else {
+					assert.Equal(t, int64(-1), sched.jobsSerial)
 				}
-				assert.Equal(t, expectedQueuedVersion, job.QueuedVersion())
-				expectedSchedulingInfoVersion := 1
-				if tc.expectedJobSchedulingInfoVersion != 0 {
-					expectedSchedulingInfoVersion = tc.expectedJobSchedulingInfoVersion
+				if len(tc.runUpdates) > 0 {
+					assert.Equal(t, tc.runUpdates[len(tc.runUpdates)-1].Serial, sched.runsSerial)
+				} else {
+					assert.Equal(t, int64(-1), sched.runsSerial)
 				}
-				assert.Equal(t, uint32(expectedSchedulingInfoVersion), job.JobSchedulingInfo().Version)
-			}
-			assert.Equal(t, 0, len(remainingLeased))
-			assert.Equal(t, 0, len(remainingQueued))
-			assert.Equal(t, 0, len(remainingTerminal))
-			cancel()
-		})
+
+				// assert that the job db is in the state we expect
+				jobs := sched.jobDb.ReadTxn().GetAll()
+				remainingLeased := stringSet(tc.expectedLeased)
+				remainingQueued := stringSet(tc.expectedQueued)
+				remainingTerminal := stringSet(tc.expectedTerminal)
+				for _, job := range jobs {
+					if job.InTerminalState() {
+						_, ok := remainingTerminal[job.Id()]
+						assert.True(t, ok)
+						allRunsTerminal := true
+						for _, run := range job.AllRuns() {
+							if !run.InTerminalState() {
+								allRunsTerminal = false
+							}
+						}
+						assert.True(t, allRunsTerminal)
+						delete(remainingTerminal, job.Id())
+					} else if job.Queued() {
+						_, ok := remainingQueued[job.Id()]
+						assert.True(t, ok)
+						delete(remainingQueued, job.Id())
+					} else {
+						_, ok := remainingLeased[job.Id()]
+						assert.True(t, ok)
+						delete(remainingLeased, job.Id())
+					}
+					// Preemption requests are only acted on once.
+					assert.False(t, job.PreemptRequested())
+					if expectedPriority, ok := tc.expectedJobPriority[job.Id()]; ok {
+						assert.Equal(t, expectedPriority, job.Priority())
+					}
+					if len(tc.expectedNodeAntiAffinities) > 0 {
+						assert.Len(t, job.JobSchedulingInfo().ObjectRequirements, 1)
+						affinity := job.JobSchedulingInfo().ObjectRequirements[0].GetPodRequirements().Affinity
+						assert.NotNil(t, affinity)
+						expectedAffinity := createAntiAffinity(t, nodeIdLabel, tc.expectedNodeAntiAffinities)
+						assert.Equal(t, expectedAffinity, affinity)
+					}
+					podRequirements := job.PodRequirements()
+					assert.NotNil(t, podRequirements)
+
+					expectedQueuedVersion := int32(1)
+					if tc.expectedQueuedVersion != 0 {
+						expectedQueuedVersion = tc.expectedQueuedVersion
+					}
+					assert.Equal(t, expectedQueuedVersion, job.QueuedVersion())
+					expectedSchedulingInfoVersion := 1
+					if tc.expectedJobSchedulingInfoVersion != 0 {
+						expectedSchedulingInfoVersion = tc.expectedJobSchedulingInfoVersion
+					}
+					assert.Equal(t, uint32(expectedSchedulingInfoVersion), job.JobSchedulingInfo().Version)
+				}
+				assert.Equal(t, 0, len(remainingLeased))
+				assert.Equal(t, 0, len(remainingQueued))
+				assert.Equal(t, 0, len(remainingTerminal))
+				cancel()
+			})
+		}
 	}
 }
 
@@ -803,22 +1079,286 @@ func subtractEventsFromOutstandingEventsByType(eventSequences []*armadaevents.Ev
 
 // Test running multiple scheduler cycles
 func TestRun(t *testing.T) {
-	// Test objects
-	jobRepo := testJobRepository{numReceivedPartitions: 100}
-	testClock := clock.NewFakeClock(time.Now())
-	schedulingAlgo := &testSchedulingAlgo{}
-	publisher := &testPublisher{}
-	clusterRepo := &testExecutorRepository{}
-	leaderController := NewStandaloneLeaderController()
-	submitChecker := &testSubmitChecker{checkSuccess: true}
-	sched, err := NewScheduler(
-		testfixtures.NewJobDb(),
-		&jobRepo,
-		clusterRepo,
+	for name, shadowScheduling := range map[string]bool{"shadow scheduling disabled": false, "shadow scheduling enabled": true} {
+		t.Run(name, func(t *testing.T) {
+			// Test objects
+			jobRepo := testJobRepository{numReceivedPartitions: 100}
+			testClock := clock.NewFakeClock(time.Now())
+			schedulingAlgo := &testSchedulingAlgo{}
+			publisher := &testPublisher{}
+			clusterRepo := &testExecutorRepository{}
+			leaderController := NewStandaloneLeaderController()
+			submitChecker := &testSubmitChecker{checkSuccess: true}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				&jobRepo,
+				clusterRepo,
+				schedulingAlgo,
+				leaderController,
+				publisher,
+				submitChecker,
+				1*time.Second,
+				15*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+
+			sched.clock = testClock
+			if shadowScheduling {
+				require.NoError(t, sched.EnableShadowScheduling())
+			}
+
+			ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+			cycles := sched.Subscribe()
+			defer sched.Unsubscribe(cycles)
+
+			//nolint:errcheck
+			go sched.Run(ctx)
+
+			time.Sleep(1 * time.Second)
+
+			// Function that runs a cycle and waits until it completes
+			var jobId string
+			fireCycle := func() CycleSummary {
+				publisher.Reset()
+				jobId = util.NewULID()
+				jobRepo.updatedJobs = []database.Job{{JobID: jobId, Queue: "testQueue", Queued: true}}
+				schedulingAlgo.jobsToSchedule = []string{jobId}
+				testClock.Step(10 * time.Second)
+				return <-cycles
+			}
+
+			// fire a cycle and assert that we became leader and published
+			summary := fireCycle()
+			assert.Equal(t, 1, len(publisher.events))
+			assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, 1)
+			assert.True(t, summary.Leader)
+			assert.True(t, summary.Scheduled)
+			assert.Equal(t, 1, summary.NumScheduledJobs)
+			assert.NoError(t, summary.Err)
+			assert.NotEmpty(t, summary.CycleId)
+			assert.Equal(t, testClock.Now(), summary.CompletedAt)
+
+			// invalidate our leadership: we should not publish
+			// With shadow scheduling, the job is scheduled, but neither published nor leased in the jobDb.
+			leaderController.token = InvalidLeaderToken()
+			summary = fireCycle()
+			assert.Equal(t, 0, len(publisher.events))
+			expectedScheduleCalls := 1
+			if shadowScheduling {
+				expectedScheduleCalls++
+			}
+			assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, expectedScheduleCalls)
+			assert.False(t, summary.Leader)
+			assert.False(t, summary.Scheduled)
+			assert.Equal(t, 0, summary.NumScheduledJobs)
+			job := sched.jobDb.ReadTxn().GetById(jobId)
+			require.NotNil(t, job)
+			assert.True(t, job.Queued())
+			assert.False(t, job.HasRuns())
+
+			// become master again: we should publish
+			leaderController.token = NewLeaderToken()
+			summary = fireCycle()
+			assert.Equal(t, 1, len(publisher.events))
+			assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, expectedScheduleCalls+1)
+			assert.True(t, summary.Leader)
+			assert.True(t, summary.Scheduled)
+
+			cancel()
+		})
+	}
+}
+
+func TestCycle_QueuedAgeMetrics(t *testing.T) {
+	// testSchedulingAlgo creates runs at testfixtures.BaseTime.
+	leased0 := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 1)[0].
+		WithCreated(testfixtures.BaseTime.Add(-10 * time.Second).UnixNano()).WithQueued(true)
+	leased1 := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass1, 1)[0].
+		WithCreated(testfixtures.BaseTime.Add(-100 * time.Second).UnixNano()).WithQueued(true)
+	queued1 := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass1, 1)[0].
+		WithCreated(testfixtures.BaseTime.Add(-1000 * time.Second).UnixNano()).WithQueued(true)
+	registry := prometheus.NewRegistry()
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+	}, registry)
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&testJobRepository{},
+		&testExecutorRepository{},
+		&testSchedulingAlgo{jobsToSchedule: []string{leased0.Id(), leased1.Id()}},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		metrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = clock.NewFakeClock(testfixtures.BaseTime.Add(time.Minute))
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{leased0, leased1, queued1}))
+	txn.Commit()
+
+	_, err = sched.cycle(armadacontext.Background(), false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+
+	metricFamilies, err := registry.Gather()
+	require.NoError(t, err)
+	cumulativeCountsByPriorityClass := make(map[string]map[float64]uint64)
+	ageByPriorityClass := make(map[string]float64)
+	for _, metricFamily := range metricFamilies {
+		for _, metric := range metricFamily.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			switch metricFamily.GetName() {
+			case NAMESPACE + "_" + SUBSYSTEM + "_queue_to_lease_latency_seconds":
+				assert.Equal(t, testfixtures.TestQueue, labels["queue"])
+				assert.Equal(t, testfixtures.TestPool, labels["pool"])
+				cumulativeCounts := make(map[float64]uint64)
+				for _, bucket := range metric.GetHistogram().GetBucket() {
+					cumulativeCounts[bucket.GetUpperBound()] = bucket.GetCumulativeCount()
+				}
+				cumulativeCountsByPriorityClass[labels["priority_class"]] = cumulativeCounts
+			case NAMESPACE + "_" + SUBSYSTEM + "_oldest_queued_job_age_seconds":
+				ageByPriorityClass[labels["priority_class"]] = metric.GetGauge().GetValue()
+			}
+		}
+	}
+
+	// Jobs leased after 10s and 100s fall into the 16s and 128s buckets respectively.
+	require.Len(t, cumulativeCountsByPriorityClass, 2)
+	assert.Equal(t, uint64(0), cumulativeCountsByPriorityClass[testfixtures.PriorityClass0][8])
+	assert.Equal(t, uint64(1), cumulativeCountsByPriorityClass[testfixtures.PriorityClass0][16])
+	assert.Equal(t, uint64(0), cumulativeCountsByPriorityClass[testfixtures.PriorityClass1][64])
+	assert.Equal(t, uint64(1), cumulativeCountsByPriorityClass[testfixtures.PriorityClass1][128])
+
+	// The oldest queued job of priority-1 was submitted 1060s before now, whereas no jobs of other priority classes
+	// remain queued.
+	assert.Equal(t, 1060.0, ageByPriorityClass[testfixtures.PriorityClass1])
+	assert.Equal(t, 0.0, ageByPriorityClass[testfixtures.PriorityClass0])
+	assert.Len(t, ageByPriorityClass, len(testfixtures.TestPriorityClasses))
+}
+
+func TestCycle_QueueCycleMetricsExpiry(t *testing.T) {
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		QueueCycleMetricsExpiry:             time.Hour,
+	}, prometheus.NewRegistry())
+	algo := &queueConsideringSchedulingAlgo{queues: []string{"A", "B"}}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&testJobRepository{},
+		&testExecutorRepository{},
+		algo,
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		metrics,
+		nil,
+	)
+	require.NoError(t, err)
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+	sched.clock = testClock
+	ctx := armadacontext.Background()
+
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, testutil.CollectAndCount(&metrics.queueCycleExaminedJobs))
+
+	// Queue A is absent from the next cycle, after its series have expired.
+	algo.queues = []string{"B"}
+	testClock.Step(2 * time.Hour)
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, testutil.CollectAndCount(&metrics.queueCycleExaminedJobs))
+	assert.Equal(t, 1, testutil.CollectAndCount(&metrics.queueCycleSchedulingTime))
+	assert.Equal(t, 1, testutil.CollectAndCount(&metrics.queueCycleScheduledJobs))
+
+	// Series also expire in cycles that don't schedule.
+	testClock.Step(2 * time.Hour)
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, testutil.CollectAndCount(&metrics.queueCycleExaminedJobs))
+}
+
+func TestRun_CycleJitter(t *testing.T) {
+	jobRepo := testJobRepository{numReceivedPartitions: 100}
+	testClock := clock.NewFakeClock(time.Now())
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		&testSubmitChecker{checkSuccess: true},
+		1*time.Second,
+		15*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+	// Lengthens every period by half.
+	sched.EnableCycleJitter(&proportionalPeriodJitter{fraction: 0.5})
+
+	ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+	defer cancel()
+	cycles := sched.Subscribe()
+	defer sched.Unsubscribe(cycles)
+	//nolint:errcheck
+	go sched.Run(ctx)
+
+	fireCycle := func() CycleSummary {
+		require.Eventually(t, testClock.HasWaiters, time.Second, time.Millisecond)
+		testClock.Step(1500 * time.Millisecond)
+		return <-cycles
+	}
+
+	// The first cycle schedules; subsequent cycles only schedule once the jittered schedule period of 22.5s has passed
+	// since, rather than the configured 15s.
+	assert.True(t, fireCycle().Scheduled)
+	for i := 0; i < 15; i++ {
+		assert.False(t, fireCycle().Scheduled, "cycle %d", i)
+	}
+	assert.True(t, fireCycle().Scheduled)
+}
+
+func TestRun_Observer(t *testing.T) {
+	jobRepo := testJobRepository{numReceivedPartitions: 100}
+	testClock := clock.NewFakeClock(time.Now())
+	schedulingAlgo := &testSchedulingAlgo{}
+	publisher := &testPublisher{}
+	jobDb := testfixtures.NewJobDb()
+	sched, err := NewScheduler(
+		jobDb,
+		&jobRepo,
+		&testExecutorRepository{},
 		schedulingAlgo,
-		leaderController,
+		NewObserverLeaderController(),
 		publisher,
-		submitChecker,
+		&testSubmitChecker{checkSuccess: true},
 		1*time.Second,
 		15*time.Second,
 		1*time.Hour,
@@ -828,45 +1368,121 @@ func TestRun(t *testing.T) {
 		nil,
 	)
 	require.NoError(t, err)
-
 	sched.clock = testClock
 
+	// Reports are served from the observer's own jobDb; the leader is never consulted.
+	repo, err := NewSchedulingContextRepository(1024)
+	require.NoError(t, err)
+	repo.EnableJobStateReports(jobDb)
+	clientProvider := NewFakeClientProvider()
+	clientProvider.Error = errors.New("no leader found")
+	reportsServer := NewLeaderProxyingSchedulingReportsServer(repo, clientProvider)
+	reportsServer.ServeLocally()
+
 	ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+	defer cancel()
+	cycles := sched.Subscribe()
+	defer sched.Unsubscribe(cycles)
 
 	//nolint:errcheck
 	go sched.Run(ctx)
 
 	time.Sleep(1 * time.Second)
 
-	// Function that runs a cycle and waits until it sees published messages
-	fireCycle := func() {
-		publisher.Reset()
-		wg := sync.WaitGroup{}
-		wg.Add(1)
-		sched.onCycleCompleted = func() { wg.Done() }
+	for i := 0; i < 3; i++ {
 		jobId := util.NewULID()
 		jobRepo.updatedJobs = []database.Job{{JobID: jobId, Queue: "testQueue", Queued: true}}
 		schedulingAlgo.jobsToSchedule = []string{jobId}
 		testClock.Step(10 * time.Second)
-		wg.Wait()
+		summary := <-cycles
+		assert.NoError(t, summary.Err)
+		assert.False(t, summary.Leader)
+		assert.False(t, summary.Scheduled)
+
+		// The observer keeps its jobDb up to date, but never schedules or publishes.
+		assert.NotNil(t, jobDb.ReadTxn().GetById(jobId))
+		assert.Equal(t, 0, schedulingAlgo.numberOfScheduleCalls)
+		assert.Empty(t, publisher.events)
+
+		report, err := reportsServer.GetJobReport(ctx, &schedulerobjects.JobReportRequest{JobId: jobId})
+		require.NoError(t, err)
+		assert.Contains(t, report.Report, "State: queued")
 	}
+}
 
-	// fire a cycle and assert that we became leader and published
-	fireCycle()
-	assert.Equal(t, 1, len(publisher.events))
-	assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, 1)
+func TestRun_CatchUpAfterBecomingLeader(t *testing.T) {
+	// Test objects
+	jobRepo := testJobRepository{numReceivedPartitions: 100}
+	testClock := clock.NewFakeClock(time.Now())
+	schedulingAlgo := &testSchedulingAlgo{}
+	publisher := &testPublisher{}
+	clusterRepo := &testExecutorRepository{}
+	leaderController := NewStandaloneLeaderController()
+	submitChecker := &testSubmitChecker{checkSuccess: true}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&jobRepo,
+		clusterRepo,
+		schedulingAlgo,
+		leaderController,
+		publisher,
+		submitChecker,
+		1*time.Second,
+		15*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+	catchUpState := NewCatchUpState()
+	sched.EnableCatchUpBackPressure(catchUpState, 100)
 
-	// invalidate our leadership: we should not publish
+	// Start off as a follower.
 	leaderController.token = InvalidLeaderToken()
-	fireCycle()
-	assert.Equal(t, 0, len(publisher.events))
-	assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, 1)
 
-	// become master again: we should publish
+	ctx, cancel := armadacontext.WithCancel(armadacontext.Background())
+	cycles := sched.Subscribe()
+	defer sched.Unsubscribe(cycles)
+
+	//nolint:errcheck
+	go sched.Run(ctx)
+
+	time.Sleep(1 * time.Second)
+
+	// Function that runs a cycle in which the jobs table has been written to up to the given serial.
+	fireCycle := func(serial int64) {
+		jobRepo.updatedJobs = []database.Job{{JobID: util.NewULID(), Queue: "testQueue", Queued: true, Serial: serial}}
+		testClock.Step(10 * time.Second)
+		<-cycles
+	}
+
+	// Followers never catch up.
+	fireCycle(1)
+	assert.False(t, catchUpState.CatchingUp())
+
+	// Take over from the previous leader with a backlog of updates to replay.
+	leaderController.token = NewLeaderToken()
+	fireCycle(1000)
+	assert.True(t, catchUpState.CatchingUp())
+
+	// Still lagging: new leases must not yet be distributed.
+	fireCycle(2000)
+	assert.True(t, catchUpState.CatchingUp())
+
+	// Lag is now below the threshold.
+	fireCycle(2010)
+	assert.False(t, catchUpState.CatchingUp())
+
+	// Losing and regaining leadership causes us to catch up again.
+	leaderController.token = InvalidLeaderToken()
+	fireCycle(2020)
+	assert.False(t, catchUpState.CatchingUp())
 	leaderController.token = NewLeaderToken()
-	fireCycle()
-	assert.Equal(t, 1, len(publisher.events))
-	assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, 2)
+	fireCycle(2030)
+	assert.True(t, catchUpState.CatchingUp())
 
 	cancel()
 }
@@ -893,7 +1509,8 @@ func TestScheduler_TestSyncState(t *testing.T) {
 					Serial:         1,
 				},
 			},
-			expectedUpdatedJobs: []*jobdb.Job{queuedJob},
+			// A non-zero queued version implies the job was leased before.
+			expectedUpdatedJobs: []*jobdb.Job{queuedJob.WithEverLeased(true).WithSchedulingInfoHash(jobdb.HashSchedulingInfo(schedulingInfoBytes))},
 			expectedJobDbIds:    []string{queuedJob.Id()},
 		},
 		"insert job that already exists": {
@@ -1002,39 +1619,550 @@ func TestScheduler_TestSyncState(t *testing.T) {
 					SchedulingInfo:        updatedSchedulingInfoBytes,
 					SchedulingInfoVersion: int32(updatedSchedulingInfo.Version),
 					Serial:                1,
+					LastModified:          testfixtures.BaseTime.Add(time.Hour),
 				},
 			},
 			expectedUpdatedJobs: []*jobdb.Job{
 				leasedJob.
 					WithJobSchedulingInfo(updatedSchedulingInfo).
+					WithSchedulingInfoHash(jobdb.HashSchedulingInfo(updatedSchedulingInfoBytes)).
 					WithQueued(true).
+					WithQueuedSince(testfixtures.BaseTime.Add(time.Hour)).
 					WithQueuedVersion(3),
 			},
 			expectedJobDbIds: []string{leasedJob.Id()},
 		},
 	}
+	for name, tc := range tests {
+		for mode, newJobDb := range newJobDbBySchedulingInfoMode {
+			t.Run(name+"/"+mode, func(t *testing.T) {
+				ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+				defer cancel()
+
+				// Test objects
+				jobRepo := &testJobRepository{
+					updatedJobs: tc.jobUpdates,
+					updatedRuns: tc.runUpdates,
+				}
+				schedulingAlgo := &testSchedulingAlgo{}
+				publisher := &testPublisher{}
+				clusterRepo := &testExecutorRepository{}
+				leaderController := NewStandaloneLeaderController()
+				sched, err := NewScheduler(
+					newJobDb(),
+					jobRepo,
+					clusterRepo,
+					schedulingAlgo,
+					leaderController,
+					publisher,
+					nil,
+					1*time.Second,
+					5*time.Second,
+					1*time.Hour,
+					maxNumberOfAttempts,
+					nodeIdLabel,
+					schedulerMetrics,
+					nil,
+				)
+				require.NoError(t, err)
+
+				// The SchedulingKeyGenerator embedded in the jobDb has some randomness,
+				// which must be consistent within tests.
+				sched.jobDb = newJobDb()
+
+				// insert initial jobs
+				txn := sched.jobDb.WriteTxn()
+				err = txn.Upsert(tc.initialJobs)
+				require.NoError(t, err)
+				txn.Commit()
+
+				updatedJobs, _, _, err := sched.syncState(ctx)
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expectedUpdatedJobs, withEagerSchedulingInfo(updatedJobs))
+				allDbJobs := sched.jobDb.ReadTxn().GetAll()
+
+				expectedIds := stringSet(tc.expectedJobDbIds)
+				require.Equal(t, len(tc.expectedJobDbIds), len(allDbJobs))
+				for _, job := range allDbJobs {
+					_, ok := expectedIds[job.Id()]
+					assert.True(t, ok)
+				}
+			})
+		}
+	}
+}
+
+func TestScheduler_SchedulingInfoConflicts(t *testing.T) {
+	// The job in the jobDb holds version 2 of its scheduling info.
+	jobDbJob := queuedJob.
+		WithJobSchedulingInfo(updatedSchedulingInfo).
+		WithSchedulingInfoHash(jobdb.HashSchedulingInfo(updatedSchedulingInfoBytes))
+	conflictingSchedulingInfo := proto.Clone(updatedSchedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+	conflictingSchedulingInfo.PriorityClassName = testfixtures.PriorityClass1
+	newerSchedulingInfo := proto.Clone(conflictingSchedulingInfo).(*schedulerobjects.JobSchedulingInfo)
+	newerSchedulingInfo.Version = 3
+	jobRepoJob := func(schedulingInfo *schedulerobjects.JobSchedulingInfo) *database.Job {
+		return &database.Job{
+			JobID:                 jobDbJob.Id(),
+			JobSet:                jobDbJob.Jobset(),
+			Queue:                 jobDbJob.Queue(),
+			Submitted:             jobDbJob.Created(),
+			Queued:                true,
+			QueuedVersion:         jobDbJob.QueuedVersion(),
+			Priority:              int64(jobDbJob.Priority()),
+			SchedulingInfo:        protoutil.MustMarshall(schedulingInfo),
+			SchedulingInfoVersion: int32(schedulingInfo.Version),
+			Serial:                1,
+		}
+	}
+	tests := map[string]struct {
+		jobUpdate *database.Job
+		refetch   bool
+		// Job returned when re-fetching.
+		refetchedJob           *database.Job
+		expectedSchedulingInfo *schedulerobjects.JobSchedulingInfo
+	}{
+		"normal progression": {
+			jobUpdate:              jobRepoJob(newerSchedulingInfo),
+			refetch:                true,
+			expectedSchedulingInfo: newerSchedulingInfo,
+		},
+		"version regression is ignored": {
+			jobUpdate:              jobRepoJob(schedulingInfo),
+			expectedSchedulingInfo: updatedSchedulingInfo,
+		},
+		"same version but different contents is ignored": {
+			jobUpdate:              jobRepoJob(conflictingSchedulingInfo),
+			expectedSchedulingInfo: updatedSchedulingInfo,
+		},
+		"version regression resolved by re-fetching newer version": {
+			jobUpdate:              jobRepoJob(schedulingInfo),
+			refetch:                true,
+			refetchedJob:           jobRepoJob(newerSchedulingInfo),
+			expectedSchedulingInfo: newerSchedulingInfo,
+		},
+		"version regression kept if re-fetched version is older": {
+			jobUpdate:              jobRepoJob(schedulingInfo),
+			refetch:                true,
+			refetchedJob:           jobRepoJob(schedulingInfo),
+			expectedSchedulingInfo: updatedSchedulingInfo,
+		},
+		"same version but different contents resolved by re-fetching": {
+			jobUpdate:              jobRepoJob(conflictingSchedulingInfo),
+			refetch:                true,
+			refetchedJob:           jobRepoJob(conflictingSchedulingInfo),
+			expectedSchedulingInfo: conflictingSchedulingInfo,
+		},
+		"conflict kept if job can't be re-fetched": {
+			jobUpdate:              jobRepoJob(conflictingSchedulingInfo),
+			refetch:                true,
+			expectedSchedulingInfo: updatedSchedulingInfo,
+		},
+	}
+	for name, tc := range tests {
+		for mode, newJobDb := range newJobDbBySchedulingInfoMode {
+			t.Run(name+"/"+mode, func(t *testing.T) {
+				ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+				defer cancel()
+
+				jobRepo := &testJobRepository{
+					updatedJobs: []database.Job{*tc.jobUpdate},
+					jobsById:    map[string]*database.Job{},
+				}
+				if tc.refetchedJob != nil {
+					jobRepo.jobsById[tc.refetchedJob.JobID] = tc.refetchedJob
+				}
+				sched, err := NewScheduler(
+					newJobDb(),
+					jobRepo,
+					&testExecutorRepository{},
+					&testSchedulingAlgo{},
+					NewStandaloneLeaderController(),
+					&testPublisher{},
+					nil,
+					1*time.Second,
+					5*time.Second,
+					1*time.Hour,
+					maxNumberOfAttempts,
+					nodeIdLabel,
+					schedulerMetrics,
+					nil,
+				)
+				require.NoError(t, err)
+				if tc.refetch {
+					sched.EnableSchedulingInfoConflictRefetch()
+				}
+
+				txn := sched.jobDb.WriteTxn()
+				require.NoError(t, txn.Upsert([]*jobdb.Job{jobDbJob}))
+				txn.Commit()
+
+				_, _, _, err = sched.syncState(ctx)
+				require.NoError(t, err)
+
+				job := sched.jobDb.ReadTxn().GetById(jobDbJob.Id())
+				require.NotNil(t, job)
+				assert.Equal(t, tc.expectedSchedulingInfo.Version, job.JobSchedulingInfo().Version)
+				assert.Equal(t, tc.expectedSchedulingInfo.PriorityClassName, job.JobSchedulingInfo().PriorityClassName)
+			})
+		}
+	}
+}
+
+func TestScheduler_ContradictoryRunOutcome(t *testing.T) {
+	tests := map[string]struct {
+		policy             jobdb.ContradictoryRunPolicy
+		expectJobSucceeded bool
+	}{
+		"prefer succeeded": {
+			policy:             jobdb.ContradictoryRunsPreferSucceeded,
+			expectJobSucceeded: true,
+		},
+		"quarantine": {
+			policy: jobdb.ContradictoryRunsQuarantine,
+		},
+	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
 			defer cancel()
+			job := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 1)[0].
+				WithQueued(false).
+				WithNewRun("testExecutor", "test-node", "node", 0, testfixtures.BaseTime)
+			jobRepo := &testJobRepository{
+				updatedRuns: []database.Run{
+					{
+						RunID:     job.LatestRun().Id(),
+						JobID:     job.Id(),
+						JobSet:    job.Jobset(),
+						Executor:  "testExecutor",
+						Node:      "node",
+						Running:   true,
+						Succeeded: true,
+						Failed:    true,
+						Serial:    1,
+					},
+				},
+			}
+			jobDb := testfixtures.NewJobDb()
+			jobDb.EnableContradictoryRunPolicy(tc.policy)
+			publisher := &testPublisher{}
+			metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+				ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+				ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+			}, prometheus.NewRegistry())
+			sched, err := NewScheduler(
+				jobDb,
+				jobRepo,
+				&testExecutorRepository{},
+				&testSchedulingAlgo{},
+				NewStandaloneLeaderController(),
+				publisher,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				metrics,
+				nil,
+			)
+			require.NoError(t, err)
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+			txn.Commit()
 
-			// Test objects
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+			assert.Equal(t, 1.0, testutil.ToFloat64(metrics.contradictoryRunOutcomes.WithLabelValues(tc.policy.String())))
+			job = sched.jobDb.ReadTxn().GetById(job.Id())
+			require.NotNil(t, job)
+			if tc.expectJobSucceeded {
+				assert.True(t, job.Succeeded())
+				require.Len(t, publisher.events, 1)
+				assert.NotNil(t, publisher.events[0].Events[0].GetJobSucceeded())
+				return
+			}
+			// Quarantined runs are left non-terminal without any events being published for them.
+			assert.Equal(t, jobdb.JobPhaseLeased, job.Phase())
+			assert.Equal(t, jobdb.RunPhaseRunning, job.LatestRun().Phase())
+			assert.True(t, job.LatestRun().OutcomeContradictory())
+			assert.Empty(t, publisher.events)
+		})
+	}
+}
+
+func TestScheduler_RunNodeReassignment(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	const executorId = "testExecutor"
+	nodes := testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)
+	for _, node := range nodes {
+		node.Executor = executorId
+		node.Id = api.NodeIdFromExecutorAndNodeName(executorId, node.Name)
+	}
+	job := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 1)[0]
+	job = job.
+		WithQueued(false).
+		WithNewRun(executorId, nodes[0].Id, nodes[0].Name, job.PodRequirements().Priority, testfixtures.BaseTime)
+	job = job.WithUpdatedRun(job.LatestRun().WithRunning(true))
+
+	// The executor reschedules the pod of the running run onto the other node without returning the lease.
+	jobRepo := &testJobRepository{
+		updatedRuns: []database.Run{
+			{
+				RunID:    job.LatestRun().Id(),
+				JobID:    job.Id(),
+				JobSet:   job.Jobset(),
+				Executor: executorId,
+				Node:     nodes[1].Name,
+				Running:  true,
+				Serial:   1,
+			},
+		},
+	}
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+	}, prometheus.NewRegistry())
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		metrics,
+		nil,
+	)
+	require.NoError(t, err)
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{job}))
+	txn.Commit()
+
+	_, jsts, _, err := sched.syncState(ctx)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.True(t, jsts[0].NodeReassigned)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.runNodeReassignments.WithLabelValues(executorId)))
+	job = sched.jobDb.ReadTxn().GetById(job.Id())
+	require.NotNil(t, job)
+	assert.Equal(t, nodes[1].Id, job.LatestRun().NodeId())
+	assert.Equal(t, nodes[1].Name, job.LatestRun().NodeName())
+
+	// The resources of the run are accounted for on the node it was reassigned to.
+	schedulingConfig := testfixtures.TestSchedulingConfig()
+	algo, err := NewFairSchedulingAlgo(schedulingConfig, 5*time.Second, nil, nil, nil)
+	require.NoError(t, err)
+	nodeDb, err := nodedb.NewNodeDb(
+		schedulingConfig.Preemption.PriorityClasses,
+		schedulingConfig.MaxExtraNodesToConsider,
+		schedulingConfig.IndexedResources,
+		schedulingConfig.IndexedTaints,
+		schedulingConfig.IndexedNodeLabels,
+		schedulingConfig.WellKnownNodeTypes,
+	)
+	require.NoError(t, err)
+	require.NoError(t, algo.addExecutorToNodeDb(nodeDb, []*jobdb.Job{job}, nodes, testfixtures.TestPool))
+	oldNode, err := nodeDb.GetNode(nodes[0].Id)
+	require.NoError(t, err)
+	assert.Empty(t, oldNode.AllocatedByJobId)
+	assert.Empty(t, oldNode.AllocatedByQueue)
+	newNode, err := nodeDb.GetNode(nodes[1].Id)
+	require.NoError(t, err)
+	assert.Contains(t, newNode.AllocatedByJobId, job.Id())
+	allocated := newNode.AllocatedByQueue[testfixtures.TestQueue]
+	assert.True(t, job.PodRequirements().ResourceRequirements.Requests.Cpu().Equal(allocated.Get(string(v1.ResourceCPU))))
+}
+
+func TestScheduler_ScheduledAtPriorityBackfill(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	jobId := util.NewULID()
+	runId := uuid.New()
+	// A running job whose run was stored without the priority it was scheduled at.
+	jobRepo := &testJobRepository{
+		updatedJobs: []database.Job{
+			{
+				JobID:                 jobId,
+				JobSet:                "testJobSet",
+				Queue:                 "testQueue",
+				SchedulingInfo:        schedulingInfoBytes,
+				SchedulingInfoVersion: int32(schedulingInfo.Version),
+				Serial:                1,
+			},
+		},
+		updatedRuns: []database.Run{
+			{
+				RunID:    runId,
+				JobID:    jobId,
+				JobSet:   "testJobSet",
+				Executor: "testExecutor",
+				Node:     "test-node",
+				Running:  true,
+				Serial:   1,
+			},
+		},
+	}
+	metrics := NewSchedulerMetrics(configuration.SchedulerMetricsConfig{
+		ScheduleCycleTimeHistogramSettings:  configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+		ReconcileCycleTimeHistogramSettings: configuration.HistogramConfig{Start: 1, Factor: 1.1, Count: 100},
+	}, prometheus.NewRegistry())
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		metrics,
+		nil,
+	)
+	require.NoError(t, err)
+
+	_, jsts, _, err := sched.syncState(ctx)
+	require.NoError(t, err)
+	require.Len(t, jsts, 1)
+	assert.True(t, jsts[0].ScheduledAtPriorityBackfilled)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.scheduledAtPriorityBackfills.WithLabelValues("testQueue")))
+	expectedPriority := testfixtures.TestPriorityClasses[testfixtures.TestDefaultPriorityClass].Priority
+	job := sched.jobDb.ReadTxn().GetById(jobId)
+	require.NotNil(t, job)
+	priority, ok := job.GetScheduledAtPriority()
+	assert.True(t, ok)
+	assert.Equal(t, expectedPriority, priority)
+
+	sched.storeBackfilledScheduledAtPriorities(ctx, jsts)
+	assert.Equal(t, map[uuid.UUID]int32{runId: expectedPriority}, jobRepo.backfilledScheduledAtPriorities)
+}
+
+func TestScheduler_SyncRunResourceUsage(t *testing.T) {
+	ctx := armadacontext.Background()
+	runId := leasedJob.LatestRun().Id()
+	jobRepo := &testJobRepository{
+		updatedResourceUsage: []database.JobRunResourceUsage{
+			{RunID: runId, CpuSeconds: 10, WorkingSetBytes: 1, Serial: 1},
+			// Usage of runs not in the jobDb is discarded.
+			{RunID: uuid.New(), CpuSeconds: 20, WorkingSetBytes: 2, Serial: 2},
+			// The most recent sample of each run takes precedence.
+			{RunID: runId, CpuSeconds: 30, WorkingSetBytes: 3, Serial: 3},
+		},
+	}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	txn := sched.jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{leasedJob}))
+	txn.Commit()
+
+	// Usage isn't loaded unless enabled.
+	_, _, _, err = sched.syncState(ctx)
+	require.NoError(t, err)
+	_, ok := sched.jobDb.ReadTxn().GetById(leasedJob.Id()).LatestRun().ResourceUsage()
+	assert.False(t, ok)
+
+	sched.EnableRunResourceUsage()
+	updatedJobs, _, _, err := sched.syncState(ctx)
+	require.NoError(t, err)
+
+	// Jobs aren't considered updated as a result of their usage changing.
+	assert.Empty(t, updatedJobs)
+	usage, ok := sched.jobDb.ReadTxn().GetById(leasedJob.Id()).LatestRun().ResourceUsage()
+	require.True(t, ok)
+	assert.Equal(t, jobdb.RunResourceUsage{CpuSeconds: 30, WorkingSetBytes: 3}, usage)
+	assert.Equal(t, int64(3), sched.runResourceUsageSerial)
+}
+
+func TestScheduler_QueueTtlAcrossRequeues(t *testing.T) {
+	tests := map[string]struct {
+		// Annotations of the job.
+		annotations map[string]string
+		// Index of the cycle after which the job is expected to have been cancelled.
+		expectedCancelledAfterCycle int
+	}{
+		"queue ttl is compared to the time spent queued": {
+			expectedCancelledAfterCycle: 3,
+		},
+		"queue ttl is compared to the time since submission if the job opts into it": {
+			annotations:                 map[string]string{configuration.QueueTtlSinceSubmissionAnnotation: "true"},
+			expectedCancelledAfterCycle: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+				ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+					{
+						Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+							PodRequirements: &schedulerobjects.PodRequirements{
+								Priority:    int32(10),
+								Annotations: tc.annotations,
+							},
+						},
+					},
+				},
+				QueueTtlSeconds: int64((10 * time.Minute).Seconds()),
+				Version:         1,
+			}
+			jobId := util.NewULID()
+			submitted := testfixtures.BaseTime.Add(-5 * time.Minute)
+
+			// The scheduling algo creates runs at testfixtures.BaseTime.
+			testClock := clock.NewFakeClock(testfixtures.BaseTime)
 			jobRepo := &testJobRepository{
-				updatedJobs: tc.jobUpdates,
-				updatedRuns: tc.runUpdates,
+				updatedJobs: []database.Job{
+					{
+						JobID:          jobId,
+						JobSet:         "testJobset",
+						Queue:          "testQueue",
+						Queued:         true,
+						QueuedVersion:  0,
+						Submitted:      submitted.UnixNano(),
+						SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+						Serial:         1,
+					},
+				},
 			}
-			schedulingAlgo := &testSchedulingAlgo{}
+			schedulingAlgo := &testSchedulingAlgo{jobsToSchedule: []string{jobId}}
 			publisher := &testPublisher{}
-			clusterRepo := &testExecutorRepository{}
-			leaderController := NewStandaloneLeaderController()
 			sched, err := NewScheduler(
 				testfixtures.NewJobDb(),
 				jobRepo,
-				clusterRepo,
+				&testExecutorRepository{},
 				schedulingAlgo,
-				leaderController,
+				NewStandaloneLeaderController(),
 				publisher,
-				nil,
+				&testSubmitChecker{checkSuccess: true},
 				1*time.Second,
 				5*time.Second,
 				1*time.Hour,
@@ -1044,33 +2172,90 @@ func TestScheduler_TestSyncState(t *testing.T) {
 				nil,
 			)
 			require.NoError(t, err)
+			sched.clock = testClock
 
-			// The SchedulingKeyGenerator embedded in the jobDb has some randomness,
-			// which must be consistent within tests.
-			sched.jobDb = testfixtures.NewJobDb()
+			isCancelled := func() bool {
+				for _, sequence := range publisher.events {
+					for _, event := range sequence.Events {
+						if event.GetCancelledJob() != nil {
+							return true
+						}
+					}
+				}
+				return false
+			}
 
-			// insert initial jobs
-			txn := sched.jobDb.WriteTxn()
-			err = txn.Upsert(tc.initialJobs)
+			// Cycle 0: the job, having been queued for 5 minutes, is leased.
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
 			require.NoError(t, err)
-			txn.Commit()
-
-			updatedJobs, _, _, err := sched.syncState(ctx)
+			job := sched.jobDb.ReadTxn().GetById(jobId)
+			require.NotNil(t, job)
+			require.False(t, job.Queued())
+			assert.Equal(t, 5*time.Minute, job.QueuedDuration(testClock.Now()))
+			assert.False(t, isCancelled())
+
+			// Cycle 1: two days later, the run is returned and the job requeued.
+			testClock.Step(48 * time.Hour)
+			schedulingAlgo.jobsToSchedule = nil
+			jobRepo.updatedJobs = nil
+			jobRepo.updatedRuns = []database.Run{
+				{
+					RunID:    job.LatestRun().Id(),
+					JobID:    jobId,
+					JobSet:   "testJobset",
+					Executor: "test-executor",
+					Node:     "node",
+					Created:  job.LatestRun().Created(),
+					Failed:   true,
+					Returned: true,
+					Serial:   1,
+				},
+			}
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
 			require.NoError(t, err)
-
-			assert.Equal(t, tc.expectedUpdatedJobs, updatedJobs)
-			allDbJobs := sched.jobDb.ReadTxn().GetAll()
-
-			expectedIds := stringSet(tc.expectedJobDbIds)
-			require.Equal(t, len(tc.expectedJobDbIds), len(allDbJobs))
-			for _, job := range allDbJobs {
-				_, ok := expectedIds[job.Id()]
-				assert.True(t, ok)
+			if tc.expectedCancelledAfterCycle == 1 {
+				assert.True(t, isCancelled())
+				return
 			}
+			assert.False(t, isCancelled())
+			job = sched.jobDb.ReadTxn().GetById(jobId)
+			require.NotNil(t, job)
+			require.True(t, job.Queued())
+			assert.Equal(t, 5*time.Minute, job.QueuedDuration(testClock.Now()))
+
+			// Cycle 2: having been queued for 9 minutes in total, the job isn't cancelled.
+			testClock.Step(4 * time.Minute)
+			jobRepo.updatedRuns = nil
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+			assert.False(t, isCancelled())
+			assert.Equal(t, 9*time.Minute, sched.jobDb.ReadTxn().GetById(jobId).QueuedDuration(testClock.Now()))
+
+			// Cycle 3: having been queued for 11 minutes in total, the job is cancelled.
+			testClock.Step(2 * time.Minute)
+			_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+			require.NoError(t, err)
+			assert.Equal(t, 3, tc.expectedCancelledAfterCycle)
+			assert.True(t, isCancelled())
 		})
 	}
 }
 
+// newJobDbBySchedulingInfoMode returns jobDbs storing scheduling info eagerly and lazily, indexed by mode,
+// such that tests can be run against both.
+var newJobDbBySchedulingInfoMode = map[string]func() *jobdb.JobDb{
+	"eager": testfixtures.NewJobDb,
+	"lazy":  testfixtures.NewLazyJobDb,
+}
+
+// withEagerSchedulingInfo returns copies of jobs holding their scheduling info unmarshalled,
+// such that jobs created by jobDbs storing scheduling info lazily can be compared with those created by other jobDbs.
+func withEagerSchedulingInfo(jobs []*jobdb.Job) []*jobdb.Job {
+	return util.Map(jobs, func(job *jobdb.Job) *jobdb.Job {
+		return job.WithJobSchedulingInfo(job.JobSchedulingInfo()).WithSchedulingInfoHash(job.SchedulingInfoHash())
+	})
+}
+
 type testSubmitChecker struct {
 	checkSuccess bool
 }
@@ -1095,9 +2280,25 @@ func (t *testSubmitChecker) CheckJobDbJobs(_ []*jobdb.Job) (bool, string) {
 type testJobRepository struct {
 	updatedJobs           []database.Job
 	updatedRuns           []database.Run
+	jobsById              map[string]*database.Job
 	errors                map[uuid.UUID]*armadaevents.Error
 	shouldError           bool
 	numReceivedPartitions uint32
+	// Number of times FetchJobUpdates fails transiently before succeeding.
+	numTransientErrors int
+	// Runs whose error FetchJobRunErrors reports as corrupt.
+	corruptRunIds map[uuid.UUID]bool
+	// Returned by FetchOldestUnprocessedUpdateTime.
+	oldestUnprocessedUpdateTime *time.Time
+	// Number of times FetchOldestUnprocessedUpdateTime has been called.
+	numOldestUnprocessedUpdateTimeFetches int
+	// Returned by FetchJobRunResourceUsageUpdates.
+	updatedResourceUsage []database.JobRunResourceUsage
+	// Returned by FetchMaxSerials.
+	maxJobSerial int64
+	maxRunSerial int64
+	// Scheduled-at priorities stored by BackfillScheduledAtPriorities.
+	backfilledScheduledAtPriorities map[uuid.UUID]int32
 }
 
 func (t *testJobRepository) FindInactiveRuns(ctx *armadacontext.Context, runIds []uuid.UUID) ([]uuid.UUID, error) {
@@ -1105,22 +2306,89 @@ func (t *testJobRepository) FindInactiveRuns(ctx *armadacontext.Context, runIds
 	panic("implement me")
 }
 
+func (t *testJobRepository) FindTerminalRuns(ctx *armadacontext.Context, runIds []uuid.UUID) ([]uuid.UUID, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (t *testJobRepository) FetchJobRunLeases(ctx *armadacontext.Context, executor string, maxResults uint, excludedRunIds []uuid.UUID) ([]*database.JobRunLease, error) {
 	// TODO implement me
 	panic("implement me")
 }
 
+func (t *testJobRepository) CountJobRunLeases(ctx *armadacontext.Context, executor string, excludedRunIds []uuid.UUID) (uint, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (t *testJobRepository) FetchJobRunSpecVersions(ctx *armadacontext.Context, executor string, runIds []uuid.UUID) ([]database.JobRunSpecVersion, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (t *testJobRepository) FetchJobRunLeasesByRunId(ctx *armadacontext.Context, executor string, runIds []uuid.UUID) ([]*database.JobRunLease, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (t *testJobRepository) FetchJobUpdates(ctx *armadacontext.Context, jobSerial int64, jobRunSerial int64) ([]database.Job, []database.Run, error) {
+	if t.numTransientErrors > 0 {
+		t.numTransientErrors--
+		return nil, nil, errors.Wrap(database.ErrTransient, "error fetching job updates")
+	}
 	if t.shouldError {
 		return nil, nil, errors.New("error fetchiung job updates")
 	}
 	return t.updatedJobs, t.updatedRuns, nil
 }
 
+func (t *testJobRepository) FetchOldestUnprocessedUpdateTime(ctx *armadacontext.Context, jobSerial int64, jobRunSerial int64) (*time.Time, error) {
+	t.numOldestUnprocessedUpdateTimeFetches++
+	if t.shouldError {
+		return nil, errors.New("error fetching oldest unprocessed update time")
+	}
+	return t.oldestUnprocessedUpdateTime, nil
+}
+
+func (t *testJobRepository) FetchMaxSerials(ctx *armadacontext.Context) (int64, int64, error) {
+	if t.shouldError {
+		return 0, 0, errors.New("error fetching max serials")
+	}
+	return t.maxJobSerial, t.maxRunSerial, nil
+}
+
+func (t *testJobRepository) StoreJobRunResourceUsage(ctx *armadacontext.Context, usage []database.JobRunResourceUsage) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (t *testJobRepository) FetchJobRunResourceUsageUpdates(ctx *armadacontext.Context, serial int64) ([]database.JobRunResourceUsage, error) {
+	if t.shouldError {
+		return nil, errors.New("error fetching job run resource usage")
+	}
+	return t.updatedResourceUsage, nil
+}
+
+func (t *testJobRepository) FetchJob(ctx *armadacontext.Context, jobId string) (*database.Job, error) {
+	if t.shouldError {
+		return nil, errors.New("error fetching job")
+	}
+	job, ok := t.jobsById[jobId]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return job, nil
+}
+
 func (t *testJobRepository) FetchJobRunErrors(ctx *armadacontext.Context, runIds []uuid.UUID) (map[uuid.UUID]*armadaevents.Error, error) {
 	if t.shouldError {
 		return nil, errors.New("error fetching job run errors")
 	}
+	for _, runId := range runIds {
+		if t.corruptRunIds[runId] {
+			return nil, &database.ErrCorruptRow{Table: "job_run_errors", RunID: runId, Err: errors.New("invalid compressed data")}
+		}
+	}
 	return t.errors, nil
 }
 
@@ -1131,20 +2399,40 @@ func (t *testJobRepository) CountReceivedPartitions(ctx *armadacontext.Context,
 	return t.numReceivedPartitions, nil
 }
 
+func (t *testJobRepository) BackfillScheduledAtPriorities(ctx *armadacontext.Context, priorityByRunId map[uuid.UUID]int32) error {
+	if t.shouldError {
+		return errors.New("error backfilling scheduled-at priorities")
+	}
+	if t.backfilledScheduledAtPriorities == nil {
+		t.backfilledScheduledAtPriorities = make(map[uuid.UUID]int32)
+	}
+	for runId, priority := range priorityByRunId {
+		t.backfilledScheduledAtPriorities[runId] = priority
+	}
+	return nil
+}
+
 type testExecutorRepository struct {
-	updateTimes map[string]time.Time
+	executors   []*schedulerobjects.Executor
 	shouldError bool
 }
 
 func (t testExecutorRepository) GetExecutors(ctx *armadacontext.Context) ([]*schedulerobjects.Executor, error) {
-	panic("not implemented")
+	if t.shouldError {
+		return nil, errors.New("error getting executors")
+	}
+	return t.executors, nil
 }
 
 func (t testExecutorRepository) GetLastUpdateTimes(ctx *armadacontext.Context) (map[string]time.Time, error) {
 	if t.shouldError {
 		return nil, errors.New("error getting last update time")
 	}
-	return t.updateTimes, nil
+	updateTimes := make(map[string]time.Time, len(t.executors))
+	for _, executor := range t.executors {
+		updateTimes[executor.Id] = executor.LastUpdateTime
+	}
+	return updateTimes, nil
 }
 
 func (t testExecutorRepository) StoreExecutor(ctx *armadacontext.Context, executor *schedulerobjects.Executor) error {
@@ -1167,6 +2455,7 @@ func (t *testSchedulingAlgo) Schedule(ctx *armadacontext.Context, txn *jobdb.Txn
 	preemptedJobs := make([]*jobdb.Job, 0, len(t.jobsToPreempt))
 	scheduledJobs := make([]*jobdb.Job, 0, len(t.jobsToSchedule))
 	failedJobs := make([]*jobdb.Job, 0, len(t.jobsToFail))
+	placementByJobId := make(map[string]Placement, len(t.jobsToSchedule))
 	for _, id := range t.jobsToPreempt {
 		job := txn.GetById(id)
 		if job == nil {
@@ -1195,7 +2484,18 @@ func (t *testSchedulingAlgo) Schedule(ctx *armadacontext.Context, txn *jobdb.Txn
 		if req := job.PodRequirements(); req != nil {
 			priority = req.Priority
 		}
-		job = job.WithQueuedVersion(job.QueuedVersion()+1).WithQueued(false).WithNewRun("test-executor", "test-node", "node", priority)
+		placement := Placement{
+			Executor:            "test-executor",
+			NodeId:              "test-node",
+			NodeName:            "node",
+			ScheduledAtPriority: priority,
+			Pool:                testfixtures.TestPool,
+		}
+		job = job.
+			WithQueuedVersion(job.QueuedVersion()+1).
+			WithQueued(false).
+			WithNewRun(placement.Executor, placement.NodeId, placement.NodeName, placement.ScheduledAtPriority, testfixtures.BaseTime)
+		placementByJobId[job.Id()] = placement
 		scheduledJobs = append(scheduledJobs, job)
 	}
 	for _, id := range t.jobsToFail {
@@ -1218,20 +2518,46 @@ func (t *testSchedulingAlgo) Schedule(ctx *armadacontext.Context, txn *jobdb.Txn
 	if err := txn.Upsert(failedJobs); err != nil {
 		return nil, err
 	}
-	return NewSchedulerResultForTest(preemptedJobs, scheduledJobs, failedJobs, nil), nil
+	return NewSchedulerResultForTest(preemptedJobs, scheduledJobs, failedJobs, placementByJobId), nil
+}
+
+// queueConsideringSchedulingAlgo is a SchedulingAlgo that considers each of queues without scheduling any jobs.
+type queueConsideringSchedulingAlgo struct {
+	queues []string
+}
+
+func (a *queueConsideringSchedulingAlgo) Schedule(_ *armadacontext.Context, _ *jobdb.Txn) (*SchedulerResult, error) {
+	sctx := schedulercontext.NewSchedulingContext(
+		"executor",
+		testfixtures.TestPool,
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestDefaultPriorityClass,
+		nil,
+		nil,
+		schedulerobjects.ResourceList{},
+	)
+	for _, queue := range a.queues {
+		if err := sctx.AddQueueSchedulingContext(queue, 1, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	return &SchedulerResult{
+		SchedulingContexts: []*schedulercontext.SchedulingContext{sctx},
+		PlacementByJobId:   make(map[string]Placement),
+	}, nil
 }
 
 func NewSchedulerResultForTest[S ~[]T, T interfaces.LegacySchedulerJob](
 	preemptedJobs S,
 	scheduledJobs S,
 	failedJobs S,
-	nodeIdByJobId map[string]string,
+	placementByJobId map[string]Placement,
 ) *SchedulerResult {
 	return &SchedulerResult{
-		PreemptedJobs: schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, preemptedJobs, GangIdAndCardinalityFromAnnotations),
-		ScheduledJobs: schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, scheduledJobs, GangIdAndCardinalityFromAnnotations),
-		FailedJobs:    schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, failedJobs, GangIdAndCardinalityFromAnnotations),
-		NodeIdByJobId: nodeIdByJobId,
+		PreemptedJobs:    schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, preemptedJobs, GangIdAndCardinalityFromAnnotations),
+		ScheduledJobs:    schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, scheduledJobs, GangIdAndCardinalityFromAnnotations),
+		FailedJobs:       schedulercontext.JobSchedulingContextsFromJobs(testfixtures.TestPriorityClasses, failedJobs, GangIdAndCardinalityFromAnnotations),
+		PlacementByJobId: placementByJobId,
 	}
 }
 
@@ -1263,3 +2589,7 @@ func stringSet(src []string) map[string]bool {
 	}
 	return set
 }
+
+func (t *testSchedulingAlgo) ShadowCopy() SchedulingAlgo {
+	return t
+}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"fmt"
+	"math/rand"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -11,8 +12,11 @@ import (
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/go-redis/redis"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -21,6 +25,7 @@ import (
 	"github.com/armadaproject/armada/internal/common/app"
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	"github.com/armadaproject/armada/internal/common/auth"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
 	dbcommon "github.com/armadaproject/armada/internal/common/database"
 	grpcCommon "github.com/armadaproject/armada/internal/common/grpc"
 	"github.com/armadaproject/armada/internal/common/health"
@@ -29,6 +34,7 @@ import (
 	"github.com/armadaproject/armada/internal/common/pulsarutils"
 	"github.com/armadaproject/armada/internal/common/serve"
 	"github.com/armadaproject/armada/internal/common/types"
+	"github.com/armadaproject/armada/internal/scheduler/build"
 	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	"github.com/armadaproject/armada/internal/scheduler/database"
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
@@ -37,8 +43,9 @@ import (
 	"github.com/armadaproject/armada/pkg/executorapi"
 )
 
-// Run sets up a Scheduler application and runs it until a SIGTERM is received
-func Run(config schedulerconfig.Configuration) error {
+// Run sets up a Scheduler application and runs it until a SIGTERM is received.
+// If config reloading is enabled, loadConfig is used to re-read the config on SIGHUP or when any of configPaths is modified.
+func Run(config schedulerconfig.Configuration, loadConfig ConfigLoader, configPaths []string) error {
 	g, ctx := armadacontext.ErrGroup(app.CreateContextWithShutdown())
 
 	// ////////////////////////////////////////////////////////////////////////
@@ -54,204 +61,747 @@ func Run(config schedulerconfig.Configuration) error {
 	// ////////////////////////////////////////////////////////////////////////
 	mux := http.NewServeMux()
 
+	// All scheduler metrics are registered through metricsRegistry, which serves a catalogue of them.
+	metricsRegistry := NewMetricsRegistry(prometheus.DefaultRegisterer)
+	mux.Handle("/metrics/catalogue", metricsRegistry)
+
 	startupCompleteCheck := health.NewStartupCompleteChecker()
 	healthChecks := health.NewMultiChecker(startupCompleteCheck)
 	health.SetupHttpMux(mux, healthChecks)
 	shutdownHttpServer := common.ServeHttp(uint16(config.Http.Port), mux)
 	defer shutdownHttpServer()
+	shutdownMetricServer := common.ServeMetrics(config.Metrics.Port)
+	defer shutdownMetricServer()
 
 	// List of services to run concurrently.
 	// Because we want to start services only once all input validation has been completed,
 	// we add all services to a slice and start them together at the end of this function.
 	var services []func() error
 
-	// ////////////////////////////////////////////////////////////////////////
-	// Database setup (postgres and redis)
-	// ////////////////////////////////////////////////////////////////////////
-	ctx.Infof("Setting up database connections")
-	db, err := dbcommon.OpenPgxPool(config.Postgres)
-	if err != nil {
-		return errors.WithMessage(err, "Error opening connection to postgres")
+	// Observer replicas never become leader, serve executors, or publish; they only serve reports.
+	isObserver := config.Role == schedulerconfig.RoleObserver
+	if isObserver {
+		ctx.Infof("Scheduler will run as an observer")
 	}
-	defer db.Close()
-	jobRepository := database.NewPostgresJobRepository(db, int32(config.DatabaseFetchSize))
-	executorRepository := database.NewPostgresExecutorRepository(db)
 
-	redisClient := redis.NewUniversalClient(config.Redis.AsUniversalOptions())
+	// ////////////////////////////////////////////////////////////////////////
+	// Dependencies
+	// ////////////////////////////////////////////////////////////////////////
+	// Connections to postgres, redis, and pulsar are established concurrently in the background,
+	// such that health, metrics, and the gRPC server are available while they're being set up.
+	// Services that need these connections wait for them before starting.
+	// Observers never publish, so don't connect to pulsar.
+	ctx.Infof("Setting up connections to postgres, redis, and pulsar")
+	// Connections established after their dependency timed out are closed straight away.
+	closePostgres := func(db *pgxpool.Pool) { db.Close() }
+	postgres := NewDependency[*pgxpool.Pool]("postgres connection")
+	postgres.EnableRelease(closePostgres)
+	postgres.Start(ctx, config.Startup.DependencyTimeout, func(_ *armadacontext.Context) (*pgxpool.Pool, error) {
+		return dbcommon.OpenPgxPool(config.Postgres)
+	})
 	defer func() {
-		err := redisClient.Close()
-		if err != nil {
+		if db, ok := postgres.TryGet(); ok {
+			closePostgres(db)
+		}
+	}()
+	closeRedis := func(redisClient redis.UniversalClient) {
+		if err := redisClient.Close(); err != nil {
 			logging.
 				WithStacktrace(ctx, err).
 				Warnf("Redis client didn't close down cleanly")
 		}
+	}
+	redisConnection := NewDependency[redis.UniversalClient]("redis connection")
+	redisConnection.EnableRelease(closeRedis)
+	redisConnection.Start(ctx, config.Startup.DependencyTimeout, func(_ *armadacontext.Context) (redis.UniversalClient, error) {
+		redisClient := redis.NewUniversalClient(config.Redis.AsUniversalOptions())
+		if err := redisClient.Ping().Err(); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return redisClient, nil
+	})
+	defer func() {
+		if redisClient, ok := redisConnection.TryGet(); ok {
+			closeRedis(redisClient)
+		}
 	}()
-	queueRepository := database.NewLegacyQueueRepository(redisClient)
-	legacyExecutorRepository := database.NewRedisExecutorRepository(redisClient, "pulsar")
-
-	// ////////////////////////////////////////////////////////////////////////
-	// Pulsar
-	// ////////////////////////////////////////////////////////////////////////
-	ctx.Infof("Setting up Pulsar connectivity")
-	pulsarClient, err := pulsarutils.NewPulsarClient(&config.Pulsar)
-	if err != nil {
-		return errors.WithMessage(err, "Error creating pulsar client")
-	}
-	defer pulsarClient.Close()
-	pulsarPublisher, err := NewPulsarPublisher(pulsarClient, pulsar.ProducerOptions{
-		Name:             fmt.Sprintf("armada-scheduler-%s", uuid.NewString()),
-		CompressionType:  config.Pulsar.CompressionType,
-		CompressionLevel: config.Pulsar.CompressionLevel,
-		BatchingMaxSize:  config.Pulsar.MaxAllowedMessageSize,
-		Topic:            config.Pulsar.JobsetEventsTopic,
-	}, config.PulsarSendTimeout)
-	if err != nil {
-		return errors.WithMessage(err, "error creating pulsar publisher")
+	var pulsarConnection *Dependency[pulsar.Client]
+	if !isObserver {
+		// Closing the client also closes any producers created from it.
+		closePulsar := func(pulsarClient pulsar.Client) { pulsarClient.Close() }
+		pulsarConnection = NewDependency[pulsar.Client]("pulsar client")
+		pulsarConnection.EnableRelease(closePulsar)
+		pulsarConnection.Start(ctx, config.Startup.DependencyTimeout, func(_ *armadacontext.Context) (pulsar.Client, error) {
+			return pulsarutils.NewPulsarClient(&config.Pulsar)
+		})
+		defer func() {
+			if pulsarClient, ok := pulsarConnection.TryGet(); ok {
+				closePulsar(pulsarClient)
+			}
+		}()
+	}
+	healthChecks.Add(postgres)
+	healthChecks.Add(redisConnection)
+	if pulsarConnection != nil {
+		healthChecks.Add(pulsarConnection)
 	}
 
 	// ////////////////////////////////////////////////////////////////////////
 	// Leader Election
 	// ////////////////////////////////////////////////////////////////////////
-	leaderController, err := createLeaderController(ctx, config.Leader)
-	if err != nil {
-		return errors.WithMessage(err, "error creating leader controller")
+	var shardAssignment *ShardAssignment
+	var foreignAllocations *ForeignAllocations
+	if config.Sharding.Enabled {
+		// Executors hold runs of all shards, but their leases are only served from the jobDb of one.
+		if config.JobDbLeases.Enabled {
+			return errors.New("jobDbLeases can't be enabled with sharding, since the jobDb only holds the jobs of one shard")
+		}
+		// Runs of jobs of unknown shard are held until their job is known, rather than dropped.
+		if !config.RunUpdateQuarantine.Enabled {
+			return errors.New("runUpdateQuarantine must be enabled with sharding")
+		}
+		var err error
+		shardAssignment, err = NewShardAssignment(config.Sharding)
+		if err != nil {
+			return errors.WithMessage(err, "error creating shard assignment")
+		}
+		foreignAllocations = NewForeignAllocations()
+		// Each shard elects its own leader.
+		config.Leader.LeaseLockName = fmt.Sprintf("%s-%d", config.Leader.LeaseLockName, shardAssignment.ShardId())
+	}
+	var leaderController LeaderController
+	if isObserver {
+		leaderController = NewObserverLeaderController()
+	} else {
+		var err error
+		leaderController, err = createLeaderController(ctx, config.Leader, metricsRegistry)
+		if err != nil {
+			return errors.WithMessage(err, "error creating leader controller")
+		}
 	}
 	services = append(services, func() error { return leaderController.Run(ctx) })
 
 	// ////////////////////////////////////////////////////////////////////////
-	// Executor Api
+	// gRPC
 	// ////////////////////////////////////////////////////////////////////////
-	ctx.Infof("Setting up executor api")
-	apiProducer, err := pulsarClient.CreateProducer(pulsar.ProducerOptions{
-		Name:             fmt.Sprintf("armada-executor-api-%s", uuid.NewString()),
-		CompressionType:  config.Pulsar.CompressionType,
-		CompressionLevel: config.Pulsar.CompressionLevel,
-		BatchingMaxSize:  config.Pulsar.MaxAllowedMessageSize,
-		Topic:            config.Pulsar.JobsetEventsTopic,
-	})
-	if err != nil {
-		return errors.Wrapf(err, "error creating pulsar producer for executor api")
-	}
-	defer apiProducer.Close()
 	authServices, err := auth.ConfigureAuth(config.Auth)
 	if err != nil {
 		return errors.WithMessage(err, "error creating auth services")
 	}
 	grpcServer := grpcCommon.CreateGrpcServer(config.Grpc.KeepaliveParams, config.Grpc.KeepaliveEnforcementPolicy, authServices, config.Grpc.Tls)
+	permissionChecker := authorization.NewPrincipalPermissionChecker(
+		config.Auth.PermissionGroupMapping,
+		config.Auth.PermissionScopeMapping,
+		config.Auth.PermissionClaimMapping,
+	)
 	defer grpcServer.GracefulStop()
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Grpc.Port))
 	if err != nil {
 		return errors.WithMessage(err, "error setting up gRPC server")
 	}
-	executorServer, err := NewExecutorApi(
-		apiProducer,
-		jobRepository,
-		executorRepository,
-		legacyExecutorRepository,
-		types.AllowedPriorities(config.Scheduling.Preemption.PriorityClasses),
-		config.Scheduling.Preemption.NodeIdLabel,
-		config.Scheduling.Preemption.PriorityClassNameOverride,
-		config.Pulsar.MaxAllowedMessageSize,
-	)
-	if err != nil {
-		return errors.WithMessage(err, "error creating executorApi")
-	}
-	executorapi.RegisterExecutorApiServer(grpcServer, executorServer)
 	services = append(services, func() error {
-		ctx.Infof("Executor api listening on %s", lis.Addr())
+		ctx.Infof("gRPC server listening on %s", lis.Addr())
 		return grpcServer.Serve(lis)
 	})
 	services = append(services, grpcCommon.CreateShutdownHandler(ctx, 5*time.Second, grpcServer))
 
-	// ////////////////////////////////////////////////////////////////////////
-	// Scheduling
-	// ////////////////////////////////////////////////////////////////////////
-	ctx.Infof("setting up scheduling loop")
+	// Leadership is exposed via the standard gRPC health service and the WhoIsLeader endpoint,
+	// such that clients can route leader-only calls to the leader.
+	grpcHealthServer := grpchealth.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, grpcHealthServer)
+	leaderHealthReporter := NewLeaderHealthReporter(leaderController, grpcHealthServer, config.Leader.HealthUpdatePeriod)
+	services = append(services, func() error { return leaderHealthReporter.Run(ctx) })
 
-	submitChecker := NewSubmitChecker(
-		30*time.Minute,
-		config.Scheduling,
-		executorRepository,
-	)
-	services = append(services, func() error {
-		return submitChecker.Run(ctx)
-	})
-	if err != nil {
-		return errors.WithMessage(err, "error creating submit checker")
+	// Shared by the executor api and the scheduler.
+	cycleMetrics := NewSchedulerMetrics(config.Metrics.Metrics, metricsRegistry)
+	var runErrorClassifier *RunErrorClassifier
+	if len(config.RunErrorClassification) > 0 {
+		runErrorClassifier, err = NewRunErrorClassifier(config.RunErrorClassification)
+		if err != nil {
+			return errors.WithMessage(err, "error creating run error classifier")
+		}
+	}
+	var nodeQuarantine *NodeQuarantine
+	if config.NodeQuarantine.Enabled {
+		nodeQuarantine, err = NewNodeQuarantine(config.NodeQuarantine, runErrorClassifier, cycleMetrics)
+		if err != nil {
+			return errors.WithMessage(err, "error creating node quarantine")
+		}
 	}
 
-	schedulingContextRepository, err := NewSchedulingContextRepository(config.Scheduling.MaxJobSchedulingContextsPerExecutor)
-	if err != nil {
-		return errors.WithMessage(err, "error creating scheduling context repository")
+	// ////////////////////////////////////////////////////////////////////////
+	// Executor Api
+	// ////////////////////////////////////////////////////////////////////////
+	// The executor api is registered immediately, but rejects requests until its dependencies are available.
+	// Observers don't serve executors.
+	catchUpState := NewCatchUpState()
+	var jobDbLeaseSnapshots *JobDbLeaseSnapshots
+	if config.JobDbLeases.Enabled && !isObserver {
+		jobDbLeaseSnapshots = NewJobDbLeaseSnapshots()
+	}
+	var executorApiMetrics *ExecutorApiMetrics
+	if config.ExecutorApiMetrics.Enabled && !isObserver {
+		executorApiMetrics = NewExecutorApiMetrics(config.ExecutorApiMetrics.KnownExecutorsRefreshInterval)
+		if err := metricsRegistry.Register(executorApiMetrics); err != nil {
+			return errors.WithStack(err)
+		}
+		services = append(services, func() error {
+			db, err := postgres.Get(ctx)
+			if err != nil {
+				return err
+			}
+			return executorApiMetrics.Run(ctx, database.NewPostgresExecutorRepository(db))
+		})
+	}
+	if !isObserver {
+		ctx.Infof("Setting up executor api")
+		executorApi := NewDependency[executorapi.ExecutorApiServer]("executor api")
+		executorApi.Start(ctx, 0, func(ctx *armadacontext.Context) (executorapi.ExecutorApiServer, error) {
+			db, err := postgres.Get(ctx)
+			if err != nil {
+				return nil, err
+			}
+			redisClient, err := redisConnection.Get(ctx)
+			if err != nil {
+				return nil, err
+			}
+			pulsarClient, err := pulsarConnection.Get(ctx)
+			if err != nil {
+				return nil, err
+			}
+			apiProducer, err := pulsarClient.CreateProducer(pulsar.ProducerOptions{
+				Name:             fmt.Sprintf("armada-executor-api-%s", uuid.NewString()),
+				CompressionType:  config.Pulsar.CompressionType,
+				CompressionLevel: config.Pulsar.CompressionLevel,
+				BatchingMaxSize:  config.Pulsar.MaxAllowedMessageSize,
+				Topic:            config.Pulsar.JobsetEventsTopic,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "error creating pulsar producer for executor api")
+			}
+			executorRepository := database.NewPostgresExecutorRepository(db)
+			executorServer, err := NewExecutorApi(
+				apiProducer,
+				database.NewPostgresJobRepository(db, int32(config.DatabaseFetchSize)),
+				executorRepository,
+				database.NewRedisExecutorRepository(redisClient, "pulsar"),
+				types.AllowedPriorities(config.Scheduling.Preemption.PriorityClasses),
+				config.Scheduling.Preemption.NodeIdLabel,
+				config.Scheduling.Preemption.PriorityClassNameOverride,
+				config.Pulsar.MaxAllowedMessageSize,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if config.LengthPrefixedNodeIds {
+				executorServer.EnableLengthPrefixedNodeIds()
+			}
+			if config.CatchUp.Enabled {
+				executorServer.EnableCatchUpBackPressure(catchUpState, config.CatchUp.RetryAfter)
+			}
+			if config.RunResourceUsage.Enabled {
+				executorServer.EnableRunResourceUsage()
+			}
+			if config.MaxLeasesPerExecutorRequest > 0 {
+				executorServer.EnableLeaseFanOutLimit(config.MaxLeasesPerExecutorRequest, cycleMetrics)
+			}
+			if nodeQuarantine != nil {
+				executorServer.EnableNodeQuarantine(nodeQuarantine)
+			}
+			if jobDbLeaseSnapshots != nil {
+				executorServer.EnableJobDbLeases(jobDbLeaseSnapshots, leaderController, config.JobDbLeases.MaxStaleness)
+			}
+			if config.RequestDeduplication.Window > 0 {
+				executorServer.EnableRequestDeduplication(config.RequestDeduplication.Window, config.RequestDeduplication.MaxEntries)
+			}
+			if len(config.Scheduling.DefaultJobTolerationsByQueue) > 0 {
+				executorServer.EnableQueueDefaultTolerations(config.Scheduling.DefaultJobTolerationsByQueue)
+			}
+			if config.ExecutorNodeDeltas.Enabled {
+				executorRepository.EnableNodeRows()
+				executorServer.EnableExecutorNodeDeltas(executorRepository, cycleMetrics)
+			}
+			if executorApiMetrics != nil {
+				executorServer.EnableRequestMetrics(executorApiMetrics)
+			}
+			return executorServer, nil
+		})
+		healthChecks.Add(executorApi)
+		executorapi.RegisterExecutorApiServer(grpcServer, NewDeferredExecutorApi(executorApi))
+		services = append(services, func() error {
+			// Errors creating the executor api are fatal.
+			_, err := executorApi.Get(ctx)
+			return err
+		})
 	}
 
+	// ////////////////////////////////////////////////////////////////////////
+	// Reporting and Admin Apis
+	// ////////////////////////////////////////////////////////////////////////
 	leaderClientConnectionProvider := NewLeaderConnectionProvider(leaderController, config.Leader)
-	schedulingReportServer := NewLeaderProxyingSchedulingReportsServer(schedulingContextRepository, leaderClientConnectionProvider)
-	schedulerobjects.RegisterSchedulerReportingServer(grpcServer, schedulingReportServer)
-
-	schedulingAlgo, err := NewFairSchedulingAlgo(
-		config.Scheduling,
-		config.MaxSchedulingDuration,
-		executorRepository,
-		queueRepository,
-		schedulingContextRepository,
-	)
+	schedulerobjects.RegisterSchedulerLeaderServer(grpcServer, NewLeaderDiscoveryServer(leaderClientConnectionProvider))
+	schedulingContextRepository, err := NewSchedulingContextRepository(config.Scheduling.MaxJobSchedulingContextsPerExecutor)
 	if err != nil {
-		return errors.WithMessage(err, "error creating scheduling algo")
+		if !config.Startup.DegradeOnOptionalDependencyFailure {
+			return errors.WithMessage(err, "error creating scheduling context repository")
+		}
+		// Scheduling reports are only used for debugging; run without them rather than failing to start.
+		logging.
+			WithStacktrace(ctx, err).
+			Warnf("Error creating scheduling context repository; scheduling reports will be unavailable")
+		schedulingContextRepository = nil
+	}
+	var waitTimeEstimator *WaitTimeEstimator
+	if config.WaitTimeEstimation.Enabled {
+		waitTimeEstimator = NewWaitTimeEstimator(config.WaitTimeEstimation)
 	}
+	var jobSetPlacementTracker *JobSetPlacementTracker
+	if config.JobSetPlacement.Enabled {
+		jobSetPlacementTracker, err = NewJobSetPlacementTracker(config.JobSetPlacement)
+		if err != nil {
+			return errors.WithMessage(err, "error creating job set placement tracker")
+		}
+	}
+	var eventRateTracker *EventRateTracker
+	if config.EventRates.Enabled {
+		eventRateTracker, err = NewEventRateTracker(config.EventRates)
+		if err != nil {
+			return errors.WithMessage(err, "error creating event rate tracker")
+		}
+	}
+	var fragmentationTracker *FragmentationTracker
+	if config.Fragmentation.Enabled {
+		fragmentationTracker = NewFragmentationTracker(config.Fragmentation)
+	}
+	var updateStalenessTracker *UpdateStalenessTracker
+	if config.UpdateStaleness.Enabled {
+		updateStalenessTracker = NewUpdateStalenessTracker()
+	}
+	if schedulingContextRepository != nil {
+		if waitTimeEstimator != nil {
+			schedulingContextRepository.EnableWaitTimeEstimates(waitTimeEstimator)
+		}
+		if updateStalenessTracker != nil {
+			schedulingContextRepository.EnableUpdateStalenessReports(updateStalenessTracker)
+		}
+		if jobSetPlacementTracker != nil {
+			schedulingContextRepository.EnableJobSetReports(jobSetPlacementTracker)
+		}
+		if eventRateTracker != nil {
+			schedulingContextRepository.EnableEventRateReports(eventRateTracker)
+		}
+		if fragmentationTracker != nil {
+			schedulingContextRepository.EnableFragmentationReports(fragmentationTracker)
+		}
+		schedulingReportServer := NewLeaderProxyingSchedulingReportsServer(schedulingContextRepository, leaderClientConnectionProvider)
+		if isObserver {
+			schedulingReportServer.ServeLocally()
+		}
+		schedulerobjects.RegisterSchedulerReportingServer(grpcServer, schedulingReportServer)
+	}
+
 	jobDb := jobdb.NewJobDb(
 		config.Scheduling.Preemption.PriorityClasses,
 		config.Scheduling.Preemption.DefaultPriorityClass,
 		config.InternedStringsCacheSize,
 	)
-	schedulerMetrics, err := metrics.New(config.SchedulerMetrics)
-	if err != nil {
-		return err
-	}
-	if err := prometheus.Register(schedulerMetrics); err != nil {
-		return errors.WithStack(err)
-	}
-	scheduler, err := NewScheduler(
-		jobDb,
-		jobRepository,
-		executorRepository,
-		schedulingAlgo,
-		leaderController,
-		pulsarPublisher,
-		submitChecker,
-		config.CyclePeriod,
-		config.SchedulePeriod,
-		config.ExecutorTimeout,
-		config.Scheduling.MaxRetries+1,
-		config.Scheduling.Preemption.NodeIdLabel,
-		NewSchedulerMetrics(config.Metrics.Metrics),
-		schedulerMetrics,
-	)
-	if err != nil {
-		return errors.WithMessage(err, "error creating scheduler")
+	if config.LazyJobSchedulingInfo {
+		jobDb.EnableLazySchedulingInfo()
+	}
+	if config.LengthPrefixedNodeIds {
+		jobDb.EnableLengthPrefixedNodeIds()
+	}
+	if config.PriorityClassCheck.Enabled && config.PriorityClassCheck.Mode == schedulerconfig.PriorityClassCheckModeFallback {
+		if err := jobDb.EnablePriorityClassFallback(config.PriorityClassCheck.FallbackPriorityClass); err != nil {
+			return err
+		}
+	}
+	if len(config.Scheduling.DefaultJobTolerationsByQueue) > 0 {
+		jobDb.EnableQueueDefaultTolerations(config.Scheduling.DefaultJobTolerationsByQueue)
+	}
+	switch config.ContradictoryRuns.Policy {
+	case schedulerconfig.ContradictoryRunPolicyPreferSucceeded:
+		jobDb.EnableContradictoryRunPolicy(jobdb.ContradictoryRunsPreferSucceeded)
+	case schedulerconfig.ContradictoryRunPolicyQuarantine:
+		jobDb.EnableContradictoryRunPolicy(jobdb.ContradictoryRunsQuarantine)
+	}
+	stateMachineMode := jobdb.StateMachineCompatibility
+	if config.JobStateMachine.Mode == schedulerconfig.JobStateMachineModeStrict {
+		stateMachineMode = jobdb.StateMachineStrict
+	}
+	var illegalTransitionObserver jobdb.IllegalTransitionObserver
+	var parameterDriftObserver jobdb.ParameterDriftObserver
+	if !config.SchedulerMetrics.Disabled {
+		jobDbMetrics := metrics.NewJobDbMetrics(config.SchedulerMetrics.JobDbCommitBreakdown)
+		if err := metricsRegistry.Register(jobDbMetrics); err != nil {
+			return err
+		}
+		jobDb.EnableCommitObserver(jobDbMetrics, config.SchedulerMetrics.JobDbCommitBreakdown)
+		illegalTransitionObserver = jobDbMetrics
+		parameterDriftObserver = jobDbMetrics
+	}
+	jobDb.EnableStateMachine(stateMachineMode, illegalTransitionObserver)
+	if config.ParameterCheckpoints.Enabled {
+		jobDb.EnableParameterCheckpoints(
+			GangIdAndCardinalityFromAnnotations,
+			config.Scheduling.MaxRetries+1,
+			config.ParameterCheckpoints.ForceRederivation,
+			parameterDriftObserver,
+		)
+	}
+	if schedulingContextRepository != nil {
+		schedulingContextRepository.EnableSchedulingOutcomeReports(jobDb)
+		schedulingContextRepository.EnableJobStateReports(jobDb)
+		schedulingContextRepository.EnableRetryReports(jobDb, config.Scheduling.MaxRetries+1, config.Scheduling.Preemption.NodeIdLabel)
+	}
+	jobNudger := NewJobNudger(jobDb, config.JobNudges)
+	executorTimeouts := NewExecutorTimeouts(config.ExecutorTimeout)
+	jobForceFailer := NewJobForceFailer(jobDb, permissionChecker)
+	jobDbRebuilder := NewJobDbRebuilder(permissionChecker)
+	executorIdlenessTracker := NewExecutorIdlenessTracker(config.ScaleHints)
+	submitCheckerInspector := NewSubmitCheckerInspector(permissionChecker)
+	// Admin requests change how jobs are scheduled, which only the leader does.
+	// Observers can't proxy them, since they don't know which replica is leader.
+	if !isObserver {
+		schedulerobjects.RegisterSchedulerAdminServer(
+			grpcServer,
+			NewLeaderProxyingSchedulerAdminServer(
+				NewSchedulerAdminServer(jobNudger, executorTimeouts, jobForceFailer, executorIdlenessTracker, submitCheckerInspector, jobDbRebuilder),
+				leaderClientConnectionProvider,
+				permissionChecker,
+			),
+		)
 	}
-	services = append(services, func() error { return scheduler.Run(ctx) })
 
 	// ////////////////////////////////////////////////////////////////////////
-	// Metrics
+	// Scheduling
 	// ////////////////////////////////////////////////////////////////////////
-	poolAssigner, err := NewPoolAssigner(config.Scheduling.ExecutorTimeout, config.Scheduling, executorRepository)
-	if err != nil {
-		return errors.WithMessage(err, "error creating pool assigner")
-	}
-	metricsCollector := NewMetricsCollector(
-		scheduler.jobDb,
-		queueRepository,
-		executorRepository,
-		poolAssigner,
-		config.Metrics.RefreshInterval,
-	)
-	if err := prometheus.Register(metricsCollector); err != nil {
-		return errors.WithStack(err)
-	}
-	services = append(services, func() error { return metricsCollector.Run(ctx) })
-	shutdownMetricServer := common.ServeMetrics(config.Metrics.Port)
-	defer shutdownMetricServer()
+	// The scheduling loop and associated metrics are started once their dependencies are available.
+	services = append(services, func() error {
+		db, err := postgres.Get(ctx)
+		if err != nil {
+			return err
+		}
+		redisClient, err := redisConnection.Get(ctx)
+		if err != nil {
+			return err
+		}
+		jobRepository := database.NewPostgresJobRepository(db, int32(config.DatabaseFetchSize))
+		if config.PriorityClassCheck.Enabled {
+			// Jobs are loaded into the jobDb only once the scheduler starts, so no job has yet been assigned a priority class.
+			if err := CheckPriorityClasses(ctx, jobRepository, config); err != nil {
+				return errors.WithMessage(err, "refusing to start")
+			}
+		}
+		executorRepository := database.NewPostgresExecutorRepository(db)
+		if config.LengthPrefixedNodeIds {
+			executorRepository.EnableLengthPrefixedNodeIds()
+		}
+		queueRepository := database.NewLegacyQueueRepository(redisClient)
+
+		var publisher Publisher = ObserverPublisher{}
+		if !isObserver {
+			pulsarClient, err := pulsarConnection.Get(ctx)
+			if err != nil {
+				return err
+			}
+			pulsarPublisher, err := NewPulsarPublisher(pulsarClient, pulsar.ProducerOptions{
+				Name:             fmt.Sprintf("armada-scheduler-%s", uuid.NewString()),
+				CompressionType:  config.Pulsar.CompressionType,
+				CompressionLevel: config.Pulsar.CompressionLevel,
+				BatchingMaxSize:  config.Pulsar.MaxAllowedMessageSize,
+				Topic:            config.Pulsar.JobsetEventsTopic,
+			}, config.PulsarSendTimeout)
+			if err != nil {
+				return errors.WithMessage(err, "error creating pulsar publisher")
+			}
+			if err := metricsRegistry.Register(pulsarPublisher); err != nil {
+				return err
+			}
+			configHash, err := SchedulingConfigHash(config.Scheduling)
+			if err != nil {
+				return err
+			}
+			pulsarPublisher.EnableProvenance(build.ReleaseVersion, build.GitCommit, configHash, config.Leader.PodName)
+			pulsarPublisher.EnableSendLimits(config.PulsarPublishing.MaxInFlightMessages, config.PulsarPublishing.MessageSendTimeout)
+			publisher = pulsarPublisher
+		}
+
+		ctx.Infof("setting up scheduling loop")
+		var executorSnapshots *ExecutorSnapshotProvider
+		if config.ExecutorSnapshots.Enabled {
+			executorSnapshots = NewExecutorSnapshotProvider(executorRepository, config.ExecutorSnapshots.RefreshInterval)
+			if err := metricsRegistry.Register(executorSnapshots); err != nil {
+				return err
+			}
+		}
+		submitChecker := NewSubmitChecker(
+			30*time.Minute,
+			config.Scheduling,
+			executorRepository,
+		)
+		if executorSnapshots != nil {
+			submitChecker.EnableExecutorSnapshots(executorSnapshots)
+			g.Go(func() error { return executorSnapshots.Run(ctx) })
+		}
+		submitCheckerInspector.SetSubmitChecker(submitChecker)
+		g.Go(func() error {
+			return submitChecker.Run(ctx)
+		})
+		schedulingAlgo, err := NewFairSchedulingAlgo(
+			config.Scheduling,
+			config.MaxSchedulingDuration,
+			executorRepository,
+			queueRepository,
+			schedulingContextRepository,
+		)
+		if err != nil {
+			return errors.WithMessage(err, "error creating scheduling algo")
+		}
+		if executorSnapshots != nil {
+			schedulingAlgo.EnableExecutorSnapshots(executorSnapshots)
+		}
+		if jobSetPlacementTracker != nil {
+			schedulingAlgo.EnableJobSetPlacementTracking(jobSetPlacementTracker)
+		}
+		if config.RunResourceUsage.Enabled {
+			schedulingAlgo.EnableUsageAwarePreemption()
+		}
+		if nodeQuarantine != nil {
+			schedulingAlgo.EnableNodeQuarantine(nodeQuarantine)
+		}
+		if config.CapacitySummary.Enabled {
+			// The metrics collector refreshes its pool assigner concurrently, so the scheduling algo needs its own.
+			capacityPoolAssigner, err := NewPoolAssigner(config.Scheduling.ExecutorTimeout, config.Scheduling, executorRepository)
+			if err != nil {
+				return errors.WithMessage(err, "error creating pool assigner")
+			}
+			if executorSnapshots != nil {
+				capacityPoolAssigner.EnableExecutorSnapshots(executorSnapshots)
+			}
+			schedulingAlgo.EnableCapacitySummary(capacityPoolAssigner)
+		}
+		if fragmentationTracker != nil {
+			if err := metricsRegistry.Register(fragmentationTracker); err != nil {
+				return err
+			}
+			schedulingAlgo.EnableFragmentationTracking(fragmentationTracker)
+		}
+		var featureGates *FeatureGate
+		if len(config.QueueFeatureGates) > 0 {
+			featureGates = NewFeatureGate(ctx, config.QueueFeatureGates)
+			if err := metricsRegistry.Register(featureGates); err != nil {
+				return err
+			}
+			schedulingAlgo.EnableFeatureGates(featureGates)
+		}
+		if foreignAllocations != nil {
+			schedulingAlgo.EnableForeignAllocations(foreignAllocations)
+		}
+		schedulerMetrics, err := metrics.New(config.SchedulerMetrics)
+		if err != nil {
+			return err
+		}
+		if err := metricsRegistry.Register(schedulerMetrics); err != nil {
+			return err
+		}
+		scheduler, err := NewScheduler(
+			jobDb,
+			jobRepository,
+			executorRepository,
+			schedulingAlgo,
+			leaderController,
+			publisher,
+			submitChecker,
+			config.CyclePeriod,
+			config.SchedulePeriod,
+			config.ExecutorTimeout,
+			config.Scheduling.MaxRetries+1,
+			config.Scheduling.Preemption.NodeIdLabel,
+			cycleMetrics,
+			schedulerMetrics,
+		)
+		if err != nil {
+			return errors.WithMessage(err, "error creating scheduler")
+		}
+		scheduler.EnableUnknownQueueHandling(config.UnknownQueues, queueRepository)
+		var cycleJitter PeriodJitter
+		if config.CycleJitter.Enabled {
+			cycleJitter = NewRandomPeriodJitter(config.CycleJitter, rand.New(rand.NewSource(rand.Int63())))
+			scheduler.EnableCycleJitter(cycleJitter)
+		}
+		if shardAssignment != nil {
+			scheduler.EnableSharding(shardAssignment, foreignAllocations)
+		}
+		if config.QueueBacklogLimits.Enabled {
+			scheduler.EnableQueueBacklogLimits(config.QueueBacklogLimits.DefaultMaxQueuedJobs, queueRepository)
+		}
+		if config.QueuePriorityCaps.Enabled {
+			scheduler.EnableQueuePriorityCaps(config.QueuePriorityCaps, queueRepository)
+		}
+		if config.QueueQuarantine.Enabled {
+			scheduler.EnableQueueQuarantine(config.QueueQuarantine)
+		}
+		if config.RunErrorBackfill.Enabled {
+			scheduler.EnableRunErrorBackfill(config.RunErrorBackfill.MaxPendingRuns, config.RunErrorBackfill.Ttl)
+		}
+		if runErrorClassifier != nil {
+			scheduler.EnableRunErrorClassification(runErrorClassifier)
+		}
+		if nodeQuarantine != nil {
+			scheduler.EnableNodeQuarantine(nodeQuarantine)
+		}
+		if jobDbLeaseSnapshots != nil {
+			scheduler.EnableJobDbLeaseSnapshots(jobDbLeaseSnapshots)
+		}
+		if config.CancellationEnforcement.Enabled {
+			scheduler.EnableCancellationEnforcement(config.CancellationEnforcement.EscalateAfter, config.CancellationEnforcement.ForceFailAfter)
+		}
+		if config.MaxConsecutiveTransientCycleFailures > 0 {
+			scheduler.EnableCycleHealthCheck(config.MaxConsecutiveTransientCycleFailures)
+		}
+		if updateStalenessTracker != nil {
+			scheduler.EnableUpdateStalenessMonitoring(
+				updateStalenessTracker,
+				config.UpdateStaleness.SamplePeriod,
+				config.UpdateStaleness.MaxStaleness,
+				config.UpdateStaleness.FailReadiness,
+			)
+		}
+		if config.SerialRegression.Enabled {
+			scheduler.EnableSerialRegressionDetection(config.SerialRegression)
+		}
+		if config.MaxConsecutiveTransientCycleFailures > 0 ||
+			(updateStalenessTracker != nil && config.UpdateStaleness.FailReadiness) ||
+			config.SerialRegression.Enabled {
+			healthChecks.Add(scheduler)
+		}
+		if config.CatchUp.Enabled {
+			scheduler.EnableCatchUpBackPressure(catchUpState, config.CatchUp.MaxSerialLag)
+		}
+		if waitTimeEstimator != nil {
+			scheduler.EnableWaitTimeEstimation(waitTimeEstimator)
+		}
+		scheduler.EnableJobNudges(jobNudger)
+		scheduler.EnableJobForceFailure(jobForceFailer)
+		scheduler.EnableJobDbRebuilds(jobDbRebuilder)
+		scheduler.EnableExecutorTimeoutOverrides(executorTimeouts)
+		if featureGates != nil {
+			scheduler.EnableFeatureGates(featureGates)
+		}
+		if burstCredits := schedulingAlgo.BurstCredits(); burstCredits != nil {
+			scheduler.EnableBurstCreditPersistence(burstCredits, jobRepository)
+		}
+		if config.ShadowScheduling {
+			if err := scheduler.EnableShadowScheduling(); err != nil {
+				return err
+			}
+		}
+		if jobSetPlacementTracker != nil {
+			scheduler.EnableJobSetPlacementTracking(jobSetPlacementTracker)
+		}
+		if config.RefetchOnSchedulingInfoConflict {
+			scheduler.EnableSchedulingInfoConflictRefetch()
+		}
+		if config.RunUpdateQuarantine.Enabled {
+			scheduler.EnableRunUpdateQuarantine(NewRunUpdateQuarantine(config.RunUpdateQuarantine, cycleMetrics))
+		}
+		if config.FailJobsExceedingLargestNode {
+			scheduler.EnableOversizedJobFailure(submitChecker, config.LargestNodeStabilisationPeriod)
+		}
+		if config.UrgentScheduling.Enabled {
+			scheduler.EnableUrgentScheduling(schedulingAlgo, config.UrgentScheduling.PriorityClasses)
+		}
+		if config.RetryUnacknowledgedAtMostOnceJobs {
+			scheduler.EnableAtMostOnceSafeRetry()
+		}
+		if config.RunResourceUsage.Enabled {
+			scheduler.EnableRunResourceUsage()
+		}
+		if config.PublishJobValidatedEvents {
+			scheduler.EnableJobValidatedEvents()
+		}
+		if config.ExecutorClockSkewWarningThreshold > 0 {
+			scheduler.EnableExecutorClockSkewWarnings(config.ExecutorClockSkewWarningThreshold)
+		}
+		if config.EventConsistency.Enabled {
+			scheduler.EnableEventConsistencyCheck(config.EventConsistency)
+		}
+		if config.JobDbWriteGuard.Enabled {
+			scheduler.EnableJobDbWriteGuard()
+		}
+		if config.RetryExhaustionNotifications.Enabled {
+			retryExhaustionNotifier, err := NewRetryExhaustionNotifier(config.RetryExhaustionNotifications)
+			if err != nil {
+				return errors.WithMessage(err, "error creating retry exhaustion notifier")
+			}
+			g.Go(func() error { return retryExhaustionNotifier.Run(ctx) })
+			scheduler.EnableRetryExhaustionNotifications(retryExhaustionNotifier)
+		}
+		if schedulingContextRepository != nil {
+			scheduler.EnableLastRunErrorTracking()
+		}
+		if config.RunAnalytics.Enabled {
+			runAnalyticsExporter, err := NewRunAnalyticsExporter(config.RunAnalytics)
+			if err != nil {
+				return errors.WithMessage(err, "error creating run analytics exporter")
+			}
+			if err := metricsRegistry.Register(runAnalyticsExporter); err != nil {
+				return err
+			}
+			g.Go(func() error { return runAnalyticsExporter.Run(ctx) })
+			scheduler.AddStateTransitionListener(runAnalyticsExporter)
+		}
+		if eventRateTracker != nil {
+			scheduler.AddStateTransitionListener(eventRateTracker)
+		}
+		if config.WarningCoalescing.Window > 0 {
+			warningCoalescer := logging.NewWarningCoalescer(config.WarningCoalescing.Window, config.WarningCoalescing.MaxKeys)
+			scheduler.EnableWarningCoalescing(warningCoalescer)
+			schedulingAlgo.EnableWarningCoalescing(warningCoalescer)
+		}
+		if config.ScaleHints.Enabled {
+			if err := metricsRegistry.Register(executorIdlenessTracker); err != nil {
+				return err
+			}
+			scheduler.EnableScaleHints(executorIdlenessTracker, submitChecker)
+		}
+		if config.ConfigReload.Enabled {
+			configReloader := NewConfigReloader(config, loadConfig)
+			if err := metricsRegistry.Register(configReloader); err != nil {
+				return err
+			}
+			configReloader.EnablePriorityClassCheck(jobRepository)
+			g.Go(func() error { return configReloader.Run(ctx, configPaths, config.ConfigReload.PollPeriod) })
+			scheduler.EnableConfigReload(configReloader)
+		}
+
+		poolAssigner, err := NewPoolAssigner(config.Scheduling.ExecutorTimeout, config.Scheduling, executorRepository)
+		if err != nil {
+			return errors.WithMessage(err, "error creating pool assigner")
+		}
+		if executorSnapshots != nil {
+			poolAssigner.EnableExecutorSnapshots(executorSnapshots)
+		}
+		metricsCollector := NewMetricsCollector(
+			scheduler.jobDb,
+			queueRepository,
+			executorRepository,
+			poolAssigner,
+			config.Metrics.RefreshInterval,
+		)
+		if eventRateTracker != nil {
+			metricsCollector.EnableEventRates(eventRateTracker)
+		}
+		if cycleJitter != nil {
+			metricsCollector.EnableRefreshJitter(cycleJitter)
+		}
+		if err := metricsRegistry.Register(metricsCollector); err != nil {
+			return err
+		}
+		g.Go(func() error { return metricsCollector.Run(ctx) })
+		return scheduler.Run(ctx)
+	})
 
 	// start all services
 	for _, service := range services {
@@ -259,12 +809,13 @@ func Run(config schedulerconfig.Configuration) error {
 	}
 
 	// Mark startup as complete, will allow the health check to return healthy
+	// once all dependencies are available.
 	startupCompleteCheck.MarkComplete()
 
 	return g.Wait()
 }
 
-func createLeaderController(ctx *armadacontext.Context, config schedulerconfig.LeaderConfig) (LeaderController, error) {
+func createLeaderController(ctx *armadacontext.Context, config schedulerconfig.LeaderConfig, registerer prometheus.Registerer) (LeaderController, error) {
 	switch mode := strings.ToLower(config.Mode); mode {
 	case "standalone":
 		ctx.Infof("Scheduler will run in standalone mode")
@@ -282,7 +833,7 @@ func createLeaderController(ctx *armadacontext.Context, config schedulerconfig.L
 		leaderController := NewKubernetesLeaderController(config, clientSet.CoordinationV1())
 		leaderStatusMetrics := NewLeaderStatusMetricsCollector(config.PodName)
 		leaderController.RegisterListener(leaderStatusMetrics)
-		prometheus.MustRegister(leaderStatusMetrics)
+		registerer.MustRegister(leaderStatusMetrics)
 		return leaderController, nil
 	default:
 		return nil, errors.Errorf("%s is not a value leader mode", config.Mode)
@@ -0,0 +1,5014 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: internal/scheduler/schedulerobjects/admin.proto
+
+package schedulerobjects
+
+import (
+	context "context"
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type NudgeJobRequest struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+}
+
+func (m *NudgeJobRequest) Reset()         { *m = NudgeJobRequest{} }
+func (m *NudgeJobRequest) String() string { return proto.CompactTextString(m) }
+func (*NudgeJobRequest) ProtoMessage()    {}
+func (*NudgeJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{0}
+}
+func (m *NudgeJobRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NudgeJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NudgeJobRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NudgeJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NudgeJobRequest.Merge(m, src)
+}
+func (m *NudgeJobRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *NudgeJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NudgeJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NudgeJobRequest proto.InternalMessageInfo
+
+func (m *NudgeJobRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type NudgeJobResponse struct {
+	JobId  string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	Queue  string `protobuf:"bytes,2,opt,name=queue,proto3" json:"queue,omitempty"`
+	JobSet string `protobuf:"bytes,3,opt,name=job_set,json=jobSet,proto3" json:"jobSet,omitempty"`
+	// State of the job in the scheduler's jobDb, e.g., "Queued" or "Running".
+	State    string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	Priority uint32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	// True if the job is queued and will be considered first within its priority band by the next scheduling round.
+	Nudged bool `protobuf:"varint,6,opt,name=nudged,proto3" json:"nudged,omitempty"`
+}
+
+func (m *NudgeJobResponse) Reset()         { *m = NudgeJobResponse{} }
+func (m *NudgeJobResponse) String() string { return proto.CompactTextString(m) }
+func (*NudgeJobResponse) ProtoMessage()    {}
+func (*NudgeJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{1}
+}
+func (m *NudgeJobResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NudgeJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NudgeJobResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NudgeJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NudgeJobResponse.Merge(m, src)
+}
+func (m *NudgeJobResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *NudgeJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NudgeJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NudgeJobResponse proto.InternalMessageInfo
+
+func (m *NudgeJobResponse) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *NudgeJobResponse) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *NudgeJobResponse) GetJobSet() string {
+	if m != nil {
+		return m.JobSet
+	}
+	return ""
+}
+
+func (m *NudgeJobResponse) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *NudgeJobResponse) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *NudgeJobResponse) GetNudged() bool {
+	if m != nil {
+		return m.Nudged
+	}
+	return false
+}
+
+type SetExecutorTimeoutRequest struct {
+	ExecutorId string `protobuf:"bytes,1,opt,name=executor_id,json=executorId,proto3" json:"executorId,omitempty"`
+	// How long to wait for a heartbeat from the executor before considering it stale.
+	// If zero, any existing override is removed.
+	Timeout time.Duration `protobuf:"bytes,2,opt,name=timeout,proto3,stdduration" json:"timeout"`
+}
+
+func (m *SetExecutorTimeoutRequest) Reset()         { *m = SetExecutorTimeoutRequest{} }
+func (m *SetExecutorTimeoutRequest) String() string { return proto.CompactTextString(m) }
+func (*SetExecutorTimeoutRequest) ProtoMessage()    {}
+func (*SetExecutorTimeoutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{2}
+}
+func (m *SetExecutorTimeoutRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetExecutorTimeoutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetExecutorTimeoutRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetExecutorTimeoutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetExecutorTimeoutRequest.Merge(m, src)
+}
+func (m *SetExecutorTimeoutRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetExecutorTimeoutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetExecutorTimeoutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetExecutorTimeoutRequest proto.InternalMessageInfo
+
+func (m *SetExecutorTimeoutRequest) GetExecutorId() string {
+	if m != nil {
+		return m.ExecutorId
+	}
+	return ""
+}
+
+func (m *SetExecutorTimeoutRequest) GetTimeout() time.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type SetExecutorTimeoutResponse struct {
+	ExecutorId string `protobuf:"bytes,1,opt,name=executor_id,json=executorId,proto3" json:"executorId,omitempty"`
+	// Override in effect for the executor after the request has been applied, or zero if there is none.
+	Timeout time.Duration `protobuf:"bytes,2,opt,name=timeout,proto3,stdduration" json:"timeout"`
+}
+
+func (m *SetExecutorTimeoutResponse) Reset()         { *m = SetExecutorTimeoutResponse{} }
+func (m *SetExecutorTimeoutResponse) String() string { return proto.CompactTextString(m) }
+func (*SetExecutorTimeoutResponse) ProtoMessage()    {}
+func (*SetExecutorTimeoutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{3}
+}
+func (m *SetExecutorTimeoutResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetExecutorTimeoutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetExecutorTimeoutResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetExecutorTimeoutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetExecutorTimeoutResponse.Merge(m, src)
+}
+func (m *SetExecutorTimeoutResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetExecutorTimeoutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetExecutorTimeoutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetExecutorTimeoutResponse proto.InternalMessageInfo
+
+func (m *SetExecutorTimeoutResponse) GetExecutorId() string {
+	if m != nil {
+		return m.ExecutorId
+	}
+	return ""
+}
+
+func (m *SetExecutorTimeoutResponse) GetTimeout() time.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type ForceFailJobRequest struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	// Why the job is being failed; included in the errors published for the job.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *ForceFailJobRequest) Reset()         { *m = ForceFailJobRequest{} }
+func (m *ForceFailJobRequest) String() string { return proto.CompactTextString(m) }
+func (*ForceFailJobRequest) ProtoMessage()    {}
+func (*ForceFailJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{4}
+}
+func (m *ForceFailJobRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ForceFailJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ForceFailJobRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ForceFailJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceFailJobRequest.Merge(m, src)
+}
+func (m *ForceFailJobRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ForceFailJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceFailJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceFailJobRequest proto.InternalMessageInfo
+
+func (m *ForceFailJobRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ForceFailJobRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ForceFailJobResponse struct {
+	JobId  string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	Queue  string `protobuf:"bytes,2,opt,name=queue,proto3" json:"queue,omitempty"`
+	JobSet string `protobuf:"bytes,3,opt,name=job_set,json=jobSet,proto3" json:"jobSet,omitempty"`
+	// State of the job in the scheduler's jobDb when the request was received, e.g., "Queued" or "Running".
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	// Reason the job will be failed with. If the job was already pending being failed, this is the original reason.
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *ForceFailJobResponse) Reset()         { *m = ForceFailJobResponse{} }
+func (m *ForceFailJobResponse) String() string { return proto.CompactTextString(m) }
+func (*ForceFailJobResponse) ProtoMessage()    {}
+func (*ForceFailJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{5}
+}
+func (m *ForceFailJobResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ForceFailJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ForceFailJobResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ForceFailJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceFailJobResponse.Merge(m, src)
+}
+func (m *ForceFailJobResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ForceFailJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceFailJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceFailJobResponse proto.InternalMessageInfo
+
+func (m *ForceFailJobResponse) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *ForceFailJobResponse) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *ForceFailJobResponse) GetJobSet() string {
+	if m != nil {
+		return m.JobSet
+	}
+	return ""
+}
+
+func (m *ForceFailJobResponse) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ForceFailJobResponse) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ScaleHintsRequest struct {
+	// If non-empty, only executors in this pool are considered.
+	Pool string `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+}
+
+func (m *ScaleHintsRequest) Reset()         { *m = ScaleHintsRequest{} }
+func (m *ScaleHintsRequest) String() string { return proto.CompactTextString(m) }
+func (*ScaleHintsRequest) ProtoMessage()    {}
+func (*ScaleHintsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{6}
+}
+func (m *ScaleHintsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScaleHintsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScaleHintsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScaleHintsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScaleHintsRequest.Merge(m, src)
+}
+func (m *ScaleHintsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScaleHintsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScaleHintsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScaleHintsRequest proto.InternalMessageInfo
+
+func (m *ScaleHintsRequest) GetPool() string {
+	if m != nil {
+		return m.Pool
+	}
+	return ""
+}
+
+type ExecutorScaleHint struct {
+	ExecutorId string `protobuf:"bytes,1,opt,name=executor_id,json=executorId,proto3" json:"executorId,omitempty"`
+	Pool       string `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	// Number of consecutive cycles the executor has had no active runs and no queued jobs only it could run.
+	IdleCycles uint32 `protobuf:"varint,3,opt,name=idle_cycles,json=idleCycles,proto3" json:"idleCycles,omitempty"`
+	// Total resources of the nodes of the executor.
+	TotalResources ResourceList `protobuf:"bytes,4,opt,name=total_resources,json=totalResources,proto3" json:"totalResources"`
+	// Resources requested by queued jobs that could run on the executor or elsewhere, up to the total resources of the executor.
+	// Shrinking the executor below this may delay those jobs.
+	RetainedResources ResourceList `protobuf:"bytes,5,opt,name=retained_resources,json=retainedResources,proto3" json:"retainedResources"`
+}
+
+func (m *ExecutorScaleHint) Reset()         { *m = ExecutorScaleHint{} }
+func (m *ExecutorScaleHint) String() string { return proto.CompactTextString(m) }
+func (*ExecutorScaleHint) ProtoMessage()    {}
+func (*ExecutorScaleHint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{7}
+}
+func (m *ExecutorScaleHint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExecutorScaleHint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExecutorScaleHint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExecutorScaleHint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutorScaleHint.Merge(m, src)
+}
+func (m *ExecutorScaleHint) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExecutorScaleHint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutorScaleHint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutorScaleHint proto.InternalMessageInfo
+
+func (m *ExecutorScaleHint) GetExecutorId() string {
+	if m != nil {
+		return m.ExecutorId
+	}
+	return ""
+}
+
+func (m *ExecutorScaleHint) GetPool() string {
+	if m != nil {
+		return m.Pool
+	}
+	return ""
+}
+
+func (m *ExecutorScaleHint) GetIdleCycles() uint32 {
+	if m != nil {
+		return m.IdleCycles
+	}
+	return 0
+}
+
+func (m *ExecutorScaleHint) GetTotalResources() ResourceList {
+	if m != nil {
+		return m.TotalResources
+	}
+	return ResourceList{}
+}
+
+func (m *ExecutorScaleHint) GetRetainedResources() ResourceList {
+	if m != nil {
+		return m.RetainedResources
+	}
+	return ResourceList{}
+}
+
+type ScaleHintsResponse struct {
+	// Executors the scheduler considers safe to shrink, sorted by executor id.
+	Executors []*ExecutorScaleHint `protobuf:"bytes,1,rep,name=executors,proto3" json:"executors,omitempty"`
+}
+
+func (m *ScaleHintsResponse) Reset()         { *m = ScaleHintsResponse{} }
+func (m *ScaleHintsResponse) String() string { return proto.CompactTextString(m) }
+func (*ScaleHintsResponse) ProtoMessage()    {}
+func (*ScaleHintsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{8}
+}
+func (m *ScaleHintsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScaleHintsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScaleHintsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScaleHintsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScaleHintsResponse.Merge(m, src)
+}
+func (m *ScaleHintsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScaleHintsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScaleHintsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScaleHintsResponse proto.InternalMessageInfo
+
+func (m *ScaleHintsResponse) GetExecutors() []*ExecutorScaleHint {
+	if m != nil {
+		return m.Executors
+	}
+	return nil
+}
+
+type InspectSubmitCheckerRequest struct {
+	// If true, the verdicts cached by the submit checker are flushed before its state is returned.
+	FlushCache bool `protobuf:"varint,1,opt,name=flush_cache,json=flushCache,proto3" json:"flushCache,omitempty"`
+	// Maximum number of cache entries to return. If zero, 100 are returned.
+	MaxCacheEntries uint32 `protobuf:"varint,2,opt,name=max_cache_entries,json=maxCacheEntries,proto3" json:"maxCacheEntries,omitempty"`
+}
+
+func (m *InspectSubmitCheckerRequest) Reset()         { *m = InspectSubmitCheckerRequest{} }
+func (m *InspectSubmitCheckerRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectSubmitCheckerRequest) ProtoMessage()    {}
+func (*InspectSubmitCheckerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{9}
+}
+func (m *InspectSubmitCheckerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InspectSubmitCheckerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InspectSubmitCheckerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InspectSubmitCheckerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectSubmitCheckerRequest.Merge(m, src)
+}
+func (m *InspectSubmitCheckerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *InspectSubmitCheckerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectSubmitCheckerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectSubmitCheckerRequest proto.InternalMessageInfo
+
+func (m *InspectSubmitCheckerRequest) GetFlushCache() bool {
+	if m != nil {
+		return m.FlushCache
+	}
+	return false
+}
+
+func (m *InspectSubmitCheckerRequest) GetMaxCacheEntries() uint32 {
+	if m != nil {
+		return m.MaxCacheEntries
+	}
+	return 0
+}
+
+type SubmitCheckerNode struct {
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Resources of the node with the overcommit factors of its pool applied, as used to check jobs.
+	Allocatable ResourceList      `protobuf:"bytes,3,opt,name=allocatable,proto3" json:"allocatable"`
+	Taints      []v1.Taint        `protobuf:"bytes,4,rep,name=taints,proto3" json:"taints"`
+	Labels      map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *SubmitCheckerNode) Reset()         { *m = SubmitCheckerNode{} }
+func (m *SubmitCheckerNode) String() string { return proto.CompactTextString(m) }
+func (*SubmitCheckerNode) ProtoMessage()    {}
+func (*SubmitCheckerNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{10}
+}
+func (m *SubmitCheckerNode) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubmitCheckerNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubmitCheckerNode.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubmitCheckerNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitCheckerNode.Merge(m, src)
+}
+func (m *SubmitCheckerNode) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubmitCheckerNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitCheckerNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitCheckerNode proto.InternalMessageInfo
+
+func (m *SubmitCheckerNode) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SubmitCheckerNode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SubmitCheckerNode) GetAllocatable() ResourceList {
+	if m != nil {
+		return m.Allocatable
+	}
+	return ResourceList{}
+}
+
+func (m *SubmitCheckerNode) GetTaints() []v1.Taint {
+	if m != nil {
+		return m.Taints
+	}
+	return nil
+}
+
+func (m *SubmitCheckerNode) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type SubmitCheckerExecutor struct {
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Pool string `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	// Time of the most recent heartbeat of the executor, according to the scheduler's clock.
+	LastUpdateTime time.Time `protobuf:"bytes,3,opt,name=last_update_time,json=lastUpdateTime,proto3,stdtime" json:"lastUpdateTime"`
+	// True if the executor is considered stale, in which case jobs aren't checked against it.
+	Stale bool                 `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	Nodes []*SubmitCheckerNode `protobuf:"bytes,5,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (m *SubmitCheckerExecutor) Reset()         { *m = SubmitCheckerExecutor{} }
+func (m *SubmitCheckerExecutor) String() string { return proto.CompactTextString(m) }
+func (*SubmitCheckerExecutor) ProtoMessage()    {}
+func (*SubmitCheckerExecutor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{11}
+}
+func (m *SubmitCheckerExecutor) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubmitCheckerExecutor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubmitCheckerExecutor.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubmitCheckerExecutor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitCheckerExecutor.Merge(m, src)
+}
+func (m *SubmitCheckerExecutor) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubmitCheckerExecutor) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitCheckerExecutor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitCheckerExecutor proto.InternalMessageInfo
+
+func (m *SubmitCheckerExecutor) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SubmitCheckerExecutor) GetPool() string {
+	if m != nil {
+		return m.Pool
+	}
+	return ""
+}
+
+func (m *SubmitCheckerExecutor) GetLastUpdateTime() time.Time {
+	if m != nil {
+		return m.LastUpdateTime
+	}
+	return time.Time{}
+}
+
+func (m *SubmitCheckerExecutor) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+func (m *SubmitCheckerExecutor) GetNodes() []*SubmitCheckerNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type SubmitCheckerCacheEntry struct {
+	// Hex-encoded scheduling key, i.e., hash of the scheduling requirements, of the jobs the verdict applies to.
+	SchedulingKey string `protobuf:"bytes,1,opt,name=scheduling_key,json=schedulingKey,proto3" json:"schedulingKey,omitempty"`
+	Schedulable   bool   `protobuf:"varint,2,opt,name=schedulable,proto3" json:"schedulable,omitempty"`
+	// Why jobs with this scheduling key are unschedulable; empty if they're schedulable.
+	Reason  string    `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Created time.Time `protobuf:"bytes,4,opt,name=created,proto3,stdtime" json:"created"`
+}
+
+func (m *SubmitCheckerCacheEntry) Reset()         { *m = SubmitCheckerCacheEntry{} }
+func (m *SubmitCheckerCacheEntry) String() string { return proto.CompactTextString(m) }
+func (*SubmitCheckerCacheEntry) ProtoMessage()    {}
+func (*SubmitCheckerCacheEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{12}
+}
+func (m *SubmitCheckerCacheEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubmitCheckerCacheEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubmitCheckerCacheEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubmitCheckerCacheEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitCheckerCacheEntry.Merge(m, src)
+}
+func (m *SubmitCheckerCacheEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubmitCheckerCacheEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitCheckerCacheEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitCheckerCacheEntry proto.InternalMessageInfo
+
+func (m *SubmitCheckerCacheEntry) GetSchedulingKey() string {
+	if m != nil {
+		return m.SchedulingKey
+	}
+	return ""
+}
+
+func (m *SubmitCheckerCacheEntry) GetSchedulable() bool {
+	if m != nil {
+		return m.Schedulable
+	}
+	return false
+}
+
+func (m *SubmitCheckerCacheEntry) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *SubmitCheckerCacheEntry) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
+	}
+	return time.Time{}
+}
+
+type InspectSubmitCheckerResponse struct {
+	// Version of the executor snapshot the submit checker was last updated from,
+	// or zero if it fetches executors on its own rather than from a shared snapshot.
+	SnapshotVersion uint64 `protobuf:"varint,1,opt,name=snapshot_version,json=snapshotVersion,proto3" json:"snapshotVersion,omitempty"`
+	// Time since executors were last updated, or zero if they haven't been updated yet.
+	SnapshotAge time.Duration `protobuf:"bytes,2,opt,name=snapshot_age,json=snapshotAge,proto3,stdduration" json:"snapshotAge"`
+	// Executors as seen by the submit checker, sorted by id.
+	Executors []*SubmitCheckerExecutor `protobuf:"bytes,3,rep,name=executors,proto3" json:"executors,omitempty"`
+	// Most recently cached verdicts, most recent first.
+	CacheEntries []*SubmitCheckerCacheEntry `protobuf:"bytes,4,rep,name=cache_entries,json=cacheEntries,proto3" json:"cacheEntries,omitempty"`
+	// Total number of cached verdicts, including those not returned.
+	NumCacheEntries uint32 `protobuf:"varint,5,opt,name=num_cache_entries,json=numCacheEntries,proto3" json:"numCacheEntries,omitempty"`
+	// Number of cached verdicts flushed by the request.
+	NumFlushedCacheEntries uint32 `protobuf:"varint,6,opt,name=num_flushed_cache_entries,json=numFlushedCacheEntries,proto3" json:"numFlushedCacheEntries,omitempty"`
+}
+
+func (m *InspectSubmitCheckerResponse) Reset()         { *m = InspectSubmitCheckerResponse{} }
+func (m *InspectSubmitCheckerResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectSubmitCheckerResponse) ProtoMessage()    {}
+func (*InspectSubmitCheckerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{13}
+}
+func (m *InspectSubmitCheckerResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InspectSubmitCheckerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InspectSubmitCheckerResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InspectSubmitCheckerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectSubmitCheckerResponse.Merge(m, src)
+}
+func (m *InspectSubmitCheckerResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *InspectSubmitCheckerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectSubmitCheckerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectSubmitCheckerResponse proto.InternalMessageInfo
+
+func (m *InspectSubmitCheckerResponse) GetSnapshotVersion() uint64 {
+	if m != nil {
+		return m.SnapshotVersion
+	}
+	return 0
+}
+
+func (m *InspectSubmitCheckerResponse) GetSnapshotAge() time.Duration {
+	if m != nil {
+		return m.SnapshotAge
+	}
+	return 0
+}
+
+func (m *InspectSubmitCheckerResponse) GetExecutors() []*SubmitCheckerExecutor {
+	if m != nil {
+		return m.Executors
+	}
+	return nil
+}
+
+func (m *InspectSubmitCheckerResponse) GetCacheEntries() []*SubmitCheckerCacheEntry {
+	if m != nil {
+		return m.CacheEntries
+	}
+	return nil
+}
+
+func (m *InspectSubmitCheckerResponse) GetNumCacheEntries() uint32 {
+	if m != nil {
+		return m.NumCacheEntries
+	}
+	return 0
+}
+
+func (m *InspectSubmitCheckerResponse) GetNumFlushedCacheEntries() uint32 {
+	if m != nil {
+		return m.NumFlushedCacheEntries
+	}
+	return 0
+}
+
+type RebuildJobDbRequest struct {
+	// Why the jobDb is being rebuilt; logged together with the principal that made the request.
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *RebuildJobDbRequest) Reset()         { *m = RebuildJobDbRequest{} }
+func (m *RebuildJobDbRequest) String() string { return proto.CompactTextString(m) }
+func (*RebuildJobDbRequest) ProtoMessage()    {}
+func (*RebuildJobDbRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{14}
+}
+func (m *RebuildJobDbRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RebuildJobDbRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RebuildJobDbRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RebuildJobDbRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildJobDbRequest.Merge(m, src)
+}
+func (m *RebuildJobDbRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RebuildJobDbRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildJobDbRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildJobDbRequest proto.InternalMessageInfo
+
+func (m *RebuildJobDbRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type RebuildJobDbResponse struct {
+	// True if a rebuild was already in progress, in which case the request has no further effect.
+	AlreadyInProgress bool `protobuf:"varint,1,opt,name=already_in_progress,json=alreadyInProgress,proto3" json:"alreadyInProgress,omitempty"`
+	// Fraction of the job repository replayed by the rebuild in progress, between zero and one.
+	Progress float64 `protobuf:"fixed64,2,opt,name=progress,proto3" json:"progress,omitempty"`
+}
+
+func (m *RebuildJobDbResponse) Reset()         { *m = RebuildJobDbResponse{} }
+func (m *RebuildJobDbResponse) String() string { return proto.CompactTextString(m) }
+func (*RebuildJobDbResponse) ProtoMessage()    {}
+func (*RebuildJobDbResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91a1ae42cd46fe7f, []int{15}
+}
+func (m *RebuildJobDbResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RebuildJobDbResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RebuildJobDbResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RebuildJobDbResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildJobDbResponse.Merge(m, src)
+}
+func (m *RebuildJobDbResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RebuildJobDbResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildJobDbResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildJobDbResponse proto.InternalMessageInfo
+
+func (m *RebuildJobDbResponse) GetAlreadyInProgress() bool {
+	if m != nil {
+		return m.AlreadyInProgress
+	}
+	return false
+}
+
+func (m *RebuildJobDbResponse) GetProgress() float64 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*NudgeJobRequest)(nil), "schedulerobjects.NudgeJobRequest")
+	proto.RegisterType((*NudgeJobResponse)(nil), "schedulerobjects.NudgeJobResponse")
+	proto.RegisterType((*SetExecutorTimeoutRequest)(nil), "schedulerobjects.SetExecutorTimeoutRequest")
+	proto.RegisterType((*SetExecutorTimeoutResponse)(nil), "schedulerobjects.SetExecutorTimeoutResponse")
+	proto.RegisterType((*ForceFailJobRequest)(nil), "schedulerobjects.ForceFailJobRequest")
+	proto.RegisterType((*ForceFailJobResponse)(nil), "schedulerobjects.ForceFailJobResponse")
+	proto.RegisterType((*ScaleHintsRequest)(nil), "schedulerobjects.ScaleHintsRequest")
+	proto.RegisterType((*ExecutorScaleHint)(nil), "schedulerobjects.ExecutorScaleHint")
+	proto.RegisterType((*ScaleHintsResponse)(nil), "schedulerobjects.ScaleHintsResponse")
+	proto.RegisterType((*InspectSubmitCheckerRequest)(nil), "schedulerobjects.InspectSubmitCheckerRequest")
+	proto.RegisterType((*SubmitCheckerNode)(nil), "schedulerobjects.SubmitCheckerNode")
+	proto.RegisterMapType((map[string]string)(nil), "schedulerobjects.SubmitCheckerNode.LabelsEntry")
+	proto.RegisterType((*SubmitCheckerExecutor)(nil), "schedulerobjects.SubmitCheckerExecutor")
+	proto.RegisterType((*SubmitCheckerCacheEntry)(nil), "schedulerobjects.SubmitCheckerCacheEntry")
+	proto.RegisterType((*InspectSubmitCheckerResponse)(nil), "schedulerobjects.InspectSubmitCheckerResponse")
+	proto.RegisterType((*RebuildJobDbRequest)(nil), "schedulerobjects.RebuildJobDbRequest")
+	proto.RegisterType((*RebuildJobDbResponse)(nil), "schedulerobjects.RebuildJobDbResponse")
+}
+
+func init() {
+	proto.RegisterFile("internal/scheduler/schedulerobjects/admin.proto", fileDescriptor_91a1ae42cd46fe7f)
+}
+
+var fileDescriptor_91a1ae42cd46fe7f = []byte{
+	// 1476 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0xcd, 0x6f, 0xd4, 0x46,
+	0x1b, 0x8f, 0x77, 0x93, 0x25, 0x4c, 0xbe, 0x27, 0x21, 0xd9, 0x2c, 0xef, 0xbb, 0xce, 0x6b, 0x78,
+	0x79, 0xc3, 0x5b, 0xd8, 0x15, 0xe9, 0x85, 0x82, 0x7a, 0x60, 0x03, 0x88, 0x50, 0x44, 0xdb, 0x0d,
+	0xb4, 0x12, 0x12, 0x75, 0x67, 0xed, 0x87, 0x8d, 0x13, 0xdb, 0x63, 0xec, 0x71, 0x44, 0xfe, 0x0b,
+	0xa4, 0x5e, 0x2a, 0xf5, 0xd2, 0xaa, 0xea, 0xdf, 0x50, 0xf5, 0xd2, 0x33, 0x47, 0x8e, 0x3d, 0xb9,
+	0x55, 0xb8, 0xf9, 0xde, 0x5b, 0x0f, 0xd5, 0x8c, 0xc7, 0xeb, 0xd9, 0x0f, 0x92, 0x6d, 0x7b, 0x69,
+	0x4f, 0xc9, 0xfc, 0x9e, 0x8f, 0x99, 0x79, 0x9e, 0x9f, 0x7f, 0x33, 0xb3, 0xa8, 0xe9, 0xf8, 0x0c,
+	0x42, 0x9f, 0xb8, 0xcd, 0xc8, 0xda, 0x03, 0x3b, 0x76, 0x21, 0x2c, 0xfe, 0xa3, 0x9d, 0x7d, 0xb0,
+	0x58, 0xd4, 0x24, 0xb6, 0xe7, 0xf8, 0x8d, 0x20, 0xa4, 0x8c, 0xe2, 0xc5, 0x41, 0x6b, 0xad, 0xde,
+	0xa5, 0xb4, 0xeb, 0x42, 0x53, 0xd8, 0x3b, 0xf1, 0xb3, 0xa6, 0x1d, 0x87, 0x84, 0x39, 0x54, 0x46,
+	0xd4, 0xf4, 0x41, 0x3b, 0x73, 0x3c, 0x88, 0x18, 0xf1, 0x02, 0xe9, 0x60, 0x1c, 0x5c, 0x8f, 0x1a,
+	0x0e, 0x6d, 0x92, 0xc0, 0x69, 0x5a, 0x34, 0x84, 0xe6, 0xe1, 0xb5, 0x66, 0x17, 0x7c, 0x08, 0x09,
+	0x03, 0x5b, 0xfa, 0x5c, 0xed, 0x3a, 0x6c, 0x2f, 0xee, 0x34, 0x2c, 0xea, 0x35, 0xbb, 0xb4, 0x4b,
+	0x8b, 0x6c, 0x7c, 0x24, 0x06, 0xe2, 0x3f, 0xe9, 0x7e, 0x63, 0x9c, 0x6d, 0x0d, 0x02, 0x59, 0xac,
+	0xf1, 0x3e, 0x5a, 0x78, 0x18, 0xdb, 0x5d, 0xb8, 0x4f, 0x3b, 0x6d, 0x78, 0x1e, 0x43, 0xc4, 0xf0,
+	0xff, 0x51, 0x65, 0x9f, 0x76, 0x4c, 0xc7, 0xae, 0x6a, 0x1b, 0xda, 0xe6, 0xd9, 0xd6, 0x72, 0x9a,
+	0xe8, 0x0b, 0xfb, 0xb4, 0xb3, 0x63, 0x5f, 0xa1, 0x9e, 0xc3, 0xc0, 0x0b, 0xd8, 0x51, 0x7b, 0x4a,
+	0x00, 0xc6, 0x77, 0x25, 0xb4, 0x58, 0xc4, 0x47, 0x01, 0xf5, 0x23, 0xf8, 0x23, 0x09, 0xf0, 0x65,
+	0x34, 0xf5, 0x3c, 0x86, 0x18, 0xaa, 0xa5, 0xc2, 0x55, 0x00, 0xaa, 0xab, 0x00, 0xf0, 0x55, 0x74,
+	0x86, 0xa7, 0x8d, 0x80, 0x55, 0xcb, 0xc2, 0x79, 0x25, 0x4d, 0xf4, 0xc5, 0x7d, 0xda, 0xd9, 0x05,
+	0xa6, 0x78, 0x57, 0x32, 0x84, 0x67, 0x8e, 0x18, 0x61, 0x50, 0x9d, 0x2c, 0x32, 0x0b, 0x40, 0xcd,
+	0x2c, 0x00, 0xbc, 0x85, 0xa6, 0x83, 0xd0, 0xa1, 0xa1, 0xc3, 0x8e, 0xaa, 0x53, 0x1b, 0xda, 0xe6,
+	0x5c, 0x6b, 0x35, 0x4d, 0x74, 0x9c, 0x63, 0x4a, 0x40, 0xcf, 0x0f, 0x5f, 0x41, 0x15, 0x9f, 0x6f,
+	0xdc, 0xae, 0x56, 0x36, 0xb4, 0xcd, 0xe9, 0x6c, 0x31, 0x19, 0xa2, 0x2e, 0x26, 0x43, 0x8c, 0xaf,
+	0x35, 0xb4, 0xbe, 0x0b, 0xec, 0xce, 0x0b, 0xb0, 0x62, 0x46, 0xc3, 0x47, 0x8e, 0x07, 0x34, 0x66,
+	0x79, 0xc5, 0xdf, 0x43, 0x33, 0x20, 0x2d, 0x45, 0xd5, 0xaa, 0x69, 0xa2, 0xaf, 0xe4, 0x70, 0x5f,
+	0xe9, 0x50, 0x81, 0xe2, 0x7b, 0xe8, 0x0c, 0xcb, 0x92, 0x89, 0x0a, 0xce, 0x6c, 0xad, 0x37, 0x32,
+	0x06, 0x36, 0x72, 0xce, 0x34, 0x6e, 0x4b, 0x86, 0xb6, 0x96, 0x5f, 0x25, 0xfa, 0x44, 0x9a, 0xe8,
+	0x79, 0xc4, 0x97, 0x3f, 0xeb, 0x5a, 0x3b, 0x1f, 0x18, 0xdf, 0x68, 0xa8, 0x36, 0x6a, 0x89, 0xb2,
+	0xa9, 0x7f, 0x8b, 0x35, 0x52, 0xb4, 0x7c, 0x97, 0x86, 0x16, 0xdc, 0x25, 0x8e, 0xfb, 0xe7, 0x18,
+	0xcb, 0xfb, 0x16, 0x02, 0x89, 0xa8, 0x2f, 0x19, 0x27, 0xfa, 0x96, 0x21, 0x6a, 0xdf, 0x32, 0xc4,
+	0xf8, 0x55, 0x43, 0x2b, 0xfd, 0x33, 0xfe, 0x53, 0x39, 0x5e, 0xec, 0x7b, 0x6a, 0x8c, 0x7d, 0xdf,
+	0x44, 0x4b, 0xbb, 0x16, 0x71, 0xe1, 0x9e, 0xe3, 0xb3, 0x28, 0x2f, 0xf3, 0x25, 0x34, 0x19, 0x50,
+	0xea, 0xca, 0x1d, 0xe3, 0x34, 0xd1, 0xe7, 0xf9, 0x58, 0x09, 0x17, 0x76, 0xe3, 0xb7, 0x12, 0x5a,
+	0xca, 0x69, 0xd4, 0xcb, 0xf2, 0x57, 0x08, 0x94, 0x4f, 0x5c, 0x3a, 0x79, 0x62, 0x3e, 0x85, 0x63,
+	0xbb, 0x60, 0x5a, 0x47, 0x96, 0x0b, 0x91, 0xa8, 0xe0, 0x5c, 0x36, 0x05, 0x87, 0xb7, 0x05, 0xaa,
+	0x4e, 0x51, 0xa0, 0xd8, 0x44, 0x0b, 0x8c, 0x32, 0xe2, 0x9a, 0x21, 0x44, 0x34, 0x0e, 0x2d, 0x88,
+	0x44, 0x4d, 0x67, 0xb6, 0xea, 0x8d, 0x21, 0xe5, 0x6c, 0x4b, 0x97, 0x07, 0x4e, 0xc4, 0x5a, 0xab,
+	0x92, 0xb0, 0xf3, 0x22, 0x3c, 0x37, 0x45, 0xed, 0x81, 0x31, 0xde, 0x43, 0x38, 0x04, 0x46, 0x1c,
+	0x1f, 0x6c, 0x65, 0x8e, 0xa9, 0xb1, 0xe6, 0x58, 0x97, 0x73, 0x2c, 0xe5, 0x19, 0x8a, 0x69, 0x86,
+	0x21, 0x23, 0x40, 0x58, 0xed, 0x9d, 0x24, 0xec, 0x13, 0x74, 0x36, 0xaf, 0x68, 0x54, 0xd5, 0x36,
+	0xca, 0x9b, 0x33, 0x5b, 0x17, 0x86, 0xa7, 0x1d, 0x6a, 0x5b, 0x6b, 0x2d, 0x4d, 0xf4, 0xe5, 0x5e,
+	0xa4, 0x52, 0xbd, 0x22, 0x9d, 0xf1, 0xad, 0x86, 0xce, 0xef, 0xf8, 0x51, 0x00, 0x16, 0xdb, 0x8d,
+	0x3b, 0x9e, 0xc3, 0xb6, 0xf7, 0xc0, 0x3a, 0x80, 0x50, 0xd1, 0xb7, 0x67, 0x6e, 0x1c, 0xed, 0x99,
+	0x16, 0xb1, 0xf6, 0x40, 0xb4, 0x7e, 0x3a, 0xeb, 0x8b, 0x80, 0xb7, 0x39, 0xaa, 0xf6, 0xa5, 0x40,
+	0xf1, 0x0e, 0x5a, 0xf2, 0xc8, 0x8b, 0x2c, 0xd0, 0x04, 0x9f, 0x85, 0x0e, 0x44, 0x82, 0x07, 0x73,
+	0xad, 0x7f, 0xa7, 0x89, 0xbe, 0xee, 0x91, 0x17, 0xc2, 0xf1, 0x4e, 0x66, 0x52, 0xb2, 0x2c, 0x0c,
+	0x98, 0x8c, 0x1f, 0xca, 0x68, 0xa9, 0x6f, 0x79, 0x0f, 0xa9, 0x0d, 0x78, 0x03, 0x95, 0x7a, 0x6c,
+	0x5c, 0x4c, 0x13, 0x7d, 0xd6, 0x51, 0x59, 0x58, 0x72, 0x04, 0xfb, 0x7c, 0xe2, 0x81, 0xca, 0x3e,
+	0x3e, 0x56, 0xd9, 0xc7, 0xc7, 0xf8, 0x31, 0x9a, 0x21, 0xae, 0x4b, 0x2d, 0xc2, 0x48, 0xc7, 0x85,
+	0x6a, 0x79, 0xac, 0xd6, 0xe6, 0x7a, 0xa7, 0x86, 0xb6, 0xd5, 0x01, 0xbe, 0x85, 0x2a, 0xbc, 0xc3,
+	0x8c, 0x13, 0xb2, 0x2c, 0xc4, 0x33, 0xbb, 0x41, 0x34, 0x48, 0xe0, 0x34, 0x2c, 0x1a, 0x42, 0xe3,
+	0xf0, 0x5a, 0xe3, 0x11, 0xf7, 0x68, 0xcd, 0xcb, 0x64, 0x32, 0xa0, 0x2d, 0xff, 0xe2, 0xa7, 0xa8,
+	0xe2, 0x92, 0x0e, 0xb8, 0x9c, 0x6f, 0x3c, 0x45, 0x73, 0x78, 0x51, 0x43, 0x85, 0x69, 0x3c, 0x10,
+	0x11, 0xbc, 0x78, 0x47, 0x99, 0x58, 0x64, 0x29, 0x54, 0xb1, 0xc8, 0x90, 0x1a, 0x41, 0x33, 0x8a,
+	0x33, 0xbe, 0x80, 0xca, 0x07, 0x70, 0x24, 0x4b, 0xba, 0x94, 0x26, 0xfa, 0xdc, 0x01, 0xa8, 0x67,
+	0x28, 0xb7, 0x72, 0xe5, 0x3a, 0x24, 0x6e, 0xbf, 0x26, 0x0a, 0x40, 0x55, 0x2e, 0x01, 0xdc, 0x28,
+	0x5d, 0xd7, 0x8c, 0x1f, 0x4b, 0xe8, 0x5c, 0xdf, 0x12, 0x73, 0xa2, 0x8e, 0xd7, 0xbf, 0xb1, 0xd4,
+	0xe3, 0x73, 0xb4, 0xe8, 0x92, 0x88, 0x99, 0x71, 0x60, 0x13, 0x06, 0x26, 0x3f, 0x73, 0x64, 0x13,
+	0x6b, 0x43, 0xe7, 0xd5, 0xa3, 0xfc, 0x56, 0xd7, 0xaa, 0xe5, 0xdf, 0x3f, 0x8f, 0x7d, 0x2c, 0x42,
+	0xb9, 0xf1, 0x25, 0x3f, 0xb7, 0x06, 0x30, 0x29, 0xd7, 0x6e, 0x26, 0xd7, 0xd3, 0x3d, 0xb9, 0x76,
+	0x07, 0xe5, 0xda, 0x05, 0xfc, 0x10, 0x4d, 0xf9, 0xd4, 0x86, 0xbc, 0x63, 0x17, 0xc6, 0xe8, 0x58,
+	0x96, 0x4f, 0x44, 0xa9, 0xf9, 0x04, 0x60, 0x7c, 0x51, 0x42, 0x6b, 0x7d, 0x11, 0xbd, 0x4f, 0xe3,
+	0x08, 0xb7, 0xd0, 0xbc, 0xcc, 0xee, 0xf8, 0x5d, 0xb3, 0xe8, 0xdd, 0xf9, 0x34, 0xd1, 0xd7, 0x0a,
+	0xcb, 0x07, 0x7d, 0x5d, 0x9c, 0xeb, 0x33, 0xe0, 0x9b, 0x68, 0x46, 0x02, 0x82, 0xfc, 0x25, 0xb1,
+	0xc1, 0xf5, 0x34, 0xd1, 0xcf, 0x29, 0xb0, 0x12, 0xae, 0x7a, 0x2b, 0x67, 0x53, 0xf9, 0xf4, 0xb3,
+	0x09, 0xef, 0xa0, 0x33, 0x56, 0x08, 0xfc, 0xba, 0x5c, 0x9d, 0x3c, 0xb5, 0x3d, 0xbd, 0xfb, 0x84,
+	0x0c, 0x11, 0x7d, 0xc9, 0x07, 0xc6, 0xf7, 0x93, 0xe8, 0x5f, 0xa3, 0x85, 0x4b, 0xaa, 0xe6, 0x3d,
+	0xb4, 0x18, 0xf9, 0x24, 0x88, 0xf6, 0x28, 0x33, 0x0f, 0x21, 0x8c, 0x1c, 0xea, 0x8b, 0xe2, 0x4c,
+	0x66, 0xea, 0x93, 0xdb, 0x3e, 0xc9, 0x4c, 0xaa, 0xfa, 0x0c, 0x98, 0xf0, 0x63, 0x34, 0xdb, 0xcb,
+	0x44, 0xba, 0x70, 0xfa, 0x4d, 0x68, 0x2d, 0x57, 0x86, 0x3c, 0xec, 0x56, 0x17, 0xc4, 0x6d, 0x48,
+	0x05, 0xf0, 0x67, 0xaa, 0xac, 0x97, 0x05, 0x57, 0xfe, 0x77, 0x0a, 0x57, 0xf2, 0x4f, 0x67, 0x1c,
+	0x69, 0xc7, 0xfb, 0x68, 0xae, 0x5f, 0x7b, 0x33, 0x11, 0xba, 0x7c, 0xca, 0x1c, 0x05, 0xbb, 0x5a,
+	0xb5, 0x34, 0xd1, 0x57, 0xad, 0xd1, 0x1a, 0x3d, 0xab, 0xe2, 0x5c, 0xeb, 0xfd, 0xd8, 0x1b, 0xd0,
+	0xfa, 0xa9, 0x42, 0xeb, 0xfd, 0xd8, 0x7b, 0x9b, 0xd6, 0x0f, 0x98, 0xb0, 0x89, 0xb8, 0xb7, 0x29,
+	0x0e, 0x12, 0xb0, 0x07, 0x52, 0x56, 0x44, 0xca, 0x8b, 0x69, 0xa2, 0x6f, 0xf8, 0xb1, 0x77, 0x37,
+	0xf3, 0x79, 0x4b, 0xe6, 0xd5, 0xd1, 0x1e, 0xc6, 0x36, 0x5a, 0x6e, 0x43, 0x27, 0x76, 0x5c, 0xfb,
+	0x3e, 0xed, 0xdc, 0xee, 0xdd, 0x44, 0x0b, 0x26, 0x6b, 0x63, 0xdc, 0xb2, 0xbe, 0xd2, 0xd0, 0x4a,
+	0x7f, 0x16, 0x49, 0xbb, 0x0f, 0xd1, 0x32, 0x71, 0x43, 0x20, 0xf6, 0x91, 0xe9, 0xf8, 0x66, 0x10,
+	0xd2, 0x6e, 0x08, 0x51, 0x24, 0x0f, 0x4e, 0x3d, 0x4d, 0xf4, 0xf3, 0xd2, 0xbc, 0xe3, 0x7f, 0x24,
+	0x8d, 0x4a, 0xfa, 0xa5, 0x21, 0x63, 0xf6, 0xc2, 0x91, 0x59, 0x38, 0xf3, 0xb4, 0xfc, 0x85, 0x33,
+	0x14, 0xdc, 0xf3, 0xdb, 0x3a, 0x9e, 0x44, 0xf3, 0xbb, 0x79, 0x97, 0x6f, 0xf1, 0x57, 0x31, 0xfe,
+	0x18, 0x4d, 0xe7, 0xaf, 0x3d, 0xfc, 0x9f, 0x61, 0x0a, 0x0c, 0xbc, 0x24, 0x6b, 0xc6, 0x49, 0x2e,
+	0x72, 0xab, 0x14, 0xe1, 0xe1, 0x57, 0x07, 0x7e, 0x67, 0x04, 0xbf, 0xde, 0xf6, 0x7c, 0xaa, 0x5d,
+	0x19, 0xcf, 0x59, 0x4e, 0xf8, 0x14, 0xcd, 0xaa, 0x37, 0x7a, 0xfc, 0xdf, 0xe1, 0xe8, 0x11, 0x6f,
+	0x8c, 0xda, 0xa5, 0xd3, 0xdc, 0x64, 0xfa, 0x4f, 0x11, 0x2a, 0x6e, 0x5f, 0x78, 0x94, 0x6e, 0x0f,
+	0xde, 0xab, 0x6b, 0x17, 0x4f, 0x76, 0x92, 0x89, 0x63, 0xb4, 0x32, 0x4a, 0xaa, 0xf0, 0xd5, 0xe1,
+	0xe8, 0x13, 0xee, 0x62, 0xb5, 0xc6, 0xb8, 0xee, 0x45, 0xb9, 0x54, 0x8a, 0x8e, 0x2a, 0xd7, 0x88,
+	0x0f, 0xa1, 0x76, 0xe9, 0x34, 0xb7, 0x2c, 0x7d, 0xeb, 0xe9, 0xab, 0xe3, 0xba, 0xf6, 0xfa, 0xb8,
+	0xae, 0xfd, 0x72, 0x5c, 0xd7, 0x5e, 0xbe, 0xa9, 0x4f, 0xbc, 0x7e, 0x53, 0x9f, 0xf8, 0xe9, 0x4d,
+	0x7d, 0xe2, 0xc9, 0xb6, 0xf2, 0x23, 0x08, 0x09, 0x3d, 0x62, 0x93, 0x20, 0xa4, 0x3c, 0x93, 0x1c,
+	0x8d, 0xf3, 0x63, 0x4e, 0xa7, 0x22, 0x74, 0xf5, 0xdd, 0xdf, 0x07, 0x00, 0x6d, 0xf8, 0x9b, 0x43,
+	0xfa, 0x11, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SchedulerAdminClient is the client API for SchedulerAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SchedulerAdminClient interface {
+	// Clear any backoff imposed on a queued job by the scheduler
+	// and move it to the front of its priority band for the next scheduling round.
+	NudgeJob(ctx context.Context, in *NudgeJobRequest, opts ...grpc.CallOption) (*NudgeJobResponse, error)
+	// Override the timeout of an executor, i.e., how long to wait for a heartbeat before considering it stale.
+	// Overrides are held in memory by the leader and take precedence over the timeout provided by the executor.
+	SetExecutorTimeout(ctx context.Context, in *SetExecutorTimeoutRequest, opts ...grpc.CallOption) (*SetExecutorTimeoutResponse, error)
+	// Fail a non-terminal job, cancelling its active run if any, with the provided reason.
+	// The job is failed by the next scheduling cycle. Repeated requests for the same job have no further effect.
+	ForceFailJob(ctx context.Context, in *ForceFailJobRequest, opts ...grpc.CallOption) (*ForceFailJobResponse, error)
+	// List the executors that have been idle for long enough to be considered safe to shrink,
+	// together with the resources the scheduler would want each to retain given the jobs currently queued.
+	ScaleHints(ctx context.Context, in *ScaleHintsRequest, opts ...grpc.CallOption) (*ScaleHintsResponse, error)
+	// Return the executors and nodes the submit checker of the replica serving the request believes exist,
+	// together with the verdicts it has cached, optionally flushing those verdicts first.
+	InspectSubmitChecker(ctx context.Context, in *InspectSubmitCheckerRequest, opts ...grpc.CallOption) (*InspectSubmitCheckerResponse, error)
+	// Replace the jobDb of the leader with one rebuilt from the job repository, e.g., if it's suspected to be corrupt.
+	// Scheduling is paused until the rebuilt jobDb has caught up with the live one and been swapped in.
+	RebuildJobDb(ctx context.Context, in *RebuildJobDbRequest, opts ...grpc.CallOption) (*RebuildJobDbResponse, error)
+}
+
+type schedulerAdminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSchedulerAdminClient(cc *grpc.ClientConn) SchedulerAdminClient {
+	return &schedulerAdminClient{cc}
+}
+
+func (c *schedulerAdminClient) NudgeJob(ctx context.Context, in *NudgeJobRequest, opts ...grpc.CallOption) (*NudgeJobResponse, error) {
+	out := new(NudgeJobResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/NudgeJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerAdminClient) SetExecutorTimeout(ctx context.Context, in *SetExecutorTimeoutRequest, opts ...grpc.CallOption) (*SetExecutorTimeoutResponse, error) {
+	out := new(SetExecutorTimeoutResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/SetExecutorTimeout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerAdminClient) ForceFailJob(ctx context.Context, in *ForceFailJobRequest, opts ...grpc.CallOption) (*ForceFailJobResponse, error) {
+	out := new(ForceFailJobResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/ForceFailJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerAdminClient) ScaleHints(ctx context.Context, in *ScaleHintsRequest, opts ...grpc.CallOption) (*ScaleHintsResponse, error) {
+	out := new(ScaleHintsResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/ScaleHints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerAdminClient) InspectSubmitChecker(ctx context.Context, in *InspectSubmitCheckerRequest, opts ...grpc.CallOption) (*InspectSubmitCheckerResponse, error) {
+	out := new(InspectSubmitCheckerResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/InspectSubmitChecker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerAdminClient) RebuildJobDb(ctx context.Context, in *RebuildJobDbRequest, opts ...grpc.CallOption) (*RebuildJobDbResponse, error) {
+	out := new(RebuildJobDbResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/RebuildJobDb", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchedulerAdminServer is the server API for SchedulerAdmin service.
+type SchedulerAdminServer interface {
+	// Clear any backoff imposed on a queued job by the scheduler
+	// and move it to the front of its priority band for the next scheduling round.
+	NudgeJob(context.Context, *NudgeJobRequest) (*NudgeJobResponse, error)
+	// Override the timeout of an executor, i.e., how long to wait for a heartbeat before considering it stale.
+	// Overrides are held in memory by the leader and take precedence over the timeout provided by the executor.
+	SetExecutorTimeout(context.Context, *SetExecutorTimeoutRequest) (*SetExecutorTimeoutResponse, error)
+	// Fail a non-terminal job, cancelling its active run if any, with the provided reason.
+	// The job is failed by the next scheduling cycle. Repeated requests for the same job have no further effect.
+	ForceFailJob(context.Context, *ForceFailJobRequest) (*ForceFailJobResponse, error)
+	// List the executors that have been idle for long enough to be considered safe to shrink,
+	// together with the resources the scheduler would want each to retain given the jobs currently queued.
+	ScaleHints(context.Context, *ScaleHintsRequest) (*ScaleHintsResponse, error)
+	// Return the executors and nodes the submit checker of the replica serving the request believes exist,
+	// together with the verdicts it has cached, optionally flushing those verdicts first.
+	InspectSubmitChecker(context.Context, *InspectSubmitCheckerRequest) (*InspectSubmitCheckerResponse, error)
+	// Replace the jobDb of the leader with one rebuilt from the job repository, e.g., if it's suspected to be corrupt.
+	// Scheduling is paused until the rebuilt jobDb has caught up with the live one and been swapped in.
+	RebuildJobDb(context.Context, *RebuildJobDbRequest) (*RebuildJobDbResponse, error)
+}
+
+// UnimplementedSchedulerAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedSchedulerAdminServer struct {
+}
+
+func (*UnimplementedSchedulerAdminServer) NudgeJob(ctx context.Context, req *NudgeJobRequest) (*NudgeJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NudgeJob not implemented")
+}
+func (*UnimplementedSchedulerAdminServer) SetExecutorTimeout(ctx context.Context, req *SetExecutorTimeoutRequest) (*SetExecutorTimeoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExecutorTimeout not implemented")
+}
+func (*UnimplementedSchedulerAdminServer) ForceFailJob(ctx context.Context, req *ForceFailJobRequest) (*ForceFailJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceFailJob not implemented")
+}
+func (*UnimplementedSchedulerAdminServer) ScaleHints(ctx context.Context, req *ScaleHintsRequest) (*ScaleHintsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScaleHints not implemented")
+}
+func (*UnimplementedSchedulerAdminServer) InspectSubmitChecker(ctx context.Context, req *InspectSubmitCheckerRequest) (*InspectSubmitCheckerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectSubmitChecker not implemented")
+}
+func (*UnimplementedSchedulerAdminServer) RebuildJobDb(ctx context.Context, req *RebuildJobDbRequest) (*RebuildJobDbResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildJobDb not implemented")
+}
+
+func RegisterSchedulerAdminServer(s *grpc.Server, srv SchedulerAdminServer) {
+	s.RegisterService(&_SchedulerAdmin_serviceDesc, srv)
+}
+
+func _SchedulerAdmin_NudgeJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NudgeJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).NudgeJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/NudgeJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).NudgeJob(ctx, req.(*NudgeJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerAdmin_SetExecutorTimeout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExecutorTimeoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).SetExecutorTimeout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/SetExecutorTimeout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).SetExecutorTimeout(ctx, req.(*SetExecutorTimeoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerAdmin_ForceFailJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceFailJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).ForceFailJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/ForceFailJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).ForceFailJob(ctx, req.(*ForceFailJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerAdmin_ScaleHints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleHintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).ScaleHints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/ScaleHints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).ScaleHints(ctx, req.(*ScaleHintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerAdmin_InspectSubmitChecker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectSubmitCheckerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).InspectSubmitChecker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/InspectSubmitChecker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).InspectSubmitChecker(ctx, req.(*InspectSubmitCheckerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerAdmin_RebuildJobDb_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildJobDbRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).RebuildJobDb(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/RebuildJobDb",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).RebuildJobDb(ctx, req.(*RebuildJobDbRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SchedulerAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "schedulerobjects.SchedulerAdmin",
+	HandlerType: (*SchedulerAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NudgeJob",
+			Handler:    _SchedulerAdmin_NudgeJob_Handler,
+		},
+		{
+			MethodName: "SetExecutorTimeout",
+			Handler:    _SchedulerAdmin_SetExecutorTimeout_Handler,
+		},
+		{
+			MethodName: "ForceFailJob",
+			Handler:    _SchedulerAdmin_ForceFailJob_Handler,
+		},
+		{
+			MethodName: "ScaleHints",
+			Handler:    _SchedulerAdmin_ScaleHints_Handler,
+		},
+		{
+			MethodName: "InspectSubmitChecker",
+			Handler:    _SchedulerAdmin_InspectSubmitChecker_Handler,
+		},
+		{
+			MethodName: "RebuildJobDb",
+			Handler:    _SchedulerAdmin_RebuildJobDb_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/scheduler/schedulerobjects/admin.proto",
+}
+
+func (m *NudgeJobRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NudgeJobRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NudgeJobRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NudgeJobResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NudgeJobResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NudgeJobResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Nudged {
+		i--
+		if m.Nudged {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Priority != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.JobSet) > 0 {
+		i -= len(m.JobSet)
+		copy(dAtA[i:], m.JobSet)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.JobSet)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Queue) > 0 {
+		i -= len(m.Queue)
+		copy(dAtA[i:], m.Queue)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Queue)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetExecutorTimeoutRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetExecutorTimeoutRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetExecutorTimeoutRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Timeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintAdmin(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x12
+	if len(m.ExecutorId) > 0 {
+		i -= len(m.ExecutorId)
+		copy(dAtA[i:], m.ExecutorId)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.ExecutorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetExecutorTimeoutResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetExecutorTimeoutResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetExecutorTimeoutResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Timeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintAdmin(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x12
+	if len(m.ExecutorId) > 0 {
+		i -= len(m.ExecutorId)
+		copy(dAtA[i:], m.ExecutorId)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.ExecutorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ForceFailJobRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ForceFailJobRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ForceFailJobRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ForceFailJobResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ForceFailJobResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ForceFailJobResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.JobSet) > 0 {
+		i -= len(m.JobSet)
+		copy(dAtA[i:], m.JobSet)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.JobSet)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Queue) > 0 {
+		i -= len(m.Queue)
+		copy(dAtA[i:], m.Queue)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Queue)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScaleHintsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScaleHintsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScaleHintsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Pool) > 0 {
+		i -= len(m.Pool)
+		copy(dAtA[i:], m.Pool)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Pool)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExecutorScaleHint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExecutorScaleHint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExecutorScaleHint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.RetainedResources.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintAdmin(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	{
+		size, err := m.TotalResources.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintAdmin(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if m.IdleCycles != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.IdleCycles))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Pool) > 0 {
+		i -= len(m.Pool)
+		copy(dAtA[i:], m.Pool)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Pool)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ExecutorId) > 0 {
+		i -= len(m.ExecutorId)
+		copy(dAtA[i:], m.ExecutorId)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.ExecutorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScaleHintsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScaleHintsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScaleHintsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Executors) > 0 {
+		for iNdEx := len(m.Executors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Executors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAdmin(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InspectSubmitCheckerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectSubmitCheckerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InspectSubmitCheckerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxCacheEntries != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.MaxCacheEntries))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.FlushCache {
+		i--
+		if m.FlushCache {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubmitCheckerNode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubmitCheckerNode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubmitCheckerNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for k := range m.Labels {
+			v := m.Labels[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintAdmin(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintAdmin(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintAdmin(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Taints) > 0 {
+		for iNdEx := len(m.Taints) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Taints[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAdmin(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	{
+		size, err := m.Allocatable.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintAdmin(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubmitCheckerExecutor) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubmitCheckerExecutor) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubmitCheckerExecutor) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAdmin(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Stale {
+		i--
+		if m.Stale {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastUpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastUpdateTime):])
+	if err6 != nil {
+		return 0, err6
+	}
+	i -= n6
+	i = encodeVarintAdmin(dAtA, i, uint64(n6))
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Pool) > 0 {
+		i -= len(m.Pool)
+		copy(dAtA[i:], m.Pool)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Pool)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubmitCheckerCacheEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubmitCheckerCacheEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubmitCheckerCacheEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err7 != nil {
+		return 0, err7
+	}
+	i -= n7
+	i = encodeVarintAdmin(dAtA, i, uint64(n7))
+	i--
+	dAtA[i] = 0x22
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Schedulable {
+		i--
+		if m.Schedulable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.SchedulingKey) > 0 {
+		i -= len(m.SchedulingKey)
+		copy(dAtA[i:], m.SchedulingKey)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.SchedulingKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InspectSubmitCheckerResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectSubmitCheckerResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InspectSubmitCheckerResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NumFlushedCacheEntries != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.NumFlushedCacheEntries))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.NumCacheEntries != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.NumCacheEntries))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.CacheEntries) > 0 {
+		for iNdEx := len(m.CacheEntries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CacheEntries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAdmin(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Executors) > 0 {
+		for iNdEx := len(m.Executors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Executors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAdmin(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	n8, err8 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.SnapshotAge, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.SnapshotAge):])
+	if err8 != nil {
+		return 0, err8
+	}
+	i -= n8
+	i = encodeVarintAdmin(dAtA, i, uint64(n8))
+	i--
+	dAtA[i] = 0x12
+	if m.SnapshotVersion != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.SnapshotVersion))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RebuildJobDbRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RebuildJobDbRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RebuildJobDbRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RebuildJobDbResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RebuildJobDbResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RebuildJobDbResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Progress != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Progress))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.AlreadyInProgress {
+		i--
+		if m.AlreadyInProgress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAdmin(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAdmin(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *NudgeJobRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *NudgeJobResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Queue)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.JobSet)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovAdmin(uint64(m.Priority))
+	}
+	if m.Nudged {
+		n += 2
+	}
+	return n
+}
+
+func (m *SetExecutorTimeoutRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorId)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout)
+	n += 1 + l + sovAdmin(uint64(l))
+	return n
+}
+
+func (m *SetExecutorTimeoutResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorId)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout)
+	n += 1 + l + sovAdmin(uint64(l))
+	return n
+}
+
+func (m *ForceFailJobRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *ForceFailJobResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Queue)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.JobSet)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *ScaleHintsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *ExecutorScaleHint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorId)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.IdleCycles != 0 {
+		n += 1 + sovAdmin(uint64(m.IdleCycles))
+	}
+	l = m.TotalResources.Size()
+	n += 1 + l + sovAdmin(uint64(l))
+	l = m.RetainedResources.Size()
+	n += 1 + l + sovAdmin(uint64(l))
+	return n
+}
+
+func (m *ScaleHintsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Executors) > 0 {
+		for _, e := range m.Executors {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *InspectSubmitCheckerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FlushCache {
+		n += 2
+	}
+	if m.MaxCacheEntries != 0 {
+		n += 1 + sovAdmin(uint64(m.MaxCacheEntries))
+	}
+	return n
+}
+
+func (m *SubmitCheckerNode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = m.Allocatable.Size()
+	n += 1 + l + sovAdmin(uint64(l))
+	if len(m.Taints) > 0 {
+		for _, e := range m.Taints {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovAdmin(uint64(len(k))) + 1 + len(v) + sovAdmin(uint64(len(v)))
+			n += mapEntrySize + 1 + sovAdmin(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *SubmitCheckerExecutor) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastUpdateTime)
+	n += 1 + l + sovAdmin(uint64(l))
+	if m.Stale {
+		n += 2
+	}
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubmitCheckerCacheEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SchedulingKey)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.Schedulable {
+		n += 2
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovAdmin(uint64(l))
+	return n
+}
+
+func (m *InspectSubmitCheckerResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotVersion != 0 {
+		n += 1 + sovAdmin(uint64(m.SnapshotVersion))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.SnapshotAge)
+	n += 1 + l + sovAdmin(uint64(l))
+	if len(m.Executors) > 0 {
+		for _, e := range m.Executors {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	if len(m.CacheEntries) > 0 {
+		for _, e := range m.CacheEntries {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	if m.NumCacheEntries != 0 {
+		n += 1 + sovAdmin(uint64(m.NumCacheEntries))
+	}
+	if m.NumFlushedCacheEntries != 0 {
+		n += 1 + sovAdmin(uint64(m.NumFlushedCacheEntries))
+	}
+	return n
+}
+
+func (m *RebuildJobDbRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *RebuildJobDbResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AlreadyInProgress {
+		n += 2
+	}
+	if m.Progress != 0 {
+		n += 9
+	}
+	return n
+}
+
+func sovAdmin(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAdmin(x uint64) (n int) {
+	return sovAdmin(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *NudgeJobRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NudgeJobRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NudgeJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NudgeJobResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NudgeJobResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NudgeJobResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Queue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Queue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobSet", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobSet = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nudged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Nudged = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetExecutorTimeoutRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetExecutorTimeoutRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetExecutorTimeoutRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Timeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetExecutorTimeoutResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetExecutorTimeoutResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetExecutorTimeoutResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Timeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ForceFailJobRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ForceFailJobRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ForceFailJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ForceFailJobResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ForceFailJobResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ForceFailJobResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Queue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Queue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobSet", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobSet = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScaleHintsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScaleHintsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScaleHintsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExecutorScaleHint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExecutorScaleHint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExecutorScaleHint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdleCycles", wireType)
+			}
+			m.IdleCycles = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IdleCycles |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TotalResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetainedResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RetainedResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScaleHintsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScaleHintsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScaleHintsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Executors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Executors = append(m.Executors, &ExecutorScaleHint{})
+			if err := m.Executors[len(m.Executors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InspectSubmitCheckerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InspectSubmitCheckerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InspectSubmitCheckerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushCache", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FlushCache = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCacheEntries", wireType)
+			}
+			m.MaxCacheEntries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCacheEntries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubmitCheckerNode) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubmitCheckerNode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubmitCheckerNode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allocatable", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Allocatable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Taints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Taints = append(m.Taints, v1.Taint{})
+			if err := m.Taints[len(m.Taints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAdmin
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAdmin
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthAdmin
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthAdmin
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAdmin
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthAdmin
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthAdmin
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipAdmin(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthAdmin
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubmitCheckerExecutor) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubmitCheckerExecutor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubmitCheckerExecutor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastUpdateTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.LastUpdateTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stale", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stale = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, &SubmitCheckerNode{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubmitCheckerCacheEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubmitCheckerCacheEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubmitCheckerCacheEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchedulingKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SchedulingKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedulable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Schedulable = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InspectSubmitCheckerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InspectSubmitCheckerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InspectSubmitCheckerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotVersion", wireType)
+			}
+			m.SnapshotVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotAge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.SnapshotAge, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Executors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Executors = append(m.Executors, &SubmitCheckerExecutor{})
+			if err := m.Executors[len(m.Executors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheEntries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CacheEntries = append(m.CacheEntries, &SubmitCheckerCacheEntry{})
+			if err := m.CacheEntries[len(m.CacheEntries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumCacheEntries", wireType)
+			}
+			m.NumCacheEntries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumCacheEntries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumFlushedCacheEntries", wireType)
+			}
+			m.NumFlushedCacheEntries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumFlushedCacheEntries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RebuildJobDbRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RebuildJobDbRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RebuildJobDbRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RebuildJobDbResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RebuildJobDbResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RebuildJobDbResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AlreadyInProgress", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AlreadyInProgress = bool(v != 0)
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Progress", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Progress = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipAdmin(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthAdmin
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupAdmin
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthAdmin
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthAdmin        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAdmin          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupAdmin = fmt.Errorf("proto: unexpected end of group")
+)
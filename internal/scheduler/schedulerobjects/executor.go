@@ -1,6 +1,8 @@
 package schedulerobjects
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
@@ -27,3 +29,13 @@ func (m *Executor) AllRuns() ([]uuid.UUID, error) {
 	}
 	return runIds, nil
 }
+
+// ClockSkew returns by how much the clock of the executor was ahead of that of the scheduler when it last heartbeated,
+// or zero if the executor didn't report the time of its heartbeat.
+// The skew includes the time taken for the heartbeat to reach the scheduler, which is assumed to be small.
+func (m *Executor) ClockSkew() time.Duration {
+	if m.LastReportedTime == nil || m.LastUpdateTime.IsZero() {
+		return 0
+	}
+	return m.LastReportedTime.Sub(m.LastUpdateTime)
+}
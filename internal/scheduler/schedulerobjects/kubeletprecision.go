@@ -0,0 +1,115 @@
+package schedulerobjects
+
+import (
+	"math/big"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// The kubelet accounts for cpu in whole millicores and for all other resources in whole units,
+// rounding the requests of each pod up. To ensure any job the scheduler places on a node also fits according to the
+// kubelet, the scheduler rounds requests up and the resources of nodes down to the same precision.
+
+// kubeletScale returns the scale of the smallest amount of a resource of type t the kubelet accounts for.
+func kubeletScale(t string) resource.Scale {
+	if t == string(v1.ResourceCPU) {
+		return resource.Milli
+	}
+	return 0
+}
+
+// RoundUpToKubeletPrecision returns q rounded up to the precision with which the kubelet accounts for resources of
+// type t, i.e., to whole millicores for cpu and to whole units otherwise. q is returned as is if no rounding is necessary.
+func RoundUpToKubeletPrecision(t string, q resource.Quantity) resource.Quantity {
+	scale := kubeletScale(t)
+	// ScaledValue rounds up.
+	rounded := *resource.NewScaledQuantity(q.ScaledValue(scale), scale)
+	if rounded.Cmp(q) == 0 {
+		return q
+	}
+	return rounded
+}
+
+// RoundDownToKubeletPrecision is like RoundUpToKubeletPrecision, but rounds down.
+func RoundDownToKubeletPrecision(t string, q resource.Quantity) resource.Quantity {
+	scale := kubeletScale(t)
+	value := q.ScaledValue(scale)
+	rounded := *resource.NewScaledQuantity(value, scale)
+	switch rounded.Cmp(q) {
+	case 0:
+		return q
+	case 1:
+		return *resource.NewScaledQuantity(value-1, scale)
+	default:
+		return rounded
+	}
+}
+
+// OvercommittedQuantity returns q multiplied by factor, rounded down to the precision with which the kubelet accounts
+// for resources of type t. The multiplication is exact, i.e., the result doesn't depend on how q is represented.
+func OvercommittedQuantity(t string, q resource.Quantity, factor float64) resource.Quantity {
+	ratFactor := new(big.Rat).SetFloat64(factor)
+	if ratFactor == nil {
+		return RoundDownToKubeletPrecision(t, q)
+	}
+	dec := q.AsDec()
+	// The value of q is dec.UnscaledBig() * 10^-dec.Scale(), and the result is expressed in units of 10^kubeletScale(t).
+	// Hence, the number of such units is dec.UnscaledBig() * factor * 10^-(dec.Scale() + kubeletScale(t)).
+	exponent := -(int64(dec.Scale()) + int64(kubeletScale(t)))
+	units := new(big.Rat).Mul(new(big.Rat).SetInt(dec.UnscaledBig()), ratFactor)
+	power := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(abs(exponent)), nil))
+	if exponent >= 0 {
+		units.Mul(units, power)
+	} else {
+		units.Quo(units, power)
+	}
+	// Int.Div rounds towards negative infinity for positive divisors.
+	floor := new(big.Int).Div(units.Num(), units.Denom())
+	if !floor.IsInt64() {
+		return RoundDownToKubeletPrecision(t, q)
+	}
+	return *resource.NewScaledQuantity(floor.Int64(), kubeletScale(t))
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// RoundedDownToKubeletPrecision returns a copy of rl with each quantity rounded down to kubelet precision.
+func (rl ResourceList) RoundedDownToKubeletPrecision() ResourceList {
+	if rl.Resources == nil {
+		return ResourceList{}
+	}
+	rv := NewResourceList(len(rl.Resources))
+	for t, q := range rl.Resources {
+		rv.Resources[t] = RoundDownToKubeletPrecision(t, q).DeepCopy()
+	}
+	return rv
+}
+
+// RoundUpV1ResourceListToKubeletPrecision returns rl with each quantity rounded up to kubelet precision.
+// If no quantity needs rounding, rl is returned as is. Otherwise, rl is copied.
+func RoundUpV1ResourceListToKubeletPrecision(rl v1.ResourceList) v1.ResourceList {
+	var rv v1.ResourceList
+	for t, q := range rl {
+		rounded := RoundUpToKubeletPrecision(string(t), q)
+		if rv == nil {
+			if rounded.Cmp(q) == 0 {
+				continue
+			}
+			rv = make(v1.ResourceList, len(rl))
+			for t, q := range rl {
+				rv[t] = q
+			}
+		}
+		rv[t] = rounded
+	}
+	if rv == nil {
+		return rl
+	}
+	return rv
+}
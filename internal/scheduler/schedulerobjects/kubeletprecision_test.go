@@ -0,0 +1,161 @@
+package schedulerobjects
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRoundToKubeletPrecision(t *testing.T) {
+	tests := map[string]struct {
+		t                 string
+		q                 resource.Quantity
+		expectedRoundUp   resource.Quantity
+		expectedRoundDown resource.Quantity
+	}{
+		"whole cpu": {
+			t:                 "cpu",
+			q:                 resource.MustParse("2"),
+			expectedRoundUp:   resource.MustParse("2"),
+			expectedRoundDown: resource.MustParse("2"),
+		},
+		"whole millicores": {
+			t:                 "cpu",
+			q:                 resource.MustParse("500m"),
+			expectedRoundUp:   resource.MustParse("500m"),
+			expectedRoundDown: resource.MustParse("500m"),
+		},
+		"fractional millicores": {
+			t:                 "cpu",
+			q:                 resource.MustParse("499500u"),
+			expectedRoundUp:   resource.MustParse("500m"),
+			expectedRoundDown: resource.MustParse("499m"),
+		},
+		"whole bytes": {
+			t:                 "memory",
+			q:                 resource.MustParse("1Gi"),
+			expectedRoundUp:   resource.MustParse("1Gi"),
+			expectedRoundDown: resource.MustParse("1Gi"),
+		},
+		"fractional bytes": {
+			t:                 "memory",
+			q:                 resource.MustParse("1500m"),
+			expectedRoundUp:   resource.MustParse("2"),
+			expectedRoundDown: resource.MustParse("1"),
+		},
+		"fractional gpu": {
+			t:                 "nvidia.com/gpu",
+			q:                 resource.MustParse("500m"),
+			expectedRoundUp:   resource.MustParse("1"),
+			expectedRoundDown: resource.MustParse("0"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			roundedUp := RoundUpToKubeletPrecision(tc.t, tc.q)
+			assert.True(t, tc.expectedRoundUp.Equal(roundedUp), "expected %s, but got %s", tc.expectedRoundUp.String(), roundedUp.String())
+			roundedDown := RoundDownToKubeletPrecision(tc.t, tc.q)
+			assert.True(t, tc.expectedRoundDown.Equal(roundedDown), "expected %s, but got %s", tc.expectedRoundDown.String(), roundedDown.String())
+		})
+	}
+}
+
+func TestOvercommittedQuantity(t *testing.T) {
+	tests := map[string]struct {
+		t        string
+		q        resource.Quantity
+		factor   float64
+		expected resource.Quantity
+	}{
+		"no overcommit": {
+			t:        "cpu",
+			q:        resource.MustParse("32"),
+			factor:   1,
+			expected: resource.MustParse("32"),
+		},
+		"cpu": {
+			t:        "cpu",
+			q:        resource.MustParse("32"),
+			factor:   1.5,
+			expected: resource.MustParse("48"),
+		},
+		"cpu rounded down to millicores": {
+			t:        "cpu",
+			q:        resource.MustParse("1"),
+			factor:   1.0 / 3,
+			expected: resource.MustParse("333m"),
+		},
+		"memory rounded down to bytes": {
+			t:        "memory",
+			q:        resource.MustParse("10"),
+			factor:   1.25,
+			expected: resource.MustParse("12"),
+		},
+		"large memory": {
+			t:        "memory",
+			q:        resource.MustParse("256Gi"),
+			factor:   1.1,
+			expected: *resource.NewQuantity(302365697638, resource.BinarySI),
+		},
+		"invalid factor": {
+			t:        "cpu",
+			q:        resource.MustParse("1500500u"),
+			factor:   math.NaN(),
+			expected: resource.MustParse("1500m"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := OvercommittedQuantity(tc.t, tc.q, tc.factor)
+			assert.True(t, tc.expected.Equal(actual), "expected %s, but got %s", tc.expected.String(), actual.String())
+		})
+	}
+}
+
+func TestNodeWithOvercommit(t *testing.T) {
+	node := &Node{
+		TotalResources: ResourceList{
+			Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("10"),
+				"memory": resource.MustParse("10Gi"),
+			},
+		},
+		AllocatableByPriorityAndResource: map[int32]ResourceList{
+			0: {
+				Resources: map[string]resource.Quantity{
+					"cpu":    resource.MustParse("4"),
+					"memory": resource.MustParse("10Gi"),
+				},
+			},
+		},
+	}
+	originalTotal := node.TotalResources.DeepCopy()
+	originalAllocatable := node.AllocatableByPriorityAndResource[0].DeepCopy()
+
+	overcommitted := node.WithOvercommit(map[string]float64{"cpu": 1.5})
+	assert.True(
+		t,
+		overcommitted.TotalResources.Equal(ResourceList{
+			Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("15"),
+				"memory": resource.MustParse("10Gi"),
+			},
+		}),
+	)
+	// Allocatable resources increase by as much as total resources.
+	assert.True(
+		t,
+		overcommitted.AllocatableByPriorityAndResource[0].Equal(ResourceList{
+			Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("9"),
+				"memory": resource.MustParse("10Gi"),
+			},
+		}),
+	)
+	// The original node is left unchanged.
+	assert.True(t, originalTotal.Equal(node.TotalResources))
+	assert.True(t, originalAllocatable.Equal(node.AllocatableByPriorityAndResource[0]))
+	assert.Same(t, node, node.WithOvercommit(nil))
+}
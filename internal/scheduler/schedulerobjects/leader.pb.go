@@ -0,0 +1,626 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: internal/scheduler/schedulerobjects/leader.proto
+
+package schedulerobjects
+
+import (
+	context "context"
+	fmt "fmt"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type WhoIsLeaderRequest struct {
+}
+
+func (m *WhoIsLeaderRequest) Reset()         { *m = WhoIsLeaderRequest{} }
+func (m *WhoIsLeaderRequest) String() string { return proto.CompactTextString(m) }
+func (*WhoIsLeaderRequest) ProtoMessage()    {}
+func (*WhoIsLeaderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66f838e3b7f82386, []int{0}
+}
+func (m *WhoIsLeaderRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WhoIsLeaderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WhoIsLeaderRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WhoIsLeaderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WhoIsLeaderRequest.Merge(m, src)
+}
+func (m *WhoIsLeaderRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WhoIsLeaderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WhoIsLeaderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WhoIsLeaderRequest proto.InternalMessageInfo
+
+type WhoIsLeaderResponse struct {
+	// Identity of the current leader, e.g., its pod name.
+	LeaderName string `protobuf:"bytes,1,opt,name=leader_name,json=leaderName,proto3" json:"leaderName,omitempty"`
+	// Address at which the leader's gRPC server can be reached.
+	// Empty if no leader connection is configured.
+	LeaderAddress string `protobuf:"bytes,2,opt,name=leader_address,json=leaderAddress,proto3" json:"leaderAddress,omitempty"`
+	// True if the replica serving the request is the leader.
+	IsCurrentProcessLeader bool `protobuf:"varint,3,opt,name=is_current_process_leader,json=isCurrentProcessLeader,proto3" json:"isCurrentProcessLeader,omitempty"`
+}
+
+func (m *WhoIsLeaderResponse) Reset()         { *m = WhoIsLeaderResponse{} }
+func (m *WhoIsLeaderResponse) String() string { return proto.CompactTextString(m) }
+func (*WhoIsLeaderResponse) ProtoMessage()    {}
+func (*WhoIsLeaderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66f838e3b7f82386, []int{1}
+}
+func (m *WhoIsLeaderResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WhoIsLeaderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WhoIsLeaderResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WhoIsLeaderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WhoIsLeaderResponse.Merge(m, src)
+}
+func (m *WhoIsLeaderResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WhoIsLeaderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WhoIsLeaderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WhoIsLeaderResponse proto.InternalMessageInfo
+
+func (m *WhoIsLeaderResponse) GetLeaderName() string {
+	if m != nil {
+		return m.LeaderName
+	}
+	return ""
+}
+
+func (m *WhoIsLeaderResponse) GetLeaderAddress() string {
+	if m != nil {
+		return m.LeaderAddress
+	}
+	return ""
+}
+
+func (m *WhoIsLeaderResponse) GetIsCurrentProcessLeader() bool {
+	if m != nil {
+		return m.IsCurrentProcessLeader
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*WhoIsLeaderRequest)(nil), "schedulerobjects.WhoIsLeaderRequest")
+	proto.RegisterType((*WhoIsLeaderResponse)(nil), "schedulerobjects.WhoIsLeaderResponse")
+}
+
+func init() {
+	proto.RegisterFile("internal/scheduler/schedulerobjects/leader.proto", fileDescriptor_66f838e3b7f82386)
+}
+
+var fileDescriptor_66f838e3b7f82386 = []byte{
+	// 320 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xcf, 0x4a, 0xf3, 0x40,
+	0x14, 0xc5, 0x3b, 0xdf, 0x07, 0xa2, 0x53, 0xfc, 0xc3, 0x58, 0x34, 0x2a, 0x4c, 0x4b, 0xa9, 0xd0,
+	0x85, 0x24, 0xa2, 0x2b, 0x97, 0xa6, 0x2b, 0x41, 0x44, 0xea, 0x42, 0x28, 0x48, 0x98, 0x26, 0x17,
+	0x1b, 0xe9, 0x64, 0xe2, 0xdc, 0xc9, 0xc2, 0xb7, 0xf0, 0xb1, 0x5c, 0x76, 0xe9, 0xaa, 0x48, 0xbb,
+	0xcb, 0xde, 0xbd, 0x98, 0xa9, 0x34, 0xad, 0x82, 0xee, 0x66, 0xce, 0xf9, 0xdd, 0x03, 0xf7, 0x5c,
+	0x7a, 0x1c, 0x27, 0x06, 0x74, 0x22, 0x86, 0x1e, 0x86, 0x03, 0x88, 0xb2, 0x21, 0xe8, 0xf9, 0x4b,
+	0xf5, 0x1f, 0x20, 0x34, 0xe8, 0x0d, 0x41, 0x44, 0xa0, 0xdd, 0x54, 0x2b, 0xa3, 0xd8, 0xd6, 0xb2,
+	0xdd, 0xac, 0x51, 0x76, 0x3b, 0x50, 0x17, 0x78, 0x59, 0x60, 0x5d, 0x78, 0xcc, 0x00, 0x4d, 0xf3,
+	0x9d, 0xd0, 0xed, 0x05, 0x19, 0x53, 0x95, 0x20, 0xb0, 0x33, 0x5a, 0xb5, 0x79, 0x41, 0x22, 0x24,
+	0x38, 0xa4, 0x41, 0xda, 0x6b, 0xbe, 0x93, 0x8f, 0xeb, 0x35, 0x2b, 0x5f, 0x09, 0x09, 0x47, 0x4a,
+	0xc6, 0x06, 0x64, 0x6a, 0x9e, 0xba, 0x74, 0xae, 0x32, 0x9f, 0x6e, 0xcc, 0x46, 0x45, 0x14, 0x69,
+	0x40, 0x74, 0xfe, 0x15, 0xd3, 0x07, 0xf9, 0xb8, 0xbe, 0x6b, 0x9d, 0x73, 0x6b, 0x94, 0x02, 0xd6,
+	0x17, 0x0c, 0x16, 0xd0, 0xbd, 0x18, 0x83, 0x30, 0xd3, 0x1a, 0x12, 0x13, 0xa4, 0x5a, 0x85, 0x80,
+	0x18, 0x58, 0xc6, 0xf9, 0xdf, 0x20, 0xed, 0x55, 0xbf, 0x95, 0x8f, 0xeb, 0x8d, 0x18, 0x3b, 0x96,
+	0xb9, 0xb6, 0x88, 0xdd, 0xa2, 0x94, 0xbb, 0xf3, 0x33, 0x71, 0x22, 0xe9, 0xe6, 0xcd, 0x57, 0x43,
+	0x56, 0x62, 0x3d, 0x5a, 0x2d, 0x35, 0xc1, 0x5a, 0xee, 0x72, 0x85, 0xee, 0xf7, 0xfe, 0xf6, 0x0f,
+	0x7f, 0xa1, 0x6c, 0x9d, 0xfe, 0xdd, 0xcb, 0x84, 0x93, 0xd1, 0x84, 0x93, 0xb7, 0x09, 0x27, 0xcf,
+	0x53, 0x5e, 0x19, 0x4d, 0x79, 0xe5, 0x75, 0xca, 0x2b, 0xbd, 0xce, 0x7d, 0x6c, 0x06, 0x59, 0xdf,
+	0x0d, 0x95, 0xf4, 0x84, 0x96, 0x22, 0x12, 0xa9, 0x56, 0x9f, 0x41, 0xb3, 0x9f, 0xf7, 0x87, 0xd3,
+	0xf7, 0x57, 0x8a, 0xa3, 0x9f, 0x7e, 0x0c, 0x00, 0x81, 0xce, 0x73, 0x22, 0x28, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SchedulerLeaderClient is the client API for SchedulerLeader service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SchedulerLeaderClient interface {
+	WhoIsLeader(ctx context.Context, in *WhoIsLeaderRequest, opts ...grpc.CallOption) (*WhoIsLeaderResponse, error)
+}
+
+type schedulerLeaderClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSchedulerLeaderClient(cc *grpc.ClientConn) SchedulerLeaderClient {
+	return &schedulerLeaderClient{cc}
+}
+
+func (c *schedulerLeaderClient) WhoIsLeader(ctx context.Context, in *WhoIsLeaderRequest, opts ...grpc.CallOption) (*WhoIsLeaderResponse, error) {
+	out := new(WhoIsLeaderResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerLeader/WhoIsLeader", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchedulerLeaderServer is the server API for SchedulerLeader service.
+type SchedulerLeaderServer interface {
+	WhoIsLeader(context.Context, *WhoIsLeaderRequest) (*WhoIsLeaderResponse, error)
+}
+
+// UnimplementedSchedulerLeaderServer can be embedded to have forward compatible implementations.
+type UnimplementedSchedulerLeaderServer struct {
+}
+
+func (*UnimplementedSchedulerLeaderServer) WhoIsLeader(ctx context.Context, req *WhoIsLeaderRequest) (*WhoIsLeaderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WhoIsLeader not implemented")
+}
+
+func RegisterSchedulerLeaderServer(s *grpc.Server, srv SchedulerLeaderServer) {
+	s.RegisterService(&_SchedulerLeader_serviceDesc, srv)
+}
+
+func _SchedulerLeader_WhoIsLeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhoIsLeaderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerLeaderServer).WhoIsLeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerLeader/WhoIsLeader",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerLeaderServer).WhoIsLeader(ctx, req.(*WhoIsLeaderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SchedulerLeader_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "schedulerobjects.SchedulerLeader",
+	HandlerType: (*SchedulerLeaderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WhoIsLeader",
+			Handler:    _SchedulerLeader_WhoIsLeader_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/scheduler/schedulerobjects/leader.proto",
+}
+
+func (m *WhoIsLeaderRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WhoIsLeaderRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WhoIsLeaderRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *WhoIsLeaderResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WhoIsLeaderResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WhoIsLeaderResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IsCurrentProcessLeader {
+		i--
+		if m.IsCurrentProcessLeader {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.LeaderAddress) > 0 {
+		i -= len(m.LeaderAddress)
+		copy(dAtA[i:], m.LeaderAddress)
+		i = encodeVarintLeader(dAtA, i, uint64(len(m.LeaderAddress)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.LeaderName) > 0 {
+		i -= len(m.LeaderName)
+		copy(dAtA[i:], m.LeaderName)
+		i = encodeVarintLeader(dAtA, i, uint64(len(m.LeaderName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLeader(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLeader(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *WhoIsLeaderRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *WhoIsLeaderResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LeaderName)
+	if l > 0 {
+		n += 1 + l + sovLeader(uint64(l))
+	}
+	l = len(m.LeaderAddress)
+	if l > 0 {
+		n += 1 + l + sovLeader(uint64(l))
+	}
+	if m.IsCurrentProcessLeader {
+		n += 2
+	}
+	return n
+}
+
+func sovLeader(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLeader(x uint64) (n int) {
+	return sovLeader(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *WhoIsLeaderRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLeader
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WhoIsLeaderRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WhoIsLeaderRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLeader(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLeader
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WhoIsLeaderResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLeader
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WhoIsLeaderResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WhoIsLeaderResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLeader
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLeader
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLeader
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LeaderName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLeader
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLeader
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLeader
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LeaderAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsCurrentProcessLeader", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLeader
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsCurrentProcessLeader = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLeader(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLeader
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipLeader(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowLeader
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLeader
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLeader
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthLeader
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupLeader
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthLeader
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthLeader        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowLeader          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupLeader = fmt.Errorf("proto: unexpected end of group")
+)
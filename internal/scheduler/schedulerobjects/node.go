@@ -55,3 +55,26 @@ func (node *Node) AvailableArmadaResource() ResourceList {
 	}
 	return tr
 }
+
+// WithOvercommit returns a copy of node with the total amount of each resource for which overcommitFactorByResource
+// contains a factor multiplied by that factor, rounded down to kubelet precision. The resources allocatable at each
+// priority are increased by the same amount as the total. If overcommitFactorByResource is empty, node is returned as is.
+func (node *Node) WithOvercommit(overcommitFactorByResource map[string]float64) *Node {
+	if len(overcommitFactorByResource) == 0 {
+		return node
+	}
+	extra := NewResourceList(len(overcommitFactorByResource))
+	for t, factor := range overcommitFactorByResource {
+		total := node.TotalResources.Get(t)
+		overcommitted := OvercommittedQuantity(t, total, factor).DeepCopy()
+		overcommitted.Sub(total)
+		extra.Set(t, overcommitted)
+	}
+	node = node.DeepCopy()
+	node.TotalResources.Add(extra)
+	for p, allocatable := range node.AllocatableByPriorityAndResource {
+		allocatable.Add(extra)
+		node.AllocatableByPriorityAndResource[p] = allocatable
+	}
+	return node
+}
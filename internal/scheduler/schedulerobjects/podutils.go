@@ -269,3 +269,25 @@ func lessNodeSelectorRequirement(a, b v1.NodeSelectorRequirement) bool {
 	}
 	return true
 }
+
+// MissingTolerations returns those of tolerations that don't match, i.e., aren't identical to apart from tolerationSeconds,
+// any of existing or any toleration preceding them in tolerations. Appending the result to existing hence adds tolerations
+// without duplicating any. Returns nil if there are none.
+func MissingTolerations(existing []v1.Toleration, tolerations []v1.Toleration) []v1.Toleration {
+	var rv []v1.Toleration
+	for i := range tolerations {
+		if !matchesAnyToleration(&tolerations[i], existing) && !matchesAnyToleration(&tolerations[i], rv) {
+			rv = append(rv, tolerations[i])
+		}
+	}
+	return rv
+}
+
+func matchesAnyToleration(toleration *v1.Toleration, tolerations []v1.Toleration) bool {
+	for i := range tolerations {
+		if toleration.MatchToleration(&tolerations[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -339,3 +339,45 @@ func getBenchmarkJobSchedulingSchedulingInfoWithAffinity() *JobSchedulingInfo {
 		},
 	}
 }
+
+func TestMissingTolerations(t *testing.T) {
+	batch := v1.Toleration{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "batch", Effect: v1.TaintEffectNoSchedule}
+	gpu := v1.Toleration{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule}
+	tolerationSeconds := int64(60)
+	batchWithSeconds := batch
+	batchWithSeconds.TolerationSeconds = &tolerationSeconds
+	tests := map[string]struct {
+		existing    []v1.Toleration
+		tolerations []v1.Toleration
+		expected    []v1.Toleration
+	}{
+		"none existing": {
+			tolerations: []v1.Toleration{batch, gpu},
+			expected:    []v1.Toleration{batch, gpu},
+		},
+		"some existing": {
+			existing:    []v1.Toleration{gpu},
+			tolerations: []v1.Toleration{batch, gpu},
+			expected:    []v1.Toleration{batch},
+		},
+		"all existing": {
+			existing:    []v1.Toleration{gpu, batch},
+			tolerations: []v1.Toleration{batch, gpu},
+			expected:    nil,
+		},
+		"existing differs only in tolerationSeconds": {
+			existing:    []v1.Toleration{batchWithSeconds},
+			tolerations: []v1.Toleration{batch},
+			expected:    nil,
+		},
+		"duplicates among tolerations": {
+			tolerations: []v1.Toleration{batch, batch},
+			expected:    []v1.Toleration{batch},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MissingTolerations(tc.existing, tc.tolerations))
+		})
+	}
+}
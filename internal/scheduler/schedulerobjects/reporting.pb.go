@@ -117,7 +117,6 @@ func (m *MostRecentForJob) GetJobId() string {
 
 type SchedulingReportRequest struct {
 	// Types that are valid to be assigned to Filter:
-	//
 	//	*SchedulingReportRequest_MostRecentForQueue
 	//	*SchedulingReportRequest_MostRecentForJob
 	Filter    isSchedulingReportRequest_Filter `protobuf_oneof:"filter"`
@@ -437,6 +436,289 @@ func (m *JobReport) GetReport() string {
 	return ""
 }
 
+type JobSetReportRequest struct {
+	Queue    string `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	JobSetId string `protobuf:"bytes,2,opt,name=job_set_id,json=jobSetId,proto3" json:"jobSetId,omitempty"`
+}
+
+func (m *JobSetReportRequest) Reset()         { *m = JobSetReportRequest{} }
+func (m *JobSetReportRequest) String() string { return proto.CompactTextString(m) }
+func (*JobSetReportRequest) ProtoMessage()    {}
+func (*JobSetReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{8}
+}
+func (m *JobSetReportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobSetReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobSetReportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobSetReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobSetReportRequest.Merge(m, src)
+}
+func (m *JobSetReportRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobSetReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobSetReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobSetReportRequest proto.InternalMessageInfo
+
+func (m *JobSetReportRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *JobSetReportRequest) GetJobSetId() string {
+	if m != nil {
+		return m.JobSetId
+	}
+	return ""
+}
+
+type JobSetReport struct {
+	Report string `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (m *JobSetReport) Reset()         { *m = JobSetReport{} }
+func (m *JobSetReport) String() string { return proto.CompactTextString(m) }
+func (*JobSetReport) ProtoMessage()    {}
+func (*JobSetReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{9}
+}
+func (m *JobSetReport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobSetReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobSetReport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobSetReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobSetReport.Merge(m, src)
+}
+func (m *JobSetReport) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobSetReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobSetReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobSetReport proto.InternalMessageInfo
+
+func (m *JobSetReport) GetReport() string {
+	if m != nil {
+		return m.Report
+	}
+	return ""
+}
+
+type EventRateReportRequest struct {
+	// If non-empty, only job sets of this queue are included.
+	Queue string `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	// Maximum number of job sets to include. If zero, the configured default is used.
+	NumJobSets int32 `protobuf:"varint,2,opt,name=num_job_sets,json=numJobSets,proto3" json:"numJobSets,omitempty"`
+}
+
+func (m *EventRateReportRequest) Reset()         { *m = EventRateReportRequest{} }
+func (m *EventRateReportRequest) String() string { return proto.CompactTextString(m) }
+func (*EventRateReportRequest) ProtoMessage()    {}
+func (*EventRateReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{10}
+}
+func (m *EventRateReportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventRateReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventRateReportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventRateReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventRateReportRequest.Merge(m, src)
+}
+func (m *EventRateReportRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventRateReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventRateReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventRateReportRequest proto.InternalMessageInfo
+
+func (m *EventRateReportRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *EventRateReportRequest) GetNumJobSets() int32 {
+	if m != nil {
+		return m.NumJobSets
+	}
+	return 0
+}
+
+type EventRateReport struct {
+	Report string `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (m *EventRateReport) Reset()         { *m = EventRateReport{} }
+func (m *EventRateReport) String() string { return proto.CompactTextString(m) }
+func (*EventRateReport) ProtoMessage()    {}
+func (*EventRateReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{11}
+}
+func (m *EventRateReport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventRateReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventRateReport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventRateReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventRateReport.Merge(m, src)
+}
+func (m *EventRateReport) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventRateReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventRateReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventRateReport proto.InternalMessageInfo
+
+func (m *EventRateReport) GetReport() string {
+	if m != nil {
+		return m.Report
+	}
+	return ""
+}
+
+type FragmentationReportRequest struct {
+	// If non-empty, only executor groups of this pool are included.
+	Pool string `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+}
+
+func (m *FragmentationReportRequest) Reset()         { *m = FragmentationReportRequest{} }
+func (m *FragmentationReportRequest) String() string { return proto.CompactTextString(m) }
+func (*FragmentationReportRequest) ProtoMessage()    {}
+func (*FragmentationReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{12}
+}
+func (m *FragmentationReportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FragmentationReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FragmentationReportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FragmentationReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FragmentationReportRequest.Merge(m, src)
+}
+func (m *FragmentationReportRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FragmentationReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FragmentationReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FragmentationReportRequest proto.InternalMessageInfo
+
+func (m *FragmentationReportRequest) GetPool() string {
+	if m != nil {
+		return m.Pool
+	}
+	return ""
+}
+
+type FragmentationReport struct {
+	Report string `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (m *FragmentationReport) Reset()         { *m = FragmentationReport{} }
+func (m *FragmentationReport) String() string { return proto.CompactTextString(m) }
+func (*FragmentationReport) ProtoMessage()    {}
+func (*FragmentationReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{13}
+}
+func (m *FragmentationReport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FragmentationReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FragmentationReport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FragmentationReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FragmentationReport.Merge(m, src)
+}
+func (m *FragmentationReport) XXX_Size() int {
+	return m.Size()
+}
+func (m *FragmentationReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_FragmentationReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FragmentationReport proto.InternalMessageInfo
+
+func (m *FragmentationReport) GetReport() string {
+	if m != nil {
+		return m.Report
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*MostRecentForQueue)(nil), "schedulerobjects.MostRecentForQueue")
 	proto.RegisterType((*MostRecentForJob)(nil), "schedulerobjects.MostRecentForJob")
@@ -446,6 +728,12 @@ func init() {
 	proto.RegisterType((*QueueReport)(nil), "schedulerobjects.QueueReport")
 	proto.RegisterType((*JobReportRequest)(nil), "schedulerobjects.JobReportRequest")
 	proto.RegisterType((*JobReport)(nil), "schedulerobjects.JobReport")
+	proto.RegisterType((*JobSetReportRequest)(nil), "schedulerobjects.JobSetReportRequest")
+	proto.RegisterType((*JobSetReport)(nil), "schedulerobjects.JobSetReport")
+	proto.RegisterType((*EventRateReportRequest)(nil), "schedulerobjects.EventRateReportRequest")
+	proto.RegisterType((*EventRateReport)(nil), "schedulerobjects.EventRateReport")
+	proto.RegisterType((*FragmentationReportRequest)(nil), "schedulerobjects.FragmentationReportRequest")
+	proto.RegisterType((*FragmentationReport)(nil), "schedulerobjects.FragmentationReport")
 }
 
 func init() {
@@ -453,39 +741,51 @@ func init() {
 }
 
 var fileDescriptor_131a439a3ff6540b = []byte{
-	// 512 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x5f, 0x6b, 0xd3, 0x50,
-	0x14, 0x6f, 0x2a, 0x2b, 0xf6, 0x4c, 0x34, 0xdc, 0x2a, 0x1b, 0x55, 0x93, 0x12, 0x7c, 0x98, 0x32,
-	0x5a, 0xd8, 0x50, 0x10, 0x61, 0x48, 0x04, 0xab, 0xc5, 0x3f, 0x98, 0xe1, 0x8b, 0x20, 0x21, 0xb7,
-	0x39, 0xeb, 0x52, 0x9a, 0x9c, 0xee, 0xe6, 0x46, 0x18, 0x3e, 0xfa, 0x05, 0xfc, 0x0c, 0x7e, 0x1a,
-	0x1f, 0x7c, 0xd8, 0xa3, 0x4f, 0x41, 0xda, 0xb7, 0x7c, 0x0a, 0x59, 0xda, 0xb5, 0xf9, 0x33, 0xb7,
-	0xd5, 0xb7, 0xe4, 0x77, 0xcf, 0x3d, 0xbf, 0xdf, 0x39, 0xbf, 0x73, 0x2e, 0xec, 0x7a, 0x81, 0x44,
-	0x11, 0x38, 0xa3, 0x4e, 0xd8, 0x3f, 0x44, 0x37, 0x1a, 0xa1, 0x58, 0x7e, 0x11, 0x1f, 0x62, 0x5f,
-	0x86, 0x1d, 0x81, 0x63, 0x12, 0xd2, 0x0b, 0x06, 0xed, 0xb1, 0x20, 0x49, 0x4c, 0x2d, 0x46, 0x18,
-	0x6f, 0x80, 0xbd, 0xa5, 0x50, 0x5a, 0xd8, 0xc7, 0x40, 0xbe, 0x24, 0xf1, 0x21, 0xc2, 0x08, 0xd9,
-	0x13, 0x80, 0xa3, 0xd3, 0x0f, 0x3b, 0x70, 0x7c, 0xdc, 0x54, 0x5a, 0xca, 0x56, 0xdd, 0xdc, 0x48,
-	0x62, 0xbd, 0x91, 0xa2, 0xef, 0x1c, 0x1f, 0xb7, 0xc9, 0xf7, 0x24, 0xfa, 0x63, 0x79, 0x6c, 0xd5,
-	0x17, 0xa0, 0xb1, 0x07, 0x6a, 0x2e, 0x5b, 0x8f, 0x38, 0x7b, 0x04, 0xb5, 0x21, 0x71, 0xdb, 0x73,
-	0xe7, 0x79, 0x1a, 0x49, 0xac, 0xdf, 0x1a, 0x12, 0x7f, 0xed, 0x66, 0x72, 0xac, 0xa5, 0x80, 0xf1,
-	0xab, 0x0a, 0x1b, 0xfb, 0x33, 0x89, 0x5e, 0x30, 0xb0, 0x52, 0xf5, 0x16, 0x1e, 0x45, 0x18, 0x4a,
-	0xf6, 0x15, 0xee, 0xf8, 0x14, 0x4a, 0x5b, 0xa4, 0xc9, 0xed, 0x03, 0x12, 0x76, 0x4a, 0x9c, 0xa6,
-	0x5d, 0xdf, 0x79, 0xd0, 0x2e, 0xd6, 0xd6, 0x2e, 0x17, 0x66, 0xb6, 0x92, 0x58, 0xbf, 0xe7, 0x97,
-	0xf0, 0xa5, 0x92, 0x57, 0x15, 0x8b, 0x95, 0xcf, 0x59, 0x08, 0x8d, 0x22, 0xf9, 0x90, 0xf8, 0x66,
-	0x35, 0xa5, 0x36, 0x2e, 0xa1, 0xee, 0x11, 0x37, 0xb5, 0x24, 0xd6, 0x9b, 0x7e, 0x01, 0xcd, 0xd1,
-	0xaa, 0xc5, 0x53, 0xf6, 0x18, 0xea, 0x5f, 0x50, 0x70, 0x0a, 0x3d, 0x79, 0xbc, 0x79, 0xad, 0xa5,
-	0x6c, 0xad, 0xcd, 0x4c, 0x58, 0x80, 0x59, 0x13, 0x16, 0xa0, 0x79, 0x1d, 0x6a, 0x07, 0xde, 0x48,
-	0xa2, 0x30, 0x9e, 0x83, 0x5a, 0xec, 0x26, 0xdb, 0x86, 0xda, 0x6c, 0x2a, 0xe6, 0x76, 0xdc, 0x4e,
-	0x62, 0x5d, 0x9d, 0x21, 0x99, 0x74, 0xf3, 0x18, 0xe3, 0x9b, 0x02, 0x2c, 0xed, 0x40, 0xde, 0x8b,
-	0xff, 0x9c, 0x8f, 0x7c, 0x45, 0xd5, 0xab, 0x56, 0x64, 0x3c, 0x83, 0xf5, 0x8c, 0x88, 0x15, 0x4b,
-	0xd8, 0x03, 0xb5, 0x47, 0x3c, 0xaf, 0x7f, 0x95, 0x99, 0x7c, 0x0a, 0xf5, 0xc5, 0xfd, 0xd5, 0xa8,
-	0x77, 0x7e, 0x54, 0x81, 0xed, 0x9f, 0x8d, 0x86, 0x75, 0xb6, 0x8b, 0xcc, 0x85, 0x46, 0x17, 0x65,
-	0xc9, 0x99, 0x87, 0xe5, 0x31, 0xfa, 0xc7, 0x2e, 0x34, 0x8d, 0xcb, 0x43, 0xd9, 0x47, 0xb8, 0xd9,
-	0x45, 0x99, 0xed, 0xdb, 0x39, 0x2b, 0x52, 0xf6, 0xb6, 0x79, 0xff, 0xc2, 0x28, 0xf6, 0x1e, 0x6e,
-	0x74, 0x51, 0x2e, 0x3b, 0x72, 0x8e, 0x94, 0x62, 0xbb, 0x9b, 0x77, 0x2f, 0x88, 0x31, 0x3f, 0xff,
-	0x9c, 0x68, 0xca, 0xc9, 0x44, 0x53, 0xfe, 0x4c, 0x34, 0xe5, 0xfb, 0x54, 0xab, 0x9c, 0x4c, 0xb5,
-	0xca, 0xef, 0xa9, 0x56, 0xf9, 0xf4, 0x62, 0xe0, 0xc9, 0xc3, 0x88, 0xb7, 0xfb, 0xe4, 0x77, 0x1c,
-	0xe1, 0x3b, 0xae, 0x33, 0x16, 0x74, 0x7a, 0x7d, 0xfe, 0xd7, 0xb9, 0xc2, 0x13, 0xc8, 0x6b, 0xe9,
-	0xcb, 0xb7, 0xfb, 0x37, 0x00, 0x00, 0xff, 0xff, 0x09, 0x81, 0x96, 0x21, 0x30, 0x05, 0x00, 0x00,
+	// 700 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x5f, 0x4b, 0xdb, 0x5e,
+	0x18, 0x6e, 0xfa, 0xd3, 0x62, 0x5f, 0x45, 0xcb, 0xa9, 0x3f, 0x2d, 0xd9, 0x96, 0xb8, 0x30, 0x87,
+	0x0e, 0x69, 0x41, 0xb7, 0xc1, 0xfe, 0xe0, 0x46, 0xdd, 0xec, 0x56, 0xf6, 0x87, 0x45, 0x06, 0x63,
+	0x30, 0x4a, 0xd2, 0xbe, 0xd6, 0x94, 0x26, 0xa7, 0x9e, 0x9c, 0x08, 0xb2, 0x8b, 0x5d, 0xec, 0x0b,
+	0xec, 0x63, 0xed, 0x62, 0x0c, 0x2f, 0x77, 0x15, 0x86, 0xde, 0xe5, 0x53, 0x8c, 0x26, 0xb1, 0xcd,
+	0x9f, 0xaa, 0xad, 0x77, 0xc9, 0x73, 0x9e, 0xf3, 0xbc, 0xcf, 0x9b, 0xf3, 0xbc, 0x87, 0xc0, 0x96,
+	0x61, 0x71, 0x64, 0x96, 0xd6, 0xad, 0xd8, 0xcd, 0x03, 0x6c, 0x39, 0x5d, 0x64, 0xc3, 0x27, 0xaa,
+	0x77, 0xb0, 0xc9, 0xed, 0x0a, 0xc3, 0x1e, 0x65, 0xdc, 0xb0, 0xda, 0xe5, 0x1e, 0xa3, 0x9c, 0x92,
+	0x42, 0x92, 0xa1, 0xbc, 0x01, 0xf2, 0x96, 0xda, 0x5c, 0xc5, 0x26, 0x5a, 0x7c, 0x97, 0xb2, 0x0f,
+	0x0e, 0x3a, 0x48, 0x1e, 0x02, 0x1c, 0xf6, 0x1f, 0x1a, 0x96, 0x66, 0x62, 0x49, 0x58, 0x11, 0xd6,
+	0xf2, 0xd5, 0x65, 0xcf, 0x95, 0x8b, 0x3e, 0xfa, 0x4e, 0x33, 0x71, 0x83, 0x9a, 0x06, 0x47, 0xb3,
+	0xc7, 0x8f, 0xd5, 0xfc, 0x00, 0x54, 0xb6, 0xa1, 0x10, 0x53, 0xab, 0x53, 0x9d, 0xdc, 0x83, 0x5c,
+	0x87, 0xea, 0x0d, 0xa3, 0x15, 0xea, 0x14, 0x3d, 0x57, 0x5e, 0xe8, 0x50, 0xfd, 0x75, 0x2b, 0xa2,
+	0x31, 0xed, 0x03, 0xca, 0xaf, 0x2c, 0x2c, 0xef, 0x05, 0x16, 0x0d, 0xab, 0xad, 0xfa, 0xee, 0x55,
+	0x3c, 0x74, 0xd0, 0xe6, 0xe4, 0x2b, 0xfc, 0x6f, 0x52, 0x9b, 0x37, 0x98, 0x2f, 0xde, 0xd8, 0xa7,
+	0xac, 0xe1, 0x17, 0xf6, 0x65, 0x67, 0x37, 0xef, 0x94, 0x93, 0xbd, 0x95, 0xd3, 0x8d, 0x55, 0x57,
+	0x3c, 0x57, 0xbe, 0x69, 0xa6, 0xf0, 0xa1, 0x93, 0x57, 0x19, 0x95, 0xa4, 0xd7, 0x89, 0x0d, 0xc5,
+	0x64, 0xf1, 0x0e, 0xd5, 0x4b, 0x59, 0xbf, 0xb4, 0x72, 0x45, 0xe9, 0x3a, 0xd5, 0xab, 0x92, 0xe7,
+	0xca, 0xa2, 0x99, 0x40, 0x63, 0x65, 0x0b, 0xc9, 0x55, 0xf2, 0x00, 0xf2, 0x47, 0xc8, 0x74, 0x6a,
+	0x1b, 0xfc, 0xb8, 0xf4, 0xdf, 0x8a, 0xb0, 0x36, 0x1d, 0x1c, 0xc2, 0x00, 0x8c, 0x1e, 0xc2, 0x00,
+	0xac, 0xce, 0x40, 0x6e, 0xdf, 0xe8, 0x72, 0x64, 0xca, 0x73, 0x28, 0x24, 0xbf, 0x26, 0xd9, 0x80,
+	0x5c, 0x90, 0x8a, 0xf0, 0x38, 0x16, 0x3d, 0x57, 0x2e, 0x04, 0x48, 0x44, 0x2e, 0xe4, 0x28, 0xdf,
+	0x05, 0x20, 0xfe, 0x17, 0x88, 0x9f, 0xc5, 0x35, 0xf3, 0x11, 0xef, 0x28, 0x3b, 0x6e, 0x47, 0xca,
+	0x13, 0x98, 0x8d, 0x98, 0x98, 0xb0, 0x85, 0x6d, 0x28, 0xd4, 0xa9, 0x1e, 0xf7, 0x3f, 0x49, 0x26,
+	0x1f, 0x41, 0x7e, 0xb0, 0x7f, 0xc2, 0xd2, 0x47, 0x50, 0xac, 0x53, 0x7d, 0x0f, 0x79, 0xbc, 0xfa,
+	0x3a, 0x4c, 0x0f, 0x93, 0x1b, 0x16, 0x3f, 0x8c, 0xc7, 0x50, 0x0d, 0x18, 0xe4, 0x3e, 0x40, 0xdf,
+	0xa8, 0x8d, 0xbc, 0x6f, 0x36, 0xeb, 0xf3, 0x97, 0x3c, 0x57, 0x26, 0x1d, 0x5f, 0x37, 0xe6, 0x77,
+	0xe6, 0x1c, 0x53, 0x9e, 0xc2, 0x5c, 0xb4, 0xee, 0x84, 0xae, 0xbf, 0xc1, 0xd2, 0xcb, 0x23, 0xb4,
+	0xb8, 0xaa, 0x71, 0xbc, 0xb6, 0xf1, 0xc7, 0x30, 0x67, 0x39, 0x66, 0x23, 0x34, 0x6f, 0x87, 0x87,
+	0x5d, 0xf2, 0x5c, 0x79, 0xd1, 0x72, 0xcc, 0xc0, 0x9d, 0x1d, 0xd9, 0x06, 0x43, 0x54, 0x79, 0x06,
+	0x0b, 0x09, 0x03, 0x13, 0x76, 0xf0, 0x02, 0xc4, 0x5d, 0xa6, 0xb5, 0x4d, 0xb4, 0xb8, 0xc6, 0x0d,
+	0x6a, 0xc5, 0xbb, 0xb8, 0x0b, 0x53, 0x3d, 0x4a, 0xbb, 0xa1, 0x12, 0xf1, 0x5c, 0x79, 0xbe, 0xff,
+	0x1e, 0xd1, 0xf1, 0xd7, 0x95, 0x1d, 0x28, 0x8e, 0x50, 0x99, 0xcc, 0xca, 0xe6, 0xef, 0x29, 0x20,
+	0x7b, 0xe7, 0xb7, 0x83, 0x7a, 0x7e, 0x1d, 0x93, 0x16, 0x14, 0x6b, 0xc8, 0x53, 0xc3, 0xb9, 0x9e,
+	0xbe, 0x49, 0x2e, 0xb8, 0x0e, 0x45, 0xe5, 0x6a, 0x2a, 0xf9, 0x08, 0xf3, 0x35, 0xe4, 0xd1, 0xd1,
+	0x19, 0x71, 0x4b, 0xa6, 0xc7, 0x5b, 0xbc, 0x75, 0x29, 0x8b, 0xbc, 0x87, 0xb9, 0x1a, 0xf2, 0xe1,
+	0x50, 0x8c, 0xb0, 0x92, 0x9c, 0x38, 0xf1, 0xc6, 0x25, 0x1c, 0xf2, 0x09, 0x16, 0x02, 0xc1, 0x61,
+	0x64, 0x57, 0x47, 0xf2, 0x93, 0xa3, 0x24, 0x4a, 0x97, 0xd3, 0x88, 0x06, 0xa4, 0x86, 0x3c, 0x99,
+	0xa6, 0xb5, 0xf4, 0xae, 0xd1, 0x89, 0x17, 0x6f, 0x5f, 0xc9, 0x24, 0x26, 0x2c, 0xd5, 0x90, 0x8f,
+	0x4c, 0x4a, 0x7a, 0xf3, 0xc5, 0xb1, 0x14, 0x57, 0xc7, 0x62, 0x57, 0xbf, 0xfc, 0x3c, 0x95, 0x84,
+	0x93, 0x53, 0x49, 0xf8, 0x7b, 0x2a, 0x09, 0x3f, 0xce, 0xa4, 0xcc, 0xc9, 0x99, 0x94, 0xf9, 0x73,
+	0x26, 0x65, 0x3e, 0xef, 0xb4, 0x0d, 0x7e, 0xe0, 0xe8, 0xe5, 0x26, 0x35, 0x2b, 0x1a, 0x33, 0xb5,
+	0x96, 0xd6, 0x63, 0xb4, 0x2f, 0x14, 0xbe, 0x55, 0xc6, 0xf8, 0x63, 0xd0, 0x73, 0xfe, 0x8f, 0xc2,
+	0xd6, 0xbf, 0x01, 0x00, 0x99, 0x63, 0xf9, 0x94, 0x5f, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -506,6 +806,13 @@ type SchedulerReportingClient interface {
 	GetQueueReport(ctx context.Context, in *QueueReportRequest, opts ...grpc.CallOption) (*QueueReport, error)
 	// Return the most recent scheduling report for each executor for the given job.
 	GetJobReport(ctx context.Context, in *JobReportRequest, opts ...grpc.CallOption) (*JobReport, error)
+	// Return the number of distinct nodes, executors, and zones the runs of the given job set were placed on.
+	GetJobSetReport(ctx context.Context, in *JobSetReportRequest, opts ...grpc.CallOption) (*JobSetReport, error)
+	// Return the per-queue rates of job events and the job sets with the most events over the recent window.
+	GetEventRateReport(ctx context.Context, in *EventRateReportRequest, opts ...grpc.CallOption) (*EventRateReport, error)
+	// Return how fragmented the free capacity of each pool is and which preemptible jobs would need to move
+	// to unblock the largest request blocked solely by fragmentation. Recommendations are advisory only.
+	GetFragmentationReport(ctx context.Context, in *FragmentationReportRequest, opts ...grpc.CallOption) (*FragmentationReport, error)
 }
 
 type schedulerReportingClient struct {
@@ -543,6 +850,33 @@ func (c *schedulerReportingClient) GetJobReport(ctx context.Context, in *JobRepo
 	return out, nil
 }
 
+func (c *schedulerReportingClient) GetJobSetReport(ctx context.Context, in *JobSetReportRequest, opts ...grpc.CallOption) (*JobSetReport, error) {
+	out := new(JobSetReport)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetJobSetReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) GetEventRateReport(ctx context.Context, in *EventRateReportRequest, opts ...grpc.CallOption) (*EventRateReport, error) {
+	out := new(EventRateReport)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetEventRateReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) GetFragmentationReport(ctx context.Context, in *FragmentationReportRequest, opts ...grpc.CallOption) (*FragmentationReport, error) {
+	out := new(FragmentationReport)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetFragmentationReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SchedulerReportingServer is the server API for SchedulerReporting service.
 type SchedulerReportingServer interface {
 	// Return the most recent scheduling report for each executor.
@@ -551,6 +885,13 @@ type SchedulerReportingServer interface {
 	GetQueueReport(context.Context, *QueueReportRequest) (*QueueReport, error)
 	// Return the most recent scheduling report for each executor for the given job.
 	GetJobReport(context.Context, *JobReportRequest) (*JobReport, error)
+	// Return the number of distinct nodes, executors, and zones the runs of the given job set were placed on.
+	GetJobSetReport(context.Context, *JobSetReportRequest) (*JobSetReport, error)
+	// Return the per-queue rates of job events and the job sets with the most events over the recent window.
+	GetEventRateReport(context.Context, *EventRateReportRequest) (*EventRateReport, error)
+	// Return how fragmented the free capacity of each pool is and which preemptible jobs would need to move
+	// to unblock the largest request blocked solely by fragmentation. Recommendations are advisory only.
+	GetFragmentationReport(context.Context, *FragmentationReportRequest) (*FragmentationReport, error)
 }
 
 // UnimplementedSchedulerReportingServer can be embedded to have forward compatible implementations.
@@ -566,6 +907,15 @@ func (*UnimplementedSchedulerReportingServer) GetQueueReport(ctx context.Context
 func (*UnimplementedSchedulerReportingServer) GetJobReport(ctx context.Context, req *JobReportRequest) (*JobReport, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetJobReport not implemented")
 }
+func (*UnimplementedSchedulerReportingServer) GetJobSetReport(ctx context.Context, req *JobSetReportRequest) (*JobSetReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobSetReport not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) GetEventRateReport(ctx context.Context, req *EventRateReportRequest) (*EventRateReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventRateReport not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) GetFragmentationReport(ctx context.Context, req *FragmentationReportRequest) (*FragmentationReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFragmentationReport not implemented")
+}
 
 func RegisterSchedulerReportingServer(s *grpc.Server, srv SchedulerReportingServer) {
 	s.RegisterService(&_SchedulerReporting_serviceDesc, srv)
@@ -625,6 +975,60 @@ func _SchedulerReporting_GetJobReport_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SchedulerReporting_GetJobSetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobSetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetJobSetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetJobSetReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetJobSetReport(ctx, req.(*JobSetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_GetEventRateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventRateReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetEventRateReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetEventRateReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetEventRateReport(ctx, req.(*EventRateReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_GetFragmentationReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FragmentationReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetFragmentationReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetFragmentationReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetFragmentationReport(ctx, req.(*FragmentationReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SchedulerReporting_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "schedulerobjects.SchedulerReporting",
 	HandlerType: (*SchedulerReportingServer)(nil),
@@ -641,6 +1045,18 @@ var _SchedulerReporting_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetJobReport",
 			Handler:    _SchedulerReporting_GetJobReport_Handler,
 		},
+		{
+			MethodName: "GetJobSetReport",
+			Handler:    _SchedulerReporting_GetJobSetReport_Handler,
+		},
+		{
+			MethodName: "GetEventRateReport",
+			Handler:    _SchedulerReporting_GetEventRateReport_Handler,
+		},
+		{
+			MethodName: "GetFragmentationReport",
+			Handler:    _SchedulerReporting_GetFragmentationReport_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/scheduler/schedulerobjects/reporting.proto",
@@ -940,20 +1356,212 @@ func (m *JobReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintReporting(dAtA []byte, offset int, v uint64) int {
-	offset -= sovReporting(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *JobSetReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *MostRecentForQueue) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *JobSetReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobSetReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.JobSetId) > 0 {
+		i -= len(m.JobSetId)
+		copy(dAtA[i:], m.JobSetId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.JobSetId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Queue) > 0 {
+		i -= len(m.Queue)
+		copy(dAtA[i:], m.Queue)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Queue)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobSetReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobSetReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobSetReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Report) > 0 {
+		i -= len(m.Report)
+		copy(dAtA[i:], m.Report)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventRateReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventRateReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventRateReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NumJobSets != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumJobSets))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Queue) > 0 {
+		i -= len(m.Queue)
+		copy(dAtA[i:], m.Queue)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Queue)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventRateReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventRateReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventRateReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Report) > 0 {
+		i -= len(m.Report)
+		copy(dAtA[i:], m.Report)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FragmentationReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FragmentationReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FragmentationReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Pool) > 0 {
+		i -= len(m.Pool)
+		copy(dAtA[i:], m.Pool)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Pool)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FragmentationReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FragmentationReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FragmentationReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Report) > 0 {
+		i -= len(m.Report)
+		copy(dAtA[i:], m.Report)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintReporting(dAtA []byte, offset int, v uint64) int {
+	offset -= sovReporting(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *MostRecentForQueue) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -1081,16 +1689,486 @@ func (m *JobReport) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovReporting(uint64(l))
 	}
-	return n
-}
+	return n
+}
+
+func (m *JobSetReportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Queue)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.JobSetId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *JobSetReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Report)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *EventRateReportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Queue)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.NumJobSets != 0 {
+		n += 1 + sovReporting(uint64(m.NumJobSets))
+	}
+	return n
+}
+
+func (m *EventRateReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Report)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *FragmentationReportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *FragmentationReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Report)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func sovReporting(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozReporting(x uint64) (n int) {
+	return sovReporting(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MostRecentForQueue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MostRecentForQueue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QueueName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MostRecentForJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MostRecentForJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchedulingReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchedulingReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForQueue", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MostRecentForQueue{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Filter = &SchedulingReportRequest_MostRecentForQueue{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MostRecentForJob{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Filter = &SchedulingReportRequest_MostRecentForJob{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verbosity", wireType)
+			}
+			m.Verbosity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Verbosity |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchedulingReport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchedulingReport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Report = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovReporting(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozReporting(x uint64) (n int) {
-	return sovReporting(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
+func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1113,10 +2191,10 @@ func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MostRecentForQueue: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueueReportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MostRecentForQueue: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueueReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1151,6 +2229,25 @@ func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
 			}
 			m.QueueName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verbosity", wireType)
+			}
+			m.Verbosity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Verbosity |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipReporting(dAtA[iNdEx:])
@@ -1172,7 +2269,7 @@ func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
+func (m *QueueReport) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1195,15 +2292,15 @@ func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MostRecentForJob: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueueReport: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MostRecentForJob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueueReport: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1231,7 +2328,7 @@ func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.JobId = string(dAtA[iNdEx:postIndex])
+			m.Report = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1254,7 +2351,7 @@ func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
+func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1277,17 +2374,17 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SchedulingReportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobReportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchedulingReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForQueue", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowReporting
@@ -1297,32 +2394,79 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthReporting
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthReporting
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &MostRecentForQueue{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Filter = &SchedulingReportRequest_MostRecentForQueue{v}
-			iNdEx = postIndex
-		case 2:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobReport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobReport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobReport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForJob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowReporting
@@ -1332,32 +2476,111 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthReporting
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthReporting
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &MostRecentForJob{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Report = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Filter = &SchedulingReportRequest_MostRecentForJob{v}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobSetReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobSetReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobSetReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Queue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Queue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Verbosity", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobSetId", wireType)
 			}
-			m.Verbosity = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowReporting
@@ -1367,11 +2590,24 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Verbosity |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobSetId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipReporting(dAtA[iNdEx:])
@@ -1393,7 +2629,7 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
+func (m *JobSetReport) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1416,10 +2652,10 @@ func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SchedulingReport: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobSetReport: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchedulingReport: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobSetReport: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1475,7 +2711,7 @@ func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
+func (m *EventRateReportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1498,15 +2734,15 @@ func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueueReportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: EventRateReportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueueReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EventRateReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field QueueName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Queue", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1534,13 +2770,13 @@ func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.QueueName = string(dAtA[iNdEx:postIndex])
+			m.Queue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Verbosity", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NumJobSets", wireType)
 			}
-			m.Verbosity = 0
+			m.NumJobSets = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowReporting
@@ -1550,7 +2786,7 @@ func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Verbosity |= int32(b&0x7F) << shift
+				m.NumJobSets |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1576,7 +2812,7 @@ func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueueReport) Unmarshal(dAtA []byte) error {
+func (m *EventRateReport) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1599,10 +2835,10 @@ func (m *QueueReport) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueueReport: wiretype end group for non-group")
+			return fmt.Errorf("proto: EventRateReport: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueueReport: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EventRateReport: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1658,7 +2894,7 @@ func (m *QueueReport) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
+func (m *FragmentationReportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1681,15 +2917,15 @@ func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobReportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: FragmentationReportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FragmentationReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1717,7 +2953,7 @@ func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.JobId = string(dAtA[iNdEx:postIndex])
+			m.Pool = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1740,7 +2976,7 @@ func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobReport) Unmarshal(dAtA []byte) error {
+func (m *FragmentationReport) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1763,10 +2999,10 @@ func (m *JobReport) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobReport: wiretype end group for non-group")
+			return fmt.Errorf("proto: FragmentationReport: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobReport: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FragmentationReport: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
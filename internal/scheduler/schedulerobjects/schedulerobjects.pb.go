@@ -74,10 +74,16 @@ type Executor struct {
 	Nodes []*Node `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	// Minimum resources which a job must request in order to be considered for scheduling on this executor.
 	MinimumJobSize ResourceList `protobuf:"bytes,4,opt,name=minimumJobSize,proto3" json:"minimumJobSize"`
-	// Last time the executor provided a heartbeat to say it was still accepting job.
+	// Last time the executor provided a heartbeat to say it was still accepting job, according to the scheduler's clock.
+	// Staleness is decided based on this time, such that an executor with a skewed clock isn't considered stale.
 	LastUpdateTime time.Time `protobuf:"bytes,5,opt,name=lastUpdateTime,proto3,stdtime" json:"lastUpdateTime"`
+	// Time at which the executor sent its last heartbeat according to its own clock, if it reported it.
+	LastReportedTime *time.Time `protobuf:"bytes,11,opt,name=last_reported_time,json=lastReportedTime,proto3,stdtime" json:"lastReportedTime,omitempty"`
 	// Jobs that are owned by the cluster but are not assigned to any node.
 	UnassignedJobRuns []string `protobuf:"bytes,9,rep,name=unassigned_job_runs,json=unassignedJobRuns,proto3" json:"unassignedJobRuns,omitempty"`
+	// If non-zero, how long to wait for a heartbeat from this executor before considering it stale.
+	// Overrides the global executor timeout.
+	Timeout time.Duration `protobuf:"bytes,10,opt,name=timeout,proto3,stdduration" json:"timeout"`
 }
 
 func (m *Executor) Reset()         { *m = Executor{} }
@@ -148,6 +154,13 @@ func (m *Executor) GetLastUpdateTime() time.Time {
 	return time.Time{}
 }
 
+func (m *Executor) GetLastReportedTime() *time.Time {
+	if m != nil {
+		return m.LastReportedTime
+	}
+	return nil
+}
+
 func (m *Executor) GetUnassignedJobRuns() []string {
 	if m != nil {
 		return m.UnassignedJobRuns
@@ -155,6 +168,13 @@ func (m *Executor) GetUnassignedJobRuns() []string {
 	return nil
 }
 
+func (m *Executor) GetTimeout() time.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
 // Node represents a node in a worker cluster.
 type Node struct {
 	// Id associated with the node. Must be unique across all clusters.
@@ -203,6 +223,9 @@ type Node struct {
 	// This should only be used for metrics
 	// This is the type the node should be reported as. It is simple a label to categorise the group the node belongs to
 	ReportingNodeType string `protobuf:"bytes,17,opt,name=reporting_node_type,json=reportingNodeType,proto3" json:"reportingNodeType,omitempty"`
+	// Hash of the node as last reported by its executor, as computed by executorapi.NodeInfoHash.
+	// Used to check that changes to nodes reported by executors are applied to the nodes the executors expect.
+	NodeInfoHash []byte `protobuf:"bytes,20,opt,name=node_info_hash,json=nodeInfoHash,proto3" json:"nodeInfoHash,omitempty"`
 }
 
 func (m *Node) Reset()         { *m = Node{} }
@@ -371,6 +394,13 @@ func (m *Node) GetReportingNodeType() string {
 	return ""
 }
 
+func (m *Node) GetNodeInfoHash() []byte {
+	if m != nil {
+		return m.NodeInfoHash
+	}
+	return nil
+}
+
 // NodeType represents a particular combination of taints and labels.
 // The scheduler groups nodes by node type. When assigning pods to nodes,
 // the scheduler only considers nodes with a NodeType for which the taints and labels match.
@@ -1023,145 +1053,150 @@ func init() {
 }
 
 var fileDescriptor_97dadc5fbd620721 = []byte{
-	// 2197 bytes of a gzipped FileDescriptorProto
+	// 2285 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x19, 0x4d, 0x6f, 0x1b, 0xc7,
-	0x55, 0x2b, 0x52, 0x14, 0x39, 0x94, 0x25, 0x6a, 0xe4, 0x8f, 0x15, 0x63, 0x73, 0x19, 0xc6, 0x0d,
-	0x94, 0xc6, 0x21, 0x1b, 0xa5, 0x40, 0x0d, 0xb7, 0x17, 0xd1, 0x52, 0x6b, 0x2a, 0x36, 0x25, 0x2f,
-	0xa5, 0x16, 0x2d, 0xd0, 0x2c, 0x96, 0xdc, 0x11, 0xbd, 0xd1, 0x72, 0x86, 0xde, 0x9d, 0x55, 0xc3,
-	0x9c, 0xdb, 0x43, 0x11, 0x20, 0x0d, 0x8a, 0x7e, 0x04, 0x28, 0xd0, 0x22, 0xb7, 0xfc, 0x82, 0xf6,
-	0xd0, 0x3f, 0xe0, 0x63, 0x8e, 0x3d, 0x31, 0x85, 0x7d, 0xe3, 0xb5, 0x7f, 0xa0, 0x98, 0x99, 0x5d,
-	0xee, 0x70, 0x97, 0x14, 0xe5, 0xa4, 0xae, 0x4e, 0xe4, 0xbc, 0xef, 0x79, 0xef, 0xcd, 0x9b, 0xf7,
-	0x66, 0xc1, 0x3d, 0x1b, 0x53, 0xe4, 0x62, 0xd3, 0xa9, 0x79, 0x9d, 0x27, 0xc8, 0xf2, 0x1d, 0xe4,
-	0x46, 0xff, 0x48, 0xfb, 0x43, 0xd4, 0xa1, 0x5e, 0x02, 0x50, 0xed, 0xbb, 0x84, 0x12, 0x58, 0x88,
-	0xc3, 0x8b, 0x5a, 0x97, 0x90, 0xae, 0x83, 0x6a, 0x1c, 0xdf, 0xf6, 0x4f, 0x6a, 0xd4, 0xee, 0x21,
-	0x8f, 0x9a, 0xbd, 0xbe, 0x60, 0x29, 0x56, 0x4e, 0xef, 0x7a, 0x55, 0x9b, 0xd4, 0xcc, 0xbe, 0x5d,
-	0xeb, 0x10, 0x17, 0xd5, 0xce, 0xde, 0xad, 0x75, 0x11, 0x46, 0xae, 0x49, 0x91, 0x15, 0xd0, 0x7c,
-	0x3f, 0xa2, 0xe9, 0x99, 0x9d, 0x27, 0x36, 0x46, 0xee, 0xa0, 0xd6, 0x3f, 0xed, 0x72, 0x26, 0x17,
-	0x79, 0xc4, 0x77, 0x3b, 0x28, 0xc1, 0xf5, 0x4e, 0xd7, 0xa6, 0x4f, 0xfc, 0x76, 0xb5, 0x43, 0x7a,
-	0xb5, 0x2e, 0xe9, 0x92, 0xc8, 0x06, 0xb6, 0xe2, 0x0b, 0xfe, 0x4f, 0x90, 0x57, 0xbe, 0x4c, 0x81,
-	0xec, 0xde, 0x47, 0xa8, 0xe3, 0x53, 0xe2, 0xc2, 0x32, 0x58, 0xb4, 0x2d, 0x55, 0x29, 0x2b, 0x5b,
-	0xb9, 0x7a, 0x61, 0x34, 0xd4, 0x56, 0x6c, 0xeb, 0x0e, 0xe9, 0xd9, 0x14, 0xf5, 0xfa, 0x74, 0xa0,
-	0x2f, 0xda, 0x16, 0x7c, 0x13, 0xa4, 0xfb, 0x84, 0x38, 0xea, 0x22, 0xa7, 0x81, 0xa3, 0xa1, 0xb6,
-	0xca, 0xd6, 0x12, 0x15, 0xc7, 0xc3, 0x1d, 0xb0, 0x84, 0x89, 0x85, 0x3c, 0x35, 0x55, 0x4e, 0x6d,
-	0xe5, 0xb7, 0xaf, 0x57, 0x13, 0xae, 0x6b, 0x12, 0x0b, 0xd5, 0x37, 0x46, 0x43, 0x6d, 0x8d, 0x13,
-	0x4a, 0x12, 0x04, 0x27, 0xfc, 0x00, 0xac, 0xf6, 0x6c, 0x6c, 0xf7, 0xfc, 0xde, 0x3e, 0x69, 0xb7,
-	0xec, 0x8f, 0x91, 0x9a, 0x2e, 0x2b, 0x5b, 0xf9, 0xed, 0x52, 0x52, 0x96, 0x1e, 0x38, 0xe3, 0xa1,
-	0xed, 0xd1, 0xfa, 0xf5, 0x67, 0x43, 0x6d, 0x81, 0x19, 0x36, 0xc9, 0xad, 0xc7, 0xd6, 0x4c, 0xbe,
-	0x63, 0x7a, 0xf4, 0xb8, 0x6f, 0x99, 0x14, 0x1d, 0xd9, 0x3d, 0xa4, 0x2e, 0x71, 0xf9, 0xc5, 0xaa,
-	0x08, 0x5e, 0x35, 0x74, 0x5c, 0xf5, 0x28, 0x0c, 0x5e, 0xbd, 0x18, 0xca, 0x9e, 0xe4, 0xfc, 0xec,
-	0x6b, 0x4d, 0xd1, 0x63, 0x30, 0x78, 0x00, 0x36, 0x7c, 0x6c, 0x7a, 0x9e, 0xdd, 0xc5, 0xc8, 0x32,
-	0x3e, 0x24, 0x6d, 0xc3, 0xf5, 0xb1, 0xa7, 0xe6, 0xca, 0xa9, 0xad, 0x5c, 0x5d, 0x1b, 0x0d, 0xb5,
-	0xd7, 0x22, 0xf4, 0x3e, 0x69, 0xeb, 0x3e, 0x96, 0x9d, 0xb0, 0x9e, 0x40, 0x56, 0xbe, 0xbc, 0x0e,
-	0xd2, 0xcc, 0x6b, 0x17, 0x0b, 0x13, 0x36, 0x7b, 0x48, 0x5d, 0x89, 0xc2, 0xc4, 0xd6, 0x72, 0x98,
-	0xd8, 0x1a, 0x6e, 0x83, 0x2c, 0x0a, 0x82, 0xaf, 0x6e, 0x70, 0xda, 0xeb, 0xa3, 0xa1, 0x06, 0x43,
-	0x98, 0x44, 0x3f, 0xa6, 0x83, 0x77, 0x01, 0x60, 0x01, 0xda, 0x6d, 0xbf, 0x8f, 0x06, 0x9e, 0x0a,
-	0xcb, 0xa9, 0xad, 0x95, 0xba, 0x3a, 0x1a, 0x6a, 0x57, 0x23, 0xa8, 0xc4, 0x27, 0xd1, 0xc2, 0x47,
-	0x20, 0xc7, 0x7c, 0x64, 0x78, 0x08, 0x61, 0x9e, 0x41, 0xe7, 0x3b, 0xfb, 0x6a, 0xe0, 0xec, 0x2c,
-	0x63, 0x6a, 0x21, 0x84, 0xb9, 0x9b, 0xc7, 0x2b, 0x78, 0x00, 0x72, 0x4c, 0xb8, 0x41, 0x07, 0x7d,
-	0xa4, 0xa6, 0x02, 0x71, 0x53, 0xf3, 0xec, 0x68, 0xd0, 0x47, 0x62, 0x67, 0x38, 0x58, 0xc9, 0x3b,
-	0x0b, 0x61, 0xf0, 0x1e, 0x58, 0x19, 0x0b, 0x34, 0x6c, 0x8b, 0xe7, 0x5b, 0x3a, 0xda, 0x1b, 0xa3,
-	0x69, 0x58, 0xf1, 0xbd, 0x09, 0x28, 0xdc, 0x01, 0x19, 0x6a, 0xda, 0x98, 0x7a, 0xea, 0x12, 0xcf,
-	0xf8, 0xcd, 0xaa, 0x38, 0xbd, 0x55, 0xb3, 0x6f, 0x57, 0xd9, 0x09, 0xaf, 0x9e, 0xbd, 0x5b, 0x3d,
-	0x62, 0x14, 0xf5, 0xd5, 0x60, 0x5f, 0x01, 0x83, 0x1e, 0xfc, 0xc2, 0x43, 0x90, 0x71, 0xcc, 0x36,
-	0x72, 0x3c, 0x35, 0xc3, 0x45, 0x54, 0xa6, 0x6f, 0xa6, 0xfa, 0x90, 0x13, 0xed, 0x61, 0xea, 0x0e,
-	0xea, 0x57, 0x47, 0x43, 0xad, 0x20, 0xb8, 0x24, 0xc3, 0x02, 0x39, 0xd0, 0x00, 0x6b, 0x94, 0x50,
-	0xd3, 0x31, 0xc2, 0x6a, 0xe1, 0xa9, 0xcb, 0x2f, 0x77, 0x86, 0x38, 0x7b, 0x88, 0xf2, 0xf4, 0xd8,
-	0x1a, 0xfe, 0x5d, 0x01, 0xb7, 0x4d, 0xc7, 0x21, 0x1d, 0x93, 0x9a, 0x6d, 0x07, 0x19, 0xed, 0x81,
-	0xd1, 0x77, 0x6d, 0xe2, 0xda, 0x74, 0x60, 0x98, 0xd8, 0x1a, 0xeb, 0x55, 0xb3, 0x7c, 0x47, 0x3f,
-	0x9a, 0xb1, 0xa3, 0x9d, 0x48, 0x44, 0x7d, 0x70, 0x18, 0x08, 0xd8, 0xc1, 0x56, 0xa8, 0x48, 0xec,
-	0x75, 0x2b, 0x30, 0xaa, 0x6c, 0xce, 0x21, 0xd7, 0xe7, 0x52, 0x40, 0x17, 0x6c, 0x78, 0xd4, 0xa4,
-	0xdc, 0xe2, 0xe0, 0x68, 0xb2, 0x88, 0xe7, 0xb8, 0x99, 0x6f, 0xcf, 0x30, 0xb3, 0xc5, 0x38, 0xea,
-	0x03, 0x71, 0x1e, 0x1b, 0x96, 0xb0, 0xea, 0x46, 0x60, 0xd5, 0x9a, 0x37, 0x89, 0xd5, 0xe3, 0x00,
-	0xe8, 0x83, 0x8d, 0xc0, 0x2e, 0x64, 0x85, 0x7a, 0x6d, 0x4b, 0x05, 0x5c, 0xe7, 0x9d, 0xf3, 0x5d,
-	0x83, 0x2c, 0x2e, 0x28, 0x54, 0xaa, 0x06, 0x4a, 0x0b, 0x66, 0x0c, 0xad, 0x27, 0x20, 0x90, 0x02,
-	0x38, 0xa1, 0xf6, 0xa9, 0x8f, 0x7c, 0xa4, 0xe6, 0x2f, 0xaa, 0xf5, 0x31, 0x23, 0x9f, 0xad, 0x95,
-	0xa3, 0xf5, 0x04, 0x84, 0x6d, 0x16, 0x9d, 0xd9, 0x1d, 0x1a, 0x95, 0x3e, 0xc3, 0xb6, 0x3c, 0x75,
-	0xf5, 0x5c, 0xb5, 0x7b, 0x82, 0x23, 0xf4, 0x98, 0x17, 0x53, 0x8b, 0x62, 0x68, 0x3d, 0x01, 0x81,
-	0x5f, 0x28, 0xa0, 0x84, 0x09, 0x36, 0x4c, 0xb7, 0x67, 0x5a, 0xa6, 0x11, 0x6d, 0x3c, 0x3a, 0x01,
-	0x57, 0xb8, 0x09, 0x3f, 0x98, 0x61, 0x42, 0x93, 0xe0, 0x1d, 0xce, 0x3b, 0x76, 0xc1, 0x38, 0xdb,
-	0x85, 0x35, 0x6f, 0x04, 0xd6, 0xbc, 0x86, 0x67, 0x53, 0xea, 0xe7, 0x21, 0xe1, 0x0e, 0xb8, 0xe2,
-	0xe3, 0x40, 0x3b, 0xcb, 0x50, 0x75, 0xad, 0xac, 0x6c, 0x65, 0xeb, 0xaf, 0x8d, 0x86, 0xda, 0x8d,
-	0x09, 0x84, 0x74, 0xa2, 0x27, 0x39, 0xe0, 0x27, 0x0a, 0xb8, 0x11, 0xee, 0xc8, 0xf0, 0x3d, 0xb3,
-	0x8b, 0xa2, 0xc8, 0x16, 0xf8, 0xfe, 0xbe, 0x37, 0x63, 0x7f, 0xa1, 0x19, 0xc7, 0x8c, 0x69, 0x22,
-	0xba, 0x95, 0xd1, 0x50, 0x2b, 0xb9, 0x53, 0xd0, 0x92, 0x19, 0x57, 0xa7, 0xe1, 0xd9, 0x4d, 0xe7,
-	0xa2, 0x3e, 0x71, 0xa9, 0x8d, 0xbb, 0x46, 0x54, 0x92, 0xd7, 0xf9, 0x85, 0xc2, 0x6f, 0xba, 0x31,
-	0xba, 0x99, 0xac, 0xbf, 0xeb, 0x09, 0x64, 0xd1, 0x04, 0x79, 0xa9, 0xc8, 0xc1, 0x37, 0x40, 0xea,
-	0x14, 0x0d, 0x82, 0x0b, 0x6f, 0x7d, 0x34, 0xd4, 0xae, 0x9c, 0xa2, 0x81, 0x24, 0x81, 0x61, 0xe1,
-	0x5b, 0x60, 0xe9, 0xcc, 0x74, 0x7c, 0x14, 0xb4, 0x26, 0xbc, 0xb3, 0xe0, 0x00, 0xb9, 0xb3, 0xe0,
-	0x80, 0x7b, 0x8b, 0x77, 0x95, 0xe2, 0x5f, 0x14, 0xf0, 0x9d, 0x0b, 0x95, 0x1d, 0x59, 0xfb, 0xd2,
-	0x4c, 0xed, 0x0d, 0x59, 0xfb, 0xfc, 0xfa, 0x3a, 0xcf, 0xba, 0xdf, 0x2a, 0xe0, 0xea, 0xb4, 0x6a,
-	0x73, 0x31, 0x57, 0x3c, 0x90, 0x8d, 0x59, 0xdd, 0xbe, 0x95, 0x34, 0x46, 0x08, 0x15, 0x1a, 0xe6,
-	0xd9, 0xf2, 0x89, 0x02, 0xae, 0x4d, 0xad, 0x42, 0x17, 0x33, 0xe6, 0x7f, 0xec, 0x99, 0x98, 0x35,
-	0x51, 0xfe, 0x5e, 0x8a, 0x35, 0xa7, 0xe0, 0xda, 0xd4, 0x9a, 0xf5, 0x0d, 0x52, 0x36, 0x3b, 0x57,
-	0xd9, 0x9f, 0x14, 0x50, 0x9e, 0x57, 0x9e, 0x2e, 0x25, 0x5b, 0x7f, 0xa7, 0x80, 0xcd, 0x99, 0x75,
-	0xe5, 0x32, 0xe2, 0x52, 0xf9, 0x6b, 0x1a, 0x64, 0xc3, 0x6a, 0xc2, 0xda, 0xe5, 0x86, 0x68, 0x97,
-	0xd3, 0xa2, 0x5d, 0x9e, 0x68, 0xe2, 0x16, 0x27, 0x9a, 0xb7, 0xc5, 0x6f, 0xda, 0xbc, 0x1d, 0x8d,
-	0x9b, 0x37, 0x31, 0xf1, 0xbc, 0x39, 0xbb, 0x13, 0x7d, 0x89, 0x06, 0xee, 0xd7, 0x0a, 0x80, 0x3e,
-	0xf6, 0x10, 0x6d, 0x60, 0x0b, 0x7d, 0x84, 0x2c, 0xc1, 0xa9, 0xa6, 0xb9, 0x8a, 0xed, 0x73, 0x54,
-	0x1c, 0x27, 0x98, 0x84, 0xba, 0xf2, 0x68, 0xa8, 0xdd, 0x4c, 0x4a, 0x94, 0x54, 0x4f, 0xd1, 0xf7,
-	0xff, 0xa8, 0xc7, 0x3d, 0x70, 0x63, 0x86, 0xcd, 0xaf, 0x42, 0x5d, 0xe5, 0x59, 0x06, 0x6c, 0xf2,
-	0x1c, 0xbd, 0xef, 0xf8, 0x1e, 0x45, 0xee, 0x44, 0xfa, 0xc2, 0x06, 0x58, 0xee, 0xb8, 0x88, 0x9d,
-	0x2e, 0xae, 0xf5, 0xfc, 0x31, 0x65, 0x23, 0xc8, 0x88, 0x90, 0x85, 0x4f, 0x29, 0xe1, 0x82, 0xd9,
-	0x25, 0xae, 0x65, 0xc9, 0xae, 0xa7, 0xb1, 0x5b, 0x55, 0x50, 0xb0, 0xc1, 0x2a, 0x1c, 0xb2, 0x1a,
-	0x16, 0x1f, 0x68, 0x72, 0x62, 0xf8, 0x88, 0xa0, 0xf2, 0xf0, 0x11, 0x41, 0xe1, 0x1f, 0x15, 0x76,
-	0x03, 0x07, 0x75, 0x20, 0xba, 0xca, 0x82, 0x3c, 0xd9, 0x4d, 0xe6, 0xc9, 0xcc, 0xad, 0x8f, 0x8f,
-	0x99, 0x24, 0x46, 0x64, 0xce, 0xad, 0x60, 0x9b, 0x53, 0x15, 0x29, 0xfa, 0x34, 0x30, 0xfc, 0x87,
-	0x02, 0x6e, 0x4e, 0x81, 0xdf, 0x77, 0x4c, 0xcf, 0x6b, 0x9a, 0x7c, 0xe2, 0x66, 0x06, 0x3e, 0xfa,
-	0x96, 0x06, 0x8e, 0xe5, 0x09, 0x4b, 0x6f, 0x07, 0x96, 0x9e, 0xab, 0x5a, 0x3f, 0x17, 0x5b, 0xfc,
-	0x54, 0x01, 0xea, 0x2c, 0x57, 0x5c, 0x4a, 0x8d, 0xfd, 0xb3, 0x02, 0x5e, 0x9f, 0xbb, 0xf5, 0x4b,
-	0xa9, 0xb5, 0xff, 0x4c, 0x81, 0xe2, 0xb4, 0x48, 0xe9, 0xbc, 0xad, 0x1b, 0xbf, 0x18, 0x29, 0x73,
-	0x5e, 0x8c, 0xa4, 0x33, 0xb7, 0xf8, 0x2d, 0xcf, 0xdc, 0xa7, 0x0a, 0x28, 0x48, 0xd1, 0xe5, 0xb9,
-	0x14, 0x94, 0xe5, 0x7a, 0x72, 0xb3, 0xb3, 0x6d, 0x97, 0x73, 0x4d, 0x6a, 0x94, 0x4b, 0xa3, 0xa1,
-	0x56, 0x8c, 0xcb, 0x97, 0xf6, 0x93, 0xd0, 0x5d, 0xfc, 0x5c, 0x01, 0xd7, 0xa6, 0xca, 0xba, 0x58,
-	0xc0, 0x7e, 0x3a, 0x19, 0xb0, 0xb7, 0x5f, 0xe2, 0xb8, 0xcc, 0x8d, 0xde, 0x6f, 0x16, 0xc1, 0x8a,
-	0x1c, 0x6e, 0xf8, 0x01, 0xc8, 0x45, 0xb3, 0x92, 0xc2, 0x9d, 0xf6, 0xce, 0xf9, 0x19, 0x52, 0x8d,
-	0x4d, 0x48, 0xeb, 0x41, 0x70, 0x22, 0x39, 0x7a, 0xf4, 0xb7, 0xf8, 0x07, 0x05, 0xac, 0xce, 0xee,
-	0x59, 0x66, 0x3b, 0xe1, 0xe7, 0x93, 0x4e, 0xa8, 0x4a, 0x57, 0xf4, 0xf8, 0x75, 0xb4, 0xda, 0x3f,
-	0xed, 0xf2, 0x3b, 0x3b, 0x54, 0x57, 0x7d, 0xec, 0x9b, 0x98, 0xda, 0x74, 0x30, 0xd7, 0x0f, 0x5f,
-	0x2f, 0x81, 0xf5, 0x7d, 0xd2, 0x6e, 0x89, 0x8d, 0xda, 0xb8, 0xdb, 0xc0, 0x27, 0x04, 0x6e, 0x83,
-	0xac, 0x63, 0x9f, 0x20, 0x6a, 0xf7, 0x10, 0x37, 0xef, 0x8a, 0x78, 0x45, 0x0a, 0x61, 0xf2, 0x2b,
-	0x52, 0x08, 0x83, 0xf7, 0xc0, 0x8a, 0x49, 0x8d, 0x1e, 0xf1, 0xa8, 0x41, 0x70, 0x27, 0x6c, 0xee,
-	0x78, 0x21, 0x37, 0xe9, 0x23, 0xe2, 0xd1, 0x03, 0xdc, 0x91, 0x39, 0x41, 0x04, 0x85, 0x3f, 0x04,
-	0xf9, 0xbe, 0x8b, 0x18, 0xdc, 0x66, 0x83, 0x61, 0x8a, 0xb3, 0x6e, 0x8e, 0x86, 0xda, 0x35, 0x09,
-	0x2c, 0xf1, 0xca, 0xd4, 0xf0, 0x01, 0x28, 0x74, 0x08, 0xee, 0xf8, 0xae, 0x8b, 0x70, 0x67, 0x60,
-	0x78, 0xe6, 0x89, 0x78, 0x32, 0xcd, 0xd6, 0x6f, 0x8d, 0x86, 0xda, 0xa6, 0x84, 0x6b, 0x99, 0x27,
-	0xb2, 0x94, 0xb5, 0x18, 0x8a, 0x0d, 0x74, 0xe3, 0x67, 0x9c, 0x0e, 0xab, 0x30, 0x06, 0x7f, 0x4d,
-	0xcc, 0x44, 0x03, 0x5d, 0x3f, 0x5e, 0x7f, 0xe4, 0x81, 0x2e, 0x81, 0x84, 0x2d, 0x90, 0xf7, 0xfc,
-	0x76, 0xcf, 0xa6, 0x06, 0x77, 0xe5, 0xf2, 0xdc, 0x03, 0x1e, 0x3e, 0x40, 0x01, 0xc1, 0x36, 0x7e,
-	0x64, 0x95, 0xd6, 0x2c, 0x38, 0xa1, 0x26, 0x35, 0x1b, 0x05, 0x27, 0x84, 0xc9, 0xc1, 0x09, 0x61,
-	0xf0, 0x57, 0x60, 0x43, 0xa4, 0xb0, 0xe1, 0xa2, 0xa7, 0xbe, 0xed, 0xa2, 0x1e, 0x8a, 0xde, 0xec,
-	0x6e, 0x27, 0xf3, 0xfc, 0x80, 0xff, 0xea, 0x12, 0xad, 0x68, 0xa1, 0x48, 0x02, 0x2e, 0xb7, 0x50,
-	0x49, 0x2c, 0xac, 0x81, 0xe5, 0x33, 0xe4, 0x7a, 0x36, 0xc1, 0x6a, 0x8e, 0xdb, 0x7a, 0x6d, 0x34,
-	0xd4, 0xd6, 0x03, 0x90, 0xc4, 0x1b, 0x52, 0xc1, 0x06, 0x58, 0xe7, 0x6d, 0x81, 0x41, 0xa9, 0x63,
-	0x78, 0xa8, 0x43, 0xb0, 0xe5, 0xa9, 0xa0, 0xac, 0x6c, 0xa5, 0x44, 0x38, 0x39, 0xf2, 0x88, 0x3a,
-	0x2d, 0x81, 0x92, 0xc3, 0x19, 0x43, 0xdd, 0x4b, 0x7f, 0xfe, 0x85, 0xa6, 0x54, 0x7e, 0xaf, 0x00,
-	0x98, 0xdc, 0x0e, 0x74, 0xc0, 0x5a, 0x9f, 0x58, 0x32, 0x28, 0xe8, 0x79, 0x5e, 0x4f, 0x7a, 0xe3,
-	0x70, 0x92, 0x50, 0x18, 0x12, 0xe3, 0x8e, 0x0c, 0x79, 0xb0, 0xa0, 0xc7, 0x45, 0xd7, 0x57, 0xc1,
-	0x8a, 0xec, 0xf8, 0xca, 0x7f, 0x32, 0x60, 0x2d, 0x26, 0x15, 0x7a, 0xe2, 0x19, 0xb6, 0x85, 0x1c,
-	0xd4, 0xa1, 0xc4, 0x0d, 0x8a, 0xd0, 0x7b, 0x73, 0xcd, 0xe1, 0xdd, 0x6f, 0xc8, 0x25, 0x4a, 0x51,
-	0x71, 0x34, 0xd4, 0xae, 0xcb, 0xc2, 0x24, 0x37, 0x4d, 0x28, 0x81, 0x87, 0x20, 0x6b, 0x9e, 0x9c,
-	0xd8, 0x98, 0x25, 0x93, 0xa8, 0x30, 0x37, 0xa7, 0x0d, 0x01, 0x3b, 0x01, 0x8d, 0x48, 0xb5, 0x90,
-	0x43, 0x4e, 0xb5, 0x10, 0x06, 0x8f, 0x41, 0x9e, 0x12, 0x07, 0xb9, 0x26, 0xb5, 0x09, 0x0e, 0xc7,
-	0x82, 0xd2, 0xd4, 0xc9, 0x62, 0x4c, 0x36, 0xbe, 0xd8, 0x64, 0x56, 0x5d, 0x5e, 0x40, 0x02, 0xf2,
-	0x26, 0xc6, 0x84, 0x06, 0x62, 0x97, 0x67, 0x8d, 0x02, 0x71, 0xe7, 0xec, 0x44, 0x4c, 0xc2, 0x37,
-	0xbc, 0xac, 0x48, 0xa2, 0xe4, 0xb2, 0x22, 0x81, 0x27, 0x8e, 0x59, 0x9a, 0xb7, 0x3c, 0xf3, 0x8f,
-	0xd9, 0x3e, 0x28, 0x84, 0x95, 0x89, 0xe0, 0x43, 0xe2, 0xd8, 0x9d, 0x01, 0xff, 0xba, 0x92, 0x13,
-	0x97, 0x67, 0x1c, 0x27, 0x5f, 0x9e, 0x71, 0x1c, 0xfc, 0x18, 0x8c, 0x5f, 0x9d, 0x26, 0xb2, 0x34,
-	0xc3, 0xa3, 0xb4, 0x35, 0xcd, 0xa1, 0xfa, 0x14, 0xfa, 0xfa, 0xcd, 0xc0, 0xb5, 0x53, 0xa5, 0xe9,
-	0x53, 0xa1, 0xc5, 0x2e, 0x58, 0x4f, 0x24, 0xd5, 0x2b, 0x19, 0x7f, 0x4e, 0x40, 0x21, 0x1e, 0xa0,
-	0x57, 0xa1, 0x67, 0x3f, 0x9d, 0xcd, 0x16, 0x72, 0x95, 0xbf, 0x29, 0x60, 0xf3, 0xd0, 0x77, 0x3c,
-	0xd3, 0x6d, 0x85, 0x69, 0xb3, 0x4f, 0xda, 0xbb, 0x88, 0x9a, 0xb6, 0xe3, 0x31, 0x91, 0xfc, 0x91,
-	0x27, 0xd0, 0xcc, 0x45, 0x72, 0x80, 0x2c, 0x52, 0xbc, 0x2d, 0xbf, 0x05, 0x96, 0x1e, 0xc7, 0xa7,
-	0x9b, 0x78, 0x3b, 0x24, 0x28, 0xe0, 0x1d, 0x90, 0x61, 0xf7, 0x2b, 0xa2, 0xc1, 0x64, 0xc3, 0x07,
-	0x5f, 0x01, 0x91, 0x07, 0x5f, 0x01, 0xf9, 0xee, 0x01, 0xc8, 0x4b, 0x6f, 0x54, 0x30, 0x0f, 0x96,
-	0x8f, 0x9b, 0xef, 0x37, 0x0f, 0x7e, 0xd6, 0x2c, 0x2c, 0xb0, 0xc5, 0xe1, 0x5e, 0x73, 0xb7, 0xd1,
-	0xfc, 0x49, 0x41, 0x61, 0x0b, 0xfd, 0xb8, 0xd9, 0x64, 0x8b, 0x45, 0x78, 0x05, 0xe4, 0x5a, 0xc7,
-	0xf7, 0xef, 0xef, 0xed, 0xed, 0xee, 0xed, 0x16, 0x52, 0x10, 0x80, 0xcc, 0x8f, 0x77, 0x1a, 0x0f,
-	0xf7, 0x76, 0x0b, 0xe9, 0xfa, 0x2f, 0x9f, 0x3d, 0x2f, 0x29, 0x5f, 0x3d, 0x2f, 0x29, 0xff, 0x7e,
-	0x5e, 0x52, 0x3e, 0x7b, 0x51, 0x5a, 0xf8, 0xea, 0x45, 0x69, 0xe1, 0x5f, 0x2f, 0x4a, 0x0b, 0xbf,
-	0xb8, 0x2f, 0x7d, 0x30, 0x15, 0xcf, 0xc6, 0x7d, 0x97, 0xb0, 0x33, 0x14, 0xac, 0x6a, 0x17, 0xf8,
-	0x32, 0xdc, 0xce, 0xf0, 0x3b, 0xec, 0xbd, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x3e, 0xd0, 0x23,
-	0xda, 0x47, 0x1e, 0x00, 0x00,
+	0x55, 0x4b, 0x51, 0x12, 0x39, 0x92, 0x25, 0x6a, 0x24, 0xdb, 0x2b, 0xda, 0x26, 0x19, 0xc5, 0x0d,
+	0xd4, 0xc6, 0x59, 0x36, 0x4e, 0x81, 0x1a, 0x6e, 0x0f, 0x15, 0x2d, 0xb5, 0xa6, 0x63, 0x53, 0xf2,
+	0x4a, 0x6a, 0xd1, 0x02, 0xcd, 0x62, 0xc8, 0x1d, 0x51, 0x1b, 0x2d, 0x67, 0xe8, 0xdd, 0x59, 0x37,
+	0xcc, 0xb9, 0x3d, 0x14, 0x01, 0xd2, 0xa0, 0xe8, 0x87, 0x81, 0x02, 0x2d, 0xf2, 0x27, 0xda, 0x43,
+	0xff, 0x80, 0x8f, 0x39, 0xf6, 0xc4, 0x04, 0xf6, 0x8d, 0xd7, 0xfe, 0x81, 0x62, 0x66, 0x76, 0xb9,
+	0xc3, 0x5d, 0x52, 0x94, 0x93, 0x3a, 0x3a, 0x91, 0xf3, 0xbe, 0xe7, 0xbd, 0x37, 0x6f, 0xe6, 0xbd,
+	0x05, 0x77, 0x1d, 0xc2, 0xb0, 0x47, 0x90, 0x5b, 0xf5, 0x5b, 0x27, 0xd8, 0x0e, 0x5c, 0xec, 0xc5,
+	0xff, 0x68, 0xf3, 0x43, 0xdc, 0x62, 0x7e, 0x0a, 0x60, 0x74, 0x3d, 0xca, 0x28, 0x2c, 0x24, 0xe1,
+	0xc5, 0x72, 0x9b, 0xd2, 0xb6, 0x8b, 0xab, 0x02, 0xdf, 0x0c, 0x8e, 0xab, 0xcc, 0xe9, 0x60, 0x9f,
+	0xa1, 0x4e, 0x57, 0xb2, 0x14, 0x4b, 0x49, 0x02, 0x3b, 0xf0, 0x10, 0x73, 0x28, 0x09, 0xf1, 0x9b,
+	0xa7, 0x77, 0x7c, 0xc3, 0xa1, 0x55, 0xd4, 0x75, 0xaa, 0x2d, 0xea, 0xe1, 0xea, 0xd3, 0x77, 0xab,
+	0x6d, 0x4c, 0xb0, 0x87, 0x18, 0xb6, 0x43, 0x9a, 0x1f, 0xc4, 0x34, 0x1d, 0xd4, 0x3a, 0x71, 0x08,
+	0xf6, 0x7a, 0xd5, 0xee, 0x69, 0x5b, 0x30, 0x79, 0xd8, 0xa7, 0x81, 0xd7, 0xc2, 0x29, 0xae, 0x77,
+	0xda, 0x0e, 0x3b, 0x09, 0x9a, 0x46, 0x8b, 0x76, 0xaa, 0x6d, 0xda, 0xa6, 0xb1, 0x09, 0x7c, 0x25,
+	0x16, 0xe2, 0x9f, 0x24, 0xdf, 0xfc, 0x2a, 0x0b, 0x72, 0xbb, 0x1f, 0xe1, 0x56, 0xc0, 0xa8, 0x07,
+	0x2b, 0x20, 0xe3, 0xd8, 0xba, 0x56, 0xd1, 0xb6, 0xf2, 0xb5, 0xc2, 0xa0, 0x5f, 0x5e, 0x72, 0xec,
+	0x5b, 0xb4, 0xe3, 0x30, 0xdc, 0xe9, 0xb2, 0x9e, 0x99, 0x71, 0x6c, 0xf8, 0x16, 0xc8, 0x76, 0x29,
+	0x75, 0xf5, 0x8c, 0xa0, 0x81, 0x83, 0x7e, 0x79, 0x99, 0xaf, 0x15, 0x2a, 0x81, 0x87, 0xdb, 0x60,
+	0x8e, 0x50, 0x1b, 0xfb, 0xfa, 0x6c, 0x65, 0x76, 0x6b, 0xf1, 0xf6, 0x15, 0x23, 0xe5, 0xda, 0x06,
+	0xb5, 0x71, 0x6d, 0x6d, 0xd0, 0x2f, 0xaf, 0x08, 0x42, 0x45, 0x82, 0xe4, 0x84, 0x1f, 0x80, 0xe5,
+	0x8e, 0x43, 0x9c, 0x4e, 0xd0, 0x79, 0x40, 0x9b, 0x07, 0xce, 0xc7, 0x58, 0xcf, 0x56, 0xb4, 0xad,
+	0xc5, 0xdb, 0xa5, 0xb4, 0x2c, 0x33, 0x74, 0xc6, 0x43, 0xc7, 0x67, 0xb5, 0x2b, 0xcf, 0xfb, 0xe5,
+	0x19, 0x6e, 0xd8, 0x28, 0xb7, 0x99, 0x58, 0x73, 0xf9, 0x2e, 0xf2, 0xd9, 0x51, 0xd7, 0x46, 0x0c,
+	0x1f, 0x3a, 0x1d, 0xac, 0xcf, 0x09, 0xf9, 0x45, 0x43, 0xc6, 0xce, 0x88, 0x1c, 0x67, 0x1c, 0x46,
+	0xc1, 0xad, 0x15, 0x23, 0xd9, 0xa3, 0x9c, 0x9f, 0x7d, 0x59, 0xd6, 0xcc, 0x04, 0x0c, 0xba, 0x00,
+	0x72, 0x88, 0xe5, 0xe1, 0x2e, 0xf5, 0x18, 0xb6, 0x2d, 0x9e, 0x23, 0xfa, 0xe2, 0x54, 0x1d, 0x9b,
+	0x83, 0x7e, 0xb9, 0xc8, 0x39, 0xcd, 0x90, 0x91, 0xa3, 0x62, 0xf7, 0x08, 0x5d, 0x85, 0x24, 0x1e,
+	0xee, 0x81, 0xb5, 0x80, 0x20, 0xdf, 0x77, 0xda, 0x04, 0xdb, 0xd6, 0x87, 0xb4, 0x69, 0x79, 0x01,
+	0xf1, 0xf5, 0x7c, 0x65, 0x76, 0x2b, 0x5f, 0x2b, 0x0f, 0xfa, 0xe5, 0x6b, 0x31, 0xfa, 0x01, 0x6d,
+	0x9a, 0x01, 0x51, 0x5d, 0xbe, 0x9a, 0x42, 0xc2, 0xfb, 0x60, 0x81, 0x1b, 0x4c, 0x03, 0xa6, 0x03,
+	0x61, 0xf3, 0x46, 0xca, 0xe6, 0x9d, 0x30, 0xa7, 0x6b, 0x6b, 0xa1, 0x5b, 0x22, 0x8e, 0x67, 0xdc,
+	0xc6, 0x68, 0xb1, 0x39, 0xb8, 0x02, 0xb2, 0x3c, 0xda, 0xe7, 0x4b, 0x2f, 0x82, 0x3a, 0x58, 0x5f,
+	0x8a, 0xd3, 0x8b, 0xaf, 0xd5, 0xf4, 0xe2, 0x6b, 0x78, 0x1b, 0xe4, 0x70, 0x98, 0xb4, 0xfa, 0x9a,
+	0xa0, 0xbd, 0x32, 0xe8, 0x97, 0x61, 0x04, 0x53, 0xe8, 0x87, 0x74, 0xf0, 0x0e, 0x00, 0x3c, 0xb1,
+	0x76, 0x9a, 0xef, 0xe3, 0x9e, 0xaf, 0xc3, 0xca, 0xec, 0xd6, 0x52, 0x4d, 0x1f, 0xf4, 0xcb, 0xeb,
+	0x31, 0x54, 0xe1, 0x53, 0x68, 0xe1, 0x23, 0x90, 0x17, 0x91, 0xf4, 0x31, 0x26, 0x7a, 0x66, 0x6a,
+	0x00, 0xd7, 0x43, 0x6f, 0xe4, 0x38, 0xd3, 0x01, 0xc6, 0x44, 0x84, 0x6c, 0xb8, 0x82, 0x7b, 0x20,
+	0xcf, 0x85, 0x5b, 0xac, 0xd7, 0xc5, 0xfa, 0x6c, 0x28, 0x6e, 0xec, 0xf9, 0x38, 0xec, 0x75, 0xb1,
+	0xdc, 0x19, 0x09, 0x57, 0xea, 0xce, 0x22, 0x18, 0xbc, 0x0b, 0x96, 0x86, 0x02, 0x2d, 0xc7, 0x16,
+	0xe7, 0x24, 0x1b, 0xef, 0x8d, 0xd3, 0xd4, 0xed, 0xe4, 0xde, 0x24, 0x14, 0x6e, 0x83, 0x79, 0x86,
+	0x1c, 0xc2, 0x7c, 0x7d, 0x4e, 0x9c, 0xd4, 0x0d, 0x43, 0x56, 0x1d, 0x03, 0x75, 0x1d, 0x83, 0x57,
+	0x26, 0xe3, 0xe9, 0xbb, 0xc6, 0x21, 0xa7, 0xa8, 0x2d, 0x87, 0xfb, 0x0a, 0x19, 0xcc, 0xf0, 0x17,
+	0xee, 0x83, 0x79, 0x17, 0x35, 0xb1, 0xeb, 0xeb, 0xf3, 0x42, 0xc4, 0xe6, 0xf8, 0xcd, 0x18, 0x0f,
+	0x05, 0xd1, 0x2e, 0x61, 0x5e, 0xaf, 0xb6, 0x3e, 0xe8, 0x97, 0x0b, 0x92, 0x4b, 0x31, 0x2c, 0x94,
+	0x03, 0x2d, 0xb0, 0xc2, 0x28, 0x43, 0xae, 0x15, 0x55, 0x39, 0x5f, 0x5f, 0x78, 0xb5, 0xb3, 0x2f,
+	0xd8, 0x23, 0x94, 0x6f, 0x26, 0xd6, 0xf0, 0x9f, 0x1a, 0xb8, 0x89, 0x5c, 0x97, 0xb6, 0x10, 0x43,
+	0x4d, 0x17, 0x5b, 0xcd, 0x9e, 0xd5, 0xf5, 0x1c, 0xea, 0x39, 0xac, 0x67, 0x21, 0x62, 0x0f, 0xf5,
+	0xea, 0x39, 0xb1, 0xa3, 0x1f, 0x4f, 0xd8, 0xd1, 0x76, 0x2c, 0xa2, 0xd6, 0xdb, 0x0f, 0x05, 0x6c,
+	0x13, 0x3b, 0x52, 0x24, 0xf7, 0xba, 0x15, 0x1a, 0x55, 0x41, 0x53, 0xc8, 0xcd, 0xa9, 0x14, 0xd0,
+	0x03, 0x6b, 0x3e, 0x43, 0x4c, 0x58, 0x1c, 0x1e, 0x72, 0x1e, 0xf1, 0xbc, 0x30, 0xf3, 0xed, 0x09,
+	0x66, 0x1e, 0x70, 0x8e, 0x5a, 0x4f, 0x9e, 0xec, 0xba, 0x2d, 0xad, 0xba, 0x1a, 0x5a, 0xb5, 0xe2,
+	0x8f, 0x62, 0xcd, 0x24, 0x00, 0x06, 0x60, 0x2d, 0xb4, 0x0b, 0xdb, 0x91, 0x5e, 0xc7, 0xd6, 0x81,
+	0xd0, 0x79, 0xeb, 0x6c, 0xd7, 0x60, 0x5b, 0x08, 0x8a, 0x94, 0xea, 0xa1, 0xd2, 0x02, 0x4a, 0xa0,
+	0xcd, 0x14, 0x04, 0x32, 0x00, 0x47, 0xd4, 0x3e, 0x09, 0x70, 0xc0, 0xeb, 0xe7, 0x39, 0xb5, 0x3e,
+	0xe6, 0xe4, 0x93, 0xb5, 0x0a, 0xb4, 0x99, 0x82, 0xf0, 0xcd, 0xe2, 0xa7, 0x4e, 0x8b, 0xc5, 0x45,
+	0xd4, 0x72, 0x6c, 0x5f, 0x5f, 0x3e, 0x53, 0xed, 0xae, 0xe4, 0x88, 0x3c, 0xe6, 0x27, 0xd4, 0xe2,
+	0x04, 0xda, 0x4c, 0x41, 0xe0, 0xe7, 0x1a, 0x28, 0x11, 0x4a, 0x2c, 0xe4, 0x75, 0x90, 0x8d, 0xac,
+	0x78, 0xe3, 0xf1, 0x09, 0xb8, 0x24, 0x4c, 0xf8, 0xe1, 0x04, 0x13, 0x1a, 0x94, 0x6c, 0x0b, 0xde,
+	0xa1, 0x0b, 0x86, 0xd9, 0x2e, 0xad, 0x79, 0x33, 0xb4, 0xe6, 0x1a, 0x99, 0x4c, 0x69, 0x9e, 0x85,
+	0x84, 0xdb, 0xe0, 0x52, 0x40, 0x42, 0xed, 0x3c, 0x43, 0xf5, 0x95, 0x8a, 0xb6, 0x95, 0xab, 0x5d,
+	0x1b, 0xf4, 0xcb, 0x57, 0x47, 0x10, 0xca, 0x89, 0x1e, 0xe5, 0x80, 0x9f, 0x68, 0xe0, 0x6a, 0xb4,
+	0x23, 0x2b, 0xf0, 0x51, 0x1b, 0xc7, 0x91, 0x2d, 0x88, 0xfd, 0x7d, 0x7f, 0xc2, 0xfe, 0x22, 0x33,
+	0x8e, 0x38, 0xd3, 0x48, 0x74, 0xf9, 0x7d, 0x59, 0xf2, 0xc6, 0xa0, 0x15, 0x33, 0xd6, 0xc7, 0xe1,
+	0xf9, 0x9d, 0x29, 0x2f, 0x67, 0x87, 0xb4, 0xad, 0xb8, 0x24, 0xaf, 0x56, 0xb4, 0xe8, 0xce, 0x1c,
+	0xa2, 0x1b, 0xe9, 0xfa, 0xbb, 0x9a, 0x42, 0xc2, 0x9f, 0x80, 0x65, 0x21, 0xc6, 0x21, 0xc7, 0xd4,
+	0x3a, 0x41, 0xfe, 0x89, 0xbe, 0x5e, 0xd1, 0xb6, 0x96, 0x6a, 0xc5, 0x41, 0xbf, 0x7c, 0x85, 0x63,
+	0xea, 0xe4, 0x98, 0xde, 0x47, 0xfe, 0x89, 0x22, 0x66, 0x49, 0x85, 0x17, 0x11, 0x58, 0x54, 0xca,
+	0x24, 0x7c, 0x13, 0xcc, 0x9e, 0xe2, 0x5e, 0x78, 0x65, 0xae, 0x0e, 0xfa, 0xe5, 0x4b, 0xa7, 0xb8,
+	0xa7, 0x30, 0x73, 0x2c, 0xfc, 0x2e, 0x98, 0x7b, 0x8a, 0xdc, 0x00, 0x87, 0x8f, 0x32, 0xf1, 0xa6,
+	0x12, 0x00, 0xf5, 0x4d, 0x25, 0x00, 0x77, 0x33, 0x77, 0xb4, 0xe2, 0xdf, 0x34, 0xf0, 0x9d, 0x73,
+	0x15, 0x2e, 0x55, 0xfb, 0xdc, 0x44, 0xed, 0x75, 0x55, 0xfb, 0xf4, 0x0a, 0x3d, 0xcd, 0xba, 0xdf,
+	0x6b, 0x60, 0x7d, 0x5c, 0xbd, 0x3a, 0x9f, 0x2b, 0xee, 0xab, 0xc6, 0x2c, 0xdf, 0xbe, 0x91, 0x36,
+	0x46, 0x0a, 0x95, 0x1a, 0xa6, 0xd9, 0xf2, 0x89, 0x06, 0x2e, 0x8f, 0xad, 0x63, 0xe7, 0x33, 0xe6,
+	0xff, 0xec, 0x99, 0x84, 0x35, 0xf1, 0x09, 0xb8, 0x10, 0x6b, 0x4e, 0xc1, 0xe5, 0xb1, 0x55, 0xef,
+	0x6b, 0xa4, 0x6c, 0x6e, 0xaa, 0xb2, 0xbf, 0x68, 0xa0, 0x32, 0xad, 0xc0, 0x5d, 0x48, 0xb6, 0xfe,
+	0x41, 0x03, 0x1b, 0x13, 0x2b, 0xd3, 0x45, 0xc4, 0x65, 0xf3, 0xef, 0x59, 0x90, 0x1b, 0xd6, 0xa3,
+	0x0a, 0xc8, 0xd4, 0xe5, 0x83, 0x3b, 0x2b, 0x1f, 0xdc, 0x23, 0xcf, 0xc0, 0xcc, 0xc8, 0xf3, 0x2f,
+	0xf3, 0x75, 0x9f, 0x7f, 0x87, 0xc3, 0xe7, 0x9f, 0xec, 0xf5, 0xde, 0x9a, 0xfc, 0x96, 0x7d, 0x85,
+	0x27, 0xe0, 0x6f, 0x35, 0x00, 0x03, 0xe2, 0x63, 0x56, 0x27, 0x36, 0xfe, 0x08, 0xdb, 0x92, 0x53,
+	0xcf, 0x0a, 0x15, 0xb7, 0xcf, 0x50, 0x71, 0x94, 0x62, 0x92, 0xea, 0x2a, 0x83, 0x7e, 0xf9, 0x7a,
+	0x5a, 0xa2, 0xa2, 0x7a, 0x8c, 0xbe, 0x6f, 0xa3, 0x1e, 0x77, 0xc0, 0xd5, 0x09, 0x36, 0xbf, 0x0e,
+	0x75, 0x9b, 0xcf, 0xe7, 0xc1, 0x86, 0xc8, 0xd1, 0x7b, 0x6e, 0xe0, 0x33, 0xec, 0x8d, 0xa4, 0x2f,
+	0xac, 0x83, 0x85, 0x96, 0x87, 0xf9, 0xe9, 0xd2, 0xb5, 0xb0, 0x33, 0x99, 0xdc, 0xe8, 0x0c, 0xdb,
+	0xbe, 0x90, 0x45, 0xf4, 0x39, 0xd1, 0x82, 0xdb, 0x25, 0x2f, 0x76, 0xc5, 0xae, 0x27, 0x89, 0x7b,
+	0x59, 0x52, 0xf0, 0xd6, 0x2c, 0x6a, 0xd3, 0xea, 0xb6, 0x68, 0x89, 0xf2, 0xb2, 0x7d, 0x89, 0xa1,
+	0x0a, 0x93, 0x42, 0x0b, 0xff, 0xac, 0xf1, 0x3b, 0x3c, 0xac, 0x03, 0xf1, 0x55, 0x16, 0xe6, 0xc9,
+	0x4e, 0x3a, 0x4f, 0x26, 0x6e, 0xdd, 0x30, 0xd3, 0x62, 0x64, 0xe6, 0xdc, 0x08, 0xb7, 0x39, 0x56,
+	0x91, 0x66, 0x8e, 0x03, 0xc3, 0x7f, 0x69, 0xe0, 0xfa, 0x18, 0xf8, 0x3d, 0x17, 0xf9, 0x7e, 0x03,
+	0x89, 0x59, 0x03, 0x37, 0xf0, 0xd1, 0x37, 0x34, 0x70, 0x28, 0x4f, 0x5a, 0x7a, 0x33, 0xb4, 0xf4,
+	0x4c, 0xd5, 0xe6, 0x99, 0xd8, 0xe2, 0xa7, 0x1a, 0xd0, 0x27, 0xb9, 0xe2, 0x42, 0x6a, 0xec, 0x5f,
+	0x35, 0xf0, 0xc6, 0xd4, 0xad, 0x5f, 0x48, 0xad, 0xfd, 0xf7, 0x2c, 0x28, 0x8e, 0x8b, 0x94, 0x1c,
+	0xcc, 0x0c, 0x67, 0x65, 0xda, 0x94, 0x59, 0x99, 0x72, 0xe6, 0x32, 0xdf, 0xf0, 0xcc, 0x7d, 0xaa,
+	0x81, 0x82, 0x12, 0x5d, 0x91, 0x4b, 0x61, 0x59, 0xae, 0xa5, 0x37, 0x3b, 0xd9, 0x76, 0xc3, 0x4c,
+	0x08, 0x91, 0xf9, 0x55, 0xe2, 0xa3, 0xa9, 0xa4, 0x7c, 0x65, 0x3f, 0x29, 0xdd, 0xc5, 0x67, 0x1a,
+	0xb8, 0x3c, 0x56, 0xd6, 0xf9, 0x02, 0xf6, 0xf3, 0xd1, 0x80, 0xbd, 0xfd, 0x0a, 0xc7, 0x65, 0x6a,
+	0xf4, 0x7e, 0x97, 0x01, 0x4b, 0x6a, 0xb8, 0xe1, 0x07, 0x20, 0x1f, 0x77, 0x5b, 0x9a, 0x70, 0xda,
+	0x3b, 0x67, 0x67, 0x88, 0x91, 0xe8, 0xb1, 0x56, 0xc3, 0xe0, 0xc4, 0x72, 0xcc, 0xf8, 0x6f, 0xf1,
+	0x4f, 0x1a, 0x58, 0x9e, 0xfc, 0x66, 0x99, 0xec, 0x84, 0x5f, 0x8e, 0x3a, 0xc1, 0x50, 0xae, 0xe8,
+	0xe1, 0x5c, 0xd8, 0xe8, 0x9e, 0xb6, 0x39, 0xc0, 0x88, 0xd4, 0x19, 0x8f, 0x03, 0x44, 0x98, 0xc3,
+	0x7a, 0x53, 0xfd, 0xf0, 0xe5, 0x1c, 0x58, 0xe5, 0x33, 0x51, 0xb9, 0x51, 0x87, 0xb4, 0x79, 0x33,
+	0xc2, 0x27, 0x6c, 0xae, 0x73, 0x8c, 0xc5, 0xcc, 0x92, 0x9b, 0x77, 0x49, 0xce, 0xa1, 0x22, 0x98,
+	0x3a, 0x87, 0x8a, 0x60, 0x7c, 0x0e, 0x85, 0x98, 0xd5, 0xa1, 0x3e, 0xb3, 0x28, 0x69, 0x45, 0x8f,
+	0x3b, 0x51, 0xc8, 0x11, 0x7b, 0x44, 0x7d, 0xb6, 0x47, 0x5a, 0x2a, 0x27, 0x88, 0xa1, 0xf0, 0x47,
+	0x60, 0xb1, 0xeb, 0x61, 0x0e, 0x77, 0x78, 0x6b, 0x39, 0x2b, 0x58, 0x37, 0x06, 0xfd, 0xf2, 0x65,
+	0x05, 0xac, 0xf0, 0xaa, 0xd4, 0xf0, 0x3e, 0x28, 0xb4, 0x28, 0x69, 0x05, 0x9e, 0x87, 0x49, 0xab,
+	0x67, 0xf9, 0xe8, 0x58, 0x0e, 0x8b, 0x73, 0xb5, 0x1b, 0x83, 0x7e, 0x79, 0x43, 0xc1, 0x1d, 0xa0,
+	0x63, 0x55, 0xca, 0x4a, 0x02, 0xc5, 0x5b, 0xc2, 0xe1, 0x20, 0xa8, 0xc5, 0x2b, 0x8c, 0x25, 0xe6,
+	0x91, 0xf3, 0x71, 0x4b, 0xd8, 0x4d, 0xd6, 0x1f, 0xb5, 0x25, 0x4c, 0x21, 0xe1, 0x01, 0x58, 0xf4,
+	0x83, 0x66, 0xc7, 0x61, 0x72, 0xfc, 0xbb, 0x30, 0xf5, 0x80, 0x47, 0x23, 0x2c, 0x20, 0xd9, 0x86,
+	0xe3, 0x65, 0x65, 0xcd, 0x83, 0x13, 0x69, 0xd2, 0x73, 0x71, 0x70, 0x22, 0x98, 0x1a, 0x9c, 0x08,
+	0x06, 0x7f, 0x03, 0xd6, 0x64, 0x0a, 0x5b, 0x1e, 0x7e, 0x12, 0x38, 0x1e, 0xee, 0xe0, 0x78, 0xea,
+	0x77, 0x33, 0x9d, 0xe7, 0x7b, 0xe2, 0xd7, 0x54, 0x68, 0xe5, 0x13, 0x8a, 0xa6, 0xe0, 0xea, 0x13,
+	0x2a, 0x8d, 0x85, 0x55, 0xb0, 0xf0, 0x14, 0x7b, 0xbe, 0x43, 0x89, 0x9e, 0x17, 0xb6, 0x5e, 0x1e,
+	0xf4, 0xcb, 0xab, 0x21, 0x48, 0xe1, 0x8d, 0xa8, 0x60, 0x1d, 0xac, 0x8a, 0x67, 0x81, 0xc5, 0x98,
+	0x6b, 0xf9, 0xb8, 0x45, 0x89, 0xed, 0x8b, 0x19, 0xf4, 0xac, 0x0c, 0xa7, 0x40, 0x1e, 0x32, 0xf7,
+	0x40, 0xa2, 0xd4, 0x70, 0x26, 0x50, 0x77, 0xb3, 0xcf, 0x3e, 0x2f, 0x6b, 0x9b, 0x7f, 0xd4, 0x00,
+	0x4c, 0x6f, 0x07, 0xba, 0x60, 0xa5, 0x4b, 0x6d, 0x15, 0x14, 0xbe, 0x79, 0xde, 0x48, 0x7b, 0x63,
+	0x7f, 0x94, 0x50, 0x1a, 0x92, 0xe0, 0x8e, 0x0d, 0xb9, 0x3f, 0x63, 0x26, 0x45, 0xd7, 0x96, 0xc1,
+	0x92, 0xea, 0xf8, 0xcd, 0xff, 0xce, 0x83, 0x95, 0x84, 0x54, 0xe8, 0xcb, 0x41, 0xee, 0x01, 0x76,
+	0x71, 0x8b, 0x8f, 0xb6, 0x65, 0x11, 0x7a, 0x6f, 0xaa, 0x39, 0x46, 0x43, 0xe1, 0x92, 0xa5, 0x68,
+	0x38, 0x72, 0x88, 0xc0, 0xc9, 0x91, 0x43, 0x04, 0x87, 0xfb, 0x20, 0x87, 0x8e, 0x8f, 0x1d, 0xc2,
+	0x93, 0x49, 0x56, 0x98, 0xeb, 0xe3, 0x9a, 0x80, 0xed, 0x90, 0x46, 0xa6, 0x5a, 0xc4, 0xa1, 0xa6,
+	0x5a, 0x04, 0x83, 0x47, 0x60, 0x91, 0x51, 0x17, 0xcb, 0x8f, 0x03, 0x51, 0x5b, 0x50, 0x1a, 0xdb,
+	0x59, 0x0c, 0xc9, 0x86, 0x17, 0x9b, 0xca, 0x6a, 0xaa, 0x0b, 0x48, 0xc1, 0x22, 0x22, 0x84, 0xb2,
+	0x50, 0xec, 0xc2, 0xa4, 0x56, 0x20, 0xe9, 0x9c, 0xed, 0x98, 0x49, 0xfa, 0x46, 0x94, 0x15, 0x45,
+	0x94, 0x5a, 0x56, 0x14, 0xf0, 0xc8, 0x31, 0xcb, 0x8a, 0x27, 0xcf, 0xf4, 0x63, 0xf6, 0x00, 0x14,
+	0xa2, 0xca, 0x44, 0xc9, 0x3e, 0x75, 0x9d, 0x56, 0x4f, 0x7c, 0x57, 0xca, 0xcb, 0xcb, 0x33, 0x89,
+	0x53, 0x2f, 0xcf, 0x24, 0x0e, 0x7e, 0x0c, 0x86, 0x73, 0xab, 0x91, 0x2c, 0x9d, 0x17, 0x51, 0xda,
+	0x1a, 0xe7, 0x50, 0x73, 0x0c, 0x7d, 0xed, 0x7a, 0xe8, 0xda, 0xb1, 0xd2, 0xcc, 0xb1, 0xd0, 0x62,
+	0x1b, 0xac, 0xa6, 0x92, 0xea, 0xb5, 0xb4, 0x3f, 0xc7, 0xa0, 0x90, 0x0c, 0xd0, 0xeb, 0xd0, 0xf3,
+	0x20, 0x9b, 0xcb, 0x15, 0xf2, 0x9b, 0xff, 0xd0, 0xc0, 0xc6, 0x7e, 0xe0, 0xfa, 0xc8, 0x3b, 0x88,
+	0xd2, 0xe6, 0x01, 0x6d, 0xee, 0x60, 0x86, 0x1c, 0xd7, 0xe7, 0x22, 0xc5, 0x90, 0x47, 0xd7, 0x62,
+	0x91, 0x02, 0xa0, 0x8a, 0x14, 0x00, 0x4e, 0xfa, 0x38, 0xd9, 0xdd, 0x24, 0x9f, 0x43, 0x92, 0x02,
+	0xde, 0x02, 0xf3, 0xfc, 0x7e, 0xc5, 0x2c, 0xec, 0x6c, 0x44, 0xe3, 0x2b, 0x21, 0x6a, 0xe3, 0x2b,
+	0x21, 0xdf, 0xdb, 0x03, 0x8b, 0xca, 0x8c, 0x0a, 0x2e, 0x82, 0x85, 0xa3, 0xc6, 0xfb, 0x8d, 0xbd,
+	0x5f, 0x34, 0x0a, 0x33, 0x7c, 0xb1, 0xbf, 0xdb, 0xd8, 0xa9, 0x37, 0x7e, 0x56, 0xd0, 0xf8, 0xc2,
+	0x3c, 0x6a, 0x34, 0xf8, 0x22, 0x03, 0x2f, 0x81, 0xfc, 0xc1, 0xd1, 0xbd, 0x7b, 0xbb, 0xbb, 0x3b,
+	0xbb, 0x3b, 0x85, 0x59, 0x08, 0xc0, 0xfc, 0x4f, 0xb7, 0xeb, 0x0f, 0x77, 0x77, 0x0a, 0xd9, 0xda,
+	0xaf, 0x9f, 0xbf, 0x28, 0x69, 0x5f, 0xbc, 0x28, 0x69, 0x5f, 0xbd, 0x28, 0x69, 0x9f, 0xbd, 0x2c,
+	0xcd, 0x7c, 0xf1, 0xb2, 0x34, 0xf3, 0x9f, 0x97, 0xa5, 0x99, 0x5f, 0xdd, 0x53, 0x3e, 0x15, 0xcb,
+	0xc1, 0x73, 0xd7, 0xa3, 0xfc, 0x0c, 0x85, 0xab, 0xea, 0x39, 0xbe, 0x99, 0x37, 0xe7, 0xc5, 0x1d,
+	0xf6, 0xde, 0xff, 0x06, 0x00, 0xd1, 0x61, 0x0f, 0x3f, 0x61, 0x1f, 0x00, 0x00,
 }
 
 func (m *Executor) Marshal() (dAtA []byte, err error) {
@@ -1184,6 +1219,24 @@ func (m *Executor) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.LastReportedTime != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastReportedTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastReportedTime):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintSchedulerobjects(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x5a
+	}
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Timeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x52
 	if len(m.UnassignedJobRuns) > 0 {
 		for iNdEx := len(m.UnassignedJobRuns) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.UnassignedJobRuns[iNdEx])
@@ -1193,12 +1246,12 @@ func (m *Executor) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x4a
 		}
 	}
-	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastUpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastUpdateTime):])
-	if err1 != nil {
-		return 0, err1
+	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastUpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastUpdateTime):])
+	if err3 != nil {
+		return 0, err3
 	}
-	i -= n1
-	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n1))
+	i -= n3
+	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n3))
 	i--
 	dAtA[i] = 0x2a
 	{
@@ -1262,6 +1315,15 @@ func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.NodeInfoHash) > 0 {
+		i -= len(m.NodeInfoHash)
+		copy(dAtA[i:], m.NodeInfoHash)
+		i = encodeVarintSchedulerobjects(dAtA, i, uint64(len(m.NodeInfoHash)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
 	if len(m.Executor) > 0 {
 		i -= len(m.Executor)
 		copy(dAtA[i:], m.Executor)
@@ -1527,12 +1589,12 @@ func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x1a
 	}
-	n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastSeen, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastSeen):])
-	if err10 != nil {
-		return 0, err10
+	n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastSeen, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastSeen):])
+	if err12 != nil {
+		return 0, err12
 	}
-	i -= n10
-	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n10))
+	i -= n12
+	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n12))
 	i--
 	dAtA[i] = 0x12
 	if len(m.Id) > 0 {
@@ -1705,12 +1767,12 @@ func (m *QueueClusterResourceUsage) MarshalToSizedBuffer(dAtA []byte) (int, erro
 		i--
 		dAtA[i] = 0x12
 	}
-	n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
-	if err13 != nil {
-		return 0, err13
+	n15, err15 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err15 != nil {
+		return 0, err15
 	}
-	i -= n13
-	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n13))
+	i -= n15
+	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n15))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -1762,12 +1824,12 @@ func (m *ClusterResourceUsageReport) MarshalToSizedBuffer(dAtA []byte) (int, err
 			dAtA[i] = 0x1a
 		}
 	}
-	n15, err15 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
-	if err15 != nil {
-		return 0, err15
+	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err17 != nil {
+		return 0, err17
 	}
-	i -= n15
-	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n15))
+	i -= n17
+	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n17))
 	i--
 	dAtA[i] = 0x12
 	if len(m.Pool) > 0 {
@@ -1862,12 +1924,12 @@ func (m *JobSchedulingInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x40
 	}
-	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.SubmitTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.SubmitTime):])
-	if err17 != nil {
-		return 0, err17
+	n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.SubmitTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.SubmitTime):])
+	if err19 != nil {
+		return 0, err19
 	}
-	i -= n17
-	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n17))
+	i -= n19
+	i = encodeVarintSchedulerobjects(dAtA, i, uint64(n19))
 	i--
 	dAtA[i] = 0x3a
 	if len(m.PriorityClassName) > 0 {
@@ -2176,6 +2238,12 @@ func (m *Executor) Size() (n int) {
 			n += 1 + l + sovSchedulerobjects(uint64(l))
 		}
 	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout)
+	n += 1 + l + sovSchedulerobjects(uint64(l))
+	if m.LastReportedTime != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastReportedTime)
+		n += 1 + l + sovSchedulerobjects(uint64(l))
+	}
 	return n
 }
 
@@ -2300,6 +2368,10 @@ func (m *Node) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovSchedulerobjects(uint64(l))
 	}
+	l = len(m.NodeInfoHash)
+	if l > 0 {
+		n += 2 + l + sovSchedulerobjects(uint64(l))
+	}
 	return n
 }
 
@@ -2782,6 +2854,75 @@ func (m *Executor) Unmarshal(dAtA []byte) error {
 			}
 			m.UnassignedJobRuns = append(m.UnassignedJobRuns, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerobjects
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerobjects
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSchedulerobjects
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Timeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastReportedTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerobjects
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerobjects
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSchedulerobjects
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastReportedTime == nil {
+				m.LastReportedTime = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.LastReportedTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerobjects(dAtA[iNdEx:])
@@ -4139,6 +4280,40 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 			}
 			m.Executor = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeInfoHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerobjects
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSchedulerobjects
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSchedulerobjects
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeInfoHash = append(m.NodeInfoHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.NodeInfoHash == nil {
+				m.NodeInfoHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerobjects(dAtA[iNdEx:])
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"math"
 	"math/rand"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
 	"github.com/armadaproject/armada/internal/scheduler/nodedb"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	clientQueue "github.com/armadaproject/armada/pkg/client/queue"
 )
 
 // SchedulingAlgo is the interface between the Pulsar-backed scheduler and the
@@ -57,6 +59,38 @@ type FairSchedulingAlgo struct {
 	// rand and clock injected here for repeatable testing.
 	rand  *rand.Rand
 	clock clock.Clock
+	// If non-nil, the placement of each run created is recorded here.
+	jobSetPlacementTracker *JobSetPlacementTracker
+	// If true, preemption victims are selected taking into account the resource usage reported by executors.
+	usageAwarePreemption bool
+	// If non-nil, no new jobs are scheduled on nodes quarantined here.
+	nodeQuarantine *NodeQuarantine
+	// If non-nil, the weight of each queue is reduced according to the debt it accrued by exceeding its fair share.
+	burstCredits *BurstCreditLedger
+	// Used to coalesce warnings logged repeatedly, e.g., for each round an executor has too many unacknowledged jobs.
+	warnings *logging.WarningCoalescer
+	// If non-nil, used to attribute queued jobs to pools when summarising the capacity and demand of each pool.
+	poolAssigner PoolAssigner
+	// If non-nil, nodes are reserved for gangs that fail to schedule for lack of capacity
+	// and the reserved capacity is backfilled with short-running preemptible jobs.
+	gangReservations *GangReservations
+	// If non-nil, jobs aren't evicted to balance resource usage across queues
+	// while the work lost to preemption within the budget window exceeds the budget.
+	preemptionBudget *PreemptionBudget
+	// If non-empty, nodes matching each job are ranked by the weighted sum of the scores of these scorers.
+	nodeScoringPipeline nodedb.NodeScoringPipeline
+	// If non-nil, executors are taken from the snapshots of this provider rather than fetched each round.
+	executorSnapshots *ExecutorSnapshotProvider
+	// Version of the snapshot the most recent round took executors from.
+	executorSnapshotVersion uint64
+	// If non-nil, the fragmentation of the nodes scheduled on is recorded here at the end of each round.
+	fragmentationTracker *FragmentationTracker
+	// Feature gates enabled for each queue; a nil FeatureGate has no gates enabled.
+	featureGates *FeatureGate
+	// If non-nil, resources allocated to runs of jobs of other shards are unavailable to the jobs of this shard.
+	foreignAllocations *ForeignAllocations
+	// If true, this is a shadow copy; see ShadowCopy.
+	shadow bool
 }
 
 func NewFairSchedulingAlgo(
@@ -69,6 +103,22 @@ func NewFairSchedulingAlgo(
 	if _, ok := config.Preemption.PriorityClasses[config.Preemption.DefaultPriorityClass]; !ok {
 		return nil, errors.Errorf("default priority class %s is missing from priority class mapping %v", config.Preemption.DefaultPriorityClass, config.Preemption.PriorityClasses)
 	}
+	var burstCredits *BurstCreditLedger
+	if config.BurstCredits.Enabled {
+		burstCredits = NewBurstCreditLedger(config.BurstCredits)
+	}
+	var gangReservations *GangReservations
+	if config.GangReservations.Enabled {
+		gangReservations = NewGangReservations(config.GangReservations, config.Preemption.PriorityClasses)
+	}
+	var preemptionBudget *PreemptionBudget
+	if config.PreemptionBudget.Enabled {
+		preemptionBudget = NewPreemptionBudget(config.PreemptionBudget)
+	}
+	nodeScoringPipeline, err := nodedb.NewNodeScoringPipeline(config.NodeScorers)
+	if err != nil {
+		return nil, err
+	}
 	return &FairSchedulingAlgo{
 		schedulingConfig:            config,
 		executorRepository:          executorRepository,
@@ -80,9 +130,60 @@ func NewFairSchedulingAlgo(
 		rand:                        util.NewThreadsafeRand(time.Now().UnixNano()),
 		clock:                       clock.RealClock{},
 		onExecutorScheduled:         func(executor *schedulerobjects.Executor) {},
+		burstCredits:                burstCredits,
+		gangReservations:            gangReservations,
+		preemptionBudget:            preemptionBudget,
+		nodeScoringPipeline:         nodeScoringPipeline,
 	}, nil
 }
 
+// BurstCredits returns the ledger of burst credit debts, or nil if burst credits are disabled.
+func (l *FairSchedulingAlgo) BurstCredits() *BurstCreditLedger {
+	return l.burstCredits
+}
+
+// EnableExecutorSnapshots causes each scheduling round to take executors from the most recent snapshot of provider.
+// The round pins that snapshot, such that the pool assigner used for capacity summaries sees the same executors,
+// provided it takes executors from the same provider.
+func (l *FairSchedulingAlgo) EnableExecutorSnapshots(provider *ExecutorSnapshotProvider) {
+	l.executorSnapshots = provider
+}
+
+// EnableJobSetPlacementTracking causes the node and executor of each run created to be recorded by tracker.
+func (l *FairSchedulingAlgo) EnableJobSetPlacementTracking(tracker *JobSetPlacementTracker) {
+	l.jobSetPlacementTracker = tracker
+}
+
+// EnableUsageAwarePreemption causes jobs that have consumed the least resources, as reported by executors,
+// to be preempted first among otherwise equivalent jobs. Jobs without reported usage are ordered by run age as usual.
+func (l *FairSchedulingAlgo) EnableUsageAwarePreemption() {
+	l.usageAwarePreemption = true
+}
+
+// EnableNodeQuarantine causes nodes quarantined by q to be marked as unschedulable,
+// such that no new jobs are scheduled on them. Jobs already running on them are unaffected.
+func (l *FairSchedulingAlgo) EnableNodeQuarantine(q *NodeQuarantine) {
+	l.nodeQuarantine = q
+}
+
+// EnableWarningCoalescing causes identical warnings logged while scheduling to be coalesced by coalescer.
+func (l *FairSchedulingAlgo) EnableWarningCoalescing(coalescer *logging.WarningCoalescer) {
+	l.warnings = coalescer
+}
+
+// EnableFeatureGates causes the gates enabled for each queue to be recorded in its scheduling contexts,
+// and new behaviour to be enabled for the queues with the corresponding gate enabled.
+func (l *FairSchedulingAlgo) EnableFeatureGates(gates *FeatureGate) {
+	l.featureGates = gates
+}
+
+// EnableForeignAllocations causes the resources allocated to runs of jobs of other shards, as recorded by
+// foreignAllocations, to be marked as allocated on their nodes at all priorities, since jobs of this shard may
+// neither use nor preempt them.
+func (l *FairSchedulingAlgo) EnableForeignAllocations(foreignAllocations *ForeignAllocations) {
+	l.foreignAllocations = foreignAllocations
+}
+
 // Schedule assigns jobs to nodes in the same way as the old lease call.
 // It iterates over each executor in turn (using lexicographical order) and assigns the jobs using a LegacyScheduler, before moving onto the next executor.
 // It maintains state of which executors it has considered already and may take multiple Schedule() calls to consider all executors if scheduling is slow.
@@ -97,7 +198,7 @@ func (l *FairSchedulingAlgo) Schedule(
 		defer cancel()
 	}
 	overallSchedulerResult := &SchedulerResult{
-		NodeIdByJobId:                make(map[string]string),
+		PlacementByJobId:             make(map[string]Placement),
 		AdditionalAnnotationsByJobId: make(map[string]map[string]string),
 	}
 
@@ -107,6 +208,13 @@ func (l *FairSchedulingAlgo) Schedule(
 		return overallSchedulerResult, nil
 	}
 
+	if l.executorSnapshots != nil {
+		snapshot, err := l.executorSnapshots.Snapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ctx = withExecutorSnapshot(ctx, snapshot)
+	}
 	fsctx, err := l.newFairSchedulingAlgoContext(ctx, txn)
 	if err != nil {
 		return nil, err
@@ -186,7 +294,7 @@ func (l *FairSchedulingAlgo) Schedule(
 		overallSchedulerResult.ScheduledJobs = append(overallSchedulerResult.ScheduledJobs, schedulerResult.ScheduledJobs...)
 		overallSchedulerResult.FailedJobs = append(overallSchedulerResult.FailedJobs, schedulerResult.FailedJobs...)
 		overallSchedulerResult.SchedulingContexts = append(overallSchedulerResult.SchedulingContexts, schedulerResult.SchedulingContexts...)
-		maps.Copy(overallSchedulerResult.NodeIdByJobId, schedulerResult.NodeIdByJobId)
+		maps.Copy(overallSchedulerResult.PlacementByJobId, schedulerResult.PlacementByJobId)
 
 		// Update fsctx.
 		fsctx.allocationByPoolAndQueueAndPriorityClass[pool] = sctx.AllocatedByQueueAndPriority()
@@ -230,6 +338,7 @@ func (it *JobQueueIteratorAdapter) Next() (interfaces.LegacySchedulerJob, error)
 
 type fairSchedulingAlgoContext struct {
 	priorityFactorByQueue                    map[string]float64
+	preemptionPolicyByQueue                  map[string]clientQueue.PreemptionPolicy
 	isActiveByQueueName                      map[string]bool
 	totalCapacityByPool                      schedulerobjects.QuantityByTAndResourceType[string]
 	jobsByExecutorId                         map[string][]*jobdb.Job
@@ -237,15 +346,22 @@ type fairSchedulingAlgoContext struct {
 	jobIdsByGangId                           map[string]map[string]bool
 	gangIdByJobId                            map[string]string
 	allocationByPoolAndQueueAndPriorityClass map[string]map[string]schedulerobjects.QuantityByTAndResourceType[string]
+	capacityByPool                           map[string]*schedulercontext.PoolCapacity
 	executors                                []*schedulerobjects.Executor
 	txn                                      *jobdb.Txn
 }
 
+// isNeverPreemptQueue returns true if the preemption policy of queue is never as of the start of the round.
+func (fsctx *fairSchedulingAlgoContext) isNeverPreemptQueue(queue string) bool {
+	return fsctx.preemptionPolicyByQueue[queue] == clientQueue.PreemptionPolicyNever
+}
+
 func (l *FairSchedulingAlgo) newFairSchedulingAlgoContext(ctx *armadacontext.Context, txn *jobdb.Txn) (*fairSchedulingAlgoContext, error) {
-	executors, err := l.executorRepository.GetExecutors(ctx)
+	executors, snapshotVersion, err := getExecutors(ctx, l.executorSnapshots, l.executorRepository)
 	if err != nil {
 		return nil, err
 	}
+	l.executorSnapshotVersion = snapshotVersion
 	executors = l.filterStaleExecutors(executors)
 
 	queues, err := l.queueRepository.GetAllQueues()
@@ -253,8 +369,15 @@ func (l *FairSchedulingAlgo) newFairSchedulingAlgoContext(ctx *armadacontext.Con
 		return nil, err
 	}
 	priorityFactorByQueue := make(map[string]float64)
+	preemptionPolicyByQueue := make(map[string]clientQueue.PreemptionPolicy)
 	for _, queue := range queues {
 		priorityFactorByQueue[queue.Name] = queue.Weight
+		preemptionPolicy, err := clientQueue.NewPreemptionPolicy(queue.PreemptionPolicy)
+		if err != nil {
+			ctx.Warnf("queue %s: %s; using %s", queue.Name, err, clientQueue.PreemptionPolicyStandard)
+			preemptionPolicy = clientQueue.PreemptionPolicyStandard
+		}
+		preemptionPolicyByQueue[queue.Name] = preemptionPolicy
 	}
 
 	// Get the total capacity available across executors.
@@ -311,12 +434,18 @@ func (l *FairSchedulingAlgo) newFairSchedulingAlgoContext(ctx *armadacontext.Con
 	// Used to calculate fair share.
 	totalAllocationByPoolAndQueue := l.aggregateAllocationByPoolAndQueueAndPriorityClass(executors, jobsByExecutorId)
 
+	capacityByPool, err := l.poolCapacities(ctx, txn, totalCapacityByPool, totalAllocationByPoolAndQueue)
+	if err != nil {
+		return nil, err
+	}
+
 	// Filter out any executor that isn't acknowledging jobs in a timely fashion
 	// Note that we do this after aggregating allocation across clusters for fair share.
 	executors = l.filterLaggingExecutors(ctx, executors, jobsByExecutorId)
 
 	return &fairSchedulingAlgoContext{
 		priorityFactorByQueue:                    priorityFactorByQueue,
+		preemptionPolicyByQueue:                  preemptionPolicyByQueue,
 		isActiveByQueueName:                      isActiveByQueueName,
 		totalCapacityByPool:                      totalCapacityByPool,
 		jobsByExecutorId:                         jobsByExecutorId,
@@ -324,6 +453,7 @@ func (l *FairSchedulingAlgo) newFairSchedulingAlgoContext(ctx *armadacontext.Con
 		jobIdsByGangId:                           jobIdsByGangId,
 		gangIdByJobId:                            gangIdByJobId,
 		allocationByPoolAndQueueAndPriorityClass: totalAllocationByPoolAndQueue,
+		capacityByPool:                           capacityByPool,
 		executors:                                executors,
 		txn:                                      txn,
 	}, nil
@@ -337,6 +467,20 @@ func (l *FairSchedulingAlgo) scheduleOnExecutors(
 	minimumJobSize schedulerobjects.ResourceList,
 	executors []*schedulerobjects.Executor,
 ) (*SchedulerResult, *schedulercontext.SchedulingContext, error) {
+	// If there are multiple executors, use pool name instead of executorId.
+	// ExecutorId is only used for reporting so this results in an aggregated report for the pool.
+	executorId := pool
+	if len(executors) == 1 {
+		executorId = executors[0].Id
+	}
+	var reservedGangsResult *SchedulerResult
+	if l.gangReservations != nil {
+		var err error
+		reservedGangsResult, err = l.placeReservedGangs(ctx, fsctx, executorId, pool, executors)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	nodeDb, err := nodedb.NewNodeDb(
 		l.schedulingConfig.Preemption.PriorityClasses,
 		l.schedulingConfig.MaxExtraNodesToConsider,
@@ -348,33 +492,27 @@ func (l *FairSchedulingAlgo) scheduleOnExecutors(
 	if err != nil {
 		return nil, nil, err
 	}
+	nodeDb.EnableNeverPreemptQueues(fsctx.isNeverPreemptQueue)
 	for _, executor := range executors {
-		if err := l.addExecutorToNodeDb(nodeDb, fsctx.jobsByExecutorId[executor.Id], executor.Nodes); err != nil {
+		if err := l.addExecutorToNodeDb(nodeDb, fsctx.jobsByExecutorId[executor.Id], executor.Nodes, executor.Pool); err != nil {
 			return nil, nil, err
 		}
 	}
-
-	// If there are multiple executors, use pool name instead of executorId.
-	// ExecutorId is only used for reporting so this results in an aggregated report for the pool.
-	executorId := pool
-	if len(executors) == 1 {
-		executorId = executors[0].Id
+	if l.jobSetPlacementTracker != nil && l.jobSetPlacementTracker.preferredExecutorWeight > 0 {
+		nodeDb.EnableJobSetExecutorPreference(l.jobSetPlacementTracker.preferredExecutorWeight, l.jobSetPlacementTracker.PreferredExecutor)
+	}
+	// Seeded from l.rand, such that placements are reproducible in tests.
+	nodeDb.EnableNodeOrdering(l.schedulingConfig.GetNodeOrdering(pool), l.rand.Int63())
+	if len(l.nodeScoringPipeline) > 0 {
+		nodeDb.EnableNodeScoring(l.nodeScoringPipeline)
+	}
+	if l.gangReservations != nil {
+		nodeDb.EnableBackfill(l.gangReservations.MayBackfill)
 	}
 	totalResources := fsctx.totalCapacityByPool[pool]
-	var fairnessCostProvider fairness.FairnessCostProvider
-	if l.schedulingConfig.FairnessModel == configuration.DominantResourceFairness {
-		fairnessCostProvider, err = fairness.NewDominantResourceFairness(
-			totalResources,
-			l.schedulingConfig.DominantResourceFairnessResourcesToConsider,
-		)
-		if err != nil {
-			return nil, nil, err
-		}
-	} else {
-		fairnessCostProvider, err = fairness.NewAssetFairness(l.schedulingConfig.ResourceScarcity)
-		if err != nil {
-			return nil, nil, err
-		}
+	fairnessCostProvider, err := l.fairnessCostProvider(totalResources)
+	if err != nil {
+		return nil, nil, err
 	}
 	sctx := schedulercontext.NewSchedulingContext(
 		executorId,
@@ -385,6 +523,18 @@ func (l *FairSchedulingAlgo) scheduleOnExecutors(
 		l.limiter,
 		totalResources,
 	)
+	var crossPoolUsageByQueue map[string]*schedulercontext.CrossPoolUsage
+	if l.schedulingConfig.CrossPoolFairness.Enabled {
+		crossPoolUsageByQueue, err = l.crossPoolUsageByQueue(fsctx, pool)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if l.burstCredits != nil {
+		if err := l.burstCredits.Restore(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
 	for queue, priorityFactor := range fsctx.priorityFactorByQueue {
 		if !fsctx.isActiveByQueueName[queue] {
 			// To ensure fair share is computed only from active queues, i.e., queues with jobs queued or running.
@@ -394,9 +544,22 @@ func (l *FairSchedulingAlgo) scheduleOnExecutors(
 		if allocatedByQueueAndPriorityClass := fsctx.allocationByPoolAndQueueAndPriorityClass[pool]; allocatedByQueueAndPriorityClass != nil {
 			allocatedByPriorityClass = allocatedByQueueAndPriorityClass[queue]
 		}
-		var weight float64 = 1
-		if priorityFactor > 0 {
-			weight = 1 / priorityFactor
+		weight := queueWeight(priorityFactor)
+		preemptionPolicy := fsctx.preemptionPolicyByQueue[queue]
+		if factor := l.schedulingConfig.Preemption.NeverPreemptFairShareFactor; preemptionPolicy == clientQueue.PreemptionPolicyNever && factor > 0 {
+			// Since jobs of this queue are never evicted, it's given a lower fair share to compensate.
+			weight *= factor
+		}
+		if l.burstCredits != nil {
+			weight = l.burstCredits.EffectiveWeight(pool, queue, weight)
+		}
+		var crossPoolUsage *schedulercontext.CrossPoolUsage
+		if crossPoolUsageByQueue != nil {
+			crossPoolUsage = crossPoolUsageByQueue[queue]
+			if crossPoolUsage == nil {
+				crossPoolUsage = &schedulercontext.CrossPoolUsage{}
+			}
+			weight = l.crossPoolEffectiveWeight(weight, crossPoolUsage)
 		}
 		queueLimiter, ok := l.limiterByQueue[queue]
 		if !ok {
@@ -410,6 +573,12 @@ func (l *FairSchedulingAlgo) scheduleOnExecutors(
 		if err := sctx.AddQueueSchedulingContext(queue, weight, allocatedByPriorityClass, queueLimiter); err != nil {
 			return nil, nil, err
 		}
+		if l.burstCredits != nil {
+			sctx.QueueSchedulingContexts[queue].BurstCreditDebt = l.burstCredits.Debt(pool, queue)
+		}
+		sctx.QueueSchedulingContexts[queue].CrossPoolUsage = crossPoolUsage
+		sctx.QueueSchedulingContexts[queue].PreemptionPolicy = preemptionPolicy
+		sctx.QueueSchedulingContexts[queue].FeatureGates = l.featureGates.EnabledGates(queue)
 	}
 	constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
 		pool,
@@ -417,13 +586,29 @@ func (l *FairSchedulingAlgo) scheduleOnExecutors(
 		minimumJobSize,
 		l.schedulingConfig,
 	)
+	sctx.ReservedResourcesByQueue = constraints.ReservedResourcesByQueue
+	sctx.NodeOrdering = string(nodeDb.NodeOrdering())
+	sctx.Capacity = fsctx.capacityByPool[pool]
+	jobRepo := NewSchedulerJobRepositoryAdapter(fsctx.txn)
+	jobRepo.ExcludeBackedOffJobs(l.clock.Now())
+	if queueFilter := queueFilterFromContext(ctx); queueFilter != nil {
+		jobRepo.FilterQueues(queueFilter)
+	}
+	nodeEvictionProbability := l.schedulingConfig.Preemption.NodeEvictionProbability
+	if l.preemptionBudget != nil {
+		sctx.PreemptionBudget = l.preemptionBudget.Usage(pool, l.clock.Now())
+		if sctx.PreemptionBudget.Exhausted {
+			// Jobs are still preempted to make room for jobs of higher priority, but not to balance resource usage.
+			nodeEvictionProbability = 0
+		}
+	}
 	scheduler := NewPreemptingQueueScheduler(
 		sctx,
 		constraints,
-		l.schedulingConfig.Preemption.NodeEvictionProbability,
+		nodeEvictionProbability,
 		l.schedulingConfig.Preemption.NodeOversubscriptionEvictionProbability,
 		l.schedulingConfig.Preemption.ProtectedFractionOfFairShare,
-		NewSchedulerJobRepositoryAdapter(fsctx.txn),
+		jobRepo,
 		nodeDb,
 		fsctx.nodeIdByJobId,
 		fsctx.jobIdsByGangId,
@@ -438,10 +623,46 @@ func (l *FairSchedulingAlgo) scheduleOnExecutors(
 	if l.schedulingConfig.EnableNewPreemptionStrategy {
 		scheduler.EnableNewPreemptionStrategy()
 	}
+	if l.schedulingConfig.EnableCapacityPruning {
+		scheduler.EnableCapacityPruning()
+	}
+	if l.usageAwarePreemption {
+		scheduler.EnableUsageAwarePreemption()
+	}
+	if l.schedulingConfig.EnableGangAwarePreemption {
+		scheduler.EnableGangAwarePreemption()
+	}
+	scheduler.SetClock(l.clock)
 	result, err := scheduler.Schedule(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
+	ClassifyBlockingCauses(sctx)
+	if l.fragmentationTracker != nil {
+		if err := l.fragmentationTracker.Update(sctx, nodeDb, fsctx.txn, fsctx.isNeverPreemptQueue); err != nil {
+			// Fragmentation is only reported; scheduling needn't fail if it can't be computed.
+			logging.WithStacktrace(ctx, err).Warnf("failed to compute fragmentation of executor group %s", executorId)
+		}
+	}
+	if l.burstCredits != nil {
+		weightByQueue := make(map[string]float64, len(fsctx.priorityFactorByQueue))
+		for queue, priorityFactor := range fsctx.priorityFactorByQueue {
+			weightByQueue[queue] = queueWeight(priorityFactor)
+		}
+		l.burstCredits.Update(sctx, weightByQueue, l.clock.Now())
+		if err := l.burstCredits.Store(ctx, pool); err != nil {
+			// Debts are stored again after the next update of the pool.
+			logging.WithStacktrace(ctx, err).Warnf("failed to store the burst credit debts of pool %s", pool)
+		}
+	}
+	if l.preemptionBudget != nil {
+		now := l.clock.Now()
+		l.preemptionBudget.RecordPreemptions(pool, result.PreemptedJobs, now)
+		// Exhausted is left as it was at the start of the round, since that determined whether jobs were evicted.
+		usage := l.preemptionBudget.Usage(pool, now)
+		sctx.PreemptionBudget.Consumed = usage.Consumed
+		sctx.PreemptionBudget.ConsumedGlobally = usage.ConsumedGlobally
+	}
 	for i, jctx := range result.PreemptedJobs {
 		jobDbJob := jctx.Job.(*jobdb.Job)
 		if run := jobDbJob.LatestRun(); run != nil {
@@ -454,35 +675,69 @@ func (l *FairSchedulingAlgo) scheduleOnExecutors(
 	for i, jctx := range result.ScheduledJobs {
 		jobDbJob := jctx.Job.(*jobdb.Job)
 		jobId := jobDbJob.GetId()
-		nodeId := result.NodeIdByJobId[jobId]
-		if nodeId == "" {
-			return nil, nil, errors.Errorf("job %s not mapped to a node", jobId)
-		}
-		node, err := nodeDb.GetNode(nodeId)
-		if err != nil {
-			return nil, nil, err
-		}
-		priority, ok := nodeDb.GetScheduledAtPriority(jobId)
+		placement, ok := result.PlacementByJobId[jobId]
 		if !ok {
-			return nil, nil, errors.Errorf("job %s not mapped to a priority", jobId)
+			return nil, nil, errors.Errorf("job %s not mapped to a node", jobId)
 		}
 		result.ScheduledJobs[i].Job = jobDbJob.
 			WithQueuedVersion(jobDbJob.QueuedVersion()+1).
 			WithQueued(false).
-			WithNewRun(node.Executor, node.Id, node.Name, priority)
+			WithNewRun(placement.Executor, placement.NodeId, placement.NodeName, placement.ScheduledAtPriority, l.clock.Now())
+		if l.jobSetPlacementTracker != nil && !l.shadow {
+			node, err := nodeDb.GetNode(placement.NodeId)
+			if err != nil {
+				return nil, nil, err
+			}
+			l.jobSetPlacementTracker.RecordRun(jobDbJob.GetQueue(), jobDbJob.GetJobSet(), jobId, placement.Executor, placement.NodeName, node.Labels, jobDbJob.GetAnnotations()[configuration.PreferredExecutorAnnotation])
+		}
 	}
 	for i, jctx := range result.FailedJobs {
 		jobDbJob := jctx.Job.(*jobdb.Job)
 		result.FailedJobs[i].Job = jobDbJob.WithQueued(false).WithFailed(true)
 	}
+	if l.gangReservations != nil {
+		// Jobs placed on and preempted from reserved nodes have already been updated.
+		result.ScheduledJobs = append(result.ScheduledJobs, reservedGangsResult.ScheduledJobs...)
+		result.PreemptedJobs = append(result.PreemptedJobs, reservedGangsResult.PreemptedJobs...)
+		for jobId, placement := range reservedGangsResult.PlacementByJobId {
+			result.PlacementByJobId[jobId] = placement
+		}
+		if err := l.reserveNodesForGangs(ctx, fsctx, executorId, pool, executors, sctx); err != nil {
+			return nil, nil, err
+		}
+	}
 	return result, sctx, nil
 }
 
+// fairnessCostProvider returns the fairness cost provider of the configured fairness model
+// for a pool with totalResources.
+func (l *FairSchedulingAlgo) fairnessCostProvider(totalResources schedulerobjects.ResourceList) (fairness.FairnessCostProvider, error) {
+	if l.schedulingConfig.FairnessModel == configuration.DominantResourceFairness {
+		return fairness.NewDominantResourceFairness(
+			totalResources,
+			l.schedulingConfig.DominantResourceFairnessResourcesToConsider,
+		)
+	}
+	return fairness.NewAssetFairness(l.schedulingConfig.ResourceScarcity)
+}
+
+// queueWeight returns the weight of a queue with the provided priority factor.
+func queueWeight(priorityFactor float64) float64 {
+	if priorityFactor > 0 {
+		return 1 / priorityFactor
+	}
+	return 1
+}
+
 // Adapter to make jobDb implement the JobRepository interface.
 //
 // TODO: Pass JobDb into the scheduler instead of using this shim to convert to a JobRepo.
 type SchedulerJobRepositoryAdapter struct {
 	txn *jobdb.Txn
+	// If non-zero, jobs backed off at this time are omitted from the queued jobs returned by GetQueueJobIds.
+	backoffTime time.Time
+	// If non-nil, GetQueueJobIds returns no jobs for queues for which this returns false.
+	queueFilter func(queue string) bool
 }
 
 func NewSchedulerJobRepositoryAdapter(txn *jobdb.Txn) *SchedulerJobRepositoryAdapter {
@@ -491,12 +746,28 @@ func NewSchedulerJobRepositoryAdapter(txn *jobdb.Txn) *SchedulerJobRepositoryAda
 	}
 }
 
+// ExcludeBackedOffJobs causes GetQueueJobIds to omit jobs that are backed off at time t.
+func (repo *SchedulerJobRepositoryAdapter) ExcludeBackedOffJobs(t time.Time) {
+	repo.backoffTime = t
+}
+
+// FilterQueues causes GetQueueJobIds to omit the jobs of queues for which filter returns false.
+func (repo *SchedulerJobRepositoryAdapter) FilterQueues(filter func(queue string) bool) {
+	repo.queueFilter = filter
+}
+
 // GetQueueJobIds is necessary to implement the JobRepository interface, which we need while transitioning from the old
 // to new scheduler.
 func (repo *SchedulerJobRepositoryAdapter) GetQueueJobIds(queue string) ([]string, error) {
 	rv := make([]string, 0)
+	if repo.queueFilter != nil && !repo.queueFilter(queue) {
+		return rv, nil
+	}
 	it := repo.txn.QueuedJobs(queue)
 	for v, _ := it.Next(); v != nil; v, _ = it.Next() {
+		if !repo.backoffTime.IsZero() && v.IsBackedOff(repo.backoffTime) {
+			continue
+		}
 		rv = append(rv, v.Id())
 	}
 	return rv, nil
@@ -515,7 +786,10 @@ func (repo *SchedulerJobRepositoryAdapter) GetExistingJobsByIds(ids []string) ([
 }
 
 // addExecutorToNodeDb adds all the nodes and jobs associated with a particular executor to the nodeDb.
-func (l *FairSchedulingAlgo) addExecutorToNodeDb(nodeDb *nodedb.NodeDb, jobs []*jobdb.Job, nodes []*schedulerobjects.Node) error {
+// The resources of the nodes are overcommitted according to the overcommit factors of pool, the pool of the executor.
+// Quarantined nodes are added as unschedulable and nodes reserved for gangs are tainted with nodedb.GangReservationTaint().
+// Resources allocated to runs of jobs of other shards aren't allocatable at any priority.
+func (l *FairSchedulingAlgo) addExecutorToNodeDb(nodeDb *nodedb.NodeDb, jobs []*jobdb.Job, nodes []*schedulerobjects.Node, pool string) error {
 	txn := nodeDb.Txn(true)
 	defer txn.Abort()
 	nodesById := armadaslices.GroupByFuncUnique(
@@ -537,8 +811,25 @@ func (l *FairSchedulingAlgo) addExecutorToNodeDb(nodeDb *nodedb.NodeDb, jobs []*
 		}
 		jobsByNodeId[nodeId] = append(jobsByNodeId[nodeId], job)
 	}
+	overcommitFactors := l.schedulingConfig.GetOvercommitFactors(pool)
+	var foreignAllocatedByNode map[string]schedulerobjects.ResourceList
+	if l.foreignAllocations != nil && len(nodes) > 0 {
+		foreignAllocatedByNode = l.foreignAllocations.AllocatedByNode(nodes[0].Executor)
+	}
 	for _, node := range nodes {
-		if err := nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, jobsByNodeId[node.Id], node); err != nil {
+		if allocated, ok := foreignAllocatedByNode[node.Name]; ok {
+			node = node.DeepCopy()
+			schedulerobjects.AllocatableByPriorityAndResourceType(node.AllocatableByPriorityAndResource).MarkAllocated(math.MaxInt32, allocated)
+		}
+		if l.nodeQuarantine != nil && !node.Unschedulable && l.nodeQuarantine.IsQuarantined(node.Executor, node.Name) {
+			node = node.DeepCopy()
+			node.Unschedulable = true
+		}
+		if l.gangReservations != nil && l.gangReservations.IsReserved(node.Id) {
+			node = node.DeepCopy()
+			node.Taints = append(node.Taints, nodedb.GangReservationTaint())
+		}
+		if err := nodeDb.CreateAndInsertWithJobDbJobsWithTxn(txn, jobsByNodeId[node.Id], node.WithOvercommit(overcommitFactors)); err != nil {
 			return err
 		}
 	}
@@ -598,7 +889,8 @@ func (l *FairSchedulingAlgo) filterLaggingExecutors(
 		if numUnacknowledgedJobs <= l.schedulingConfig.MaxUnacknowledgedJobsPerExecutor {
 			activeExecutors = append(activeExecutors, executor)
 		} else {
-			ctx.Warnf(
+			l.warnings.Warnf(
+				ctx, executor.Id,
 				"%d unacknowledged jobs on executor %s exceeds limit of %d; executor will not be considered for scheduling",
 				numUnacknowledgedJobs, executor.Id, l.schedulingConfig.MaxUnacknowledgedJobsPerExecutor,
 			)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
@@ -15,12 +16,15 @@ import (
 	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/armadacontext"
 	armadaslices "github.com/armadaproject/armada/internal/common/slices"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/database"
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
 	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
 	"github.com/armadaproject/armada/internal/scheduler/nodedb"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+	clientQueue "github.com/armadaproject/armada/pkg/client/queue"
 )
 
 func TestSchedule(t *testing.T) {
@@ -401,7 +405,7 @@ func TestSchedule(t *testing.T) {
 				for nodeIndex, existingJobs := range existingJobsByExecutorNodeIndex {
 					node := executor.Nodes[nodeIndex]
 					for jobIndex, job := range existingJobs.jobs {
-						job = job.WithQueued(false).WithNewRun(executor.Id, node.Id, node.Name, job.PodRequirements().Priority)
+						job = job.WithQueued(false).WithNewRun(executor.Id, node.Id, node.Name, job.PodRequirements().Priority, testfixtures.BaseTime)
 						if existingJobs.acknowledged {
 							run := job.LatestRun()
 							node.StateByJobRunId[run.Id().String()] = schedulerobjects.JobRunState_RUNNING
@@ -480,8 +484,16 @@ func TestSchedule(t *testing.T) {
 				assert.False(t, dbJob.Queued())
 				dbRun := dbJob.LatestRun()
 				assert.False(t, dbRun.Failed())
-				assert.Equal(t, schedulerResult.NodeIdByJobId[dbJob.Id()], dbRun.NodeId())
+				placement := schedulerResult.PlacementByJobId[dbJob.Id()]
+				assert.Equal(t, placement.Executor, dbRun.Executor())
+				assert.Equal(t, placement.NodeId, dbRun.NodeId())
+				assert.Equal(t, placement.NodeName, dbRun.NodeName())
 				assert.NotEmpty(t, dbRun.NodeName())
+				scheduledAtPriority, ok := dbJob.GetScheduledAtPriority()
+				assert.True(t, ok)
+				assert.Equal(t, placement.ScheduledAtPriority, scheduledAtPriority)
+				assert.Equal(t, testfixtures.TestPool, placement.Pool)
+				assert.Equal(t, testfixtures.BaseTime.UnixNano(), dbRun.Created())
 			}
 
 			// Check that failed jobs are marked as such consistently.
@@ -505,6 +517,34 @@ func TestSchedule(t *testing.T) {
 				dbJob := txn.GetById(job.Id())
 				assert.True(t, job.Equal(dbJob), "expected %v but got %v", job, dbJob)
 			}
+
+			// Check that lease events are placed identically to the runs created.
+			eventSequences, err := EventsFromSchedulerResult(schedulerResult, testfixtures.BaseTime)
+			require.NoError(t, err)
+			scheduledJobsByRunId := make(map[uuid.UUID]*jobdb.Job, len(scheduledJobs))
+			for _, job := range scheduledJobs {
+				dbJob := txn.GetById(job.Id())
+				scheduledJobsByRunId[dbJob.LatestRun().Id()] = dbJob
+			}
+			numLeased := 0
+			for _, sequence := range eventSequences {
+				for _, event := range sequence.Events {
+					leased := event.GetJobRunLeased()
+					if leased == nil {
+						continue
+					}
+					numLeased++
+					dbJob, ok := scheduledJobsByRunId[armadaevents.UuidFromProtoUuid(leased.RunId)]
+					require.True(t, ok)
+					dbRun := dbJob.LatestRun()
+					assert.Equal(t, dbRun.Executor(), leased.ExecutorId)
+					assert.Equal(t, dbRun.NodeName(), leased.NodeId)
+					scheduledAtPriority, ok := dbJob.GetScheduledAtPriority()
+					assert.Equal(t, ok, leased.HasScheduledAtPriority)
+					assert.Equal(t, scheduledAtPriority, leased.ScheduledAtPriority)
+				}
+			}
+			assert.Equal(t, len(scheduledJobs), numLeased)
 		})
 	}
 }
@@ -517,7 +557,7 @@ func BenchmarkNodeDbConstruction(b *testing.B) {
 			nodes := testfixtures.N32CpuNodes(numNodes, testfixtures.TestPriorities)
 			for i, node := range nodes {
 				for j := 32 * i; j < 32*(i+1); j++ {
-					jobs[j] = jobs[j].WithNewRun("executor-01", node.Id, node.Name, jobs[j].PodRequirements().Priority)
+					jobs[j] = jobs[j].WithNewRun("executor-01", node.Id, node.Name, jobs[j].PodRequirements().Priority, testfixtures.BaseTime)
 				}
 			}
 			armadaslices.Shuffle(jobs)
@@ -544,9 +584,84 @@ func BenchmarkNodeDbConstruction(b *testing.B) {
 					schedulingConfig.WellKnownNodeTypes,
 				)
 				require.NoError(b, err)
-				err = algo.addExecutorToNodeDb(nodeDb, jobs, nodes)
+				err = algo.addExecutorToNodeDb(nodeDb, jobs, nodes, testfixtures.TestPool)
 				require.NoError(b, err)
 			}
 		})
 	}
 }
+
+func TestSchedule_PreemptionPolicies(t *testing.T) {
+	ctx := armadacontext.Background()
+	config := testfixtures.TestSchedulingConfig()
+	config.Preemption.NeverPreemptFairShareFactor = 0.5
+	executor := testfixtures.Test1Node32CoreExecutor("executor")
+	node := executor.Nodes[0]
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{executor}, nil).AnyTimes()
+	// The policy of queue protected is changed from never to standard between rounds.
+	mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+	gomock.InOrder(
+		mockQueueRepo.EXPECT().GetAllQueues().Return([]*database.Queue{
+			{Name: "protected", Weight: 1, PreemptionPolicy: string(clientQueue.PreemptionPolicyNever)},
+			{Name: "other", Weight: 1},
+			{Name: "new", Weight: 1},
+		}, nil),
+		mockQueueRepo.EXPECT().GetAllQueues().Return([]*database.Queue{
+			{Name: "protected", Weight: 1, PreemptionPolicy: string(clientQueue.PreemptionPolicyStandard)},
+			{Name: "other", Weight: 1},
+			{Name: "new", Weight: 1},
+		}, nil),
+	)
+	algo, err := NewFairSchedulingAlgo(config, 0, mockExecutorRepo, mockQueueRepo, nil)
+	require.NoError(t, err)
+	algo.clock = clock.NewFakeClock(testfixtures.BaseTime)
+
+	// Queues protected and other are running preemptible jobs filling the node and queue new submits as many jobs.
+	var runningJobs []*jobdb.Job
+	for _, queue := range []string{"protected", "other"} {
+		for _, job := range testfixtures.N1Cpu4GiJobs(queue, testfixtures.PriorityClass0, 16) {
+			runningJobs = append(runningJobs, job.WithQueued(false).WithNewRun(executor.Id, node.Id, node.Name, 0, testfixtures.BaseTime))
+		}
+	}
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(runningJobs))
+	require.NoError(t, txn.Upsert(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("new", testfixtures.PriorityClass0, 32))))
+	txn.Commit()
+
+	schedule := func() (map[string]int, map[string]int, *schedulercontext.QueueSchedulingContext) {
+		txn := jobDb.WriteTxn()
+		defer txn.Abort()
+		result, err := algo.Schedule(ctx, txn)
+		require.NoError(t, err)
+		numPreemptedByQueue := make(map[string]int)
+		for _, job := range PreemptedJobsFromSchedulerResult[*jobdb.Job](result) {
+			numPreemptedByQueue[job.Queue()]++
+		}
+		numScheduledByQueue := make(map[string]int)
+		for _, job := range ScheduledJobsFromSchedulerResult[*jobdb.Job](result) {
+			numScheduledByQueue[job.Queue()]++
+		}
+		require.Len(t, result.SchedulingContexts, 1)
+		return numPreemptedByQueue, numScheduledByQueue, result.SchedulingContexts[0].QueueSchedulingContexts["protected"]
+	}
+
+	// Jobs of the never-preempt queue aren't preempted, and its fair share is reduced.
+	numPreemptedByQueue, numScheduledByQueue, qctx := schedule()
+	assert.Zero(t, numPreemptedByQueue["protected"])
+	assert.Positive(t, numPreemptedByQueue["other"])
+	assert.Equal(t, numPreemptedByQueue["other"], numScheduledByQueue["new"])
+	assert.Equal(t, clientQueue.PreemptionPolicyNever, qctx.PreemptionPolicy)
+	assert.Equal(t, 0.5, qctx.Weight)
+	assert.Regexp(t, `Preemption policy:\s+never`, qctx.ReportString(0))
+
+	// The policy change takes effect the next round.
+	numPreemptedByQueue, numScheduledByQueue, qctx = schedule()
+	assert.Positive(t, numPreemptedByQueue["protected"])
+	assert.Equal(t, numPreemptedByQueue["protected"]+numPreemptedByQueue["other"], numScheduledByQueue["new"])
+	assert.Equal(t, clientQueue.PreemptionPolicyStandard, qctx.PreemptionPolicy)
+	assert.Equal(t, 1.0, qctx.Weight)
+	assert.Regexp(t, `Preemption policy:\s+standard`, qctx.ReportString(0))
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// Reasons longer than this are truncated when recorded as scheduling outcomes, such that outcomes remain compact.
+const maxSchedulingOutcomeReasonLength = 256
+
+type cycleIdKey struct{}
+
+// withCycleId returns a copy of ctx associated with the id of the scheduler cycle it's used for.
+func withCycleId(ctx *armadacontext.Context, cycleId string) *armadacontext.Context {
+	return armadacontext.WithValue(ctx, cycleIdKey{}, cycleId)
+}
+
+// cycleIdFromContext returns the id of the scheduler cycle ctx is used for, or the empty string if there's none.
+func cycleIdFromContext(ctx *armadacontext.Context) string {
+	cycleId, _ := ctx.Value(cycleIdKey{}).(string)
+	return cycleId
+}
+
+// recordSchedulingOutcomes records in txn the outcome of evaluating each queued job considered by the scheduling round
+// that produced result, replacing that of previous rounds. Jobs evicted by the round are ignored, since they were
+// running rather than queued. If a job was evaluated for several executors, it's recorded as scheduled if it was
+// scheduled for any of them, as failed if it was failed, and as unschedulable for the last otherwise.
+func (s *Scheduler) recordSchedulingOutcomes(ctx *armadacontext.Context, txn *jobdb.Txn, result *SchedulerResult) error {
+	cycleId := cycleIdFromContext(ctx)
+	now := s.clock.Now()
+	outcomesByJobId := make(map[string]jobdb.SchedulingOutcome)
+	newOutcome := func(kind jobdb.SchedulingOutcomeKind, reason string) jobdb.SchedulingOutcome {
+		if len(reason) > maxSchedulingOutcomeReasonLength {
+			reason = reason[:maxSchedulingOutcomeReasonLength-3] + "..."
+		}
+		return jobdb.SchedulingOutcome{CycleId: cycleId, Time: now, Kind: kind, Reason: reason}
+	}
+	for _, sctx := range result.SchedulingContexts {
+		for _, qctx := range sctx.QueueSchedulingContexts {
+			for jobId, jctx := range qctx.UnsuccessfulJobSchedulingContexts {
+				if qctx.EvictedJobsById[jobId] {
+					continue
+				}
+				outcomesByJobId[jobId] = newOutcome(jobdb.SchedulingOutcomeUnschedulable, jctx.UnschedulableReason)
+			}
+		}
+	}
+	for _, jctx := range result.FailedJobs {
+		outcomesByJobId[jctx.JobId] = newOutcome(jobdb.SchedulingOutcomeFailed, jctx.UnschedulableReason)
+	}
+	for _, jctx := range result.ScheduledJobs {
+		reason := ""
+		if nodeId := result.PlacementByJobId[jctx.JobId].NodeId; nodeId != "" {
+			reason = "scheduled onto node " + nodeId
+		}
+		outcomesByJobId[jctx.JobId] = newOutcome(jobdb.SchedulingOutcomeScheduled, reason)
+	}
+	return txn.SetSchedulingOutcomes(outcomesByJobId)
+}
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestScheduler_RecordSchedulingOutcomes(t *testing.T) {
+	jobId := queuedJob.Id()
+	unsuccessful := func(reason string) *schedulercontext.SchedulingContext {
+		return &schedulercontext.SchedulingContext{
+			QueueSchedulingContexts: map[string]*schedulercontext.QueueSchedulingContext{
+				queuedJob.Queue(): {
+					UnsuccessfulJobSchedulingContexts: map[string]*schedulercontext.JobSchedulingContext{
+						jobId: {JobId: jobId, UnschedulableReason: reason},
+					},
+				},
+			},
+		}
+	}
+	longReason := strings.Repeat("x", 2*maxSchedulingOutcomeReasonLength)
+	truncatedLongReason := longReason[:maxSchedulingOutcomeReasonLength-3] + "..."
+	cycles := []struct {
+		result *SchedulerResult
+		// Index of the cycle the outcome retained after this cycle is expected to be from, or -1 if there's none.
+		expectedCycle  int
+		expectedKind   jobdb.SchedulingOutcomeKind
+		expectedReason string
+	}{
+		{
+			result:        &SchedulerResult{},
+			expectedCycle: -1,
+		},
+		{
+			result: &SchedulerResult{
+				SchedulingContexts: []*schedulercontext.SchedulingContext{unsuccessful("insufficient cpu")},
+			},
+			expectedCycle:  1,
+			expectedKind:   jobdb.SchedulingOutcomeUnschedulable,
+			expectedReason: "insufficient cpu",
+		},
+		{
+			result: &SchedulerResult{
+				SchedulingContexts: []*schedulercontext.SchedulingContext{unsuccessful(longReason)},
+			},
+			expectedCycle:  2,
+			expectedKind:   jobdb.SchedulingOutcomeUnschedulable,
+			expectedReason: truncatedLongReason,
+		},
+		{
+			// Jobs not evaluated retain the outcome of the most recent round in which they were.
+			result:         &SchedulerResult{},
+			expectedCycle:  2,
+			expectedKind:   jobdb.SchedulingOutcomeUnschedulable,
+			expectedReason: truncatedLongReason,
+		},
+		{
+			// Scheduled for one executor, but not another.
+			result: &SchedulerResult{
+				SchedulingContexts: []*schedulercontext.SchedulingContext{unsuccessful("node selector mismatch")},
+				ScheduledJobs:      []*schedulercontext.JobSchedulingContext{{JobId: jobId}},
+				PlacementByJobId:   map[string]Placement{jobId: {NodeId: "node-1"}},
+			},
+			expectedCycle:  4,
+			expectedKind:   jobdb.SchedulingOutcomeScheduled,
+			expectedReason: "scheduled onto node node-1",
+		},
+		{
+			result: &SchedulerResult{
+				FailedJobs: []*schedulercontext.JobSchedulingContext{{JobId: jobId, UnschedulableReason: "gang unschedulable"}},
+			},
+			expectedCycle:  5,
+			expectedKind:   jobdb.SchedulingOutcomeFailed,
+			expectedReason: "gang unschedulable",
+		},
+	}
+
+	baseTime := time.Now()
+	fakeClock := clock.NewFakeClock(baseTime)
+	sched := &Scheduler{clock: fakeClock}
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert([]*jobdb.Job{queuedJob}))
+	txn.Commit()
+	for i, cycle := range cycles {
+		fakeClock.SetTime(baseTime.Add(time.Duration(i) * time.Second))
+		ctx := withCycleId(armadacontext.Background(), fmt.Sprintf("cycle-%d", i))
+
+		txn := jobDb.WriteTxn()
+		require.NoError(t, sched.recordSchedulingOutcomes(ctx, txn, cycle.result))
+		txn.Commit()
+
+		outcome, ok := jobDb.ReadTxn().GetSchedulingOutcome(jobId)
+		if cycle.expectedCycle < 0 {
+			assert.False(t, ok, "cycle %d", i)
+			continue
+		}
+		require.True(t, ok, "cycle %d", i)
+		assert.Equal(
+			t,
+			jobdb.SchedulingOutcome{
+				CycleId: fmt.Sprintf("cycle-%d", cycle.expectedCycle),
+				Time:    baseTime.Add(time.Duration(cycle.expectedCycle) * time.Second),
+				Kind:    cycle.expectedKind,
+				Reason:  cycle.expectedReason,
+			},
+			outcome,
+			"cycle %d", i,
+		)
+	}
+
+	// The outcome is included in the job report.
+	repo, err := NewSchedulingContextRepository(1024)
+	require.NoError(t, err)
+	repo.EnableSchedulingOutcomeReports(jobDb)
+	assert.Contains(t, repo.getJobReportString(jobId), "Last scheduling outcome: Failed at")
+	assert.Contains(t, repo.getJobReportString(jobId), "gang unschedulable")
+
+	// Outcomes are deleted together with their job, and not recorded for jobs not in the jobDb.
+	txn = jobDb.WriteTxn()
+	require.NoError(t, txn.BatchDelete([]string{jobId}))
+	_, ok := txn.GetSchedulingOutcome(jobId)
+	assert.False(t, ok)
+	require.NoError(t, sched.recordSchedulingOutcomes(armadacontext.Background(), txn, cycles[1].result))
+	_, ok = txn.GetSchedulingOutcome(jobId)
+	assert.False(t, ok)
+	txn.Commit()
+	assert.Contains(t, repo.getJobReportString(jobId), "Last scheduling outcome: none recorded")
+}
@@ -0,0 +1,175 @@
+package scheduler
+
+import (
+	"runtime/debug"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// ErrSchedulingPanic is returned by scheduling rounds in which the scheduling algorithm panicked.
+var ErrSchedulingPanic = errors.New("scheduling algorithm panicked")
+
+type queueFilterKey struct{}
+
+// withQueueFilter returns a copy of ctx such that scheduling algorithms using it only schedule the queued jobs of queues
+// for which filter returns true. Running jobs of other queues are still taken into account, e.g., for fair share.
+func withQueueFilter(ctx *armadacontext.Context, filter func(queue string) bool) *armadacontext.Context {
+	return armadacontext.WithValue(ctx, queueFilterKey{}, filter)
+}
+
+// queueFilterFromContext returns the queue filter ctx is associated with, or nil if there's none.
+func queueFilterFromContext(ctx *armadacontext.Context) func(queue string) bool {
+	filter, _ := ctx.Value(queueFilterKey{}).(func(queue string) bool)
+	return filter
+}
+
+// EnableQueueQuarantine causes queues that make the scheduling algorithm panic to be quarantined, i.e., their queued
+// jobs are skipped, such that other queues keep being scheduled. Once config.MaxConsecutivePanics consecutive rounds
+// have panicked, queues are scheduled one at a time to find those responsible.
+func (s *Scheduler) EnableQueueQuarantine(config schedulerconfig.QueueQuarantineConfig) {
+	if config.MaxConsecutivePanics < 1 {
+		config.MaxConsecutivePanics = 1
+	}
+	s.queueQuarantineConfig = &config
+	s.quarantinedQueues = make(map[string]time.Time)
+}
+
+// schedule runs a scheduling round, recovering from any panic in the scheduling algorithm.
+// Rounds that panic return ErrSchedulingPanic, in which case any changes made to txn must be discarded.
+func (s *Scheduler) schedule(ctx *armadacontext.Context, txn *jobdb.Txn) (*SchedulerResult, error) {
+	if s.queueQuarantineConfig == nil {
+		return s.scheduleRecoveringPanics(ctx, txn)
+	}
+	s.releaseExpiredQuarantines(ctx)
+	if s.consecutiveSchedulingPanics >= s.queueQuarantineConfig.MaxConsecutivePanics {
+		return s.scheduleQueuesIndividually(ctx, txn)
+	}
+	if len(s.quarantinedQueues) > 0 {
+		quarantinedQueues := maps.Keys(s.quarantinedQueues)
+		slices.Sort(quarantinedQueues)
+		ctx.Errorf("skipping queued jobs of quarantined queues %v since they caused the scheduling algorithm to panic", quarantinedQueues)
+		ctx = withQueueFilter(ctx, func(queue string) bool {
+			_, ok := s.quarantinedQueues[queue]
+			return !ok
+		})
+	}
+	result, err := s.scheduleRecoveringPanics(ctx, txn)
+	if errors.Is(err, ErrSchedulingPanic) {
+		s.consecutiveSchedulingPanics++
+	} else if err == nil {
+		s.consecutiveSchedulingPanics = 0
+	}
+	return result, err
+}
+
+// scheduleRecoveringPanics calls the scheduling algorithm, converting any panic into an ErrSchedulingPanic error.
+func (s *Scheduler) scheduleRecoveringPanics(ctx *armadacontext.Context, txn *jobdb.Txn) (result *SchedulerResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, errors.Wrapf(ErrSchedulingPanic, "%v", r)
+			ctx.WithError(err).WithField("stacktrace", string(debug.Stack())).Error("recovered from panic in scheduling algorithm")
+			s.metrics.ReportSchedulingPanic()
+		}
+	}()
+	return s.schedulingAlgo.Schedule(ctx, txn)
+}
+
+// scheduleQueuesIndividually runs a separate scheduling round for each queue with queued jobs that isn't quarantined,
+// considering only the queued jobs of that queue. Each queue whose round panics has the changes made by its round
+// rolled back and is quarantined. The results of the remaining rounds are combined.
+// If every queue panics on its own, and there's more than one, the panic is assumed not to be caused by any particular
+// queue, in which case no queue is quarantined and ErrSchedulingPanic is returned.
+func (s *Scheduler) scheduleQueuesIndividually(ctx *armadacontext.Context, txn *jobdb.Txn) (*SchedulerResult, error) {
+	queues := make([]string, 0)
+	for _, queue := range txn.QueuesWithQueuedJobs() {
+		if _, ok := s.quarantinedQueues[queue]; !ok {
+			queues = append(queues, queue)
+		}
+	}
+	ctx.Errorf(
+		"%d consecutive scheduling rounds panicked; scheduling queues %v one at a time to find those responsible",
+		s.consecutiveSchedulingPanics, queues,
+	)
+	s.consecutiveSchedulingPanics = 0
+
+	overallSchedulerResult := &SchedulerResult{
+		PlacementByJobId:             make(map[string]Placement),
+		AdditionalAnnotationsByJobId: make(map[string]map[string]string),
+	}
+	panickingQueues := make([]string, 0)
+	for _, queue := range queues {
+		queue := queue
+		savepoint := txn.Savepoint()
+		result, err := s.scheduleRecoveringPanics(
+			withQueueFilter(ctx, func(q string) bool { return q == queue }),
+			txn,
+		)
+		if errors.Is(err, ErrSchedulingPanic) {
+			if err := txn.RollbackTo(savepoint); err != nil {
+				return nil, err
+			}
+			panickingQueues = append(panickingQueues, queue)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		overallSchedulerResult.PreemptedJobs = append(overallSchedulerResult.PreemptedJobs, result.PreemptedJobs...)
+		overallSchedulerResult.ScheduledJobs = append(overallSchedulerResult.ScheduledJobs, result.ScheduledJobs...)
+		overallSchedulerResult.FailedJobs = append(overallSchedulerResult.FailedJobs, result.FailedJobs...)
+		overallSchedulerResult.SchedulingContexts = append(overallSchedulerResult.SchedulingContexts, result.SchedulingContexts...)
+		maps.Copy(overallSchedulerResult.PlacementByJobId, result.PlacementByJobId)
+		maps.Copy(overallSchedulerResult.AdditionalAnnotationsByJobId, result.AdditionalAnnotationsByJobId)
+	}
+	if len(panickingQueues) == 0 {
+		ctx.Error("no queue caused the scheduling algorithm to panic when scheduled on its own; no queues were quarantined")
+	} else if len(panickingQueues) == len(queues) && len(queues) > 1 {
+		return nil, errors.Wrapf(
+			ErrSchedulingPanic,
+			"every one of queues %v caused the scheduling algorithm to panic when scheduled on its own; no queues were quarantined",
+			queues,
+		)
+	}
+	for _, queue := range panickingQueues {
+		s.quarantineQueue(ctx, queue)
+	}
+	return overallSchedulerResult, nil
+}
+
+// quarantineQueue causes the queued jobs of queue to be skipped by subsequent scheduling rounds.
+func (s *Scheduler) quarantineQueue(ctx *armadacontext.Context, queue string) {
+	s.quarantinedQueues[queue] = s.clock.Now()
+	s.metrics.ReportQueueQuarantine(queue, true)
+	if s.queueQuarantineConfig.Duration > 0 {
+		ctx.Errorf(
+			"QUARANTINED QUEUE %s: scheduling its jobs caused the scheduling algorithm to panic; its queued jobs won't be scheduled for %s",
+			queue, s.queueQuarantineConfig.Duration,
+		)
+	} else {
+		ctx.Errorf(
+			"QUARANTINED QUEUE %s: scheduling its jobs caused the scheduling algorithm to panic; its queued jobs won't be scheduled until the scheduler restarts",
+			queue,
+		)
+	}
+}
+
+// releaseExpiredQuarantines releases any queue that has been quarantined for longer than the quarantine duration.
+func (s *Scheduler) releaseExpiredQuarantines(ctx *armadacontext.Context) {
+	if s.queueQuarantineConfig.Duration <= 0 {
+		return
+	}
+	now := s.clock.Now()
+	for queue, quarantinedAt := range s.quarantinedQueues {
+		if now.Sub(quarantinedAt) >= s.queueQuarantineConfig.Duration {
+			delete(s.quarantinedQueues, queue)
+			s.metrics.ReportQueueQuarantine(queue, false)
+			ctx.Warnf("released queue %s from quarantine after %s", queue, now.Sub(quarantinedAt))
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestScheduler_SchedulingPanics(t *testing.T) {
+	tests := map[string]struct {
+		quarantineEnabled bool
+		// Queues the scheduling algorithm panics on when asked to schedule them.
+		panickingQueues []string
+		// Whether each cycle is expected to fail due to a panic.
+		expectedPanicByCycle []bool
+		// Queues expected to be quarantined and whose jobs are expected to have been leased after the last cycle.
+		expectedQuarantinedQueues []string
+		expectedLeasedQueues      []string
+	}{
+		"no panics": {
+			quarantineEnabled:    true,
+			expectedPanicByCycle: []bool{false, false, false, false},
+			expectedLeasedQueues: []string{"queue-a", "queue-b", "queue-c"},
+		},
+		"panics without quarantine": {
+			panickingQueues:      []string{"queue-b"},
+			expectedPanicByCycle: []bool{true, true, true, true},
+		},
+		"panicking queue is quarantined": {
+			quarantineEnabled:         true,
+			panickingQueues:           []string{"queue-b"},
+			expectedPanicByCycle:      []bool{true, true, false, false},
+			expectedQuarantinedQueues: []string{"queue-b"},
+			expectedLeasedQueues:      []string{"queue-a", "queue-c"},
+		},
+		"several panicking queues are quarantined": {
+			quarantineEnabled:         true,
+			panickingQueues:           []string{"queue-a", "queue-c"},
+			expectedPanicByCycle:      []bool{true, true, false, false},
+			expectedQuarantinedQueues: []string{"queue-a", "queue-c"},
+			expectedLeasedQueues:      []string{"queue-b"},
+		},
+		"panics not caused by any particular queue": {
+			quarantineEnabled:    true,
+			panickingQueues:      []string{"queue-a", "queue-b", "queue-c"},
+			expectedPanicByCycle: []bool{true, true, true, true},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			schedulingAlgo := &queuePanickingSchedulingAlgo{panickingQueues: util.StringListToSet(tc.panickingQueues)}
+			sched := newSchedulingPanicsTestScheduler(t, schedulingAlgo)
+			if tc.quarantineEnabled {
+				sched.EnableQueueQuarantine(schedulerconfig.QueueQuarantineConfig{Enabled: true, MaxConsecutivePanics: 2})
+			}
+			jobs := upsertQueuedJobs(t, sched.jobDb, "queue-a", "queue-b", "queue-c")
+
+			for i, expectPanic := range tc.expectedPanicByCycle {
+				previouslyLeased := leasedQueues(sched.jobDb, jobs)
+				_, err := sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+				if expectPanic {
+					assert.True(t, errors.Is(err, ErrSchedulingPanic), "cycle %d: %v", i, err)
+					// Jobs leased before the panic are rolled back.
+					assert.Equal(t, previouslyLeased, leasedQueues(sched.jobDb, jobs), "cycle %d", i)
+				} else {
+					require.NoError(t, err, "cycle %d", i)
+				}
+			}
+
+			assert.ElementsMatch(t, tc.expectedQuarantinedQueues, maps.Keys(sched.quarantinedQueues))
+			assert.ElementsMatch(t, tc.expectedLeasedQueues, leasedQueues(sched.jobDb, jobs))
+		})
+	}
+}
+
+func TestScheduler_QueueQuarantineExpiry(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+
+	testClock := clock.NewFakeClock(time.Now())
+	schedulingAlgo := &queuePanickingSchedulingAlgo{panickingQueues: map[string]bool{"queue-b": true}}
+	sched := newSchedulingPanicsTestScheduler(t, schedulingAlgo)
+	sched.clock = testClock
+	sched.EnableQueueQuarantine(schedulerconfig.QueueQuarantineConfig{Enabled: true, MaxConsecutivePanics: 1, Duration: time.Hour})
+	jobs := upsertQueuedJobs(t, sched.jobDb, "queue-a", "queue-b")
+
+	_, err := sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	assert.True(t, errors.Is(err, ErrSchedulingPanic))
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+	assert.Contains(t, sched.quarantinedQueues, "queue-b")
+	assert.Equal(t, []string{"queue-a"}, leasedQueues(sched.jobDb, jobs))
+
+	// New jobs of the quarantined queue are skipped, while those of other queues are scheduled.
+	jobs = append(jobs, upsertQueuedJobs(t, sched.jobDb, "queue-a", "queue-b")...)
+	testClock.Step(time.Hour - time.Second)
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"queue-a"}, leasedQueues(sched.jobDb, jobs))
+
+	// Once released, the queue is scheduled again.
+	testClock.Step(time.Second)
+	schedulingAlgo.panickingQueues = nil
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), true)
+	require.NoError(t, err)
+	assert.Empty(t, sched.quarantinedQueues)
+	assert.Equal(t, []string{"queue-a", "queue-b"}, leasedQueues(sched.jobDb, jobs))
+}
+
+func newSchedulingPanicsTestScheduler(t *testing.T, schedulingAlgo SchedulingAlgo) *Scheduler {
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		&testJobRepository{},
+		&testExecutorRepository{},
+		schedulingAlgo,
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	return sched
+}
+
+// upsertQueuedJobs creates a queued job in each of queues.
+func upsertQueuedJobs(t *testing.T, jobDb *jobdb.JobDb, queues ...string) []*jobdb.Job {
+	jobs := make([]*jobdb.Job, len(queues))
+	for i, queue := range queues {
+		jobs[i] = testfixtures.JobDb.NewJob(util.NewULID(), "testJobset", queue, 10, schedulingInfo, true, 1, false, false, false, 1)
+	}
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(jobs))
+	txn.Commit()
+	return jobs
+}
+
+// leasedQueues returns the queues of which all of jobs have been leased, in lexicographical order.
+func leasedQueues(jobDb *jobdb.JobDb, jobs []*jobdb.Job) []string {
+	txn := jobDb.ReadTxn()
+	isLeasedByQueue := make(map[string]bool)
+	for _, job := range jobs {
+		isLeased := !txn.GetById(job.Id()).Queued()
+		if previous, ok := isLeasedByQueue[job.Queue()]; ok {
+			isLeased = isLeased && previous
+		}
+		isLeasedByQueue[job.Queue()] = isLeased
+	}
+	queues := make([]string, 0)
+	for queue, isLeased := range isLeasedByQueue {
+		if isLeased {
+			queues = append(queues, queue)
+		}
+	}
+	slices.Sort(queues)
+	return queues
+}
+
+// queuePanickingSchedulingAlgo leases all queued jobs of the queues it's asked to schedule, one queue at a time in
+// lexicographical order, but panics after leasing the jobs of any of panickingQueues.
+type queuePanickingSchedulingAlgo struct {
+	panickingQueues map[string]bool
+}
+
+func (algo *queuePanickingSchedulingAlgo) Schedule(ctx *armadacontext.Context, txn *jobdb.Txn) (*SchedulerResult, error) {
+	queueFilter := queueFilterFromContext(ctx)
+	scheduledJobs := make([]*jobdb.Job, 0)
+	placementByJobId := make(map[string]Placement)
+	for _, queue := range txn.QueuesWithQueuedJobs() {
+		if queueFilter != nil && !queueFilter(queue) {
+			continue
+		}
+		queuedJobs := make([]*jobdb.Job, 0)
+		it := txn.QueuedJobs(queue)
+		for job, _ := it.Next(); job != nil; job, _ = it.Next() {
+			placement := Placement{Executor: "test-executor", NodeId: "test-node", NodeName: "node", Pool: testfixtures.TestPool}
+			job = job.WithQueuedVersion(job.QueuedVersion()+1).WithQueued(false).WithNewRun(placement.Executor, placement.NodeId, placement.NodeName, placement.ScheduledAtPriority, testfixtures.BaseTime)
+			placementByJobId[job.Id()] = placement
+			queuedJobs = append(queuedJobs, job)
+		}
+		if err := txn.Upsert(queuedJobs); err != nil {
+			return nil, err
+		}
+		if algo.panickingQueues[queue] {
+			panic(fmt.Sprintf("pathological job in queue %s", queue))
+		}
+		scheduledJobs = append(scheduledJobs, queuedJobs...)
+	}
+	return NewSchedulerResultForTest(nil, scheduledJobs, nil, placementByJobId), nil
+}
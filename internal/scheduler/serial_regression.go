@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// ErrSerialRegression is returned by cycles of a scheduler that has halted since the highest serials in the database
+// are lower than those the scheduler has read up to.
+var ErrSerialRegression = errors.New("serial regression detected")
+
+// EnableSerialRegressionDetection causes the scheduler to compare the highest serials assigned by the job repository
+// with those it has read up to before each fetch of job and run updates, including the first fetch at startup.
+// If the serial sequences have been reset, e.g., because the database was restored from a backup, the former may be
+// lower, in which case the scheduler would otherwise never receive another update.
+// Regressions by more than config.Tolerance serials are logged, counted, and handled according to config.Policy.
+func (s *Scheduler) EnableSerialRegressionDetection(config schedulerconfig.SerialRegressionConfig) {
+	s.serialRegressionConfig = &config
+}
+
+// checkSerialRegression returns an error if the scheduler has halted due to a serial regression, or if one is detected
+// and the policy is to halt. If the policy is to rebuild, the serials read up to are reset and all jobs are deleted
+// from txn, such that the jobDb is rebuilt by the subsequent fetch.
+func (s *Scheduler) checkSerialRegression(ctx *armadacontext.Context, txn *jobdb.Txn) error {
+	if s.serialRegressionConfig == nil {
+		return nil
+	}
+	if s.haltedBySerialRegression.Load() {
+		return errors.WithStack(ErrSerialRegression)
+	}
+	var maxJobSerial, maxRunSerial int64
+	err := s.retryTransient(ctx, "fetching max serials", func() error {
+		var err error
+		maxJobSerial, maxRunSerial, err = s.jobRepository.FetchMaxSerials(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	jobsRegressed := s.jobsSerial-maxJobSerial > s.serialRegressionConfig.Tolerance
+	runsRegressed := s.runsSerial-maxRunSerial > s.serialRegressionConfig.Tolerance
+	if !jobsRegressed && !runsRegressed {
+		return nil
+	}
+	if jobsRegressed {
+		s.metrics.ReportSerialRegression("jobs")
+	}
+	if runsRegressed {
+		s.metrics.ReportSerialRegression("runs")
+	}
+	ctx.Errorf(
+		"serial regression detected: the highest job and run serials in the database are %d and %d, "+
+			"but the scheduler has read up to %d and %d respectively, which exceeds the tolerance of %d",
+		maxJobSerial, maxRunSerial, s.jobsSerial, s.runsSerial, s.serialRegressionConfig.Tolerance,
+	)
+
+	if s.serialRegressionConfig.Policy == schedulerconfig.SerialRegressionPolicyRebuild {
+		ctx.Errorf("resetting serials and rebuilding the jobDb from scratch")
+		if err := txn.BatchDelete(util.Map(txn.GetAll(), func(job *jobdb.Job) string { return job.Id() })); err != nil {
+			return err
+		}
+		s.jobsSerial = -1
+		s.runsSerial = -1
+		s.jobDbLoaded = false
+		if s.runResourceUsageEnabled {
+			s.runResourceUsageSerial = -1
+		}
+		return nil
+	}
+	ctx.Errorf("halting; the scheduler must be restarted once its database is consistent")
+	s.haltedBySerialRegression.Store(true)
+	return errors.Wrapf(
+		ErrSerialRegression, "serials in the database (jobs %d, runs %d) have regressed below those read up to (jobs %d, runs %d)",
+		maxJobSerial, maxRunSerial, s.jobsSerial, s.runsSerial,
+	)
+}
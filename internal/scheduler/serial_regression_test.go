@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestScheduler_SerialRegression(t *testing.T) {
+	// Jobs in the database after the regression, which differ from that in the jobDb.
+	dbJobs := []database.Job{
+		{
+			JobID:          queuedJob.Id(),
+			JobSet:         queuedJob.Jobset(),
+			Queue:          queuedJob.Queue(),
+			Submitted:      queuedJob.Created(),
+			Queued:         true,
+			QueuedVersion:  1,
+			Priority:       int64(queuedJob.Priority()),
+			SchedulingInfo: schedulingInfoBytes,
+			Serial:         5,
+		},
+	}
+	tests := map[string]struct {
+		policy       schedulerconfig.SerialRegressionPolicy
+		maxJobSerial int64
+		maxRunSerial int64
+		// If true, syncState is expected to fail due to a serial regression.
+		expectHalt bool
+		// If true, the jobDb is expected to be rebuilt from the database.
+		expectRebuild bool
+		// Expected increase of the serial regressions metric, by table.
+		expectedRegressions map[string]float64
+	}{
+		"no regression": {
+			policy:       schedulerconfig.SerialRegressionPolicyHalt,
+			maxJobSerial: 100,
+			maxRunSerial: 100,
+		},
+		"regression within tolerance": {
+			policy:       schedulerconfig.SerialRegressionPolicyHalt,
+			maxJobSerial: 95,
+			maxRunSerial: 90,
+		},
+		"halt on job serial regression": {
+			policy:              schedulerconfig.SerialRegressionPolicyHalt,
+			maxJobSerial:        5,
+			maxRunSerial:        100,
+			expectHalt:          true,
+			expectedRegressions: map[string]float64{"jobs": 1},
+		},
+		"halt on run serial regression": {
+			policy:              schedulerconfig.SerialRegressionPolicyHalt,
+			maxJobSerial:        100,
+			maxRunSerial:        5,
+			expectHalt:          true,
+			expectedRegressions: map[string]float64{"runs": 1},
+		},
+		"halt by default": {
+			maxJobSerial:        5,
+			maxRunSerial:        5,
+			expectHalt:          true,
+			expectedRegressions: map[string]float64{"jobs": 1, "runs": 1},
+		},
+		"rebuild": {
+			policy:              schedulerconfig.SerialRegressionPolicyRebuild,
+			maxJobSerial:        5,
+			maxRunSerial:        5,
+			expectRebuild:       true,
+			expectedRegressions: map[string]float64{"jobs": 1, "runs": 1},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			jobRepo := &testJobRepository{
+				maxJobSerial: tc.maxJobSerial,
+				maxRunSerial: tc.maxRunSerial,
+			}
+			if tc.expectHalt || tc.expectRebuild {
+				// The test repository ignores the serials it's passed; there are only updates to read
+				// if the serials regressed, in which case the scheduler mustn't read them unless rebuilding.
+				jobRepo.updatedJobs = dbJobs
+			}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				jobRepo,
+				&testExecutorRepository{},
+				&testSchedulingAlgo{},
+				NewStandaloneLeaderController(),
+				&testPublisher{},
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.EnableSerialRegressionDetection(schedulerconfig.SerialRegressionConfig{
+				Enabled:   true,
+				Tolerance: 10,
+				Policy:    tc.policy,
+			})
+
+			// The scheduler has previously read up to serial 100 and holds a job no longer in the database.
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert([]*jobdb.Job{leasedJob}))
+			txn.Commit()
+			sched.jobsSerial = 100
+			sched.runsSerial = 100
+			regressionsBefore := map[string]float64{
+				"jobs": testutil.ToFloat64(schedulerMetrics.serialRegressions.WithLabelValues("jobs")),
+				"runs": testutil.ToFloat64(schedulerMetrics.serialRegressions.WithLabelValues("runs")),
+			}
+
+			_, _, _, err = sched.syncState(ctx)
+			for table, before := range regressionsBefore {
+				assert.Equal(
+					t, tc.expectedRegressions[table], testutil.ToFloat64(schedulerMetrics.serialRegressions.WithLabelValues(table))-before,
+				)
+			}
+			if tc.expectHalt {
+				assert.True(t, errors.Is(err, ErrSerialRegression))
+				assert.True(t, errors.Is(sched.Check(), ErrSerialRegression))
+				assert.Equal(t, int64(100), sched.jobsSerial)
+				assert.Equal(t, int64(100), sched.runsSerial)
+				assert.NotNil(t, sched.jobDb.ReadTxn().GetById(leasedJob.Id()))
+				assert.Nil(t, sched.jobDb.ReadTxn().GetById(queuedJob.Id()))
+
+				// The scheduler remains halted even if the regression is resolved.
+				jobRepo.maxJobSerial = 100
+				jobRepo.maxRunSerial = 100
+				_, _, _, err = sched.syncState(ctx)
+				assert.True(t, errors.Is(err, ErrSerialRegression))
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, sched.Check())
+			if tc.expectRebuild {
+				// The jobDb only contains what's in the database and serials are those read from it.
+				assert.Equal(t, int64(5), sched.jobsSerial)
+				assert.Equal(t, int64(-1), sched.runsSerial)
+				assert.Nil(t, sched.jobDb.ReadTxn().GetById(leasedJob.Id()))
+				assert.NotNil(t, sched.jobDb.ReadTxn().GetById(queuedJob.Id()))
+			} else {
+				assert.Equal(t, int64(100), sched.jobsSerial)
+				assert.Equal(t, int64(100), sched.runsSerial)
+				assert.NotNil(t, sched.jobDb.ReadTxn().GetById(leasedJob.Id()))
+			}
+		})
+	}
+}
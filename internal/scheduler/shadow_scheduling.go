@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"golang.org/x/time/rate"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// ShadowSchedulingAlgo is a SchedulingAlgo able to run scheduling rounds free of side effects.
+type ShadowSchedulingAlgo interface {
+	SchedulingAlgo
+	// ShadowCopy returns a copy of the algorithm, rounds run by which leave the state of the original unchanged.
+	ShadowCopy() SchedulingAlgo
+}
+
+// EnableShadowScheduling causes scheduling rounds to be run while not leader, at most once per schedule period,
+// the decisions of which are discarded. This keeps the jobDb and the caches of the scheduling algorithm warm,
+// such that the first scheduling round after becoming leader isn't much slower than any other.
+// Returns an error if the scheduling algorithm can't run rounds free of side effects.
+func (s *Scheduler) EnableShadowScheduling() error {
+	if _, ok := s.schedulingAlgo.(ShadowSchedulingAlgo); !ok {
+		return errors.Errorf("scheduling algorithm %T doesn't support shadow scheduling", s.schedulingAlgo)
+	}
+	s.shadowScheduling = true
+	return nil
+}
+
+// shadowSchedule runs a scheduling round against a shadow transaction of the jobDb and discards its decisions.
+// Shadow transactions can't be committed, so the jobDb is never modified, and nothing is published.
+// The round is run by a shadow copy of the scheduling algorithm, such that its state, e.g., rate limiters and
+// preemption budgets, is unaffected.
+func (s *Scheduler) shadowSchedule(ctx *armadacontext.Context) {
+	txn := s.jobDb.ShadowWriteTxn()
+	defer txn.Abort()
+	start := s.clock.Now()
+	result, err := s.shadowScheduleRecoveringPanics(ctx, txn)
+	s.previousShadowRoundEnd = s.clock.Now()
+	if err != nil {
+		logging.WithStacktrace(ctx, err).Warn("shadow scheduling round failed")
+		s.metrics.ReportShadowSchedulingFailure()
+		return
+	}
+	s.metrics.ReportShadowSchedulingRound(s.previousShadowRoundEnd.Sub(start), len(result.ScheduledJobs))
+	ctx.Infof("shadow scheduling round completed in %s; it would have leased %d jobs", s.previousShadowRoundEnd.Sub(start), len(result.ScheduledJobs))
+}
+
+// shadowScheduleRecoveringPanics runs a round of a shadow copy of the scheduling algorithm,
+// converting any panic into an ErrSchedulingPanic error.
+func (s *Scheduler) shadowScheduleRecoveringPanics(ctx *armadacontext.Context, txn *jobdb.Txn) (result *SchedulerResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, errors.Wrapf(ErrSchedulingPanic, "%v", r)
+		}
+	}()
+	return s.schedulingAlgo.(ShadowSchedulingAlgo).ShadowCopy().Schedule(ctx, txn)
+}
+
+// ShadowCopy returns a copy of l that schedules as l would, but doesn't modify the state of l.
+// Rate limiters, burst credits, preemption budgets, and gang reservations are cloned; scheduling contexts,
+// fragmentation, and placements aren't recorded; and the executor groups to schedule are always those l would
+// schedule next. Caches, e.g., of executors, are shared, such that rounds run by the copy keep them warm.
+func (l *FairSchedulingAlgo) ShadowCopy() SchedulingAlgo {
+	now := l.clock.Now()
+	shadow := *l
+	shadow.shadow = true
+	shadow.limiter = cloneLimiter(l.limiter, now)
+	shadow.limiterByQueue = make(map[string]*rate.Limiter, len(l.limiterByQueue))
+	for queue, limiter := range l.limiterByQueue {
+		shadow.limiterByQueue[queue] = cloneLimiter(limiter, now)
+	}
+	shadow.executorGroupsToSchedule = slices.Clone(l.executorGroupsToSchedule)
+	shadow.onExecutorScheduled = func(executor *schedulerobjects.Executor) {}
+	shadow.schedulingContextRepository = nil
+	shadow.fragmentationTracker = nil
+	if l.burstCredits != nil {
+		shadow.burstCredits = l.burstCredits.clone()
+	}
+	if l.preemptionBudget != nil {
+		shadow.preemptionBudget = l.preemptionBudget.clone()
+	}
+	if l.gangReservations != nil {
+		shadow.gangReservations = l.gangReservations.clone()
+	}
+	return &shadow
+}
+
+// cloneLimiter returns a limiter with the same limit and burst as limiter and the tokens limiter has at now.
+func cloneLimiter(limiter *rate.Limiter, now time.Time) *rate.Limiter {
+	clone := rate.NewLimiter(limiter.Limit(), limiter.Burst())
+	if n := limiter.Burst() - int(limiter.TokensAt(now)); n > 0 {
+		clone.ReserveN(now, n)
+	}
+	return clone
+}
+
+func (l *BurstCreditLedger) clone() *BurstCreditLedger {
+	debtByPoolAndQueue := make(map[string]map[string]float64, len(l.debtByPoolAndQueue))
+	for pool, debtByQueue := range l.debtByPoolAndQueue {
+		debtByPoolAndQueue[pool] = maps.Clone(debtByQueue)
+	}
+	return &BurstCreditLedger{
+		config:             l.config,
+		debtByPoolAndQueue: debtByPoolAndQueue,
+		lastUpdatedByPool:  maps.Clone(l.lastUpdatedByPool),
+	}
+}
+
+func (b *PreemptionBudget) clone() *PreemptionBudget {
+	return &PreemptionBudget{
+		config:      b.config,
+		preemptions: slices.Clone(b.preemptions),
+	}
+}
+
+// clone returns a copy of r. Reservations are never modified once created, so they're shared.
+func (r *GangReservations) clone() *GangReservations {
+	reservationsByGroup := make(map[string][]*gangReservation, len(r.reservationsByGroup))
+	for group, reservations := range r.reservationsByGroup {
+		reservationsByGroup[group] = slices.Clone(reservations)
+	}
+	return &GangReservations{
+		config:                  r.config,
+		priorityClasses:         r.priorityClasses,
+		backfillPriorityClasses: r.backfillPriorityClasses,
+		reservationsByGroup:     reservationsByGroup,
+		reservationByNodeId:     maps.Clone(r.reservationByNodeId),
+		reservationByGangId:     maps.Clone(r.reservationByGangId),
+	}
+}
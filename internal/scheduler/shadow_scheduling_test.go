@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestFairSchedulingAlgo_ShadowCopy(t *testing.T) {
+	ctx := armadacontext.Background()
+	nodes := testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)
+	nodes[0].Executor = "test-executor"
+	executors := []*schedulerobjects.Executor{{
+		Id:             "test-executor",
+		Pool:           "test-pool",
+		Nodes:          nodes,
+		LastUpdateTime: testfixtures.BaseTime,
+	}}
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return(executors, nil).AnyTimes()
+	mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+	mockQueueRepo.EXPECT().GetAllQueues().Return([]*database.Queue{{Name: "queue-a", Weight: 1}}, nil).AnyTimes()
+
+	config := testfixtures.TestSchedulingConfig()
+	config.MaximumSchedulingRate = 1
+	config.MaximumSchedulingBurst = 8
+	config.BurstCredits.Enabled = true
+	config.BurstCredits.AccrualRate = 1
+	config.PreemptionBudget.Enabled = true
+	config.PreemptionBudget.Window = time.Hour
+	algo, err := NewFairSchedulingAlgo(config, 0, mockExecutorRepo, mockQueueRepo, nil)
+	require.NoError(t, err)
+	testClock := clock.NewFakeClock(testfixtures.BaseTime)
+	algo.clock = testClock
+	var numExecutorsScheduled int
+	algo.onExecutorScheduled = func(executor *schedulerobjects.Executor) { numExecutorsScheduled++ }
+
+	jobDb := testfixtures.NewJobDb()
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("queue-a", testfixtures.PriorityClass0, 8))))
+	txn.Commit()
+
+	// Each shadow round schedules all jobs, since none of them consumes the tokens of the rate limiters of the original.
+	for i := 0; i < 2; i++ {
+		txn := jobDb.ShadowWriteTxn()
+		result, err := algo.ShadowCopy().Schedule(ctx, txn)
+		require.NoError(t, err)
+		assert.Len(t, result.ScheduledJobs, 8)
+		txn.Abort()
+		testClock.Step(time.Second)
+	}
+	assert.Equal(t, float64(8), algo.limiter.TokensAt(testClock.Now()))
+	assert.Empty(t, algo.limiterByQueue)
+	assert.Empty(t, algo.burstCredits.lastUpdatedByPool)
+	assert.Empty(t, algo.preemptionBudget.preemptions)
+	assert.Empty(t, algo.executorGroupsToSchedule)
+	assert.Zero(t, numExecutorsScheduled)
+
+	// Whereas a round of the original consumes them.
+	result, err := algo.Schedule(ctx, jobDb.WriteTxn())
+	require.NoError(t, err)
+	assert.Len(t, result.ScheduledJobs, 8)
+	assert.Less(t, algo.limiter.TokensAt(testClock.Now()), float64(1))
+	assert.NotEmpty(t, algo.burstCredits.lastUpdatedByPool)
+	assert.Equal(t, 1, numExecutorsScheduled)
+}
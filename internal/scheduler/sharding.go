@@ -0,0 +1,209 @@
+package scheduler
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// ShardAssignment determines which queues are owned by the shard of this scheduler.
+// Queues are assigned to shards according to a static mapping or, for queues not included in that mapping,
+// by consistent hashing of the queue name, such that increasing the number of shards moves as few queues as possible.
+type ShardAssignment struct {
+	shardId     int
+	numShards   int
+	queueShards map[string]int
+}
+
+func NewShardAssignment(config schedulerconfig.ShardingConfig) (*ShardAssignment, error) {
+	if config.NumShards < 1 {
+		return nil, errors.Errorf("numShards must be positive, but is %d", config.NumShards)
+	}
+	if config.ShardId < 0 || config.ShardId >= config.NumShards {
+		return nil, errors.Errorf("shardId must be in [0, %d), but is %d", config.NumShards, config.ShardId)
+	}
+	for queue, shardId := range config.QueueShards {
+		if shardId < 0 || shardId >= config.NumShards {
+			return nil, errors.Errorf("queue %s is assigned to shard %d, which isn't in [0, %d)", queue, shardId, config.NumShards)
+		}
+	}
+	return &ShardAssignment{
+		shardId:     config.ShardId,
+		numShards:   config.NumShards,
+		queueShards: config.QueueShards,
+	}, nil
+}
+
+// ShardId returns the id of the shard of this scheduler.
+func (sa *ShardAssignment) ShardId() int {
+	return sa.shardId
+}
+
+// ShardForQueue returns the id of the shard the provided queue is assigned to.
+func (sa *ShardAssignment) ShardForQueue(queue string) int {
+	if shardId, ok := sa.queueShards[queue]; ok {
+		return shardId
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(queue))
+	return jumpConsistentHash(h.Sum64(), sa.numShards)
+}
+
+// OwnsQueue returns true if the provided queue is assigned to the shard of this scheduler.
+func (sa *ShardAssignment) OwnsQueue(queue string) bool {
+	return sa.ShardForQueue(queue) == sa.shardId
+}
+
+// EnableSharding causes the scheduler to only load jobs of queues owned by the shard of assignment into the jobDb.
+// Since only such jobs are in the jobDb, only those are scheduled, expired, or otherwise updated by this scheduler.
+// The runs of jobs of other shards are recorded in foreignAllocations, such that the resources they're allocated
+// are accounted for when scheduling; see FairSchedulingAlgo.EnableForeignAllocations.
+func (s *Scheduler) EnableSharding(assignment *ShardAssignment, foreignAllocations *ForeignAllocations) {
+	s.shardAssignment = assignment
+	s.foreignAllocations = foreignAllocations
+}
+
+// filterUpdatesOfOtherShards returns the subset of jobs owned by this shard and the subset of runs not of jobs of
+// other shards. Updates of jobs of other shards and of their runs are recorded in s.foreignAllocations instead.
+// Runs carry no queue; a run is considered to be of a job of another shard if its job is among the updated jobs of
+// other shards or already recorded in s.foreignAllocations. Runs of jobs that are neither of this nor of another
+// shard are returned, since their job is unknown; these are quarantined until their job is known.
+// Hence, no update is discarded, even though the serials are advanced past all of them.
+func (s *Scheduler) filterUpdatesOfOtherShards(txn *jobdb.Txn, updatedJobs []database.Job, updatedRuns []database.Run) ([]database.Job, []database.Run) {
+	ownedJobIds := make(map[string]bool)
+	otherShardJobs := make([]database.Job, 0)
+	ownedJobs := make([]database.Job, 0, len(updatedJobs))
+	for _, job := range updatedJobs {
+		if s.shardAssignment.OwnsQueue(job.Queue) {
+			ownedJobIds[job.JobID] = true
+			ownedJobs = append(ownedJobs, job)
+		} else {
+			otherShardJobs = append(otherShardJobs, job)
+		}
+	}
+	s.foreignAllocations.upsertJobs(otherShardJobs)
+	ownedRuns := make([]database.Run, 0, len(updatedRuns))
+	otherShardRuns := make([]database.Run, 0)
+	for _, run := range updatedRuns {
+		if !ownedJobIds[run.JobID] && txn.GetById(run.JobID) == nil && s.foreignAllocations.hasJob(run.JobID) {
+			otherShardRuns = append(otherShardRuns, run)
+		} else {
+			ownedRuns = append(ownedRuns, run)
+		}
+	}
+	s.foreignAllocations.upsertRuns(otherShardRuns)
+	return ownedJobs, ownedRuns
+}
+
+// ForeignAllocations records the jobs of other shards and the nodes their active runs are on, such that the resources
+// allocated to those runs are accounted for when scheduling; shards share nodes, but can't preempt each other's jobs.
+// Jobs are recorded until terminal.
+type ForeignAllocations struct {
+	// Non-terminal jobs of other shards by id.
+	jobsById map[string]*foreignJob
+	mu       sync.Mutex
+}
+
+type foreignJob struct {
+	// Resources requested by the job.
+	requests schedulerobjects.ResourceList
+	// Nodes of the active runs of the job by run id.
+	nodeByRunId map[uuid.UUID]foreignNode
+}
+
+type foreignNode struct {
+	executor string
+	name     string
+}
+
+func NewForeignAllocations() *ForeignAllocations {
+	return &ForeignAllocations{jobsById: make(map[string]*foreignJob)}
+}
+
+func (fa *ForeignAllocations) upsertJobs(jobs []database.Job) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	for _, job := range jobs {
+		if job.Succeeded || job.Failed || job.Cancelled {
+			delete(fa.jobsById, job.JobID)
+			continue
+		}
+		schedulingInfo := &schedulerobjects.JobSchedulingInfo{}
+		if err := proto.Unmarshal(job.SchedulingInfo, schedulingInfo); err != nil {
+			logrus.WithError(err).Errorf("failed to unmarshal scheduling info of job %s of another shard", job.JobID)
+		}
+		var requests schedulerobjects.ResourceList
+		if req := schedulingInfo.GetPodRequirements(); req != nil {
+			requests = schedulerobjects.ResourceListFromV1ResourceList(req.ResourceRequirements.Requests)
+		}
+		if existing, ok := fa.jobsById[job.JobID]; ok {
+			existing.requests = requests
+		} else {
+			fa.jobsById[job.JobID] = &foreignJob{requests: requests, nodeByRunId: make(map[uuid.UUID]foreignNode)}
+		}
+	}
+}
+
+func (fa *ForeignAllocations) hasJob(jobId string) bool {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	_, ok := fa.jobsById[jobId]
+	return ok
+}
+
+func (fa *ForeignAllocations) upsertRuns(runs []database.Run) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	for _, run := range runs {
+		job, ok := fa.jobsById[run.JobID]
+		if !ok {
+			continue
+		}
+		if run.Succeeded || run.Failed || run.Cancelled || run.Returned {
+			delete(job.nodeByRunId, run.RunID)
+		} else {
+			job.nodeByRunId[run.RunID] = foreignNode{executor: run.Executor, name: run.Node}
+		}
+	}
+}
+
+// AllocatedByNode returns the resources allocated to active runs of jobs of other shards on the nodes of executor,
+// indexed by node name.
+func (fa *ForeignAllocations) AllocatedByNode(executor string) map[string]schedulerobjects.ResourceList {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	allocatedByNode := make(map[string]schedulerobjects.ResourceList)
+	for _, job := range fa.jobsById {
+		for _, node := range job.nodeByRunId {
+			if node.executor != executor {
+				continue
+			}
+			allocated := allocatedByNode[node.name]
+			allocated.Add(job.requests)
+			allocatedByNode[node.name] = allocated
+		}
+	}
+	return allocatedByNode
+}
+
+// jumpConsistentHash maps key to a bucket in [0, numBuckets) such that, when numBuckets is increased by one,
+// only 1/numBuckets of all keys are moved, all of them to the new bucket.
+// See "A Fast, Minimal Memory, Consistent Hash Algorithm" by Lamping and Veach.
+func jumpConsistentHash(key uint64, numBuckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(numBuckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
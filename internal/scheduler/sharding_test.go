@@ -0,0 +1,280 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	protoutil "github.com/armadaproject/armada/internal/common/proto"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func testShardAssignments(t *testing.T) []*ShardAssignment {
+	assignments := make([]*ShardAssignment, 2)
+	for shardId := range assignments {
+		assignment, err := NewShardAssignment(schedulerconfig.ShardingConfig{
+			Enabled:     true,
+			ShardId:     shardId,
+			NumShards:   2,
+			QueueShards: map[string]int{"queue-a": 0, "queue-b": 1},
+		})
+		require.NoError(t, err)
+		assignments[shardId] = assignment
+	}
+	return assignments
+}
+
+func TestShardAssignment(t *testing.T) {
+	assignments := testShardAssignments(t)
+	assert.True(t, assignments[0].OwnsQueue("queue-a"))
+	assert.False(t, assignments[0].OwnsQueue("queue-b"))
+	assert.False(t, assignments[1].OwnsQueue("queue-a"))
+	assert.True(t, assignments[1].OwnsQueue("queue-b"))
+
+	// Each queue not in the static mapping is owned by exactly one shard.
+	for i := 0; i < 100; i++ {
+		queue := fmt.Sprintf("queue-%d", i)
+		assert.NotEqual(t, assignments[0].OwnsQueue(queue), assignments[1].OwnsQueue(queue))
+		assert.Equal(t, assignments[0].ShardForQueue(queue), assignments[1].ShardForQueue(queue))
+	}
+}
+
+func TestShardAssignment_ConsistentHashing(t *testing.T) {
+	assignments := make(map[int]*ShardAssignment)
+	for _, numShards := range []int{3, 4} {
+		assignment, err := NewShardAssignment(schedulerconfig.ShardingConfig{NumShards: numShards})
+		require.NoError(t, err)
+		assignments[numShards] = assignment
+	}
+	numMoved := 0
+	for i := 0; i < 1000; i++ {
+		queue := fmt.Sprintf("queue-%d", i)
+		before := assignments[3].ShardForQueue(queue)
+		after := assignments[4].ShardForQueue(queue)
+		assert.GreaterOrEqual(t, before, 0)
+		assert.Less(t, before, 3)
+		if before != after {
+			// Queues only ever move to the new shard.
+			assert.Equal(t, 3, after)
+			numMoved++
+		}
+	}
+	assert.Greater(t, numMoved, 150)
+	assert.Less(t, numMoved, 350)
+}
+
+func TestNewShardAssignment_Invalid(t *testing.T) {
+	tests := map[string]schedulerconfig.ShardingConfig{
+		"no shards":                {NumShards: 0},
+		"negative shard id":        {ShardId: -1, NumShards: 2},
+		"shard id out of range":    {ShardId: 2, NumShards: 2},
+		"queue shard out of range": {NumShards: 2, QueueShards: map[string]int{"queue-a": 2}},
+		"negative queue shard":     {NumShards: 2, QueueShards: map[string]int{"queue-a": -1}},
+	}
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewShardAssignment(config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestScheduler_Sharding(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+
+	jobIdsByQueue := map[string][]string{
+		"queue-a": {util.NewULID(), util.NewULID()},
+		"queue-b": {util.NewULID(), util.NewULID()},
+	}
+	jobRepo := &testJobRepository{}
+	serial := int64(0)
+	for _, queue := range []string{"queue-a", "queue-b"} {
+		for _, jobId := range jobIdsByQueue[queue] {
+			serial++
+			jobRepo.updatedJobs = append(jobRepo.updatedJobs, database.Job{
+				JobID:                 jobId,
+				JobSet:                "testJobSet",
+				Queue:                 queue,
+				Queued:                true,
+				QueuedVersion:         1,
+				SchedulingInfo:        shardingTestSchedulingInfoBytes,
+				SchedulingInfoVersion: int32(schedulingInfo.Version),
+				Serial:                serial,
+			})
+		}
+	}
+	// One run of a job of each queue, leased to the same executor.
+	for i, queue := range []string{"queue-a", "queue-b"} {
+		jobRepo.updatedRuns = append(jobRepo.updatedRuns, database.Run{
+			RunID:    uuid.New(),
+			JobID:    jobIdsByQueue[queue][0],
+			JobSet:   "testJobSet",
+			Executor: "testExecutor",
+			Node:     "testNode",
+			Serial:   int64(i + 1),
+		})
+	}
+
+	for shardId, assignment := range testShardAssignments(t) {
+		sched, err := NewScheduler(
+			testfixtures.NewJobDb(),
+			jobRepo,
+			&testExecutorRepository{},
+			&testSchedulingAlgo{},
+			NewStandaloneLeaderController(),
+			&testPublisher{},
+			nil,
+			1*time.Second,
+			5*time.Second,
+			1*time.Hour,
+			maxNumberOfAttempts,
+			nodeIdLabel,
+			schedulerMetrics,
+			nil,
+		)
+		require.NoError(t, err)
+		foreignAllocations := NewForeignAllocations()
+		sched.EnableSharding(assignment, foreignAllocations)
+
+		_, _, _, err = sched.syncState(ctx)
+		require.NoError(t, err)
+
+		ownedQueue := "queue-a"
+		otherQueue := "queue-b"
+		if shardId == 1 {
+			ownedQueue, otherQueue = otherQueue, ownedQueue
+		}
+		txn := sched.jobDb.ReadTxn()
+		for _, jobId := range jobIdsByQueue[ownedQueue] {
+			assert.NotNil(t, txn.GetById(jobId))
+		}
+		for _, jobId := range jobIdsByQueue[otherQueue] {
+			assert.Nil(t, txn.GetById(jobId))
+			assert.True(t, foreignAllocations.hasJob(jobId))
+		}
+		job := txn.GetById(jobIdsByQueue[ownedQueue][0])
+		require.NotNil(t, job)
+		assert.True(t, job.HasRuns())
+		assert.Equal(t, "testExecutor", job.LatestRun().Executor())
+
+		// The run of the job of the other shard is accounted for on its node.
+		allocatedByNode := foreignAllocations.AllocatedByNode("testExecutor")
+		assert.Equal(t, []string{"testNode"}, maps.Keys(allocatedByNode))
+		assert.True(t, shardingTestRequests.Equal(allocatedByNode["testNode"]))
+
+		// Serials include updates of other shards, such that those aren't fetched again.
+		assert.Equal(t, serial, sched.jobsSerial)
+		assert.Equal(t, int64(2), sched.runsSerial)
+	}
+}
+
+func TestScheduler_Sharding_RunsOfJobsOfOtherShardsReceivedLater(t *testing.T) {
+	s := &Scheduler{
+		shardAssignment:    testShardAssignments(t)[0],
+		foreignAllocations: NewForeignAllocations(),
+	}
+	txn := testfixtures.NewJobDb().ReadTxn()
+	otherJob := database.Job{JobID: util.NewULID(), Queue: "queue-b", SchedulingInfo: shardingTestSchedulingInfoBytes}
+	ownedJobs, ownedRuns := s.filterUpdatesOfOtherShards(txn, []database.Job{otherJob}, nil)
+	assert.Empty(t, ownedJobs)
+	assert.Empty(t, ownedRuns)
+
+	// Runs of jobs of the other shard received after the job are recorded rather than dropped,
+	// whereas runs of unknown jobs are returned, such that they're retried once their job is known.
+	run := database.Run{RunID: uuid.New(), JobID: otherJob.JobID, Executor: "testExecutor", Node: "testNode"}
+	orphanedRun := database.Run{RunID: uuid.New(), JobID: util.NewULID(), Executor: "testExecutor", Node: "testNode"}
+	ownedJobs, ownedRuns = s.filterUpdatesOfOtherShards(txn, nil, []database.Run{run, orphanedRun})
+	assert.Empty(t, ownedJobs)
+	assert.Equal(t, []database.Run{orphanedRun}, ownedRuns)
+	assert.True(t, shardingTestRequests.Equal(s.foreignAllocations.AllocatedByNode("testExecutor")["testNode"]))
+
+	// Resources are released once the run terminates, and the job is forgotten once it does.
+	run.Succeeded = true
+	s.filterUpdatesOfOtherShards(txn, nil, []database.Run{run})
+	assert.Empty(t, s.foreignAllocations.AllocatedByNode("testExecutor"))
+	otherJob.Succeeded = true
+	s.filterUpdatesOfOtherShards(txn, []database.Job{otherJob}, nil)
+	assert.False(t, s.foreignAllocations.hasJob(otherJob.JobID))
+}
+
+func TestFairSchedulingAlgo_ForeignAllocations(t *testing.T) {
+	ctx := armadacontext.Background()
+	nodes := testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)
+	nodes[0].Executor = "test-executor"
+	executors := []*schedulerobjects.Executor{{
+		Id:             "test-executor",
+		Pool:           "test-pool",
+		Nodes:          nodes,
+		LastUpdateTime: testfixtures.BaseTime,
+	}}
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return(executors, nil).AnyTimes()
+	mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+	mockQueueRepo.EXPECT().GetAllQueues().Return([]*database.Queue{{Name: "queue-a", Weight: 1}}, nil).AnyTimes()
+
+	// 30 of the 32 cpu of the node are allocated to a run of a job of another shard.
+	foreignAllocations := NewForeignAllocations()
+	otherJob := database.Job{JobID: util.NewULID(), Queue: "queue-b", SchedulingInfo: shardingTestSchedulingInfoBytes}
+	foreignAllocations.upsertJobs([]database.Job{otherJob})
+	foreignAllocations.upsertRuns([]database.Run{{RunID: uuid.New(), JobID: otherJob.JobID, Executor: "test-executor", Node: nodes[0].Name}})
+
+	for name, tc := range map[string]struct {
+		foreignAllocations *ForeignAllocations
+		expectedScheduled  int
+	}{
+		"without foreign allocations": {expectedScheduled: 8},
+		"with foreign allocations":    {foreignAllocations: foreignAllocations, expectedScheduled: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			algo, err := NewFairSchedulingAlgo(testfixtures.TestSchedulingConfig(), 0, mockExecutorRepo, mockQueueRepo, nil)
+			require.NoError(t, err)
+			algo.clock = clock.NewFakeClock(testfixtures.BaseTime)
+			if tc.foreignAllocations != nil {
+				algo.EnableForeignAllocations(tc.foreignAllocations)
+			}
+			txn := testfixtures.NewJobDb().WriteTxn()
+			require.NoError(t, txn.Upsert(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("queue-a", testfixtures.PriorityClass0, 8))))
+			result, err := algo.Schedule(ctx, txn)
+			require.NoError(t, err)
+			assert.Len(t, result.ScheduledJobs, tc.expectedScheduled)
+		})
+	}
+}
+
+var (
+	shardingTestRequests = schedulerobjects.ResourceList{
+		Resources: map[string]resource.Quantity{"cpu": resource.MustParse("30")},
+	}
+	shardingTestSchedulingInfoBytes = protoutil.MustMarshall(&schedulerobjects.JobSchedulingInfo{
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: &schedulerobjects.PodRequirements{
+						Priority: int32(10),
+						ResourceRequirements: v1.ResourceRequirements{
+							Requests: v1.ResourceList{"cpu": resource.MustParse("30")},
+						},
+					},
+				},
+			},
+		},
+		Version: 1,
+	})
+)
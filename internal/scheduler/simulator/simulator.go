@@ -475,6 +475,9 @@ func (s *Simulator) handleScheduleEvent(ctx *armadacontext.Context) error {
 			if s.schedulingConfig.EnableNewPreemptionStrategy {
 				sch.EnableNewPreemptionStrategy()
 			}
+			if s.schedulingConfig.EnableCapacityPruning {
+				sch.EnableCapacityPruning()
+			}
 			schedulerCtx := ctx
 			if s.SuppressSchedulerLogs {
 				schedulerCtx = &armadacontext.Context{
@@ -517,19 +520,11 @@ func (s *Simulator) handleScheduleEvent(ctx *armadacontext.Context) error {
 				preemptedJobs[i] = job.WithQueued(false).WithFailed(true)
 			}
 			for i, job := range scheduledJobs {
-				nodeId := result.NodeIdByJobId[job.GetId()]
-				if nodeId == "" {
+				placement, ok := result.PlacementByJobId[job.GetId()]
+				if !ok {
 					return errors.Errorf("job %s not mapped to a node", job.GetId())
 				}
-				if node, err := nodeDb.GetNode(nodeId); err != nil {
-					return err
-				} else {
-					priority, ok := nodeDb.GetScheduledAtPriority(job.Id())
-					if !ok {
-						return errors.Errorf("job %s not mapped to a priority", job.Id())
-					}
-					scheduledJobs[i] = job.WithQueued(false).WithNewRun(node.Executor, node.Id, node.Name, priority)
-				}
+				scheduledJobs[i] = job.WithQueued(false).WithNewRun(placement.Executor, placement.NodeId, placement.NodeName, placement.ScheduledAtPriority, s.time)
 			}
 			for i, job := range failedJobs {
 				if run := job.LatestRun(); run != nil {
@@ -557,7 +552,7 @@ func (s *Simulator) handleScheduleEvent(ctx *armadacontext.Context) error {
 				return err
 			}
 
-			eventSequences, err = scheduler.AppendEventSequencesFromScheduledJobs(eventSequences, scheduledJobs, make(map[string]map[string]string), s.time)
+			eventSequences, err = scheduler.AppendEventSequencesFromScheduledJobs(eventSequences, scheduledJobs, result.PlacementByJobId, make(map[string]map[string]string), s.time)
 			if err != nil {
 				return err
 			}
@@ -643,7 +638,7 @@ func (s *Simulator) handleEventSequence(ctx *armadacontext.Context, es *armadaev
 }
 
 func (s *Simulator) handleSubmitJob(txn *jobdb.Txn, e *armadaevents.SubmitJob, time time.Time, eventSequence *armadaevents.EventSequence) (*jobdb.Job, bool, error) {
-	schedulingInfo, err := scheduleringester.SchedulingInfoFromSubmitJob(e, time, s.schedulingConfig.Preemption.PriorityClasses)
+	schedulingInfo, err := scheduleringester.SchedulingInfoFromSubmitJob(e, time, s.schedulingConfig.Preemption.PriorityClasses, false)
 	if err != nil {
 		return nil, false, err
 	}
@@ -773,7 +768,7 @@ func (s *Simulator) handleJobSucceeded(txn *jobdb.Txn, e *armadaevents.JobSuccee
 		}
 		delete(s.jobTemplatesByDependencyIds, jobTemplate.Id)
 	}
-	return job.WithSucceeded(true).WithUpdatedRun(run.WithRunning(false).WithSucceeded(true)), true, nil
+	return job.WithSucceeded(true).WithUpdatedRun(run.WithSucceeded(true).WithRunning(false)), true, nil
 }
 
 func (s *Simulator) unbindRunningJob(job *jobdb.Job) error {
@@ -122,7 +122,7 @@ func (w *Writer) flattenStateTransition(flattenedStateTransitions []*FlattenedAr
 		})
 		w.prevSeenEventByJobId[associatedJob.GetId()] = event
 
-		if associatedJob.Succeeded() || associatedJob.Failed() || associatedJob.Cancelled() {
+		if associatedJob.InTerminalState() {
 			delete(w.prevSeenEventByJobId, associatedJob.GetId())
 		}
 	}
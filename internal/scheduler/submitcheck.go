@@ -9,6 +9,9 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/armada/configuration"
@@ -16,6 +19,7 @@ import (
 	"github.com/armadaproject/armada/internal/common/logging"
 	armadaslices "github.com/armadaproject/armada/internal/common/slices"
 	"github.com/armadaproject/armada/internal/common/types"
+	"github.com/armadaproject/armada/internal/common/util"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/database"
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
@@ -26,13 +30,18 @@ import (
 )
 
 type minimalExecutor struct {
-	nodeDb     *nodedb.NodeDb
+	nodeDb *nodedb.NodeDb
+	// Nodes of the executor with the overcommit factors of its pool applied, as inserted into nodeDb.
+	nodes      []*schedulerobjects.Node
+	pool       string
 	updateTime time.Time
 }
 
 type schedulingResult struct {
 	isSchedulable bool
 	reason        string
+	// Time at which the result was computed; only set for cached results.
+	created time.Time
 }
 
 const maxJobSchedulingResults = 10000
@@ -43,21 +52,32 @@ type SubmitScheduleChecker interface {
 }
 
 type SubmitChecker struct {
-	executorTimeout           time.Duration
-	priorityClasses           map[string]types.PriorityClass
-	gangIdAnnotation          string
-	executorById              map[string]minimalExecutor
-	priorities                []int32
-	indexedResources          []configuration.IndexedResource
-	indexedTaints             []string
-	indexedNodeLabels         []string
-	wellKnownNodeTypes        []configuration.WellKnownNodeType
+	executorTimeout         time.Duration
+	priorityClasses         map[string]types.PriorityClass
+	gangIdAnnotation        string
+	executorById            map[string]minimalExecutor
+	priorities              []int32
+	indexedResources        []configuration.IndexedResource
+	indexedTaints           []string
+	indexedNodeLabels       []string
+	wellKnownNodeTypes      []configuration.WellKnownNodeType
+	overcommitFactorsByPool map[string]map[string]float64
+	// Tolerations added to the pods of jobs in each queue when they're leased.
+	defaultTolerationsByQueue map[string][]v1.Toleration
 	executorRepository        database.ExecutorRepository
 	clock                     clock.Clock
 	mu                        sync.Mutex
 	schedulingKeyGenerator    *schedulerobjects.SchedulingKeyGenerator
 	jobSchedulingResultsCache *lru.Cache
-	ExecutorUpdateFrequency   time.Duration
+	// Ids of the executors each scheduling key is feasible on; see FeasibleExecutors.
+	feasibleExecutorsCache  *lru.Cache
+	ExecutorUpdateFrequency time.Duration
+	// If non-nil, executors are updated whenever this provider fetches a new snapshot rather than fetched periodically.
+	executorSnapshots *ExecutorSnapshotProvider
+	// Version of the snapshot executors were last updated from.
+	executorSnapshotVersion uint64
+	// Time at which the executors were last fetched, or zero if they haven't been yet.
+	executorsUpdated time.Time
 }
 
 func NewSubmitChecker(
@@ -69,6 +89,10 @@ func NewSubmitChecker(
 	if err != nil {
 		panic(errors.WithStack(err))
 	}
+	feasibleExecutorsCache, err := lru.New(maxJobSchedulingResults)
+	if err != nil {
+		panic(errors.WithStack(err))
+	}
 	return &SubmitChecker{
 		executorTimeout:           executorTimeout,
 		priorityClasses:           schedulingConfig.Preemption.PriorityClasses,
@@ -79,28 +103,56 @@ func NewSubmitChecker(
 		indexedTaints:             schedulingConfig.IndexedTaints,
 		indexedNodeLabels:         schedulingConfig.IndexedNodeLabels,
 		wellKnownNodeTypes:        schedulingConfig.WellKnownNodeTypes,
+		overcommitFactorsByPool:   schedulingConfig.OvercommitFactorsByPool,
+		defaultTolerationsByQueue: schedulingConfig.DefaultJobTolerationsByQueue,
 		executorRepository:        executorRepository,
 		clock:                     clock.RealClock{},
 		schedulingKeyGenerator:    schedulerobjects.NewSchedulingKeyGenerator(),
 		jobSchedulingResultsCache: jobSchedulingResultsCache,
+		feasibleExecutorsCache:    feasibleExecutorsCache,
 		ExecutorUpdateFrequency:   schedulingConfig.ExecutorUpdateFrequency,
 	}
 }
 
+// EnableExecutorSnapshots causes executors to be updated from each snapshot fetched by provider,
+// such that the submit checker agrees with other consumers of the provider about which nodes exist.
+// Must be called before the provider is used.
+func (srv *SubmitChecker) EnableExecutorSnapshots(provider *ExecutorSnapshotProvider) {
+	srv.executorSnapshots = provider
+	provider.Subscribe(srv.updateExecutorsFromSnapshot)
+}
+
 func (srv *SubmitChecker) Run(ctx *armadacontext.Context) error {
+	if srv.executorSnapshots != nil {
+		// Executors are updated by the snapshot provider.
+		return nil
+	}
 	srv.updateExecutors(ctx)
 
-	ticker := time.NewTicker(srv.ExecutorUpdateFrequency)
+	executorUpdateFrequency := srv.executorUpdateFrequency()
+	ticker := time.NewTicker(executorUpdateFrequency)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
 			srv.updateExecutors(ctx)
+			// The frequency may have been changed by reloading the config.
+			if frequency := srv.executorUpdateFrequency(); frequency != executorUpdateFrequency {
+				executorUpdateFrequency = frequency
+				ticker.Reset(executorUpdateFrequency)
+			}
 		}
 	}
 }
 
+func (srv *SubmitChecker) executorUpdateFrequency() time.Duration {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.ExecutorUpdateFrequency
+}
+
 func (srv *SubmitChecker) updateExecutors(ctx *armadacontext.Context) {
 	executors, err := srv.executorRepository.GetExecutors(ctx)
 	if err != nil {
@@ -109,12 +161,29 @@ func (srv *SubmitChecker) updateExecutors(ctx *armadacontext.Context) {
 			Error("Error fetching executors")
 		return
 	}
+	srv.setExecutors(ctx, executors, srv.clock.Now())
+}
+
+func (srv *SubmitChecker) updateExecutorsFromSnapshot(ctx *armadacontext.Context, snapshot *ExecutorSnapshot) {
+	srv.setExecutors(ctx, snapshot.Executors(), snapshot.Created)
+	srv.mu.Lock()
+	srv.executorSnapshotVersion = snapshot.Version
+	srv.mu.Unlock()
+}
+
+func (srv *SubmitChecker) setExecutors(ctx *armadacontext.Context, executors []*schedulerobjects.Executor, updated time.Time) {
 	for _, executor := range executors {
-		nodeDb, err := srv.constructNodeDb(executor.Nodes)
+		nodes := make([]*schedulerobjects.Node, len(executor.Nodes))
+		for i, node := range executor.Nodes {
+			nodes[i] = node.WithOvercommit(srv.overcommitFactorsByPool[executor.Pool])
+		}
+		nodeDb, err := srv.constructNodeDb(nodes)
 		if err == nil {
 			srv.mu.Lock()
 			srv.executorById[executor.Id] = minimalExecutor{
 				nodeDb:     nodeDb,
+				nodes:      nodes,
+				pool:       executor.Pool,
 				updateTime: executor.LastUpdateTime,
 			}
 			srv.mu.Unlock()
@@ -134,10 +203,42 @@ func (srv *SubmitChecker) updateExecutors(ctx *armadacontext.Context) {
 	// Create a new schedulingKeyGenerator to get a new initial state.
 	srv.schedulingKeyGenerator = schedulerobjects.NewSchedulingKeyGenerator()
 	srv.jobSchedulingResultsCache.Purge()
+	srv.feasibleExecutorsCache.Purge()
+	srv.mu.Lock()
+	srv.executorsUpdated = updated
+	srv.mu.Unlock()
 }
 
 func (srv *SubmitChecker) CheckApiJobs(jobs []*api.Job) (bool, string) {
-	return srv.check(schedulercontext.JobSchedulingContextsFromJobs(srv.priorityClasses, jobs, GangIdAndCardinalityFromAnnotations))
+	return srv.check(schedulercontext.JobSchedulingContextsFromJobs(srv.priorityClasses, srv.withQueueDefaultTolerations(jobs), GangIdAndCardinalityFromAnnotations))
+}
+
+// withQueueDefaultTolerations returns jobs with the default tolerations of their queue added to their main pod spec,
+// such that they're checked against nodes as they would be scheduled. Jobs are copied rather than modified,
+// since the tolerations mustn't be stored with the jobs.
+func (srv *SubmitChecker) withQueueDefaultTolerations(jobs []*api.Job) []*api.Job {
+	if len(srv.defaultTolerationsByQueue) == 0 {
+		return jobs
+	}
+	rv := make([]*api.Job, len(jobs))
+	for i, job := range jobs {
+		rv[i] = job
+		podSpec := job.GetMainPodSpec()
+		if podSpec == nil {
+			continue
+		}
+		missing := schedulerobjects.MissingTolerations(podSpec.Tolerations, srv.defaultTolerationsByQueue[job.Queue])
+		if len(missing) == 0 {
+			continue
+		}
+		podSpecWithTolerations := *podSpec
+		podSpecWithTolerations.Tolerations = append(slices.Clone(podSpec.Tolerations), missing...)
+		jobWithTolerations := *job
+		jobWithTolerations.PodSpec = &podSpecWithTolerations
+		jobWithTolerations.PodSpecs = nil
+		rv[i] = &jobWithTolerations
+	}
+	return rv
 }
 
 func (srv *SubmitChecker) CheckJobDbJobs(jobs []*jobdb.Job) (bool, string) {
@@ -145,7 +246,14 @@ func (srv *SubmitChecker) CheckJobDbJobs(jobs []*jobdb.Job) (bool, string) {
 }
 
 func (srv *SubmitChecker) check(jctxs []*schedulercontext.JobSchedulingContext) (bool, string) {
-	// First, check if all jobs can be scheduled individually.
+	// First, check that no job is larger than every node, in which case we can tell exactly why it's unschedulable.
+	largestNodeResources := srv.LargestNodeResources()
+	for i, jctx := range jctxs {
+		if oversized, ok := nodedb.FindOversizedResource(jctx.PodRequirements.ResourceRequirements.Requests, largestNodeResources); ok {
+			return false, fmt.Sprintf("%d-th job unschedulable:\n%s", i, oversized.Reason())
+		}
+	}
+	// Then, check if all jobs can be scheduled individually.
 	for i, jctx := range jctxs {
 		// Override min cardinality to enable individual job scheduling checks, but reset after
 		originalGangMinCardinality := jctx.GangMinCardinality
@@ -166,6 +274,12 @@ func (srv *SubmitChecker) check(jctxs []*schedulercontext.JobSchedulingContext)
 		if gangId == "" {
 			continue
 		}
+		if diagnostic := crossQueueGangDiagnostic(gangId, util.Map(
+			jctxsInGang,
+			func(jctx *schedulercontext.JobSchedulingContext) interfaces.LegacySchedulerJob { return jctx.Job },
+		)); diagnostic != "" {
+			return false, fmt.Sprintf("gang %s is unschedulable:\n%s", gangId, diagnostic)
+		}
 		if schedulingResult := srv.getSchedulingResult(jctxsInGang); !schedulingResult.isSchedulable {
 			return schedulingResult.isSchedulable, fmt.Sprintf("gang %s is unschedulable:\n%s", gangId, schedulingResult.reason)
 		}
@@ -185,6 +299,7 @@ func (srv *SubmitChecker) getIndividualSchedulingResult(jctx *schedulercontext.J
 		result = obj.(schedulingResult)
 	} else {
 		result = srv.getSchedulingResult([]*schedulercontext.JobSchedulingContext{jctx})
+		result.created = srv.clock.Now()
 		srv.jobSchedulingResultsCache.Add(schedulingKey, result)
 	}
 	if !result.isSchedulable {
@@ -264,6 +379,78 @@ func (srv *SubmitChecker) getSchedulingResult(jctxs []*schedulercontext.JobSched
 	return schedulingResult{isSchedulable: isSchedulable, reason: sb.String()}
 }
 
+// LargestNodeResources returns the largest amount of each resource allocatable on any single node of any executor
+// that isn't stale.
+// If there are no such executors, e.g., since they've not yet been loaded, the returned list is empty.
+func (srv *SubmitChecker) LargestNodeResources() schedulerobjects.ResourceList {
+	srv.mu.Lock()
+	executorById := maps.Clone(srv.executorById)
+	srv.mu.Unlock()
+	largest := schedulerobjects.ResourceList{Resources: make(map[string]resource.Quantity)}
+	for _, executor := range srv.filterStaleExecutors(executorById) {
+		for t, q := range executor.nodeDb.LargestNodeResources().Resources {
+			if current, ok := largest.Resources[t]; !ok || q.Cmp(current) > 0 {
+				largest.Resources[t] = q
+			}
+		}
+	}
+	return largest
+}
+
+// ExecutorCapacity is the pool and total resources of an executor.
+type ExecutorCapacity struct {
+	Pool           string
+	TotalResources schedulerobjects.ResourceList
+}
+
+// Executors returns the capacity of each executor that isn't stale, indexed by executor id.
+func (srv *SubmitChecker) Executors() map[string]ExecutorCapacity {
+	srv.mu.Lock()
+	executorById := maps.Clone(srv.executorById)
+	srv.mu.Unlock()
+	rv := make(map[string]ExecutorCapacity, len(executorById))
+	for id, executor := range srv.filterStaleExecutors(executorById) {
+		rv[id] = ExecutorCapacity{
+			Pool:           executor.pool,
+			TotalResources: executor.nodeDb.TotalResources(),
+		}
+	}
+	return rv
+}
+
+// FeasibleExecutors returns the ids of the executors that aren't stale and that job could be scheduled on
+// if they were empty, ignoring the gang the job may be part of. Results are cached by scheduling key
+// until the executors are next updated.
+func (srv *SubmitChecker) FeasibleExecutors(job *jobdb.Job) []string {
+	schedulingKey, ok := job.GetSchedulingKey()
+	if !ok {
+		srv.mu.Lock()
+		schedulingKey = interfaces.SchedulingKeyFromLegacySchedulerJob(srv.schedulingKeyGenerator, job)
+		srv.mu.Unlock()
+	}
+	if obj, ok := srv.feasibleExecutorsCache.Get(schedulingKey); ok {
+		return obj.([]string)
+	}
+
+	srv.mu.Lock()
+	executorById := maps.Clone(srv.executorById)
+	srv.mu.Unlock()
+	var rv []string
+	for id, executor := range srv.filterStaleExecutors(executorById) {
+		jctxs := schedulercontext.JobSchedulingContextsFromJobs(srv.priorityClasses, []*jobdb.Job{job}, GangIdAndCardinalityFromAnnotations)
+		jctxs[0].GangMinCardinality = 1
+		txn := executor.nodeDb.Txn(true)
+		ok, err := executor.nodeDb.ScheduleManyWithTxn(txn, jctxs)
+		txn.Abort()
+		if err == nil && ok {
+			rv = append(rv, id)
+		}
+	}
+	slices.Sort(rv)
+	srv.feasibleExecutorsCache.Add(schedulingKey, rv)
+	return rv
+}
+
 func (srv *SubmitChecker) filterStaleExecutors(executorsById map[string]minimalExecutor) map[string]minimalExecutor {
 	rv := make(map[string]minimalExecutor)
 	for id, executor := range executorsById {
@@ -274,6 +461,8 @@ func (srv *SubmitChecker) filterStaleExecutors(executorsById map[string]minimalE
 	return rv
 }
 
+// constructNodeDb returns a nodeDb containing nodes, which should already have been overcommitted according to the
+// overcommit factors of their pool, in the same way as when scheduling.
 func (srv *SubmitChecker) constructNodeDb(nodes []*schedulerobjects.Node) (*nodedb.NodeDb, error) {
 	nodeDb, err := nodedb.NewNodeDb(
 		srv.priorityClasses,
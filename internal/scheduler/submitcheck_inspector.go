@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/armada/permissions"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// Number of cache entries returned by InspectSubmitChecker if the request doesn't specify a maximum.
+const defaultMaxSubmitCheckerCacheEntries = 100
+
+// Inspect returns the executors and nodes the submit checker believes exist, as used to check jobs,
+// together with up to maxCacheEntries of the most recently cached verdicts.
+// If flushCache is true, cached verdicts are flushed first and the number flushed is returned instead.
+func (srv *SubmitChecker) Inspect(flushCache bool, maxCacheEntries int) *schedulerobjects.InspectSubmitCheckerResponse {
+	srv.mu.Lock()
+	executorById := maps.Clone(srv.executorById)
+	snapshotVersion := srv.executorSnapshotVersion
+	executorsUpdated := srv.executorsUpdated
+	srv.mu.Unlock()
+
+	rv := &schedulerobjects.InspectSubmitCheckerResponse{SnapshotVersion: snapshotVersion}
+	if !executorsUpdated.IsZero() {
+		rv.SnapshotAge = srv.clock.Since(executorsUpdated)
+	}
+	executorIds := maps.Keys(executorById)
+	slices.Sort(executorIds)
+	for _, executorId := range executorIds {
+		executor := executorById[executorId]
+		inspectedExecutor := &schedulerobjects.SubmitCheckerExecutor{
+			Id:             executorId,
+			Pool:           executor.pool,
+			LastUpdateTime: executor.updateTime,
+			Stale:          srv.clock.Since(executor.updateTime) >= srv.executorTimeout,
+			Nodes:          make([]*schedulerobjects.SubmitCheckerNode, len(executor.nodes)),
+		}
+		for i, node := range executor.nodes {
+			inspectedExecutor.Nodes[i] = &schedulerobjects.SubmitCheckerNode{
+				Id:          node.Id,
+				Name:        node.Name,
+				Allocatable: node.TotalResources,
+				Taints:      node.Taints,
+				Labels:      node.Labels,
+			}
+		}
+		rv.Executors = append(rv.Executors, inspectedExecutor)
+	}
+
+	if flushCache {
+		rv.NumFlushedCacheEntries = uint32(srv.jobSchedulingResultsCache.Len())
+		srv.jobSchedulingResultsCache.Purge()
+		srv.feasibleExecutorsCache.Purge()
+	}
+	// Keys are ordered from least to most recently used.
+	keys := srv.jobSchedulingResultsCache.Keys()
+	rv.NumCacheEntries = uint32(len(keys))
+	for i := len(keys) - 1; i >= 0 && len(rv.CacheEntries) < maxCacheEntries; i-- {
+		obj, ok := srv.jobSchedulingResultsCache.Peek(keys[i])
+		if !ok {
+			// Evicted since the keys were listed.
+			continue
+		}
+		schedulingKey := keys[i].(schedulerobjects.SchedulingKey)
+		result := obj.(schedulingResult)
+		rv.CacheEntries = append(rv.CacheEntries, &schedulerobjects.SubmitCheckerCacheEntry{
+			SchedulingKey: hex.EncodeToString(schedulingKey[:]),
+			Schedulable:   result.isSchedulable,
+			Reason:        result.reason,
+			Created:       result.created,
+		})
+	}
+	return rv
+}
+
+// SubmitCheckerInspector implements the InspectSubmitChecker admin endpoint, which allows operators to see
+// which executors and nodes the submit checker believes exist and what it has cached when it rejects jobs unexpectedly.
+// Each replica has its own submit checker, so requests are always served locally.
+type SubmitCheckerInspector struct {
+	permissionChecker authorization.PermissionChecker
+	// Set once the submit checker has been created, which happens after its dependencies are available.
+	submitChecker atomic.Pointer[SubmitChecker]
+}
+
+func NewSubmitCheckerInspector(permissionChecker authorization.PermissionChecker) *SubmitCheckerInspector {
+	return &SubmitCheckerInspector{permissionChecker: permissionChecker}
+}
+
+// SetSubmitChecker sets the submit checker inspected by requests.
+func (i *SubmitCheckerInspector) SetSubmitChecker(submitChecker *SubmitChecker) {
+	i.submitChecker.Store(submitChecker)
+}
+
+// InspectSubmitChecker is a gRPC endpoint for inspecting, and optionally flushing the cache of, the submit checker.
+func (i *SubmitCheckerInspector) InspectSubmitChecker(ctx context.Context, request *schedulerobjects.InspectSubmitCheckerRequest) (*schedulerobjects.InspectSubmitCheckerResponse, error) {
+	principal := authorization.GetPrincipal(ctx)
+	if i.permissionChecker == nil || !i.permissionChecker.UserHasPermission(ctx, permissions.InspectScheduler) {
+		return nil, &armadaerrors.ErrUnauthorized{
+			Principal:  principal.GetName(),
+			Permission: string(permissions.InspectScheduler),
+			Action:     "inspect submit checker",
+			Message:    fmt.Sprintf("user %s does not have permission to inspect the scheduler", principal.GetName()),
+		}
+	}
+	submitChecker := i.submitChecker.Load()
+	if submitChecker == nil {
+		return nil, status.Error(codes.Unavailable, "submit checker not yet initialised")
+	}
+	maxCacheEntries := int(request.GetMaxCacheEntries())
+	if maxCacheEntries == 0 {
+		maxCacheEntries = defaultMaxSubmitCheckerCacheEntries
+	}
+	return submitChecker.Inspect(request.GetFlushCache(), maxCacheEntries), nil
+}
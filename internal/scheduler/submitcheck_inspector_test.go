@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/armada/permissions"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/common/auth/permission"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestSubmitCheckerInspector_InspectSubmitChecker(t *testing.T) {
+	ctx := armadacontext.Background()
+	fakeClock := clock.NewFakeClock(testfixtures.BaseTime)
+	executorRepository := &testExecutorRepository{
+		executors: []*schedulerobjects.Executor{testfixtures.Test1Node32CoreExecutor("executor1")},
+	}
+	provider := NewExecutorSnapshotProvider(executorRepository, time.Minute)
+	provider.clock = fakeClock
+	ctrl := gomock.NewController(t)
+	submitChecker := NewSubmitChecker(30*time.Minute, testfixtures.TestSchedulingConfig(), schedulermocks.NewMockExecutorRepository(ctrl))
+	submitChecker.clock = fakeClock
+	submitChecker.EnableExecutorSnapshots(provider)
+	inspector := NewSubmitCheckerInspector(testInspectSchedulerPermissionChecker())
+	inspect := func(request *schedulerobjects.InspectSubmitCheckerRequest) *schedulerobjects.InspectSubmitCheckerResponse {
+		response, err := inspector.InspectSubmitChecker(forceFailContext("alice"), request)
+		require.NoError(t, err)
+		return response
+	}
+
+	// Requests fail until the submit checker has been created.
+	_, err := inspector.InspectSubmitChecker(forceFailContext("alice"), &schedulerobjects.InspectSubmitCheckerRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	inspector.SetSubmitChecker(submitChecker)
+	assert.Equal(t, &schedulerobjects.InspectSubmitCheckerResponse{}, inspect(&schedulerobjects.InspectSubmitCheckerRequest{}))
+
+	// Executors and their nodes are returned as of the most recent snapshot.
+	_, err = provider.Refresh(ctx)
+	require.NoError(t, err)
+	fakeClock.Step(10 * time.Second)
+	response := inspect(&schedulerobjects.InspectSubmitCheckerRequest{})
+	assert.Equal(t, uint64(1), response.SnapshotVersion)
+	assert.Equal(t, 10*time.Second, response.SnapshotAge)
+	require.Len(t, response.Executors, 1)
+	assert.Equal(t, "executor1", response.Executors[0].Id)
+	assert.Equal(t, testfixtures.TestPool, response.Executors[0].Pool)
+	assert.False(t, response.Executors[0].Stale)
+	require.Len(t, response.Executors[0].Nodes, 1)
+	node := executorRepository.executors[0].Nodes[0]
+	assert.Equal(t, node.Id, response.Executors[0].Nodes[0].Id)
+	assert.Equal(t, node.Name, response.Executors[0].Nodes[0].Name)
+	assert.True(t, node.TotalResources.Equal(response.Executors[0].Nodes[0].Allocatable))
+
+	executorRepository.executors = append(executorRepository.executors, testfixtures.Test1Node32CoreExecutor("executor2"))
+	_, err = provider.Refresh(ctx)
+	require.NoError(t, err)
+	response = inspect(&schedulerobjects.InspectSubmitCheckerRequest{})
+	assert.Equal(t, uint64(2), response.SnapshotVersion)
+	assert.Equal(t, time.Duration(0), response.SnapshotAge)
+	require.Len(t, response.Executors, 2)
+	assert.Equal(t, "executor2", response.Executors[1].Id)
+
+	// Verdicts are returned most recent first, up to the maximum requested.
+	schedulable := testfixtures.N1Cpu4GiJobs(testfixtures.TestQueue, testfixtures.PriorityClass0, 1)[0]
+	isSchedulable, _ := submitChecker.CheckJobDbJobs([]*jobdb.Job{schedulable})
+	require.True(t, isSchedulable)
+	fakeClock.Step(time.Second)
+	unschedulable := testfixtures.WithNodeSelectorJob(map[string]string{"foo": "bar"}, testfixtures.Test1Cpu4GiJob(testfixtures.TestQueue, testfixtures.PriorityClass1))
+	isSchedulable, _ = submitChecker.CheckJobDbJobs([]*jobdb.Job{unschedulable})
+	require.False(t, isSchedulable)
+	response = inspect(&schedulerobjects.InspectSubmitCheckerRequest{})
+	assert.Equal(t, uint32(2), response.NumCacheEntries)
+	require.Len(t, response.CacheEntries, 2)
+	assert.False(t, response.CacheEntries[0].Schedulable)
+	assert.NotEmpty(t, response.CacheEntries[0].Reason)
+	assert.Equal(t, testfixtures.BaseTime.Add(11*time.Second), response.CacheEntries[0].Created)
+	assert.Len(t, response.CacheEntries[0].SchedulingKey, 64)
+	assert.True(t, response.CacheEntries[1].Schedulable)
+	assert.Equal(t, testfixtures.BaseTime.Add(10*time.Second), response.CacheEntries[1].Created)
+	response = inspect(&schedulerobjects.InspectSubmitCheckerRequest{MaxCacheEntries: 1})
+	assert.Equal(t, uint32(2), response.NumCacheEntries)
+	assert.Len(t, response.CacheEntries, 1)
+
+	// Flushing empties the cache.
+	response = inspect(&schedulerobjects.InspectSubmitCheckerRequest{FlushCache: true})
+	assert.Equal(t, uint32(2), response.NumFlushedCacheEntries)
+	assert.Equal(t, uint32(0), response.NumCacheEntries)
+	assert.Empty(t, response.CacheEntries)
+	assert.Len(t, response.Executors, 2)
+	assert.Equal(t, uint32(0), inspect(&schedulerobjects.InspectSubmitCheckerRequest{}).NumCacheEntries)
+
+	// Executors that haven't heartbeated within the executor timeout are reported as stale.
+	fakeClock.Step(time.Hour)
+	response = inspect(&schedulerobjects.InspectSubmitCheckerRequest{})
+	assert.True(t, response.Executors[0].Stale)
+}
+
+func TestSubmitCheckerInspector_Unauthorized(t *testing.T) {
+	inspector := NewSubmitCheckerInspector(testInspectSchedulerPermissionChecker())
+	inspector.SetSubmitChecker(NewSubmitChecker(30*time.Minute, testfixtures.TestSchedulingConfig(), &testExecutorRepository{}))
+	_, err := inspector.InspectSubmitChecker(forceFailContext("mallory"), &schedulerobjects.InspectSubmitCheckerRequest{FlushCache: true})
+	var unauthorized *armadaerrors.ErrUnauthorized
+	assert.ErrorAs(t, err, &unauthorized)
+	_, err = NewSubmitCheckerInspector(nil).InspectSubmitChecker(context.Background(), &schedulerobjects.InspectSubmitCheckerRequest{})
+	assert.ErrorAs(t, err, &unauthorized)
+}
+
+func testInspectSchedulerPermissionChecker() authorization.PermissionChecker {
+	return authorization.NewPrincipalPermissionChecker(
+		map[permission.Permission][]string{permissions.InspectScheduler: {"alice"}},
+		nil,
+		nil,
+	)
+}
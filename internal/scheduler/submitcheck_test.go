@@ -1,16 +1,20 @@
 package scheduler
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
 	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
@@ -92,6 +96,10 @@ func TestSubmitChecker_TestCheckApiJobs(t *testing.T) {
 	defaultTimeout := 15 * time.Minute
 	testfixtures.BaseTime = time.Now().UTC()
 	expiredTime := testfixtures.BaseTime.Add(-defaultTimeout).Add(-1 * time.Second)
+	gangAcrossQueues := testfixtures.TestNApiJobGang(2)
+	gangAcrossQueues[1].Queue = "otherQueue"
+	gangAcrossJobSets := testfixtures.TestNApiJobGang(2)
+	gangAcrossJobSets[1].JobSetId = "otherJobSet"
 
 	tests := map[string]struct {
 		executorTimout time.Duration
@@ -99,6 +107,8 @@ func TestSubmitChecker_TestCheckApiJobs(t *testing.T) {
 		executors      []*schedulerobjects.Executor
 		jobs           []*api.Job
 		expectPass     bool
+		// If non-empty, expected to be contained in the message returned if the jobs don't pass.
+		expectedMsg string
 	}{
 		"one job schedules": {
 			executorTimout: defaultTimeout,
@@ -163,6 +173,22 @@ func TestSubmitChecker_TestCheckApiJobs(t *testing.T) {
 			jobs:           testfixtures.TestNApiJobGang(100),
 			expectPass:     false,
 		},
+		"gang job split across queues": {
+			executorTimout: defaultTimeout,
+			config:         testfixtures.TestSchedulingConfig(),
+			executors:      []*schedulerobjects.Executor{testfixtures.TestExecutor(testfixtures.BaseTime)},
+			jobs:           gangAcrossQueues,
+			expectPass:     false,
+			expectedMsg:    fmt.Sprintf("queue otherQueue, job set : jobs [%s]", gangAcrossQueues[1].Id),
+		},
+		"gang job split across job sets": {
+			executorTimout: defaultTimeout,
+			config:         testfixtures.TestSchedulingConfig(),
+			executors:      []*schedulerobjects.Executor{testfixtures.TestExecutor(testfixtures.BaseTime)},
+			jobs:           gangAcrossJobSets,
+			expectPass:     false,
+			expectedMsg:    fmt.Sprintf("job set otherJobSet: jobs [%s]", gangAcrossJobSets[1].Id),
+		},
 		"Less than min cardinality gang jobs in a batch skips submit check": {
 			executorTimout: defaultTimeout,
 			config:         testfixtures.TestSchedulingConfig(),
@@ -187,8 +213,81 @@ func TestSubmitChecker_TestCheckApiJobs(t *testing.T) {
 			assert.Equal(t, tc.expectPass, result)
 			if !tc.expectPass {
 				assert.NotEqual(t, "", msg)
+				assert.Contains(t, msg, tc.expectedMsg)
 			}
 			logrus.Info(msg)
 		})
 	}
 }
+
+func TestSubmitChecker_JobExceedingLargestNode(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+	baseTime := time.Now().UTC()
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{testfixtures.TestExecutor(baseTime)}, nil).AnyTimes()
+	submitCheck := NewSubmitChecker(15*time.Minute, testfixtures.TestSchedulingConfig(), mockExecutorRepo)
+	submitCheck.clock = clock.NewFakeClock(baseTime)
+
+	// No resource is considered oversized before any executors are known.
+	assert.Empty(t, submitCheck.LargestNodeResources().Resources)
+
+	submitCheck.updateExecutors(ctx)
+	assert.True(
+		t,
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("9"), "memory": resource.MustParse("9Gi")}}.Equal(
+			submitCheck.LargestNodeResources(),
+		),
+	)
+
+	// The job requests less cpu, but more memory, than the largest node has.
+	job := testfixtures.WithRequestsJobs(
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1"), "memory": resource.MustParse("16Gi")}},
+		testfixtures.N1Cpu4GiJobs("queue", testfixtures.PriorityClass1, 1),
+	)[0]
+	isSchedulable, reason := submitCheck.CheckJobDbJobs([]*jobdb.Job{job})
+	assert.False(t, isSchedulable)
+	assert.Contains(t, reason, "exceeds largest node: job requests 16Gi memory, but the largest node has 9Gi memory allocatable")
+}
+
+func TestSubmitChecker_QueueDefaultTolerations(t *testing.T) {
+	toleration := v1.Toleration{Key: "largeJobsOnly", Operator: v1.TolerationOpEqual, Value: "true", Effect: v1.TaintEffectNoSchedule}
+	config := testfixtures.TestSchedulingConfig()
+	config.DefaultJobTolerationsByQueue = map[string][]v1.Toleration{"tolerated": {toleration}}
+	executor := testfixtures.TestExecutor(testfixtures.BaseTime)
+	executor.Nodes = []*schedulerobjects.Node{testfixtures.TestTainted32CpuNode(testfixtures.TestPriorities)}
+
+	ctx := armadacontext.Background()
+	ctrl := gomock.NewController(t)
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{executor}, nil).AnyTimes()
+	submitCheck := NewSubmitChecker(15*time.Minute, config, mockExecutorRepo)
+	submitCheck.clock = clock.NewFakeClock(testfixtures.BaseTime)
+	submitCheck.updateExecutors(ctx)
+
+	// Only jobs in a queue tolerating the taint by default can be placed on the tainted node.
+	apiJob := func(queue string) *api.Job {
+		job := testfixtures.Test1CoreCpuApiJob()
+		job.Queue = queue
+		return job
+	}
+	toleratedJob := apiJob("tolerated")
+	isSchedulable, _ := submitCheck.CheckApiJobs([]*api.Job{toleratedJob})
+	assert.True(t, isSchedulable)
+	isSchedulable, _ = submitCheck.CheckApiJobs([]*api.Job{apiJob("other")})
+	assert.False(t, isSchedulable)
+	// The tolerations aren't stored with the job.
+	assert.Empty(t, toleratedJob.GetMainPodSpec().Tolerations)
+
+	jobDb := testfixtures.NewJobDb()
+	jobDb.EnableQueueDefaultTolerations(config.DefaultJobTolerationsByQueue)
+	jobDbJob := func(queue string) *jobdb.Job {
+		schedulingInfo := testfixtures.Test1Cpu4GiJob(queue, testfixtures.PriorityClass1).JobSchedulingInfo()
+		return jobDb.NewJob(util.NewULID(), "jobSet", queue, 0, schedulingInfo, true, 0, false, false, false, 1)
+	}
+	isSchedulable, _ = submitCheck.CheckJobDbJobs([]*jobdb.Job{jobDbJob("tolerated")})
+	assert.True(t, isSchedulable)
+	isSchedulable, _ = submitCheck.CheckJobDbJobs([]*jobdb.Job{jobDbJob("other")})
+	assert.False(t, isSchedulable)
+}
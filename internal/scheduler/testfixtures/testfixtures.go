@@ -89,6 +89,13 @@ func NewJobDb() *jobdb.JobDb {
 	)
 }
 
+// NewLazyJobDb returns a jobDb like NewJobDb, except that it stores the scheduling info of the jobs it creates lazily.
+func NewLazyJobDb() *jobdb.JobDb {
+	jobDb := NewJobDb()
+	jobDb.EnableLazySchedulingInfo()
+	return jobDb
+}
+
 func IntRange(a, b int) []int {
 	rv := make([]int, b-a+1)
 	for i := range rv {
@@ -135,11 +142,26 @@ func WithUnifiedSchedulingByPoolConfig(config configuration.SchedulingConfig) co
 	return config
 }
 
+func WithGangPriorityInheritanceConfig(config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	config.EnableGangPriorityInheritance = true
+	return config
+}
+
 func WithMaxUnacknowledgedJobsPerExecutorConfig(v uint, config configuration.SchedulingConfig) configuration.SchedulingConfig {
 	config.MaxUnacknowledgedJobsPerExecutor = v
 	return config
 }
 
+func WithCapacityPruningConfig(config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	config.EnableCapacityPruning = true
+	return config
+}
+
+func WithReservedResourcesConfig(reservedResourcesByQueue map[string]map[string]resource.Quantity, config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	config.ReservedResourcesByPool = map[string]map[string]map[string]resource.Quantity{"pool": reservedResourcesByQueue}
+	return config
+}
+
 func WithProtectedFractionOfFairShareConfig(v float64, config configuration.SchedulingConfig) configuration.SchedulingConfig {
 	config.Preemption.ProtectedFractionOfFairShare = v
 	return config
@@ -849,9 +871,11 @@ func Test1CoreCpuApiJob() *api.Job {
 
 func TestNApiJobGang(n int) []*api.Job {
 	gangId := uuid.NewString()
+	queue := uuid.NewString()
 	gang := make([]*api.Job, n)
 	for i := 0; i < n; i++ {
 		job := Test1CoreCpuApiJob()
+		job.Queue = queue
 		job.Annotations = map[string]string{
 			configuration.GangIdAnnotation:                 gangId,
 			configuration.GangCardinalityAnnotation:        fmt.Sprintf("%d", n),
@@ -864,9 +888,11 @@ func TestNApiJobGang(n int) []*api.Job {
 
 func TestNApiJobGangLessThanMinCardinality(n int) []*api.Job {
 	gangId := uuid.NewString()
+	queue := uuid.NewString()
 	gang := make([]*api.Job, n)
 	for i := 0; i < n; i++ {
 		job := Test1CoreCpuApiJob()
+		job.Queue = queue
 		job.Annotations = map[string]string{
 			configuration.GangIdAnnotation:                 gangId,
 			configuration.GangCardinalityAnnotation:        fmt.Sprintf("%d", n+2),
@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+// Outcomes reported by the unknown queue metric.
+const (
+	unknownQueueOutcomeFailed       = "failed"
+	unknownQueueOutcomeQueueCreated = "queueCreated"
+	unknownQueueOutcomeHeld         = "held"
+	unknownQueueOutcomeReleased     = "released"
+)
+
+// EnableUnknownQueueHandling causes jobs submitted to queues not in queueRepository
+// to be handled according to config.Policy rather than left queued indefinitely.
+func (s *Scheduler) EnableUnknownQueueHandling(config schedulerconfig.UnknownQueuesConfig, queueRepository database.QueueRepository) {
+	s.unknownQueuesConfig = config
+	s.queueRepository = queueRepository
+	s.heldJobIds = make(map[string]bool)
+	s.unknownQueuesMissingSince = make(map[string]time.Time)
+}
+
+// handleJobsInUnknownQueues applies the configured UnknownQueuePolicy to queued jobs whose queue doesn't exist.
+// Jobs that are failed are updated in txn and an EventSequence is generated for each of them.
+func (s *Scheduler) handleJobsInUnknownQueues(ctx *armadacontext.Context, txn *jobdb.Txn) ([]*armadaevents.EventSequence, error) {
+	policy := s.unknownQueuesConfig.Policy
+	if s.queueRepository == nil || policy == "" || policy == schedulerconfig.UnknownQueuePolicyIgnore {
+		return nil, nil
+	}
+	queuesWithQueuedJobs := txn.QueuesWithQueuedJobs()
+	if len(queuesWithQueuedJobs) == 0 && len(s.heldJobIds) == 0 {
+		return nil, nil
+	}
+	queues, err := s.queueRepository.GetAllQueues()
+	if err != nil {
+		return nil, err
+	}
+	knownQueues := make(map[string]bool, len(queues))
+	for _, queue := range queues {
+		knownQueues[queue.Name] = true
+	}
+
+	heldJobIds := make(map[string]bool)
+	unknownQueuesMissingSince := make(map[string]time.Time)
+	jobsToFail := make([]*jobdb.Job, 0)
+	for _, queue := range queuesWithQueuedJobs {
+		if knownQueues[queue] {
+			continue
+		}
+		switch policy {
+		case schedulerconfig.UnknownQueuePolicyFail:
+			jobsToFail = append(jobsToFail, queuedJobs(txn, queue)...)
+		case schedulerconfig.UnknownQueuePolicyAutoCreate:
+			if err := s.queueRepository.CreateQueue(&database.Queue{
+				Name:   queue,
+				Weight: s.unknownQueuesConfig.DefaultPriorityFactor,
+			}); err != nil {
+				return nil, errors.WithMessagef(err, "failed to create queue %s", queue)
+			}
+			ctx.Infof("created queue %s for jobs submitted to it", queue)
+			s.metrics.ReportUnknownQueueJobs(string(policy), unknownQueueOutcomeQueueCreated, len(queuedJobs(txn, queue)))
+		case schedulerconfig.UnknownQueuePolicyHold:
+			// The grace period starts once the queue is first found not to exist, rather than when each job was created,
+			// such that jobs submitted before the queue was deleted aren't failed immediately.
+			missingSince, ok := s.unknownQueuesMissingSince[queue]
+			if !ok {
+				missingSince = s.clock.Now()
+			}
+			unknownQueuesMissingSince[queue] = missingSince
+			gracePeriodExpired := s.clock.Since(missingSince) > s.unknownQueuesConfig.GracePeriod
+			numHeld := 0
+			for _, job := range queuedJobs(txn, queue) {
+				if gracePeriodExpired {
+					jobsToFail = append(jobsToFail, job)
+					continue
+				}
+				if !s.heldJobIds[job.Id()] {
+					numHeld++
+				}
+				heldJobIds[job.Id()] = true
+			}
+			s.metrics.ReportUnknownQueueJobs(string(policy), unknownQueueOutcomeHeld, numHeld)
+		default:
+			return nil, errors.Errorf("unknown queue policy %s", policy)
+		}
+	}
+
+	// Jobs held previously that are still queued but whose queue now exists have been released.
+	numReleased := 0
+	for _, jobId := range maps.Keys(s.heldJobIds) {
+		if heldJobIds[jobId] {
+			continue
+		}
+		if job := txn.GetById(jobId); job != nil && job.Queued() && knownQueues[job.Queue()] {
+			numReleased++
+		}
+	}
+	s.metrics.ReportUnknownQueueJobs(string(policy), unknownQueueOutcomeReleased, numReleased)
+	s.heldJobIds = heldJobIds
+	s.unknownQueuesMissingSince = unknownQueuesMissingSince
+
+	events := make([]*armadaevents.EventSequence, 0, len(jobsToFail))
+	for i, job := range jobsToFail {
+		ctx.Warnf("failing job %s as queue %s does not exist", job.Id(), job.Queue())
+		jobId, err := armadaevents.ProtoUuidFromUlidString(job.Id())
+		if err != nil {
+			return nil, err
+		}
+		message := s.abandonedQueuedJobMessage(job, abandonedReasonQueueDoesNotExist, fmt.Sprintf("Queue %s does not exist", job.Queue()))
+		jobsToFail[i] = job.WithQueued(false).WithFailed(true)
+		events = append(events, &armadaevents.EventSequence{
+			Queue:      job.Queue(),
+			JobSetName: job.Jobset(),
+			Events: []*armadaevents.EventSequence_Event{
+				{
+					Created: s.now(),
+					Event: &armadaevents.EventSequence_Event_JobErrors{
+						JobErrors: &armadaevents.JobErrors{
+							JobId: jobId,
+							Errors: []*armadaevents.Error{
+								{
+									Terminal: true,
+									Reason: &armadaevents.Error_QueueDoesNotExist{
+										QueueDoesNotExist: &armadaevents.QueueDoesNotExist{
+											Queue:   job.Queue(),
+											Message: message,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if err := txn.Upsert(jobsToFail); err != nil {
+		return nil, err
+	}
+	s.metrics.ReportUnknownQueueJobs(string(policy), unknownQueueOutcomeFailed, len(jobsToFail))
+	return events, nil
+}
+
+// queuedJobs returns all queued jobs in the given queue.
+func queuedJobs(txn *jobdb.Txn, queue string) []*jobdb.Job {
+	jobs := make([]*jobdb.Job, 0)
+	it := txn.QueuedJobs(queue)
+	for job, _ := it.Next(); job != nil; job, _ = it.Next() {
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
@@ -0,0 +1,207 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_HandleJobsInUnknownQueues(t *testing.T) {
+	now := time.Now()
+	gracePeriod := 10 * time.Minute
+	jobInKnownQueue := newQueuedJobForUnknownQueueTest("knownQueue", now)
+	jobInUnknownQueue := newQueuedJobForUnknownQueueTest("unknownQueue", now)
+	oldJobInUnknownQueue := newQueuedJobForUnknownQueueTest("unknownQueue", now.Add(-2*gracePeriod))
+
+	tests := map[string]struct {
+		policy schedulerconfig.UnknownQueuePolicy
+		jobs   []*jobdb.Job
+		// Each cycle, the clock is advanced by this amount before handling jobs.
+		cycleIntervals []time.Duration
+		// If non-empty, this queue is created after the first cycle.
+		queueCreatedAfterFirstCycle string
+		expectedFailedJobs          []string
+		expectedQueuedJobs          []string
+		expectedQueues              []string
+	}{
+		"ignore": {
+			policy:             schedulerconfig.UnknownQueuePolicyIgnore,
+			jobs:               []*jobdb.Job{jobInKnownQueue, jobInUnknownQueue},
+			cycleIntervals:     []time.Duration{0},
+			expectedQueuedJobs: []string{jobInKnownQueue.Id(), jobInUnknownQueue.Id()},
+			expectedQueues:     []string{"knownQueue"},
+		},
+		"fail": {
+			policy:             schedulerconfig.UnknownQueuePolicyFail,
+			jobs:               []*jobdb.Job{jobInKnownQueue, jobInUnknownQueue},
+			cycleIntervals:     []time.Duration{0},
+			expectedFailedJobs: []string{jobInUnknownQueue.Id()},
+			expectedQueuedJobs: []string{jobInKnownQueue.Id()},
+			expectedQueues:     []string{"knownQueue"},
+		},
+		"auto create": {
+			policy:             schedulerconfig.UnknownQueuePolicyAutoCreate,
+			jobs:               []*jobdb.Job{jobInKnownQueue, jobInUnknownQueue},
+			cycleIntervals:     []time.Duration{0},
+			expectedQueuedJobs: []string{jobInKnownQueue.Id(), jobInUnknownQueue.Id()},
+			expectedQueues:     []string{"knownQueue", "unknownQueue"},
+		},
+		"hold within grace period": {
+			policy:             schedulerconfig.UnknownQueuePolicyHold,
+			jobs:               []*jobdb.Job{jobInKnownQueue, jobInUnknownQueue},
+			cycleIntervals:     []time.Duration{0, gracePeriod / 2},
+			expectedQueuedJobs: []string{jobInKnownQueue.Id(), jobInUnknownQueue.Id()},
+			expectedQueues:     []string{"knownQueue"},
+		},
+		"hold beyond grace period": {
+			policy:             schedulerconfig.UnknownQueuePolicyHold,
+			jobs:               []*jobdb.Job{jobInKnownQueue, jobInUnknownQueue, oldJobInUnknownQueue},
+			cycleIntervals:     []time.Duration{0, gracePeriod + time.Second},
+			expectedFailedJobs: []string{jobInUnknownQueue.Id(), oldJobInUnknownQueue.Id()},
+			expectedQueuedJobs: []string{jobInKnownQueue.Id()},
+			expectedQueues:     []string{"knownQueue"},
+		},
+		// The grace period starts once the queue is found not to exist, not when the job was created.
+		"hold job created before grace period": {
+			policy:             schedulerconfig.UnknownQueuePolicyHold,
+			jobs:               []*jobdb.Job{jobInKnownQueue, oldJobInUnknownQueue},
+			cycleIntervals:     []time.Duration{0, gracePeriod / 2},
+			expectedQueuedJobs: []string{jobInKnownQueue.Id(), oldJobInUnknownQueue.Id()},
+			expectedQueues:     []string{"knownQueue"},
+		},
+		"hold with queue created before grace period expires": {
+			policy:                      schedulerconfig.UnknownQueuePolicyHold,
+			jobs:                        []*jobdb.Job{jobInKnownQueue, jobInUnknownQueue},
+			cycleIntervals:              []time.Duration{0, gracePeriod + time.Second},
+			queueCreatedAfterFirstCycle: "unknownQueue",
+			expectedQueuedJobs:          []string{jobInKnownQueue.Id(), jobInUnknownQueue.Id()},
+			expectedQueues:              []string{"knownQueue", "unknownQueue"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+
+			testClock := clock.NewFakeClock(now)
+			queueRepository := &testQueueRepository{queues: []*database.Queue{{Name: "knownQueue", Weight: 1}}}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				nil,
+				nil,
+				nil,
+				NewStandaloneLeaderController(),
+				nil,
+				nil,
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = testClock
+			sched.EnableUnknownQueueHandling(
+				schedulerconfig.UnknownQueuesConfig{
+					Policy:                tc.policy,
+					GracePeriod:           gracePeriod,
+					DefaultPriorityFactor: 1,
+				},
+				queueRepository,
+			)
+
+			txn := sched.jobDb.WriteTxn()
+			require.NoError(t, txn.Upsert(tc.jobs))
+			txn.Commit()
+
+			failedJobs := make(map[string]bool)
+			for i, interval := range tc.cycleIntervals {
+				testClock.Step(interval)
+				txn := sched.jobDb.WriteTxn()
+				eventSequences, err := sched.handleJobsInUnknownQueues(ctx, txn)
+				require.NoError(t, err)
+				txn.Commit()
+				for _, eventSequence := range eventSequences {
+					for _, event := range eventSequence.Events {
+						jobErrors := event.GetJobErrors()
+						require.NotNil(t, jobErrors)
+						require.Len(t, jobErrors.Errors, 1)
+						assert.True(t, jobErrors.Errors[0].Terminal)
+						assert.Equal(t, eventSequence.Queue, jobErrors.Errors[0].GetQueueDoesNotExist().GetQueue())
+						jobId, err := armadaevents.UlidStringFromProtoUuid(jobErrors.JobId)
+						require.NoError(t, err)
+						failedJobs[jobId] = true
+					}
+				}
+				if i == 0 && tc.queueCreatedAfterFirstCycle != "" {
+					require.NoError(t, queueRepository.CreateQueue(&database.Queue{Name: tc.queueCreatedAfterFirstCycle, Weight: 1}))
+				}
+			}
+
+			assert.Equal(t, stringSet(tc.expectedFailedJobs), failedJobs)
+			txn = sched.jobDb.ReadTxn()
+			for _, jobId := range tc.expectedFailedJobs {
+				job := txn.GetById(jobId)
+				assert.True(t, job.Failed())
+				assert.False(t, job.Queued())
+			}
+			for _, jobId := range tc.expectedQueuedJobs {
+				job := txn.GetById(jobId)
+				assert.False(t, job.Failed())
+				assert.True(t, job.Queued())
+			}
+			queueNames := make([]string, 0)
+			for _, queue := range queueRepository.queues {
+				queueNames = append(queueNames, queue.Name)
+			}
+			assert.ElementsMatch(t, tc.expectedQueues, queueNames)
+		})
+	}
+}
+
+func newQueuedJobForUnknownQueueTest(queue string, created time.Time) *jobdb.Job {
+	return testfixtures.JobDb.NewJob(
+		util.NewULID(),
+		"testJobset",
+		queue,
+		uint32(10),
+		schedulingInfo,
+		true,
+		1,
+		false,
+		false,
+		false,
+		created.UnixNano(),
+	)
+}
+
+type testQueueRepository struct {
+	queues []*database.Queue
+}
+
+func (r *testQueueRepository) GetAllQueues() ([]*database.Queue, error) {
+	return r.queues, nil
+}
+
+func (r *testQueueRepository) CreateQueue(queue *database.Queue) error {
+	for _, existing := range r.queues {
+		if existing.Name == queue.Name {
+			return nil
+		}
+	}
+	r.queues = append(r.queues, queue)
+	return nil
+}
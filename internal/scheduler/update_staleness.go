@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/common/logging"
+)
+
+// UpdateStalenessTracker records the age of the oldest job or run update in postgres not yet processed by the scheduler.
+// It's updated by the Scheduler and read by, e.g., the SchedulingContextRepository to include it in reports.
+type UpdateStalenessTracker struct {
+	mu sync.Mutex
+	// Age of the oldest unprocessed update as of sampledAt.
+	staleness time.Duration
+	// Time at which staleness was most recently sampled; zero if never sampled.
+	sampledAt time.Time
+}
+
+func NewUpdateStalenessTracker() *UpdateStalenessTracker {
+	return &UpdateStalenessTracker{}
+}
+
+// Staleness returns the most recently sampled age of the oldest unprocessed update and the time it was sampled at.
+// The returned time is zero if the age hasn't yet been sampled.
+func (t *UpdateStalenessTracker) Staleness() (time.Duration, time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.staleness, t.sampledAt
+}
+
+func (t *UpdateStalenessTracker) record(staleness time.Duration, sampledAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.staleness = staleness
+	t.sampledAt = sampledAt
+}
+
+// EnableUpdateStalenessMonitoring causes the scheduler to sample the age of the oldest job or run update in postgres
+// it hasn't yet processed at the start of cycles at most every samplePeriod, recording it in tracker
+// and exporting it as a metric. If maxStaleness is positive, a warning is logged whenever the age exceeds it and,
+// if failReadiness is true, the scheduler reports unhealthy until a sample no longer exceeds it.
+func (s *Scheduler) EnableUpdateStalenessMonitoring(tracker *UpdateStalenessTracker, samplePeriod, maxStaleness time.Duration, failReadiness bool) {
+	s.updateStalenessTracker = tracker
+	s.updateStalenessSamplePeriod = samplePeriod
+	s.maxUpdateStaleness = maxStaleness
+	s.failReadinessOnUpdateStaleness = failReadiness
+}
+
+// sampleUpdateStaleness records the age of the oldest update with a serial greater than those processed so far,
+// unless the previous sample was taken less than the sample period ago.
+// Errors are logged rather than returned, since sampling is best-effort and mustn't cause the cycle to fail.
+func (s *Scheduler) sampleUpdateStaleness(ctx *armadacontext.Context) {
+	if s.updateStalenessTracker == nil {
+		return
+	}
+	now := s.clock.Now()
+	if _, sampledAt := s.updateStalenessTracker.Staleness(); !sampledAt.IsZero() && now.Sub(sampledAt) < s.updateStalenessSamplePeriod {
+		return
+	}
+	oldest, err := s.jobRepository.FetchOldestUnprocessedUpdateTime(ctx, s.jobsSerial, s.runsSerial)
+	if err != nil {
+		logging.WithStacktrace(ctx, err).Warn("failed to fetch the time of the oldest unprocessed update")
+		return
+	}
+	staleness := time.Duration(0)
+	if oldest != nil && now.After(*oldest) {
+		staleness = now.Sub(*oldest)
+	}
+	s.updateStalenessTracker.record(staleness, now)
+	s.metrics.ReportOldestUnprocessedUpdateAge(staleness)
+	if s.maxUpdateStaleness > 0 && staleness > s.maxUpdateStaleness {
+		ctx.Warnf(
+			"oldest unprocessed job or run update was made %s ago, which exceeds the bound of %s; jobs serial is %d and runs serial is %d",
+			staleness, s.maxUpdateStaleness, s.jobsSerial, s.runsSerial,
+		)
+	}
+}
+
+// checkUpdateStaleness returns an error if readiness should fail since the most recently sampled age of the oldest
+// unprocessed update exceeds the configured bound.
+func (s *Scheduler) checkUpdateStaleness() error {
+	if s.updateStalenessTracker == nil || !s.failReadinessOnUpdateStaleness || s.maxUpdateStaleness <= 0 {
+		return nil
+	}
+	if staleness, _ := s.updateStalenessTracker.Staleness(); staleness > s.maxUpdateStaleness {
+		return errors.Errorf("oldest unprocessed job or run update was made %s ago, which exceeds the bound of %s", staleness, s.maxUpdateStaleness)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestScheduler_UpdateStalenessMonitoring(t *testing.T) {
+	ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+	defer cancel()
+
+	testClock := clock.NewFakeClock(time.Now())
+	jobRepo := &testJobRepository{}
+	sched, err := NewScheduler(
+		testfixtures.NewJobDb(),
+		jobRepo,
+		&testExecutorRepository{},
+		&testSchedulingAlgo{},
+		NewStandaloneLeaderController(),
+		&testPublisher{},
+		nil,
+		1*time.Second,
+		5*time.Second,
+		1*time.Hour,
+		maxNumberOfAttempts,
+		nodeIdLabel,
+		schedulerMetrics,
+		nil,
+	)
+	require.NoError(t, err)
+	sched.clock = testClock
+	tracker := NewUpdateStalenessTracker()
+	sched.EnableUpdateStalenessMonitoring(tracker, time.Minute, 5*time.Minute, true)
+
+	// Nothing is sampled before the first cycle.
+	_, sampledAt := tracker.Staleness()
+	assert.True(t, sampledAt.IsZero())
+	assert.NoError(t, sched.Check())
+
+	// No unprocessed updates.
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	staleness, sampledAt := tracker.Staleness()
+	assert.Equal(t, time.Duration(0), staleness)
+	assert.True(t, testClock.Now().Equal(sampledAt))
+	assert.Equal(t, float64(0), testutil.ToFloat64(schedulerMetrics.oldestUnprocessedUpdateAge))
+	assert.NoError(t, sched.Check())
+
+	// Updates are only sampled once per sample period.
+	oldest := testClock.Now().Add(-10 * time.Minute)
+	jobRepo.oldestUnprocessedUpdateTime = &oldest
+	testClock.Step(30 * time.Second)
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, jobRepo.numOldestUnprocessedUpdateTimeFetches)
+	staleness, _ = tracker.Staleness()
+	assert.Equal(t, time.Duration(0), staleness)
+
+	// Staleness exceeding the bound fails the health check.
+	testClock.Step(30 * time.Second)
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, jobRepo.numOldestUnprocessedUpdateTimeFetches)
+	staleness, _ = tracker.Staleness()
+	assert.Equal(t, 11*time.Minute, staleness)
+	assert.Equal(t, (11 * time.Minute).Seconds(), testutil.ToFloat64(schedulerMetrics.oldestUnprocessedUpdateAge))
+	assert.Error(t, sched.Check())
+
+	// The health check recovers once updates are processed.
+	jobRepo.oldestUnprocessedUpdateTime = nil
+	testClock.Step(time.Minute)
+	_, err = sched.cycle(ctx, false, sched.leaderController.GetToken(), false)
+	require.NoError(t, err)
+	staleness, _ = tracker.Staleness()
+	assert.Equal(t, time.Duration(0), staleness)
+	assert.NoError(t, sched.Check())
+}
+
+func TestSchedulingContextRepository_UpdateStalenessReport(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10)
+	require.NoError(t, err)
+	tracker := NewUpdateStalenessTracker()
+	repo.EnableUpdateStalenessReports(tracker)
+
+	report, err := repo.GetSchedulingReport(armadacontext.Background(), &schedulerobjects.SchedulingReportRequest{})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "Oldest unprocessed update age: not yet sampled")
+
+	tracker.record(3*time.Minute, time.Now())
+	report, err = repo.GetSchedulingReport(armadacontext.Background(), &schedulerobjects.SchedulingReportRequest{})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "Oldest unprocessed update age: 3m0s")
+}
@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/nodedb"
+)
+
+// UrgentSchedulingAlgo places jobs of urgent priority classes as soon as they're discovered,
+// instead of waiting for the next full scheduling round.
+type UrgentSchedulingAlgo interface {
+	// ScheduleUrgent should assign as many of the provided queued jobs as possible to nodes with free capacity for them,
+	// without preempting any other jobs. Any jobs that are scheduled should be marked as such in the JobDb using the transaction provided.
+	ScheduleUrgent(ctx *armadacontext.Context, txn *jobdb.Txn, jobs []*jobdb.Job) (*SchedulerResult, error)
+}
+
+// ScheduleUrgent places each job in turn on the first node found with enough free capacity, across all active executors.
+// Unlike Schedule, this doesn't consider fairness, preemption, or scheduling constraints other than the job's pod requirements.
+// Gang jobs are skipped, since they have to be scheduled together with the rest of their gang.
+func (l *FairSchedulingAlgo) ScheduleUrgent(ctx *armadacontext.Context, txn *jobdb.Txn, jobs []*jobdb.Job) (*SchedulerResult, error) {
+	result := &SchedulerResult{
+		PlacementByJobId:             make(map[string]Placement),
+		AdditionalAnnotationsByJobId: make(map[string]map[string]string),
+	}
+	if l.schedulingConfig.DisableScheduling || len(jobs) == 0 {
+		return result, nil
+	}
+
+	executors, err := l.executorRepository.GetExecutors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	executors = l.filterStaleExecutors(executors)
+	jobsByExecutorId := make(map[string][]*jobdb.Job)
+	for _, job := range txn.GetAll() {
+		if job.Queued() || !job.HasRuns() {
+			continue
+		}
+		executorId := job.LatestRun().Executor()
+		jobsByExecutorId[executorId] = append(jobsByExecutorId[executorId], job)
+	}
+	nodeDb, err := nodedb.NewNodeDb(
+		l.schedulingConfig.Preemption.PriorityClasses,
+		l.schedulingConfig.MaxExtraNodesToConsider,
+		l.schedulingConfig.IndexedResources,
+		l.schedulingConfig.IndexedTaints,
+		l.schedulingConfig.IndexedNodeLabels,
+		l.schedulingConfig.WellKnownNodeTypes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if l.gangReservations != nil {
+		nodeDb.EnableBackfill(l.gangReservations.MayBackfill)
+	}
+	poolByExecutorId := make(map[string]string, len(executors))
+	for _, executor := range executors {
+		poolByExecutorId[executor.Id] = executor.Pool
+		if err := l.addExecutorToNodeDb(nodeDb, jobsByExecutorId[executor.Id], executor.Nodes, executor.Pool); err != nil {
+			return nil, err
+		}
+	}
+
+	nodeDbTxn := nodeDb.Txn(true)
+	defer nodeDbTxn.Abort()
+	scheduledJobs := make([]*jobdb.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if !job.Queued() {
+			continue
+		}
+		if _, _, _, isGangJob, err := GangIdAndCardinalityFromLegacySchedulerJob(job); err != nil {
+			return nil, err
+		} else if isGangJob {
+			continue
+		}
+		jctx := schedulercontext.JobSchedulingContextFromJob(l.schedulingConfig.Preemption.PriorityClasses, job, GangIdAndCardinalityFromAnnotations)
+		node, err := nodeDb.ScheduleWithoutPreemptionWithTxn(nodeDbTxn, jctx)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			ctx.Infof("no node with enough free capacity for urgent job %s; leaving it for the next scheduling round", job.Id())
+			continue
+		}
+		placement := Placement{
+			Executor:            node.Executor,
+			NodeId:              node.Id,
+			NodeName:            node.Name,
+			ScheduledAtPriority: jctx.PodSchedulingContext.ScheduledAtPriority,
+			Pool:                poolByExecutorId[node.Executor],
+		}
+		job = job.
+			WithQueuedVersion(job.QueuedVersion()+1).
+			WithQueued(false).
+			WithNewRun(placement.Executor, placement.NodeId, placement.NodeName, placement.ScheduledAtPriority, l.clock.Now())
+		if l.jobSetPlacementTracker != nil {
+			l.jobSetPlacementTracker.RecordRun(job.Queue(), job.Jobset(), job.Id(), node.Executor, node.Name, node.Labels, job.GetAnnotations()[configuration.PreferredExecutorAnnotation])
+		}
+		jctx.Job = job
+		result.ScheduledJobs = append(result.ScheduledJobs, jctx)
+		result.PlacementByJobId[job.Id()] = placement
+		scheduledJobs = append(scheduledJobs, job)
+	}
+	if err := txn.Upsert(scheduledJobs); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// scheduleUrgentJobs leases any queued jobs of urgent priority classes among updatedJobs
+// and publishes the resulting events, before the rest of the cycle runs.
+// Returns updatedJobs with the scheduled jobs replaced by their leased versions, such that the rest of the cycle
+// doesn't overwrite the leases, together with the result of scheduling.
+func (s *Scheduler) scheduleUrgentJobs(ctx *armadacontext.Context, updatedJobs []*jobdb.Job, leaderToken LeaderToken) ([]*jobdb.Job, *SchedulerResult, error) {
+	txn := s.jobDb.WriteTxn()
+	defer txn.Abort()
+	var urgentJobs []*jobdb.Job
+	for _, job := range updatedJobs {
+		if !slices.Contains(s.urgentPriorityClasses, job.GetPriorityClassName()) {
+			continue
+		}
+		// Use the version in the jobDb, since updatedJobs includes jobs deleted from the jobDb upon becoming terminal.
+		job = txn.GetById(job.Id())
+		if job == nil || !job.Queued() || job.CancelRequested() || job.CancelByJobsetRequested() || s.heldJobIds[job.Id()] || s.isBacklogLimited(job.Id()) || s.isCrossQueueGangJob(job.Id()) {
+			continue
+		}
+		urgentJobs = append(urgentJobs, job)
+	}
+	if len(urgentJobs) == 0 {
+		return updatedJobs, nil, nil
+	}
+
+	result, err := s.urgentSchedulingAlgo.ScheduleUrgent(ctx, txn, urgentJobs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(result.ScheduledJobs) == 0 {
+		return updatedJobs, result, nil
+	}
+	events, err := s.eventsFromSchedulerResult(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkEventConsistency(ctx, txn, events); err != nil {
+		return nil, nil, err
+	}
+	attachCorrelationIds(ctx, txn, nil, events)
+	isLeader := func() bool {
+		return s.leaderController.ValidateToken(leaderToken)
+	}
+	if err := s.publisher.PublishMessages(withLeaderEpoch(ctx, leaderToken.Epoch()), events, isLeader); err != nil {
+		return nil, nil, errors.WithMessage(err, "error publishing urgent leases")
+	}
+	txn.Commit()
+	ctx.Infof("leased %d of %d urgent jobs ahead of the scheduling round", len(result.ScheduledJobs), len(urgentJobs))
+
+	scheduledJobsById := make(map[string]*jobdb.Job, len(result.ScheduledJobs))
+	for _, job := range ScheduledJobsFromSchedulerResult[*jobdb.Job](result) {
+		scheduledJobsById[job.Id()] = job
+	}
+	rv := make([]*jobdb.Job, len(updatedJobs))
+	for i, job := range updatedJobs {
+		if scheduledJob, ok := scheduledJobsById[job.Id()]; ok {
+			job = scheduledJob
+		}
+		rv[i] = job
+	}
+	return rv, result, nil
+}
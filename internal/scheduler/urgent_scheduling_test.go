@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/armadaproject/armada/internal/common/armadacontext"
+	protoutil "github.com/armadaproject/armada/internal/common/proto"
+	"github.com/armadaproject/armada/internal/common/util"
+	"github.com/armadaproject/armada/internal/scheduler/database"
+	schedulermocks "github.com/armadaproject/armada/internal/scheduler/mocks"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+	"github.com/armadaproject/armada/pkg/armadaevents"
+)
+
+func TestScheduler_UrgentJobsLeasedInFastPath(t *testing.T) {
+	tests := map[string]struct {
+		isLeader bool
+		// If true, there's no node the urgent job fits on.
+		noCapacity bool
+		// Ids of jobs expected to be leased in the fast path.
+		expectedFastPathLeased []string
+	}{
+		"urgent job leased ahead of scheduling round": {
+			isLeader:               true,
+			expectedFastPathLeased: []string{"urgent"},
+		},
+		"urgent job left queued if there's no free capacity": {
+			isLeader:   true,
+			noCapacity: true,
+		},
+		"nothing leased if not leader": {
+			isLeader: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := armadacontext.WithTimeout(armadacontext.Background(), 5*time.Second)
+			defer cancel()
+			testClock := clock.NewFakeClock(testfixtures.BaseTime)
+
+			jobIds := map[string]string{"urgent": util.NewULID(), "normal": util.NewULID()}
+			jobRepo := &testJobRepository{
+				updatedJobs: []database.Job{
+					urgentSchedulingTestJob(jobIds["urgent"], testfixtures.PriorityClass2, 1),
+					urgentSchedulingTestJob(jobIds["normal"], testfixtures.PriorityClass0, 2),
+				},
+			}
+
+			executor := testfixtures.Test1Node32CoreExecutor("executor1")
+			if tc.noCapacity {
+				executor.Nodes[0].TotalResources = schedulerobjects.ResourceList{}
+				executor.Nodes[0].AllocatableByPriorityAndResource = schedulerobjects.NewAllocatableByPriorityAndResourceType(
+					testfixtures.TestPriorities,
+					schedulerobjects.ResourceList{},
+				)
+			}
+			ctrl := gomock.NewController(t)
+			mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+			mockExecutorRepo.EXPECT().GetExecutors(ctx).Return([]*schedulerobjects.Executor{executor}, nil).AnyTimes()
+			mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+			urgentSchedulingAlgo, err := NewFairSchedulingAlgo(testfixtures.TestSchedulingConfig(), 0, mockExecutorRepo, mockQueueRepo, nil)
+			require.NoError(t, err)
+			urgentSchedulingAlgo.clock = testClock
+
+			leaderController := NewStandaloneLeaderController()
+			if !tc.isLeader {
+				leaderController.token = InvalidLeaderToken()
+			}
+			// The regular scheduling round leases nothing, and errors if asked to lease a job that's already leased.
+			schedulingAlgo := &testSchedulingAlgo{}
+			publisher := &recordingPublisher{}
+			sched, err := NewScheduler(
+				testfixtures.NewJobDb(),
+				jobRepo,
+				&testExecutorRepository{},
+				schedulingAlgo,
+				leaderController,
+				publisher,
+				&testSubmitChecker{checkSuccess: true},
+				1*time.Second,
+				5*time.Second,
+				1*time.Hour,
+				maxNumberOfAttempts,
+				nodeIdLabel,
+				schedulerMetrics,
+				nil,
+			)
+			require.NoError(t, err)
+			sched.clock = testClock
+			sched.EnableUrgentScheduling(urgentSchedulingAlgo, []string{testfixtures.PriorityClass2})
+
+			_, err = sched.cycle(ctx, false, leaderController.GetToken(), true)
+			require.NoError(t, err)
+
+			// If anything was leased in the fast path, it was published on its own before the rest of the cycle.
+			var fastPathLeased []string
+			if len(publisher.publishedBatches) > 1 {
+				for _, sequence := range publisher.publishedBatches[0] {
+					for _, event := range sequence.Events {
+						if lease := event.GetJobRunLeased(); lease != nil {
+							jobId, err := armadaevents.UlidStringFromProtoUuid(lease.JobId)
+							require.NoError(t, err)
+							fastPathLeased = append(fastPathLeased, jobId)
+						}
+					}
+				}
+			}
+			expectedFastPathLeased := make([]string, len(tc.expectedFastPathLeased))
+			for i, name := range tc.expectedFastPathLeased {
+				expectedFastPathLeased[i] = jobIds[name]
+			}
+			assert.ElementsMatch(t, expectedFastPathLeased, fastPathLeased)
+
+			// Jobs leased in the fast path are leased in the jobDb; all other jobs remain queued.
+			txn := sched.jobDb.ReadTxn()
+			for name, jobId := range jobIds {
+				job := txn.GetById(jobId)
+				require.NotNil(t, job)
+				if util.ContainsString(tc.expectedFastPathLeased, name) {
+					assert.False(t, job.Queued())
+					require.NotNil(t, job.LatestRun())
+					assert.Equal(t, executor.Id, job.LatestRun().Executor())
+					assert.Equal(t, executor.Nodes[0].Name, job.LatestRun().NodeName())
+					assert.Equal(t, testClock.Now().UnixNano(), job.LatestRun().Created())
+				} else {
+					assert.True(t, job.Queued())
+					assert.False(t, job.HasRuns())
+				}
+			}
+		})
+	}
+}
+
+func urgentSchedulingTestJob(jobId string, priorityClassName string, serial int64) database.Job {
+	req := testfixtures.Test1Cpu4GiPodReqs("testQueue", util.ULID(), 0)
+	req.Annotations = nil
+	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
+		PriorityClassName: priorityClassName,
+		ObjectRequirements: []*schedulerobjects.ObjectRequirements{
+			{
+				Requirements: &schedulerobjects.ObjectRequirements_PodRequirements{
+					PodRequirements: req,
+				},
+			},
+		},
+		Version: 1,
+	}
+	return database.Job{
+		JobID:          jobId,
+		JobSet:         "testJobset",
+		Queue:          "testQueue",
+		Queued:         true,
+		QueuedVersion:  0,
+		SchedulingInfo: protoutil.MustMarshall(schedulingInfo),
+		Serial:         serial,
+	}
+}
+
+// recordingPublisher is a Publisher that records every batch of events published.
+type recordingPublisher struct {
+	publishedBatches [][]*armadaevents.EventSequence
+}
+
+func (p *recordingPublisher) PublishMessages(_ *armadacontext.Context, events []*armadaevents.EventSequence, shouldPublish func() bool) error {
+	if shouldPublish() {
+		p.publishedBatches = append(p.publishedBatches, events)
+	}
+	return nil
+}
+
+func (p *recordingPublisher) PublishMarkers(_ *armadacontext.Context, _ uuid.UUID) (uint32, error) {
+	return 100, nil
+}
@@ -0,0 +1,219 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+)
+
+// WaitTimeEstimator estimates how long queued jobs will wait before being scheduled.
+// Jobs are grouped by queue and by a bucket determined by their request for a particular resource.
+// For each such group, the estimate is the number of queued jobs ahead of a job divided by the rate
+// at which jobs in the group have been scheduled over the most recent cycles.
+//
+// Update is called once per cycle by the scheduler. Estimates may be read concurrently with updates;
+// each update computes an immutable snapshot which is then swapped for the previous one atomically.
+// Queued jobs are counted by the jobDb as they're written, such that updates don't iterate over them;
+// see CountQueuedJobs.
+type WaitTimeEstimator struct {
+	resourceName     v1.ResourceName
+	bucketBoundaries []resource.Quantity
+	bucketNames      []string
+	windowSize       int
+	// Number of jobs scheduled by each of the most recent cycles, oldest first.
+	cycles []waitTimeEstimatorCycle
+	// Snapshot computed by the most recent update.
+	snapshot atomic.Pointer[waitTimeEstimatorSnapshot]
+}
+
+type queueAndBucket struct {
+	queue  string
+	bucket string
+}
+
+type waitTimeEstimatorCycle struct {
+	time                         time.Time
+	numScheduledByQueueAndBucket map[queueAndBucket]int
+}
+
+type waitTimeEstimatorSnapshot struct {
+	// Jobs scheduled per second over the window.
+	throughputByQueueAndBucket map[queueAndBucket]float64
+	// Number of queued jobs by priority.
+	numQueuedByQueueAndBucketAndPriority map[queueAndBucket]map[uint32]int
+}
+
+// WaitTimeEstimate is the estimated time until the last job in a queue and bucket is scheduled.
+type WaitTimeEstimate struct {
+	Bucket string
+	// If false, there's been no recent throughput and the wait time is unknown.
+	Known    bool
+	WaitTime time.Duration
+}
+
+func (e WaitTimeEstimate) String() string {
+	if !e.Known {
+		return "unknown"
+	}
+	return e.WaitTime.String()
+}
+
+func NewWaitTimeEstimator(config schedulerconfig.WaitTimeEstimationConfig) *WaitTimeEstimator {
+	bucketBoundaries := slices.Clone(config.BucketBoundaries)
+	slices.SortFunc(bucketBoundaries, func(a, b resource.Quantity) bool { return a.Cmp(b) == -1 })
+	bucketNames := make([]string, 0, len(bucketBoundaries)+1)
+	for _, q := range bucketBoundaries {
+		bucketNames = append(bucketNames, fmt.Sprintf("%s<=%s", config.ResourceName, q.String()))
+	}
+	if len(bucketBoundaries) > 0 {
+		bucketNames = append(bucketNames, fmt.Sprintf("%s>%s", config.ResourceName, bucketBoundaries[len(bucketBoundaries)-1].String()))
+	} else {
+		bucketNames = append(bucketNames, "all")
+	}
+	windowSize := config.WindowSize
+	if windowSize < 2 {
+		// At least two cycles are needed to compute throughput.
+		windowSize = 2
+	}
+	rv := &WaitTimeEstimator{
+		resourceName:     v1.ResourceName(config.ResourceName),
+		bucketBoundaries: bucketBoundaries,
+		bucketNames:      bucketNames,
+		windowSize:       windowSize,
+	}
+	rv.snapshot.Store(&waitTimeEstimatorSnapshot{})
+	return rv
+}
+
+// Bucket returns the name of the bucket jobs with the given requests are assigned to.
+func (e *WaitTimeEstimator) Bucket(requests v1.ResourceList) string {
+	q := requests[e.resourceName]
+	for i, boundary := range e.bucketBoundaries {
+		if q.Cmp(boundary) <= 0 {
+			return e.bucketNames[i]
+		}
+	}
+	return e.bucketNames[len(e.bucketNames)-1]
+}
+
+// CountQueuedJobs causes jobDb to count queued jobs by the buckets of e, as needed by Update.
+// Must be called before any jobs are upserted into jobDb.
+func (e *WaitTimeEstimator) CountQueuedJobs(jobDb *jobdb.JobDb) {
+	jobDb.EnableQueuedJobCounts(func(job *jobdb.Job) string {
+		return e.Bucket(job.GetResourceRequirements().Requests)
+	})
+}
+
+// Update records the jobs scheduled in this cycle and the jobs currently queued in txn
+// and refreshes estimates accordingly. The jobDb of txn must count queued jobs by the buckets of e; see CountQueuedJobs.
+func (e *WaitTimeEstimator) Update(now time.Time, txn *jobdb.Txn, result SchedulerResult) {
+	numScheduledByQueueAndBucket := make(map[queueAndBucket]int)
+	for _, jctx := range result.ScheduledJobs {
+		key := queueAndBucket{
+			queue:  jctx.Job.GetQueue(),
+			bucket: e.Bucket(jctx.Job.GetResourceRequirements().Requests),
+		}
+		numScheduledByQueueAndBucket[key]++
+	}
+	e.cycles = append(e.cycles, waitTimeEstimatorCycle{time: now, numScheduledByQueueAndBucket: numScheduledByQueueAndBucket})
+	if len(e.cycles) > e.windowSize {
+		e.cycles = e.cycles[len(e.cycles)-e.windowSize:]
+	}
+
+	// Jobs scheduled by the oldest cycle in the window were scheduled before the window started and are excluded.
+	throughputByQueueAndBucket := make(map[queueAndBucket]float64)
+	if len(e.cycles) > 1 {
+		window := e.cycles[len(e.cycles)-1].time.Sub(e.cycles[0].time).Seconds()
+		if window > 0 {
+			for _, cycle := range e.cycles[1:] {
+				for key, n := range cycle.numScheduledByQueueAndBucket {
+					throughputByQueueAndBucket[key] += float64(n) / window
+				}
+			}
+		}
+	}
+
+	numQueuedByQueueAndBucketAndPriority := make(map[queueAndBucket]map[uint32]int)
+	for countKey, n := range txn.QueuedJobCounts() {
+		key := queueAndBucket{queue: countKey.Queue, bucket: countKey.Bucket}
+		numQueuedByPriority := numQueuedByQueueAndBucketAndPriority[key]
+		if numQueuedByPriority == nil {
+			numQueuedByPriority = make(map[uint32]int)
+			numQueuedByQueueAndBucketAndPriority[key] = numQueuedByPriority
+		}
+		numQueuedByPriority[countKey.Priority] = n
+	}
+
+	e.snapshot.Store(&waitTimeEstimatorSnapshot{
+		throughputByQueueAndBucket:           throughputByQueueAndBucket,
+		numQueuedByQueueAndBucketAndPriority: numQueuedByQueueAndBucketAndPriority,
+	})
+}
+
+// Estimate returns the estimated wait time of a job with the given priority submitted to queue and bucket,
+// i.e., the time until all queued jobs of equal or higher priority (i.e., equal or lower priority value) are scheduled.
+// The second return value is false if the wait time is unknown because the queue and bucket had no recent throughput.
+func (e *WaitTimeEstimator) Estimate(queue, bucket string, priority uint32) (time.Duration, bool) {
+	return e.snapshot.Load().estimate(queueAndBucket{queue: queue, bucket: bucket}, priority)
+}
+
+func (snapshot *waitTimeEstimatorSnapshot) estimate(key queueAndBucket, priority uint32) (time.Duration, bool) {
+	throughput := snapshot.throughputByQueueAndBucket[key]
+	if throughput <= 0 {
+		return 0, false
+	}
+	numAhead := 0
+	for p, n := range snapshot.numQueuedByQueueAndBucketAndPriority[key] {
+		if p <= priority {
+			numAhead += n
+		}
+	}
+	return time.Duration(float64(numAhead) / throughput * float64(time.Second)), true
+}
+
+// EstimatesForQueue returns, for each bucket with queued jobs or recent throughput in the given queue,
+// the estimated time until all jobs currently queued in that bucket are scheduled.
+func (e *WaitTimeEstimator) EstimatesForQueue(queue string) []WaitTimeEstimate {
+	return e.estimatesForQueue(e.snapshot.Load(), queue)
+}
+
+// Estimates returns the estimates of EstimatesForQueue for all queues.
+func (e *WaitTimeEstimator) Estimates() map[string][]WaitTimeEstimate {
+	snapshot := e.snapshot.Load()
+	queues := make(map[string]bool)
+	for key := range snapshot.throughputByQueueAndBucket {
+		queues[key.queue] = true
+	}
+	for key := range snapshot.numQueuedByQueueAndBucketAndPriority {
+		queues[key.queue] = true
+	}
+	rv := make(map[string][]WaitTimeEstimate, len(queues))
+	for _, queue := range maps.Keys(queues) {
+		rv[queue] = e.estimatesForQueue(snapshot, queue)
+	}
+	return rv
+}
+
+func (e *WaitTimeEstimator) estimatesForQueue(snapshot *waitTimeEstimatorSnapshot, queue string) []WaitTimeEstimate {
+	rv := make([]WaitTimeEstimate, 0)
+	for _, bucket := range e.bucketNames {
+		key := queueAndBucket{queue: queue, bucket: bucket}
+		_, hasQueued := snapshot.numQueuedByQueueAndBucketAndPriority[key]
+		_, hasThroughput := snapshot.throughputByQueueAndBucket[key]
+		if !hasQueued && !hasThroughput {
+			continue
+		}
+		waitTime, known := snapshot.estimate(key, math.MaxUint32)
+		rv = append(rv, WaitTimeEstimate{Bucket: bucket, Known: known, WaitTime: waitTime})
+	}
+	return rv
+}
@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestWaitTimeEstimator_Bucket(t *testing.T) {
+	estimator := NewWaitTimeEstimator(testWaitTimeEstimationConfig())
+	assert.Equal(t, "cpu<=1", estimator.Bucket(testfixtures.Test1Cpu4GiJob("A", testfixtures.PriorityClass0).GetResourceRequirements().Requests))
+	assert.Equal(t, "cpu<=16", estimator.Bucket(testfixtures.Test16Cpu128GiJob("A", testfixtures.PriorityClass0).GetResourceRequirements().Requests))
+	assert.Equal(t, "cpu>16", estimator.Bucket(testfixtures.Test32Cpu256GiJob("A", testfixtures.PriorityClass0).GetResourceRequirements().Requests))
+}
+
+func TestWaitTimeEstimator_SteadyState(t *testing.T) {
+	const (
+		numJobsPerCycle = 5
+		numCycles       = 10
+	)
+	cyclePeriod := 10 * time.Second
+	estimator := NewWaitTimeEstimator(testWaitTimeEstimationConfig())
+
+	// Queue A has small jobs, some of which are of higher priority (i.e., lower priority value), and large jobs.
+	// Only small jobs are ever scheduled.
+	highPriorityJobs := testfixtures.WithPriorityJobs(0, testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 20))
+	lowPriorityJobs := testfixtures.WithPriorityJobs(1, testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 200))
+	largeJobs := testfixtures.N32Cpu256GiJobs("A", testfixtures.PriorityClass0, 10)
+	smallJobs := append(highPriorityJobs, lowPriorityJobs...)
+	jobDb := testfixtures.NewJobDb()
+	estimator.CountQueuedJobs(jobDb)
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(queuedJobsForWaitTimeTest(append(smallJobs, largeJobs...))))
+	txn.Commit()
+
+	// Before any jobs have been scheduled, all estimates are unknown.
+	now := time.Now()
+	estimator.Update(now, jobDb.ReadTxn(), SchedulerResult{})
+	assert.Equal(
+		t,
+		[]WaitTimeEstimate{{Bucket: "cpu<=1"}, {Bucket: "cpu>16"}},
+		estimator.EstimatesForQueue("A"),
+	)
+
+	// Each cycle, schedule a fixed number of small jobs in priority order.
+	for i := 0; i < numCycles; i++ {
+		now = now.Add(cyclePeriod)
+		scheduledJobs := smallJobs[:numJobsPerCycle]
+		smallJobs = smallJobs[numJobsPerCycle:]
+		txn := jobDb.WriteTxn()
+		require.NoError(t, txn.BatchDelete(util.Map(scheduledJobs, func(job *jobdb.Job) string { return job.Id() })))
+		txn.Commit()
+		estimator.Update(now, jobDb.ReadTxn(), SchedulerResult{
+			ScheduledJobs: util.Map(scheduledJobs, func(job *jobdb.Job) *schedulercontext.JobSchedulingContext {
+				return &schedulercontext.JobSchedulingContext{JobId: job.Id(), Job: job}
+			}),
+		})
+	}
+
+	// In steady state, throughput is numJobsPerCycle per cyclePeriod, i.e., 0.5 jobs per second.
+	// 220 - 5*10 = 170 small jobs remain queued, all of which are low-priority.
+	assert.Equal(
+		t,
+		[]WaitTimeEstimate{
+			{Bucket: "cpu<=1", Known: true, WaitTime: 340 * time.Second},
+			{Bucket: "cpu>16"},
+		},
+		estimator.EstimatesForQueue("A"),
+	)
+	waitTime, ok := estimator.Estimate("A", "cpu<=1", 0)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), waitTime)
+	waitTime, ok = estimator.Estimate("A", "cpu<=1", 1)
+	assert.True(t, ok)
+	assert.Equal(t, 340*time.Second, waitTime)
+
+	// Large jobs and other queues have no throughput.
+	_, ok = estimator.Estimate("A", "cpu>16", 1)
+	assert.False(t, ok)
+	_, ok = estimator.Estimate("B", "cpu<=1", 1)
+	assert.False(t, ok)
+	assert.Empty(t, estimator.EstimatesForQueue("B"))
+
+	// Once scheduling stops, throughput decays to zero as cycles leave the window.
+	for i := 0; i < testWaitTimeEstimationConfig().WindowSize; i++ {
+		now = now.Add(cyclePeriod)
+		estimator.Update(now, jobDb.ReadTxn(), SchedulerResult{})
+	}
+	_, ok = estimator.Estimate("A", "cpu<=1", 1)
+	assert.False(t, ok)
+}
+
+func TestWaitTimeEstimator_QueueReport(t *testing.T) {
+	estimator := NewWaitTimeEstimator(testWaitTimeEstimationConfig())
+	jobDb := testfixtures.NewJobDb()
+	estimator.CountQueuedJobs(jobDb)
+	txn := jobDb.WriteTxn()
+	require.NoError(t, txn.Upsert(queuedJobsForWaitTimeTest(testfixtures.N1Cpu4GiJobs("A", testfixtures.PriorityClass0, 10))))
+	txn.Commit()
+	estimator.Update(time.Now(), jobDb.ReadTxn(), SchedulerResult{})
+
+	repo, err := NewSchedulingContextRepository(10)
+	require.NoError(t, err)
+	repo.EnableWaitTimeEstimates(estimator)
+	assert.Contains(t, repo.getQueueReportString("A", 0), "Estimated wait times for queue A:\n")
+	assert.Contains(t, repo.getQueueReportString("A", 0), "cpu<=1: unknown\n")
+	assert.Contains(t, repo.getQueueReportString("B", 0), "Estimated wait times for queue B: unknown\n")
+}
+
+func testWaitTimeEstimationConfig() schedulerconfig.WaitTimeEstimationConfig {
+	return schedulerconfig.WaitTimeEstimationConfig{
+		Enabled:          true,
+		ResourceName:     "cpu",
+		BucketBoundaries: []resource.Quantity{resource.MustParse("16"), resource.MustParse("1")},
+		WindowSize:       5,
+	}
+}
+
+func queuedJobsForWaitTimeTest(jobs []*jobdb.Job) []*jobdb.Job {
+	return util.Map(jobs, func(job *jobdb.Job) *jobdb.Job { return job.WithQueued(true) })
+}
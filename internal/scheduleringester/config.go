@@ -16,6 +16,15 @@ type Configuration struct {
 	Pulsar configuration.PulsarConfig
 	// Map of allowed priority classes by name
 	PriorityClasses map[string]types.PriorityClass
+	// If true, the pod overhead declared in a job's pod spec is added to the resource requests recorded for that job,
+	// such that it's accounted for when scheduling the job and when computing the fair share of its queue.
+	IncludePodOverhead bool
+	// If true, messages published by a scheduler leader older than the newest one seen for the same job set are discarded,
+	// such that messages an old leader had in flight when leadership changed aren't applied after those of the new leader.
+	RejectStaleLeaderEpochs bool
+	// Maximum number of job sets for which the newest leader epoch seen is remembered if RejectStaleLeaderEpochs is true.
+	// Once exceeded, the epochs of the least recently seen job sets are forgotten.
+	MaxLeaderEpochJobSets int
 	// Pulsar subscription name
 	SubscriptionName string
 	// Number of messages that will be batched together before being inserted into the database
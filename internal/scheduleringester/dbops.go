@@ -1,6 +1,8 @@
 package scheduleringester
 
 import (
+	"time"
+
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/google/uuid"
 	"golang.org/x/exp/maps"
@@ -122,12 +124,16 @@ type (
 	MarkJobsCancelled          map[string]bool
 	MarkJobsSucceeded          map[string]bool
 	MarkJobsFailed             map[string]bool
+	MarkJobsAcknowledged       map[string]bool
 	UpdateJobPriorities        map[string]int64
 	UpdateJobSchedulingInfo    map[string]*JobSchedulingInfoUpdate
 	UpdateJobQueuedState       map[string]*JobQueuedStateUpdate
 	MarkRunsSucceeded          map[uuid.UUID]bool
 	MarkRunsFailed             map[uuid.UUID]*JobRunFailed
-	MarkRunsRunning            map[uuid.UUID]bool
+	MarkRunsPending            map[uuid.UUID]time.Time
+	MarkRunsRunning            map[uuid.UUID]time.Time
+	UpdateRunNodes             map[uuid.UUID]string
+	MarkRunsCancelled          map[uuid.UUID]bool
 	InsertJobRunErrors         map[uuid.UUID]*schedulerdb.JobRunError
 	InsertPartitionMarker      struct {
 		markers []*schedulerdb.Marker
@@ -216,6 +222,10 @@ func (a MarkJobsFailed) Merge(b DbOperation) bool {
 	return mergeInMap(a, b)
 }
 
+func (a MarkJobsAcknowledged) Merge(b DbOperation) bool {
+	return mergeInMap(a, b)
+}
+
 func (a UpdateJobPriorities) Merge(b DbOperation) bool {
 	return mergeInMap(a, b)
 }
@@ -228,10 +238,22 @@ func (a MarkRunsFailed) Merge(b DbOperation) bool {
 	return mergeInMap(a, b)
 }
 
+func (a MarkRunsPending) Merge(b DbOperation) bool {
+	return mergeInMap(a, b)
+}
+
 func (a MarkRunsRunning) Merge(b DbOperation) bool {
 	return mergeInMap(a, b)
 }
 
+func (a UpdateRunNodes) Merge(b DbOperation) bool {
+	return mergeInMap(a, b)
+}
+
+func (a MarkRunsCancelled) Merge(b DbOperation) bool {
+	return mergeInMap(a, b)
+}
+
 func (a InsertJobRunErrors) Merge(b DbOperation) bool {
 	return mergeInMap(a, b)
 }
@@ -314,10 +336,15 @@ func (a MarkJobsFailed) CanBeAppliedBefore(b DbOperation) bool {
 	return !definesJob(a, b)
 }
 
-func (a MarkJobsCancelled) CanBeAppliedBefore(b DbOperation) bool {
+func (a MarkJobsAcknowledged) CanBeAppliedBefore(b DbOperation) bool {
 	return !definesJob(a, b)
 }
 
+func (a MarkJobsCancelled) CanBeAppliedBefore(b DbOperation) bool {
+	// Runs of cancelled jobs are marked cancelled too; hence, runs of these jobs must be inserted first.
+	return !definesJob(a, b) && !definesRunForJob(a, b)
+}
+
 func (a UpdateJobSchedulingInfo) CanBeAppliedBefore(b DbOperation) bool {
 	return !definesJob(a, b)
 }
@@ -339,10 +366,22 @@ func (a MarkRunsFailed) CanBeAppliedBefore(b DbOperation) bool {
 	return !definesRun(a, b)
 }
 
+func (a MarkRunsPending) CanBeAppliedBefore(b DbOperation) bool {
+	return !definesRun(a, b)
+}
+
 func (a MarkRunsRunning) CanBeAppliedBefore(b DbOperation) bool {
 	return !definesRun(a, b)
 }
 
+func (a UpdateRunNodes) CanBeAppliedBefore(b DbOperation) bool {
+	return !definesRun(a, b)
+}
+
+func (a MarkRunsCancelled) CanBeAppliedBefore(b DbOperation) bool {
+	return !definesRun(a, b)
+}
+
 func (a *InsertPartitionMarker) CanBeAppliedBefore(b DbOperation) bool {
 	// Partition markers can never be brought forward
 	return false
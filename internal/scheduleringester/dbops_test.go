@@ -3,6 +3,7 @@ package scheduleringester
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -169,6 +170,13 @@ func TestDbOperationOptimisation(t *testing.T) {
 			MarkJobsFailed{jobIds[1]: true},                           // 2
 			InsertJobs{jobIds[2]: &schedulerdb.Job{JobID: jobIds[2]}}, // 2
 		}},
+		"MarkJobsAcknowledged": {N: 2, Ops: []DbOperation{
+			InsertJobs{jobIds[0]: &schedulerdb.Job{JobID: jobIds[0]}}, // 1
+			MarkJobsAcknowledged{jobIds[0]: true},                     // 2
+			InsertJobs{jobIds[1]: &schedulerdb.Job{JobID: jobIds[1]}}, // 2
+			MarkJobsAcknowledged{jobIds[1]: true},                     // 2
+			InsertJobs{jobIds[2]: &schedulerdb.Job{JobID: jobIds[2]}}, // 2
+		}},
 		"MarkJobsCancelled": {N: 2, Ops: []DbOperation{
 			InsertJobs{jobIds[0]: &schedulerdb.Job{JobID: jobIds[0]}}, // 1
 			MarkJobsCancelled{jobIds[0]: true},                        // 2
@@ -176,6 +184,14 @@ func TestDbOperationOptimisation(t *testing.T) {
 			MarkJobsCancelled{jobIds[1]: true},                        // 2
 			InsertJobs{jobIds[2]: &schedulerdb.Job{JobID: jobIds[2]}}, // 2
 		}},
+		"MarkJobsCancelled, InsertRuns": {N: 3, Ops: []DbOperation{
+			InsertJobs{jobIds[0]: &schedulerdb.Job{JobID: jobIds[0]}},                                                                // 1
+			InsertJobs{jobIds[1]: &schedulerdb.Job{JobID: jobIds[1]}},                                                                // 1
+			InsertRuns{runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0]}}}, // 2
+			MarkJobsCancelled{jobIds[0]: true},                                                                                       // 3
+			InsertRuns{runIds[1]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[1], RunID: runIds[1]}}}, // 2
+			MarkJobsCancelled{jobIds[1]: true},                                                                                       // 3
+		}},
 		"MarkRunsSucceeded": {N: 3, Ops: []DbOperation{
 			InsertJobs{jobIds[0]: &schedulerdb.Job{JobID: jobIds[0]}},                                                                // 1
 			InsertRuns{runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0]}}}, // 2
@@ -194,13 +210,40 @@ func TestDbOperationOptimisation(t *testing.T) {
 			MarkRunsFailed{runIds[1]: &JobRunFailed{true, true}},                                                                     // 3
 			InsertJobs{jobIds[2]: &schedulerdb.Job{JobID: jobIds[2]}},                                                                // 3
 		}},
+		"MarkRunsPending": {N: 3, Ops: []DbOperation{
+			InsertJobs{jobIds[0]: &schedulerdb.Job{JobID: jobIds[0]}},                                                                // 1
+			InsertRuns{runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0]}}}, // 2
+			MarkRunsPending{runIds[0]: time.Time{}},                   // 3
+			InsertJobs{jobIds[1]: &schedulerdb.Job{JobID: jobIds[1]}}, // 3
+			InsertRuns{runIds[1]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[1]}}}, // 3
+			MarkRunsPending{runIds[1]: time.Time{}},                   // 3
+			InsertJobs{jobIds[2]: &schedulerdb.Job{JobID: jobIds[2]}}, // 3
+		}},
 		"MarkRunsRunning": {N: 3, Ops: []DbOperation{
 			InsertJobs{jobIds[0]: &schedulerdb.Job{JobID: jobIds[0]}},                                                                // 1
 			InsertRuns{runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0]}}}, // 2
-			MarkRunsRunning{runIds[0]: true},                          // 3
+			MarkRunsRunning{runIds[0]: time.Time{}},                   // 3
+			InsertJobs{jobIds[1]: &schedulerdb.Job{JobID: jobIds[1]}}, // 3
+			InsertRuns{runIds[1]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[1]}}}, // 3
+			MarkRunsRunning{runIds[1]: time.Time{}},                   // 3
+			InsertJobs{jobIds[2]: &schedulerdb.Job{JobID: jobIds[2]}}, // 3
+		}},
+		"UpdateRunNodes": {N: 3, Ops: []DbOperation{
+			InsertJobs{jobIds[0]: &schedulerdb.Job{JobID: jobIds[0]}},                                                                // 1
+			InsertRuns{runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0]}}}, // 2
+			UpdateRunNodes{runIds[0]: "node"},                         // 3
+			InsertJobs{jobIds[1]: &schedulerdb.Job{JobID: jobIds[1]}}, // 3
+			InsertRuns{runIds[1]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[1]}}}, // 3
+			UpdateRunNodes{runIds[1]: "node"},                         // 3
+			InsertJobs{jobIds[2]: &schedulerdb.Job{JobID: jobIds[2]}}, // 3
+		}},
+		"MarkRunsCancelled": {N: 3, Ops: []DbOperation{
+			InsertJobs{jobIds[0]: &schedulerdb.Job{JobID: jobIds[0]}},                                                                // 1
+			InsertRuns{runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0]}}}, // 2
+			MarkRunsCancelled{runIds[0]: true},                        // 3
 			InsertJobs{jobIds[1]: &schedulerdb.Job{JobID: jobIds[1]}}, // 3
 			InsertRuns{runIds[1]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[1]}}}, // 3
-			MarkRunsRunning{runIds[1]: true},                          // 3
+			MarkRunsCancelled{runIds[1]: true},                        // 3
 			InsertJobs{jobIds[2]: &schedulerdb.Job{JobID: jobIds[2]}}, // 3
 		}},
 		"InsertPartitionMarker": {N: 2, Ops: []DbOperation{
@@ -390,6 +433,19 @@ func (db *mockDb) apply(op DbOperation) error {
 				return errors.Errorf("job %s not in db", jobId)
 			}
 		}
+	case MarkJobsCancelled:
+		for jobId := range o {
+			if job, ok := db.Jobs[jobId]; ok {
+				job.Cancelled = true
+			} else {
+				return errors.Errorf("job %s not in db", jobId)
+			}
+			for _, run := range db.Runs {
+				if run.JobID == jobId {
+					run.Cancelled = true
+				}
+			}
+		}
 	case MarkJobsSucceeded:
 		for jobId := range o {
 			if job, ok := db.Jobs[jobId]; ok {
@@ -406,6 +462,14 @@ func (db *mockDb) apply(op DbOperation) error {
 				return errors.Errorf("job %s not in db", jobId)
 			}
 		}
+	case MarkJobsAcknowledged:
+		for jobId := range o {
+			if job, ok := db.Jobs[jobId]; ok {
+				job.Acknowledged = true
+			} else {
+				return errors.Errorf("job %s not in db", jobId)
+			}
+		}
 	case UpdateJobPriorities:
 		for jobId, priority := range o {
 			if job, ok := db.Jobs[jobId]; ok {
@@ -430,10 +494,37 @@ func (db *mockDb) apply(op DbOperation) error {
 				return errors.Errorf("run %s not in db", runId)
 			}
 		}
+	case MarkRunsPending:
+		for runId, pendingTime := range o {
+			pendingTime := pendingTime
+			if run, ok := db.Runs[runId]; ok {
+				run.PendingTimestamp = &pendingTime
+			} else {
+				return errors.Errorf("run %s not in db", runId)
+			}
+		}
 	case MarkRunsRunning:
-		for runId := range o {
+		for runId, runningTime := range o {
+			runningTime := runningTime
 			if run, ok := db.Runs[runId]; ok {
 				run.Running = true
+				run.RunningTimestamp = &runningTime
+			} else {
+				return errors.Errorf("run %s not in db", runId)
+			}
+		}
+	case UpdateRunNodes:
+		for runId, nodeName := range o {
+			if run, ok := db.Runs[runId]; ok {
+				run.Node = nodeName
+			} else {
+				return errors.Errorf("run %s not in db", runId)
+			}
+		}
+	case MarkRunsCancelled:
+		for runId := range o {
+			if run, ok := db.Runs[runId]; ok {
+				run.Cancelled = true
 			} else {
 				return errors.Errorf("run %s not in db", runId)
 			}
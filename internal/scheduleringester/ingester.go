@@ -35,7 +35,7 @@ func Run(config Configuration) {
 	if err != nil {
 		panic(errors.WithMessage(err, "Error creating  compressor"))
 	}
-	converter := NewInstructionConverter(svcMetrics, config.PriorityClasses, compressor)
+	converter := NewInstructionConverter(svcMetrics, config.PriorityClasses, compressor, config.IncludePodOverhead)
 
 	// Expose profiling endpoints if enabled.
 	pprofServer := profiling.SetupPprofHttpServer(config.PprofPort)
@@ -46,13 +46,23 @@ func Run(config Configuration) {
 		}
 	}()
 
+	msgFilter := schedulers.ForPulsarScheduler
+	if config.RejectStaleLeaderEpochs {
+		leaderEpochFilter, err := schedulers.NewLeaderEpochFilter(config.MaxLeaderEpochJobSets)
+		if err != nil {
+			panic(errors.WithMessage(err, "Error creating leader epoch filter"))
+		}
+		msgFilter = func(msg pulsar.Message) bool {
+			return schedulers.ForPulsarScheduler(msg) && leaderEpochFilter.Filter(msg)
+		}
+	}
 	ingester := ingest.NewFilteredMsgIngestionPipeline(
 		config.Pulsar,
 		config.SubscriptionName,
 		config.BatchSize,
 		config.BatchDuration,
 		pulsar.Failover,
-		schedulers.ForPulsarScheduler,
+		msgFilter,
 		converter,
 		schedulerDb,
 		config.Metrics,
@@ -18,6 +18,7 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/adapters"
 	schedulerdb "github.com/armadaproject/armada/internal/scheduler/database"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/pkg/api"
 	"github.com/armadaproject/armada/pkg/armadaevents"
 )
 
@@ -32,17 +33,21 @@ type InstructionConverter struct {
 	metrics         *metrics.Metrics
 	priorityClasses map[string]types.PriorityClass
 	compressor      compress.Compressor
+	// If true, pod overhead is included in the resource requests recorded for each job.
+	includePodOverhead bool
 }
 
 func NewInstructionConverter(
 	metrics *metrics.Metrics,
 	priorityClasses map[string]types.PriorityClass,
 	compressor compress.Compressor,
+	includePodOverhead bool,
 ) ingest.InstructionConverter[*DbOperationsWithMessageIds] {
 	return &InstructionConverter{
-		metrics:         metrics,
-		priorityClasses: priorityClasses,
-		compressor:      compressor,
+		metrics:            metrics,
+		priorityClasses:    priorityClasses,
+		compressor:         compressor,
+		includePodOverhead: includePodOverhead,
 	}
 }
 
@@ -80,7 +85,9 @@ func (c *InstructionConverter) dbOperationsFromEventSequence(es *armadaevents.Ev
 		case *armadaevents.EventSequence_Event_JobRunLeased:
 			operationsFromEvent, err = c.handleJobRunLeased(event.GetJobRunLeased(), meta)
 		case *armadaevents.EventSequence_Event_JobRunRunning:
-			operationsFromEvent, err = c.handleJobRunRunning(event.GetJobRunRunning())
+			operationsFromEvent, err = c.handleJobRunRunning(event.GetJobRunRunning(), eventTime)
+		case *armadaevents.EventSequence_Event_JobRunAssigned:
+			operationsFromEvent, err = c.handleJobRunAssigned(event.GetJobRunAssigned(), eventTime)
 		case *armadaevents.EventSequence_Event_JobRunSucceeded:
 			operationsFromEvent, err = c.handleJobRunSucceeded(event.GetJobRunSucceeded())
 		case *armadaevents.EventSequence_Event_JobRunErrors:
@@ -99,8 +106,12 @@ func (c *InstructionConverter) dbOperationsFromEventSequence(es *armadaevents.Ev
 			operationsFromEvent, err = c.handleCancelJobSet(event.GetCancelJobSet(), meta)
 		case *armadaevents.EventSequence_Event_CancelledJob:
 			operationsFromEvent, err = c.handleCancelledJob(event.GetCancelledJob())
+		case *armadaevents.EventSequence_Event_JobRunCancelled:
+			operationsFromEvent, err = c.handleJobRunCancelled(event.GetJobRunCancelled())
 		case *armadaevents.EventSequence_Event_JobRequeued:
 			operationsFromEvent, err = c.handleJobRequeued(event.GetJobRequeued())
+		case *armadaevents.EventSequence_Event_JobValidated:
+			operationsFromEvent, err = c.handleJobValidated(event.GetJobValidated())
 		case *armadaevents.EventSequence_Event_PartitionMarker:
 			operationsFromEvent, err = c.handlePartitionMarker(event.GetPartitionMarker(), *event.Created)
 		case *armadaevents.EventSequence_Event_ReprioritisedJob,
@@ -108,7 +119,7 @@ func (c *InstructionConverter) dbOperationsFromEventSequence(es *armadaevents.Ev
 			*armadaevents.EventSequence_Event_ResourceUtilisation,
 			*armadaevents.EventSequence_Event_StandaloneIngressInfo,
 			*armadaevents.EventSequence_Event_JobRunPreempted,
-			*armadaevents.EventSequence_Event_JobRunAssigned:
+			*armadaevents.EventSequence_Event_JobRetriesExhausted:
 			// These events can all be safely ignored
 			log.Debugf("Ignoring event type %T", event)
 		default:
@@ -227,9 +238,38 @@ func (c *InstructionConverter) handleJobRequeued(jobRequeued *armadaevents.JobRe
 	}, nil
 }
 
-func (c *InstructionConverter) handleJobRunRunning(jobRunRunning *armadaevents.JobRunRunning) ([]DbOperation, error) {
+// handleJobRunAssigned records the time at which the run became pending, i.e., was assigned to a node,
+// as reported by the executor according to its clock.
+func (c *InstructionConverter) handleJobRunAssigned(jobRunAssigned *armadaevents.JobRunAssigned, eventTime time.Time) ([]DbOperation, error) {
+	runId := armadaevents.UuidFromProtoUuid(jobRunAssigned.GetRunId())
+	ops := []DbOperation{MarkRunsPending{runId: eventTime}}
+	if nodeName := nodeNameFromResourceInfos(jobRunAssigned.GetResourceInfos()); nodeName != "" {
+		ops = append(ops, UpdateRunNodes{runId: nodeName})
+	}
+	return ops, nil
+}
+
+// handleJobRunRunning marks the run running and records the time at which it started running,
+// as reported by the executor according to its clock.
+func (c *InstructionConverter) handleJobRunRunning(jobRunRunning *armadaevents.JobRunRunning, eventTime time.Time) ([]DbOperation, error) {
 	runId := armadaevents.UuidFromProtoUuid(jobRunRunning.GetRunId())
-	return []DbOperation{MarkRunsRunning{runId: true}}, nil
+	ops := []DbOperation{MarkRunsRunning{runId: eventTime}}
+	if nodeName := nodeNameFromResourceInfos(jobRunRunning.GetResourceInfos()); nodeName != "" {
+		ops = append(ops, UpdateRunNodes{runId: nodeName})
+	}
+	return ops, nil
+}
+
+// nodeNameFromResourceInfos returns the name of the node the executor reported the pod of a run on,
+// or the empty string if it didn't report one. The node may differ from that the run was leased to,
+// e.g., if the executor rescheduled the pod onto another node after a node failure without returning the lease.
+func nodeNameFromResourceInfos(resourceInfos []*armadaevents.KubernetesResourceInfo) string {
+	for _, resourceInfo := range resourceInfos {
+		if nodeName := resourceInfo.GetPodInfo().GetNodeName(); nodeName != "" {
+			return nodeName
+		}
+	}
+	return ""
 }
 
 func (c *InstructionConverter) handleJobRunSucceeded(jobRunSucceeded *armadaevents.JobRunSucceeded) ([]DbOperation, error) {
@@ -281,6 +321,18 @@ func (c *InstructionConverter) handleJobSucceeded(jobSucceeded *armadaevents.Job
 	}}, nil
 }
 
+// handleJobValidated records that a JobValidated event was published for the job,
+// such that the scheduler doesn't publish another one after a failover.
+func (c *InstructionConverter) handleJobValidated(jobValidated *armadaevents.JobValidated) ([]DbOperation, error) {
+	jobId, err := armadaevents.UlidStringFromProtoUuid(jobValidated.GetJobId())
+	if err != nil {
+		return nil, err
+	}
+	return []DbOperation{MarkJobsAcknowledged{
+		jobId: true,
+	}}, nil
+}
+
 func (c *InstructionConverter) handleJobErrors(jobErrors *armadaevents.JobErrors) ([]DbOperation, error) {
 	jobId, err := armadaevents.UlidStringFromProtoUuid(jobErrors.GetJobId())
 	if err != nil {
@@ -348,6 +400,11 @@ func (c *InstructionConverter) handleCancelledJob(cancelledJob *armadaevents.Can
 	}}, nil
 }
 
+func (c *InstructionConverter) handleJobRunCancelled(jobRunCancelled *armadaevents.JobRunCancelled) ([]DbOperation, error) {
+	runId := armadaevents.UuidFromProtoUuid(jobRunCancelled.GetRunId())
+	return []DbOperation{MarkRunsCancelled{runId: true}}, nil
+}
+
 func (c *InstructionConverter) handlePartitionMarker(pm *armadaevents.PartitionMarker, created time.Time) ([]DbOperation, error) {
 	return []DbOperation{&InsertPartitionMarker{
 		markers: []*schedulerdb.Marker{
@@ -362,11 +419,19 @@ func (c *InstructionConverter) handlePartitionMarker(pm *armadaevents.PartitionM
 
 // schedulingInfoFromSubmitJob returns a minimal representation of a job containing only the info needed by the scheduler.
 func (c *InstructionConverter) schedulingInfoFromSubmitJob(submitJob *armadaevents.SubmitJob, submitTime time.Time) (*schedulerobjects.JobSchedulingInfo, error) {
-	return SchedulingInfoFromSubmitJob(submitJob, submitTime, c.priorityClasses)
+	return SchedulingInfoFromSubmitJob(submitJob, submitTime, c.priorityClasses, c.includePodOverhead)
 }
 
 // SchedulingInfoFromSubmitJob returns a minimal representation of a job containing only the info needed by the scheduler.
-func SchedulingInfoFromSubmitJob(submitJob *armadaevents.SubmitJob, submitTime time.Time, priorityClasses map[string]types.PriorityClass) (*schedulerobjects.JobSchedulingInfo, error) {
+// The resource requests of each pod are computed from its full pod spec,
+// i.e., as the max of the sum over all containers and the max over all init containers,
+// plus the pod overhead if includePodOverhead is true.
+func SchedulingInfoFromSubmitJob(
+	submitJob *armadaevents.SubmitJob,
+	submitTime time.Time,
+	priorityClasses map[string]types.PriorityClass,
+	includePodOverhead bool,
+) (*schedulerobjects.JobSchedulingInfo, error) {
 	// Component common to all jobs.
 	schedulingInfo := &schedulerobjects.JobSchedulingInfo{
 		Lifetime:        submitJob.Lifetime,
@@ -385,6 +450,9 @@ func SchedulingInfoFromSubmitJob(submitJob *armadaevents.SubmitJob, submitTime t
 		podSpec := object.PodSpec.PodSpec
 		schedulingInfo.PriorityClassName = podSpec.PriorityClassName
 		podRequirements := adapters.PodRequirementsFromPodSpec(podSpec, priorityClasses)
+		if includePodOverhead {
+			podRequirements.ResourceRequirements = api.AddPodOverhead(podRequirements.ResourceRequirements, podSpec)
+		}
 		if submitJob.ObjectMeta != nil {
 			podRequirements.Annotations = maps.Clone(submitJob.ObjectMeta.Annotations)
 		}
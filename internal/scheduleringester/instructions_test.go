@@ -68,12 +68,20 @@ func TestConvertSequence(t *testing.T) {
 		},
 		"job run running": {
 			events:   []*armadaevents.EventSequence_Event{f.Running},
-			expected: []DbOperation{MarkRunsRunning{f.RunIdUuid: true}},
+			expected: []DbOperation{MarkRunsRunning{f.RunIdUuid: f.BaseTime}, UpdateRunNodes{f.RunIdUuid: f.NodeName}},
+		},
+		"job run assigned": {
+			events:   []*armadaevents.EventSequence_Event{f.Assigned},
+			expected: []DbOperation{MarkRunsPending{f.RunIdUuid: f.BaseTime}},
 		},
 		"job run succeeded": {
 			events:   []*armadaevents.EventSequence_Event{f.JobRunSucceeded},
 			expected: []DbOperation{MarkRunsSucceeded{f.RunIdUuid: true}},
 		},
+		"job run cancelled": {
+			events:   []*armadaevents.EventSequence_Event{f.JobRunCancelled},
+			expected: []DbOperation{MarkRunsCancelled{f.RunIdUuid: true}},
+		},
 		"lease returned": {
 			events: []*armadaevents.EventSequence_Event{f.LeaseReturned},
 			expected: []DbOperation{
@@ -108,6 +116,12 @@ func TestConvertSequence(t *testing.T) {
 				MarkJobsSucceeded{f.JobIdString: true},
 			},
 		},
+		"job validated": {
+			events: []*armadaevents.EventSequence_Event{f.JobValidated},
+			expected: []DbOperation{
+				MarkJobsAcknowledged{f.JobIdString: true},
+			},
+		},
 		"reprioritise job": {
 			events: []*armadaevents.EventSequence_Event{f.JobReprioritiseRequested},
 			expected: []DbOperation{
@@ -185,14 +199,16 @@ func TestConvertSequence(t *testing.T) {
 			events: []*armadaevents.EventSequence_Event{f.JobSetCancelRequested, f.Running, f.JobSucceeded},
 			expected: []DbOperation{
 				MarkJobSetsCancelRequested{JobSetKey{queue: f.Queue, jobSet: f.JobSetName}: &JobSetCancelAction{cancelQueued: true, cancelLeased: true}},
-				MarkRunsRunning{f.RunIdUuid: true},
+				MarkRunsRunning{f.RunIdUuid: f.BaseTime},
+				UpdateRunNodes{f.RunIdUuid: f.NodeName},
 				MarkJobsSucceeded{f.JobIdString: true},
 			},
 		},
 		"ignored events": {
 			events: []*armadaevents.EventSequence_Event{f.Running, f.JobPreempted, f.JobSucceeded},
 			expected: []DbOperation{
-				MarkRunsRunning{f.RunIdUuid: true},
+				MarkRunsRunning{f.RunIdUuid: f.BaseTime},
+				UpdateRunNodes{f.RunIdUuid: f.NodeName},
 				MarkJobsSucceeded{f.JobIdString: true},
 			},
 		},
@@ -200,7 +216,7 @@ func TestConvertSequence(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			converter := InstructionConverter{m, f.PriorityClasses, compressor}
+			converter := InstructionConverter{m, f.PriorityClasses, compressor, false}
 			es := f.NewEventSequence(tc.events...)
 			results := converter.dbOperationsFromEventSequence(es)
 			assertOperationsEqual(t, tc.expected, results)
@@ -306,3 +322,33 @@ func getExpectedSubmitMessageSchedulingInfo(t *testing.T) *schedulerobjects.JobS
 	}
 	return expectedSubmitSchedulingInfo
 }
+
+func TestSchedulingInfoFromSubmitJob_PodOverhead(t *testing.T) {
+	submit, err := f.DeepCopy(f.Submit)
+	require.NoError(t, err)
+	submitJob := submit.GetSubmitJob()
+	podSpec := submitJob.MainObject.GetPodSpec().PodSpec
+	podSpec.InitContainers = []v1.Container{
+		{
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{"cpu": resource.MustParse("10")},
+			},
+		},
+	}
+	podSpec.Overhead = v1.ResourceList{"cpu": resource.MustParse("1")}
+	for name, tc := range map[string]struct {
+		includePodOverhead bool
+		expectedCpu        resource.Quantity
+	}{
+		"overhead excluded": {includePodOverhead: false, expectedCpu: resource.MustParse("10")},
+		"overhead included": {includePodOverhead: true, expectedCpu: resource.MustParse("11")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			schedulingInfo, err := SchedulingInfoFromSubmitJob(submitJob, f.BaseTime, f.PriorityClasses, tc.includePodOverhead)
+			require.NoError(t, err)
+			require.Len(t, schedulingInfo.ObjectRequirements, 1)
+			actualCpu := schedulingInfo.ObjectRequirements[0].GetPodRequirements().ResourceRequirements.Requests["cpu"]
+			assert.Equal(t, 0, tc.expectedCpu.Cmp(actualCpu), "expected %s, got %s", tc.expectedCpu.String(), actualCpu.String())
+		})
+	}
+}
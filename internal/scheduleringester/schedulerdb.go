@@ -138,7 +138,8 @@ func (s *SchedulerDb) WriteDbOp(ctx *armadacontext.Context, tx pgx.Tx, op DbOper
 			return errors.WithStack(err)
 		}
 	case UpdateJobQueuedState:
-		updateQueuedStateSqlStatement := "update jobs set queued = $1::bool, queued_version = $2::int where job_id = $3 and $2::int > queued_version"
+		// Requests to preempt a job apply to the run it had at the time, so they're discarded once it's requeued or leased.
+		updateQueuedStateSqlStatement := "update jobs set queued = $1::bool, queued_version = $2::int, preempt_requested = false where job_id = $3 and $2::int > queued_version"
 
 		batch := &pgx.Batch{}
 		for key, value := range o {
@@ -200,6 +201,12 @@ func (s *SchedulerDb) WriteDbOp(ctx *armadacontext.Context, tx pgx.Tx, op DbOper
 		if err != nil {
 			return errors.WithStack(err)
 		}
+	case MarkJobsAcknowledged:
+		jobIds := maps.Keys(o)
+		err := queries.MarkJobsAcknowledgedById(ctx, jobIds)
+		if err != nil {
+			return errors.WithStack(err)
+		}
 	case UpdateJobPriorities:
 		// TODO: This will be slow if there's a large number of ids.
 		// Could be addressed by using a separate table for priority + upsert.
@@ -242,12 +249,52 @@ func (s *SchedulerDb) WriteDbOp(ctx *armadacontext.Context, tx pgx.Tx, op DbOper
 		if err != nil {
 			return errors.WithStack(err)
 		}
+	case MarkRunsPending:
+		// TODO: This will be slow if there's a large number of ids.
+		for runId, pendingTime := range o {
+			pendingTime := pendingTime
+			err := queries.SetPendingTime(ctx, schedulerdb.SetPendingTimeParams{
+				PendingTimestamp: &pendingTime,
+				RunID:            runId,
+			})
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
 	case MarkRunsRunning:
 		runIds := maps.Keys(o)
 		err := queries.MarkJobRunsRunningById(ctx, runIds)
 		if err != nil {
 			return errors.WithStack(err)
 		}
+		// TODO: This will be slow if there's a large number of ids.
+		for runId, runningTime := range o {
+			runningTime := runningTime
+			err := queries.SetRunningTime(ctx, schedulerdb.SetRunningTimeParams{
+				RunningTimestamp: &runningTime,
+				RunID:            runId,
+			})
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case UpdateRunNodes:
+		// TODO: This will be slow if there's a large number of ids.
+		for runId, nodeName := range o {
+			err := queries.SetRunNode(ctx, schedulerdb.SetRunNodeParams{
+				Node:  nodeName,
+				RunID: runId,
+			})
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case MarkRunsCancelled:
+		runIds := maps.Keys(o)
+		err := queries.MarkJobRunsCancelledById(ctx, runIds)
+		if err != nil {
+			return errors.WithStack(err)
+		}
 	case InsertJobRunErrors:
 		records := make([]any, len(o))
 		i := 0
@@ -64,6 +64,16 @@ func TestWriteOps(t *testing.T) {
 				jobIds[3]: &JobQueuedStateUpdate{Queued: false, QueuedStateVersion: 1},
 			},
 		}},
+		"UpdateJobQueuedState": {Ops: []DbOperation{
+			InsertJobs{
+				jobIds[0]: &schedulerdb.Job{JobID: jobIds[0], Queue: testQueueName, JobSet: "set1", Queued: true, QueuedVersion: 1},
+				jobIds[1]: &schedulerdb.Job{JobID: jobIds[1], Queue: testQueueName, JobSet: "set1", QueuedVersion: 1, PreemptRequested: true},
+			},
+			UpdateJobQueuedState{
+				jobIds[0]: &JobQueuedStateUpdate{Queued: false, QueuedStateVersion: 2},
+				jobIds[1]: &JobQueuedStateUpdate{Queued: true, QueuedStateVersion: 2},
+			},
+		}},
 		"UpdateJobSetPriorities": {Ops: []DbOperation{
 			InsertJobs{
 				jobIds[0]: &schedulerdb.Job{JobID: jobIds[0], Queue: testQueueName, JobSet: "set1"},
@@ -168,6 +178,17 @@ func TestWriteOps(t *testing.T) {
 				jobIds[1]: true,
 			},
 		}},
+		"MarkJobsAcknowledged": {Ops: []DbOperation{
+			InsertJobs{
+				jobIds[0]: &schedulerdb.Job{JobID: jobIds[0], JobSet: "set1"},
+				jobIds[1]: &schedulerdb.Job{JobID: jobIds[1], JobSet: "set2"},
+				jobIds[2]: &schedulerdb.Job{JobID: jobIds[2], JobSet: "set1"},
+			},
+			MarkJobsAcknowledged{
+				jobIds[0]: true,
+				jobIds[1]: true,
+			},
+		}},
 		"MarkRunsSucceeded": {Ops: []DbOperation{
 			InsertJobs{
 				jobIds[0]: &schedulerdb.Job{JobID: jobIds[0], JobSet: "set1"},
@@ -235,6 +256,24 @@ func TestWriteOps(t *testing.T) {
 				runIds[2]: &JobRunFailed{LeaseReturned: false},
 			},
 		}},
+		"MarkRunsPending": {Ops: []DbOperation{
+			InsertJobs{
+				jobIds[0]: &schedulerdb.Job{JobID: jobIds[0], JobSet: "set1"},
+				jobIds[1]: &schedulerdb.Job{JobID: jobIds[1], JobSet: "set2"},
+				jobIds[2]: &schedulerdb.Job{JobID: jobIds[2], JobSet: "set1"},
+				jobIds[3]: &schedulerdb.Job{JobID: jobIds[3], JobSet: "set2"},
+			},
+			InsertRuns{
+				runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0]}},
+				runIds[1]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[1], RunID: runIds[1]}},
+				runIds[2]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[2], RunID: runIds[2]}},
+				runIds[3]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[3], RunID: runIds[3]}},
+			},
+			MarkRunsPending{
+				runIds[0]: time.Unix(1, 0),
+				runIds[1]: time.Unix(2, 0),
+			},
+		}},
 		"MarkRunsRunning": {Ops: []DbOperation{
 			InsertJobs{
 				jobIds[0]: &schedulerdb.Job{JobID: jobIds[0], JobSet: "set1"},
@@ -249,8 +288,34 @@ func TestWriteOps(t *testing.T) {
 				runIds[3]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[3], RunID: runIds[3]}},
 			},
 			MarkRunsRunning{
+				runIds[0]: time.Unix(1, 0),
+				runIds[1]: time.Unix(2, 0),
+			},
+		}},
+		"UpdateRunNodes": {Ops: []DbOperation{
+			InsertJobs{
+				jobIds[0]: &schedulerdb.Job{JobID: jobIds[0], JobSet: "set1"},
+				jobIds[1]: &schedulerdb.Job{JobID: jobIds[1], JobSet: "set2"},
+			},
+			InsertRuns{
+				runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0], Node: "node1"}},
+				runIds[1]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[1], RunID: runIds[1], Node: "node1"}},
+			},
+			UpdateRunNodes{
+				runIds[0]: "node2",
+			},
+		}},
+		"MarkRunsCancelled": {Ops: []DbOperation{
+			InsertJobs{
+				jobIds[0]: &schedulerdb.Job{JobID: jobIds[0], JobSet: "set1"},
+				jobIds[1]: &schedulerdb.Job{JobID: jobIds[1], JobSet: "set2"},
+			},
+			InsertRuns{
+				runIds[0]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[0], RunID: runIds[0]}},
+				runIds[1]: &JobRunDetails{queue: testQueueName, dbRun: &schedulerdb.Run{JobID: jobIds[1], RunID: runIds[1]}},
+			},
+			MarkRunsCancelled{
 				runIds[0]: true,
-				runIds[1]: true,
 			},
 		}},
 		"Insert PositionMarkers": {Ops: []DbOperation{
@@ -304,10 +369,14 @@ func addDefaultValues(op DbOperation) DbOperation {
 	case MarkJobsCancelRequested:
 	case MarkJobsSucceeded:
 	case MarkJobsFailed:
+	case MarkJobsAcknowledged:
 	case UpdateJobPriorities:
 	case MarkRunsSucceeded:
 	case MarkRunsFailed:
+	case MarkRunsPending:
 	case MarkRunsRunning:
+	case UpdateRunNodes:
+	case MarkRunsCancelled:
 	}
 	return op
 }
@@ -390,6 +459,7 @@ func assertOpSuccess(t *testing.T, schedulerDb *SchedulerDb, serials map[string]
 			if e, ok := expected[job.JobID]; ok {
 				assert.Equal(t, e.Queued, job.Queued)
 				assert.Equal(t, e.QueuedStateVersion, job.QueuedVersion)
+				assert.False(t, job.PreemptRequested)
 				numChanged++
 			}
 		}
@@ -508,6 +578,19 @@ func assertOpSuccess(t *testing.T, schedulerDb *SchedulerDb, serials map[string]
 			}
 		}
 		assert.Equal(t, len(expected), numChanged)
+	case MarkJobsAcknowledged:
+		jobs, err := selectNewJobs(ctx, serials["jobs"])
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		numChanged := 0
+		for _, job := range jobs {
+			if _, ok := expected[job.JobID]; ok {
+				assert.True(t, job.Acknowledged)
+				numChanged++
+			}
+		}
+		assert.Equal(t, len(expected), numChanged)
 	case UpdateJobPriorities:
 		jobs, err := selectNewJobs(ctx, serials["jobs"])
 		if err != nil {
@@ -573,6 +656,31 @@ func assertOpSuccess(t *testing.T, schedulerDb *SchedulerDb, serials map[string]
 			}
 		}
 		assert.Equal(t, len(expected), len(runs))
+	case MarkRunsPending:
+		jobs, err := selectNewJobs(ctx, 0)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		jobIds := make([]string, 0)
+		for _, job := range jobs {
+			jobIds = append(jobIds, job.JobID)
+		}
+
+		runs, err := queries.SelectNewRunsForJobs(ctx, schedulerdb.SelectNewRunsForJobsParams{
+			Serial: serials["runs"],
+			JobIds: jobIds,
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, run := range runs {
+			if pendingTime, ok := expected[run.RunID]; ok {
+				if assert.NotNil(t, run.PendingTimestamp) {
+					assert.True(t, pendingTime.Equal(*run.PendingTimestamp))
+				}
+			}
+		}
+		assert.Equal(t, len(expected), len(runs))
 	case MarkRunsRunning:
 		jobs, err := selectNewJobs(ctx, 0)
 		if err != nil {
@@ -592,12 +700,59 @@ func assertOpSuccess(t *testing.T, schedulerDb *SchedulerDb, serials map[string]
 		}
 		numChanged := 0
 		for _, run := range runs {
-			if _, ok := expected[run.RunID]; ok {
+			if runningTime, ok := expected[run.RunID]; ok {
 				assert.True(t, run.Running)
+				if assert.NotNil(t, run.RunningTimestamp) {
+					assert.True(t, runningTime.Equal(*run.RunningTimestamp))
+				}
 				numChanged++
 			}
 		}
 		assert.Equal(t, len(expected), len(runs))
+	case UpdateRunNodes:
+		jobs, err := selectNewJobs(ctx, 0)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		jobIds := make([]string, 0)
+		for _, job := range jobs {
+			jobIds = append(jobIds, job.JobID)
+		}
+
+		runs, err := queries.SelectNewRunsForJobs(ctx, schedulerdb.SelectNewRunsForJobsParams{
+			Serial: serials["runs"],
+			JobIds: jobIds,
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, run := range runs {
+			if nodeName, ok := expected[run.RunID]; ok {
+				assert.Equal(t, nodeName, run.Node)
+			}
+		}
+		// Only runs whose node changed are updated.
+		assert.Equal(t, len(expected), len(runs))
+	case MarkRunsCancelled:
+		jobs, err := selectNewJobs(ctx, 0)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		jobIds := make([]string, 0)
+		for _, job := range jobs {
+			jobIds = append(jobIds, job.JobID)
+		}
+
+		runs, err := queries.SelectNewRunsForJobs(ctx, schedulerdb.SelectNewRunsForJobsParams{
+			Serial: serials["runs"],
+			JobIds: jobIds,
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, run := range runs {
+			assert.Equal(t, expected[run.RunID], run.Cancelled)
+		}
 	case InsertJobRunErrors:
 		expectedIds := maps.Keys(expected)
 		as, err := queries.SelectRunErrorsById(ctx, expectedIds)
@@ -212,3 +212,28 @@ func TestsNoSetup() error {
 
 	return nil
 }
+
+// TestsSchedulerIntegration runs the scheduler integration tests, which run the scheduler against postgres.
+func TestsSchedulerIntegration() error {
+	docker_Net, err := dockerNet()
+	if err != nil {
+		return err
+	}
+
+	err = dockerRun("run", "-d", "--name=postgres", docker_Net, "-p", "5432:5432", "-e", "POSTGRES_PASSWORD=psw", "postgres:14.2")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := dockerRun("rm", "-f", "postgres"); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	err = sh.Run("sleep", "3")
+	if err != nil {
+		return err
+	}
+	return sh.RunV("go", "test", "-tags", "integration", "-count=1", "-timeout", "10m", "-run", "TestSchedulerIntegration", "./internal/scheduler/")
+}
@@ -1020,6 +1020,9 @@ func SwaggerJsonTemplate() string {
 		"        \"kubernetesId\": {\n" +
 		"          \"type\": \"string\"\n" +
 		"        },\n" +
+		"        \"leaseNeverAcknowledged\": {\n" +
+		"          \"type\": \"boolean\"\n" +
+		"        },\n" +
 		"        \"podNumber\": {\n" +
 		"          \"type\": \"integer\",\n" +
 		"          \"format\": \"int32\"\n" +
@@ -1667,6 +1670,16 @@ func SwaggerJsonTemplate() string {
 		"            \"type\": \"string\"\n" +
 		"          }\n" +
 		"        },\n" +
+		"        \"maxJobPriority\": {\n" +
+		"          \"description\": \"Highest priority jobs in this queue may have, i.e., the smallest priority value, since jobs with smaller values\\nare scheduled first. Jobs submitted or reprioritised above it are clamped to it by the scheduler.\\nIf zero, priorities aren't capped.\",\n" +
+		"          \"type\": \"integer\",\n" +
+		"          \"format\": \"int64\"\n" +
+		"        },\n" +
+		"        \"maxQueuedJobs\": {\n" +
+		"          \"description\": \"Maximum number of jobs that may be queued in this queue at any one time.\\nJobs submitted beyond this are failed by the scheduler. If zero, the scheduler's default applies.\",\n" +
+		"          \"type\": \"integer\",\n" +
+		"          \"format\": \"int64\"\n" +
+		"        },\n" +
 		"        \"name\": {\n" +
 		"          \"type\": \"string\"\n" +
 		"        },\n" +
@@ -1676,6 +1689,10 @@ func SwaggerJsonTemplate() string {
 		"            \"$ref\": \"#/definitions/QueuePermissions\"\n" +
 		"          }\n" +
 		"        },\n" +
+		"        \"preemptionPolicy\": {\n" +
+		"          \"description\": \"Determines whether running jobs of this queue may be preempted: \\\"never\\\", \\\"standard\\\", or \\\"aggressive\\\".\\nJobs of never-preempt queues are never selected as preemption victims, whereas jobs of aggressive queues\\nare preferred victims. If empty, \\\"standard\\\" applies.\",\n" +
+		"          \"type\": \"string\"\n" +
+		"        },\n" +
 		"        \"priorityFactor\": {\n" +
 		"          \"type\": \"number\",\n" +
 		"          \"format\": \"double\"\n" +
@@ -371,6 +371,8 @@ type JobLeaseReturnedEvent struct {
 	KubernetesId string    `protobuf:"bytes,7,opt,name=kubernetes_id,json=kubernetesId,proto3" json:"kubernetesId,omitempty"`
 	PodNumber    int32     `protobuf:"varint,8,opt,name=pod_number,json=podNumber,proto3" json:"podNumber,omitempty"`
 	RunAttempted bool      `protobuf:"varint,9,opt,name=run_attempted,json=runAttempted,proto3" json:"runAttempted,omitempty"`
+	// True if the executor never acted on the lease, i.e., it never created a pod for it.
+	LeaseNeverAcknowledged bool `protobuf:"varint,10,opt,name=lease_never_acknowledged,json=leaseNeverAcknowledged,proto3" json:"leaseNeverAcknowledged,omitempty"`
 }
 
 func (m *JobLeaseReturnedEvent) Reset()      { *m = JobLeaseReturnedEvent{} }
@@ -468,6 +470,13 @@ func (m *JobLeaseReturnedEvent) GetRunAttempted() bool {
 	return false
 }
 
+func (m *JobLeaseReturnedEvent) GetLeaseNeverAcknowledged() bool {
+	if m != nil {
+		return m.LeaseNeverAcknowledged
+	}
+	return false
+}
+
 type JobLeaseExpiredEvent struct {
 	JobId    string    `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
 	JobSetId string    `protobuf:"bytes,2,opt,name=job_set_id,json=jobSetId,proto3" json:"jobSetId,omitempty"`
@@ -2776,169 +2785,171 @@ func init() {
 func init() { proto.RegisterFile("pkg/api/event.proto", fileDescriptor_7758595c3bb8cf56) }
 
 var fileDescriptor_7758595c3bb8cf56 = []byte{
-	// 2578 bytes of a gzipped FileDescriptorProto
+	// 2611 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0x4d, 0x6c, 0x1b, 0xc7,
-	0xf5, 0xd7, 0x52, 0xe2, 0xd7, 0x48, 0xa2, 0xa4, 0xd1, 0x87, 0xd7, 0xb4, 0x2d, 0x0a, 0x0c, 0xf0,
-	0x8f, 0x62, 0xc4, 0x64, 0xfe, 0x72, 0x52, 0x18, 0x46, 0xd0, 0xc0, 0x94, 0xe5, 0x44, 0x82, 0x1d,
-	0x3b, 0x94, 0x8d, 0xb4, 0x45, 0x00, 0x66, 0xb9, 0x3b, 0xa2, 0x56, 0x22, 0x77, 0x36, 0xbb, 0xb3,
-	0xb6, 0x15, 0x23, 0x40, 0xd1, 0xa2, 0x45, 0x2e, 0x45, 0x53, 0xb4, 0xf7, 0xe4, 0xdc, 0x5e, 0x7a,
-	0xe9, 0xb5, 0x87, 0xa2, 0x87, 0xf4, 0xe6, 0xa2, 0x28, 0x90, 0x13, 0xdb, 0xda, 0x09, 0x50, 0xf0,
-	0xd0, 0x7b, 0x6f, 0xc5, 0xbc, 0x99, 0x25, 0x67, 0x56, 0x14, 0x24, 0x2b, 0x4d, 0x6a, 0x08, 0xba,
-	0x24, 0xe6, 0xef, 0xcd, 0x7b, 0xf3, 0xf6, 0xcd, 0xef, 0xcd, 0xbc, 0xf9, 0x10, 0x9a, 0xf5, 0x77,
-	0x5b, 0x55, 0xcb, 0x77, 0xab, 0xe4, 0x3e, 0xf1, 0x58, 0xc5, 0x0f, 0x28, 0xa3, 0x78, 0xd4, 0xf2,
-	0xdd, 0x62, 0xa9, 0x45, 0x69, 0xab, 0x4d, 0xaa, 0x00, 0x35, 0xa3, 0xad, 0x2a, 0x73, 0x3b, 0x24,
-	0x64, 0x56, 0xc7, 0x17, 0xad, 0x8a, 0x7d, 0xd5, 0x0f, 0x22, 0x12, 0x11, 0x09, 0xce, 0xc5, 0xe0,
-	0x36, 0xb1, 0xda, 0x6c, 0x5b, 0xa2, 0xe7, 0x92, 0xb6, 0x48, 0xc7, 0x67, 0x7b, 0x52, 0x78, 0xa9,
-	0xe5, 0xb2, 0xed, 0xa8, 0x59, 0xb1, 0x69, 0xa7, 0xda, 0xa2, 0x2d, 0x3a, 0x68, 0xc5, 0x7f, 0xc1,
-	0x0f, 0xf8, 0x97, 0x6c, 0x7e, 0x5e, 0xda, 0xe2, 0x9d, 0x58, 0x9e, 0x47, 0x99, 0xc5, 0x5c, 0xea,
-	0x85, 0x52, 0xfa, 0xea, 0xee, 0x95, 0xb0, 0xe2, 0x52, 0x2e, 0xed, 0x58, 0xf6, 0xb6, 0xeb, 0x91,
-	0x60, 0xaf, 0x1a, 0xfb, 0x14, 0x90, 0x90, 0x46, 0x81, 0x4d, 0xaa, 0x2d, 0xe2, 0x91, 0xc0, 0x62,
-	0xc4, 0x11, 0x5a, 0xe5, 0x5f, 0xa5, 0xd0, 0xcc, 0x06, 0x6d, 0x6e, 0x46, 0xcd, 0x8e, 0xcb, 0x18,
-	0x71, 0xd6, 0x78, 0x30, 0xf0, 0x45, 0x94, 0xd9, 0xa1, 0xcd, 0x86, 0xeb, 0x98, 0xc6, 0x92, 0xb1,
-	0x9c, 0xaf, 0xcd, 0xf6, 0xba, 0xa5, 0xa9, 0x1d, 0xda, 0x5c, 0x77, 0x5e, 0xa6, 0x1d, 0x97, 0xc1,
-	0x37, 0xd4, 0xd3, 0x00, 0xe0, 0x57, 0x11, 0xe2, 0x6d, 0x43, 0xc2, 0x78, 0xfb, 0x14, 0xb4, 0x5f,
-	0xe8, 0x75, 0x4b, 0x78, 0x87, 0x36, 0x37, 0x09, 0xd3, 0x54, 0x72, 0x31, 0x86, 0x5f, 0x42, 0x69,
-	0x08, 0x9e, 0x39, 0x3a, 0xe8, 0x00, 0x00, 0xb5, 0x03, 0x00, 0xf0, 0x3a, 0xca, 0xda, 0x01, 0xe1,
-	0x3e, 0x9b, 0x63, 0x4b, 0xc6, 0xf2, 0xf8, 0x4a, 0xb1, 0x22, 0x02, 0x51, 0x89, 0xc3, 0x55, 0xb9,
-	0x1b, 0x0f, 0x50, 0x6d, 0xf6, 0xf3, 0x6e, 0x69, 0xa4, 0xd7, 0x2d, 0xc5, 0x2a, 0x9f, 0xfc, 0xad,
-	0x64, 0xd4, 0xe3, 0x1f, 0xf8, 0x45, 0x34, 0xba, 0x43, 0x9b, 0x66, 0x1a, 0xcc, 0xe4, 0x2a, 0x96,
-	0xef, 0x56, 0x36, 0x68, 0xb3, 0x36, 0x2e, 0x95, 0xb8, 0xb0, 0xce, 0xff, 0x53, 0xfe, 0xa7, 0x81,
-	0x0a, 0x1b, 0xb4, 0xf9, 0x0e, 0x77, 0xe0, 0x64, 0xc7, 0xa4, 0xfc, 0xbb, 0x14, 0x5a, 0xd8, 0xa0,
-	0xcd, 0xeb, 0x91, 0xdf, 0x76, 0x6d, 0x8b, 0x91, 0x1b, 0x34, 0xf2, 0x4e, 0x38, 0x0d, 0x56, 0xd1,
-	0x14, 0x0d, 0xdc, 0x96, 0xeb, 0x59, 0xed, 0x86, 0xfc, 0xc0, 0x34, 0xf4, 0x7f, 0xae, 0xd7, 0x2d,
-	0x9d, 0x89, 0x45, 0x1b, 0x89, 0x0f, 0x9d, 0xd4, 0x04, 0xe5, 0xcf, 0x52, 0x40, 0x91, 0x9b, 0xc4,
-	0x0a, 0x4f, 0x7a, 0xda, 0x7c, 0x07, 0x21, 0xbb, 0x1d, 0x85, 0x8c, 0x04, 0x83, 0x50, 0x9d, 0xe9,
-	0x75, 0x4b, 0xb3, 0x12, 0xd5, 0x9c, 0xcd, 0xf7, 0xc1, 0xf2, 0xcf, 0xc7, 0xd0, 0x7c, 0x1c, 0xa2,
-	0x3a, 0x61, 0x51, 0xe0, 0x9d, 0x46, 0x6a, 0x68, 0xa4, 0xf0, 0xcb, 0x28, 0x13, 0x10, 0x2b, 0xa4,
-	0x9e, 0x99, 0x01, 0x9d, 0xb9, 0x5e, 0xb7, 0x34, 0x2d, 0x10, 0x45, 0x41, 0xb6, 0xc1, 0x6f, 0xa0,
-	0xc9, 0xdd, 0xa8, 0x49, 0x02, 0x8f, 0x30, 0x12, 0xf2, 0x8e, 0xb2, 0xa0, 0x54, 0xec, 0x75, 0x4b,
-	0x0b, 0x03, 0x81, 0xd6, 0xd7, 0x84, 0x8a, 0x73, 0x37, 0x7d, 0xea, 0x34, 0xbc, 0xa8, 0xd3, 0x24,
-	0x81, 0x99, 0x5b, 0x32, 0x96, 0xd3, 0xc2, 0x4d, 0x9f, 0x3a, 0x6f, 0x03, 0xa8, 0xba, 0xd9, 0x07,
-	0x79, 0xc7, 0x41, 0xe4, 0x35, 0x2c, 0x06, 0x22, 0xe2, 0x98, 0xf9, 0x25, 0x63, 0x39, 0x27, 0x3a,
-	0x0e, 0x22, 0xef, 0x5a, 0x8c, 0xab, 0x1d, 0xab, 0x78, 0xf9, 0x5f, 0x06, 0x9a, 0x8b, 0x19, 0xb1,
-	0xf6, 0xd0, 0x77, 0x83, 0x93, 0x3e, 0xbb, 0xfe, 0x6c, 0x0c, 0x4d, 0x6d, 0xd0, 0xe6, 0x1d, 0xe2,
-	0x39, 0xae, 0xd7, 0x3a, 0x25, 0xff, 0x30, 0xf2, 0xef, 0xa3, 0x73, 0xe6, 0x6b, 0xd1, 0x39, 0x7b,
-	0x64, 0x3a, 0xbf, 0x82, 0x72, 0xa0, 0x67, 0x75, 0x08, 0x24, 0x41, 0xbe, 0x36, 0xdf, 0xeb, 0x96,
-	0x66, 0x78, 0x03, 0xab, 0xa3, 0xc6, 0x2a, 0x2b, 0x21, 0xee, 0x6a, 0xac, 0x11, 0xfa, 0x96, 0x4d,
-	0x20, 0x01, 0xa4, 0xab, 0xb2, 0x0d, 0xe0, 0xaa, 0xab, 0x2a, 0x5e, 0xfe, 0x83, 0xe0, 0x43, 0x3d,
-	0xf2, 0xbc, 0x53, 0x3e, 0x7c, 0x53, 0x7c, 0xb8, 0x8c, 0xf2, 0x1e, 0x75, 0x88, 0x18, 0xd8, 0xec,
-	0x20, 0x46, 0x1c, 0x4c, 0x8c, 0x6c, 0x2e, 0xc6, 0x8e, 0x3d, 0x27, 0xaa, 0x24, 0xca, 0x1f, 0x8f,
-	0x44, 0xe8, 0x19, 0x49, 0xf4, 0xdb, 0x0c, 0x9a, 0xe5, 0x45, 0x88, 0xd7, 0x0a, 0x48, 0x18, 0xae,
-	0x7b, 0x5b, 0xf4, 0x94, 0x48, 0x27, 0x8b, 0x48, 0xe8, 0x78, 0x44, 0x1a, 0x7f, 0x36, 0x22, 0xe1,
-	0x47, 0x68, 0xc6, 0x15, 0x24, 0x6a, 0x58, 0x8e, 0xc3, 0xff, 0x4f, 0x42, 0x33, 0xbf, 0x34, 0xba,
-	0x3c, 0xbe, 0x52, 0x89, 0x77, 0x47, 0x49, 0x96, 0x55, 0x24, 0x70, 0x2d, 0x56, 0x58, 0xf3, 0x58,
-	0xb0, 0x57, 0x5b, 0xec, 0x75, 0x4b, 0x45, 0x37, 0x21, 0x52, 0x3a, 0x9e, 0x4e, 0xca, 0x8a, 0xbb,
-	0x68, 0x7e, 0xa8, 0x29, 0xfc, 0x02, 0x1a, 0xdd, 0x25, 0x7b, 0xc0, 0xe1, 0x74, 0x6d, 0xa6, 0xd7,
-	0x2d, 0x4d, 0xee, 0x92, 0x3d, 0xc5, 0x14, 0x97, 0x72, 0x26, 0xde, 0xb7, 0xda, 0x11, 0x91, 0xd4,
-	0x05, 0x26, 0x02, 0xa0, 0x32, 0x11, 0x80, 0xab, 0xa9, 0x2b, 0x46, 0xf9, 0xdf, 0x63, 0xc8, 0xdc,
-	0xa0, 0xcd, 0x7b, 0x9e, 0xd5, 0x6c, 0x93, 0xbb, 0x74, 0xd3, 0xde, 0x26, 0x4e, 0xd4, 0x26, 0xa7,
-	0x79, 0xf3, 0x1c, 0x54, 0xa3, 0x5a, 0x96, 0xe5, 0x8e, 0x95, 0x65, 0xf9, 0xe7, 0x38, 0xcb, 0xca,
-	0x8f, 0xb3, 0xb0, 0x53, 0xbc, 0x61, 0xb9, 0xed, 0xd3, 0xfd, 0xcf, 0x7f, 0x83, 0x71, 0xef, 0x21,
-	0x44, 0x1e, 0xba, 0xac, 0x61, 0x53, 0x87, 0x84, 0x66, 0x16, 0xe6, 0xab, 0x72, 0x3c, 0x5f, 0x29,
-	0x61, 0xae, 0xac, 0x3d, 0x74, 0xd9, 0x2a, 0x6f, 0x24, 0xe6, 0xa8, 0xb3, 0xdc, 0x13, 0x12, 0x63,
-	0x03, 0xc3, 0xa6, 0x51, 0xcf, 0xf7, 0xe1, 0xfd, 0x7c, 0xce, 0x7d, 0x1d, 0x3e, 0xe7, 0x8f, 0xc5,
-	0x67, 0x74, 0x2c, 0x3e, 0x4f, 0x1e, 0x8f, 0xcf, 0x85, 0x67, 0x5c, 0x35, 0x1c, 0x84, 0x6d, 0xea,
-	0x31, 0xcb, 0xf5, 0x48, 0xd0, 0x08, 0x99, 0xc5, 0x22, 0xbe, 0x6c, 0x8c, 0xc3, 0x30, 0xcc, 0xc1,
-	0x30, 0xac, 0xc6, 0xe2, 0x4d, 0x90, 0xd6, 0x4a, 0xbd, 0x6e, 0xe9, 0x9c, 0xad, 0x83, 0xda, 0xea,
-	0x30, 0xb3, 0x4f, 0x88, 0x5f, 0x43, 0x69, 0xdb, 0x8a, 0x42, 0x62, 0x4e, 0x2c, 0x19, 0xcb, 0x85,
-	0x15, 0x24, 0x0c, 0x73, 0x44, 0x90, 0x19, 0x84, 0x2a, 0x99, 0x01, 0x28, 0x3a, 0xa8, 0xa0, 0x8f,
-	0xba, 0xba, 0x9c, 0xe4, 0x8f, 0xb6, 0x9c, 0xa4, 0x0f, 0x5d, 0x4e, 0xbe, 0x1a, 0x85, 0x63, 0xd3,
-	0x3b, 0x01, 0x11, 0x1b, 0xdb, 0xd3, 0xac, 0x1e, 0x96, 0xd5, 0x17, 0x51, 0x26, 0x88, 0xbc, 0x41,
-	0xe1, 0x05, 0xee, 0x06, 0x91, 0xa7, 0xc7, 0x03, 0x00, 0xbc, 0x8e, 0x66, 0x7c, 0x11, 0x4d, 0xf7,
-	0x3e, 0x89, 0x4f, 0xe5, 0xc4, 0x4a, 0x72, 0xa1, 0xd7, 0x2d, 0x9d, 0x1d, 0x08, 0x93, 0xe7, 0x72,
-	0x53, 0x09, 0x51, 0xc2, 0x94, 0xf4, 0x20, 0x37, 0xcc, 0x54, 0x3d, 0xe1, 0xcb, 0x54, 0x42, 0x54,
-	0x5e, 0x83, 0xaa, 0x41, 0x99, 0x52, 0x56, 0x69, 0xc7, 0x87, 0x5a, 0x05, 0xc6, 0x02, 0xae, 0x0e,
-	0x60, 0xb0, 0x27, 0xc4, 0xc7, 0x01, 0xa0, 0x7e, 0x1c, 0x00, 0xe5, 0x3f, 0x8e, 0xc9, 0x53, 0x76,
-	0xdb, 0x26, 0xc4, 0x39, 0xa5, 0xcb, 0xe9, 0xbe, 0xef, 0x58, 0xfb, 0xbe, 0x4f, 0xf3, 0xb0, 0xef,
-	0xbb, 0xc7, 0xdc, 0xb6, 0x1b, 0xc2, 0xe5, 0xcf, 0x29, 0x91, 0xbe, 0x11, 0x22, 0x7d, 0x6c, 0xa0,
-	0xf9, 0x5b, 0xd6, 0xc3, 0xba, 0xbc, 0x35, 0x0b, 0x6f, 0xd0, 0xe0, 0x0e, 0x09, 0x5c, 0xea, 0xc8,
-	0x62, 0xe3, 0x72, 0x5c, 0x6c, 0x24, 0x87, 0xa2, 0x32, 0x54, 0x4b, 0x54, 0x1f, 0x17, 0xe4, 0xb7,
-	0x0e, 0xb7, 0x5c, 0x1f, 0x0e, 0x9f, 0xf4, 0xe2, 0x18, 0xff, 0xd4, 0x40, 0x0b, 0x8c, 0x32, 0xab,
-	0xdd, 0xb0, 0xa3, 0x4e, 0xd4, 0xb6, 0x60, 0xce, 0x8e, 0x42, 0xab, 0xc5, 0x17, 0x7e, 0x1e, 0xeb,
-	0x95, 0x03, 0x63, 0x7d, 0x97, 0xab, 0xad, 0xf6, 0xb5, 0xee, 0x71, 0x25, 0x11, 0xea, 0xf3, 0x32,
-	0xd4, 0x73, 0x6c, 0x48, 0x93, 0xfa, 0x50, 0xb4, 0xf8, 0x99, 0x81, 0x8a, 0x07, 0x8f, 0xde, 0xd1,
-	0xaa, 0x88, 0xef, 0xab, 0x55, 0x04, 0xdf, 0x43, 0x8b, 0x3b, 0xd9, 0x8a, 0x7a, 0x27, 0x5b, 0xf1,
-	0x77, 0x5b, 0xf0, 0x49, 0xf1, 0x9d, 0x6c, 0xe5, 0x9d, 0xc8, 0xf2, 0x98, 0xcb, 0xf6, 0x0e, 0xab,
-	0x3a, 0x8a, 0x9f, 0x1a, 0xe8, 0xec, 0x81, 0x1f, 0xfd, 0x3c, 0x78, 0x58, 0xfe, 0x4a, 0x5c, 0x26,
-	0xd6, 0x89, 0x1f, 0xb8, 0x34, 0x70, 0x99, 0xfb, 0xe1, 0x89, 0x3f, 0xe5, 0x7c, 0x1d, 0x4d, 0x78,
-	0xe4, 0x41, 0x43, 0x7e, 0xf0, 0x1e, 0x4c, 0x53, 0x06, 0x6c, 0x35, 0xe6, 0x3d, 0xf2, 0xe0, 0x8e,
-	0x84, 0x15, 0x17, 0xc6, 0x15, 0x18, 0xbf, 0x86, 0xf2, 0x01, 0xf9, 0x20, 0x22, 0x21, 0xa3, 0x81,
-	0x9c, 0xa6, 0x20, 0x51, 0xfb, 0xa0, 0x9a, 0xa8, 0x7d, 0xb0, 0xfc, 0x65, 0x0a, 0x6e, 0xd6, 0x94,
-	0x38, 0x9f, 0xf4, 0xa2, 0xe2, 0x7f, 0x12, 0xe6, 0x3f, 0xa7, 0x10, 0xde, 0xa0, 0xcd, 0x55, 0xcb,
-	0xb3, 0x49, 0xbb, 0x7d, 0xe2, 0xa9, 0xac, 0x45, 0x29, 0x7d, 0xd4, 0x28, 0x3d, 0xdb, 0xe6, 0xbd,
-	0xfc, 0x58, 0xbc, 0x38, 0x91, 0x31, 0x3d, 0xe9, 0xb4, 0xfd, 0x56, 0x42, 0xfa, 0xfb, 0x31, 0xa0,
-	0xe9, 0x5d, 0x12, 0x74, 0x5c, 0xcf, 0x3a, 0xdd, 0x8e, 0x3e, 0xcf, 0xf7, 0x8c, 0xdf, 0xce, 0x56,
-	0x41, 0x21, 0x50, 0xee, 0x08, 0x04, 0xfa, 0x53, 0x0a, 0x6e, 0x25, 0xef, 0xf9, 0xce, 0xc9, 0x67,
-	0xcf, 0x31, 0x33, 0x52, 0x3e, 0x1d, 0xcb, 0x1c, 0xfa, 0x74, 0xec, 0x37, 0x05, 0x34, 0x01, 0x11,
-	0xbc, 0x45, 0x42, 0x5e, 0x9c, 0xe1, 0xdb, 0x28, 0x1f, 0xc6, 0xcf, 0xeb, 0x20, 0x96, 0xe3, 0x2b,
-	0x0b, 0xb1, 0xbe, 0xfe, 0xee, 0x4e, 0x38, 0xd2, 0x6f, 0x3c, 0x70, 0xe4, 0xad, 0x91, 0xfa, 0xc0,
-	0x06, 0x5e, 0x45, 0x19, 0x88, 0x8a, 0x23, 0x8b, 0xb8, 0xd9, 0xd8, 0x9a, 0xf2, 0x5c, 0x4d, 0x0c,
-	0xb8, 0x68, 0xa6, 0xd9, 0x91, 0xaa, 0xd8, 0x41, 0x53, 0x4e, 0xfc, 0xe4, 0xab, 0xb1, 0x45, 0x23,
-	0xcf, 0x31, 0xa7, 0xc1, 0xda, 0xb9, 0xd8, 0xda, 0x90, 0x17, 0x61, 0xb5, 0xf3, 0xbd, 0x6e, 0xc9,
-	0x74, 0x34, 0x81, 0x66, 0xbd, 0xa0, 0xcb, 0xb8, 0xab, 0x6d, 0x78, 0x20, 0x05, 0x63, 0xac, 0xb8,
-	0xaa, 0x3c, 0x9b, 0x12, 0xae, 0x8a, 0x66, 0xba, 0xab, 0x02, 0xc3, 0xef, 0xa3, 0x02, 0xfc, 0xab,
-	0x11, 0xc8, 0x37, 0x44, 0x7d, 0x0e, 0xa8, 0xc6, 0xb4, 0x07, 0x46, 0xe2, 0x25, 0x57, 0x5b, 0xc5,
-	0x35, 0xd3, 0x93, 0x9a, 0x08, 0xbf, 0x87, 0x04, 0xd0, 0x20, 0xe2, 0x4d, 0x8a, 0x7c, 0x21, 0x78,
-	0x56, 0xeb, 0x40, 0x7d, 0xaf, 0x22, 0x32, 0xb1, 0xad, 0xc0, 0x9a, 0xf9, 0x09, 0x55, 0x82, 0xdf,
-	0x44, 0x59, 0x5f, 0xbc, 0xff, 0x90, 0xf4, 0x99, 0x8b, 0xed, 0xaa, 0xcf, 0x42, 0xe4, 0x9c, 0x20,
-	0x10, 0xcd, 0x5a, 0xac, 0xcd, 0x0d, 0x05, 0xe2, 0xe1, 0x00, 0x4c, 0x3e, 0x8a, 0x21, 0xf5, 0x3d,
-	0x81, 0x30, 0x24, 0x1b, 0xea, 0x86, 0x24, 0x88, 0x3b, 0x08, 0x47, 0x70, 0x13, 0xd6, 0x60, 0xb4,
-	0x11, 0xca, 0xbb, 0x30, 0x98, 0x29, 0xc6, 0x57, 0x2e, 0xf4, 0xf7, 0x5b, 0xc3, 0xee, 0xca, 0xc4,
-	0x3d, 0x5f, 0x94, 0x10, 0x69, 0xbd, 0x4c, 0x27, 0xa5, 0x9c, 0x05, 0x5b, 0x70, 0x84, 0x06, 0xb3,
-	0x9f, 0xc2, 0x02, 0xe5, 0x60, 0x4d, 0xb0, 0x40, 0x34, 0xd3, 0x59, 0x20, 0x30, 0x91, 0x46, 0xf2,
-	0xfc, 0x0c, 0xa6, 0x43, 0x2d, 0x8d, 0xd4, 0x83, 0xb5, 0x38, 0x8d, 0x24, 0x96, 0x4c, 0x23, 0x09,
-	0xe3, 0x06, 0x9a, 0x0c, 0xd4, 0xfa, 0x19, 0xb6, 0xae, 0x0a, 0xab, 0xf6, 0x17, 0xd7, 0x82, 0x55,
-	0x9a, 0x92, 0xce, 0x2a, 0x4d, 0x84, 0x37, 0x11, 0xb2, 0xfb, 0x95, 0x23, 0x1c, 0x63, 0x8f, 0xaf,
-	0x9c, 0x89, 0xad, 0x27, 0x6a, 0xca, 0x9a, 0xc9, 0xb7, 0xab, 0x83, 0xe6, 0x9a, 0x5d, 0xc5, 0x0c,
-	0x0f, 0x83, 0x1d, 0x97, 0x4e, 0x70, 0xe0, 0xaf, 0x84, 0x41, 0xaf, 0xa9, 0xe4, 0x9a, 0x18, 0x63,
-	0x7a, 0x18, 0xfa, 0x30, 0xf7, 0x92, 0xf5, 0x0b, 0x07, 0xb8, 0x0b, 0x50, 0xbc, 0x4c, 0x94, 0x14,
-	0xc2, 0xcb, 0x41, 0x73, 0xdd, 0xcb, 0x01, 0x8e, 0xdf, 0x45, 0xe3, 0xd1, 0x60, 0xbb, 0x6e, 0x4e,
-	0x81, 0x55, 0xf3, 0xa0, 0x9d, 0xbc, 0x28, 0xe3, 0x15, 0x05, 0xcd, 0xae, 0x6a, 0x09, 0x7f, 0x0f,
-	0x4d, 0xc4, 0x37, 0xd6, 0xae, 0xb7, 0x45, 0xcd, 0x19, 0xdd, 0x72, 0xf2, 0xb2, 0x5a, 0x58, 0x76,
-	0x07, 0xa8, 0x6e, 0x59, 0x11, 0x60, 0x1b, 0x15, 0x02, 0x6d, 0xdb, 0x6a, 0x62, 0x7d, 0x3e, 0x1c,
-	0xb2, 0xa9, 0x15, 0xf3, 0xa1, 0xae, 0xa6, 0xcf, 0x87, 0xba, 0x8c, 0x67, 0x70, 0x24, 0x16, 0x59,
-	0x73, 0x56, 0xcf, 0x60, 0x75, 0xed, 0x15, 0x19, 0x2c, 0x1b, 0xea, 0x19, 0x2c, 0x41, 0xbc, 0x8b,
-	0x64, 0xae, 0x0c, 0x0e, 0xa4, 0xcd, 0x39, 0x3d, 0x7f, 0x87, 0x9e, 0x5a, 0x8b, 0xfc, 0x4d, 0xaa,
-	0xea, 0xf9, 0x9b, 0x94, 0x72, 0xce, 0xf9, 0xf1, 0x4d, 0x87, 0x39, 0xaf, 0x73, 0x4e, 0xbf, 0x02,
-	0x91, 0xe5, 0x50, 0x8c, 0xe9, 0x9c, 0xeb, 0xc3, 0xb5, 0x1c, 0xca, 0xc0, 0xc1, 0x78, 0x58, 0xfe,
-	0x71, 0x0a, 0x4d, 0x25, 0x6e, 0x8b, 0xf0, 0xff, 0xa1, 0x31, 0x28, 0x95, 0x44, 0xdd, 0x81, 0x7b,
-	0xdd, 0x52, 0xc1, 0xd3, 0xeb, 0x24, 0x90, 0xe3, 0x15, 0x94, 0x8b, 0x6f, 0xed, 0xe4, 0xb5, 0x0d,
-	0xd4, 0x1c, 0x31, 0xa6, 0xd6, 0x1c, 0x31, 0x86, 0xab, 0x28, 0xdb, 0x11, 0xeb, 0xb2, 0xac, 0x3a,
-	0x20, 0xd4, 0x12, 0x52, 0x2b, 0x31, 0x09, 0x29, 0x85, 0xd4, 0xd8, 0x11, 0x6e, 0x26, 0xfb, 0x97,
-	0x56, 0xe9, 0x67, 0xb9, 0xb4, 0x2a, 0xdf, 0x44, 0x79, 0x08, 0xdf, 0x4d, 0x37, 0x64, 0xf8, 0x8d,
-	0x38, 0x38, 0xa6, 0x01, 0x07, 0x60, 0x33, 0x60, 0x44, 0x2d, 0x29, 0x84, 0x13, 0xa2, 0x91, 0xea,
-	0x84, 0x8c, 0xe9, 0x87, 0x08, 0x43, 0xeb, 0x4d, 0x16, 0x10, 0xab, 0x13, 0x97, 0x21, 0x4b, 0x28,
-	0xd5, 0xaf, 0xe5, 0xa6, 0x7b, 0xdd, 0xd2, 0x84, 0xab, 0x56, 0x65, 0x29, 0xd7, 0xc1, 0xb5, 0x41,
-	0x6c, 0x44, 0x61, 0x31, 0xa4, 0xe7, 0x43, 0xc2, 0x55, 0xfe, 0xc9, 0x28, 0x9a, 0xdc, 0x80, 0x02,
-	0xaf, 0x2e, 0x4a, 0xa7, 0x23, 0xf4, 0xfb, 0x12, 0x4a, 0x3f, 0xb0, 0x98, 0xbd, 0x0d, 0xbd, 0xe6,
-	0x44, 0xa0, 0x00, 0x50, 0x03, 0x05, 0x00, 0x5e, 0x45, 0x53, 0x5b, 0x01, 0xed, 0x34, 0x64, 0x77,
-	0xbc, 0xda, 0x1c, 0x1d, 0xbc, 0xdc, 0xe6, 0x22, 0xe9, 0xa8, 0xfe, 0x72, 0x5b, 0x13, 0x0c, 0xea,
-	0xce, 0xb1, 0x43, 0xeb, 0xce, 0xeb, 0xa8, 0x40, 0x82, 0x80, 0x06, 0xeb, 0x5b, 0xb7, 0xdc, 0x30,
-	0xe4, 0x93, 0x42, 0x1a, 0x7c, 0x84, 0xbc, 0xd7, 0x25, 0x8a, 0x72, 0x42, 0x07, 0xbf, 0x8e, 0x26,
-	0xb6, 0x68, 0x60, 0x93, 0x46, 0x9b, 0xb4, 0x2c, 0x7b, 0x0f, 0xaa, 0x80, 0x9c, 0x98, 0x9a, 0x00,
-	0xbf, 0x09, 0xb0, 0x7a, 0x76, 0xa1, 0xc0, 0xf8, 0x32, 0xca, 0x0b, 0x6d, 0x8f, 0x3c, 0x80, 0x75,
-	0x3f, 0x27, 0x78, 0x0e, 0xe0, 0xdb, 0xe4, 0x81, 0xca, 0xf3, 0x18, 0x2b, 0xff, 0x22, 0x85, 0x26,
-	0xde, 0xe5, 0x21, 0x8b, 0x87, 0xa1, 0xff, 0xd1, 0xc6, 0xa1, 0x1f, 0x7d, 0xbc, 0x6a, 0xfe, 0x12,
-	0xca, 0xc2, 0xd0, 0xf4, 0x87, 0x44, 0x2c, 0xe8, 0x01, 0xed, 0x68, 0x0a, 0x19, 0x81, 0xec, 0x8b,
-	0xc9, 0xd8, 0xf1, 0x63, 0x92, 0x3e, 0x5a, 0x4c, 0x2e, 0x7e, 0x17, 0xa5, 0x21, 0x15, 0x71, 0x1e,
-	0xa5, 0xd7, 0xf8, 0x08, 0x4d, 0x8f, 0xe0, 0x71, 0x94, 0x5d, 0xbb, 0xef, 0xda, 0x8c, 0x38, 0xd3,
-	0x06, 0xce, 0xa2, 0xd1, 0xdb, 0xb7, 0x6f, 0x4d, 0xa7, 0xf0, 0x1c, 0x9a, 0xbe, 0x4e, 0x2c, 0xa7,
-	0xed, 0x7a, 0x64, 0xed, 0xa1, 0x28, 0x17, 0xa6, 0x47, 0x57, 0xfe, 0x9a, 0x42, 0x69, 0xb1, 0x37,
-	0xba, 0x82, 0x0a, 0x75, 0xe2, 0xd3, 0x80, 0xdd, 0x8a, 0xda, 0xcc, 0xf5, 0xdb, 0x04, 0x17, 0x06,
-	0xa9, 0xc2, 0x93, 0xb8, 0xb8, 0xb0, 0x6f, 0x7f, 0xb2, 0xc6, 0xbd, 0xc1, 0x97, 0x51, 0x46, 0x68,
-	0xe2, 0xfd, 0xc9, 0x75, 0xa0, 0x12, 0x41, 0x53, 0x6f, 0x12, 0x26, 0xd2, 0x0a, 0x14, 0x42, 0x8c,
-	0xfb, 0xa5, 0x4f, 0x3f, 0xd3, 0x8a, 0x67, 0x06, 0x16, 0xb5, 0xd4, 0x2f, 0xbf, 0xf0, 0xa3, 0xbf,
-	0x7c, 0xf9, 0xcb, 0xd4, 0x85, 0xb2, 0x59, 0xbd, 0xff, 0xff, 0xd5, 0x1d, 0xda, 0xbc, 0x14, 0x12,
-	0x56, 0x7d, 0x04, 0x83, 0xfd, 0x51, 0xf5, 0x91, 0xeb, 0x7c, 0x74, 0xd5, 0xb8, 0xf8, 0x8a, 0x81,
-	0xaf, 0xa2, 0x34, 0x50, 0x46, 0xba, 0xa6, 0xd2, 0xe7, 0x60, 0xdb, 0xa3, 0x1f, 0xa7, 0x0c, 0xd0,
-	0xcd, 0xbc, 0x05, 0x7f, 0xf7, 0x84, 0x0f, 0xf8, 0x88, 0xa2, 0x58, 0xa3, 0x45, 0xa3, 0xd5, 0x6d,
-	0x62, 0xef, 0xd6, 0x49, 0xe8, 0x53, 0x2f, 0x24, 0xb5, 0xf7, 0xbf, 0xf8, 0xc7, 0xe2, 0xc8, 0x0f,
-	0x9f, 0x2c, 0x1a, 0x9f, 0x3f, 0x59, 0x34, 0x1e, 0x3f, 0x59, 0x34, 0xfe, 0xfe, 0x64, 0xd1, 0xf8,
-	0xe4, 0xe9, 0xe2, 0xc8, 0xe3, 0xa7, 0x8b, 0x23, 0x5f, 0x3c, 0x5d, 0x1c, 0xf9, 0xc1, 0x8b, 0xca,
-	0x1f, 0x4a, 0x59, 0x41, 0xc7, 0x72, 0x2c, 0x3f, 0xa0, 0x3b, 0xc4, 0x66, 0xf2, 0x57, 0xfc, 0x77,
-	0x4e, 0xbf, 0x4e, 0xcd, 0x5d, 0x03, 0xe0, 0x8e, 0x10, 0x57, 0xd6, 0x69, 0xe5, 0x9a, 0xef, 0x36,
-	0x33, 0xe0, 0xcb, 0xe5, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0xec, 0x60, 0x34, 0x80, 0xf4, 0x35,
-	0x00, 0x00,
+	0xf5, 0xd7, 0x92, 0xe2, 0xd7, 0x50, 0xa2, 0xa4, 0xd1, 0x87, 0xd7, 0x74, 0x2c, 0x0a, 0xcc, 0x1f,
+	0xff, 0x28, 0x46, 0x42, 0xa6, 0x72, 0x52, 0x04, 0x41, 0xd1, 0xc0, 0x54, 0x94, 0x44, 0x82, 0x9d,
+	0x38, 0x94, 0x8d, 0xb4, 0x45, 0x50, 0x66, 0xb9, 0x3b, 0xa2, 0x56, 0x22, 0x77, 0x36, 0xbb, 0xb3,
+	0x92, 0x95, 0x20, 0x40, 0xd1, 0xa2, 0x45, 0x2e, 0x05, 0x52, 0xb4, 0xf7, 0xe4, 0xdc, 0x5e, 0x7a,
+	0xe9, 0xb5, 0x87, 0xa2, 0x87, 0xf4, 0xe6, 0xa2, 0x28, 0x90, 0x13, 0xdb, 0x3a, 0x09, 0x50, 0xf0,
+	0xd0, 0x9e, 0x7b, 0x2b, 0xe6, 0xcd, 0x2c, 0x39, 0x43, 0x51, 0x90, 0x2c, 0x27, 0x85, 0x21, 0xf0,
+	0x92, 0x98, 0xbf, 0x37, 0xef, 0xcd, 0xdb, 0x37, 0xbf, 0x37, 0xf3, 0xe6, 0x43, 0x68, 0xde, 0xdf,
+	0x6f, 0x55, 0x2d, 0xdf, 0xad, 0x92, 0x03, 0xe2, 0xb1, 0x8a, 0x1f, 0x50, 0x46, 0x71, 0xd2, 0xf2,
+	0xdd, 0x62, 0xa9, 0x45, 0x69, 0xab, 0x4d, 0xaa, 0x00, 0x35, 0xa3, 0x9d, 0x2a, 0x73, 0x3b, 0x24,
+	0x64, 0x56, 0xc7, 0x17, 0xad, 0x8a, 0x7d, 0xd5, 0xf7, 0x22, 0x12, 0x11, 0x09, 0x2e, 0xc4, 0xe0,
+	0x2e, 0xb1, 0xda, 0x6c, 0x57, 0xa2, 0x57, 0x86, 0x6d, 0x91, 0x8e, 0xcf, 0x8e, 0xa4, 0xf0, 0xd9,
+	0x96, 0xcb, 0x76, 0xa3, 0x66, 0xc5, 0xa6, 0x9d, 0x6a, 0x8b, 0xb6, 0xe8, 0xa0, 0x15, 0xff, 0x05,
+	0x3f, 0xe0, 0x5f, 0xb2, 0xf9, 0x13, 0xd2, 0x16, 0xef, 0xc4, 0xf2, 0x3c, 0xca, 0x2c, 0xe6, 0x52,
+	0x2f, 0x94, 0xd2, 0xe7, 0xf7, 0x5f, 0x0c, 0x2b, 0x2e, 0xe5, 0xd2, 0x8e, 0x65, 0xef, 0xba, 0x1e,
+	0x09, 0x8e, 0xaa, 0xb1, 0x4f, 0x01, 0x09, 0x69, 0x14, 0xd8, 0xa4, 0xda, 0x22, 0x1e, 0x09, 0x2c,
+	0x46, 0x1c, 0xa1, 0x55, 0xfe, 0x55, 0x02, 0xcd, 0x6d, 0xd1, 0xe6, 0x76, 0xd4, 0xec, 0xb8, 0x8c,
+	0x11, 0x67, 0x83, 0x07, 0x03, 0x5f, 0x43, 0xe9, 0x3d, 0xda, 0x6c, 0xb8, 0x8e, 0x69, 0xac, 0x18,
+	0xab, 0xb9, 0xda, 0x7c, 0xaf, 0x5b, 0x9a, 0xd9, 0xa3, 0xcd, 0x4d, 0xe7, 0x19, 0xda, 0x71, 0x19,
+	0x7c, 0x43, 0x3d, 0x05, 0x00, 0x7e, 0x1e, 0x21, 0xde, 0x36, 0x24, 0x8c, 0xb7, 0x4f, 0x40, 0xfb,
+	0xa5, 0x5e, 0xb7, 0x84, 0xf7, 0x68, 0x73, 0x9b, 0x30, 0x4d, 0x25, 0x1b, 0x63, 0xf8, 0x69, 0x94,
+	0x82, 0xe0, 0x99, 0xc9, 0x41, 0x07, 0x00, 0xa8, 0x1d, 0x00, 0x80, 0x37, 0x51, 0xc6, 0x0e, 0x08,
+	0xf7, 0xd9, 0x9c, 0x5c, 0x31, 0x56, 0xf3, 0x6b, 0xc5, 0x8a, 0x08, 0x44, 0x25, 0x0e, 0x57, 0xe5,
+	0x4e, 0x3c, 0x40, 0xb5, 0xf9, 0xcf, 0xba, 0xa5, 0x89, 0x5e, 0xb7, 0x14, 0xab, 0x7c, 0xfc, 0xb7,
+	0x92, 0x51, 0x8f, 0x7f, 0xe0, 0xa7, 0x50, 0x72, 0x8f, 0x36, 0xcd, 0x14, 0x98, 0xc9, 0x56, 0x2c,
+	0xdf, 0xad, 0x6c, 0xd1, 0x66, 0x2d, 0x2f, 0x95, 0xb8, 0xb0, 0xce, 0xff, 0x53, 0xfe, 0xa7, 0x81,
+	0x0a, 0x5b, 0xb4, 0xf9, 0x16, 0x77, 0xe0, 0x62, 0xc7, 0xa4, 0xfc, 0xbb, 0x04, 0x5a, 0xda, 0xa2,
+	0xcd, 0x57, 0x22, 0xbf, 0xed, 0xda, 0x16, 0x23, 0xaf, 0xd2, 0xc8, 0xbb, 0xe0, 0x34, 0x58, 0x47,
+	0x33, 0x34, 0x70, 0x5b, 0xae, 0x67, 0xb5, 0x1b, 0xf2, 0x03, 0x53, 0xd0, 0xff, 0x95, 0x5e, 0xb7,
+	0x74, 0x29, 0x16, 0x6d, 0x0d, 0x7d, 0xe8, 0xb4, 0x26, 0x28, 0x7f, 0x9a, 0x00, 0x8a, 0xdc, 0x24,
+	0x56, 0x78, 0xd1, 0xd3, 0xe6, 0xdb, 0x08, 0xd9, 0xed, 0x28, 0x64, 0x24, 0x18, 0x84, 0xea, 0x52,
+	0xaf, 0x5b, 0x9a, 0x97, 0xa8, 0xe6, 0x6c, 0xae, 0x0f, 0x96, 0xff, 0x3d, 0x89, 0x16, 0xe3, 0x10,
+	0xd5, 0x09, 0x8b, 0x02, 0x6f, 0x1c, 0xa9, 0x91, 0x91, 0xc2, 0xcf, 0xa0, 0x74, 0x40, 0xac, 0x90,
+	0x7a, 0x66, 0x1a, 0x74, 0x16, 0x7a, 0xdd, 0xd2, 0xac, 0x40, 0x14, 0x05, 0xd9, 0x06, 0xbf, 0x8c,
+	0xa6, 0xf7, 0xa3, 0x26, 0x09, 0x3c, 0xc2, 0x48, 0xc8, 0x3b, 0xca, 0x80, 0x52, 0xb1, 0xd7, 0x2d,
+	0x2d, 0x0d, 0x04, 0x5a, 0x5f, 0x53, 0x2a, 0xce, 0xdd, 0xf4, 0xa9, 0xd3, 0xf0, 0xa2, 0x4e, 0x93,
+	0x04, 0x66, 0x76, 0xc5, 0x58, 0x4d, 0x09, 0x37, 0x7d, 0xea, 0xbc, 0x01, 0xa0, 0xea, 0x66, 0x1f,
+	0xe4, 0x1d, 0x07, 0x91, 0xd7, 0xb0, 0x18, 0x88, 0x88, 0x63, 0xe6, 0x56, 0x8c, 0xd5, 0xac, 0xe8,
+	0x38, 0x88, 0xbc, 0x1b, 0x31, 0xae, 0x76, 0xac, 0xe2, 0xf8, 0x87, 0xc8, 0x6c, 0x73, 0x36, 0x34,
+	0x3c, 0x72, 0x40, 0x82, 0x86, 0x65, 0xef, 0x7b, 0xf4, 0xb0, 0x4d, 0x9c, 0x16, 0x71, 0x4c, 0x04,
+	0xb6, 0xfe, 0xaf, 0xd7, 0x2d, 0xad, 0x40, 0x9b, 0x37, 0x78, 0x93, 0x1b, 0x4a, 0x0b, 0xc5, 0xea,
+	0xd2, 0xe8, 0x16, 0xe5, 0x7f, 0x19, 0x68, 0x21, 0x66, 0xdc, 0xc6, 0x3d, 0xdf, 0x0d, 0x2e, 0xfa,
+	0xec, 0xfd, 0xf3, 0x49, 0x34, 0xb3, 0x45, 0x9b, 0xb7, 0x89, 0xe7, 0xb8, 0x5e, 0x6b, 0x9c, 0x5c,
+	0xa3, 0x92, 0xeb, 0x58, 0xba, 0xa4, 0x1f, 0x29, 0x5d, 0x32, 0x67, 0x4e, 0x97, 0xe7, 0x50, 0x16,
+	0xf4, 0xac, 0x0e, 0x81, 0x24, 0xcb, 0xd5, 0x16, 0x7b, 0xdd, 0xd2, 0x1c, 0x6f, 0x60, 0x75, 0xd4,
+	0x58, 0x65, 0x24, 0xc4, 0x5d, 0x8d, 0x35, 0x42, 0xdf, 0xb2, 0x89, 0x99, 0x1b, 0xb8, 0x2a, 0xdb,
+	0x00, 0xae, 0xba, 0xaa, 0xe2, 0xe5, 0x3f, 0x08, 0x3e, 0xd4, 0x23, 0xcf, 0x1b, 0xf3, 0xe1, 0x9b,
+	0xe2, 0xc3, 0x75, 0x94, 0xf3, 0xa8, 0x43, 0xc4, 0xc0, 0x66, 0x06, 0x31, 0xe2, 0xe0, 0xd0, 0xc8,
+	0x66, 0x63, 0xec, 0xdc, 0x73, 0xae, 0x4a, 0xa2, 0xdc, 0xf9, 0x48, 0x84, 0x1e, 0x92, 0x44, 0xbf,
+	0x4d, 0xa3, 0x79, 0x5e, 0xe4, 0x78, 0xad, 0x80, 0x84, 0xe1, 0xa6, 0xb7, 0x43, 0xc7, 0x44, 0xba,
+	0x58, 0x44, 0x42, 0xe7, 0x23, 0x52, 0xfe, 0xe1, 0x88, 0x84, 0x3f, 0x40, 0x73, 0xae, 0x20, 0x51,
+	0xc3, 0x72, 0x1c, 0xfe, 0x7f, 0x12, 0x9a, 0xb9, 0x95, 0xe4, 0x6a, 0x7e, 0xad, 0x12, 0xef, 0xbe,
+	0x86, 0x59, 0x56, 0x91, 0xc0, 0x8d, 0x58, 0x61, 0xc3, 0x63, 0xc1, 0x51, 0x6d, 0xb9, 0xd7, 0x2d,
+	0x15, 0xdd, 0x21, 0x91, 0xd2, 0xf1, 0xec, 0xb0, 0xac, 0xb8, 0x8f, 0x16, 0x47, 0x9a, 0xc2, 0x4f,
+	0xa2, 0xe4, 0x3e, 0x39, 0x02, 0x0e, 0xa7, 0x6a, 0x73, 0xbd, 0x6e, 0x69, 0x7a, 0x9f, 0x1c, 0x29,
+	0xa6, 0xb8, 0x94, 0x33, 0xf1, 0xc0, 0x6a, 0x47, 0xc4, 0x4c, 0x0c, 0x98, 0x08, 0x80, 0xca, 0x44,
+	0x00, 0x5e, 0x4a, 0xbc, 0x68, 0x94, 0xff, 0x33, 0x89, 0xcc, 0x2d, 0xda, 0xbc, 0xeb, 0x59, 0xcd,
+	0x36, 0xb9, 0x43, 0xb7, 0xed, 0x5d, 0xe2, 0x44, 0x6d, 0x32, 0xce, 0x9b, 0xc7, 0xa0, 0xda, 0xd5,
+	0xb2, 0x2c, 0x7b, 0xae, 0x2c, 0xcb, 0x3d, 0xc6, 0x59, 0x56, 0xbe, 0x9f, 0x81, 0x9d, 0xe8, 0xab,
+	0x96, 0xdb, 0x1e, 0xef, 0xaf, 0xbe, 0x0e, 0xc6, 0xbd, 0x83, 0x10, 0xb9, 0xe7, 0xb2, 0x86, 0x4d,
+	0x1d, 0x12, 0x9a, 0x19, 0x98, 0xaf, 0xca, 0xf1, 0x7c, 0xa5, 0x84, 0xb9, 0xb2, 0x71, 0xcf, 0x65,
+	0xeb, 0xd4, 0x91, 0x13, 0x4b, 0xed, 0x32, 0xf7, 0x84, 0xc4, 0xd8, 0xc0, 0xb0, 0x69, 0xd4, 0x73,
+	0x7d, 0xf8, 0x38, 0x9f, 0xb3, 0x8f, 0xc2, 0xe7, 0xdc, 0xb9, 0xf8, 0x8c, 0xce, 0xc5, 0xe7, 0xe9,
+	0xf3, 0xf1, 0xb9, 0xf0, 0x90, 0xab, 0x86, 0x83, 0xb0, 0x4d, 0x3d, 0x66, 0xf1, 0x23, 0xcc, 0x46,
+	0xc8, 0x2c, 0x16, 0xf1, 0x65, 0x23, 0x0f, 0xc3, 0xb0, 0x00, 0xc3, 0xb0, 0x1e, 0x8b, 0xb7, 0x41,
+	0x5a, 0x2b, 0xf5, 0xba, 0xa5, 0x2b, 0xb6, 0x0e, 0x6a, 0xab, 0xc3, 0xdc, 0x31, 0x21, 0x7e, 0x01,
+	0xa5, 0x6c, 0x2b, 0x0a, 0x89, 0x39, 0xb5, 0x62, 0xac, 0x16, 0xd6, 0x90, 0x30, 0xcc, 0x11, 0x41,
+	0x66, 0x10, 0xaa, 0x64, 0x06, 0xa0, 0xe8, 0xa0, 0x82, 0x3e, 0xea, 0xea, 0x72, 0x92, 0x3b, 0xdb,
+	0x72, 0x92, 0x3a, 0x75, 0x39, 0xf9, 0x2a, 0x09, 0xc7, 0xb2, 0xb7, 0x03, 0x22, 0x36, 0xce, 0xe3,
+	0xac, 0x1e, 0x95, 0xd5, 0xd7, 0x50, 0x9a, 0x1f, 0x47, 0xf4, 0x0b, 0x2f, 0x70, 0x37, 0x88, 0x3c,
+	0x3d, 0x1e, 0x00, 0xe0, 0x4d, 0x34, 0xe7, 0x8b, 0x68, 0xba, 0x07, 0x24, 0x3e, 0xf5, 0x13, 0x2b,
+	0xc9, 0xd5, 0x5e, 0xb7, 0x74, 0x79, 0x20, 0x1c, 0x3e, 0xf7, 0x9b, 0x19, 0x12, 0x0d, 0x99, 0x92,
+	0x1e, 0x64, 0x47, 0x99, 0xaa, 0x47, 0xde, 0x49, 0xa6, 0x40, 0x54, 0xde, 0x40, 0xa6, 0x3e, 0xa5,
+	0xac, 0xd3, 0x8e, 0x0f, 0xb5, 0x0a, 0x8c, 0x05, 0x5c, 0x4d, 0xc0, 0x60, 0x4f, 0x89, 0x8f, 0x03,
+	0x40, 0xfd, 0x38, 0x00, 0xca, 0x7f, 0x9c, 0x94, 0xa7, 0xf8, 0xb6, 0x4d, 0x88, 0x33, 0xa6, 0xcb,
+	0x78, 0xdf, 0x77, 0xae, 0x7d, 0xdf, 0x27, 0x39, 0xd8, 0xf7, 0xdd, 0x65, 0x6e, 0xdb, 0x0d, 0xe1,
+	0x72, 0x69, 0x4c, 0xa4, 0x6f, 0x84, 0x48, 0x1f, 0x19, 0x68, 0xf1, 0x96, 0x75, 0xaf, 0x2e, 0x6f,
+	0xe5, 0xc2, 0x57, 0x69, 0x70, 0x9b, 0x04, 0x2e, 0x75, 0x64, 0xb1, 0x71, 0x3d, 0x2e, 0x36, 0x86,
+	0x87, 0xa2, 0x32, 0x52, 0x4b, 0x54, 0x1f, 0x57, 0xe5, 0xb7, 0x8e, 0xb6, 0x5c, 0x1f, 0x0d, 0x5f,
+	0xf4, 0xe2, 0x18, 0xff, 0xcc, 0x40, 0x4b, 0x8c, 0x32, 0xab, 0xdd, 0xb0, 0xa3, 0x4e, 0xd4, 0xb6,
+	0x60, 0xce, 0x8e, 0x42, 0xab, 0xc5, 0x17, 0x7e, 0x1e, 0xeb, 0xb5, 0x13, 0x63, 0x7d, 0x87, 0xab,
+	0xad, 0xf7, 0xb5, 0xee, 0x72, 0x25, 0x11, 0xea, 0x27, 0x64, 0xa8, 0x17, 0xd8, 0x88, 0x26, 0xf5,
+	0x91, 0x68, 0xf1, 0x53, 0x03, 0x15, 0x4f, 0x1e, 0xbd, 0xb3, 0x55, 0x11, 0xdf, 0x57, 0xab, 0x08,
+	0xbe, 0x87, 0x16, 0x77, 0xbe, 0x15, 0xf5, 0xce, 0xb7, 0xe2, 0xef, 0xb7, 0xe0, 0x93, 0xe2, 0x3b,
+	0xdf, 0xca, 0x5b, 0x91, 0xe5, 0x31, 0x97, 0x1d, 0x9d, 0x56, 0x75, 0x14, 0x3f, 0x31, 0xd0, 0xe5,
+	0x13, 0x3f, 0xfa, 0x71, 0xf0, 0xb0, 0xfc, 0x95, 0xb8, 0xac, 0xac, 0x13, 0x3f, 0x70, 0x69, 0xe0,
+	0x32, 0xf7, 0xfd, 0x0b, 0x7f, 0xca, 0xf9, 0x1d, 0x34, 0xe5, 0x91, 0xc3, 0x86, 0xfc, 0xe0, 0x23,
+	0x98, 0xa6, 0x0c, 0xd8, 0x6a, 0x2c, 0x7a, 0xe4, 0xf0, 0xb6, 0x84, 0x15, 0x17, 0xf2, 0x0a, 0x8c,
+	0x5f, 0x40, 0xb9, 0x80, 0xbc, 0x17, 0x91, 0x90, 0xd1, 0x40, 0x4e, 0x53, 0x90, 0xa8, 0x7d, 0x50,
+	0x4d, 0xd4, 0x3e, 0x58, 0xfe, 0x32, 0x81, 0x16, 0xf5, 0x38, 0x13, 0x67, 0x1c, 0xe6, 0xaf, 0x3d,
+	0xcc, 0x7f, 0x4e, 0x20, 0xbc, 0x45, 0x9b, 0xeb, 0x96, 0x67, 0x93, 0x76, 0xfb, 0xc2, 0x53, 0x59,
+	0x8b, 0x52, 0xea, 0xac, 0x51, 0x7a, 0xb8, 0xcd, 0x7b, 0xf9, 0xbe, 0x78, 0xd1, 0x22, 0x63, 0x4a,
+	0x9c, 0x71, 0x48, 0x1f, 0x39, 0xa4, 0xbf, 0x9f, 0x04, 0x9a, 0xde, 0x21, 0x41, 0xc7, 0xf5, 0xac,
+	0xf1, 0x76, 0xf4, 0x71, 0xbe, 0x67, 0xfc, 0xdf, 0x6c, 0x15, 0x14, 0x02, 0x65, 0xcf, 0x40, 0xa0,
+	0x3f, 0x25, 0xe0, 0x56, 0xf2, 0xae, 0xef, 0x58, 0x6c, 0x9c, 0x91, 0x23, 0x33, 0x52, 0x3e, 0x4d,
+	0x4b, 0x9f, 0xfa, 0x34, 0xed, 0x37, 0x05, 0x34, 0x05, 0x11, 0xbc, 0x45, 0x42, 0x5e, 0x9c, 0xe1,
+	0x37, 0x51, 0x2e, 0x8c, 0x9f, 0xef, 0x41, 0x2c, 0xf3, 0x6b, 0x4b, 0xb1, 0xbe, 0xfe, 0xae, 0x4f,
+	0x38, 0xd2, 0x6f, 0x3c, 0x70, 0xe4, 0xf5, 0x89, 0xfa, 0xc0, 0x06, 0x5e, 0x47, 0x69, 0x88, 0x8a,
+	0x23, 0x8b, 0xb8, 0xf9, 0xd8, 0x9a, 0xf2, 0x1c, 0x4e, 0x0c, 0xb8, 0x68, 0xa6, 0xd9, 0x91, 0xaa,
+	0xd8, 0x41, 0x33, 0x4e, 0xfc, 0xa4, 0xac, 0xb1, 0xc3, 0xdf, 0x94, 0x99, 0xb3, 0x60, 0xed, 0x4a,
+	0x6c, 0x6d, 0xc4, 0x8b, 0xb3, 0xda, 0x13, 0xbd, 0x6e, 0xc9, 0x74, 0x34, 0x81, 0x66, 0xbd, 0xa0,
+	0xcb, 0xb8, 0xab, 0xf0, 0x12, 0xc4, 0x31, 0x93, 0xba, 0xab, 0xca, 0xb3, 0x2c, 0xe1, 0xaa, 0x68,
+	0xa6, 0xbb, 0x2a, 0x30, 0xfc, 0x2e, 0x2a, 0xc0, 0xbf, 0x1a, 0x81, 0x7c, 0xa3, 0xd4, 0xe7, 0x80,
+	0x6a, 0x4c, 0x7b, 0xc0, 0x24, 0x5e, 0x8a, 0xb5, 0x55, 0x5c, 0x33, 0x3d, 0xad, 0x89, 0xf0, 0x3b,
+	0x48, 0x00, 0x0d, 0x22, 0xde, 0xa4, 0xc8, 0x17, 0x88, 0x97, 0xb5, 0x0e, 0xd4, 0xf7, 0x2a, 0x22,
+	0x13, 0xdb, 0x0a, 0xac, 0x99, 0x9f, 0x52, 0x25, 0xf8, 0x35, 0x94, 0xf1, 0xc5, 0xfb, 0x0f, 0x49,
+	0x9f, 0x85, 0xd8, 0xae, 0xfa, 0x2c, 0x44, 0xce, 0x09, 0x02, 0xd1, 0xac, 0xc5, 0xda, 0xdc, 0x50,
+	0x20, 0x1e, 0x0e, 0x98, 0x19, 0xdd, 0x90, 0xfa, 0x9e, 0x40, 0x18, 0x92, 0x0d, 0x75, 0x43, 0x12,
+	0xc4, 0x1d, 0x84, 0x23, 0xb8, 0x09, 0x6b, 0x30, 0xda, 0x08, 0xe5, 0x5d, 0x18, 0xcc, 0x14, 0xf9,
+	0xb5, 0xab, 0xfd, 0xfd, 0xd6, 0xa8, 0xbb, 0x32, 0x71, 0xcf, 0x17, 0x0d, 0x89, 0xb4, 0x5e, 0x66,
+	0x87, 0xa5, 0x9c, 0x05, 0x3b, 0x70, 0x84, 0x66, 0xe6, 0x74, 0x16, 0x28, 0x07, 0x6b, 0x82, 0x05,
+	0xa2, 0x99, 0xce, 0x02, 0x81, 0x89, 0x34, 0x92, 0xe7, 0x67, 0x26, 0x1a, 0x4e, 0x23, 0xf5, 0x60,
+	0x2d, 0x4e, 0x23, 0x89, 0x0d, 0xa7, 0x91, 0x84, 0x71, 0x03, 0x4d, 0x07, 0x6a, 0xfd, 0x6c, 0xe6,
+	0x75, 0x56, 0x1d, 0x2f, 0xae, 0x05, 0xab, 0x34, 0x25, 0x9d, 0x55, 0x9a, 0x08, 0x6f, 0x23, 0x64,
+	0xf7, 0x2b, 0x47, 0x38, 0xc6, 0xce, 0xaf, 0x5d, 0x8a, 0xad, 0x0f, 0xd5, 0x94, 0x35, 0x93, 0x6f,
+	0x57, 0x07, 0xcd, 0x35, 0xbb, 0x8a, 0x19, 0x1e, 0x06, 0xf9, 0x8b, 0x38, 0xe6, 0xb4, 0x1e, 0x06,
+	0xbd, 0xa6, 0x92, 0x6b, 0x62, 0x8c, 0xe9, 0x61, 0xe8, 0xc3, 0xdc, 0x4b, 0xd6, 0x2f, 0x1c, 0xcc,
+	0x82, 0xee, 0xe5, 0x50, 0x49, 0x21, 0xbc, 0x1c, 0x34, 0xd7, 0xbd, 0x1c, 0xe0, 0xf8, 0x6d, 0x94,
+	0x8f, 0x06, 0xdb, 0x75, 0x73, 0x06, 0xac, 0x9a, 0x27, 0xed, 0xe4, 0x45, 0x19, 0xaf, 0x28, 0x68,
+	0x76, 0x55, 0x4b, 0xf8, 0x7b, 0x68, 0x2a, 0xbe, 0xb1, 0x76, 0xbd, 0x1d, 0x6a, 0xce, 0xe9, 0x96,
+	0x87, 0x2f, 0xab, 0x85, 0x65, 0x77, 0x80, 0xea, 0x96, 0x15, 0x01, 0xb6, 0x51, 0x21, 0xd0, 0xb6,
+	0xad, 0x26, 0xd6, 0xe7, 0xc3, 0x11, 0x9b, 0x5a, 0x31, 0x1f, 0xea, 0x6a, 0xfa, 0x7c, 0xa8, 0xcb,
+	0x78, 0x06, 0x47, 0x62, 0x91, 0x35, 0xe7, 0xf5, 0x0c, 0x56, 0xd7, 0x5e, 0x91, 0xc1, 0xb2, 0xa1,
+	0x9e, 0xc1, 0x12, 0xc4, 0xfb, 0x48, 0xe6, 0xca, 0xe0, 0x40, 0xda, 0x5c, 0xd0, 0xf3, 0x77, 0xe4,
+	0xa9, 0xb5, 0xc8, 0xdf, 0x61, 0x55, 0x3d, 0x7f, 0x87, 0xa5, 0x9c, 0x73, 0x7e, 0x7c, 0xd3, 0x61,
+	0x2e, 0xea, 0x9c, 0xd3, 0xaf, 0x40, 0x64, 0x39, 0x14, 0x63, 0x3a, 0xe7, 0xfa, 0x70, 0x2d, 0x8b,
+	0xd2, 0x70, 0x30, 0x1e, 0x96, 0x7f, 0x92, 0x40, 0x33, 0x43, 0xb7, 0x45, 0xf8, 0xff, 0xd1, 0x24,
+	0x94, 0x4a, 0xa2, 0xee, 0xc0, 0xbd, 0x6e, 0xa9, 0xe0, 0xe9, 0x75, 0x12, 0xc8, 0xf1, 0x1a, 0xca,
+	0xc6, 0xb7, 0x76, 0xf2, 0xda, 0x06, 0x6a, 0x8e, 0x18, 0x53, 0x6b, 0x8e, 0x18, 0xc3, 0x55, 0x94,
+	0xe9, 0x88, 0x75, 0x59, 0x56, 0x1d, 0x10, 0x6a, 0x09, 0xa9, 0x95, 0x98, 0x84, 0x94, 0x42, 0x6a,
+	0xf2, 0x0c, 0x37, 0x93, 0xfd, 0x4b, 0xab, 0xd4, 0xc3, 0x5c, 0x5a, 0x95, 0x6f, 0xa2, 0x1c, 0x84,
+	0xef, 0xa6, 0x1b, 0x32, 0xfc, 0x72, 0x1c, 0x1c, 0xd3, 0x80, 0x03, 0xb0, 0x39, 0x30, 0xa2, 0x96,
+	0x14, 0xc2, 0x09, 0xd1, 0x48, 0x75, 0x42, 0xc6, 0xf4, 0x7d, 0x84, 0xa1, 0xf5, 0x36, 0x0b, 0x88,
+	0xd5, 0x91, 0x3a, 0x78, 0x05, 0x25, 0xfa, 0xb5, 0xdc, 0x6c, 0xaf, 0x5b, 0x9a, 0x72, 0xd5, 0xaa,
+	0x2c, 0xe1, 0x3a, 0xb8, 0x36, 0x88, 0x8d, 0x28, 0x2c, 0x46, 0xf4, 0x7c, 0x4a, 0xb8, 0xca, 0x3f,
+	0x4d, 0xa2, 0xe9, 0x2d, 0x28, 0xf0, 0xea, 0xa2, 0x74, 0x3a, 0x43, 0xbf, 0x4f, 0xa3, 0xd4, 0xa1,
+	0xc5, 0xec, 0x5d, 0xe8, 0x35, 0x2b, 0x02, 0x05, 0x80, 0x1a, 0x28, 0x00, 0xf8, 0xcb, 0xf0, 0x9d,
+	0x80, 0x76, 0x1a, 0xb2, 0x3b, 0x5e, 0x6d, 0x26, 0x07, 0x2f, 0xc3, 0xb9, 0x48, 0x3a, 0xaa, 0xbf,
+	0x0c, 0xd7, 0x04, 0x83, 0xba, 0x73, 0xf2, 0xd4, 0xba, 0xf3, 0x15, 0x54, 0x20, 0x41, 0x40, 0x83,
+	0xcd, 0x9d, 0x5b, 0x6e, 0x18, 0xf2, 0x49, 0x21, 0x05, 0x3e, 0x42, 0xde, 0xeb, 0x12, 0x45, 0x79,
+	0x48, 0x87, 0x9f, 0x5d, 0xec, 0xd0, 0xc0, 0x26, 0x8d, 0x36, 0x69, 0x59, 0xf6, 0x11, 0x54, 0x01,
+	0x59, 0x31, 0x35, 0x01, 0x7e, 0x13, 0x60, 0xf5, 0xec, 0x42, 0x81, 0xf9, 0x09, 0xb0, 0xd0, 0xf6,
+	0xc8, 0x21, 0xac, 0xfb, 0x59, 0xc1, 0x73, 0x00, 0xdf, 0x20, 0x87, 0x2a, 0xcf, 0x63, 0xac, 0xfc,
+	0x8b, 0x04, 0x9a, 0x7a, 0x9b, 0x87, 0x2c, 0x1e, 0x86, 0xfe, 0x47, 0x1b, 0xa7, 0x7e, 0xf4, 0xf9,
+	0xaa, 0xf9, 0x67, 0x51, 0x06, 0x86, 0xa6, 0x3f, 0x24, 0x62, 0x41, 0x0f, 0x68, 0x47, 0x53, 0x48,
+	0x0b, 0xe4, 0x58, 0x4c, 0x26, 0xcf, 0x1f, 0x93, 0xd4, 0xd9, 0x62, 0x72, 0xed, 0xbb, 0x28, 0x05,
+	0xa9, 0x88, 0x73, 0x28, 0xb5, 0xc1, 0x47, 0x68, 0x76, 0x02, 0xe7, 0x51, 0x66, 0xe3, 0xc0, 0xb5,
+	0x19, 0x71, 0x66, 0x0d, 0x9c, 0x41, 0xc9, 0x37, 0xdf, 0xbc, 0x35, 0x9b, 0xc0, 0x0b, 0x68, 0xf6,
+	0x15, 0x62, 0x39, 0x6d, 0xd7, 0x23, 0x1b, 0xf7, 0x44, 0xb9, 0x30, 0x9b, 0x5c, 0xfb, 0x6b, 0x02,
+	0xa5, 0xc4, 0xde, 0xe8, 0x45, 0x54, 0xa8, 0x13, 0x9f, 0x06, 0xec, 0x56, 0xd4, 0x66, 0xae, 0xdf,
+	0x26, 0xb8, 0x30, 0x48, 0x15, 0x9e, 0xc4, 0xc5, 0xa5, 0x63, 0xfb, 0x93, 0x0d, 0xee, 0x0d, 0xbe,
+	0x8e, 0xd2, 0x42, 0x13, 0x1f, 0x4f, 0xae, 0x13, 0x95, 0x08, 0x9a, 0x79, 0x8d, 0x30, 0x91, 0x56,
+	0xa0, 0x10, 0x62, 0xdc, 0x2f, 0x7d, 0xfa, 0x99, 0x56, 0xbc, 0x34, 0xb0, 0xa8, 0xa5, 0x7e, 0xf9,
+	0xc9, 0x1f, 0xff, 0xe5, 0xcb, 0x5f, 0x26, 0xae, 0x96, 0xcd, 0xea, 0xc1, 0xb7, 0xaa, 0x7b, 0xb4,
+	0xf9, 0x6c, 0x48, 0x58, 0xf5, 0x03, 0x18, 0xec, 0x0f, 0xab, 0x1f, 0xb8, 0xce, 0x87, 0x2f, 0x19,
+	0xd7, 0x9e, 0x33, 0xf0, 0x4b, 0x28, 0x05, 0x94, 0x91, 0xae, 0xa9, 0xf4, 0x39, 0xd9, 0x76, 0xf2,
+	0xa3, 0x84, 0x01, 0xba, 0xe9, 0xd7, 0xe1, 0xef, 0xaa, 0xf0, 0x09, 0x1f, 0x51, 0x14, 0x6b, 0xb4,
+	0x68, 0xb4, 0xbe, 0x4b, 0xec, 0xfd, 0x3a, 0x09, 0x7d, 0xea, 0x85, 0xa4, 0xf6, 0xee, 0xe7, 0xff,
+	0x58, 0x9e, 0xf8, 0xd1, 0x83, 0x65, 0xe3, 0xb3, 0x07, 0xcb, 0xc6, 0xfd, 0x07, 0xcb, 0xc6, 0xdf,
+	0x1f, 0x2c, 0x1b, 0x1f, 0x7f, 0xb1, 0x3c, 0x71, 0xff, 0x8b, 0xe5, 0x89, 0xcf, 0xbf, 0x58, 0x9e,
+	0xf8, 0xc1, 0x53, 0xca, 0x1f, 0x62, 0x59, 0x41, 0xc7, 0x72, 0x2c, 0x3f, 0xa0, 0x7b, 0xc4, 0x66,
+	0xf2, 0x57, 0xfc, 0x77, 0x54, 0xbf, 0x4e, 0x2c, 0xdc, 0x00, 0xe0, 0xb6, 0x10, 0x57, 0x36, 0x69,
+	0xe5, 0x86, 0xef, 0x36, 0xd3, 0xe0, 0xcb, 0xf5, 0xff, 0x0e, 0x00, 0xd3, 0xb9, 0x75, 0x3e, 0x54,
+	0x36, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3473,6 +3484,16 @@ func (m *JobLeaseReturnedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.LeaseNeverAcknowledged {
+		i--
+		if m.LeaseNeverAcknowledged {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
 	if m.RunAttempted {
 		i--
 		if m.RunAttempted {
@@ -5795,6 +5816,9 @@ func (m *JobLeaseReturnedEvent) Size() (n int) {
 	if m.RunAttempted {
 		n += 2
 	}
+	if m.LeaseNeverAcknowledged {
+		n += 2
+	}
 	return n
 }
 
@@ -6897,6 +6921,7 @@ func (this *JobLeaseReturnedEvent) String() string {
 		`KubernetesId:` + fmt.Sprintf("%v", this.KubernetesId) + `,`,
 		`PodNumber:` + fmt.Sprintf("%v", this.PodNumber) + `,`,
 		`RunAttempted:` + fmt.Sprintf("%v", this.RunAttempted) + `,`,
+		`LeaseNeverAcknowledged:` + fmt.Sprintf("%v", this.LeaseNeverAcknowledged) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -8624,6 +8649,26 @@ func (m *JobLeaseReturnedEvent) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.RunAttempted = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseNeverAcknowledged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LeaseNeverAcknowledged = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
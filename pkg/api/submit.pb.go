@@ -855,6 +855,17 @@ type Queue struct {
 	GroupOwners    []string             `protobuf:"bytes,4,rep,name=group_owners,json=groupOwners,proto3" json:"groupOwners,omitempty"`
 	ResourceLimits map[string]float64   `protobuf:"bytes,5,rep,name=resource_limits,json=resourceLimits,proto3" json:"resourceLimits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
 	Permissions    []*Queue_Permissions `protobuf:"bytes,6,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// Maximum number of jobs that may be queued in this queue at any one time.
+	// Jobs submitted beyond this are failed by the scheduler. If zero, the scheduler's default applies.
+	MaxQueuedJobs uint32 `protobuf:"varint,7,opt,name=max_queued_jobs,json=maxQueuedJobs,proto3" json:"maxQueuedJobs,omitempty"`
+	// Highest priority jobs in this queue may have, i.e., the smallest priority value, since jobs with smaller values
+	// are scheduled first. Jobs submitted or reprioritised above it are clamped to it by the scheduler.
+	// If zero, priorities aren't capped.
+	MaxJobPriority uint32 `protobuf:"varint,8,opt,name=max_job_priority,json=maxJobPriority,proto3" json:"maxJobPriority,omitempty"`
+	// Determines whether running jobs of this queue may be preempted: "never", "standard", or "aggressive".
+	// Jobs of never-preempt queues are never selected as preemption victims, whereas jobs of aggressive queues
+	// are preferred victims. If empty, "standard" applies.
+	PreemptionPolicy string `protobuf:"bytes,9,opt,name=preemption_policy,json=preemptionPolicy,proto3" json:"preemptionPolicy,omitempty"`
 }
 
 func (m *Queue) Reset()      { *m = Queue{} }
@@ -931,6 +942,27 @@ func (m *Queue) GetPermissions() []*Queue_Permissions {
 	return nil
 }
 
+func (m *Queue) GetMaxQueuedJobs() uint32 {
+	if m != nil {
+		return m.MaxQueuedJobs
+	}
+	return 0
+}
+
+func (m *Queue) GetMaxJobPriority() uint32 {
+	if m != nil {
+		return m.MaxJobPriority
+	}
+	return 0
+}
+
+func (m *Queue) GetPreemptionPolicy() string {
+	if m != nil {
+		return m.PreemptionPolicy
+	}
+	return ""
+}
+
 type Queue_Permissions struct {
 	Subjects []*Queue_Permissions_Subject `protobuf:"bytes,1,rep,name=subjects,proto3" json:"subjects,omitempty"`
 	Verbs    []string                     `protobuf:"bytes,2,rep,name=verbs,proto3" json:"verbs,omitempty"`
@@ -1759,153 +1791,158 @@ func init() {
 func init() { proto.RegisterFile("pkg/api/submit.proto", fileDescriptor_e998bacb27df16c1) }
 
 var fileDescriptor_e998bacb27df16c1 = []byte{
-	// 2324 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xcd, 0x6f, 0x1b, 0xd7,
-	0x11, 0xd7, 0x8a, 0x12, 0x25, 0x0e, 0xf5, 0x41, 0x3d, 0x7d, 0xad, 0xd6, 0x0a, 0xa9, 0x6e, 0x9a,
-	0x56, 0x16, 0x12, 0xb2, 0x56, 0x6a, 0xd4, 0x76, 0x03, 0x18, 0xa6, 0x44, 0xdb, 0x72, 0x1c, 0x59,
-	0x96, 0xac, 0x26, 0xe9, 0xa1, 0xcc, 0x92, 0x3b, 0xa2, 0x56, 0x22, 0x77, 0xe9, 0xfd, 0x90, 0xe1,
-	0x16, 0x01, 0x82, 0x1e, 0x5a, 0xf4, 0x16, 0xa0, 0xc7, 0xfe, 0x07, 0xe9, 0x3f, 0xd2, 0x63, 0x80,
-	0x5e, 0xd2, 0x0b, 0xd1, 0xda, 0xfd, 0x00, 0x78, 0xeb, 0xbd, 0x87, 0xe2, 0xcd, 0xdb, 0xe5, 0xbe,
-	0x25, 0x29, 0x5b, 0x32, 0xe0, 0xf6, 0xa6, 0xf7, 0x7b, 0x33, 0xbf, 0x99, 0x79, 0x6f, 0xde, 0xcc,
-	0x2c, 0x05, 0x0b, 0xed, 0xd3, 0x46, 0xc9, 0x68, 0x5b, 0x25, 0x2f, 0xa8, 0xb5, 0x2c, 0xbf, 0xd8,
-	0x76, 0x1d, 0xdf, 0x61, 0x29, 0xa3, 0x6d, 0x69, 0x57, 0x1a, 0x8e, 0xd3, 0x68, 0x62, 0x89, 0xa0,
-	0x5a, 0x70, 0x54, 0xc2, 0x56, 0xdb, 0x7f, 0x2e, 0x24, 0x34, 0xfd, 0xf4, 0x86, 0x57, 0xb4, 0x1c,
-	0x52, 0xad, 0x3b, 0x2e, 0x96, 0xce, 0xae, 0x95, 0x1a, 0x68, 0xa3, 0x6b, 0xf8, 0x68, 0x86, 0x32,
-	0xab, 0x21, 0x01, 0x97, 0x31, 0x6c, 0xdb, 0xf1, 0x0d, 0xdf, 0x72, 0x6c, 0x2f, 0xdc, 0xfd, 0xa0,
-	0x61, 0xf9, 0xc7, 0x41, 0xad, 0x58, 0x77, 0x5a, 0xa5, 0x86, 0xd3, 0x70, 0x62, 0x3b, 0x7c, 0x45,
-	0x0b, 0xfa, 0x2b, 0x14, 0xef, 0x39, 0x7a, 0x8c, 0x46, 0xd3, 0x3f, 0x16, 0xa8, 0xde, 0xcd, 0xc0,
-	0xc2, 0x03, 0xa7, 0x76, 0x40, 0xce, 0xef, 0xe3, 0xd3, 0x00, 0x3d, 0x7f, 0xc7, 0xc7, 0x16, 0xdb,
-	0x84, 0xc9, 0xb6, 0x6b, 0x39, 0xae, 0xe5, 0x3f, 0x57, 0x95, 0x35, 0x65, 0x5d, 0x29, 0x2f, 0x75,
-	0x3b, 0x05, 0x16, 0x61, 0xef, 0x3b, 0x2d, 0xcb, 0xa7, 0x78, 0xf6, 0x7b, 0x72, 0xec, 0x3a, 0x64,
-	0x6c, 0xa3, 0x85, 0x5e, 0xdb, 0xa8, 0xa3, 0x9a, 0x5a, 0x53, 0xd6, 0x33, 0xe5, 0xe5, 0x6e, 0xa7,
-	0x30, 0xdf, 0x03, 0x25, 0xad, 0x58, 0x92, 0x7d, 0x08, 0x99, 0x7a, 0xd3, 0x42, 0xdb, 0xaf, 0x5a,
-	0xa6, 0x3a, 0x49, 0x6a, 0x64, 0x4b, 0x80, 0x3b, 0xa6, 0x6c, 0x2b, 0xc2, 0xd8, 0x01, 0xa4, 0x9b,
-	0x46, 0x0d, 0x9b, 0x9e, 0x3a, 0xb6, 0x96, 0x5a, 0xcf, 0x6e, 0xbe, 0x57, 0x34, 0xda, 0x56, 0x71,
-	0x58, 0x28, 0xc5, 0x87, 0x24, 0x57, 0xb1, 0x7d, 0xf7, 0x79, 0x79, 0xa1, 0xdb, 0x29, 0xe4, 0x84,
-	0xa2, 0x44, 0x1b, 0x52, 0xb1, 0x06, 0x64, 0xa5, 0x73, 0x56, 0xc7, 0x89, 0x79, 0xe3, 0x7c, 0xe6,
-	0x3b, 0xb1, 0xb0, 0xa0, 0x5f, 0xe9, 0x76, 0x0a, 0x8b, 0x12, 0x85, 0x64, 0x43, 0x66, 0x66, 0xbf,
-	0x55, 0x60, 0xc1, 0xc5, 0xa7, 0x81, 0xe5, 0xa2, 0x59, 0xb5, 0x1d, 0x13, 0xab, 0x61, 0x30, 0x69,
-	0x32, 0x79, 0xed, 0x7c, 0x93, 0xfb, 0xa1, 0xd6, 0xae, 0x63, 0xa2, 0x1c, 0x98, 0xde, 0xed, 0x14,
-	0x56, 0xdd, 0x81, 0xcd, 0xd8, 0x01, 0x55, 0xd9, 0x67, 0x83, 0xfb, 0xec, 0x11, 0x4c, 0xb6, 0x1d,
-	0xb3, 0xea, 0xb5, 0xb1, 0xae, 0x8e, 0xae, 0x29, 0xeb, 0xd9, 0xcd, 0x2b, 0x45, 0x91, 0x9a, 0xe4,
-	0x03, 0x4f, 0xcd, 0xe2, 0xd9, 0xb5, 0xe2, 0x9e, 0x63, 0x1e, 0xb4, 0xb1, 0x4e, 0xf7, 0x39, 0xd7,
-	0x16, 0x8b, 0x04, 0xf7, 0x44, 0x08, 0xb2, 0x3d, 0xc8, 0x44, 0x84, 0x9e, 0x3a, 0x41, 0xe1, 0xbc,
-	0x92, 0x51, 0xa4, 0x95, 0x58, 0x78, 0x89, 0xb4, 0x0a, 0x31, 0xb6, 0x05, 0x13, 0x96, 0xdd, 0x70,
-	0xd1, 0xf3, 0xd4, 0x0c, 0xf1, 0x31, 0x22, 0xda, 0x11, 0xd8, 0x96, 0x63, 0x1f, 0x59, 0x8d, 0xf2,
-	0x22, 0x77, 0x2c, 0x14, 0x93, 0x58, 0x22, 0x4d, 0x76, 0x17, 0x26, 0x3d, 0x74, 0xcf, 0xac, 0x3a,
-	0x7a, 0x2a, 0x48, 0x2c, 0x07, 0x02, 0x0c, 0x59, 0xc8, 0x99, 0x48, 0x4e, 0x76, 0x26, 0xc2, 0x78,
-	0x8e, 0x7b, 0xf5, 0x63, 0x34, 0x83, 0x26, 0xba, 0x6a, 0x36, 0xce, 0xf1, 0x1e, 0x28, 0xe7, 0x78,
-	0x0f, 0x64, 0x3b, 0x30, 0xf7, 0x34, 0xc0, 0x00, 0xab, 0xbe, 0xdf, 0xac, 0x7a, 0x58, 0x77, 0x6c,
-	0xd3, 0x53, 0xa7, 0xd6, 0x94, 0xf5, 0x54, 0xf9, 0x9d, 0x6e, 0xa7, 0xb0, 0x42, 0x9b, 0x4f, 0xfc,
-	0xe6, 0x81, 0xd8, 0x92, 0x48, 0x66, 0xfb, 0xb6, 0x34, 0x03, 0xb2, 0xd2, 0xc5, 0xb3, 0x77, 0x21,
-	0x75, 0x8a, 0xe2, 0x8d, 0x66, 0xca, 0x73, 0xdd, 0x4e, 0x61, 0xfa, 0x14, 0xe5, 0xe7, 0xc9, 0x77,
-	0xd9, 0x55, 0x18, 0x3f, 0x33, 0x9a, 0x01, 0xd2, 0x15, 0x67, 0xca, 0xf3, 0xdd, 0x4e, 0x61, 0x96,
-	0x00, 0x49, 0x50, 0x48, 0xdc, 0x1a, 0xbd, 0xa1, 0x68, 0x47, 0x90, 0xeb, 0x4f, 0xed, 0xb7, 0x62,
-	0xa7, 0x05, 0xcb, 0xe7, 0xe4, 0xf3, 0xdb, 0x30, 0xa7, 0xff, 0x3b, 0x05, 0xd3, 0x89, 0xac, 0x61,
-	0xb7, 0x60, 0xcc, 0x7f, 0xde, 0x46, 0x32, 0x33, 0xb3, 0x99, 0x93, 0xf3, 0xea, 0xc9, 0xf3, 0x36,
-	0x52, 0xb9, 0x98, 0xe1, 0x12, 0x89, 0x5c, 0x27, 0x1d, 0x6e, 0xbc, 0xed, 0xb8, 0xbe, 0xa7, 0x8e,
-	0xae, 0xa5, 0xd6, 0xa7, 0x85, 0x71, 0x02, 0x64, 0xe3, 0x04, 0xb0, 0x2f, 0x92, 0x75, 0x25, 0x45,
-	0xf9, 0xf7, 0xee, 0x60, 0x16, 0xbf, 0x79, 0x41, 0xb9, 0x09, 0x59, 0xbf, 0xe9, 0x55, 0xd1, 0x36,
-	0x6a, 0x4d, 0x34, 0xd5, 0xb1, 0x35, 0x65, 0x7d, 0xb2, 0xac, 0x76, 0x3b, 0x85, 0x05, 0x9f, 0x9f,
-	0x28, 0xa1, 0x92, 0x2e, 0xc4, 0x28, 0x95, 0x5f, 0x74, 0xfd, 0x2a, 0x2f, 0xc8, 0xea, 0xb8, 0x54,
-	0x7e, 0xd1, 0xf5, 0x77, 0x8d, 0x16, 0x26, 0xca, 0x6f, 0x88, 0xb1, 0xdb, 0x30, 0x1d, 0x78, 0x58,
-	0xad, 0x37, 0x03, 0xcf, 0x47, 0x77, 0x67, 0x4f, 0x4d, 0x93, 0x45, 0xad, 0xdb, 0x29, 0x2c, 0x05,
-	0x1e, 0x6e, 0x45, 0xb8, 0xa4, 0x3c, 0x25, 0xe3, 0xff, 0xab, 0x14, 0xd3, 0x7d, 0x98, 0x4e, 0x3c,
-	0x71, 0x76, 0x63, 0xc8, 0x95, 0x87, 0x12, 0x74, 0xe5, 0x6c, 0xf0, 0xca, 0x2f, 0x7d, 0xe1, 0xfa,
-	0x5f, 0x14, 0xc8, 0xf5, 0x97, 0x6f, 0xae, 0x4f, 0x6f, 0x39, 0x0c, 0x90, 0xf4, 0x09, 0x90, 0xf5,
-	0x09, 0x60, 0x3f, 0x06, 0x38, 0x71, 0x6a, 0x55, 0x0f, 0xa9, 0x27, 0x8e, 0xc6, 0x97, 0x72, 0xe2,
-	0xd4, 0x0e, 0xb0, 0xaf, 0x27, 0x46, 0x18, 0x33, 0x61, 0x8e, 0x6b, 0xb9, 0xc2, 0x5e, 0x95, 0x0b,
-	0x44, 0xc9, 0xb6, 0x72, 0x6e, 0x47, 0x11, 0xf5, 0xe7, 0xc4, 0xa9, 0x49, 0x58, 0xa2, 0xfe, 0xf4,
-	0x6d, 0xe9, 0xff, 0x11, 0xb1, 0x6d, 0x19, 0x76, 0x1d, 0x9b, 0x51, 0x6c, 0x1b, 0x90, 0xe6, 0xa6,
-	0x2d, 0x53, 0x0e, 0xee, 0xc4, 0xa9, 0x25, 0x3c, 0x1d, 0x27, 0xe0, 0x0d, 0x83, 0xeb, 0x9d, 0x5e,
-	0xea, 0xb5, 0xa7, 0xf7, 0x01, 0x4c, 0x08, 0x67, 0xc4, 0x70, 0x90, 0x11, 0x5d, 0x9f, 0x8c, 0x27,
-	0xba, 0xbe, 0x40, 0xd8, 0xfb, 0x90, 0x76, 0xd1, 0xf0, 0x1c, 0x3b, 0xcc, 0x7e, 0x92, 0x16, 0x88,
-	0x2c, 0x2d, 0x10, 0xfd, 0x1f, 0x0a, 0xcc, 0x3f, 0x20, 0xa7, 0x92, 0x27, 0x90, 0x8c, 0x4a, 0xb9,
-	0x6c, 0x54, 0xa3, 0xaf, 0x8d, 0xea, 0x36, 0xa4, 0x8f, 0xac, 0xa6, 0x8f, 0x2e, 0x9d, 0x40, 0x76,
-	0x73, 0xae, 0x77, 0xa5, 0xe8, 0xdf, 0xa5, 0x0d, 0xe1, 0xb9, 0x10, 0x92, 0x3d, 0x17, 0x88, 0x14,
-	0xe7, 0xd8, 0x05, 0xe2, 0xfc, 0x18, 0xa6, 0x64, 0x6e, 0xf6, 0x53, 0x48, 0x7b, 0xbe, 0xe1, 0xa3,
-	0xa7, 0x2a, 0x6b, 0xa9, 0xf5, 0x99, 0xcd, 0xe9, 0x9e, 0x79, 0x8e, 0x0a, 0x32, 0x21, 0x20, 0x93,
-	0x09, 0x44, 0xff, 0xa7, 0x02, 0x4b, 0x0f, 0x78, 0x1e, 0x85, 0xb3, 0xa2, 0xf5, 0x4b, 0x8c, 0xce,
-	0x4d, 0xba, 0x2c, 0xe5, 0x02, 0x97, 0xf5, 0xd6, 0x93, 0xe7, 0x23, 0x98, 0xb2, 0xf1, 0x59, 0xb5,
-	0x37, 0xfc, 0x8e, 0xd1, 0xf0, 0x4b, 0x75, 0xd8, 0xc6, 0x67, 0x7b, 0x83, 0xf3, 0x6f, 0x56, 0x82,
-	0xf5, 0x3f, 0x8e, 0xc2, 0xf2, 0x40, 0xa0, 0x5e, 0xdb, 0xb1, 0x3d, 0x64, 0x7f, 0x50, 0x40, 0x75,
-	0xe3, 0x0d, 0xaa, 0x7c, 0x55, 0x17, 0xbd, 0xa0, 0xe9, 0x8b, 0xd8, 0xb3, 0x9b, 0x37, 0xa3, 0x43,
-	0x1d, 0x46, 0x50, 0xdc, 0xef, 0x53, 0xde, 0x17, 0xba, 0xa2, 0x53, 0xbc, 0xd7, 0xed, 0x14, 0xbe,
-	0xe7, 0x0e, 0x97, 0x90, 0xbc, 0x5d, 0x3e, 0x47, 0x44, 0x73, 0x61, 0xf5, 0x55, 0xfc, 0x6f, 0xa5,
-	0x38, 0xdb, 0xb0, 0x28, 0x95, 0x24, 0x11, 0x25, 0x7d, 0x7d, 0x5c, 0xa6, 0x9c, 0x5c, 0x85, 0x71,
-	0x74, 0x5d, 0xc7, 0x95, 0x6d, 0x12, 0x20, 0x8b, 0x12, 0xa0, 0x7f, 0x09, 0x73, 0x03, 0xf6, 0xd8,
-	0x31, 0x30, 0x51, 0x35, 0xc5, 0x3a, 0x2c, 0x9b, 0xe2, 0x3e, 0xb4, 0xfe, 0xb2, 0x19, 0xfb, 0x58,
-	0xce, 0x77, 0x3b, 0x05, 0x8d, 0x8a, 0x63, 0x0c, 0xca, 0x27, 0x9d, 0xeb, 0xdf, 0xd3, 0xbf, 0x4a,
-	0xc3, 0xf8, 0x63, 0x4a, 0xb2, 0x1f, 0xc0, 0x18, 0xb5, 0x5b, 0x11, 0x1d, 0xb5, 0x1c, 0x3b, 0xd9,
-	0x6a, 0x69, 0x9f, 0x55, 0x60, 0x36, 0x4a, 0xc4, 0xea, 0x91, 0x51, 0xf7, 0xc3, 0x28, 0x95, 0xf2,
-	0x6a, 0xb7, 0x53, 0x50, 0xa3, 0xad, 0xbb, 0xb4, 0x23, 0x29, 0xcf, 0x24, 0x77, 0xf8, 0x74, 0x10,
-	0x78, 0xe8, 0x56, 0x9d, 0x67, 0x36, 0xba, 0xa2, 0x25, 0x64, 0xc4, 0x74, 0xc0, 0xe1, 0x47, 0x84,
-	0xca, 0xd3, 0x41, 0x8c, 0xf2, 0xe7, 0xd0, 0x70, 0x9d, 0xa0, 0x1d, 0xe9, 0x8a, 0x82, 0x4a, 0xcf,
-	0x81, 0xf0, 0x01, 0xe5, 0xac, 0x04, 0x33, 0x84, 0x59, 0x17, 0x3d, 0x27, 0x70, 0xeb, 0x58, 0x6d,
-	0x5a, 0x2d, 0xcb, 0x8f, 0x3e, 0xaa, 0xf2, 0x74, 0xb0, 0x74, 0x18, 0xc5, 0xfd, 0x50, 0xe2, 0x21,
-	0x09, 0x88, 0x6c, 0xa6, 0xf8, 0xdc, 0xc4, 0x86, 0x1c, 0x5f, 0x72, 0x87, 0x1d, 0x40, 0xb6, 0x8d,
-	0x6e, 0xcb, 0xf2, 0x3c, 0x9a, 0xaf, 0xc4, 0x47, 0xd4, 0x92, 0x64, 0x62, 0x2f, 0xde, 0x15, 0xbe,
-	0x4b, 0xe2, 0xb2, 0xef, 0x12, 0xac, 0xfd, 0x4b, 0x81, 0xac, 0xa4, 0xc7, 0xf6, 0x61, 0xd2, 0x0b,
-	0x6a, 0x27, 0x58, 0xef, 0xbd, 0xd6, 0xfc, 0x70, 0x0b, 0xc5, 0x03, 0x21, 0x16, 0x7e, 0x4d, 0x84,
-	0x3a, 0x89, 0xaf, 0x89, 0x10, 0xa3, 0xf7, 0x82, 0x6e, 0x4d, 0x8c, 0x14, 0xd1, 0x7b, 0xe1, 0x40,
-	0xe2, 0xbd, 0x70, 0x40, 0xfb, 0x1c, 0x26, 0x42, 0x5e, 0x9e, 0x3d, 0xa7, 0x96, 0x6d, 0xca, 0xd9,
-	0xc3, 0xd7, 0x72, 0xf6, 0xf0, 0x75, 0x2f, 0xcb, 0x46, 0x5f, 0x9d, 0x65, 0x9a, 0x05, 0xf3, 0x43,
-	0xee, 0xe0, 0x0d, 0x5e, 0xbc, 0xf2, 0xda, 0x17, 0x5f, 0x81, 0x0c, 0x9d, 0xd7, 0x43, 0xcb, 0xf3,
-	0xd9, 0x0d, 0x48, 0x53, 0xcd, 0x8d, 0xce, 0x13, 0xe2, 0xf3, 0x14, 0x5d, 0x40, 0xec, 0xca, 0x5d,
-	0x40, 0x20, 0xfa, 0x21, 0x30, 0xd1, 0x7d, 0x9b, 0x52, 0xa1, 0xe2, 0x43, 0x69, 0x5d, 0xa0, 0x68,
-	0x4a, 0x0d, 0x85, 0x86, 0xd2, 0xde, 0x46, 0xb2, 0xad, 0x4c, 0xc9, 0xb8, 0x7e, 0x13, 0x66, 0xc9,
-	0xfa, 0x3d, 0xec, 0x0d, 0x6d, 0x17, 0x7c, 0xa9, 0xfa, 0x6d, 0x50, 0x0f, 0x7c, 0x17, 0x8d, 0x96,
-	0x65, 0x37, 0xfa, 0x39, 0xde, 0x85, 0x94, 0x1d, 0xb4, 0x88, 0x62, 0x5a, 0x1c, 0xa4, 0x1d, 0xb4,
-	0xe4, 0x83, 0xb4, 0x83, 0x96, 0x7e, 0x0b, 0x72, 0xa4, 0xb7, 0x63, 0x1f, 0x39, 0x97, 0x35, 0xfe,
-	0x11, 0x30, 0xd2, 0xdd, 0xc6, 0x26, 0xfa, 0x78, 0x59, 0xed, 0xdf, 0x29, 0xe1, 0xa5, 0x70, 0xd3,
-	0x17, 0x2e, 0x4d, 0x4f, 0x60, 0xd6, 0xa8, 0xfb, 0xd6, 0x19, 0x56, 0xc3, 0x7e, 0x2c, 0x92, 0x38,
-	0xbb, 0x39, 0x2b, 0xcd, 0x25, 0x9c, 0xb1, 0x7c, 0xa5, 0xdb, 0x29, 0x2c, 0x0b, 0x59, 0x81, 0xca,
-	0x17, 0x30, 0x9d, 0xd8, 0xd0, 0xbf, 0x51, 0x00, 0x62, 0xd5, 0x0b, 0x3b, 0x73, 0x13, 0xb2, 0x94,
-	0x19, 0x26, 0x77, 0xc6, 0xa3, 0x5c, 0x1c, 0x17, 0x05, 0x4e, 0xc0, 0x0f, 0x9c, 0xc4, 0x93, 0x82,
-	0x18, 0xe5, 0xaa, 0x4d, 0x34, 0xbc, 0x48, 0x35, 0x15, 0xab, 0x0a, 0xb8, 0x5f, 0x35, 0x46, 0xf5,
-	0x67, 0x30, 0x4f, 0xe7, 0x76, 0xd8, 0x36, 0x0d, 0x3f, 0xee, 0xf3, 0xd7, 0xe5, 0x39, 0x3f, 0x99,
-	0xd5, 0xaf, 0x1a, 0x3c, 0x2e, 0xd1, 0xc7, 0x02, 0x50, 0xcb, 0x86, 0x5f, 0x3f, 0x1e, 0x66, 0xfd,
-	0x73, 0x98, 0x3e, 0x32, 0x2c, 0xfe, 0x02, 0x12, 0x6f, 0x4b, 0x8d, 0xbd, 0x48, 0x2a, 0x88, 0xe7,
-	0x21, 0x54, 0x1e, 0xf7, 0xbf, 0xb7, 0x29, 0x19, 0xef, 0xc5, 0xbb, 0xe5, 0xe2, 0xff, 0x31, 0xde,
-	0x3e, 0xeb, 0xaf, 0x8f, 0x37, 0xa9, 0x70, 0x89, 0x78, 0xb3, 0x90, 0xa9, 0xd8, 0xe6, 0x27, 0x86,
-	0x7b, 0x8a, 0xae, 0xfe, 0xb5, 0x02, 0x8b, 0xc9, 0x17, 0xfe, 0x09, 0x7a, 0x9e, 0xd1, 0x40, 0xf6,
-	0x93, 0xcb, 0xc5, 0x7f, 0x7f, 0x24, 0x3a, 0x81, 0xeb, 0x90, 0x42, 0xdb, 0x0c, 0x7f, 0x76, 0x9b,
-	0x21, 0xb5, 0x9e, 0x3d, 0x51, 0x27, 0x50, 0xae, 0xea, 0xf7, 0x47, 0xf6, 0xb9, 0x7c, 0x79, 0x02,
-	0xc6, 0xf1, 0x0c, 0x6d, 0x7f, 0x43, 0x83, 0xac, 0xf4, 0x63, 0x05, 0xcb, 0xc2, 0x44, 0xb8, 0xcc,
-	0x8d, 0x6c, 0x5c, 0x85, 0xac, 0xf4, 0x55, 0xcb, 0xa6, 0x60, 0x72, 0xd7, 0x31, 0x71, 0xcf, 0x71,
-	0xfd, 0xdc, 0x08, 0x5f, 0xdd, 0x47, 0xc3, 0x6c, 0x72, 0x51, 0x65, 0xe3, 0x33, 0x98, 0x8c, 0xc6,
-	0x78, 0x06, 0x90, 0x7e, 0x7c, 0x58, 0x39, 0xac, 0x6c, 0xe7, 0x46, 0x38, 0xdf, 0x5e, 0x65, 0x77,
-	0x7b, 0x67, 0xf7, 0x5e, 0x4e, 0xe1, 0x8b, 0xfd, 0xc3, 0xdd, 0x5d, 0xbe, 0x18, 0x65, 0xd3, 0x90,
-	0x39, 0x38, 0xdc, 0xda, 0xaa, 0x54, 0xb6, 0x2b, 0xdb, 0xb9, 0x14, 0x57, 0xba, 0x7b, 0x67, 0xe7,
-	0x61, 0x65, 0x3b, 0x37, 0xc6, 0xe5, 0x0e, 0x77, 0x3f, 0xde, 0x7d, 0xf4, 0xe9, 0x6e, 0x6e, 0x7c,
-	0xf3, 0x37, 0x19, 0x48, 0x8b, 0xc9, 0x89, 0xfd, 0x0c, 0x40, 0xfc, 0x45, 0x8f, 0x6e, 0x71, 0xe8,
-	0xe7, 0xa8, 0xb6, 0x34, 0x7c, 0xdc, 0xd2, 0x57, 0x7e, 0xfd, 0xe7, 0xbf, 0xff, 0x7e, 0x74, 0x5e,
-	0x9f, 0x29, 0x9d, 0x5d, 0x2b, 0x9d, 0x38, 0xb5, 0xf0, 0xc7, 0xf6, 0x5b, 0xca, 0x06, 0xfb, 0x14,
-	0x40, 0x74, 0x82, 0x24, 0x6f, 0xe2, 0xdb, 0x4c, 0x5b, 0x26, 0x78, 0xb0, 0x63, 0x0c, 0x12, 0x8b,
-	0x76, 0xc0, 0x89, 0x7f, 0x01, 0x53, 0x3d, 0xe2, 0x03, 0xf4, 0x99, 0x2a, 0x95, 0xb5, 0x24, 0xfb,
-	0x52, 0x51, 0xfc, 0x4e, 0x5f, 0x8c, 0x7e, 0x80, 0x2f, 0x56, 0xf8, 0x75, 0xe9, 0xab, 0x44, 0xbe,
-	0xa4, 0xcf, 0x85, 0xe4, 0x1e, 0xfa, 0x12, 0xbf, 0x0d, 0x39, 0x79, 0xc8, 0x27, 0xf7, 0xaf, 0x0c,
-	0x1f, 0xff, 0x85, 0x99, 0xd5, 0x57, 0x7d, 0x1b, 0xe8, 0x05, 0x32, 0xb6, 0xa2, 0x2f, 0x44, 0x91,
-	0x48, 0x73, 0x3e, 0x72, 0x7b, 0xf7, 0x20, 0x2b, 0x1e, 0x82, 0x98, 0x40, 0xa5, 0x2c, 0x3d, 0x37,
-	0x80, 0x05, 0xe2, 0x9c, 0xd1, 0x33, 0x9c, 0x93, 0x52, 0x96, 0x13, 0xd5, 0x61, 0x4a, 0x22, 0xf2,
-	0xd8, 0x4c, 0xcc, 0xc4, 0xbb, 0xba, 0xf6, 0x0e, 0xad, 0xcf, 0x7b, 0xaf, 0xfa, 0xf7, 0x89, 0x34,
-	0xaf, 0xaf, 0x70, 0xd2, 0x1a, 0x97, 0x42, 0xb3, 0x54, 0x27, 0x99, 0xf0, 0x05, 0x73, 0x23, 0xbb,
-	0x90, 0x15, 0x65, 0xea, 0xe2, 0xde, 0x5e, 0x21, 0xe2, 0x45, 0x2d, 0xd7, 0xf3, 0xb6, 0xf4, 0x2b,
-	0xde, 0x1c, 0xbe, 0x0c, 0x9d, 0x96, 0xf8, 0x5e, 0xef, 0x74, 0xb2, 0x46, 0x46, 0x4e, 0x6b, 0x09,
-	0xa7, 0x03, 0x92, 0x91, 0x9c, 0xfe, 0x0c, 0xb2, 0xa2, 0x03, 0x0b, 0xa7, 0x97, 0x63, 0x1b, 0x89,
-	0xc6, 0x7c, 0x6e, 0x04, 0x2a, 0x59, 0x61, 0x1b, 0x03, 0x11, 0xb0, 0xbb, 0x30, 0x79, 0x0f, 0x7d,
-	0x41, 0xbb, 0x10, 0xd3, 0xc6, 0x33, 0x86, 0x26, 0x9d, 0x50, 0xc4, 0xc3, 0x06, 0x79, 0x4c, 0xc8,
-	0x44, 0x3c, 0x1e, 0x13, 0x31, 0x9f, 0x37, 0xb5, 0x68, 0xda, 0x90, 0xed, 0xb0, 0xe4, 0xe9, 0x1a,
-	0x59, 0x58, 0x60, 0x4c, 0x3e, 0x0f, 0x71, 0x10, 0x3f, 0x52, 0xd8, 0x13, 0x98, 0x8a, 0xac, 0x50,
-	0x17, 0x5f, 0x8c, 0x7d, 0x93, 0xa6, 0x1b, 0x6d, 0x26, 0x09, 0xeb, 0xef, 0x10, 0xe9, 0x32, 0x5b,
-	0xec, 0x77, 0xbb, 0x64, 0x71, 0x96, 0x5b, 0x90, 0xbe, 0x4f, 0xff, 0xba, 0x62, 0xe7, 0x9c, 0x9f,
-	0x26, 0x9e, 0xa8, 0x10, 0xda, 0x3a, 0xc6, 0xfa, 0x69, 0xaf, 0xe6, 0x7f, 0xf1, 0xdd, 0xdf, 0xf2,
-	0x23, 0x5f, 0xbd, 0xc8, 0x2b, 0x7f, 0x7a, 0x91, 0x57, 0xbe, 0x7d, 0x91, 0x57, 0xfe, 0xfa, 0x22,
-	0xaf, 0x7c, 0xfd, 0x32, 0x3f, 0xf2, 0xed, 0xcb, 0xfc, 0xc8, 0x77, 0x2f, 0xf3, 0x23, 0x3f, 0xff,
-	0xa1, 0xf4, 0xdf, 0x34, 0xc3, 0x6d, 0x19, 0xa6, 0xd1, 0x76, 0x1d, 0x3e, 0x6d, 0x87, 0xab, 0x52,
-	0xf8, 0xef, 0xb3, 0x6f, 0x46, 0x17, 0xee, 0x10, 0xb0, 0x27, 0xb6, 0x8b, 0x3b, 0x4e, 0xf1, 0x4e,
-	0xdb, 0xaa, 0xa5, 0xc9, 0x97, 0x0f, 0xff, 0x1b, 0x00, 0x00, 0xff, 0xff, 0xcc, 0xc7, 0x3a, 0xe5,
-	0x10, 0x1c, 0x00, 0x00,
+	// 2409 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xcd, 0x6f, 0x1b, 0xc7,
+	0x15, 0xd7, 0x8a, 0x12, 0x25, 0x3e, 0xea, 0x83, 0x1a, 0x7d, 0xad, 0xd6, 0x0a, 0xa9, 0x6e, 0x9a,
+	0x56, 0x11, 0x12, 0xaa, 0x56, 0x6a, 0xd4, 0x56, 0x03, 0x18, 0xa6, 0x44, 0xdb, 0xb2, 0x1d, 0x59,
+	0x16, 0xad, 0x26, 0xe9, 0xa1, 0xcc, 0x92, 0x3b, 0xa2, 0x56, 0x22, 0x77, 0xd7, 0xbb, 0xb3, 0xb2,
+	0xd5, 0x22, 0x40, 0xd1, 0x43, 0x8b, 0xde, 0x02, 0xf4, 0xd8, 0xff, 0x20, 0xfd, 0x47, 0x7a, 0x0c,
+	0xd0, 0x4b, 0xda, 0x03, 0xd1, 0xda, 0xfd, 0x00, 0x78, 0xeb, 0xbd, 0x87, 0x62, 0xde, 0xec, 0x72,
+	0x67, 0x49, 0xca, 0x92, 0x0c, 0xb8, 0xbd, 0x71, 0x7e, 0xef, 0xbd, 0xdf, 0x7b, 0x6f, 0xe6, 0xcd,
+	0x9b, 0x19, 0x2e, 0xcc, 0xb9, 0x27, 0x8d, 0x75, 0xc3, 0xb5, 0xd6, 0xfd, 0xa0, 0xd6, 0xb2, 0x58,
+	0xd1, 0xf5, 0x1c, 0xe6, 0x90, 0x94, 0xe1, 0x5a, 0xda, 0xb5, 0x86, 0xe3, 0x34, 0x9a, 0x74, 0x1d,
+	0xa1, 0x5a, 0x70, 0xb8, 0x4e, 0x5b, 0x2e, 0x3b, 0x13, 0x1a, 0x9a, 0x7e, 0x72, 0xd3, 0x2f, 0x5a,
+	0x0e, 0x9a, 0xd6, 0x1d, 0x8f, 0xae, 0x9f, 0x5e, 0x5f, 0x6f, 0x50, 0x9b, 0x7a, 0x06, 0xa3, 0x66,
+	0xa8, 0xb3, 0x1c, 0x12, 0x70, 0x1d, 0xc3, 0xb6, 0x1d, 0x66, 0x30, 0xcb, 0xb1, 0xfd, 0x50, 0xfa,
+	0x61, 0xc3, 0x62, 0x47, 0x41, 0xad, 0x58, 0x77, 0x5a, 0xeb, 0x0d, 0xa7, 0xe1, 0xc4, 0x7e, 0xf8,
+	0x08, 0x07, 0xf8, 0x2b, 0x54, 0xef, 0x06, 0x7a, 0x44, 0x8d, 0x26, 0x3b, 0x12, 0xa8, 0xde, 0xc9,
+	0xc0, 0xdc, 0x03, 0xa7, 0x56, 0xc1, 0xe0, 0xf7, 0xe9, 0xb3, 0x80, 0xfa, 0x6c, 0x87, 0xd1, 0x16,
+	0xd9, 0x80, 0x71, 0xd7, 0xb3, 0x1c, 0xcf, 0x62, 0x67, 0xaa, 0xb2, 0xa2, 0xac, 0x2a, 0xa5, 0x85,
+	0x4e, 0xbb, 0x40, 0x22, 0xec, 0x03, 0xa7, 0x65, 0x31, 0xcc, 0x67, 0xbf, 0xab, 0x47, 0x6e, 0x40,
+	0xc6, 0x36, 0x5a, 0xd4, 0x77, 0x8d, 0x3a, 0x55, 0x53, 0x2b, 0xca, 0x6a, 0xa6, 0xb4, 0xd8, 0x69,
+	0x17, 0x66, 0xbb, 0xa0, 0x64, 0x15, 0x6b, 0x92, 0x8f, 0x20, 0x53, 0x6f, 0x5a, 0xd4, 0x66, 0x55,
+	0xcb, 0x54, 0xc7, 0xd1, 0x0c, 0x7d, 0x09, 0x70, 0xc7, 0x94, 0x7d, 0x45, 0x18, 0xa9, 0x40, 0xba,
+	0x69, 0xd4, 0x68, 0xd3, 0x57, 0x47, 0x56, 0x52, 0xab, 0xd9, 0x8d, 0xf7, 0x8a, 0x86, 0x6b, 0x15,
+	0x07, 0xa5, 0x52, 0x7c, 0x84, 0x7a, 0x65, 0x9b, 0x79, 0x67, 0xa5, 0xb9, 0x4e, 0xbb, 0x90, 0x13,
+	0x86, 0x12, 0x6d, 0x48, 0x45, 0x1a, 0x90, 0x95, 0xe6, 0x59, 0x1d, 0x45, 0xe6, 0xb5, 0xf3, 0x99,
+	0xef, 0xc4, 0xca, 0x82, 0x7e, 0xa9, 0xd3, 0x2e, 0xcc, 0x4b, 0x14, 0x92, 0x0f, 0x99, 0x99, 0xfc,
+	0x46, 0x81, 0x39, 0x8f, 0x3e, 0x0b, 0x2c, 0x8f, 0x9a, 0x55, 0xdb, 0x31, 0x69, 0x35, 0x4c, 0x26,
+	0x8d, 0x2e, 0xaf, 0x9f, 0xef, 0x72, 0x3f, 0xb4, 0xda, 0x75, 0x4c, 0x2a, 0x27, 0xa6, 0x77, 0xda,
+	0x85, 0x65, 0xaf, 0x4f, 0x18, 0x07, 0xa0, 0x2a, 0xfb, 0xa4, 0x5f, 0x4e, 0x1e, 0xc3, 0xb8, 0xeb,
+	0x98, 0x55, 0xdf, 0xa5, 0x75, 0x75, 0x78, 0x45, 0x59, 0xcd, 0x6e, 0x5c, 0x2b, 0x8a, 0xd2, 0xc4,
+	0x18, 0x78, 0x69, 0x16, 0x4f, 0xaf, 0x17, 0xf7, 0x1c, 0xb3, 0xe2, 0xd2, 0x3a, 0xae, 0xe7, 0x8c,
+	0x2b, 0x06, 0x09, 0xee, 0xb1, 0x10, 0x24, 0x7b, 0x90, 0x89, 0x08, 0x7d, 0x75, 0x6c, 0x25, 0x75,
+	0x11, 0xa3, 0x28, 0x2b, 0x31, 0xf0, 0x13, 0x65, 0x15, 0x62, 0x64, 0x0b, 0xc6, 0x2c, 0xbb, 0xe1,
+	0x51, 0xdf, 0x57, 0x33, 0xc8, 0x47, 0x90, 0x68, 0x47, 0x60, 0x5b, 0x8e, 0x7d, 0x68, 0x35, 0x4a,
+	0xf3, 0x3c, 0xb0, 0x50, 0x4d, 0x62, 0x89, 0x2c, 0xc9, 0x5d, 0x18, 0xf7, 0xa9, 0x77, 0x6a, 0xd5,
+	0xa9, 0xaf, 0x82, 0xc4, 0x52, 0x11, 0x60, 0xc8, 0x82, 0xc1, 0x44, 0x7a, 0x72, 0x30, 0x11, 0xc6,
+	0x6b, 0xdc, 0xaf, 0x1f, 0x51, 0x33, 0x68, 0x52, 0x4f, 0xcd, 0xc6, 0x35, 0xde, 0x05, 0xe5, 0x1a,
+	0xef, 0x82, 0x64, 0x07, 0x66, 0x9e, 0x05, 0x34, 0xa0, 0x55, 0xc6, 0x9a, 0x55, 0x9f, 0xd6, 0x1d,
+	0xdb, 0xf4, 0xd5, 0x89, 0x15, 0x65, 0x35, 0x55, 0x7a, 0xa7, 0xd3, 0x2e, 0x2c, 0xa1, 0xf0, 0x29,
+	0x6b, 0x56, 0x84, 0x48, 0x22, 0x99, 0xee, 0x11, 0x69, 0x06, 0x64, 0xa5, 0x85, 0x27, 0xef, 0x42,
+	0xea, 0x84, 0x8a, 0x3d, 0x9a, 0x29, 0xcd, 0x74, 0xda, 0x85, 0xc9, 0x13, 0x2a, 0x6f, 0x4f, 0x2e,
+	0x25, 0xef, 0xc3, 0xe8, 0xa9, 0xd1, 0x0c, 0x28, 0x2e, 0x71, 0xa6, 0x34, 0xdb, 0x69, 0x17, 0xa6,
+	0x11, 0x90, 0x14, 0x85, 0xc6, 0xe6, 0xf0, 0x4d, 0x45, 0x3b, 0x84, 0x5c, 0x6f, 0x69, 0xbf, 0x15,
+	0x3f, 0x2d, 0x58, 0x3c, 0xa7, 0x9e, 0xdf, 0x86, 0x3b, 0xfd, 0xdf, 0x29, 0x98, 0x4c, 0x54, 0x0d,
+	0xd9, 0x84, 0x11, 0x76, 0xe6, 0x52, 0x74, 0x33, 0xb5, 0x91, 0x93, 0xeb, 0xea, 0xe9, 0x99, 0x4b,
+	0xb1, 0x5d, 0x4c, 0x71, 0x8d, 0x44, 0xad, 0xa3, 0x0d, 0x77, 0xee, 0x3a, 0x1e, 0xf3, 0xd5, 0xe1,
+	0x95, 0xd4, 0xea, 0xa4, 0x70, 0x8e, 0x80, 0xec, 0x1c, 0x01, 0xf2, 0x45, 0xb2, 0xaf, 0xa4, 0xb0,
+	0xfe, 0xde, 0xed, 0xaf, 0xe2, 0x37, 0x6f, 0x28, 0xb7, 0x20, 0xcb, 0x9a, 0x7e, 0x95, 0xda, 0x46,
+	0xad, 0x49, 0x4d, 0x75, 0x64, 0x45, 0x59, 0x1d, 0x2f, 0xa9, 0x9d, 0x76, 0x61, 0x8e, 0xf1, 0x19,
+	0x45, 0x54, 0xb2, 0x85, 0x18, 0xc5, 0xf6, 0x4b, 0x3d, 0x56, 0xe5, 0x0d, 0x59, 0x1d, 0x95, 0xda,
+	0x2f, 0xf5, 0xd8, 0xae, 0xd1, 0xa2, 0x89, 0xf6, 0x1b, 0x62, 0xe4, 0x36, 0x4c, 0x06, 0x3e, 0xad,
+	0xd6, 0x9b, 0x81, 0xcf, 0xa8, 0xb7, 0xb3, 0xa7, 0xa6, 0xd1, 0xa3, 0xd6, 0x69, 0x17, 0x16, 0x02,
+	0x9f, 0x6e, 0x45, 0xb8, 0x64, 0x3c, 0x21, 0xe3, 0xff, 0xab, 0x12, 0xd3, 0x19, 0x4c, 0x26, 0xb6,
+	0x38, 0xb9, 0x39, 0x60, 0xc9, 0x43, 0x0d, 0x5c, 0x72, 0xd2, 0xbf, 0xe4, 0x57, 0x5e, 0x70, 0xfd,
+	0xcf, 0x0a, 0xe4, 0x7a, 0xdb, 0x37, 0xb7, 0xc7, 0xbd, 0x1c, 0x26, 0x88, 0xf6, 0x08, 0xc8, 0xf6,
+	0x08, 0x90, 0x1f, 0x02, 0x1c, 0x3b, 0xb5, 0xaa, 0x4f, 0xf1, 0x4c, 0x1c, 0x8e, 0x17, 0xe5, 0xd8,
+	0xa9, 0x55, 0x68, 0xcf, 0x99, 0x18, 0x61, 0xc4, 0x84, 0x19, 0x6e, 0xe5, 0x09, 0x7f, 0x55, 0xae,
+	0x10, 0x15, 0xdb, 0xd2, 0xb9, 0x27, 0x8a, 0xe8, 0x3f, 0xc7, 0x4e, 0x4d, 0xc2, 0x12, 0xfd, 0xa7,
+	0x47, 0xa4, 0xff, 0x47, 0xe4, 0xb6, 0x65, 0xd8, 0x75, 0xda, 0x8c, 0x72, 0x5b, 0x83, 0x34, 0x77,
+	0x6d, 0x99, 0x72, 0x72, 0xc7, 0x4e, 0x2d, 0x11, 0xe9, 0x28, 0x02, 0x6f, 0x98, 0x5c, 0x77, 0xf6,
+	0x52, 0x17, 0xce, 0xde, 0x87, 0x30, 0x26, 0x82, 0x11, 0x97, 0x83, 0x8c, 0x38, 0xf5, 0xd1, 0x79,
+	0xe2, 0xd4, 0x17, 0x08, 0xf9, 0x00, 0xd2, 0x1e, 0x35, 0x7c, 0xc7, 0x0e, 0xab, 0x1f, 0xb5, 0x05,
+	0x22, 0x6b, 0x0b, 0x44, 0xff, 0x87, 0x02, 0xb3, 0x0f, 0x30, 0xa8, 0xe4, 0x0c, 0x24, 0xb3, 0x52,
+	0xae, 0x9a, 0xd5, 0xf0, 0x85, 0x59, 0xdd, 0x86, 0xf4, 0xa1, 0xd5, 0x64, 0xd4, 0xc3, 0x19, 0xc8,
+	0x6e, 0xcc, 0x74, 0x97, 0x94, 0xb2, 0xbb, 0x28, 0x10, 0x91, 0x0b, 0x25, 0x39, 0x72, 0x81, 0x48,
+	0x79, 0x8e, 0x5c, 0x22, 0xcf, 0x87, 0x30, 0x21, 0x73, 0x93, 0x1f, 0x43, 0xda, 0x67, 0x06, 0xa3,
+	0xbe, 0xaa, 0xac, 0xa4, 0x56, 0xa7, 0x36, 0x26, 0xbb, 0xee, 0x39, 0x2a, 0xc8, 0x84, 0x82, 0x4c,
+	0x26, 0x10, 0xfd, 0x9f, 0x0a, 0x2c, 0x3c, 0xe0, 0x75, 0x14, 0xde, 0x15, 0xad, 0x9f, 0xd3, 0x68,
+	0xde, 0xa4, 0xc5, 0x52, 0x2e, 0xb1, 0x58, 0x6f, 0xbd, 0x78, 0x3e, 0x86, 0x09, 0x9b, 0x3e, 0xaf,
+	0x76, 0x2f, 0xbf, 0x23, 0x78, 0xf9, 0xc5, 0x3e, 0x6c, 0xd3, 0xe7, 0x7b, 0xfd, 0xf7, 0xdf, 0xac,
+	0x04, 0xeb, 0x7f, 0x18, 0x86, 0xc5, 0xbe, 0x44, 0x7d, 0xd7, 0xb1, 0x7d, 0x4a, 0x7e, 0xaf, 0x80,
+	0xea, 0xc5, 0x02, 0xec, 0x7c, 0x55, 0x8f, 0xfa, 0x41, 0x93, 0x89, 0xdc, 0xb3, 0x1b, 0xb7, 0xa2,
+	0x49, 0x1d, 0x44, 0x50, 0xdc, 0xef, 0x31, 0xde, 0x17, 0xb6, 0xe2, 0xa4, 0x78, 0xaf, 0xd3, 0x2e,
+	0x7c, 0xc7, 0x1b, 0xac, 0x21, 0x45, 0xbb, 0x78, 0x8e, 0x8a, 0xe6, 0xc1, 0xf2, 0xeb, 0xf8, 0xdf,
+	0x4a, 0x73, 0xb6, 0x61, 0x5e, 0x6a, 0x49, 0x22, 0x4b, 0x7c, 0x7d, 0x5c, 0xa5, 0x9d, 0xbc, 0x0f,
+	0xa3, 0xd4, 0xf3, 0x1c, 0x4f, 0xf6, 0x89, 0x80, 0xac, 0x8a, 0x80, 0xfe, 0x25, 0xcc, 0xf4, 0xf9,
+	0x23, 0x47, 0x40, 0x44, 0xd7, 0x14, 0xe3, 0xb0, 0x6d, 0x8a, 0xf5, 0xd0, 0x7a, 0xdb, 0x66, 0x1c,
+	0x63, 0x29, 0xdf, 0x69, 0x17, 0x34, 0x6c, 0x8e, 0x31, 0x28, 0xcf, 0x74, 0xae, 0x57, 0xa6, 0xff,
+	0x65, 0x0c, 0x46, 0x9f, 0x60, 0x91, 0x7d, 0x0f, 0x46, 0xf0, 0xb8, 0x15, 0xd9, 0xe1, 0x91, 0x63,
+	0x27, 0x8f, 0x5a, 0x94, 0x93, 0x32, 0x4c, 0x47, 0x85, 0x58, 0x3d, 0x34, 0xea, 0x2c, 0xcc, 0x52,
+	0x29, 0x2d, 0x77, 0xda, 0x05, 0x35, 0x12, 0xdd, 0x45, 0x89, 0x64, 0x3c, 0x95, 0x94, 0xf0, 0xdb,
+	0x41, 0xe0, 0x53, 0xaf, 0xea, 0x3c, 0xb7, 0xa9, 0x27, 0x8e, 0x84, 0x8c, 0xb8, 0x1d, 0x70, 0xf8,
+	0x31, 0xa2, 0x92, 0x39, 0xc4, 0x28, 0xdf, 0x0e, 0x0d, 0xcf, 0x09, 0xdc, 0xc8, 0x56, 0x34, 0x54,
+	0xdc, 0x0e, 0x88, 0xf7, 0x19, 0x67, 0x25, 0x98, 0x50, 0x98, 0xf6, 0xa8, 0xef, 0x04, 0x5e, 0x9d,
+	0x56, 0x9b, 0x56, 0xcb, 0x62, 0xd1, 0xa3, 0x2a, 0x8f, 0x13, 0x8b, 0x93, 0x51, 0xdc, 0x0f, 0x35,
+	0x1e, 0xa1, 0x82, 0xa8, 0x66, 0xcc, 0xcf, 0x4b, 0x08, 0xe4, 0xfc, 0x92, 0x12, 0x52, 0x81, 0xac,
+	0x4b, 0xbd, 0x96, 0xe5, 0xfb, 0x78, 0xbf, 0x12, 0x8f, 0xa8, 0x05, 0xc9, 0xc5, 0x5e, 0x2c, 0x15,
+	0xb1, 0x4b, 0xea, 0x72, 0xec, 0x12, 0x4c, 0xb6, 0x60, 0xba, 0x65, 0xbc, 0xa8, 0x62, 0x57, 0x30,
+	0xab, 0xc7, 0x4e, 0x8d, 0x3f, 0x67, 0x94, 0xd5, 0xc9, 0xd2, 0xb5, 0x4e, 0xbb, 0xb0, 0xd8, 0x32,
+	0x5e, 0x20, 0xb5, 0xf9, 0xc0, 0xa9, 0xc9, 0x14, 0x93, 0x09, 0x01, 0xb9, 0x0b, 0x39, 0x4e, 0xc2,
+	0x0b, 0xac, 0xdb, 0x51, 0xc6, 0x91, 0x05, 0x33, 0x6c, 0x19, 0x2f, 0x1e, 0x38, 0xb5, 0x01, 0x4d,
+	0x65, 0x2a, 0x29, 0x21, 0x0f, 0x61, 0xc6, 0xf5, 0x28, 0x97, 0xf1, 0xa6, 0xe1, 0x3a, 0x4d, 0xab,
+	0x7e, 0xa6, 0x66, 0xb0, 0x7a, 0xb0, 0x0e, 0x63, 0xe1, 0x1e, 0xca, 0xe4, 0x3a, 0xec, 0x95, 0x69,
+	0xff, 0x52, 0x20, 0x2b, 0xcd, 0x08, 0xd9, 0x87, 0x71, 0x3f, 0xa8, 0x1d, 0xd3, 0x7a, 0xb7, 0x0f,
+	0xe5, 0x07, 0xcf, 0x5d, 0xb1, 0x22, 0xd4, 0xc2, 0x77, 0x52, 0x68, 0x93, 0x78, 0x27, 0x85, 0x18,
+	0x76, 0x02, 0xea, 0xd5, 0xc4, 0x65, 0x29, 0xea, 0x04, 0x1c, 0x48, 0x74, 0x02, 0x0e, 0x68, 0x9f,
+	0xc3, 0x58, 0xc8, 0xcb, 0xf7, 0xc5, 0x89, 0x65, 0x9b, 0xf2, 0xbe, 0xe0, 0x63, 0x79, 0x5f, 0xf0,
+	0x71, 0x77, 0xff, 0x0c, 0xbf, 0x7e, 0xff, 0x68, 0x16, 0xcc, 0x0e, 0xa8, 0xae, 0x37, 0xe8, 0x65,
+	0xca, 0x85, 0xbd, 0xac, 0x0c, 0x19, 0x9c, 0xaf, 0x47, 0x96, 0xcf, 0xc8, 0x4d, 0x48, 0x63, 0xdd,
+	0x44, 0xf3, 0x09, 0xf1, 0x7c, 0x8a, 0xf3, 0x4d, 0x48, 0xe5, 0xf3, 0x4d, 0x20, 0xfa, 0x01, 0x10,
+	0x71, 0xaf, 0x68, 0x4a, 0x2d, 0x98, 0x5f, 0xb7, 0xeb, 0x02, 0xa5, 0xa6, 0x74, 0x54, 0xe2, 0x75,
+	0xbb, 0x2b, 0x48, 0x1e, 0x98, 0x13, 0x32, 0xae, 0xdf, 0x82, 0x69, 0xf4, 0x7e, 0x8f, 0x76, 0xaf,
+	0xa3, 0x97, 0xec, 0x41, 0xfa, 0x6d, 0x50, 0x2b, 0xcc, 0xa3, 0x46, 0xcb, 0xb2, 0x1b, 0xbd, 0x1c,
+	0xef, 0x42, 0xca, 0x0e, 0x5a, 0x48, 0x31, 0x29, 0x26, 0xd2, 0x0e, 0x5a, 0xf2, 0x44, 0xda, 0x41,
+	0x4b, 0xdf, 0x84, 0x1c, 0xda, 0xed, 0xd8, 0x87, 0xce, 0x55, 0x9d, 0x7f, 0x0c, 0x04, 0x6d, 0xb7,
+	0x69, 0x93, 0x32, 0x7a, 0x55, 0xeb, 0xdf, 0x2a, 0x90, 0xe9, 0xba, 0xbe, 0x74, 0xd3, 0x7d, 0x0a,
+	0xd3, 0x46, 0x9d, 0x59, 0xa7, 0xb4, 0x1a, 0xde, 0x34, 0x44, 0x11, 0x67, 0x37, 0xa6, 0xa5, 0x1b,
+	0x17, 0x67, 0x14, 0x9d, 0x40, 0xe8, 0x0a, 0x34, 0xd1, 0x09, 0x12, 0x02, 0xfd, 0x6b, 0x05, 0x20,
+	0x36, 0xbd, 0x74, 0x30, 0xb7, 0x20, 0x2b, 0x77, 0x20, 0x5e, 0x8b, 0xa3, 0xa2, 0x75, 0x3f, 0x1b,
+	0xd4, 0x7e, 0x20, 0x46, 0xb9, 0x69, 0x93, 0x1a, 0x7e, 0x64, 0x9a, 0x8a, 0x4d, 0x05, 0xdc, 0x6b,
+	0x1a, 0xa3, 0xfa, 0x73, 0x98, 0xc5, 0x79, 0x3b, 0x70, 0x4d, 0x83, 0xc5, 0x37, 0x98, 0x1b, 0xf2,
+	0x0b, 0x26, 0x59, 0xd5, 0xaf, 0xbb, 0x52, 0x5d, 0xe1, 0x84, 0x0e, 0x40, 0x2d, 0x19, 0xac, 0x7e,
+	0x34, 0xc8, 0xfb, 0xe7, 0x30, 0x79, 0x68, 0x58, 0x7c, 0x07, 0x24, 0xf6, 0x96, 0x1a, 0x47, 0x91,
+	0x34, 0x10, 0xdb, 0x43, 0x98, 0x3c, 0xe9, 0xdd, 0x6f, 0x13, 0x32, 0xde, 0xcd, 0x77, 0xcb, 0xa3,
+	0xff, 0xc7, 0x7c, 0x7b, 0xbc, 0x5f, 0x9c, 0x6f, 0xd2, 0xe0, 0x0a, 0xf9, 0x66, 0x21, 0x53, 0xb6,
+	0xcd, 0x4f, 0x0c, 0xef, 0x84, 0x7a, 0xfa, 0x57, 0x0a, 0xcc, 0x27, 0x77, 0xf8, 0x27, 0xd4, 0xf7,
+	0x8d, 0x06, 0x25, 0x3f, 0xba, 0x5a, 0xfe, 0xf7, 0x87, 0xa2, 0x19, 0xb8, 0x01, 0x29, 0x6a, 0x9b,
+	0xe1, 0x1f, 0x8a, 0x53, 0x68, 0xd6, 0xf5, 0x27, 0xfa, 0x04, 0x95, 0xbb, 0xfa, 0xfd, 0xa1, 0x7d,
+	0xae, 0x5f, 0x1a, 0x83, 0x51, 0x7a, 0x4a, 0x6d, 0xb6, 0xa6, 0x41, 0x56, 0xfa, 0x1b, 0x86, 0x64,
+	0x61, 0x2c, 0x1c, 0xe6, 0x86, 0xd6, 0xde, 0x87, 0xac, 0xf4, 0x5e, 0x27, 0x13, 0x30, 0xce, 0xff,
+	0x3b, 0xda, 0x73, 0x3c, 0x96, 0x1b, 0xe2, 0xa3, 0xfb, 0xd4, 0x30, 0x9b, 0x5c, 0x55, 0x59, 0xfb,
+	0x0c, 0xc6, 0xa3, 0x07, 0x0a, 0x01, 0x48, 0x3f, 0x39, 0x28, 0x1f, 0x94, 0xb7, 0x73, 0x43, 0x9c,
+	0x6f, 0xaf, 0xbc, 0xbb, 0xbd, 0xb3, 0x7b, 0x2f, 0xa7, 0xf0, 0xc1, 0xfe, 0xc1, 0xee, 0x2e, 0x1f,
+	0x0c, 0x93, 0x49, 0xc8, 0x54, 0x0e, 0xb6, 0xb6, 0xca, 0xe5, 0xed, 0xf2, 0x76, 0x2e, 0xc5, 0x8d,
+	0xee, 0xde, 0xd9, 0x79, 0x54, 0xde, 0xce, 0x8d, 0x70, 0xbd, 0x83, 0xdd, 0x87, 0xbb, 0x8f, 0x3f,
+	0xdd, 0xcd, 0x8d, 0x6e, 0xfc, 0x3a, 0x03, 0x69, 0x71, 0x27, 0x24, 0x3f, 0x01, 0x10, 0xbf, 0x70,
+	0xd3, 0xcd, 0x0f, 0x7c, 0x68, 0x6b, 0x0b, 0x83, 0x2f, 0x92, 0xfa, 0xd2, 0xaf, 0xfe, 0xf4, 0xf7,
+	0xdf, 0x0d, 0xcf, 0xea, 0x53, 0xfc, 0xff, 0xff, 0x63, 0xa7, 0x16, 0x7e, 0x46, 0xd8, 0x54, 0xd6,
+	0xc8, 0xa7, 0x00, 0xe2, 0x24, 0x48, 0xf2, 0x26, 0x5e, 0x9d, 0xda, 0x22, 0xc2, 0xfd, 0x27, 0x46,
+	0x44, 0xbc, 0xa9, 0xac, 0xc5, 0xdc, 0xe2, 0x44, 0x20, 0x3f, 0x83, 0x89, 0x2e, 0x71, 0x85, 0x32,
+	0xa2, 0x4a, 0x6d, 0x2d, 0xc9, 0xbe, 0x50, 0x14, 0x5f, 0x20, 0x8a, 0xd1, 0xa7, 0x85, 0x62, 0x99,
+	0x2f, 0x97, 0xbe, 0x8c, 0xe4, 0x0b, 0xfa, 0x4c, 0xc8, 0xec, 0x53, 0x16, 0x92, 0xf3, 0xc0, 0x6d,
+	0xc8, 0xc9, 0xcf, 0x17, 0x0c, 0xff, 0xda, 0xe0, 0x87, 0x8d, 0x70, 0xb3, 0xfc, 0xba, 0x57, 0x8f,
+	0x5e, 0x40, 0x67, 0x4b, 0xfa, 0x5c, 0x94, 0x86, 0xf4, 0x82, 0xa1, 0xdc, 0xdf, 0x3d, 0xc8, 0x8a,
+	0x8d, 0x20, 0xee, 0xd6, 0x52, 0x95, 0x9e, 0x9b, 0xc0, 0x1c, 0x72, 0x4e, 0xe9, 0x19, 0xce, 0x89,
+	0x25, 0xcb, 0x89, 0xea, 0x30, 0x21, 0x11, 0xf9, 0x64, 0x2a, 0x66, 0xe2, 0xa7, 0xba, 0xf6, 0x0e,
+	0x8e, 0xcf, 0xdb, 0xaf, 0xfa, 0x77, 0x91, 0x34, 0xcf, 0xa7, 0x7c, 0x89, 0xf3, 0xd6, 0xb8, 0x22,
+	0x35, 0xd7, 0xeb, 0xa8, 0x16, 0x6e, 0x62, 0xb2, 0x0b, 0x59, 0xd1, 0xa6, 0x2e, 0x1f, 0xed, 0x35,
+	0x24, 0x9e, 0xd7, 0x72, 0xdd, 0x68, 0xd7, 0x7f, 0xc1, 0x0f, 0x87, 0x2f, 0xc3, 0xa0, 0x25, 0xbe,
+	0x8b, 0x83, 0x4e, 0xf6, 0x48, 0x29, 0x68, 0x2d, 0x11, 0x74, 0xe0, 0x9a, 0x52, 0xd0, 0x9f, 0x41,
+	0x56, 0x9c, 0xc0, 0x22, 0xe8, 0xc5, 0xd8, 0x47, 0xe2, 0x60, 0x3e, 0x37, 0x03, 0x15, 0xbd, 0x90,
+	0xb5, 0xbe, 0x0c, 0xf8, 0xff, 0xf2, 0xf7, 0x28, 0x13, 0xb4, 0x73, 0x31, 0x6d, 0x7c, 0xc7, 0xd0,
+	0xa4, 0x19, 0x8a, 0x78, 0x48, 0x3f, 0x8f, 0x09, 0x99, 0x88, 0xc7, 0x27, 0x22, 0xe7, 0xf3, 0x6e,
+	0x2d, 0x9a, 0x36, 0x40, 0x1c, 0xb6, 0x3c, 0x5d, 0x43, 0x0f, 0x73, 0x84, 0xc8, 0x93, 0x21, 0x66,
+	0xe1, 0x07, 0x0a, 0x79, 0x0a, 0x13, 0x91, 0x17, 0x3c, 0xc5, 0xe7, 0xe3, 0xd8, 0xa4, 0xdb, 0x8d,
+	0x36, 0x95, 0x84, 0xf5, 0x77, 0x90, 0x74, 0x91, 0xcc, 0xf7, 0x86, 0xbd, 0x6e, 0x71, 0x96, 0x4d,
+	0x48, 0xdf, 0xc7, 0x8f, 0x72, 0xe4, 0x9c, 0xf9, 0xd3, 0xc4, 0x16, 0x15, 0x4a, 0x5b, 0x47, 0xb4,
+	0x7e, 0xd2, 0xed, 0xf9, 0x5f, 0x7c, 0xfb, 0xb7, 0xfc, 0xd0, 0x2f, 0x5f, 0xe6, 0x95, 0x3f, 0xbe,
+	0xcc, 0x2b, 0xdf, 0xbc, 0xcc, 0x2b, 0x7f, 0x7d, 0x99, 0x57, 0xbe, 0x7a, 0x95, 0x1f, 0xfa, 0xe6,
+	0x55, 0x7e, 0xe8, 0xdb, 0x57, 0xf9, 0xa1, 0x9f, 0x7e, 0x5f, 0xfa, 0x4e, 0x68, 0x78, 0x2d, 0xc3,
+	0x34, 0x5c, 0xcf, 0xe1, 0xb7, 0xed, 0x70, 0xb4, 0x1e, 0x7e, 0x18, 0xfc, 0x7a, 0x78, 0xee, 0x0e,
+	0x02, 0x7b, 0x42, 0x5c, 0xdc, 0x71, 0x8a, 0x77, 0x5c, 0xab, 0x96, 0xc6, 0x58, 0x3e, 0xfa, 0xef,
+	0x00, 0x5d, 0x7d, 0xda, 0xb6, 0xea, 0x1c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3148,6 +3185,23 @@ func (m *Queue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.PreemptionPolicy) > 0 {
+		i -= len(m.PreemptionPolicy)
+		copy(dAtA[i:], m.PreemptionPolicy)
+		i = encodeVarintSubmit(dAtA, i, uint64(len(m.PreemptionPolicy)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.MaxJobPriority != 0 {
+		i = encodeVarintSubmit(dAtA, i, uint64(m.MaxJobPriority))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.MaxQueuedJobs != 0 {
+		i = encodeVarintSubmit(dAtA, i, uint64(m.MaxQueuedJobs))
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.Permissions) > 0 {
 		for iNdEx := len(m.Permissions) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -4172,6 +4226,16 @@ func (m *Queue) Size() (n int) {
 			n += 1 + l + sovSubmit(uint64(l))
 		}
 	}
+	if m.MaxQueuedJobs != 0 {
+		n += 1 + sovSubmit(uint64(m.MaxQueuedJobs))
+	}
+	if m.MaxJobPriority != 0 {
+		n += 1 + sovSubmit(uint64(m.MaxJobPriority))
+	}
+	l = len(m.PreemptionPolicy)
+	if l > 0 {
+		n += 1 + l + sovSubmit(uint64(l))
+	}
 	return n
 }
 
@@ -4689,6 +4753,9 @@ func (this *Queue) String() string {
 		`GroupOwners:` + fmt.Sprintf("%v", this.GroupOwners) + `,`,
 		`ResourceLimits:` + mapStringForResourceLimits + `,`,
 		`Permissions:` + repeatedStringForPermissions + `,`,
+		`MaxQueuedJobs:` + fmt.Sprintf("%v", this.MaxQueuedJobs) + `,`,
+		`MaxJobPriority:` + fmt.Sprintf("%v", this.MaxJobPriority) + `,`,
+		`PreemptionPolicy:` + fmt.Sprintf("%v", this.PreemptionPolicy) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -7563,6 +7630,76 @@ func (m *Queue) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxQueuedJobs", wireType)
+			}
+			m.MaxQueuedJobs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubmit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxQueuedJobs |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxJobPriority", wireType)
+			}
+			m.MaxJobPriority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubmit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxJobPriority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreemptionPolicy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubmit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubmit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubmit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreemptionPolicy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSubmit(dAtA[iNdEx:])
@@ -3,6 +3,8 @@ package api
 import (
 	"fmt"
 	math "math"
+	"regexp"
+	"strconv"
 	"strings"
 	time "time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/armadaproject/armada/internal/common/armadaerrors"
 	"github.com/armadaproject/armada/internal/common/logging"
@@ -86,10 +89,89 @@ func NewNodeFromNodeInfo(nodeInfo *NodeInfo, executor string, allowedPriorities
 	}, nil
 }
 
+// NodeIdFromExecutorAndNodeName returns the id of the node with the provided name belonging to the provided executor.
+// Since executor ids and node names may both contain the separator, distinct pairs may result in the same id,
+// e.g., executor "a-b" with node "c" and executor "a" with node "b-c"; see LengthPrefixedNodeIdFromExecutorAndNodeName.
 func NodeIdFromExecutorAndNodeName(executor, nodeName string) string {
 	return fmt.Sprintf("%s-%s", executor, nodeName)
 }
 
+// LengthPrefixedNodeIdFromExecutorAndNodeName returns the id of the node with the provided name belonging to the
+// provided executor, prefixed with the length of the executor id, e.g., "10:executor-a-node-b" for executor
+// "executor-a" and node "node-b". Unlike NodeIdFromExecutorAndNodeName, distinct pairs never result in the same id,
+// and the executor id and node name can be recovered using ExecutorAndNodeNameFromLengthPrefixedNodeId.
+func LengthPrefixedNodeIdFromExecutorAndNodeName(executor, nodeName string) string {
+	return fmt.Sprintf("%d%s%s-%s", len(executor), nodeIdLengthPrefixSeparator, executor, nodeName)
+}
+
+// ExecutorAndNodeNameFromLengthPrefixedNodeId returns the executor id and node name
+// from which a node id was created by LengthPrefixedNodeIdFromExecutorAndNodeName.
+func ExecutorAndNodeNameFromLengthPrefixedNodeId(nodeId string) (string, string, error) {
+	invalidNodeId := func(message string) error {
+		return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+			Name:    "nodeId",
+			Value:   nodeId,
+			Message: message,
+		})
+	}
+	prefix, rest, ok := strings.Cut(nodeId, nodeIdLengthPrefixSeparator)
+	if !ok {
+		return "", "", invalidNodeId("node id has no length prefix")
+	}
+	n, err := strconv.Atoi(prefix)
+	if err != nil || n < 0 || strconv.Itoa(n) != prefix {
+		return "", "", invalidNodeId("length prefix of node id is not a non-negative integer")
+	}
+	if len(rest) <= n || rest[n] != '-' {
+		return "", "", invalidNodeId("length prefix of node id doesn't match the executor id")
+	}
+	return rest[:n], rest[n+1:], nil
+}
+
+const (
+	// Separates the length prefix from the rest of node ids created by LengthPrefixedNodeIdFromExecutorAndNodeName.
+	nodeIdLengthPrefixSeparator = ":"
+	// Maximum length of executor ids accepted by ValidateExecutorId.
+	maxExecutorIdLength = 63
+)
+
+// Executor ids must begin and end with an alphanumeric character and contain only alphanumeric characters,
+// '-', '_', and '.'; in particular, they may not contain nodeIdLengthPrefixSeparator.
+var executorIdRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$`)
+
+// ValidateExecutorId returns an error if executor isn't a valid executor id, i.e.,
+// if it's empty, longer than 63 characters, or contains characters other than alphanumerics, '-', '_', and '.'.
+func ValidateExecutorId(executor string) error {
+	var message string
+	if executor == "" {
+		message = "executor id is empty"
+	} else if len(executor) > maxExecutorIdLength {
+		message = fmt.Sprintf("executor id is longer than %d characters", maxExecutorIdLength)
+	} else if !executorIdRegexp.MatchString(executor) {
+		message = "executor id must consist of alphanumeric characters, '-', '_', or '.', and must begin and end with an alphanumeric character"
+	}
+	if message != "" {
+		return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+			Name:    "executorId",
+			Value:   executor,
+			Message: message,
+		})
+	}
+	return nil
+}
+
+// ValidateNodeName returns an error if nodeName isn't a valid Kubernetes node name, i.e., a DNS-1123 subdomain.
+func ValidateNodeName(nodeName string) error {
+	if messages := validation.IsDNS1123Subdomain(nodeName); len(messages) > 0 {
+		return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+			Name:    "nodeName",
+			Value:   nodeName,
+			Message: strings.Join(messages, "; "),
+		})
+	}
+	return nil
+}
+
 func JobRunStateFromApiJobState(s JobState) schedulerobjects.JobRunState {
 	switch s {
 	case JobState_QUEUED:
@@ -193,6 +275,29 @@ func SchedulingResourceRequirementsFromPodSpec(podSpec *v1.PodSpec) v1.ResourceR
 	return rv
 }
 
+// AddPodOverhead adds the pod overhead declared in podSpec, if any, to the resource requirements rr.
+// As in Kubernetes, overhead is always added to requests,
+// but only added to limits for resources for which a limit is already set.
+// The maps of rr are modified in-place.
+func AddPodOverhead(rr v1.ResourceRequirements, podSpec *v1.PodSpec) v1.ResourceRequirements {
+	if podSpec == nil || len(podSpec.Overhead) == 0 {
+		return rr
+	}
+	if rr.Requests == nil {
+		rr.Requests = make(v1.ResourceList, len(podSpec.Overhead))
+	}
+	for t, overhead := range podSpec.Overhead {
+		q := rr.Requests[t]
+		q.Add(overhead)
+		rr.Requests[t] = q
+		if limit, ok := rr.Limits[t]; ok {
+			limit.Add(overhead)
+			rr.Limits[t] = limit
+		}
+	}
+	return rr
+}
+
 // PriorityFromPodSpec returns the priority in a pod spec.
 // If priority is set directly, that value is returned.
 // Otherwise, it returns the value of the key podSpec.
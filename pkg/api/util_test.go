@@ -1,6 +1,7 @@
 package api
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -254,6 +255,84 @@ func TestSchedulingResourceRequirementsFromPodSpec(t *testing.T) {
 	}
 }
 
+func TestAddPodOverhead(t *testing.T) {
+	tests := map[string]struct {
+		input    *v1.PodSpec
+		expected v1.ResourceRequirements
+	}{
+		"init container dominant": {
+			input: &v1.PodSpec{
+				Containers: []v1.Container{
+					{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(1)}}},
+					{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(2)}}},
+				},
+				InitContainers: []v1.Container{
+					{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(10)}}},
+				},
+				Overhead: v1.ResourceList{"cpu": QuantityWithMilliValue(5)},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(15)},
+				Limits:   v1.ResourceList{},
+			},
+		},
+		"sum of containers dominant": {
+			input: &v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(4)},
+							Limits:   v1.ResourceList{"cpu": QuantityWithMilliValue(4)},
+						},
+					},
+					{
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(4)},
+							Limits:   v1.ResourceList{"cpu": QuantityWithMilliValue(4)},
+						},
+					},
+				},
+				InitContainers: []v1.Container{
+					{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(6)}}},
+				},
+				Overhead: v1.ResourceList{"cpu": QuantityWithMilliValue(1), "memory": QuantityWithMilliValue(3)},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(9), "memory": QuantityWithMilliValue(3)},
+				Limits:   v1.ResourceList{"cpu": QuantityWithMilliValue(9)},
+			},
+		},
+		"no overhead": {
+			input: &v1.PodSpec{
+				Containers: []v1.Container{
+					{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(1)}}},
+				},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{"cpu": QuantityWithMilliValue(1)},
+				Limits:   v1.ResourceList{},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := AddPodOverhead(SchedulingResourceRequirementsFromPodSpec(tc.input), tc.input)
+			assertResourceListEqual(t, tc.expected.Requests, actual.Requests)
+			assertResourceListEqual(t, tc.expected.Limits, actual.Limits)
+		})
+	}
+}
+
+func assertResourceListEqual(t *testing.T, expected, actual v1.ResourceList) {
+	if !assert.Equal(t, len(expected), len(actual), "expected %v, got %v", expected, actual) {
+		return
+	}
+	for name, q := range expected {
+		a := actual[name]
+		assert.Equal(t, 0, q.Cmp(a), "%s: expected %s, got %s", name, q.String(), a.String())
+	}
+}
+
 // quantityWithMilliValue returns a new quantity with the provided milli value assigned to it.
 // Using this instead of resource.MustParse avoids populating the cached string field,
 // which may cause assert.Equal to return false for quantities with equal value but where
@@ -488,3 +567,78 @@ func TestPriorityFromPodSpec(t *testing.T) {
 func pointerFromValue[T any](v T) *T {
 	return &v
 }
+
+func TestLengthPrefixedNodeIdFromExecutorAndNodeName(t *testing.T) {
+	// Pairs of executor id and node name that result in the same id when joined with a '-'.
+	collidingPairs := [][2]string{
+		{"a", "b-c-d"},
+		{"a-b", "c-d"},
+		{"a-b-c", "d"},
+	}
+	nodeIds := make(map[string]bool)
+	for _, pair := range collidingPairs {
+		executor, nodeName := pair[0], pair[1]
+		assert.Equal(t, "a-b-c-d", NodeIdFromExecutorAndNodeName(executor, nodeName))
+
+		nodeId := LengthPrefixedNodeIdFromExecutorAndNodeName(executor, nodeName)
+		assert.False(t, nodeIds[nodeId], "duplicate node id %s", nodeId)
+		nodeIds[nodeId] = true
+
+		actualExecutor, actualNodeName, err := ExecutorAndNodeNameFromLengthPrefixedNodeId(nodeId)
+		if assert.NoError(t, err) {
+			assert.Equal(t, executor, actualExecutor)
+			assert.Equal(t, nodeName, actualNodeName)
+		}
+	}
+	assert.Equal(t, "10:executor-a-node-b", LengthPrefixedNodeIdFromExecutorAndNodeName("executor-a", "node-b"))
+
+	// Round-trip with executor ids and node names containing the length prefix separator.
+	actualExecutor, actualNodeName, err := ExecutorAndNodeNameFromLengthPrefixedNodeId(LengthPrefixedNodeIdFromExecutorAndNodeName("1:a", "2:b-c"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1:a", actualExecutor)
+		assert.Equal(t, "2:b-c", actualNodeName)
+	}
+}
+
+func TestExecutorAndNodeNameFromLengthPrefixedNodeId_Invalid(t *testing.T) {
+	for _, nodeId := range []string{
+		"",
+		"executor-node",
+		":executor-node",
+		"x:executor-node",
+		"-1:executor-node",
+		"+8:executor-node",
+		"08:executor-node",
+		"9:executor-node",
+		"20:executor-node",
+	} {
+		_, _, err := ExecutorAndNodeNameFromLengthPrefixedNodeId(nodeId)
+		assert.Error(t, err, nodeId)
+	}
+}
+
+func TestValidateExecutorId(t *testing.T) {
+	for _, executor := range []string{"executor", "executor-1", "Executor_1.a", "a"} {
+		assert.NoError(t, ValidateExecutorId(executor), executor)
+	}
+	for _, executor := range []string{
+		"",
+		"-executor",
+		"executor-",
+		"executor:1",
+		"executor/1",
+		"executor 1",
+		strings.Repeat("a", 64),
+	} {
+		assert.Error(t, ValidateExecutorId(executor), executor)
+	}
+}
+
+func TestValidateNodeName(t *testing.T) {
+	for _, nodeName := range []string{"node", "node-1", "node-1.example.com"} {
+		assert.NoError(t, ValidateNodeName(nodeName), nodeName)
+	}
+	for _, nodeName := range []string{"", "Node", "node_1", "node:1", "-node", strings.Repeat("a", 254)} {
+		assert.Error(t, ValidateNodeName(nodeName), nodeName)
+	}
+}
@@ -109,6 +109,13 @@ type EventSequence struct {
 	Groups []string `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
 	// For efficiency, we bundle several events (i.e., state transitions) in a single log message.
 	Events []*EventSequence_Event `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"`
+	// Identifies the scheduler that produced the sequence. Set only on sequences published by the scheduler;
+	// consumers must not rely on it being present.
+	Provenance *Provenance `protobuf:"bytes,6,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	// Leadership epoch of the scheduler that published the sequence, which increases each time a different replica
+	// becomes leader. Used to discard sequences published by a previous leader after a newer one started publishing.
+	// Zero if unknown, e.g., for sequences not published by the scheduler.
+	LeaderEpoch uint64 `protobuf:"varint,7,opt,name=leader_epoch,json=leaderEpoch,proto3" json:"leaderEpoch,omitempty"`
 }
 
 func (m *EventSequence) Reset()         { *m = EventSequence{} }
@@ -179,6 +186,20 @@ func (m *EventSequence) GetEvents() []*EventSequence_Event {
 	return nil
 }
 
+func (m *EventSequence) GetProvenance() *Provenance {
+	if m != nil {
+		return m.Provenance
+	}
+	return nil
+}
+
+func (m *EventSequence) GetLeaderEpoch() uint64 {
+	if m != nil {
+		return m.LeaderEpoch
+	}
+	return 0
+}
+
 // List of possible events, i.e., state transitions.
 type EventSequence_Event struct {
 	Created *time.Time `protobuf:"bytes,18,opt,name=created,proto3,stdtime" json:"created,omitempty"`
@@ -204,7 +225,13 @@ type EventSequence_Event struct {
 	//	*EventSequence_Event_PartitionMarker
 	//	*EventSequence_Event_JobRunPreemptionRequested
 	//	*EventSequence_Event_JobRequeued
+	//	*EventSequence_Event_JobRunCancelled
+	//	*EventSequence_Event_JobValidated
+	//	*EventSequence_Event_JobRetriesExhausted
 	Event isEventSequence_Event_Event `protobuf_oneof:"event"`
+	// Correlation id of the job the event relates to, copied from the job's correlation id annotation.
+	// Used to correlate events with client-side requests in tracing systems. Empty if the job has no such annotation.
+	CorrelationId string `protobuf:"bytes,24,opt,name=correlation_id,json=correlationId,proto3" json:"correlationId,omitempty"`
 }
 
 func (m *EventSequence_Event) Reset()         { *m = EventSequence_Event{} }
@@ -309,6 +336,15 @@ type EventSequence_Event_JobRunPreemptionRequested struct {
 type EventSequence_Event_JobRequeued struct {
 	JobRequeued *JobRequeued `protobuf:"bytes,22,opt,name=jobRequeued,proto3,oneof" json:"jobRequeued,omitempty"`
 }
+type EventSequence_Event_JobRunCancelled struct {
+	JobRunCancelled *JobRunCancelled `protobuf:"bytes,23,opt,name=jobRunCancelled,proto3,oneof" json:"jobRunCancelled,omitempty"`
+}
+type EventSequence_Event_JobValidated struct {
+	JobValidated *JobValidated `protobuf:"bytes,25,opt,name=jobValidated,proto3,oneof" json:"jobValidated,omitempty"`
+}
+type EventSequence_Event_JobRetriesExhausted struct {
+	JobRetriesExhausted *JobRetriesExhausted `protobuf:"bytes,26,opt,name=jobRetriesExhausted,proto3,oneof" json:"jobRetriesExhausted,omitempty"`
+}
 
 func (*EventSequence_Event_SubmitJob) isEventSequence_Event_Event()                 {}
 func (*EventSequence_Event_ReprioritiseJob) isEventSequence_Event_Event()           {}
@@ -331,6 +367,9 @@ func (*EventSequence_Event_JobRunPreempted) isEventSequence_Event_Event()
 func (*EventSequence_Event_PartitionMarker) isEventSequence_Event_Event()           {}
 func (*EventSequence_Event_JobRunPreemptionRequested) isEventSequence_Event_Event() {}
 func (*EventSequence_Event_JobRequeued) isEventSequence_Event_Event()               {}
+func (*EventSequence_Event_JobRunCancelled) isEventSequence_Event_Event()           {}
+func (*EventSequence_Event_JobValidated) isEventSequence_Event_Event()              {}
+func (*EventSequence_Event_JobRetriesExhausted) isEventSequence_Event_Event()       {}
 
 func (m *EventSequence_Event) GetEvent() isEventSequence_Event_Event {
 	if m != nil {
@@ -493,6 +532,34 @@ func (m *EventSequence_Event) GetJobRequeued() *JobRequeued {
 	return nil
 }
 
+func (m *EventSequence_Event) GetJobRunCancelled() *JobRunCancelled {
+	if x, ok := m.GetEvent().(*EventSequence_Event_JobRunCancelled); ok {
+		return x.JobRunCancelled
+	}
+	return nil
+}
+
+func (m *EventSequence_Event) GetJobValidated() *JobValidated {
+	if x, ok := m.GetEvent().(*EventSequence_Event_JobValidated); ok {
+		return x.JobValidated
+	}
+	return nil
+}
+
+func (m *EventSequence_Event) GetJobRetriesExhausted() *JobRetriesExhausted {
+	if x, ok := m.GetEvent().(*EventSequence_Event_JobRetriesExhausted); ok {
+		return x.JobRetriesExhausted
+	}
+	return nil
+}
+
+func (m *EventSequence_Event) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*EventSequence_Event) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -517,7 +584,93 @@ func (*EventSequence_Event) XXX_OneofWrappers() []interface{} {
 		(*EventSequence_Event_PartitionMarker)(nil),
 		(*EventSequence_Event_JobRunPreemptionRequested)(nil),
 		(*EventSequence_Event_JobRequeued)(nil),
+		(*EventSequence_Event_JobRunCancelled)(nil),
+		(*EventSequence_Event_JobValidated)(nil),
+		(*EventSequence_Event_JobRetriesExhausted)(nil),
+	}
+}
+
+// Identifies the build, configuration, and cycle of the scheduler that published an event sequence.
+// Intended for debugging historical scheduling decisions.
+type Provenance struct {
+	// Release version of the scheduler build.
+	BuildVersion string `protobuf:"bytes,1,opt,name=build_version,json=buildVersion,proto3" json:"buildVersion,omitempty"`
+	// Git commit the scheduler was built from.
+	BuildCommit string `protobuf:"bytes,2,opt,name=build_commit,json=buildCommit,proto3" json:"buildCommit,omitempty"`
+	// Hash of the effective scheduling config of the scheduler, computed at startup.
+	ConfigHash string `protobuf:"bytes,3,opt,name=config_hash,json=configHash,proto3" json:"configHash,omitempty"`
+	// Name of the pod of the leader that published the sequence.
+	LeaderName string `protobuf:"bytes,4,opt,name=leader_name,json=leaderName,proto3" json:"leaderName,omitempty"`
+	// Id of the scheduler cycle in which the sequence was published.
+	CycleId string `protobuf:"bytes,5,opt,name=cycle_id,json=cycleId,proto3" json:"cycleId,omitempty"`
+}
+
+func (m *Provenance) Reset()         { *m = Provenance{} }
+func (m *Provenance) String() string { return proto.CompactTextString(m) }
+func (*Provenance) ProtoMessage()    {}
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6aab92ca59e015f8, []int{1}
+}
+func (m *Provenance) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Provenance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Provenance.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Provenance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Provenance.Merge(m, src)
+}
+func (m *Provenance) XXX_Size() int {
+	return m.Size()
+}
+func (m *Provenance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Provenance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Provenance proto.InternalMessageInfo
+
+func (m *Provenance) GetBuildVersion() string {
+	if m != nil {
+		return m.BuildVersion
+	}
+	return ""
+}
+
+func (m *Provenance) GetBuildCommit() string {
+	if m != nil {
+		return m.BuildCommit
+	}
+	return ""
+}
+
+func (m *Provenance) GetConfigHash() string {
+	if m != nil {
+		return m.ConfigHash
+	}
+	return ""
+}
+
+func (m *Provenance) GetLeaderName() string {
+	if m != nil {
+		return m.LeaderName
+	}
+	return ""
+}
+
+func (m *Provenance) GetCycleId() string {
+	if m != nil {
+		return m.CycleId
 	}
+	return ""
 }
 
 // Resource usage of a particular k8s object created as part of a job.
@@ -533,7 +686,7 @@ func (m *ResourceUtilisation) Reset()         { *m = ResourceUtilisation{} }
 func (m *ResourceUtilisation) String() string { return proto.CompactTextString(m) }
 func (*ResourceUtilisation) ProtoMessage()    {}
 func (*ResourceUtilisation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{1}
+	return fileDescriptor_6aab92ca59e015f8, []int{2}
 }
 func (m *ResourceUtilisation) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -612,7 +765,7 @@ func (m *Uuid) Reset()         { *m = Uuid{} }
 func (m *Uuid) String() string { return proto.CompactTextString(m) }
 func (*Uuid) ProtoMessage()    {}
 func (*Uuid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{2}
+	return fileDescriptor_6aab92ca59e015f8, []int{3}
 }
 func (m *Uuid) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -701,7 +854,7 @@ func (m *SubmitJob) Reset()         { *m = SubmitJob{} }
 func (m *SubmitJob) String() string { return proto.CompactTextString(m) }
 func (*SubmitJob) ProtoMessage()    {}
 func (*SubmitJob) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{3}
+	return fileDescriptor_6aab92ca59e015f8, []int{4}
 }
 func (m *SubmitJob) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -833,7 +986,7 @@ func (m *KubernetesMainObject) Reset()         { *m = KubernetesMainObject{} }
 func (m *KubernetesMainObject) String() string { return proto.CompactTextString(m) }
 func (*KubernetesMainObject) ProtoMessage()    {}
 func (*KubernetesMainObject) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{4}
+	return fileDescriptor_6aab92ca59e015f8, []int{5}
 }
 func (m *KubernetesMainObject) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -917,7 +1070,7 @@ func (m *KubernetesObject) Reset()         { *m = KubernetesObject{} }
 func (m *KubernetesObject) String() string { return proto.CompactTextString(m) }
 func (*KubernetesObject) ProtoMessage()    {}
 func (*KubernetesObject) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{5}
+	return fileDescriptor_6aab92ca59e015f8, []int{6}
 }
 func (m *KubernetesObject) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1044,7 +1197,7 @@ func (m *ObjectMeta) Reset()         { *m = ObjectMeta{} }
 func (m *ObjectMeta) String() string { return proto.CompactTextString(m) }
 func (*ObjectMeta) ProtoMessage()    {}
 func (*ObjectMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{6}
+	return fileDescriptor_6aab92ca59e015f8, []int{7}
 }
 func (m *ObjectMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1127,7 +1280,7 @@ func (m *PodSpecWithAvoidList) Reset()         { *m = PodSpecWithAvoidList{} }
 func (m *PodSpecWithAvoidList) String() string { return proto.CompactTextString(m) }
 func (*PodSpecWithAvoidList) ProtoMessage()    {}
 func (*PodSpecWithAvoidList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{7}
+	return fileDescriptor_6aab92ca59e015f8, []int{8}
 }
 func (m *PodSpecWithAvoidList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1180,7 +1333,7 @@ func (m *ReprioritiseJob) Reset()         { *m = ReprioritiseJob{} }
 func (m *ReprioritiseJob) String() string { return proto.CompactTextString(m) }
 func (*ReprioritiseJob) ProtoMessage()    {}
 func (*ReprioritiseJob) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{8}
+	return fileDescriptor_6aab92ca59e015f8, []int{9}
 }
 func (m *ReprioritiseJob) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1234,7 +1387,7 @@ func (m *JobRequeued) Reset()         { *m = JobRequeued{} }
 func (m *JobRequeued) String() string { return proto.CompactTextString(m) }
 func (*JobRequeued) ProtoMessage()    {}
 func (*JobRequeued) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{9}
+	return fileDescriptor_6aab92ca59e015f8, []int{10}
 }
 func (m *JobRequeued) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1284,6 +1437,173 @@ func (m *JobRequeued) GetUpdateSequenceNumber() int32 {
 	return 0
 }
 
+// Generated by the scheduler the first time it admits a submitted job, i.e., once the job has been validated
+// and added to the queue. Published at most once per job, including across scheduler failovers.
+// The queue and job set of the job are those of the enclosing event sequence.
+type JobValidated struct {
+	JobId *Uuid `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	// Priority of the job once the priority class has been applied.
+	EffectivePriority uint32 `protobuf:"varint,2,opt,name=effective_priority,json=effectivePriority,proto3" json:"effectivePriority,omitempty"`
+	// Name of the priority class of the job, after applying the default priority class if none was set.
+	PriorityClassName string `protobuf:"bytes,3,opt,name=priority_class_name,json=priorityClassName,proto3" json:"priorityClassName,omitempty"`
+	// True if the job didn't specify a priority class and the default priority class was applied.
+	DefaultPriorityClassApplied bool `protobuf:"varint,4,opt,name=default_priority_class_applied,json=defaultPriorityClassApplied,proto3" json:"defaultPriorityClassApplied,omitempty"`
+	// True if the submitted priority was clamped to the maximum job priority of the queue.
+	PriorityClamped bool `protobuf:"varint,5,opt,name=priority_clamped,json=priorityClamped,proto3" json:"priorityClamped,omitempty"`
+	// Id of the scheduling cycle in which the job was admitted.
+	CycleId string `protobuf:"bytes,6,opt,name=cycle_id,json=cycleId,proto3" json:"cycleId,omitempty"`
+}
+
+func (m *JobValidated) Reset()         { *m = JobValidated{} }
+func (m *JobValidated) String() string { return proto.CompactTextString(m) }
+func (*JobValidated) ProtoMessage()    {}
+func (*JobValidated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6aab92ca59e015f8, []int{11}
+}
+func (m *JobValidated) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobValidated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobValidated.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobValidated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobValidated.Merge(m, src)
+}
+func (m *JobValidated) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobValidated) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobValidated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobValidated proto.InternalMessageInfo
+
+func (m *JobValidated) GetJobId() *Uuid {
+	if m != nil {
+		return m.JobId
+	}
+	return nil
+}
+
+func (m *JobValidated) GetEffectivePriority() uint32 {
+	if m != nil {
+		return m.EffectivePriority
+	}
+	return 0
+}
+
+func (m *JobValidated) GetPriorityClassName() string {
+	if m != nil {
+		return m.PriorityClassName
+	}
+	return ""
+}
+
+func (m *JobValidated) GetDefaultPriorityClassApplied() bool {
+	if m != nil {
+		return m.DefaultPriorityClassApplied
+	}
+	return false
+}
+
+func (m *JobValidated) GetPriorityClamped() bool {
+	if m != nil {
+		return m.PriorityClamped
+	}
+	return false
+}
+
+func (m *JobValidated) GetCycleId() string {
+	if m != nil {
+		return m.CycleId
+	}
+	return ""
+}
+
+// Generated by the scheduler, in addition to the JobErrors event failing the job, when a job is failed since it was
+// attempted the maximum number of times. Intended for notifying users, e.g., such that they can halt their pipelines.
+// Notifications are rate-limited per job set, such that not every such job has one.
+// The queue and job set of the job are those of the enclosing event sequence.
+type JobRetriesExhausted struct {
+	JobId *Uuid `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	// Number of times the job was attempted.
+	Attempts uint32 `protobuf:"varint,2,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Id of the last run of the job.
+	LastRunId *Uuid `protobuf:"bytes,3,opt,name=last_run_id,json=lastRunId,proto3" json:"lastRunId,omitempty"`
+	// Summary of the error of the last run of the job, or empty if there's none.
+	LastRunError string `protobuf:"bytes,4,opt,name=last_run_error,json=lastRunError,proto3" json:"lastRunError,omitempty"`
+}
+
+func (m *JobRetriesExhausted) Reset()         { *m = JobRetriesExhausted{} }
+func (m *JobRetriesExhausted) String() string { return proto.CompactTextString(m) }
+func (*JobRetriesExhausted) ProtoMessage()    {}
+func (*JobRetriesExhausted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6aab92ca59e015f8, []int{12}
+}
+func (m *JobRetriesExhausted) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobRetriesExhausted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobRetriesExhausted.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobRetriesExhausted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRetriesExhausted.Merge(m, src)
+}
+func (m *JobRetriesExhausted) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobRetriesExhausted) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRetriesExhausted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobRetriesExhausted proto.InternalMessageInfo
+
+func (m *JobRetriesExhausted) GetJobId() *Uuid {
+	if m != nil {
+		return m.JobId
+	}
+	return nil
+}
+
+func (m *JobRetriesExhausted) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *JobRetriesExhausted) GetLastRunId() *Uuid {
+	if m != nil {
+		return m.LastRunId
+	}
+	return nil
+}
+
+func (m *JobRetriesExhausted) GetLastRunError() string {
+	if m != nil {
+		return m.LastRunError
+	}
+	return ""
+}
+
 // Set the priority of all jobs part of a job set.
 // This sets the priority of all jobs in the job set currently in the queued state.
 type ReprioritiseJobSet struct {
@@ -1294,7 +1614,7 @@ func (m *ReprioritiseJobSet) Reset()         { *m = ReprioritiseJobSet{} }
 func (m *ReprioritiseJobSet) String() string { return proto.CompactTextString(m) }
 func (*ReprioritiseJobSet) ProtoMessage()    {}
 func (*ReprioritiseJobSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{10}
+	return fileDescriptor_6aab92ca59e015f8, []int{13}
 }
 func (m *ReprioritiseJobSet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1335,13 +1655,17 @@ func (m *ReprioritiseJobSet) GetPriority() uint32 {
 type ReprioritisedJob struct {
 	JobId    *Uuid  `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
 	Priority uint32 `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	// If priority was clamped to the maximum job priority of the job's queue, the priority requested for the job
+	// and a message explaining why it wasn't applied. Only populated if the scheduler is configured to report clamps in events.
+	RequestedPriority uint32 `protobuf:"varint,3,opt,name=requested_priority,json=requestedPriority,proto3" json:"requestedPriority,omitempty"`
+	ClampMessage      string `protobuf:"bytes,4,opt,name=clamp_message,json=clampMessage,proto3" json:"clampMessage,omitempty"`
 }
 
 func (m *ReprioritisedJob) Reset()         { *m = ReprioritisedJob{} }
 func (m *ReprioritisedJob) String() string { return proto.CompactTextString(m) }
 func (*ReprioritisedJob) ProtoMessage()    {}
 func (*ReprioritisedJob) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{11}
+	return fileDescriptor_6aab92ca59e015f8, []int{14}
 }
 func (m *ReprioritisedJob) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1384,6 +1708,20 @@ func (m *ReprioritisedJob) GetPriority() uint32 {
 	return 0
 }
 
+func (m *ReprioritisedJob) GetRequestedPriority() uint32 {
+	if m != nil {
+		return m.RequestedPriority
+	}
+	return 0
+}
+
+func (m *ReprioritisedJob) GetClampMessage() string {
+	if m != nil {
+		return m.ClampMessage
+	}
+	return ""
+}
+
 // A request to cancel a particular job.
 // This will cancel all runs (preempting it if running) for the job (i.e., move them to the failed state)
 // and then cancel job itself (i.e., move it to the failed state).
@@ -1396,7 +1734,7 @@ func (m *CancelJob) Reset()         { *m = CancelJob{} }
 func (m *CancelJob) String() string { return proto.CompactTextString(m) }
 func (*CancelJob) ProtoMessage()    {}
 func (*CancelJob) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{12}
+	return fileDescriptor_6aab92ca59e015f8, []int{15}
 }
 func (m *CancelJob) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1449,7 +1787,7 @@ func (m *JobSetFilter) Reset()         { *m = JobSetFilter{} }
 func (m *JobSetFilter) String() string { return proto.CompactTextString(m) }
 func (*JobSetFilter) ProtoMessage()    {}
 func (*JobSetFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{13}
+	return fileDescriptor_6aab92ca59e015f8, []int{16}
 }
 func (m *JobSetFilter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1496,7 +1834,7 @@ func (m *CancelJobSet) Reset()         { *m = CancelJobSet{} }
 func (m *CancelJobSet) String() string { return proto.CompactTextString(m) }
 func (*CancelJobSet) ProtoMessage()    {}
 func (*CancelJobSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{14}
+	return fileDescriptor_6aab92ca59e015f8, []int{17}
 }
 func (m *CancelJobSet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1550,7 +1888,7 @@ func (m *CancelledJob) Reset()         { *m = CancelledJob{} }
 func (m *CancelledJob) String() string { return proto.CompactTextString(m) }
 func (*CancelledJob) ProtoMessage()    {}
 func (*CancelledJob) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{15}
+	return fileDescriptor_6aab92ca59e015f8, []int{18}
 }
 func (m *CancelledJob) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1593,6 +1931,60 @@ func (m *CancelledJob) GetReason() string {
 	return ""
 }
 
+// Indicates that a job run has been cancelled, such that the executor should tear down any resources created for it.
+// Published in addition to CancelledJob for runs that may not yet be known to the executor when the job is cancelled.
+type JobRunCancelled struct {
+	RunId *Uuid `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"runId,omitempty"`
+	JobId *Uuid `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+}
+
+func (m *JobRunCancelled) Reset()         { *m = JobRunCancelled{} }
+func (m *JobRunCancelled) String() string { return proto.CompactTextString(m) }
+func (*JobRunCancelled) ProtoMessage()    {}
+func (*JobRunCancelled) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6aab92ca59e015f8, []int{19}
+}
+func (m *JobRunCancelled) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobRunCancelled) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobRunCancelled.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobRunCancelled) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRunCancelled.Merge(m, src)
+}
+func (m *JobRunCancelled) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobRunCancelled) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRunCancelled.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobRunCancelled proto.InternalMessageInfo
+
+func (m *JobRunCancelled) GetRunId() *Uuid {
+	if m != nil {
+		return m.RunId
+	}
+	return nil
+}
+
+func (m *JobRunCancelled) GetJobId() *Uuid {
+	if m != nil {
+		return m.JobId
+	}
+	return nil
+}
+
 type JobSucceeded struct {
 	JobId *Uuid `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
 	// Runtime information, e.g., which node the job is running on, its IP address etc,
@@ -1605,7 +1997,7 @@ func (m *JobSucceeded) Reset()         { *m = JobSucceeded{} }
 func (m *JobSucceeded) String() string { return proto.CompactTextString(m) }
 func (*JobSucceeded) ProtoMessage()    {}
 func (*JobSucceeded) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{16}
+	return fileDescriptor_6aab92ca59e015f8, []int{20}
 }
 func (m *JobSucceeded) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1676,7 +2068,7 @@ func (m *JobRunLeased) Reset()         { *m = JobRunLeased{} }
 func (m *JobRunLeased) String() string { return proto.CompactTextString(m) }
 func (*JobRunLeased) ProtoMessage()    {}
 func (*JobRunLeased) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{17}
+	return fileDescriptor_6aab92ca59e015f8, []int{21}
 }
 func (m *JobRunLeased) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1775,7 +2167,7 @@ func (m *JobRunAssigned) Reset()         { *m = JobRunAssigned{} }
 func (m *JobRunAssigned) String() string { return proto.CompactTextString(m) }
 func (*JobRunAssigned) ProtoMessage()    {}
 func (*JobRunAssigned) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{18}
+	return fileDescriptor_6aab92ca59e015f8, []int{22}
 }
 func (m *JobRunAssigned) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1838,7 +2230,7 @@ func (m *JobRunRunning) Reset()         { *m = JobRunRunning{} }
 func (m *JobRunRunning) String() string { return proto.CompactTextString(m) }
 func (*JobRunRunning) ProtoMessage()    {}
 func (*JobRunRunning) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{19}
+	return fileDescriptor_6aab92ca59e015f8, []int{23}
 }
 func (m *JobRunRunning) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1901,7 +2293,7 @@ func (m *KubernetesResourceInfo) Reset()         { *m = KubernetesResourceInfo{}
 func (m *KubernetesResourceInfo) String() string { return proto.CompactTextString(m) }
 func (*KubernetesResourceInfo) ProtoMessage()    {}
 func (*KubernetesResourceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{20}
+	return fileDescriptor_6aab92ca59e015f8, []int{24}
 }
 func (m *KubernetesResourceInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1992,7 +2384,7 @@ func (m *PodInfo) Reset()         { *m = PodInfo{} }
 func (m *PodInfo) String() string { return proto.CompactTextString(m) }
 func (*PodInfo) ProtoMessage()    {}
 func (*PodInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{21}
+	return fileDescriptor_6aab92ca59e015f8, []int{25}
 }
 func (m *PodInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2047,7 +2439,7 @@ func (m *IngressInfo) Reset()         { *m = IngressInfo{} }
 func (m *IngressInfo) String() string { return proto.CompactTextString(m) }
 func (*IngressInfo) ProtoMessage()    {}
 func (*IngressInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{22}
+	return fileDescriptor_6aab92ca59e015f8, []int{26}
 }
 func (m *IngressInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2110,7 +2502,7 @@ func (m *StandaloneIngressInfo) Reset()         { *m = StandaloneIngressInfo{} }
 func (m *StandaloneIngressInfo) String() string { return proto.CompactTextString(m) }
 func (*StandaloneIngressInfo) ProtoMessage()    {}
 func (*StandaloneIngressInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{23}
+	return fileDescriptor_6aab92ca59e015f8, []int{27}
 }
 func (m *StandaloneIngressInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2209,7 +2601,7 @@ func (m *JobRunSucceeded) Reset()         { *m = JobRunSucceeded{} }
 func (m *JobRunSucceeded) String() string { return proto.CompactTextString(m) }
 func (*JobRunSucceeded) ProtoMessage()    {}
 func (*JobRunSucceeded) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{24}
+	return fileDescriptor_6aab92ca59e015f8, []int{28}
 }
 func (m *JobRunSucceeded) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2272,7 +2664,7 @@ func (m *JobErrors) Reset()         { *m = JobErrors{} }
 func (m *JobErrors) String() string { return proto.CompactTextString(m) }
 func (*JobErrors) ProtoMessage()    {}
 func (*JobErrors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{25}
+	return fileDescriptor_6aab92ca59e015f8, []int{29}
 }
 func (m *JobErrors) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2333,7 +2725,7 @@ func (m *JobRunErrors) Reset()         { *m = JobRunErrors{} }
 func (m *JobRunErrors) String() string { return proto.CompactTextString(m) }
 func (*JobRunErrors) ProtoMessage()    {}
 func (*JobRunErrors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{26}
+	return fileDescriptor_6aab92ca59e015f8, []int{30}
 }
 func (m *JobRunErrors) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2408,14 +2800,20 @@ type Error struct {
 	//	*Error_PodTerminated
 	//	*Error_JobRunPreemptedError
 	//	*Error_GangJobUnschedulable
+	//	*Error_QueueDoesNotExist
+	//	*Error_QueueBacklogLimitReached
+	//	*Error_JobExceedsLargestNode
+	//	*Error_JobForceFailed
 	Reason isError_Reason `protobuf_oneof:"reason"`
+	// Name of the run error classification rule that determined whether the job was retried, if any.
+	Classification string `protobuf:"bytes,15,opt,name=classification,proto3" json:"classification,omitempty"`
 }
 
 func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{27}
+	return fileDescriptor_6aab92ca59e015f8, []int{31}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2483,18 +2881,34 @@ type Error_JobRunPreemptedError struct {
 type Error_GangJobUnschedulable struct {
 	GangJobUnschedulable *GangJobUnschedulable `protobuf:"bytes,12,opt,name=gangJobUnschedulable,proto3,oneof" json:"gangJobUnschedulable,omitempty"`
 }
+type Error_QueueDoesNotExist struct {
+	QueueDoesNotExist *QueueDoesNotExist `protobuf:"bytes,13,opt,name=queueDoesNotExist,proto3,oneof" json:"queueDoesNotExist,omitempty"`
+}
+type Error_QueueBacklogLimitReached struct {
+	QueueBacklogLimitReached *QueueBacklogLimitReached `protobuf:"bytes,14,opt,name=queueBacklogLimitReached,proto3,oneof" json:"queueBacklogLimitReached,omitempty"`
+}
+type Error_JobExceedsLargestNode struct {
+	JobExceedsLargestNode *JobExceedsLargestNode `protobuf:"bytes,16,opt,name=jobExceedsLargestNode,proto3,oneof" json:"jobExceedsLargestNode,omitempty"`
+}
+type Error_JobForceFailed struct {
+	JobForceFailed *JobForceFailed `protobuf:"bytes,17,opt,name=jobForceFailed,proto3,oneof" json:"jobForceFailed,omitempty"`
+}
 
-func (*Error_KubernetesError) isError_Reason()      {}
-func (*Error_ContainerError) isError_Reason()       {}
-func (*Error_ExecutorError) isError_Reason()        {}
-func (*Error_PodUnschedulable) isError_Reason()     {}
-func (*Error_LeaseExpired) isError_Reason()         {}
-func (*Error_MaxRunsExceeded) isError_Reason()      {}
-func (*Error_PodError) isError_Reason()             {}
-func (*Error_PodLeaseReturned) isError_Reason()     {}
-func (*Error_PodTerminated) isError_Reason()        {}
-func (*Error_JobRunPreemptedError) isError_Reason() {}
-func (*Error_GangJobUnschedulable) isError_Reason() {}
+func (*Error_KubernetesError) isError_Reason()          {}
+func (*Error_ContainerError) isError_Reason()           {}
+func (*Error_ExecutorError) isError_Reason()            {}
+func (*Error_PodUnschedulable) isError_Reason()         {}
+func (*Error_LeaseExpired) isError_Reason()             {}
+func (*Error_MaxRunsExceeded) isError_Reason()          {}
+func (*Error_PodError) isError_Reason()                 {}
+func (*Error_PodLeaseReturned) isError_Reason()         {}
+func (*Error_PodTerminated) isError_Reason()            {}
+func (*Error_JobRunPreemptedError) isError_Reason()     {}
+func (*Error_GangJobUnschedulable) isError_Reason()     {}
+func (*Error_QueueDoesNotExist) isError_Reason()        {}
+func (*Error_QueueBacklogLimitReached) isError_Reason() {}
+func (*Error_JobExceedsLargestNode) isError_Reason()    {}
+func (*Error_JobForceFailed) isError_Reason()           {}
 
 func (m *Error) GetReason() isError_Reason {
 	if m != nil {
@@ -2587,6 +3001,41 @@ func (m *Error) GetGangJobUnschedulable() *GangJobUnschedulable {
 	return nil
 }
 
+func (m *Error) GetQueueDoesNotExist() *QueueDoesNotExist {
+	if x, ok := m.GetReason().(*Error_QueueDoesNotExist); ok {
+		return x.QueueDoesNotExist
+	}
+	return nil
+}
+
+func (m *Error) GetQueueBacklogLimitReached() *QueueBacklogLimitReached {
+	if x, ok := m.GetReason().(*Error_QueueBacklogLimitReached); ok {
+		return x.QueueBacklogLimitReached
+	}
+	return nil
+}
+
+func (m *Error) GetJobExceedsLargestNode() *JobExceedsLargestNode {
+	if x, ok := m.GetReason().(*Error_JobExceedsLargestNode); ok {
+		return x.JobExceedsLargestNode
+	}
+	return nil
+}
+
+func (m *Error) GetJobForceFailed() *JobForceFailed {
+	if x, ok := m.GetReason().(*Error_JobForceFailed); ok {
+		return x.JobForceFailed
+	}
+	return nil
+}
+
+func (m *Error) GetClassification() string {
+	if m != nil {
+		return m.Classification
+	}
+	return ""
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Error) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -2601,6 +3050,10 @@ func (*Error) XXX_OneofWrappers() []interface{} {
 		(*Error_PodTerminated)(nil),
 		(*Error_JobRunPreemptedError)(nil),
 		(*Error_GangJobUnschedulable)(nil),
+		(*Error_QueueDoesNotExist)(nil),
+		(*Error_QueueBacklogLimitReached)(nil),
+		(*Error_JobExceedsLargestNode)(nil),
+		(*Error_JobForceFailed)(nil),
 	}
 }
 
@@ -2616,7 +3069,7 @@ func (m *KubernetesError) Reset()         { *m = KubernetesError{} }
 func (m *KubernetesError) String() string { return proto.CompactTextString(m) }
 func (*KubernetesError) ProtoMessage()    {}
 func (*KubernetesError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{28}
+	return fileDescriptor_6aab92ca59e015f8, []int{32}
 }
 func (m *KubernetesError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2674,7 +3127,7 @@ func (m *PodError) Reset()         { *m = PodError{} }
 func (m *PodError) String() string { return proto.CompactTextString(m) }
 func (*PodError) ProtoMessage()    {}
 func (*PodError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{29}
+	return fileDescriptor_6aab92ca59e015f8, []int{33}
 }
 func (m *PodError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2762,7 +3215,7 @@ func (m *ContainerError) Reset()         { *m = ContainerError{} }
 func (m *ContainerError) String() string { return proto.CompactTextString(m) }
 func (*ContainerError) ProtoMessage()    {}
 func (*ContainerError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{30}
+	return fileDescriptor_6aab92ca59e015f8, []int{34}
 }
 func (m *ContainerError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2832,13 +3285,15 @@ type PodLeaseReturned struct {
 	Message      string      `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	PodNumber    int32       `protobuf:"varint,3,opt,name=pod_number,json=podNumber,proto3" json:"podNumber,omitempty"`
 	RunAttempted bool        `protobuf:"varint,4,opt,name=run_attempted,json=runAttempted,proto3" json:"runAttempted,omitempty"`
+	// True if the executor never acted on the lease, i.e., it never created a pod for it.
+	LeaseNeverAcknowledged bool `protobuf:"varint,5,opt,name=lease_never_acknowledged,json=leaseNeverAcknowledged,proto3" json:"leaseNeverAcknowledged,omitempty"`
 }
 
 func (m *PodLeaseReturned) Reset()         { *m = PodLeaseReturned{} }
 func (m *PodLeaseReturned) String() string { return proto.CompactTextString(m) }
 func (*PodLeaseReturned) ProtoMessage()    {}
 func (*PodLeaseReturned) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{31}
+	return fileDescriptor_6aab92ca59e015f8, []int{35}
 }
 func (m *PodLeaseReturned) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2895,6 +3350,13 @@ func (m *PodLeaseReturned) GetRunAttempted() bool {
 	return false
 }
 
+func (m *PodLeaseReturned) GetLeaseNeverAcknowledged() bool {
+	if m != nil {
+		return m.LeaseNeverAcknowledged
+	}
+	return false
+}
+
 // Indicates that the lease on the job that the pod was part of could not be renewed.
 // If this happens, the executor deletes the pod and generates a JobRunError with this message as the reason.
 type PodTerminated struct {
@@ -2909,7 +3371,7 @@ func (m *PodTerminated) Reset()         { *m = PodTerminated{} }
 func (m *PodTerminated) String() string { return proto.CompactTextString(m) }
 func (*PodTerminated) ProtoMessage()    {}
 func (*PodTerminated) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{32}
+	return fileDescriptor_6aab92ca59e015f8, []int{36}
 }
 func (m *PodTerminated) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2973,7 +3435,7 @@ func (m *ExecutorError) Reset()         { *m = ExecutorError{} }
 func (m *ExecutorError) String() string { return proto.CompactTextString(m) }
 func (*ExecutorError) ProtoMessage()    {}
 func (*ExecutorError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{33}
+	return fileDescriptor_6aab92ca59e015f8, []int{37}
 }
 func (m *ExecutorError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3014,7 +3476,7 @@ func (m *PodUnschedulable) Reset()         { *m = PodUnschedulable{} }
 func (m *PodUnschedulable) String() string { return proto.CompactTextString(m) }
 func (*PodUnschedulable) ProtoMessage()    {}
 func (*PodUnschedulable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{34}
+	return fileDescriptor_6aab92ca59e015f8, []int{38}
 }
 func (m *PodUnschedulable) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3078,7 +3540,7 @@ func (m *LeaseExpired) Reset()         { *m = LeaseExpired{} }
 func (m *LeaseExpired) String() string { return proto.CompactTextString(m) }
 func (*LeaseExpired) ProtoMessage()    {}
 func (*LeaseExpired) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{35}
+	return fileDescriptor_6aab92ca59e015f8, []int{39}
 }
 func (m *LeaseExpired) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3115,7 +3577,7 @@ func (m *MaxRunsExceeded) Reset()         { *m = MaxRunsExceeded{} }
 func (m *MaxRunsExceeded) String() string { return proto.CompactTextString(m) }
 func (*MaxRunsExceeded) ProtoMessage()    {}
 func (*MaxRunsExceeded) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{36}
+	return fileDescriptor_6aab92ca59e015f8, []int{40}
 }
 func (m *MaxRunsExceeded) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3158,7 +3620,7 @@ func (m *JobRunPreemptedError) Reset()         { *m = JobRunPreemptedError{} }
 func (m *JobRunPreemptedError) String() string { return proto.CompactTextString(m) }
 func (*JobRunPreemptedError) ProtoMessage()    {}
 func (*JobRunPreemptedError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{37}
+	return fileDescriptor_6aab92ca59e015f8, []int{41}
 }
 func (m *JobRunPreemptedError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3195,7 +3657,7 @@ func (m *GangJobUnschedulable) Reset()         { *m = GangJobUnschedulable{} }
 func (m *GangJobUnschedulable) String() string { return proto.CompactTextString(m) }
 func (*GangJobUnschedulable) ProtoMessage()    {}
 func (*GangJobUnschedulable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{38}
+	return fileDescriptor_6aab92ca59e015f8, []int{42}
 }
 func (m *GangJobUnschedulable) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3231,6 +3693,256 @@ func (m *GangJobUnschedulable) GetMessage() string {
 	return ""
 }
 
+// Indicates that a job was failed by the scheduler because the queue it was submitted to does not exist.
+type QueueDoesNotExist struct {
+	Queue   string `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *QueueDoesNotExist) Reset()         { *m = QueueDoesNotExist{} }
+func (m *QueueDoesNotExist) String() string { return proto.CompactTextString(m) }
+func (*QueueDoesNotExist) ProtoMessage()    {}
+func (*QueueDoesNotExist) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6aab92ca59e015f8, []int{43}
+}
+func (m *QueueDoesNotExist) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueueDoesNotExist) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueueDoesNotExist.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueueDoesNotExist) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueDoesNotExist.Merge(m, src)
+}
+func (m *QueueDoesNotExist) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueueDoesNotExist) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueDoesNotExist.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueDoesNotExist proto.InternalMessageInfo
+
+func (m *QueueDoesNotExist) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *QueueDoesNotExist) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Indicates that a job was failed by the scheduler because its queue already had the maximum number of queued jobs.
+type QueueBacklogLimitReached struct {
+	Queue string `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	// Maximum number of queued jobs allowed in the queue.
+	Limit   uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *QueueBacklogLimitReached) Reset()         { *m = QueueBacklogLimitReached{} }
+func (m *QueueBacklogLimitReached) String() string { return proto.CompactTextString(m) }
+func (*QueueBacklogLimitReached) ProtoMessage()    {}
+func (*QueueBacklogLimitReached) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6aab92ca59e015f8, []int{44}
+}
+func (m *QueueBacklogLimitReached) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueueBacklogLimitReached) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueueBacklogLimitReached.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueueBacklogLimitReached) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueBacklogLimitReached.Merge(m, src)
+}
+func (m *QueueBacklogLimitReached) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueueBacklogLimitReached) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueBacklogLimitReached.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueBacklogLimitReached proto.InternalMessageInfo
+
+func (m *QueueBacklogLimitReached) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *QueueBacklogLimitReached) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *QueueBacklogLimitReached) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Indicates that a job was failed by the scheduler because it requests more of some resource than any node has allocatable.
+type JobExceedsLargestNode struct {
+	// Name of the resource, e.g., "memory".
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Amount of the resource requested by the job.
+	Requested string `protobuf:"bytes,2,opt,name=requested,proto3" json:"requested,omitempty"`
+	// Largest amount of the resource allocatable on any node.
+	Largest string `protobuf:"bytes,3,opt,name=largest,proto3" json:"largest,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *JobExceedsLargestNode) Reset()         { *m = JobExceedsLargestNode{} }
+func (m *JobExceedsLargestNode) String() string { return proto.CompactTextString(m) }
+func (*JobExceedsLargestNode) ProtoMessage()    {}
+func (*JobExceedsLargestNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6aab92ca59e015f8, []int{45}
+}
+func (m *JobExceedsLargestNode) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobExceedsLargestNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobExceedsLargestNode.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobExceedsLargestNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobExceedsLargestNode.Merge(m, src)
+}
+func (m *JobExceedsLargestNode) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobExceedsLargestNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobExceedsLargestNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobExceedsLargestNode proto.InternalMessageInfo
+
+func (m *JobExceedsLargestNode) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *JobExceedsLargestNode) GetRequested() string {
+	if m != nil {
+		return m.Requested
+	}
+	return ""
+}
+
+func (m *JobExceedsLargestNode) GetLargest() string {
+	if m != nil {
+		return m.Largest
+	}
+	return ""
+}
+
+func (m *JobExceedsLargestNode) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Indicates that a job was failed by an operator via the scheduler's ForceFailJob admin endpoint.
+type JobForceFailed struct {
+	// Reason provided by the operator.
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Name of the principal that requested the job be failed.
+	Principal string `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	Message   string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *JobForceFailed) Reset()         { *m = JobForceFailed{} }
+func (m *JobForceFailed) String() string { return proto.CompactTextString(m) }
+func (*JobForceFailed) ProtoMessage()    {}
+func (*JobForceFailed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6aab92ca59e015f8, []int{46}
+}
+func (m *JobForceFailed) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobForceFailed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobForceFailed.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobForceFailed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobForceFailed.Merge(m, src)
+}
+func (m *JobForceFailed) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobForceFailed) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobForceFailed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobForceFailed proto.InternalMessageInfo
+
+func (m *JobForceFailed) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *JobForceFailed) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *JobForceFailed) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 // Generated by the scheduler whenever it detects a SubmitJob message that includes a previously used deduplication id
 // (i.e., when it detects a duplicate job submission).
 type JobDuplicateDetected struct {
@@ -3242,7 +3954,7 @@ func (m *JobDuplicateDetected) Reset()         { *m = JobDuplicateDetected{} }
 func (m *JobDuplicateDetected) String() string { return proto.CompactTextString(m) }
 func (*JobDuplicateDetected) ProtoMessage()    {}
 func (*JobDuplicateDetected) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{39}
+	return fileDescriptor_6aab92ca59e015f8, []int{47}
 }
 func (m *JobDuplicateDetected) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3301,7 +4013,7 @@ func (m *JobRunPreempted) Reset()         { *m = JobRunPreempted{} }
 func (m *JobRunPreempted) String() string { return proto.CompactTextString(m) }
 func (*JobRunPreempted) ProtoMessage()    {}
 func (*JobRunPreempted) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{40}
+	return fileDescriptor_6aab92ca59e015f8, []int{48}
 }
 func (m *JobRunPreempted) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3370,7 +4082,7 @@ func (m *PartitionMarker) Reset()         { *m = PartitionMarker{} }
 func (m *PartitionMarker) String() string { return proto.CompactTextString(m) }
 func (*PartitionMarker) ProtoMessage()    {}
 func (*PartitionMarker) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{41}
+	return fileDescriptor_6aab92ca59e015f8, []int{49}
 }
 func (m *PartitionMarker) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3423,7 +4135,7 @@ func (m *JobRunPreemptionRequested) Reset()         { *m = JobRunPreemptionReque
 func (m *JobRunPreemptionRequested) String() string { return proto.CompactTextString(m) }
 func (*JobRunPreemptionRequested) ProtoMessage()    {}
 func (*JobRunPreemptionRequested) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6aab92ca59e015f8, []int{42}
+	return fileDescriptor_6aab92ca59e015f8, []int{50}
 }
 func (m *JobRunPreemptionRequested) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3471,6 +4183,7 @@ func init() {
 	proto.RegisterEnum("armadaevents.KubernetesReason", KubernetesReason_name, KubernetesReason_value)
 	proto.RegisterType((*EventSequence)(nil), "armadaevents.EventSequence")
 	proto.RegisterType((*EventSequence_Event)(nil), "armadaevents.EventSequence.Event")
+	proto.RegisterType((*Provenance)(nil), "armadaevents.Provenance")
 	proto.RegisterType((*ResourceUtilisation)(nil), "armadaevents.ResourceUtilisation")
 	proto.RegisterMapType((map[string]resource.Quantity)(nil), "armadaevents.ResourceUtilisation.MaxResourcesForPeriodEntry")
 	proto.RegisterMapType((map[string]resource.Quantity)(nil), "armadaevents.ResourceUtilisation.TotalCumulativeUsageEntry")
@@ -3484,12 +4197,15 @@ func init() {
 	proto.RegisterType((*PodSpecWithAvoidList)(nil), "armadaevents.PodSpecWithAvoidList")
 	proto.RegisterType((*ReprioritiseJob)(nil), "armadaevents.ReprioritiseJob")
 	proto.RegisterType((*JobRequeued)(nil), "armadaevents.JobRequeued")
+	proto.RegisterType((*JobValidated)(nil), "armadaevents.JobValidated")
+	proto.RegisterType((*JobRetriesExhausted)(nil), "armadaevents.JobRetriesExhausted")
 	proto.RegisterType((*ReprioritiseJobSet)(nil), "armadaevents.ReprioritiseJobSet")
 	proto.RegisterType((*ReprioritisedJob)(nil), "armadaevents.ReprioritisedJob")
 	proto.RegisterType((*CancelJob)(nil), "armadaevents.CancelJob")
 	proto.RegisterType((*JobSetFilter)(nil), "armadaevents.JobSetFilter")
 	proto.RegisterType((*CancelJobSet)(nil), "armadaevents.CancelJobSet")
 	proto.RegisterType((*CancelledJob)(nil), "armadaevents.CancelledJob")
+	proto.RegisterType((*JobRunCancelled)(nil), "armadaevents.JobRunCancelled")
 	proto.RegisterType((*JobSucceeded)(nil), "armadaevents.JobSucceeded")
 	proto.RegisterType((*JobRunLeased)(nil), "armadaevents.JobRunLeased")
 	proto.RegisterMapType((map[string]string)(nil), "armadaevents.JobRunLeased.AdditionalAnnotationsEntry")
@@ -3516,6 +4232,10 @@ func init() {
 	proto.RegisterType((*MaxRunsExceeded)(nil), "armadaevents.MaxRunsExceeded")
 	proto.RegisterType((*JobRunPreemptedError)(nil), "armadaevents.JobRunPreemptedError")
 	proto.RegisterType((*GangJobUnschedulable)(nil), "armadaevents.GangJobUnschedulable")
+	proto.RegisterType((*QueueDoesNotExist)(nil), "armadaevents.QueueDoesNotExist")
+	proto.RegisterType((*QueueBacklogLimitReached)(nil), "armadaevents.QueueBacklogLimitReached")
+	proto.RegisterType((*JobExceedsLargestNode)(nil), "armadaevents.JobExceedsLargestNode")
+	proto.RegisterType((*JobForceFailed)(nil), "armadaevents.JobForceFailed")
 	proto.RegisterType((*JobDuplicateDetected)(nil), "armadaevents.JobDuplicateDetected")
 	proto.RegisterType((*JobRunPreempted)(nil), "armadaevents.JobRunPreempted")
 	proto.RegisterType((*PartitionMarker)(nil), "armadaevents.PartitionMarker")
@@ -3525,233 +4245,283 @@ func init() {
 func init() { proto.RegisterFile("pkg/armadaevents/events.proto", fileDescriptor_6aab92ca59e015f8) }
 
 var fileDescriptor_6aab92ca59e015f8 = []byte{
-	// 3612 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5b, 0x49, 0x70, 0x1b, 0xd7,
-	0x99, 0x56, 0x03, 0x24, 0x96, 0x1f, 0x5c, 0xa0, 0x27, 0x92, 0x86, 0x68, 0x89, 0xa0, 0x5b, 0x9e,
-	0xb1, 0xec, 0xb2, 0x41, 0x5b, 0x5e, 0xca, 0xcb, 0x94, 0x5d, 0x84, 0x44, 0x6b, 0xb1, 0x28, 0xd1,
-	0xa0, 0xe8, 0xd1, 0xb8, 0x3c, 0x05, 0x37, 0xd0, 0x8f, 0x60, 0x8b, 0x8d, 0xee, 0x76, 0x77, 0x83,
-	0x22, 0xab, 0x7c, 0x98, 0x99, 0x9a, 0xf1, 0xdc, 0x1c, 0xa5, 0x92, 0x43, 0xaa, 0x72, 0x70, 0xae,
-	0x71, 0x55, 0xce, 0x39, 0xe7, 0x14, 0x1f, 0x52, 0x29, 0xe7, 0x96, 0x13, 0x92, 0xb2, 0x2b, 0x87,
-	0xe0, 0x90, 0x73, 0x92, 0x4b, 0x52, 0x6f, 0xeb, 0x7e, 0xaf, 0xbb, 0x21, 0x51, 0x5b, 0xe4, 0x94,
-	0x4e, 0x64, 0x7f, 0xff, 0xda, 0x6f, 0xf9, 0xfb, 0x7f, 0xff, 0xfb, 0x01, 0x27, 0xbd, 0xdd, 0xde,
-	0x8a, 0xe1, 0xf7, 0x0d, 0xd3, 0xc0, 0x7b, 0xd8, 0x09, 0x83, 0x15, 0xf6, 0xa7, 0xe1, 0xf9, 0x6e,
-	0xe8, 0xa2, 0x29, 0x99, 0xb4, 0xa8, 0xef, 0xbe, 0x1e, 0x34, 0x2c, 0x77, 0xc5, 0xf0, 0xac, 0x95,
-	0xae, 0xeb, 0xe3, 0x95, 0xbd, 0x97, 0x56, 0x7a, 0xd8, 0xc1, 0xbe, 0x11, 0x62, 0x93, 0x49, 0x2c,
-	0x9e, 0x96, 0x78, 0x1c, 0x1c, 0xde, 0x74, 0xfd, 0x5d, 0xcb, 0xe9, 0x65, 0x71, 0xd6, 0x7b, 0xae,
-	0xdb, 0xb3, 0xf1, 0x0a, 0x7d, 0xea, 0x0c, 0xb6, 0x57, 0x42, 0xab, 0x8f, 0x83, 0xd0, 0xe8, 0x7b,
-	0x9c, 0x61, 0x29, 0xc9, 0x70, 0xd3, 0x37, 0x3c, 0x0f, 0xfb, 0xdc, 0xb9, 0xc5, 0x57, 0x62, 0x53,
-	0x7d, 0xa3, 0xbb, 0x63, 0x39, 0xd8, 0x3f, 0x58, 0xa1, 0xef, 0xe3, 0x59, 0x2b, 0x3e, 0x0e, 0xdc,
-	0x81, 0xdf, 0xc5, 0x29, 0xb3, 0x2f, 0xf4, 0xac, 0x70, 0x67, 0xd0, 0x69, 0x74, 0xdd, 0xfe, 0x4a,
-	0xcf, 0xed, 0xb9, 0xb1, 0x7a, 0xf2, 0x44, 0x1f, 0xe8, 0x7f, 0x9c, 0xfd, 0x4d, 0xcb, 0x09, 0xb1,
-	0xef, 0x18, 0xf6, 0x4a, 0xd0, 0xdd, 0xc1, 0xe6, 0xc0, 0xc6, 0x7e, 0xfc, 0x9f, 0xdb, 0xb9, 0x81,
-	0xbb, 0x61, 0x90, 0x02, 0x98, 0xac, 0x7e, 0x6b, 0x0e, 0xa6, 0xd7, 0xc8, 0xd0, 0x6d, 0xe2, 0x4f,
-	0x06, 0xd8, 0xe9, 0x62, 0xf4, 0x2c, 0x4c, 0x7e, 0x32, 0xc0, 0x03, 0x5c, 0xd3, 0x96, 0xb5, 0xd3,
-	0xe5, 0xe6, 0xb1, 0xd1, 0xb0, 0x3e, 0x4b, 0x81, 0xe7, 0xdd, 0xbe, 0x15, 0xe2, 0xbe, 0x17, 0x1e,
-	0xb4, 0x18, 0x07, 0x7a, 0x13, 0xa6, 0x6e, 0xb8, 0x9d, 0x76, 0x80, 0xc3, 0xb6, 0x63, 0xf4, 0x71,
-	0x2d, 0x47, 0x25, 0x6a, 0xa3, 0x61, 0x7d, 0xee, 0x86, 0xdb, 0xd9, 0xc4, 0xe1, 0x15, 0xa3, 0x2f,
-	0x8b, 0x41, 0x8c, 0xa2, 0x17, 0xa0, 0x38, 0x08, 0xb0, 0xdf, 0xb6, 0xcc, 0x5a, 0x9e, 0x8a, 0xcd,
-	0x8d, 0x86, 0xf5, 0x2a, 0x81, 0x2e, 0x9a, 0x92, 0x48, 0x81, 0x21, 0xe8, 0x79, 0x28, 0xf4, 0x7c,
-	0x77, 0xe0, 0x05, 0xb5, 0x89, 0xe5, 0xbc, 0xe0, 0x66, 0x88, 0xcc, 0xcd, 0x10, 0x74, 0x15, 0x0a,
-	0x6c, 0x3d, 0xd4, 0x26, 0x97, 0xf3, 0xa7, 0x2b, 0x67, 0x9e, 0x6a, 0xc8, 0x8b, 0xa4, 0xa1, 0xbc,
-	0x30, 0x7b, 0x62, 0x0a, 0x19, 0x5d, 0x56, 0xc8, 0x97, 0xd5, 0x1f, 0x8f, 0xc2, 0x24, 0xe5, 0x43,
-	0x57, 0xa1, 0xd8, 0xf5, 0x31, 0x99, 0xac, 0x1a, 0x5a, 0xd6, 0x4e, 0x57, 0xce, 0x2c, 0x36, 0xd8,
-	0x1a, 0x68, 0x88, 0x49, 0x6a, 0x5c, 0x13, 0x8b, 0xa4, 0x79, 0x7c, 0x34, 0xac, 0x1f, 0xe5, 0xec,
-	0xb1, 0xd6, 0x5b, 0xbf, 0xab, 0x6b, 0x2d, 0xa1, 0x05, 0x6d, 0x40, 0x39, 0x18, 0x74, 0xfa, 0x56,
-	0x78, 0xc9, 0xed, 0xd0, 0x31, 0xaf, 0x9c, 0x79, 0x42, 0x75, 0x77, 0x53, 0x90, 0x9b, 0x4f, 0x8c,
-	0x86, 0xf5, 0x63, 0x11, 0x77, 0xac, 0xf1, 0xc2, 0x91, 0x56, 0xac, 0x04, 0xed, 0xc0, 0xac, 0x8f,
-	0x3d, 0xdf, 0x72, 0x7d, 0x2b, 0xb4, 0x02, 0x4c, 0xf4, 0xe6, 0xa8, 0xde, 0x93, 0xaa, 0xde, 0x96,
-	0xca, 0xd4, 0x3c, 0x39, 0x1a, 0xd6, 0x8f, 0x27, 0x24, 0x15, 0x1b, 0x49, 0xb5, 0x28, 0x04, 0x94,
-	0x80, 0x36, 0x71, 0x48, 0xe7, 0xb3, 0x72, 0x66, 0xf9, 0xb6, 0xc6, 0x36, 0x71, 0xd8, 0x5c, 0x1e,
-	0x0d, 0xeb, 0x27, 0xd2, 0xf2, 0x8a, 0xc9, 0x0c, 0xfd, 0xc8, 0x86, 0xaa, 0x8c, 0x9a, 0xe4, 0x05,
-	0x27, 0xa8, 0xcd, 0xa5, 0xf1, 0x36, 0x09, 0x57, 0x73, 0x69, 0x34, 0xac, 0x2f, 0x26, 0x65, 0x15,
-	0x7b, 0x29, 0xcd, 0x64, 0x7e, 0xba, 0x86, 0xd3, 0xc5, 0x36, 0x31, 0x33, 0x99, 0x35, 0x3f, 0x67,
-	0x05, 0x99, 0xcd, 0x4f, 0xc4, 0xad, 0xce, 0x4f, 0x04, 0xa3, 0x8f, 0x60, 0x2a, 0x7a, 0x20, 0xe3,
-	0x55, 0xe0, 0xeb, 0x28, 0x5b, 0x29, 0x19, 0xa9, 0xc5, 0xd1, 0xb0, 0xbe, 0x20, 0xcb, 0x28, 0xaa,
-	0x15, 0x6d, 0xb1, 0x76, 0x9b, 0x8d, 0x4c, 0x71, 0xbc, 0x76, 0xc6, 0x21, 0x6b, 0xb7, 0xd3, 0x23,
-	0xa2, 0x68, 0x23, 0xda, 0xc9, 0x26, 0x1e, 0x74, 0xbb, 0x18, 0x9b, 0xd8, 0xac, 0x95, 0xb2, 0xb4,
-	0x5f, 0x92, 0x38, 0x98, 0x76, 0x59, 0x46, 0xd5, 0x2e, 0x53, 0xc8, 0x58, 0xdf, 0x70, 0x3b, 0x6b,
-	0xbe, 0xef, 0xfa, 0x41, 0xad, 0x9c, 0x35, 0xd6, 0x97, 0x04, 0x99, 0x8d, 0x75, 0xc4, 0xad, 0x8e,
-	0x75, 0x04, 0x73, 0x7f, 0x5b, 0x03, 0xe7, 0x32, 0x36, 0x02, 0x6c, 0xd6, 0x60, 0x8c, 0xbf, 0x11,
-	0x47, 0xe4, 0x6f, 0x84, 0xa4, 0xfc, 0x8d, 0x28, 0xc8, 0x84, 0x19, 0xf6, 0xbc, 0x1a, 0x04, 0x56,
-	0xcf, 0xc1, 0x66, 0xad, 0x42, 0xf5, 0x9f, 0xc8, 0xd2, 0x2f, 0x78, 0x9a, 0x27, 0x46, 0xc3, 0x7a,
-	0x4d, 0x95, 0x53, 0x6c, 0x24, 0x74, 0xa2, 0x8f, 0x61, 0x9a, 0x21, 0xad, 0x81, 0xe3, 0x58, 0x4e,
-	0xaf, 0x36, 0x45, 0x8d, 0x3c, 0x99, 0x65, 0x84, 0xb3, 0x34, 0x9f, 0x1c, 0x0d, 0xeb, 0x4f, 0x28,
-	0x52, 0x8a, 0x09, 0x55, 0x21, 0x89, 0x18, 0x0c, 0x88, 0x27, 0x76, 0x3a, 0x2b, 0x62, 0x5c, 0x52,
-	0x99, 0x58, 0xc4, 0x48, 0x48, 0xaa, 0x11, 0x23, 0x41, 0x8c, 0xe7, 0x83, 0x4f, 0xf2, 0xcc, 0xf8,
-	0xf9, 0xe0, 0xf3, 0x2c, 0xcd, 0x47, 0xc6, 0x54, 0x2b, 0xda, 0xd0, 0xa7, 0x40, 0x3e, 0x3c, 0xe7,
-	0x06, 0x9e, 0x6d, 0x75, 0x8d, 0x10, 0x9f, 0xc3, 0x21, 0xee, 0x92, 0x48, 0x3d, 0x4b, 0xad, 0xe8,
-	0x29, 0x2b, 0x29, 0xce, 0xa6, 0x3e, 0x1a, 0xd6, 0x97, 0xb2, 0x74, 0x28, 0x56, 0x33, 0xad, 0xa0,
-	0xff, 0xd2, 0x60, 0x3e, 0x08, 0x0d, 0xc7, 0x34, 0x6c, 0xd7, 0xc1, 0x17, 0x9d, 0x9e, 0x8f, 0x83,
-	0xe0, 0xa2, 0xb3, 0xed, 0xd6, 0xaa, 0xd4, 0xfe, 0xa9, 0x44, 0x58, 0xcf, 0x62, 0x6d, 0x9e, 0x1a,
-	0x0d, 0xeb, 0xf5, 0x4c, 0x2d, 0x8a, 0x07, 0xd9, 0x86, 0xd0, 0x3e, 0x1c, 0x13, 0x59, 0xc5, 0x56,
-	0x68, 0xd9, 0x56, 0x60, 0x84, 0x96, 0xeb, 0xd4, 0x8e, 0x52, 0xfb, 0x4f, 0x25, 0xa3, 0x63, 0x8a,
-	0xb1, 0xf9, 0xd4, 0x68, 0x58, 0x3f, 0x99, 0xa1, 0x41, 0xb1, 0x9d, 0x65, 0x22, 0x5e, 0x42, 0x1b,
-	0x3e, 0x26, 0x8c, 0xd8, 0xac, 0x1d, 0x1b, 0xbf, 0x84, 0x22, 0x26, 0x79, 0x09, 0x45, 0x60, 0xd6,
-	0x12, 0x8a, 0x88, 0xc4, 0x92, 0x67, 0xf8, 0xa1, 0x45, 0xcc, 0xae, 0x1b, 0xfe, 0x2e, 0xf6, 0x6b,
-	0x73, 0x59, 0x96, 0x36, 0x54, 0x26, 0x66, 0x29, 0x21, 0xa9, 0x5a, 0x4a, 0x10, 0xd1, 0x2d, 0x0d,
-	0x54, 0xd7, 0x2c, 0xd7, 0x69, 0x91, 0xb4, 0x21, 0x20, 0xaf, 0x37, 0x4f, 0x8d, 0x3e, 0x73, 0x9b,
-	0xd7, 0x93, 0xd9, 0x9b, 0xcf, 0x8c, 0x86, 0xf5, 0x53, 0x63, 0xb5, 0x29, 0x8e, 0x8c, 0x37, 0x8a,
-	0xae, 0x43, 0x85, 0x10, 0x31, 0x4d, 0xc0, 0xcc, 0xda, 0x02, 0xf5, 0xe1, 0x78, 0xda, 0x07, 0xce,
-	0x40, 0x33, 0x90, 0x79, 0x49, 0x42, 0xb1, 0x23, 0xab, 0x6a, 0x16, 0x61, 0x92, 0xca, 0xeb, 0xa3,
-	0x02, 0x1c, 0xcb, 0x58, 0x1b, 0xe8, 0x6d, 0x28, 0xf8, 0x03, 0x87, 0x24, 0x6c, 0x2c, 0x4b, 0x41,
-	0xaa, 0xd5, 0xad, 0x81, 0x65, 0xb2, 0x6c, 0xd1, 0x1f, 0x38, 0x4a, 0x0e, 0x37, 0x49, 0x01, 0x22,
-	0x4f, 0xb2, 0x45, 0xcb, 0xe4, 0xd9, 0xc8, 0x58, 0xf9, 0x1b, 0x6e, 0x47, 0x95, 0xa7, 0x00, 0xc2,
-	0x30, 0x2d, 0x16, 0x5e, 0xdb, 0x22, 0xbb, 0x8a, 0xe5, 0x19, 0x4f, 0xab, 0x6a, 0xde, 0x1b, 0x74,
-	0xb0, 0xef, 0xe0, 0x10, 0x07, 0xe2, 0x1d, 0xe8, 0xb6, 0xa2, 0x51, 0xc4, 0x97, 0x10, 0x49, 0xff,
-	0x94, 0x8c, 0xa3, 0x1f, 0x6a, 0x50, 0xeb, 0x1b, 0xfb, 0x6d, 0x01, 0x06, 0xed, 0x6d, 0xd7, 0x6f,
-	0x7b, 0xd8, 0xb7, 0x5c, 0x93, 0x26, 0x9f, 0x95, 0x33, 0xff, 0x76, 0xc7, 0x8d, 0xd4, 0x58, 0x37,
-	0xf6, 0x05, 0x1c, 0xbc, 0xeb, 0xfa, 0x1b, 0x54, 0x7c, 0xcd, 0x09, 0xfd, 0x83, 0xe6, 0xc9, 0xaf,
-	0x86, 0xf5, 0x23, 0x64, 0x5a, 0xfa, 0x59, 0x3c, 0xad, 0x6c, 0x18, 0x7d, 0x4f, 0x83, 0x85, 0xd0,
-	0x0d, 0x0d, 0xbb, 0xdd, 0x1d, 0xf4, 0x07, 0xb6, 0x11, 0x5a, 0x7b, 0xb8, 0x3d, 0x08, 0x8c, 0x1e,
-	0xe6, 0x39, 0xee, 0x5b, 0x77, 0x76, 0xea, 0x1a, 0x91, 0x3f, 0x1b, 0x89, 0x6f, 0x11, 0x69, 0xe6,
-	0xd3, 0x09, 0xee, 0xd3, 0x5c, 0x98, 0xc1, 0xd2, 0xca, 0x44, 0x17, 0x7f, 0xa2, 0xc1, 0xe2, 0xf8,
-	0xd7, 0x44, 0xa7, 0x20, 0xbf, 0x8b, 0x0f, 0xf8, 0x29, 0xe2, 0xe8, 0x68, 0x58, 0x9f, 0xde, 0xc5,
-	0x07, 0xd2, 0xa8, 0x13, 0x2a, 0xfa, 0x0f, 0x98, 0xdc, 0x33, 0xec, 0x01, 0xe6, 0x4b, 0xa2, 0xd1,
-	0x60, 0xe7, 0xa5, 0x86, 0x7c, 0x5e, 0x6a, 0x78, 0xbb, 0x3d, 0x02, 0x34, 0xc4, 0x8c, 0x34, 0xde,
-	0x1f, 0x18, 0x4e, 0x68, 0x85, 0x07, 0x6c, 0xb9, 0x50, 0x05, 0xf2, 0x72, 0xa1, 0xc0, 0x9b, 0xb9,
-	0xd7, 0xb5, 0xc5, 0x2f, 0x34, 0x38, 0x3e, 0xf6, 0xa5, 0xbf, 0x0b, 0x1e, 0xea, 0x6d, 0x98, 0x20,
-	0x0b, 0x9f, 0x9c, 0x6f, 0x76, 0xac, 0xde, 0xce, 0x6b, 0xaf, 0x50, 0x77, 0x0a, 0xec, 0x38, 0xc2,
-	0x10, 0xf9, 0x38, 0xc2, 0x10, 0x72, 0x46, 0xb3, 0xdd, 0x9b, 0xaf, 0xbd, 0x42, 0x9d, 0x2a, 0x30,
-	0x23, 0x14, 0x90, 0x8d, 0x50, 0x40, 0xff, 0x5b, 0x01, 0xca, 0xd1, 0x01, 0x42, 0xda, 0x83, 0xda,
-	0x3d, 0xed, 0xc1, 0x0b, 0x50, 0x35, 0xb1, 0xc9, 0xbf, 0x7c, 0x96, 0xeb, 0x88, 0xdd, 0x5c, 0x66,
-	0xd1, 0x55, 0xa1, 0x29, 0xf2, 0xb3, 0x09, 0x12, 0x3a, 0x03, 0x25, 0x9e, 0x68, 0x1f, 0xd0, 0x8d,
-	0x3c, 0xdd, 0x5c, 0x18, 0x0d, 0xeb, 0x48, 0x60, 0x92, 0x68, 0xc4, 0x87, 0x5a, 0x00, 0xec, 0xf4,
-	0xba, 0x8e, 0x43, 0x83, 0xa7, 0xfc, 0x35, 0xf5, 0x0d, 0xae, 0x46, 0x74, 0x76, 0x0e, 0x8d, 0xf9,
-	0xe5, 0x73, 0x68, 0x8c, 0xa2, 0x8f, 0x00, 0xfa, 0x86, 0xe5, 0x30, 0x39, 0x9e, 0xdf, 0xeb, 0xe3,
-	0x42, 0xca, 0x7a, 0xc4, 0xc9, 0xb4, 0xc7, 0x92, 0xb2, 0xf6, 0x18, 0x25, 0xa7, 0x45, 0x7e, 0xde,
-	0xae, 0x15, 0xe8, 0x2e, 0x5d, 0x1a, 0xa7, 0x9a, 0xab, 0x9d, 0x27, 0x27, 0x46, 0x2e, 0x22, 0xe9,
-	0x14, 0x5a, 0xc8, 0xb0, 0xd9, 0xd6, 0x36, 0x0e, 0xad, 0x3e, 0xa6, 0x99, 0x3d, 0x1f, 0x36, 0x81,
-	0xc9, 0xc3, 0x26, 0x30, 0xf4, 0x3a, 0x80, 0x11, 0xae, 0xbb, 0x41, 0x78, 0xd5, 0xe9, 0x62, 0x9a,
-	0xb1, 0x97, 0x98, 0xfb, 0x31, 0x2a, 0xbb, 0x1f, 0xa3, 0xe8, 0x2d, 0xa8, 0x78, 0xfc, 0x23, 0xd4,
-	0xb1, 0x31, 0xcd, 0xc8, 0x4b, 0xec, 0x93, 0x22, 0xc1, 0x92, 0xac, 0xcc, 0x8d, 0xce, 0xc3, 0x6c,
-	0xd7, 0x75, 0xba, 0x03, 0xdf, 0xc7, 0x4e, 0xf7, 0x60, 0xd3, 0xd8, 0xc6, 0x34, 0xfb, 0x2e, 0xb1,
-	0xa5, 0x92, 0x20, 0xc9, 0x4b, 0x25, 0x41, 0x42, 0xaf, 0x42, 0x39, 0xaa, 0x5e, 0xd0, 0x04, 0xbb,
-	0xcc, 0x0f, 0xc2, 0x02, 0x94, 0x84, 0x63, 0x4e, 0xe2, 0xbc, 0x15, 0x44, 0x59, 0x1a, 0x4d, 0x9a,
-	0xb9, 0xf3, 0x12, 0x2c, 0x3b, 0x2f, 0xc1, 0xe8, 0x22, 0x1c, 0xa5, 0xdf, 0xc5, 0x76, 0x18, 0xda,
-	0xed, 0x00, 0x77, 0x5d, 0xc7, 0x0c, 0x68, 0x4e, 0x9c, 0x67, 0xee, 0x53, 0xe2, 0xb5, 0xd0, 0xde,
-	0x64, 0x24, 0xd9, 0xfd, 0x04, 0x49, 0xff, 0x95, 0x06, 0x73, 0x59, 0x4b, 0x28, 0xb1, 0x9c, 0xb5,
-	0x07, 0xb2, 0x9c, 0x3f, 0x80, 0x92, 0xe7, 0x9a, 0xed, 0xc0, 0xc3, 0x5d, 0x1e, 0xb1, 0x12, 0x8b,
-	0x79, 0xc3, 0x35, 0x37, 0x3d, 0xdc, 0xfd, 0x77, 0x2b, 0xdc, 0x59, 0xdd, 0x73, 0x2d, 0xf3, 0xb2,
-	0x15, 0xf0, 0x55, 0xe7, 0x31, 0x8a, 0x92, 0x21, 0x14, 0x39, 0xd8, 0x2c, 0x41, 0x81, 0x59, 0xd1,
-	0x7f, 0x9d, 0x87, 0x6a, 0x72, 0xd9, 0xfe, 0x33, 0xbd, 0x0a, 0xba, 0x0e, 0x45, 0x8b, 0xa5, 0xcc,
-	0x3c, 0x83, 0xf8, 0x17, 0x29, 0xa6, 0x37, 0xe2, 0x82, 0x60, 0x63, 0xef, 0xa5, 0x06, 0xcf, 0xad,
-	0xe9, 0x10, 0x50, 0xcd, 0x5c, 0x52, 0xd5, 0xcc, 0x41, 0xd4, 0x82, 0x62, 0x80, 0xfd, 0x3d, 0xab,
-	0x8b, 0x79, 0x70, 0xaa, 0xcb, 0x9a, 0xbb, 0xae, 0x8f, 0x89, 0xce, 0x4d, 0xc6, 0x12, 0xeb, 0xe4,
-	0x32, 0xaa, 0x4e, 0x0e, 0xa2, 0x0f, 0xa0, 0xdc, 0x75, 0x9d, 0x6d, 0xab, 0xb7, 0x6e, 0x78, 0x3c,
-	0x3c, 0x9d, 0xcc, 0xd2, 0x7a, 0x56, 0x30, 0xf1, 0x22, 0x84, 0x78, 0x4c, 0x14, 0x21, 0x22, 0xae,
-	0x78, 0x42, 0xff, 0x34, 0x01, 0x10, 0x4f, 0x0e, 0x7a, 0x03, 0x2a, 0x78, 0x1f, 0x77, 0x07, 0xa1,
-	0xeb, 0x8b, 0xef, 0x04, 0xaf, 0xe9, 0x09, 0x58, 0x09, 0xec, 0x10, 0xa3, 0x64, 0xa3, 0x3a, 0x46,
-	0x1f, 0x07, 0x9e, 0xd1, 0x15, 0xc5, 0x40, 0xea, 0x4c, 0x04, 0xca, 0x1b, 0x35, 0x02, 0xd1, 0xbf,
-	0xc2, 0x04, 0x2d, 0x1f, 0xb2, 0x3a, 0x20, 0x1a, 0x0d, 0xeb, 0x33, 0x8e, 0x5a, 0x38, 0xa4, 0x74,
-	0xf4, 0x0e, 0x4c, 0xef, 0x46, 0x0b, 0x8f, 0xf8, 0x36, 0x41, 0x05, 0x68, 0x6a, 0x17, 0x13, 0x14,
-	0xef, 0xa6, 0x64, 0x1c, 0x6d, 0x43, 0xc5, 0x70, 0x1c, 0x37, 0xa4, 0xdf, 0x20, 0x51, 0x1b, 0x7c,
-	0x76, 0xdc, 0x32, 0x6d, 0xac, 0xc6, 0xbc, 0x2c, 0x4b, 0xa2, 0xc1, 0x43, 0xd2, 0x20, 0x07, 0x0f,
-	0x09, 0x46, 0x2d, 0x28, 0xd8, 0x46, 0x07, 0xdb, 0x22, 0xe8, 0x3f, 0x3d, 0xd6, 0xc4, 0x65, 0xca,
-	0xc6, 0xb4, 0xd3, 0x4f, 0x3e, 0x93, 0x93, 0x3f, 0xf9, 0x0c, 0x59, 0xdc, 0x86, 0x6a, 0xd2, 0x9f,
-	0xc3, 0x25, 0x30, 0xcf, 0xca, 0x09, 0x4c, 0xf9, 0x8e, 0x29, 0x93, 0x01, 0x15, 0xc9, 0xa9, 0x87,
-	0x61, 0x42, 0xff, 0xa9, 0x06, 0x73, 0x59, 0x7b, 0x17, 0xad, 0x4b, 0x3b, 0x5e, 0xe3, 0x35, 0x8e,
-	0x8c, 0xa5, 0xce, 0x65, 0xc7, 0x6c, 0xf5, 0x78, 0xa3, 0x37, 0x61, 0xc6, 0x71, 0x4d, 0xdc, 0x36,
-	0x88, 0x01, 0xdb, 0x0a, 0xc2, 0x5a, 0x8e, 0xd6, 0x8e, 0x69, 0x6d, 0x84, 0x50, 0x56, 0x05, 0x41,
-	0x92, 0x9e, 0x56, 0x08, 0xfa, 0xff, 0x69, 0x30, 0x9b, 0x28, 0x5d, 0xde, 0x77, 0x12, 0x25, 0xa7,
-	0x3e, 0xb9, 0xc3, 0xa5, 0x3e, 0xfa, 0x0f, 0x72, 0x50, 0x91, 0xce, 0x75, 0xf7, 0xed, 0xc3, 0x0d,
-	0x98, 0xe5, 0x5f, 0x4a, 0xcb, 0xe9, 0xb1, 0xe3, 0x54, 0x8e, 0x17, 0x29, 0x52, 0x37, 0x05, 0x97,
-	0xdc, 0xce, 0x66, 0xc4, 0x4b, 0x4f, 0x53, 0xb4, 0x82, 0x15, 0x28, 0x98, 0x64, 0x62, 0x46, 0xa5,
-	0xa0, 0xeb, 0xb0, 0x30, 0xf0, 0x4c, 0x23, 0xc4, 0xed, 0x80, 0xd7, 0xdc, 0xdb, 0xce, 0xa0, 0xdf,
-	0xc1, 0x3e, 0xdd, 0xf1, 0x93, 0xac, 0xe6, 0xc2, 0x38, 0x44, 0x51, 0xfe, 0x0a, 0xa5, 0x4b, 0x3a,
-	0xe7, 0xb2, 0xe8, 0xfa, 0x05, 0x40, 0xe9, 0xba, 0xb2, 0x32, 0xbe, 0xda, 0x21, 0xc7, 0xf7, 0x33,
-	0x0d, 0xaa, 0xc9, 0x72, 0xf1, 0x23, 0x99, 0xe8, 0x03, 0x28, 0x47, 0xa5, 0xdf, 0xfb, 0x76, 0xe0,
-	0x79, 0x28, 0xf8, 0xd8, 0x08, 0x5c, 0x87, 0xef, 0x4c, 0x1a, 0x62, 0x18, 0x22, 0x87, 0x18, 0x86,
-	0xe8, 0xd7, 0x60, 0x8a, 0x8d, 0xe0, 0xbb, 0x96, 0x1d, 0x62, 0x1f, 0x9d, 0x83, 0x42, 0x10, 0x1a,
-	0x21, 0x0e, 0x6a, 0xda, 0x72, 0xfe, 0xf4, 0xcc, 0x99, 0x85, 0x74, 0x95, 0x97, 0x90, 0x99, 0x56,
-	0xc6, 0x29, 0x6b, 0x65, 0x88, 0xfe, 0x3f, 0x1a, 0x4c, 0xc9, 0xc5, 0xec, 0x07, 0xa3, 0xf6, 0x2e,
-	0x5f, 0xed, 0x53, 0xe1, 0x83, 0xfd, 0x60, 0x66, 0xf6, 0xee, 0xac, 0xff, 0x5c, 0x63, 0x23, 0x1b,
-	0x55, 0x41, 0xef, 0xd7, 0x7c, 0x2f, 0x2e, 0x85, 0x90, 0x1d, 0x16, 0xd0, 0xc0, 0x76, 0xd8, 0x52,
-	0x08, 0x0d, 0x7f, 0x8a, 0xb8, 0x1c, 0xfe, 0x14, 0x82, 0x7e, 0xab, 0x40, 0x3d, 0x8f, 0x2b, 0xde,
-	0x8f, 0xba, 0x08, 0x94, 0xc8, 0x4e, 0xf2, 0x77, 0x91, 0x9d, 0xbc, 0x00, 0x45, 0xfa, 0x39, 0x88,
-	0x12, 0x07, 0x3a, 0x69, 0x04, 0x52, 0x6f, 0x1c, 0x19, 0x72, 0x9b, 0xa8, 0x35, 0x79, 0x7f, 0x51,
-	0x0b, 0xb5, 0xe1, 0xf8, 0x8e, 0x11, 0xb4, 0x45, 0x9c, 0x35, 0xdb, 0x46, 0xd8, 0x8e, 0xe2, 0x44,
-	0x81, 0x1e, 0x53, 0x9e, 0x1e, 0x0d, 0xeb, 0xcb, 0x3b, 0x46, 0xb0, 0x29, 0x78, 0x56, 0xc3, 0x8d,
-	0x74, 0xd4, 0x58, 0xc8, 0xe6, 0x40, 0x5b, 0x30, 0x9f, 0xad, 0xbc, 0x48, 0x3d, 0xa7, 0x45, 0xde,
-	0xe0, 0xb6, 0x9a, 0x8f, 0x65, 0x90, 0xd1, 0xf7, 0x35, 0x58, 0x30, 0x4c, 0x93, 0x56, 0x48, 0x0d,
-	0xbb, 0x2d, 0xa7, 0x52, 0x25, 0xba, 0xfe, 0x5e, 0x1d, 0x7f, 0xad, 0xd2, 0x58, 0x8d, 0x04, 0x53,
-	0x69, 0x15, 0x2d, 0x79, 0x1b, 0x59, 0x74, 0xc9, 0xa3, 0xf9, 0x4c, 0x86, 0x45, 0x0f, 0x16, 0xc7,
-	0x6b, 0x7e, 0x28, 0xd9, 0xcb, 0x5f, 0x34, 0x98, 0x51, 0x2f, 0x74, 0x1e, 0xf9, 0xa6, 0x48, 0x85,
-	0x83, 0xfc, 0x43, 0x0a, 0x07, 0x7f, 0xd6, 0x60, 0x5a, 0xb9, 0x67, 0x7a, 0x7c, 0x5e, 0xfd, 0x47,
-	0x39, 0x58, 0xc8, 0x56, 0xf3, 0x50, 0x0e, 0xbf, 0x17, 0x80, 0xa4, 0xb1, 0x17, 0xe3, 0xbc, 0x6c,
-	0x3e, 0x75, 0xf6, 0xa5, 0xaf, 0x20, 0x72, 0xe0, 0xd4, 0x05, 0x91, 0x10, 0x47, 0xd7, 0xa1, 0x62,
-	0x49, 0x57, 0x51, 0xf9, 0xac, 0x1b, 0x03, 0xf9, 0x02, 0x8a, 0x55, 0x48, 0xc6, 0x5c, 0x3b, 0xc9,
-	0xaa, 0x9a, 0x05, 0x98, 0x20, 0x89, 0xa3, 0xbe, 0x07, 0x45, 0xee, 0x0e, 0x7a, 0x19, 0xca, 0x34,
-	0xc6, 0xd2, 0xf3, 0x1c, 0xdb, 0x76, 0x34, 0xe5, 0x21, 0x60, 0xa2, 0x19, 0xa4, 0x24, 0x30, 0xf4,
-	0x1a, 0x00, 0x49, 0xfb, 0x79, 0x74, 0xcd, 0xd1, 0x18, 0x45, 0xcf, 0x8d, 0x9e, 0x6b, 0xa6, 0x42,
-	0x6a, 0x39, 0x02, 0xf5, 0x9f, 0xe5, 0xa0, 0x22, 0x5f, 0x7e, 0xdd, 0x93, 0xf1, 0x4f, 0x41, 0x9c,
-	0xe9, 0xdb, 0x86, 0x69, 0x92, 0xbf, 0x58, 0x7c, 0x4e, 0x57, 0xc6, 0x0e, 0x92, 0xf8, 0x7f, 0x55,
-	0x48, 0xb0, 0x40, 0x46, 0xdb, 0x0b, 0xac, 0x04, 0x49, 0xb2, 0x5a, 0x4d, 0xd2, 0x16, 0x77, 0x61,
-	0x3e, 0x53, 0x95, 0x1c, 0xb9, 0x26, 0x1f, 0x54, 0xe4, 0xfa, 0xc5, 0x24, 0xcc, 0x67, 0x5e, 0x3a,
-	0x3e, 0xf2, 0x5d, 0xac, 0xee, 0xa0, 0xfc, 0x03, 0xd9, 0x41, 0x9f, 0x69, 0x59, 0x33, 0xcb, 0x2e,
-	0x70, 0xde, 0x38, 0xc4, 0x4d, 0xec, 0x83, 0x9a, 0x63, 0x75, 0x59, 0x4e, 0xde, 0xd3, 0x9e, 0x28,
-	0x1c, 0x76, 0x4f, 0xa0, 0x17, 0xd9, 0x11, 0x9a, 0xda, 0x2a, 0x52, 0x5b, 0x22, 0x42, 0x24, 0x4c,
-	0x15, 0x39, 0x84, 0xde, 0x81, 0x69, 0x21, 0xc1, 0x0a, 0x37, 0xa5, 0xb8, 0xaa, 0xc2, 0x79, 0x92,
-	0xb5, 0x9b, 0x29, 0x19, 0xff, 0xc7, 0xae, 0xe1, 0xbf, 0x6a, 0x30, 0x9b, 0xe8, 0x42, 0x78, 0x7c,
-	0xbe, 0x41, 0x9f, 0x6b, 0x50, 0x8e, 0x1a, 0x60, 0xee, 0xfb, 0x10, 0xb1, 0x0a, 0x05, 0xcc, 0x9a,
-	0x30, 0x58, 0xb8, 0x3b, 0x96, 0x68, 0x92, 0x23, 0x34, 0xde, 0x16, 0x97, 0xe8, 0xbb, 0x68, 0x71,
-	0x41, 0xfd, 0x37, 0x9a, 0x38, 0x1e, 0xc4, 0x3e, 0x3d, 0xd2, 0xa9, 0x88, 0xdf, 0x29, 0x7f, 0xaf,
-	0xef, 0xf4, 0xcb, 0x32, 0x4c, 0x52, 0x3e, 0x72, 0x7c, 0x0f, 0xb1, 0xdf, 0xb7, 0x1c, 0xc3, 0xa6,
-	0xaf, 0x53, 0x62, 0xfb, 0x56, 0x60, 0xf2, 0xbe, 0x15, 0x18, 0xda, 0x81, 0xd9, 0xb8, 0xe4, 0x48,
-	0xd5, 0x64, 0xf7, 0xde, 0xbd, 0xa7, 0x32, 0xb1, 0x4b, 0x85, 0x84, 0xa4, 0xda, 0x9c, 0x90, 0x20,
-	0x22, 0x13, 0x66, 0xba, 0xae, 0x13, 0x1a, 0x96, 0x83, 0x7d, 0x66, 0x28, 0x9f, 0xd5, 0x7b, 0x74,
-	0x56, 0xe1, 0x61, 0x95, 0x1b, 0x55, 0x4e, 0xed, 0x3d, 0x52, 0x69, 0xe8, 0x63, 0x98, 0x16, 0x47,
-	0x28, 0x66, 0x64, 0x22, 0xab, 0xf7, 0x68, 0x4d, 0x66, 0x61, 0x4b, 0x5a, 0x91, 0x52, 0x7b, 0x8f,
-	0x14, 0x12, 0xb2, 0xa1, 0xea, 0xb9, 0xe6, 0x96, 0xc3, 0x4f, 0x1c, 0x46, 0xc7, 0xc6, 0xbc, 0xce,
-	0xbd, 0x94, 0x4a, 0x79, 0x14, 0x2e, 0x16, 0x8a, 0x93, 0xb2, 0x6a, 0x37, 0x5f, 0x92, 0x8a, 0x3e,
-	0x82, 0x29, 0x9b, 0x1c, 0x48, 0xd6, 0xf6, 0x3d, 0xcb, 0xc7, 0x66, 0x76, 0xef, 0xdd, 0x65, 0x89,
-	0x83, 0x05, 0x42, 0x59, 0x46, 0xed, 0x3f, 0x92, 0x29, 0x64, 0xf6, 0xfb, 0xc6, 0x7e, 0x6b, 0xe0,
-	0x04, 0x6b, 0xfb, 0xbc, 0x8f, 0xaa, 0x98, 0x35, 0xfb, 0xeb, 0x2a, 0x13, 0x9b, 0xfd, 0x84, 0xa4,
-	0x3a, 0xfb, 0x09, 0x22, 0xba, 0x4c, 0xe3, 0x3c, 0x9b, 0x12, 0xd6, 0x83, 0xb7, 0x90, 0x1a, 0x2d,
-	0x36, 0x1b, 0xac, 0xe4, 0xc4, 0x9f, 0x14, 0xa5, 0x91, 0x06, 0x3e, 0x07, 0xf4, 0xb5, 0x5b, 0x38,
-	0x1c, 0xf8, 0x0e, 0x36, 0x79, 0xfb, 0x5d, 0x7a, 0x0e, 0x14, 0xae, 0x68, 0x0e, 0x14, 0x34, 0x35,
-	0x07, 0x0a, 0x95, 0xac, 0x29, 0xcf, 0x35, 0xaf, 0xb1, 0x2d, 0x13, 0x46, 0x4d, 0x79, 0x4f, 0xa6,
-	0x4c, 0xc5, 0x2c, 0x6c, 0x4d, 0x29, 0x52, 0xea, 0x9a, 0x52, 0x48, 0xbc, 0x0f, 0x4c, 0xee, 0x1a,
-	0x62, 0x23, 0x55, 0x19, 0xd3, 0x07, 0x96, 0xe2, 0x8c, 0xfa, 0xc0, 0x52, 0x94, 0x54, 0x1f, 0x58,
-	0x8a, 0x83, 0x58, 0xef, 0x19, 0x4e, 0xef, 0x92, 0xdb, 0x51, 0x57, 0xf5, 0x54, 0x96, 0xf5, 0xf3,
-	0x19, 0x9c, 0xcc, 0x7a, 0x96, 0x0e, 0xd5, 0x7a, 0x16, 0x47, 0xb3, 0x24, 0x4a, 0x53, 0xfa, 0x17,
-	0x1a, 0xcc, 0x26, 0xe2, 0x0c, 0x7a, 0x1b, 0xa2, 0x6e, 0x97, 0x6b, 0x07, 0x9e, 0x48, 0x93, 0x95,
-	0xee, 0x18, 0x82, 0x67, 0x75, 0xc7, 0x10, 0x1c, 0x5d, 0x06, 0x88, 0xbe, 0x49, 0xb7, 0x0b, 0xd2,
-	0x34, 0x47, 0x8b, 0x39, 0xe5, 0x1c, 0x2d, 0x46, 0xf5, 0xaf, 0xf3, 0x50, 0x12, 0x0b, 0xf5, 0xa1,
-	0x1c, 0xa3, 0x56, 0xa0, 0xd8, 0xc7, 0x01, 0xed, 0x92, 0xc9, 0xc5, 0xd9, 0x10, 0x87, 0xe4, 0x6c,
-	0x88, 0x43, 0x6a, 0xb2, 0x96, 0xbf, 0xa7, 0x64, 0x6d, 0xe2, 0xd0, 0xc9, 0x1a, 0xa6, 0x37, 0xe4,
-	0x52, 0xb8, 0x15, 0x77, 0x52, 0xb7, 0x8f, 0xe1, 0xe2, 0xfe, 0x5c, 0x16, 0x4c, 0xdc, 0x9f, 0xcb,
-	0x24, 0xb4, 0x0b, 0x47, 0xa5, 0x7b, 0x33, 0x5e, 0xb7, 0x24, 0x81, 0x6f, 0x66, 0x7c, 0x3b, 0x42,
-	0x8b, 0x72, 0xb1, 0xed, 0xbd, 0x9b, 0x40, 0xe5, 0x6c, 0x37, 0x49, 0xd3, 0xff, 0x90, 0x83, 0x19,
-	0xd5, 0xdf, 0x87, 0x32, 0xb1, 0x2f, 0x43, 0x19, 0xef, 0x5b, 0x61, 0xbb, 0xeb, 0x9a, 0x98, 0x1f,
-	0x19, 0xe9, 0x3c, 0x11, 0xf0, 0xac, 0x6b, 0x2a, 0xf3, 0x24, 0x30, 0x79, 0x35, 0xe4, 0x0f, 0xb5,
-	0x1a, 0xe2, 0x32, 0xef, 0xc4, 0x9d, 0xcb, 0xbc, 0xd9, 0xe3, 0x5c, 0x7e, 0x48, 0xe3, 0x7c, 0x2b,
-	0x07, 0xd5, 0x64, 0x34, 0xfe, 0x6e, 0x6c, 0x21, 0x75, 0x37, 0xe4, 0x0f, 0xbd, 0x1b, 0xde, 0x81,
-	0x69, 0x92, 0x3b, 0x1a, 0x61, 0xc8, 0xfb, 0x47, 0x27, 0x68, 0xce, 0xc5, 0x62, 0xd3, 0xc0, 0x59,
-	0x15, 0xb8, 0x12, 0x9b, 0x24, 0x5c, 0xff, 0xef, 0x1c, 0x4c, 0x2b, 0x5f, 0x8d, 0xc7, 0x2f, 0xa4,
-	0xe8, 0xb3, 0x30, 0xad, 0x24, 0x63, 0xfa, 0xff, 0xb2, 0x75, 0xa2, 0x66, 0x41, 0x8f, 0xdf, 0xb8,
-	0xcc, 0xc0, 0x94, 0x9c, 0xd5, 0xe9, 0x4d, 0x98, 0x4d, 0x24, 0x61, 0xf2, 0x0b, 0x68, 0x87, 0x79,
-	0x01, 0x7d, 0x01, 0xe6, 0xb2, 0x72, 0x07, 0xfd, 0x3c, 0xcc, 0x65, 0x7d, 0xd5, 0xef, 0xde, 0xc0,
-	0x97, 0x1a, 0xb5, 0x90, 0xee, 0x34, 0xbf, 0x00, 0xe0, 0xe0, 0x9b, 0xed, 0x3b, 0x1e, 0xff, 0xd8,
-	0x78, 0xe2, 0x9b, 0x97, 0x12, 0xa7, 0xa5, 0x92, 0xc0, 0x88, 0x26, 0xd7, 0x36, 0xdb, 0x77, 0x3c,
-	0x74, 0x51, 0x4d, 0xae, 0x6d, 0xa6, 0x34, 0x09, 0x4c, 0xff, 0xff, 0xbc, 0x38, 0x99, 0xc7, 0xad,
-	0xda, 0x1f, 0x42, 0xd5, 0x13, 0x0f, 0x77, 0xf6, 0x96, 0x9e, 0x4d, 0x22, 0xfe, 0xa4, 0xa5, 0x19,
-	0x95, 0xa2, 0xea, 0xe6, 0x87, 0xce, 0xdc, 0x21, 0x75, 0xb7, 0x12, 0xa7, 0xcf, 0x19, 0x95, 0x82,
-	0xfe, 0x13, 0x8e, 0x8a, 0x4e, 0xb6, 0x3d, 0x2c, 0x1c, 0xcf, 0x8f, 0x55, 0xce, 0x3a, 0xcb, 0x23,
-	0x81, 0xa4, 0xe7, 0xb3, 0x09, 0x52, 0x42, 0x3d, 0xf7, 0x7d, 0xe2, 0xb0, 0xea, 0x93, 0xce, 0xcf,
-	0x26, 0x48, 0xfa, 0xe7, 0x1a, 0xcc, 0x26, 0x9a, 0xdf, 0xd1, 0x39, 0x28, 0xd1, 0xdf, 0xc6, 0xdd,
-	0x7e, 0x06, 0xe8, 0x82, 0xa4, 0x7c, 0x8a, 0x85, 0x22, 0x87, 0xd0, 0xab, 0x50, 0x8e, 0x7a, 0xe4,
-	0xf9, 0x8d, 0x36, 0xdb, 0x7c, 0x02, 0x54, 0x36, 0x9f, 0x00, 0xf5, 0x1f, 0x6b, 0x70, 0x7c, 0x6c,
-	0x63, 0xfc, 0xa3, 0xae, 0x19, 0x3c, 0xf7, 0x22, 0x94, 0xc4, 0x9d, 0x33, 0x02, 0x28, 0xbc, 0xbf,
-	0xb5, 0xb6, 0xb5, 0x76, 0xae, 0x7a, 0x04, 0x55, 0xa0, 0xb8, 0xb1, 0x76, 0xe5, 0xdc, 0xc5, 0x2b,
-	0xe7, 0xab, 0x1a, 0x79, 0x68, 0x6d, 0x5d, 0xb9, 0x42, 0x1e, 0x72, 0xcf, 0x5d, 0x96, 0x3b, 0xe0,
-	0xd8, 0xf7, 0x18, 0x4d, 0x41, 0x69, 0xd5, 0xf3, 0x68, 0x00, 0x60, 0xb2, 0x6b, 0x7b, 0x16, 0xd9,
-	0xab, 0x55, 0x0d, 0x15, 0x21, 0x7f, 0xf5, 0xea, 0x7a, 0x35, 0x87, 0xe6, 0xa0, 0x7a, 0x0e, 0x1b,
-	0xa6, 0x6d, 0x39, 0x58, 0x44, 0x9d, 0x6a, 0xbe, 0x79, 0xe3, 0xab, 0x6f, 0x96, 0xb4, 0xaf, 0xbf,
-	0x59, 0xd2, 0x7e, 0xff, 0xcd, 0x92, 0x76, 0xeb, 0xdb, 0xa5, 0x23, 0x5f, 0x7f, 0xbb, 0x74, 0xe4,
-	0xb7, 0xdf, 0x2e, 0x1d, 0xf9, 0xf0, 0x45, 0xe9, 0x77, 0xa0, 0xec, 0x9d, 0x3c, 0xdf, 0x25, 0x01,
-	0x97, 0x3f, 0xad, 0x24, 0x7f, 0x19, 0xfb, 0x65, 0xee, 0xe4, 0x2a, 0x7d, 0xdc, 0x60, 0x7c, 0x8d,
-	0x8b, 0x6e, 0x83, 0x01, 0xf4, 0xc7, 0x8b, 0x41, 0xa7, 0x40, 0x7f, 0xa4, 0xf8, 0xf2, 0xdf, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0x70, 0xb2, 0xed, 0xbc, 0x54, 0x3b, 0x00, 0x00,
+	// 4414 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5c, 0x4b, 0x6c, 0xe4, 0x46,
+	0x7a, 0x1e, 0x76, 0x4b, 0xfd, 0xf8, 0xf5, 0x2e, 0x3d, 0xcc, 0xd1, 0x78, 0xd4, 0x5a, 0xda, 0xeb,
+	0x1d, 0x2f, 0xec, 0x96, 0x77, 0xfc, 0x80, 0xd7, 0xbb, 0xd8, 0x8d, 0x7a, 0x24, 0x7b, 0x66, 0x2c,
+	0x69, 0xe4, 0xd6, 0x8c, 0xe3, 0x2c, 0x36, 0xe9, 0x50, 0x64, 0xa9, 0xc5, 0x11, 0x9b, 0xe4, 0x92,
+	0x6c, 0x8d, 0x04, 0xf8, 0x90, 0x04, 0x79, 0x5c, 0x82, 0xc4, 0x8b, 0x04, 0xc8, 0x02, 0x39, 0x6c,
+	0x72, 0x09, 0x92, 0x05, 0x72, 0xca, 0x21, 0xe7, 0xdc, 0xf6, 0x10, 0x04, 0xce, 0x2d, 0x40, 0x80,
+	0x4e, 0x60, 0x27, 0x97, 0x3e, 0x24, 0xb9, 0xe5, 0x71, 0x49, 0x50, 0x0f, 0x92, 0x55, 0x64, 0xb5,
+	0x46, 0x9a, 0x47, 0x66, 0x17, 0x73, 0x9a, 0xe1, 0xf7, 0xbf, 0x8a, 0xac, 0xaa, 0xbf, 0xfe, 0xff,
+	0xaf, 0xbf, 0x05, 0x57, 0x83, 0xa3, 0xee, 0x9a, 0x19, 0xf6, 0x4c, 0xdb, 0xc4, 0xc7, 0xd8, 0x8b,
+	0xa3, 0x35, 0xf6, 0x4f, 0x33, 0x08, 0xfd, 0xd8, 0x47, 0x93, 0x22, 0x69, 0xd9, 0x38, 0x7a, 0x37,
+	0x6a, 0x3a, 0xfe, 0x9a, 0x19, 0x38, 0x6b, 0x96, 0x1f, 0xe2, 0xb5, 0xe3, 0x6f, 0xac, 0x75, 0xb1,
+	0x87, 0x43, 0x33, 0xc6, 0x36, 0x93, 0x58, 0xbe, 0x26, 0xf0, 0x78, 0x38, 0x7e, 0xe0, 0x87, 0x47,
+	0x8e, 0xd7, 0x55, 0x71, 0x36, 0xba, 0xbe, 0xdf, 0x75, 0xf1, 0x1a, 0x7d, 0xda, 0xef, 0x1f, 0xac,
+	0xc5, 0x4e, 0x0f, 0x47, 0xb1, 0xd9, 0x0b, 0x38, 0xc3, 0x4a, 0x9e, 0xe1, 0x41, 0x68, 0x06, 0x01,
+	0x0e, 0xf9, 0xe0, 0x96, 0xdf, 0xca, 0x4c, 0xf5, 0x4c, 0xeb, 0xd0, 0xf1, 0x70, 0x78, 0xba, 0x46,
+	0xdf, 0x27, 0x70, 0xd6, 0x42, 0x1c, 0xf9, 0xfd, 0xd0, 0xc2, 0x05, 0xb3, 0xaf, 0x77, 0x9d, 0xf8,
+	0xb0, 0xbf, 0xdf, 0xb4, 0xfc, 0xde, 0x5a, 0xd7, 0xef, 0xfa, 0x99, 0x7a, 0xf2, 0x44, 0x1f, 0xe8,
+	0xff, 0x38, 0xfb, 0x7b, 0x8e, 0x17, 0xe3, 0xd0, 0x33, 0xdd, 0xb5, 0xc8, 0x3a, 0xc4, 0x76, 0xdf,
+	0xc5, 0x61, 0xf6, 0x3f, 0x7f, 0xff, 0x3e, 0xb6, 0xe2, 0xa8, 0x00, 0x30, 0x59, 0xe3, 0xcf, 0x75,
+	0x98, 0xda, 0x24, 0x9f, 0x6e, 0x0f, 0xff, 0xa0, 0x8f, 0x3d, 0x0b, 0xa3, 0x57, 0x61, 0xfc, 0x07,
+	0x7d, 0xdc, 0xc7, 0xba, 0xb6, 0xaa, 0x5d, 0xab, 0xb7, 0xe6, 0x87, 0x83, 0xc6, 0x0c, 0x05, 0x5e,
+	0xf3, 0x7b, 0x4e, 0x8c, 0x7b, 0x41, 0x7c, 0xda, 0x66, 0x1c, 0xe8, 0x3d, 0x98, 0xbc, 0xef, 0xef,
+	0x77, 0x22, 0x1c, 0x77, 0x3c, 0xb3, 0x87, 0xf5, 0x12, 0x95, 0xd0, 0x87, 0x83, 0xc6, 0xc2, 0x7d,
+	0x7f, 0x7f, 0x0f, 0xc7, 0x3b, 0x66, 0x4f, 0x14, 0x83, 0x0c, 0x45, 0xaf, 0x43, 0xb5, 0x1f, 0xe1,
+	0xb0, 0xe3, 0xd8, 0x7a, 0x99, 0x8a, 0x2d, 0x0c, 0x07, 0x8d, 0x59, 0x02, 0xdd, 0xb2, 0x05, 0x91,
+	0x0a, 0x43, 0xd0, 0x6b, 0x50, 0xe9, 0x86, 0x7e, 0x3f, 0x88, 0xf4, 0xb1, 0xd5, 0x72, 0xc2, 0xcd,
+	0x10, 0x91, 0x9b, 0x21, 0xe8, 0x0e, 0x54, 0xd8, 0x7a, 0xd0, 0xc7, 0x57, 0xcb, 0xd7, 0x26, 0xae,
+	0x7f, 0xa5, 0x29, 0x2e, 0x92, 0xa6, 0xf4, 0xc2, 0xec, 0x89, 0x29, 0x64, 0x74, 0x51, 0x21, 0x43,
+	0x50, 0x1b, 0x20, 0x08, 0xfd, 0x63, 0xec, 0x99, 0x9e, 0x85, 0xf5, 0xca, 0xaa, 0x76, 0x6d, 0xe2,
+	0xba, 0x2e, 0x2b, 0xdd, 0x4d, 0xe9, 0xec, 0x0b, 0x64, 0xfc, 0xe2, 0x17, 0xc8, 0x50, 0xf4, 0x6d,
+	0x98, 0x74, 0xb1, 0x69, 0xe3, 0xb0, 0x83, 0x03, 0xdf, 0x3a, 0xd4, 0xab, 0xab, 0xda, 0xb5, 0xb1,
+	0xd6, 0xe5, 0xe1, 0xa0, 0xb1, 0xc8, 0xf0, 0x4d, 0x02, 0x0b, 0xc2, 0x13, 0x02, 0xbc, 0xfc, 0xef,
+	0x0b, 0x30, 0x4e, 0x47, 0x8e, 0xee, 0x40, 0xd5, 0x0a, 0x31, 0x59, 0x3e, 0x3a, 0xa2, 0x03, 0x5b,
+	0x6e, 0xb2, 0x55, 0xd9, 0x4c, 0x96, 0x4d, 0xf3, 0x6e, 0xb2, 0x6c, 0xa9, 0xfa, 0x39, 0xce, 0x9e,
+	0xa9, 0xfe, 0xec, 0x9f, 0x1a, 0x5a, 0x3b, 0xd1, 0x82, 0x76, 0xa1, 0x1e, 0xf5, 0xf7, 0x7b, 0x4e,
+	0x7c, 0xdb, 0xdf, 0xa7, 0xab, 0x60, 0xe2, 0xfa, 0x0b, 0xf2, 0xbb, 0xee, 0x25, 0xe4, 0xd6, 0x0b,
+	0xc3, 0x41, 0x63, 0x3e, 0xe5, 0xce, 0x34, 0xde, 0xbc, 0xd4, 0xce, 0x94, 0xa0, 0x43, 0x98, 0x09,
+	0x71, 0x10, 0x3a, 0x7e, 0xe8, 0xc4, 0x4e, 0x84, 0x89, 0xde, 0x12, 0xd5, 0x7b, 0x55, 0xd6, 0xdb,
+	0x96, 0x99, 0x5a, 0x57, 0x87, 0x83, 0xc6, 0xe5, 0x9c, 0xa4, 0x64, 0x23, 0xaf, 0x16, 0xc5, 0x80,
+	0x72, 0xd0, 0x1e, 0x8e, 0xe9, 0x0a, 0x9b, 0xb8, 0xbe, 0x7a, 0xa6, 0xb1, 0x3d, 0x1c, 0xb7, 0x56,
+	0x87, 0x83, 0xc6, 0x8b, 0x45, 0x79, 0xc9, 0xa4, 0x42, 0x3f, 0x72, 0x61, 0x56, 0x44, 0x6d, 0xf2,
+	0x82, 0x63, 0xd4, 0xe6, 0xca, 0x68, 0x9b, 0x84, 0xab, 0xb5, 0x32, 0x1c, 0x34, 0x96, 0xf3, 0xb2,
+	0x92, 0xbd, 0x82, 0x66, 0x32, 0x3f, 0x16, 0x59, 0x41, 0x2e, 0x31, 0x33, 0xae, 0x9a, 0x9f, 0x1b,
+	0x09, 0x99, 0xcd, 0x4f, 0xca, 0x2d, 0xcf, 0x4f, 0x0a, 0xa3, 0xef, 0xc3, 0x64, 0xfa, 0x40, 0xbe,
+	0x57, 0x85, 0xaf, 0x23, 0xb5, 0x52, 0xf2, 0xa5, 0x96, 0x87, 0x83, 0xc6, 0x92, 0x28, 0x23, 0xa9,
+	0x96, 0xb4, 0x65, 0xda, 0x5d, 0xf6, 0x65, 0xaa, 0xa3, 0xb5, 0x33, 0x0e, 0x51, 0xbb, 0x5b, 0xfc,
+	0x22, 0x92, 0x36, 0xa2, 0x9d, 0xb8, 0x95, 0xbe, 0x65, 0x61, 0x6c, 0x63, 0x5b, 0xaf, 0xa9, 0xb4,
+	0xdf, 0x16, 0x38, 0x98, 0x76, 0x51, 0x46, 0xd6, 0x2e, 0x52, 0xc8, 0xb7, 0xbe, 0xef, 0xef, 0x6f,
+	0x86, 0xa1, 0x1f, 0x46, 0x7a, 0x5d, 0xf5, 0xad, 0x6f, 0x27, 0x64, 0xf6, 0xad, 0x53, 0x6e, 0xf9,
+	0x5b, 0xa7, 0x30, 0x1f, 0x6f, 0xbb, 0xef, 0x6d, 0x61, 0x33, 0xc2, 0xb6, 0x0e, 0x23, 0xc6, 0x9b,
+	0x72, 0xa4, 0xe3, 0x4d, 0x91, 0xc2, 0x78, 0x53, 0x0a, 0xb2, 0x61, 0x9a, 0x3d, 0xaf, 0x47, 0x91,
+	0xd3, 0xf5, 0xb0, 0xad, 0x4f, 0x50, 0xfd, 0x2f, 0xaa, 0xf4, 0x27, 0x3c, 0xad, 0x17, 0x87, 0x83,
+	0x86, 0x2e, 0xcb, 0x49, 0x36, 0x72, 0x3a, 0xd1, 0xaf, 0xc2, 0x14, 0x43, 0xda, 0x7d, 0xcf, 0x73,
+	0xbc, 0xae, 0x3e, 0x49, 0x8d, 0x5c, 0x51, 0x19, 0xe1, 0x2c, 0xad, 0x2b, 0xc3, 0x41, 0xe3, 0x05,
+	0x49, 0x4a, 0x32, 0x21, 0x2b, 0x24, 0x1e, 0x83, 0x01, 0xd9, 0xc4, 0x4e, 0xa9, 0x3c, 0xc6, 0x6d,
+	0x99, 0x89, 0x79, 0x8c, 0x9c, 0xa4, 0xec, 0x31, 0x72, 0xc4, 0x6c, 0x3e, 0xf8, 0x24, 0x4f, 0x8f,
+	0x9e, 0x0f, 0x3e, 0xcf, 0xc2, 0x7c, 0x28, 0xa6, 0x5a, 0xd2, 0x86, 0x3e, 0x05, 0x72, 0x14, 0x6e,
+	0xf4, 0x03, 0xd7, 0xb1, 0xcc, 0x18, 0x6f, 0xe0, 0x18, 0x5b, 0xc4, 0x53, 0xcf, 0x50, 0x2b, 0x46,
+	0xc1, 0x4a, 0x81, 0xb3, 0x65, 0x0c, 0x07, 0x8d, 0x15, 0x95, 0x0e, 0xc9, 0xaa, 0xd2, 0x0a, 0xfa,
+	0x35, 0x0d, 0x16, 0xa3, 0xd8, 0xf4, 0x6c, 0xd3, 0xf5, 0x3d, 0x7c, 0xcb, 0xeb, 0x86, 0x38, 0x8a,
+	0x6e, 0x79, 0x07, 0xbe, 0x3e, 0x4b, 0xed, 0xbf, 0x94, 0x73, 0xeb, 0x2a, 0xd6, 0xd6, 0x4b, 0xc3,
+	0x41, 0xa3, 0xa1, 0xd4, 0x22, 0x8d, 0x40, 0x6d, 0x08, 0x9d, 0xc0, 0x7c, 0x12, 0xe7, 0xdc, 0x8b,
+	0x1d, 0xd7, 0x89, 0xcc, 0xd8, 0xf1, 0x3d, 0x7d, 0x6e, 0x55, 0x2b, 0x9e, 0xcb, 0xed, 0x22, 0x63,
+	0xeb, 0x2b, 0xc3, 0x41, 0xe3, 0xaa, 0x42, 0x83, 0x64, 0x5b, 0x65, 0x22, 0x5b, 0x42, 0xbb, 0x21,
+	0x26, 0x8c, 0xd8, 0xd6, 0xe7, 0x47, 0x2f, 0xa1, 0x94, 0x49, 0x5c, 0x42, 0x29, 0xa8, 0x5a, 0x42,
+	0x29, 0x91, 0x58, 0x0a, 0xcc, 0x30, 0x76, 0x88, 0xd9, 0x6d, 0x33, 0x3c, 0xc2, 0xa1, 0xbe, 0xa0,
+	0xb2, 0xb4, 0x2b, 0x33, 0x31, 0x4b, 0x39, 0x49, 0xd9, 0x52, 0x8e, 0x88, 0x3e, 0xd3, 0x40, 0x1e,
+	0x9a, 0xe3, 0x7b, 0x6d, 0x12, 0xc8, 0x44, 0xe4, 0xf5, 0x16, 0xa9, 0xd1, 0xaf, 0x9d, 0xf1, 0x7a,
+	0x22, 0x7b, 0xeb, 0x6b, 0xc3, 0x41, 0xe3, 0xa5, 0x91, 0xda, 0xa4, 0x81, 0x8c, 0x36, 0x8a, 0x3e,
+	0x81, 0x09, 0x42, 0xc4, 0x34, 0x24, 0xb4, 0xf5, 0x25, 0x3a, 0x86, 0xcb, 0xc5, 0x31, 0x70, 0x06,
+	0x16, 0xe0, 0x08, 0x12, 0x92, 0x1d, 0x51, 0x55, 0x36, 0x81, 0xe9, 0xd9, 0xa0, 0xbf, 0x30, 0x7a,
+	0x02, 0x53, 0x26, 0x71, 0x02, 0x53, 0x50, 0x35, 0x81, 0x29, 0x91, 0xfb, 0x80, 0x8f, 0x4d, 0xd7,
+	0xb1, 0x69, 0x1c, 0x75, 0x79, 0x84, 0x0f, 0x48, 0x39, 0x52, 0x1f, 0x90, 0x22, 0x05, 0x1f, 0x90,
+	0x52, 0xc8, 0x16, 0xa0, 0xaf, 0x15, 0x87, 0x0e, 0x8e, 0x36, 0x4f, 0x0e, 0xcd, 0x3e, 0x9d, 0xad,
+	0x65, 0xd5, 0x16, 0xb8, 0x5d, 0x64, 0x64, 0x5b, 0x40, 0xa1, 0x41, 0xde, 0x02, 0x0a, 0x06, 0xd4,
+	0x82, 0x69, 0xcb, 0x0f, 0x43, 0xec, 0xd2, 0x1d, 0x41, 0x62, 0x6d, 0x9d, 0xc6, 0xda, 0xd4, 0x17,
+	0x0b, 0x14, 0x29, 0xe4, 0x9e, 0x92, 0x08, 0xad, 0x2a, 0x8c, 0xd3, 0xb1, 0x19, 0x7f, 0x55, 0x02,
+	0xc8, 0x82, 0x5c, 0xf4, 0x5d, 0x98, 0xda, 0xef, 0x3b, 0xae, 0xdd, 0x39, 0xc6, 0x61, 0x44, 0xb6,
+	0x34, 0xcb, 0x17, 0xe8, 0x87, 0xa1, 0x84, 0x8f, 0x19, 0x2e, 0x68, 0x9e, 0x14, 0x71, 0x12, 0xff,
+	0x32, 0x05, 0x96, 0xdf, 0xeb, 0x39, 0x31, 0xcf, 0x1e, 0xe8, 0xf2, 0xa0, 0xf8, 0x0d, 0x0a, 0x8b,
+	0xf1, 0xaf, 0x00, 0xa3, 0x6f, 0xc2, 0x84, 0xe5, 0x7b, 0x07, 0x4e, 0xb7, 0x73, 0x68, 0x46, 0x87,
+	0x3c, 0x87, 0xa0, 0x81, 0x37, 0x83, 0x6f, 0x9a, 0x91, 0x18, 0x3b, 0x43, 0x86, 0x12, 0x51, 0x1e,
+	0x78, 0xd3, 0xac, 0x65, 0x2c, 0x13, 0x65, 0x70, 0x3e, 0x6b, 0xc9, 0x50, 0xf4, 0x06, 0xd4, 0xac,
+	0x53, 0xcb, 0xc5, 0xe4, 0x53, 0x8e, 0x53, 0xb9, 0x45, 0x1a, 0x50, 0x13, 0x4c, 0xfa, 0x88, 0x55,
+	0x0e, 0x19, 0xc3, 0x0a, 0xcc, 0x2b, 0xfc, 0x1a, 0xfa, 0x0e, 0x54, 0xc2, 0x3e, 0x9d, 0x12, 0x16,
+	0x61, 0x23, 0x79, 0x1d, 0xdc, 0xeb, 0x3b, 0x36, 0xcb, 0xbd, 0xc2, 0xbe, 0x3c, 0x3d, 0xe3, 0x14,
+	0x20, 0xf2, 0x24, 0xf7, 0x72, 0x6c, 0xbd, 0x74, 0xb6, 0xfc, 0x7d, 0x7f, 0x5f, 0x96, 0xa7, 0x00,
+	0xc2, 0x30, 0x95, 0x38, 0xcd, 0x8e, 0x43, 0x4e, 0x04, 0x16, 0x23, 0xbf, 0x2c, 0xab, 0xf9, 0xb0,
+	0xbf, 0x8f, 0x43, 0x0f, 0xc7, 0x38, 0x4a, 0xde, 0x81, 0x1e, 0x09, 0x74, 0x92, 0x43, 0x01, 0x11,
+	0x27, 0x59, 0xc4, 0xd1, 0x1f, 0x6a, 0xa0, 0xf7, 0xcc, 0x93, 0x4e, 0x02, 0x46, 0x9d, 0x03, 0x3f,
+	0xec, 0x04, 0x38, 0x74, 0x7c, 0x9b, 0xa6, 0x72, 0x13, 0xd7, 0xbf, 0xfd, 0xd0, 0x43, 0xa0, 0xb9,
+	0x6d, 0x9e, 0x24, 0x70, 0xf4, 0xbe, 0x1f, 0xee, 0x52, 0xf1, 0x4d, 0x2f, 0x0e, 0x4f, 0x5b, 0x57,
+	0x7f, 0x3a, 0x68, 0x5c, 0x22, 0x6b, 0xa6, 0xa7, 0xe2, 0x69, 0xab, 0x61, 0xf4, 0xfb, 0x1a, 0x2c,
+	0xc5, 0x7e, 0x6c, 0xba, 0x1d, 0xab, 0xdf, 0xeb, 0x93, 0xb5, 0x7e, 0x8c, 0x3b, 0xfd, 0xc8, 0xec,
+	0x62, 0x9e, 0x31, 0x7e, 0xeb, 0xe1, 0x83, 0xba, 0x4b, 0xe4, 0x6f, 0xa4, 0xe2, 0xf7, 0x88, 0x34,
+	0x1b, 0xd3, 0x8b, 0x7c, 0x4c, 0x0b, 0xb1, 0x82, 0xa5, 0xad, 0x44, 0x97, 0xff, 0x44, 0x83, 0xe5,
+	0xd1, 0xaf, 0x89, 0x5e, 0x82, 0xf2, 0x11, 0x3e, 0xe5, 0x7b, 0x6c, 0x6e, 0x38, 0x68, 0x4c, 0x1d,
+	0xe1, 0x53, 0xe1, 0xab, 0x13, 0x2a, 0xfa, 0x25, 0x18, 0x3f, 0x36, 0xdd, 0x3e, 0xe6, 0x4b, 0xa2,
+	0xd9, 0x64, 0xd5, 0x87, 0xa6, 0x58, 0x7d, 0x68, 0x06, 0x47, 0x5d, 0x02, 0x34, 0x93, 0x19, 0x69,
+	0x7e, 0xd4, 0x37, 0xbd, 0xd8, 0x89, 0x4f, 0xd9, 0x72, 0xa1, 0x0a, 0xc4, 0xe5, 0x42, 0x81, 0xf7,
+	0x4a, 0xef, 0x6a, 0xcb, 0x3f, 0xd6, 0xe0, 0xf2, 0xc8, 0x97, 0xfe, 0x59, 0x18, 0xa1, 0xd1, 0x81,
+	0x31, 0xb2, 0xf0, 0x49, 0xb5, 0xe0, 0xd0, 0xe9, 0x1e, 0xbe, 0xf3, 0x16, 0x1d, 0x4e, 0x85, 0x25,
+	0xf7, 0x0c, 0x11, 0x93, 0x7b, 0x86, 0x90, 0x8a, 0x87, 0xeb, 0x3f, 0x78, 0xe7, 0x2d, 0x3a, 0xa8,
+	0x0a, 0x33, 0x42, 0x01, 0xd1, 0x08, 0x05, 0x8c, 0xff, 0xad, 0x40, 0x3d, 0x4d, 0x7e, 0x85, 0x3d,
+	0xa8, 0x3d, 0xd2, 0x1e, 0xbc, 0x09, 0xb3, 0x36, 0xb6, 0x79, 0xd4, 0xc6, 0x1d, 0x34, 0xf3, 0x82,
+	0xf4, 0x08, 0x93, 0x68, 0x92, 0xfc, 0x4c, 0x8e, 0x84, 0xae, 0x43, 0x8d, 0x27, 0x89, 0xa7, 0x74,
+	0x23, 0x4f, 0xb5, 0x96, 0x86, 0x83, 0x06, 0x4a, 0x30, 0x41, 0x34, 0xe5, 0x23, 0x35, 0x0d, 0x56,
+	0x0b, 0xda, 0xc6, 0xb1, 0xa9, 0x8f, 0xa9, 0x6a, 0x1a, 0x77, 0x52, 0x3a, 0xf3, 0x8f, 0x19, 0xbf,
+	0xe8, 0x1f, 0x33, 0x14, 0x7d, 0x1f, 0xa0, 0x67, 0x3a, 0x1e, 0x93, 0xd3, 0xc7, 0x55, 0x41, 0x6e,
+	0xe6, 0x52, 0xb6, 0x53, 0x4e, 0xa6, 0x3d, 0x93, 0x14, 0xb5, 0x67, 0x28, 0xa9, 0x74, 0x30, 0x5b,
+	0x91, 0x5e, 0x59, 0x2d, 0x17, 0xb3, 0xeb, 0x4c, 0x35, 0x57, 0x4b, 0x9d, 0x33, 0x17, 0x11, 0x9d,
+	0x33, 0x87, 0xc8, 0x67, 0x73, 0x9d, 0x03, 0x1c, 0x3b, 0x3d, 0xac, 0x57, 0xb3, 0xcf, 0x96, 0x60,
+	0xe2, 0x67, 0x4b, 0x30, 0xf4, 0x2e, 0x80, 0x19, 0x6f, 0xfb, 0x51, 0x7c, 0x87, 0x94, 0x82, 0x48,
+	0xb6, 0x59, 0x63, 0xc3, 0xcf, 0x50, 0x71, 0xf8, 0x19, 0x8a, 0xbe, 0x05, 0x13, 0x01, 0x0f, 0xa0,
+	0xf6, 0x5d, 0x4c, 0xb3, 0xc9, 0x1a, 0x3b, 0xef, 0x04, 0x58, 0x3c, 0xef, 0x04, 0x18, 0x7d, 0x00,
+	0x33, 0x96, 0xef, 0x59, 0xfd, 0x30, 0xc4, 0x9e, 0x75, 0xba, 0x67, 0x1e, 0x60, 0x9a, 0x39, 0xd6,
+	0xd8, 0x52, 0xc9, 0x91, 0xc4, 0xa5, 0x92, 0x23, 0xa1, 0xb7, 0xa1, 0x9e, 0xd6, 0x02, 0x69, 0x72,
+	0x58, 0xe7, 0x45, 0x9c, 0x04, 0x14, 0x84, 0x33, 0x4e, 0x32, 0x78, 0x27, 0x4a, 0x33, 0x0c, 0x7d,
+	0x32, 0x1b, 0xbc, 0x00, 0x8b, 0x83, 0x17, 0x60, 0x74, 0x0b, 0xe6, 0x68, 0x4c, 0xd7, 0x89, 0x63,
+	0xb7, 0x13, 0x61, 0xcb, 0xf7, 0xec, 0x88, 0xe6, 0x73, 0x65, 0x36, 0x7c, 0x4a, 0xbc, 0x1b, 0xbb,
+	0x7b, 0x8c, 0x24, 0x0e, 0x3f, 0x47, 0x32, 0xfe, 0x56, 0x83, 0x05, 0xd5, 0x12, 0xca, 0x2d, 0x67,
+	0xed, 0x89, 0x2c, 0xe7, 0x8f, 0xa1, 0x16, 0xf8, 0x76, 0x27, 0x0a, 0xb0, 0xa5, 0x97, 0x54, 0x8b,
+	0x79, 0xd7, 0xb7, 0xf7, 0x02, 0x6c, 0xfd, 0xa2, 0x13, 0x1f, 0xae, 0x1f, 0xfb, 0x8e, 0xbd, 0xe5,
+	0x44, 0x7c, 0xd5, 0x05, 0x8c, 0x22, 0xc5, 0x68, 0x55, 0x0e, 0xb6, 0x6a, 0x50, 0x61, 0x56, 0x8c,
+	0xbf, 0x2b, 0xc3, 0x6c, 0x7e, 0xd9, 0xfe, 0x3c, 0xbd, 0x0a, 0xfa, 0x04, 0xaa, 0x0e, 0x4b, 0xf7,
+	0x78, 0x04, 0xf1, 0x55, 0xc1, 0xa7, 0x37, 0xb3, 0xf2, 0x7a, 0xf3, 0xf8, 0x1b, 0x4d, 0x9e, 0x17,
+	0xd2, 0x4f, 0x40, 0x35, 0x73, 0x49, 0x59, 0x33, 0x07, 0x51, 0x1b, 0xaa, 0x11, 0x0e, 0x8f, 0x1d,
+	0x0b, 0x73, 0xe7, 0xd4, 0x10, 0x35, 0x5b, 0x7e, 0x88, 0x89, 0xce, 0x3d, 0xc6, 0x92, 0xe9, 0xe4,
+	0x32, 0xb2, 0x4e, 0x0e, 0xa2, 0x8f, 0xa1, 0xce, 0x02, 0xc1, 0x6d, 0x33, 0xe0, 0xee, 0xe9, 0xaa,
+	0x4a, 0xeb, 0x8d, 0x84, 0x89, 0x17, 0xd0, 0x92, 0xc7, 0x5c, 0x01, 0x2d, 0xe5, 0xca, 0x26, 0xf4,
+	0xdf, 0xc6, 0x00, 0xb2, 0xc9, 0x21, 0xb1, 0x26, 0x3e, 0xc1, 0x56, 0x3f, 0xf6, 0xc3, 0xe4, 0x9c,
+	0xe0, 0xb1, 0x66, 0x02, 0x4b, 0x8e, 0x1d, 0x32, 0x94, 0x6c, 0x54, 0x12, 0x9f, 0x46, 0x81, 0x69,
+	0x25, 0xa5, 0x75, 0x3a, 0x98, 0x14, 0x14, 0x37, 0x6a, 0x0a, 0xa2, 0x57, 0x60, 0x8c, 0x3c, 0xf0,
+	0x88, 0x18, 0x0d, 0x07, 0x8d, 0x69, 0x4f, 0x0e, 0x68, 0x29, 0x9d, 0xc4, 0xef, 0x47, 0xe9, 0xc2,
+	0x23, 0x63, 0x1b, 0xcb, 0xe2, 0xf7, 0x8c, 0x20, 0x8d, 0x6e, 0x52, 0xc4, 0xd1, 0x01, 0x4c, 0x98,
+	0x9e, 0xe7, 0xc7, 0xf4, 0x0c, 0x4a, 0x2a, 0xed, 0xaf, 0x8e, 0x5a, 0xa6, 0xcd, 0xf5, 0x8c, 0x97,
+	0x45, 0x49, 0xd4, 0x79, 0x08, 0x1a, 0x44, 0xe7, 0x21, 0xc0, 0xa8, 0x0d, 0x15, 0xd7, 0xdc, 0xc7,
+	0x6e, 0xe2, 0xf4, 0x5f, 0x1e, 0x69, 0x62, 0x8b, 0xb2, 0x31, 0xed, 0xf4, 0xc8, 0x67, 0x72, 0xe2,
+	0x91, 0xcf, 0x90, 0xe5, 0x03, 0x98, 0xcd, 0x8f, 0xe7, 0x7c, 0x01, 0xcc, 0xab, 0x62, 0x00, 0x53,
+	0x7f, 0x68, 0xc8, 0x64, 0xc2, 0x84, 0x30, 0xa8, 0xa7, 0x61, 0xc2, 0xf8, 0x0b, 0x0d, 0x16, 0x54,
+	0x7b, 0x17, 0x6d, 0x0b, 0x3b, 0x5e, 0xe3, 0xf5, 0x39, 0xc5, 0x52, 0xe7, 0xb2, 0x23, 0xb6, 0x7a,
+	0xb6, 0xd1, 0x5b, 0x30, 0xed, 0xf9, 0x36, 0xee, 0x98, 0xc4, 0x80, 0xeb, 0x44, 0x24, 0x61, 0x2b,
+	0x27, 0xb9, 0x24, 0xa1, 0xac, 0x27, 0x04, 0x31, 0x97, 0x94, 0x08, 0xc6, 0x6f, 0x69, 0x30, 0x93,
+	0x2b, 0xbb, 0x3f, 0x76, 0x10, 0x25, 0x86, 0x3e, 0xa5, 0xf3, 0x85, 0x3e, 0xc6, 0x1f, 0x94, 0x60,
+	0x42, 0xa8, 0x49, 0x3c, 0xf6, 0x18, 0xee, 0xc3, 0x0c, 0x3f, 0x29, 0x1d, 0xaf, 0xcb, 0xd2, 0xa9,
+	0x12, 0x2f, 0xb0, 0x15, 0xee, 0xdd, 0x48, 0x29, 0x3a, 0xe5, 0xa5, 0xd9, 0x14, 0xad, 0xbe, 0x46,
+	0x12, 0x26, 0x98, 0x98, 0x96, 0x29, 0xe8, 0x13, 0x58, 0xea, 0x07, 0xb6, 0x19, 0xe3, 0x4e, 0xc4,
+	0x6f, 0xb0, 0x3a, 0x5e, 0xbf, 0xb7, 0x8f, 0x43, 0xba, 0xe3, 0xc7, 0x59, 0xbd, 0x90, 0x71, 0x24,
+	0x57, 0x5c, 0x3b, 0x94, 0x2e, 0xe8, 0x5c, 0x50, 0xd1, 0x8d, 0x7f, 0x2c, 0xc3, 0xa4, 0x58, 0xe4,
+	0x78, 0xec, 0xcf, 0xb2, 0x03, 0x08, 0x1f, 0x1c, 0x60, 0x8b, 0x66, 0x57, 0xb9, 0x49, 0x6a, 0x0c,
+	0x07, 0x8d, 0x2b, 0x29, 0x75, 0xb7, 0x38, 0x5b, 0x73, 0x05, 0x22, 0xba, 0x03, 0xf3, 0x89, 0x96,
+	0x8e, 0xe5, 0x9a, 0x51, 0xd4, 0x11, 0x3c, 0x1d, 0x55, 0x98, 0x90, 0x6f, 0x10, 0x6a, 0x2e, 0x8f,
+	0x9f, 0x2b, 0x10, 0x91, 0x07, 0x2b, 0x36, 0x3e, 0x30, 0xfb, 0x6e, 0xdc, 0xc9, 0x29, 0x36, 0x83,
+	0xc0, 0x75, 0x30, 0x73, 0x8a, 0xb5, 0xd6, 0xab, 0xc3, 0x41, 0xe3, 0xab, 0x9c, 0x73, 0x57, 0xd4,
+	0xb2, 0xce, 0xd8, 0x04, 0x2b, 0x57, 0xce, 0x60, 0x23, 0x01, 0xbf, 0x68, 0xa7, 0x17, 0x60, 0x56,
+	0x46, 0xe0, 0x51, 0x9c, 0x30, 0xc0, 0x5e, 0x20, 0x69, 0x9d, 0xc9, 0x91, 0xa4, 0x42, 0x44, 0xe5,
+	0x5c, 0x85, 0x88, 0x1f, 0x95, 0x60, 0x5e, 0x51, 0x5d, 0x7a, 0x12, 0xfb, 0xcf, 0x8c, 0x29, 0x14,
+	0x89, 0xfb, 0x2f, 0xc1, 0xc4, 0xfd, 0x97, 0x60, 0xe8, 0x43, 0x98, 0x70, 0xcd, 0x28, 0xee, 0xf0,
+	0x0a, 0x48, 0x79, 0xa4, 0x61, 0x7a, 0xe0, 0x11, 0xd6, 0x76, 0xae, 0x0a, 0x52, 0x4f, 0x41, 0xf4,
+	0x0b, 0x30, 0x9d, 0x2a, 0xc3, 0xa4, 0xea, 0x2e, 0x9e, 0x64, 0x9c, 0x8d, 0x56, 0xe3, 0xc5, 0x93,
+	0x4c, 0xc4, 0x8d, 0x9b, 0x80, 0x8a, 0x97, 0x81, 0x92, 0x63, 0xd1, 0xce, 0xe9, 0x58, 0xfe, 0xa8,
+	0x04, 0xb3, 0xf9, 0x3b, 0xbe, 0x67, 0xe1, 0xe1, 0xc8, 0xd6, 0x0b, 0x93, 0x12, 0x6d, 0x27, 0x97,
+	0x1a, 0xd2, 0x9d, 0x92, 0x52, 0x55, 0x5b, 0xaf, 0x40, 0x24, 0xd1, 0x02, 0x5d, 0xb0, 0x9d, 0x1e,
+	0x8e, 0x68, 0x99, 0x44, 0xf8, 0xc6, 0x94, 0xb0, 0xcd, 0x70, 0xf1, 0x1b, 0x8b, 0xb8, 0x71, 0x0a,
+	0xf5, 0xf4, 0x02, 0xf1, 0xb1, 0xbf, 0xc8, 0x6b, 0x50, 0x09, 0xb1, 0x19, 0xf9, 0x1e, 0x3f, 0x23,
+	0xe9, 0x61, 0xcf, 0x10, 0xf1, 0xb0, 0x67, 0x88, 0x71, 0x97, 0xba, 0xb5, 0x3d, 0x1c, 0xbf, 0xef,
+	0xb8, 0x31, 0x0e, 0xd1, 0x06, 0x54, 0xa2, 0xd8, 0x8c, 0x71, 0xa4, 0x6b, 0xab, 0xe5, 0x6b, 0xd3,
+	0xd7, 0x97, 0x8a, 0x77, 0x85, 0x84, 0xcc, 0xb4, 0x32, 0x4e, 0x51, 0x2b, 0x43, 0x8c, 0xdf, 0xd0,
+	0x60, 0x52, 0xbc, 0x12, 0x7d, 0x32, 0x6a, 0x2f, 0xf8, 0x6a, 0x9f, 0x26, 0x63, 0x70, 0x9f, 0xcc,
+	0x52, 0xbb, 0x98, 0xf5, 0x1f, 0x6a, 0x30, 0x93, 0x2b, 0xbe, 0x3f, 0xeb, 0xba, 0xa6, 0xf1, 0xd7,
+	0x1a, 0x9b, 0xed, 0xf4, 0x7e, 0xef, 0x71, 0x3f, 0x49, 0x37, 0x2b, 0x94, 0x92, 0xf3, 0x37, 0xd2,
+	0x4b, 0xaa, 0x28, 0x74, 0x44, 0xa1, 0x94, 0x06, 0x47, 0x92, 0xb8, 0x18, 0x1c, 0x49, 0x04, 0xe3,
+	0xb3, 0x0a, 0x1d, 0x79, 0x76, 0x97, 0xfb, 0xac, 0x4b, 0xc4, 0xb9, 0xdc, 0xa5, 0x7c, 0x81, 0xdc,
+	0xe5, 0x75, 0xa8, 0xd2, 0x60, 0x31, 0x4d, 0x2b, 0xe8, 0x42, 0x22, 0x90, 0x24, 0x52, 0x61, 0xc8,
+	0x19, 0x31, 0xcd, 0xf8, 0xe3, 0xc5, 0x34, 0xa8, 0x03, 0x97, 0x0f, 0xcd, 0xa8, 0x93, 0x44, 0x61,
+	0x76, 0xc7, 0xcc, 0x8e, 0x7a, 0x7a, 0x70, 0xd6, 0x5a, 0x2f, 0x0f, 0x07, 0x8d, 0xd5, 0x43, 0x33,
+	0xda, 0x4b, 0x78, 0xd6, 0x63, 0x85, 0x4f, 0x5c, 0x52, 0x73, 0xa0, 0x7b, 0xb0, 0xa8, 0x56, 0x5e,
+	0xa5, 0x23, 0xa7, 0x77, 0x37, 0xd1, 0x99, 0x9a, 0xe7, 0x15, 0x64, 0xf4, 0x43, 0x0d, 0x96, 0x4c,
+	0xdb, 0xa6, 0x77, 0x7f, 0xa6, 0xdb, 0x11, 0x13, 0xad, 0x1a, 0x5d, 0x7f, 0x6f, 0x8f, 0x6e, 0x18,
+	0x68, 0xae, 0xa7, 0x82, 0x85, 0xa4, 0x8b, 0x5e, 0xe6, 0x9a, 0x2a, 0xba, 0x30, 0xa2, 0x45, 0x25,
+	0xc3, 0x72, 0x00, 0xcb, 0xa3, 0x35, 0x3f, 0x95, 0xdc, 0xe6, 0xbf, 0x35, 0x98, 0x96, 0x5b, 0x15,
+	0x9e, 0xf9, 0xa6, 0x28, 0xb8, 0x83, 0xf2, 0x53, 0x72, 0x07, 0xff, 0xa5, 0xc1, 0x94, 0xd4, 0x41,
+	0xf1, 0xfc, 0xbc, 0xfa, 0x8f, 0x4a, 0xb0, 0xa4, 0x56, 0xf3, 0x54, 0x4a, 0x63, 0x37, 0x81, 0x24,
+	0xb9, 0xb7, 0xb2, 0xac, 0x6d, 0xb1, 0x50, 0x19, 0xa3, 0xaf, 0x90, 0x64, 0xc8, 0x85, 0xd6, 0x87,
+	0x44, 0x9c, 0xdc, 0x85, 0x3b, 0x42, 0x93, 0x45, 0x59, 0x75, 0x17, 0x2e, 0xb6, 0x56, 0xb0, 0xfa,
+	0xe9, 0x88, 0x86, 0x0a, 0x51, 0x55, 0xab, 0x02, 0x63, 0x24, 0xad, 0x34, 0x8e, 0xa1, 0xca, 0x87,
+	0x83, 0xde, 0x84, 0x3a, 0xf5, 0xb1, 0x34, 0x07, 0x62, 0xdb, 0x8e, 0xc6, 0x85, 0x04, 0xcc, 0xa5,
+	0x3e, 0xb5, 0x04, 0x43, 0xef, 0x00, 0x90, 0xa2, 0x00, 0xf7, 0xae, 0x25, 0xea, 0xa3, 0x68, 0x90,
+	0x1d, 0xf8, 0x76, 0xc1, 0xa5, 0xd6, 0x53, 0xd0, 0xf8, 0xcb, 0x12, 0x4c, 0x08, 0x23, 0x7f, 0x34,
+	0xe3, 0x9f, 0x42, 0x52, 0xf1, 0xeb, 0x98, 0xb6, 0x4d, 0xfe, 0xc5, 0xc9, 0x71, 0xba, 0x36, 0xf2,
+	0x23, 0x25, 0xff, 0x5f, 0x4f, 0x24, 0x98, 0x23, 0xa3, 0x8d, 0x73, 0x4e, 0x8e, 0x24, 0x58, 0x9d,
+	0xcd, 0xd3, 0x96, 0x8f, 0x60, 0x51, 0xa9, 0x4a, 0xf4, 0x5c, 0xe3, 0x4f, 0xca, 0x73, 0xfd, 0xcd,
+	0x38, 0x2c, 0x2a, 0xdb, 0x69, 0x9e, 0xf9, 0x2e, 0x96, 0x77, 0x50, 0xf9, 0x89, 0xec, 0xa0, 0xdf,
+	0xd6, 0x54, 0x33, 0xcb, 0xae, 0x77, 0xbf, 0x79, 0x8e, 0x1e, 0xa3, 0x27, 0x35, 0xc7, 0xf2, 0xb2,
+	0x1c, 0x7f, 0xa4, 0x3d, 0x51, 0x39, 0xef, 0x9e, 0x20, 0x39, 0x38, 0x95, 0x33, 0xf9, 0xed, 0x51,
+	0x3d, 0xf5, 0x10, 0x39, 0x53, 0x55, 0x0e, 0x91, 0x2c, 0x2a, 0x91, 0x60, 0x65, 0xdd, 0x5a, 0x96,
+	0x45, 0x71, 0x9e, 0x7c, 0x65, 0x77, 0x52, 0xc4, 0xff, 0x7f, 0xd7, 0xf0, 0xff, 0xa4, 0xe1, 0xbd,
+	0x14, 0x4d, 0x3f, 0x1f, 0x67, 0xd0, 0xef, 0x69, 0x50, 0x4f, 0x5b, 0x3b, 0x1f, 0x3b, 0x89, 0x58,
+	0x87, 0x0a, 0x2d, 0x4d, 0x24, 0xee, 0x6e, 0x3e, 0xd7, 0x90, 0x4e, 0x68, 0xbc, 0x05, 0x3d, 0xd7,
+	0x51, 0xd8, 0xe6, 0x82, 0xc6, 0xdf, 0x6b, 0x49, 0x7a, 0x90, 0x8d, 0xe9, 0x99, 0x4e, 0x45, 0xf6,
+	0x4e, 0xe5, 0x47, 0x7d, 0xa7, 0xff, 0x9c, 0x82, 0x71, 0xca, 0x47, 0x6a, 0x1c, 0x31, 0x0e, 0x7b,
+	0x8e, 0x67, 0xba, 0xf4, 0x75, 0x6a, 0x6c, 0xdf, 0x26, 0x98, 0xb8, 0x6f, 0x13, 0x8c, 0xf4, 0x87,
+	0x65, 0x17, 0x12, 0x54, 0x8d, 0xba, 0xab, 0xfc, 0x43, 0x99, 0x89, 0xd5, 0xda, 0x72, 0x92, 0x72,
+	0x7f, 0x58, 0x8e, 0x48, 0xba, 0x6a, 0x2d, 0xdf, 0x8b, 0x4d, 0xc7, 0xc3, 0x21, 0x33, 0x54, 0x56,
+	0x75, 0xd5, 0xde, 0x90, 0x78, 0x58, 0x5d, 0x57, 0x96, 0x93, 0xbb, 0x6a, 0x65, 0x1a, 0xe9, 0xaa,
+	0x4d, 0x52, 0xa8, 0xcd, 0xb4, 0x8e, 0x55, 0xe8, 0xaa, 0xdd, 0x14, 0x59, 0xd8, 0x92, 0x96, 0xa4,
+	0xe4, 0xae, 0x5a, 0x89, 0x44, 0xfa, 0xd4, 0x03, 0xdf, 0xbe, 0xe7, 0xf1, 0x8c, 0xc3, 0xdc, 0x77,
+	0x99, 0x97, 0x2c, 0xdc, 0xa4, 0xef, 0xe6, 0xb8, 0x98, 0x2b, 0xce, 0xcb, 0xca, 0x7d, 0xea, 0x79,
+	0x2a, 0xe9, 0xaa, 0x73, 0xb1, 0x19, 0xe1, 0xcd, 0x93, 0xc0, 0x09, 0xb1, 0xad, 0xee, 0x2a, 0xdf,
+	0x12, 0x38, 0x78, 0xc9, 0x4e, 0x40, 0xe4, 0xae, 0x3a, 0x91, 0x42, 0x66, 0x9f, 0xf4, 0xf6, 0xf4,
+	0xbd, 0x68, 0xf3, 0x84, 0x77, 0x08, 0x57, 0x55, 0xb3, 0xbf, 0x2d, 0x33, 0xb1, 0xd9, 0xcf, 0x49,
+	0xca, 0xb3, 0x9f, 0x23, 0xa2, 0x2d, 0xea, 0xe7, 0xd9, 0x94, 0xb0, 0xee, 0xf2, 0xa5, 0xc2, 0xd7,
+	0x62, 0xb3, 0xc1, 0xea, 0x72, 0xfc, 0x49, 0x52, 0x9a, 0x6a, 0xe0, 0x73, 0x40, 0x5f, 0xbb, 0x8d,
+	0xe3, 0x7e, 0xe8, 0x61, 0x5b, 0xaf, 0x8f, 0x98, 0x03, 0x89, 0x2b, 0x9d, 0x03, 0x09, 0x2d, 0xcc,
+	0x81, 0x44, 0x25, 0x6b, 0x2a, 0xf0, 0xed, 0xbb, 0x6c, 0xcb, 0xc4, 0x69, 0xbb, 0xf9, 0x95, 0x82,
+	0xa9, 0x8c, 0x85, 0xad, 0x29, 0x49, 0x4a, 0x5e, 0x53, 0x12, 0x89, 0x77, 0x38, 0x8b, 0xfd, 0xb0,
+	0xec, 0x4b, 0x4d, 0x8c, 0xe8, 0x70, 0x2e, 0x70, 0xa6, 0x1d, 0xce, 0x05, 0x4a, 0xa1, 0xc3, 0xb9,
+	0xc0, 0x41, 0xac, 0x77, 0x4d, 0xaf, 0x7b, 0xdb, 0xdf, 0x97, 0x57, 0xf5, 0xa4, 0xca, 0xfa, 0x07,
+	0x0a, 0x4e, 0x66, 0x5d, 0xa5, 0x43, 0xb6, 0xae, 0xe2, 0x40, 0x01, 0xef, 0x6b, 0xd8, 0xf0, 0x71,
+	0xb4, 0xe3, 0xc7, 0x9b, 0x27, 0xe4, 0x5a, 0x6c, 0x8a, 0x5f, 0x56, 0x4b, 0xa6, 0x3f, 0xca, 0xb3,
+	0xb1, 0x2a, 0x6c, 0x41, 0x5a, 0x32, 0x5a, 0x54, 0x8e, 0x7e, 0x57, 0x03, 0x9d, 0xa2, 0x2d, 0xd3,
+	0x3a, 0x72, 0xfd, 0xee, 0x96, 0xd3, 0x73, 0xe2, 0x36, 0x36, 0xc9, 0xa0, 0x78, 0xeb, 0xfa, 0x2b,
+	0x0a, 0xcb, 0x0a, 0xee, 0xd6, 0x2b, 0xc3, 0x41, 0xc3, 0x18, 0xa5, 0x4b, 0x1a, 0xc7, 0x48, 0x8b,
+	0xb4, 0xc1, 0x9c, 0xfc, 0xb4, 0x81, 0x6e, 0x95, 0x68, 0xcb, 0x0c, 0xbb, 0x38, 0x8a, 0x77, 0x7c,
+	0x1b, 0xab, 0x1b, 0xcc, 0x6f, 0xab, 0x58, 0x59, 0x4d, 0x42, 0xa9, 0x45, 0x6e, 0x30, 0x57, 0xb2,
+	0xf0, 0x5f, 0x3c, 0xbc, 0xef, 0x87, 0x16, 0x7e, 0xdf, 0x74, 0x48, 0x93, 0xf0, 0xdc, 0x88, 0x5f,
+	0x3c, 0x08, 0x3c, 0xe9, 0x2f, 0x1e, 0x04, 0xac, 0xf0, 0x8b, 0x07, 0x81, 0x86, 0x36, 0x60, 0x9a,
+	0x5e, 0x0c, 0x39, 0x07, 0xbc, 0xe9, 0x8a, 0x76, 0xf0, 0xd7, 0xb9, 0x8f, 0x97, 0x28, 0xe2, 0xdd,
+	0x9d, 0x4c, 0x21, 0x6d, 0x02, 0xbc, 0x74, 0xfa, 0x63, 0x0d, 0x66, 0x72, 0xe7, 0x12, 0xfa, 0x0e,
+	0xa4, 0xbd, 0x93, 0x77, 0x4f, 0x03, 0x2c, 0x36, 0xd4, 0x8a, 0xb8, 0xaa, 0xd7, 0x92, 0xe0, 0x68,
+	0x0b, 0x20, 0x79, 0xbe, 0x75, 0xd6, 0xa1, 0x4e, 0x63, 0xfa, 0x8c, 0x53, 0x8c, 0xe9, 0x33, 0xd4,
+	0xf8, 0xbc, 0x0c, 0xb5, 0xc4, 0xb1, 0x3d, 0x95, 0xb4, 0x7b, 0x0d, 0xaa, 0xc9, 0x65, 0x42, 0x29,
+	0x8b, 0x9e, 0x7b, 0x85, 0x7b, 0x84, 0x84, 0x4b, 0x0e, 0xee, 0xcb, 0x8f, 0x14, 0xdc, 0x8f, 0x9d,
+	0x3b, 0xb8, 0xc7, 0x30, 0x23, 0x1f, 0xcf, 0x49, 0x87, 0xc3, 0xd9, 0x67, 0x7e, 0xd2, 0x8d, 0x25,
+	0x0a, 0xe6, 0xba, 0xb1, 0x44, 0x12, 0x3a, 0x82, 0x39, 0xa1, 0x0b, 0x83, 0xd7, 0xde, 0xc9, 0x41,
+	0x39, 0x3d, 0xba, 0xb9, 0xad, 0x4d, 0xb9, 0xd8, 0x71, 0x70, 0x94, 0x43, 0xc5, 0xec, 0x28, 0x4f,
+	0x33, 0xfe, 0xb5, 0x04, 0xd3, 0xf2, 0x78, 0x9f, 0xca, 0xc4, 0xbe, 0x09, 0x75, 0x7c, 0xe2, 0xc4,
+	0x1d, 0xcb, 0xb7, 0xd9, 0xd4, 0x8e, 0xb3, 0x79, 0x22, 0xe0, 0x0d, 0x69, 0x57, 0xb7, 0x6b, 0x09,
+	0x26, 0xae, 0x86, 0xf2, 0xb9, 0x56, 0x43, 0x76, 0x55, 0x31, 0xf6, 0xf0, 0xab, 0x0a, 0xf5, 0x77,
+	0xae, 0x3f, 0xa5, 0xef, 0xfc, 0x1f, 0x25, 0x98, 0xcd, 0x9f, 0xde, 0x3f, 0x1b, 0x5b, 0x48, 0xde,
+	0x0d, 0xe5, 0x73, 0xef, 0x86, 0xef, 0xc2, 0x14, 0xc9, 0x35, 0xf8, 0x05, 0x6e, 0x7a, 0x2f, 0xce,
+	0x7c, 0x53, 0xdf, 0x5b, 0x4f, 0x70, 0xc9, 0x37, 0x09, 0x38, 0xfa, 0x15, 0xd0, 0x69, 0xf4, 0xd6,
+	0xf1, 0xf0, 0x31, 0x0e, 0x3b, 0xa6, 0x75, 0xe4, 0xf9, 0x0f, 0x5c, 0x6c, 0x77, 0xd3, 0x1b, 0x70,
+	0x5a, 0x86, 0xa7, 0x3c, 0x3b, 0x84, 0x65, 0x5d, 0xe0, 0x10, 0xcb, 0xf0, 0x6a, 0x0e, 0xe3, 0xd7,
+	0x4b, 0x30, 0x25, 0x45, 0x31, 0xcf, 0x9f, 0xcb, 0x32, 0x66, 0x60, 0x4a, 0x4a, 0x0e, 0x8c, 0xdf,
+	0x64, 0xeb, 0x50, 0x8e, 0x59, 0x9e, 0xbf, 0xef, 0x32, 0x0d, 0x93, 0x62, 0x96, 0x61, 0xb4, 0x60,
+	0x26, 0x97, 0x14, 0x88, 0x2f, 0xa0, 0x9d, 0xe7, 0x05, 0x8c, 0x25, 0x58, 0x50, 0xc5, 0xb2, 0xc6,
+	0x07, 0xb0, 0xa0, 0x8a, 0x32, 0x2f, 0x6e, 0xc0, 0x87, 0xb9, 0x42, 0xcc, 0x78, 0x91, 0xdf, 0xe6,
+	0x5f, 0x74, 0x4a, 0x8c, 0x3f, 0xd5, 0x40, 0x1f, 0x15, 0x2b, 0x5e, 0xc4, 0x30, 0xe9, 0xa6, 0x77,
+	0x92, 0xdf, 0xf3, 0x4c, 0x31, 0x56, 0x0a, 0x88, 0xac, 0x14, 0xb8, 0xb0, 0xcf, 0x37, 0xfe, 0x45,
+	0x83, 0x45, 0x65, 0x0c, 0x49, 0xea, 0x07, 0x49, 0xec, 0x22, 0x96, 0xa3, 0x13, 0x4c, 0x5c, 0x4f,
+	0x09, 0x46, 0x1a, 0x2c, 0xd3, 0x46, 0x07, 0xb1, 0xc1, 0x32, 0x2c, 0xfe, 0x04, 0xae, 0x9d, 0x71,
+	0x92, 0x51, 0xbb, 0xcc, 0xb2, 0x38, 0x6a, 0x0e, 0x89, 0xa3, 0xe6, 0x90, 0xf8, 0x9a, 0x63, 0xe7,
+	0x7a, 0xcd, 0x3f, 0x63, 0xd7, 0x5e, 0x62, 0xfc, 0x99, 0x9d, 0x76, 0xda, 0x39, 0x4e, 0xbb, 0xb7,
+	0xa1, 0x1e, 0x84, 0x8e, 0x67, 0x39, 0x81, 0xe9, 0x8a, 0x6f, 0x96, 0x82, 0xd2, 0x46, 0x49, 0xc0,
+	0x8b, 0xcf, 0xc7, 0x4f, 0x34, 0xba, 0x0d, 0x8a, 0x3f, 0x3c, 0xbd, 0x09, 0xe0, 0xe1, 0x07, 0x9d,
+	0x87, 0xd6, 0xcc, 0xd8, 0xa6, 0xc7, 0x0f, 0x6e, 0xe7, 0x4a, 0x4c, 0xb5, 0x04, 0x23, 0x9a, 0x7c,
+	0xd7, 0xee, 0x3c, 0xb4, 0x52, 0x45, 0x35, 0xf9, 0xae, 0x5d, 0xd0, 0x94, 0x60, 0xc6, 0xef, 0x94,
+	0x61, 0x26, 0xb7, 0x67, 0xd1, 0xf7, 0x48, 0x43, 0x16, 0x7f, 0x78, 0xf8, 0x68, 0x69, 0xb0, 0x9f,
+	0xf2, 0xe7, 0x2d, 0x4d, 0xcb, 0x14, 0x59, 0x37, 0xaf, 0xd4, 0x95, 0xce, 0xa9, 0x3b, 0xdf, 0xee,
+	0x34, 0x2d, 0x53, 0xd0, 0x2f, 0xc3, 0x1c, 0x47, 0x48, 0x6b, 0x1d, 0x1f, 0xf8, 0xe8, 0x36, 0x2a,
+	0xde, 0x5d, 0x96, 0x08, 0xe4, 0x47, 0x3e, 0x93, 0x23, 0xe5, 0xd4, 0xf3, 0xb1, 0x8f, 0x9d, 0x57,
+	0x7d, 0x7e, 0xf0, 0x33, 0x39, 0x12, 0xa9, 0xad, 0xce, 0xe4, 0x7e, 0x0b, 0x8b, 0x36, 0xa0, 0x46,
+	0xff, 0x78, 0xc7, 0xd9, 0x33, 0x40, 0x17, 0x24, 0xe5, 0x93, 0x9b, 0xdc, 0x38, 0x44, 0x17, 0x7e,
+	0xa2, 0x98, 0x3b, 0x20, 0xb6, 0xf0, 0x13, 0x50, 0x5a, 0xf8, 0x09, 0x68, 0xfc, 0xb1, 0x06, 0x97,
+	0x47, 0xfe, 0x4e, 0xf6, 0x59, 0x17, 0x5a, 0xbf, 0xfe, 0x06, 0xd4, 0x92, 0xe6, 0x21, 0x04, 0x50,
+	0xf9, 0xe8, 0xde, 0xe6, 0xbd, 0xcd, 0x8d, 0xd9, 0x4b, 0x68, 0x02, 0xaa, 0xbb, 0x9b, 0x3b, 0x1b,
+	0xb7, 0x76, 0x3e, 0x98, 0xd5, 0xc8, 0x43, 0xfb, 0xde, 0xce, 0x0e, 0x79, 0x28, 0x7d, 0x7d, 0x4b,
+	0xfc, 0x51, 0x01, 0x0b, 0x4a, 0xd1, 0x24, 0xd4, 0xd6, 0x83, 0x80, 0x9e, 0x52, 0x4c, 0x76, 0xf3,
+	0xd8, 0x21, 0x7b, 0x75, 0x56, 0x43, 0x55, 0x28, 0xdf, 0xb9, 0xb3, 0x3d, 0x5b, 0x42, 0x0b, 0x30,
+	0xbb, 0x81, 0x4d, 0xdb, 0x75, 0x3c, 0x9c, 0x1c, 0x8d, 0xb3, 0xe5, 0xd6, 0xfd, 0x9f, 0x7e, 0xb1,
+	0xa2, 0x7d, 0xfe, 0xc5, 0x8a, 0xf6, 0xcf, 0x5f, 0xac, 0x68, 0x9f, 0x7d, 0xb9, 0x72, 0xe9, 0xf3,
+	0x2f, 0x57, 0x2e, 0xfd, 0xc3, 0x97, 0x2b, 0x97, 0xbe, 0xf7, 0x86, 0xf0, 0x87, 0x6a, 0xd8, 0x3b,
+	0x05, 0xa1, 0x4f, 0xa2, 0x02, 0xfe, 0xb4, 0x96, 0xff, 0xd3, 0x3d, 0x3f, 0x29, 0x5d, 0x5d, 0xa7,
+	0x8f, 0xbb, 0x8c, 0xaf, 0x79, 0xcb, 0x6f, 0x32, 0x80, 0xfe, 0x2d, 0x93, 0x68, 0xbf, 0x42, 0xff,
+	0x66, 0xc9, 0x9b, 0xff, 0x37, 0x00, 0x61, 0xd6, 0xb9, 0x3e, 0xf5, 0x47, 0x00, 0x00,
 }
 
 func (m *EventSequence) Marshal() (dAtA []byte, err error) {
@@ -3774,6 +4544,23 @@ func (m *EventSequence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.LeaderEpoch != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.LeaderEpoch))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Provenance != nil {
+		{
+			size, err := m.Provenance.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if len(m.Events) > 0 {
 		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3850,13 +4637,22 @@ func (m *EventSequence_Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			}
 		}
 	}
+	if len(m.CorrelationId) > 0 {
+		i -= len(m.CorrelationId)
+		copy(dAtA[i:], m.CorrelationId)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.CorrelationId)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc2
+	}
 	if m.Created != nil {
-		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Created):])
-		if err1 != nil {
-			return 0, err1
+		n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Created):])
+		if err2 != nil {
+			return 0, err2
 		}
-		i -= n1
-		i = encodeVarintEvents(dAtA, i, uint64(n1))
+		i -= n2
+		i = encodeVarintEvents(dAtA, i, uint64(n2))
 		i--
 		dAtA[i] = 0x1
 		i--
@@ -4318,31 +5114,158 @@ func (m *EventSequence_Event_JobRequeued) MarshalToSizedBuffer(dAtA []byte) (int
 	}
 	return len(dAtA) - i, nil
 }
-func (m *ResourceUtilisation) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *ResourceUtilisation) MarshalTo(dAtA []byte) (int, error) {
+func (m *EventSequence_Event_JobRunCancelled) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResourceUtilisation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *EventSequence_Event_JobRunCancelled) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.TotalCumulativeUsage) > 0 {
-		for k := range m.TotalCumulativeUsage {
-			v := m.TotalCumulativeUsage[k]
-			baseI := i
-			{
+	if m.JobRunCancelled != nil {
+		{
+			size, err := m.JobRunCancelled.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xba
+	}
+	return len(dAtA) - i, nil
+}
+func (m *EventSequence_Event_JobValidated) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventSequence_Event_JobValidated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.JobValidated != nil {
+		{
+			size, err := m.JobValidated.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xca
+	}
+	return len(dAtA) - i, nil
+}
+func (m *EventSequence_Event_JobRetriesExhausted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventSequence_Event_JobRetriesExhausted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.JobRetriesExhausted != nil {
+		{
+			size, err := m.JobRetriesExhausted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd2
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Provenance) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Provenance) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Provenance) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CycleId) > 0 {
+		i -= len(m.CycleId)
+		copy(dAtA[i:], m.CycleId)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.CycleId)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.LeaderName) > 0 {
+		i -= len(m.LeaderName)
+		copy(dAtA[i:], m.LeaderName)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.LeaderName)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ConfigHash) > 0 {
+		i -= len(m.ConfigHash)
+		copy(dAtA[i:], m.ConfigHash)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.ConfigHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.BuildCommit) > 0 {
+		i -= len(m.BuildCommit)
+		copy(dAtA[i:], m.BuildCommit)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.BuildCommit)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.BuildVersion) > 0 {
+		i -= len(m.BuildVersion)
+		copy(dAtA[i:], m.BuildVersion)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.BuildVersion)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResourceUtilisation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceUtilisation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceUtilisation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TotalCumulativeUsage) > 0 {
+		for k := range m.TotalCumulativeUsage {
+			v := m.TotalCumulativeUsage[k]
+			baseI := i
+			{
 				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
@@ -5020,6 +5943,139 @@ func (m *JobRequeued) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *JobValidated) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobValidated) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobValidated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CycleId) > 0 {
+		i -= len(m.CycleId)
+		copy(dAtA[i:], m.CycleId)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.CycleId)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.PriorityClamped {
+		i--
+		if m.PriorityClamped {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.DefaultPriorityClassApplied {
+		i--
+		if m.DefaultPriorityClassApplied {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.PriorityClassName) > 0 {
+		i -= len(m.PriorityClassName)
+		copy(dAtA[i:], m.PriorityClassName)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.PriorityClassName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.EffectivePriority != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.EffectivePriority))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.JobId != nil {
+		{
+			size, err := m.JobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobRetriesExhausted) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobRetriesExhausted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobRetriesExhausted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LastRunError) > 0 {
+		i -= len(m.LastRunError)
+		copy(dAtA[i:], m.LastRunError)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.LastRunError)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.LastRunId != nil {
+		{
+			size, err := m.LastRunId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Attempts != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.Attempts))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.JobId != nil {
+		{
+			size, err := m.JobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ReprioritiseJobSet) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5068,6 +6124,18 @@ func (m *ReprioritisedJob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ClampMessage) > 0 {
+		i -= len(m.ClampMessage)
+		copy(dAtA[i:], m.ClampMessage)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.ClampMessage)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.RequestedPriority != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.RequestedPriority))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Priority != 0 {
 		i = encodeVarintEvents(dAtA, i, uint64(m.Priority))
 		i--
@@ -5151,20 +6219,20 @@ func (m *JobSetFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.States) > 0 {
-		dAtA45 := make([]byte, len(m.States)*10)
-		var j44 int
+		dAtA52 := make([]byte, len(m.States)*10)
+		var j51 int
 		for _, num := range m.States {
 			for num >= 1<<7 {
-				dAtA45[j44] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA52[j51] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j44++
+				j51++
 			}
-			dAtA45[j44] = uint8(num)
-			j44++
+			dAtA52[j51] = uint8(num)
+			j51++
 		}
-		i -= j44
-		copy(dAtA[i:], dAtA45[:j44])
-		i = encodeVarintEvents(dAtA, i, uint64(j44))
+		i -= j51
+		copy(dAtA[i:], dAtA52[:j51])
+		i = encodeVarintEvents(dAtA, i, uint64(j51))
 		i--
 		dAtA[i] = 0xa
 	}
@@ -5199,20 +6267,20 @@ func (m *CancelJobSet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 	}
 	if len(m.States) > 0 {
-		dAtA47 := make([]byte, len(m.States)*10)
-		var j46 int
+		dAtA54 := make([]byte, len(m.States)*10)
+		var j53 int
 		for _, num := range m.States {
 			for num >= 1<<7 {
-				dAtA47[j46] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA54[j53] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j46++
+				j53++
 			}
-			dAtA47[j46] = uint8(num)
-			j46++
+			dAtA54[j53] = uint8(num)
+			j53++
 		}
-		i -= j46
-		copy(dAtA[i:], dAtA47[:j46])
-		i = encodeVarintEvents(dAtA, i, uint64(j46))
+		i -= j53
+		copy(dAtA[i:], dAtA54[:j53])
+		i = encodeVarintEvents(dAtA, i, uint64(j53))
 		i--
 		dAtA[i] = 0xa
 	}
@@ -5261,7 +6329,7 @@ func (m *CancelledJob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *JobSucceeded) Marshal() (dAtA []byte, err error) {
+func (m *JobRunCancelled) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5271,33 +6339,31 @@ func (m *JobSucceeded) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *JobSucceeded) MarshalTo(dAtA []byte) (int, error) {
+func (m *JobRunCancelled) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *JobSucceeded) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *JobRunCancelled) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.ResourceInfos) > 0 {
-		for iNdEx := len(m.ResourceInfos) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.ResourceInfos[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintEvents(dAtA, i, uint64(size))
+	if m.JobId != nil {
+		{
+			size, err := m.JobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			i--
-			dAtA[i] = 0x12
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.JobId != nil {
+	if m.RunId != nil {
 		{
-			size, err := m.JobId.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.RunId.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5310,7 +6376,7 @@ func (m *JobSucceeded) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *JobRunLeased) Marshal() (dAtA []byte, err error) {
+func (m *JobSucceeded) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5320,19 +6386,68 @@ func (m *JobRunLeased) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *JobRunLeased) MarshalTo(dAtA []byte) (int, error) {
+func (m *JobSucceeded) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *JobRunLeased) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *JobSucceeded) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.AdditionalAnnotations) > 0 {
-		for k := range m.AdditionalAnnotations {
-			v := m.AdditionalAnnotations[k]
+	if len(m.ResourceInfos) > 0 {
+		for iNdEx := len(m.ResourceInfos) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ResourceInfos[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintEvents(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.JobId != nil {
+		{
+			size, err := m.JobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobRunLeased) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobRunLeased) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobRunLeased) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AdditionalAnnotations) > 0 {
+		for k := range m.AdditionalAnnotations {
+			v := m.AdditionalAnnotations[k]
 			baseI := i
 			i -= len(v)
 			copy(dAtA[i:], v)
@@ -6002,6 +7117,13 @@ func (m *Error) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			}
 		}
 	}
+	if len(m.Classification) > 0 {
+		i -= len(m.Classification)
+		copy(dAtA[i:], m.Classification)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Classification)))
+		i--
+		dAtA[i] = 0x7a
+	}
 	if m.Terminal {
 		i--
 		if m.Terminal {
@@ -6246,6 +7368,94 @@ func (m *Error_GangJobUnschedulable) MarshalToSizedBuffer(dAtA []byte) (int, err
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Error_QueueDoesNotExist) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Error_QueueDoesNotExist) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.QueueDoesNotExist != nil {
+		{
+			size, err := m.QueueDoesNotExist.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Error_QueueBacklogLimitReached) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Error_QueueBacklogLimitReached) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.QueueBacklogLimitReached != nil {
+		{
+			size, err := m.QueueBacklogLimitReached.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Error_JobExceedsLargestNode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Error_JobExceedsLargestNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.JobExceedsLargestNode != nil {
+		{
+			size, err := m.JobExceedsLargestNode.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Error_JobForceFailed) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Error_JobForceFailed) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.JobForceFailed != nil {
+		{
+			size, err := m.JobForceFailed.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *KubernetesError) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6440,6 +7650,16 @@ func (m *PodLeaseReturned) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.LeaseNeverAcknowledged {
+		i--
+		if m.LeaseNeverAcknowledged {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.RunAttempted {
 		i--
 		if m.RunAttempted {
@@ -6714,7 +7934,7 @@ func (m *GangJobUnschedulable) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *JobDuplicateDetected) Marshal() (dAtA []byte, err error) {
+func (m *QueueDoesNotExist) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6724,44 +7944,34 @@ func (m *JobDuplicateDetected) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *JobDuplicateDetected) MarshalTo(dAtA []byte) (int, error) {
+func (m *QueueDoesNotExist) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *JobDuplicateDetected) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *QueueDoesNotExist) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.OldJobId != nil {
-		{
-			size, err := m.OldJobId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEvents(dAtA, i, uint64(size))
-		}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Message)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.NewJobId != nil {
-		{
-			size, err := m.NewJobId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEvents(dAtA, i, uint64(size))
-		}
+	if len(m.Queue) > 0 {
+		i -= len(m.Queue)
+		copy(dAtA[i:], m.Queue)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Queue)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *JobRunPreempted) Marshal() (dAtA []byte, err error) {
+func (m *QueueBacklogLimitReached) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6771,68 +7981,39 @@ func (m *JobRunPreempted) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *JobRunPreempted) MarshalTo(dAtA []byte) (int, error) {
+func (m *QueueBacklogLimitReached) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *JobRunPreempted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *QueueBacklogLimitReached) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.PreemptiveRunId != nil {
-		{
-			size, err := m.PreemptiveRunId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEvents(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.PreemptiveJobId != nil {
-		{
-			size, err := m.PreemptiveJobId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEvents(dAtA, i, uint64(size))
-		}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Message)))
 		i--
 		dAtA[i] = 0x1a
 	}
-	if m.PreemptedRunId != nil {
-		{
-			size, err := m.PreemptedRunId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEvents(dAtA, i, uint64(size))
-		}
+	if m.Limit != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.Limit))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x10
 	}
-	if m.PreemptedJobId != nil {
-		{
-			size, err := m.PreemptedJobId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEvents(dAtA, i, uint64(size))
-		}
+	if len(m.Queue) > 0 {
+		i -= len(m.Queue)
+		copy(dAtA[i:], m.Queue)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Queue)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PartitionMarker) Marshal() (dAtA []byte, err error) {
+func (m *JobExceedsLargestNode) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6842,37 +8023,48 @@ func (m *PartitionMarker) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PartitionMarker) MarshalTo(dAtA []byte) (int, error) {
+func (m *JobExceedsLargestNode) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PartitionMarker) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *JobExceedsLargestNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Partition != 0 {
-		i = encodeVarintEvents(dAtA, i, uint64(m.Partition))
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Message)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x22
 	}
-	if m.GroupId != nil {
-		{
-			size, err := m.GroupId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEvents(dAtA, i, uint64(size))
-		}
+	if len(m.Largest) > 0 {
+		i -= len(m.Largest)
+		copy(dAtA[i:], m.Largest)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Largest)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Requested) > 0 {
+		i -= len(m.Requested)
+		copy(dAtA[i:], m.Requested)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Requested)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Resource) > 0 {
+		i -= len(m.Resource)
+		copy(dAtA[i:], m.Resource)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Resource)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *JobRunPreemptionRequested) Marshal() (dAtA []byte, err error) {
+func (m *JobForceFailed) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6882,27 +8074,229 @@ func (m *JobRunPreemptionRequested) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *JobRunPreemptionRequested) MarshalTo(dAtA []byte) (int, error) {
+func (m *JobForceFailed) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *JobRunPreemptionRequested) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *JobForceFailed) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.JobId != nil {
-		{
-			size, err := m.JobId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEvents(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Principal) > 0 {
+		i -= len(m.Principal)
+		copy(dAtA[i:], m.Principal)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Principal)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobDuplicateDetected) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobDuplicateDetected) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobDuplicateDetected) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.OldJobId != nil {
+		{
+			size, err := m.OldJobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.NewJobId != nil {
+		{
+			size, err := m.NewJobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobRunPreempted) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobRunPreempted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobRunPreempted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PreemptiveRunId != nil {
+		{
+			size, err := m.PreemptiveRunId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.PreemptiveJobId != nil {
+		{
+			size, err := m.PreemptiveJobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.PreemptedRunId != nil {
+		{
+			size, err := m.PreemptedRunId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PreemptedJobId != nil {
+		{
+			size, err := m.PreemptedJobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PartitionMarker) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PartitionMarker) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PartitionMarker) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Partition != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.Partition))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupId != nil {
+		{
+			size, err := m.GroupId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobRunPreemptionRequested) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobRunPreemptionRequested) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobRunPreemptionRequested) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.JobId != nil {
+		{
+			size, err := m.JobId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvents(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
 	if m.RunId != nil {
 		{
@@ -6960,6 +8354,13 @@ func (m *EventSequence) Size() (n int) {
 			n += 1 + l + sovEvents(uint64(l))
 		}
 	}
+	if m.Provenance != nil {
+		l = m.Provenance.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.LeaderEpoch != 0 {
+		n += 1 + sovEvents(uint64(m.LeaderEpoch))
+	}
 	return n
 }
 
@@ -6976,6 +8377,10 @@ func (m *EventSequence_Event) Size() (n int) {
 		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Created)
 		n += 2 + l + sovEvents(uint64(l))
 	}
+	l = len(m.CorrelationId)
+	if l > 0 {
+		n += 2 + l + sovEvents(uint64(l))
+	}
 	return n
 }
 
@@ -7231,61 +8636,126 @@ func (m *EventSequence_Event_JobRequeued) Size() (n int) {
 	}
 	return n
 }
-func (m *ResourceUtilisation) Size() (n int) {
+func (m *EventSequence_Event_JobRunCancelled) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.RunId != nil {
-		l = m.RunId.Size()
-		n += 1 + l + sovEvents(uint64(l))
-	}
-	if m.JobId != nil {
-		l = m.JobId.Size()
-		n += 1 + l + sovEvents(uint64(l))
-	}
-	if m.ResourceInfo != nil {
-		l = m.ResourceInfo.Size()
-		n += 1 + l + sovEvents(uint64(l))
-	}
-	if len(m.MaxResourcesForPeriod) > 0 {
-		for k, v := range m.MaxResourcesForPeriod {
-			_ = k
-			_ = v
-			l = v.Size()
-			mapEntrySize := 1 + len(k) + sovEvents(uint64(len(k))) + 1 + l + sovEvents(uint64(l))
-			n += mapEntrySize + 1 + sovEvents(uint64(mapEntrySize))
-		}
-	}
-	if len(m.TotalCumulativeUsage) > 0 {
-		for k, v := range m.TotalCumulativeUsage {
-			_ = k
-			_ = v
-			l = v.Size()
-			mapEntrySize := 1 + len(k) + sovEvents(uint64(len(k))) + 1 + l + sovEvents(uint64(l))
-			n += mapEntrySize + 1 + sovEvents(uint64(mapEntrySize))
-		}
+	if m.JobRunCancelled != nil {
+		l = m.JobRunCancelled.Size()
+		n += 2 + l + sovEvents(uint64(l))
 	}
 	return n
 }
-
-func (m *Uuid) Size() (n int) {
+func (m *EventSequence_Event_JobValidated) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.High64 != 0 {
-		n += 9
-	}
-	if m.Low64 != 0 {
-		n += 9
+	if m.JobValidated != nil {
+		l = m.JobValidated.Size()
+		n += 2 + l + sovEvents(uint64(l))
 	}
 	return n
 }
-
-func (m *SubmitJob) Size() (n int) {
+func (m *EventSequence_Event_JobRetriesExhausted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobRetriesExhausted != nil {
+		l = m.JobRetriesExhausted.Size()
+		n += 2 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+func (m *Provenance) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.BuildVersion)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.BuildCommit)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.ConfigHash)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.LeaderName)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.CycleId)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *ResourceUtilisation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RunId != nil {
+		l = m.RunId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.JobId != nil {
+		l = m.JobId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.ResourceInfo != nil {
+		l = m.ResourceInfo.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if len(m.MaxResourcesForPeriod) > 0 {
+		for k, v := range m.MaxResourcesForPeriod {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + len(k) + sovEvents(uint64(len(k))) + 1 + l + sovEvents(uint64(l))
+			n += mapEntrySize + 1 + sovEvents(uint64(mapEntrySize))
+		}
+	}
+	if len(m.TotalCumulativeUsage) > 0 {
+		for k, v := range m.TotalCumulativeUsage {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + len(k) + sovEvents(uint64(len(k))) + 1 + l + sovEvents(uint64(l))
+			n += mapEntrySize + 1 + sovEvents(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *Uuid) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.High64 != 0 {
+		n += 9
+	}
+	if m.Low64 != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *SubmitJob) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -7529,6 +8999,60 @@ func (m *JobRequeued) Size() (n int) {
 	return n
 }
 
+func (m *JobValidated) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobId != nil {
+		l = m.JobId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.EffectivePriority != 0 {
+		n += 1 + sovEvents(uint64(m.EffectivePriority))
+	}
+	l = len(m.PriorityClassName)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.DefaultPriorityClassApplied {
+		n += 2
+	}
+	if m.PriorityClamped {
+		n += 2
+	}
+	l = len(m.CycleId)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *JobRetriesExhausted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobId != nil {
+		l = m.JobId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.Attempts != 0 {
+		n += 1 + sovEvents(uint64(m.Attempts))
+	}
+	if m.LastRunId != nil {
+		l = m.LastRunId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.LastRunError)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
 func (m *ReprioritiseJobSet) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7554,6 +9078,13 @@ func (m *ReprioritisedJob) Size() (n int) {
 	if m.Priority != 0 {
 		n += 1 + sovEvents(uint64(m.Priority))
 	}
+	if m.RequestedPriority != 0 {
+		n += 1 + sovEvents(uint64(m.RequestedPriority))
+	}
+	l = len(m.ClampMessage)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
 	return n
 }
 
@@ -7627,6 +9158,23 @@ func (m *CancelledJob) Size() (n int) {
 	return n
 }
 
+func (m *JobRunCancelled) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RunId != nil {
+		l = m.RunId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.JobId != nil {
+		l = m.JobId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
 func (m *JobSucceeded) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7932,6 +9480,10 @@ func (m *Error) Size() (n int) {
 	if m.Reason != nil {
 		n += m.Reason.Size()
 	}
+	l = len(m.Classification)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
 	return n
 }
 
@@ -8067,6 +9619,54 @@ func (m *Error_GangJobUnschedulable) Size() (n int) {
 	}
 	return n
 }
+func (m *Error_QueueDoesNotExist) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.QueueDoesNotExist != nil {
+		l = m.QueueDoesNotExist.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+func (m *Error_QueueBacklogLimitReached) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.QueueBacklogLimitReached != nil {
+		l = m.QueueBacklogLimitReached.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+func (m *Error_JobExceedsLargestNode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobExceedsLargestNode != nil {
+		l = m.JobExceedsLargestNode.Size()
+		n += 2 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+func (m *Error_JobForceFailed) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobForceFailed != nil {
+		l = m.JobForceFailed.Size()
+		n += 2 + l + sovEvents(uint64(l))
+	}
+	return n
+}
 func (m *KubernetesError) Size() (n int) {
 	if m == nil {
 		return 0
@@ -8164,6 +9764,9 @@ func (m *PodLeaseReturned) Size() (n int) {
 	if m.RunAttempted {
 		n += 2
 	}
+	if m.LeaseNeverAcknowledged {
+		n += 2
+	}
 	return n
 }
 
@@ -8268,85 +9871,168 @@ func (m *GangJobUnschedulable) Size() (n int) {
 	return n
 }
 
-func (m *JobDuplicateDetected) Size() (n int) {
+func (m *QueueDoesNotExist) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.NewJobId != nil {
-		l = m.NewJobId.Size()
+	l = len(m.Queue)
+	if l > 0 {
 		n += 1 + l + sovEvents(uint64(l))
 	}
-	if m.OldJobId != nil {
-		l = m.OldJobId.Size()
+	l = len(m.Message)
+	if l > 0 {
 		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
 }
 
-func (m *JobRunPreempted) Size() (n int) {
+func (m *QueueBacklogLimitReached) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.PreemptedJobId != nil {
-		l = m.PreemptedJobId.Size()
+	l = len(m.Queue)
+	if l > 0 {
 		n += 1 + l + sovEvents(uint64(l))
 	}
-	if m.PreemptedRunId != nil {
-		l = m.PreemptedRunId.Size()
+	if m.Limit != 0 {
+		n += 1 + sovEvents(uint64(m.Limit))
+	}
+	l = len(m.Message)
+	if l > 0 {
 		n += 1 + l + sovEvents(uint64(l))
 	}
-	if m.PreemptiveJobId != nil {
-		l = m.PreemptiveJobId.Size()
+	return n
+}
+
+func (m *JobExceedsLargestNode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Resource)
+	if l > 0 {
 		n += 1 + l + sovEvents(uint64(l))
 	}
-	if m.PreemptiveRunId != nil {
-		l = m.PreemptiveRunId.Size()
+	l = len(m.Requested)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.Largest)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
 		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
 }
 
-func (m *PartitionMarker) Size() (n int) {
+func (m *JobForceFailed) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.GroupId != nil {
-		l = m.GroupId.Size()
+	l = len(m.Reason)
+	if l > 0 {
 		n += 1 + l + sovEvents(uint64(l))
 	}
-	if m.Partition != 0 {
-		n += 1 + sovEvents(uint64(m.Partition))
+	l = len(m.Principal)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
 }
 
-func (m *JobRunPreemptionRequested) Size() (n int) {
+func (m *JobDuplicateDetected) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.RunId != nil {
-		l = m.RunId.Size()
+	if m.NewJobId != nil {
+		l = m.NewJobId.Size()
 		n += 1 + l + sovEvents(uint64(l))
 	}
-	if m.JobId != nil {
-		l = m.JobId.Size()
+	if m.OldJobId != nil {
+		l = m.OldJobId.Size()
 		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
 }
 
-func sovEvents(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozEvents(x uint64) (n int) {
+func (m *JobRunPreempted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PreemptedJobId != nil {
+		l = m.PreemptedJobId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.PreemptedRunId != nil {
+		l = m.PreemptedRunId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.PreemptiveJobId != nil {
+		l = m.PreemptiveJobId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.PreemptiveRunId != nil {
+		l = m.PreemptiveRunId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *PartitionMarker) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupId != nil {
+		l = m.GroupId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.Partition != 0 {
+		n += 1 + sovEvents(uint64(m.Partition))
+	}
+	return n
+}
+
+func (m *JobRunPreemptionRequested) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RunId != nil {
+		l = m.RunId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.JobId != nil {
+		l = m.JobId.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func sovEvents(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozEvents(x uint64) (n int) {
 	return sovEvents(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
 func (m *EventSequence) Unmarshal(dAtA []byte) error {
@@ -8540,6 +10226,61 @@ func (m *EventSequence) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Provenance == nil {
+				m.Provenance = &Provenance{}
+			}
+			if err := m.Provenance.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
+			}
+			m.LeaderEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -9361,59 +11102,9 @@ func (m *EventSequence_Event) Unmarshal(dAtA []byte) error {
 			}
 			m.Event = &EventSequence_Event_JobRequeued{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResourceUtilisation) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResourceUtilisation: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResourceUtilisation: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 23:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobRunCancelled", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9440,18 +11131,17 @@ func (m *ResourceUtilisation) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RunId == nil {
-				m.RunId = &Uuid{}
-			}
-			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &JobRunCancelled{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Event = &EventSequence_Event_JobRunCancelled{v}
 			iNdEx = postIndex
-		case 2:
+		case 24:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CorrelationId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -9461,31 +11151,27 @@ func (m *ResourceUtilisation) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.CorrelationId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 25:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobValidated", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9512,16 +11198,15 @@ func (m *ResourceUtilisation) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ResourceInfo == nil {
-				m.ResourceInfo = &KubernetesResourceInfo{}
-			}
-			if err := m.ResourceInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &JobValidated{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Event = &EventSequence_Event_JobValidated{v}
 			iNdEx = postIndex
-		case 4:
+		case 26:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxResourcesForPeriod", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobRetriesExhausted", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9548,234 +11233,11 @@ func (m *ResourceUtilisation) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.MaxResourcesForPeriod == nil {
-				m.MaxResourcesForPeriod = make(map[string]resource.Quantity)
+			v := &JobRetriesExhausted{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			var mapkey string
-			mapvalue := &resource.Quantity{}
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &resource.Quantity{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipEvents(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.MaxResourcesForPeriod[mapkey] = *mapvalue
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalCumulativeUsage", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.TotalCumulativeUsage == nil {
-				m.TotalCumulativeUsage = make(map[string]resource.Quantity)
-			}
-			var mapkey string
-			mapvalue := &resource.Quantity{}
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &resource.Quantity{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipEvents(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.TotalCumulativeUsage[mapkey] = *mapvalue
+			m.Event = &EventSequence_Event_JobRetriesExhausted{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9798,77 +11260,7 @@ func (m *ResourceUtilisation) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Uuid) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Uuid: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Uuid: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field High64", wireType)
-			}
-			m.High64 = 0
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.High64 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 2:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Low64", wireType)
-			}
-			m.Low64 = 0
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Low64 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SubmitJob) Unmarshal(dAtA []byte) error {
+func (m *Provenance) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9891,17 +11283,17 @@ func (m *SubmitJob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubmitJob: wiretype end group for non-group")
+			return fmt.Errorf("proto: Provenance: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubmitJob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Provenance: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BuildVersion", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -9911,31 +11303,27 @@ func (m *SubmitJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.BuildVersion = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeduplicationId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BuildCommit", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9963,68 +11351,13 @@ func (m *SubmitJob) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DeduplicationId = string(dAtA[iNdEx:postIndex])
+			m.BuildCommit = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
-			}
-			m.Priority = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Priority |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
-			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MainObject", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigHash", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -10034,33 +11367,29 @@ func (m *SubmitJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.MainObject == nil {
-				m.MainObject = &KubernetesMainObject{}
-			}
-			if err := m.MainObject.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ConfigHash = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -10070,108 +11399,27 @@ func (m *SubmitJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Objects = append(m.Objects, &KubernetesObject{})
-			if err := m.Objects[len(m.Objects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LeaderName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lifetime", wireType)
-			}
-			m.Lifetime = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Lifetime |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AtMostOnce", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.AtMostOnce = bool(v != 0)
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Preemptible", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Preemptible = bool(v != 0)
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConcurrencySafe", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.ConcurrencySafe = bool(v != 0)
-		case 11:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scheduler", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CycleId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10199,47 +11447,8 @@ func (m *SubmitJob) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Scheduler = string(dAtA[iNdEx:postIndex])
+			m.CycleId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsDuplicate", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.IsDuplicate = bool(v != 0)
-		case 13:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field QueueTtlSeconds", wireType)
-			}
-			m.QueueTtlSeconds = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.QueueTtlSeconds |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -10261,7 +11470,7 @@ func (m *SubmitJob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KubernetesMainObject) Unmarshal(dAtA []byte) error {
+func (m *ResourceUtilisation) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10284,15 +11493,15 @@ func (m *KubernetesMainObject) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KubernetesMainObject: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResourceUtilisation: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KubernetesMainObject: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResourceUtilisation: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10319,16 +11528,16 @@ func (m *KubernetesMainObject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
+			if m.RunId == nil {
+				m.RunId = &Uuid{}
 			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodSpec", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10355,65 +11564,16 @@ func (m *KubernetesMainObject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PodSpecWithAvoidList{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
 			}
-			m.Object = &KubernetesMainObject_PodSpec{v}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *KubernetesObject) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: KubernetesObject: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KubernetesObject: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceInfo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10440,16 +11600,16 @@ func (m *KubernetesObject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
+			if m.ResourceInfo == nil {
+				m.ResourceInfo = &KubernetesResourceInfo{}
 			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ResourceInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodSpec", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxResourcesForPeriod", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10476,50 +11636,109 @@ func (m *KubernetesObject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PodSpecWithAvoidList{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Object = &KubernetesObject_PodSpec{v}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ingress", wireType)
+			if m.MaxResourcesForPeriod == nil {
+				m.MaxResourcesForPeriod = make(map[string]resource.Quantity)
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+			var mapkey string
+			mapvalue := &resource.Quantity{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &resource.Quantity{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipEvents(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &v1.IngressSpec{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Object = &KubernetesObject_Ingress{v}
+			m.MaxResourcesForPeriod[mapkey] = *mapvalue
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalCumulativeUsage", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10546,17 +11765,2680 @@ func (m *KubernetesObject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &v11.ServiceSpec{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if m.TotalCumulativeUsage == nil {
+				m.TotalCumulativeUsage = make(map[string]resource.Quantity)
 			}
-			m.Object = &KubernetesObject_Service{v}
-			iNdEx = postIndex
-		case 5:
+			var mapkey string
+			mapvalue := &resource.Quantity{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &resource.Quantity{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipEvents(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.TotalCumulativeUsage[mapkey] = *mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Uuid) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Uuid: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Uuid: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field High64", wireType)
+			}
+			m.High64 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.High64 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Low64", wireType)
+			}
+			m.Low64 = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Low64 = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubmitJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubmitJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubmitJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeduplicationId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeduplicationId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MainObject", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MainObject == nil {
+				m.MainObject = &KubernetesMainObject{}
+			}
+			if err := m.MainObject.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Objects = append(m.Objects, &KubernetesObject{})
+			if err := m.Objects[len(m.Objects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lifetime", wireType)
+			}
+			m.Lifetime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Lifetime |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AtMostOnce", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AtMostOnce = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Preemptible", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Preemptible = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConcurrencySafe", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConcurrencySafe = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheduler", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scheduler = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsDuplicate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsDuplicate = bool(v != 0)
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueTtlSeconds", wireType)
+			}
+			m.QueueTtlSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QueueTtlSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KubernetesMainObject) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KubernetesMainObject: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KubernetesMainObject: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodSpec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PodSpecWithAvoidList{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Object = &KubernetesMainObject_PodSpec{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KubernetesObject) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KubernetesObject: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KubernetesObject: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodSpec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PodSpecWithAvoidList{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Object = &KubernetesObject_PodSpec{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &v1.IngressSpec{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Object = &KubernetesObject_Ingress{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &v11.ServiceSpec{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Object = &KubernetesObject_Service{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigMap", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &v11.ConfigMap{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Object = &KubernetesObject_ConfigMap{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ObjectMeta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ObjectMeta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ObjectMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KubernetesId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KubernetesId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Annotations == nil {
+				m.Annotations = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipEvents(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Annotations[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipEvents(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PodSpecWithAvoidList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PodSpecWithAvoidList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PodSpecWithAvoidList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodSpec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PodSpec == nil {
+				m.PodSpec = &v11.PodSpec{}
+			}
+			if err := m.PodSpec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeAvoidlist", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeAvoidlist = append(m.NodeAvoidlist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReprioritiseJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReprioritiseJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReprioritiseJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobRequeued) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobRequeued: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobRequeued: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchedulingInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SchedulingInfo == nil {
+				m.SchedulingInfo = &schedulerobjects.JobSchedulingInfo{}
+			}
+			if err := m.SchedulingInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateSequenceNumber", wireType)
+			}
+			m.UpdateSequenceNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdateSequenceNumber |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobValidated) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobValidated: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobValidated: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EffectivePriority", wireType)
+			}
+			m.EffectivePriority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EffectivePriority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PriorityClassName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PriorityClassName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultPriorityClassApplied", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DefaultPriorityClassApplied = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PriorityClamped", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PriorityClamped = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CycleId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CycleId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobRetriesExhausted) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobRetriesExhausted: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobRetriesExhausted: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempts", wireType)
+			}
+			m.Attempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Attempts |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastRunId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastRunId == nil {
+				m.LastRunId = &Uuid{}
+			}
+			if err := m.LastRunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastRunError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastRunError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReprioritiseJobSet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReprioritiseJobSet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReprioritiseJobSet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReprioritisedJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReprioritisedJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReprioritisedJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestedPriority", wireType)
+			}
+			m.RequestedPriority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestedPriority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClampMessage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClampMessage = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobSetFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobSetFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobSetFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v JobState
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= JobState(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.States = append(m.States, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEvents
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthEvents
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.States) == 0 {
+					m.States = make([]JobState, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v JobState
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= JobState(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.States = append(m.States, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field States", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelJobSet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelJobSet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelJobSet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v JobState
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= JobState(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.States = append(m.States, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEvents
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthEvents
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.States) == 0 {
+					m.States = make([]JobState, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v JobState
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= JobState(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.States = append(m.States, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field States", wireType)
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigMap", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -10566,26 +14448,23 @@ func (m *KubernetesObject) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &v11.ConfigMap{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Object = &KubernetesObject_ConfigMap{v}
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10608,7 +14487,7 @@ func (m *KubernetesObject) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ObjectMeta) Unmarshal(dAtA []byte) error {
+func (m *CancelledJob) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10631,143 +14510,15 @@ func (m *ObjectMeta) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ObjectMeta: wiretype end group for non-group")
+			return fmt.Errorf("proto: CancelledJob: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ObjectMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CancelledJob: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ExecutorId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KubernetesId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.KubernetesId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10788,115 +14539,24 @@ func (m *ObjectMeta) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthEvents
 			}
 			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Annotations == nil {
-				m.Annotations = make(map[string]string)
-			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipEvents(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Annotations[mapkey] = mapvalue
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -10906,118 +14566,23 @@ func (m *ObjectMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = make(map[string]string)
-			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipEvents(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.Labels[mapkey] = mapvalue
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11040,7 +14605,7 @@ func (m *ObjectMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PodSpecWithAvoidList) Unmarshal(dAtA []byte) error {
+func (m *JobRunCancelled) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11063,15 +14628,15 @@ func (m *PodSpecWithAvoidList) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PodSpecWithAvoidList: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobRunCancelled: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PodSpecWithAvoidList: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobRunCancelled: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodSpec", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11098,18 +14663,18 @@ func (m *PodSpecWithAvoidList) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.PodSpec == nil {
-				m.PodSpec = &v11.PodSpec{}
+			if m.RunId == nil {
+				m.RunId = &Uuid{}
 			}
-			if err := m.PodSpec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeAvoidlist", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -11119,23 +14684,27 @@ func (m *PodSpecWithAvoidList) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeAvoidlist = append(m.NodeAvoidlist, string(dAtA[iNdEx:postIndex]))
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11158,7 +14727,7 @@ func (m *PodSpecWithAvoidList) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReprioritiseJob) Unmarshal(dAtA []byte) error {
+func (m *JobSucceeded) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11181,10 +14750,10 @@ func (m *ReprioritiseJob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReprioritiseJob: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobSucceeded: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReprioritiseJob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobSucceeded: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11224,10 +14793,10 @@ func (m *ReprioritiseJob) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceInfos", wireType)
 			}
-			m.Priority = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -11237,11 +14806,26 @@ func (m *ReprioritiseJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Priority |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceInfos = append(m.ResourceInfos, &KubernetesResourceInfo{})
+			if err := m.ResourceInfos[len(m.ResourceInfos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -11263,7 +14847,7 @@ func (m *ReprioritiseJob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobRequeued) Unmarshal(dAtA []byte) error {
+func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11286,17 +14870,89 @@ func (m *JobRequeued) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobRequeued: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobRunLeased: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobRequeued: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobRunLeased: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RunId == nil {
+				m.RunId = &Uuid{}
+			}
+			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -11306,33 +14962,29 @@ func (m *JobRequeued) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchedulingInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -11342,29 +14994,25 @@ func (m *JobRequeued) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SchedulingInfo == nil {
-				m.SchedulingInfo = &schedulerobjects.JobSchedulingInfo{}
-			}
-			if err := m.SchedulingInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NodeId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field UpdateSequenceNumber", wireType)
 			}
@@ -11383,6 +15031,172 @@ func (m *JobRequeued) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasScheduledAtPriority", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasScheduledAtPriority = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledAtPriority", wireType)
+			}
+			m.ScheduledAtPriority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScheduledAtPriority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdditionalAnnotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AdditionalAnnotations == nil {
+				m.AdditionalAnnotations = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipEvents(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.AdditionalAnnotations[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -11404,7 +15218,7 @@ func (m *JobRequeued) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReprioritiseJobSet) Unmarshal(dAtA []byte) error {
+func (m *JobRunAssigned) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11427,17 +15241,17 @@ func (m *ReprioritiseJobSet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReprioritiseJobSet: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobRunAssigned: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReprioritiseJobSet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobRunAssigned: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
 			}
-			m.Priority = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -11447,62 +15261,29 @@ func (m *ReprioritiseJobSet) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Priority |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ReprioritisedJob) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.RunId == nil {
+				m.RunId = &Uuid{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ReprioritisedJob: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReprioritisedJob: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
@@ -11538,11 +15319,11 @@ func (m *ReprioritisedJob) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceInfos", wireType)
 			}
-			m.Priority = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -11552,11 +15333,26 @@ func (m *ReprioritisedJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Priority |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceInfos = append(m.ResourceInfos, &KubernetesResourceInfo{})
+			if err := m.ResourceInfos[len(m.ResourceInfos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -11578,7 +15374,7 @@ func (m *ReprioritisedJob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CancelJob) Unmarshal(dAtA []byte) error {
+func (m *JobRunRunning) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11601,15 +15397,15 @@ func (m *CancelJob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CancelJob: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobRunRunning: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CancelJob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobRunRunning: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11636,18 +15432,18 @@ func (m *CancelJob) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
+			if m.RunId == nil {
+				m.RunId = &Uuid{}
 			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -11657,143 +15453,62 @@ func (m *CancelJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Reason = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *JobSetFilter) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceInfos", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: JobSetFilter: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobSetFilter: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType == 0 {
-				var v JobState
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= JobState(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.States = append(m.States, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthEvents
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthEvents
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				if elementCount != 0 && len(m.States) == 0 {
-					m.States = make([]JobState, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v JobState
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= JobState(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.States = append(m.States, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field States", wireType)
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceInfos = append(m.ResourceInfos, &KubernetesResourceInfo{})
+			if err := m.ResourceInfos[len(m.ResourceInfos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -11815,7 +15530,7 @@ func (m *JobSetFilter) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CancelJobSet) Unmarshal(dAtA []byte) error {
+func (m *KubernetesResourceInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11838,86 +15553,88 @@ func (m *CancelJobSet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CancelJobSet: wiretype end group for non-group")
+			return fmt.Errorf("proto: KubernetesResourceInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CancelJobSet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KubernetesResourceInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v JobState
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= JobState(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.States = append(m.States, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthEvents
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthEvents
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
 				}
-				var elementCount int
-				if elementCount != 0 && len(m.States) == 0 {
-					m.States = make([]JobState, 0, elementCount)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				for iNdEx < postIndex {
-					var v JobState
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= JobState(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.States = append(m.States, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field States", wireType)
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PodInfo{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Info = &KubernetesResourceInfo_PodInfo{v}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IngressInfo", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -11927,23 +15644,26 @@ func (m *CancelJobSet) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Reason = string(dAtA[iNdEx:postIndex])
+			v := &IngressInfo{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Info = &KubernetesResourceInfo_IngressInfo{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11966,7 +15686,7 @@ func (m *CancelJobSet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CancelledJob) Unmarshal(dAtA []byte) error {
+func (m *PodInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11989,17 +15709,17 @@ func (m *CancelledJob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CancelledJob: wiretype end group for non-group")
+			return fmt.Errorf("proto: PodInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CancelledJob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PodInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -12009,33 +15729,29 @@ func (m *CancelledJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NodeName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
 			}
-			var stringLen uint64
+			m.PodNumber = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -12045,24 +15761,11 @@ func (m *CancelledJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.PodNumber |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Reason = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -12084,7 +15787,7 @@ func (m *CancelledJob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobSucceeded) Unmarshal(dAtA []byte) error {
+func (m *IngressInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12107,17 +15810,17 @@ func (m *JobSucceeded) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobSucceeded: wiretype end group for non-group")
+			return fmt.Errorf("proto: IngressInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobSucceeded: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IngressInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -12127,31 +15830,27 @@ func (m *JobSucceeded) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NodeName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceInfos", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IngressAddresses", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12168,20 +15867,99 @@ func (m *JobSucceeded) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ResourceInfos = append(m.ResourceInfos, &KubernetesResourceInfo{})
-			if err := m.ResourceInfos[len(m.ResourceInfos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.IngressAddresses == nil {
+				m.IngressAddresses = make(map[int32]string)
+			}
+			var mapkey int32
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEvents
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEvents
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthEvents
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipEvents(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthEvents
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.IngressAddresses[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12204,7 +15982,7 @@ func (m *JobSucceeded) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
+func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12227,10 +16005,10 @@ func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobRunLeased: wiretype end group for non-group")
+			return fmt.Errorf("proto: StandaloneIngressInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobRunLeased: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StandaloneIngressInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12307,9 +16085,9 @@ func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -12319,117 +16097,31 @@ func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExecutorId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.NodeId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdateSequenceNumber", wireType)
-			}
-			m.UpdateSequenceNumber = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.UpdateSequenceNumber |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HasScheduledAtPriority", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.HasScheduledAtPriority = bool(v != 0)
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledAtPriority", wireType)
-			}
-			m.ScheduledAtPriority = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ScheduledAtPriority |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 8:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AdditionalAnnotations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IngressAddresses", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12456,10 +16148,10 @@ func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AdditionalAnnotations == nil {
-				m.AdditionalAnnotations = make(map[string]string)
+			if m.IngressAddresses == nil {
+				m.IngressAddresses = make(map[int32]string)
 			}
-			var mapkey string
+			var mapkey int32
 			var mapvalue string
 			for iNdEx < postIndex {
 				entryPreIndex := iNdEx
@@ -12480,7 +16172,6 @@ func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
 				}
 				fieldNum := int32(wire >> 3)
 				if fieldNum == 1 {
-					var stringLenmapkey uint64
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowEvents
@@ -12490,24 +16181,11 @@ func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
+						mapkey |= int32(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
 					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
 				} else if fieldNum == 2 {
 					var stringLenmapvalue uint64
 					for shift := uint(0); ; shift += 7 {
@@ -12552,63 +16230,13 @@ func (m *JobRunLeased) Unmarshal(dAtA []byte) error {
 					iNdEx += skippy
 				}
 			}
-			m.AdditionalAnnotations[mapkey] = mapvalue
+			m.IngressAddresses[mapkey] = mapvalue
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *JobRunAssigned) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: JobRunAssigned: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobRunAssigned: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -12618,33 +16246,48 @@ func (m *JobRunAssigned) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RunId == nil {
-				m.RunId = &Uuid{}
+			m.NodeName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
 			}
-			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.PodNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PodNumber |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PodName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -12654,33 +16297,29 @@ func (m *JobRunAssigned) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.PodName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceInfos", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PodNamespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -12690,25 +16329,23 @@ func (m *JobRunAssigned) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ResourceInfos = append(m.ResourceInfos, &KubernetesResourceInfo{})
-			if err := m.ResourceInfos[len(m.ResourceInfos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.PodNamespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12731,7 +16368,7 @@ func (m *JobRunAssigned) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobRunRunning) Unmarshal(dAtA []byte) error {
+func (m *JobRunSucceeded) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12754,10 +16391,10 @@ func (m *JobRunRunning) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobRunRunning: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobRunSucceeded: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobRunRunning: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobRunSucceeded: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12887,7 +16524,7 @@ func (m *JobRunRunning) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KubernetesResourceInfo) Unmarshal(dAtA []byte) error {
+func (m *JobErrors) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12910,15 +16547,15 @@ func (m *KubernetesResourceInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KubernetesResourceInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobErrors: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KubernetesResourceInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobErrors: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12945,51 +16582,16 @@ func (m *KubernetesResourceInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
 			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodInfo", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &PodInfo{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Info = &KubernetesResourceInfo_PodInfo{v}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IngressInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13016,11 +16618,10 @@ func (m *KubernetesResourceInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &IngressInfo{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Errors = append(m.Errors, &Error{})
+			if err := m.Errors[len(m.Errors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Info = &KubernetesResourceInfo_IngressInfo{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13043,7 +16644,7 @@ func (m *KubernetesResourceInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PodInfo) Unmarshal(dAtA []byte) error {
+func (m *JobRunErrors) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13066,17 +16667,17 @@ func (m *PodInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PodInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobRunErrors: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PodInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobRunErrors: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -13086,98 +16687,33 @@ func (m *PodInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
-			}
-			m.PodNumber = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.PodNumber |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if m.RunId == nil {
+				m.RunId = &Uuid{}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *IngressInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: IngressInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IngressInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -13187,136 +16723,61 @@ func (m *IngressInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeName = string(dAtA[iNdEx:postIndex])
+			if m.JobId == nil {
+				m.JobId = &Uuid{}
+			}
+			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IngressAddresses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.IngressAddresses == nil {
-				m.IngressAddresses = make(map[int32]string)
-			}
-			var mapkey int32
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapkey |= int32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipEvents(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.IngressAddresses[mapkey] = mapvalue
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Errors = append(m.Errors, &Error{})
+			if err := m.Errors[len(m.Errors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13339,7 +16800,7 @@ func (m *IngressInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
+func (m *Error) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13362,15 +16823,35 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StandaloneIngressInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: Error: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StandaloneIngressInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Terminal", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Terminal = bool(v != 0)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KubernetesError", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13397,16 +16878,15 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RunId == nil {
-				m.RunId = &Uuid{}
-			}
-			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &KubernetesError{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Reason = &Error_KubernetesError{v}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerError", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13433,16 +16913,15 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &ContainerError{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Reason = &Error_ContainerError{v}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorError", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13469,16 +16948,15 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
-			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &ExecutorError{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Reason = &Error_ExecutorError{v}
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IngressAddresses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PodUnschedulable", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13505,95 +16983,52 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.IngressAddresses == nil {
-				m.IngressAddresses = make(map[int32]string)
+			v := &PodUnschedulable{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			var mapkey int32
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowEvents
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			m.Reason = &Error_PodUnschedulable{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseExpired", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapkey |= int32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowEvents
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthEvents
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipEvents(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthEvents
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.IngressAddresses[mapkey] = mapvalue
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LeaseExpired{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Reason = &Error_LeaseExpired{v}
 			iNdEx = postIndex
-		case 5:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRunsExceeded", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -13603,29 +17038,32 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeName = string(dAtA[iNdEx:postIndex])
+			v := &MaxRunsExceeded{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Reason = &Error_MaxRunsExceeded{v}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodError", wireType)
 			}
-			m.PodNumber = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -13635,16 +17073,32 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PodNumber |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PodError{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Reason = &Error_PodError{v}
+			iNdEx = postIndex
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PodLeaseReturned", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -13654,29 +17108,32 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PodName = string(dAtA[iNdEx:postIndex])
+			v := &PodLeaseReturned{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Reason = &Error_PodLeaseReturned{v}
 			iNdEx = postIndex
-		case 8:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodNamespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PodTerminated", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -13686,77 +17143,65 @@ func (m *StandaloneIngressInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PodNamespace = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
+			v := &PodTerminated{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
+			m.Reason = &Error_PodTerminated{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobRunPreemptedError", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *JobRunSucceeded) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			v := &JobRunPreemptedError{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: JobRunSucceeded: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobRunSucceeded: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Reason = &Error_JobRunPreemptedError{v}
+			iNdEx = postIndex
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GangJobUnschedulable", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13783,16 +17228,15 @@ func (m *JobRunSucceeded) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RunId == nil {
-				m.RunId = &Uuid{}
-			}
-			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &GangJobUnschedulable{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Reason = &Error_GangJobUnschedulable{v}
 			iNdEx = postIndex
-		case 2:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueDoesNotExist", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13819,16 +17263,15 @@ func (m *JobRunSucceeded) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &QueueDoesNotExist{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Reason = &Error_QueueDoesNotExist{v}
 			iNdEx = postIndex
-		case 3:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceInfos", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueBacklogLimitReached", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13855,64 +17298,47 @@ func (m *JobRunSucceeded) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ResourceInfos = append(m.ResourceInfos, &KubernetesResourceInfo{})
-			if err := m.ResourceInfos[len(m.ResourceInfos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &QueueBacklogLimitReached{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Reason = &Error_QueueBacklogLimitReached{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Classification", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *JobErrors) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: JobErrors: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobErrors: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Classification = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobExceedsLargestNode", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13939,16 +17365,15 @@ func (m *JobErrors) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &JobExceedsLargestNode{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Reason = &Error_JobExceedsLargestNode{v}
 			iNdEx = postIndex
-		case 2:
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobForceFailed", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13975,10 +17400,11 @@ func (m *JobErrors) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Errors = append(m.Errors, &Error{})
-			if err := m.Errors[len(m.Errors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &JobForceFailed{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Reason = &Error_JobForceFailed{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -14001,7 +17427,7 @@ func (m *JobErrors) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobRunErrors) Unmarshal(dAtA []byte) error {
+func (m *KubernetesError) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14024,17 +17450,17 @@ func (m *JobRunErrors) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobRunErrors: wiretype end group for non-group")
+			return fmt.Errorf("proto: KubernetesError: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobRunErrors: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KubernetesError: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RunId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceType", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14044,31 +17470,27 @@ func (m *JobRunErrors) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RunId == nil {
-				m.RunId = &Uuid{}
-			}
-			if err := m.RunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ResourceType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14095,44 +17517,10 @@ func (m *JobRunErrors) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.JobId == nil {
-				m.JobId = &Uuid{}
-			}
-			if err := m.JobId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if m.ResourceId == nil {
+				m.ResourceId = &Uuid{}
 			}
-			m.Errors = append(m.Errors, &Error{})
-			if err := m.Errors[len(m.Errors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ResourceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -14157,7 +17545,7 @@ func (m *JobRunErrors) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Error) Unmarshal(dAtA []byte) error {
+func (m *PodError) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14180,17 +17568,17 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Error: wiretype end group for non-group")
+			return fmt.Errorf("proto: PodError: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PodError: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Terminal", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14200,17 +17588,33 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Terminal = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KubernetesError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14220,32 +17624,29 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &KubernetesError{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Reason = &Error_KubernetesError{v}
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14255,30 +17656,46 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ContainerError{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Reason = &Error_ContainerError{v}
+			m.NodeName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
+			}
+			m.PodNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PodNumber |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerErrors", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14305,17 +17722,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ExecutorError{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ContainerErrors = append(m.ContainerErrors, &ContainerError{})
+			if err := m.ContainerErrors[len(m.ContainerErrors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Reason = &Error_ExecutorError{v}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodUnschedulable", wireType)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KubernetesReason", wireType)
 			}
-			var msglen int
+			m.KubernetesReason = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14325,30 +17741,64 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.KubernetesReason |= KubernetesReason(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthEvents
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PodUnschedulable{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContainerError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
 			}
-			m.Reason = &Error_PodUnschedulable{v}
-			iNdEx = postIndex
-		case 6:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContainerError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContainerError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaseExpired", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14375,17 +17825,37 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &LeaseExpired{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Reason = &Error_LeaseExpired{v}
-			iNdEx = postIndex
-		case 7:
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExitCode", wireType)
+			}
+			m.ExitCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExitCode |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxRunsExceeded", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14395,32 +17865,29 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &MaxRunsExceeded{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Reason = &Error_MaxRunsExceeded{v}
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14430,32 +17897,29 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PodError{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Reason = &Error_PodError{v}
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodLeaseReturned", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KubernetesReason", wireType)
 			}
-			var msglen int
+			m.KubernetesReason = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14465,30 +17929,64 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.KubernetesReason |= KubernetesReason(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthEvents
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PodLeaseReturned{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PodLeaseReturned) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
 			}
-			m.Reason = &Error_PodLeaseReturned{v}
-			iNdEx = postIndex
-		case 10:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PodLeaseReturned: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PodLeaseReturned: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodTerminated", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14515,17 +18013,18 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PodTerminated{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Reason = &Error_PodTerminated{v}
 			iNdEx = postIndex
-		case 11:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobRunPreemptedError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14535,32 +18034,29 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &JobRunPreemptedError{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Reason = &Error_JobRunPreemptedError{v}
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GangJobUnschedulable", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
 			}
-			var msglen int
+			m.PodNumber = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14570,27 +18066,51 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.PodNumber |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunAttempted", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.RunAttempted = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseNeverAcknowledged", wireType)
 			}
-			v := &GangJobUnschedulable{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Reason = &Error_GangJobUnschedulable{v}
-			iNdEx = postIndex
+			m.LeaseNeverAcknowledged = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -14612,7 +18132,7 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KubernetesError) Unmarshal(dAtA []byte) error {
+func (m *PodTerminated) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14635,15 +18155,83 @@ func (m *KubernetesError) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KubernetesError: wiretype end group for non-group")
+			return fmt.Errorf("proto: PodTerminated: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KubernetesError: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PodTerminated: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ObjectMeta == nil {
+				m.ObjectMeta = &ObjectMeta{}
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -14671,13 +18259,13 @@ func (m *KubernetesError) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ResourceType = string(dAtA[iNdEx:postIndex])
+			m.NodeName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceId", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
 			}
-			var msglen int
+			m.PodNumber = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14687,28 +18275,61 @@ func (m *KubernetesError) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.PodNumber |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthEvents
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ResourceId == nil {
-				m.ResourceId = &Uuid{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExecutorError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
 			}
-			if err := m.ResourceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExecutorError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExecutorError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -14730,7 +18351,7 @@ func (m *KubernetesError) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PodError) Unmarshal(dAtA []byte) error {
+func (m *PodUnschedulable) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14753,10 +18374,10 @@ func (m *PodError) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PodError: wiretype end group for non-group")
+			return fmt.Errorf("proto: PodUnschedulable: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PodError: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PodUnschedulable: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -14878,11 +18499,111 @@ func (m *PodError) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseExpired) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseExpired: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseExpired: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaxRunsExceeded) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaxRunsExceeded: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaxRunsExceeded: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerErrors", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -14892,45 +18613,74 @@ func (m *PodError) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerErrors = append(m.ContainerErrors, &ContainerError{})
-			if err := m.ContainerErrors[len(m.ContainerErrors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KubernetesReason", wireType)
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
 			}
-			m.KubernetesReason = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.KubernetesReason |= KubernetesReason(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobRunPreemptedError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobRunPreemptedError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobRunPreemptedError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -14952,7 +18702,7 @@ func (m *PodError) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ContainerError) Unmarshal(dAtA []byte) error {
+func (m *GangJobUnschedulable) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14975,68 +18725,13 @@ func (m *ContainerError) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContainerError: wiretype end group for non-group")
+			return fmt.Errorf("proto: GangJobUnschedulable: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContainerError: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GangJobUnschedulable: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
-			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExitCode", wireType)
-			}
-			m.ExitCode = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ExitCode |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
@@ -15068,57 +18763,6 @@ func (m *ContainerError) Unmarshal(dAtA []byte) error {
 			}
 			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Reason = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KubernetesReason", wireType)
-			}
-			m.KubernetesReason = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.KubernetesReason |= KubernetesReason(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -15140,7 +18784,7 @@ func (m *ContainerError) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PodLeaseReturned) Unmarshal(dAtA []byte) error {
+func (m *QueueDoesNotExist) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15163,17 +18807,17 @@ func (m *PodLeaseReturned) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PodLeaseReturned: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueueDoesNotExist: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PodLeaseReturned: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueueDoesNotExist: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Queue", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -15183,27 +18827,23 @@ func (m *PodLeaseReturned) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
-			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Queue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
@@ -15237,45 +18877,6 @@ func (m *PodLeaseReturned) Unmarshal(dAtA []byte) error {
 			}
 			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
-			}
-			m.PodNumber = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.PodNumber |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RunAttempted", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.RunAttempted = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -15297,7 +18898,7 @@ func (m *PodLeaseReturned) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PodTerminated) Unmarshal(dAtA []byte) error {
+func (m *QueueBacklogLimitReached) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15320,51 +18921,15 @@ func (m *PodTerminated) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PodTerminated: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueueBacklogLimitReached: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PodTerminated: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueueBacklogLimitReached: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEvents
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
-			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Queue", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15392,13 +18957,13 @@ func (m *PodTerminated) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Queue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var stringLen uint64
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -15408,29 +18973,16 @@ func (m *PodTerminated) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Limit |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEvents
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.NodeName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			m.PodNumber = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -15440,61 +18992,24 @@ func (m *PodTerminated) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PodNumber |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ExecutorError) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ExecutorError: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExecutorError: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -15516,7 +19031,7 @@ func (m *ExecutorError) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PodUnschedulable) Unmarshal(dAtA []byte) error {
+func (m *JobExceedsLargestNode) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15539,17 +19054,17 @@ func (m *PodUnschedulable) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PodUnschedulable: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobExceedsLargestNode: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PodUnschedulable: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobExceedsLargestNode: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -15559,31 +19074,27 @@ func (m *PodUnschedulable) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ObjectMeta == nil {
-				m.ObjectMeta = &ObjectMeta{}
-			}
-			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Resource = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Requested", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15611,11 +19122,11 @@ func (m *PodUnschedulable) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Requested = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Largest", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15643,13 +19154,13 @@ func (m *PodUnschedulable) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeName = string(dAtA[iNdEx:postIndex])
+			m.Largest = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PodNumber", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			m.PodNumber = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEvents
@@ -15659,61 +19170,24 @@ func (m *PodUnschedulable) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PodNumber |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LeaseExpired) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LeaseExpired: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaseExpired: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -15735,7 +19209,7 @@ func (m *LeaseExpired) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaxRunsExceeded) Unmarshal(dAtA []byte) error {
+func (m *JobForceFailed) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15758,15 +19232,15 @@ func (m *MaxRunsExceeded) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaxRunsExceeded: wiretype end group for non-group")
+			return fmt.Errorf("proto: JobForceFailed: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaxRunsExceeded: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: JobForceFailed: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15794,109 +19268,41 @@ func (m *MaxRunsExceeded) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *JobRunPreemptedError) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Principal", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: JobRunPreemptedError: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobRunPreemptedError: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEvents(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GangJobUnschedulable) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEvents
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GangJobUnschedulable: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GangJobUnschedulable: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Principal = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
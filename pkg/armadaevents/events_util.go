@@ -129,6 +129,12 @@ func (ev *EventSequence_Event) UnmarshalJSON(data []byte) error {
 				return err
 			}
 			ev.Event = &jobRunRunning
+		case "jobRunCancelled":
+			var jobRunCancelled EventSequence_Event_JobRunCancelled
+			if err = json.Unmarshal(rawEvent.EventBytes, &jobRunCancelled); err != nil {
+				return err
+			}
+			ev.Event = &jobRunCancelled
 		case "jobRunSucceeded":
 			var jobRunSucceeded EventSequence_Event_JobRunSucceeded
 			if err = json.Unmarshal(rawEvent.EventBytes, &jobRunSucceeded); err != nil {
@@ -165,6 +171,18 @@ func (ev *EventSequence_Event) UnmarshalJSON(data []byte) error {
 				return err
 			}
 			ev.Event = &jobRunPreempted
+		case "jobValidated":
+			var jobValidated EventSequence_Event_JobValidated
+			if err = json.Unmarshal(rawEvent.EventBytes, &jobValidated); err != nil {
+				return err
+			}
+			ev.Event = &jobValidated
+		case "jobRetriesExhausted":
+			var jobRetriesExhausted EventSequence_Event_JobRetriesExhausted
+			if err = json.Unmarshal(rawEvent.EventBytes, &jobRetriesExhausted); err != nil {
+				return err
+			}
+			ev.Event = &jobRetriesExhausted
 		default:
 			return errors.New("could not determine EventSequence_Event.Event type for unmarshaling")
 		}
@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+// PreemptionPolicy determines whether running jobs of a queue may be preempted.
+type PreemptionPolicy string
+
+const (
+	// Jobs are never selected as preemption victims.
+	PreemptionPolicyNever PreemptionPolicy = "never"
+	// Jobs are preempted according to the fair share and priority class of the queue.
+	PreemptionPolicyStandard PreemptionPolicy = "standard"
+	// Jobs are preferred preemption victims, and are preempted even if the queue is below its protected fraction of fair share.
+	PreemptionPolicyAggressive PreemptionPolicy = "aggressive"
+)
+
+// NewPreemptionPolicy returns PreemptionPolicy from input string. An empty string maps to PreemptionPolicyStandard.
+// If input string doesn't match one of the allowed values ["never", "standard", "aggressive"] error is returned.
+func NewPreemptionPolicy(in string) (PreemptionPolicy, error) {
+	if in == "" {
+		return PreemptionPolicyStandard, nil
+	}
+	switch policy := PreemptionPolicy(in); policy {
+	case PreemptionPolicyNever, PreemptionPolicyStandard, PreemptionPolicyAggressive:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid preemption policy value: %s", in)
+	}
+}
+
+// UnmarshalJSON is implementation of https://pkg.go.dev/encoding/json#Unmarshaler interface.
+func (policy *PreemptionPolicy) UnmarshalJSON(data []byte) error {
+	preemptionPolicy := ""
+	if err := json.Unmarshal(data, &preemptionPolicy); err != nil {
+		return err
+	}
+
+	out, err := NewPreemptionPolicy(preemptionPolicy)
+	if err != nil {
+		return fmt.Errorf("invalid queue preemption policy. %s", err)
+	}
+
+	*policy = out
+	return nil
+}
+
+// Generate is implementation of https://pkg.go.dev/testing/quick#Generator interface.
+// This method is used for writing tests usign https://pkg.go.dev/testing/quick package
+func (policy PreemptionPolicy) Generate(rand *rand.Rand, size int) reflect.Value {
+	values := []PreemptionPolicy{
+		PreemptionPolicyNever,
+		PreemptionPolicyStandard,
+		PreemptionPolicyAggressive,
+	}
+
+	return reflect.ValueOf(values[rand.Intn(len(values))])
+}
@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPreemptionPolicyUnmarshal(t *testing.T) {
+	tests := map[string]struct {
+		Policies []PreemptionPolicy
+		Fail     bool
+	}{
+		"ValidPolicy": {
+			Policies: []PreemptionPolicy{
+				PreemptionPolicyNever,
+				PreemptionPolicyStandard,
+				PreemptionPolicyAggressive,
+			},
+			Fail: false,
+		},
+		"InvalidPolicy": {
+			Policies: []PreemptionPolicy{
+				"random_policy1",
+				"Never",
+			},
+			Fail: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(subT *testing.T) {
+			for _, policy := range test.Policies {
+				data, err := json.Marshal(policy)
+				if err != nil {
+					t.Errorf("failed to marshal preemption policy %s to json: %s", policy, err)
+				}
+				result := PreemptionPolicy("")
+
+				err = json.Unmarshal(data, &result)
+				if test.Fail && err == nil {
+					t.Fatalf("failed to throw an error on invalid preemption policy string %s", policy)
+				}
+				if !test.Fail && err != nil {
+					t.Fatalf("failed to unmarshal valid preemption policy %s. %s", policy, err)
+				}
+				if !test.Fail && result != policy {
+					t.Fatalf("unmarshalled preemption policy %s, expected %s", result, policy)
+				}
+			}
+		})
+	}
+}
+
+func TestNewPreemptionPolicyDefaultsToStandard(t *testing.T) {
+	policy, err := NewPreemptionPolicy("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy != PreemptionPolicyStandard {
+		t.Fatalf("expected %s, got %s", PreemptionPolicyStandard, policy)
+	}
+}
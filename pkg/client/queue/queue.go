@@ -11,6 +11,10 @@ type Queue struct {
 	Permissions    []Permissions  `json:"permissions"`
 	PriorityFactor PriorityFactor `json:"priorityFactor"`
 	ResourceLimits ResourceLimits `json:"resourceLimits"`
+	MaxQueuedJobs  uint32         `json:"maxQueuedJobs"`
+	MaxJobPriority uint32         `json:"maxJobPriority"`
+	// If empty, PreemptionPolicyStandard applies.
+	PreemptionPolicy PreemptionPolicy `json:"preemptionPolicy,omitempty"`
 }
 
 // NewQueue returnes new Queue using the in parameter. Error is returned if
@@ -30,6 +34,11 @@ func NewQueue(in *api.Queue) (Queue, error) {
 		return Queue{}, fmt.Errorf("failed to map resource limits: %v. %s", in.ResourceLimits, err)
 	}
 
+	preemptionPolicy, err := NewPreemptionPolicy(in.PreemptionPolicy)
+	if err != nil {
+		return Queue{}, fmt.Errorf("failed to map preemption policy. %s", err)
+	}
+
 	permissions := []Permissions{}
 	if len(in.GroupOwners) != 0 || len(in.UserOwners) != 0 {
 		permissions = append(permissions, NewPermissionsFromOwners(in.UserOwners, in.GroupOwners))
@@ -46,9 +55,12 @@ func NewQueue(in *api.Queue) (Queue, error) {
 	return Queue{
 		Name: in.Name,
 		// Kind:           "Queue",
-		PriorityFactor: priorityFactor,
-		ResourceLimits: resourceLimits,
-		Permissions:    permissions,
+		PriorityFactor:   priorityFactor,
+		ResourceLimits:   resourceLimits,
+		Permissions:      permissions,
+		MaxQueuedJobs:    in.MaxQueuedJobs,
+		MaxJobPriority:   in.MaxJobPriority,
+		PreemptionPolicy: preemptionPolicy,
 	}, nil
 }
 
@@ -57,8 +69,11 @@ func (q Queue) ToAPI() *api.Queue {
 	result := &api.Queue{
 		Name: q.Name,
 		// Kind:           q.Kind,
-		PriorityFactor: float64(q.PriorityFactor),
-		ResourceLimits: map[string]float64{},
+		PriorityFactor:   float64(q.PriorityFactor),
+		ResourceLimits:   map[string]float64{},
+		MaxQueuedJobs:    q.MaxQueuedJobs,
+		MaxJobPriority:   q.MaxJobPriority,
+		PreemptionPolicy: string(q.PreemptionPolicy),
 	}
 
 	for resourceName, resourceLimit := range q.ResourceLimits {
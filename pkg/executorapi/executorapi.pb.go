@@ -5,16 +5,19 @@ package executorapi
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	io "io"
 	math "math"
 	math_bits "math/bits"
 	reflect "reflect"
 	strings "strings"
+	time "time"
 
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	types "github.com/gogo/protobuf/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -29,6 +32,7 @@ import (
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -97,6 +101,33 @@ type LeaseRequest struct {
 	UnassignedJobRunIds []armadaevents.Uuid `protobuf:"bytes,6,rep,name=unassigned_job_run_ids,json=unassignedJobRunIds,proto3" json:"unassignedJobRunIds"`
 	// Max number of jobs this request should return
 	MaxJobsToLease uint32 `protobuf:"varint,7,opt,name=max_jobs_to_lease,json=maxJobsToLease,proto3" json:"maxJobsToLease,omitempty"`
+	// If non-zero, how long the scheduler should wait for a heartbeat from this executor before considering it stale,
+	// overriding the scheduler's default executor timeout.
+	ExecutorTimeout time.Duration `protobuf:"bytes,8,opt,name=executor_timeout,json=executorTimeout,proto3,stdduration" json:"executorTimeout"`
+	// Hash of the spec of each run the executor holds, as provided by the JobRunLease the run was created from.
+	// The scheduler re-sends the lease of any active run the executor holds for which the hash differs from that of
+	// the run's current spec. Leases of runs for which no hash is included are only sent if the executor doesn't hold them.
+	JobRunSpecHashes []*JobRunSpecHash `protobuf:"bytes,9,rep,name=job_run_spec_hashes,json=jobRunSpecHashes,proto3" json:"jobRunSpecHashes,omitempty"`
+	// Actual resource usage of the runs the executor holds, if the executor is configured to report it.
+	// Usage is advisory; runs for which no usage is reported are treated as if their usage is unknown.
+	JobRunResourceUsage []*JobRunResourceUsage `protobuf:"bytes,10,rep,name=job_run_resource_usage,json=jobRunResourceUsage,proto3" json:"jobRunResourceUsage,omitempty"`
+	// Time at which the executor sent this request, according to the executor's clock.
+	// Used by the scheduler to measure the skew between its clock and that of the executor.
+	SentAt *time.Time `protobuf:"bytes,11,opt,name=sent_at,json=sentAt,proto3,stdtime" json:"sentAt,omitempty"`
+	// If true, nodes contains only the nodes added or changed since the previous request,
+	// which the scheduler must have accepted node deltas in response to; see EndMarker.accepts_node_deltas.
+	// Otherwise, nodes contains all nodes of the executor.
+	NodesDelta bool `protobuf:"varint,12,opt,name=nodes_delta,json=nodesDelta,proto3" json:"nodesDelta,omitempty"`
+	// Names of the nodes removed since the previous request. Only set if nodes_delta is true.
+	RemovedNodeNames []string `protobuf:"bytes,13,rep,name=removed_node_names,json=removedNodeNames,proto3" json:"removedNodeNames,omitempty"`
+	// Hash of all nodes of the executor as of this request, as computed by NodesHash. Only set if nodes_delta is true.
+	// If it doesn't match the hash of the nodes stored by the scheduler once the delta is applied to them,
+	// the delta is discarded and the executor is asked to report all of its nodes instead.
+	NodesHash []byte `protobuf:"bytes,14,opt,name=nodes_hash,json=nodesHash,proto3" json:"nodesHash,omitempty"`
+	// Identifies this request among those of the executor. Increases with each request, except that a request retried
+	// because no response to it was received has the same id, such that the scheduler can answer it with the response
+	// it already sent. If zero, the request is never answered with a previous response.
+	RequestId uint64 `protobuf:"varint,15,opt,name=request_id,json=requestId,proto3" json:"requestId,omitempty"`
 }
 
 func (m *LeaseRequest) Reset()      { *m = LeaseRequest{} }
@@ -180,6 +211,174 @@ func (m *LeaseRequest) GetMaxJobsToLease() uint32 {
 	return 0
 }
 
+func (m *LeaseRequest) GetExecutorTimeout() time.Duration {
+	if m != nil {
+		return m.ExecutorTimeout
+	}
+	return 0
+}
+
+func (m *LeaseRequest) GetJobRunSpecHashes() []*JobRunSpecHash {
+	if m != nil {
+		return m.JobRunSpecHashes
+	}
+	return nil
+}
+
+func (m *LeaseRequest) GetJobRunResourceUsage() []*JobRunResourceUsage {
+	if m != nil {
+		return m.JobRunResourceUsage
+	}
+	return nil
+}
+
+func (m *LeaseRequest) GetSentAt() *time.Time {
+	if m != nil {
+		return m.SentAt
+	}
+	return nil
+}
+
+func (m *LeaseRequest) GetNodesDelta() bool {
+	if m != nil {
+		return m.NodesDelta
+	}
+	return false
+}
+
+func (m *LeaseRequest) GetRemovedNodeNames() []string {
+	if m != nil {
+		return m.RemovedNodeNames
+	}
+	return nil
+}
+
+func (m *LeaseRequest) GetNodesHash() []byte {
+	if m != nil {
+		return m.NodesHash
+	}
+	return nil
+}
+
+func (m *LeaseRequest) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+type JobRunSpecHash struct {
+	JobRunId *armadaevents.Uuid `protobuf:"bytes,1,opt,name=job_run_id,json=jobRunId,proto3" json:"jobRunId,omitempty"`
+	SpecHash []byte             `protobuf:"bytes,2,opt,name=spec_hash,json=specHash,proto3" json:"specHash,omitempty"`
+}
+
+func (m *JobRunSpecHash) Reset()      { *m = JobRunSpecHash{} }
+func (*JobRunSpecHash) ProtoMessage() {}
+func (*JobRunSpecHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_57e0d9d0e484e459, []int{2}
+}
+func (m *JobRunSpecHash) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobRunSpecHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobRunSpecHash.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobRunSpecHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRunSpecHash.Merge(m, src)
+}
+func (m *JobRunSpecHash) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobRunSpecHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRunSpecHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobRunSpecHash proto.InternalMessageInfo
+
+func (m *JobRunSpecHash) GetJobRunId() *armadaevents.Uuid {
+	if m != nil {
+		return m.JobRunId
+	}
+	return nil
+}
+
+func (m *JobRunSpecHash) GetSpecHash() []byte {
+	if m != nil {
+		return m.SpecHash
+	}
+	return nil
+}
+
+type JobRunResourceUsage struct {
+	JobRunId *armadaevents.Uuid `protobuf:"bytes,1,opt,name=job_run_id,json=jobRunId,proto3" json:"jobRunId,omitempty"`
+	// Total CPU time consumed by the run so far.
+	CpuSeconds float64 `protobuf:"fixed64,2,opt,name=cpu_seconds,json=cpuSeconds,proto3" json:"cpuSeconds,omitempty"`
+	// Current working set memory of the run.
+	WorkingSetBytes int64 `protobuf:"varint,3,opt,name=working_set_bytes,json=workingSetBytes,proto3" json:"workingSetBytes,omitempty"`
+}
+
+func (m *JobRunResourceUsage) Reset()      { *m = JobRunResourceUsage{} }
+func (*JobRunResourceUsage) ProtoMessage() {}
+func (*JobRunResourceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_57e0d9d0e484e459, []int{3}
+}
+func (m *JobRunResourceUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobRunResourceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobRunResourceUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobRunResourceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRunResourceUsage.Merge(m, src)
+}
+func (m *JobRunResourceUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobRunResourceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRunResourceUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobRunResourceUsage proto.InternalMessageInfo
+
+func (m *JobRunResourceUsage) GetJobRunId() *armadaevents.Uuid {
+	if m != nil {
+		return m.JobRunId
+	}
+	return nil
+}
+
+func (m *JobRunResourceUsage) GetCpuSeconds() float64 {
+	if m != nil {
+		return m.CpuSeconds
+	}
+	return 0
+}
+
+func (m *JobRunResourceUsage) GetWorkingSetBytes() int64 {
+	if m != nil {
+		return m.WorkingSetBytes
+	}
+	return 0
+}
+
 // Indicates that a job run is now leased.
 type JobRunLease struct {
 	JobRunId *armadaevents.Uuid      `protobuf:"bytes,1,opt,name=job_run_id,json=jobRunId,proto3" json:"jobRunId,omitempty"`
@@ -188,12 +387,14 @@ type JobRunLease struct {
 	User     string                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
 	Groups   []string                `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`
 	Job      *armadaevents.SubmitJob `protobuf:"bytes,6,opt,name=job,proto3" json:"job,omitempty"`
+	// Hash of the spec of this run; see LeaseRequest.job_run_spec_hashes.
+	SpecHash []byte `protobuf:"bytes,7,opt,name=spec_hash,json=specHash,proto3" json:"specHash,omitempty"`
 }
 
 func (m *JobRunLease) Reset()      { *m = JobRunLease{} }
 func (*JobRunLease) ProtoMessage() {}
 func (*JobRunLease) Descriptor() ([]byte, []int) {
-	return fileDescriptor_57e0d9d0e484e459, []int{2}
+	return fileDescriptor_57e0d9d0e484e459, []int{4}
 }
 func (m *JobRunLease) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -264,6 +465,13 @@ func (m *JobRunLease) GetJob() *armadaevents.SubmitJob {
 	return nil
 }
 
+func (m *JobRunLease) GetSpecHash() []byte {
+	if m != nil {
+		return m.SpecHash
+	}
+	return nil
+}
+
 // Indicates that the job runs with the given ids should be cancelled.
 type CancelRuns struct {
 	JobRunIdsToCancel []*armadaevents.Uuid `protobuf:"bytes,1,rep,name=job_run_ids_to_cancel,json=jobRunIdsToCancel,proto3" json:"jobRunIdsToCancel,omitempty"`
@@ -272,7 +480,7 @@ type CancelRuns struct {
 func (m *CancelRuns) Reset()      { *m = CancelRuns{} }
 func (*CancelRuns) ProtoMessage() {}
 func (*CancelRuns) Descriptor() ([]byte, []int) {
-	return fileDescriptor_57e0d9d0e484e459, []int{3}
+	return fileDescriptor_57e0d9d0e484e459, []int{5}
 }
 func (m *CancelRuns) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -316,7 +524,7 @@ type PreemptRuns struct {
 func (m *PreemptRuns) Reset()      { *m = PreemptRuns{} }
 func (*PreemptRuns) ProtoMessage() {}
 func (*PreemptRuns) Descriptor() ([]byte, []int) {
-	return fileDescriptor_57e0d9d0e484e459, []int{4}
+	return fileDescriptor_57e0d9d0e484e459, []int{6}
 }
 func (m *PreemptRuns) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -352,14 +560,74 @@ func (m *PreemptRuns) GetJobRunIdsToPreempt() []*armadaevents.Uuid {
 	return nil
 }
 
+// Indicates that the nodes with the given names have been quarantined by the scheduler,
+// since many runs failed on them, and should be tainted with a NoSchedule taint with the given key.
+// Nodes not listed are not quarantined.
+type QuarantineNodes struct {
+	NodeNames []string `protobuf:"bytes,1,rep,name=node_names,json=nodeNames,proto3" json:"nodeNames,omitempty"`
+	TaintKey  string   `protobuf:"bytes,2,opt,name=taint_key,json=taintKey,proto3" json:"taintKey,omitempty"`
+}
+
+func (m *QuarantineNodes) Reset()      { *m = QuarantineNodes{} }
+func (*QuarantineNodes) ProtoMessage() {}
+func (*QuarantineNodes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_57e0d9d0e484e459, []int{7}
+}
+func (m *QuarantineNodes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuarantineNodes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuarantineNodes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuarantineNodes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantineNodes.Merge(m, src)
+}
+func (m *QuarantineNodes) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuarantineNodes) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantineNodes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantineNodes proto.InternalMessageInfo
+
+func (m *QuarantineNodes) GetNodeNames() []string {
+	if m != nil {
+		return m.NodeNames
+	}
+	return nil
+}
+
+func (m *QuarantineNodes) GetTaintKey() string {
+	if m != nil {
+		return m.TaintKey
+	}
+	return ""
+}
+
 // Indicates the end of the lease stream.
 type EndMarker struct {
+	// If non-zero, the scheduler is not yet ready to lease new runs and the executor should retry after this duration.
+	RetryAfter time.Duration `protobuf:"bytes,1,opt,name=retry_after,json=retryAfter,proto3,stdduration" json:"retryAfter"`
+	// If true, the scheduler has stored the nodes of the executor as of the request, such that the executor may report
+	// only the nodes changed since in its next request; see LeaseRequest.nodes_delta.
+	// If false, the next request must contain all nodes of the executor.
+	AcceptsNodeDeltas bool `protobuf:"varint,2,opt,name=accepts_node_deltas,json=acceptsNodeDeltas,proto3" json:"acceptsNodeDeltas,omitempty"`
 }
 
 func (m *EndMarker) Reset()      { *m = EndMarker{} }
 func (*EndMarker) ProtoMessage() {}
 func (*EndMarker) Descriptor() ([]byte, []int) {
-	return fileDescriptor_57e0d9d0e484e459, []int{5}
+	return fileDescriptor_57e0d9d0e484e459, []int{8}
 }
 func (m *EndMarker) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -388,19 +656,34 @@ func (m *EndMarker) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_EndMarker proto.InternalMessageInfo
 
+func (m *EndMarker) GetRetryAfter() time.Duration {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return 0
+}
+
+func (m *EndMarker) GetAcceptsNodeDeltas() bool {
+	if m != nil {
+		return m.AcceptsNodeDeltas
+	}
+	return false
+}
+
 type LeaseStreamMessage struct {
 	// Types that are valid to be assigned to Event:
 	//	*LeaseStreamMessage_Lease
 	//	*LeaseStreamMessage_CancelRuns
 	//	*LeaseStreamMessage_End
 	//	*LeaseStreamMessage_PreemptRuns
+	//	*LeaseStreamMessage_QuarantineNodes
 	Event isLeaseStreamMessage_Event `protobuf_oneof:"event"`
 }
 
 func (m *LeaseStreamMessage) Reset()      { *m = LeaseStreamMessage{} }
 func (*LeaseStreamMessage) ProtoMessage() {}
 func (*LeaseStreamMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_57e0d9d0e484e459, []int{6}
+	return fileDescriptor_57e0d9d0e484e459, []int{9}
 }
 func (m *LeaseStreamMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -447,11 +730,15 @@ type LeaseStreamMessage_End struct {
 type LeaseStreamMessage_PreemptRuns struct {
 	PreemptRuns *PreemptRuns `protobuf:"bytes,4,opt,name=preempt_runs,json=preemptRuns,proto3,oneof" json:"preemptRuns,omitempty"`
 }
+type LeaseStreamMessage_QuarantineNodes struct {
+	QuarantineNodes *QuarantineNodes `protobuf:"bytes,5,opt,name=quarantine_nodes,json=quarantineNodes,proto3,oneof" json:"quarantineNodes,omitempty"`
+}
 
-func (*LeaseStreamMessage_Lease) isLeaseStreamMessage_Event()       {}
-func (*LeaseStreamMessage_CancelRuns) isLeaseStreamMessage_Event()  {}
-func (*LeaseStreamMessage_End) isLeaseStreamMessage_Event()         {}
-func (*LeaseStreamMessage_PreemptRuns) isLeaseStreamMessage_Event() {}
+func (*LeaseStreamMessage_Lease) isLeaseStreamMessage_Event()           {}
+func (*LeaseStreamMessage_CancelRuns) isLeaseStreamMessage_Event()      {}
+func (*LeaseStreamMessage_End) isLeaseStreamMessage_Event()             {}
+func (*LeaseStreamMessage_PreemptRuns) isLeaseStreamMessage_Event()     {}
+func (*LeaseStreamMessage_QuarantineNodes) isLeaseStreamMessage_Event() {}
 
 func (m *LeaseStreamMessage) GetEvent() isLeaseStreamMessage_Event {
 	if m != nil {
@@ -488,6 +775,13 @@ func (m *LeaseStreamMessage) GetPreemptRuns() *PreemptRuns {
 	return nil
 }
 
+func (m *LeaseStreamMessage) GetQuarantineNodes() *QuarantineNodes {
+	if x, ok := m.GetEvent().(*LeaseStreamMessage_QuarantineNodes); ok {
+		return x.QuarantineNodes
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*LeaseStreamMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -495,6 +789,7 @@ func (*LeaseStreamMessage) XXX_OneofWrappers() []interface{} {
 		(*LeaseStreamMessage_CancelRuns)(nil),
 		(*LeaseStreamMessage_End)(nil),
 		(*LeaseStreamMessage_PreemptRuns)(nil),
+		(*LeaseStreamMessage_QuarantineNodes)(nil),
 	}
 }
 
@@ -503,9 +798,12 @@ func init() {
 	proto.RegisterType((*LeaseRequest)(nil), "executorapi.LeaseRequest")
 	proto.RegisterMapType((map[string]resource.Quantity)(nil), "executorapi.LeaseRequest.MinimumJobSizeEntry")
 	proto.RegisterMapType((map[string]resource.Quantity)(nil), "executorapi.LeaseRequest.ResourcesEntry")
+	proto.RegisterType((*JobRunSpecHash)(nil), "executorapi.JobRunSpecHash")
+	proto.RegisterType((*JobRunResourceUsage)(nil), "executorapi.JobRunResourceUsage")
 	proto.RegisterType((*JobRunLease)(nil), "executorapi.JobRunLease")
 	proto.RegisterType((*CancelRuns)(nil), "executorapi.CancelRuns")
 	proto.RegisterType((*PreemptRuns)(nil), "executorapi.PreemptRuns")
+	proto.RegisterType((*QuarantineNodes)(nil), "executorapi.QuarantineNodes")
 	proto.RegisterType((*EndMarker)(nil), "executorapi.EndMarker")
 	proto.RegisterType((*LeaseStreamMessage)(nil), "executorapi.LeaseStreamMessage")
 }
@@ -513,72 +811,104 @@ func init() {
 func init() { proto.RegisterFile("pkg/executorapi/executorapi.proto", fileDescriptor_57e0d9d0e484e459) }
 
 var fileDescriptor_57e0d9d0e484e459 = []byte{
-	// 1028 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xcf, 0x73, 0xdb, 0xc4,
-	0x17, 0xb7, 0xe2, 0xd8, 0xfd, 0x66, 0x95, 0xe4, 0xdb, 0x6c, 0x5a, 0x57, 0x75, 0x8a, 0x15, 0xcc,
-	0x0c, 0x63, 0x66, 0x5a, 0x89, 0x09, 0x1c, 0x02, 0x03, 0xcc, 0x60, 0xc6, 0x43, 0x93, 0x69, 0x3a,
-	0x54, 0x09, 0x0c, 0xe5, 0xe2, 0x91, 0xac, 0x57, 0x45, 0xb6, 0xa5, 0x55, 0xb5, 0x52, 0x89, 0x7b,
-	0xe2, 0x4f, 0xe0, 0xc0, 0x85, 0x03, 0xff, 0x0d, 0x87, 0x1e, 0x3b, 0xc3, 0xa5, 0x5c, 0x34, 0x90,
-	0xdc, 0xf4, 0x57, 0x30, 0xbb, 0x2b, 0x45, 0xab, 0xc4, 0xe5, 0xcc, 0xc9, 0x7e, 0x3f, 0xf6, 0xf3,
-	0x7e, 0x7c, 0xde, 0xdb, 0x15, 0x7a, 0x37, 0x9a, 0x79, 0x26, 0x9c, 0xc1, 0x24, 0x4d, 0x48, 0x6c,
-	0x47, 0xbe, 0xfc, 0xdf, 0x88, 0x62, 0x92, 0x10, 0xac, 0x4a, 0xaa, 0xee, 0x3b, 0xcc, 0xdf, 0x8e,
-	0x03, 0xdb, 0xb5, 0xe1, 0x05, 0x84, 0x09, 0x35, 0xc5, 0x8f, 0xf0, 0xed, 0x6e, 0x73, 0x73, 0xe4,
-	0x9b, 0xcf, 0x53, 0x48, 0xa1, 0x50, 0xee, 0x78, 0x84, 0x78, 0x73, 0x30, 0xb9, 0xe4, 0xa4, 0xcf,
-	0x4c, 0x08, 0xa2, 0x64, 0x51, 0x18, 0x1f, 0x78, 0x7e, 0x72, 0x9a, 0x3a, 0xc6, 0x84, 0x04, 0xa6,
-	0x47, 0x3c, 0x52, 0x79, 0x31, 0x89, 0x0b, 0xfc, 0x5f, 0xe1, 0xfe, 0xf1, 0x6c, 0x9f, 0x1a, 0x3e,
-	0x61, 0x31, 0x02, 0x7b, 0x72, 0xea, 0x87, 0x10, 0x2f, 0xcc, 0x32, 0x68, 0x0c, 0x94, 0xa4, 0xf1,
-	0x04, 0x4c, 0x0f, 0x42, 0x88, 0xed, 0x04, 0x5c, 0x71, 0xaa, 0xff, 0x1d, 0x5a, 0x1b, 0xb1, 0x34,
-	0x1f, 0xf9, 0x34, 0xc1, 0x07, 0xa8, 0x2d, 0x72, 0xd6, 0x94, 0xdd, 0xe6, 0x40, 0xdd, 0xdb, 0x31,
-	0xe4, 0x7a, 0x0c, 0xee, 0x78, 0x0c, 0xcf, 0x53, 0x08, 0x27, 0x30, 0xbc, 0x95, 0x67, 0xfa, 0x4d,
-	0x61, 0xb9, 0x4f, 0x02, 0x3f, 0xe1, 0xa9, 0x5b, 0x05, 0x40, 0xff, 0xcf, 0x36, 0x5a, 0x7f, 0x04,
-	0x36, 0x05, 0x8b, 0xf9, 0xd3, 0x04, 0x7f, 0x82, 0x2e, 0xbb, 0x35, 0xf6, 0x5d, 0x4d, 0xd9, 0x55,
-	0x06, 0x6b, 0x43, 0x2d, 0xcf, 0xf4, 0x5b, 0xa5, 0xfa, 0xc0, 0x95, 0x70, 0x50, 0xa5, 0xc5, 0xef,
-	0xa3, 0xd5, 0x88, 0x90, 0xb9, 0xb6, 0xc2, 0xcf, 0xe0, 0x3c, 0xd3, 0x37, 0x99, 0x2c, 0x79, 0x73,
-	0x3b, 0x7e, 0x8a, 0xd6, 0xca, 0x3a, 0xa9, 0xd6, 0xe4, 0x15, 0x0c, 0x0c, 0x99, 0x35, 0x39, 0x21,
-	0xc3, 0x2a, 0x5d, 0x47, 0x61, 0x12, 0x2f, 0x86, 0x5b, 0xaf, 0x32, 0xbd, 0x91, 0x67, 0x7a, 0x05,
-	0x61, 0x55, 0x7f, 0x31, 0x41, 0x37, 0x03, 0x3f, 0xf4, 0x83, 0x34, 0x18, 0x4f, 0x89, 0x33, 0xa6,
-	0xfe, 0x4b, 0xd0, 0x56, 0x79, 0x84, 0x07, 0x6f, 0x8f, 0x70, 0x24, 0x4e, 0x1c, 0x12, 0xe7, 0xd8,
-	0x7f, 0x09, 0x22, 0x4c, 0xa7, 0x08, 0xb3, 0x19, 0xd4, 0x8c, 0xd6, 0x15, 0x19, 0xef, 0xa3, 0x56,
-	0x48, 0x5c, 0xa0, 0x5a, 0x8b, 0x47, 0xd9, 0x30, 0x18, 0xfa, 0x63, 0xe2, 0xc2, 0x41, 0xf8, 0x8c,
-	0x0c, 0xb7, 0xf3, 0x4c, 0xff, 0x3f, 0xb7, 0x4b, 0x4d, 0x10, 0x07, 0xb0, 0x8b, 0x3a, 0x69, 0x68,
-	0x53, 0xea, 0x7b, 0x21, 0xb8, 0x3c, 0xdb, 0x38, 0x0d, 0xc7, 0xbe, 0x4b, 0xb5, 0x36, 0x87, 0xc2,
-	0x75, 0x52, 0xbf, 0x4d, 0x7d, 0x77, 0xb8, 0x53, 0x64, 0xb5, 0x5d, 0x9d, 0x3c, 0x24, 0x8e, 0x95,
-	0x86, 0x07, 0x2e, 0xb5, 0x96, 0x29, 0xf1, 0xd7, 0x68, 0x2b, 0xb0, 0xcf, 0x18, 0x3c, 0x1d, 0x27,
-	0x64, 0x3c, 0x67, 0x75, 0x6b, 0x37, 0x76, 0x95, 0xc1, 0xc6, 0xf0, 0x5e, 0x9e, 0xe9, 0x5a, 0x60,
-	0x9f, 0x1d, 0x12, 0x87, 0x9e, 0x10, 0xde, 0x11, 0x29, 0xcb, 0xcd, 0xba, 0xa5, 0xfb, 0x8b, 0x82,
-	0x36, 0xeb, 0x54, 0xe0, 0xf7, 0x50, 0x73, 0x06, 0x8b, 0x62, 0x44, 0xb6, 0xf2, 0x4c, 0xdf, 0x98,
-	0xc1, 0x42, 0x82, 0x60, 0x56, 0xfc, 0x14, 0xb5, 0x5e, 0xd8, 0xf3, 0x14, 0xf8, 0x54, 0xa8, 0x7b,
-	0x86, 0x21, 0xc6, 0xdf, 0x90, 0xc7, 0xdf, 0x88, 0x66, 0x1e, 0x6f, 0x5c, 0x49, 0xa4, 0xf1, 0x24,
-	0xb5, 0xc3, 0xc4, 0x4f, 0x16, 0xa2, 0x83, 0x1c, 0x40, 0xee, 0x20, 0x57, 0x7c, 0xba, 0xb2, 0xaf,
-	0x74, 0x7f, 0x55, 0xd0, 0xf6, 0x12, 0xfe, 0xfe, 0x0b, 0xb9, 0xf5, 0x7f, 0x5f, 0x41, 0xaa, 0x60,
-	0x82, 0xb7, 0x10, 0x3f, 0x44, 0xa8, 0xa2, 0x99, 0xa7, 0xb6, 0x9c, 0xe5, 0x4e, 0x9e, 0xe9, 0x78,
-	0x5a, 0x50, 0x28, 0x41, 0xff, 0xaf, 0xd4, 0xe1, 0x0f, 0x50, 0x8b, 0x5f, 0x4f, 0xc5, 0xaa, 0xf1,
-	0x44, 0xb8, 0x42, 0x4e, 0x84, 0x2b, 0xf0, 0x7d, 0xd4, 0x66, 0xe4, 0x43, 0xa2, 0x35, 0xb9, 0x2f,
-	0xbf, 0x0e, 0x84, 0x46, 0xbe, 0x0e, 0x84, 0x86, 0xad, 0x70, 0x4a, 0x21, 0xd6, 0x56, 0xab, 0x15,
-	0x66, 0xb2, 0xbc, 0xc2, 0x4c, 0x66, 0xa8, 0x5e, 0x4c, 0xd2, 0x48, 0xcc, 0x7d, 0x81, 0x2a, 0x34,
-	0x32, 0xaa, 0xd0, 0xe0, 0xcf, 0x50, 0x73, 0x4a, 0x1c, 0xad, 0xcd, 0x2b, 0xbe, 0x53, 0xaf, 0xf8,
-	0x38, 0x75, 0x02, 0x3f, 0x39, 0x24, 0x8e, 0x60, 0x69, 0x4a, 0x1c, 0x99, 0xa5, 0x29, 0x71, 0xfa,
-	0x14, 0xa1, 0xaf, 0xec, 0x70, 0x02, 0x73, 0x2b, 0x0d, 0x29, 0x06, 0x74, 0x5b, 0xda, 0x15, 0x36,
-	0xd3, 0x13, 0x6e, 0x2c, 0xae, 0xc2, 0x65, 0xfd, 0xd4, 0xf3, 0x4c, 0xdf, 0x29, 0x7b, 0x47, 0x4f,
-	0x88, 0x40, 0x93, 0xc2, 0x6c, 0x5d, 0x33, 0xf6, 0x7f, 0x44, 0xea, 0x37, 0x31, 0x30, 0x33, 0x8f,
-	0x7a, 0x8a, 0x3a, 0x57, 0xa2, 0x46, 0xc2, 0xfa, 0x2f, 0x61, 0x77, 0xf3, 0x4c, 0xbf, 0x27, 0x21,
-	0x17, 0x78, 0x52, 0x5c, 0x7c, 0xdd, 0xda, 0x57, 0xd1, 0xda, 0x28, 0x74, 0x8f, 0xec, 0x78, 0x06,
-	0x71, 0xff, 0x8f, 0x15, 0x84, 0xf9, 0xec, 0x1c, 0x27, 0x31, 0xd8, 0xc1, 0x11, 0x50, 0x6a, 0x7b,
-	0x80, 0x47, 0xa8, 0x25, 0x16, 0x59, 0xcc, 0x90, 0x56, 0xbb, 0xda, 0xa4, 0x89, 0x13, 0x83, 0x31,
-	0xaf, 0x6f, 0xf6, 0xc3, 0x86, 0x25, 0x4e, 0xe3, 0x13, 0xa4, 0x8a, 0xde, 0xb1, 0xba, 0x68, 0xb1,
-	0x04, 0x77, 0x6a, 0x60, 0x55, 0xe3, 0xc5, 0x1b, 0x30, 0xb9, 0x94, 0x6b, 0x80, 0xa8, 0xd2, 0xe3,
-	0xcf, 0x51, 0x13, 0x42, 0x97, 0x4f, 0x9b, 0xba, 0xd7, 0xa9, 0xa1, 0x5d, 0x16, 0x26, 0xb8, 0x86,
-	0xd0, 0xad, 0xa1, 0xb0, 0x73, 0xf8, 0x7b, 0xb4, 0x5e, 0xb4, 0x56, 0x64, 0xb5, 0xba, 0xa4, 0x44,
-	0x89, 0x99, 0xe1, 0xdd, 0x3c, 0xd3, 0x6f, 0x47, 0x95, 0xa2, 0x86, 0xa8, 0x4a, 0x86, 0xe1, 0x0d,
-	0xd4, 0xe2, 0xf4, 0xec, 0xfd, 0xa6, 0x20, 0x75, 0x54, 0xc0, 0x7d, 0x19, 0xf9, 0xf8, 0x71, 0xf1,
-	0x04, 0x8a, 0xce, 0x51, 0x7c, 0xf7, 0xad, 0x4f, 0x45, 0x57, 0xbf, 0x6e, 0xaa, 0x51, 0x33, 0x50,
-	0x3e, 0x54, 0xf0, 0x17, 0x68, 0xdd, 0x82, 0x88, 0xc4, 0x09, 0x7f, 0x88, 0x29, 0xbe, 0xd2, 0x84,
-	0xf2, 0x19, 0xef, 0x76, 0x0c, 0xf1, 0x59, 0x61, 0x94, 0x1f, 0x0c, 0xc6, 0x88, 0xe5, 0x3d, 0x7c,
-	0xf2, 0xe6, 0xef, 0x5e, 0xe3, 0xa7, 0xf3, 0x9e, 0xf2, 0xea, 0xbc, 0xa7, 0xbc, 0x3e, 0xef, 0x29,
-	0x7f, 0x9d, 0xf7, 0x94, 0x9f, 0x2f, 0x7a, 0x8d, 0xd7, 0x17, 0xbd, 0xc6, 0x9b, 0x8b, 0x5e, 0xe3,
-	0x07, 0x53, 0xfa, 0xe4, 0x10, 0x83, 0x17, 0xc5, 0x64, 0x0a, 0x93, 0xa4, 0x90, 0xcc, 0x2b, 0xdf,
-	0x44, 0x4e, 0x9b, 0x87, 0xf8, 0xe8, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4f, 0x29, 0xd9, 0x6b,
-	0x2d, 0x09, 0x00, 0x00,
+	// 1537 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0x4d, 0x73, 0xdb, 0xc4,
+	0x1b, 0x8f, 0xea, 0xbc, 0x79, 0x9d, 0xd7, 0x75, 0x9b, 0x2a, 0x4e, 0x6b, 0xb9, 0xfe, 0xcf, 0xfc,
+	0xc7, 0xcc, 0xb4, 0x32, 0x93, 0x32, 0x4c, 0xcb, 0x00, 0x33, 0x35, 0xcd, 0xd0, 0x84, 0x26, 0x50,
+	0x27, 0x65, 0x28, 0x17, 0x8d, 0x2c, 0x6d, 0x1d, 0x39, 0x91, 0x56, 0xd1, 0xae, 0xda, 0xb8, 0x07,
+	0x86, 0x6f, 0x40, 0x0f, 0x1c, 0xe0, 0xc0, 0x77, 0xe0, 0x0b, 0x70, 0xef, 0xb1, 0xc7, 0x9e, 0x04,
+	0xa4, 0xc3, 0x45, 0xdf, 0x80, 0x1b, 0xb3, 0x2f, 0xb2, 0x56, 0x8e, 0x0b, 0x1c, 0x7a, 0xe0, 0x64,
+	0xef, 0xef, 0xb7, 0xcf, 0xeb, 0x3e, 0xcf, 0xb3, 0x2b, 0x70, 0x2d, 0x3c, 0xea, 0xb7, 0xd1, 0x29,
+	0x72, 0x62, 0x8a, 0x23, 0x3b, 0xf4, 0xd4, 0xff, 0x66, 0x18, 0x61, 0x8a, 0x61, 0x45, 0x81, 0x6a,
+	0x57, 0xd9, 0x7e, 0x3b, 0xf2, 0x6d, 0xd7, 0x46, 0x4f, 0x50, 0x40, 0x49, 0x5b, 0xfc, 0x88, 0xbd,
+	0xb5, 0x2a, 0xa7, 0x43, 0xaf, 0x7d, 0x12, 0xa3, 0x18, 0x49, 0x70, 0xa3, 0x8f, 0x71, 0xff, 0x18,
+	0xb5, 0xf9, 0xaa, 0x17, 0x3f, 0x6e, 0x23, 0x3f, 0xa4, 0x43, 0x49, 0xd6, 0xc7, 0x49, 0x37, 0x8e,
+	0x6c, 0xea, 0xe1, 0x40, 0xf2, 0xc6, 0x38, 0x4f, 0x3d, 0x1f, 0x11, 0x6a, 0xfb, 0xa1, 0xdc, 0x70,
+	0xa3, 0xef, 0xd1, 0xc3, 0xb8, 0x67, 0x3a, 0xd8, 0x6f, 0xf7, 0x71, 0x1f, 0xe7, 0x3b, 0xd9, 0x8a,
+	0x2f, 0xf8, 0x3f, 0xb9, 0xfd, 0xbd, 0xa3, 0x5b, 0xc4, 0xf4, 0x30, 0x73, 0xd2, 0xb7, 0x9d, 0x43,
+	0x2f, 0x40, 0xd1, 0xb0, 0x9d, 0x79, 0x1d, 0x21, 0x82, 0xe3, 0xc8, 0x41, 0xed, 0x3e, 0x0a, 0x50,
+	0x64, 0x53, 0xe4, 0x0a, 0xa9, 0xe6, 0x97, 0xa0, 0xbc, 0xc5, 0xe2, 0xbc, 0xef, 0x11, 0x0a, 0xb7,
+	0xc1, 0xac, 0x08, 0x5a, 0xd7, 0x1a, 0xa5, 0x56, 0x65, 0x73, 0xc3, 0x54, 0x13, 0x62, 0xf2, 0x8d,
+	0xfb, 0xe8, 0x24, 0x46, 0x81, 0x83, 0x3a, 0x17, 0xd3, 0xc4, 0x58, 0x11, 0xcc, 0x75, 0xec, 0x7b,
+	0x94, 0xc7, 0xde, 0x95, 0x0a, 0x9a, 0x69, 0x05, 0x2c, 0xdc, 0x47, 0x36, 0x41, 0x5d, 0xb6, 0x9f,
+	0x50, 0x78, 0x1b, 0x8c, 0xd2, 0x6d, 0x79, 0xae, 0xae, 0x35, 0xb4, 0x56, 0xb9, 0xa3, 0xa7, 0x89,
+	0x71, 0x31, 0x83, 0xb7, 0x5d, 0x45, 0x0f, 0xc8, 0x51, 0xf8, 0x7f, 0x30, 0x1d, 0x62, 0x7c, 0xac,
+	0x5f, 0xe0, 0x32, 0x30, 0x4d, 0x8c, 0x25, 0xb6, 0x56, 0x76, 0x73, 0x1e, 0x3e, 0x02, 0xe5, 0x2c,
+	0x4e, 0xa2, 0x97, 0x78, 0x04, 0x2d, 0x53, 0x3d, 0x76, 0xd5, 0x21, 0xb3, 0x9b, 0x6d, 0xdd, 0x0a,
+	0x68, 0x34, 0xec, 0xac, 0xbe, 0x48, 0x8c, 0xa9, 0x34, 0x31, 0x72, 0x15, 0xdd, 0xfc, 0x2f, 0xc4,
+	0x60, 0xc5, 0xf7, 0x02, 0xcf, 0x8f, 0x7d, 0x6b, 0x80, 0x7b, 0x16, 0xf1, 0x9e, 0x21, 0x7d, 0x9a,
+	0x5b, 0xb8, 0xf1, 0x66, 0x0b, 0xbb, 0x42, 0x62, 0x07, 0xf7, 0xf6, 0xbd, 0x67, 0x48, 0x98, 0x59,
+	0x93, 0x66, 0x96, 0xfc, 0x02, 0xd9, 0x1d, 0x5b, 0xc3, 0x5b, 0x60, 0x26, 0xc0, 0x2e, 0x22, 0xfa,
+	0x0c, 0xb7, 0xb2, 0x68, 0x32, 0xed, 0x7b, 0xd8, 0x45, 0xdb, 0xc1, 0x63, 0xdc, 0xa9, 0xa6, 0x89,
+	0xb1, 0xcc, 0x79, 0x25, 0x09, 0x42, 0x00, 0xba, 0x60, 0x2d, 0x0e, 0x6c, 0x42, 0xbc, 0x7e, 0x80,
+	0x5c, 0xee, 0x6d, 0x14, 0x07, 0x96, 0xe7, 0x12, 0x7d, 0x96, 0xab, 0x82, 0xc5, 0x43, 0x7d, 0x18,
+	0x7b, 0x6e, 0x67, 0x43, 0x7a, 0x55, 0xcd, 0x25, 0x77, 0x70, 0xaf, 0x1b, 0x07, 0xdb, 0x2e, 0xe9,
+	0x4e, 0x02, 0xe1, 0xa7, 0x60, 0xd5, 0xb7, 0x4f, 0x99, 0x7a, 0x62, 0x51, 0x6c, 0x1d, 0xb3, 0xb8,
+	0xf5, 0xb9, 0x86, 0xd6, 0x5a, 0xec, 0x5c, 0x49, 0x13, 0x43, 0xf7, 0xed, 0xd3, 0x1d, 0xdc, 0x23,
+	0x07, 0x98, 0x67, 0x44, 0xf1, 0x72, 0xa9, 0xc8, 0x40, 0x1b, 0xac, 0x8c, 0xea, 0x82, 0x75, 0x00,
+	0x8e, 0xa9, 0x3e, 0xdf, 0xd0, 0x5a, 0x95, 0xcd, 0x75, 0x53, 0x74, 0x88, 0x99, 0xd5, 0xbd, 0x79,
+	0x57, 0x76, 0xd0, 0xc8, 0xdf, 0xe5, 0x4c, 0xf4, 0x40, 0x48, 0xfe, 0xf0, 0xab, 0xa1, 0x75, 0xc7,
+	0x41, 0x38, 0x00, 0xd5, 0x2c, 0x0d, 0x24, 0x44, 0x8e, 0x75, 0x68, 0x93, 0x43, 0x44, 0xf4, 0xb2,
+	0xac, 0x71, 0xf5, 0xfc, 0x44, 0x80, 0xfb, 0x21, 0x72, 0xee, 0xd9, 0xe4, 0xb0, 0x53, 0x4f, 0x13,
+	0xa3, 0x36, 0x28, 0x60, 0x85, 0x94, 0xaf, 0x8c, 0x73, 0xf0, 0x14, 0xac, 0x65, 0xb6, 0xb2, 0xea,
+	0xb1, 0x62, 0x62, 0xf7, 0x91, 0x0e, 0xb8, 0xb9, 0xc6, 0x04, 0x73, 0x59, 0x25, 0x3e, 0x64, 0xfb,
+	0x3a, 0xd7, 0xd2, 0xc4, 0xb8, 0x3a, 0x38, 0x4f, 0x28, 0x66, 0xab, 0x13, 0x68, 0xb8, 0x0b, 0xe6,
+	0x08, 0x0a, 0xa8, 0x65, 0x53, 0xbd, 0xc2, 0xf3, 0x57, 0x3b, 0x97, 0xbf, 0x83, 0x6c, 0xc2, 0xf0,
+	0xc6, 0x5b, 0x61, 0xdb, 0xef, 0xd0, 0x5c, 0xef, 0x73, 0x96, 0xbd, 0x59, 0x81, 0xb2, 0x7e, 0xe5,
+	0xf5, 0x64, 0xb9, 0xe8, 0x98, 0xda, 0xfa, 0x42, 0x43, 0x6b, 0xcd, 0x8b, 0x7e, 0xe5, 0xf0, 0x5d,
+	0x86, 0xaa, 0xfd, 0x9a, 0xa3, 0xf0, 0x3e, 0x80, 0x11, 0xf2, 0xf1, 0x13, 0xe4, 0x5a, 0x0c, 0xb5,
+	0x02, 0xdb, 0x47, 0x44, 0x5f, 0x6c, 0x94, 0x5a, 0x65, 0x91, 0x51, 0xc9, 0xb2, 0x72, 0xde, 0x63,
+	0x9c, 0x9a, 0xd1, 0x71, 0x0e, 0xbe, 0x0f, 0x84, 0x6e, 0x7e, 0x6c, 0xfa, 0x52, 0x43, 0x6b, 0x2d,
+	0x74, 0x2e, 0xb3, 0x5a, 0xe5, 0x28, 0x4b, 0xbb, 0x22, 0x5e, 0x1e, 0x81, 0x4c, 0x2e, 0x12, 0x8d,
+	0xc8, 0xe6, 0xcd, 0x72, 0x43, 0x6b, 0x4d, 0x0b, 0x39, 0x89, 0x16, 0xc6, 0x4d, 0x79, 0x04, 0xd6,
+	0xbe, 0xd7, 0xc0, 0x52, 0x71, 0x36, 0xc0, 0xff, 0x81, 0xd2, 0x11, 0x1a, 0xca, 0x99, 0xb5, 0x9a,
+	0x26, 0xc6, 0xe2, 0x11, 0x1a, 0x2a, 0xd2, 0x8c, 0x85, 0x8f, 0xc0, 0xcc, 0x13, 0xfb, 0x38, 0x46,
+	0x7c, 0x4c, 0x55, 0x36, 0x4d, 0x53, 0xcc, 0x63, 0x53, 0x9d, 0xc7, 0x66, 0x78, 0xd4, 0xe7, 0x9d,
+	0x9c, 0xd5, 0x86, 0xf9, 0x20, 0xb6, 0x03, 0xea, 0xd1, 0xa1, 0x68, 0x69, 0xae, 0x40, 0x6d, 0x69,
+	0x0e, 0x7c, 0x70, 0xe1, 0x96, 0x56, 0xfb, 0x51, 0x03, 0xd5, 0x09, 0x03, 0xe5, 0xbf, 0xe0, 0x5b,
+	0xf3, 0x3b, 0x0d, 0x2c, 0x15, 0x3b, 0x07, 0xde, 0x03, 0x20, 0x1f, 0x3d, 0xdc, 0xbb, 0xc9, 0x93,
+	0x67, 0x2d, 0x4d, 0x0c, 0x38, 0x90, 0x63, 0x45, 0xd1, 0x3e, 0x9f, 0x61, 0xf0, 0x26, 0x28, 0x8f,
+	0xba, 0x96, 0xfb, 0xbf, 0x20, 0x84, 0x88, 0x34, 0xa5, 0x0a, 0x65, 0x58, 0xf3, 0x0f, 0x0d, 0x54,
+	0x27, 0x34, 0xd7, 0x5b, 0x74, 0xeb, 0x36, 0xa8, 0x38, 0x61, 0x6c, 0x11, 0xe4, 0xe0, 0xc0, 0x25,
+	0xdc, 0x31, 0x4d, 0xf4, 0x87, 0x13, 0xc6, 0xfb, 0x02, 0x55, 0xfb, 0x23, 0x47, 0xe1, 0x36, 0x58,
+	0x7d, 0x8a, 0xa3, 0x23, 0x2f, 0xe8, 0x5b, 0x04, 0x51, 0xab, 0x37, 0xa4, 0xfc, 0xbe, 0xd2, 0x5a,
+	0xa5, 0xce, 0xd5, 0x34, 0x31, 0xd6, 0x25, 0xb9, 0x8f, 0x68, 0x87, 0x51, 0x8a, 0x96, 0xe5, 0x31,
+	0xaa, 0xf9, 0xe7, 0x05, 0x50, 0x11, 0x71, 0x8a, 0x69, 0xfa, 0xf6, 0xe2, 0x7b, 0x07, 0xcc, 0xf0,
+	0xa7, 0x8e, 0xbc, 0x75, 0x79, 0x09, 0x70, 0x40, 0x2d, 0x01, 0x0e, 0xc0, 0xeb, 0x60, 0x96, 0xdd,
+	0x03, 0x88, 0xf2, 0x20, 0xca, 0xe2, 0x65, 0x20, 0x10, 0xf5, 0x65, 0x20, 0x10, 0x76, 0x9b, 0xc7,
+	0x04, 0x45, 0xfa, 0x74, 0x7e, 0x9b, 0xb3, 0xb5, 0x7a, 0x9b, 0xb3, 0x35, 0xd3, 0xda, 0x8f, 0x70,
+	0x1c, 0x8a, 0x2b, 0x50, 0x6a, 0x15, 0x88, 0xaa, 0x55, 0x20, 0xf0, 0x43, 0x50, 0x1a, 0xe0, 0x9e,
+	0x3e, 0xcb, 0x23, 0xbe, 0x5c, 0x8c, 0x78, 0x3f, 0xee, 0xf9, 0x1e, 0xdd, 0xc1, 0x3d, 0xd1, 0x1f,
+	0x03, 0xdc, 0x53, 0xfb, 0x63, 0x80, 0x7b, 0xc5, 0x1a, 0x9b, 0xfb, 0x97, 0x35, 0x46, 0x00, 0xf8,
+	0xc4, 0x0e, 0x1c, 0x74, 0xdc, 0x8d, 0x03, 0x02, 0x11, 0xb8, 0xa4, 0xdc, 0xb5, 0xec, 0x4e, 0x74,
+	0x38, 0x29, 0x9f, 0x52, 0x93, 0x0e, 0xc1, 0x48, 0x13, 0x63, 0x23, 0x4b, 0x38, 0x39, 0xc0, 0x42,
+	0x9b, 0x62, 0x6b, 0xf5, 0x1c, 0xd9, 0x7c, 0x0a, 0x2a, 0x5f, 0x44, 0x88, 0xd1, 0xdc, 0xea, 0x21,
+	0x58, 0x1b, 0xb3, 0x1a, 0x0a, 0xf6, 0x6f, 0xcc, 0x36, 0xd2, 0xc4, 0xb8, 0xa2, 0x68, 0x96, 0xfa,
+	0x14, 0xbb, 0xf0, 0x3c, 0xdb, 0xfc, 0x06, 0x2c, 0x3f, 0x88, 0xed, 0x88, 0x4d, 0x84, 0x00, 0xed,
+	0x61, 0x37, 0x9f, 0xcc, 0x72, 0xbe, 0x6b, 0xfc, 0x94, 0x46, 0x93, 0x79, 0x7c, 0xb0, 0x97, 0x47,
+	0x20, 0xcb, 0x36, 0xb5, 0xbd, 0x80, 0x5a, 0x6c, 0x70, 0x89, 0xf2, 0xe2, 0xd9, 0xe6, 0xe0, 0x67,
+	0x85, 0xe9, 0x35, 0x9f, 0x61, 0xcd, 0x9f, 0x35, 0x50, 0xde, 0x0a, 0xdc, 0x5d, 0x3b, 0x3a, 0x42,
+	0x11, 0xec, 0x82, 0x4a, 0x84, 0x68, 0x34, 0xb4, 0xec, 0xc7, 0x14, 0x45, 0xba, 0xf6, 0x4f, 0x0f,
+	0x86, 0xec, 0xd9, 0x05, 0xb8, 0xd4, 0x1d, 0x26, 0xc4, 0xdf, 0x0a, 0xca, 0x1a, 0x7e, 0x0e, 0xaa,
+	0xb6, 0xe3, 0xa0, 0x90, 0x12, 0x71, 0x6d, 0xf1, 0x8b, 0x4f, 0x74, 0xf6, 0xbc, 0x38, 0x2b, 0x49,
+	0xb3, 0xe8, 0xf9, 0x4d, 0xa7, 0xc6, 0xb7, 0x7a, 0x8e, 0x6c, 0xfe, 0x52, 0x02, 0x90, 0xb7, 0xe5,
+	0x3e, 0x8d, 0x90, 0xed, 0xef, 0x22, 0xc2, 0x67, 0xd0, 0x16, 0x98, 0x11, 0xcf, 0x25, 0xe1, 0xb5,
+	0x3e, 0xe1, 0x45, 0xc0, 0xa5, 0x44, 0xcf, 0x1d, 0x17, 0xdf, 0x4f, 0xf7, 0xa6, 0xba, 0x42, 0x1a,
+	0x1e, 0x80, 0x8a, 0xa8, 0x30, 0x76, 0xfa, 0x44, 0x4e, 0xf6, 0xcb, 0x05, 0x65, 0x79, 0x79, 0xca,
+	0xc9, 0x34, 0x5a, 0x17, 0x14, 0x82, 0x1c, 0x87, 0x1f, 0x81, 0x12, 0x0a, 0x5c, 0xde, 0xc8, 0x95,
+	0xcd, 0xb5, 0x82, 0xb6, 0x51, 0xf6, 0x45, 0x1b, 0xa1, 0xc0, 0x2d, 0x68, 0x61, 0x72, 0xf0, 0x2b,
+	0xb0, 0x20, 0x0b, 0x50, 0x78, 0x35, 0x3d, 0x21, 0x44, 0xa5, 0x7e, 0x3b, 0xeb, 0x69, 0x62, 0x5c,
+	0x0a, 0x73, 0xa0, 0xa0, 0xb1, 0x12, 0x16, 0x2a, 0x7d, 0xe5, 0x64, 0x54, 0x7f, 0x56, 0xf6, 0x36,
+	0x66, 0xda, 0xaf, 0x14, 0xb4, 0x8f, 0x15, 0xa9, 0x98, 0xa8, 0x27, 0x45, 0xb0, 0x60, 0x65, 0x79,
+	0x8c, 0xec, 0xcc, 0x81, 0x19, 0xde, 0x2e, 0x9b, 0x3f, 0x69, 0xa0, 0xb2, 0x25, 0x55, 0xdf, 0x09,
+	0x3d, 0xb8, 0x27, 0x3f, 0x69, 0xc4, 0x19, 0x11, 0xb8, 0xfe, 0xc6, 0xa7, 0x7f, 0xcd, 0x38, 0x4f,
+	0x15, 0x8a, 0xa0, 0xa5, 0xbd, 0xab, 0xc1, 0x8f, 0xc1, 0x42, 0x17, 0x85, 0x38, 0xa2, 0xfc, 0xc3,
+	0x8a, 0xc0, 0xb1, 0x74, 0x67, 0x9f, 0x65, 0xb5, 0xb5, 0x73, 0x75, 0xbd, 0xc5, 0x7c, 0xef, 0x3c,
+	0x78, 0xf5, 0x7b, 0x7d, 0xea, 0xdb, 0xb3, 0xba, 0xf6, 0xe2, 0xac, 0xae, 0xbd, 0x3c, 0xab, 0x6b,
+	0xbf, 0x9d, 0xd5, 0xb5, 0xe7, 0xaf, 0xeb, 0x53, 0x2f, 0x5f, 0xd7, 0xa7, 0x5e, 0xbd, 0xae, 0x4f,
+	0x7d, 0xdd, 0x56, 0x3e, 0x21, 0xc5, 0x20, 0x08, 0x23, 0x3c, 0x40, 0x0e, 0x95, 0xab, 0xf6, 0xd8,
+	0x47, 0x72, 0x6f, 0x96, 0x9b, 0xb8, 0xf9, 0xd7, 0x00, 0xa3, 0x2e, 0x67, 0x27, 0x3e, 0x0f, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -801,6 +1131,83 @@ func (m *LeaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RequestId != 0 {
+		i = encodeVarintExecutorapi(dAtA, i, uint64(m.RequestId))
+		i--
+		dAtA[i] = 0x78
+	}
+	if len(m.NodesHash) > 0 {
+		i -= len(m.NodesHash)
+		copy(dAtA[i:], m.NodesHash)
+		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.NodesHash)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if len(m.RemovedNodeNames) > 0 {
+		for iNdEx := len(m.RemovedNodeNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RemovedNodeNames[iNdEx])
+			copy(dAtA[i:], m.RemovedNodeNames[iNdEx])
+			i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.RemovedNodeNames[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if m.NodesDelta {
+		i--
+		if m.NodesDelta {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.SentAt != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.SentAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.SentAt):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintExecutorapi(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.JobRunResourceUsage) > 0 {
+		for iNdEx := len(m.JobRunResourceUsage) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.JobRunResourceUsage[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExecutorapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.JobRunSpecHashes) > 0 {
+		for iNdEx := len(m.JobRunSpecHashes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.JobRunSpecHashes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExecutorapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ExecutorTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.ExecutorTimeout):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintExecutorapi(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x42
 	if m.MaxJobsToLease != 0 {
 		i = encodeVarintExecutorapi(dAtA, i, uint64(m.MaxJobsToLease))
 		i--
@@ -899,7 +1306,7 @@ func (m *LeaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *JobRunLease) Marshal() (dAtA []byte, err error) {
+func (m *JobRunSpecHash) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -909,55 +1316,20 @@ func (m *JobRunLease) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *JobRunLease) MarshalTo(dAtA []byte) (int, error) {
+func (m *JobRunSpecHash) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *JobRunLease) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *JobRunSpecHash) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Job != nil {
-		{
-			size, err := m.Job.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintExecutorapi(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
-	}
-	if len(m.Groups) > 0 {
-		for iNdEx := len(m.Groups) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Groups[iNdEx])
-			copy(dAtA[i:], m.Groups[iNdEx])
-			i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.Groups[iNdEx])))
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if len(m.User) > 0 {
-		i -= len(m.User)
-		copy(dAtA[i:], m.User)
-		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.User)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Jobset) > 0 {
-		i -= len(m.Jobset)
-		copy(dAtA[i:], m.Jobset)
-		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.Jobset)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Queue) > 0 {
-		i -= len(m.Queue)
-		copy(dAtA[i:], m.Queue)
-		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.Queue)))
+	if len(m.SpecHash) > 0 {
+		i -= len(m.SpecHash)
+		copy(dAtA[i:], m.SpecHash)
+		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.SpecHash)))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -976,7 +1348,7 @@ func (m *JobRunLease) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *CancelRuns) Marshal() (dAtA []byte, err error) {
+func (m *JobRunResourceUsage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -986,24 +1358,154 @@ func (m *CancelRuns) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CancelRuns) MarshalTo(dAtA []byte) (int, error) {
+func (m *JobRunResourceUsage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CancelRuns) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *JobRunResourceUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.JobRunIdsToCancel) > 0 {
-		for iNdEx := len(m.JobRunIdsToCancel) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.JobRunIdsToCancel[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
+	if m.WorkingSetBytes != 0 {
+		i = encodeVarintExecutorapi(dAtA, i, uint64(m.WorkingSetBytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.CpuSeconds != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CpuSeconds))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.JobRunId != nil {
+		{
+			size, err := m.JobRunId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintExecutorapi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobRunLease) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobRunLease) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobRunLease) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SpecHash) > 0 {
+		i -= len(m.SpecHash)
+		copy(dAtA[i:], m.SpecHash)
+		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.SpecHash)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Job != nil {
+		{
+			size, err := m.Job.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintExecutorapi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Groups) > 0 {
+		for iNdEx := len(m.Groups) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Groups[iNdEx])
+			copy(dAtA[i:], m.Groups[iNdEx])
+			i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.Groups[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.User) > 0 {
+		i -= len(m.User)
+		copy(dAtA[i:], m.User)
+		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.User)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Jobset) > 0 {
+		i -= len(m.Jobset)
+		copy(dAtA[i:], m.Jobset)
+		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.Jobset)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Queue) > 0 {
+		i -= len(m.Queue)
+		copy(dAtA[i:], m.Queue)
+		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.Queue)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.JobRunId != nil {
+		{
+			size, err := m.JobRunId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintExecutorapi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CancelRuns) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelRuns) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CancelRuns) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.JobRunIdsToCancel) > 0 {
+		for iNdEx := len(m.JobRunIdsToCancel) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.JobRunIdsToCancel[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
 				i = encodeVarintExecutorapi(dAtA, i, uint64(size))
 			}
 			i--
@@ -1050,6 +1552,45 @@ func (m *PreemptRuns) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *QuarantineNodes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuarantineNodes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuarantineNodes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TaintKey) > 0 {
+		i -= len(m.TaintKey)
+		copy(dAtA[i:], m.TaintKey)
+		i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.TaintKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.NodeNames) > 0 {
+		for iNdEx := len(m.NodeNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.NodeNames[iNdEx])
+			copy(dAtA[i:], m.NodeNames[iNdEx])
+			i = encodeVarintExecutorapi(dAtA, i, uint64(len(m.NodeNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *EndMarker) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1070,6 +1611,24 @@ func (m *EndMarker) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AcceptsNodeDeltas {
+		i--
+		if m.AcceptsNodeDeltas {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	n9, err9 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RetryAfter, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.RetryAfter):])
+	if err9 != nil {
+		return 0, err9
+	}
+	i -= n9
+	i = encodeVarintExecutorapi(dAtA, i, uint64(n9))
+	i--
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
@@ -1189,6 +1748,27 @@ func (m *LeaseStreamMessage_PreemptRuns) MarshalToSizedBuffer(dAtA []byte) (int,
 	}
 	return len(dAtA) - i, nil
 }
+func (m *LeaseStreamMessage_QuarantineNodes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaseStreamMessage_QuarantineNodes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.QuarantineNodes != nil {
+		{
+			size, err := m.QuarantineNodes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintExecutorapi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintExecutorapi(dAtA []byte, offset int, v uint64) int {
 	offset -= sovExecutorapi(v)
 	base := offset
@@ -1262,6 +1842,76 @@ func (m *LeaseRequest) Size() (n int) {
 	if m.MaxJobsToLease != 0 {
 		n += 1 + sovExecutorapi(uint64(m.MaxJobsToLease))
 	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.ExecutorTimeout)
+	n += 1 + l + sovExecutorapi(uint64(l))
+	if len(m.JobRunSpecHashes) > 0 {
+		for _, e := range m.JobRunSpecHashes {
+			l = e.Size()
+			n += 1 + l + sovExecutorapi(uint64(l))
+		}
+	}
+	if len(m.JobRunResourceUsage) > 0 {
+		for _, e := range m.JobRunResourceUsage {
+			l = e.Size()
+			n += 1 + l + sovExecutorapi(uint64(l))
+		}
+	}
+	if m.SentAt != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.SentAt)
+		n += 1 + l + sovExecutorapi(uint64(l))
+	}
+	if m.NodesDelta {
+		n += 2
+	}
+	if len(m.RemovedNodeNames) > 0 {
+		for _, s := range m.RemovedNodeNames {
+			l = len(s)
+			n += 1 + l + sovExecutorapi(uint64(l))
+		}
+	}
+	l = len(m.NodesHash)
+	if l > 0 {
+		n += 1 + l + sovExecutorapi(uint64(l))
+	}
+	if m.RequestId != 0 {
+		n += 1 + sovExecutorapi(uint64(m.RequestId))
+	}
+	return n
+}
+
+func (m *JobRunSpecHash) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobRunId != nil {
+		l = m.JobRunId.Size()
+		n += 1 + l + sovExecutorapi(uint64(l))
+	}
+	l = len(m.SpecHash)
+	if l > 0 {
+		n += 1 + l + sovExecutorapi(uint64(l))
+	}
+	return n
+}
+
+func (m *JobRunResourceUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobRunId != nil {
+		l = m.JobRunId.Size()
+		n += 1 + l + sovExecutorapi(uint64(l))
+	}
+	if m.CpuSeconds != 0 {
+		n += 9
+	}
+	if m.WorkingSetBytes != 0 {
+		n += 1 + sovExecutorapi(uint64(m.WorkingSetBytes))
+	}
 	return n
 }
 
@@ -1297,6 +1947,10 @@ func (m *JobRunLease) Size() (n int) {
 		l = m.Job.Size()
 		n += 1 + l + sovExecutorapi(uint64(l))
 	}
+	l = len(m.SpecHash)
+	if l > 0 {
+		n += 1 + l + sovExecutorapi(uint64(l))
+	}
 	return n
 }
 
@@ -1330,12 +1984,36 @@ func (m *PreemptRuns) Size() (n int) {
 	return n
 }
 
+func (m *QuarantineNodes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.NodeNames) > 0 {
+		for _, s := range m.NodeNames {
+			l = len(s)
+			n += 1 + l + sovExecutorapi(uint64(l))
+		}
+	}
+	l = len(m.TaintKey)
+	if l > 0 {
+		n += 1 + l + sovExecutorapi(uint64(l))
+	}
+	return n
+}
+
 func (m *EndMarker) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.RetryAfter)
+	n += 1 + l + sovExecutorapi(uint64(l))
+	if m.AcceptsNodeDeltas {
+		n += 2
+	}
 	return n
 }
 
@@ -1399,6 +2077,18 @@ func (m *LeaseStreamMessage_PreemptRuns) Size() (n int) {
 	}
 	return n
 }
+func (m *LeaseStreamMessage_QuarantineNodes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.QuarantineNodes != nil {
+		l = m.QuarantineNodes.Size()
+		n += 1 + l + sovExecutorapi(uint64(l))
+	}
+	return n
+}
 
 func sovExecutorapi(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -1435,6 +2125,16 @@ func (this *LeaseRequest) String() string {
 		repeatedStringForUnassignedJobRunIds += fmt.Sprintf("%v", f) + ","
 	}
 	repeatedStringForUnassignedJobRunIds += "}"
+	repeatedStringForJobRunSpecHashes := "[]*JobRunSpecHash{"
+	for _, f := range this.JobRunSpecHashes {
+		repeatedStringForJobRunSpecHashes += strings.Replace(f.String(), "JobRunSpecHash", "JobRunSpecHash", 1) + ","
+	}
+	repeatedStringForJobRunSpecHashes += "}"
+	repeatedStringForJobRunResourceUsage := "[]*JobRunResourceUsage{"
+	for _, f := range this.JobRunResourceUsage {
+		repeatedStringForJobRunResourceUsage += strings.Replace(f.String(), "JobRunResourceUsage", "JobRunResourceUsage", 1) + ","
+	}
+	repeatedStringForJobRunResourceUsage += "}"
 	keysForResources := make([]string, 0, len(this.Resources))
 	for k, _ := range this.Resources {
 		keysForResources = append(keysForResources, k)
@@ -1463,21 +2163,53 @@ func (this *LeaseRequest) String() string {
 		`Nodes:` + repeatedStringForNodes + `,`,
 		`UnassignedJobRunIds:` + repeatedStringForUnassignedJobRunIds + `,`,
 		`MaxJobsToLease:` + fmt.Sprintf("%v", this.MaxJobsToLease) + `,`,
+		`ExecutorTimeout:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ExecutorTimeout), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`JobRunSpecHashes:` + repeatedStringForJobRunSpecHashes + `,`,
+		`JobRunResourceUsage:` + repeatedStringForJobRunResourceUsage + `,`,
+		`SentAt:` + strings.Replace(fmt.Sprintf("%v", this.SentAt), "Timestamp", "types.Timestamp", 1) + `,`,
+		`NodesDelta:` + fmt.Sprintf("%v", this.NodesDelta) + `,`,
+		`RemovedNodeNames:` + fmt.Sprintf("%v", this.RemovedNodeNames) + `,`,
+		`NodesHash:` + fmt.Sprintf("%v", this.NodesHash) + `,`,
+		`RequestId:` + fmt.Sprintf("%v", this.RequestId) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *JobRunLease) String() string {
+func (this *JobRunSpecHash) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&JobRunLease{`,
+	s := strings.Join([]string{`&JobRunSpecHash{`,
 		`JobRunId:` + strings.Replace(fmt.Sprintf("%v", this.JobRunId), "Uuid", "armadaevents.Uuid", 1) + `,`,
-		`Queue:` + fmt.Sprintf("%v", this.Queue) + `,`,
-		`Jobset:` + fmt.Sprintf("%v", this.Jobset) + `,`,
+		`SpecHash:` + fmt.Sprintf("%v", this.SpecHash) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *JobRunResourceUsage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&JobRunResourceUsage{`,
+		`JobRunId:` + strings.Replace(fmt.Sprintf("%v", this.JobRunId), "Uuid", "armadaevents.Uuid", 1) + `,`,
+		`CpuSeconds:` + fmt.Sprintf("%v", this.CpuSeconds) + `,`,
+		`WorkingSetBytes:` + fmt.Sprintf("%v", this.WorkingSetBytes) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *JobRunLease) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&JobRunLease{`,
+		`JobRunId:` + strings.Replace(fmt.Sprintf("%v", this.JobRunId), "Uuid", "armadaevents.Uuid", 1) + `,`,
+		`Queue:` + fmt.Sprintf("%v", this.Queue) + `,`,
+		`Jobset:` + fmt.Sprintf("%v", this.Jobset) + `,`,
 		`User:` + fmt.Sprintf("%v", this.User) + `,`,
 		`Groups:` + fmt.Sprintf("%v", this.Groups) + `,`,
 		`Job:` + strings.Replace(fmt.Sprintf("%v", this.Job), "SubmitJob", "armadaevents.SubmitJob", 1) + `,`,
+		`SpecHash:` + fmt.Sprintf("%v", this.SpecHash) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1512,11 +2244,24 @@ func (this *PreemptRuns) String() string {
 	}, "")
 	return s
 }
+func (this *QuarantineNodes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&QuarantineNodes{`,
+		`NodeNames:` + fmt.Sprintf("%v", this.NodeNames) + `,`,
+		`TaintKey:` + fmt.Sprintf("%v", this.TaintKey) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *EndMarker) String() string {
 	if this == nil {
 		return "nil"
 	}
 	s := strings.Join([]string{`&EndMarker{`,
+		`RetryAfter:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.RetryAfter), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`AcceptsNodeDeltas:` + fmt.Sprintf("%v", this.AcceptsNodeDeltas) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1571,6 +2316,16 @@ func (this *LeaseStreamMessage_PreemptRuns) String() string {
 	}, "")
 	return s
 }
+func (this *LeaseStreamMessage_QuarantineNodes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LeaseStreamMessage_QuarantineNodes{`,
+		`QuarantineNodes:` + strings.Replace(fmt.Sprintf("%v", this.QuarantineNodes), "QuarantineNodes", "QuarantineNodes", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringExecutorapi(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -2040,17 +2795,482 @@ func (m *LeaseRequest) Unmarshal(dAtA []byte) error {
 			if postIndex < 0 {
 				return ErrInvalidLengthExecutorapi
 			}
-			if postIndex > l {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, &api.NodeInfo{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnassignedJobRunIds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UnassignedJobRunIds = append(m.UnassignedJobRunIds, armadaevents.Uuid{})
+			if err := m.UnassignedJobRunIds[len(m.UnassignedJobRunIds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxJobsToLease", wireType)
+			}
+			m.MaxJobsToLease = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxJobsToLease |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.ExecutorTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobRunSpecHashes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobRunSpecHashes = append(m.JobRunSpecHashes, &JobRunSpecHash{})
+			if err := m.JobRunSpecHashes[len(m.JobRunSpecHashes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobRunResourceUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobRunResourceUsage = append(m.JobRunResourceUsage, &JobRunResourceUsage{})
+			if err := m.JobRunResourceUsage[len(m.JobRunResourceUsage)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SentAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SentAt == nil {
+				m.SentAt = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.SentAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodesDelta", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NodesDelta = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedNodeNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemovedNodeNames = append(m.RemovedNodeNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodesHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodesHash = append(m.NodesHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.NodesHash == nil {
+				m.NodesHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			m.RequestId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExecutorapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobRunSpecHash) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExecutorapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobRunSpecHash: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobRunSpecHash: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobRunId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.JobRunId == nil {
+				m.JobRunId = &armadaevents.Uuid{}
+			}
+			if err := m.JobRunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpecHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpecHash = append(m.SpecHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.SpecHash == nil {
+				m.SpecHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExecutorapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobRunResourceUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExecutorapi
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Nodes = append(m.Nodes, &api.NodeInfo{})
-			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobRunResourceUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobRunResourceUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnassignedJobRunIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobRunId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2077,16 +3297,29 @@ func (m *LeaseRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UnassignedJobRunIds = append(m.UnassignedJobRunIds, armadaevents.Uuid{})
-			if err := m.UnassignedJobRunIds[len(m.UnassignedJobRunIds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.JobRunId == nil {
+				m.JobRunId = &armadaevents.Uuid{}
+			}
+			if err := m.JobRunId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuSeconds", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.CpuSeconds = float64(math.Float64frombits(v))
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxJobsToLease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkingSetBytes", wireType)
 			}
-			m.MaxJobsToLease = 0
+			m.WorkingSetBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowExecutorapi
@@ -2096,7 +3329,7 @@ func (m *LeaseRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxJobsToLease |= uint32(b&0x7F) << shift
+				m.WorkingSetBytes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2351,6 +3584,40 @@ func (m *JobRunLease) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpecHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpecHash = append(m.SpecHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.SpecHash == nil {
+				m.SpecHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExecutorapi(dAtA[iNdEx:])
@@ -2540,6 +3807,120 @@ func (m *PreemptRuns) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QuarantineNodes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExecutorapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuarantineNodes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuarantineNodes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeNames = append(m.NodeNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaintKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaintKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExecutorapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *EndMarker) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2569,6 +3950,59 @@ func (m *EndMarker) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: EndMarker: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryAfter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.RetryAfter, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AcceptsNodeDeltas", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AcceptsNodeDeltas = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExecutorapi(dAtA[iNdEx:])
@@ -2759,6 +4193,41 @@ func (m *LeaseStreamMessage) Unmarshal(dAtA []byte) error {
 			}
 			m.Event = &LeaseStreamMessage_PreemptRuns{v}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuarantineNodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutorapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &QuarantineNodes{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &LeaseStreamMessage_QuarantineNodes{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExecutorapi(dAtA[iNdEx:])
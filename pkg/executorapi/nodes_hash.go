@@ -0,0 +1,106 @@
+package executorapi
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/armadaproject/armada/pkg/api"
+)
+
+// NodeInfoHash returns a hash of node, such that nodes reported differently have different hashes.
+// Since the protobuf encoding of maps isn't deterministic, fields are hashed individually, with map entries sorted by key.
+func NodeInfoHash(node *api.NodeInfo) []byte {
+	h := nodeHasher{Hash: fnv.New128a()}
+	h.string(node.Name)
+	h.int(len(node.Taints))
+	for _, taint := range node.Taints {
+		h.string(taint.Key)
+		h.string(taint.Value)
+		h.string(string(taint.Effect))
+		if taint.TimeAdded != nil {
+			h.int(int(taint.TimeAdded.Unix()))
+		}
+	}
+	h.int(len(node.Labels))
+	for _, key := range sortedKeys(node.Labels) {
+		h.string(key)
+		h.string(node.Labels[key])
+	}
+	h.quantities(node.AllocatableResources)
+	h.quantities(node.AvailableResources)
+	h.quantities(node.TotalResources)
+	h.computeResourcesByPriority(node.AllocatedResources)
+	h.int(len(node.RunIdsByState))
+	for _, runId := range sortedKeys(node.RunIdsByState) {
+		h.string(runId)
+		h.int(int(node.RunIdsByState[runId]))
+	}
+	h.computeResourcesByPriority(node.NonArmadaAllocatedResources)
+	if node.Unschedulable {
+		h.int(1)
+	} else {
+		h.int(0)
+	}
+	h.int(len(node.ResourceUsageByQueue))
+	for _, queue := range sortedKeys(node.ResourceUsageByQueue) {
+		h.string(queue)
+		h.quantities(node.ResourceUsageByQueue[queue].GetResources())
+	}
+	h.string(node.NodeType)
+	return h.Sum(nil)
+}
+
+// NodesHash returns a hash of all nodes of an executor, given the NodeInfoHash of each indexed by node name.
+func NodesHash(nodeInfoHashByName map[string][]byte) []byte {
+	h := nodeHasher{Hash: fnv.New128a()}
+	h.int(len(nodeInfoHashByName))
+	for _, name := range sortedKeys(nodeInfoHashByName) {
+		h.string(name)
+		h.Write(nodeInfoHashByName[name])
+	}
+	return h.Sum(nil)
+}
+
+// nodeHasher writes values to a hash such that distinct sequences of values are written as distinct bytes.
+type nodeHasher struct {
+	hash.Hash
+}
+
+func (h nodeHasher) int(v int) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	h.Write(b[:])
+}
+
+func (h nodeHasher) string(s string) {
+	h.int(len(s))
+	h.Write([]byte(s))
+}
+
+func (h nodeHasher) quantities(quantities map[string]resource.Quantity) {
+	h.int(len(quantities))
+	for _, name := range sortedKeys(quantities) {
+		q := quantities[name]
+		h.string(name)
+		h.string(q.String())
+	}
+}
+
+func (h nodeHasher) computeResourcesByPriority(resourcesByPriority map[int32]api.ComputeResource) {
+	h.int(len(resourcesByPriority))
+	for _, priority := range sortedKeys(resourcesByPriority) {
+		h.int(int(priority))
+		h.quantities(resourcesByPriority[priority].Resources)
+	}
+}
+
+func sortedKeys[K int32 | string, V any](m map[K]V) []K {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	return keys
+}